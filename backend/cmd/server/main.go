@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"victus/internal/api"
 	"victus/internal/db"
+	"victus/internal/store"
 )
 
 func main() {
@@ -32,7 +34,8 @@ func main() {
 	}
 	log.Println("database migrations completed")
 
-	srv := api.NewServer(database)
+	slowQuery := time.Duration(getEnvInt("SLOW_QUERY_MS", 100)) * time.Millisecond
+	srv := api.NewServer(store.LogSlowQueries(database, slowQuery))
 
 	server := &http.Server{
 		Addr:         ":" + port,
@@ -51,6 +54,7 @@ func main() {
 	log.Printf("  port: %s", port)
 	log.Printf("  database: PostgreSQL")
 	log.Printf("  cors: %s", corsOrigin)
+	log.Printf("  slow query log: %dms", slowQuery.Milliseconds())
 
 	go func() {
 		log.Printf("listening on http://localhost:%s", port)
@@ -83,6 +87,19 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("ignoring invalid %s %q", key, value)
+		return defaultValue
+	}
+	return parsed
+}
+
 func loadEnvFile(path string) error {
 	file, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// SetDayExemptionRequest is the request body for marking a day as sick or travelling.
+type SetDayExemptionRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+// getAdherenceCalendar handles GET /api/adherence/calendar?months=6
+// Returns per-day adherence classifications for a calendar heatmap.
+func (s *Server) getAdherenceCalendar(w http.ResponseWriter, r *http.Request) {
+	months := 6
+	if m := r.URL.Query().Get("months"); m != "" {
+		v, err := strconv.Atoi(m)
+		if err != nil || v < 1 || v > domain.AdherenceCalendarMaxMonths {
+			writeError(w, http.StatusBadRequest, "invalid_months", "months must be between 1 and 12")
+			return
+		}
+		months = v
+	}
+
+	cal, err := s.adherenceService.GetCalendar(r.Context(), months, s.now())
+	if err != nil {
+		writeInternalError(w, err, "getAdherenceCalendar")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cal)
+}
+
+// getMacroCompliance handles GET /api/adherence/macros?days=28
+// Returns per-day protein, carb and fat compliance with rolling 7-day rates.
+func (s *Server) getMacroCompliance(w http.ResponseWriter, r *http.Request) {
+	days := 28
+	if d := r.URL.Query().Get("days"); d != "" {
+		v, err := strconv.Atoi(d)
+		if err != nil || v < 1 || v > domain.MacroComplianceMaxDays {
+			writeError(w, http.StatusBadRequest, "invalid_days", "days must be between 1 and 90")
+			return
+		}
+		days = v
+	}
+
+	report, err := s.adherenceService.GetMacroCompliance(r.Context(), days, s.now())
+	if err != nil {
+		writeInternalError(w, err, "getMacroCompliance")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// setDayExemption handles PUT /api/adherence/exemptions/{date}
+// Marks a day as sick or travelling so it is not counted as a miss.
+func (s *Server) setDayExemption(w http.ResponseWriter, r *http.Request) {
+	var req SetDayExemptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	exemption := domain.DayExemption{
+		Date:   r.PathValue("date"),
+		Reason: domain.DayExemptionReason(req.Reason),
+		Note:   req.Note,
+	}
+	if err := s.adherenceService.SetExemption(r.Context(), exemption); err != nil {
+		if domain.IsValidationError(err) {
+			writeDomainError(w, err, "setDayExemption")
+			return
+		}
+		writeInternalError(w, err, "setDayExemption")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exemption)
+}
+
+// clearDayExemption handles DELETE /api/adherence/exemptions/{date}
+func (s *Server) clearDayExemption(w http.ResponseWriter, r *http.Request) {
+	if err := s.adherenceService.ClearExemption(r.Context(), r.PathValue("date")); err != nil {
+		if errors.Is(err, store.ErrDayExemptionNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "No exemption for this date")
+			return
+		}
+		writeInternalError(w, err, "clearDayExemption")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
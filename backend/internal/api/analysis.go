@@ -29,6 +29,18 @@ type DualTrackAnalysisResponse struct {
 	PlanProjection      []ProjectionPointResponse      `json:"planProjection"`
 	TrendProjection     []ProjectionPointResponse      `json:"trendProjection,omitempty"`
 	LandingPoint        *LandingPointProjectionResponse `json:"landingPoint,omitempty"`
+	SmoothedWeightKg    float64                        `json:"smoothedWeightKg,omitempty"` // Trend weight with the user's method
+	TrendMethod         string                         `json:"trendMethod,omitempty"`      // ema, hull, or loess
+	// Primary progress track: "weight", or "body_fat" for recomp plans
+	Track                  string   `json:"track"`
+	PlannedBodyFatPercent  *float64 `json:"plannedBodyFatPercent,omitempty"`
+	ActualBodyFatPercent   *float64 `json:"actualBodyFatPercent,omitempty"`
+	BodyFatVariancePercent *float64 `json:"bodyFatVariancePercent,omitempty"`
+	WeightBandMinKg        float64  `json:"weightBandMinKg,omitempty"`
+	WeightBandMaxKg        float64  `json:"weightBandMaxKg,omitempty"`
+	WeightInBand           *bool    `json:"weightInBand,omitempty"`
+	// Event plans: countdown, tightened tolerance and final-week water-cut warnings
+	Event *EventCountdownResponse `json:"event,omitempty"`
 }
 
 // LandingPointProjectionResponse represents where the user will end up at current pace.
@@ -64,7 +76,7 @@ func (s *Server) analyzePlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse optional date parameter, default to today
-	analysisDate := time.Now()
+	analysisDate := s.now()
 	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
 		parsed, err := time.Parse("2006-01-02", dateStr)
 		if err != nil {
@@ -109,7 +121,7 @@ func (s *Server) analyzePlan(w http.ResponseWriter, r *http.Request) {
 // analyzeActivePlan handles GET /api/plans/active/analysis
 func (s *Server) analyzeActivePlan(w http.ResponseWriter, r *http.Request) {
 	// Parse optional date parameter, default to today
-	analysisDate := time.Now()
+	analysisDate := s.now()
 	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
 		parsed, err := time.Parse("2006-01-02", dateStr)
 		if err != nil {
@@ -166,6 +178,30 @@ func analysisToResponse(a *domain.DualTrackAnalysis) DualTrackAnalysisResponse {
 		GracePeriod:         a.GracePeriod,
 		TrendDiverging:      a.TrendDiverging,
 		TrendDivergingMsg:   a.TrendDivergingMsg,
+		Track:               string(a.Track),
+		SmoothedWeightKg:    a.SmoothedWeightKg,
+		TrendMethod:         string(a.TrendMethod),
+	}
+
+	// Body-fat track (recomp plans)
+	if a.Track == domain.ProgressTrackBodyFat {
+		response.PlannedBodyFatPercent = a.PlannedBodyFatPercent
+		response.ActualBodyFatPercent = a.ActualBodyFatPercent
+		response.BodyFatVariancePercent = a.BodyFatVariancePercent
+		response.WeightBandMinKg = a.WeightBandMinKg
+		response.WeightBandMaxKg = a.WeightBandMaxKg
+		response.WeightInBand = &a.WeightInBand
+	}
+
+	if a.Event != nil {
+		response.Event = &EventCountdownResponse{
+			EventName:        a.Event.EventName,
+			EventDate:        a.Event.EventDate.Format("2006-01-02"),
+			DaysToEvent:      a.Event.DaysToEvent,
+			Urgency:          string(a.Event.Urgency),
+			TolerancePercent: a.Event.TolerancePercent,
+			Warnings:         waterCutWarningsToResponse(a.Event.Warnings),
+		}
 	}
 
 	// Convert options
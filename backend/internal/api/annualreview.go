@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// getAnnualReview handles GET /api/review/annual
+// Returns the review of the most recent completed year.
+func (s *Server) getAnnualReview(w http.ResponseWriter, r *http.Request) {
+	s.writeAnnualReview(w, r, s.annualReviewService.LastCompletedYear())
+}
+
+// getAnnualReviewByYear handles GET /api/review/annual/{year}?refresh=true
+// Returns the review of a year; the current year is reviewed to date.
+// refresh=true regenerates a stored review, e.g. after importing old data.
+func (s *Server) getAnnualReviewByYear(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		writeDomainError(w, domain.ErrInvalidReviewYear, "getAnnualReviewByYear")
+		return
+	}
+	s.writeAnnualReview(w, r, year)
+}
+
+func (s *Server) writeAnnualReview(w http.ResponseWriter, r *http.Request, year int) {
+	refresh := r.URL.Query().Get("refresh") == "true"
+	review, err := s.annualReviewService.GetAnnualReview(r.Context(), year, refresh)
+	if err != nil {
+		writeDomainError(w, err, "getAnnualReview")
+		return
+	}
+	writeJSON(w, http.StatusOK, review)
+}
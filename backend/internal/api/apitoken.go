@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// Headers carrying a signed request's timestamp, nonce and signature.
+const (
+	headerRequestTimestamp = "X-Victus-Timestamp"
+	headerRequestNonce     = "X-Victus-Nonce"
+	headerRequestSignature = "X-Victus-Signature"
+)
+
+// CreateAPITokenRequest is the request body for creating a personal API token.
+type CreateAPITokenRequest struct {
+	Name             string              `json:"name"`
+	Scopes           []domain.TokenScope `json:"scopes"`
+	ExpiresAt        *time.Time          `json:"expiresAt,omitempty"`
+	RequireSignature bool                `json:"requireSignature"`
+}
+
+// CreateAPITokenResponse returns the new token. The plaintext token and the
+// signing secret are only ever returned here. The secret keys signed
+// requests and is never sent with them.
+type CreateAPITokenResponse struct {
+	domain.APIToken
+	Token         string `json:"token"`
+	SigningSecret string `json:"signingSecret"`
+}
+
+// APITokenUsageResponse is a token with its per-endpoint request counts.
+type APITokenUsageResponse struct {
+	Token     domain.APIToken                `json:"token"`
+	Endpoints []domain.APITokenEndpointUsage `json:"endpoints"`
+}
+
+// listAPITokens handles GET /api/tokens
+func (s *Server) listAPITokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := s.apiTokenService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listAPITokens")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// createAPIToken handles POST /api/tokens
+func (s *Server) createAPIToken(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	token, plaintext, err := s.apiTokenService.Create(r.Context(), req.Name, req.Scopes, req.ExpiresAt, req.RequireSignature, time.Now())
+	if err != nil {
+		if domain.IsValidationError(err) {
+			writeDomainError(w, err, "createAPIToken")
+			return
+		}
+		writeInternalError(w, err, "createAPIToken")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateAPITokenResponse{APIToken: *token, Token: plaintext, SigningSecret: token.SigningSecret})
+}
+
+// getAPITokenUsage handles GET /api/tokens/{id}/usage
+func (s *Server) getAPITokenUsage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	token, usage, err := s.apiTokenService.GetUsage(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, store.ErrAPITokenNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "API token not found")
+			return
+		}
+		writeInternalError(w, err, "getAPITokenUsage")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(APITokenUsageResponse{Token: *token, Endpoints: usage})
+}
+
+// revokeAPIToken handles DELETE /api/tokens/{id}
+// Revocation takes effect immediately; the token's usage history is kept.
+func (s *Server) revokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.apiTokenService.Revoke(r.Context(), id, time.Now()); err != nil {
+		if errors.Is(err, store.ErrAPITokenNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "API token not found or already revoked")
+			return
+		}
+		writeInternalError(w, err, "revokeAPIToken")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tokenAuthMiddleware authenticates requests carrying a personal API token
+// as a bearer token, verifies signed requests, enforces the token's scopes and
+// records its usage. Requests without an Authorization header are served
+// unless refuseAnonymous turns them away.
+func (s *Server) tokenAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			if !s.refuseAnonymous(w, r) {
+				next.ServeHTTP(w, r)
+			}
+			return
+		}
+
+		plaintext, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || plaintext == "" {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Authorization header must be a bearer token")
+			return
+		}
+
+		signed := domain.SignedAPIRequest{
+			Timestamp: r.Header.Get(headerRequestTimestamp),
+			Nonce:     r.Header.Get(headerRequestNonce),
+			Signature: r.Header.Get(headerRequestSignature),
+			Method:    r.Method,
+			Path:      r.URL.RequestURI(),
+		}
+		if signed.IsSigned() && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid_body", "Could not read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			signed.Body = body
+		}
+
+		now := time.Now()
+		token, err := s.apiTokenService.Authenticate(r.Context(), plaintext, signed, now)
+		if err != nil {
+			writeDomainError(w, err, "tokenAuthMiddleware")
+			return
+		}
+		if !token.Allows(r.Method, r.URL.Path) {
+			writeError(w, http.StatusForbidden, "insufficient_scope", "API token scopes do not allow this request")
+			return
+		}
+
+		// Count against the route pattern so per-date paths share one endpoint
+		endpoint := r.Method + " " + r.URL.Path
+		if _, pattern := s.mux.Handler(r); pattern != "" {
+			endpoint = pattern
+		}
+		if err := s.apiTokenService.RecordUsage(r.Context(), token, endpoint, clientIP(r), now); err != nil {
+			// Usage auditing must not block the request
+			log.Printf("recording api token usage failed: %v", err)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiTokenContextKey{}, token)))
+	})
+}
+
+// refuseAnonymous writes a 401 and returns true for a request made without a
+// token that needs one: any API request when API_TOKEN_REQUIRED is set, and
+// admin paths (token management, admin routes, export and import) once an
+// admin token exists. Until then
+// the first admin token can be created without one.
+func (s *Server) refuseAnonymous(w http.ResponseWriter, r *http.Request) bool {
+	path := r.URL.Path
+	if s.apiTokenRequired && strings.HasPrefix(path, "/api/") && path != "/api/health" {
+		writeError(w, http.StatusUnauthorized, "api_token_required", "A bearer API token is required")
+		return true
+	}
+	if !domain.IsAdminPath(path) {
+		return false
+	}
+	exists, err := s.apiTokenService.HasActiveAdminToken(r.Context(), time.Now())
+	if err != nil {
+		writeInternalError(w, err, "refuseAnonymous")
+		return true
+	}
+	if exists {
+		writeError(w, http.StatusUnauthorized, "admin_token_required", "An admin API token is required for this route")
+		return true
+	}
+	return false
+}
+
+// apiTokenContextKey carries the authenticated API token, so batched
+// sub-requests can be checked against its scopes.
+type apiTokenContextKey struct{}
+
+// apiTokenFromContext returns the request's API token, or nil for requests
+// made without one.
+func apiTokenFromContext(ctx context.Context) *domain.APIToken {
+	token, _ := ctx.Value(apiTokenContextKey{}).(*domain.APIToken)
+	return token
+}
+
+// clientIP returns the remote address without its port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
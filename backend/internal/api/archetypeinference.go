@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/service"
+)
+
+// ArchetypeInferenceResponse is an inferred archetype with its evidence.
+type ArchetypeInferenceResponse struct {
+	Archetype  string  `json:"archetype,omitempty"`
+	Confidence float64 `json:"confidence"`
+	Source     string  `json:"source"` // rules, llm
+	Reason     string  `json:"reason"`
+}
+
+// ArchetypeAssignmentResponse is the outcome of inferring one session's archetype.
+type ArchetypeAssignmentResponse struct {
+	SessionID    int64                         `json:"sessionId"`
+	SessionOrder int                           `json:"sessionOrder"`
+	Inference    ArchetypeInferenceResponse    `json:"inference"`
+	Applied      bool                          `json:"applied"` // Fatigue applied
+	Queued       bool                          `json:"queued"`  // Waiting in the review queue
+	Fatigue      *SessionFatigueReportResponse `json:"fatigue,omitempty"`
+}
+
+// ArchetypeReviewResponse is a queued low-confidence inference.
+type ArchetypeReviewResponse struct {
+	ID           int64                      `json:"id"`
+	SessionID    int64                      `json:"sessionId"`
+	Date         string                     `json:"date"`
+	SessionOrder int                        `json:"sessionOrder"`
+	Type         string                     `json:"type"`
+	DurationMin  int                        `json:"durationMin"`
+	Notes        string                     `json:"notes,omitempty"`
+	Suggestion   ArchetypeInferenceResponse `json:"suggestion"`
+	Status       string                     `json:"status"` // pending, confirmed, dismissed
+	Resolved     string                     `json:"resolvedArchetype,omitempty"`
+	CreatedAt    string                     `json:"createdAt"`
+	ResolvedAt   string                     `json:"resolvedAt,omitempty"`
+}
+
+// ConfirmArchetypeReviewRequest is the request body for confirming a review.
+type ConfirmArchetypeReviewRequest struct {
+	Archetype string `json:"archetype,omitempty"` // Omit to accept the suggestion
+}
+
+// ConfirmArchetypeReviewResponse is the response body for confirming a review.
+type ConfirmArchetypeReviewResponse struct {
+	Review  ArchetypeReviewResponse       `json:"review"`
+	Fatigue *SessionFatigueReportResponse `json:"fatigue,omitempty"`
+}
+
+// inferArchetypes handles POST /api/logs/{date}/archetypes/infer
+// Infers archetypes for the day's sessions that lack one, applying fatigue
+// for confident inferences and queueing the rest for review.
+func (s *Server) inferArchetypes(w http.ResponseWriter, r *http.Request) {
+	assignments, err := s.archetypeService.InferForDate(r.Context(), r.PathValue("date"))
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "inferArchetypes")
+		}
+		return
+	}
+
+	resp := make([]ArchetypeAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		resp[i] = archetypeAssignmentToResponse(a)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// listArchetypeReviews handles GET /api/archetype-reviews
+// Returns the pending low-confidence inferences, oldest first.
+func (s *Server) listArchetypeReviews(w http.ResponseWriter, r *http.Request) {
+	reviews, err := s.archetypeService.ListPendingReviews(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listArchetypeReviews")
+		return
+	}
+
+	resp := make([]ArchetypeReviewResponse, len(reviews))
+	for i := range reviews {
+		resp[i] = archetypeReviewToResponse(&reviews[i])
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// confirmArchetypeReview handles POST /api/archetype-reviews/{id}/confirm
+// Assigns the suggested (or given) archetype and applies the session's fatigue.
+func (s *Server) confirmArchetypeReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Review ID must be a number")
+		return
+	}
+
+	var req ConfirmArchetypeReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+	var archetype domain.Archetype
+	if req.Archetype != "" {
+		if archetype, err = domain.ParseArchetype(req.Archetype); err != nil {
+			writeDomainError(w, err, "confirmArchetypeReview")
+			return
+		}
+	}
+
+	review, report, err := s.archetypeService.ConfirmReview(r.Context(), id, archetype)
+	if err != nil {
+		writeDomainError(w, err, "confirmArchetypeReview")
+		return
+	}
+
+	resp := ConfirmArchetypeReviewResponse{Review: archetypeReviewToResponse(review)}
+	if report != nil {
+		fatigue := toSessionFatigueReportResponse(report)
+		resp.Fatigue = &fatigue
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// dismissArchetypeReview handles POST /api/archetype-reviews/{id}/dismiss
+// Closes a review without applying fatigue.
+func (s *Server) dismissArchetypeReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Review ID must be a number")
+		return
+	}
+
+	review, err := s.archetypeService.DismissReview(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "dismissArchetypeReview")
+		return
+	}
+	writeJSON(w, http.StatusOK, archetypeReviewToResponse(review))
+}
+
+func archetypeInferenceToResponse(i domain.ArchetypeInference) ArchetypeInferenceResponse {
+	return ArchetypeInferenceResponse{
+		Archetype:  string(i.Archetype),
+		Confidence: i.Confidence,
+		Source:     string(i.Source),
+		Reason:     i.Reason,
+	}
+}
+
+func archetypeAssignmentToResponse(a service.ArchetypeAssignment) ArchetypeAssignmentResponse {
+	resp := ArchetypeAssignmentResponse{
+		SessionID:    a.SessionID,
+		SessionOrder: a.SessionOrder,
+		Inference:    archetypeInferenceToResponse(a.Inference),
+		Applied:      a.Applied,
+		Queued:       a.Queued,
+	}
+	if a.Report != nil {
+		fatigue := toSessionFatigueReportResponse(a.Report)
+		resp.Fatigue = &fatigue
+	}
+	return resp
+}
+
+func archetypeReviewToResponse(r *domain.ArchetypeReview) ArchetypeReviewResponse {
+	resp := ArchetypeReviewResponse{
+		ID:           r.ID,
+		SessionID:    r.SessionID,
+		Date:         r.Date,
+		SessionOrder: r.SessionOrder,
+		Type:         string(r.Type),
+		DurationMin:  r.DurationMin,
+		Notes:        r.Notes,
+		Suggestion:   archetypeInferenceToResponse(r.Inference),
+		Status:       string(r.Status),
+		Resolved:     string(r.Resolved),
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.ResolvedAt != nil {
+		resp.ResolvedAt = r.ResolvedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"victus/internal/store"
+)
+
+// maxBatchRequests caps the sub-requests in one batch.
+const maxBatchRequests = 20
+
+// batchTxRoutes are the routes that can share a batch transaction: log writes
+// whose stores join a request-scoped transaction instead of opening their own.
+// A batch runs atomically only when every item matches one of them.
+var batchTxRoutes = map[string]bool{
+	"POST /api/logs":                          true,
+	"PATCH /api/logs/{date}/actual-training":  true,
+	"PATCH /api/logs/{date}/active-calories":  true,
+	"PATCH /api/logs/{date}/fasting-override": true,
+	"PATCH /api/logs/{date}/check-in":         true,
+	"PATCH /api/logs/{date}/environment":      true,
+	"PATCH /api/logs/{date}/health-sync":      true,
+	"PATCH /api/logs/{date}/consumed-macros":  true,
+	"PUT /api/logs/{date}/notes":              true,
+	"PUT /api/sessions/{id}/notes":            true,
+}
+
+// BatchItemRequest is one sub-request of a batch.
+type BatchItemRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"` // e.g. /api/logs/2026-03-02/check-in, query string allowed
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchRequest is the request body for POST /api/batch.
+type BatchRequest struct {
+	Requests []BatchItemRequest `json:"requests"`
+}
+
+// BatchItemResult is the response to one sub-request.
+type BatchItemResult struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponse is returned by POST /api/batch, with one result per
+// sub-request in order.
+type BatchResponse struct {
+	Atomic     bool              `json:"atomic"`               // Items ran in one transaction
+	RolledBack bool              `json:"rolledBack,omitempty"` // An atomic batch failed and nothing was saved
+	Results    []BatchItemResult `json:"results"`
+}
+
+// errBatchItemFailed aborts an atomic batch when an item doesn't succeed.
+var errBatchItemFailed = errors.New("batch item failed")
+
+// batch handles POST /api/batch
+// Runs sub-requests in order. When every item is a log write that can share
+// a transaction, the batch is atomic: the first failing item stops it and
+// rolls back the items before it. Otherwise items run independently.
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	if len(req.Requests) == 0 || len(req.Requests) > maxBatchRequests {
+		writeError(w, http.StatusBadRequest, "invalid_batch_size", fmt.Sprintf("requests must hold 1-%d items", maxBatchRequests))
+		return
+	}
+
+	subs := make([]*http.Request, len(req.Requests))
+	atomic := true
+	for i, item := range req.Requests {
+		sub, err := newBatchSubRequest(r, item)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_batch_item", fmt.Sprintf("requests[%d]: %v", i, err))
+			return
+		}
+		subs[i] = sub
+		if _, pattern := s.mux.Handler(sub); !batchTxRoutes[pattern] {
+			atomic = false
+		}
+	}
+
+	resp := BatchResponse{Atomic: atomic, Results: make([]BatchItemResult, 0, len(subs))}
+	if !atomic {
+		for _, sub := range subs {
+			resp.Results = append(resp.Results, s.serveBatchItem(sub))
+		}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	err := store.RunInTx(r.Context(), s.db, func(ctx context.Context) error {
+		for _, sub := range subs {
+			result := s.serveBatchItem(sub.WithContext(ctx))
+			resp.Results = append(resp.Results, result)
+			if result.Status >= http.StatusBadRequest {
+				return errBatchItemFailed
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchItemFailed) {
+		// Items after the failure didn't run
+		resp.RolledBack = true
+		for len(resp.Results) < len(subs) {
+			resp.Results = append(resp.Results, BatchItemResult{Status: http.StatusFailedDependency})
+		}
+	} else if err != nil {
+		writeInternalError(w, err, "batch")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// newBatchSubRequest builds the request for one batch item. Items carry no
+// headers of their own; the batch's authentication covers them.
+func newBatchSubRequest(parent *http.Request, item BatchItemRequest) (*http.Request, error) {
+	switch item.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("unsupported method %q", item.Method)
+	}
+	u, err := url.Parse(item.Path)
+	if err != nil || u.IsAbs() || !strings.HasPrefix(u.Path, "/api/") {
+		return nil, fmt.Errorf("path must be an /api/ path")
+	}
+	if u.Path == "/api/batch" {
+		return nil, fmt.Errorf("batches can't be nested")
+	}
+
+	sub, err := http.NewRequestWithContext(parent.Context(), item.Method, u.RequestURI(), bytes.NewReader(item.Body))
+	if err != nil {
+		return nil, err
+	}
+	sub.RemoteAddr = parent.RemoteAddr
+	if len(item.Body) > 0 {
+		sub.Header.Set("Content-Type", "application/json")
+	}
+	return sub, nil
+}
+
+// serveBatchItem runs one sub-request through the router, applying the
+// batch token's scopes to it, or the anonymous access rules without one.
+func (s *Server) serveBatchItem(r *http.Request) BatchItemResult {
+	rec := &batchRecorder{header: make(http.Header)}
+	token := apiTokenFromContext(r.Context())
+	switch {
+	case token == nil:
+		if !s.refuseAnonymous(rec, r) {
+			s.mux.ServeHTTP(rec, r)
+		}
+	case !token.Allows(r.Method, r.URL.Path):
+		writeError(rec, http.StatusForbidden, "insufficient_scope", "API token scopes do not allow this request")
+	default:
+		s.mux.ServeHTTP(rec, r)
+	}
+
+	result := BatchItemResult{Status: rec.status}
+	if result.Status == 0 {
+		result.Status = http.StatusOK
+	}
+	if body := bytes.TrimSpace(rec.body.Bytes()); len(body) > 0 && json.Valid(body) {
+		result.Body = json.RawMessage(body)
+	}
+	return result
+}
+
+// batchRecorder captures a sub-request's response.
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *batchRecorder) Header() http.Header { return b.header }
+
+func (b *batchRecorder) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+func (b *batchRecorder) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: A batch replays sub-requests behind the batch's own auth;
+// tests pin that items keep their order and bodies, that a token's scopes
+// still apply per item, and that malformed or nested items are rejected.
+type BatchSuite struct {
+	suite.Suite
+	srv *Server
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
+
+func (s *BatchSuite) SetupTest() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/profile", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+	mux.HandleFunc("GET /api/logs/{date}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"date": r.PathValue("date")})
+	})
+	s.srv = &Server{mux: mux}
+}
+
+func (s *BatchSuite) post(ctx context.Context, body string) (*httptest.ResponseRecorder, BatchResponse) {
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/api/batch", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.srv.batch(rec, req)
+	var resp BatchResponse
+	if rec.Code == http.StatusOK {
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec, resp
+}
+
+func (s *BatchSuite) TestItemsRunInOrder() {
+	rec, resp := s.post(s.T().Context(), `{"requests": [
+		{"method": "PUT", "path": "/api/profile", "body": {"heightCm": 180}},
+		{"method": "GET", "path": "/api/logs/2026-03-02"},
+		{"method": "GET", "path": "/api/nowhere"}
+	]}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.False(resp.Atomic, "profile and read routes can't share a transaction")
+	s.Require().Len(resp.Results, 3)
+	s.Equal(http.StatusOK, resp.Results[0].Status)
+	s.JSONEq(`{"heightCm": 180}`, string(resp.Results[0].Body))
+	s.JSONEq(`{"date": "2026-03-02"}`, string(resp.Results[1].Body))
+	s.Equal(http.StatusNotFound, resp.Results[2].Status, "a failing item doesn't stop a non-atomic batch")
+}
+
+func (s *BatchSuite) TestTokenScopesApplyPerItem() {
+	token := &domain.APIToken{Scopes: []domain.TokenScope{domain.TokenScopeLogsWrite}}
+	ctx := context.WithValue(s.T().Context(), apiTokenContextKey{}, token)
+	_, resp := s.post(ctx, `{"requests": [
+		{"method": "GET", "path": "/api/logs/2026-03-02"},
+		{"method": "PUT", "path": "/api/profile", "body": {}}
+	]}`)
+	s.Require().Len(resp.Results, 2)
+	s.Equal(http.StatusOK, resp.Results[0].Status)
+	s.Equal(http.StatusForbidden, resp.Results[1].Status)
+}
+
+func (s *BatchSuite) TestRejectsInvalidItems() {
+	for _, body := range []string{
+		`{"requests": []}`,
+		`{"requests": [{"method": "TRACE", "path": "/api/logs/2026-03-02"}]}`,
+		`{"requests": [{"method": "GET", "path": "https://example.com/api/logs"}]}`,
+		`{"requests": [{"method": "POST", "path": "/api/batch", "body": {"requests": []}}]}`,
+	} {
+		rec, _ := s.post(s.T().Context(), body)
+		s.Equal(http.StatusBadRequest, rec.Code, body)
+	}
+}
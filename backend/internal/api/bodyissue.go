@@ -12,6 +12,7 @@ type BodyPartIssueResponse struct {
 	ID        int64  `json:"id"`
 	Date      string `json:"date"`
 	BodyPart  string `json:"bodyPart"`
+	Side      string `json:"side,omitempty"`
 	Symptom   string `json:"symptom"`
 	Severity  int    `json:"severity"`
 	RawText   string `json:"rawText"`
@@ -20,8 +21,10 @@ type BodyPartIssueResponse struct {
 }
 
 // CreateBodyIssueRequest represents a single body part issue to create.
+// BodyPart may name a side ("left knee"); Side sets it explicitly.
 type CreateBodyIssueRequest struct {
 	BodyPart  string `json:"bodyPart"`
+	Side      string `json:"side,omitempty"`
 	Symptom   string `json:"symptom"`
 	RawText   string `json:"rawText"`
 	SessionID *int64 `json:"sessionId,omitempty"`
@@ -76,6 +79,17 @@ func (s *Server) createBodyIssues(w http.ResponseWriter, r *http.Request) {
 	// Convert to domain inputs and validate
 	inputs := make([]domain.BodyPartIssueInput, 0, len(req.Issues))
 	for _, issue := range req.Issues {
+		// Validate side if provided
+		var side domain.BodySide
+		if issue.Side != "" {
+			parsed, err := domain.ParseBodySide(issue.Side)
+			if err != nil {
+				writeDomainError(w, err, "createBodyIssues")
+				return
+			}
+			side = parsed
+		}
+
 		// Validate body part
 		bodyPart, err := domain.ParseMuscleGroup(issue.BodyPart)
 		if err != nil {
@@ -87,21 +101,30 @@ func (s *Server) createBodyIssues(w http.ResponseWriter, r *http.Request) {
 			}
 			// Create an issue for each mapped muscle
 			for _, muscle := range muscles {
+				muscleSide := domain.SideForAlias(issue.BodyPart, muscle)
+				if side != "" && domain.LateralMuscles[muscle] {
+					muscleSide = side
+				}
 				inputs = append(inputs, domain.BodyPartIssueInput{
 					Date:      req.Date,
 					BodyPart:  muscle,
 					Symptom:   issue.Symptom,
 					RawText:   issue.RawText,
 					SessionID: issue.SessionID,
+					Side:      muscleSide,
 				})
 			}
 		} else {
+			if !domain.LateralMuscles[bodyPart] {
+				side = "" // Trunk muscles aren't tracked per side
+			}
 			inputs = append(inputs, domain.BodyPartIssueInput{
 				Date:      req.Date,
 				BodyPart:  bodyPart,
 				Symptom:   issue.Symptom,
 				RawText:   issue.RawText,
 				SessionID: issue.SessionID,
+				Side:      side,
 			})
 		}
 	}
@@ -185,6 +208,7 @@ func toBodyPartIssueResponse(issue domain.BodyPartIssue) BodyPartIssueResponse {
 		ID:        issue.ID,
 		Date:      issue.Date,
 		BodyPart:  string(issue.BodyPart),
+		Side:      string(issue.Side),
 		Symptom:   issue.Symptom,
 		Severity:  int(issue.Severity),
 		RawText:   issue.RawText,
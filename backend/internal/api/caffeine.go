@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// LogCaffeineRequest is the body of POST /api/logs/{date}/caffeine.
+type LogCaffeineRequest struct {
+	Time   string  `json:"time,omitempty"` // HH:MM; defaults to now for today
+	Source string  `json:"source"`
+	Mg     float64 `json:"mg"`
+}
+
+// listCaffeine handles GET /api/logs/{date}/caffeine
+func (s *Server) listCaffeine(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.caffeineService.ListForDate(r.Context(), r.PathValue("date"))
+	if err != nil {
+		writeInternalError(w, err, "listCaffeine")
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// logCaffeine handles POST /api/logs/{date}/caffeine
+// Logs one caffeine intake; no daily log is needed for the date.
+func (s *Server) logCaffeine(w http.ResponseWriter, r *http.Request) {
+	var req LogCaffeineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	entry, err := s.caffeineService.Log(r.Context(), domain.CaffeineEntry{
+		Date:   r.PathValue("date"),
+		Time:   req.Time,
+		Source: domain.CaffeineSource(req.Source),
+		Mg:     req.Mg,
+	})
+	if err != nil {
+		writeDomainError(w, err, "logCaffeine")
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// deleteCaffeine handles DELETE /api/caffeine/{id}
+func (s *Server) deleteCaffeine(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.caffeineService.Delete(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deleteCaffeine")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
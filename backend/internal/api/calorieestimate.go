@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+
+	"victus/internal/domain"
+)
+
+// SessionCalorieEstimateResponse is one session's share of an active calorie estimate.
+type SessionCalorieEstimateResponse struct {
+	SessionOrder int     `json:"sessionOrder"`
+	Type         string  `json:"type"`
+	DurationMin  int     `json:"durationMin"`
+	AvgHeartRate *int    `json:"avgHeartRate,omitempty"`
+	MET          float64 `json:"met"`
+	Method       string  `json:"method"` // met, heart_rate
+	Kcal         int     `json:"kcal"`
+}
+
+// ActiveCalorieEstimateResponse is the response body for the active calorie estimate endpoints.
+type ActiveCalorieEstimateResponse struct {
+	Date     string                           `json:"date"`
+	WeightKg float64                          `json:"weightKg"`
+	Kcal     int                              `json:"kcal"`
+	Sessions []SessionCalorieEstimateResponse `json:"sessions"`
+	Applied  *bool                            `json:"applied,omitempty"` // Set by POST; false when wearable data exists
+}
+
+// getActiveCalorieEstimate handles GET /api/logs/{date}/active-calories/estimate
+// Estimates the day's active burn from its actual sessions without storing it.
+func (s *Server) getActiveCalorieEstimate(w http.ResponseWriter, r *http.Request) {
+	estimate, err := s.calorieEstimateService.EstimateForDate(r.Context(), r.PathValue("date"))
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "getActiveCalorieEstimate")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, activeCalorieEstimateToResponse(estimate))
+}
+
+// applyActiveCalorieEstimate handles POST /api/logs/{date}/active-calories/estimate
+// Stores the estimate as the day's active calories unless a wearable value
+// exists, then re-runs the Flux Engine so TDEE picks it up.
+func (s *Server) applyActiveCalorieEstimate(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	estimate, applied, err := s.calorieEstimateService.ApplyForDate(r.Context(), date)
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "applyActiveCalorieEstimate")
+		}
+		return
+	}
+	if applied {
+		if _, err := s.dailyLogService.RecalculateFlux(r.Context(), date, s.now()); err != nil {
+			writeInternalError(w, err, "applyActiveCalorieEstimate")
+			return
+		}
+	}
+
+	resp := activeCalorieEstimateToResponse(estimate)
+	resp.Applied = &applied
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func activeCalorieEstimateToResponse(e *domain.ActiveCalorieEstimate) ActiveCalorieEstimateResponse {
+	sessions := make([]SessionCalorieEstimateResponse, len(e.Sessions))
+	for i, session := range e.Sessions {
+		sessions[i] = SessionCalorieEstimateResponse{
+			SessionOrder: session.SessionOrder,
+			Type:         string(session.Type),
+			DurationMin:  session.DurationMin,
+			AvgHeartRate: session.AvgHeartRate,
+			MET:          session.MET,
+			Method:       string(session.Method),
+			Kcal:         session.Kcal,
+		}
+	}
+	return ActiveCalorieEstimateResponse{
+		Date:     e.Date,
+		WeightKg: e.WeightKg,
+		Kcal:     e.Kcal,
+		Sessions: sessions,
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// CreateChallengeRequest is the body of POST /api/challenges.
+type CreateChallengeRequest struct {
+	Type          string `json:"type"`
+	DurationDays  int    `json:"durationDays"`
+	Name          string `json:"name,omitempty"`          // Defaults to one built from type and length
+	StartDate     string `json:"startDate,omitempty"`     // Defaults to today
+	AllowedMisses int    `json:"allowedMisses,omitempty"` // Defaults to 0
+}
+
+// listChallenges handles GET /api/challenges
+func (s *Server) listChallenges(w http.ResponseWriter, r *http.Request) {
+	challenges, err := s.challengeService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listChallenges")
+		return
+	}
+	writeJSON(w, http.StatusOK, challenges)
+}
+
+// createChallenge handles POST /api/challenges
+func (s *Server) createChallenge(w http.ResponseWriter, r *http.Request) {
+	var req CreateChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	progress, err := s.challengeService.Create(r.Context(), domain.Challenge{
+		Name:          req.Name,
+		Type:          domain.ChallengeType(req.Type),
+		StartDate:     req.StartDate,
+		DurationDays:  req.DurationDays,
+		AllowedMisses: req.AllowedMisses,
+	})
+	if err != nil {
+		writeDomainError(w, err, "createChallenge")
+		return
+	}
+	writeJSON(w, http.StatusCreated, progress)
+}
+
+// getChallenge handles GET /api/challenges/{id}
+func (s *Server) getChallenge(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	progress, err := s.challengeService.Get(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "getChallenge")
+		return
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+// abandonChallenge handles POST /api/challenges/{id}/abandon
+func (s *Server) abandonChallenge(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	progress, err := s.challengeService.Abandon(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "abandonChallenge")
+		return
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+// deleteChallenge handles DELETE /api/challenges/{id}
+func (s *Server) deleteChallenge(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.challengeService.Delete(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deleteChallenge")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listChallengeNotifications handles GET /api/challenges/notifications
+// Lists challenges that completed or failed and haven't been acknowledged.
+func (s *Server) listChallengeNotifications(w http.ResponseWriter, r *http.Request) {
+	if err := s.challengeService.Refresh(r.Context()); err != nil {
+		writeInternalError(w, err, "listChallengeNotifications")
+		return
+	}
+	challenges, err := s.challengeService.PendingNotifications(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listChallengeNotifications")
+		return
+	}
+	writeJSON(w, http.StatusOK, challenges)
+}
+
+// dismissChallengeNotification handles POST /api/challenges/{id}/dismiss
+func (s *Server) dismissChallengeNotification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.challengeService.DismissNotification(r.Context(), id); err != nil {
+		writeDomainError(w, err, "dismissChallengeNotification")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
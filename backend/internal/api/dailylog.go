@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"time"
 
@@ -24,7 +25,7 @@ func (s *Server) createDailyLog(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "validation_error", err.Error())
 		return
 	}
-	now := time.Now()
+	now := s.now()
 
 	saved, err := s.dailyLogService.Create(r.Context(), input, now)
 	if err != nil {
@@ -33,7 +34,7 @@ func (s *Server) createDailyLog(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "createDailyLog")
 			return
 		}
 		if errors.Is(err, store.ErrDailyLogAlreadyExists) {
@@ -58,7 +59,7 @@ func (s *Server) createDailyLog(w http.ResponseWriter, r *http.Request) {
 
 // getTodayLog handles GET /api/logs/today
 func (s *Server) getTodayLog(w http.ResponseWriter, r *http.Request) {
-	now := time.Now()
+	now := s.now()
 	log, err := s.dailyLogService.GetToday(r.Context(), now)
 
 	if errors.Is(err, store.ErrDailyLogNotFound) {
@@ -80,8 +81,15 @@ func (s *Server) getTodayLog(w http.ResponseWriter, r *http.Request) {
 		trainingLoad = nil
 	}
 
+	response := requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad)
+
+	// Suggest active recovery on rest days - fatigue map is supplementary
+	if body, err := s.fatigueService.GetBodyStatus(r.Context(), now); err == nil {
+		response.ActiveRecovery = domain.SuggestActiveRecovery(log.PlannedSessions, log.ActualSessions, body, trainingLoad)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad))
+	json.NewEncoder(w).Encode(response)
 }
 
 // getLogByDate handles GET /api/logs/{date}
@@ -143,16 +151,62 @@ func (s *Server) getLogsRange(w http.ResponseWriter, r *http.Request) {
 }
 
 // deleteTodayLog handles DELETE /api/logs/today
+// Succeeds whether or not today has a log.
 func (s *Server) deleteTodayLog(w http.ResponseWriter, r *http.Request) {
-	now := time.Now()
-	if err := s.dailyLogService.DeleteToday(r.Context(), now); err != nil {
-		writeInternalError(w, err, "deleteTodayLog")
+	today := s.now().Format("2006-01-02")
+	if _, err := s.logDeletionService.Delete(r.Context(), today); err != nil && !errors.Is(err, store.ErrDailyLogNotFound) {
+		if !handleDailyLogError(w, err, "") {
+			writeInternalError(w, err, "deleteTodayLog")
+		}
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// LogDeletionResponse reports what deleting a daily log removed and recomputed.
+type LogDeletionResponse struct {
+	Date                  string             `json:"date"`
+	SessionsRemoved       int                `json:"sessionsRemoved"`
+	FatigueEventsReversed int                `json:"fatigueEventsReversed"`
+	FatigueReversed       map[string]float64 `json:"fatigueReversed,omitempty"` // Percentage points per muscle
+	MetabolicRecords      int                `json:"metabolicRecordsRemoved"`
+	EntriesRemoved        int                `json:"entriesRemoved"` // Meal, hunger, caffeine, portion and recovery rows
+	FluxRecalculated      bool               `json:"fluxRecalculated"`
+	PlanWeekUpdated       *int               `json:"planWeekUpdated,omitempty"`
+	StaleDebriefWeek      string             `json:"staleDebriefWeek,omitempty"`
+}
+
+// deleteLog handles DELETE /api/logs/{date}
+// Deletes the log and its dependents, then recomputes what was derived from it.
+func (s *Server) deleteLog(w http.ResponseWriter, r *http.Request) {
+	result, err := s.logDeletionService.Delete(r.Context(), r.PathValue("date"))
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "deleteLog")
+		}
+		return
+	}
+
+	resp := LogDeletionResponse{
+		Date:                  result.Date,
+		SessionsRemoved:       result.SessionsRemoved,
+		FatigueEventsReversed: result.FatigueEventsReversed,
+		MetabolicRecords:      result.MetabolicRecords,
+		EntriesRemoved:        result.EntriesRemoved,
+		FluxRecalculated:      result.FluxRecalculated,
+		PlanWeekUpdated:       result.PlanWeekUpdated,
+		StaleDebriefWeek:      result.StaleDebriefWeek,
+	}
+	if len(result.FatigueReversed) > 0 {
+		resp.FatigueReversed = make(map[string]float64, len(result.FatigueReversed))
+		for muscle, amount := range result.FatigueReversed {
+			resp.FatigueReversed[string(muscle)] = math.Round(amount*10) / 10
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 // updateActiveCalories handles PATCH /api/logs/{date}/active-calories
 func (s *Server) updateActiveCalories(w http.ResponseWriter, r *http.Request) {
 	date := r.PathValue("date")
@@ -255,6 +309,81 @@ func (s *Server) updateFastingOverride(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad))
 }
 
+// updateCheckIn handles PATCH /api/logs/{date}/check-in
+func (s *Server) updateCheckIn(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if date == "" {
+		writeError(w, http.StatusBadRequest, "missing_date", "Date parameter is required")
+		return
+	}
+
+	var req requests.UpdateCheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	log, err := s.dailyLogService.UpdateCheckIn(r.Context(), date, domain.CheckIn{
+		Mood:     req.Mood,
+		Stress:   req.Stress,
+		Soreness: req.Soreness,
+		Hunger:   req.Hunger,
+	})
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "updateCheckIn")
+		}
+		return
+	}
+
+	// Calculate training load metrics (ACR)
+	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), log.Date, log.ActualSessions, log.PlannedSessions)
+	if err != nil {
+		trainingLoad = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad))
+}
+
+// updateEnvironment handles PATCH /api/logs/{date}/environment
+func (s *Server) updateEnvironment(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if date == "" {
+		writeError(w, http.StatusBadRequest, "missing_date", "Date parameter is required")
+		return
+	}
+
+	var req requests.UpdateEnvironmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	conditions, err := domain.ParseEnvironment(req.Environment)
+	if err != nil {
+		writeDomainError(w, err, "updateEnvironment")
+		return
+	}
+
+	log, err := s.dailyLogService.UpdateEnvironment(r.Context(), date, conditions)
+	if err != nil {
+		if !handleDailyLogError(w, err, "No log exists for this date") {
+			writeInternalError(w, err, "updateEnvironment")
+		}
+		return
+	}
+
+	// Calculate training load metrics (ACR)
+	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), log.Date, log.ActualSessions, log.PlannedSessions)
+	if err != nil {
+		trainingLoad = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad))
+}
+
 // syncHealthData handles PATCH /api/logs/{date}/health-sync
 // Upserts health metrics from HealthKit. Creates a minimal log if none exists.
 func (s *Server) syncHealthData(w http.ResponseWriter, r *http.Request) {
@@ -269,6 +398,12 @@ func (s *Server) syncHealthData(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
 		return
 	}
+	if req.WeighInAt != nil {
+		if err := domain.ValidateWeighInTime(*req.WeighInAt); err != nil {
+			writeDomainError(w, err, "syncHealthData")
+			return
+		}
+	}
 
 	metrics := req.ToHealthKitMetrics()
 	log, err := s.dailyLogService.UpsertHealthKitMetrics(r.Context(), date, metrics)
@@ -281,6 +416,9 @@ func (s *Server) syncHealthData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Late arrivals patch derived values on the past day
+	s.reconcileLateData(r.Context(), date, req.LateDataFields())
+
 	// Calculate training load metrics (ACR)
 	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), log.Date, log.ActualSessions, log.PlannedSessions)
 	if err != nil {
@@ -324,6 +462,9 @@ func (s *Server) addConsumedMacros(w http.ResponseWriter, r *http.Request) {
 		CarbsG:   req.CarbsG,
 		FatG:     req.FatG,
 	}
+	if req.EatenAt != nil {
+		macros.EatenAt = *req.EatenAt
+	}
 
 	log, err := s.dailyLogService.AddConsumedMacros(r.Context(), date, macros)
 	if err != nil {
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// getDataQuality handles GET /api/data-quality
+// Optional query param: ?days=N (default 30, 7-365)
+func (s *Server) getDataQuality(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if d := r.URL.Query().Get("days"); d != "" {
+		v, err := strconv.Atoi(d)
+		if err != nil || v < 7 || v > 365 {
+			writeError(w, http.StatusBadRequest, "invalid_days", "days must be between 7 and 365")
+			return
+		}
+		days = v
+	}
+
+	report, err := s.dataQualityService.GetReport(r.Context(), s.now(), days)
+	if err != nil {
+		writeInternalError(w, err, "getDataQuality")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
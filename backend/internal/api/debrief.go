@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -57,6 +58,30 @@ func (s *Server) getWeeklyDebriefByDate(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(requests.WeeklyDebriefToResponse(debrief))
 }
 
+// getWeeklyDebriefReport handles GET /api/debrief/weekly/{date}/report.pdf
+// Returns a printable PDF of the debrief for the week containing the date.
+func (s *Server) getWeeklyDebriefReport(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "Date must be in YYYY-MM-DD format")
+		return
+	}
+
+	pdf, debrief, err := s.weeklyDebriefService.GenerateWeeklyReport(r.Context(), date)
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusNotFound, "profile_not_found", "Create a profile first")
+			return
+		}
+		writeInternalError(w, err, "getWeeklyDebriefReport")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="victus-debrief-%s.pdf"`, debrief.WeekStartDate))
+	w.Write(pdf)
+}
+
 // getCurrentWeekDebrief handles GET /api/debrief/current
 // Returns an in-progress debrief for the current incomplete week.
 func (s *Server) getCurrentWeekDebrief(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/service"
+)
+
+// DailyDigestResponse is the digest with the message text the channels receive.
+type DailyDigestResponse struct {
+	domain.DailyDigest
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// digestDate reads ?date=YYYY-MM-DD, defaulting to today.
+func (s *Server) digestDate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		return s.now().Format("2006-01-02"), true
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "date must be in YYYY-MM-DD format")
+		return "", false
+	}
+	return date, true
+}
+
+// getDailyDigest handles GET /api/digest/daily?date=YYYY-MM-DD
+// Previews the end-of-day digest without sending it.
+func (s *Server) getDailyDigest(w http.ResponseWriter, r *http.Request) {
+	date, ok := s.digestDate(w, r)
+	if !ok {
+		return
+	}
+
+	digest, err := s.digestService.Build(r.Context(), date)
+	if err != nil {
+		writeInternalError(w, err, "getDailyDigest")
+		return
+	}
+	writeJSON(w, http.StatusOK, DailyDigestResponse{DailyDigest: *digest, Title: digest.Title(), Text: digest.Text()})
+}
+
+// sendDailyDigest handles POST /api/admin/digest/send?date=YYYY-MM-DD
+// Sends the digest now, e.g. to check the ntfy or SMTP settings.
+func (s *Server) sendDailyDigest(w http.ResponseWriter, r *http.Request) {
+	date, ok := s.digestDate(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := s.digestService.Send(r.Context(), date)
+	if errors.Is(err, service.ErrDigestNotConfigured) {
+		writeError(w, http.StatusConflict, "digest_not_configured", "Set DIGEST_NTFY_URL or the DIGEST_SMTP_* variables to send digests")
+		return
+	}
+	if err != nil && result == nil {
+		writeInternalError(w, err, "sendDailyDigest")
+		return
+	}
+	if err != nil && len(result.Channels) == 0 {
+		writeError(w, http.StatusBadGateway, "digest_delivery_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,10 @@
+package api
+
+import "net/http"
+
+// isDryRun reports whether a mutation was called with ?dryRun=true. Dry runs
+// go through the same validation as the real request and return the
+// would-be result with 200 OK, but persist nothing.
+func isDryRun(r *http.Request) bool {
+	return r.URL.Query().Get("dryRun") == "true"
+}
@@ -109,6 +109,7 @@ func (s *Server) submitEchoHandler(w http.ResponseWriter, r *http.Request) {
 		Session:           requests.ToSessionResponse(result.Session),
 		EchoResult:        requests.ToEchoResultResponse(result.EchoResult),
 		BodyIssuesCreated: requests.ToBodyIssueResponses(result.BodyIssuesCreated),
+		RecordsSet:        result.RecordsSet,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -171,12 +172,31 @@ func (s *Server) getSessionHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests.ToSessionResponse(session))
 }
 
+// draftRemindersHandler handles GET /api/sessions/drafts/reminders.
+// Returns draft sessions still awaiting RPE after the reminder threshold.
+func (s *Server) draftRemindersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	drafts, err := s.echoService.ListDraftReminders(r.Context())
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.ToDraftReminderResponses(drafts))
+}
+
 // registerEchoRoutes registers echo-related routes.
 // Called from NewServer to set up the echo endpoints.
 func (s *Server) registerEchoRoutes() {
 	s.mux.HandleFunc("/api/logs/{date}/sessions/quick", s.quickSubmitSessionHandler)
 	s.mux.HandleFunc("/api/sessions/{id}/echo", s.submitEchoHandler)
 	s.mux.HandleFunc("/api/sessions/{id}/finalize", s.finalizeSessionHandler)
+	s.mux.HandleFunc("/api/sessions/drafts/reminders", s.draftRemindersHandler)
 	s.mux.HandleFunc("/api/sessions/{id}", s.getSessionHandler)
 }
 
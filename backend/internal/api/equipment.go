@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"victus/internal/api/requests"
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// EquipmentProfileResponse is the equipment profile plus what is usable today.
+type EquipmentProfileResponse struct {
+	domain.EquipmentProfile
+	Traveling      bool     `json:"traveling"`
+	AvailableToday []string `json:"availableToday"` // Empty when the inventory is not set (unrestricted)
+}
+
+func toEquipmentProfileResponse(ep *domain.EquipmentProfile, now time.Time) EquipmentProfileResponse {
+	today := now.Format("2006-01-02")
+	resp := EquipmentProfileResponse{
+		EquipmentProfile: *ep,
+		Traveling:        ep.IsTraveling(today),
+		AvailableToday:   make([]string, 0),
+	}
+	avail := ep.AvailableOn(today)
+	for e := range domain.ValidEquipmentTypes {
+		if avail != nil && avail.Has(e) {
+			resp.AvailableToday = append(resp.AvailableToday, string(e))
+		}
+	}
+	sort.Strings(resp.AvailableToday)
+	return resp
+}
+
+// getEquipmentProfile handles GET /api/profile/equipment
+func (s *Server) getEquipmentProfile(w http.ResponseWriter, r *http.Request) {
+	ep, err := s.equipmentService.Get(r.Context())
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "No profile exists")
+			return
+		}
+		writeInternalError(w, err, "getEquipmentProfile")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEquipmentProfileResponse(ep, s.now()))
+}
+
+// updateEquipmentProfile handles PUT /api/profile/equipment
+// Omitting travelMode clears an active travel override.
+func (s *Server) updateEquipmentProfile(w http.ResponseWriter, r *http.Request) {
+	var req domain.EquipmentProfile
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	ep, err := s.equipmentService.Update(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusBadRequest, "profile_required", "A user profile is required to set equipment")
+			return
+		}
+		if domain.IsValidationError(err) {
+			writeDomainError(w, err, "updateEquipmentProfile")
+			return
+		}
+		writeInternalError(w, err, "updateEquipmentProfile")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEquipmentProfileResponse(ep, s.now()))
+}
+
+// getRecommendedPrograms handles GET /api/training-programs/recommended
+// Returns templates ranked by equipment fit, honoring travel mode.
+func (s *Server) getRecommendedPrograms(w http.ResponseWriter, r *http.Request) {
+	fits, err := s.programService.Recommend(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "getRecommendedPrograms")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.ProgramFitsToResponse(fits))
+}
@@ -0,0 +1,438 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"victus/internal/domain"
+	"victus/internal/service"
+	"victus/internal/store"
+)
+
+// errorCatalogEntry maps a sentinel error to a stable machine-readable code
+// and HTTP status. Codes are part of the API contract: clients branch on them,
+// so never rename one. The user-facing message key is derived from the code.
+type errorCatalogEntry struct {
+	err    error
+	code   string
+	status int
+}
+
+// messageKey returns the key clients use to look up a localized message.
+func (e errorCatalogEntry) messageKey() string {
+	return "errors." + e.code
+}
+
+// errorCatalog lists every domain, store and service sentinel error the API
+// can surface. Store errors that mirror a domain error share its code.
+var errorCatalog = []errorCatalogEntry{
+	// Profile validation errors
+	{domain.ErrInvalidHeight, "invalid_height", http.StatusBadRequest},
+	{domain.ErrInvalidBirthDate, "invalid_birth_date", http.StatusBadRequest},
+	{domain.ErrInvalidSex, "invalid_sex", http.StatusBadRequest},
+	{domain.ErrInvalidGoal, "invalid_goal", http.StatusBadRequest},
+	{domain.ErrInvalidCurrentWeight, "invalid_current_weight", http.StatusBadRequest},
+	{domain.ErrInvalidTargetWeight, "invalid_target_weight", http.StatusBadRequest},
+	{domain.ErrInvalidTimeframeWeeks, "invalid_timeframe_weeks", http.StatusBadRequest},
+	{domain.ErrInvalidWeeklyChange, "invalid_weekly_change", http.StatusBadRequest},
+	{domain.ErrMacroRatiosNotSum100, "macro_ratios_not_sum_100", http.StatusBadRequest},
+	{domain.ErrMealRatiosNotSum100, "meal_ratios_not_sum_100", http.StatusBadRequest},
+	{domain.ErrInvalidRatio, "invalid_ratio", http.StatusBadRequest},
+	{domain.ErrInvalidFruitTarget, "invalid_fruit_target", http.StatusBadRequest},
+	{domain.ErrInvalidVeggieTarget, "invalid_veggie_target", http.StatusBadRequest},
+	{domain.ErrInvalidPointsMultiplier, "invalid_points_multiplier", http.StatusBadRequest},
+	{domain.ErrInvalidBMREquation, "invalid_bmr_equation", http.StatusBadRequest},
+	{domain.ErrInvalidBodyFatPercent, "invalid_body_fat_percent", http.StatusBadRequest},
+	{domain.ErrInvalidSupplement, "invalid_supplement", http.StatusBadRequest},
+	{domain.ErrInvalidTDEESource, "invalid_tdee_source", http.StatusBadRequest},
+	{domain.ErrInvalidManualTDEE, "invalid_manual_tdee", http.StatusBadRequest},
+	{domain.ErrInvalidRecalibrationTolerance, "invalid_recalibration_tolerance", http.StatusBadRequest},
+	{domain.ErrInvalidCalorieTolerance, "invalid_calorie_tolerance", http.StatusBadRequest},
+	{domain.ErrInvalidProteinFloor, "invalid_protein_floor", http.StatusBadRequest},
+	{domain.ErrInvalidFatMinimum, "invalid_fat_minimum", http.StatusBadRequest},
+	{domain.ErrInvalidCarbTolerance, "invalid_carb_tolerance", http.StatusBadRequest},
+	{domain.ErrInvalidFastingProtocol, "invalid_fasting_protocol", http.StatusBadRequest},
+	{domain.ErrInvalidEatingWindow, "invalid_eating_window", http.StatusBadRequest},
+	{domain.ErrInvalidWeightTrendMethod, "invalid_weight_trend_method", http.StatusBadRequest},
+	{domain.ErrInvalidWeightTrendWindow, "invalid_weight_trend_window", http.StatusBadRequest},
+	{domain.ErrInvalidLogLockDays, "invalid_log_lock_days", http.StatusBadRequest},
+
+	// DailyLog validation errors
+	{domain.ErrInvalidDate, "invalid_date", http.StatusBadRequest},
+	{domain.ErrInvalidWeight, "invalid_weight", http.StatusBadRequest},
+	{domain.ErrInvalidWeighInTime, "invalid_weigh_in_time", http.StatusBadRequest},
+	{domain.ErrInvalidBedtime, "invalid_bedtime", http.StatusBadRequest},
+	{domain.ErrInvalidBodyFat, "invalid_body_fat", http.StatusBadRequest},
+	{domain.ErrInvalidHeartRate, "invalid_heart_rate", http.StatusBadRequest},
+	{domain.ErrInvalidHRV, "invalid_hrv", http.StatusBadRequest},
+	{domain.ErrInvalidSleepQuality, "invalid_sleep_quality", http.StatusBadRequest},
+	{domain.ErrInvalidSleepHours, "invalid_sleep_hours", http.StatusBadRequest},
+	{domain.ErrInvalidTrainingType, "invalid_training_type", http.StatusBadRequest},
+	{domain.ErrInvalidTrainingDuration, "invalid_training_duration", http.StatusBadRequest},
+	{domain.ErrInvalidDayType, "invalid_day_type", http.StatusBadRequest},
+	{domain.ErrInvalidSessionOrder, "invalid_session_order", http.StatusBadRequest},
+	{domain.ErrInvalidPerceivedIntensity, "invalid_perceived_intensity", http.StatusBadRequest},
+	{domain.ErrTooManySessions, "too_many_sessions", http.StatusBadRequest},
+	{domain.ErrInvalidSessionStartTime, "invalid_session_start_time", http.StatusBadRequest},
+	{domain.ErrInvalidSessionHeartRate, "invalid_session_heart_rate", http.StatusBadRequest},
+	{domain.ErrInvalidMealTime, "invalid_meal_time", http.StatusBadRequest},
+
+	// NutritionPlan validation errors
+	{domain.ErrInvalidPlanStatus, "invalid_plan_status", http.StatusBadRequest},
+	{domain.ErrInvalidPlanStartDate, "invalid_plan_start_date", http.StatusBadRequest},
+	{domain.ErrPlanStartDateTooOld, "plan_start_date_too_old", http.StatusBadRequest},
+	{domain.ErrInvalidPlanStartWeight, "invalid_plan_start_weight", http.StatusBadRequest},
+	{domain.ErrInvalidPlanGoalWeight, "invalid_plan_goal_weight", http.StatusBadRequest},
+	{domain.ErrInvalidPlanDuration, "invalid_plan_duration", http.StatusBadRequest},
+	{domain.ErrPlanDeficitTooAggressive, "plan_deficit_too_aggressive", http.StatusBadRequest},
+	{domain.ErrPlanSurplusTooAggressive, "plan_surplus_too_aggressive", http.StatusBadRequest},
+	{domain.ErrInvalidKcalFactor, "invalid_kcal_factor", http.StatusBadRequest},
+	{domain.ErrKcalFactorAutoTuneRequiresOverride, "kcal_factor_auto_tune_requires_override", http.StatusBadRequest},
+	{domain.ErrInvalidPlanMode, "invalid_plan_mode", http.StatusBadRequest},
+	{domain.ErrRecompBodyFatRequired, "recomp_body_fat_required", http.StatusBadRequest},
+	{domain.ErrInvalidPlanBodyFat, "invalid_plan_body_fat", http.StatusBadRequest},
+	{domain.ErrRecompGoalNotLower, "recomp_goal_not_lower", http.StatusBadRequest},
+	{domain.ErrRecompTooAggressive, "recomp_too_aggressive", http.StatusBadRequest},
+	{domain.ErrRecompWeightOutsideBand, "recomp_weight_outside_band", http.StatusBadRequest},
+	{domain.ErrEventDateRequired, "event_date_required", http.StatusBadRequest},
+	{domain.ErrInvalidEventDate, "invalid_event_date", http.StatusBadRequest},
+	{domain.ErrEventDateOutsidePlan, "event_date_outside_plan", http.StatusBadRequest},
+	{domain.ErrEventTimelineFixed, "event_timeline_fixed", http.StatusBadRequest},
+	{domain.ErrPlanNotEvent, "plan_not_event", http.StatusBadRequest},
+	{domain.ErrActivePlanExists, "active_plan_exists", http.StatusConflict},
+	{domain.ErrPlanNotFound, "plan_not_found", http.StatusNotFound},
+
+	// Dual-Track Analysis errors
+	{domain.ErrPlanEnded, "plan_ended", http.StatusBadRequest},
+	{domain.ErrPlanNotStarted, "plan_not_started", http.StatusBadRequest},
+	{domain.ErrInsufficientWeightData, "insufficient_weight_data", http.StatusBadRequest},
+
+	// Fatigue/Body Map errors
+	{domain.ErrInvalidMuscleGroup, "invalid_muscle_group", http.StatusBadRequest},
+	{domain.ErrInvalidArchetype, "invalid_archetype", http.StatusBadRequest},
+	{domain.ErrInvalidBodySide, "invalid_body_side", http.StatusBadRequest},
+	{domain.ErrInvalidSideShare, "invalid_side_share", http.StatusBadRequest},
+
+	// Progression Pattern validation errors
+	{domain.ErrInvalidProgressionType, "invalid_progression_type", http.StatusBadRequest},
+	{domain.ErrInvalidStrengthConfig, "invalid_strength_config", http.StatusBadRequest},
+	{domain.ErrInvalidSkillConfig, "invalid_skill_config", http.StatusBadRequest},
+	{domain.ErrProgressionTypeMismatch, "progression_type_mismatch", http.StatusBadRequest},
+
+	// Training Program validation errors
+	{domain.ErrInvalidProgramDifficulty, "invalid_program_difficulty", http.StatusBadRequest},
+	{domain.ErrInvalidProgramFocus, "invalid_program_focus", http.StatusBadRequest},
+	{domain.ErrInvalidEquipmentType, "invalid_equipment_type", http.StatusBadRequest},
+	{domain.ErrInvalidTravelModeUntil, "invalid_travel_mode_until", http.StatusBadRequest},
+	{domain.ErrInvalidProgramStatus, "invalid_program_status", http.StatusBadRequest},
+	{domain.ErrInvalidInstallationStatus, "invalid_installation_status", http.StatusBadRequest},
+	{domain.ErrInvalidProgramName, "invalid_program_name", http.StatusBadRequest},
+	{domain.ErrInvalidProgramDuration, "invalid_program_duration", http.StatusBadRequest},
+	{domain.ErrInvalidTrainingDaysPerWeek, "invalid_training_days_per_week", http.StatusBadRequest},
+	{domain.ErrInvalidWeekNumber, "invalid_week_number", http.StatusBadRequest},
+	{domain.ErrInvalidVolumeScale, "invalid_volume_scale", http.StatusBadRequest},
+	{domain.ErrInvalidIntensityScale, "invalid_intensity_scale", http.StatusBadRequest},
+	{domain.ErrInvalidProgramDayNumber, "invalid_program_day_number", http.StatusBadRequest},
+	{domain.ErrInvalidProgramDayLabel, "invalid_program_day_label", http.StatusBadRequest},
+	{domain.ErrInvalidProgramDayDuration, "invalid_program_day_duration", http.StatusBadRequest},
+	{domain.ErrInvalidProgramDayLoadScore, "invalid_program_day_load_score", http.StatusBadRequest},
+	{domain.ErrInvalidInstallationStartDate, "invalid_installation_start_date", http.StatusBadRequest},
+	{domain.ErrInstallationStartDateTooOld, "installation_start_date_too_old", http.StatusBadRequest},
+	{domain.ErrInvalidWeekDayMapping, "invalid_week_day_mapping", http.StatusBadRequest},
+	{domain.ErrProgramNotFound, "program_not_found", http.StatusNotFound},
+	{domain.ErrActiveInstallationExists, "active_installation_exists", http.StatusConflict},
+	{domain.ErrInstallationNotFound, "installation_not_found", http.StatusNotFound},
+	{domain.ErrInstallationNotActive, "installation_not_active", http.StatusConflict},
+	{domain.ErrInvalidRescheduleDates, "invalid_reschedule_dates", http.StatusBadRequest},
+	{domain.ErrRescheduleOutsideWeek, "reschedule_outside_week", http.StatusBadRequest},
+	{domain.ErrRescheduleInPast, "reschedule_in_past", http.StatusBadRequest},
+	{domain.ErrRescheduleDayTrained, "reschedule_day_trained", http.StatusConflict},
+	{domain.ErrRescheduleDayOccupied, "reschedule_day_occupied", http.StatusConflict},
+	{domain.ErrNoSessionToReschedule, "no_session_to_reschedule", http.StatusBadRequest},
+	{domain.ErrInvalidAutoregulationBound, "invalid_autoregulation_bound", http.StatusBadRequest},
+	{domain.ErrInvalidWaveShape, "invalid_wave_shape", http.StatusBadRequest},
+	{domain.ErrInvalidDeloadCadence, "invalid_deload_cadence", http.StatusBadRequest},
+
+	// Session exercise (Block Constructor) validation errors
+	{domain.ErrInvalidSessionPhase, "invalid_session_phase", http.StatusBadRequest},
+	{domain.ErrInvalidSessionExerciseID, "invalid_session_exercise_id", http.StatusBadRequest},
+	{domain.ErrInvalidSessionExerciseOrder, "invalid_session_exercise_order", http.StatusBadRequest},
+	{domain.ErrDuplicateSessionExerciseOrder, "duplicate_session_exercise_order", http.StatusBadRequest},
+	{domain.ErrTooManySessionExercises, "too_many_session_exercises", http.StatusBadRequest},
+
+	// Echo logging validation errors
+	{domain.ErrSessionNotDraft, "session_not_draft", http.StatusConflict},
+	{domain.ErrSessionNotFound, "session_not_found", http.StatusNotFound},
+	{domain.ErrInvalidRPEOffset, "invalid_rpe_offset", http.StatusBadRequest},
+	{domain.ErrInvalidJointDelta, "invalid_joint_delta", http.StatusBadRequest},
+	{domain.ErrEchoAlreadyApplied, "echo_already_applied", http.StatusConflict},
+
+	// Voice command parsing errors
+	{domain.ErrNilVoiceCommand, "nil_voice_command", http.StatusBadRequest},
+	{domain.ErrInvalidVoiceIntent, "invalid_voice_intent", http.StatusBadRequest},
+	{domain.ErrMissingVoiceData, "missing_voice_data", http.StatusBadRequest},
+	{domain.ErrInvalidVoiceData, "invalid_voice_data", http.StatusBadRequest},
+	{domain.ErrUnclearVoiceAnswer, "unclear_voice_answer", http.StatusBadRequest},
+	{domain.ErrVoiceClarificationClosed, "voice_clarification_closed", http.StatusConflict},
+
+	// Food matching errors
+	{domain.ErrEmptyFoodQuery, "empty_food_query", http.StatusBadRequest},
+
+	// Solver scoring and feedback errors
+	{domain.ErrSolverIngredientsRequired, "solver_ingredients_required", http.StatusBadRequest},
+	{domain.ErrInvalidSolverIngredientAmount, "invalid_solver_ingredient_amount", http.StatusBadRequest},
+	{domain.ErrInvalidSolverFeedbackAction, "invalid_solver_feedback_action", http.StatusBadRequest},
+	{domain.ErrSolverFeedbackFoodsRequired, "solver_feedback_foods_required", http.StatusBadRequest},
+	{domain.ErrInvalidMealHunger, "invalid_meal_hunger", http.StatusBadRequest},
+
+	// Meal template errors
+	{domain.ErrMealTemplateNameRequired, "meal_template_name_required", http.StatusBadRequest},
+	{domain.ErrInvalidMealName, "invalid_meal_name", http.StatusBadRequest},
+	{domain.ErrMealTemplateEmpty, "meal_template_empty", http.StatusBadRequest},
+	{domain.ErrMealTemplateTooManyItems, "meal_template_too_many_items", http.StatusBadRequest},
+	{domain.ErrInvalidMealTemplateItem, "invalid_meal_template_item", http.StatusBadRequest},
+	{domain.ErrNothingToCopy, "nothing_to_copy", http.StatusBadRequest},
+
+	// Meal item errors
+	{domain.ErrMealItemFoodRequired, "meal_item_food_required", http.StatusBadRequest},
+	{domain.ErrInvalidMealItemGrams, "invalid_meal_item_grams", http.StatusBadRequest},
+
+	// Adherence calendar errors
+	{domain.ErrInvalidExemptionReason, "invalid_exemption_reason", http.StatusBadRequest},
+
+	// Weekly target editing errors
+	{domain.ErrWeeklyTargetInPast, "weekly_target_in_past", http.StatusBadRequest},
+	{domain.ErrInvalidWeeklyTargetMacros, "invalid_weekly_target_macros", http.StatusBadRequest},
+	{domain.ErrWeeklyTargetMacroMismatch, "weekly_target_macro_mismatch", http.StatusBadRequest},
+	{domain.ErrWeeklyTargetUnsafe, "weekly_target_unsafe", http.StatusBadRequest},
+
+	// Session template errors
+	{domain.ErrSessionTemplateNameRequired, "session_template_name_required", http.StatusBadRequest},
+	{domain.ErrSessionTemplateTooManyExercises, "session_template_too_many_exercises", http.StatusBadRequest},
+	{domain.ErrInvalidSessionTemplateExercise, "invalid_session_template_exercise", http.StatusBadRequest},
+	{domain.ErrSessionNotFoundOnDay, "session_not_found_on_day", http.StatusNotFound},
+
+	// API token errors
+	{domain.ErrInvalidAPITokenName, "invalid_api_token_name", http.StatusBadRequest},
+	{domain.ErrAPITokenScopesRequired, "api_token_scopes_required", http.StatusBadRequest},
+	{domain.ErrInvalidTokenScope, "invalid_token_scope", http.StatusBadRequest},
+	{domain.ErrAPITokenExpiryInPast, "api_token_expiry_in_past", http.StatusBadRequest},
+
+	// Semantic search errors
+	{domain.ErrInvalidSemanticQuery, "invalid_semantic_query", http.StatusBadRequest},
+	{domain.ErrInvalidSemanticLimit, "invalid_semantic_limit", http.StatusBadRequest},
+
+	// Cross-entity search errors
+	{domain.ErrInvalidSearchQuery, "invalid_search_query", http.StatusBadRequest},
+	{domain.ErrInvalidSearchLimit, "invalid_search_limit", http.StatusBadRequest},
+	{domain.ErrInvalidSearchKind, "invalid_search_kind", http.StatusBadRequest},
+
+	// Annual review errors
+	{domain.ErrInvalidReviewYear, "invalid_review_year", http.StatusBadRequest},
+
+	// Food price errors
+	{domain.ErrInvalidFoodPrice, "invalid_food_price", http.StatusBadRequest},
+	{domain.ErrInvalidPriceBasis, "invalid_price_basis", http.StatusBadRequest},
+	{domain.ErrInvalidMaxCost, "invalid_max_cost", http.StatusBadRequest},
+	{domain.ErrInvalidCostWeeks, "invalid_cost_weeks", http.StatusBadRequest},
+
+	// Grocery list errors
+	{domain.ErrInvalidGroceryItems, "invalid_grocery_items", http.StatusBadRequest},
+	{domain.ErrInvalidGroceryItemAmount, "invalid_grocery_item_amount", http.StatusBadRequest},
+	{domain.ErrFuturePurchaseDate, "future_purchase_date", http.StatusBadRequest},
+
+	// Caffeine errors
+	{domain.ErrInvalidCaffeineTime, "invalid_caffeine_time", http.StatusBadRequest},
+	{domain.ErrInvalidCaffeineSource, "invalid_caffeine_source", http.StatusBadRequest},
+	{domain.ErrInvalidCaffeineAmount, "invalid_caffeine_amount", http.StatusBadRequest},
+
+	// Check-in errors
+	{domain.ErrInvalidCheckIn, "invalid_check_in", http.StatusBadRequest},
+	{domain.ErrInvalidRollupMonth, "invalid_rollup_month", http.StatusBadRequest},
+
+	// Environment errors
+	{domain.ErrInvalidEnvironmentCondition, "invalid_environment_condition", http.StatusBadRequest},
+
+	// Personal record errors
+	{domain.ErrInvalidRecordMovement, "invalid_record_movement", http.StatusBadRequest},
+	{domain.ErrInvalidRecordKind, "invalid_record_kind", http.StatusBadRequest},
+	{domain.ErrInvalidRecordSource, "invalid_record_source", http.StatusBadRequest},
+	{domain.ErrInvalidRecordDate, "invalid_record_date", http.StatusBadRequest},
+	{domain.ErrInvalidRecordValue, "invalid_record_value", http.StatusBadRequest},
+
+	// Substitution errors
+	{domain.ErrInvalidSubstitutionDate, "invalid_substitution_date", http.StatusBadRequest},
+	{domain.ErrInvalidSubstitutionMovements, "invalid_substitution_movements", http.StatusBadRequest},
+	{domain.ErrInvalidSubstitutionMuscle, "invalid_substitution_muscle", http.StatusBadRequest},
+
+	// Logging lock errors
+	{domain.ErrDayLocked, "day_locked", http.StatusConflict},
+	{domain.ErrDayNotLocked, "day_not_locked", http.StatusConflict},
+	{domain.ErrInvalidRetroEditReason, "invalid_retro_edit_reason", http.StatusBadRequest},
+
+	// Note sync errors
+	{domain.ErrInvalidNoteResolution, "invalid_note_resolution", http.StatusBadRequest},
+	{domain.ErrNoteConflictResolved, "note_conflict_resolved", http.StatusConflict},
+
+	// Archetype inference errors
+	{domain.ErrInvalidArchetypeConfidence, "invalid_archetype_confidence", http.StatusBadRequest},
+	{domain.ErrArchetypeRequired, "archetype_required", http.StatusBadRequest},
+	{domain.ErrArchetypeReviewResolved, "archetype_review_resolved", http.StatusConflict},
+
+	// Strength rotation errors
+	{domain.ErrInvalidStrengthSplit, "invalid_strength_split", http.StatusBadRequest},
+	{domain.ErrArchetypeNotInSplit, "archetype_not_in_split", http.StatusBadRequest},
+	{domain.ErrInvalidMonthRange, "invalid_month_range", http.StatusBadRequest},
+	{domain.ErrInvalidAbandonReason, "invalid_abandon_reason", http.StatusBadRequest},
+	{domain.ErrAbandonNoteTooLong, "abandon_note_too_long", http.StatusBadRequest},
+	{domain.ErrPlanNotCompletable, "plan_not_completable", http.StatusConflict},
+
+	// Recovery activity errors
+	{domain.ErrInvalidRecoveryActivityType, "invalid_recovery_activity_type", http.StatusBadRequest},
+	{domain.ErrInvalidRecoveryActivityDuration, "invalid_recovery_activity_duration", http.StatusBadRequest},
+	{domain.ErrInvalidRecoveryActivityIntensity, "invalid_recovery_activity_intensity", http.StatusBadRequest},
+
+	// Habit errors
+	{domain.ErrInvalidHabitName, "invalid_habit_name", http.StatusBadRequest},
+	{domain.ErrHabitArchived, "habit_archived", http.StatusConflict},
+
+	// Challenge errors
+	{domain.ErrInvalidChallengeType, "invalid_challenge_type", http.StatusBadRequest},
+	{domain.ErrInvalidChallengeDuration, "invalid_challenge_duration", http.StatusBadRequest},
+	{domain.ErrInvalidChallengeMisses, "invalid_challenge_misses", http.StatusBadRequest},
+	{domain.ErrInvalidChallengeName, "invalid_challenge_name", http.StatusBadRequest},
+	{domain.ErrChallengeNotActive, "challenge_not_active", http.StatusConflict},
+
+	// Injury risk errors
+	{domain.ErrInvalidInjuryRiskMonth, "invalid_injury_risk_month", http.StatusBadRequest},
+
+	// Wearable sync errors
+	{domain.ErrInvalidWearableSource, "invalid_wearable_source", http.StatusBadRequest},
+	{domain.ErrEmptyWearablePayload, "empty_wearable_payload", http.StatusBadRequest},
+	{domain.ErrTooManyWearableRecords, "too_many_wearable_records", http.StatusBadRequest},
+	{domain.ErrInvalidWearableMetric, "invalid_wearable_metric", http.StatusBadRequest},
+	{domain.ErrInvalidWearableActivity, "invalid_wearable_activity", http.StatusBadRequest},
+
+	// Export errors
+	{domain.ErrInvalidExportFormat, "invalid_export_format", http.StatusBadRequest},
+
+	// Restore errors
+	{domain.ErrInvalidExportArchive, "invalid_export_archive", http.StatusBadRequest},
+	{domain.ErrUnsupportedExportVersion, "unsupported_export_version", http.StatusBadRequest},
+	{domain.ErrInvalidRestoreTable, "invalid_restore_table", http.StatusBadRequest},
+	{domain.ErrInvalidRestoreStrategy, "invalid_restore_strategy", http.StatusBadRequest},
+
+	// Onboarding errors
+	{domain.ErrOnboardingStepOutOfOrder, "onboarding_step_out_of_order", http.StatusConflict},
+	{domain.ErrOnboardingComplete, "onboarding_complete", http.StatusConflict},
+	{domain.ErrOnboardingTargetDirection, "onboarding_target_direction", http.StatusBadRequest},
+	{domain.ErrOnboardingWeeklyChangeDirection, "onboarding_weekly_change_direction", http.StatusBadRequest},
+
+	// Signed API request errors
+	{domain.ErrAPIRequestUnsigned, "api_request_unsigned", http.StatusUnauthorized},
+	{domain.ErrAPIRequestBadSignature, "api_request_bad_signature", http.StatusUnauthorized},
+	{domain.ErrAPIRequestStaleTimestamp, "api_request_stale_timestamp", http.StatusUnauthorized},
+	{domain.ErrAPIRequestInvalidNonce, "api_request_invalid_nonce", http.StatusUnauthorized},
+	{domain.ErrAPIRequestReplayed, "api_request_replayed", http.StatusUnauthorized},
+
+	// Store errors
+	{store.ErrDayExemptionNotFound, "day_exemption_not_found", http.StatusNotFound},
+	{store.ErrAPITokenNotFound, "api_token_not_found", http.StatusNotFound},
+	{store.ErrDailyLogNotFound, "daily_log_not_found", http.StatusNotFound},
+	{store.ErrDailyLogAlreadyExists, "daily_log_already_exists", http.StatusConflict},
+	{store.ErrInsufficientData, "insufficient_data", http.StatusBadRequest},
+	{store.ErrWeightRequired, "weight_required", http.StatusBadRequest},
+	{store.ErrArchetypeNotFound, "archetype_not_found", http.StatusNotFound},
+	{store.ErrMuscleGroupNotFound, "muscle_group_not_found", http.StatusNotFound},
+	{store.ErrFoodReferenceNotFound, "food_reference_not_found", http.StatusNotFound},
+	{store.ErrFoodPriceNotFound, "food_price_not_found", http.StatusNotFound},
+	{store.ErrGroceryListNotFound, "grocery_list_not_found", http.StatusNotFound},
+	{store.ErrGroceryItemNotFound, "grocery_item_not_found", http.StatusNotFound},
+	{store.ErrCaffeineEntryNotFound, "caffeine_entry_not_found", http.StatusNotFound},
+	{store.ErrRecoveryActivityNotFound, "recovery_activity_not_found", http.StatusNotFound},
+	{store.ErrHabitNotFound, "habit_not_found", http.StatusNotFound},
+	{store.ErrHabitExists, "habit_exists", http.StatusConflict},
+	{store.ErrChallengeNotFound, "challenge_not_found", http.StatusNotFound},
+	{store.ErrVoiceClarificationNotFound, "voice_clarification_not_found", http.StatusNotFound},
+	{store.ErrPersonalRecordNotFound, "personal_record_not_found", http.StatusNotFound},
+	{store.ErrMealTemplateNotFound, "meal_template_not_found", http.StatusNotFound},
+	{store.ErrMealItemNotFound, "meal_item_not_found", http.StatusNotFound},
+	{store.ErrMealTemplateExists, "meal_template_exists", http.StatusConflict},
+	{store.ErrMetabolicHistoryNotFound, "metabolic_history_not_found", http.StatusNotFound},
+	{store.ErrMetabolicAdaptationNotFound, "metabolic_adaptation_not_found", http.StatusNotFound},
+	{store.ErrMovementNotFound, "movement_not_found", http.StatusNotFound},
+	{store.ErrImportedFoodEntryNotFound, "imported_food_entry_not_found", http.StatusNotFound},
+	{store.ErrPlanNotFound, "plan_not_found", http.StatusNotFound},
+	{store.ErrPlanRetrospectiveNotFound, "plan_retrospective_not_found", http.StatusNotFound},
+	{store.ErrActivePlanExists, "active_plan_exists", http.StatusConflict},
+	{store.ErrPlannedDayTypeNotFound, "planned_day_type_not_found", http.StatusNotFound},
+	{store.ErrPlannerSessionNotFound, "planner_session_not_found", http.StatusNotFound},
+	{store.ErrProfileNotFound, "profile_not_found", http.StatusNotFound},
+	{store.ErrProgramNotFound, "program_not_found", http.StatusNotFound},
+	{store.ErrActiveInstallationExists, "active_installation_exists", http.StatusConflict},
+	{store.ErrInstallationNotFound, "installation_not_found", http.StatusNotFound},
+	{store.ErrSessionTemplateNotFound, "session_template_not_found", http.StatusNotFound},
+	{store.ErrSessionTemplateExists, "session_template_exists", http.StatusConflict},
+	{store.ErrNoteConflictNotFound, "note_conflict_not_found", http.StatusNotFound},
+	{store.ErrArchetypeReviewNotFound, "archetype_review_not_found", http.StatusNotFound},
+
+	// Service errors
+	{service.ErrInvalidAPIToken, "invalid_api_token", http.StatusUnauthorized},
+}
+
+// lookupError finds the catalog entry for err, following wrapped errors.
+func lookupError(err error) (errorCatalogEntry, bool) {
+	for _, entry := range errorCatalog {
+		if errors.Is(err, entry.err) {
+			return entry, true
+		}
+	}
+	return errorCatalogEntry{}, false
+}
+
+// errorCategory is the coarse error field for a catalog status, kept for
+// clients that predate codes.
+func errorCategory(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	default:
+		return "validation_error"
+	}
+}
+
+// writeDomainError writes a cataloged error with its status, code and message
+// key. Uncataloged validation errors fall back to a plain 400 and anything
+// else is an internal error.
+func writeDomainError(w http.ResponseWriter, err error, context string) {
+	entry, ok := lookupError(err)
+	if !ok {
+		if isValidationError(err) {
+			writeDomainError(w, err, "writeDomainError")
+			return
+		}
+		writeInternalError(w, err, context)
+		return
+	}
+
+	category := errorCategory(entry.status)
+	if entry.status != http.StatusNotFound {
+		log.Printf("ERROR %d %s/%s: %s", entry.status, category, entry.code, err.Error())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(entry.status)
+	json.NewEncoder(w).Encode(APIError{
+		Error:      category,
+		Code:       entry.code,
+		MessageKey: entry.messageKey(),
+		Message:    err.Error(),
+	})
+}
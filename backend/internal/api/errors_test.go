@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Error codes are an API contract; these tests keep the catalog
+// complete as domain errors are added and its codes unambiguous.
+type ErrorCatalogSuite struct {
+	suite.Suite
+}
+
+func TestErrorCatalogSuite(t *testing.T) {
+	suite.Run(t, new(ErrorCatalogSuite))
+}
+
+// sentinelNames returns the Err* identifiers declared at package level in file.
+func (s *ErrorCatalogSuite) sentinelNames(file string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	s.Require().NoError(err)
+
+	var names []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if len(name.Name) > 3 && name.Name[:3] == "Err" {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// catalogedDomainNames returns the domain.Err* selectors used in errors.go.
+func (s *ErrorCatalogSuite) catalogedDomainNames() map[string]bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	s.Require().NoError(err)
+
+	names := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "domain" {
+				names[sel.Sel.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+func (s *ErrorCatalogSuite) TestEveryDomainErrorIsCataloged() {
+	cataloged := s.catalogedDomainNames()
+	for _, file := range []string{"../domain/errors.go", "../domain/apitoken.go"} {
+		for _, name := range s.sentinelNames(file) {
+			s.True(cataloged[name], "domain.%s has no catalog entry", name)
+		}
+	}
+}
+
+func (s *ErrorCatalogSuite) TestSharedCodesMeanTheSameThing() {
+	byCode := make(map[string]errorCatalogEntry)
+	for _, entry := range errorCatalog {
+		if prev, ok := byCode[entry.code]; ok {
+			s.Equal(prev.err.Error(), entry.err.Error(), "code %q is used for different errors", entry.code)
+			s.Equal(prev.status, entry.status, "code %q has different statuses", entry.code)
+		}
+		byCode[entry.code] = entry
+	}
+}
+
+func (s *ErrorCatalogSuite) TestWriteDomainErrorFollowsWrappedErrors() {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("analyze plan 7: %w", domain.ErrPlanNotFound), "test")
+
+	s.Equal(http.StatusNotFound, rec.Code)
+	var resp APIError
+	s.Require().NoError(json.NewDecoder(rec.Body).Decode(&resp))
+	s.Equal("not_found", resp.Error)
+	s.Equal("plan_not_found", resp.Code)
+	s.Equal("errors.plan_not_found", resp.MessageKey)
+}
+
+func (s *ErrorCatalogSuite) TestValidationErrorsKeepTheirCategory() {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, domain.ErrInvalidHeight, "test")
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+	var resp APIError
+	s.Require().NoError(json.NewDecoder(rec.Body).Decode(&resp))
+	s.Equal("validation_error", resp.Error)
+	s.Equal("invalid_height", resp.Code)
+	s.Equal(domain.ErrInvalidHeight.Error(), resp.Message)
+}
+
+func (s *ErrorCatalogSuite) TestUncatalogedErrorIsInternal() {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, errors.New("connection reset"), "test")
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// EventCountdownResponse is the event section of a plan analysis.
+type EventCountdownResponse struct {
+	EventName        string                    `json:"eventName,omitempty"`
+	EventDate        string                    `json:"eventDate"`
+	DaysToEvent      int                       `json:"daysToEvent"`
+	Urgency          string                    `json:"urgency"`          // low, moderate, high, critical
+	TolerancePercent float64                   `json:"tolerancePercent"` // Tightened for urgency
+	Warnings         []WaterCutWarningResponse `json:"warnings,omitempty"`
+}
+
+// WaterCutWarningResponse flags a final-week water cut.
+type WaterCutWarningResponse struct {
+	Severity           string  `json:"severity"` // info, caution, danger
+	RequiredCutKg      float64 `json:"requiredCutKg"`
+	RequiredCutPercent float64 `json:"requiredCutPercent"`
+	Message            string  `json:"message"`
+}
+
+// EventProjectionResponse is the response body for the event projection endpoints.
+type EventProjectionResponse struct {
+	PlanID                 int64                     `json:"planId"`
+	EventName              string                    `json:"eventName,omitempty"`
+	EventDate              string                    `json:"eventDate"`
+	DaysToEvent            int                       `json:"daysToEvent"`
+	Urgency                string                    `json:"urgency"`
+	CurrentWeightKg        float64                   `json:"currentWeightKg"`
+	GoalWeightKg           float64                   `json:"goalWeightKg"`
+	ProjectedWeightKg      float64                   `json:"projectedWeightKg"`
+	WeeklyChangeKg         float64                   `json:"weeklyChangeKg"`
+	RequiredWeeklyChangeKg float64                   `json:"requiredWeeklyChangeKg"`
+	UncertaintyKg          float64                   `json:"uncertaintyKg"`
+	MakeWeightProbability  float64                   `json:"makeWeightProbability"` // 0-1
+	Samples                int                       `json:"samples"`
+	Points                 []ProjectionPointResponse `json:"points"`
+	Warnings               []WaterCutWarningResponse `json:"warnings,omitempty"`
+}
+
+// getEventProjection handles GET /api/plans/{id}/event-projection
+// Projects the weight trend to the event date with the probability of making
+// weight. Optional ?date=YYYY-MM-DD projects as of that day.
+func (s *Server) getEventProjection(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Plan ID must be a number")
+		return
+	}
+	asOf, ok := projectionDate(w, r, s.now())
+	if !ok {
+		return
+	}
+
+	projection, err := s.analysisService.ProjectEvent(r.Context(), id, asOf)
+	if err != nil {
+		writeDomainError(w, err, "getEventProjection")
+		return
+	}
+	writeJSON(w, http.StatusOK, eventProjectionToResponse(projection))
+}
+
+// getActiveEventProjection handles GET /api/plans/active/event-projection
+func (s *Server) getActiveEventProjection(w http.ResponseWriter, r *http.Request) {
+	asOf, ok := projectionDate(w, r, s.now())
+	if !ok {
+		return
+	}
+
+	projection, err := s.analysisService.ProjectActiveEvent(r.Context(), asOf)
+	if err != nil {
+		writeDomainError(w, err, "getActiveEventProjection")
+		return
+	}
+	writeJSON(w, http.StatusOK, eventProjectionToResponse(projection))
+}
+
+// projectionDate parses the optional date query parameter, defaulting to now.
+// Writes a 400 and returns false when the date is malformed.
+func projectionDate(w http.ResponseWriter, r *http.Request, now time.Time) (time.Time, bool) {
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		return now, true
+	}
+	parsed, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "Date must be in YYYY-MM-DD format")
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// eventProjectionToResponse converts a domain event projection to its API response.
+func eventProjectionToResponse(p *domain.EventProjection) EventProjectionResponse {
+	resp := EventProjectionResponse{
+		PlanID:                 p.PlanID,
+		EventName:              p.EventName,
+		EventDate:              p.EventDate.Format("2006-01-02"),
+		DaysToEvent:            p.DaysToEvent,
+		Urgency:                string(p.Urgency),
+		CurrentWeightKg:        p.CurrentWeightKg,
+		GoalWeightKg:           p.GoalWeightKg,
+		ProjectedWeightKg:      p.ProjectedWeightKg,
+		WeeklyChangeKg:         p.WeeklyChangeKg,
+		RequiredWeeklyChangeKg: p.RequiredWeeklyChangeKg,
+		UncertaintyKg:          p.UncertaintyKg,
+		MakeWeightProbability:  p.MakeWeightProbability,
+		Samples:                p.Samples,
+		Points:                 make([]ProjectionPointResponse, len(p.Points)),
+		Warnings:               waterCutWarningsToResponse(p.Warnings),
+	}
+	for i, point := range p.Points {
+		resp.Points[i] = ProjectionPointResponse{
+			WeekNumber: point.WeekNumber,
+			Date:       point.Date.Format("2006-01-02"),
+			WeightKg:   point.WeightKg,
+		}
+	}
+	return resp
+}
+
+// waterCutWarningsToResponse converts water-cut warnings, nil when there are none.
+func waterCutWarningsToResponse(warnings []domain.WaterCutWarning) []WaterCutWarningResponse {
+	if len(warnings) == 0 {
+		return nil
+	}
+	resp := make([]WaterCutWarningResponse, len(warnings))
+	for i, warning := range warnings {
+		resp[i] = WaterCutWarningResponse{
+			Severity:           string(warning.Severity),
+			RequiredCutKg:      warning.RequiredCutKg,
+			RequiredCutPercent: warning.RequiredCutPercent,
+			Message:            warning.Message,
+		}
+	}
+	return resp
+}
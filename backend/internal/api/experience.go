@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"victus/internal/store"
+)
+
+// getTrainingExperience handles GET /api/profile/training-experience
+// Returns the experience level derived from the training log (training age
+// and progression velocity), with its default RPE target.
+func (s *Server) getTrainingExperience(w http.ResponseWriter, r *http.Request) {
+	exp, err := s.experienceService.Get(r.Context())
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusNotFound, "profile_not_found", "A user profile is required to derive training experience")
+			return
+		}
+		writeInternalError(w, err, "getTrainingExperience")
+		return
+	}
+	writeJSON(w, http.StatusOK, exp)
+}
+
+// experienceDefaultRPE returns the RPE target for sessions planned without
+// one, or nil when no experience model is available.
+func (s *Server) experienceDefaultRPE(ctx context.Context) *int {
+	exp, err := s.experienceService.Get(ctx)
+	if err != nil {
+		if !errors.Is(err, store.ErrProfileNotFound) {
+			log.Printf("training experience unavailable for default RPE: %v", err)
+		}
+		return nil
+	}
+	rpe := exp.DefaultRPE
+	return &rpe
+}
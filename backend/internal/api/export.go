@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"victus/internal/domain"
+)
+
+// exportHistory handles GET /api/export
+// Optional query param: ?format=json|csv (defaults to json). Streams the full
+// history as a download: one JSON document, or a ZIP of CSV files.
+func (s *Server) exportHistory(w http.ResponseWriter, r *http.Request) {
+	format, err := domain.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		writeDomainError(w, err, "exportHistory")
+		return
+	}
+
+	dw := &downloadWriter{
+		w:           w,
+		contentType: format.ContentType(),
+		filename:    s.exportService.Filename(format),
+	}
+	if err := s.exportService.Export(r.Context(), format, dw); err != nil {
+		if !dw.started {
+			writeInternalError(w, err, "exportHistory")
+			return
+		}
+		// Headers are gone; the client gets a truncated file
+		log.Printf("export interrupted after headers were sent: %v", err)
+	}
+}
+
+// Maximum restore upload size: 50MB, room for years of history
+const maxRestoreSize = 50 << 20
+
+// restoreHistory handles POST /api/import
+// Accepts multipart/form-data with:
+//   - file: a JSON export or CSV ZIP from GET /api/export (required)
+//   - conflicts: JSON object of per-table strategies, e.g.
+//     {"daily_logs":"merge","weekly_targets":"overwrite"} (optional, default skip)
+func (s *Server) restoreHistory(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRestoreSize)
+	if err := r.ParseMultipartForm(maxRestoreSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusBadRequest, "file_too_large", "Maximum upload size is 50MB")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid_form", "Failed to parse multipart form: "+err.Error())
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing_file", "No file provided in 'file' field")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "read_error", "Failed to read uploaded file")
+		return
+	}
+
+	var raw map[string]string
+	if conflicts := r.FormValue("conflicts"); conflicts != "" {
+		if err := json.Unmarshal([]byte(conflicts), &raw); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_form", "conflicts must be a JSON object of table to strategy")
+			return
+		}
+	}
+	strategies, err := domain.ParseRestoreStrategies(raw)
+	if err != nil {
+		writeDomainError(w, err, "restoreHistory")
+		return
+	}
+
+	result, err := s.restoreService.Restore(r.Context(), data, strategies)
+	if err != nil {
+		writeDomainError(w, err, "restoreHistory")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// downloadWriter sends attachment headers with the first write, so a failure
+// before any output can still be answered with an error response.
+type downloadWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.w.Header().Set("Content-Type", d.contentType)
+		d.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, d.filename))
+		d.w.WriteHeader(http.StatusOK)
+	}
+	return d.w.Write(p)
+}
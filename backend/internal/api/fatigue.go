@@ -4,20 +4,22 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"victus/internal/domain"
 )
 
 // MuscleFatigueResponse represents a muscle's fatigue state in API responses.
 type MuscleFatigueResponse struct {
-	MuscleGroupID  int     `json:"muscleGroupId"`
-	Muscle         string  `json:"muscle"`
-	DisplayName    string  `json:"displayName"`
-	FatiguePercent float64 `json:"fatiguePercent"`
-	Status         string  `json:"status"`
-	Color          string  `json:"color"`
-	LastUpdated    string  `json:"lastUpdated,omitempty"`
+	MuscleGroupID  int                 `json:"muscleGroupId"`
+	Muscle         string              `json:"muscle"`
+	DisplayName    string              `json:"displayName"`
+	FatiguePercent float64             `json:"fatiguePercent"`
+	Status         string              `json:"status"`
+	Color          string              `json:"color"`
+	LastUpdated    string              `json:"lastUpdated,omitempty"`
+	Sides          *domain.SideFatigue `json:"sides,omitempty"`          // Limb muscles whose sides differ
+	OverloadedSide string              `json:"overloadedSide,omitempty"` // left/right when one side is well ahead
 }
 
 // BodyStatusResponse represents the complete body fatigue state.
@@ -29,11 +31,12 @@ type BodyStatusResponse struct {
 
 // FatigueInjectionResponse represents fatigue added to a muscle.
 type FatigueInjectionResponse struct {
-	Muscle          string  `json:"muscle"`
-	DisplayName     string  `json:"displayName"`
-	InjectedPercent float64 `json:"injectedPercent"`
-	NewTotal        float64 `json:"newTotal"`
-	Status          string  `json:"status"`
+	Muscle          string              `json:"muscle"`
+	DisplayName     string              `json:"displayName"`
+	InjectedPercent float64             `json:"injectedPercent"`
+	NewTotal        float64             `json:"newTotal"`
+	Status          string              `json:"status"`
+	NewSides        *domain.SideFatigue `json:"newSides,omitempty"`
 }
 
 // SessionFatigueReportResponse represents the fatigue report after a workout.
@@ -58,16 +61,18 @@ type ApplyLoadRequest struct {
 	Archetype   string `json:"archetype"`
 	DurationMin int    `json:"durationMin"`
 	RPE         *int   `json:"rpe,omitempty"`
+	Side        string `json:"side,omitempty"` // left/right for a one-sided session
 }
 
 // ApplyMuscleFatigueRequest represents pre-computed per-muscle fatigue injections.
 type ApplyMuscleFatigueRequest struct {
-	Muscles map[string]float64 `json:"muscles"` // muscle_name -> fatigue percent to inject
+	Muscles   map[string]float64 `json:"muscles"`             // muscle_name -> fatigue percent to inject
+	LeftShare map[string]float64 `json:"leftShare,omitempty"` // muscle_name -> left side's share (0-1) from unilateral movements
 }
 
 // getBodyStatus handles GET /api/body-status
 func (s *Server) getBodyStatus(w http.ResponseWriter, r *http.Request) {
-	status, err := s.fatigueService.GetBodyStatus(r.Context(), time.Now())
+	status, err := s.fatigueService.GetBodyStatus(r.Context(), s.now())
 	if err != nil {
 		writeInternalError(w, err, "getBodyStatus")
 		return
@@ -79,6 +84,76 @@ func (s *Server) getBodyStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// getBodyStatusToday handles GET /api/body-status/today
+// Returns fatigue, active issues and readiness aggregated into joint
+// integrity and a 0-10 systemic load.
+func (s *Server) getBodyStatusToday(w http.ResponseWriter, r *http.Request) {
+	status, err := s.bodyStatusService.GetToday(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getBodyStatusToday")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// getJointIntegritySeries handles GET /api/body-status/joints?days=N
+// Returns the daily joint integrity series (default 14 days, max 90).
+func (s *Server) getJointIntegritySeries(w http.ResponseWriter, r *http.Request) {
+	days := 14
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 90 {
+			writeError(w, http.StatusBadRequest, "invalid_days", "days must be between 1 and 90")
+			return
+		}
+		days = n
+	}
+
+	series, err := s.jointIntegrityService.GetSeries(r.Context(), days)
+	if err != nil {
+		writeInternalError(w, err, "getJointIntegritySeries")
+		return
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// getFatigueHeatmap handles GET /api/fatigue/heatmap
+// Optional query param: ?compare=Nd (default 7d, max 90d)
+func (s *Server) getFatigueHeatmap(w http.ResponseWriter, r *http.Request) {
+	compareParam := r.URL.Query().Get("compare")
+	if compareParam == "" {
+		compareParam = "7d"
+	}
+
+	compareDays, ok := parseCompareDays(compareParam)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_compare", "Compare must be a day window like 7d (1-90 days)")
+		return
+	}
+
+	heatmap, err := s.fatigueService.GetFatigueHeatmap(r.Context(), s.now(), compareDays)
+	if err != nil {
+		writeInternalError(w, err, "getFatigueHeatmap")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(heatmap)
+}
+
+// parseCompareDays parses a day window such as "7d" into a day count.
+func parseCompareDays(param string) (int, bool) {
+	numStr, found := strings.CutSuffix(param, "d")
+	if !found {
+		return 0, false
+	}
+	days, err := strconv.Atoi(numStr)
+	if err != nil || days < 1 || days > domain.MaxHeatmapCompareDays {
+		return 0, false
+	}
+	return days, true
+}
+
 // getArchetypes handles GET /api/archetypes
 func (s *Server) getArchetypes(w http.ResponseWriter, r *http.Request) {
 	archetypes, err := s.fatigueService.GetAllArchetypes(r.Context())
@@ -125,8 +200,17 @@ func (s *Server) applyFatigueByParams(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate side if provided
+	var side domain.BodySide
+	if req.Side != "" {
+		if side, err = domain.ParseBodySide(req.Side); err != nil {
+			writeDomainError(w, err, "applyFatigueByParams")
+			return
+		}
+	}
+
 	// Apply the load
-	report, err := s.fatigueService.ApplyLoadByParams(r.Context(), archetype, req.DurationMin, req.RPE)
+	report, err := s.fatigueService.ApplyLoadByParams(r.Context(), archetype, req.DurationMin, req.RPE, side)
 	if err != nil {
 		writeInternalError(w, err, "applyFatigueByParams")
 		return
@@ -168,7 +252,24 @@ func (s *Server) applyMuscleFatigue(w http.ResponseWriter, r *http.Request) {
 		muscles[mg] = pct
 	}
 
-	report, err := s.fatigueService.ApplyMuscleFatigue(r.Context(), muscles)
+	var split domain.SideSplit
+	if len(req.LeftShare) > 0 {
+		split = make(domain.SideSplit, len(req.LeftShare))
+		for name, share := range req.LeftShare {
+			mg, err := domain.ParseMuscleGroup(name)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid_muscle", "Unknown muscle group: "+name)
+				return
+			}
+			split[mg] = share
+		}
+		if err := split.Validate(); err != nil {
+			writeDomainError(w, err, "applyMuscleFatigue")
+			return
+		}
+	}
+
+	report, err := s.fatigueService.ApplyMuscleFatigue(r.Context(), muscles, split)
 	if err != nil {
 		writeInternalError(w, err, "applyMuscleFatigue")
 		return
@@ -243,6 +344,8 @@ func toBodyStatusResponse(status *domain.BodyStatus) BodyStatusResponse {
 			Status:         string(m.Status),
 			Color:          m.Color,
 			LastUpdated:    m.LastUpdated,
+			Sides:          m.Sides,
+			OverloadedSide: string(m.OverloadedSide),
 		}
 	}
 
@@ -276,6 +379,7 @@ func toSessionFatigueReportResponse(report *domain.SessionFatigueReport) Session
 			InjectedPercent: inj.InjectedPercent,
 			NewTotal:        inj.NewTotal,
 			Status:          string(inj.Status),
+			NewSides:        inj.NewSides,
 		}
 	}
 
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+	"victus/internal/service"
+)
+
+// SetFoodPriceRequest is the body of PUT /api/food-prices/{foodId}.
+type SetFoodPriceRequest struct {
+	Amount float64 `json:"amount"`
+	Basis  string  `json:"basis"` // "serving" or "100g"
+}
+
+// EstimateFoodCostRequest is the body of POST /api/food-prices/estimate.
+type EstimateFoodCostRequest struct {
+	Items []ScoreIngredientRequest `json:"items"`
+}
+
+// foodIDParam parses the {foodId} path value.
+func foodIDParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("foodId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "foodId must be a valid integer")
+		return 0, false
+	}
+	return id, true
+}
+
+// listFoodPrices handles GET /api/food-prices
+func (s *Server) listFoodPrices(w http.ResponseWriter, r *http.Request) {
+	prices, err := s.foodCostService.ListPrices(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listFoodPrices")
+		return
+	}
+	writeJSON(w, http.StatusOK, prices)
+}
+
+// setFoodPrice handles PUT /api/food-prices/{foodId}
+// Sets the food's price per standard serving or per 100g.
+func (s *Server) setFoodPrice(w http.ResponseWriter, r *http.Request) {
+	foodID, ok := foodIDParam(w, r)
+	if !ok {
+		return
+	}
+	var req SetFoodPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	price, err := s.foodCostService.SetPrice(r.Context(), domain.FoodPrice{
+		FoodReferenceID: foodID,
+		Amount:          req.Amount,
+		Basis:           domain.PriceBasis(req.Basis),
+	})
+	if err != nil {
+		writeDomainError(w, err, "setFoodPrice")
+		return
+	}
+	writeJSON(w, http.StatusOK, price)
+}
+
+// deleteFoodPrice handles DELETE /api/food-prices/{foodId}
+func (s *Server) deleteFoodPrice(w http.ResponseWriter, r *http.Request) {
+	foodID, ok := foodIDParam(w, r)
+	if !ok {
+		return
+	}
+	if err := s.foodCostService.DeletePrice(r.Context(), foodID); err != nil {
+		writeDomainError(w, err, "deleteFoodPrice")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// estimateFoodCost handles POST /api/food-prices/estimate
+// Prices a grocery list or meal; items without a price are listed with a null cost.
+func (s *Server) estimateFoodCost(w http.ResponseWriter, r *http.Request) {
+	var req EstimateFoodCostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	items := make([]service.CostItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.CostItem{FoodID: item.FoodID, AmountG: item.AmountG})
+	}
+	estimate, err := s.foodCostService.Estimate(r.Context(), items)
+	if err != nil {
+		writeDomainError(w, err, "estimateFoodCost")
+		return
+	}
+	writeJSON(w, http.StatusOK, estimate)
+}
+
+// getWeeklyFoodCosts handles GET /api/food-prices/weekly?weeks=12
+// Returns the estimated cost of logged food per week, oldest first.
+func (s *Server) getWeeklyFoodCosts(w http.ResponseWriter, r *http.Request) {
+	weeks := domain.FoodCostTrendDefaultWeeks
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeDomainError(w, domain.ErrInvalidCostWeeks, "getWeeklyFoodCosts")
+			return
+		}
+		weeks = n
+	}
+
+	trend, err := s.foodCostService.WeeklyTrend(r.Context(), weeks)
+	if err != nil {
+		writeDomainError(w, err, "getWeeklyFoodCosts")
+		return
+	}
+	writeJSON(w, http.StatusOK, trend)
+}
@@ -2,8 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"victus/internal/domain"
+	"victus/internal/store"
 )
 
 // FoodReferenceResponse represents a food reference item in API responses.
@@ -75,3 +80,104 @@ func (s *Server) updateFoodReference(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ConfirmFoodMatchRequest is the request body for confirming or correcting a food match.
+type ConfirmFoodMatchRequest struct {
+	Query           string `json:"query"`
+	FoodReferenceID int64  `json:"foodReferenceId"`
+}
+
+// matchFood handles GET /api/food-reference/match?q=greek+yoghurt
+// Returns the best match, ranked candidates, and whether the user should confirm.
+func (s *Server) matchFood(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if strings.TrimSpace(query) == "" {
+		writeError(w, http.StatusBadRequest, "missing_query", "q query parameter is required")
+		return
+	}
+
+	result, err := s.foodMatchService.Match(r.Context(), query)
+	if err != nil {
+		writeInternalError(w, err, "matchFood")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// confirmFoodMatch handles POST /api/food-reference/match/confirm
+// Records the chosen food for a name so future matches resolve it directly.
+func (s *Server) confirmFoodMatch(w http.ResponseWriter, r *http.Request) {
+	var req ConfirmFoodMatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	if req.FoodReferenceID <= 0 {
+		writeError(w, http.StatusBadRequest, "validation_error", "foodReferenceId is required")
+		return
+	}
+
+	synonym, err := s.foodMatchService.Confirm(r.Context(), req.Query, req.FoodReferenceID)
+	if err != nil {
+		if domain.IsValidationError(err) {
+			writeDomainError(w, err, "confirmFoodMatch")
+			return
+		}
+		if errors.Is(err, store.ErrFoodReferenceNotFound) {
+			writeError(w, http.StatusBadRequest, "validation_error", "Unknown foodReferenceId")
+			return
+		}
+		writeInternalError(w, err, "confirmFoodMatch")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(synonym)
+}
+
+// listFoodSynonyms handles GET /api/food-reference/synonyms
+func (s *Server) listFoodSynonyms(w http.ResponseWriter, r *http.Request) {
+	synonyms, err := s.foodMatchService.ListSynonyms(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listFoodSynonyms")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(synonyms)
+}
+
+// getDefaultPortion handles GET /api/food-reference/{id}/portion?meal=breakfast
+// Returns the learned default portion used when the food is logged without a quantity.
+func (s *Server) getDefaultPortion(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	var meal *domain.MealName
+	if m := r.URL.Query().Get("meal"); m != "" {
+		name := domain.MealName(m)
+		if !domain.ValidMealNames[name] {
+			writeError(w, http.StatusBadRequest, "validation_error", "meal must be breakfast, lunch, or dinner")
+			return
+		}
+		meal = &name
+	}
+
+	portion, err := s.foodMatchService.DefaultPortionByID(r.Context(), id, meal)
+	if err != nil {
+		if errors.Is(err, store.ErrFoodReferenceNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Food not found")
+			return
+		}
+		writeInternalError(w, err, "getDefaultPortion")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(portion)
+}
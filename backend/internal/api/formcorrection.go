@@ -25,6 +25,17 @@ func (s *Server) analyzeFormCorrection(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Stalled progressions get a concrete regression from the library when the model offers none
+	if req.MovementID != "" {
+		stalled, regression, err := s.movementService.GetStallRegression(r.Context(), req.MovementID)
+		if err == nil && stalled {
+			result.StalledProgression = true
+			if result.Regression == nil && regression != nil {
+				result.Regression = &regression.Name
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
 }
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// CreateGroceryListRequest is the body of POST /api/grocery-lists.
+type CreateGroceryListRequest struct {
+	WeekStart string                   `json:"weekStart"` // YYYY-MM-DD
+	Items     []ScoreIngredientRequest `json:"items"`
+}
+
+// SetGroceryItemPurchasedRequest is the body of PATCH /api/grocery-lists/{id}/items/{itemId}.
+type SetGroceryItemPurchasedRequest struct {
+	Purchased   bool   `json:"purchased"`
+	PurchasedOn string `json:"purchasedOn,omitempty"` // YYYY-MM-DD, defaults to today
+}
+
+// listGroceryLists handles GET /api/grocery-lists
+func (s *Server) listGroceryLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := s.foodCostService.ListGroceryLists(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listGroceryLists")
+		return
+	}
+	writeJSON(w, http.StatusOK, lists)
+}
+
+// createGroceryList handles POST /api/grocery-lists
+// Saves a grocery list (typically the items priced through /api/food-prices/estimate).
+func (s *Server) createGroceryList(w http.ResponseWriter, r *http.Request) {
+	var req CreateGroceryListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	list := domain.GroceryList{WeekStart: req.WeekStart, Items: make([]domain.GroceryItem, 0, len(req.Items))}
+	for _, item := range req.Items {
+		list.Items = append(list.Items, domain.GroceryItem{FoodReferenceID: item.FoodID, AmountG: item.AmountG})
+	}
+	created, err := s.foodCostService.CreateGroceryList(r.Context(), list)
+	if err != nil {
+		writeDomainError(w, err, "createGroceryList")
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// setGroceryItemPurchased handles PATCH /api/grocery-lists/{id}/items/{itemId}
+// Marks an item bought (or not) and returns the updated list.
+func (s *Server) setGroceryItemPurchased(w http.ResponseWriter, r *http.Request) {
+	listID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+	itemID, err := strconv.ParseInt(r.PathValue("itemId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "itemId must be a valid integer")
+		return
+	}
+	var req SetGroceryItemPurchasedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	list, err := s.foodCostService.SetGroceryItemPurchased(r.Context(), listID, itemID, req.Purchased, req.PurchasedOn)
+	if err != nil {
+		writeDomainError(w, err, "setGroceryItemPurchased")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// deleteGroceryList handles DELETE /api/grocery-lists/{id}
+func (s *Server) deleteGroceryList(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+	if err := s.foodCostService.DeleteGroceryList(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deleteGroceryList")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getShoppingRollup handles GET /api/stats/shopping?month=YYYY-MM
+// Defaults to the current month.
+func (s *Server) getShoppingRollup(w http.ResponseWriter, r *http.Request) {
+	rollup, err := s.foodCostService.GetShoppingRollup(r.Context(), r.URL.Query().Get("month"))
+	if err != nil {
+		writeDomainError(w, err, "getShoppingRollup")
+		return
+	}
+	writeJSON(w, http.StatusOK, rollup)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// HabitRequest is the body of POST /api/habits and PUT /api/habits/{id}.
+type HabitRequest struct {
+	Name            string `json:"name"`
+	CountInVitality bool   `json:"countInVitality"`
+	Archived        bool   `json:"archived,omitempty"` // Only on update; new habits start active
+}
+
+// SetChecklistItemRequest is the body of PUT /api/logs/{date}/checklist/{habitId}.
+type SetChecklistItemRequest struct {
+	Done bool `json:"done"`
+}
+
+// listHabits handles GET /api/habits
+func (s *Server) listHabits(w http.ResponseWriter, r *http.Request) {
+	habits, err := s.habitService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listHabits")
+		return
+	}
+	writeJSON(w, http.StatusOK, habits)
+}
+
+// createHabit handles POST /api/habits
+func (s *Server) createHabit(w http.ResponseWriter, r *http.Request) {
+	var req HabitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	habit, err := s.habitService.Create(r.Context(), domain.Habit{
+		Name:            req.Name,
+		CountInVitality: req.CountInVitality,
+	})
+	if err != nil {
+		writeDomainError(w, err, "createHabit")
+		return
+	}
+	writeJSON(w, http.StatusCreated, habit)
+}
+
+// updateHabit handles PUT /api/habits/{id}
+// Renames, changes the vitality flag, or archives/restores a habit.
+func (s *Server) updateHabit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+	var req HabitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	habit, err := s.habitService.Update(r.Context(), domain.Habit{
+		ID:              id,
+		Name:            req.Name,
+		CountInVitality: req.CountInVitality,
+		Archived:        req.Archived,
+	})
+	if err != nil {
+		writeDomainError(w, err, "updateHabit")
+		return
+	}
+	writeJSON(w, http.StatusOK, habit)
+}
+
+// deleteHabit handles DELETE /api/habits/{id}
+// Removes the habit and its history; archive it instead to keep the history.
+func (s *Server) deleteHabit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.habitService.Delete(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deleteHabit")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getChecklist handles GET /api/logs/{date}/checklist
+// Lists the active habits with whether each was done on the date and its streak.
+func (s *Server) getChecklist(w http.ResponseWriter, r *http.Request) {
+	items, err := s.habitService.Checklist(r.Context(), r.PathValue("date"))
+	if err != nil {
+		writeInternalError(w, err, "getChecklist")
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// setChecklistItem handles PUT /api/logs/{date}/checklist/{habitId}
+// Checks a habit off for the date, or unchecks it.
+func (s *Server) setChecklistItem(w http.ResponseWriter, r *http.Request) {
+	habitID, err := strconv.ParseInt(r.PathValue("habitId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "habitId must be a valid integer")
+		return
+	}
+	var req SetChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	item, err := s.habitService.SetCheck(r.Context(), r.PathValue("date"), habitID, req.Done)
+	if err != nil {
+		writeDomainError(w, err, "setChecklistItem")
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
@@ -9,9 +9,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
+	"victus/internal/api/requests"
+	"victus/internal/domain"
 	"victus/internal/testutil"
 
 	"github.com/stretchr/testify/suite"
@@ -440,6 +443,36 @@ func (s *HandlerSuite) TestPlanCreationErrorMapping() {
 	})
 }
 
+func (s *HandlerSuite) TestPlanCreationDryRun() {
+	s.createProfile()
+	req := map[string]interface{}{
+		"startDate":     time.Now().Format("2006-01-02"),
+		"startWeightKg": 90,
+		"goalWeightKg":  85,
+		"durationWeeks": 10,
+	}
+
+	s.Run("dry run returns weekly targets without saving", func() {
+		rec := s.doRequest("POST", "/api/plans?dryRun=true", req)
+		s.Equal(http.StatusOK, rec.Code)
+
+		var resp requests.PlanResponse
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+		s.Len(resp.WeeklyTargets, 10)
+
+		rec = s.doRequest("GET", "/api/plans/active", nil)
+		s.Equal("null\n", rec.Body.String(), "nothing was persisted")
+	})
+
+	s.Run("dry run reports the same conflict as a real create", func() {
+		rec := s.doRequest("POST", "/api/plans", req)
+		s.Require().Equal(http.StatusCreated, rec.Code)
+
+		rec = s.doRequest("POST", "/api/plans?dryRun=true", req)
+		s.Equal(http.StatusConflict, rec.Code)
+	})
+}
+
 func (s *HandlerSuite) TestPlanNotFoundErrorMapping() {
 	s.Run("GET non-existent plan returns 404", func() {
 		rec := s.doRequest("GET", "/api/plans/99999", nil)
@@ -492,6 +525,43 @@ func (s *HandlerSuite) TestDailyLogConflictMapping() {
 	})
 }
 
+// --- Log deletion tests ---
+// Justification: Meal entries, caffeine and the other day rows are keyed by
+// date, not by log; a log recreated for the date must not inherit them.
+
+func (s *HandlerSuite) TestLogDeletionRemovesDayEntries() {
+	s.createProfile()
+	date := time.Now().Format("2006-01-02")
+	s.createDailyLogForDate(date)
+
+	var foodID int64
+	s.Require().NoError(s.db.QueryRow(
+		`INSERT INTO food_reference (category, food_item, protein_g_per_100, carbs_g_per_100, fat_g_per_100)
+		 VALUES ('high_carb', 'Oats', 13.2, 67.7, 6.5) RETURNING id`,
+	).Scan(&foodID))
+	rec := s.doRequest("POST", "/api/logs/"+date+"/meal-items", requests.MealItemRequest{FoodReferenceID: foodID, Grams: 80})
+	s.Require().Equal(http.StatusCreated, rec.Code, rec.Body.String())
+	_, err := s.db.Exec(`INSERT INTO caffeine_log (log_date, intake_time, source, mg) VALUES ($1, '08:00', 'coffee', 95)`, date)
+	s.Require().NoError(err)
+
+	rec = s.doRequest("DELETE", "/api/logs/"+date, nil)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	var deleted LogDeletionResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &deleted))
+	s.Equal(3, deleted.EntriesRemoved, "item, its portion and the caffeine entry")
+
+	s.createDailyLogForDate(date)
+	rec = s.doRequest("GET", "/api/logs/"+date+"/meal-items", nil)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.JSONEq("[]", rec.Body.String(), "the recreated log has no items")
+
+	rec = s.doRequest("GET", "/api/logs/"+date, nil)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var log requests.DailyLogResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &log))
+	s.Equal(0, log.ConsumedCalories)
+}
+
 func (s *HandlerSuite) TestRecalibrateEndpoint() {
 	s.Run("increase_deficit updates plan parameters and returns 200", func() {
 		s.createProfile()
@@ -726,3 +796,144 @@ func (s *HandlerSuite) TestApplyFatigueByParams_Validation() {
 		s.Equal("invalid_rpe", resp.Error)
 	})
 }
+
+// --- Batch endpoint tests ---
+// Justification: Atomic batches are the point of the endpoint; only a real
+// transaction shows a failing item undoing the log written before it.
+
+func (s *HandlerSuite) TestBatchAtomic() {
+	s.createProfile()
+	logReq := map[string]interface{}{
+		"date":                    "2026-01-20",
+		"weightKg":                85,
+		"sleepQuality":            80,
+		"plannedTrainingSessions": []map[string]interface{}{{"type": "strength", "durationMin": 60}},
+		"dayType":                 "performance",
+	}
+
+	s.Run("failing item rolls back the batch", func() {
+		rec := s.doRequest("POST", "/api/batch", map[string]interface{}{
+			"requests": []map[string]interface{}{
+				{"method": "POST", "path": "/api/logs", "body": logReq},
+				{"method": "PATCH", "path": "/api/logs/2026-01-20/active-calories", "body": "not an object"},
+			},
+		})
+		s.Require().Equal(http.StatusOK, rec.Code)
+
+		var resp BatchResponse
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+		s.True(resp.Atomic)
+		s.True(resp.RolledBack)
+		s.Equal(http.StatusCreated, resp.Results[0].Status)
+		s.Equal(http.StatusBadRequest, resp.Results[1].Status)
+		s.Equal(http.StatusNotFound, s.doRequest("GET", "/api/logs/2026-01-20", nil).Code)
+	})
+
+	s.Run("successful batch commits", func() {
+		rec := s.doRequest("POST", "/api/batch", map[string]interface{}{
+			"requests": []map[string]interface{}{
+				{"method": "POST", "path": "/api/logs", "body": logReq},
+				{"method": "PUT", "path": "/api/logs/2026-01-20/notes", "body": map[string]string{"notes": "Long walk"}},
+			},
+		})
+		s.Require().Equal(http.StatusOK, rec.Code)
+
+		var resp BatchResponse
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+		s.True(resp.Atomic)
+		s.False(resp.RolledBack)
+		s.Equal(http.StatusOK, resp.Results[1].Status)
+		s.Equal(http.StatusOK, s.doRequest("GET", "/api/logs/2026-01-20", nil).Code)
+	})
+}
+
+// --- API token access tests ---
+// Justification: Which requests get through without a token depends on the
+// middleware, the stored tokens and the batch fan-out together.
+
+func (s *HandlerSuite) doTokenRequest(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	jsonBody, err := json.Marshal(body)
+	s.Require().NoError(err)
+
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	s.server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *HandlerSuite) TestAnonymousAccess() {
+	adminReq := map[string]interface{}{"name": "admin", "scopes": []string{"admin"}}
+
+	// The first admin token can be minted without a token
+	rec := s.doRequest("POST", "/api/tokens", adminReq)
+	s.Require().Equal(http.StatusCreated, rec.Code)
+	var admin CreateAPITokenResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &admin))
+
+	s.Run("token management needs the admin token once one exists", func() {
+		rec := s.doRequest("POST", "/api/tokens", adminReq)
+		s.Equal(http.StatusUnauthorized, rec.Code)
+		s.Contains(rec.Body.String(), "admin_token_required")
+
+		rec = s.doTokenRequest("POST", "/api/tokens", admin.Token, map[string]interface{}{"name": "watch", "scopes": []string{"logs:write"}})
+		s.Equal(http.StatusCreated, rec.Code)
+	})
+
+	s.Run("export and import need the admin token too", func() {
+		rec := s.doRequest("GET", "/api/export", nil)
+		s.Equal(http.StatusUnauthorized, rec.Code)
+		s.Contains(rec.Body.String(), "admin_token_required")
+
+		rec = s.doRequest("POST", "/api/import", nil)
+		s.Equal(http.StatusUnauthorized, rec.Code)
+		s.Contains(rec.Body.String(), "admin_token_required")
+	})
+
+	s.Run("batch items get the same check", func() {
+		rec := s.doRequest("POST", "/api/batch", map[string]interface{}{
+			"requests": []map[string]interface{}{{"method": "POST", "path": "/api/tokens", "body": adminReq}},
+		})
+		s.Require().Equal(http.StatusOK, rec.Code)
+
+		var resp BatchResponse
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+		s.Equal(http.StatusUnauthorized, resp.Results[0].Status)
+	})
+
+	s.Run("unauthenticated write is rejected when tokens are required", func() {
+		s.server.apiTokenRequired = true
+		defer func() { s.server.apiTokenRequired = false }()
+
+		rec := s.doRequest("POST", "/api/logs", map[string]interface{}{"date": "2026-01-20", "weightKg": 85})
+		s.Equal(http.StatusUnauthorized, rec.Code)
+		s.Contains(rec.Body.String(), "api_token_required")
+		s.Equal(http.StatusOK, s.doRequest("GET", "/api/health", nil).Code)
+	})
+	s.Equal(http.StatusNotFound, s.doRequest("GET", "/api/logs/2026-01-20", nil).Code)
+}
+
+func (s *HandlerSuite) TestSignedRequestsUseTheSigningSecret() {
+	rec := s.doRequest("POST", "/api/tokens", map[string]interface{}{"name": "webhook", "scopes": []string{"read"}, "requireSignature": true})
+	s.Require().Equal(http.StatusCreated, rec.Code)
+	var created CreateAPITokenResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &created))
+	s.Require().NotEmpty(created.SigningSecret)
+
+	signedGet := func(key, nonce string) int {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req := httptest.NewRequest("GET", "/api/profile", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Token)
+		req.Header.Set(headerRequestTimestamp, timestamp)
+		req.Header.Set(headerRequestNonce, nonce)
+		req.Header.Set(headerRequestSignature, domain.SignAPIRequest(key, timestamp, nonce, "GET", "/api/profile", nil))
+		rec := httptest.NewRecorder()
+		s.server.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	s.NotEqual(http.StatusUnauthorized, signedGet(created.SigningSecret, "n-1"))
+	s.Equal(http.StatusUnauthorized, signedGet(created.Token, "n-2"), "the bearer token can't sign requests")
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// getLiveness handles GET /healthz
+// Returns 503 when the database is unreachable.
+func (s *Server) getLiveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, s.healthService.Liveness(r.Context(), time.Now()))
+}
+
+// getReadiness handles GET /readyz
+// Returns 503 when a critical component (database, migrations) is down;
+// Ollama and background job lag only degrade the status.
+func (s *Server) getReadiness(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, s.healthService.Readiness(r.Context(), time.Now()))
+}
+
+func writeHealthReport(w http.ResponseWriter, report *domain.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if !report.IsReady() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
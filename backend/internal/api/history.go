@@ -3,7 +3,6 @@ package api
 import (
 	"encoding/json"
 	"net/http"
-	"time"
 
 	"victus/internal/api/requests"
 )
@@ -15,13 +14,13 @@ func (s *Server) getHistorySummary(w http.ResponseWriter, r *http.Request) {
 		rangeParam = "30d"
 	}
 
-	startDate, ok := parseWeightTrendRange(rangeParam, time.Now())
+	startDate, ok := parseWeightTrendRange(rangeParam, s.now())
 	if !ok {
 		writeError(w, http.StatusBadRequest, "invalid_range", "Range must be one of 7d, 30d, 90d, all")
 		return
 	}
 
-	endDate := time.Now().Format("2006-01-02")
+	endDate := s.now().Format("2006-01-02")
 	summary, err := s.dailyLogService.GetHistorySummary(r.Context(), startDate, endDate)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal_error", "")
@@ -31,3 +30,25 @@ func (s *Server) getHistorySummary(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(requests.HistoryToResponse(summary))
 }
+
+// getCheckInRollup handles GET /api/stats/check-ins?month=YYYY-MM
+// Defaults to the current month.
+func (s *Server) getCheckInRollup(w http.ResponseWriter, r *http.Request) {
+	rollup, err := s.dailyLogService.GetCheckInRollup(r.Context(), r.URL.Query().Get("month"))
+	if err != nil {
+		writeDomainError(w, err, "getCheckInRollup")
+		return
+	}
+	writeJSON(w, http.StatusOK, rollup)
+}
+
+// getMealTimingRollup handles GET /api/stats/meal-timing?month=YYYY-MM
+// Defaults to the current month.
+func (s *Server) getMealTimingRollup(w http.ResponseWriter, r *http.Request) {
+	rollup, err := s.dailyLogService.GetMealTimingRollup(r.Context(), r.URL.Query().Get("month"))
+	if err != nil {
+		writeDomainError(w, err, "getMealTimingRollup")
+		return
+	}
+	writeJSON(w, http.StatusOK, rollup)
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
+
+	"victus/internal/store"
 )
 
 // Maximum upload size: 10MB
@@ -91,3 +94,144 @@ func (s *Server) getMonthlySummaries(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(summaries)
 }
+
+// MonthlySummaryComputationResponse reports a monthly summary computation.
+type MonthlySummaryComputationResponse struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Upserts int    `json:"upserts"` // Computed summaries written
+	Removed int    `json:"removed"` // Computed summaries whose sessions are gone
+	Skipped int    `json:"skipped"` // Left to imported summaries
+}
+
+// computeMonthlySummaries handles POST /api/admin/monthly-summaries/compute
+// Query parameters:
+//   - from: Start year-month (e.g., "2025-01") - optional, defaults to every month
+//   - to: End year-month (e.g., "2025-12") - optional, defaults to the current month
+func (s *Server) computeMonthlySummaries(w http.ResponseWriter, r *http.Request) {
+	now := s.now()
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		from = "0001-01"
+	}
+	to := r.URL.Query().Get("to")
+	if to == "" {
+		to = now.Format("2006-01")
+	}
+
+	result, err := s.monthlySummaryService.Compute(r.Context(), from, to)
+	if err != nil {
+		if isValidationError(err) {
+			writeDomainError(w, err, "computeMonthlySummaries")
+			return
+		}
+		writeInternalError(w, err, "computeMonthlySummaries")
+		return
+	}
+	writeJSON(w, http.StatusOK, MonthlySummaryComputationResponse{
+		From:    result.From,
+		To:      result.To,
+		Upserts: result.Upserts,
+		Removed: result.Removed,
+		Skipped: result.Skipped,
+	})
+}
+
+// uploadNutritionData handles POST /api/import/nutrition
+// Accepts multipart/form-data with:
+//   - file: MyFitnessPal or Cronometer CSV export (required)
+func (s *Server) uploadNutritionData(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		if err.Error() == "http: request body too large" {
+			writeError(w, http.StatusBadRequest, "file_too_large", "Maximum upload size is 10MB")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid_form", "Failed to parse multipart form: "+err.Error())
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing_file", "No file provided in 'file' field")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "read_error", "Failed to read uploaded file")
+		return
+	}
+
+	result, err := s.importService.ProcessNutritionUpload(r.Context(), header.Filename, data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "import_error", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// listUnmatchedFoods handles GET /api/import/nutrition/unmatched
+// Returns imported food entries that could not be matched to the food reference.
+func (s *Server) listUnmatchedFoods(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.importService.ListUnmatchedFoods(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listUnmatchedFoods")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// ResolveUnmatchedFoodRequest is the request body for resolving an unmatched import entry.
+type ResolveUnmatchedFoodRequest struct {
+	FoodReferenceID int64 `json:"foodReferenceId"`
+	ApplyToName     bool  `json:"applyToName"` // Also resolve other entries with the same raw name
+}
+
+// ResolveUnmatchedFoodResponse reports how many entries were linked.
+type ResolveUnmatchedFoodResponse struct {
+	Resolved int `json:"resolved"`
+}
+
+// resolveUnmatchedFood handles POST /api/import/nutrition/unmatched/{id}/resolve
+func (s *Server) resolveUnmatchedFood(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	var req ResolveUnmatchedFoodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	if req.FoodReferenceID <= 0 {
+		writeError(w, http.StatusBadRequest, "validation_error", "foodReferenceId is required")
+		return
+	}
+
+	resolved, err := s.importService.ResolveUnmatchedFood(r.Context(), id, req.FoodReferenceID, req.ApplyToName)
+	if errors.Is(err, store.ErrImportedFoodEntryNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "Imported food entry not found")
+		return
+	}
+	if errors.Is(err, store.ErrFoodReferenceNotFound) {
+		writeError(w, http.StatusBadRequest, "validation_error", "Unknown foodReferenceId")
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err, "resolveUnmatchedFood")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ResolveUnmatchedFoodResponse{Resolved: resolved})
+}
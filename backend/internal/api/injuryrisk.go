@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+)
+
+// getInjuryRisk handles GET /api/review/injury-risk
+// Returns the current month's injury risk report, to date.
+func (s *Server) getInjuryRisk(w http.ResponseWriter, r *http.Request) {
+	s.writeInjuryRisk(w, r, s.injuryRiskService.CurrentMonth())
+}
+
+// getInjuryRiskByMonth handles GET /api/review/injury-risk/{month}
+// month is YYYY-MM.
+func (s *Server) getInjuryRiskByMonth(w http.ResponseWriter, r *http.Request) {
+	s.writeInjuryRisk(w, r, r.PathValue("month"))
+}
+
+func (s *Server) writeInjuryRisk(w http.ResponseWriter, r *http.Request, month string) {
+	report, err := s.injuryRiskService.Report(r.Context(), month)
+	if err != nil {
+		writeDomainError(w, err, "getInjuryRisk")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/api/requests"
+	"victus/internal/domain"
+	"victus/internal/service"
+)
+
+// MealItemResponse is a logged, edited or deleted food item with the updated
+// daily log. Item is omitted after a delete.
+type MealItemResponse struct {
+	Item *domain.MealItem          `json:"item,omitempty"`
+	Log  requests.DailyLogResponse `json:"log"`
+}
+
+// listMealItems handles GET /api/logs/{date}/meal-items
+func (s *Server) listMealItems(w http.ResponseWriter, r *http.Request) {
+	items, err := s.mealItemService.List(r.Context(), r.PathValue("date"))
+	if err != nil {
+		writeDomainError(w, err, "listMealItems")
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// addMealItem handles POST /api/logs/{date}/meal-items
+// Logs a food item and adds its macros to the day's totals.
+func (s *Server) addMealItem(w http.ResponseWriter, r *http.Request) {
+	var req requests.MealItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	result, err := s.mealItemService.Add(r.Context(), r.PathValue("date"), requests.MealItemInputFromRequest(req))
+	if err != nil {
+		writeDomainError(w, err, "addMealItem")
+		return
+	}
+	s.writeMealItemResult(w, r, http.StatusCreated, result)
+}
+
+// updateMealItem handles PUT /api/logs/{date}/meal-items/{id}
+// Replaces a logged item and moves the day's totals by the difference.
+func (s *Server) updateMealItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+	var req requests.MealItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	result, err := s.mealItemService.Update(r.Context(), r.PathValue("date"), id, requests.MealItemInputFromRequest(req))
+	if err != nil {
+		writeDomainError(w, err, "updateMealItem")
+		return
+	}
+	s.writeMealItemResult(w, r, http.StatusOK, result)
+}
+
+// deleteMealItem handles DELETE /api/logs/{date}/meal-items/{id}
+// Removes a logged item and takes its macros off the day's totals.
+func (s *Server) deleteMealItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	result, err := s.mealItemService.Delete(r.Context(), r.PathValue("date"), id)
+	if err != nil {
+		writeDomainError(w, err, "deleteMealItem")
+		return
+	}
+	s.writeMealItemResult(w, r, http.StatusOK, result)
+}
+
+// writeMealItemResult writes the item and the updated daily log.
+func (s *Server) writeMealItemResult(w http.ResponseWriter, r *http.Request, status int, result *service.MealItemResult) {
+	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), result.Log.Date, result.Log.ActualSessions, result.Log.PlannedSessions)
+	if err != nil {
+		trainingLoad = nil
+	}
+	writeJSON(w, status, MealItemResponse{
+		Item: result.Item,
+		Log:  requests.DailyLogToResponseWithTrainingLoad(result.Log, trainingLoad),
+	})
+}
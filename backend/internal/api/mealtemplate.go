@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"victus/internal/api/requests"
+	"victus/internal/domain"
+	"victus/internal/service"
+	"victus/internal/store"
+)
+
+// CopyMealsRequest is the request body for copying a previous day's meals.
+type CopyMealsRequest struct {
+	SourceDate string   `json:"sourceDate"`
+	Meals      []string `json:"meals,omitempty"` // Defaults to every logged meal slot
+}
+
+// ReusedMealResponse describes one meal logged from a copy or template.
+type ReusedMealResponse struct {
+	Meal        domain.MealName         `json:"meal"`
+	ScaleFactor float64                 `json:"scaleFactor"`
+	Items       []domain.ScaledMealItem `json:"items,omitempty"`
+	Calories    int                     `json:"calories"`
+	ProteinG    int                     `json:"proteinG"`
+	CarbsG      int                     `json:"carbsG"`
+	FatG        int                     `json:"fatG"`
+}
+
+// MealReuseResponse is returned after copying meals or applying a template.
+type MealReuseResponse struct {
+	Meals []ReusedMealResponse      `json:"meals"`
+	Log   requests.DailyLogResponse `json:"log"`
+}
+
+// listMealTemplates handles GET /api/meal-templates
+func (s *Server) listMealTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.mealTemplateService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listMealTemplates")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+// createMealTemplate handles POST /api/meal-templates
+func (s *Server) createMealTemplate(w http.ResponseWriter, r *http.Request) {
+	var t domain.MealTemplate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	if err := s.mealTemplateService.Create(r.Context(), &t); err != nil {
+		switch {
+		case domain.IsValidationError(err):
+			writeDomainError(w, err, "createMealTemplate")
+		case errors.Is(err, store.ErrFoodReferenceNotFound):
+			writeError(w, http.StatusBadRequest, "validation_error", "Unknown foodReferenceId in items")
+		case errors.Is(err, store.ErrMealTemplateExists):
+			writeError(w, http.StatusConflict, "already_exists", err.Error())
+		default:
+			writeInternalError(w, err, "createMealTemplate")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(t)
+}
+
+// deleteMealTemplate handles DELETE /api/meal-templates/{id}
+func (s *Server) deleteMealTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.mealTemplateService.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, store.ErrMealTemplateNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Meal template not found")
+			return
+		}
+		writeInternalError(w, err, "deleteMealTemplate")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyMealTemplate handles POST /api/logs/{date}/meal-templates/{id}
+// Logs the template into its meal slot, scaled to the day's point targets.
+func (s *Server) applyMealTemplate(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	result, err := s.mealTemplateService.Apply(r.Context(), date, id)
+	if err != nil {
+		s.writeMealReuseError(w, err, "applyMealTemplate")
+		return
+	}
+	s.writeMealReuseResult(w, r, result)
+}
+
+// copyMeals handles POST /api/logs/{date}/copy-meals
+// Copies the meals logged on sourceDate into date, scaled to the day's point targets.
+func (s *Server) copyMeals(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+
+	var req CopyMealsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	if req.SourceDate == "" {
+		writeError(w, http.StatusBadRequest, "validation_error", "sourceDate is required")
+		return
+	}
+	if req.SourceDate == date {
+		writeError(w, http.StatusBadRequest, "validation_error", "sourceDate must differ from the target date")
+		return
+	}
+
+	meals := make([]domain.MealName, len(req.Meals))
+	for i, m := range req.Meals {
+		meals[i] = domain.MealName(m)
+	}
+
+	result, err := s.mealTemplateService.CopyDay(r.Context(), date, req.SourceDate, meals)
+	if err != nil {
+		s.writeMealReuseError(w, err, "copyMeals")
+		return
+	}
+	s.writeMealReuseResult(w, r, result)
+}
+
+// writeMealReuseError maps copy/template errors to HTTP responses.
+func (s *Server) writeMealReuseError(w http.ResponseWriter, err error, handler string) {
+	switch {
+	case errors.Is(err, store.ErrMealTemplateNotFound):
+		writeError(w, http.StatusNotFound, "not_found", "Meal template not found")
+	case errors.Is(err, store.ErrProfileNotFound):
+		writeError(w, http.StatusNotFound, "not_found", "Profile not found")
+	case errors.Is(err, store.ErrFoodReferenceNotFound):
+		writeError(w, http.StatusConflict, "stale_template", "Template references a food that no longer has nutrition data")
+	default:
+		if !handleDailyLogError(w, err, "No log exists for the target or source date") {
+			writeInternalError(w, err, handler)
+		}
+	}
+}
+
+// writeMealReuseResult writes the logged meals and the updated daily log.
+func (s *Server) writeMealReuseResult(w http.ResponseWriter, r *http.Request, result *service.MealReuseResult) {
+	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), result.Log.Date, result.Log.ActualSessions, result.Log.PlannedSessions)
+	if err != nil {
+		trainingLoad = nil
+	}
+
+	response := MealReuseResponse{
+		Meals: make([]ReusedMealResponse, len(result.Meals)),
+		Log:   requests.DailyLogToResponseWithTrainingLoad(result.Log, trainingLoad),
+	}
+	for i, m := range result.Meals {
+		response.Meals[i] = ReusedMealResponse{
+			Meal:        m.Meal,
+			ScaleFactor: m.ScaleFactor,
+			Items:       m.Items,
+			Calories:    m.Macros.Calories,
+			ProteinG:    m.Macros.ProteinG,
+			CarbsG:      m.Macros.CarbsG,
+			FatG:        m.Macros.FatG,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
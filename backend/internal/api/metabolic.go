@@ -26,6 +26,19 @@ func (s *Server) getMetabolicChart(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// getDietFatigue returns the diet fatigue index for today.
+// GET /api/metabolic/diet-fatigue
+func (s *Server) getDietFatigue(w http.ResponseWriter, r *http.Request) {
+	index, err := s.metabolicService.GetDietFatigue(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(index)
+}
+
 // getMetabolicNotification returns any pending weekly strategy notification.
 // GET /api/metabolic/notification
 func (s *Server) getMetabolicNotification(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// getMetabolicAdaptation handles GET /api/metabolic/adaptation
+// Runs the detector as of today and returns the current adaptation event, or
+// null when none is showing.
+func (s *Server) getMetabolicAdaptation(w http.ResponseWriter, r *http.Request) {
+	adaptation, err := s.adaptationService.Check(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getMetabolicAdaptation")
+		return
+	}
+	writeJSON(w, http.StatusOK, adaptation)
+}
+
+// listMetabolicAdaptationHistory handles GET /api/metabolic/adaptation/history
+func (s *Server) listMetabolicAdaptationHistory(w http.ResponseWriter, r *http.Request) {
+	events, err := s.adaptationService.History(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listMetabolicAdaptationHistory")
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// listMetabolicAdaptationNotifications handles GET /api/metabolic/adaptation/notifications
+// Lists detections that haven't been acknowledged.
+func (s *Server) listMetabolicAdaptationNotifications(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.adaptationService.Check(r.Context()); err != nil {
+		writeInternalError(w, err, "listMetabolicAdaptationNotifications")
+		return
+	}
+	events, err := s.adaptationService.PendingNotifications(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listMetabolicAdaptationNotifications")
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// dismissMetabolicAdaptation handles POST /api/metabolic/adaptation/{id}/dismiss
+func (s *Server) dismissMetabolicAdaptation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.adaptationService.DismissNotification(r.Context(), id); err != nil {
+		writeDomainError(w, err, "dismissMetabolicAdaptation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
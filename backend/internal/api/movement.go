@@ -72,6 +72,16 @@ func (s *Server) getFilteredMovements(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(movements)
 }
 
+func (s *Server) getMovementAnalytics(w http.ResponseWriter, r *http.Request) {
+	analytics, err := s.movementService.GetAnalytics(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics)
+}
+
 func (s *Server) getNeuralBattery(w http.ResponseWriter, r *http.Request) {
 	battery := s.dailyLogService.GetNeuralBattery(r.Context())
 	w.Header().Set("Content-Type", "application/json")
@@ -93,6 +103,10 @@ func (s *Server) completeMovementSession(w http.ResponseWriter, r *http.Request)
 
 	progress, err := s.movementService.RecordSessionCompletion(r.Context(), id, input)
 	if err != nil {
+		if domain.IsValidationError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
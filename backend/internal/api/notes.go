@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// UpdateNotesRequest is the request body for editing a day or session note.
+// Base is the note text the device loaded before editing; without it the
+// edit overwrites whatever is stored.
+type UpdateNotesRequest struct {
+	Notes string  `json:"notes"`
+	Base  *string `json:"base,omitempty"`
+}
+
+// ResolveNoteConflictRequest is the request body for settling a note conflict.
+// Text is required for the "custom" resolution only.
+type ResolveNoteConflictRequest struct {
+	Resolution string `json:"resolution"`
+	Text       string `json:"text,omitempty"`
+}
+
+// updateDayNotes handles PUT /api/logs/{date}/notes
+// Saves the day's notes, merging with or recording a conflict against a
+// concurrent edit from another device.
+func (s *Server) updateDayNotes(w http.ResponseWriter, r *http.Request) {
+	var req UpdateNotesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	result, err := s.noteService.UpdateDayNotes(r.Context(), r.PathValue("date"), req.Notes, req.Base)
+	if err != nil {
+		writeDomainError(w, err, "updateDayNotes")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// updateSessionNotes handles PUT /api/sessions/{id}/notes
+// Saves a training session's notes, with the same conflict handling as
+// day notes.
+func (s *Server) updateSessionNotes(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_session_id", "Session ID must be a valid integer")
+		return
+	}
+	var req UpdateNotesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	result, err := s.noteService.UpdateSessionNotes(r.Context(), sessionID, req.Notes, req.Base)
+	if err != nil {
+		writeDomainError(w, err, "updateSessionNotes")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// listNoteConflicts handles GET /api/note-conflicts
+// Returns the unresolved note conflicts, oldest first.
+func (s *Server) listNoteConflicts(w http.ResponseWriter, r *http.Request) {
+	conflicts, err := s.noteService.ListConflicts(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listNoteConflicts")
+		return
+	}
+	writeJSON(w, http.StatusOK, conflicts)
+}
+
+// resolveNoteConflict handles POST /api/note-conflicts/{id}/resolve
+// Keeps the last written text, restores the overwritten one, applies the
+// suggested merge or saves custom text.
+func (s *Server) resolveNoteConflict(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+	var req ResolveNoteConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	resolution, err := domain.ParseNoteResolution(req.Resolution)
+	if err != nil {
+		writeDomainError(w, err, "resolveNoteConflict")
+		return
+	}
+
+	result, err := s.noteService.ResolveConflict(r.Context(), id, resolution, req.Text)
+	if err != nil {
+		writeDomainError(w, err, "resolveNoteConflict")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
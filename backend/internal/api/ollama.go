@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ollamaModelNamePattern matches Ollama model names such as "llama3.2:1b" or "library/qwen2.5:7b".
+var ollamaModelNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*(:[A-Za-z0-9._-]+)?$`)
+
+// PullOllamaModelRequest is the request body for POST /api/admin/ollama/models/pull.
+type PullOllamaModelRequest struct {
+	Model string `json:"model"`
+}
+
+// getOllamaModels handles GET /api/admin/ollama/models
+// Returns installed models, which model each task runs on, and pull progress.
+func (s *Server) getOllamaModels(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(s.ollamaService.ModelStatus())
+}
+
+// getOllamaCircuits handles GET /api/admin/ollama/circuits
+// Returns each Ollama endpoint's circuit breaker state and transition counts.
+func (s *Server) getOllamaCircuits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(s.ollamaService.CircuitStats())
+}
+
+// pullOllamaModel handles POST /api/admin/ollama/models/pull
+// Starts the download in the background and returns 202 with its progress;
+// poll GET /api/admin/ollama/models to follow it.
+func (s *Server) pullOllamaModel(w http.ResponseWriter, r *http.Request) {
+	var req PullOllamaModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	model := strings.TrimSpace(req.Model)
+	if !ollamaModelNamePattern.MatchString(model) {
+		writeError(w, http.StatusBadRequest, "invalid_model", "model must be an Ollama model name, e.g. llama3.2:1b")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, s.ollamaService.PullModel(model))
+}
+
+// getLLMUsage handles GET /api/admin/llm/usage?days=7
+// Returns per-feature LLM usage over the last days (1-90) and today's budget standing.
+func (s *Server) getLLMUsage(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if d := r.URL.Query().Get("days"); d != "" {
+		v, err := strconv.Atoi(d)
+		if err != nil || v < 1 || v > 90 {
+			writeError(w, http.StatusBadRequest, "invalid_days", "days must be between 1 and 90")
+			return
+		}
+		days = v
+	}
+
+	report, err := s.ollamaService.UsageReport(r.Context(), days)
+	if err != nil {
+		writeInternalError(w, err, "getLLMUsage")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, report)
+}
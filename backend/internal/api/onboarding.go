@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// OnboardingResponse is the wizard's progress with the suggested answers for
+// the steps that are open.
+type OnboardingResponse struct {
+	*domain.OnboardingState
+	Defaults domain.OnboardingDefaults `json:"defaults"`
+}
+
+// getOnboarding handles GET /api/onboarding
+// Installs that already have a profile report onboarding as complete.
+func (s *Server) getOnboarding(w http.ResponseWriter, r *http.Request) {
+	now := s.now()
+	state, err := s.onboardingService.Get(r.Context(), now)
+	if err != nil {
+		writeInternalError(w, err, "getOnboarding")
+		return
+	}
+	writeJSON(w, http.StatusOK, OnboardingResponse{OnboardingState: state, Defaults: state.Defaults(now)})
+}
+
+// submitOnboardingBasicInfo handles POST /api/onboarding/basic-info
+// Answering it again after later steps clears them.
+func (s *Server) submitOnboardingBasicInfo(w http.ResponseWriter, r *http.Request) {
+	var req domain.OnboardingBasicInfo
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	now := s.now()
+	state, err := s.onboardingService.SubmitBasicInfo(r.Context(), req, now)
+	s.writeOnboarding(w, state, err, now, "submitOnboardingBasicInfo")
+}
+
+// submitOnboardingGoal handles POST /api/onboarding/goal
+// Unset target weight, weekly change and timeframe are derived from the goal.
+func (s *Server) submitOnboardingGoal(w http.ResponseWriter, r *http.Request) {
+	var req domain.OnboardingGoal
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	now := s.now()
+	state, err := s.onboardingService.SubmitGoal(r.Context(), req, now)
+	s.writeOnboarding(w, state, err, now, "submitOnboardingGoal")
+}
+
+// submitOnboardingRatios handles POST /api/onboarding/ratios
+// Saves the profile from the answers so far.
+func (s *Server) submitOnboardingRatios(w http.ResponseWriter, r *http.Request) {
+	var req domain.OnboardingRatios
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	now := s.now()
+	state, err := s.onboardingService.SubmitRatios(r.Context(), req, now)
+	s.writeOnboarding(w, state, err, now, "submitOnboardingRatios")
+}
+
+// submitOnboardingFirstPlan handles POST /api/onboarding/first-plan
+// Creates the first plan, or skips it, and finishes onboarding.
+func (s *Server) submitOnboardingFirstPlan(w http.ResponseWriter, r *http.Request) {
+	var req domain.OnboardingPlan
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	now := s.now()
+	state, err := s.onboardingService.SubmitFirstPlan(r.Context(), req, now)
+	s.writeOnboarding(w, state, err, now, "submitOnboardingFirstPlan")
+}
+
+// writeOnboarding writes the progress after a step, or the step's error.
+func (s *Server) writeOnboarding(w http.ResponseWriter, state *domain.OnboardingState, err error, now time.Time, context string) {
+	if err != nil {
+		writeDomainError(w, err, context)
+		return
+	}
+	writeJSON(w, http.StatusOK, OnboardingResponse{OnboardingState: state, Defaults: state.Defaults(now)})
+}
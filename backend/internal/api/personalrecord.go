@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// AddPersonalRecordRequest is the body of POST /api/records.
+type AddPersonalRecordRequest struct {
+	MovementID string  `json:"movementId"`
+	Kind       string  `json:"kind"`
+	Value      float64 `json:"value"`          // kg for heaviest_load, seconds otherwise
+	Date       string  `json:"date,omitempty"` // YYYY-MM-DD; defaults to today
+	Note       string  `json:"note,omitempty"`
+}
+
+// CorrectPersonalRecordRequest is the body of PATCH /api/records/{id}.
+type CorrectPersonalRecordRequest struct {
+	Value float64 `json:"value"`
+	Date  string  `json:"date,omitempty"` // Keeps the current date when empty
+	Note  string  `json:"note,omitempty"` // Keeps the current note when empty
+}
+
+// getRecordRegistry handles GET /api/records
+// Returns the current best per movement and kind.
+func (s *Server) getRecordRegistry(w http.ResponseWriter, r *http.Request) {
+	records, err := s.personalRecordService.GetRegistry(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getRecordRegistry")
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// getRecordHistory handles GET /api/records/history?movementId=
+// Returns every record entry by date, optionally for one movement.
+func (s *Server) getRecordHistory(w http.ResponseWriter, r *http.Request) {
+	records, err := s.personalRecordService.GetHistory(r.Context(), r.URL.Query().Get("movementId"))
+	if err != nil {
+		writeInternalError(w, err, "getRecordHistory")
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// addPersonalRecord handles POST /api/records
+// Adds a record by hand, e.g. one set away from the app.
+func (s *Server) addPersonalRecord(w http.ResponseWriter, r *http.Request) {
+	var req AddPersonalRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	record, err := s.personalRecordService.AddManual(r.Context(), domain.PersonalRecord{
+		MovementID: req.MovementID,
+		Kind:       domain.RecordKind(req.Kind),
+		Value:      req.Value,
+		Date:       req.Date,
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeDomainError(w, err, "addPersonalRecord")
+		return
+	}
+	writeJSON(w, http.StatusCreated, record)
+}
+
+// correctPersonalRecord handles PATCH /api/records/{id}
+func (s *Server) correctPersonalRecord(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	var req CorrectPersonalRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	record, err := s.personalRecordService.Correct(r.Context(), id, req.Value, req.Date, req.Note)
+	if err != nil {
+		writeDomainError(w, err, "correctPersonalRecord")
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+// deletePersonalRecord handles DELETE /api/records/{id}
+func (s *Server) deletePersonalRecord(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.personalRecordService.Delete(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deletePersonalRecord")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listRecordCelebrations handles GET /api/records/celebrations
+// Returns new records whose celebration hasn't been dismissed.
+func (s *Server) listRecordCelebrations(w http.ResponseWriter, r *http.Request) {
+	records, err := s.personalRecordService.ListCelebrations(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listRecordCelebrations")
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// dismissRecordCelebration handles POST /api/records/celebrations/{id}/dismiss
+func (s *Server) dismissRecordCelebration(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.personalRecordService.DismissCelebration(r.Context(), id); err != nil {
+		writeDomainError(w, err, "dismissRecordCelebration")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,11 +3,14 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
 
 	"victus/internal/api/requests"
+	"victus/internal/domain"
+	"victus/internal/service"
 	"victus/internal/store"
 )
 
@@ -23,7 +26,7 @@ func parsePlanID(w http.ResponseWriter, r *http.Request) (int64, bool) {
 	return id, true
 }
 
-// createPlan handles POST /api/plans
+// createPlan handles POST /api/plans (?dryRun=true validates without saving)
 func (s *Server) createPlan(w http.ResponseWriter, r *http.Request) {
 	var req requests.CreatePlanRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -32,9 +35,16 @@ func (s *Server) createPlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := requests.PlanInputFromRequest(req)
-	now := time.Now()
-
-	plan, err := s.planService.Create(r.Context(), input, now)
+	now := s.now()
+	dryRun := isDryRun(r)
+
+	var plan *domain.NutritionPlan
+	var err error
+	if dryRun {
+		plan, err = s.planService.PreviewCreate(r.Context(), input, now)
+	} else {
+		plan, err = s.planService.Create(r.Context(), input, now)
+	}
 	if err != nil {
 		if errors.Is(err, store.ErrProfileNotFound) {
 			writeError(w, http.StatusBadRequest, "profile_required", "A user profile must be created before creating a nutrition plan")
@@ -45,7 +55,7 @@ func (s *Server) createPlan(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "createPlan")
 			return
 		}
 		writeInternalError(w, err, "createPlan")
@@ -53,7 +63,9 @@ func (s *Server) createPlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	if !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
 	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, now))
 }
 
@@ -72,7 +84,7 @@ func (s *Server) getActivePlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, time.Now()))
+	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, s.now()))
 }
 
 // getPlanByID handles GET /api/plans/{id}
@@ -93,7 +105,64 @@ func (s *Server) getPlanByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, time.Now()))
+	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, s.now()))
+}
+
+// getActivePlanDailyTargets handles GET /api/plans/active/daily-targets?start=YYYY-MM-DD&end=YYYY-MM-DD
+func (s *Server) getActivePlanDailyTargets(w http.ResponseWriter, r *http.Request) {
+	plan, err := s.planService.GetActive(r.Context())
+	if err != nil {
+		if errors.Is(err, store.ErrPlanNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "No active nutrition plan exists")
+			return
+		}
+		writeInternalError(w, err, "getActivePlanDailyTargets")
+		return
+	}
+	s.writePlanDailyTargets(w, r, plan.ID, "getActivePlanDailyTargets")
+}
+
+// getPlanDailyTargets handles GET /api/plans/{id}/daily-targets?start=YYYY-MM-DD&end=YYYY-MM-DD
+func (s *Server) getPlanDailyTargets(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+	s.writePlanDailyTargets(w, r, id, "getPlanDailyTargets")
+}
+
+// writePlanDailyTargets looks up a plan's daily targets in the optional
+// start/end range, which defaults to the whole plan.
+func (s *Server) writePlanDailyTargets(w http.ResponseWriter, r *http.Request, planID int64, handler string) {
+	startDate := r.URL.Query().Get("start")
+	endDate := r.URL.Query().Get("end")
+	if startDate != "" {
+		if _, err := time.Parse("2006-01-02", startDate); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_start_date", "start must be in YYYY-MM-DD format")
+			return
+		}
+	}
+	if endDate != "" {
+		if _, err := time.Parse("2006-01-02", endDate); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_end_date", "end must be in YYYY-MM-DD format")
+			return
+		}
+	}
+	if startDate != "" && endDate != "" && endDate < startDate {
+		writeError(w, http.StatusBadRequest, "invalid_range", "end must be on or after start")
+		return
+	}
+
+	targets, err := s.planService.DailyTargets(r.Context(), planID, startDate, endDate)
+	if err != nil {
+		if errors.Is(err, store.ErrPlanNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
+			return
+		}
+		writeInternalError(w, err, handler)
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.DailyPlanTargetsToResponse(targets))
 }
 
 // listPlans handles GET /api/plans
@@ -104,7 +173,7 @@ func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
+	now := s.now()
 	response := make([]requests.PlanSummaryResponse, len(plans))
 	for i, plan := range plans {
 		response[i] = requests.PlanToSummaryResponse(plan, now)
@@ -121,30 +190,87 @@ func (s *Server) completePlan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.planService.Complete(r.Context(), id); err != nil {
+	completion, err := s.planCompletionService.Complete(r.Context(), id)
+	if err != nil {
 		if errors.Is(err, store.ErrPlanNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
 			return
 		}
-		writeInternalError(w, err, "completePlan")
+		writeDomainError(w, err, "completePlan")
 		return
 	}
 
+	writeJSON(w, http.StatusOK, requests.PlanCompletionToResponse(completion))
+}
+
+// getPendingPlanCompletion handles GET /api/plans/completion/pending
+// Completes the active plan if its final week has passed, then returns the
+// latest completion awaiting a next phase choice, or null.
+func (s *Server) getPendingPlanCompletion(w http.ResponseWriter, r *http.Request) {
+	completion, err := s.planCompletionService.Pending(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getPendingPlanCompletion")
+		return
+	}
+	if completion == nil {
+		writeJSON(w, http.StatusOK, nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.PlanCompletionToResponse(completion))
+}
+
+// getPlanRetrospective handles GET /api/plans/{id}/retrospective
+func (s *Server) getPlanRetrospective(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+
+	completion, err := s.planCompletionService.Get(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "getPlanRetrospective")
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.PlanCompletionToResponse(completion))
+}
+
+// dismissNextPhasePrompt handles POST /api/plans/{id}/next-phase/dismiss
+func (s *Server) dismissNextPhasePrompt(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.planCompletionService.DismissPrompt(r.Context(), id); err != nil {
+		writeDomainError(w, err, "dismissNextPhasePrompt")
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// abandonPlan handles POST /api/plans/{id}/abandon
+// abandonPlan handles POST /api/plans/{id}/abandon with an optional exit survey
 func (s *Server) abandonPlan(w http.ResponseWriter, r *http.Request) {
 	id, ok := parsePlanID(w, r)
 	if !ok {
 		return
 	}
 
-	if err := s.planService.Abandon(r.Context(), id); err != nil {
+	// The exit survey is optional; an empty body abandons without reasons
+	var req requests.AbandonPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	if err := s.planService.Abandon(r.Context(), id, requests.AbandonSurveyFromRequest(req)); err != nil {
 		if errors.Is(err, store.ErrPlanNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
 			return
 		}
+		if isValidationError(err) {
+			writeDomainError(w, err, "abandonPlan")
+			return
+		}
 		writeInternalError(w, err, "abandonPlan")
 		return
 	}
@@ -190,7 +316,7 @@ func (s *Server) resumePlan(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// recalibratePlan handles POST /api/plans/{id}/recalibrate
+// recalibratePlan handles POST /api/plans/{id}/recalibrate (?dryRun=true previews)
 func (s *Server) recalibratePlan(w http.ResponseWriter, r *http.Request) {
 	id, ok := parsePlanID(w, r)
 	if !ok {
@@ -204,16 +330,25 @@ func (s *Server) recalibratePlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	recalibrationType := requests.RecalibrationInputFromRequest(req)
-	now := time.Now()
-
-	plan, err := s.planService.Recalibrate(r.Context(), id, recalibrationType, now)
+	now := s.now()
+
+	var plan *domain.NutritionPlan
+	var err error
+	if isDryRun(r) {
+		var preview *service.RegenerationPreview
+		if preview, err = s.planService.PreviewRegeneration(r.Context(), id, &recalibrationType, now); err == nil {
+			plan = preview.Plan
+		}
+	} else {
+		plan, err = s.planService.Recalibrate(r.Context(), id, recalibrationType, now)
+	}
 	if err != nil {
 		if errors.Is(err, store.ErrPlanNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
 			return
 		}
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "recalibratePlan")
 			return
 		}
 		writeInternalError(w, err, "recalibratePlan")
@@ -267,7 +402,7 @@ func (s *Server) deletePlan(w http.ResponseWriter, r *http.Request) {
 
 // getCurrentWeekTarget handles GET /api/plans/current-week
 func (s *Server) getCurrentWeekTarget(w http.ResponseWriter, r *http.Request) {
-	target, err := s.planService.GetCurrentWeekTarget(r.Context(), time.Now())
+	target, err := s.planService.GetCurrentWeekTarget(r.Context(), s.now())
 	if err != nil {
 		if errors.Is(err, store.ErrPlanNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "No active nutrition plan exists")
@@ -282,21 +417,126 @@ func (s *Server) getCurrentWeekTarget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := requests.WeeklyTargetResponse{
-		WeekNumber:        target.WeekNumber,
-		StartDate:         target.StartDate.Format("2006-01-02"),
-		EndDate:           target.EndDate.Format("2006-01-02"),
-		ProjectedWeightKg: target.ProjectedWeightKg,
-		ProjectedTDEE:     target.ProjectedTDEE,
-		TargetIntakeKcal:  target.TargetIntakeKcal,
-		TargetCarbsG:      target.TargetCarbsG,
-		TargetProteinG:    target.TargetProteinG,
-		TargetFatsG:       target.TargetFatsG,
-		ActualWeightKg:    target.ActualWeightKg,
-		ActualIntakeKcal:  target.ActualIntakeKcal,
-		DaysLogged:        target.DaysLogged,
-	}
+	response := requests.WeeklyTargetToResponse(*target)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// previewRegeneration handles GET /api/plans/{id}/regenerate/preview?option=
+// Without an option the preview regenerates targets from the current profile.
+func (s *Server) previewRegeneration(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+
+	var option *domain.RecalibrationOptionType
+	if raw := r.URL.Query().Get("option"); raw != "" {
+		opt := domain.RecalibrationOptionType(raw)
+		switch opt {
+		case domain.RecalibrationIncreaseDeficit, domain.RecalibrationExtendTimeline,
+			domain.RecalibrationReviseGoal, domain.RecalibrationKeepCurrent:
+			option = &opt
+		default:
+			writeError(w, http.StatusBadRequest, "invalid_option", "option must be increase_deficit, extend_timeline, revise_goal, or keep_current")
+			return
+		}
+	}
+
+	now := s.now()
+	preview, err := s.planService.PreviewRegeneration(r.Context(), id, option, now)
+	if err != nil {
+		if errors.Is(err, store.ErrPlanNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
+			return
+		}
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusBadRequest, "profile_required", "A user profile is required to regenerate targets")
+			return
+		}
+		writeInternalError(w, err, "previewRegeneration")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.RegenerationPreviewToResponse(preview.Plan, preview.Diffs, r.URL.Query().Get("option"), now))
+}
+
+// regeneratePlan handles POST /api/plans/{id}/regenerate
+func (s *Server) regeneratePlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	plan, err := s.planService.Regenerate(r.Context(), id, now)
+	if err != nil {
+		if errors.Is(err, store.ErrPlanNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
+			return
+		}
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusBadRequest, "profile_required", "A user profile is required to regenerate targets")
+			return
+		}
+		writeInternalError(w, err, "regeneratePlan")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.PlanToResponse(plan, now))
+}
+
+// editWeeklyTarget handles PATCH /api/plans/{id}/weeks/{week}
+func (s *Server) editWeeklyTarget(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePlanID(w, r)
+	if !ok {
+		return
+	}
+	week, err := strconv.Atoi(r.PathValue("week"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_week", "Week number must be a number")
+		return
+	}
+
+	var req requests.EditWeeklyTargetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	target, err := s.planService.EditWeeklyTarget(r.Context(), id, week, requests.WeeklyTargetEditFromRequest(req), s.now())
+	if err != nil {
+		if errors.Is(err, store.ErrPlanNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Nutrition plan not found")
+			return
+		}
+		if isValidationError(err) {
+			writeDomainError(w, err, "editWeeklyTarget")
+			return
+		}
+		writeInternalError(w, err, "editWeeklyTarget")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.WeeklyTargetToResponse(*target))
+}
+
+// WeeklyActualsRollupResponse reports a manual weekly actuals rollup.
+type WeeklyActualsRollupResponse struct {
+	WeeksUpdated int `json:"weeksUpdated"`
+}
+
+// rollupWeeklyActuals handles POST /api/admin/weekly-actuals/rollup
+// Recomputes the active plan's weekly actuals now instead of waiting for the nightly job.
+func (s *Server) rollupWeeklyActuals(w http.ResponseWriter, r *http.Request) {
+	updated, err := s.weeklyActualsService.RollupActivePlan(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "rollupWeeklyActuals")
+		return
+	}
+	writeJSON(w, http.StatusOK, WeeklyActualsRollupResponse{WeeksUpdated: updated})
+}
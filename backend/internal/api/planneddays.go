@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
 
 	"victus/internal/domain"
@@ -20,9 +22,10 @@ type PlannedSessionResponse struct {
 
 // PlannedDayResponse represents a planned day type in API responses.
 type PlannedDayResponse struct {
-	Date     string                   `json:"date"`
-	DayType  string                   `json:"dayType"`
-	Sessions []PlannedSessionResponse `json:"sessions,omitempty"`
+	Date      string                   `json:"date"`
+	DayType   string                   `json:"dayType"`
+	Sessions  []PlannedSessionResponse `json:"sessions,omitempty"`
+	DoubleDay *domain.DoubleDayWarning `json:"doubleDay,omitempty"` // Set when hard sessions exceed the daily ceiling
 }
 
 // PlannedDaysResponse represents a list of planned day types.
@@ -104,15 +107,26 @@ func (s *Server) upsertPlannedDay(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to save planned day")
 		return
 	}
+	s.refreshPlanDailyTargets(r, date)
 
-	// Save planner sessions if provided
+	// Save planner sessions if provided; training planned without an RPE gets
+	// the experience level's target
 	var sessions []domain.PlannerSession
+	var defaultRPE *int
+	defaultLoaded := false
 	for i, sessionInput := range req.Sessions {
+		rpe := sessionInput.RPE
+		if rpe == nil && sessionInput.TrainingType != string(domain.TrainingTypeRest) {
+			if !defaultLoaded {
+				defaultRPE, defaultLoaded = s.experienceDefaultRPE(r.Context()), true
+			}
+			rpe = defaultRPE
+		}
 		ps, err := domain.NewPlannerSession(date, i+1, domain.PlannerSessionInput{
 			TrainingType: sessionInput.TrainingType,
 			DurationMin:  sessionInput.DurationMin,
 			LoadScore:    sessionInput.LoadScore,
-			RPE:          sessionInput.RPE,
+			RPE:          rpe,
 			Notes:        sessionInput.Notes,
 		})
 		if err != nil {
@@ -140,9 +154,10 @@ func (s *Server) upsertPlannedDay(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := PlannedDayResponse{
-		Date:     pdt.Date,
-		DayType:  string(pdt.DayType),
-		Sessions: responseSessions,
+		Date:      pdt.Date,
+		DayType:   string(pdt.DayType),
+		Sessions:  responseSessions,
+		DoubleDay: s.evaluatePlannedDoubleDay(r.Context(), date, sessions),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -174,6 +189,7 @@ func (s *Server) deletePlannedDay(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete planned day")
 		return
 	}
+	s.refreshPlanDailyTargets(r, date)
 
 	if err := s.plannerSessionStore.DeleteByDate(r.Context(), date); err != nil {
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete planned sessions")
@@ -212,3 +228,34 @@ func (s *Server) getPlannedSessions(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// evaluatePlannedDoubleDay warns when planner sessions stack two hard sessions
+// beyond the daily ceiling. Readiness comes from the day's log CNS status when
+// one exists (i.e. planning for today); otherwise the full ceiling applies.
+func (s *Server) evaluatePlannedDoubleDay(ctx context.Context, date string, planned []domain.PlannerSession) *domain.DoubleDayWarning {
+	sessions := make([]domain.TrainingSession, len(planned))
+	for i, ps := range planned {
+		sessions[i] = domain.TrainingSession{
+			IsPlanned:          true,
+			Type:               ps.TrainingType,
+			DurationMin:        ps.DurationMin,
+			PerceivedIntensity: ps.RPE,
+		}
+	}
+
+	var readiness domain.CNSStatus
+	if log, err := s.dailyLogService.GetByDate(ctx, date); err == nil {
+		readiness = domain.DayReadiness(log)
+	}
+
+	return domain.EvaluateDoubleDay(date, sessions, readiness)
+}
+
+// refreshPlanDailyTargets re-materializes the active plan's daily targets
+// after a planned day type changed. Best-effort: the planned day has been
+// saved, so a failure is only logged.
+func (s *Server) refreshPlanDailyTargets(r *http.Request, date string) {
+	if err := s.planService.RefreshDailyTargets(r.Context(), date); err != nil {
+		log.Printf("planned days: plan daily targets refresh from %s failed: %v", date, err)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// getPlateaus handles GET /api/plateaus
+// Runs plateau detection for today and returns the current detections.
+func (s *Server) getPlateaus(w http.ResponseWriter, r *http.Request) {
+	detections, err := s.plateauService.Detect(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "getPlateaus")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detections)
+}
+
+// getPlateauHistory handles GET /api/plateaus/history
+func (s *Server) getPlateauHistory(w http.ResponseWriter, r *http.Request) {
+	detections, err := s.plateauService.GetHistory(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "getPlateauHistory")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detections)
+}
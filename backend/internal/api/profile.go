@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"time"
 
 	"victus/internal/api/requests"
 	"victus/internal/domain"
@@ -59,10 +58,13 @@ func writeInternalError(w http.ResponseWriter, err error, context string) {
 	}
 }
 
-// APIError represents a JSON error response.
+// APIError represents a JSON error response. Code and MessageKey are set for
+// cataloged errors (see errors.go).
 type APIError struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error      string `json:"error"`
+	Code       string `json:"code,omitempty"`
+	MessageKey string `json:"messageKey,omitempty"`
+	Message    string `json:"message,omitempty"`
 }
 
 // getProfile handles GET /api/profile
@@ -95,7 +97,7 @@ func (s *Server) upsertProfile(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		// Check if it's a domain validation error (invalid enum values)
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "upsertProfile")
 			return
 		}
 		// Otherwise it's a date parsing error
@@ -103,10 +105,10 @@ func (s *Server) upsertProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	saved, err := s.profileService.Upsert(r.Context(), profile, time.Now())
+	saved, err := s.profileService.Upsert(r.Context(), profile, s.now())
 	if err != nil {
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "upsertProfile")
 			return
 		}
 		writeInternalError(w, err, "upsertProfile")
@@ -141,7 +143,7 @@ func handleDailyLogError(w http.ResponseWriter, err error, notFoundMsg string) b
 		return true
 	}
 	if isValidationError(err) {
-		writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+		writeDomainError(w, err, "handleDailyLogError")
 		return true
 	}
 	return false
@@ -5,13 +5,13 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
-	"time"
 
 	"victus/internal/api/requests"
+	"victus/internal/domain"
 	"victus/internal/store"
 )
 
-// listPrograms handles GET /api/training-programs
+// listPrograms handles GET /api/training-programs?include=weeks
 func (s *Server) listPrograms(w http.ResponseWriter, r *http.Request) {
 	filters := store.ProgramFilters{
 		Difficulty: r.URL.Query().Get("difficulty"),
@@ -24,6 +24,25 @@ func (s *Server) listPrograms(w http.ResponseWriter, r *http.Request) {
 		filters.IsTemplate = &isTemplate
 	}
 
+	// include=weeks returns full programs, weeks and days included, so the
+	// library can render without fetching each program separately.
+	if r.URL.Query().Get("include") == "weeks" {
+		programs, err := s.programService.ListWithWeeks(r.Context(), filters)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal_error", "")
+			return
+		}
+
+		response := make([]requests.ProgramResponse, len(programs))
+		for i, p := range programs {
+			response[i] = requests.ProgramToResponse(p)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	programs, err := s.programService.List(r.Context(), filters)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal_error", "")
@@ -71,12 +90,12 @@ func (s *Server) createProgram(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := requests.ProgramInputFromRequest(req)
-	now := time.Now()
+	now := s.now()
 
 	program, err := s.programService.Create(r.Context(), input, now)
 	if err != nil {
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "createProgram")
 			return
 		}
 		writeError(w, http.StatusInternalServerError, "internal_error", "")
@@ -88,6 +107,36 @@ func (s *Server) createProgram(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests.ProgramToResponse(program))
 }
 
+// generateProgram handles POST /api/training-programs/generate (?dryRun=true previews)
+// Builds a custom program's weeks and days from mesocycle parameters.
+func (s *Server) generateProgram(w http.ResponseWriter, r *http.Request) {
+	var req requests.GenerateProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	input := requests.MesocycleInputFromRequest(req)
+	now := s.now()
+
+	if isDryRun(r) {
+		program, err := s.programService.PreviewGenerate(r.Context(), input, now)
+		if err != nil {
+			writeDomainError(w, err, "generateProgram")
+			return
+		}
+		writeJSON(w, http.StatusOK, requests.ProgramToResponse(program))
+		return
+	}
+
+	program, err := s.programService.Generate(r.Context(), input, now)
+	if err != nil {
+		writeDomainError(w, err, "generateProgram")
+		return
+	}
+	writeJSON(w, http.StatusCreated, requests.ProgramToResponse(program))
+}
+
 // deleteProgram handles DELETE /api/training-programs/{id}
 // Query params:
 //   - force=true: Delete even if program has an active installation
@@ -140,7 +189,7 @@ func (s *Server) getProgramWaveform(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests.WaveformToResponse(waveform))
 }
 
-// installProgram handles POST /api/training-programs/{id}/install
+// installProgram handles POST /api/training-programs/{id}/install (?dryRun=true previews)
 func (s *Server) installProgram(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -156,9 +205,16 @@ func (s *Server) installProgram(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := requests.InstallInputFromRequest(id, req)
-	now := time.Now()
-
-	installation, err := s.programService.Install(r.Context(), input, now)
+	now := s.now()
+
+	var installation *domain.ProgramInstallation
+	var sessions []domain.ScheduledSession
+	dryRun := isDryRun(r)
+	if dryRun {
+		installation, sessions, err = s.programService.PreviewInstall(r.Context(), input, now)
+	} else {
+		installation, err = s.programService.Install(r.Context(), input, now)
+	}
 	if err != nil {
 		if errors.Is(err, store.ErrProgramNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "Training program not found")
@@ -169,7 +225,7 @@ func (s *Server) installProgram(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if isValidationError(err) {
-			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			writeDomainError(w, err, "installProgram")
 			return
 		}
 		writeError(w, http.StatusInternalServerError, "internal_error", "")
@@ -177,6 +233,13 @@ func (s *Server) installProgram(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if dryRun {
+		json.NewEncoder(w).Encode(requests.InstallPreviewResponse{
+			Installation: requests.InstallationToResponse(installation, now),
+			Sessions:     requests.ScheduledSessionsToResponse(sessions),
+		})
+		return
+	}
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(requests.InstallationToResponse(installation, now))
 }
@@ -196,7 +259,7 @@ func (s *Server) getActiveInstallation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(requests.InstallationToResponse(installation, time.Now()))
+	json.NewEncoder(w).Encode(requests.InstallationToResponse(installation, s.now()))
 }
 
 // getInstallationByID handles GET /api/program-installations/{id}
@@ -219,7 +282,7 @@ func (s *Server) getInstallationByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(requests.InstallationToResponse(installation, time.Now()))
+	json.NewEncoder(w).Encode(requests.InstallationToResponse(installation, s.now()))
 }
 
 // abandonInstallation handles POST /api/program-installations/{id}/abandon
@@ -286,3 +349,95 @@ func (s *Server) getScheduledSessions(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(requests.ScheduledSessionsToResponse(sessions))
 }
+
+// swapProgramDays handles POST /api/program-installations/{id}/swap
+// Swaps the program sessions on two days of the current program week.
+func (s *Server) swapProgramDays(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Installation ID must be a number")
+		return
+	}
+
+	var req requests.SwapProgramDaysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	moves, sessions, err := s.programService.SwapDays(r.Context(), id, req.DateA, req.DateB)
+	if err != nil {
+		writeDomainError(w, err, "swapProgramDays")
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.RescheduleResponse{
+		Moves:    moves,
+		Sessions: requests.ScheduledSessionsToResponse(sessions),
+	})
+}
+
+// pushProgramDay handles POST /api/program-installations/{id}/push
+// Moves today's program session to tomorrow, making today a rest day.
+func (s *Server) pushProgramDay(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Installation ID must be a number")
+		return
+	}
+
+	moves, sessions, err := s.programService.PushToday(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "pushProgramDay")
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.RescheduleResponse{
+		Moves:    moves,
+		Sessions: requests.ScheduledSessionsToResponse(sessions),
+	})
+}
+
+// setInstallationAutoregulation handles PUT /api/program-installations/{id}/autoregulation
+// Turns readiness-scaled prescriptions on (with an optional bound) or off.
+func (s *Server) setInstallationAutoregulation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Installation ID must be a number")
+		return
+	}
+
+	var req requests.SetAutoregulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	installation, err := s.programService.SetAutoregulation(r.Context(), id, req.Enabled, req.Bound)
+	if err != nil {
+		writeDomainError(w, err, "setInstallationAutoregulation")
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.InstallationToResponse(installation, s.now()))
+}
+
+// setInstallationDeloadDayTypes handles PUT /api/program-installations/{id}/deload-day-types
+// Overrides (or restores) the biasing of deload weeks' nutrition days.
+func (s *Server) setInstallationDeloadDayTypes(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Installation ID must be a number")
+		return
+	}
+
+	var req requests.SetDeloadDayTypesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	installation, err := s.programService.SetKeepDeloadDayTypes(r.Context(), id, req.Keep)
+	if err != nil {
+		writeDomainError(w, err, "setInstallationDeloadDayTypes")
+		return
+	}
+	writeJSON(w, http.StatusOK, requests.InstallationToResponse(installation, s.now()))
+}
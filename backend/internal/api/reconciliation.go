@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// reconcileLateData patches derived values for a past day after late data arrives.
+// Failures are logged and never fail the sync that triggered them.
+func (s *Server) reconcileLateData(ctx context.Context, date string, fields []domain.LateDataField) {
+	if _, err := s.reconciliationService.ReconcileDate(ctx, date, fields, s.now()); err != nil {
+		log.Printf("reconciliation failed for %s: %v", date, err)
+	}
+}
+
+// reconcileDailyLog handles POST /api/logs/{date}/reconcile
+// Manually re-runs reconciliation for a past day across all late-data fields.
+func (s *Server) reconcileDailyLog(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "Date must be in YYYY-MM-DD format")
+		return
+	}
+
+	now := s.now()
+	if !domain.IsLateArrival(date, now) {
+		writeError(w, http.StatusBadRequest, "not_past_date", "Only past days can be reconciled")
+		return
+	}
+
+	result, err := s.reconciliationService.ReconcileDate(r.Context(), date, domain.AllLateDataFields, now)
+	if err != nil {
+		writeInternalError(w, err, "reconcileDailyLog")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// getStaleDebriefs handles GET /api/debrief/stale
+// Lists weeks whose debriefs were invalidated by late data and need regeneration.
+func (s *Server) getStaleDebriefs(w http.ResponseWriter, r *http.Request) {
+	stale, err := s.reconciliationService.ListStaleDebriefs(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getStaleDebriefs")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"weeks": stale})
+}
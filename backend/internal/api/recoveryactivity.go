@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// LogRecoveryActivityRequest is the body of POST /api/logs/{date}/recovery-activities.
+type LogRecoveryActivityRequest struct {
+	Type        string `json:"type"`
+	DurationMin int    `json:"durationMin"`
+	Intensity   int    `json:"intensity,omitempty"` // 1-5; defaults to 3 (moderate)
+}
+
+// RecoveryActivityResponse is a logged activity with its net recovery points.
+type RecoveryActivityResponse struct {
+	domain.RecoveryActivity
+	NetPoints float64 `json:"netPoints"` // Benefit less load; negative when the session costs more than it gives
+}
+
+// RecoveryActivityTypeResponse is one entry of the recovery protocol library.
+type RecoveryActivityTypeResponse struct {
+	Type domain.RecoveryActivityType `json:"type"`
+	domain.RecoveryActivityCoefficients
+}
+
+func recoveryActivityToResponse(a domain.RecoveryActivity) RecoveryActivityResponse {
+	return RecoveryActivityResponse{RecoveryActivity: a, NetPoints: a.NetPoints()}
+}
+
+// getRecoveryActivityLibrary handles GET /api/recovery-activities/library
+// Lists the activity types with their per-minute coefficients at moderate intensity.
+func (s *Server) getRecoveryActivityLibrary(w http.ResponseWriter, r *http.Request) {
+	library := make([]RecoveryActivityTypeResponse, 0, len(domain.RecoveryActivityLibrary))
+	for t, coeffs := range domain.RecoveryActivityLibrary {
+		library = append(library, RecoveryActivityTypeResponse{Type: t, RecoveryActivityCoefficients: coeffs})
+	}
+	sort.Slice(library, func(i, j int) bool { return library[i].Type < library[j].Type })
+	writeJSON(w, http.StatusOK, library)
+}
+
+// listRecoveryActivities handles GET /api/logs/{date}/recovery-activities
+func (s *Server) listRecoveryActivities(w http.ResponseWriter, r *http.Request) {
+	activities, err := s.recoveryLogService.ListForDate(r.Context(), r.PathValue("date"))
+	if err != nil {
+		writeInternalError(w, err, "listRecoveryActivities")
+		return
+	}
+	resp := make([]RecoveryActivityResponse, len(activities))
+	for i, a := range activities {
+		resp[i] = recoveryActivityToResponse(a)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// logRecoveryActivity handles POST /api/logs/{date}/recovery-activities
+// Logs one sauna, cold, massage or similar session; no daily log is needed for the date.
+func (s *Server) logRecoveryActivity(w http.ResponseWriter, r *http.Request) {
+	var req LogRecoveryActivityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	activity, err := s.recoveryLogService.Log(r.Context(), domain.RecoveryActivity{
+		Date:        r.PathValue("date"),
+		Type:        domain.RecoveryActivityType(req.Type),
+		DurationMin: req.DurationMin,
+		Intensity:   req.Intensity,
+	})
+	if err != nil {
+		writeDomainError(w, err, "logRecoveryActivity")
+		return
+	}
+	writeJSON(w, http.StatusCreated, recoveryActivityToResponse(*activity))
+}
+
+// deleteRecoveryActivity handles DELETE /api/recovery-activities/{id}
+func (s *Server) deleteRecoveryActivity(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.recoveryLogService.Delete(r.Context(), id); err != nil {
+		writeDomainError(w, err, "deleteRecoveryActivity")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
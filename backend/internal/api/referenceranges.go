@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// PersonalReferenceRangeResponse is a metric's percentiles over the trailing 90 days.
+type PersonalReferenceRangeResponse struct {
+	Metric      string  `json:"metric"` // hrv, resting_hr, sleep_quality
+	P10         float64 `json:"p10"`
+	P25         float64 `json:"p25"`
+	P50         float64 `json:"p50"`
+	P75         float64 `json:"p75"`
+	P90         float64 `json:"p90"`
+	SampleCount int     `json:"sampleCount"`
+	WindowStart string  `json:"windowStart"`
+	WindowEnd   string  `json:"windowEnd"`
+	ComputedAt  string  `json:"computedAt"`
+}
+
+// PersonalReferenceRangesResponse lists the metrics with a personal range;
+// missing metrics use the generic thresholds.
+type PersonalReferenceRangesResponse struct {
+	Ranges []PersonalReferenceRangeResponse `json:"ranges"`
+}
+
+func referenceRangesToResponse(ranges []domain.PersonalReferenceRange) PersonalReferenceRangesResponse {
+	resp := PersonalReferenceRangesResponse{Ranges: make([]PersonalReferenceRangeResponse, len(ranges))}
+	for i, r := range ranges {
+		resp.Ranges[i] = PersonalReferenceRangeResponse{
+			Metric:      string(r.Metric),
+			P10:         r.P10,
+			P25:         r.P25,
+			P50:         r.P50,
+			P75:         r.P75,
+			P90:         r.P90,
+			SampleCount: r.SampleCount,
+			WindowStart: r.WindowStart,
+			WindowEnd:   r.WindowEnd,
+			ComputedAt:  r.ComputedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return resp
+}
+
+// getReferenceRanges handles GET /api/reference-ranges
+func (s *Server) getReferenceRanges(w http.ResponseWriter, r *http.Request) {
+	ranges, err := s.referenceRangeService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getReferenceRanges")
+		return
+	}
+	writeJSON(w, http.StatusOK, referenceRangesToResponse(ranges))
+}
+
+// recomputeReferenceRanges handles POST /api/admin/reference-ranges/recompute
+// Rebuilds the ranges now instead of waiting for the nightly job.
+func (s *Server) recomputeReferenceRanges(w http.ResponseWriter, r *http.Request) {
+	ranges, err := s.referenceRangeService.Recompute(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "recomputeReferenceRanges")
+		return
+	}
+	writeJSON(w, http.StatusOK, referenceRangesToResponse(ranges))
+}
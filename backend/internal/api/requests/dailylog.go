@@ -8,17 +8,23 @@ import (
 
 // TrainingSessionRequest represents a single training session in API requests.
 type TrainingSessionRequest struct {
-	Type        string `json:"type"`
-	DurationMin int    `json:"durationMin"`
-	Notes       string `json:"notes,omitempty"`
+	Type        string   `json:"type"`
+	DurationMin int      `json:"durationMin"`
+	Archetype   string   `json:"archetype,omitempty"` // Planned archetype; strength defaults to the next in the rotation
+	Notes       string   `json:"notes,omitempty"`
+	Environment []string `json:"environment,omitempty"` // "hot", "humid", "altitude"
 }
 
 // ActualTrainingSessionRequest represents an actual training session in API requests.
 type ActualTrainingSessionRequest struct {
-	Type               string `json:"type"`
-	DurationMin        int    `json:"durationMin"`
-	PerceivedIntensity *int   `json:"perceivedIntensity,omitempty"` // RPE 1-10
-	Notes              string `json:"notes,omitempty"`
+	Type               string   `json:"type"`
+	DurationMin        int      `json:"durationMin"`
+	PerceivedIntensity *int     `json:"perceivedIntensity,omitempty"` // RPE 1-10
+	AvgHeartRate       *int     `json:"avgHeartRate,omitempty"`       // Average bpm, improves calorie estimates
+	Archetype          string   `json:"archetype,omitempty"`          // Workout archetype, if known
+	StartTime          string   `json:"startTime,omitempty"`          // HH:MM the session started
+	Notes              string   `json:"notes,omitempty"`
+	Environment        []string `json:"environment,omitempty"` // "hot", "humid", "altitude"
 }
 
 // UpdateActualTrainingRequest is the request body for PATCH /api/logs/:date/actual-training.
@@ -36,46 +42,94 @@ type UpdateFastingOverrideRequest struct {
 	FastingOverride *string `json:"fastingOverride"` // "standard", "16_8", "20_4", or null to clear
 }
 
+// UpdateCheckInRequest is the request body for PATCH /api/logs/:date/check-in.
+type UpdateCheckInRequest struct {
+	Mood     int  `json:"mood"`             // 1 (awful) to 5 (great)
+	Stress   int  `json:"stress"`           // 1 (none) to 5 (severe)
+	Soreness int  `json:"soreness"`         // 1 (none) to 5 (severe)
+	Hunger   *int `json:"hunger,omitempty"` // Optional: 1 (none) to 5 (severe)
+}
+
+// UpdateEnvironmentRequest is the request body for PATCH /api/logs/:date/environment.
+type UpdateEnvironmentRequest struct {
+	Environment []string `json:"environment"` // "hot", "humid", "altitude"; empty to clear
+}
+
 // AddConsumedMacrosRequest is the request body for PATCH /api/logs/:date/consumed-macros.
 // Macros are additive - they are added to the existing totals.
 // If Meal is specified, also updates per-meal consumed values.
 type AddConsumedMacrosRequest struct {
-	Meal     *string `json:"meal,omitempty"` // Optional: "breakfast", "lunch", or "dinner"
+	Meal     *string `json:"meal,omitempty"`    // Optional: "breakfast", "lunch", or "dinner"
+	EatenAt  *string `json:"eatenAt,omitempty"` // Optional: HH:MM, records a timed meal entry
 	Calories int     `json:"calories"`
 	ProteinG int     `json:"proteinG"`
 	CarbsG   int     `json:"carbsG"`
 	FatG     int     `json:"fatG"`
 }
 
+// MealItemRequest is the request body for logging or replacing a food item
+// under /api/logs/:date/meal-items.
+type MealItemRequest struct {
+	FoodReferenceID int64   `json:"foodReferenceId"`
+	Grams           float64 `json:"grams"`
+	Meal            *string `json:"meal,omitempty"`    // Optional: "breakfast", "lunch", or "dinner"
+	EatenAt         *string `json:"eatenAt,omitempty"` // Optional: HH:MM, feeds meal timing
+}
+
+// MealItemInputFromRequest converts a MealItemRequest to a domain.MealItemInput.
+func MealItemInputFromRequest(req MealItemRequest) domain.MealItemInput {
+	in := domain.MealItemInput{
+		FoodReferenceID: req.FoodReferenceID,
+		Grams:           req.Grams,
+	}
+	if req.Meal != nil {
+		meal := domain.MealName(*req.Meal)
+		in.Meal = &meal
+	}
+	if req.EatenAt != nil {
+		in.EatenAt = *req.EatenAt
+	}
+	return in
+}
+
 // CreateDailyLogRequest is the request body for POST /api/logs.
 type CreateDailyLogRequest struct {
 	Date                    string                   `json:"date,omitempty"`
 	WeightKg                float64                  `json:"weightKg"`
+	WeighInTime             *string                  `json:"weighInTime,omitempty"` // HH:MM of the weigh-in
 	BodyFatPercent          *float64                 `json:"bodyFatPercent,omitempty"`
 	RestingHeartRate        *int                     `json:"restingHeartRate,omitempty"`
 	HRVMs                   *int                     `json:"hrvMs,omitempty"` // Heart Rate Variability in milliseconds
 	SleepQuality            int                      `json:"sleepQuality"`
 	SleepHours              *float64                 `json:"sleepHours,omitempty"`
+	Bedtime                 *string                  `json:"bedtime,omitempty"` // HH:MM of the night the sleep describes
 	PlannedTrainingSessions []TrainingSessionRequest `json:"plannedTrainingSessions"`
 	DayType                 string                   `json:"dayType,omitempty"`
 	Notes                   string                   `json:"notes,omitempty"`
+	Environment             []string                 `json:"environment,omitempty"` // "hot", "humid", "altitude"
 }
 
 // TrainingSessionResponse represents a training session in API responses.
 type TrainingSessionResponse struct {
-	SessionOrder int    `json:"sessionOrder"`
-	Type         string `json:"type"`
-	DurationMin  int    `json:"durationMin"`
-	Notes        string `json:"notes,omitempty"`
+	SessionOrder int                           `json:"sessionOrder"`
+	Type         string                        `json:"type"`
+	DurationMin  int                           `json:"durationMin"`
+	Archetype    string                        `json:"archetype,omitempty"`
+	Notes        string                        `json:"notes,omitempty"`
+	Environment  []domain.EnvironmentCondition `json:"environment,omitempty"`
 }
 
 // ActualTrainingSessionResponse represents an actual training session in API responses.
 type ActualTrainingSessionResponse struct {
-	SessionOrder       int    `json:"sessionOrder"`
-	Type               string `json:"type"`
-	DurationMin        int    `json:"durationMin"`
-	PerceivedIntensity *int   `json:"perceivedIntensity,omitempty"`
-	Notes              string `json:"notes,omitempty"`
+	SessionOrder       int                           `json:"sessionOrder"`
+	Type               string                        `json:"type"`
+	DurationMin        int                           `json:"durationMin"`
+	PerceivedIntensity *int                          `json:"perceivedIntensity,omitempty"`
+	AvgHeartRate       *int                          `json:"avgHeartRate,omitempty"`
+	Archetype          string                        `json:"archetype,omitempty"`
+	StartTime          string                        `json:"startTime,omitempty"`
+	Notes              string                        `json:"notes,omitempty"`
+	Environment        []domain.EnvironmentCondition `json:"environment,omitempty"`
 }
 
 // TrainingSummaryResponse provides aggregate info about training sessions.
@@ -96,11 +150,12 @@ type TrainingLoadResponse struct {
 
 // RecoveryScoreResponse contains recovery score with component breakdown.
 type RecoveryScoreResponse struct {
-	Score          float64 `json:"score"`                    // Total score 0-100
-	RestComponent  float64 `json:"restComponent"`            // Rest days component (0-35)
-	ACRComponent   float64 `json:"acrComponent"`             // ACR zone component (0-30)
-	SleepComponent float64 `json:"sleepComponent"`           // Sleep quality component (0-20)
-	RHRComponent   float64 `json:"rhrComponent,omitempty"`   // RHR deviation component (0-15)
+	Score             float64 `json:"score"`                       // Total score 0-100
+	RestComponent     float64 `json:"restComponent"`               // Rest days component (0-35)
+	ACRComponent      float64 `json:"acrComponent"`                // ACR zone component (0-30)
+	SleepComponent    float64 `json:"sleepComponent"`              // Sleep quality component (0-20)
+	RHRComponent      float64 `json:"rhrComponent,omitempty"`      // RHR deviation component (0-15)
+	ActivityComponent float64 `json:"activityComponent,omitempty"` // Recovery activities component (-10 to +10)
 }
 
 // AdjustmentMultipliersResponse contains adjustment factors for daily TDEE.
@@ -114,10 +169,13 @@ type AdjustmentMultipliersResponse struct {
 
 // CNSStatusResponse contains CNS status from HRV analysis.
 type CNSStatusResponse struct {
-	CurrentHRV   int     `json:"currentHrv"`   // Today's HRV in ms
-	BaselineHRV  float64 `json:"baselineHrv"`  // 7-day moving average
-	DeviationPct float64 `json:"deviationPct"` // (current - baseline) / baseline
-	Status       string  `json:"status"`       // optimized, strained, depleted
+	CurrentHRV       int     `json:"currentHrv"`                 // Today's HRV in ms
+	BaselineHRV      float64 `json:"baselineHrv"`                // 7-day moving average
+	DeviationPct     float64 `json:"deviationPct"`               // (current - baseline) / baseline
+	Status           string  `json:"status"`                     // optimized, strained, depleted
+	SubjectiveStrain bool    `json:"subjectiveStrain,omitempty"` // Check-in escalated or confirmed strain
+	BelowReference   bool    `json:"belowReference,omitempty"`   // 7-day average below the reference range
+	ReferenceSource  string  `json:"referenceSource,omitempty"`  // personal (trailing 90 days) or garmin
 }
 
 // TrainingOverrideResponse contains recommended training modification when CNS depleted.
@@ -191,11 +249,13 @@ type DailyTargetsRangeResponse struct {
 type DailyLogResponse struct {
 	Date                    string                          `json:"date"`
 	WeightKg                float64                         `json:"weightKg"`
+	WeighInTime             *string                         `json:"weighInTime,omitempty"`
 	BodyFatPercent          *float64                        `json:"bodyFatPercent,omitempty"`
 	RestingHeartRate        *int                            `json:"restingHeartRate,omitempty"`
 	HRVMs                   *int                            `json:"hrvMs,omitempty"`                 // Heart Rate Variability in milliseconds
 	SleepQuality            int                             `json:"sleepQuality"`
 	SleepHours              *float64                        `json:"sleepHours,omitempty"`
+	Bedtime                 *string                         `json:"bedtime,omitempty"`
 	PlannedTrainingSessions []TrainingSessionResponse       `json:"plannedTrainingSessions"`
 	ActualTrainingSessions  []ActualTrainingSessionResponse `json:"actualTrainingSessions,omitempty"`
 	TrainingSummary         TrainingSummaryResponse         `json:"trainingSummary"`
@@ -211,18 +271,22 @@ type DailyLogResponse struct {
 	AdjustmentMultipliers   *AdjustmentMultipliersResponse  `json:"adjustmentMultipliers,omitempty"` // Adjustment multipliers breakdown
 	CNSStatus               *CNSStatusResponse              `json:"cnsStatus,omitempty"`             // CNS status from HRV analysis
 	TrainingOverrides       []TrainingOverrideResponse      `json:"trainingOverrides,omitempty"`     // Training adjustments when CNS depleted
-	ActiveCaloriesBurned    *int                            `json:"activeCaloriesBurned,omitempty"`  // User-entered active calories from wearable
+	ActiveCaloriesBurned    *int                            `json:"activeCaloriesBurned,omitempty"`  // Active calories from a wearable or estimated from sessions
+	ActiveCaloriesSource    string                          `json:"activeCaloriesSource,omitempty"`  // "wearable" or "estimated"
 	Steps                   *int                            `json:"steps,omitempty"`                 // Daily step count from wearable
 	BMRPrecisionMode        bool                            `json:"bmrPrecisionMode,omitempty"`      // True if Katch-McArdle auto-selected using recent body fat
 	BodyFatUsedDate         *string                         `json:"bodyFatUsedDate,omitempty"`       // Date of body fat measurement used for precision BMR
 	Notes                   string                          `json:"notes,omitempty"`                 // Daily notes/observations
 	FastingOverride         *string                         `json:"fastingOverride,omitempty"`       // Override for fasting protocol (nil = use profile)
+	CheckIn                 *domain.CheckIn                 `json:"checkIn,omitempty"`               // Mood, stress and soreness (1-5)
+	Environment             []domain.EnvironmentCondition   `json:"environment,omitempty"`           // Day's conditions (hot, humid, altitude)
 	FastedItemsKcal         int                             `json:"fastedItemsKcal"`                 // Calories logged during fasting window
 	ConsumedCalories        int                             `json:"consumedCalories"`                // Total consumed calories
 	ConsumedProteinG        int                             `json:"consumedProteinG"`                // Total consumed protein in grams
 	ConsumedCarbsG          int                             `json:"consumedCarbsG"`                  // Total consumed carbs in grams
 	ConsumedFatG            int                             `json:"consumedFatG"`                    // Total consumed fat in grams
 	MealsConsumed           MealsConsumedResponse           `json:"mealsConsumed"`                   // Per-meal consumed macros
+	ActiveRecovery          *domain.ActiveRecoverySuggestion `json:"activeRecovery,omitempty"`        // Optional rest-day protocol (today only)
 	CreatedAt               string                          `json:"createdAt,omitempty"`
 	UpdatedAt               string                          `json:"updatedAt,omitempty"`
 }
@@ -236,13 +300,27 @@ func ActualTrainingFromRequest(req UpdateActualTrainingRequest) ([]domain.Traini
 		if err != nil {
 			return nil, err
 		}
+		environment, err := domain.ParseEnvironment(s.Environment)
+		if err != nil {
+			return nil, err
+		}
+		var archetype domain.Archetype
+		if s.Archetype != "" {
+			if archetype, err = domain.ParseArchetype(s.Archetype); err != nil {
+				return nil, err
+			}
+		}
 		sessions[i] = domain.TrainingSession{
 			SessionOrder:       i + 1,
 			IsPlanned:          false,
 			Type:               trainingType,
 			DurationMin:        s.DurationMin,
 			PerceivedIntensity: s.PerceivedIntensity,
+			AvgHeartRate:       s.AvgHeartRate,
+			Archetype:          archetype,
+			StartTime:          s.StartTime,
 			Notes:              s.Notes,
+			Environment:        environment,
 		}
 	}
 	return sessions, nil
@@ -257,12 +335,24 @@ func DailyLogInputFromRequest(req CreateDailyLogRequest) (domain.DailyLogInput,
 		if err != nil {
 			return domain.DailyLogInput{}, err
 		}
+		environment, err := domain.ParseEnvironment(s.Environment)
+		if err != nil {
+			return domain.DailyLogInput{}, err
+		}
+		var archetype domain.Archetype
+		if s.Archetype != "" {
+			if archetype, err = domain.ParseArchetype(s.Archetype); err != nil {
+				return domain.DailyLogInput{}, err
+			}
+		}
 		sessions[i] = domain.TrainingSession{
 			SessionOrder: i + 1,
 			IsPlanned:    true,
 			Type:         trainingType,
 			DurationMin:  s.DurationMin,
+			Archetype:    archetype,
 			Notes:        s.Notes,
+			Environment:  environment,
 		}
 	}
 
@@ -272,17 +362,25 @@ func DailyLogInputFromRequest(req CreateDailyLogRequest) (domain.DailyLogInput,
 		return domain.DailyLogInput{}, err
 	}
 
+	environment, err := domain.ParseEnvironment(req.Environment)
+	if err != nil {
+		return domain.DailyLogInput{}, err
+	}
+
 	return domain.DailyLogInput{
 		Date:             req.Date,
 		WeightKg:         req.WeightKg,
+		WeighInTime:      req.WeighInTime,
 		BodyFatPercent:   req.BodyFatPercent,
 		RestingHeartRate: req.RestingHeartRate,
 		HRVMs:            req.HRVMs,
 		SleepQuality:     domain.SleepQuality(req.SleepQuality),
 		SleepHours:       req.SleepHours,
+		Bedtime:          req.Bedtime,
 		PlannedSessions:  sessions,
 		DayType:          dayType,
 		Notes:            req.Notes,
+		Environment:      environment,
 	}, nil
 }
 
@@ -305,11 +403,12 @@ func RecoveryScoreToResponse(r *domain.RecoveryScore) *RecoveryScoreResponse {
 		return nil
 	}
 	return &RecoveryScoreResponse{
-		Score:          r.Score,
-		RestComponent:  r.RestComponent,
-		ACRComponent:   r.ACRComponent,
-		SleepComponent: r.SleepComponent,
-		RHRComponent:   r.RHRComponent,
+		Score:             r.Score,
+		RestComponent:     r.RestComponent,
+		ACRComponent:      r.ACRComponent,
+		SleepComponent:    r.SleepComponent,
+		RHRComponent:      r.RHRComponent,
+		ActivityComponent: r.ActivityComponent,
 	}
 }
 
@@ -333,10 +432,13 @@ func CNSStatusToResponse(c *domain.CNSResult) *CNSStatusResponse {
 		return nil
 	}
 	return &CNSStatusResponse{
-		CurrentHRV:   c.CurrentHRV,
-		BaselineHRV:  c.BaselineHRV,
-		DeviationPct: c.DeviationPct,
-		Status:       string(c.Status),
+		CurrentHRV:       c.CurrentHRV,
+		BaselineHRV:      c.BaselineHRV,
+		DeviationPct:     c.DeviationPct,
+		Status:           string(c.Status),
+		SubjectiveStrain: c.SubjectiveStrain,
+		BelowReference:   c.BelowReference,
+		ReferenceSource:  c.ReferenceSource,
 	}
 }
 
@@ -430,7 +532,9 @@ func trainingSessionsToResponse(sessions []domain.TrainingSession) []TrainingSes
 			SessionOrder: s.SessionOrder,
 			Type:         string(s.Type),
 			DurationMin:  s.DurationMin,
+			Archetype:    string(s.Archetype),
 			Notes:        s.Notes,
+			Environment:  s.Environment,
 		}
 	}
 	return resp
@@ -448,7 +552,11 @@ func actualTrainingSessionsToResponse(sessions []domain.TrainingSession) []Actua
 			Type:               string(s.Type),
 			DurationMin:        s.DurationMin,
 			PerceivedIntensity: s.PerceivedIntensity,
+			AvgHeartRate:       s.AvgHeartRate,
+			Archetype:          string(s.Archetype),
+			StartTime:          s.StartTime,
 			Notes:              s.Notes,
+			Environment:        s.Environment,
 		}
 	}
 	return resp
@@ -468,7 +576,9 @@ func DailyLogToResponseWithTrainingLoad(d *domain.DailyLog, trainingLoad *domain
 			SessionOrder: s.SessionOrder,
 			Type:         string(s.Type),
 			DurationMin:  s.DurationMin,
+			Archetype:    string(s.Archetype),
 			Notes:        s.Notes,
+			Environment:  s.Environment,
 		}
 	}
 
@@ -482,7 +592,11 @@ func DailyLogToResponseWithTrainingLoad(d *domain.DailyLog, trainingLoad *domain
 				Type:               string(s.Type),
 				DurationMin:        s.DurationMin,
 				PerceivedIntensity: s.PerceivedIntensity,
+				AvgHeartRate:       s.AvgHeartRate,
+				Archetype:          string(s.Archetype),
+				StartTime:          s.StartTime,
 				Notes:              s.Notes,
+				Environment:        s.Environment,
 			}
 		}
 	}
@@ -492,11 +606,13 @@ func DailyLogToResponseWithTrainingLoad(d *domain.DailyLog, trainingLoad *domain
 	resp := DailyLogResponse{
 		Date:                    d.Date,
 		WeightKg:                d.WeightKg,
+		WeighInTime:             d.WeighInTime,
 		BodyFatPercent:          d.BodyFatPercent,
 		RestingHeartRate:        d.RestingHeartRate,
 		HRVMs:                   d.HRVMs,
 		SleepQuality:            int(d.SleepQuality),
 		SleepHours:              d.SleepHours,
+		Bedtime:                 d.Bedtime,
 		PlannedTrainingSessions: plannedSessions,
 		ActualTrainingSessions:  actualSessions,
 		TrainingSummary: TrainingSummaryResponse{
@@ -518,10 +634,13 @@ func DailyLogToResponseWithTrainingLoad(d *domain.DailyLog, trainingLoad *domain
 		CNSStatus:             CNSStatusToResponse(d.CNSResult),
 		TrainingOverrides:     TrainingOverridesToResponse(d.TrainingOverrides),
 		ActiveCaloriesBurned:  d.ActiveCaloriesBurned,
+		ActiveCaloriesSource:  string(d.ActiveCaloriesSource),
 		Steps:                 d.Steps,
 		BMRPrecisionMode:      d.BMRPrecisionMode,
 		BodyFatUsedDate:       d.BodyFatUsedDate,
 		Notes:                 d.Notes,
+		CheckIn:               d.CheckIn,
+		Environment:           d.Environment,
 		FastedItemsKcal:       d.FastedItemsKcal,
 		ConsumedCalories:      d.ConsumedCalories,
 		ConsumedProteinG:      d.ConsumedProteinG,
@@ -6,23 +6,34 @@ import (
 
 // WeeklyDebriefResponse is the API response for weekly debrief.
 type WeeklyDebriefResponse struct {
-	WeekStartDate   string                        `json:"weekStartDate"`
-	WeekEndDate     string                        `json:"weekEndDate"`
-	VitalityScore   VitalityScoreResponse         `json:"vitalityScore"`
-	Narrative       NarrativeResponse             `json:"narrative"`
-	Recommendations []RecommendationResponse      `json:"recommendations"`
-	DailyBreakdown  []DebriefDayResponse          `json:"dailyBreakdown"`
-	GeneratedAt     string                        `json:"generatedAt"`
+	WeekStartDate    string                        `json:"weekStartDate"`
+	WeekEndDate      string                        `json:"weekEndDate"`
+	VitalityScore    VitalityScoreResponse         `json:"vitalityScore"`
+	Narrative        NarrativeResponse             `json:"narrative"`
+	Recommendations  []RecommendationResponse      `json:"recommendations"`
+	DailyBreakdown   []DebriefDayResponse          `json:"dailyBreakdown"`
+	AutoClosedDrafts int                           `json:"autoClosedDrafts"`
+	Caffeine         *domain.CaffeineSleepAnalysis `json:"caffeine,omitempty"`
+	EnvironmentNotes []domain.EnvironmentNote      `json:"environmentNotes"`
+	Substitutions    []domain.SubstitutionDecision `json:"substitutions"`
+	MacroCompliance  domain.MacroComplianceSummary `json:"macroCompliance"`
+	Tolerances       domain.AdherenceTolerances    `json:"tolerances"`           // Bands the week was scored with
+	Challenges       []domain.ChallengeProgress    `json:"challenges,omitempty"` // Only while a challenge is running
+	// Only when adaptation was detected during the week
+	MetabolicAdaptation *domain.MetabolicAdaptation `json:"metabolicAdaptation,omitempty"`
+	GeneratedAt         string                      `json:"generatedAt"`
 }
 
 // VitalityScoreResponse represents the weekly vitality score.
 type VitalityScoreResponse struct {
 	Overall           float64               `json:"overall"`
+	ScoringProfile    string                `json:"scoringProfile"`
 	MealAdherence     float64               `json:"mealAdherence"`
 	TrainingAdherence float64               `json:"trainingAdherence"`
 	WeightDelta       float64               `json:"weightDelta"`
 	TrendWeight       float64               `json:"trendWeight"`
 	MetabolicFlux     MetabolicFluxResponse `json:"metabolicFlux"`
+	HabitAdherence    *float64              `json:"habitAdherence,omitempty"` // Only when a habit counts toward vitality
 }
 
 // MetabolicFluxResponse represents the metabolic trend for the week.
@@ -50,35 +61,43 @@ type RecommendationResponse struct {
 
 // DebriefDayResponse represents a single day in the weekly breakdown.
 type DebriefDayResponse struct {
-	Date             string   `json:"date"`
-	DayName          string   `json:"dayName"`
-	DayType          string   `json:"dayType"`
-	TargetCalories   int      `json:"targetCalories"`
-	ConsumedCalories int      `json:"consumedCalories"`
-	CalorieDelta     int      `json:"calorieDelta"`
-	TargetProteinG   int      `json:"targetProteinG"`
-	ConsumedProteinG int      `json:"consumedProteinG"`
-	ProteinPercent   float64  `json:"proteinPercent"`
-	PlannedSessions  int      `json:"plannedSessions"`
-	ActualSessions   int      `json:"actualSessions"`
-	TrainingLoad     float64  `json:"trainingLoad"`
-	AvgRPE           *float64 `json:"avgRpe,omitempty"`
-	HRVMs            *int     `json:"hrvMs,omitempty"`
-	CNSStatus        *string  `json:"cnsStatus,omitempty"`
-	SleepQuality     int      `json:"sleepQuality"`
-	SleepHours       *float64 `json:"sleepHours,omitempty"`
-	Notes            string   `json:"notes,omitempty"`
+	Date             string                        `json:"date"`
+	DayName          string                        `json:"dayName"`
+	DayType          string                        `json:"dayType"`
+	TargetCalories   int                           `json:"targetCalories"`
+	ConsumedCalories int                           `json:"consumedCalories"`
+	CalorieDelta     int                           `json:"calorieDelta"`
+	TargetProteinG   int                           `json:"targetProteinG"`
+	ConsumedProteinG int                           `json:"consumedProteinG"`
+	ProteinPercent   float64                       `json:"proteinPercent"`
+	PlannedSessions  int                           `json:"plannedSessions"`
+	ActualSessions   int                           `json:"actualSessions"`
+	TrainingLoad     float64                       `json:"trainingLoad"`
+	AvgRPE           *float64                      `json:"avgRpe,omitempty"`
+	HRVMs            *int                          `json:"hrvMs,omitempty"`
+	CNSStatus        *string                       `json:"cnsStatus,omitempty"`
+	SleepQuality     int                           `json:"sleepQuality"`
+	SleepHours       *float64                      `json:"sleepHours,omitempty"`
+	Notes            string                        `json:"notes,omitempty"`
+	Environment      []domain.EnvironmentCondition `json:"environment,omitempty"`
 }
 
 // WeeklyDebriefToResponse converts a domain WeeklyDebrief to the API response.
 func WeeklyDebriefToResponse(debrief *domain.WeeklyDebrief) WeeklyDebriefResponse {
 	if debrief == nil {
 		return WeeklyDebriefResponse{
-			Recommendations: []RecommendationResponse{},
-			DailyBreakdown:  []DebriefDayResponse{},
+			Recommendations:  []RecommendationResponse{},
+			DailyBreakdown:   []DebriefDayResponse{},
+			EnvironmentNotes: []domain.EnvironmentNote{},
+			Substitutions:    []domain.SubstitutionDecision{},
 		}
 	}
 
+	substitutions := debrief.Substitutions
+	if substitutions == nil {
+		substitutions = []domain.SubstitutionDecision{}
+	}
+
 	// Convert recommendations
 	recommendations := make([]RecommendationResponse, len(debrief.Recommendations))
 	for i, rec := range debrief.Recommendations {
@@ -112,6 +131,7 @@ func WeeklyDebriefToResponse(debrief *domain.WeeklyDebrief) WeeklyDebriefRespons
 			SleepQuality:     day.SleepQuality,
 			SleepHours:       day.SleepHours,
 			Notes:            day.Notes,
+			Environment:      day.Environment,
 		}
 		if day.CNSStatus != nil {
 			status := string(*day.CNSStatus)
@@ -125,6 +145,7 @@ func WeeklyDebriefToResponse(debrief *domain.WeeklyDebrief) WeeklyDebriefRespons
 		WeekEndDate:   debrief.WeekEndDate,
 		VitalityScore: VitalityScoreResponse{
 			Overall:           debrief.VitalityScore.Overall,
+			ScoringProfile:    string(debrief.VitalityScore.ScoringProfile),
 			MealAdherence:     debrief.VitalityScore.MealAdherence,
 			TrainingAdherence: debrief.VitalityScore.TrainingAdherence,
 			WeightDelta:       debrief.VitalityScore.WeightDelta,
@@ -135,13 +156,22 @@ func WeeklyDebriefToResponse(debrief *domain.WeeklyDebrief) WeeklyDebriefRespons
 				DeltaKcal: debrief.VitalityScore.MetabolicFlux.DeltaKcal,
 				Trend:     debrief.VitalityScore.MetabolicFlux.Trend,
 			},
+			HabitAdherence: debrief.VitalityScore.HabitAdherence,
 		},
 		Narrative: NarrativeResponse{
 			Text:           debrief.Narrative.Text,
 			GeneratedByLLM: debrief.Narrative.GeneratedByLLM,
 		},
-		Recommendations: recommendations,
-		DailyBreakdown:  dailyBreakdown,
-		GeneratedAt:     debrief.GeneratedAt,
+		Recommendations:     recommendations,
+		DailyBreakdown:      dailyBreakdown,
+		AutoClosedDrafts:    debrief.AutoClosedDrafts,
+		Caffeine:            debrief.Caffeine,
+		EnvironmentNotes:    debrief.EnvironmentNotes,
+		Substitutions:       substitutions,
+		MacroCompliance:     debrief.MacroCompliance,
+		Tolerances:          debrief.Tolerances,
+		Challenges:          debrief.Challenges,
+		MetabolicAdaptation: debrief.MetabolicAdaptation,
+		GeneratedAt:         debrief.GeneratedAt,
 	}
 }
@@ -1,6 +1,9 @@
 package requests
 
-import "victus/internal/store"
+import (
+	"victus/internal/domain"
+	"victus/internal/store"
+)
 
 // HealthSyncRequest is the request body for PATCH /api/logs/{date}/health-sync.
 // All fields are optional - only provided fields are updated.
@@ -11,6 +14,7 @@ type HealthSyncRequest struct {
 	RHR        *int     `json:"rhr,omitempty"`
 	SleepHours *float64 `json:"sleep_hours,omitempty"`
 	Weight     *float64 `json:"weight,omitempty"`     // kg
+	WeighInAt  *string  `json:"weigh_in_at,omitempty"` // HH:MM of the weight reading
 	BodyFat    *float64 `json:"body_fat,omitempty"`   // percentage 0-100
 }
 
@@ -22,6 +26,26 @@ func (r HealthSyncRequest) ToHealthKitMetrics() store.HealthKitMetrics {
 		RestingHeartRate:     r.RHR,
 		SleepHours:           r.SleepHours,
 		WeightKg:             r.Weight,
+		WeighInTime:          r.WeighInAt,
 		BodyFatPercent:       r.BodyFat,
 	}
 }
+
+// LateDataFields lists the provided metrics that feed derived values, used to
+// reconcile past days when HealthKit delivers data late.
+func (r HealthSyncRequest) LateDataFields() []domain.LateDataField {
+	var fields []domain.LateDataField
+	if r.RHR != nil {
+		fields = append(fields, domain.LateDataRestingHR)
+	}
+	if r.SleepHours != nil {
+		fields = append(fields, domain.LateDataSleep)
+	}
+	if r.ActiveKcal != nil {
+		fields = append(fields, domain.LateDataActiveCalories)
+	}
+	if r.Weight != nil {
+		fields = append(fields, domain.LateDataWeight)
+	}
+	return fields
+}
@@ -13,6 +13,17 @@ type CreatePlanRequest struct {
 	StartWeightKg float64 `json:"startWeightKg"`  // Starting weight in kg
 	GoalWeightKg  float64 `json:"goalWeightKg"`   // Target weight in kg
 	DurationWeeks int     `json:"durationWeeks"`  // Duration in weeks (4-104)
+	// Optional: TDEE = weight × factor (kcal/kg) instead of BMR-based
+	KcalFactorOverride *float64 `json:"kcalFactorOverride,omitempty"`
+	// Optional: tune the factor every 2 weeks from observed results
+	KcalFactorAutoTune bool `json:"kcalFactorAutoTune,omitempty"`
+	// Optional: "weight" (default), "recomp" or "event"; recomp requires start and goal body fat
+	Mode                string   `json:"mode,omitempty"`
+	StartBodyFatPercent *float64 `json:"startBodyFatPercent,omitempty"`
+	GoalBodyFatPercent  *float64 `json:"goalBodyFatPercent,omitempty"`
+	// Event only: weigh-in date (YYYY-MM-DD); durationWeeks may be omitted to run up to it
+	EventDate string `json:"eventDate,omitempty"`
+	EventName string `json:"eventName,omitempty"`
 }
 
 // WeeklyTargetResponse represents a single week's targets in API responses.
@@ -29,48 +40,121 @@ type WeeklyTargetResponse struct {
 	ActualWeightKg    *float64 `json:"actualWeightKg,omitempty"`
 	ActualIntakeKcal  *int     `json:"actualIntakeKcal,omitempty"`
 	DaysLogged        int      `json:"daysLogged"`
+	DaysExpected      int      `json:"daysExpected"`               // 7, fewer when the week began before tracking
+	Prorated          bool     `json:"prorated"`                   // Week began before tracking started
+	LoggingComplete   bool     `json:"loggingComplete"`            // Enough of the expected days logged
+	LoggingAdherence  *float64 `json:"loggingAdherence,omitempty"` // Logged over expected days (0-1)
+	Pinned            bool     `json:"pinned"`                     // Manually edited; kept by regeneration
+}
+
+// DailyPlanTargetResponse is one day's materialized plan targets.
+type DailyPlanTargetResponse struct {
+	Date       string `json:"date"`
+	WeekNumber int    `json:"weekNumber"`
+	DayNumber  int    `json:"dayNumber"`
+	DayType    string `json:"dayType"`
+	CarbsG     int    `json:"carbsG"`
+	ProteinG   int    `json:"proteinG"`
+	FatsG      int    `json:"fatsG"`
+	Calories   int    `json:"calories"`
+}
+
+// AbandonPlanRequest is the optional exit survey body for POST /api/plans/{id}/abandon.
+type AbandonPlanRequest struct {
+	Reasons []string `json:"reasons,omitempty"` // too_aggressive, life_event, injury, illness, lost_motivation, no_progress, other
+	Note    string   `json:"note,omitempty"`
+}
+
+// PlanHistoryWarningResponse flags a new plan repeating abandoned plans.
+type PlanHistoryWarningResponse struct {
+	Reason      string  `json:"reason"`
+	PlanIDs     []int64 `json:"planIds"`
+	Occurrences int     `json:"occurrences"`
+	Message     string  `json:"message"`
 }
 
 // PlanResponse is the response body for plan endpoints.
 type PlanResponse struct {
-	ID                       int64                  `json:"id"`
-	Name                     string                 `json:"name,omitempty"`
-	StartDate                string                 `json:"startDate"`
-	StartWeightKg            float64                `json:"startWeightKg"`
-	GoalWeightKg             float64                `json:"goalWeightKg"`
-	DurationWeeks            int                    `json:"durationWeeks"`
-	RequiredWeeklyChangeKg   float64                `json:"requiredWeeklyChangeKg"`
-	RequiredDailyDeficitKcal float64                `json:"requiredDailyDeficitKcal"`
-	Status                   string                 `json:"status"`
-	CurrentWeek              int                    `json:"currentWeek"` // 0 if not started, >duration if ended
-	WeeklyTargets            []WeeklyTargetResponse `json:"weeklyTargets"`
-	LastRecalibratedAt       string                 `json:"lastRecalibratedAt,omitempty"`
-	CreatedAt                string                 `json:"createdAt,omitempty"`
-	UpdatedAt                string                 `json:"updatedAt,omitempty"`
+	ID                       int64                        `json:"id"`
+	Name                     string                       `json:"name,omitempty"`
+	StartDate                string                       `json:"startDate"`
+	StartWeightKg            float64                      `json:"startWeightKg"`
+	GoalWeightKg             float64                      `json:"goalWeightKg"`
+	DurationWeeks            int                          `json:"durationWeeks"`
+	RequiredWeeklyChangeKg   float64                      `json:"requiredWeeklyChangeKg"`
+	RequiredDailyDeficitKcal float64                      `json:"requiredDailyDeficitKcal"`
+	Status                   string                       `json:"status"`
+	CurrentWeek              int                          `json:"currentWeek"` // 0 if not started, >duration if ended
+	KcalFactorOverride       *float64                     `json:"kcalFactorOverride,omitempty"`
+	KcalFactorAutoTune       bool                         `json:"kcalFactorAutoTune"`
+	KcalFactorTunedAt        string                       `json:"kcalFactorTunedAt,omitempty"`
+	Mode                     string                       `json:"mode"`
+	StartBodyFatPercent      *float64                     `json:"startBodyFatPercent,omitempty"`
+	GoalBodyFatPercent       *float64                     `json:"goalBodyFatPercent,omitempty"`
+	EventDate                string                       `json:"eventDate,omitempty"`
+	EventName                string                       `json:"eventName,omitempty"`
+	DaysToEvent              *int                         `json:"daysToEvent,omitempty"`
+	WeeklyTargets            []WeeklyTargetResponse       `json:"weeklyTargets"`
+	LastRecalibratedAt       string                       `json:"lastRecalibratedAt,omitempty"`
+	AbandonReasons           []string                     `json:"abandonReasons,omitempty"`
+	AbandonNote              string                       `json:"abandonNote,omitempty"`
+	HistoryWarnings          []PlanHistoryWarningResponse `json:"historyWarnings,omitempty"` // Create and dry run only
+	CreatedAt                string                       `json:"createdAt,omitempty"`
+	UpdatedAt                string                       `json:"updatedAt,omitempty"`
 }
 
 // PlanSummaryResponse is a condensed plan response for list endpoints.
 type PlanSummaryResponse struct {
-	ID                     int64   `json:"id"`
-	Name                   string  `json:"name,omitempty"`
-	StartDate              string  `json:"startDate"`
-	StartWeightKg          float64 `json:"startWeightKg"`
-	GoalWeightKg           float64 `json:"goalWeightKg"`
-	DurationWeeks          int     `json:"durationWeeks"`
-	RequiredWeeklyChangeKg float64 `json:"requiredWeeklyChangeKg"`
-	Status                 string  `json:"status"`
-	CurrentWeek            int     `json:"currentWeek"`
+	ID                     int64    `json:"id"`
+	Name                   string   `json:"name,omitempty"`
+	StartDate              string   `json:"startDate"`
+	StartWeightKg          float64  `json:"startWeightKg"`
+	GoalWeightKg           float64  `json:"goalWeightKg"`
+	DurationWeeks          int      `json:"durationWeeks"`
+	RequiredWeeklyChangeKg float64  `json:"requiredWeeklyChangeKg"`
+	Status                 string   `json:"status"`
+	Mode                   string   `json:"mode"`
+	CurrentWeek            int      `json:"currentWeek"`
+	AbandonReasons         []string `json:"abandonReasons,omitempty"`
 }
 
 // PlanInputFromRequest converts a CreatePlanRequest to a NutritionPlanInput.
 func PlanInputFromRequest(req CreatePlanRequest) domain.NutritionPlanInput {
 	return domain.NutritionPlanInput{
-		Name:          req.Name,
-		StartDate:     req.StartDate,
-		StartWeightKg: req.StartWeightKg,
-		GoalWeightKg:  req.GoalWeightKg,
-		DurationWeeks: req.DurationWeeks,
+		Name:                req.Name,
+		StartDate:           req.StartDate,
+		StartWeightKg:       req.StartWeightKg,
+		GoalWeightKg:        req.GoalWeightKg,
+		DurationWeeks:       req.DurationWeeks,
+		KcalFactorOverride:  req.KcalFactorOverride,
+		KcalFactorAutoTune:  req.KcalFactorAutoTune,
+		Mode:                domain.PlanMode(req.Mode),
+		StartBodyFatPercent: req.StartBodyFatPercent,
+		GoalBodyFatPercent:  req.GoalBodyFatPercent,
+		EventDate:           req.EventDate,
+		EventName:           req.EventName,
+	}
+}
+
+// AbandonSurveyFromRequest converts an AbandonPlanRequest to a PlanExitSurvey.
+func AbandonSurveyFromRequest(req AbandonPlanRequest) domain.PlanExitSurvey {
+	reasons := make([]domain.PlanAbandonReason, len(req.Reasons))
+	for i, r := range req.Reasons {
+		reasons[i] = domain.PlanAbandonReason(r)
 	}
+	return domain.PlanExitSurvey{Reasons: reasons, Note: req.Note}
+}
+
+// abandonReasonsToStrings converts exit survey reasons for responses.
+func abandonReasonsToStrings(reasons []domain.PlanAbandonReason) []string {
+	if len(reasons) == 0 {
+		return nil
+	}
+	out := make([]string, len(reasons))
+	for i, r := range reasons {
+		out[i] = string(r)
+	}
+	return out
 }
 
 // PlanToResponse converts a NutritionPlan to a PlanResponse.
@@ -86,29 +170,40 @@ func PlanToResponse(p *domain.NutritionPlan, now time.Time) PlanResponse {
 		RequiredDailyDeficitKcal: p.RequiredDailyDeficitKcal,
 		Status:                   string(p.Status),
 		CurrentWeek:              p.GetCurrentWeek(now),
+		KcalFactorOverride:       p.KcalFactorOverride,
+		KcalFactorAutoTune:       p.KcalFactorAutoTune,
+		Mode:                     string(p.Mode),
+		StartBodyFatPercent:      p.StartBodyFatPercent,
+		GoalBodyFatPercent:       p.GoalBodyFatPercent,
 		WeeklyTargets:            make([]WeeklyTargetResponse, len(p.WeeklyTargets)),
 	}
 
 	for i, target := range p.WeeklyTargets {
-		resp.WeeklyTargets[i] = WeeklyTargetResponse{
-			WeekNumber:        target.WeekNumber,
-			StartDate:         target.StartDate.Format("2006-01-02"),
-			EndDate:           target.EndDate.Format("2006-01-02"),
-			ProjectedWeightKg: target.ProjectedWeightKg,
-			ProjectedTDEE:     target.ProjectedTDEE,
-			TargetIntakeKcal:  target.TargetIntakeKcal,
-			TargetCarbsG:      target.TargetCarbsG,
-			TargetProteinG:    target.TargetProteinG,
-			TargetFatsG:       target.TargetFatsG,
-			ActualWeightKg:    target.ActualWeightKg,
-			ActualIntakeKcal:  target.ActualIntakeKcal,
-			DaysLogged:        target.DaysLogged,
-		}
+		resp.WeeklyTargets[i] = WeeklyTargetToResponse(target)
 	}
 
 	if p.LastRecalibratedAt != nil {
 		resp.LastRecalibratedAt = p.LastRecalibratedAt.Format(time.RFC3339)
 	}
+	if p.KcalFactorTunedAt != nil {
+		resp.KcalFactorTunedAt = p.KcalFactorTunedAt.Format(time.RFC3339)
+	}
+	if p.EventDate != nil {
+		days := p.DaysToEvent(now)
+		resp.EventDate = p.EventDate.Format("2006-01-02")
+		resp.EventName = p.EventName
+		resp.DaysToEvent = &days
+	}
+	resp.AbandonReasons = abandonReasonsToStrings(p.AbandonReasons)
+	resp.AbandonNote = p.AbandonNote
+	for _, w := range p.HistoryWarnings {
+		resp.HistoryWarnings = append(resp.HistoryWarnings, PlanHistoryWarningResponse{
+			Reason:      string(w.Reason),
+			PlanIDs:     w.PlanIDs,
+			Occurrences: w.Occurrences,
+			Message:     w.Message,
+		})
+	}
 	if !p.CreatedAt.IsZero() {
 		resp.CreatedAt = p.CreatedAt.Format(time.RFC3339)
 	}
@@ -130,10 +225,63 @@ func PlanToSummaryResponse(p *domain.NutritionPlan, now time.Time) PlanSummaryRe
 		DurationWeeks:          p.DurationWeeks,
 		RequiredWeeklyChangeKg: p.RequiredWeeklyChangeKg,
 		Status:                 string(p.Status),
+		Mode:                   string(p.Mode),
 		CurrentWeek:            p.GetCurrentWeek(now),
+		AbandonReasons:         abandonReasonsToStrings(p.AbandonReasons),
 	}
 }
 
+// NextPhaseOptionResponse is a phase offered after a plan completes, with the
+// create plan request to open the simulator with.
+type NextPhaseOptionResponse struct {
+	Type           string            `json:"type"` // maintenance, reverse, new_cut
+	Title          string            `json:"title"`
+	Rationale      string            `json:"rationale"`
+	Recommended    bool              `json:"recommended"`
+	SimulatorInput CreatePlanRequest `json:"simulatorInput"`
+}
+
+// PlanCompletionResponse is a completed plan's retrospective, its weekly
+// targets as archived at completion, and the next phases on offer.
+type PlanCompletionResponse struct {
+	Retrospective domain.PlanRetrospective  `json:"retrospective"`
+	ArchivedWeeks []WeeklyTargetResponse    `json:"archivedWeeks"`
+	NextPhases    []NextPhaseOptionResponse `json:"nextPhases"`
+	PromptPending bool                      `json:"promptPending"` // Next phase not yet chosen or dismissed
+}
+
+// PlanCompletionToResponse converts a PlanCompletion to a PlanCompletionResponse.
+func PlanCompletionToResponse(c *domain.PlanCompletion) PlanCompletionResponse {
+	resp := PlanCompletionResponse{
+		Retrospective: c.Retrospective,
+		ArchivedWeeks: make([]WeeklyTargetResponse, len(c.ArchivedWeeks)),
+		NextPhases:    make([]NextPhaseOptionResponse, len(c.NextPhases)),
+		PromptPending: c.PromptPending,
+	}
+	for i, w := range c.ArchivedWeeks {
+		resp.ArchivedWeeks[i] = WeeklyTargetToResponse(w)
+	}
+	for i, o := range c.NextPhases {
+		resp.NextPhases[i] = NextPhaseOptionResponse{
+			Type:        string(o.Type),
+			Title:       o.Title,
+			Rationale:   o.Rationale,
+			Recommended: o.Recommended,
+			SimulatorInput: CreatePlanRequest{
+				Name:               o.Input.Name,
+				StartDate:          o.Input.StartDate,
+				StartWeightKg:      o.Input.StartWeightKg,
+				GoalWeightKg:       o.Input.GoalWeightKg,
+				DurationWeeks:      o.Input.DurationWeeks,
+				KcalFactorOverride: o.Input.KcalFactorOverride,
+				KcalFactorAutoTune: o.Input.KcalFactorAutoTune,
+				Mode:               string(o.Input.Mode),
+			},
+		}
+	}
+	return resp
+}
+
 // RecalibrationRecordResponse represents a recalibration history entry in API responses.
 type RecalibrationRecordResponse struct {
 	ID         int64                        `json:"id"`
@@ -145,18 +293,21 @@ type RecalibrationRecordResponse struct {
 
 // RecalibrationDetailsResponse represents the before/after snapshot.
 type RecalibrationDetailsResponse struct {
-	BeforeGoalWeightKg           float64 `json:"beforeGoalWeightKg"`
-	BeforeDurationWeeks          int     `json:"beforeDurationWeeks"`
-	BeforeRequiredWeeklyChangeKg float64 `json:"beforeRequiredWeeklyChangeKg"`
-	BeforeDailyDeficitKcal       float64 `json:"beforeDailyDeficitKcal"`
-	AfterGoalWeightKg            float64 `json:"afterGoalWeightKg"`
-	AfterDurationWeeks           int     `json:"afterDurationWeeks"`
-	AfterRequiredWeeklyChangeKg  float64 `json:"afterRequiredWeeklyChangeKg"`
-	AfterDailyDeficitKcal        float64 `json:"afterDailyDeficitKcal"`
-	CurrentWeek                  int     `json:"currentWeek"`
-	ActualWeightKg               float64 `json:"actualWeightKg"`
-	FeasibilityTag               string  `json:"feasibilityTag,omitempty"`
-	Impact                       string  `json:"impact,omitempty"`
+	BeforeGoalWeightKg           float64  `json:"beforeGoalWeightKg"`
+	BeforeDurationWeeks          int      `json:"beforeDurationWeeks"`
+	BeforeRequiredWeeklyChangeKg float64  `json:"beforeRequiredWeeklyChangeKg"`
+	BeforeDailyDeficitKcal       float64  `json:"beforeDailyDeficitKcal"`
+	AfterGoalWeightKg            float64  `json:"afterGoalWeightKg"`
+	AfterDurationWeeks           int      `json:"afterDurationWeeks"`
+	AfterRequiredWeeklyChangeKg  float64  `json:"afterRequiredWeeklyChangeKg"`
+	AfterDailyDeficitKcal        float64  `json:"afterDailyDeficitKcal"`
+	CurrentWeek                  int      `json:"currentWeek"`
+	ActualWeightKg               float64  `json:"actualWeightKg"`
+	FeasibilityTag               string   `json:"feasibilityTag,omitempty"`
+	Impact                       string   `json:"impact,omitempty"`
+	BeforeKcalFactor             *float64 `json:"beforeKcalFactor,omitempty"`
+	AfterKcalFactor              *float64 `json:"afterKcalFactor,omitempty"`
+	ObservedTDEE                 int      `json:"observedTDEE,omitempty"`
 }
 
 // RecalibrationRecordToResponse converts a domain RecalibrationRecord to API response.
@@ -178,6 +329,9 @@ func RecalibrationRecordToResponse(r domain.RecalibrationRecord) RecalibrationRe
 			ActualWeightKg:               r.Details.ActualWeightKg,
 			FeasibilityTag:               r.Details.FeasibilityTag,
 			Impact:                       r.Details.Impact,
+			BeforeKcalFactor:             r.Details.BeforeKcalFactor,
+			AfterKcalFactor:              r.Details.AfterKcalFactor,
+			ObservedTDEE:                 r.Details.ObservedTDEE,
 		},
 		CreatedAt: r.CreatedAt.Format(time.RFC3339),
 	}
@@ -192,3 +346,111 @@ type RecalibratePlanRequest struct {
 func RecalibrationInputFromRequest(req RecalibratePlanRequest) domain.RecalibrationOptionType {
 	return domain.RecalibrationOptionType(req.Type)
 }
+
+// WeeklyTargetToResponse converts a WeeklyTarget to a WeeklyTargetResponse.
+func WeeklyTargetToResponse(t domain.WeeklyTarget) WeeklyTargetResponse {
+	return WeeklyTargetResponse{
+		WeekNumber:        t.WeekNumber,
+		StartDate:         t.StartDate.Format("2006-01-02"),
+		EndDate:           t.EndDate.Format("2006-01-02"),
+		ProjectedWeightKg: t.ProjectedWeightKg,
+		ProjectedTDEE:     t.ProjectedTDEE,
+		TargetIntakeKcal:  t.TargetIntakeKcal,
+		TargetCarbsG:      t.TargetCarbsG,
+		TargetProteinG:    t.TargetProteinG,
+		TargetFatsG:       t.TargetFatsG,
+		ActualWeightKg:    t.ActualWeightKg,
+		ActualIntakeKcal:  t.ActualIntakeKcal,
+		DaysLogged:        t.DaysLogged,
+		DaysExpected:      t.DaysExpected,
+		Prorated:          t.Prorated(),
+		LoggingComplete:   t.LoggingComplete(),
+		LoggingAdherence:  t.LoggingAdherence(),
+		Pinned:            t.Pinned,
+	}
+}
+
+// DailyPlanTargetsToResponse converts materialized daily targets to responses.
+func DailyPlanTargetsToResponse(targets []domain.DailyPlanTarget) []DailyPlanTargetResponse {
+	responses := make([]DailyPlanTargetResponse, len(targets))
+	for i, t := range targets {
+		responses[i] = DailyPlanTargetResponse{
+			Date:       t.Date.Format("2006-01-02"),
+			WeekNumber: t.WeekNumber,
+			DayNumber:  t.DayNumber,
+			DayType:    string(t.DayType),
+			CarbsG:     t.CarbsG,
+			ProteinG:   t.ProteinG,
+			FatsG:      t.FatsG,
+			Calories:   t.Calories,
+		}
+	}
+	return responses
+}
+
+// EditWeeklyTargetRequest is the request body for PATCH /api/plans/{id}/weeks/{week}.
+// Omitted fields are unchanged; editing any target pins the week unless pinned is false.
+type EditWeeklyTargetRequest struct {
+	TargetIntakeKcal *int  `json:"targetIntakeKcal,omitempty"`
+	TargetCarbsG     *int  `json:"targetCarbsG,omitempty"`
+	TargetProteinG   *int  `json:"targetProteinG,omitempty"`
+	TargetFatsG      *int  `json:"targetFatsG,omitempty"`
+	Pinned           *bool `json:"pinned,omitempty"`
+}
+
+// WeeklyTargetEditFromRequest converts an EditWeeklyTargetRequest to a domain edit.
+func WeeklyTargetEditFromRequest(req EditWeeklyTargetRequest) domain.WeeklyTargetEdit {
+	return domain.WeeklyTargetEdit{
+		TargetIntakeKcal: req.TargetIntakeKcal,
+		TargetCarbsG:     req.TargetCarbsG,
+		TargetProteinG:   req.TargetProteinG,
+		TargetFatsG:      req.TargetFatsG,
+		Pinned:           req.Pinned,
+	}
+}
+
+// WeeklyTargetDiffResponse is one week of a regeneration preview.
+type WeeklyTargetDiffResponse struct {
+	WeekNumber  int                   `json:"weekNumber"`
+	Change      string                `json:"change"` // unchanged, changed, added, removed, pinned
+	Before      *WeeklyTargetResponse `json:"before,omitempty"`
+	After       *WeeklyTargetResponse `json:"after,omitempty"`
+	IntakeDelta int                   `json:"intakeDelta"`
+}
+
+// RegenerationPreviewResponse is the response body for GET /api/plans/{id}/regenerate/preview.
+type RegenerationPreviewResponse struct {
+	Option       string                     `json:"option,omitempty"`
+	Plan         PlanResponse               `json:"plan"`
+	Weeks        []WeeklyTargetDiffResponse `json:"weeks"`
+	ChangedWeeks int                        `json:"changedWeeks"`
+}
+
+// RegenerationPreviewToResponse converts a plan preview and its diffs to a response.
+func RegenerationPreviewToResponse(plan *domain.NutritionPlan, diffs []domain.WeeklyTargetDiff, option string, now time.Time) RegenerationPreviewResponse {
+	resp := RegenerationPreviewResponse{
+		Option: option,
+		Plan:   PlanToResponse(plan, now),
+		Weeks:  make([]WeeklyTargetDiffResponse, len(diffs)),
+	}
+	for i, d := range diffs {
+		week := WeeklyTargetDiffResponse{
+			WeekNumber:  d.WeekNumber,
+			Change:      string(d.Change),
+			IntakeDelta: d.IntakeDelta,
+		}
+		if d.Before != nil {
+			before := WeeklyTargetToResponse(*d.Before)
+			week.Before = &before
+		}
+		if d.After != nil {
+			after := WeeklyTargetToResponse(*d.After)
+			week.After = &after
+		}
+		if d.Change != domain.WeeklyTargetUnchanged && d.Change != domain.WeeklyTargetKept {
+			resp.ChangedWeeks++
+		}
+		resp.Weeks[i] = week
+	}
+	return resp
+}
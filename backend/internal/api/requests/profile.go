@@ -27,6 +27,15 @@ type SupplementConfigRequest struct {
 	CollagenG     float64 `json:"collagenG"`     // Collagen peptides (grams)
 }
 
+// TolerancesRequest represents adherence bands (fractions of target) in API requests.
+// Zero fields use the defaults.
+type TolerancesRequest struct {
+	Calories     float64 `json:"calories"`     // ±deviation, 0.02-0.30 (default 0.10)
+	ProteinFloor float64 `json:"proteinFloor"` // Minimum fraction, 0.50-1.00 (default 0.90)
+	FatMinimum   float64 `json:"fatMinimum"`   // Minimum fraction, 0.30-1.00 (default 0.70)
+	Carbs        float64 `json:"carbs"`        // ±deviation, 0.05-0.50 (default 0.20)
+}
+
 // CreateProfileRequest is the request body for PUT /api/profile.
 type CreateProfileRequest struct {
 	HeightCM               float64                 `json:"height_cm"`
@@ -53,6 +62,10 @@ type CreateProfileRequest struct {
 	FastingProtocol        string                  `json:"fastingProtocol,omitempty"`        // standard (default), 16_8, or 20_4
 	EatingWindowStart      string                  `json:"eatingWindowStart,omitempty"`      // HH:MM format (e.g., "12:00")
 	EatingWindowEnd        string                  `json:"eatingWindowEnd,omitempty"`        // HH:MM format (e.g., "20:00")
+	WeightTrendMethod      string                  `json:"weightTrendMethod,omitempty"`      // ema (default), hull, or loess
+	WeightTrendWindow      *int                    `json:"weightTrendWindow,omitempty"`      // Samples; 0 = method default
+	AdherenceTolerances    *TolerancesRequest      `json:"adherenceTolerances,omitempty"`    // Calorie and per-macro adherence bands
+	LogLockDays            *int                    `json:"logLockDays,omitempty"`            // Lock days older than this; 0 = off (max 90)
 }
 
 // MealRatiosResponse represents meal distribution ratios in API responses.
@@ -76,6 +89,14 @@ type SupplementConfigResponse struct {
 	CollagenG     float64 `json:"collagenG"`
 }
 
+// TolerancesResponse represents adherence bands (fractions of target) in API responses.
+type TolerancesResponse struct {
+	Calories     float64 `json:"calories"`
+	ProteinFloor float64 `json:"proteinFloor"`
+	FatMinimum   float64 `json:"fatMinimum"`
+	Carbs        float64 `json:"carbs"`
+}
+
 // ProfileResponse is the response body for profile endpoints.
 type ProfileResponse struct {
 	HeightCM               float64                  `json:"height_cm"`
@@ -102,7 +123,11 @@ type ProfileResponse struct {
 	FastingProtocol        string                   `json:"fastingProtocol"`        // standard, 16_8, or 20_4
 	EatingWindowStart      string                   `json:"eatingWindowStart"`      // HH:MM format
 	EatingWindowEnd        string                   `json:"eatingWindowEnd"`        // HH:MM format
+	WeightTrendMethod      string                   `json:"weightTrendMethod"`      // ema, hull, or loess
+	WeightTrendWindow      int                      `json:"weightTrendWindow"`      // Samples; 0 = method default
 	EffectiveMealRatios    MealRatiosResponse       `json:"effectiveMealRatios"`    // Meal ratios adjusted for fasting protocol
+	AdherenceTolerances    TolerancesResponse       `json:"adherenceTolerances"`
+	LogLockDays            int                      `json:"logLockDays"` // 0 = logging lock off
 	CreatedAt              string                   `json:"createdAt,omitempty"`
 	UpdatedAt              string                   `json:"updatedAt,omitempty"`
 }
@@ -199,6 +224,27 @@ func ProfileFromRequest(req CreateProfileRequest) (*domain.UserProfile, error) {
 	if req.EatingWindowEnd != "" {
 		profile.EatingWindowEnd = req.EatingWindowEnd
 	}
+	if req.WeightTrendMethod != "" {
+		method, err := domain.ParseWeightTrendMethod(req.WeightTrendMethod)
+		if err != nil {
+			return nil, err
+		}
+		profile.WeightSmoothing.Method = method
+	}
+	if req.WeightTrendWindow != nil {
+		profile.WeightSmoothing.Window = *req.WeightTrendWindow
+	}
+	if req.AdherenceTolerances != nil {
+		profile.AdherenceTolerances = domain.AdherenceTolerances{
+			Calories:     req.AdherenceTolerances.Calories,
+			ProteinFloor: req.AdherenceTolerances.ProteinFloor,
+			FatMinimum:   req.AdherenceTolerances.FatMinimum,
+			Carbs:        req.AdherenceTolerances.Carbs,
+		}
+	}
+	if req.LogLockDays != nil {
+		profile.LogLockDays = *req.LogLockDays
+	}
 
 	return profile, nil
 }
@@ -238,6 +284,17 @@ func ProfileToResponse(p *domain.UserProfile) ProfileResponse {
 		FastingProtocol:        string(p.FastingProtocol),
 		EatingWindowStart:      p.EatingWindowStart,
 		EatingWindowEnd:        p.EatingWindowEnd,
+		WeightTrendMethod:      string(p.WeightSmoothing.EffectiveMethod()),
+		WeightTrendWindow:      p.WeightSmoothing.Window,
+		LogLockDays:            p.LogLockDays,
+	}
+
+	tolerances := p.AdherenceTolerances.WithDefaults()
+	resp.AdherenceTolerances = TolerancesResponse{
+		Calories:     tolerances.Calories,
+		ProteinFloor: tolerances.ProteinFloor,
+		FatMinimum:   tolerances.FatMinimum,
+		Carbs:        tolerances.Carbs,
 	}
 
 	// Include effective meal ratios (adjusted for fasting protocol)
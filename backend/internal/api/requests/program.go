@@ -49,8 +49,41 @@ type ProgramDayRequest struct {
 
 // InstallProgramRequest is the request body for POST /api/training-programs/{id}/install.
 type InstallProgramRequest struct {
-	StartDate      string `json:"startDate"` // YYYY-MM-DD
-	WeekDayMapping []int  `json:"weekDayMapping"`
+	StartDate           string  `json:"startDate"` // YYYY-MM-DD
+	WeekDayMapping      []int   `json:"weekDayMapping"`
+	AutoregulationBound float64 `json:"autoregulationBound,omitempty"` // e.g. 0.15 = ±15%; 0 = off
+	KeepDeloadDayTypes  bool    `json:"keepDeloadDayTypes,omitempty"`  // Don't bias deload weeks' nutrition days
+}
+
+// SetAutoregulationRequest is the request body for turning readiness scaling on or off.
+type SetAutoregulationRequest struct {
+	Enabled bool    `json:"enabled"`
+	Bound   float64 `json:"bound,omitempty"` // Defaults to 0.15 when enabling
+}
+
+// SetDeloadDayTypesRequest is the request body for overriding deload nutrition biasing.
+type SetDeloadDayTypesRequest struct {
+	Keep bool `json:"keep"` // Keep the program's nutrition days in deload weeks
+}
+
+// GenerateProgramRequest is the request body for POST /api/training-programs/generate.
+type GenerateProgramRequest struct {
+	Name                string   `json:"name"`
+	Description         string   `json:"description"`
+	DurationWeeks       int      `json:"durationWeeks"`
+	TrainingDaysPerWeek int      `json:"trainingDaysPerWeek"`
+	Focus               string   `json:"focus"`
+	Difficulty          string   `json:"difficulty"`
+	DeloadEvery         int      `json:"deloadEvery"` // Every Nth week is a deload; 0 = none
+	WaveShape           string   `json:"waveShape"`   // linear, undulating or step
+	Equipment           []string `json:"equipment"`
+	Tags                []string `json:"tags"`
+}
+
+// SwapProgramDaysRequest is the request body for swapping two program days.
+type SwapProgramDaysRequest struct {
+	DateA string `json:"dateA"` // YYYY-MM-DD
+	DateB string `json:"dateB"` // YYYY-MM-DD
 }
 
 // =============================================================================
@@ -136,10 +169,19 @@ type InstallationResponse struct {
 	CurrentWeek           int                    `json:"currentWeek"`
 	Status                string                 `json:"status"`
 	TotalSessionsScheduled int                   `json:"totalSessionsScheduled"`
+	Reschedules           []domain.ProgramReschedule `json:"reschedules"`
+	AutoregulationBound   float64                `json:"autoregulationBound"` // 0 = off
+	KeepDeloadDayTypes    bool                   `json:"keepDeloadDayTypes"`  // Deload weeks keep the program's nutrition days
 	CreatedAt             string                 `json:"createdAt,omitempty"`
 	UpdatedAt             string                 `json:"updatedAt,omitempty"`
 }
 
+// InstallPreviewResponse is a dry-run installation with the sessions it would schedule.
+type InstallPreviewResponse struct {
+	Installation InstallationResponse       `json:"installation"`
+	Sessions     []ScheduledSessionResponse `json:"sessions"`
+}
+
 // ScheduledSessionResponse is a single scheduled training session.
 type ScheduledSessionResponse struct {
 	Date               string                     `json:"date"`
@@ -150,7 +192,10 @@ type ScheduledSessionResponse struct {
 	DurationMin        int                        `json:"durationMin"`
 	LoadScore          float64                    `json:"loadScore"`
 	NutritionDay       string                     `json:"nutritionDay"`
+	DeloadAdjusted     bool                       `json:"deloadAdjusted"` // Nutrition day biased away from performance for a deload week
 	ProgressionPattern *domain.ProgressionPattern `json:"progressionPattern,omitempty"`
+	SessionExercises   []domain.SessionExercise   `json:"sessionExercises,omitempty"`
+	Autoregulation     *domain.SessionAutoregulation `json:"autoregulation,omitempty"` // Readiness scaling applied to the prescription
 }
 
 // =============================================================================
@@ -205,6 +250,24 @@ func InstallInputFromRequest(programID int64, req InstallProgramRequest) domain.
 		ProgramID:      programID,
 		StartDate:      req.StartDate,
 		WeekDayMapping: req.WeekDayMapping,
+		AutoregulationBound: req.AutoregulationBound,
+		KeepDeloadDayTypes: req.KeepDeloadDayTypes,
+	}
+}
+
+// MesocycleInputFromRequest converts a GenerateProgramRequest to domain.MesocycleInput.
+func MesocycleInputFromRequest(req GenerateProgramRequest) domain.MesocycleInput {
+	return domain.MesocycleInput{
+		Name:                req.Name,
+		Description:         req.Description,
+		DurationWeeks:       req.DurationWeeks,
+		TrainingDaysPerWeek: req.TrainingDaysPerWeek,
+		Focus:               req.Focus,
+		Difficulty:          req.Difficulty,
+		DeloadEvery:         req.DeloadEvery,
+		WaveShape:           req.WaveShape,
+		Equipment:           req.Equipment,
+		Tags:                req.Tags,
 	}
 }
 
@@ -317,6 +380,13 @@ func InstallationToResponse(i *domain.ProgramInstallation, now time.Time) Instal
 		CurrentWeek:           i.GetCurrentWeek(now),
 		Status:                string(i.Status),
 		TotalSessionsScheduled: i.TotalSessionCount(),
+		AutoregulationBound:   i.AutoregulationBound,
+		KeepDeloadDayTypes:    i.KeepDeloadDayTypes,
+	}
+
+	resp.Reschedules = i.Reschedules
+	if resp.Reschedules == nil {
+		resp.Reschedules = []domain.ProgramReschedule{}
 	}
 
 	if i.Program != nil {
@@ -334,6 +404,13 @@ func InstallationToResponse(i *domain.ProgramInstallation, now time.Time) Instal
 	return resp
 }
 
+// RescheduleResponse is the result of moving program days: the moves made
+// and the installation's sessions with their new dates.
+type RescheduleResponse struct {
+	Moves    []domain.ProgramReschedule `json:"moves"`
+	Sessions []ScheduledSessionResponse `json:"sessions"`
+}
+
 // ScheduledSessionsToResponse converts domain ScheduledSessions to responses.
 func ScheduledSessionsToResponse(sessions []domain.ScheduledSession) []ScheduledSessionResponse {
 	resp := make([]ScheduledSessionResponse, len(sessions))
@@ -347,7 +424,34 @@ func ScheduledSessionsToResponse(sessions []domain.ScheduledSession) []Scheduled
 			DurationMin:        s.DurationMin,
 			LoadScore:          s.LoadScore,
 			NutritionDay:       string(s.NutritionDay),
+			DeloadAdjusted:     s.DeloadAdjusted,
 			ProgressionPattern: s.ProgressionPattern,
+			SessionExercises:   s.SessionExercises,
+			Autoregulation:     s.Autoregulation,
+		}
+	}
+	return resp
+}
+
+// ProgramRecommendationResponse is a template program with its equipment fit.
+type ProgramRecommendationResponse struct {
+	Program          ProgramSummaryResponse `json:"program"`
+	Available        bool                   `json:"available"`
+	MissingEquipment []string               `json:"missingEquipment"`
+}
+
+// ProgramFitsToResponse converts ranked equipment fits to response format.
+func ProgramFitsToResponse(fits []domain.ProgramEquipmentFit) []ProgramRecommendationResponse {
+	resp := make([]ProgramRecommendationResponse, len(fits))
+	for i, f := range fits {
+		missing := make([]string, len(f.MissingEquipment))
+		for j, e := range f.MissingEquipment {
+			missing[j] = string(e)
+		}
+		resp[i] = ProgramRecommendationResponse{
+			Program:          ProgramToSummaryResponse(f.Program),
+			Available:        f.Available,
+			MissingEquipment: missing,
 		}
 	}
 	return resp
@@ -1,6 +1,10 @@
 package requests
 
-import "victus/internal/domain"
+import (
+	"time"
+
+	"victus/internal/domain"
+)
 
 // QuickSessionRequest is the request body for POST /api/logs/:date/sessions/quick.
 // Creates a draft session that can be enriched later via echo.
@@ -58,9 +62,10 @@ type BodyIssueResponse struct {
 
 // EchoResponse is the response for POST /api/sessions/:id/echo.
 type EchoResponse struct {
-	Session           SessionResponse      `json:"session"`
-	EchoResult        *EchoResultResponse  `json:"echoResult,omitempty"`
-	BodyIssuesCreated []BodyIssueResponse  `json:"bodyIssuesCreated,omitempty"`
+	Session           SessionResponse         `json:"session"`
+	EchoResult        *EchoResultResponse     `json:"echoResult,omitempty"`
+	BodyIssuesCreated []BodyIssueResponse     `json:"bodyIssuesCreated,omitempty"`
+	RecordsSet        []domain.PersonalRecord `json:"recordsSet,omitempty"`
 }
 
 // ToSessionResponse converts a domain TrainingSession to API response format.
@@ -125,3 +130,28 @@ func ToBodyIssueResponses(issues []domain.BodyPartIssue) []BodyIssueResponse {
 	}
 	return result
 }
+
+// DraftReminderResponse is a draft session awaiting RPE past the reminder threshold.
+type DraftReminderResponse struct {
+	Session    SessionResponse `json:"session"`
+	LogDate    string          `json:"logDate"`
+	CreatedAt  string          `json:"createdAt"`
+	RemindedAt string          `json:"remindedAt"`
+}
+
+// ToDraftReminderResponses converts draft states to response format.
+func ToDraftReminderResponses(drafts []domain.DraftSessionState) []DraftReminderResponse {
+	result := make([]DraftReminderResponse, 0, len(drafts))
+	for _, d := range drafts {
+		resp := DraftReminderResponse{
+			Session:   ToSessionResponse(&d.Session),
+			LogDate:   d.LogDate,
+			CreatedAt: d.CreatedAt.Format(time.RFC3339),
+		}
+		if d.RemindedAt != nil {
+			resp.RemindedAt = d.RemindedAt.Format(time.RFC3339)
+		}
+		result = append(result, resp)
+	}
+	return result
+}
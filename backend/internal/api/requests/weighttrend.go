@@ -43,3 +43,82 @@ func WeightTrendToResponse(points []domain.WeightSample, trend *domain.WeightTre
 		Trend:  trendResp,
 	}
 }
+
+type SmoothedWeightPointResponse struct {
+	Date     string  `json:"date"`
+	WeightKg float64 `json:"weightKg"`
+	TrendKg  float64 `json:"trendKg"`
+	LowerKg  float64 `json:"lowerKg"`
+	UpperKg  float64 `json:"upperKg"`
+}
+
+type SmoothedWeightTrendResponse struct {
+	Method           string                        `json:"method"`
+	Window           int                           `json:"window"` // 0 = method default
+	Points           []SmoothedWeightPointResponse `json:"points"`
+	ResidualStdDevKg float64                       `json:"residualStdDevKg"`
+	CurrentTrendKg   *float64                      `json:"currentTrendKg,omitempty"`
+	Trend            *WeightTrendSummaryResponse   `json:"trend,omitempty"`
+}
+
+func SmoothedWeightTrendToResponse(smoothed *domain.SmoothedWeightTrend, trend *domain.WeightTrend) SmoothedWeightTrendResponse {
+	resp := SmoothedWeightTrendResponse{
+		Method:           string(smoothed.Smoothing.EffectiveMethod()),
+		Window:           smoothed.Smoothing.Window,
+		Points:           make([]SmoothedWeightPointResponse, len(smoothed.Points)),
+		ResidualStdDevKg: smoothed.ResidualStdDevKg,
+	}
+	for i, point := range smoothed.Points {
+		resp.Points[i] = SmoothedWeightPointResponse{
+			Date:     point.Date,
+			WeightKg: point.WeightKg,
+			TrendKg:  point.TrendKg,
+			LowerKg:  point.LowerKg,
+			UpperKg:  point.UpperKg,
+		}
+	}
+	if len(smoothed.Points) > 0 {
+		resp.CurrentTrendKg = &smoothed.CurrentTrendKg
+	}
+	if trend != nil {
+		resp.Trend = &WeightTrendSummaryResponse{
+			WeeklyChangeKg: trend.WeeklyChangeKg,
+			RSquared:       trend.RSquared,
+			StartWeightKg:  trend.StartWeightKg,
+			EndWeightKg:    trend.EndWeightKg,
+		}
+	}
+	return resp
+}
+
+type WeighInOffsetBlockResponse struct {
+	StartHour int     `json:"startHour"`
+	EndHour   int     `json:"endHour"`
+	OffsetKg  float64 `json:"offsetKg"`
+	Samples   int     `json:"samples"`
+	Trusted   bool    `json:"trusted"`
+}
+
+type WeighInOffsetCurveResponse struct {
+	Blocks       []WeighInOffsetBlockResponse `json:"blocks"`
+	TimedSamples int                          `json:"timedSamples"`
+	HasReference bool                         `json:"hasReference"` // Morning block anchors the curve
+}
+
+func WeighInOffsetCurveToResponse(curve *domain.WeighInOffsetCurve) WeighInOffsetCurveResponse {
+	blocks := make([]WeighInOffsetBlockResponse, len(curve.Blocks))
+	for i, block := range curve.Blocks {
+		blocks[i] = WeighInOffsetBlockResponse{
+			StartHour: block.StartHour,
+			EndHour:   block.EndHour,
+			OffsetKg:  block.OffsetKg,
+			Samples:   block.Samples,
+			Trusted:   block.Trusted,
+		}
+	}
+	return WeighInOffsetCurveResponse{
+		Blocks:       blocks,
+		TimedSamples: curve.TimedSamples,
+		HasReference: curve.HasReference,
+	}
+}
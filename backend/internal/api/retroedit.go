@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// OpenRetroEditRequest is the request body for unlocking a locked day.
+type OpenRetroEditRequest struct {
+	Reason string `json:"reason"`
+}
+
+// openRetroEdit handles POST /api/logs/{date}/retro-edit
+// Opens a short window in which a locked day can be edited. The reason and
+// every edit made in the window are kept as the day's audit trail.
+func (s *Server) openRetroEdit(w http.ResponseWriter, r *http.Request) {
+	var req OpenRetroEditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	edit, err := s.dailyLogService.OpenRetroEdit(r.Context(), r.PathValue("date"), req.Reason)
+	if err != nil {
+		if domain.IsValidationError(err) {
+			writeDomainError(w, err, "openRetroEdit")
+			return
+		}
+		writeInternalError(w, err, "openRetroEdit")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(edit)
+}
+
+// listRetroEdits handles GET /api/logs/retro-edits?start=YYYY-MM-DD&end=YYYY-MM-DD
+// Returns the retro-edit audit trail for the days in the range.
+func (s *Server) listRetroEdits(w http.ResponseWriter, r *http.Request) {
+	startDate := r.URL.Query().Get("start")
+	endDate := r.URL.Query().Get("end")
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_start_date", "start must be in YYYY-MM-DD format")
+		return
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_end_date", "end must be in YYYY-MM-DD format")
+		return
+	}
+	if end.Before(start) {
+		writeError(w, http.StatusBadRequest, "invalid_range", "end must be on or after start")
+		return
+	}
+
+	edits, err := s.dailyLogService.ListRetroEdits(r.Context(), startDate, endDate)
+	if err != nil {
+		writeInternalError(w, err, "listRetroEdits")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(edits)
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"victus/internal/api/requests"
+	"victus/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The scenario is the only test that runs logging, planning,
+// recalibration, the flux engine and debriefs together through the API on a
+// moving clock. Golden outputs catch cross-module drift that unit tests can't.
+//
+// After an intended behavior change, regenerate the golden file with:
+//
+//	go test ./internal/api -run TestScenarioSuite -update
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+const (
+	scenarioGoldenFile = "testdata/scenario_8week.golden.json"
+	scenarioStart      = "2026-01-05" // A Monday
+	scenarioWeeks      = 8
+	// scenarioRecalibrateWeek is the week whose Monday recalibrates the plan.
+	scenarioRecalibrateWeek = 5
+)
+
+// scenarioProfile uses ratios and weights that are exact in float32, so the
+// REAL columns they pass through don't perturb the golden values.
+var scenarioProfile = requests.CreateProfileRequest{
+	HeightCM:             180,
+	BirthDate:            "1990-06-15",
+	Sex:                  "male",
+	Goal:                 "lose_weight",
+	TargetWeightKg:       86,
+	TargetWeeklyChangeKg: -0.5,
+	CarbRatio:            0.5,
+	ProteinRatio:         0.25,
+	FatRatio:             0.25,
+	MealRatios:           requests.MealRatiosRequest{Breakfast: 0.25, Lunch: 0.25, Dinner: 0.5},
+	TDEESource:           "formula",
+}
+
+var scenarioPlan = requests.CreatePlanRequest{
+	Name:          "Scenario cut",
+	StartDate:     scenarioStart,
+	StartWeightKg: 90,
+	GoalWeightKg:  86,
+	DurationWeeks: scenarioWeeks,
+}
+
+// scenarioWobble is the within-week weigh-in noise, Monday first, in
+// sixteenths of a kilogram so every weight is exact in float32.
+var scenarioWobble = [7]float64{0, 0.25, 0.125, 0, -0.125, 0.125, 0}
+
+// scenarioWeight is the weigh-in on day d of the scenario (0 = first Monday).
+func scenarioWeight(d int) float64 {
+	return 90 - 0.0625*float64(d) + scenarioWobble[d%7]
+}
+
+// scenarioSleep is the sleep quality logged on day d.
+func scenarioSleep(d int) int {
+	return 70 + 5*(d%3)
+}
+
+// scenarioTrainingDay reports whether day d has a planned strength session
+// (Monday, Wednesday, Friday).
+func scenarioTrainingDay(d int) bool {
+	wd := d % 7
+	return wd == 0 || wd == 2 || wd == 4
+}
+
+// scenarioTrained reports whether the planned session on day d was done.
+// Fridays are skipped on odd weeks.
+func scenarioTrained(d int) bool {
+	return scenarioTrainingDay(d) && !(d%7 == 4 && (d/7)%2 == 1)
+}
+
+// scenarioAteOnTarget reports whether food was logged on day d, matching its
+// target. Sundays are never logged, and week 3 also misses its Saturday.
+func scenarioAteOnTarget(d int) bool {
+	wd := d % 7
+	return wd != 6 && !(d/7 == 2 && wd == 5)
+}
+
+func scenarioDate(d int) string {
+	start, _ := time.Parse("2006-01-02", scenarioStart)
+	return start.AddDate(0, 0, d).Format("2006-01-02")
+}
+
+func scenarioLogRequest(d int) requests.CreateDailyLogRequest {
+	req := requests.CreateDailyLogRequest{
+		Date:         scenarioDate(d),
+		WeightKg:     scenarioWeight(d),
+		SleepQuality: scenarioSleep(d),
+		DayType:      "fatburner",
+	}
+	if scenarioTrainingDay(d) {
+		req.DayType = "performance"
+		req.PlannedTrainingSessions = []requests.TrainingSessionRequest{{Type: "strength", DurationMin: 60}}
+	}
+	return req
+}
+
+// ScenarioTargets is one week's plan targets.
+type ScenarioTargets struct {
+	Week     int `json:"week"`
+	Calories int `json:"calories"`
+	CarbsG   int `json:"carbsG"`
+	ProteinG int `json:"proteinG"`
+	FatsG    int `json:"fatsG"`
+}
+
+// ScenarioWeek is what the scenario observes for one week: the targets in
+// force and the debrief generated the following Monday.
+type ScenarioWeek struct {
+	Week      int                            `json:"week"`
+	Targets   ScenarioTargets                `json:"targets"`
+	Vitality  requests.VitalityScoreResponse `json:"vitality"`
+	Narrative string                         `json:"narrative"`
+}
+
+// ScenarioGolden is the golden output of the scenario.
+type ScenarioGolden struct {
+	InitialTargets      []ScenarioTargets `json:"initialTargets"`
+	RecalibratedTargets []ScenarioTargets `json:"recalibratedTargets"`
+	Weeks               []ScenarioWeek    `json:"weeks"`
+}
+
+func scenarioTargetsFrom(weeks []requests.WeeklyTargetResponse) []ScenarioTargets {
+	targets := make([]ScenarioTargets, len(weeks))
+	for i, w := range weeks {
+		targets[i] = ScenarioTargets{
+			Week:     w.WeekNumber,
+			Calories: w.TargetIntakeKcal,
+			CarbsG:   w.TargetCarbsG,
+			ProteinG: w.TargetProteinG,
+			FatsG:    w.TargetFatsG,
+		}
+	}
+	return targets
+}
+
+type ScenarioSuite struct {
+	suite.Suite
+	pg     *testutil.PostgresContainer
+	server *Server
+}
+
+func TestScenarioSuite(t *testing.T) {
+	suite.Run(t, new(ScenarioSuite))
+}
+
+func (s *ScenarioSuite) SetupSuite() {
+	s.pg = testutil.SetupPostgres(s.T())
+}
+
+func (s *ScenarioSuite) SetupTest() {
+	s.Require().NoError(s.pg.ClearTables(s.T().Context()))
+
+	s.T().Setenv("SIM_CLOCK_ENABLED", "true")
+	s.T().Setenv("SIM_CLOCK_START", scenarioStart)
+	s.T().Setenv("OLLAMA_URL", "http://127.0.0.1:1") // Unreachable: narratives use the template fallback
+	s.server = NewServer(s.pg.DB)
+}
+
+// do sends a request and decodes a 2xx JSON response into out (if non-nil).
+func (s *ScenarioSuite) do(method, path string, body, out interface{}) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		s.Require().NoError(err)
+		reqBody = bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, path, reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	s.server.Handler().ServeHTTP(rec, req)
+
+	s.Require().True(rec.Code >= 200 && rec.Code < 300, "%s %s: %d %s", method, path, rec.Code, rec.Body.String())
+	if out != nil {
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), out), "%s %s", method, path)
+	}
+}
+
+func (s *ScenarioSuite) setDate(d int) {
+	var clock SimClockResponse
+	s.do("PUT", "/api/sim/clock", SetSimClockRequest{Date: scenarioDate(d)}, &clock)
+	s.Require().Equal(scenarioDate(d), clock.Date)
+}
+
+// logDay records day d: weigh-in and planned training, then food and
+// completed training as the scenario dictates.
+func (s *ScenarioSuite) logDay(d int) {
+	var log requests.DailyLogResponse
+	s.do("POST", "/api/logs", scenarioLogRequest(d), &log)
+
+	date := scenarioDate(d)
+	if scenarioAteOnTarget(d) {
+		s.do("PATCH", "/api/logs/"+date+"/consumed-macros", requests.AddConsumedMacrosRequest{
+			Calories: log.CalculatedTargets.TotalCalories,
+			ProteinG: log.CalculatedTargets.TotalProteinG,
+			CarbsG:   log.CalculatedTargets.TotalCarbsG,
+			FatG:     log.CalculatedTargets.TotalFatsG,
+		}, nil)
+	}
+	if scenarioTrained(d) {
+		rpe := 7
+		s.do("PATCH", "/api/logs/"+date+"/actual-training", requests.UpdateActualTrainingRequest{
+			ActualSessions: []requests.ActualTrainingSessionRequest{{Type: "strength", DurationMin: 60, PerceivedIntensity: &rpe}},
+		}, nil)
+	}
+}
+
+func (s *ScenarioSuite) currentTargets() ScenarioTargets {
+	var target requests.WeeklyTargetResponse
+	s.do("GET", "/api/plans/current-week", nil, &target)
+	return scenarioTargetsFrom([]requests.WeeklyTargetResponse{target})[0]
+}
+
+func (s *ScenarioSuite) TestEightWeekCut() {
+	var got ScenarioGolden
+
+	s.do("PUT", "/api/profile", scenarioProfile, nil)
+	var plan requests.PlanResponse
+	s.do("POST", "/api/plans", scenarioPlan, &plan)
+	got.InitialTargets = scenarioTargetsFrom(plan.WeeklyTargets)
+
+	for week := 1; week <= scenarioWeeks; week++ {
+		monday := (week - 1) * 7
+		s.setDate(monday)
+
+		if week == scenarioRecalibrateWeek {
+			s.do("POST", fmt.Sprintf("/api/plans/%d/recalibrate", plan.ID),
+				requests.RecalibratePlanRequest{Type: "increase_deficit"}, &plan)
+			got.RecalibratedTargets = scenarioTargetsFrom(plan.WeeklyTargets)
+		}
+
+		observed := ScenarioWeek{Week: week, Targets: s.currentTargets()}
+		for d := monday; d < monday+7; d++ {
+			s.setDate(d)
+			s.logDay(d)
+		}
+
+		// The debrief for the week is ready the following Monday
+		s.setDate(monday + 7)
+		var debrief requests.WeeklyDebriefResponse
+		s.do("GET", "/api/debrief/weekly", nil, &debrief)
+		s.Require().Equal(scenarioDate(monday), debrief.WeekStartDate)
+		s.Require().False(debrief.Narrative.GeneratedByLLM)
+		observed.Vitality = debrief.VitalityScore
+		observed.Narrative = debrief.Narrative.Text
+
+		got.Weeks = append(got.Weeks, observed)
+	}
+
+	s.assertGolden(got)
+}
+
+func (s *ScenarioSuite) assertGolden(got ScenarioGolden) {
+	gotJSON, err := json.MarshalIndent(got, "", "  ")
+	s.Require().NoError(err)
+	gotJSON = append(gotJSON, '\n')
+
+	if *updateGolden {
+		s.Require().NoError(os.MkdirAll(filepath.Dir(scenarioGoldenFile), 0o755))
+		s.Require().NoError(os.WriteFile(scenarioGoldenFile, gotJSON, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(scenarioGoldenFile)
+	s.Require().NoError(err, "missing golden file; run with -update")
+	s.JSONEq(string(want), string(gotJSON))
+}
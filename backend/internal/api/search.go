@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+)
+
+// SearchResponse is returned by GET /api/search.
+type SearchResponse struct {
+	Query   string                `json:"query"`
+	Results []domain.SearchResult `json:"results"`
+}
+
+// search handles GET /api/search?q=knee+pain&types=note,session&limit=20
+// Returns notes, sessions, program days, templates, foods, movements, programs
+// and tagged days matching the query, best match first.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	limit := domain.SearchDefaultLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil {
+			writeDomainError(w, domain.ErrInvalidSearchLimit, "search")
+			return
+		}
+		limit = v
+	}
+
+	query := r.URL.Query().Get("q")
+	results, err := s.searchService.Search(r.Context(), query, r.URL.Query().Get("types"), limit)
+	if err != nil {
+		writeDomainError(w, err, "search")
+		return
+	}
+	writeJSON(w, http.StatusOK, SearchResponse{Query: query, Results: results})
+}
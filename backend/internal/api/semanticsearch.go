@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"victus/internal/domain"
+	"victus/internal/service"
+)
+
+// FoodSearchHitResponse is a food returned by GET /api/food-reference/search.
+type FoodSearchHitResponse struct {
+	ID             int64   `json:"id"`
+	Category       string  `json:"category"`
+	FoodItem       string  `json:"foodItem"`
+	ProteinGPer100 float64 `json:"proteinGPer100"`
+	CarbsGPer100   float64 `json:"carbsGPer100"`
+	FatGPer100     float64 `json:"fatGPer100"`
+	ServingUnit    string  `json:"servingUnit"`
+	ServingSizeG   float64 `json:"servingSizeG"`
+	IsPantryStaple bool    `json:"isPantryStaple"`
+	Similarity     float64 `json:"similarity"` // Cosine similarity to the query, 1 is identical
+}
+
+// MovementSearchHitResponse is a movement returned by GET /api/movements/search.
+type MovementSearchHitResponse struct {
+	Movement   domain.Movement `json:"movement"`
+	Similarity float64         `json:"similarity"`
+}
+
+// semanticSearchParams reads ?q= and ?limit= for the semantic search endpoints.
+// Range checks happen in the domain; this only rejects a non-numeric limit.
+func semanticSearchParams(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	limit := domain.SemanticSearchDefaultLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil {
+			writeDomainError(w, domain.ErrInvalidSemanticLimit, "semanticSearchParams")
+			return "", 0, false
+		}
+		limit = v
+	}
+	return r.URL.Query().Get("q"), limit, true
+}
+
+// writeSemanticSearchError maps search errors: bad input is 400, an
+// unreachable embeddings model is 503.
+func writeSemanticSearchError(w http.ResponseWriter, err error, context string) {
+	if errors.Is(err, service.ErrSemanticSearchUnavailable) {
+		writeError(w, http.StatusServiceUnavailable, "semantic_search_unavailable", "Semantic search needs Ollama with an embedding model; try again later")
+		return
+	}
+	writeDomainError(w, err, context)
+}
+
+// searchFoods handles GET /api/food-reference/search?q=something+crunchy+high+protein&limit=10
+// Returns foods ranked by semantic similarity to the query.
+func (s *Server) searchFoods(w http.ResponseWriter, r *http.Request) {
+	query, limit, ok := semanticSearchParams(w, r)
+	if !ok {
+		return
+	}
+
+	hits, err := s.semanticSearchService.SearchFoods(r.Context(), query, limit)
+	if err != nil {
+		writeSemanticSearchError(w, err, "searchFoods")
+		return
+	}
+
+	response := make([]FoodSearchHitResponse, len(hits))
+	for i, hit := range hits {
+		f := hit.Food
+		response[i] = FoodSearchHitResponse{
+			ID:             f.ID,
+			Category:       string(f.Category),
+			FoodItem:       f.FoodItem,
+			ProteinGPer100: f.ProteinGPer100,
+			CarbsGPer100:   f.CarbsGPer100,
+			FatGPer100:     f.FatGPer100,
+			ServingUnit:    f.ServingUnit,
+			ServingSizeG:   f.ServingSizeG,
+			IsPantryStaple: f.IsPantryStaple,
+			Similarity:     hit.Similarity,
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// searchMovements handles GET /api/movements/search?q=knee-friendly+leg+exercise&limit=10
+// Returns movements ranked by semantic similarity to the query.
+func (s *Server) searchMovements(w http.ResponseWriter, r *http.Request) {
+	query, limit, ok := semanticSearchParams(w, r)
+	if !ok {
+		return
+	}
+
+	hits, err := s.semanticSearchService.SearchMovements(r.Context(), query, limit)
+	if err != nil {
+		writeSemanticSearchError(w, err, "searchMovements")
+		return
+	}
+
+	response := make([]MovementSearchHitResponse, len(hits))
+	for i, hit := range hits {
+		response[i] = MovementSearchHitResponse{Movement: hit.Movement, Similarity: hit.Similarity}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// reindexSemanticSearch handles POST /api/admin/semantic-index/reindex
+// Re-indexes now, e.g. after pulling a new embedding model, instead of
+// waiting for the scheduled run.
+func (s *Server) reindexSemanticSearch(w http.ResponseWriter, r *http.Request) {
+	result, err := s.semanticSearchService.Reindex(r.Context())
+	if err != nil {
+		writeSemanticSearchError(w, err, "reindexSemanticSearch")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
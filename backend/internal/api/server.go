@@ -8,39 +8,88 @@ import (
 	"strconv"
 	"time"
 
+	"victus/internal/domain"
 	"victus/internal/service"
 	"victus/internal/store"
 )
 
 // Server wraps HTTP server configuration and routing.
 type Server struct {
-	mux                  *http.ServeMux
-	profileService       *service.ProfileService
-	dailyLogService      *service.DailyLogService
-	trainingConfigStore  *store.TrainingConfigStore
-	planService          *service.NutritionPlanService
-	analysisService      *service.AnalysisService
-	fatigueService       *service.FatigueService
-	programService       *service.TrainingProgramService
-	metabolicService     *service.MetabolicService
-	solverService        *service.SolverService
-	weeklyDebriefService *service.WeeklyDebriefService
-	importService        *service.ImportService
-	bodyIssueService     *service.BodyIssueService
-	auditService         *service.AuditService
-	echoService          *service.EchoService
-	ollamaService        *service.OllamaService
-	movementService      *service.MovementService
-	systemicLoadService  *service.SystemicLoadService
-	garminSyncService    *service.GarminSyncService
-	plannedDayTypeStore  *store.PlannedDayTypeStore
-	plannerSessionStore  *store.PlannerSessionStore
-	foodReferenceStore   *store.FoodReferenceStore
-	monthlySummaryStore  *store.MonthlySummaryStore
+	mux                    *http.ServeMux
+	db                     store.DBTX // Begins batch transactions
+	profileService         *service.ProfileService
+	dailyLogService        *service.DailyLogService
+	trainingConfigStore    *store.TrainingConfigStore
+	planService            *service.NutritionPlanService
+	analysisService        *service.AnalysisService
+	fatigueService         *service.FatigueService
+	bodyStatusService      *service.BodyStatusService
+	jointIntegrityService  *service.JointIntegrityService
+	substitutionService    *service.SubstitutionService
+	programService         *service.TrainingProgramService
+	metabolicService       *service.MetabolicService
+	solverService          *service.SolverService
+	weeklyDebriefService   *service.WeeklyDebriefService
+	annualReviewService    *service.AnnualReviewService
+	injuryRiskService      *service.InjuryRiskService
+	importService          *service.ImportService
+	bodyIssueService       *service.BodyIssueService
+	auditService           *service.AuditService
+	echoService            *service.EchoService
+	ollamaService          *service.OllamaService
+	movementService        *service.MovementService
+	equipmentService       *service.EquipmentService
+	experienceService      *service.ExperienceService
+	systemicLoadService    *service.SystemicLoadService
+	garminSyncService      *service.GarminSyncService
+	wearableSyncService    *service.WearableSyncService
+	exportService          *service.ExportService
+	restoreService         *service.RestoreService
+	plateauService         *service.PlateauService
+	dataQualityService     *service.DataQualityService
+	weekPreviewService     *service.WeekPreviewService
+	digestService          *service.DigestService
+	reconciliationService  *service.ReconciliationService
+	foodMatchService       *service.FoodMatchService
+	semanticSearchService  *service.SemanticSearchService
+	searchService          *service.SearchService
+	noteService            *service.NoteService
+	foodCostService        *service.FoodCostService
+	caffeineService        *service.CaffeineService
+	recoveryLogService     *service.RecoveryActivityService
+	habitService           *service.HabitService
+	challengeService       *service.ChallengeService
+	adaptationService      *service.MetabolicAdaptationService
+	planCompletionService  *service.PlanCompletionService
+	personalRecordService  *service.PersonalRecordService
+	mealTemplateService    *service.MealTemplateService
+	mealItemService        *service.MealItemService
+	onboardingService      *service.OnboardingService
+	sessionTemplateService *service.SessionTemplateService
+	apiTokenService        *service.APITokenService
+	apiTokenRequired       bool // API_TOKEN_REQUIRED: refuse API requests without a bearer token
+	healthService          *service.HealthService
+	adherenceService       *service.AdherenceService
+	calorieEstimateService *service.CalorieEstimationService
+	archetypeService       *service.ArchetypeInferenceService
+	rotationService        *service.StrengthRotationService
+	logDeletionService     *service.LogDeletionService
+	weeklyActualsService   *service.WeeklyActualsService
+	monthlySummaryService  *service.MonthlySummaryService
+	referenceRangeService  *service.PersonalReferenceService
+	plannedDayTypeStore    *store.PlannedDayTypeStore
+	plannerSessionStore    *store.PlannerSessionStore
+	foodReferenceStore     *store.FoodReferenceStore
+	monthlySummaryStore    *store.MonthlySummaryStore
+	clock                  service.Clock
+	simClock               *service.SimClock // Set only when simulated time is enabled
 }
 
 // NewServer configures routes and middleware.
 func NewServer(db store.DBTX) *Server {
+	// Statements join a batch's transaction when the request context carries one
+	db = store.TxScoped(db)
+
 	profileStore := store.NewProfileStore(db)
 	dailyLogStore := store.NewDailyLogStore(db)
 	trainingSessionStore := store.NewTrainingSessionStore(db)
@@ -55,30 +104,141 @@ func NewServer(db store.DBTX) *Server {
 	monthlySummaryStore := store.NewMonthlySummaryStore(db)
 	bodyIssueStore := store.NewBodyIssueStore(db)
 	movementStore := store.NewMovementStore(db)
+	plateauStore := store.NewPlateauStore(db)
+	reconciliationStore := store.NewReconciliationStore(db)
+	nutritionImportStore := store.NewNutritionImportStore(db)
+	foodPortionStore := store.NewFoodPortionStore(db)
+	mealTemplateStore := store.NewMealTemplateStore(db)
+	sessionTemplateStore := store.NewSessionTemplateStore(db)
+	apiTokenStore := store.NewAPITokenStore(db)
+	dayExemptionStore := store.NewDayExemptionStore(db)
 
 	// Create services
 	dailyLogService := service.NewDailyLogService(dailyLogStore, trainingSessionStore, profileStore)
 	dailyLogService.SetMetabolicStore(metabolicStore) // Enable Flux Engine
+	retroEditStore := store.NewRetroEditStore(db)
+	dailyLogService.SetRetroEditStore(retroEditStore) // Enable the logging lock
+	calorieEstimateService := service.NewCalorieEstimationService(trainingConfigStore, dailyLogStore, trainingSessionStore, profileStore)
+	dailyLogService.SetCalorieEstimator(calorieEstimateService) // Estimate active calories without a wearable
+	weeklyActualsService := service.NewWeeklyActualsService(planStore, dailyLogStore)
+	// Complete plans whose final week has passed, archiving their final actuals
+	planCompletionService := service.NewPlanCompletionService(planStore, store.NewPlanCompletionStore(db), profileStore, weeklyActualsService)
+	monthlySummaryService := service.NewMonthlySummaryService(monthlySummaryStore, trainingSessionStore, calorieEstimateService)
+	dailyLogService.SetWeeklyRollup(weeklyActualsService) // Keep plan weekly actuals live
+	// Judge HRV, resting HR and sleep against the user's own trailing 90 days
+	personalReferenceService := service.NewPersonalReferenceService(store.NewPersonalReferenceStore(db), dailyLogStore)
+	dailyLogService.SetReferenceRanges(personalReferenceService)
+	recoveryActivityStore := store.NewRecoveryActivityStore(db)
+	dailyLogService.SetRecoveryActivityStore(recoveryActivityStore) // Sauna, cold, massage count toward readiness
+	habitService := service.NewHabitService(store.NewHabitStore(db))
+	challengeService := service.NewChallengeService(store.NewChallengeStore(db), dailyLogStore, trainingSessionStore)
+	adaptationService := service.NewMetabolicAdaptationService(store.NewMetabolicAdaptationStore(db), dailyLogStore, profileStore)
 
 	// Create Ollama service for AI recipe naming (uses localhost:11434 by default)
 	ollamaURL := os.Getenv("OLLAMA_URL")
 	ollamaService := service.NewOllamaService(ollamaURL)
+	ollamaService.SetTaskModels(service.OllamaTaskParse, service.ParseOllamaModelList(os.Getenv("OLLAMA_PARSE_MODELS")))
+	ollamaService.SetTaskModels(service.OllamaTaskNarrative, service.ParseOllamaModelList(os.Getenv("OLLAMA_NARRATIVE_MODELS")))
+	ollamaService.SetTaskModels(service.OllamaTaskEmbed, service.ParseOllamaModelList(os.Getenv("OLLAMA_EMBED_MODELS")))
+	ollamaService.SetUsageStore(store.NewLLMUsageStore(db))
+	if budgets, err := domain.ParseLLMBudgets(os.Getenv("LLM_DAILY_BUDGETS")); err != nil {
+		log.Printf("ignoring invalid LLM_DAILY_BUDGETS: %v", err)
+	} else {
+		ollamaService.SetBudgets(budgets)
+	}
 	dailyLogService.SetOllamaService(ollamaService) // Enable AI insights
 
+	// Background job heartbeats, reported by /readyz. Leases elect one
+	// replica to run each scheduled job.
+	jobMonitor := service.NewJobMonitor()
+	jobMonitor.SetLeases(service.NewJobLeases(store.NewJobLeaseStore(db)))
+
 	// Create fatigue service with body issue integration
 	fatigueService := service.NewFatigueService(fatigueStore)
 	fatigueService.SetBodyIssueStore(bodyIssueStore) // Enable Semantic Body fatigue modifiers
 
+	// Track the strength split so planned strength sessions get the next archetype
+	rotationService := service.NewStrengthRotationService(store.NewStrengthRotationStore(db))
+	fatigueService.SetRotationRecorder(rotationService)
+	dailyLogService.SetStrengthRotation(rotationService)
+
+	// Infer archetypes for sessions logged without one, so their fatigue applies
+	archetypeService := service.NewArchetypeInferenceService(trainingSessionStore, store.NewArchetypeReviewStore(db), dailyLogStore, fatigueService)
+	archetypeService.SetClassifier(ollamaService) // LLM fallback for low-confidence inferences
+
 	// Create movement service for Adaptive Movement Engine
 	movementService := service.NewMovementService(movementStore, fatigueService)
 
+	// Equipment inventory and travel mode drive movement and program substitution
+	equipmentService := service.NewEquipmentService(profileStore)
+	movementService.SetEquipmentSource(equipmentService)
+
+	// Personal record registry, fed by set logging and echo achievements
+	personalRecordStore := store.NewPersonalRecordStore(db)
+	personalRecordService := service.NewPersonalRecordService(personalRecordStore, movementStore)
+	movementService.SetRecordKeeper(personalRecordService)
+
+	// Create program service; warm-ups are generated from the movement catalog
+	programService := service.NewTrainingProgramService(programStore, plannedDayTypeStore)
+	programService.SetWarmupSource(movementService)
+	programService.SetEquipmentSource(equipmentService)
+	programService.SetSessionStore(trainingSessionStore) // Reschedules never move logged training
+	programService.SetReadinessSource(dailyLogService)   // Autoregulated installations scale by readiness
+
+	// Training age derived from the log gates recommendations and sets default RPE targets
+	experienceService := service.NewExperienceService(profileStore, trainingSessionStore, personalRecordStore)
+	programService.SetExperienceSource(experienceService)
+
+	// Semantic search over foods and movements (Ollama embeddings in pgvector)
+	semanticSearchService := service.NewSemanticSearchService(foodReferenceStore, movementStore, store.NewEmbeddingStore(db), ollamaService)
+	semanticSearchService.SetJobMonitor(jobMonitor)
+
 	// Create solver service for Macro Tetris feature
+	foodMatchService := service.NewFoodMatchService(foodReferenceStore, foodPortionStore)
 	solverService := service.NewSolverService(foodReferenceStore, ollamaService, fatigueService)
+	solverService.SetFeedbackStore(store.NewSolverFeedbackStore(db)) // Learn food preferences from feedback
+	solverService.SetSatietyStore(store.NewSatietyStore(db))         // Learn satiety from per-meal hunger ratings
+	foodPriceStore := store.NewFoodPriceStore(db)
+	solverService.SetPriceStore(foodPriceStore) // Cost estimates and budget filtering
+
+	// Daily body status: fatigue, issues and readiness, snapshotted per day.
+	// Joint integrity is capped by the echo-fed joint recovery series.
+	jointIntegrityService := service.NewJointIntegrityService(store.NewJointIntegrityStore(db), trainingSessionStore, bodyIssueStore)
+	movementService.SetJointIntegritySource(jointIntegrityService)
+	bodyStatusService := service.NewBodyStatusService(fatigueService, dailyLogService, bodyIssueStore, store.NewBodyStatusStore(db))
+	bodyStatusService.SetJointIntegritySource(jointIntegrityService)
+	solverService.SetBodyStatusSource(bodyStatusService)
 
 	// Create weekly debrief service for Mission Report feature
 	weeklyDebriefService := service.NewWeeklyDebriefService(
 		dailyLogStore, trainingSessionStore, profileStore, metabolicStore, ollamaService,
 	)
+	weeklyDebriefService.SetPlanStore(planStore)                      // Enable plan-aware vitality scoring
+	weeklyDebriefService.SetReconciliationStore(reconciliationStore)  // Clear late-data regeneration flags
+	weeklyDebriefService.SetFatigueService(fatigueService)            // Fatigue snapshot in PDF reports
+	weeklyDebriefService.SetCaffeineStore(store.NewCaffeineStore(db)) // Caffeine vs sleep analysis
+	substitutionStore := store.NewSubstitutionStore(db)
+	weeklyDebriefService.SetSubstitutionStore(substitutionStore) // Fatigue swaps from the session runner
+	// Freeze completed weeks' adherence tolerances so settings changes don't rescore them
+	weeklyDebriefService.SetToleranceStore(store.NewDebriefToleranceStore(db))
+	weeklyDebriefService.SetReferenceRanges(personalReferenceService)    // Flag sleep against the user's range
+	weeklyDebriefService.SetRecoveryActivityStore(recoveryActivityStore) // Recovery work in the vitality score
+	weeklyDebriefService.SetHabitAdherence(habitService)                 // Counted habits as a small vitality component
+	weeklyDebriefService.SetChallenges(challengeService)                 // Card for each challenge running that week
+	weeklyDebriefService.SetMetabolicAdaptation(adaptationService)       // Adaptation detected that week leads the recommendations
+
+	// Fatigue-aware exercise substitution for today's scheduled session
+	substitutionService := service.NewSubstitutionService(programService, movementService, fatigueService, substitutionStore)
+
+	// Create annual review service for the year-end report
+	annualReviewService := service.NewAnnualReviewService(
+		dailyLogStore, trainingSessionStore, profileStore, planStore, store.NewAnnualReviewStore(db), ollamaService,
+	)
+	// Weeks with retro-edited days don't count towards vitality streaks
+	annualReviewService.SetRetroEditStore(retroEditStore)
+
+	// Monthly injury risk report from load spikes and recurring joint issues
+	injuryRiskService := service.NewInjuryRiskService(trainingSessionStore, bodyIssueStore, fatigueStore)
 
 	// Create audit service for Strategy Auditor (Check Engine light)
 	auditService := service.NewAuditService(fatigueStore, dailyLogStore, plannedDayTypeStore, ollamaURL)
@@ -86,74 +246,240 @@ func NewServer(db store.DBTX) *Server {
 	// Create systemic load service for Systemic Gyroscope (Load Balancing)
 	systemicLoadService := service.NewSystemicLoadService(dailyLogService, fatigueService, ollamaService)
 
+	// Create week preview service for Sunday planning
+	weekPreviewService := service.NewWeekPreviewService(
+		profileStore, dailyLogStore, trainingSessionStore, plannedDayTypeStore, plannerSessionStore, programStore,
+	)
+
+	// End-of-day digest via ntfy or email (DIGEST_* variables)
+	noteService := service.NewNoteService(store.NewNoteStore(db), dailyLogService)
+	digestService := service.NewDigestService(dailyLogStore, trainingSessionStore, weekPreviewService)
+	digestService.SetJobMonitor(jobMonitor)
+	weeklyActualsService.SetJobMonitor(jobMonitor)
+	monthlySummaryService.SetJobMonitor(jobMonitor)
+	personalReferenceService.SetJobMonitor(jobMonitor)
+	challengeService.SetJobMonitor(jobMonitor)
+	adaptationService.SetJobMonitor(jobMonitor)
+	planCompletionService.SetJobMonitor(jobMonitor)
+
+	// Create reconciliation service for late wearable data (backfill)
+	reconciliationService := service.NewReconciliationService(dailyLogService, reconciliationStore)
+	logDeletionService := service.NewLogDeletionService(dailyLogService, dailyLogStore, trainingSessionStore, metabolicStore, fatigueStore, weeklyActualsService, reconciliationStore)
+	garminSyncService := service.NewGarminSyncService(dailyLogStore)
+	garminSyncService.SetReconciler(reconciliationService)
+	garminSyncService.SetJobMonitor(jobMonitor)
+
+	// Garmin Connect / Apple Health export payloads, deduplicated per source
+	wearableSyncService := service.NewWearableSyncService(dailyLogStore, trainingSessionStore, store.NewWearableImportStore(db))
+	wearableSyncService.SetReconciler(reconciliationService)
+
 	mux := http.NewServeMux()
 	srv := &Server{
-		mux:                  mux,
-		profileService:       service.NewProfileService(profileStore),
-		dailyLogService:      dailyLogService,
-		trainingConfigStore:  trainingConfigStore,
-		planService:          service.NewNutritionPlanService(planStore, profileStore),
-		analysisService:      service.NewAnalysisService(planStore, profileStore, dailyLogStore),
-		fatigueService:       fatigueService,
-		programService:       service.NewTrainingProgramService(programStore, plannedDayTypeStore),
-		metabolicService:     service.NewMetabolicService(metabolicStore, dailyLogStore),
-		solverService:        solverService,
-		weeklyDebriefService: weeklyDebriefService,
-		importService:        service.NewImportService(dailyLogStore, monthlySummaryStore),
-		garminSyncService:    service.NewGarminSyncService(dailyLogStore),
-		bodyIssueService:     service.NewBodyIssueService(bodyIssueStore),
-		auditService:         auditService,
-		ollamaService:        ollamaService,
-		movementService:      movementService,
-		systemicLoadService:  systemicLoadService,
-		plannedDayTypeStore:  plannedDayTypeStore,
-		plannerSessionStore:  plannerSessionStore,
-		foodReferenceStore:   foodReferenceStore,
-		monthlySummaryStore:  monthlySummaryStore,
+		mux:                    mux,
+		db:                     db,
+		profileService:         service.NewProfileService(profileStore),
+		dailyLogService:        dailyLogService,
+		trainingConfigStore:    trainingConfigStore,
+		planService:            service.NewNutritionPlanService(planStore, profileStore),
+		analysisService:        service.NewAnalysisService(planStore, profileStore, dailyLogStore),
+		fatigueService:         fatigueService,
+		bodyStatusService:      bodyStatusService,
+		jointIntegrityService:  jointIntegrityService,
+		substitutionService:    substitutionService,
+		programService:         programService,
+		metabolicService:       service.NewMetabolicService(metabolicStore, dailyLogStore),
+		solverService:          solverService,
+		weeklyDebriefService:   weeklyDebriefService,
+		annualReviewService:    annualReviewService,
+		injuryRiskService:      injuryRiskService,
+		importService:          service.NewImportService(dailyLogStore, monthlySummaryStore, foodReferenceStore, nutritionImportStore),
+		garminSyncService:      garminSyncService,
+		wearableSyncService:    wearableSyncService,
+		exportService:          service.NewExportService(store.NewExportStore(db)),
+		restoreService:         service.NewRestoreService(store.NewRestoreStore(db)),
+		reconciliationService:  reconciliationService,
+		foodMatchService:       foodMatchService,
+		semanticSearchService:  semanticSearchService,
+		searchService:          service.NewSearchService(store.NewSearchStore(db)),
+		noteService:            noteService,
+		foodCostService:        service.NewFoodCostService(foodPriceStore, foodReferenceStore, foodPortionStore, store.NewGroceryStore(db)),
+		caffeineService:        service.NewCaffeineService(store.NewCaffeineStore(db)),
+		recoveryLogService:     service.NewRecoveryActivityService(recoveryActivityStore),
+		habitService:           habitService,
+		challengeService:       challengeService,
+		adaptationService:      adaptationService,
+		planCompletionService:  planCompletionService,
+		personalRecordService:  personalRecordService,
+		mealTemplateService:    service.NewMealTemplateService(mealTemplateStore, foodReferenceStore, profileStore, dailyLogService),
+		mealItemService:        service.NewMealItemService(dailyLogService, dailyLogStore, store.NewMealItemStore(db), foodReferenceStore, foodPortionStore),
+		sessionTemplateService: service.NewSessionTemplateService(sessionTemplateStore, dailyLogService),
+		apiTokenService:        service.NewAPITokenService(apiTokenStore),
+		apiTokenRequired:       os.Getenv("API_TOKEN_REQUIRED") == "true",
+		healthService:          service.NewHealthService(db, ollamaService, jobMonitor),
+		plateauService:         service.NewPlateauService(plateauStore, dailyLogStore, movementStore, profileStore),
+		dataQualityService:     service.NewDataQualityService(dailyLogStore, trainingSessionStore),
+		adherenceService:       service.NewAdherenceService(dailyLogStore, dayExemptionStore),
+		calorieEstimateService: calorieEstimateService,
+		archetypeService:       archetypeService,
+		rotationService:        rotationService,
+		logDeletionService:     logDeletionService,
+		weeklyActualsService:   weeklyActualsService,
+		monthlySummaryService:  monthlySummaryService,
+		referenceRangeService:  personalReferenceService,
+		weekPreviewService:     weekPreviewService,
+		digestService:          digestService,
+		bodyIssueService:       service.NewBodyIssueService(bodyIssueStore),
+		auditService:           auditService,
+		ollamaService:          ollamaService,
+		movementService:        movementService,
+		equipmentService:       equipmentService,
+		experienceService:      experienceService,
+		systemicLoadService:    systemicLoadService,
+		plannedDayTypeStore:    plannedDayTypeStore,
+		plannerSessionStore:    plannerSessionStore,
+		foodReferenceStore:     foodReferenceStore,
+		monthlySummaryStore:    monthlySummaryStore,
+		clock:                  service.SystemClock,
 	}
 
 	// Enable AI phase insights for plans
 	srv.planService.SetOllamaService(ollamaService)
+	// Enable kcal factor auto-tuning from logged results
+	srv.planService.SetAdaptiveDataLister(dailyLogStore)
+	srv.planService.SetDayTypeLister(plannedDayTypeStore) // Daily targets follow the dashboard's day types
+	dailyLogService.SetDailyTargetRefresher(srv.planService)
+	programService.SetDailyTargetRefresher(srv.planService)
+	srv.planService.SetJobMonitor(jobMonitor)
+	srv.planService.SetWeeklyRollup(weeklyActualsService) // Prorate and fill a new plan's weeks
+	// Score adherence against the user's tolerances
+	srv.adherenceService.SetProfileStore(profileStore)
 
 	// Create echo service for Neural Echo feature
 	echoService := service.NewEchoService(trainingSessionStore, bodyIssueStore, dailyLogStore, ollamaService)
+	echoService.SetJobMonitor(jobMonitor)
+	echoService.SetRecordKeeper(personalRecordService)
+	echoService.SetJointTracker(jointIntegrityService)
+	echoService.SetArchetypeInferrer(archetypeService)
 	srv.echoService = echoService
 
+	// First-run setup writes the profile and first plan through their services
+	srv.onboardingService = service.NewOnboardingService(store.NewOnboardingStore(db), profileStore, srv.profileService, srv.planService)
+
 	// Health
 	mux.HandleFunc("/api/health", srv.healthHandler)
+	mux.HandleFunc("GET /healthz", srv.getLiveness)
+	mux.HandleFunc("GET /readyz", srv.getReadiness)
 
 	// Profile routes
 	mux.HandleFunc("GET /api/profile", srv.getProfile)
 	mux.HandleFunc("PUT /api/profile", srv.upsertProfile)
 	mux.HandleFunc("DELETE /api/profile", srv.deleteProfile)
+	mux.HandleFunc("GET /api/profile/equipment", srv.getEquipmentProfile)
+	mux.HandleFunc("PUT /api/profile/equipment", srv.updateEquipmentProfile)
+	mux.HandleFunc("GET /api/profile/training-experience", srv.getTrainingExperience)
+
+	// Onboarding routes
+	mux.HandleFunc("GET /api/onboarding", srv.getOnboarding)
+	mux.HandleFunc("POST /api/onboarding/basic-info", srv.submitOnboardingBasicInfo)
+	mux.HandleFunc("POST /api/onboarding/goal", srv.submitOnboardingGoal)
+	mux.HandleFunc("POST /api/onboarding/ratios", srv.submitOnboardingRatios)
+	mux.HandleFunc("POST /api/onboarding/first-plan", srv.submitOnboardingFirstPlan)
 
 	// Daily log routes
 	mux.HandleFunc("POST /api/logs", srv.createDailyLog)
 	mux.HandleFunc("GET /api/logs", srv.getLogsRange)
 	mux.HandleFunc("GET /api/logs/today", srv.getTodayLog)
+	mux.HandleFunc("GET /api/logs/retro-edits", srv.listRetroEdits)
 	mux.HandleFunc("GET /api/logs/{date}", srv.getLogByDate)
 	mux.HandleFunc("DELETE /api/logs/today", srv.deleteTodayLog)
+	mux.HandleFunc("DELETE /api/logs/{date}", srv.deleteLog)
 	mux.HandleFunc("PATCH /api/logs/{date}/actual-training", srv.updateActualTraining)
 	mux.HandleFunc("PATCH /api/logs/{date}/active-calories", srv.updateActiveCalories)
+	mux.HandleFunc("GET /api/logs/{date}/active-calories/estimate", srv.getActiveCalorieEstimate)
+	mux.HandleFunc("POST /api/logs/{date}/active-calories/estimate", srv.applyActiveCalorieEstimate)
 	mux.HandleFunc("PATCH /api/logs/{date}/fasting-override", srv.updateFastingOverride)
+	mux.HandleFunc("PATCH /api/logs/{date}/check-in", srv.updateCheckIn)
+	mux.HandleFunc("PATCH /api/logs/{date}/environment", srv.updateEnvironment)
 	mux.HandleFunc("PATCH /api/logs/{date}/health-sync", srv.syncHealthData)
+	mux.HandleFunc("POST /api/logs/{date}/reconcile", srv.reconcileDailyLog)
+	mux.HandleFunc("POST /api/logs/{date}/retro-edit", srv.openRetroEdit)
+	mux.HandleFunc("PUT /api/logs/{date}/notes", srv.updateDayNotes)
 	mux.HandleFunc("PATCH /api/logs/{date}/consumed-macros", srv.addConsumedMacros)
 	mux.HandleFunc("DELETE /api/logs/{date}/consumed-macros/{meal}", srv.clearMealConsumedMacros)
+	mux.HandleFunc("GET /api/logs/{date}/meal-items", srv.listMealItems)
+	mux.HandleFunc("POST /api/logs/{date}/meal-items", srv.addMealItem)
+	mux.HandleFunc("PUT /api/logs/{date}/meal-items/{id}", srv.updateMealItem)
+	mux.HandleFunc("DELETE /api/logs/{date}/meal-items/{id}", srv.deleteMealItem)
+	mux.HandleFunc("PUT /api/logs/{date}/meal-hunger/{meal}", srv.setMealHunger)
+	mux.HandleFunc("POST /api/logs/{date}/copy-meals", srv.copyMeals)
+	mux.HandleFunc("POST /api/logs/{date}/meal-templates/{id}", srv.applyMealTemplate)
+	mux.HandleFunc("POST /api/logs/{date}/session-templates/{id}", srv.applySessionTemplate)
 	mux.HandleFunc("GET /api/logs/{date}/insight", srv.getDayInsight)
+	mux.HandleFunc("GET /api/logs/{date}/caffeine", srv.listCaffeine)
+	mux.HandleFunc("POST /api/logs/{date}/caffeine", srv.logCaffeine)
+	mux.HandleFunc("DELETE /api/caffeine/{id}", srv.deleteCaffeine)
+	mux.HandleFunc("GET /api/recovery-activities/library", srv.getRecoveryActivityLibrary)
+	mux.HandleFunc("GET /api/logs/{date}/recovery-activities", srv.listRecoveryActivities)
+	mux.HandleFunc("POST /api/logs/{date}/recovery-activities", srv.logRecoveryActivity)
+	mux.HandleFunc("DELETE /api/recovery-activities/{id}", srv.deleteRecoveryActivity)
+
+	// Daily habit checklist routes
+	mux.HandleFunc("GET /api/habits", srv.listHabits)
+	mux.HandleFunc("POST /api/habits", srv.createHabit)
+	mux.HandleFunc("PUT /api/habits/{id}", srv.updateHabit)
+	mux.HandleFunc("DELETE /api/habits/{id}", srv.deleteHabit)
+	mux.HandleFunc("GET /api/logs/{date}/checklist", srv.getChecklist)
+	mux.HandleFunc("PUT /api/logs/{date}/checklist/{habitId}", srv.setChecklistItem)
+
+	// Challenge routes
+	mux.HandleFunc("GET /api/challenges", srv.listChallenges)
+	mux.HandleFunc("POST /api/challenges", srv.createChallenge)
+	mux.HandleFunc("GET /api/challenges/notifications", srv.listChallengeNotifications)
+	mux.HandleFunc("GET /api/challenges/{id}", srv.getChallenge)
+	mux.HandleFunc("POST /api/challenges/{id}/abandon", srv.abandonChallenge)
+	mux.HandleFunc("POST /api/challenges/{id}/dismiss", srv.dismissChallengeNotification)
+	mux.HandleFunc("DELETE /api/challenges/{id}", srv.deleteChallenge)
 
 	// Training config routes
 	mux.HandleFunc("GET /api/training-configs", srv.getTrainingConfigs)
 
 	// Body status / fatigue routes (Adaptive Load feature)
 	mux.HandleFunc("GET /api/body-status", srv.getBodyStatus)
+	mux.HandleFunc("GET /api/body-status/today", srv.getBodyStatusToday)
+	mux.HandleFunc("GET /api/body-status/joints", srv.getJointIntegritySeries)
+	mux.HandleFunc("GET /api/fatigue/heatmap", srv.getFatigueHeatmap)
 	mux.HandleFunc("GET /api/archetypes", srv.getArchetypes)
 	mux.HandleFunc("POST /api/fatigue/apply", srv.applyFatigueByParams)
 	mux.HandleFunc("POST /api/fatigue/apply-muscles", srv.applyMuscleFatigue)
 	mux.HandleFunc("POST /api/sessions/{id}/apply-load", srv.applySessionLoad)
 
+	// Note sync routes (edits from several devices)
+	mux.HandleFunc("PUT /api/sessions/{id}/notes", srv.updateSessionNotes)
+	mux.HandleFunc("GET /api/note-conflicts", srv.listNoteConflicts)
+	mux.HandleFunc("POST /api/note-conflicts/{id}/resolve", srv.resolveNoteConflict)
+	mux.HandleFunc("POST /api/logs/{date}/archetypes/infer", srv.inferArchetypes)
+	mux.HandleFunc("GET /api/archetype-reviews", srv.listArchetypeReviews)
+	mux.HandleFunc("POST /api/archetype-reviews/{id}/confirm", srv.confirmArchetypeReview)
+	mux.HandleFunc("POST /api/archetype-reviews/{id}/dismiss", srv.dismissArchetypeReview)
+	mux.HandleFunc("GET /api/strength-rotation", srv.getStrengthRotation)
+	mux.HandleFunc("PUT /api/strength-rotation", srv.updateStrengthRotation)
+
 	// Stats routes
 	mux.HandleFunc("GET /api/stats/weight-trend", srv.getWeightTrend)
+	mux.HandleFunc("GET /api/weight/trend", srv.getSmoothedWeightTrend)
+	mux.HandleFunc("GET /api/weight/time-of-day-offsets", srv.getWeighInOffsets)
 	mux.HandleFunc("GET /api/stats/history", srv.getHistorySummary)
+	mux.HandleFunc("GET /api/stats/check-ins", srv.getCheckInRollup)
+	mux.HandleFunc("GET /api/stats/meal-timing", srv.getMealTimingRollup)
+
+	// Data quality routes (completeness score and nudges)
+	mux.HandleFunc("GET /api/data-quality", srv.getDataQuality)
+
+	// Adherence calendar routes
+	mux.HandleFunc("GET /api/adherence/calendar", srv.getAdherenceCalendar)
+	mux.HandleFunc("GET /api/adherence/macros", srv.getMacroCompliance)
+	mux.HandleFunc("PUT /api/adherence/exemptions/{date}", srv.setDayExemption)
+	mux.HandleFunc("DELETE /api/adherence/exemptions/{date}", srv.clearDayExemption)
 
 	// Calendar routes
 	mux.HandleFunc("GET /api/calendar/summary", srv.getCalendarSummary)
@@ -166,12 +492,59 @@ func NewServer(db store.DBTX) *Server {
 	// Planned sessions routes (Workout Planner → Command Center)
 	mux.HandleFunc("GET /api/planned-sessions/{date}", srv.getPlannedSessions)
 
+	// Weekly planning preview (forward-looking debrief)
+	mux.HandleFunc("GET /api/planning/week-preview", srv.getWeekPreview)
+	mux.HandleFunc("GET /api/planning/conflicts", srv.getPlanningConflicts)
+
+	// End-of-day digest preview (sending is scheduled, or POST /api/admin/digest/send)
+	mux.HandleFunc("GET /api/digest/daily", srv.getDailyDigest)
+
 	// Food reference routes (Cockpit Dashboard)
 	mux.HandleFunc("GET /api/food-reference", srv.getFoodReference)
 	mux.HandleFunc("PATCH /api/food-reference/{id}", srv.updateFoodReference)
+	mux.HandleFunc("GET /api/food-reference/match", srv.matchFood)
+	mux.HandleFunc("GET /api/food-reference/search", srv.searchFoods)
+	mux.HandleFunc("POST /api/food-reference/match/confirm", srv.confirmFoodMatch)
+	mux.HandleFunc("GET /api/food-reference/synonyms", srv.listFoodSynonyms)
+	mux.HandleFunc("GET /api/food-reference/{id}/portion", srv.getDefaultPortion)
+
+	// Meal template routes
+	mux.HandleFunc("GET /api/meal-templates", srv.listMealTemplates)
+	mux.HandleFunc("POST /api/meal-templates", srv.createMealTemplate)
+	mux.HandleFunc("DELETE /api/meal-templates/{id}", srv.deleteMealTemplate)
+
+	// Session template routes
+	mux.HandleFunc("GET /api/session-templates", srv.listSessionTemplates)
+	mux.HandleFunc("POST /api/session-templates", srv.createSessionTemplate)
+	mux.HandleFunc("PATCH /api/session-templates/{id}", srv.updateSessionTemplate)
+	mux.HandleFunc("DELETE /api/session-templates/{id}", srv.deleteSessionTemplate)
+
+	// Personal API token routes
+	mux.HandleFunc("GET /api/tokens", srv.listAPITokens)
+	mux.HandleFunc("POST /api/tokens", srv.createAPIToken)
+	mux.HandleFunc("GET /api/tokens/{id}/usage", srv.getAPITokenUsage)
+	mux.HandleFunc("DELETE /api/tokens/{id}", srv.revokeAPIToken)
 
 	// Macro Tetris Solver route
 	mux.HandleFunc("POST /api/solver/solve", srv.solveMacros)
+	mux.HandleFunc("POST /api/solver/score", srv.scoreSolution)
+	mux.HandleFunc("POST /api/solver/feedback", srv.recordSolverFeedback)
+	mux.HandleFunc("GET /api/solver/preferences", srv.getSolverPreferences)
+	mux.HandleFunc("GET /api/solver/satiety", srv.getSolverSatiety)
+
+	// Food prices and cost estimates
+	mux.HandleFunc("GET /api/food-prices", srv.listFoodPrices)
+	mux.HandleFunc("PUT /api/food-prices/{foodId}", srv.setFoodPrice)
+	mux.HandleFunc("DELETE /api/food-prices/{foodId}", srv.deleteFoodPrice)
+	mux.HandleFunc("POST /api/food-prices/estimate", srv.estimateFoodCost)
+	mux.HandleFunc("GET /api/food-prices/weekly", srv.getWeeklyFoodCosts)
+
+	// Grocery lists and shopping-to-logging rollup
+	mux.HandleFunc("GET /api/grocery-lists", srv.listGroceryLists)
+	mux.HandleFunc("POST /api/grocery-lists", srv.createGroceryList)
+	mux.HandleFunc("PATCH /api/grocery-lists/{id}/items/{itemId}", srv.setGroceryItemPurchased)
+	mux.HandleFunc("DELETE /api/grocery-lists/{id}", srv.deleteGroceryList)
+	mux.HandleFunc("GET /api/stats/shopping", srv.getShoppingRollup)
 
 	// Nutrition plan routes (Issue #27)
 	mux.HandleFunc("POST /api/plans", srv.createPlan)
@@ -179,20 +552,32 @@ func NewServer(db store.DBTX) *Server {
 	mux.HandleFunc("GET /api/plans/active", srv.getActivePlan)
 	mux.HandleFunc("GET /api/plans/current-week", srv.getCurrentWeekTarget)
 	mux.HandleFunc("GET /api/plans/active/analysis", srv.analyzeActivePlan)
+	mux.HandleFunc("GET /api/plans/active/event-projection", srv.getActiveEventProjection)
+	mux.HandleFunc("GET /api/plans/active/daily-targets", srv.getActivePlanDailyTargets)
+	mux.HandleFunc("GET /api/plans/completion/pending", srv.getPendingPlanCompletion)
 	mux.HandleFunc("GET /api/plans/{id}", srv.getPlanByID)
 	mux.HandleFunc("GET /api/plans/{id}/analysis", srv.analyzePlan)
+	mux.HandleFunc("GET /api/plans/{id}/event-projection", srv.getEventProjection)
 	mux.HandleFunc("GET /api/plans/{id}/phase-insight", srv.getPhaseInsight)
+	mux.HandleFunc("GET /api/plans/{id}/daily-targets", srv.getPlanDailyTargets)
 	mux.HandleFunc("POST /api/plans/{id}/complete", srv.completePlan)
+	mux.HandleFunc("GET /api/plans/{id}/retrospective", srv.getPlanRetrospective)
+	mux.HandleFunc("POST /api/plans/{id}/next-phase/dismiss", srv.dismissNextPhasePrompt)
 	mux.HandleFunc("POST /api/plans/{id}/abandon", srv.abandonPlan)
 	mux.HandleFunc("POST /api/plans/{id}/pause", srv.pausePlan)
 	mux.HandleFunc("POST /api/plans/{id}/resume", srv.resumePlan)
 	mux.HandleFunc("POST /api/plans/{id}/recalibrate", srv.recalibratePlan)
 	mux.HandleFunc("GET /api/plans/{id}/recalibrations", srv.getRecalibrationHistory)
+	mux.HandleFunc("GET /api/plans/{id}/regenerate/preview", srv.previewRegeneration)
+	mux.HandleFunc("POST /api/plans/{id}/regenerate", srv.regeneratePlan)
+	mux.HandleFunc("PATCH /api/plans/{id}/weeks/{week}", srv.editWeeklyTarget)
 	mux.HandleFunc("DELETE /api/plans/{id}", srv.deletePlan)
 
 	// Training program routes (Program Management feature)
 	mux.HandleFunc("GET /api/training-programs", srv.listPrograms)
+	mux.HandleFunc("GET /api/training-programs/recommended", srv.getRecommendedPrograms)
 	mux.HandleFunc("POST /api/training-programs", srv.createProgram)
+	mux.HandleFunc("POST /api/training-programs/generate", srv.generateProgram)
 	mux.HandleFunc("GET /api/training-programs/{id}", srv.getProgramByID)
 	mux.HandleFunc("DELETE /api/training-programs/{id}", srv.deleteProgram)
 	mux.HandleFunc("GET /api/training-programs/{id}/waveform", srv.getProgramWaveform)
@@ -204,22 +589,51 @@ func NewServer(db store.DBTX) *Server {
 	mux.HandleFunc("POST /api/program-installations/{id}/abandon", srv.abandonInstallation)
 	mux.HandleFunc("DELETE /api/program-installations/{id}", srv.deleteInstallation)
 	mux.HandleFunc("GET /api/program-installations/{id}/sessions", srv.getScheduledSessions)
+	mux.HandleFunc("POST /api/program-installations/{id}/swap", srv.swapProgramDays)
+	mux.HandleFunc("POST /api/program-installations/{id}/push", srv.pushProgramDay)
+	mux.HandleFunc("PUT /api/program-installations/{id}/autoregulation", srv.setInstallationAutoregulation)
+	mux.HandleFunc("PUT /api/program-installations/{id}/deload-day-types", srv.setInstallationDeloadDayTypes)
 
 	// Metabolic Flux Engine routes
 	mux.HandleFunc("GET /api/metabolic/chart", srv.getMetabolicChart)
+	mux.HandleFunc("GET /api/metabolic/diet-fatigue", srv.getDietFatigue)
 	mux.HandleFunc("GET /api/metabolic/notification", srv.getMetabolicNotification)
 	mux.HandleFunc("POST /api/metabolic/notification/{id}/dismiss", srv.dismissMetabolicNotification)
+	mux.HandleFunc("GET /api/metabolic/adaptation", srv.getMetabolicAdaptation)
+	mux.HandleFunc("GET /api/metabolic/adaptation/history", srv.listMetabolicAdaptationHistory)
+	mux.HandleFunc("GET /api/metabolic/adaptation/notifications", srv.listMetabolicAdaptationNotifications)
+	mux.HandleFunc("POST /api/metabolic/adaptation/{id}/dismiss", srv.dismissMetabolicAdaptation)
 
 	// Weekly Debrief routes (Mission Report feature)
 	mux.HandleFunc("GET /api/debrief/weekly", srv.getWeeklyDebrief)
 	mux.HandleFunc("GET /api/debrief/weekly/{date}", srv.getWeeklyDebriefByDate)
+	mux.HandleFunc("GET /api/debrief/weekly/{date}/report.pdf", srv.getWeeklyDebriefReport)
 	mux.HandleFunc("GET /api/debrief/current", srv.getCurrentWeekDebrief)
+	mux.HandleFunc("GET /api/debrief/stale", srv.getStaleDebriefs)
+
+	// Annual review (year-end report)
+	mux.HandleFunc("GET /api/review/annual", srv.getAnnualReview)
+	mux.HandleFunc("GET /api/review/annual/{year}", srv.getAnnualReviewByYear)
+
+	// Injury risk (monthly report)
+	mux.HandleFunc("GET /api/review/injury-risk", srv.getInjuryRisk)
+	mux.HandleFunc("GET /api/review/injury-risk/{month}", srv.getInjuryRiskByMonth)
 
 	// Garmin Data Import routes
 	mux.HandleFunc("POST /api/import/garmin", srv.uploadGarminData)
 	mux.HandleFunc("POST /api/sync/garmin", srv.syncGarminData)
+	mux.HandleFunc("POST /api/sync/wearable", srv.syncWearableData)
 	mux.HandleFunc("GET /api/stats/monthly-summaries", srv.getMonthlySummaries)
 
+	// Historical nutrition import routes (MyFitnessPal / Cronometer)
+	mux.HandleFunc("POST /api/import/nutrition", srv.uploadNutritionData)
+	mux.HandleFunc("GET /api/import/nutrition/unmatched", srv.listUnmatchedFoods)
+	mux.HandleFunc("POST /api/import/nutrition/unmatched/{id}/resolve", srv.resolveUnmatchedFood)
+
+	// Full-history export and restore
+	mux.HandleFunc("GET /api/export", srv.exportHistory)
+	mux.HandleFunc("POST /api/import", srv.restoreHistory)
+
 	// Body Issues routes (Semantic Tagger - Phase 4)
 	mux.HandleFunc("POST /api/body-issues", srv.createBodyIssues)
 	mux.HandleFunc("GET /api/body-issues/active", srv.getActiveBodyIssues)
@@ -229,6 +643,30 @@ func NewServer(db store.DBTX) *Server {
 	// Strategy Auditor routes (Check Engine light - Phase 4.2)
 	mux.HandleFunc("GET /api/audit/status", srv.getAuditStatus)
 
+	// Ollama model management (admin scope for API tokens)
+	mux.HandleFunc("GET /api/admin/ollama/models", srv.getOllamaModels)
+	mux.HandleFunc("POST /api/admin/ollama/models/pull", srv.pullOllamaModel)
+	mux.HandleFunc("GET /api/admin/ollama/circuits", srv.getOllamaCircuits)
+	mux.HandleFunc("GET /api/admin/llm/usage", srv.getLLMUsage)
+	mux.HandleFunc("POST /api/admin/semantic-index/reindex", srv.reindexSemanticSearch)
+	mux.HandleFunc("POST /api/admin/digest/send", srv.sendDailyDigest)
+	mux.HandleFunc("POST /api/admin/weekly-actuals/rollup", srv.rollupWeeklyActuals)
+	mux.HandleFunc("POST /api/admin/monthly-summaries/compute", srv.computeMonthlySummaries)
+	mux.HandleFunc("POST /api/admin/reference-ranges/recompute", srv.recomputeReferenceRanges)
+
+	// Cross-entity keyword search (Postgres full-text)
+	mux.HandleFunc("GET /api/search", srv.search)
+
+	// Batched sub-requests, one transaction where every item allows it
+	mux.HandleFunc("POST /api/batch", srv.batch)
+
+	// Plateau detection routes (Plateau Breaker)
+	mux.HandleFunc("GET /api/plateaus", srv.getPlateaus)
+	mux.HandleFunc("GET /api/plateaus/history", srv.getPlateauHistory)
+
+	// Personal reference ranges for HRV, resting HR and sleep quality
+	mux.HandleFunc("GET /api/reference-ranges", srv.getReferenceRanges)
+
 	// Systemic Gyroscope routes (Load Balancing)
 	mux.HandleFunc("GET /api/systemic-load", srv.getSystemicLoad)
 
@@ -241,26 +679,70 @@ func NewServer(db store.DBTX) *Server {
 	// Movement taxonomy routes (Adaptive Movement Engine)
 	mux.HandleFunc("GET /api/movements", srv.listMovements)
 	mux.HandleFunc("GET /api/movements/filtered", srv.getFilteredMovements)
+	mux.HandleFunc("GET /api/movements/analytics", srv.getMovementAnalytics)
+	mux.HandleFunc("GET /api/movements/search", srv.searchMovements)
 	mux.HandleFunc("GET /api/movements/{id}", srv.getMovementByID)
 	mux.HandleFunc("GET /api/movements/{id}/progress", srv.getMovementProgress)
 	mux.HandleFunc("POST /api/movements/{id}/complete-session", srv.completeMovementSession)
 	mux.HandleFunc("GET /api/neural-battery", srv.getNeuralBattery)
 	mux.HandleFunc("POST /api/movements/analyze-form", srv.analyzeFormCorrection)
 
+	// Warm-up generator routes
+	mux.HandleFunc("GET /api/warmup", srv.getWarmup)
+	mux.HandleFunc("GET /api/warmup/pins", srv.listWarmupPins)
+	mux.HandleFunc("PUT /api/warmup/pins/{movementId}", srv.pinWarmupMovement)
+	mux.HandleFunc("DELETE /api/warmup/pins/{movementId}", srv.unpinWarmupMovement)
+
+	// Personal record registry
+	mux.HandleFunc("GET /api/records", srv.getRecordRegistry)
+	mux.HandleFunc("GET /api/records/history", srv.getRecordHistory)
+	mux.HandleFunc("POST /api/records", srv.addPersonalRecord)
+	mux.HandleFunc("PATCH /api/records/{id}", srv.correctPersonalRecord)
+	mux.HandleFunc("DELETE /api/records/{id}", srv.deletePersonalRecord)
+	mux.HandleFunc("GET /api/records/celebrations", srv.listRecordCelebrations)
+	mux.HandleFunc("POST /api/records/celebrations/{id}/dismiss", srv.dismissRecordCelebration)
+
+	// Fatigue-aware substitution routes (session runner)
+	mux.HandleFunc("GET /api/substitutions/today", srv.getTodaySubstitutions)
+	mux.HandleFunc("POST /api/substitutions", srv.recordSubstitution)
+
 	// Echo logging routes (Neural Echo feature)
 	srv.registerEchoRoutes()
 
 	// Voice command routes (Neural Voice Command feature)
-	voiceService := service.NewVoiceCommandService(ollamaService, bodyIssueStore, dailyLogService, foodReferenceStore)
-	voiceHandler := NewVoiceCommandHandler(voiceService)
+	voiceService := service.NewVoiceCommandService(ollamaService, bodyIssueStore, dailyLogService, foodReferenceStore, store.NewVoiceClarificationStore(db))
+	voiceService.SetFoodResolver(foodMatchService)   // Synonym-aware matching with serving conversion
+	voiceService.SetPortionLearner(foodMatchService) // Learned default portions for quantity-less logs
+	voiceService.SetArchetypeInferrer(archetypeService)
+	voiceService.SetPlanner(plannerSessionStore, plannedDayTypeStore) // Confirmed PLANNING commands
+	voiceService.SetDailyTargetRefresher(srv.planService)
+
+	// Simulated time for end-to-end tests and demos (SIM_CLOCK_ENABLED=true)
+	if simClock := simClockFromEnv(); simClock != nil {
+		srv.useSimClock(simClock,
+			dailyLogService, fatigueService, movementService, programService, solverService,
+			weeklyDebriefService, annualReviewService, auditService, systemicLoadService, garminSyncService, echoService,
+			voiceService, srv.planService, srv.metabolicService, srv.importService, srv.bodyIssueService,
+			srv.foodCostService, srv.caffeineService, personalRecordService, bodyStatusService,
+			jointIntegrityService, substitutionService, digestService, noteService, experienceService,
+			calorieEstimateService, archetypeService, rotationService, logDeletionService, weeklyActualsService,
+			monthlySummaryService, personalReferenceService, habitService, challengeService,
+			injuryRiskService, wearableSyncService, srv.exportService, adaptationService, planCompletionService,
+		)
+	}
+
+	voiceHandler := NewVoiceCommandHandler(voiceService, srv.clock)
 	mux.HandleFunc("POST /api/voice/parse", voiceHandler.ParseVoiceCommand)
+	mux.HandleFunc("GET /api/voice/clarifications", voiceHandler.ListClarifications)
+	mux.HandleFunc("POST /api/voice/clarifications/{id}/answer", voiceHandler.AnswerClarification)
+	mux.HandleFunc("POST /api/voice/clarifications/{id}/dismiss", voiceHandler.DismissClarification)
 
 	return srv
 }
 
 // Handler returns the root HTTP handler with middleware applied.
 func (s *Server) Handler() http.Handler {
-	return corsMiddleware(loggingMiddleware(s.mux))
+	return corsMiddleware(loggingMiddleware(s.tokenAuthMiddleware(s.mux), slowHandlerThreshold()))
 }
 
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
@@ -285,13 +767,33 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
+// defaultSlowHandlerMs is the latency above which a request is logged as slow.
+const defaultSlowHandlerMs = 500
+
+// slowHandlerThreshold reads SLOW_HANDLER_MS (0 disables slow-request logging).
+func slowHandlerThreshold() time.Duration {
+	ms := defaultSlowHandlerMs
+	if v := os.Getenv("SLOW_HANDLER_MS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			ms = parsed
+		} else {
+			log.Printf("ignoring invalid SLOW_HANDLER_MS %q", v)
+		}
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// loggingMiddleware logs every request, and flags those slower than slowThreshold.
+func loggingMiddleware(next http.Handler, slowThreshold time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
 		next.ServeHTTP(rw, r)
 		duration := time.Since(start)
 		log.Printf("%s %s %d %dms %s", r.Method, r.URL.Path, rw.status, duration.Milliseconds(), r.RemoteAddr)
+		if slowThreshold > 0 && duration >= slowThreshold {
+			log.Printf("slow request %s %s %dms (threshold %dms)", r.Method, r.URL.Path, duration.Milliseconds(), slowThreshold.Milliseconds())
+		}
 	})
 }
 
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"victus/internal/api/requests"
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// CreateSessionTemplateRequest is the request body for saving a session template.
+// Either the template fields are given directly, or fromDate names a day whose
+// session (by sessionOrder) is saved as the template.
+type CreateSessionTemplateRequest struct {
+	domain.SessionTemplate
+	FromDate     string `json:"fromDate,omitempty"`
+	SessionOrder int    `json:"sessionOrder,omitempty"`
+}
+
+// UpdateSessionTemplateRequest is the request body for favoriting a template.
+type UpdateSessionTemplateRequest struct {
+	Favorite bool `json:"favorite"`
+}
+
+// listSessionTemplates handles GET /api/session-templates
+func (s *Server) listSessionTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.sessionTemplateService.List(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listSessionTemplates")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+// createSessionTemplate handles POST /api/session-templates
+func (s *Server) createSessionTemplate(w http.ResponseWriter, r *http.Request) {
+	var req CreateSessionTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	t := &req.SessionTemplate
+	var err error
+	if req.FromDate != "" {
+		order := req.SessionOrder
+		if order == 0 {
+			order = 1
+		}
+		t, err = s.sessionTemplateService.CreateFromSession(r.Context(), req.Name, req.FromDate, order, req.Favorite)
+	} else {
+		err = s.sessionTemplateService.Create(r.Context(), t)
+	}
+	if err != nil {
+		switch {
+		case domain.IsValidationError(err):
+			writeDomainError(w, err, "createSessionTemplate")
+		case errors.Is(err, store.ErrSessionTemplateExists):
+			writeError(w, http.StatusConflict, "already_exists", err.Error())
+		default:
+			if !handleDailyLogError(w, err, "No log exists for fromDate") {
+				writeInternalError(w, err, "createSessionTemplate")
+			}
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(t)
+}
+
+// updateSessionTemplate handles PATCH /api/session-templates/{id}
+func (s *Server) updateSessionTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	var req UpdateSessionTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	t, err := s.sessionTemplateService.SetFavorite(r.Context(), id, req.Favorite)
+	if err != nil {
+		if errors.Is(err, store.ErrSessionTemplateNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Session template not found")
+			return
+		}
+		writeInternalError(w, err, "updateSessionTemplate")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// deleteSessionTemplate handles DELETE /api/session-templates/{id}
+func (s *Server) deleteSessionTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	if err := s.sessionTemplateService.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, store.ErrSessionTemplateNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Session template not found")
+			return
+		}
+		writeInternalError(w, err, "deleteSessionTemplate")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applySessionTemplate handles POST /api/logs/{date}/session-templates/{id}
+// Logs the template as an additional actual session on the day.
+func (s *Server) applySessionTemplate(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	log, err := s.sessionTemplateService.Apply(r.Context(), date, id, s.now())
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrSessionTemplateNotFound):
+			writeError(w, http.StatusNotFound, "not_found", "Session template not found")
+		case domain.IsValidationError(err):
+			writeDomainError(w, err, "applySessionTemplate")
+		default:
+			if !handleDailyLogError(w, err, "No log exists for this date") {
+				writeInternalError(w, err, "applySessionTemplate")
+			}
+		}
+		return
+	}
+
+	trainingLoad, err := s.dailyLogService.GetTrainingLoadMetrics(r.Context(), log.Date, log.ActualSessions, log.PlannedSessions)
+	if err != nil {
+		trainingLoad = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.DailyLogToResponseWithTrainingLoad(log, trainingLoad))
+}
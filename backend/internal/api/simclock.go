@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"victus/internal/service"
+)
+
+// simClockFromEnv returns a SimClock when SIM_CLOCK_ENABLED=true, starting at
+// SIM_CLOCK_START (YYYY-MM-DD) or, if unset or invalid, the current time.
+// Returns nil when simulated time is disabled.
+func simClockFromEnv() *service.SimClock {
+	if os.Getenv("SIM_CLOCK_ENABLED") != "true" {
+		return nil
+	}
+
+	start := time.Now()
+	if v := os.Getenv("SIM_CLOCK_START"); v != "" {
+		if t, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+			start = t.Add(12 * time.Hour) // Midday, so small advances stay on the same date
+		} else {
+			log.Printf("sim clock: ignoring invalid SIM_CLOCK_START %q", v)
+		}
+	}
+	return service.NewSimClock(start)
+}
+
+// useSimClock switches the server and services to a simulated clock and
+// registers the routes that move it.
+func (s *Server) useSimClock(clock *service.SimClock, services ...interface{ SetClock(service.Clock) }) {
+	log.Printf("sim clock: enabled at %s", clock.Now().Format(time.RFC3339))
+	s.clock = clock
+	s.simClock = clock
+	for _, svc := range services {
+		svc.SetClock(clock)
+	}
+
+	s.mux.HandleFunc("GET /api/sim/clock", s.getSimClock)
+	s.mux.HandleFunc("PUT /api/sim/clock", s.setSimClock)
+	s.mux.HandleFunc("POST /api/sim/clock/advance", s.advanceSimClock)
+}
+
+// now returns the current time from the server's clock.
+func (s *Server) now() time.Time {
+	return s.clock.Now()
+}
+
+// SimClockResponse is the simulated time.
+type SimClockResponse struct {
+	Now  string `json:"now"`  // RFC3339
+	Date string `json:"date"` // YYYY-MM-DD, what handlers treat as today
+}
+
+// SetSimClockRequest moves the simulated clock to a date.
+type SetSimClockRequest struct {
+	Date string `json:"date"` // YYYY-MM-DD
+}
+
+// AdvanceSimClockRequest moves the simulated clock forward.
+type AdvanceSimClockRequest struct {
+	Days  int `json:"days"`
+	Hours int `json:"hours"`
+}
+
+func (s *Server) writeSimClock(w http.ResponseWriter) {
+	now := s.simClock.Now()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SimClockResponse{
+		Now:  now.Format(time.RFC3339),
+		Date: now.Format("2006-01-02"),
+	})
+}
+
+// getSimClock handles GET /api/sim/clock
+func (s *Server) getSimClock(w http.ResponseWriter, r *http.Request) {
+	s.writeSimClock(w)
+}
+
+// setSimClock handles PUT /api/sim/clock
+// Sets the simulated date; the time of day is kept.
+func (s *Server) setSimClock(w http.ResponseWriter, r *http.Request) {
+	var req SetSimClockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	date, err := time.ParseInLocation("2006-01-02", req.Date, s.simClock.Now().Location())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "Date must be in YYYY-MM-DD format")
+		return
+	}
+
+	now := s.simClock.Now()
+	s.simClock.Set(time.Date(date.Year(), date.Month(), date.Day(),
+		now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), now.Location()))
+	s.writeSimClock(w)
+}
+
+// advanceSimClock handles POST /api/sim/clock/advance
+// Moves the simulated clock forward; it never moves backwards this way.
+func (s *Server) advanceSimClock(w http.ResponseWriter, r *http.Request) {
+	var req AdvanceSimClockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+	if req.Days < 0 || req.Hours < 0 || req.Days+req.Hours == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_duration", "Advance by a positive number of days or hours")
+		return
+	}
+
+	now := s.simClock.Now()
+	s.simClock.Set(now.AddDate(0, 0, req.Days).Add(time.Duration(req.Hours) * time.Hour))
+	s.writeSimClock(w)
+}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"sort"
+	"time"
 
 	"victus/internal/domain"
+	"victus/internal/service"
 )
 
 // SolveMacrosRequest represents the API request body for macro solving.
@@ -18,6 +22,8 @@ type SolveMacrosRequest struct {
 	PlannedTraining []PlannedTrainingRequest `json:"plannedTraining,omitempty"`
 	MealTime        string                   `json:"mealTime,omitempty"`
 	ActiveProtocol  string                   `json:"activeProtocol,omitempty"`
+	// Optional cost limit; needs food prices (see /api/food-prices)
+	MaxCost *float64 `json:"maxCost,omitempty"`
 }
 
 // PlannedTrainingRequest represents a planned training session in the solver request.
@@ -34,12 +40,112 @@ type SolveMacrosResponse struct {
 
 // SolutionResponse represents a single solver solution.
 type SolutionResponse struct {
-	Ingredients []IngredientResponse        `json:"ingredients"`
-	TotalMacros MacroBudgetResponse         `json:"totalMacros"`
-	MatchScore  float64                     `json:"matchScore"`
-	RecipeName  string                      `json:"recipeName"`
-	WhyText     string                      `json:"whyText"`
-	Refinement  *SemanticRefinementResponse `json:"refinement,omitempty"`
+	Ingredients    []IngredientResponse        `json:"ingredients"`
+	TotalMacros    MacroBudgetResponse         `json:"totalMacros"`
+	MatchScore     float64                     `json:"matchScore"`
+	ScoreBreakdown ScoreBreakdownResponse      `json:"scoreBreakdown"`
+	RecipeName     string                      `json:"recipeName"`
+	WhyText        string                      `json:"whyText"`
+	Refinement     *SemanticRefinementResponse `json:"refinement,omitempty"`
+	Cost           *domain.CostEstimate        `json:"cost,omitempty"`
+}
+
+// ScoreBreakdownResponse explains how a solution's matchScore was reached:
+// total = macroPoints + ingredientCountPoints + fiberPoints + preferenceBonus - absurdityPenalty.
+type ScoreBreakdownResponse struct {
+	Calories               MacroScoreResponse `json:"calories"`
+	Protein                MacroScoreResponse `json:"protein"`
+	Carbs                  MacroScoreResponse `json:"carbs"`
+	Fat                    MacroScoreResponse `json:"fat"`
+	MacroAccuracy          float64            `json:"macroAccuracy"`
+	MacroPoints            float64            `json:"macroPoints"`
+	IngredientCountPoints  float64            `json:"ingredientCountPoints"`
+	IngredientCountPenalty float64            `json:"ingredientCountPenalty"`
+	EstimatedFiberG        float64            `json:"estimatedFiberG"`
+	FiberPoints            float64            `json:"fiberPoints"`
+	PreferenceBonus        float64            `json:"preferenceBonus"`
+	SatietyBonus           float64            `json:"satietyBonus"`
+	Compatibility          float64            `json:"compatibility"`
+	AbsurdityCode          string             `json:"absurdityCode,omitempty"`
+	AbsurdityPenalty       float64            `json:"absurdityPenalty"`
+	Total                  float64            `json:"total"`
+}
+
+// MacroScoreResponse is one macro's contribution to macroAccuracy.
+type MacroScoreResponse struct {
+	Target       float64 `json:"target"`
+	Actual       float64 `json:"actual"`
+	ErrorPercent float64 `json:"errorPercent"`
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`
+}
+
+// ScoreSolutionRequest represents the API request body for re-scoring an edited solution.
+type ScoreSolutionRequest struct {
+	RemainingProteinG int                      `json:"remainingProteinG"`
+	RemainingCarbsG   int                      `json:"remainingCarbsG"`
+	RemainingFatG     int                      `json:"remainingFatG"`
+	RemainingCalories int                      `json:"remainingCalories"`
+	Ingredients       []ScoreIngredientRequest `json:"ingredients"`
+}
+
+// ScoreIngredientRequest is one food and amount in an edited solution.
+type ScoreIngredientRequest struct {
+	FoodID  int64   `json:"foodId"`
+	AmountG float64 `json:"amountG"`
+}
+
+// SolverFeedbackRequest records what the user did with a suggested solution.
+type SolverFeedbackRequest struct {
+	Action        string  `json:"action"`                  // "accepted", "rejected", or "edited"
+	FoodIDs       []int64 `json:"foodIds"`                 // Foods in the suggestion
+	EditedFoodIDs []int64 `json:"editedFoodIds,omitempty"` // Foods actually logged (edited only)
+	Date          string  `json:"date,omitempty"`          // Day the meal was logged (defaults to today when meal is set)
+	Meal          *string `json:"meal,omitempty"`          // "breakfast", "lunch", or "dinner", for satiety learning
+}
+
+// SolverPreferencesResponse lists the learned food preferences, most liked first.
+type SolverPreferencesResponse struct {
+	Preferences []FoodPreferenceResponse `json:"preferences"`
+}
+
+// FoodPreferenceResponse is the learned preference for one food.
+type FoodPreferenceResponse struct {
+	FoodID       int64   `json:"foodId"`
+	FoodName     string  `json:"foodName"`
+	Level        string  `json:"level"`  // "liked", "tolerated", or "never_suggest"
+	Weight       float64 `json:"weight"` // -1 to 1
+	Score        float64 `json:"score"`
+	SignalCount  int     `json:"signalCount"`
+	LastSignalAt string  `json:"lastSignalAt"`
+}
+
+// MealHungerRequest is the hunger rating that followed a meal.
+type MealHungerRequest struct {
+	Hunger int `json:"hunger"` // 1 (satisfied) to 5 (ravenous) before the next meal
+}
+
+// SatietyResponse is the learned satiety of foods and categories, most filling first.
+type SatietyResponse struct {
+	Foods              []FoodSatietyResponse     `json:"foods"`
+	Categories         []CategorySatietyResponse `json:"categories"`
+	RecentHunger       *float64                  `json:"recentHunger,omitempty"` // Average meal hunger over the last 7 days
+	HungerTrendingHigh bool                      `json:"hungerTrendingHigh"`     // Fatburner-day suggestions favor filling foods
+}
+
+// FoodSatietyResponse is the learned satiety of one food.
+type FoodSatietyResponse struct {
+	FoodID   int64   `json:"foodId"`
+	FoodName string  `json:"foodName"`
+	Score    float64 `json:"score"` // -1 (followed by more hunger) to 1 (less)
+	Meals    int     `json:"meals"`
+}
+
+// CategorySatietyResponse is the learned satiety of one food category.
+type CategorySatietyResponse struct {
+	Category string  `json:"category"`
+	Score    float64 `json:"score"` // -1 to 1
+	Meals    int     `json:"meals"`
 }
 
 // SemanticRefinementResponse represents AI-enhanced recipe presentation.
@@ -47,6 +153,7 @@ type SemanticRefinementResponse struct {
 	MissionTitle      string  `json:"missionTitle"`
 	TacticalPrep      string  `json:"tacticalPrep"`
 	AbsurdityAlert    *string `json:"absurdityAlert,omitempty"`
+	FlavorPatch       *string `json:"flavorPatch,omitempty"`
 	ContextualInsight string  `json:"contextualInsight"`
 	GeneratedByLLM    bool    `json:"generatedByLlm"`
 }
@@ -120,8 +227,12 @@ func (s *Server) solveMacros(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	result, err := s.solverService.SolveWithContext(r.Context(), budget, trainingCtx)
+	result, err := s.solverService.SolveWithContext(r.Context(), budget, trainingCtx, req.MaxCost)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMaxCost) {
+			writeDomainError(w, err, "solveMacros")
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "solver_error", err.Error())
 		return
 	}
@@ -133,43 +244,264 @@ func (s *Server) solveMacros(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, sol := range result.Solutions {
-		ingredients := make([]IngredientResponse, 0, len(sol.Ingredients))
-		for _, ing := range sol.Ingredients {
-			ingredients = append(ingredients, IngredientResponse{
-				FoodID:   ing.Food.ID,
-				FoodName: ing.Food.FoodItem,
-				AmountG:  ing.AmountG,
-				Display:  ing.Display,
-			})
+		response.Solutions = append(response.Solutions, solutionToResponse(sol))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// scoreSolution handles POST /api/solver/score
+func (s *Server) scoreSolution(w http.ResponseWriter, r *http.Request) {
+	var req ScoreSolutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	if req.RemainingCalories <= 0 {
+		writeError(w, http.StatusBadRequest, "insufficient_budget", "Remaining calories must be positive to score")
+		return
+	}
+
+	budget := domain.MacroBudget{
+		ProteinG:     float64(req.RemainingProteinG),
+		CarbsG:       float64(req.RemainingCarbsG),
+		FatG:         float64(req.RemainingFatG),
+		CaloriesKcal: req.RemainingCalories,
+	}
+	items := make([]service.SolverScoreItem, 0, len(req.Ingredients))
+	for _, ing := range req.Ingredients {
+		items = append(items, service.SolverScoreItem{FoodID: ing.FoodID, AmountG: ing.AmountG})
+	}
+
+	solution, err := s.solverService.Score(r.Context(), budget, items)
+	if err != nil {
+		writeDomainError(w, err, "scoreSolution")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(solutionToResponse(*solution))
+}
+
+// recordSolverFeedback handles POST /api/solver/feedback
+func (s *Server) recordSolverFeedback(w http.ResponseWriter, r *http.Request) {
+	var req SolverFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	action, err := domain.ParseSolverFeedbackAction(req.Action)
+	if err != nil {
+		writeDomainError(w, err, "recordSolverFeedback")
+		return
+	}
+
+	feedback := domain.SolverFeedback{
+		Action:        action,
+		FoodIDs:       req.FoodIDs,
+		EditedFoodIDs: req.EditedFoodIDs,
+		Date:          req.Date,
+	}
+	if req.Meal != nil {
+		meal := domain.MealName(*req.Meal)
+		feedback.Meal = &meal
+	}
+	if err := s.solverService.RecordFeedback(r.Context(), feedback); err != nil {
+		writeDomainError(w, err, "recordSolverFeedback")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getSolverPreferences handles GET /api/solver/preferences
+func (s *Server) getSolverPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := s.solverService.Preferences(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getSolverPreferences")
+		return
+	}
+	foods, err := s.foodReferenceStore.ListPantryFoods(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getSolverPreferences")
+		return
+	}
+	names := make(map[int64]string, len(foods))
+	for _, f := range foods {
+		names[f.ID] = f.FoodItem
+	}
+
+	response := SolverPreferencesResponse{Preferences: make([]FoodPreferenceResponse, 0, len(prefs))}
+	for _, p := range prefs {
+		response.Preferences = append(response.Preferences, FoodPreferenceResponse{
+			FoodID:       p.FoodReferenceID,
+			FoodName:     names[p.FoodReferenceID],
+			Level:        string(p.Level),
+			Weight:       p.Weight,
+			Score:        p.Score,
+			SignalCount:  p.SignalCount,
+			LastSignalAt: p.LastSignalAt.Format(time.RFC3339),
+		})
+	}
+	sort.Slice(response.Preferences, func(i, j int) bool {
+		a, b := response.Preferences[i], response.Preferences[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
 		}
+		return a.FoodID < b.FoodID
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// setMealHunger handles PUT /api/logs/{date}/meal-hunger/{meal}
+func (s *Server) setMealHunger(w http.ResponseWriter, r *http.Request) {
+	var req MealHungerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	hunger := domain.MealHunger{
+		Date:   r.PathValue("date"),
+		Meal:   domain.MealName(r.PathValue("meal")),
+		Hunger: req.Hunger,
+	}
+	if err := s.solverService.RecordMealHunger(r.Context(), hunger); err != nil {
+		writeDomainError(w, err, "setMealHunger")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hunger)
+}
 
-		solResp := SolutionResponse{
-			Ingredients: ingredients,
-			TotalMacros: MacroBudgetResponse{
-				ProteinG:     sol.TotalMacros.ProteinG,
-				CarbsG:       sol.TotalMacros.CarbsG,
-				FatG:         sol.TotalMacros.FatG,
-				CaloriesKcal: sol.TotalMacros.CaloriesKcal,
-			},
-			MatchScore: sol.MatchScore,
-			RecipeName: sol.RecipeName,
-			WhyText:    sol.WhyText,
+// getSolverSatiety handles GET /api/solver/satiety
+func (s *Server) getSolverSatiety(w http.ResponseWriter, r *http.Request) {
+	profile, err := s.solverService.Satiety(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getSolverSatiety")
+		return
+	}
+
+	response := SatietyResponse{
+		Foods:      []FoodSatietyResponse{},
+		Categories: []CategorySatietyResponse{},
+	}
+	if profile != nil {
+		foods, err := s.foodReferenceStore.ListPantryFoods(r.Context())
+		if err != nil {
+			writeInternalError(w, err, "getSolverSatiety")
+			return
+		}
+		names := make(map[int64]string, len(foods))
+		for _, f := range foods {
+			names[f.ID] = f.FoodItem
 		}
 
-		// Add refinement if available
-		if sol.Refinement != nil {
-			solResp.Refinement = &SemanticRefinementResponse{
-				MissionTitle:      sol.Refinement.MissionTitle,
-				TacticalPrep:      sol.Refinement.TacticalPrep,
-				AbsurdityAlert:    sol.Refinement.AbsurdityAlert,
-				ContextualInsight: sol.Refinement.ContextualInsight,
-				GeneratedByLLM:    sol.Refinement.GeneratedByLLM,
+		for id, sc := range profile.Foods {
+			response.Foods = append(response.Foods, FoodSatietyResponse{
+				FoodID: id, FoodName: names[id], Score: sc.Score, Meals: sc.Meals,
+			})
+		}
+		sort.Slice(response.Foods, func(i, j int) bool {
+			a, b := response.Foods[i], response.Foods[j]
+			if a.Score != b.Score {
+				return a.Score > b.Score
 			}
+			return a.FoodID < b.FoodID
+		})
+		for category, sc := range profile.Categories {
+			response.Categories = append(response.Categories, CategorySatietyResponse{
+				Category: string(category), Score: sc.Score, Meals: sc.Meals,
+			})
 		}
-
-		response.Solutions = append(response.Solutions, solResp)
+		sort.Slice(response.Categories, func(i, j int) bool {
+			a, b := response.Categories[i], response.Categories[j]
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+			return a.Category < b.Category
+		})
+		response.RecentHunger = profile.RecentHunger
+		response.HungerTrendingHigh = profile.HungerTrendingHigh
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// solutionToResponse converts a solver solution to its API response.
+func solutionToResponse(sol domain.SolverSolution) SolutionResponse {
+	ingredients := make([]IngredientResponse, 0, len(sol.Ingredients))
+	for _, ing := range sol.Ingredients {
+		ingredients = append(ingredients, IngredientResponse{
+			FoodID:   ing.Food.ID,
+			FoodName: ing.Food.FoodItem,
+			AmountG:  ing.AmountG,
+			Display:  ing.Display,
+		})
+	}
+
+	resp := SolutionResponse{
+		Ingredients: ingredients,
+		TotalMacros: MacroBudgetResponse{
+			ProteinG:     sol.TotalMacros.ProteinG,
+			CarbsG:       sol.TotalMacros.CarbsG,
+			FatG:         sol.TotalMacros.FatG,
+			CaloriesKcal: sol.TotalMacros.CaloriesKcal,
+		},
+		MatchScore:     sol.MatchScore,
+		ScoreBreakdown: scoreBreakdownToResponse(sol.Breakdown),
+		RecipeName:     sol.RecipeName,
+		WhyText:        sol.WhyText,
+		Cost:           sol.Cost,
+	}
+
+	// Add refinement if available
+	if sol.Refinement != nil {
+		resp.Refinement = &SemanticRefinementResponse{
+			MissionTitle:      sol.Refinement.MissionTitle,
+			TacticalPrep:      sol.Refinement.TacticalPrep,
+			AbsurdityAlert:    sol.Refinement.AbsurdityAlert,
+			FlavorPatch:       sol.Refinement.FlavorPatch,
+			ContextualInsight: sol.Refinement.ContextualInsight,
+			GeneratedByLLM:    sol.Refinement.GeneratedByLLM,
+		}
+	}
+	return resp
+}
+
+func scoreBreakdownToResponse(b domain.SolverScoreBreakdown) ScoreBreakdownResponse {
+	macro := func(c domain.MacroScoreComponent) MacroScoreResponse {
+		return MacroScoreResponse{
+			Target:       c.Target,
+			Actual:       c.Actual,
+			ErrorPercent: c.ErrorPercent,
+			Score:        c.Score,
+			Weight:       c.Weight,
+		}
+	}
+	return ScoreBreakdownResponse{
+		Calories:               macro(b.Calories),
+		Protein:                macro(b.Protein),
+		Carbs:                  macro(b.Carbs),
+		Fat:                    macro(b.Fat),
+		MacroAccuracy:          b.MacroAccuracy,
+		MacroPoints:            b.MacroPoints,
+		IngredientCountPoints:  b.IngredientCountPoints,
+		IngredientCountPenalty: b.IngredientCountPenalty,
+		EstimatedFiberG:        b.EstimatedFiberG,
+		FiberPoints:            b.FiberPoints,
+		PreferenceBonus:        b.PreferenceBonus,
+		SatietyBonus:           b.SatietyBonus,
+		Compatibility:          b.Compatibility,
+		AbsurdityCode:          b.AbsurdityCode,
+		AbsurdityPenalty:       b.AbsurdityPenalty,
+		Total:                  b.Total,
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// StrengthRotationResponse is where the user is in their strength split.
+type StrengthRotationResponse struct {
+	Split         string   `json:"split"`         // push_pull_legs, upper_lower, full_body
+	Sequence      []string `json:"sequence"`      // The split's archetypes in order
+	NextArchetype string   `json:"nextArchetype"` // Pre-filled on new strength sessions
+	LastArchetype string   `json:"lastArchetype,omitempty"`
+	LastDate      string   `json:"lastDate,omitempty"`
+	UpdatedAt     string   `json:"updatedAt,omitempty"`
+}
+
+// UpdateStrengthRotationRequest is the request body for PUT /api/strength-rotation.
+type UpdateStrengthRotationRequest struct {
+	Split         string `json:"split"`
+	NextArchetype string `json:"nextArchetype,omitempty"` // Omit to start the split from the beginning
+}
+
+// getStrengthRotation handles GET /api/strength-rotation
+func (s *Server) getStrengthRotation(w http.ResponseWriter, r *http.Request) {
+	rotation, err := s.rotationService.Get(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getStrengthRotation")
+		return
+	}
+	writeJSON(w, http.StatusOK, strengthRotationToResponse(rotation))
+}
+
+// updateStrengthRotation handles PUT /api/strength-rotation
+// Switches the split or corrects the next expected archetype.
+func (s *Server) updateStrengthRotation(w http.ResponseWriter, r *http.Request) {
+	var req UpdateStrengthRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Could not parse request body as JSON")
+		return
+	}
+
+	split, err := domain.ParseStrengthSplit(req.Split)
+	if err != nil {
+		writeDomainError(w, err, "updateStrengthRotation")
+		return
+	}
+	var next domain.Archetype
+	if req.NextArchetype != "" {
+		if next, err = domain.ParseArchetype(req.NextArchetype); err != nil {
+			writeDomainError(w, err, "updateStrengthRotation")
+			return
+		}
+	}
+
+	rotation, err := s.rotationService.Configure(r.Context(), split, next)
+	if err != nil {
+		writeDomainError(w, err, "updateStrengthRotation")
+		return
+	}
+	writeJSON(w, http.StatusOK, strengthRotationToResponse(rotation))
+}
+
+func strengthRotationToResponse(r *domain.StrengthRotation) StrengthRotationResponse {
+	sequence := domain.StrengthSplitSequences[r.Split]
+	resp := StrengthRotationResponse{
+		Split:         string(r.Split),
+		Sequence:      make([]string, len(sequence)),
+		NextArchetype: string(r.NextArchetype()),
+		LastArchetype: string(r.LastArchetype),
+		LastDate:      r.LastDate,
+	}
+	for i, a := range sequence {
+		resp.Sequence[i] = string(a)
+	}
+	if !r.UpdatedAt.IsZero() {
+		resp.UpdatedAt = r.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
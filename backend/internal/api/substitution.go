@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"victus/internal/domain"
+)
+
+// RecordSubstitutionRequest is the body of POST /api/substitutions.
+type RecordSubstitutionRequest struct {
+	Date            string   `json:"date,omitempty"` // YYYY-MM-DD; defaults to today
+	SessionLabel    string   `json:"sessionLabel"`
+	OriginalID      string   `json:"originalId"`
+	SubstituteID    string   `json:"substituteId"`
+	FatiguedMuscles []string `json:"fatiguedMuscles"`
+	Accepted        bool     `json:"accepted"`
+}
+
+// getTodaySubstitutions handles GET /api/substitutions/today
+// Returns swaps for exercises in today's session that target an overreached muscle.
+func (s *Server) getTodaySubstitutions(w http.ResponseWriter, r *http.Request) {
+	result, err := s.substitutionService.ProposeToday(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "getTodaySubstitutions")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// recordSubstitution handles POST /api/substitutions
+// Logs whether the runner's proposed swap was taken, for the weekly debrief.
+func (s *Server) recordSubstitution(w http.ResponseWriter, r *http.Request) {
+	var req RecordSubstitutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	muscles := make([]domain.MuscleGroup, len(req.FatiguedMuscles))
+	for i, m := range req.FatiguedMuscles {
+		muscles[i] = domain.MuscleGroup(m)
+	}
+	decision, err := s.substitutionService.RecordDecision(r.Context(), domain.SubstitutionDecision{
+		Date:            req.Date,
+		SessionLabel:    req.SessionLabel,
+		OriginalID:      req.OriginalID,
+		SubstituteID:    req.SubstituteID,
+		FatiguedMuscles: muscles,
+		Accepted:        req.Accepted,
+	})
+	if err != nil {
+		writeDomainError(w, err, "recordSubstitution")
+		return
+	}
+	writeJSON(w, http.StatusCreated, decision)
+}
@@ -4,12 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"time"
+
+	"victus/internal/domain"
 )
 
-// StartBackgroundJobs launches long-running background tasks (e.g. daily Garmin sync).
-// Call this in a goroutine from main, passing a context cancelled on shutdown.
+// StartBackgroundJobs launches long-running background tasks (e.g. daily Garmin sync,
+// draft session lifecycle, kcal factor auto-tuning, end-of-day digest). Call this in a goroutine from
+// main, passing a context cancelled on shutdown. Safe to call on every replica:
+// each job only runs on the replica holding its lease.
 func (s *Server) StartBackgroundJobs(ctx context.Context) {
+	go s.echoService.RunDraftLifecycleSchedule(ctx)
+	go s.planService.RunKcalFactorTuneSchedule(ctx)
+	go s.ollamaService.DetectModels(ctx)
+	go s.semanticSearchService.RunReindexSchedule(ctx)
+	go s.digestService.RunDailySchedule(ctx)
+	go s.weeklyActualsService.RunNightlySchedule(ctx)
+	go s.monthlySummaryService.RunNightlySchedule(ctx)
+	go s.referenceRangeService.RunNightlySchedule(ctx)
+	go s.challengeService.RunNightlySchedule(ctx)
+	go s.adaptationService.RunNightlySchedule(ctx)
+	go s.planCompletionService.RunNightlySchedule(ctx)
 	s.garminSyncService.RunDailySchedule(ctx)
 }
 
@@ -18,7 +32,7 @@ func (s *Server) StartBackgroundJobs(ctx context.Context) {
 func (s *Server) syncGarminData(w http.ResponseWriter, r *http.Request) {
 	date := r.URL.Query().Get("date")
 	if date == "" {
-		date = time.Now().Format("2006-01-02")
+		date = s.now().Format("2006-01-02")
 	}
 
 	result, err := s.garminSyncService.SyncDate(r.Context(), date)
@@ -30,3 +44,22 @@ func (s *Server) syncGarminData(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
 }
+
+// syncWearableData handles POST /api/sync/wearable
+// Imports a Garmin Connect or Apple Health export: day metrics (HRV, resting HR,
+// sleep, active calories) into daily logs and activities into actual sessions.
+// Records already imported from the same source are skipped.
+func (s *Server) syncWearableData(w http.ResponseWriter, r *http.Request) {
+	var payload domain.WearablePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		return
+	}
+
+	result, err := s.wearableSyncService.Sync(r.Context(), payload)
+	if err != nil {
+		writeDomainError(w, err, "syncWearableData")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
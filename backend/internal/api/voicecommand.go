@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"time"
+	"strconv"
 
 	"victus/internal/domain"
 	"victus/internal/service"
@@ -14,11 +14,12 @@ import (
 // VoiceCommandHandler handles voice command parsing requests.
 type VoiceCommandHandler struct {
 	voiceService *service.VoiceCommandService
+	clock        service.Clock
 }
 
 // NewVoiceCommandHandler creates a new voice command handler.
-func NewVoiceCommandHandler(voiceService *service.VoiceCommandService) *VoiceCommandHandler {
-	return &VoiceCommandHandler{voiceService: voiceService}
+func NewVoiceCommandHandler(voiceService *service.VoiceCommandService, clock service.Clock) *VoiceCommandHandler {
+	return &VoiceCommandHandler{voiceService: voiceService, clock: clock}
 }
 
 // ParseVoiceCommandRequest represents the input for voice command parsing.
@@ -71,7 +72,7 @@ func (h *VoiceCommandHandler) ParseVoiceCommand(w http.ResponseWriter, r *http.R
 
 	// Default to today's date if not provided
 	if req.Date == "" {
-		req.Date = time.Now().Format("2006-01-02")
+		req.Date = h.clock.Now().Format("2006-01-02")
 	}
 
 	log.Printf("[VOICE] Queued voice command: %q (date: %s)", req.RawInput, req.Date)
@@ -89,6 +90,71 @@ func (h *VoiceCommandHandler) ParseVoiceCommand(w http.ResponseWriter, r *http.R
 	})
 }
 
+// AnswerClarificationRequest is the body of POST /api/voice/clarifications/{id}/answer.
+type AnswerClarificationRequest struct {
+	Answer string `json:"answer"` // e.g. "about 20 minutes"
+}
+
+// VoiceClarificationResponse is a held command after an answer or dismissal.
+type VoiceClarificationResponse struct {
+	Clarification *domain.VoiceClarification `json:"clarification"`
+	ActionTaken   *ActionTaken               `json:"action_taken,omitempty"` // What was persisted once resolved
+}
+
+// ListClarifications handles GET /api/voice/clarifications
+// Returns the voice commands held for an answer, oldest first.
+func (h *VoiceCommandHandler) ListClarifications(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.voiceService.PendingClarifications(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listVoiceClarifications")
+		return
+	}
+	writeJSON(w, http.StatusOK, pending)
+}
+
+// AnswerClarification handles POST /api/voice/clarifications/{id}/answer
+func (h *VoiceCommandHandler) AnswerClarification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	var req AnswerClarificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON request body")
+		return
+	}
+
+	outcome, err := h.voiceService.AnswerClarification(r.Context(), id, req.Answer)
+	if err != nil {
+		writeDomainError(w, err, "answerVoiceClarification")
+		return
+	}
+
+	response := VoiceClarificationResponse{Clarification: outcome.Clarification}
+	if outcome.Action != nil {
+		response.ActionTaken = &ActionTaken{Type: outcome.Action.Type, Summary: outcome.Action.Summary}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// DismissClarification handles POST /api/voice/clarifications/{id}/dismiss
+func (h *VoiceCommandHandler) DismissClarification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "id must be a valid integer")
+		return
+	}
+
+	clarification, err := h.voiceService.DismissClarification(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "dismissVoiceClarification")
+		return
+	}
+	writeJSON(w, http.StatusOK, VoiceClarificationResponse{Clarification: clarification})
+}
+
 // writeJSON writes a JSON response with the given status code.
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
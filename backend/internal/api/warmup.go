@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// WarmupResponse is the response for GET /api/warmup.
+type WarmupResponse struct {
+	Archetype domain.Archetype         `json:"archetype"`
+	Exercises []domain.SessionExercise `json:"exercises"`
+}
+
+// getWarmup handles GET /api/warmup?archetype=push
+// Returns a prepare-phase exercise list that avoids currently stressed joints.
+func (s *Server) getWarmup(w http.ResponseWriter, r *http.Request) {
+	archetype, err := domain.ParseArchetype(r.URL.Query().Get("archetype"))
+	if err != nil {
+		writeDomainError(w, err, "getWarmup")
+		return
+	}
+
+	exercises, err := s.movementService.GenerateWarmup(r.Context(), archetype, s.now())
+	if err != nil {
+		writeInternalError(w, err, "getWarmup")
+		return
+	}
+	writeJSON(w, http.StatusOK, WarmupResponse{Archetype: archetype, Exercises: exercises})
+}
+
+// listWarmupPins handles GET /api/warmup/pins
+func (s *Server) listWarmupPins(w http.ResponseWriter, r *http.Request) {
+	pins, err := s.movementService.ListWarmupPins(r.Context())
+	if err != nil {
+		writeInternalError(w, err, "listWarmupPins")
+		return
+	}
+	writeJSON(w, http.StatusOK, pins)
+}
+
+// pinWarmupMovement handles PUT /api/warmup/pins/{movementId}
+func (s *Server) pinWarmupMovement(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("movementId")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing_id", "movementId is required")
+		return
+	}
+
+	if err := s.movementService.PinWarmupMovement(r.Context(), id); err != nil {
+		if errors.Is(err, store.ErrMovementNotFound) {
+			writeDomainError(w, err, "pinWarmupMovement")
+			return
+		}
+		writeInternalError(w, err, "pinWarmupMovement")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// unpinWarmupMovement handles DELETE /api/warmup/pins/{movementId}
+func (s *Server) unpinWarmupMovement(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("movementId")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing_id", "movementId is required")
+		return
+	}
+
+	if err := s.movementService.UnpinWarmupMovement(r.Context(), id); err != nil {
+		writeInternalError(w, err, "unpinWarmupMovement")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
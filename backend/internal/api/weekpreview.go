@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// PlanningConflictsResponse is the response for GET /api/planning/conflicts.
+type PlanningConflictsResponse struct {
+	WeekStartDate string                       `json:"weekStartDate"`
+	WeekEndDate   string                       `json:"weekEndDate"`
+	Conflicts     []domain.WeekPreviewConflict `json:"conflicts"`
+	DoubleDays    []domain.DoubleDayWarning    `json:"doubleDays"`
+}
+
+// getWeekPreview handles GET /api/planning/week-preview
+// Optional query param: ?start=YYYY-MM-DD (defaults to next Monday)
+func (s *Server) getWeekPreview(w http.ResponseWriter, r *http.Request) {
+	preview, ok := s.loadWeekPreview(w, r, "getWeekPreview")
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// getPlanningConflicts handles GET /api/planning/conflicts
+// Optional query param: ?start=YYYY-MM-DD (defaults to next Monday)
+// Returns only the week's conflicts, with double-day details for move suggestions.
+func (s *Server) getPlanningConflicts(w http.ResponseWriter, r *http.Request) {
+	preview, ok := s.loadWeekPreview(w, r, "getPlanningConflicts")
+	if !ok {
+		return
+	}
+
+	resp := PlanningConflictsResponse{
+		WeekStartDate: preview.WeekStartDate,
+		WeekEndDate:   preview.WeekEndDate,
+		Conflicts:     preview.Conflicts,
+		DoubleDays:    make([]domain.DoubleDayWarning, 0),
+	}
+	for _, day := range preview.Days {
+		if day.DoubleDay != nil {
+			resp.DoubleDays = append(resp.DoubleDays, *day.DoubleDay)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// loadWeekPreview parses ?start and builds the preview, writing the error response on failure.
+func (s *Server) loadWeekPreview(w http.ResponseWriter, r *http.Request, handler string) (*domain.WeekPreview, bool) {
+	var weekStart time.Time
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		parsed, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_date", "start must be in YYYY-MM-DD format")
+			return nil, false
+		}
+		weekStart = parsed
+	}
+
+	preview, err := s.weekPreviewService.GetPreview(r.Context(), weekStart, s.now())
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			writeError(w, http.StatusBadRequest, "profile_required", "A user profile is required for the week preview")
+			return nil, false
+		}
+		if errors.Is(err, domain.ErrInsufficientWeightData) {
+			writeError(w, http.StatusBadRequest, "insufficient_data", "No recent weight available to project targets")
+			return nil, false
+		}
+		writeInternalError(w, err, handler)
+		return nil, false
+	}
+	return preview, true
+}
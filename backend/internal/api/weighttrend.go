@@ -3,9 +3,11 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"victus/internal/api/requests"
+	"victus/internal/domain"
 )
 
 func (s *Server) getWeightTrend(w http.ResponseWriter, r *http.Request) {
@@ -14,7 +16,7 @@ func (s *Server) getWeightTrend(w http.ResponseWriter, r *http.Request) {
 		rangeParam = "30d"
 	}
 
-	startDate, ok := parseWeightTrendRange(rangeParam, time.Now())
+	startDate, ok := parseWeightTrendRange(rangeParam, s.now())
 	if !ok {
 		writeError(w, http.StatusBadRequest, "invalid_range", "Range must be one of 7d, 30d, 90d, all")
 		return
@@ -30,6 +32,65 @@ func (s *Server) getWeightTrend(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests.WeightTrendToResponse(points, trend))
 }
 
+// getSmoothedWeightTrend handles GET /api/weight/trend?method=ema|hull|loess&window=N&range=30d
+// Method and window default to the profile's smoothing preference.
+func (s *Server) getSmoothedWeightTrend(w http.ResponseWriter, r *http.Request) {
+	rangeParam := r.URL.Query().Get("range")
+	if rangeParam == "" {
+		rangeParam = "30d"
+	}
+
+	startDate, ok := parseWeightTrendRange(rangeParam, s.now())
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_range", "Range must be one of 7d, 30d, 90d, all")
+		return
+	}
+
+	var override domain.WeightSmoothing
+	if methodParam := r.URL.Query().Get("method"); methodParam != "" {
+		method, err := domain.ParseWeightTrendMethod(methodParam)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_method", err.Error())
+			return
+		}
+		override.Method = method
+	}
+	if windowParam := r.URL.Query().Get("window"); windowParam != "" {
+		window, err := strconv.Atoi(windowParam)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_window", "Window must be a whole number of samples")
+			return
+		}
+		override.Window = window
+	}
+	if err := override.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_window", err.Error())
+		return
+	}
+
+	smoothed, trend, err := s.dailyLogService.GetSmoothedWeightTrend(r.Context(), startDate, override)
+	if err != nil {
+		writeInternalError(w, err, "getSmoothedWeightTrend")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.SmoothedWeightTrendToResponse(smoothed, trend))
+}
+
+// getWeighInOffsets handles GET /api/weight/time-of-day-offsets
+// Returns the learned time-of-day offset curve applied to timed weigh-ins.
+func (s *Server) getWeighInOffsets(w http.ResponseWriter, r *http.Request) {
+	curve, err := s.dailyLogService.GetWeighInOffsets(r.Context(), s.now())
+	if err != nil {
+		writeInternalError(w, err, "getWeighInOffsets")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests.WeighInOffsetCurveToResponse(curve))
+}
+
 func parseWeightTrendRange(rangeParam string, now time.Time) (string, bool) {
 	switch rangeParam {
 	case "7d":
@@ -1,7 +1,9 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -11,35 +13,92 @@ func RunMigrations(db *sql.DB) error {
 	return RunPostgresMigrations(db)
 }
 
+// pgTableMigrations create the tables, in dependency order.
+var pgTableMigrations = []string{
+	pgCreateUserProfileTable,
+	pgCreateDailyLogsTable,
+	pgCreateTrainingConfigsTable,
+	pgCreatePlannedDayTypesTable,
+	pgCreateFoodReferenceTable,
+	pgCreateNutritionPlansTable,
+	pgCreateWeeklyTargetsTable,
+	pgCreateMuscleGroupsTable,
+	pgCreateTrainingArchetypesTable,
+	pgCreateTrainingSessionsTable, // After training_archetypes (references it)
+	pgCreateMuscleFatigueTable,
+	pgCreateMuscleFatigueSnapshotsTable, // After muscle_fatigue (history for heatmap deltas)
+	pgCreateFatigueEventsTable, // After training_sessions (references it)
+	pgCreateTrainingProgramsTable,
+	pgCreateProgramWeeksTable,
+	pgCreateProgramDaysTable,
+	pgCreateProgramInstallationsTable,
+	pgCreateMetabolicHistoryTable,
+	pgCreateMonthlySummariesTable,
+	pgCreateBodyPartIssuesTable,  // After training_sessions (references it)
+	pgCreatePlannedSessionsTable, // Ad-hoc workout planner sessions
+	pgCreateMovementsTable,
+	pgCreateUserMovementProgressTable,
+	pgCreateMovementSessionLogTable, // After movements (references it)
+	pgCreateWarmupPinsTable,         // After movements (references it)
+	pgCreateRecalibrationHistoryTable,
+	pgCreatePlateauDetectionsTable,
+	pgCreateDailyLogReconciliationsTable,
+	pgCreateDebriefRegenerationFlagsTable,
+	pgCreateImportedFoodEntriesTable,
+	pgCreateFoodSynonymsTable,
+	pgCreateFoodPortionLogTable,
+	pgCreateMealTemplatesTable,
+	pgCreateDayExemptionsTable,
+	pgCreateSessionTemplatesTable,
+	pgCreateAPITokensTable,
+	pgCreateAPITokenUsageTable,
+	pgCreateAPITokenNoncesTable,
+	pgCreateSchemaVersionTable,
+	pgCreateJobLeasesTable,
+	pgCreateSolverFoodFeedbackTable, // After food_reference (references it)
+	pgCreateLLMUsageTable,
+	pgCreateEmbeddingsTable, // Needs the pgvector extension
+	pgCreateAnnualReviewsTable,
+	pgCreateFoodPricesTable, // After food_reference (references it)
+	pgCreateCaffeineLogTable,
+	pgCreatePersonalRecordsTable, // After movements and training_sessions (references them)
+	pgCreateBodyStatusSnapshotsTable,
+	pgCreateJointIntegrityDeltasTable, // After training_sessions (references it)
+	pgCreateJointIntegritySeriesTable,
+	pgCreateSubstitutionLogTable,
+	pgCreateDebriefTolerancesTable,
+	pgCreateRetroEditsTable,
+	pgCreateMealEntriesTable,
+	pgCreateMealHungerTable,
+	pgCreateGroceryListsTable,
+	pgCreateGroceryListItemsTable, // After grocery_lists and food_reference (references them)
+	pgCreateProgramSessionAutoregulationTable, // After program_installations (references it)
+	pgCreateNoteConflictsTable,
+	pgCreateArchetypeReviewsTable, // After training_sessions (references it)
+	pgCreateStrengthRotationTable,
+	pgCreatePlanDailyTargetsTable, // After nutrition_plans (references it)
+	pgCreatePersonalReferenceRangesTable,
+	pgCreateRecoveryActivitiesTable,
+	pgCreateHabitsTable,
+	pgCreateHabitChecksTable, // After habits (references it)
+	pgCreateChallengesTable,
+	pgCreateVoiceClarificationsTable,
+	pgCreateWearableImportsTable,
+	pgCreateMetabolicAdaptationEventsTable,
+	pgCreatePlanRetrospectivesTable,    // After nutrition_plans (references it)
+	pgCreateArchivedWeeklyTargetsTable, // After nutrition_plans (references it)
+	pgCreateOnboardingStateTable,
+}
+
+// SchemaVersion is the number of migrations this build applies. Migrations
+// are append-only, so a database recording a lower count has pending ones.
+func SchemaVersion() int {
+	return len(pgTableMigrations) + len(pgAlterMigrations)
+}
+
 // RunPostgresMigrations applies all database migrations for PostgreSQL.
 func RunPostgresMigrations(db *sql.DB) error {
-	migrations := []string{
-		pgCreateUserProfileTable,
-		pgCreateDailyLogsTable,
-		pgCreateTrainingConfigsTable,
-		pgCreatePlannedDayTypesTable,
-		pgCreateFoodReferenceTable,
-		pgCreateNutritionPlansTable,
-		pgCreateWeeklyTargetsTable,
-		pgCreateMuscleGroupsTable,
-		pgCreateTrainingArchetypesTable,
-		pgCreateTrainingSessionsTable, // After training_archetypes (references it)
-		pgCreateMuscleFatigueTable,
-		pgCreateFatigueEventsTable, // After training_sessions (references it)
-		pgCreateTrainingProgramsTable,
-		pgCreateProgramWeeksTable,
-		pgCreateProgramDaysTable,
-		pgCreateProgramInstallationsTable,
-		pgCreateMetabolicHistoryTable,
-		pgCreateMonthlySummariesTable,
-		pgCreateBodyPartIssuesTable,  // After training_sessions (references it)
-		pgCreatePlannedSessionsTable, // Ad-hoc workout planner sessions
-		pgCreateMovementsTable,
-		pgCreateUserMovementProgressTable,
-		pgCreateRecalibrationHistoryTable,
-	}
-
-	for i, migration := range migrations {
+	for i, migration := range pgTableMigrations {
 		if _, err := db.Exec(migration); err != nil {
 			return fmt.Errorf("postgres migration %d failed: %w", i, err)
 		}
@@ -69,6 +128,9 @@ func RunPostgresMigrations(db *sql.DB) error {
 	if err := pgSeedFoodReference(db); err != nil {
 		return fmt.Errorf("seeding food reference failed: %w", err)
 	}
+	if err := pgSeedFoodSynonyms(db); err != nil {
+		return fmt.Errorf("seeding food synonyms failed: %w", err)
+	}
 	if err := pgSeedTrainingPrograms(db); err != nil {
 		return fmt.Errorf("seeding training programs failed: %w", err)
 	}
@@ -76,9 +138,34 @@ func RunPostgresMigrations(db *sql.DB) error {
 		return fmt.Errorf("seeding movements failed: %w", err)
 	}
 
+	// Record the schema version for readiness probes
+	if _, err := db.Exec(`
+		INSERT INTO schema_version (id, version, applied_at) VALUES (1, $1, NOW())
+		ON CONFLICT (id) DO UPDATE SET version = EXCLUDED.version, applied_at = EXCLUDED.applied_at
+	`, SchemaVersion()); err != nil {
+		return fmt.Errorf("recording schema version failed: %w", err)
+	}
+
 	return nil
 }
 
+// PendingMigrations returns how many migrations this build has that the
+// database hasn't applied yet.
+func PendingMigrations(ctx context.Context, conn DBTX) (int, error) {
+	var applied int
+	err := conn.QueryRowContext(ctx, `SELECT version FROM schema_version WHERE id = 1`).Scan(&applied)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SchemaVersion(), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if applied >= SchemaVersion() {
+		return 0, nil
+	}
+	return SchemaVersion() - applied, nil
+}
+
 const pgCreateUserProfileTable = `
 CREATE TABLE IF NOT EXISTS user_profile (
     id INTEGER PRIMARY KEY CHECK (id = 1),
@@ -306,6 +393,15 @@ CREATE TABLE IF NOT EXISTS muscle_fatigue (
 );
 CREATE INDEX IF NOT EXISTS idx_muscle_fatigue_muscle ON muscle_fatigue(muscle_group_id)`
 
+const pgCreateMuscleFatigueSnapshotsTable = `
+CREATE TABLE IF NOT EXISTS muscle_fatigue_snapshots (
+    id SERIAL PRIMARY KEY,
+    muscle_group_id INTEGER NOT NULL REFERENCES muscle_groups(id),
+    fatigue_percent REAL NOT NULL CHECK (fatigue_percent BETWEEN 0 AND 100),
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_muscle_fatigue_snapshots_muscle_time ON muscle_fatigue_snapshots(muscle_group_id, recorded_at)`
+
 const pgCreateFatigueEventsTable = `
 CREATE TABLE IF NOT EXISTS fatigue_events (
     id SERIAL PRIMARY KEY,
@@ -467,6 +563,578 @@ CREATE TABLE IF NOT EXISTS recalibration_history (
 );
 CREATE INDEX IF NOT EXISTS idx_recalibration_history_plan ON recalibration_history(plan_id)`
 
+const pgCreatePlateauDetectionsTable = `
+CREATE TABLE IF NOT EXISTS plateau_detections (
+    id SERIAL PRIMARY KEY,
+    plateau_type TEXT NOT NULL CHECK (plateau_type IN ('weight', 'strength')),
+    subject TEXT NOT NULL,
+    detected_on TEXT NOT NULL,
+    duration_days INTEGER NOT NULL DEFAULT 0,
+    session_count INTEGER NOT NULL DEFAULT 0,
+    metric REAL NOT NULL,
+    recommendations JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(plateau_type, subject, detected_on)
+);
+CREATE INDEX IF NOT EXISTS idx_plateau_detections_detected_on ON plateau_detections(detected_on)`
+
+const pgCreateDailyLogReconciliationsTable = `
+CREATE TABLE IF NOT EXISTS daily_log_reconciliations (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]',
+    cns_reclassified JSONB NOT NULL DEFAULT '[]',
+    flux_recalculated BOOLEAN NOT NULL DEFAULT FALSE,
+    reconciled_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_daily_log_reconciliations_date ON daily_log_reconciliations(log_date)`
+
+const pgCreateDebriefRegenerationFlagsTable = `
+CREATE TABLE IF NOT EXISTS debrief_regeneration_flags (
+    week_start_date TEXT PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    flagged_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    regenerated_at TIMESTAMP
+)`
+
+const pgCreateImportedFoodEntriesTable = `
+CREATE TABLE IF NOT EXISTS imported_food_entries (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL CHECK (source IN ('mfp', 'cronometer')),
+    log_date TEXT NOT NULL,
+    meal TEXT NOT NULL DEFAULT '',
+    raw_name TEXT NOT NULL DEFAULT '',
+    amount TEXT NOT NULL DEFAULT '',
+    food_reference_id INTEGER REFERENCES food_reference(id) ON DELETE SET NULL,
+    match_score REAL NOT NULL DEFAULT 0,
+    calories INTEGER NOT NULL DEFAULT 0,
+    protein_g INTEGER NOT NULL DEFAULT 0,
+    carbs_g INTEGER NOT NULL DEFAULT 0,
+    fat_g INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(source, log_date, meal, raw_name, amount, calories)
+);
+CREATE INDEX IF NOT EXISTS idx_imported_food_entries_unmatched ON imported_food_entries(log_date) WHERE food_reference_id IS NULL AND raw_name <> ''`
+
+const pgCreateFoodSynonymsTable = `
+CREATE TABLE IF NOT EXISTS food_synonyms (
+    alias TEXT PRIMARY KEY,
+    food_reference_id INTEGER NOT NULL REFERENCES food_reference(id) ON DELETE CASCADE,
+    source TEXT NOT NULL DEFAULT 'user' CHECK (source IN ('seed', 'user')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateFoodPortionLogTable = `
+CREATE TABLE IF NOT EXISTS food_portion_log (
+    id SERIAL PRIMARY KEY,
+    food_reference_id INTEGER NOT NULL REFERENCES food_reference(id) ON DELETE CASCADE,
+    meal TEXT NOT NULL DEFAULT '',
+    quantity_g REAL NOT NULL CHECK (quantity_g > 0),
+    log_date TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_food_portion_log_food ON food_portion_log(food_reference_id, created_at DESC)`
+
+const pgCreateMealTemplatesTable = `
+CREATE TABLE IF NOT EXISTS meal_templates (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    meal TEXT NOT NULL CHECK (meal IN ('breakfast', 'lunch', 'dinner')),
+    items JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateSessionTemplatesTable = `
+CREATE TABLE IF NOT EXISTS session_templates (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    training_type TEXT NOT NULL,
+    duration_min INTEGER NOT NULL CHECK (duration_min BETWEEN 0 AND 480),
+    typical_rpe INTEGER CHECK (typical_rpe IS NULL OR typical_rpe BETWEEN 1 AND 10),
+    exercises JSONB NOT NULL DEFAULT '[]',
+    notes TEXT NOT NULL DEFAULT '',
+    favorite BOOLEAN NOT NULL DEFAULT false,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateAPITokensTable = `
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    hint TEXT NOT NULL,
+    scopes JSONB NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateAPITokenUsageTable = `
+CREATE TABLE IF NOT EXISTS api_token_usage (
+    token_id INTEGER NOT NULL REFERENCES api_tokens(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP NOT NULL,
+    last_used_ip TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (token_id, endpoint)
+)`
+
+const pgCreateAPITokenNoncesTable = `
+CREATE TABLE IF NOT EXISTS api_token_nonces (
+    token_id INTEGER NOT NULL REFERENCES api_tokens(id) ON DELETE CASCADE,
+    nonce TEXT NOT NULL,
+    seen_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (token_id, nonce)
+)`
+
+const pgCreateSchemaVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateJobLeasesTable = `
+CREATE TABLE IF NOT EXISTS job_leases (
+    job_name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    lease_until TIMESTAMP NOT NULL,
+    renewed_at TIMESTAMP NOT NULL
+)`
+
+const pgCreateSolverFoodFeedbackTable = `
+CREATE TABLE IF NOT EXISTS solver_food_feedback (
+    id SERIAL PRIMARY KEY,
+    food_reference_id INTEGER NOT NULL REFERENCES food_reference(id) ON DELETE CASCADE,
+    signal TEXT NOT NULL CHECK (signal IN ('accepted', 'rejected', 'removed', 'added')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_solver_food_feedback_created ON solver_food_feedback(created_at)`
+
+const pgCreateLLMUsageTable = `
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id SERIAL PRIMARY KEY,
+    feature TEXT NOT NULL,
+    model TEXT NOT NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_feature_created ON llm_usage(feature, created_at)`
+
+// embeddings holds pgvector embeddings of foods and movements for semantic
+// search. The column is dimensionless so the embedding model can change; rows
+// are keyed by model and searched by exact scan, which is plenty for catalogs
+// of a few hundred items.
+const pgCreateEmbeddingsTable = `
+CREATE EXTENSION IF NOT EXISTS vector;
+CREATE TABLE IF NOT EXISTS embeddings (
+    kind TEXT NOT NULL CHECK (kind IN ('food', 'movement')),
+    item_id TEXT NOT NULL,
+    model TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    embedding vector NOT NULL,
+    indexed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, item_id)
+)`
+
+// annual_reviews stores generated year-in-numbers reviews as JSON, one per
+// year, so the narrative isn't regenerated on every view.
+const pgCreateAnnualReviewsTable = `
+CREATE TABLE IF NOT EXISTS annual_reviews (
+    year INTEGER PRIMARY KEY,
+    review JSONB NOT NULL,
+    generated_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// food_prices holds optional user-entered prices, at most one per food.
+const pgCreateFoodPricesTable = `
+CREATE TABLE IF NOT EXISTS food_prices (
+    food_reference_id INTEGER PRIMARY KEY REFERENCES food_reference(id) ON DELETE CASCADE,
+    amount REAL NOT NULL CHECK (amount > 0),
+    basis TEXT NOT NULL CHECK (basis IN ('serving', '100g')),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateCaffeineLogTable = `
+CREATE TABLE IF NOT EXISTS caffeine_log (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    intake_time TEXT NOT NULL,
+    source TEXT NOT NULL CHECK (source IN ('coffee', 'espresso', 'tea', 'energy_drink', 'soda', 'pre_workout', 'supplement', 'other')),
+    mg REAL NOT NULL CHECK (mg > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_caffeine_log_date ON caffeine_log(log_date)`
+
+const pgCreatePersonalRecordsTable = `
+CREATE TABLE IF NOT EXISTS personal_records (
+    id SERIAL PRIMARY KEY,
+    movement_id TEXT NOT NULL REFERENCES movements(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('heaviest_load', 'longest_hold', 'fastest_circuit')),
+    value REAL NOT NULL CHECK (value > 0),
+    source TEXT NOT NULL CHECK (source IN ('set_log', 'echo', 'manual')),
+    session_id INTEGER REFERENCES training_sessions(id) ON DELETE SET NULL,
+    record_date TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    celebration_pending BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_personal_records_movement ON personal_records(movement_id, kind)`
+
+// body_status_snapshots keeps the aggregated body status per day; the latest
+// read of a day replaces earlier ones.
+const pgCreateBodyStatusSnapshotsTable = `
+CREATE TABLE IF NOT EXISTS body_status_snapshots (
+    snapshot_date TEXT PRIMARY KEY,
+    status JSONB NOT NULL,
+    systemic_load REAL NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// joint_integrity_deltas holds echo joint deltas per tracked joint.
+const pgCreateJointIntegrityDeltasTable = `
+CREATE TABLE IF NOT EXISTS joint_integrity_deltas (
+    id SERIAL PRIMARY KEY,
+    delta_date TEXT NOT NULL,
+    joint TEXT NOT NULL,
+    delta REAL NOT NULL CHECK (delta >= -1 AND delta <= 1),
+    session_id INTEGER REFERENCES training_sessions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_joint_integrity_deltas_date ON joint_integrity_deltas(delta_date)`
+
+// joint_integrity_series keeps the computed joint integrity per day.
+const pgCreateJointIntegritySeriesTable = `
+CREATE TABLE IF NOT EXISTS joint_integrity_series (
+    series_date TEXT PRIMARY KEY,
+    joints JSONB NOT NULL,
+    rest_day BOOLEAN NOT NULL,
+    pain_joints JSONB NOT NULL DEFAULT '[]',
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// substitution_log records accepted and declined fatigue substitutions.
+const pgCreateSubstitutionLogTable = `
+CREATE TABLE IF NOT EXISTS substitution_log (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    session_label TEXT NOT NULL DEFAULT '',
+    original_id TEXT NOT NULL,
+    substitute_id TEXT NOT NULL,
+    fatigued_muscles JSONB NOT NULL DEFAULT '[]',
+    accepted BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_substitution_log_date ON substitution_log(log_date)`
+
+// debrief_tolerances freezes the adherence tolerances a completed week was
+// scored with, so later settings changes don't rescore it.
+const pgCreateDebriefTolerancesTable = `
+CREATE TABLE IF NOT EXISTS debrief_tolerances (
+    week_start_date TEXT PRIMARY KEY,
+    tolerances JSONB NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// retro_edits is the audit trail of edits to days behind the logging lock.
+// Each row is one approval with the edits made through it.
+const pgCreateRetroEditsTable = `
+CREATE TABLE IF NOT EXISTS retro_edits (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    opened_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    actions JSONB NOT NULL DEFAULT '[]'
+);
+CREATE INDEX IF NOT EXISTS idx_retro_edits_date ON retro_edits(log_date)`
+
+// meal_entries records when food was eaten, for meal timing analytics.
+// Meal is NULL for food logged outside a meal slot. Food items logged one by
+// one are entries too (see pgAlterMigrations); eaten_at is optional for them.
+const pgCreateMealEntriesTable = `
+CREATE TABLE IF NOT EXISTS meal_entries (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    meal TEXT,
+    eaten_at TEXT NOT NULL,
+    calories INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_meal_entries_date ON meal_entries(log_date)`
+
+// meal_hunger holds the hunger rating that followed each meal, for satiety learning.
+const pgCreateMealHungerTable = `
+CREATE TABLE IF NOT EXISTS meal_hunger (
+    log_date TEXT NOT NULL,
+    meal TEXT NOT NULL CHECK (meal IN ('breakfast', 'lunch', 'dinner')),
+    hunger INTEGER NOT NULL CHECK (hunger BETWEEN 1 AND 5),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (log_date, meal)
+)`
+
+const pgCreateGroceryListsTable = `
+CREATE TABLE IF NOT EXISTS grocery_lists (
+    id SERIAL PRIMARY KEY,
+    week_start TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// grocery_list_items.purchased_on is NULL until the item is bought.
+const pgCreateGroceryListItemsTable = `
+CREATE TABLE IF NOT EXISTS grocery_list_items (
+    id SERIAL PRIMARY KEY,
+    list_id INTEGER NOT NULL REFERENCES grocery_lists(id) ON DELETE CASCADE,
+    food_reference_id INTEGER NOT NULL REFERENCES food_reference(id) ON DELETE CASCADE,
+    amount_g REAL NOT NULL CHECK (amount_g > 0),
+    purchased_on TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_grocery_list_items_purchased ON grocery_list_items(purchased_on)`
+
+const pgCreateProgramSessionAutoregulationTable = `
+CREATE TABLE IF NOT EXISTS program_session_autoregulation (
+    installation_id INTEGER NOT NULL REFERENCES program_installations(id) ON DELETE CASCADE,
+    session_date TEXT NOT NULL,
+    week_number INTEGER NOT NULL,
+    day_number INTEGER NOT NULL,
+    readiness_score REAL NOT NULL,
+    scale REAL NOT NULL,
+    base_load_score REAL NOT NULL,
+    base_duration_min INTEGER NOT NULL,
+    load_score REAL NOT NULL,
+    duration_min INTEGER NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (installation_id, week_number, day_number)
+)`
+
+// note_conflicts keeps the text a note edit replaced when another device had
+// changed the note since the edit began. session_id has no foreign key: a
+// conflict outlives its session being re-logged, so the text isn't lost.
+const pgCreateNoteConflictsTable = `
+CREATE TABLE IF NOT EXISTS note_conflicts (
+    id SERIAL PRIMARY KEY,
+    target TEXT NOT NULL CHECK (target IN ('day', 'session')),
+    log_date TEXT NOT NULL,
+    session_id INTEGER,
+    base TEXT NOT NULL,
+    kept TEXT NOT NULL,
+    overwritten TEXT NOT NULL,
+    suggested TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolution TEXT CHECK (resolution IN ('keep', 'restore', 'merge', 'custom'))
+);
+CREATE INDEX IF NOT EXISTS idx_note_conflicts_open ON note_conflicts(created_at) WHERE resolved_at IS NULL`
+
+// archetype_reviews queues low-confidence archetype inferences; fatigue is
+// only applied for a queued session once its review is confirmed.
+const pgCreateArchetypeReviewsTable = `
+CREATE TABLE IF NOT EXISTS archetype_reviews (
+    id SERIAL PRIMARY KEY,
+    training_session_id INTEGER NOT NULL UNIQUE REFERENCES training_sessions(id) ON DELETE CASCADE,
+    suggested_archetype TEXT,
+    confidence REAL NOT NULL CHECK (confidence BETWEEN 0 AND 1),
+    source TEXT NOT NULL CHECK (source IN ('rules', 'llm')),
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    resolved_archetype TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_archetype_reviews_pending ON archetype_reviews(created_at) WHERE status = 'pending'`
+
+// strength_rotation is the single-row position in the user's strength split.
+const pgCreateStrengthRotationTable = `
+CREATE TABLE IF NOT EXISTS strength_rotation (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    split TEXT NOT NULL CHECK (split IN ('push_pull_legs', 'upper_lower', 'full_body')),
+    position INTEGER NOT NULL DEFAULT 0 CHECK (position >= 0),
+    last_archetype TEXT,
+    last_date TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// plan_daily_targets holds each plan day's targets as generated when the
+// plan's weekly targets were saved; past days are never regenerated.
+const pgCreatePlanDailyTargetsTable = `
+CREATE TABLE IF NOT EXISTS plan_daily_targets (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES nutrition_plans(id) ON DELETE CASCADE,
+    target_date TEXT NOT NULL,
+    week_number INTEGER NOT NULL CHECK (week_number >= 1),
+    day_number INTEGER NOT NULL CHECK (day_number BETWEEN 1 AND 7),
+    day_type TEXT NOT NULL CHECK (day_type IN ('performance', 'fatburner', 'metabolize')),
+    carbs_g INTEGER NOT NULL,
+    protein_g INTEGER NOT NULL,
+    fats_g INTEGER NOT NULL,
+    calories INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(plan_id, target_date)
+)`
+
+const pgCreatePersonalReferenceRangesTable = `
+CREATE TABLE IF NOT EXISTS personal_reference_ranges (
+    metric TEXT PRIMARY KEY CHECK (metric IN ('hrv', 'resting_hr', 'sleep_quality')),
+    p10 REAL NOT NULL,
+    p25 REAL NOT NULL,
+    p50 REAL NOT NULL,
+    p75 REAL NOT NULL,
+    p90 REAL NOT NULL,
+    sample_count INTEGER NOT NULL,
+    window_start TEXT NOT NULL,
+    window_end TEXT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateRecoveryActivitiesTable = `
+CREATE TABLE IF NOT EXISTS recovery_activities (
+    id SERIAL PRIMARY KEY,
+    activity_date TEXT NOT NULL,
+    activity_type TEXT NOT NULL CHECK (activity_type IN ('sauna', 'cold_plunge', 'massage', 'foam_rolling', 'compression')),
+    duration_min INTEGER NOT NULL CHECK (duration_min > 0),
+    intensity INTEGER NOT NULL CHECK (intensity BETWEEN 1 AND 5),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_recovery_activities_date ON recovery_activities(activity_date)`
+
+const pgCreateHabitsTable = `
+CREATE TABLE IF NOT EXISTS habits (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    count_in_vitality BOOLEAN NOT NULL DEFAULT FALSE,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    start_date TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateHabitChecksTable = `
+CREATE TABLE IF NOT EXISTS habit_checks (
+    habit_id INTEGER NOT NULL REFERENCES habits(id) ON DELETE CASCADE,
+    check_date TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (habit_id, check_date)
+);
+CREATE INDEX IF NOT EXISTS idx_habit_checks_date ON habit_checks(check_date)`
+
+const pgCreateChallengesTable = `
+CREATE TABLE IF NOT EXISTS challenges (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    challenge_type TEXT NOT NULL CHECK (challenge_type IN ('protein_target', 'no_missed_sessions', 'daily_logging')),
+    start_date TEXT NOT NULL,
+    duration_days INTEGER NOT NULL CHECK (duration_days BETWEEN 7 AND 90),
+    allowed_misses INTEGER NOT NULL DEFAULT 0 CHECK (allowed_misses >= 0),
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'failed', 'abandoned')),
+    ended_date TEXT,
+    notification_pending BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_challenges_status ON challenges(status)`
+
+const pgCreateVoiceClarificationsTable = `
+CREATE TABLE IF NOT EXISTS voice_clarifications (
+    id SERIAL PRIMARY KEY,
+    log_date TEXT NOT NULL,
+    raw_input TEXT NOT NULL,
+    command JSONB NOT NULL,
+    field TEXT NOT NULL DEFAULT '',
+    question TEXT NOT NULL DEFAULT '',
+    answers JSONB NOT NULL DEFAULT '[]',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_voice_clarifications_status ON voice_clarifications(status)`
+
+const pgCreateWearableImportsTable = `
+CREATE TABLE IF NOT EXISTS wearable_imports (
+    source TEXT NOT NULL CHECK (source IN ('garmin', 'apple_health')),
+    record_kind TEXT NOT NULL CHECK (record_kind IN ('day', 'activity')),
+    external_id TEXT NOT NULL,
+    log_date TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    imported_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source, record_kind, external_id)
+);
+CREATE INDEX IF NOT EXISTS idx_wearable_imports_date ON wearable_imports(log_date)`
+
+const pgCreateMetabolicAdaptationEventsTable = `
+CREATE TABLE IF NOT EXISTS metabolic_adaptation_events (
+    id SERIAL PRIMARY KEY,
+    detected_on TEXT UNIQUE NOT NULL,
+    severity TEXT NOT NULL CHECK (severity IN ('moderate', 'severe')),
+    weeks_sustained INTEGER NOT NULL,
+    baseline_ratio REAL NOT NULL,
+    recent_ratio REAL NOT NULL,
+    unexplained_kcal INTEGER NOT NULL,
+    observed_drop_kcal INTEGER NOT NULL,
+    predicted_drop_kcal INTEGER NOT NULL,
+    weight_change_kg REAL NOT NULL,
+    maintenance_kcal INTEGER NOT NULL,
+    recommendation JSONB NOT NULL,
+    notification_pending BOOLEAN NOT NULL DEFAULT true,
+    notification_dismissed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_metabolic_adaptation_pending ON metabolic_adaptation_events(detected_on DESC) WHERE notification_pending`
+
+const pgCreatePlanRetrospectivesTable = `
+CREATE TABLE IF NOT EXISTS plan_retrospectives (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER UNIQUE NOT NULL REFERENCES nutrition_plans(id) ON DELETE CASCADE,
+    completed_on TEXT NOT NULL,
+    report JSONB NOT NULL,
+    prompt_pending BOOLEAN NOT NULL DEFAULT true,
+    prompt_dismissed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateArchivedWeeklyTargetsTable = `
+CREATE TABLE IF NOT EXISTS archived_weekly_targets (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES nutrition_plans(id) ON DELETE CASCADE,
+    week_number INTEGER NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    projected_weight_kg REAL NOT NULL,
+    projected_tdee INTEGER NOT NULL,
+    target_intake_kcal INTEGER NOT NULL,
+    target_carbs_g INTEGER NOT NULL,
+    target_protein_g INTEGER NOT NULL,
+    target_fats_g INTEGER NOT NULL,
+    actual_weight_kg REAL,
+    actual_intake_kcal INTEGER,
+    days_logged INTEGER NOT NULL DEFAULT 0,
+    days_expected INTEGER NOT NULL DEFAULT 7,
+    is_pinned BOOLEAN NOT NULL DEFAULT false,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(plan_id, week_number)
+)`
+
+// onboarding_state is the single-row progress of the first-run wizard: the
+// next step and the answers so far, kept as the domain's JSON.
+const pgCreateOnboardingStateTable = `
+CREATE TABLE IF NOT EXISTS onboarding_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    state JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+const pgCreateDayExemptionsTable = `
+CREATE TABLE IF NOT EXISTS day_exemptions (
+    exempt_date TEXT PRIMARY KEY,
+    reason TEXT NOT NULL CHECK (reason IN ('sick', 'travel')),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
 var pgAlterMigrations = []string{
 	// Add progression_config column to program_days for optional pattern-based progression
 	`ALTER TABLE program_days ADD COLUMN IF NOT EXISTS progression_config TEXT`,
@@ -480,6 +1148,14 @@ var pgAlterMigrations = []string{
 	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS raw_echo_log TEXT`,
 	// Echo logging: parsed metadata (achievements, rpe_offset, etc.)
 	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS extra_metadata JSONB`,
+	// Draft lifecycle: when the RPE reminder was raised and when the draft was auto-closed
+	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS draft_reminded_at TIMESTAMP`,
+	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS auto_closed_at TIMESTAMP`,
+	// Equipment availability: movement requirements, profile inventory, and temporary travel override
+	`ALTER TABLE movements ADD COLUMN IF NOT EXISTS equipment JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS equipment JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS travel_equipment JSONB`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS travel_until TEXT`,
 	// HRV reference ranges from Garmin for CNS alert detection
 	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS hrv_reference_min INTEGER`,
 	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS hrv_reference_max INTEGER`,
@@ -507,6 +1183,111 @@ var pgAlterMigrations = []string{
 			WHERE d2.has_explicit_weight = false
 		) sub
 		WHERE d.log_date = sub.log_date AND sub.prev_weight IS NOT NULL`,
+	// Weekly targets: manual edits pinned so plan regeneration leaves them alone
+	`ALTER TABLE weekly_targets ADD COLUMN IF NOT EXISTS is_pinned BOOLEAN NOT NULL DEFAULT false`,
+	// Kcal factor override persisted with optional auto-tuning from observed results
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS kcal_factor_override REAL`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS kcal_factor_auto_tune BOOLEAN NOT NULL DEFAULT false`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS kcal_factor_tuned_at TIMESTAMP`,
+	// Allow auto-tuning adjustments in recalibration history
+	`ALTER TABLE recalibration_history DROP CONSTRAINT IF EXISTS recalibration_history_action_type_check`,
+	`ALTER TABLE recalibration_history ADD CONSTRAINT recalibration_history_action_type_check
+		CHECK (action_type IN ('increase_deficit', 'extend_timeline', 'revise_goal', 'keep_current', 'auto_tune_kcal_factor'))`,
+	// Body recomposition plans: mode plus start/goal body fat
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS mode TEXT NOT NULL DEFAULT 'weight'`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS start_body_fat_percent REAL`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS goal_body_fat_percent REAL`,
+	// Weight trend smoothing preference (window 0 = method default)
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS weight_trend_method TEXT NOT NULL DEFAULT 'ema'`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS weight_trend_window INTEGER NOT NULL DEFAULT 0`,
+	// Weigh-in time (HH:MM) for time-of-day weight correction
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS weigh_in_time TEXT`,
+	// API token audit: last caller IP, soft revocation, signed-request requirement
+	`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS last_used_ip TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP`,
+	`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS require_signature BOOLEAN NOT NULL DEFAULT false`,
+	// Composite indexes for the hottest store queries; measure with
+	// BenchmarkStoreQueries (go test -run '^$' -bench StoreQueries ./internal/store),
+	// which runs each query before and after these indexes.
+	// Planned/actual session lookups per log filter on is_planned and sort by
+	// session_order; the unique (daily_log_id, session_order, is_planned) index
+	// can only use its first column for that.
+	`CREATE INDEX IF NOT EXISTS idx_training_sessions_log_planned ON training_sessions(daily_log_id, is_planned, session_order)`,
+	// Flux notification lookup reads the newest pending record; a partial index
+	// skips the (vast majority of) dismissed rows.
+	`CREATE INDEX IF NOT EXISTS idx_metabolic_history_pending ON metabolic_history(calculated_at DESC) WHERE notification_pending`,
+	// Subjective daily check-in (1-5 scales)
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS mood INTEGER`,
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS stress INTEGER`,
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS soreness INTEGER`,
+	// Environment conditions (hot, humid, altitude) for days and sessions
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS environment JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS environment JSONB NOT NULL DEFAULT '[]'`,
+	// Adherence tolerances (fractions of target) for calories and each macro
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS adherence_calorie_tolerance REAL NOT NULL DEFAULT 0.10`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS adherence_protein_floor REAL NOT NULL DEFAULT 0.90`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS adherence_fat_minimum REAL NOT NULL DEFAULT 0.70`,
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS adherence_carb_tolerance REAL NOT NULL DEFAULT 0.20`,
+	// Logging lock: days older than this many days need a retro-edit (0 = off)
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS log_lock_days INTEGER NOT NULL DEFAULT 0`,
+	// Meal timing: bedtime (HH:MM) for the night a log's sleep describes, session start (HH:MM)
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS bedtime TEXT`,
+	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS start_time TEXT NOT NULL DEFAULT ''`,
+	// Optional hunger check-in (1-5) for the diet fatigue index
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS hunger INTEGER`,
+	// Satiety learning: the day and meal slot solver feedback was logged against
+	`ALTER TABLE solver_food_feedback ADD COLUMN IF NOT EXISTS log_date TEXT`,
+	`ALTER TABLE solver_food_feedback ADD COLUMN IF NOT EXISTS meal TEXT`,
+	// Program rescheduling: JSON list of program days moved off their mapped date
+	`ALTER TABLE program_installations ADD COLUMN IF NOT EXISTS reschedules TEXT NOT NULL DEFAULT '[]'`,
+	// Intensity autoregulation: readiness scaling limit (0 = off)
+	`ALTER TABLE program_installations ADD COLUMN IF NOT EXISTS autoregulation_bound REAL NOT NULL DEFAULT 0`,
+	// Cross-entity search: full-text indexes matching the expressions in store.SearchStore
+	`CREATE INDEX IF NOT EXISTS idx_daily_logs_notes_fts ON daily_logs USING GIN (to_tsvector('english', coalesce(notes, '')))`,
+	`CREATE INDEX IF NOT EXISTS idx_daily_logs_environment_fts ON daily_logs USING GIN (jsonb_to_tsvector('english', environment, '["string"]'))`,
+	`CREATE INDEX IF NOT EXISTS idx_training_sessions_notes_fts ON training_sessions USING GIN (to_tsvector('english', coalesce(notes, '')))`,
+	`CREATE INDEX IF NOT EXISTS idx_program_days_fts ON program_days USING GIN (to_tsvector('english', label || ' ' || coalesce(notes, '')))`,
+	`CREATE INDEX IF NOT EXISTS idx_session_templates_fts ON session_templates USING GIN (to_tsvector('english', name || ' ' || notes))`,
+	`CREATE INDEX IF NOT EXISTS idx_food_reference_fts ON food_reference USING GIN (to_tsvector('english', food_item))`,
+	`CREATE INDEX IF NOT EXISTS idx_movements_fts ON movements USING GIN ((to_tsvector('english', name) || jsonb_to_tsvector('english', tags, '["string"]')))`,
+	`CREATE INDEX IF NOT EXISTS idx_training_programs_fts ON training_programs USING GIN (to_tsvector('english', name || ' ' || coalesce(description, '') || ' ' || tags))`,
+	`CREATE INDEX IF NOT EXISTS idx_day_exemptions_fts ON day_exemptions USING GIN (to_tsvector('english', reason || ' ' || note))`,
+	// Training age: experience model derived from the training log
+	`ALTER TABLE user_profile ADD COLUMN IF NOT EXISTS training_experience JSONB`,
+	// Event-targeting plans: weigh-in date (YYYY-MM-DD) and competition name
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS event_date TEXT`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS event_name TEXT NOT NULL DEFAULT ''`,
+	// Session calorie estimation: average session HR and where active calories came from
+	`ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS avg_heart_rate INTEGER`,
+	`ALTER TABLE daily_logs ADD COLUMN IF NOT EXISTS active_calories_source TEXT`,
+	`UPDATE daily_logs SET active_calories_source = 'wearable'
+		WHERE active_calories_burned IS NOT NULL AND active_calories_source IS NULL`,
+	// Late-start proration: days of each plan week on or after tracking started
+	`ALTER TABLE weekly_targets ADD COLUMN IF NOT EXISTS days_expected INTEGER NOT NULL DEFAULT 7`,
+	// Plan exit survey: why a plan was abandoned
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS abandon_reasons JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE nutrition_plans ADD COLUMN IF NOT EXISTS abandon_note TEXT NOT NULL DEFAULT ''`,
+	// Left/right fatigue: optional per-side values for limb muscles, and the side named in a body issue
+	`ALTER TABLE muscle_fatigue ADD COLUMN IF NOT EXISTS left_percent REAL CHECK (left_percent BETWEEN 0 AND 100)`,
+	`ALTER TABLE muscle_fatigue ADD COLUMN IF NOT EXISTS right_percent REAL CHECK (right_percent BETWEEN 0 AND 100)`,
+	`ALTER TABLE muscle_fatigue_snapshots ADD COLUMN IF NOT EXISTS left_percent REAL`,
+	`ALTER TABLE muscle_fatigue_snapshots ADD COLUMN IF NOT EXISTS right_percent REAL`,
+	`ALTER TABLE body_part_issues ADD COLUMN IF NOT EXISTS side TEXT NOT NULL DEFAULT ''`,
+	// Tag catalog movements worked one side at a time
+	`UPDATE movements SET tags = tags || '["unilateral"]'
+		WHERE id IN ('cali_rows_arch', 'cali_squat_pistol', 'cali_lunge_std') AND NOT tags ? 'unilateral'`,
+	// Deload nutrition: opt out of biasing deload weeks' day types
+	`ALTER TABLE program_installations ADD COLUMN IF NOT EXISTS keep_deload_day_types BOOLEAN NOT NULL DEFAULT false`,
+	// Itemized meal logging: a meal entry can be a food item with its portion and macros
+	`ALTER TABLE meal_entries ALTER COLUMN eaten_at DROP NOT NULL`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS food_reference_id INTEGER REFERENCES food_reference(id) ON DELETE SET NULL`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS grams REAL`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS protein_g INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS carbs_g INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS fat_g INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE meal_entries ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW()`,
+	// API token request signing keyed by its own secret, never sent with requests
+	`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS signing_secret TEXT NOT NULL DEFAULT ''`,
 }
 
 func pgSeedTrainingConfigs(db *sql.DB) error {
@@ -703,6 +1484,52 @@ func pgSeedFoodReference(db *sql.DB) error {
 	return nil
 }
 
+// pgSeedFoodSynonyms seeds common aliases for food_reference items.
+// Aliases are canonicalized when loaded, so plain lowercase names suffice.
+// User-learned aliases for the same name are never overwritten.
+func pgSeedFoodSynonyms(db *sql.DB) error {
+	synonyms := []struct {
+		Alias    string
+		FoodItem string
+	}{
+		{"chicken", "Chicken/Turkey Breast"},
+		{"turkey", "Chicken/Turkey Breast"},
+		{"chicken breast", "Chicken/Turkey Breast"},
+		{"salmon", "Salmon/Tuna/Perch"},
+		{"tuna", "Salmon/Tuna/Perch"},
+		{"fish", "Salmon/Tuna/Perch"},
+		{"greek yoghurt", "Low-fat Greek Yoghurt"},
+		{"yoghurt", "Low-fat Greek Yoghurt"},
+		{"skyr", "Low-fat Greek Yoghurt"},
+		{"egg", "Eggs"},
+		{"rice", "Brown Rice"},
+		{"whey", "Whey Protein"},
+		{"protein shake", "Whey Protein"},
+		{"protein powder", "Whey Protein"},
+		{"peanut butter", "Nut Butter"},
+		{"almond butter", "Nut Butter"},
+		{"almonds", "Nuts"},
+		{"walnuts", "Nuts"},
+		{"bread", "Wholegrain Bread"},
+		{"toast", "Wholegrain Bread"},
+		{"quinoa", "Quinoa/Amaranth"},
+		{"apple", "Green Apple"},
+	}
+
+	for _, syn := range synonyms {
+		_, err := db.Exec(`
+			INSERT INTO food_synonyms (alias, food_reference_id, source)
+			SELECT $1, id, 'seed' FROM food_reference WHERE food_item = $2
+			ORDER BY id LIMIT 1
+			ON CONFLICT (alias) DO NOTHING
+		`, syn.Alias, syn.FoodItem)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ptr(f float64) *float64 {
 	return &f
 }
@@ -1176,6 +2003,26 @@ CREATE TABLE IF NOT EXISTS user_movement_progress (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 )`
 
+const pgCreateMovementSessionLogTable = `
+CREATE TABLE IF NOT EXISTS movement_session_log (
+    id SERIAL PRIMARY KEY,
+    movement_id TEXT NOT NULL REFERENCES movements(id) ON DELETE CASCADE,
+    difficulty INTEGER NOT NULL CHECK (difficulty BETWEEN 1 AND 10),
+    completed_reps INTEGER NOT NULL DEFAULT 0,
+    target_reps INTEGER NOT NULL DEFAULT 0,
+    rpe INTEGER NOT NULL DEFAULT 0,
+    had_form_issue BOOLEAN NOT NULL DEFAULT false,
+    successful BOOLEAN NOT NULL DEFAULT false,
+    performed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_movement_session_log_movement ON movement_session_log(movement_id, performed_at)`
+
+const pgCreateWarmupPinsTable = `
+CREATE TABLE IF NOT EXISTS warmup_pins (
+    movement_id TEXT PRIMARY KEY REFERENCES movements(id) ON DELETE CASCADE,
+    pinned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
 func pgSeedMovements(db *sql.DB) error {
 	type seedMov struct {
 		ID            string
@@ -1186,37 +2033,38 @@ func pgSeedMovements(db *sql.DB) error {
 		PrimaryLoad   string
 		JointStress   string
 		ProgressionID string
+		Equipment     string
 	}
 
 	movements := []seedMov{
-		{"gmb_bear", "Bear Crawl", "locomotion", `["GMB"]`, 3, "Shoulder/Core", `{"wrist":0.7,"shoulder":0.4}`, "loco_01"},
-		{"gmb_monkey", "Sideways Monkey", "locomotion", `["GMB"]`, 4, "Hip/Ankle", `{"wrist":0.6,"ankle":0.5}`, "loco_02"},
-		{"gmb_frogger", "Frogger", "locomotion", `["GMB"]`, 4, "Wrist/Knee", `{"wrist":0.8,"knee":0.4}`, "loco_03"},
-		{"cali_pushup_knees", "Knee Push-ups", "push", `["CaliMove"]`, 2, "Chest/Triceps", `{"wrist":0.4,"elbow":0.3}`, "push_horiz_01"},
-		{"cali_pushup_std", "Standard Push-up", "push", `["CaliMove"]`, 4, "Chest/Triceps", `{"wrist":0.6,"elbow":0.4}`, "push_horiz_02"},
-		{"cali_dips_bench", "Bench Dips", "push", `["CaliMove"]`, 3, "Triceps/Shoulder", `{"shoulder":0.7,"elbow":0.5}`, "push_vert_01"},
-		{"cali_dips_pbar", "Parallel Bar Dips", "push", `["CaliMove"]`, 6, "Triceps/Chest", `{"shoulder":0.8,"elbow":0.6}`, "push_vert_02"},
-		{"cali_pullup_neg", "Negative Pull-ups", "pull", `["CaliMove"]`, 4, "Lats/Biceps", `{"elbow":0.6,"shoulder":0.4}`, "pull_vert_01"},
-		{"cali_pullup_std", "Standard Pull-up", "pull", `["CaliMove"]`, 6, "Lats/Biceps", `{"elbow":0.5,"shoulder":0.4}`, "pull_vert_02"},
-		{"cali_rows_inv", "Inverted Rows", "pull", `["CaliMove"]`, 3, "Upper Back", `{"elbow":0.3,"shoulder":0.2}`, "pull_horiz_01"},
-		{"cali_rows_arch", "Archer Rows", "pull", `["CaliMove"]`, 7, "Upper Back", `{"elbow":0.7,"shoulder":0.6}`, "pull_horiz_02"},
-		{"cali_squat_air", "Air Squat", "legs", `["CaliMove"]`, 2, "Quads/Glutes", `{"knee":0.3,"ankle":0.2}`, "legs_01"},
-		{"cali_squat_pistol", "Pistol Squat", "legs", `["CaliMove"]`, 8, "Quads/Glutes", `{"knee":0.8,"ankle":0.7}`, "legs_02"},
-		{"cali_lunge_std", "Reverse Lunge", "legs", `["CaliMove"]`, 3, "Quads/Glutes", `{"knee":0.4,"hip":0.2}`, "legs_03"},
-		{"cali_plank_elbow", "Elbow Plank", "core", `["CaliMove"]`, 2, "Core", `{"lower_back":0.4}`, "core_01"},
-		{"cali_hollow_body", "Hollow Body Hold", "core", `["CaliMove"]`, 5, "Core", `{"lower_back":0.6}`, "core_02"},
-		{"cali_leg_raises", "Hanging Leg Raises", "core", `["CaliMove"]`, 7, "Core/Hip Flexors", `{"shoulder":0.5,"lower_back":0.4}`, "core_03"},
-		{"cali_lsit_floor", "Floor L-Sit", "core", `["CaliMove"]`, 8, "Core/Triceps", `{"wrist":0.9,"elbow":0.4}`, "core_04"},
-		{"cali_pike_press", "Pike Push-up", "push", `["CaliMove"]`, 6, "Shoulders", `{"shoulder":0.7,"wrist":0.7}`, "push_ovh_01"},
-		{"cali_handstand_wall", "Wall Handstand", "skill", `["CaliMove"]`, 7, "Shoulders/Core", `{"wrist":0.9,"shoulder":0.6}`, "skill_01"},
+		{"gmb_bear", "Bear Crawl", "locomotion", `["GMB"]`, 3, "Shoulder/Core", `{"wrist":0.7,"shoulder":0.4}`, "loco_01", `[]`},
+		{"gmb_monkey", "Sideways Monkey", "locomotion", `["GMB"]`, 4, "Hip/Ankle", `{"wrist":0.6,"ankle":0.5}`, "loco_02", `[]`},
+		{"gmb_frogger", "Frogger", "locomotion", `["GMB"]`, 4, "Wrist/Knee", `{"wrist":0.8,"knee":0.4}`, "loco_03", `[]`},
+		{"cali_pushup_knees", "Knee Push-ups", "push", `["CaliMove"]`, 2, "Chest/Triceps", `{"wrist":0.4,"elbow":0.3}`, "push_horiz_01", `[]`},
+		{"cali_pushup_std", "Standard Push-up", "push", `["CaliMove"]`, 4, "Chest/Triceps", `{"wrist":0.6,"elbow":0.4}`, "push_horiz_02", `[]`},
+		{"cali_dips_bench", "Bench Dips", "push", `["CaliMove"]`, 3, "Triceps/Shoulder", `{"shoulder":0.7,"elbow":0.5}`, "push_vert_01", `["bench"]`},
+		{"cali_dips_pbar", "Parallel Bar Dips", "push", `["CaliMove"]`, 6, "Triceps/Chest", `{"shoulder":0.8,"elbow":0.6}`, "push_vert_02", `["dip_bars"]`},
+		{"cali_pullup_neg", "Negative Pull-ups", "pull", `["CaliMove"]`, 4, "Lats/Biceps", `{"elbow":0.6,"shoulder":0.4}`, "pull_vert_01", `["pullup_bar"]`},
+		{"cali_pullup_std", "Standard Pull-up", "pull", `["CaliMove"]`, 6, "Lats/Biceps", `{"elbow":0.5,"shoulder":0.4}`, "pull_vert_02", `["pullup_bar"]`},
+		{"cali_rows_inv", "Inverted Rows", "pull", `["CaliMove"]`, 3, "Upper Back", `{"elbow":0.3,"shoulder":0.2}`, "pull_horiz_01", `["pullup_bar"]`},
+		{"cali_rows_arch", "Archer Rows", "pull", `["CaliMove","unilateral"]`, 7, "Upper Back", `{"elbow":0.7,"shoulder":0.6}`, "pull_horiz_02", `["pullup_bar"]`},
+		{"cali_squat_air", "Air Squat", "legs", `["CaliMove"]`, 2, "Quads/Glutes", `{"knee":0.3,"ankle":0.2}`, "legs_01", `[]`},
+		{"cali_squat_pistol", "Pistol Squat", "legs", `["CaliMove","unilateral"]`, 8, "Quads/Glutes", `{"knee":0.8,"ankle":0.7}`, "legs_02", `[]`},
+		{"cali_lunge_std", "Reverse Lunge", "legs", `["CaliMove","unilateral"]`, 3, "Quads/Glutes", `{"knee":0.4,"hip":0.2}`, "legs_03", `[]`},
+		{"cali_plank_elbow", "Elbow Plank", "core", `["CaliMove"]`, 2, "Core", `{"lower_back":0.4}`, "core_01", `[]`},
+		{"cali_hollow_body", "Hollow Body Hold", "core", `["CaliMove"]`, 5, "Core", `{"lower_back":0.6}`, "core_02", `[]`},
+		{"cali_leg_raises", "Hanging Leg Raises", "core", `["CaliMove"]`, 7, "Core/Hip Flexors", `{"shoulder":0.5,"lower_back":0.4}`, "core_03", `["pullup_bar"]`},
+		{"cali_lsit_floor", "Floor L-Sit", "core", `["CaliMove"]`, 8, "Core/Triceps", `{"wrist":0.9,"elbow":0.4}`, "core_04", `[]`},
+		{"cali_pike_press", "Pike Push-up", "push", `["CaliMove"]`, 6, "Shoulders", `{"shoulder":0.7,"wrist":0.7}`, "push_ovh_01", `[]`},
+		{"cali_handstand_wall", "Wall Handstand", "skill", `["CaliMove"]`, 7, "Shoulders/Core", `{"wrist":0.9,"shoulder":0.6}`, "skill_01", `[]`},
 	}
 
 	for _, m := range movements {
 		_, err := db.Exec(`
-			INSERT INTO movements (id, name, category, tags, difficulty, primary_load, joint_stress, progression_id)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-			ON CONFLICT (id) DO NOTHING
-		`, m.ID, m.Name, m.Category, m.Tags, m.Difficulty, m.PrimaryLoad, m.JointStress, m.ProgressionID)
+			INSERT INTO movements (id, name, category, tags, difficulty, primary_load, joint_stress, progression_id, equipment)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (id) DO UPDATE SET equipment = EXCLUDED.equipment
+		`, m.ID, m.Name, m.Category, m.Tags, m.Difficulty, m.PrimaryLoad, m.JointStress, m.ProgressionID, m.Equipment)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// =============================================================================
+// REST-DAY ACTIVE RECOVERY
+// =============================================================================
+//
+// Rest days with high residual muscle fatigue get a short, optional protocol:
+// mobility aimed at the most fatigued areas, plus an easy walk unless the legs
+// are overreached. Recent load (ACR) stretches the walk when acute load is high.
+
+const (
+	// ActiveRecoveryFatigueThreshold is the muscle fatigue (%) that qualifies as residual.
+	// Matches the top of the "stimulated" band; anything fatigued or worse counts.
+	ActiveRecoveryFatigueThreshold = 50.0
+	// ActiveRecoveryMaxTargetAreas caps how many muscles the mobility block targets.
+	ActiveRecoveryMaxTargetAreas = 3
+	// ActiveRecoveryMaxDurationMin caps the whole protocol; it should stay short.
+	ActiveRecoveryMaxDurationMin = 30
+	// ActiveRecoveryMaxRPE is the intensity ceiling for every activity.
+	ActiveRecoveryMaxRPE = 3
+
+	activeRecoveryMobilityBaseMin    = 5 // Plus a few minutes per target area
+	activeRecoveryMobilityPerAreaMin = 3
+	activeRecoveryWalkMin            = 10
+	activeRecoveryWalkHighLoadMin    = 20 // When acute load runs ahead of chronic
+)
+
+// activeRecoveryLegMuscles are the muscles that make walking counterproductive when overreached.
+var activeRecoveryLegMuscles = map[MuscleGroup]bool{
+	MuscleQuads:      true,
+	MuscleGlutes:     true,
+	MuscleHamstrings: true,
+	MuscleCalves:     true,
+}
+
+// ActiveRecoveryArea is a fatigued muscle targeted by the protocol.
+type ActiveRecoveryArea struct {
+	Muscle         MuscleGroup `json:"muscle"`
+	DisplayName    string      `json:"displayName"`
+	FatiguePercent float64     `json:"fatiguePercent"`
+}
+
+// ActiveRecoveryActivity is one block of the protocol.
+type ActiveRecoveryActivity struct {
+	Type        TrainingType `json:"type"`
+	DurationMin int          `json:"durationMin"`
+	MaxRPE      int          `json:"maxRpe"`
+	Focus       string       `json:"focus"`
+}
+
+// ActiveRecoverySuggestion is an optional rest-day protocol.
+type ActiveRecoverySuggestion struct {
+	Reason           string                   `json:"reason"`
+	TargetAreas      []ActiveRecoveryArea     `json:"targetAreas"`
+	Activities       []ActiveRecoveryActivity `json:"activities"`
+	TotalDurationMin int                      `json:"totalDurationMin"`
+	Optional         bool                     `json:"optional"`
+}
+
+// IsRestDay reports whether a day has no non-rest training planned or logged.
+func IsRestDay(planned, actual []TrainingSession) bool {
+	return !HasNonRestSession(planned) && !HasNonRestSession(actual)
+}
+
+// SuggestActiveRecovery builds a rest-day protocol from the fatigue map and recent load.
+// Returns nil when the day has training, fatigue data is missing, or no muscle
+// carries residual fatigue above ActiveRecoveryFatigueThreshold.
+// trainingLoad may be nil when load history is unavailable.
+func SuggestActiveRecovery(planned, actual []TrainingSession, body *BodyStatus, trainingLoad *TrainingLoadResult) *ActiveRecoverySuggestion {
+	if body == nil || !IsRestDay(planned, actual) {
+		return nil
+	}
+
+	fatigued := make([]MuscleFatigueState, 0)
+	for _, m := range body.Muscles {
+		if m.FatiguePercent > ActiveRecoveryFatigueThreshold {
+			fatigued = append(fatigued, m)
+		}
+	}
+	if len(fatigued) == 0 {
+		return nil
+	}
+	sort.SliceStable(fatigued, func(i, j int) bool {
+		return fatigued[i].FatiguePercent > fatigued[j].FatiguePercent
+	})
+
+	legsOverreached := false
+	for _, m := range fatigued {
+		if activeRecoveryLegMuscles[m.Muscle] && GetFatigueStatus(m.FatiguePercent) == FatigueStatusOverreached {
+			legsOverreached = true
+			break
+		}
+	}
+
+	targets := fatigued
+	if len(targets) > ActiveRecoveryMaxTargetAreas {
+		targets = targets[:ActiveRecoveryMaxTargetAreas]
+	}
+	areas := make([]ActiveRecoveryArea, len(targets))
+	focus := ""
+	for i, m := range targets {
+		displayName := m.DisplayName
+		if displayName == "" {
+			displayName = MuscleGroupDisplayNames[m.Muscle]
+		}
+		areas[i] = ActiveRecoveryArea{
+			Muscle:         m.Muscle,
+			DisplayName:    displayName,
+			FatiguePercent: math.Round(m.FatiguePercent),
+		}
+		if i > 0 {
+			focus += ", "
+		}
+		focus += displayName
+	}
+
+	activities := []ActiveRecoveryActivity{{
+		Type:        TrainingTypeMobility,
+		DurationMin: activeRecoveryMobilityBaseMin + activeRecoveryMobilityPerAreaMin*len(areas),
+		MaxRPE:      ActiveRecoveryMaxRPE,
+		Focus:       "Gentle mobility for " + focus,
+	}}
+
+	reason := itoa(len(fatigued)) + " muscle group(s) still above " + itoa(int(ActiveRecoveryFatigueThreshold)) + "% fatigue"
+	if legsOverreached {
+		reason += "; legs are overreached, so skip the walk"
+	} else {
+		walk := activeRecoveryWalkMin
+		if trainingLoad != nil && trainingLoad.ACR > ACROptimalUpper {
+			walk = activeRecoveryWalkHighLoadMin
+			reason += "; recent load is high, an easy walk aids circulation"
+		}
+		activities = append(activities, ActiveRecoveryActivity{
+			Type:        TrainingTypeWalking,
+			DurationMin: walk,
+			MaxRPE:      ActiveRecoveryMaxRPE,
+			Focus:       "Easy conversational-pace walk",
+		})
+	}
+
+	// Trim the last block so the protocol stays short
+	total := 0
+	for i := range activities {
+		remaining := ActiveRecoveryMaxDurationMin - total
+		if activities[i].DurationMin > remaining {
+			activities[i].DurationMin = remaining
+		}
+		total += activities[i].DurationMin
+	}
+
+	return &ActiveRecoverySuggestion{
+		Reason:           reason,
+		TargetAreas:      areas,
+		Activities:       activities,
+		TotalDurationMin: total,
+		Optional:         true,
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ActiveRecoverySuite struct {
+	suite.Suite
+}
+
+func TestActiveRecoverySuite(t *testing.T) {
+	suite.Run(t, new(ActiveRecoverySuite))
+}
+
+func bodyWith(fatigue map[MuscleGroup]float64) *BodyStatus {
+	muscles := make([]MuscleFatigueState, 0, len(fatigue))
+	for m, pct := range fatigue {
+		muscles = append(muscles, MuscleFatigueState{
+			Muscle:         m,
+			DisplayName:    MuscleGroupDisplayNames[m],
+			FatiguePercent: pct,
+			Status:         GetFatigueStatus(pct),
+		})
+	}
+	return &BodyStatus{Muscles: muscles}
+}
+
+func restSessions() []TrainingSession {
+	return []TrainingSession{{Type: TrainingTypeRest}}
+}
+
+func (s *ActiveRecoverySuite) TestNoSuggestionOnTrainingDay() {
+	planned := []TrainingSession{doubleDaySession(TrainingTypeStrength, 60, 7)}
+	body := bodyWith(map[MuscleGroup]float64{MuscleChest: 80})
+
+	s.Nil(SuggestActiveRecovery(planned, nil, body, nil))
+}
+
+func (s *ActiveRecoverySuite) TestNoSuggestionWhenLoggedTrainingOnPlannedRestDay() {
+	actual := []TrainingSession{doubleDaySession(TrainingTypeRun, 30, 6)}
+	body := bodyWith(map[MuscleGroup]float64{MuscleQuads: 70})
+
+	s.Nil(SuggestActiveRecovery(restSessions(), actual, body, nil))
+}
+
+func (s *ActiveRecoverySuite) TestNoSuggestionWhenFatigueLow() {
+	body := bodyWith(map[MuscleGroup]float64{MuscleChest: 50, MuscleLats: 20})
+
+	s.Nil(SuggestActiveRecovery(restSessions(), nil, body, nil))
+}
+
+func (s *ActiveRecoverySuite) TestNoSuggestionWithoutFatigueData() {
+	s.Nil(SuggestActiveRecovery(restSessions(), nil, nil, nil))
+}
+
+func (s *ActiveRecoverySuite) TestTargetsMostFatiguedAreas() {
+	body := bodyWith(map[MuscleGroup]float64{
+		MuscleChest:   60,
+		MuscleLats:    90,
+		MuscleTriceps: 55,
+		MuscleBiceps:  70,
+	})
+
+	got := SuggestActiveRecovery(restSessions(), nil, body, nil)
+	s.Require().NotNil(got)
+	s.True(got.Optional)
+	s.Require().Len(got.TargetAreas, ActiveRecoveryMaxTargetAreas)
+	s.Equal(MuscleLats, got.TargetAreas[0].Muscle)
+	s.Equal(MuscleBiceps, got.TargetAreas[1].Muscle)
+	s.Equal(MuscleChest, got.TargetAreas[2].Muscle)
+
+	s.Require().Len(got.Activities, 2)
+	s.Equal(TrainingTypeMobility, got.Activities[0].Type)
+	s.Equal(TrainingTypeWalking, got.Activities[1].Type)
+	s.Equal(activeRecoveryWalkMin, got.Activities[1].DurationMin)
+	s.LessOrEqual(got.TotalDurationMin, ActiveRecoveryMaxDurationMin)
+}
+
+func (s *ActiveRecoverySuite) TestSkipsWalkWhenLegsOverreached() {
+	body := bodyWith(map[MuscleGroup]float64{MuscleQuads: 85})
+
+	got := SuggestActiveRecovery(restSessions(), nil, body, nil)
+	s.Require().NotNil(got)
+	s.Require().Len(got.Activities, 1)
+	s.Equal(TrainingTypeMobility, got.Activities[0].Type)
+	s.Contains(got.Reason, "skip the walk")
+}
+
+func (s *ActiveRecoverySuite) TestHighLoadLengthensWalkWithinCap() {
+	body := bodyWith(map[MuscleGroup]float64{
+		MuscleChest: 60,
+		MuscleLats:  65,
+		MuscleCore:  70,
+	})
+	load := &TrainingLoadResult{ACR: 1.4}
+
+	got := SuggestActiveRecovery(restSessions(), nil, body, load)
+	s.Require().NotNil(got)
+	s.Require().Len(got.Activities, 2)
+	s.Equal(ActiveRecoveryMaxDurationMin, got.TotalDurationMin)
+	s.Equal(ActiveRecoveryMaxDurationMin-got.Activities[0].DurationMin, got.Activities[1].DurationMin)
+	s.Greater(got.Activities[1].DurationMin, activeRecoveryWalkMin)
+}
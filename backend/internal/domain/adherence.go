@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// ADHERENCE CALENDAR
+// =============================================================================
+//
+// Each day is classified against its calorie target for a heatmap view. The
+// on-target definition is shared with the weekly debrief's meal adherence so
+// the calendar and the debrief score never disagree. Days the user marks as
+// sick or travelling are shown as exempt instead of as misses.
+
+// MealAdherenceTolerance is the default fractional calorie deviation from target still counted as on-target.
+const MealAdherenceTolerance = 0.10
+
+// AdherenceTolerances are the user's on-target bands, as fractions of
+// target. Zero fields fall back to the defaults (see WithDefaults).
+type AdherenceTolerances struct {
+	Calories     float64 `json:"calories"`     // Calories within this deviation of target
+	ProteinFloor float64 `json:"proteinFloor"` // Protein at or above this fraction of target
+	FatMinimum   float64 `json:"fatMinimum"`   // Fat at or above this fraction of target
+	Carbs        float64 `json:"carbs"`        // Carbs within this deviation of target
+}
+
+// DefaultAdherenceTolerances: calories ±10%, protein ≥90%, fat ≥70%, carbs ±20%.
+var DefaultAdherenceTolerances = AdherenceTolerances{
+	Calories:     MealAdherenceTolerance,
+	ProteinFloor: 0.90,
+	FatMinimum:   0.70,
+	Carbs:        0.20,
+}
+
+// WithDefaults returns the tolerances with unset fields filled from the defaults.
+func (t AdherenceTolerances) WithDefaults() AdherenceTolerances {
+	if t.Calories == 0 {
+		t.Calories = DefaultAdherenceTolerances.Calories
+	}
+	if t.ProteinFloor == 0 {
+		t.ProteinFloor = DefaultAdherenceTolerances.ProteinFloor
+	}
+	if t.FatMinimum == 0 {
+		t.FatMinimum = DefaultAdherenceTolerances.FatMinimum
+	}
+	if t.Carbs == 0 {
+		t.Carbs = DefaultAdherenceTolerances.Carbs
+	}
+	return t
+}
+
+// Validate checks each tolerance is in range; zero means default.
+// Calories 2-30%, protein floor 50-100%, fat minimum 30-100%, carbs 5-50%.
+func (t AdherenceTolerances) Validate() error {
+	if t.Calories != 0 && (t.Calories < 0.02 || t.Calories > 0.30) {
+		return ErrInvalidCalorieTolerance
+	}
+	if t.ProteinFloor != 0 && (t.ProteinFloor < 0.50 || t.ProteinFloor > 1.0) {
+		return ErrInvalidProteinFloor
+	}
+	if t.FatMinimum != 0 && (t.FatMinimum < 0.30 || t.FatMinimum > 1.0) {
+		return ErrInvalidFatMinimum
+	}
+	if t.Carbs != 0 && (t.Carbs < 0.05 || t.Carbs > 0.50) {
+		return ErrInvalidCarbTolerance
+	}
+	return nil
+}
+
+// AdherenceCalendarMaxMonths caps the calendar range.
+const AdherenceCalendarMaxMonths = 12
+
+// DayAdherence classifies a single day.
+type DayAdherence string
+
+const (
+	AdherenceOnTarget DayAdherence = "on_target"
+	AdherenceOver     DayAdherence = "over"
+	AdherenceUnder    DayAdherence = "under"
+	AdherenceUnlogged DayAdherence = "unlogged"
+	AdherenceSick     DayAdherence = "sick"
+	AdherenceTravel   DayAdherence = "travel"
+)
+
+// DayExemptionReason explains why a day is excluded from adherence.
+type DayExemptionReason string
+
+const (
+	DayExemptionSick   DayExemptionReason = "sick"
+	DayExemptionTravel DayExemptionReason = "travel"
+)
+
+// ValidDayExemptionReasons contains all valid exemption reasons.
+var ValidDayExemptionReasons = map[DayExemptionReason]bool{
+	DayExemptionSick:   true,
+	DayExemptionTravel: true,
+}
+
+// DayExemption marks a day as sick or travelling.
+type DayExemption struct {
+	Date   string             `json:"date"`
+	Reason DayExemptionReason `json:"reason"`
+	Note   string             `json:"note,omitempty"`
+}
+
+// Validate checks the exemption's date and reason.
+func (e DayExemption) Validate() error {
+	if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if !ValidDayExemptionReasons[e.Reason] {
+		return ErrInvalidExemptionReason
+	}
+	return nil
+}
+
+// ClassifyDayAdherence compares a day's consumed calories with its target
+// using the default tolerance.
+func ClassifyDayAdherence(log DailyLog) DayAdherence {
+	return ClassifyDayAdherenceWithin(log, MealAdherenceTolerance)
+}
+
+// ClassifyDayAdherenceWithin compares a day's consumed calories with its
+// target using the given fractional tolerance. Days with no target or
+// nothing logged are unlogged.
+func ClassifyDayAdherenceWithin(log DailyLog, tolerance float64) DayAdherence {
+	target := log.CalculatedTargets.TotalCalories
+	if target <= 0 || log.ConsumedCalories <= 0 {
+		return AdherenceUnlogged
+	}
+	deviation := float64(log.ConsumedCalories-target) / float64(target)
+	switch {
+	case math.Abs(deviation) <= tolerance:
+		return AdherenceOnTarget
+	case deviation > 0:
+		return AdherenceOver
+	default:
+		return AdherenceUnder
+	}
+}
+
+// AdherenceCalendarDay is one cell of the heatmap.
+type AdherenceCalendarDay struct {
+	Date             string       `json:"date"`
+	Status           DayAdherence `json:"status"`
+	ConsumedCalories int          `json:"consumedCalories,omitempty"`
+	TargetCalories   int          `json:"targetCalories,omitempty"`
+}
+
+// AdherenceCalendar is the heatmap for a date range.
+type AdherenceCalendar struct {
+	StartDate string                 `json:"startDate"`
+	EndDate   string                 `json:"endDate"`
+	Days      []AdherenceCalendarDay `json:"days"`
+	Counts    map[DayAdherence]int   `json:"counts"`
+	// AdherencePercent is on-target days over days that could be judged
+	// (excludes unlogged and exempt days). Nil when no day could be judged.
+	AdherencePercent *float64 `json:"adherencePercent,omitempty"`
+}
+
+// BuildAdherenceCalendar classifies every day from start to end (inclusive)
+// against the calorie tolerance. Exemptions take precedence over logged
+// data; dates without a log are unlogged.
+func BuildAdherenceCalendar(start, end time.Time, logs []DailyLog, exemptions []DayExemption, tolerances AdherenceTolerances) AdherenceCalendar {
+	tolerance := tolerances.WithDefaults().Calories
+	byDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		byDate[l.Date] = l
+	}
+	exempt := make(map[string]DayExemptionReason, len(exemptions))
+	for _, e := range exemptions {
+		exempt[e.Date] = e.Reason
+	}
+
+	cal := AdherenceCalendar{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Days:      []AdherenceCalendarDay{},
+		Counts:    make(map[DayAdherence]int),
+	}
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		day := AdherenceCalendarDay{Date: date, Status: AdherenceUnlogged}
+		if l, ok := byDate[date]; ok {
+			day.Status = ClassifyDayAdherenceWithin(l, tolerance)
+			day.ConsumedCalories = l.ConsumedCalories
+			day.TargetCalories = l.CalculatedTargets.TotalCalories
+		}
+		switch exempt[date] {
+		case DayExemptionSick:
+			day.Status = AdherenceSick
+		case DayExemptionTravel:
+			day.Status = AdherenceTravel
+		}
+		cal.Days = append(cal.Days, day)
+		cal.Counts[day.Status]++
+	}
+
+	judged := cal.Counts[AdherenceOnTarget] + cal.Counts[AdherenceOver] + cal.Counts[AdherenceUnder]
+	if judged > 0 {
+		pct := math.Round(float64(cal.Counts[AdherenceOnTarget])/float64(judged)*1000) / 10
+		cal.AdherencePercent = &pct
+	}
+	return cal
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AdherenceSuite struct {
+	suite.Suite
+}
+
+func TestAdherenceSuite(t *testing.T) {
+	suite.Run(t, new(AdherenceSuite))
+}
+
+func adherenceLog(date string, consumed, target int) DailyLog {
+	return DailyLog{
+		Date:              date,
+		ConsumedCalories:  consumed,
+		CalculatedTargets: DailyTargets{TotalCalories: target},
+	}
+}
+
+func (s *AdherenceSuite) TestClassifyDayAdherence() {
+	s.Equal(AdherenceOnTarget, ClassifyDayAdherence(adherenceLog("", 2200, 2000)), "10% over is still on target")
+	s.Equal(AdherenceOnTarget, ClassifyDayAdherence(adherenceLog("", 1800, 2000)))
+	s.Equal(AdherenceOver, ClassifyDayAdherence(adherenceLog("", 2300, 2000)))
+	s.Equal(AdherenceUnder, ClassifyDayAdherence(adherenceLog("", 1500, 2000)))
+	s.Equal(AdherenceUnlogged, ClassifyDayAdherence(adherenceLog("", 0, 2000)))
+	s.Equal(AdherenceUnlogged, ClassifyDayAdherence(adherenceLog("", 1500, 0)))
+}
+
+func (s *AdherenceSuite) TestCalendarCoversEveryDay() {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	logs := []DailyLog{
+		adherenceLog("2026-03-01", 2000, 2000),
+		adherenceLog("2026-03-02", 2600, 2000),
+		adherenceLog("2026-03-03", 1200, 2000),
+		adherenceLog("2026-03-04", 3000, 2000), // Sick day: exempt despite being over
+	}
+	exemptions := []DayExemption{{Date: "2026-03-04", Reason: DayExemptionSick}}
+
+	cal := BuildAdherenceCalendar(start, end, logs, exemptions, DefaultAdherenceTolerances)
+
+	s.Require().Len(cal.Days, 5)
+	s.Equal("2026-03-01", cal.StartDate)
+	s.Equal("2026-03-05", cal.EndDate)
+	s.Equal(AdherenceOnTarget, cal.Days[0].Status)
+	s.Equal(AdherenceOver, cal.Days[1].Status)
+	s.Equal(AdherenceUnder, cal.Days[2].Status)
+	s.Equal(AdherenceSick, cal.Days[3].Status)
+	s.Equal(AdherenceUnlogged, cal.Days[4].Status, "no log at all")
+	s.Equal(1, cal.Counts[AdherenceSick])
+	s.Require().NotNil(cal.AdherencePercent)
+	s.Equal(33.3, *cal.AdherencePercent, "exempt and unlogged days are not judged")
+}
+
+func (s *AdherenceSuite) TestTravelWithoutLog() {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cal := BuildAdherenceCalendar(day, day, nil, []DayExemption{{Date: "2026-03-01", Reason: DayExemptionTravel}}, DefaultAdherenceTolerances)
+
+	s.Equal(AdherenceTravel, cal.Days[0].Status)
+	s.Nil(cal.AdherencePercent)
+}
+
+func (s *AdherenceSuite) TestCalendarMatchesDebriefMealAdherence() {
+	logs := []DailyLog{
+		adherenceLog("2026-03-01", 2000, 2000),
+		adherenceLog("2026-03-02", 2600, 2000),
+		adherenceLog("2026-03-03", 1900, 2000),
+		adherenceLog("2026-03-04", 1500, 2000),
+	}
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cal := BuildAdherenceCalendar(start, start.AddDate(0, 0, 3), logs, nil, DefaultAdherenceTolerances)
+
+	s.Equal(calculateMealAdherence(logs, DefaultAdherenceTolerances), *cal.AdherencePercent)
+}
+
+func (s *AdherenceSuite) TestExemptionValidation() {
+	s.NoError(DayExemption{Date: "2026-03-01", Reason: DayExemptionSick}.Validate())
+	s.ErrorIs(DayExemption{Date: "03/01/2026", Reason: DayExemptionSick}.Validate(), ErrInvalidDate)
+	s.ErrorIs(DayExemption{Date: "2026-03-01", Reason: "holiday"}.Validate(), ErrInvalidExemptionReason)
+}
+
+func (s *AdherenceSuite) TestCustomCalorieTolerance() {
+	logs := []DailyLog{
+		adherenceLog("2026-03-01", 2300, 2000),
+		adherenceLog("2026-03-02", 2000, 2000),
+	}
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	loose := AdherenceTolerances{Calories: 0.15}
+
+	cal := BuildAdherenceCalendar(start, start.AddDate(0, 0, 1), logs, nil, loose)
+
+	s.Equal(AdherenceOnTarget, cal.Days[0].Status, "15% over is on target with a 15% tolerance")
+	s.Equal(100.0, calculateMealAdherence(logs, loose))
+	s.Equal(50.0, calculateMealAdherence(logs, AdherenceTolerances{}), "zero means the ±10% default")
+}
+
+func (s *AdherenceSuite) TestTolerancesValidate() {
+	s.NoError(AdherenceTolerances{}.Validate(), "zero fields use the defaults")
+	s.NoError(DefaultAdherenceTolerances.Validate())
+	s.ErrorIs(AdherenceTolerances{Calories: 0.5}.Validate(), ErrInvalidCalorieTolerance)
+	s.ErrorIs(AdherenceTolerances{ProteinFloor: 1.2}.Validate(), ErrInvalidProteinFloor)
+	s.ErrorIs(AdherenceTolerances{FatMinimum: 0.1}.Validate(), ErrInvalidFatMinimum)
+	s.ErrorIs(AdherenceTolerances{Carbs: 0.01}.Validate(), ErrInvalidCarbTolerance)
+
+	filled := AdherenceTolerances{ProteinFloor: 0.8}.WithDefaults()
+	s.Equal(0.8, filled.ProteinFloor)
+	s.Equal(DefaultAdherenceTolerances.Carbs, filled.Carbs)
+}
@@ -13,6 +13,9 @@ const (
 	RecalibrationExtendTimeline  RecalibrationOptionType = "extend_timeline"
 	RecalibrationReviseGoal      RecalibrationOptionType = "revise_goal"
 	RecalibrationKeepCurrent     RecalibrationOptionType = "keep_current"
+
+	// RecalibrationAutoTuneKcalFactor is logged by kcal factor auto-tuning, not chosen by the user.
+	RecalibrationAutoTuneKcalFactor RecalibrationOptionType = "auto_tune_kcal_factor"
 )
 
 // FeasibilityTag indicates how achievable a recalibration option is.
@@ -42,6 +45,22 @@ type DualTrackAnalysis struct {
 	PlanProjection      []ProjectionPoint // Linear interpolation from start to goal
 	TrendProjection     []ProjectionPoint // Projection based on current trend
 	LandingPoint        *LandingPointProjection // Where user will end up at current pace
+
+	// Smoothed trend weight using the user's chosen method (0 if unavailable)
+	SmoothedWeightKg float64
+	TrendMethod      WeightTrendMethod
+
+	// Recomp plans judge progress on body fat; weight only has to stay in band
+	Track                  ProgressTrack
+	PlannedBodyFatPercent  *float64
+	ActualBodyFatPercent   *float64 // Trend value when available, else latest reading
+	BodyFatVariancePercent *float64 // Actual - Planned in percentage points
+	WeightBandMinKg        float64
+	WeightBandMaxKg        float64
+	WeightInBand           bool
+
+	// Event plans: countdown, tightened tolerance and final-week warnings
+	Event *EventCountdown
 }
 
 // LandingPointProjection represents where the user will end up if they continue
@@ -75,6 +94,11 @@ type AnalysisInput struct {
 	TolerancePercent float64       // From profile (1-10%, default 3%)
 	WeightTrend      *WeightTrend  // Current trend from weight history (optional)
 	AnalysisDate     time.Time
+	SmoothedWeight   *SmoothedWeightTrend // Trend with the user's smoothing method (optional)
+
+	// Recomp plans only
+	BodyFatTrend         *BodyFatTrend // Body-fat trend since plan start (optional)
+	LatestBodyFatPercent *float64      // Most recent reading, used without a trend
 }
 
 // CalculateDualTrackAnalysis performs variance analysis between plan and actual progress.
@@ -139,6 +163,7 @@ func CalculateDualTrackAnalysis(input AnalysisInput) (*DualTrackAnalysis, error)
 	}
 
 	analysis := &DualTrackAnalysis{
+		Track:               ProgressTrackWeight,
 		PlanID:              plan.ID,
 		AnalysisDate:        analysisDate,
 		CurrentWeek:         currentWeek,
@@ -151,6 +176,11 @@ func CalculateDualTrackAnalysis(input AnalysisInput) (*DualTrackAnalysis, error)
 		GracePeriod:         gracePeriod,
 	}
 
+	if input.SmoothedWeight != nil {
+		analysis.SmoothedWeightKg = input.SmoothedWeight.CurrentTrendKg
+		analysis.TrendMethod = input.SmoothedWeight.Smoothing.Method
+	}
+
 	// Generate plan projection points
 	analysis.PlanProjection = generatePlanProjection(plan)
 
@@ -196,6 +226,13 @@ func CalculateDualTrackAnalysis(input AnalysisInput) (*DualTrackAnalysis, error)
 		analysis.Options = nil
 	}
 
+	if plan.IsRecomp() {
+		applyRecompTrack(analysis, input)
+	}
+	if plan.IsEvent() {
+		applyEventCountdown(analysis, input)
+	}
+
 	return analysis, nil
 }
 
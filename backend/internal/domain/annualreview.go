@@ -0,0 +1,452 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// ANNUAL REVIEW
+// =============================================================================
+//
+// The annual review is the year-end counterpart of the weekly debrief: a
+// year-in-numbers summary (sessions by type, accumulated training load, weight
+// change, vitality streaks, plan outcomes) plus a narrative. A review covers a
+// calendar year; for the current year it covers the year to date. Reviews are
+// persisted as JSON, so the types carry json tags.
+
+const (
+	// VitalityStreakThreshold is the weekly vitality score a week must reach
+	// to extend a streak.
+	VitalityStreakThreshold = 70.0
+	// annualReviewMaxStreaks is how many of the longest streaks are reported.
+	annualReviewMaxStreaks = 3
+	// planGoalToleranceKg counts a plan's goal as reached within this margin.
+	planGoalToleranceKg = 0.5
+	// annualReviewFirstYear is the earliest year a review can be generated for.
+	annualReviewFirstYear = 2000
+)
+
+// AnnualReview is a year-in-numbers summary.
+type AnnualReview struct {
+	Year       int    `json:"year"`
+	StartDate  string `json:"startDate"` // Jan 1, YYYY-MM-DD
+	EndDate    string `json:"endDate"`   // Dec 31, or the last day covered for the current year
+	DaysLogged int    `json:"daysLogged"`
+
+	Training AnnualTraining      `json:"training"`
+	Weight   AnnualWeight        `json:"weight"`
+	Vitality AnnualVitality      `json:"vitality"`
+	Plans    []AnnualPlanOutcome `json:"plans"`
+
+	Narrative      string `json:"narrative"`
+	GeneratedByLLM bool   `json:"generatedByLlm"`
+	NarrativeModel string `json:"narrativeModel"` // Ollama model or "template"
+	GeneratedAt    string `json:"generatedAt"`    // ISO8601 timestamp
+}
+
+// AnnualTraining totals completed (actual) sessions. Load is the RPE-weighted
+// session load (see SessionLoad) accumulated over the year.
+type AnnualTraining struct {
+	TotalSessions    int                `json:"totalSessions"`
+	TotalDurationMin int                `json:"totalDurationMin"`
+	TotalLoad        float64            `json:"totalLoad"`
+	ByType           []AnnualTypeTotals `json:"byType"` // Most sessions first
+	PeakWeekStart    string             `json:"peakWeekStart,omitempty"`
+	PeakWeekLoad     float64            `json:"peakWeekLoad"`
+}
+
+// AnnualTypeTotals totals one training type.
+type AnnualTypeTotals struct {
+	Type        TrainingType `json:"type"`
+	Sessions    int          `json:"sessions"`
+	DurationMin int          `json:"durationMin"`
+	Load        float64      `json:"load"`
+}
+
+// AnnualWeight compares the first and last weigh-ins of the year.
+type AnnualWeight struct {
+	StartKg   *float64 `json:"startKg"`
+	EndKg     *float64 `json:"endKg"`
+	ChangeKg  *float64 `json:"changeKg"`
+	LowestKg  *float64 `json:"lowestKg"`
+	HighestKg *float64 `json:"highestKg"`
+}
+
+// AnnualVitality summarizes the weekly vitality scores of the year.
+type AnnualVitality struct {
+	WeeksScored   int              `json:"weeksScored"`
+	AverageScore  float64          `json:"averageScore"`
+	BestWeekStart string           `json:"bestWeekStart,omitempty"`
+	BestWeekScore float64          `json:"bestWeekScore"`
+	Streaks       []VitalityStreak `json:"streaks"` // Longest first, at most 3
+	// Tolerances are the adherence bands the weeks were scored with
+	Tolerances AdherenceTolerances `json:"tolerances"`
+}
+
+// VitalityStreak is a run of consecutive weeks scoring at least
+// VitalityStreakThreshold.
+type VitalityStreak struct {
+	StartDate    string  `json:"startDate"` // Monday of the first week
+	EndDate      string  `json:"endDate"`   // Sunday of the last week
+	Weeks        int     `json:"weeks"`
+	AverageScore float64 `json:"averageScore"`
+}
+
+// AnnualPlanOutcome is how a nutrition plan active during the year turned out.
+type AnnualPlanOutcome struct {
+	PlanID          int64      `json:"planId"`
+	Name            string     `json:"name"`
+	Status          PlanStatus `json:"status"`
+	StartDate       string     `json:"startDate"`
+	EndDate         string     `json:"endDate"` // Scheduled end
+	StartWeightKg   float64    `json:"startWeightKg"`
+	GoalWeightKg    float64    `json:"goalWeightKg"`
+	FinalWeightKg   *float64   `json:"finalWeightKg"`   // Last weekly weigh-in recorded against the plan
+	ProgressPercent *float64   `json:"progressPercent"` // Share of the planned change achieved
+	GoalReached     bool       `json:"goalReached"`
+}
+
+// AnnualReviewInput is the data an annual review is computed from.
+type AnnualReviewInput struct {
+	Year      int
+	EndDate   string     // Last day to include, YYYY-MM-DD
+	DailyLogs []DailyLog // Ordered by date, with ActualSessions filled
+	Plans     []*NutritionPlan
+	Scoring   VitalityScoringProfile
+	// Days changed through a retro-edit; their weeks can't extend a streak
+	RetroEditedDates map[string]bool
+}
+
+// AnnualReviewPeriod returns the dates a review of year covers as of now:
+// the whole year once it is over, otherwise Jan 1 through yesterday.
+func AnnualReviewPeriod(year int, now time.Time) (start, end string, err error) {
+	if year < annualReviewFirstYear || year > now.Year() {
+		return "", "", ErrInvalidReviewYear
+	}
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if year == now.Year() {
+		yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+		if yesterday.Before(first) {
+			return "", "", ErrInvalidReviewYear
+		}
+		last = yesterday
+	}
+	return first.Format("2006-01-02"), last.Format("2006-01-02"), nil
+}
+
+// IsAnnualReviewFinal reports whether a review was generated after its year
+// ended, so it covers the whole year and can be served from storage as is.
+func IsAnnualReviewFinal(review *AnnualReview) bool {
+	generated, err := time.Parse(time.RFC3339, review.GeneratedAt)
+	if err != nil {
+		return false
+	}
+	return generated.Year() > review.Year
+}
+
+// BuildAnnualReview computes the numbers of an annual review. The narrative
+// is left for the caller.
+func BuildAnnualReview(input AnnualReviewInput) AnnualReview {
+	review := AnnualReview{
+		Year:      input.Year,
+		StartDate: fmt.Sprintf("%d-01-01", input.Year),
+		EndDate:   input.EndDate,
+		Plans:     []AnnualPlanOutcome{},
+	}
+	for _, log := range input.DailyLogs {
+		if log.Date >= review.StartDate && log.Date <= review.EndDate {
+			review.DaysLogged++
+		}
+	}
+
+	review.Training = buildAnnualTraining(input.DailyLogs)
+	review.Weight = buildAnnualWeight(input.DailyLogs)
+	review.Vitality = buildAnnualVitality(input.DailyLogs, input.Scoring, input.RetroEditedDates)
+	review.Vitality.Tolerances = input.Scoring.Tolerances.WithDefaults()
+
+	for _, plan := range input.Plans {
+		if outcome, ok := buildPlanOutcome(plan, review.StartDate, review.EndDate); ok {
+			review.Plans = append(review.Plans, outcome)
+		}
+	}
+	return review
+}
+
+func buildAnnualTraining(logs []DailyLog) AnnualTraining {
+	training := AnnualTraining{ByType: []AnnualTypeTotals{}}
+	byType := make(map[TrainingType]*AnnualTypeTotals)
+	weekLoads := make(map[string]float64)
+
+	for _, log := range logs {
+		for _, session := range log.ActualSessions {
+			if session.Type == TrainingTypeRest {
+				continue
+			}
+			load := SessionLoad(session.Type, session.DurationMin, session.PerceivedIntensity)
+			totals, ok := byType[session.Type]
+			if !ok {
+				totals = &AnnualTypeTotals{Type: session.Type}
+				byType[session.Type] = totals
+			}
+			totals.Sessions++
+			totals.DurationMin += session.DurationMin
+			totals.Load += load
+
+			training.TotalSessions++
+			training.TotalDurationMin += session.DurationMin
+			training.TotalLoad += load
+			if monday, ok := weekStartOf(log.Date); ok {
+				weekLoads[monday] += load
+			}
+		}
+	}
+
+	for _, totals := range byType {
+		totals.Load = math.Round(totals.Load*10) / 10
+		training.ByType = append(training.ByType, *totals)
+	}
+	sort.Slice(training.ByType, func(i, j int) bool {
+		a, b := training.ByType[i], training.ByType[j]
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		return a.Type < b.Type
+	})
+
+	for week, load := range weekLoads {
+		if load > training.PeakWeekLoad || (load == training.PeakWeekLoad && week < training.PeakWeekStart) {
+			training.PeakWeekStart, training.PeakWeekLoad = week, load
+		}
+	}
+	training.TotalLoad = math.Round(training.TotalLoad*10) / 10
+	training.PeakWeekLoad = math.Round(training.PeakWeekLoad*10) / 10
+	return training
+}
+
+func buildAnnualWeight(logs []DailyLog) AnnualWeight {
+	var weight AnnualWeight
+	for _, log := range logs {
+		if log.WeightKg <= 0 {
+			continue
+		}
+		kg := log.WeightKg
+		if weight.StartKg == nil {
+			weight.StartKg = &kg
+		}
+		weight.EndKg = &kg
+		if weight.LowestKg == nil || kg < *weight.LowestKg {
+			weight.LowestKg = &kg
+		}
+		if weight.HighestKg == nil || kg > *weight.HighestKg {
+			weight.HighestKg = &kg
+		}
+	}
+	if weight.StartKg != nil {
+		change := math.Round((*weight.EndKg-*weight.StartKg)*100) / 100
+		weight.ChangeKg = &change
+	}
+	return weight
+}
+
+// weeklyVitality is the vitality score of one Monday-to-Sunday week.
+type weeklyVitality struct {
+	monday      time.Time
+	score       float64
+	retroEdited bool // A day of the week was changed through a retro-edit
+}
+
+func buildAnnualVitality(logs []DailyLog, scoring VitalityScoringProfile, retroEdited map[string]bool) AnnualVitality {
+	vitality := AnnualVitality{Streaks: []VitalityStreak{}}
+
+	// Group logs into Monday-to-Sunday weeks; the first and last weeks of the
+	// year may be partial
+	byWeek := make(map[string][]DailyLog)
+	var weekKeys []string
+	for _, log := range logs {
+		monday, ok := weekStartOf(log.Date)
+		if !ok {
+			continue
+		}
+		if _, seen := byWeek[monday]; !seen {
+			weekKeys = append(weekKeys, monday)
+		}
+		byWeek[monday] = append(byWeek[monday], log)
+	}
+	sort.Strings(weekKeys)
+
+	weeks := make([]weeklyVitality, 0, len(weekKeys))
+	total := 0.0
+	for _, key := range weekKeys {
+		monday, _ := time.Parse("2006-01-02", key)
+		score := CalculateVitalityScore(byWeek[key], nil, scoring).Overall
+		week := weeklyVitality{monday: monday, score: score}
+		for _, log := range byWeek[key] {
+			week.retroEdited = week.retroEdited || retroEdited[log.Date]
+		}
+		weeks = append(weeks, week)
+		total += score
+		if score > vitality.BestWeekScore {
+			vitality.BestWeekStart, vitality.BestWeekScore = key, score
+		}
+	}
+	vitality.WeeksScored = len(weeks)
+	if len(weeks) > 0 {
+		vitality.AverageScore = math.Round(total/float64(len(weeks))*10) / 10
+	}
+	vitality.Streaks = findVitalityStreaks(weeks)
+	return vitality
+}
+
+// findVitalityStreaks returns the longest runs of consecutive qualifying
+// weeks. A week without logs, or with a retro-edited day, breaks a streak.
+func findVitalityStreaks(weeks []weeklyVitality) []VitalityStreak {
+	streaks := []VitalityStreak{}
+	var run []weeklyVitality
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		sum := 0.0
+		for _, w := range run {
+			sum += w.score
+		}
+		streaks = append(streaks, VitalityStreak{
+			StartDate:    run[0].monday.Format("2006-01-02"),
+			EndDate:      run[len(run)-1].monday.AddDate(0, 0, 6).Format("2006-01-02"),
+			Weeks:        len(run),
+			AverageScore: math.Round(sum/float64(len(run))*10) / 10,
+		})
+		run = nil
+	}
+
+	for _, w := range weeks {
+		if w.score < VitalityStreakThreshold || w.retroEdited {
+			flush()
+			continue
+		}
+		if len(run) > 0 && !run[len(run)-1].monday.AddDate(0, 0, 7).Equal(w.monday) {
+			flush()
+		}
+		run = append(run, w)
+	}
+	flush()
+
+	sort.SliceStable(streaks, func(i, j int) bool {
+		if streaks[i].Weeks != streaks[j].Weeks {
+			return streaks[i].Weeks > streaks[j].Weeks
+		}
+		return streaks[i].AverageScore > streaks[j].AverageScore
+	})
+	if len(streaks) > annualReviewMaxStreaks {
+		streaks = streaks[:annualReviewMaxStreaks]
+	}
+	return streaks
+}
+
+// buildPlanOutcome reports a plan if its scheduled span overlaps the period.
+func buildPlanOutcome(plan *NutritionPlan, periodStart, periodEnd string) (AnnualPlanOutcome, bool) {
+	start := plan.StartDate.Format("2006-01-02")
+	end := plan.StartDate.AddDate(0, 0, plan.DurationWeeks*7-1).Format("2006-01-02")
+	if start > periodEnd || end < periodStart {
+		return AnnualPlanOutcome{}, false
+	}
+
+	outcome := AnnualPlanOutcome{
+		PlanID:        plan.ID,
+		Name:          plan.Name,
+		Status:        plan.Status,
+		StartDate:     start,
+		EndDate:       end,
+		StartWeightKg: plan.StartWeightKg,
+		GoalWeightKg:  plan.GoalWeightKg,
+	}
+	for _, target := range plan.WeeklyTargets {
+		if target.ActualWeightKg != nil {
+			kg := *target.ActualWeightKg
+			outcome.FinalWeightKg = &kg
+		}
+	}
+	if outcome.FinalWeightKg == nil {
+		return outcome, true
+	}
+
+	final := *outcome.FinalWeightKg
+	planned := plan.GoalWeightKg - plan.StartWeightKg
+	outcome.GoalReached = math.Abs(final-plan.GoalWeightKg) <= planGoalToleranceKg ||
+		(planned < 0 && final <= plan.GoalWeightKg) ||
+		(planned > 0 && final >= plan.GoalWeightKg)
+	if math.Abs(planned) > planGoalToleranceKg {
+		progress := math.Round((final-plan.StartWeightKg)/planned*1000) / 10
+		outcome.ProgressPercent = &progress
+	}
+	return outcome, true
+}
+
+// weekStartOf returns the Monday of the week containing date.
+func weekStartOf(date string) (string, bool) {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", false
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return t.AddDate(0, 0, -offset).Format("2006-01-02"), true
+}
+
+// GenerateFallbackAnnualNarrative creates a template-based narrative when the
+// LLM is unavailable.
+func GenerateFallbackAnnualNarrative(review *AnnualReview) string {
+	var sb strings.Builder
+
+	period := fmt.Sprintf("%d", review.Year)
+	if !strings.HasSuffix(review.EndDate, "-12-31") {
+		period = fmt.Sprintf("%d so far", review.Year)
+	}
+	fmt.Fprintf(&sb, "%s in numbers: %d days logged, %d training sessions totalling %d hours.",
+		period, review.DaysLogged, review.Training.TotalSessions, review.Training.TotalDurationMin/60)
+	if len(review.Training.ByType) > 0 {
+		top := review.Training.ByType[0]
+		fmt.Fprintf(&sb, " Most of it was %s (%d sessions).", strings.ReplaceAll(string(top.Type), "_", " "), top.Sessions)
+	}
+	if review.Training.PeakWeekStart != "" {
+		fmt.Fprintf(&sb, " Accumulated load came to %.0f, peaking in the week of %s.",
+			review.Training.TotalLoad, review.Training.PeakWeekStart)
+	}
+	sb.WriteString("\n\n")
+
+	if w := review.Weight; w.ChangeKg != nil {
+		switch {
+		case *w.ChangeKg > 0.1:
+			fmt.Fprintf(&sb, "Weight went up %.1fkg, from %.1fkg to %.1fkg.", *w.ChangeKg, *w.StartKg, *w.EndKg)
+		case *w.ChangeKg < -0.1:
+			fmt.Fprintf(&sb, "Weight came down %.1fkg, from %.1fkg to %.1fkg.", -*w.ChangeKg, *w.StartKg, *w.EndKg)
+		default:
+			fmt.Fprintf(&sb, "Weight held steady at %.1fkg.", *w.EndKg)
+		}
+		sb.WriteString(" ")
+	}
+	if v := review.Vitality; v.WeeksScored > 0 {
+		fmt.Fprintf(&sb, "Vitality averaged %.0f/100 over %d weeks", v.AverageScore, v.WeeksScored)
+		if len(v.Streaks) > 0 {
+			fmt.Fprintf(&sb, ", with a best run of %d straight weeks at %.0f or above.", v.Streaks[0].Weeks, VitalityStreakThreshold)
+		} else {
+			fmt.Fprintf(&sb, ", without a single week at %.0f or above.", VitalityStreakThreshold)
+		}
+	}
+
+	if len(review.Plans) > 0 {
+		reached := 0
+		for _, p := range review.Plans {
+			if p.GoalReached {
+				reached++
+			}
+		}
+		fmt.Fprintf(&sb, "\n\n%d of %d nutrition plans hit their goal weight.", reached, len(review.Plans))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The annual review is persisted once the year is over, so its
+// numbers must be right the first time; tests pin the period rules, the
+// training and weight totals, streak detection and plan outcomes.
+type AnnualReviewSuite struct {
+	suite.Suite
+}
+
+func TestAnnualReviewSuite(t *testing.T) {
+	suite.Run(t, new(AnnualReviewSuite))
+}
+
+func (s *AnnualReviewSuite) TestPeriodCoversWholeYearOnceOver() {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	start, end, err := AnnualReviewPeriod(2025, now)
+	s.Require().NoError(err)
+	s.Equal("2025-01-01", start)
+	s.Equal("2025-12-31", end)
+
+	start, end, err = AnnualReviewPeriod(2026, now)
+	s.Require().NoError(err)
+	s.Equal("2026-01-01", start)
+	s.Equal("2026-03-09", end, "year to date stops at yesterday")
+}
+
+func (s *AnnualReviewSuite) TestPeriodRejectsFutureAndUnstartedYears() {
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	for _, year := range []int{2027, 1999, 2026} {
+		_, _, err := AnnualReviewPeriod(year, now)
+		s.ErrorIs(err, ErrInvalidReviewYear, "year %d", year)
+	}
+}
+
+func (s *AnnualReviewSuite) TestReviewIsFinalOnlyWhenGeneratedAfterYearEnd() {
+	s.False(IsAnnualReviewFinal(&AnnualReview{Year: 2025, GeneratedAt: "2025-12-31T22:00:00Z"}))
+	s.True(IsAnnualReviewFinal(&AnnualReview{Year: 2025, GeneratedAt: "2026-01-01T08:00:00Z"}))
+	s.False(IsAnnualReviewFinal(&AnnualReview{Year: 2025}))
+}
+
+func (s *AnnualReviewSuite) TestTrainingTotalsSkipRestAndFindPeakWeek() {
+	rpe := 8
+	logs := []DailyLog{
+		{Date: "2025-03-03", ActualSessions: []TrainingSession{
+			{Type: TrainingTypeRun, DurationMin: 30},
+			{Type: TrainingTypeRest},
+		}},
+		{Date: "2025-03-05", ActualSessions: []TrainingSession{{Type: TrainingTypeHIIT, DurationMin: 60, PerceivedIntensity: &rpe}}},
+		{Date: "2025-03-12", ActualSessions: []TrainingSession{{Type: TrainingTypeRun, DurationMin: 45}}},
+	}
+
+	training := buildAnnualTraining(logs)
+
+	s.Equal(3, training.TotalSessions)
+	s.Equal(135, training.TotalDurationMin)
+	s.Require().Len(training.ByType, 2)
+	run := training.ByType[0]
+	s.Equal(TrainingTypeRun, run.Type, "most sessions first")
+	s.Equal(2, run.Sessions)
+	s.Equal(75, run.DurationMin)
+	s.InDelta(SessionLoad(TrainingTypeRun, 30, nil)+SessionLoad(TrainingTypeRun, 45, nil), run.Load, 0.05)
+	s.Equal("2025-03-03", training.PeakWeekStart, "the week with the HIIT session")
+	s.InDelta(SessionLoad(TrainingTypeRun, 30, nil)+SessionLoad(TrainingTypeHIIT, 60, &rpe), training.PeakWeekLoad, 0.05)
+}
+
+func (s *AnnualReviewSuite) TestWeightUsesFirstAndLastWeighIns() {
+	weight := buildAnnualWeight([]DailyLog{
+		{Date: "2025-01-02", WeightKg: 84.2},
+		{Date: "2025-01-03"}, // No weigh-in
+		{Date: "2025-06-01", WeightKg: 79.5},
+		{Date: "2025-12-30", WeightKg: 80.1},
+	})
+
+	s.Require().NotNil(weight.ChangeKg)
+	s.Equal(-4.1, *weight.ChangeKg)
+	s.Equal(84.2, *weight.StartKg)
+	s.Equal(80.1, *weight.EndKg)
+	s.Equal(79.5, *weight.LowestKg)
+	s.Equal(84.2, *weight.HighestKg)
+
+	s.Nil(buildAnnualWeight(nil).ChangeKg)
+}
+
+func (s *AnnualReviewSuite) TestStreaksNeedConsecutiveQualifyingWeeks() {
+	week := func(monday string, score float64) weeklyVitality {
+		t, _ := time.Parse("2006-01-02", monday)
+		return weeklyVitality{monday: t, score: score}
+	}
+	weeks := []weeklyVitality{
+		week("2025-01-06", 75), week("2025-01-13", 80), // 2 weeks
+		week("2025-01-20", 60),
+		week("2025-01-27", 90), week("2025-02-03", 72), week("2025-02-10", 71), // 3 weeks
+		week("2025-02-24", 85), // Gap week without logs breaks the run
+		week("2025-03-03", 40),
+	}
+
+	streaks := findVitalityStreaks(weeks)
+
+	s.Equal([]VitalityStreak{
+		{StartDate: "2025-01-27", EndDate: "2025-02-16", Weeks: 3, AverageScore: 77.7},
+		{StartDate: "2025-01-06", EndDate: "2025-01-19", Weeks: 2, AverageScore: 77.5},
+		{StartDate: "2025-02-24", EndDate: "2025-03-02", Weeks: 1, AverageScore: 85},
+	}, streaks)
+}
+
+func (s *AnnualReviewSuite) TestRetroEditedWeekBreaksStreak() {
+	week := func(monday string, retroEdited bool) weeklyVitality {
+		t, _ := time.Parse("2006-01-02", monday)
+		return weeklyVitality{monday: t, score: 80, retroEdited: retroEdited}
+	}
+	weeks := []weeklyVitality{
+		week("2025-01-06", false), week("2025-01-13", false),
+		week("2025-01-20", true), // Qualifies on score, but history was changed
+		week("2025-01-27", false),
+	}
+
+	streaks := findVitalityStreaks(weeks)
+
+	s.Equal([]VitalityStreak{
+		{StartDate: "2025-01-06", EndDate: "2025-01-19", Weeks: 2, AverageScore: 80},
+		{StartDate: "2025-01-27", EndDate: "2025-02-02", Weeks: 1, AverageScore: 80},
+	}, streaks)
+}
+
+func (s *AnnualReviewSuite) TestPlanOutcomes() {
+	final := 78.3
+	cut := &NutritionPlan{
+		ID: 1, Name: "Spring Cut", Status: PlanStatusCompleted,
+		StartDate: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), DurationWeeks: 12,
+		StartWeightKg: 84, GoalWeightKg: 78,
+		WeeklyTargets: []WeeklyTarget{{ActualWeightKg: &final}, {}},
+	}
+	old := &NutritionPlan{
+		ID: 2, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), DurationWeeks: 8,
+		StartWeightKg: 90, GoalWeightKg: 86,
+	}
+
+	outcome, ok := buildPlanOutcome(cut, "2025-01-01", "2025-12-31")
+	s.Require().True(ok)
+	s.Equal("2025-05-25", outcome.EndDate)
+	s.True(outcome.GoalReached, "within tolerance of the goal")
+	s.Require().NotNil(outcome.ProgressPercent)
+	s.Equal(95.0, *outcome.ProgressPercent)
+
+	_, ok = buildPlanOutcome(old, "2025-01-01", "2025-12-31")
+	s.False(ok, "ended before the year started")
+}
+
+func (s *AnnualReviewSuite) TestFallbackNarrativeMentionsHeadlineNumbers() {
+	change, start, end := -4.1, 84.2, 80.1
+	review := &AnnualReview{
+		Year: 2025, EndDate: "2025-12-31", DaysLogged: 301,
+		Training: AnnualTraining{TotalSessions: 180, TotalDurationMin: 9000, TotalLoad: 2100,
+			ByType: []AnnualTypeTotals{{Type: TrainingTypeRun, Sessions: 90}}, PeakWeekStart: "2025-05-05"},
+		Weight:   AnnualWeight{StartKg: &start, EndKg: &end, ChangeKg: &change},
+		Vitality: AnnualVitality{WeeksScored: 50, AverageScore: 71, Streaks: []VitalityStreak{{Weeks: 9}}},
+		Plans:    []AnnualPlanOutcome{{GoalReached: true}, {}},
+	}
+
+	text := GenerateFallbackAnnualNarrative(review)
+
+	for _, want := range []string{"2025 in numbers", "180 training sessions", "150 hours", "run (90 sessions)",
+		"came down 4.1kg", "9 straight weeks", "1 of 2 nutrition plans"} {
+		s.Contains(text, want)
+	}
+
+	review.EndDate = "2025-06-30"
+	s.Contains(GenerateFallbackAnnualNarrative(review), "2025 so far")
+}
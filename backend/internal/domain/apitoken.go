@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// PERSONAL API TOKENS
+// =============================================================================
+//
+// Scripts and shortcuts (e.g. an Apple Shortcut posting the morning weight)
+// authenticate with a personal bearer token instead of the main credentials.
+// Each token carries scopes limiting what it can do. Only a SHA-256 hash is
+// stored; the plaintext is shown once, when the token is created.
+//
+// Webhook-style integrations can additionally sign each request: an HMAC of
+// the timestamp, a one-time nonce, the method, path and body, keyed by a
+// signing secret issued with the token. The secret never travels with a
+// request, and stale timestamps and reused nonces are rejected, so a captured
+// request can't be replayed or re-signed.
+
+// TokenScope limits what a personal API token may do.
+type TokenScope string
+
+const (
+	// TokenScopeRead allows GET requests anywhere in the API.
+	TokenScopeRead TokenScope = "read"
+	// TokenScopeLogsWrite allows reads plus writes to daily logs.
+	TokenScopeLogsWrite TokenScope = "logs:write"
+	// TokenScopeAdmin allows every request, including token management.
+	TokenScopeAdmin TokenScope = "admin"
+)
+
+// ValidTokenScopes contains all valid token scopes.
+var ValidTokenScopes = map[TokenScope]bool{
+	TokenScopeRead:      true,
+	TokenScopeLogsWrite: true,
+	TokenScopeAdmin:     true,
+}
+
+const (
+	// APITokenPrefix marks Victus tokens so they are recognisable in scripts and secret scanners.
+	APITokenPrefix = "vct_"
+	// APISigningSecretPrefix marks a token's request signing secret.
+	APISigningSecretPrefix = "vcs_"
+	// APITokenMaxNameLength caps the token label.
+	APITokenMaxNameLength = 100
+
+	// APITokenSignatureWindow is how far a signed request's timestamp may be
+	// from the server clock. Nonces are remembered for twice this long.
+	APITokenSignatureWindow = 5 * time.Minute
+	// APITokenMaxNonceLength caps the nonce of a signed request.
+	APITokenMaxNonceLength = 128
+
+	apiTokenRandomBytes = 32
+	apiTokenHintLength  = len(APITokenPrefix) + 4
+)
+
+// APIToken is a stored personal API token. The plaintext is never stored.
+type APIToken struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	Scopes     []TokenScope `json:"scopes"`
+	Hint       string       `json:"hint"` // Leading characters of the token, to tell tokens apart
+	TokenHash  string       `json:"-"`
+	ExpiresAt  *time.Time   `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time   `json:"lastUsedAt,omitempty"`
+	LastUsedIP string       `json:"lastUsedIp,omitempty"`
+	RevokedAt  *time.Time   `json:"revokedAt,omitempty"`
+	// RequireSignature rejects requests that aren't signed (see SignAPIRequest)
+	RequireSignature bool `json:"requireSignature"`
+	// SigningSecret keys request signatures. Unlike the token it is stored as
+	// is, since verifying a signature needs it; tokens issued before signing
+	// secrets existed have none and can't sign.
+	SigningSecret string    `json:"-"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// APITokenEndpointUsage counts a token's requests to one endpoint.
+type APITokenEndpointUsage struct {
+	Endpoint   string    `json:"endpoint"` // Route pattern, e.g. "PATCH /api/logs/{date}/health-sync"
+	Requests   int       `json:"requests"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	LastUsedIP string    `json:"lastUsedIp,omitempty"`
+}
+
+// Signed request errors. These are authentication failures, not validation errors.
+var (
+	ErrAPIRequestUnsigned       = errors.New("api token requires signed requests")
+	ErrAPIRequestBadSignature   = errors.New("request signature does not match")
+	ErrAPIRequestStaleTimestamp = errors.New("request timestamp is missing or outside the allowed window")
+	ErrAPIRequestInvalidNonce   = errors.New("request nonce is missing or too long")
+	ErrAPIRequestReplayed       = errors.New("request nonce has already been used")
+)
+
+// SignedAPIRequest holds the signature headers and signed content of a request.
+type SignedAPIRequest struct {
+	Timestamp string // Unix seconds
+	Nonce     string
+	Signature string // Hex HMAC-SHA256
+	Method    string
+	Path      string
+	Body      []byte
+}
+
+// IsSigned reports whether any signature header was sent.
+func (r SignedAPIRequest) IsSigned() bool {
+	return r.Timestamp != "" || r.Nonce != "" || r.Signature != ""
+}
+
+// SignAPIRequest returns the hex HMAC-SHA256 signature of a request, keyed by
+// the token's signing secret.
+func SignAPIRequest(secret, timestamp, nonce, method, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + nonce + "\n" + method + "\n" + path + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the timestamp window, nonce and signature of a request
+// against the token's signing secret; without a secret nothing verifies.
+// Nonce reuse is checked separately against stored nonces.
+func (r SignedAPIRequest) Verify(secret string, now time.Time) error {
+	seconds, err := strconv.ParseInt(r.Timestamp, 10, 64)
+	if err != nil {
+		return ErrAPIRequestStaleTimestamp
+	}
+	skew := now.Sub(time.Unix(seconds, 0))
+	if skew > APITokenSignatureWindow || skew < -APITokenSignatureWindow {
+		return ErrAPIRequestStaleTimestamp
+	}
+	if r.Nonce == "" || len(r.Nonce) > APITokenMaxNonceLength {
+		return ErrAPIRequestInvalidNonce
+	}
+
+	if secret == "" {
+		return ErrAPIRequestBadSignature
+	}
+	expected := SignAPIRequest(secret, r.Timestamp, r.Nonce, r.Method, r.Path, r.Body)
+	if !hmac.Equal([]byte(strings.ToLower(r.Signature)), []byte(expected)) {
+		return ErrAPIRequestBadSignature
+	}
+	return nil
+}
+
+// NewAPIToken validates the name and scopes and generates a new token and its
+// signing secret. Returns the token to store and the plaintext to hand to the
+// user once; the signing secret is handed over with it.
+func NewAPIToken(name string, scopes []TokenScope, expiresAt *time.Time, requireSignature bool, now time.Time) (*APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > APITokenMaxNameLength {
+		return nil, "", ErrInvalidAPITokenName
+	}
+	if len(scopes) == 0 {
+		return nil, "", ErrAPITokenScopesRequired
+	}
+	seen := make(map[TokenScope]bool, len(scopes))
+	unique := make([]TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !ValidTokenScopes[scope] {
+			return nil, "", ErrInvalidTokenScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", ErrAPITokenExpiryInPast
+	}
+
+	plaintext, err := randomAPISecret(APITokenPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	signingSecret, err := randomAPISecret(APISigningSecretPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &APIToken{
+		Name:             name,
+		Scopes:           unique,
+		Hint:             plaintext[:apiTokenHintLength],
+		TokenHash:        HashAPIToken(plaintext),
+		SigningSecret:    signingSecret,
+		ExpiresAt:        expiresAt,
+		RequireSignature: requireSignature,
+	}, plaintext, nil
+}
+
+// randomAPISecret returns prefix followed by random hex.
+func randomAPISecret(prefix string) (string, error) {
+	raw := make([]byte, apiTokenRandomBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(raw), nil
+}
+
+// HashAPIToken returns the hex SHA-256 hash a token is stored and looked up by.
+func HashAPIToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired reports whether the token has passed its expiry.
+func (t *APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// IsActive reports whether the token can still authenticate.
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && !t.IsExpired(now)
+}
+
+// HasScope reports whether the token carries the scope.
+func (t *APIToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdminPath reports whether path is token management (/api/tokens), an
+// admin route (/api/admin), or the full-history export (/api/export) and
+// restore (/api/import), which only admin tokens may use once one exists.
+func IsAdminPath(path string) bool {
+	switch path {
+	case "/api/tokens", "/api/export", "/api/import":
+		return true
+	}
+	return strings.HasPrefix(path, "/api/tokens/") || strings.HasPrefix(path, "/api/admin/")
+}
+
+// Allows reports whether the token's scopes permit a request, given its HTTP
+// method (e.g. "GET") and path. Admin paths (see IsAdminPath) always require
+// the admin scope.
+func (t *APIToken) Allows(method, path string) bool {
+	if t.HasScope(TokenScopeAdmin) {
+		return true
+	}
+	if IsAdminPath(path) {
+		return false
+	}
+	if path == "/api/batch" {
+		// Each sub-request is checked against the scopes on its own
+		return method == "POST"
+	}
+	if method == "GET" || method == "HEAD" {
+		return t.HasScope(TokenScopeRead) || t.HasScope(TokenScopeLogsWrite)
+	}
+	return t.HasScope(TokenScopeLogsWrite) && (path == "/api/logs" || strings.HasPrefix(path, "/api/logs/"))
+}
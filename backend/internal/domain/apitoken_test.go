@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type APITokenSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestAPITokenSuite(t *testing.T) {
+	suite.Run(t, new(APITokenSuite))
+}
+
+func (s *APITokenSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+}
+
+func (s *APITokenSuite) TestNewTokenIsHashedAtRest() {
+	token, plaintext, err := NewAPIToken(" Weight shortcut ", []TokenScope{TokenScopeLogsWrite, TokenScopeLogsWrite}, nil, false, s.now)
+	s.Require().NoError(err)
+
+	s.True(strings.HasPrefix(plaintext, APITokenPrefix))
+	s.True(strings.HasPrefix(token.SigningSecret, APISigningSecretPrefix))
+	s.NotContains(token.SigningSecret, strings.TrimPrefix(plaintext, APITokenPrefix), "the signing secret is independent of the token")
+	s.Equal("Weight shortcut", token.Name)
+	s.Equal([]TokenScope{TokenScopeLogsWrite}, token.Scopes)
+	s.Equal(HashAPIToken(plaintext), token.TokenHash)
+	s.NotContains(token.TokenHash, plaintext[len(APITokenPrefix):])
+	s.True(strings.HasPrefix(plaintext, token.Hint))
+
+	_, other, err := NewAPIToken("Second", []TokenScope{TokenScopeRead}, nil, false, s.now)
+	s.Require().NoError(err)
+	s.NotEqual(plaintext, other)
+}
+
+func (s *APITokenSuite) TestValidation() {
+	past := s.now.Add(-time.Hour)
+
+	_, _, err := NewAPIToken("", []TokenScope{TokenScopeRead}, nil, false, s.now)
+	s.ErrorIs(err, ErrInvalidAPITokenName)
+	_, _, err = NewAPIToken("Script", nil, nil, false, s.now)
+	s.ErrorIs(err, ErrAPITokenScopesRequired)
+	_, _, err = NewAPIToken("Script", []TokenScope{"write"}, nil, false, s.now)
+	s.ErrorIs(err, ErrInvalidTokenScope)
+	_, _, err = NewAPIToken("Script", []TokenScope{TokenScopeRead}, &past, false, s.now)
+	s.ErrorIs(err, ErrAPITokenExpiryInPast)
+}
+
+func (s *APITokenSuite) TestExpiry() {
+	expires := s.now.Add(24 * time.Hour)
+	token := &APIToken{ExpiresAt: &expires}
+
+	s.False(token.IsExpired(s.now))
+	s.True(token.IsExpired(expires))
+	s.False((&APIToken{}).IsExpired(s.now))
+}
+
+func (s *APITokenSuite) TestScopes() {
+	read := &APIToken{Scopes: []TokenScope{TokenScopeRead}}
+	logs := &APIToken{Scopes: []TokenScope{TokenScopeLogsWrite}}
+	admin := &APIToken{Scopes: []TokenScope{TokenScopeAdmin}}
+
+	cases := []struct {
+		method, path      string
+		read, logs, admin bool
+	}{
+		{"GET", "/api/logs/2026-03-01", true, true, true},
+		{"PATCH", "/api/logs/2026-03-01/health-sync", false, true, true},
+		{"POST", "/api/logs", false, true, true},
+		{"PUT", "/api/profile", false, false, true},
+		{"GET", "/api/tokens", false, false, true},
+		{"DELETE", "/api/tokens/3", false, false, true},
+		{"GET", "/api/admin/ollama/models", false, false, true},
+		{"GET", "/api/export", false, false, true},
+		{"POST", "/api/import", false, false, true},
+		{"POST", "/api/import/nutrition", false, false, true},
+		{"GET", "/api/import/nutrition/unmatched", true, true, true},
+		{"POST", "/api/batch", true, true, true},
+	}
+	for _, tc := range cases {
+		s.Equal(tc.read, read.Allows(tc.method, tc.path), "read %s %s", tc.method, tc.path)
+		s.Equal(tc.logs, logs.Allows(tc.method, tc.path), "logs:write %s %s", tc.method, tc.path)
+		s.Equal(tc.admin, admin.Allows(tc.method, tc.path), "admin %s %s", tc.method, tc.path)
+	}
+}
+
+func (s *APITokenSuite) signed(secret string, at time.Time, body string) SignedAPIRequest {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return SignedAPIRequest{
+		Timestamp: timestamp,
+		Nonce:     "n-1",
+		Signature: SignAPIRequest(secret, timestamp, "n-1", "PATCH", "/api/logs/2026-03-01/health-sync", []byte(body)),
+		Method:    "PATCH",
+		Path:      "/api/logs/2026-03-01/health-sync",
+		Body:      []byte(body),
+	}
+}
+
+func (s *APITokenSuite) TestSignedRequestVerifies() {
+	req := s.signed("vcs_secret", s.now.Add(-time.Minute), `{"weight":80.1}`)
+
+	s.True(req.IsSigned())
+	s.NoError(req.Verify("vcs_secret", s.now))
+	s.False(SignedAPIRequest{Method: "GET"}.IsSigned())
+}
+
+func (s *APITokenSuite) TestSignedRequestRejections() {
+	req := s.signed("vcs_secret", s.now, `{"weight":80.1}`)
+	s.ErrorIs(req.Verify("vcs_other", s.now), ErrAPIRequestBadSignature)
+
+	tampered := req
+	tampered.Body = []byte(`{"weight":95}`)
+	s.ErrorIs(tampered.Verify("vcs_secret", s.now), ErrAPIRequestBadSignature)
+
+	stale := s.signed("vcs_secret", s.now.Add(-APITokenSignatureWindow-time.Second), "")
+	s.ErrorIs(stale.Verify("vcs_secret", s.now), ErrAPIRequestStaleTimestamp)
+
+	noNonce := req
+	noNonce.Nonce = ""
+	s.ErrorIs(noNonce.Verify("vcs_secret", s.now), ErrAPIRequestInvalidNonce)
+
+	unkeyed := s.signed("", s.now, `{"weight":80.1}`)
+	s.ErrorIs(unkeyed.Verify("", s.now), ErrAPIRequestBadSignature, "tokens without a signing secret can't sign")
+}
+
+func (s *APITokenSuite) TestRevokedTokenIsInactive() {
+	revoked := s.now.Add(-time.Minute)
+
+	s.True((&APIToken{}).IsActive(s.now))
+	s.False((&APIToken{RevokedAt: &revoked}).IsActive(s.now))
+}
@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// ARCHETYPE INFERENCE
+// =============================================================================
+//
+// Fatigue is applied per archetype, but sessions logged by voice or quick
+// entry rarely name one. Archetypes are inferred in order of evidence:
+//   1. the training type, for cardio types that map to a single archetype,
+//   2. keywords in the session notes ("bench", "squats", "leg day"),
+//   3. the recent rotation of resistance archetypes (push → pull → legs,
+//      upper ↔ lower),
+//   4. a default for the type, at low confidence.
+// An LLM can refine a low-confidence result. Inferences below
+// MinArchetypeConfidence wait in a review queue and only apply fatigue once
+// confirmed.
+
+// ArchetypeInferenceSource names how an archetype was inferred.
+type ArchetypeInferenceSource string
+
+const (
+	ArchetypeSourceRules ArchetypeInferenceSource = "rules"
+	ArchetypeSourceLLM   ArchetypeInferenceSource = "llm"
+)
+
+// ArchetypeReviewStatus is the state of a queued low-confidence inference.
+type ArchetypeReviewStatus string
+
+const (
+	ArchetypeReviewPending   ArchetypeReviewStatus = "pending"
+	ArchetypeReviewConfirmed ArchetypeReviewStatus = "confirmed"
+	ArchetypeReviewDismissed ArchetypeReviewStatus = "dismissed"
+)
+
+const (
+	// MinArchetypeConfidence is the confidence at which an inferred archetype
+	// applies fatigue without review.
+	MinArchetypeConfidence = 0.7
+	// ArchetypeRotationLookback is how many recent archetypes the rotation
+	// rule considers.
+	ArchetypeRotationLookback = 6
+)
+
+// ArchetypeInference is an inferred archetype for a session.
+type ArchetypeInference struct {
+	Archetype  Archetype // Empty when nothing could be inferred
+	Confidence float64   // 0-1
+	Source     ArchetypeInferenceSource
+	Reason     string
+}
+
+// Confident reports whether the inference can apply fatigue without review.
+func (i ArchetypeInference) Confident() bool {
+	return i.Archetype != "" && i.Confidence >= MinArchetypeConfidence
+}
+
+// ArchetypeReview is a low-confidence inference waiting for the user.
+type ArchetypeReview struct {
+	ID           int64
+	SessionID    int64
+	Date         string
+	SessionOrder int
+	Type         TrainingType
+	DurationMin  int
+	Notes        string
+	Inference    ArchetypeInference
+	Status       ArchetypeReviewStatus
+	Resolved     Archetype // Archetype applied on confirmation
+	CreatedAt    time.Time
+	ResolvedAt   *time.Time
+}
+
+// typeArchetypes maps training types that imply a single archetype.
+var typeArchetypes = map[TrainingType]Archetype{
+	TrainingTypeRun:     ArchetypeCardioImpact,
+	TrainingTypeHIIT:    ArchetypeCardioImpact,
+	TrainingTypeWalking: ArchetypeCardioLow,
+	TrainingTypeCycle:   ArchetypeCardioLow,
+	TrainingTypeRow:     ArchetypeCardioLow,
+}
+
+// typeDefaultArchetypes is the low-confidence fallback for resistance types.
+var typeDefaultArchetypes = map[TrainingType]Archetype{
+	TrainingTypeStrength:     ArchetypeFullBody,
+	TrainingTypeCalisthenics: ArchetypeFullBody,
+	TrainingTypeGMB:          ArchetypeFullBody,
+	TrainingTypeMixed:        ArchetypeFullBody,
+}
+
+// NeedsArchetype reports whether sessions of a type load muscles enough to
+// carry an archetype. Rest, qigong and mobility don't.
+func NeedsArchetype(t TrainingType) bool {
+	_, cardio := typeArchetypes[t]
+	_, resistance := typeDefaultArchetypes[t]
+	return cardio || resistance
+}
+
+// archetypeKeyword maps a notes keyword to an archetype. Split names ("push
+// day") weigh more than exercises ("bench").
+type archetypeKeyword struct {
+	archetype Archetype
+	weight    int
+}
+
+var archetypeKeywords = map[string]archetypeKeyword{
+	"push":       {ArchetypePush, 2},
+	"bench":      {ArchetypePush, 1},
+	"press":      {ArchetypePush, 1},
+	"ohp":        {ArchetypePush, 1},
+	"dip":        {ArchetypePush, 1},
+	"dips":       {ArchetypePush, 1},
+	"pushup":     {ArchetypePush, 1},
+	"pushups":    {ArchetypePush, 1},
+	"chest":      {ArchetypePush, 1},
+	"triceps":    {ArchetypePush, 1},
+	"pull":       {ArchetypePull, 2},
+	"pullup":     {ArchetypePull, 1},
+	"pullups":    {ArchetypePull, 1},
+	"chinup":     {ArchetypePull, 1},
+	"chinups":    {ArchetypePull, 1},
+	"rows":       {ArchetypePull, 1},
+	"deadlift":   {ArchetypePull, 1},
+	"deadlifts":  {ArchetypePull, 1},
+	"curl":       {ArchetypePull, 1},
+	"curls":      {ArchetypePull, 1},
+	"biceps":     {ArchetypePull, 1},
+	"lats":       {ArchetypePull, 1},
+	"leg":        {ArchetypeLegs, 2},
+	"legs":       {ArchetypeLegs, 2},
+	"squat":      {ArchetypeLegs, 1},
+	"squats":     {ArchetypeLegs, 1},
+	"lunge":      {ArchetypeLegs, 1},
+	"lunges":     {ArchetypeLegs, 1},
+	"legpress":   {ArchetypeLegs, 1},
+	"rdl":        {ArchetypeLegs, 1},
+	"rdls":       {ArchetypeLegs, 1},
+	"quads":      {ArchetypeLegs, 1},
+	"hamstrings": {ArchetypeLegs, 1},
+	"calves":     {ArchetypeLegs, 1},
+	"upper":      {ArchetypeUpper, 2},
+	"lower":      {ArchetypeLower, 2},
+	"fullbody":   {ArchetypeFullBody, 2},
+}
+
+// archetypeRotation gives the next archetype in common splits.
+var archetypeRotation = map[Archetype]Archetype{
+	ArchetypePush:     ArchetypePull,
+	ArchetypePull:     ArchetypeLegs,
+	ArchetypeLegs:     ArchetypePush,
+	ArchetypeUpper:    ArchetypeLower,
+	ArchetypeLower:    ArchetypeUpper,
+	ArchetypeFullBody: ArchetypeFullBody,
+}
+
+// InferArchetype infers a session's archetype from its type, notes and the
+// archetypes of recent sessions (most recent first).
+func InferArchetype(session TrainingSession, recent []Archetype) ArchetypeInference {
+	if a, ok := typeArchetypes[session.Type]; ok {
+		return ArchetypeInference{
+			Archetype:  a,
+			Confidence: 0.9,
+			Source:     ArchetypeSourceRules,
+			Reason:     fmt.Sprintf("%s sessions are %s", session.Type, ArchetypeDisplayNames[a]),
+		}
+	}
+	fallback, ok := typeDefaultArchetypes[session.Type]
+	if !ok {
+		return ArchetypeInference{Source: ArchetypeSourceRules, Reason: "no archetype for " + string(session.Type)}
+	}
+
+	if inference, ok := inferFromNotes(session.Notes); ok {
+		return inference
+	}
+	if inference, ok := inferFromRotation(recent); ok {
+		return inference
+	}
+	return ArchetypeInference{
+		Archetype:  fallback,
+		Confidence: 0.4,
+		Source:     ArchetypeSourceRules,
+		Reason:     "default for " + string(session.Type),
+	}
+}
+
+// inferFromNotes scores the notes' keywords. One archetype named outright is
+// confident; push with pull reads as upper body and upper with lower as full
+// body; anything else is ambiguous.
+func inferFromNotes(notes string) (ArchetypeInference, bool) {
+	text := strings.ToLower(notes)
+	text = strings.NewReplacer("full body", "fullbody", "full-body", "fullbody",
+		"leg press", "legpress", "push-up", "pushup", "pull-up", "pullup", "chin-up", "chinup").Replace(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+
+	scores := make(map[Archetype]int)
+	named := make(map[Archetype]bool)
+	var matched []string
+	for _, w := range words {
+		kw, ok := archetypeKeywords[w]
+		if !ok {
+			continue
+		}
+		scores[kw.archetype] += kw.weight
+		named[kw.archetype] = named[kw.archetype] || kw.weight > 1
+		matched = append(matched, w)
+	}
+	if len(scores) == 0 {
+		return ArchetypeInference{}, false
+	}
+	reason := "notes mention " + strings.Join(matched, ", ")
+
+	if len(scores) == 1 {
+		for a := range scores {
+			confidence := 0.8
+			if named[a] {
+				confidence = 0.9
+			}
+			return ArchetypeInference{Archetype: a, Confidence: confidence, Source: ArchetypeSourceRules, Reason: reason}, true
+		}
+	}
+
+	upper := scores[ArchetypePush] > 0 || scores[ArchetypePull] > 0 || scores[ArchetypeUpper] > 0
+	lower := scores[ArchetypeLegs] > 0 || scores[ArchetypeLower] > 0
+	switch {
+	case upper && lower || scores[ArchetypeFullBody] > 0:
+		return ArchetypeInference{Archetype: ArchetypeFullBody, Confidence: 0.75, Source: ArchetypeSourceRules, Reason: reason}, true
+	case upper:
+		return ArchetypeInference{Archetype: ArchetypeUpper, Confidence: 0.75, Source: ArchetypeSourceRules, Reason: reason}, true
+	}
+
+	// Mixed signals within one region, e.g. "legs" with "lower": take the top
+	// score without trusting it
+	var best Archetype
+	for a, score := range scores {
+		if best == "" || score > scores[best] || score == scores[best] && a < best {
+			best = a
+		}
+	}
+	return ArchetypeInference{Archetype: best, Confidence: 0.5, Source: ArchetypeSourceRules, Reason: reason}, true
+}
+
+// inferFromRotation predicts the next archetype of the split the recent
+// resistance sessions follow. Confidence grows with each recent session that
+// kept to the rotation, so a single previous session is never enough.
+func inferFromRotation(recent []Archetype) (ArchetypeInference, bool) {
+	var resistance []Archetype
+	for _, a := range recent {
+		if _, ok := archetypeRotation[a]; ok {
+			resistance = append(resistance, a)
+		}
+		if len(resistance) == ArchetypeRotationLookback {
+			break
+		}
+	}
+	if len(resistance) == 0 {
+		return ArchetypeInference{}, false
+	}
+
+	last := resistance[0]
+	consistent := 0
+	for i := 0; i+1 < len(resistance) && archetypeRotation[resistance[i+1]] == resistance[i]; i++ {
+		consistent++
+	}
+	next := archetypeRotation[last]
+	return ArchetypeInference{
+		Archetype:  next,
+		Confidence: math.Min(0.5+0.1*float64(consistent), 0.8),
+		Source:     ArchetypeSourceRules,
+		Reason:     fmt.Sprintf("follows %s in your recent rotation", ArchetypeDisplayNames[last]),
+	}, true
+}
+
+// ValidateArchetypeInference checks an inference from an external source.
+func ValidateArchetypeInference(i ArchetypeInference) error {
+	if !ValidArchetypes[i.Archetype] {
+		return ErrInvalidArchetype
+	}
+	if i.Confidence < 0 || i.Confidence > 1 {
+		return ErrInvalidArchetypeConfidence
+	}
+	return nil
+}
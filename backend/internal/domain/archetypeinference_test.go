@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Inferred archetypes apply fatigue without the user looking;
+// tests pin which evidence wins, the confidence that decides between applying
+// and queueing for review, and the rotation rule's need for history.
+type ArchetypeInferenceSuite struct {
+	suite.Suite
+}
+
+func TestArchetypeInferenceSuite(t *testing.T) {
+	suite.Run(t, new(ArchetypeInferenceSuite))
+}
+
+func (s *ArchetypeInferenceSuite) session(t TrainingType, notes string) TrainingSession {
+	return TrainingSession{SessionOrder: 1, Type: t, DurationMin: 45, Notes: notes}
+}
+
+func (s *ArchetypeInferenceSuite) TestCardioTypesMapDirectly() {
+	cases := map[TrainingType]Archetype{
+		TrainingTypeRun:     ArchetypeCardioImpact,
+		TrainingTypeHIIT:    ArchetypeCardioImpact,
+		TrainingTypeCycle:   ArchetypeCardioLow,
+		TrainingTypeWalking: ArchetypeCardioLow,
+		TrainingTypeRow:     ArchetypeCardioLow,
+	}
+	for typ, want := range cases {
+		inference := InferArchetype(s.session(typ, "legs felt heavy"), nil)
+		s.Equal(want, inference.Archetype, string(typ))
+		s.True(inference.Confident(), string(typ))
+	}
+}
+
+func (s *ArchetypeInferenceSuite) TestTypesWithoutArchetype() {
+	for _, typ := range []TrainingType{TrainingTypeRest, TrainingTypeQigong, TrainingTypeMobility} {
+		s.False(NeedsArchetype(typ), string(typ))
+		s.Empty(InferArchetype(s.session(typ, ""), nil).Archetype, string(typ))
+	}
+	s.True(NeedsArchetype(TrainingTypeStrength))
+	s.True(NeedsArchetype(TrainingTypeRun))
+}
+
+func (s *ArchetypeInferenceSuite) TestNotesKeywords() {
+	cases := []struct {
+		notes     string
+		want      Archetype
+		confident bool
+	}{
+		{"Push day, felt strong", ArchetypePush, true},
+		{"bench 5x5 then dips", ArchetypePush, true},
+		{"Pull-ups and barbell rows", ArchetypePull, true},
+		{"LEG DAY: squats, lunges", ArchetypeLegs, true},
+		{"bench and chin-ups superset", ArchetypeUpper, true},
+		{"squats then overhead press", ArchetypeFullBody, true},
+		{"full-body circuit", ArchetypeFullBody, true},
+		{"leg press and calves", ArchetypeLegs, true},
+		{"lower, legs mostly", ArchetypeLegs, false},
+	}
+	for _, tc := range cases {
+		inference := InferArchetype(s.session(TrainingTypeStrength, tc.notes), nil)
+		s.Equal(tc.want, inference.Archetype, tc.notes)
+		s.Equal(tc.confident, inference.Confident(), tc.notes)
+		s.Equal(ArchetypeSourceRules, inference.Source, tc.notes)
+		s.Contains(inference.Reason, "notes mention", tc.notes)
+	}
+}
+
+func (s *ArchetypeInferenceSuite) TestNotesBeatRotation() {
+	recent := []Archetype{ArchetypePush, ArchetypeLegs, ArchetypePull, ArchetypePush}
+	inference := InferArchetype(s.session(TrainingTypeStrength, "squats"), recent)
+	s.Equal(ArchetypeLegs, inference.Archetype)
+}
+
+func (s *ArchetypeInferenceSuite) TestRotation() {
+	// Push, legs, pull, push (most recent first) kept to push → pull → legs
+	recent := []Archetype{ArchetypePush, ArchetypeLegs, ArchetypePull, ArchetypePush}
+	inference := InferArchetype(s.session(TrainingTypeStrength, ""), recent)
+	s.Equal(ArchetypePull, inference.Archetype)
+	s.InDelta(0.8, inference.Confidence, 0.001)
+	s.True(inference.Confident())
+
+	// Cardio between lifting days doesn't break the rotation
+	recent = []Archetype{ArchetypeCardioLow, ArchetypeUpper, ArchetypeCardioImpact, ArchetypeLower, ArchetypeUpper}
+	inference = InferArchetype(s.session(TrainingTypeCalisthenics, ""), recent)
+	s.Equal(ArchetypeLower, inference.Archetype)
+	s.InDelta(0.7, inference.Confidence, 0.001)
+
+	// One previous session is a guess
+	inference = InferArchetype(s.session(TrainingTypeStrength, ""), []Archetype{ArchetypeLegs})
+	s.Equal(ArchetypePush, inference.Archetype)
+	s.False(inference.Confident())
+}
+
+func (s *ArchetypeInferenceSuite) TestFallback() {
+	inference := InferArchetype(s.session(TrainingTypeStrength, "felt good"), nil)
+	s.Equal(ArchetypeFullBody, inference.Archetype)
+	s.False(inference.Confident(), "the type default always goes to review")
+}
+
+func (s *ArchetypeInferenceSuite) TestValidateInference() {
+	s.NoError(ValidateArchetypeInference(ArchetypeInference{Archetype: ArchetypePull, Confidence: 0.6}))
+	s.ErrorIs(ValidateArchetypeInference(ArchetypeInference{Archetype: "arms", Confidence: 0.6}), ErrInvalidArchetype)
+	s.ErrorIs(ValidateArchetypeInference(ArchetypeInference{Archetype: ArchetypePull, Confidence: 1.2}), ErrInvalidArchetypeConfidence)
+}
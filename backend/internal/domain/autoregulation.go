@@ -0,0 +1,91 @@
+package domain
+
+import "math"
+
+// =============================================================================
+// INTENSITY AUTOREGULATION
+// =============================================================================
+//
+// An installation in autoregulation mode scales each day's prescribed load
+// and duration by that morning's readiness (the day's recovery score). The
+// prescription is unchanged at the moderate recovery threshold and reaches
+// the installation's bound at the good and poor thresholds either side, so a
+// well-recovered morning earns at most +bound and a poor one at most -bound.
+
+const (
+	// DefaultAutoregulationBound is the scaling limit when none is given (±15%).
+	DefaultAutoregulationBound = 0.15
+	// MinAutoregulationBound and MaxAutoregulationBound limit the configurable bound.
+	MinAutoregulationBound = 0.05
+	MaxAutoregulationBound = 0.25
+)
+
+// SessionAutoregulation records the readiness scaling applied to a scheduled
+// session, with the prescription before and after it.
+type SessionAutoregulation struct {
+	Date            string  `json:"date"` // YYYY-MM-DD
+	WeekNumber      int     `json:"weekNumber"`
+	DayNumber       int     `json:"dayNumber"`
+	ReadinessScore  float64 `json:"readinessScore"` // Recovery score (0-100)
+	Scale           float64 `json:"scale"`          // Multiplier applied, within 1 ± bound
+	BaseLoadScore   float64 `json:"baseLoadScore"`
+	BaseDurationMin int     `json:"baseDurationMin"`
+	LoadScore       float64 `json:"loadScore"`
+	DurationMin     int     `json:"durationMin"`
+}
+
+// ValidateAutoregulationBound checks a bound is off (0) or within limits.
+func ValidateAutoregulationBound(bound float64) error {
+	if bound == 0 {
+		return nil
+	}
+	if math.IsNaN(bound) || bound < MinAutoregulationBound || bound > MaxAutoregulationBound {
+		return ErrInvalidAutoregulationBound
+	}
+	return nil
+}
+
+// AutoregulationScale returns the multiplier for a readiness score under the
+// given bound, rounded to three decimals.
+func AutoregulationScale(readiness, bound float64) float64 {
+	neutral := RecoveryScoreModerateThreshold
+	var t float64
+	if readiness >= neutral {
+		t = (readiness - neutral) / (RecoveryScoreGoodThreshold - neutral)
+	} else {
+		t = (readiness - neutral) / (neutral - RecoveryScorePoorThreshold)
+	}
+	t = math.Max(-1, math.Min(t, 1))
+	return math.Round((1+t*bound)*1000) / 1000
+}
+
+// Autoregulate scales the session's load and duration by the readiness score
+// and records the scaling on it. Duration stays within program day limits.
+// Rest sessions are left alone.
+func (s *ScheduledSession) Autoregulate(readiness, bound float64) {
+	if s.TrainingType == TrainingTypeRest {
+		return
+	}
+	scale := AutoregulationScale(readiness, bound)
+	duration := int(math.Round(float64(s.DurationMin) * scale))
+	duration = max(MinDayDurationMin, min(duration, MaxDayDurationMin))
+	record := SessionAutoregulation{
+		Date:            s.Date.Format("2006-01-02"),
+		WeekNumber:      s.WeekNumber,
+		DayNumber:       s.DayNumber,
+		ReadinessScore:  math.Round(readiness*10) / 10,
+		Scale:           scale,
+		BaseLoadScore:   s.LoadScore,
+		BaseDurationMin: s.DurationMin,
+		LoadScore:       math.Round(s.LoadScore*scale*100) / 100,
+		DurationMin:     duration,
+	}
+	s.ApplyAutoregulation(record)
+}
+
+// ApplyAutoregulation sets the session's prescription from a recorded scaling.
+func (s *ScheduledSession) ApplyAutoregulation(record SessionAutoregulation) {
+	s.LoadScore = record.LoadScore
+	s.DurationMin = record.DurationMin
+	s.Autoregulation = &record
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Readiness scaling is piecewise around the recovery
+// thresholds and must never leave its bound; tests pin the anchor points,
+// the clamping, the duration limits and what gets recorded.
+type AutoregulationSuite struct {
+	suite.Suite
+}
+
+func TestAutoregulationSuite(t *testing.T) {
+	suite.Run(t, new(AutoregulationSuite))
+}
+
+func (s *AutoregulationSuite) TestScale() {
+	s.Equal(1.0, AutoregulationScale(60, 0.15), "moderate threshold is neutral")
+	s.Equal(1.15, AutoregulationScale(80, 0.15))
+	s.Equal(1.15, AutoregulationScale(100, 0.15), "capped at the bound")
+	s.Equal(0.85, AutoregulationScale(30, 0.15))
+	s.Equal(0.85, AutoregulationScale(0, 0.15))
+	s.Equal(1.075, AutoregulationScale(70, 0.15))
+	s.Equal(0.95, AutoregulationScale(50, 0.15))
+	s.Equal(0.9, AutoregulationScale(30, 0.10))
+}
+
+func (s *AutoregulationSuite) TestValidateBound() {
+	s.NoError(ValidateAutoregulationBound(0))
+	s.NoError(ValidateAutoregulationBound(DefaultAutoregulationBound))
+	s.ErrorIs(ValidateAutoregulationBound(0.01), ErrInvalidAutoregulationBound)
+	s.ErrorIs(ValidateAutoregulationBound(0.5), ErrInvalidAutoregulationBound)
+
+	_, err := NewProgramInstallation(InstallProgramInput{
+		ProgramID: 1, StartDate: "2026-03-02", WeekDayMapping: []int{1}, AutoregulationBound: 15,
+	}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	s.ErrorIs(err, ErrInvalidAutoregulationBound, "a percentage instead of a fraction")
+}
+
+func (s *AutoregulationSuite) TestAutoregulate() {
+	session := ScheduledSession{
+		Date: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), WeekNumber: 2, DayNumber: 2,
+		TrainingType: TrainingTypeStrength, DurationMin: 60, LoadScore: 3,
+	}
+	session.Autoregulate(84.3, 0.15)
+	s.Equal(69, session.DurationMin)
+	s.Equal(3.45, session.LoadScore)
+	s.Equal(&SessionAutoregulation{
+		Date: "2026-03-11", WeekNumber: 2, DayNumber: 2, ReadinessScore: 84.3, Scale: 1.15,
+		BaseLoadScore: 3, BaseDurationMin: 60, LoadScore: 3.45, DurationMin: 69,
+	}, session.Autoregulation)
+
+	short := ScheduledSession{TrainingType: TrainingTypeWalking, DurationMin: 16, LoadScore: 1}
+	short.Autoregulate(10, 0.25)
+	s.Equal(MinDayDurationMin, short.DurationMin, "duration stays within program day limits")
+	s.Equal(0.75, short.LoadScore)
+
+	rest := ScheduledSession{TrainingType: TrainingTypeRest, DurationMin: 30, LoadScore: 1}
+	rest.Autoregulate(90, 0.15)
+	s.Nil(rest.Autoregulation)
+	s.Equal(30, rest.DurationMin)
+}
@@ -117,6 +117,7 @@ type BodyPartIssue struct {
 	Severity    IssueSeverity `json:"severity"`    // Inferred from symptom
 	RawText     string        `json:"rawText"`     // Original note excerpt
 	SessionID   *int64        `json:"sessionId"`   // Optional link to training session
+	Side        BodySide      `json:"side,omitempty"` // Side named for a limb muscle, e.g. "left knee"
 	CreatedAt   time.Time     `json:"createdAt"`
 }
 
@@ -128,6 +129,7 @@ type BodyPartIssueInput struct {
 	Severity  IssueSeverity `json:"severity"`
 	RawText   string        `json:"rawText"`
 	SessionID *int64        `json:"sessionId"`
+	Side      BodySide      `json:"side,omitempty"`
 }
 
 // ResolveSeverity sets the Severity field based on the Symptom.
@@ -150,11 +152,14 @@ func GetSymptomSeverity(symptom string) IssueSeverity {
 }
 
 // GetMuscleGroupsForAlias returns the muscle groups associated with a body alias.
-// Returns nil if the alias is not recognized.
+// A leading side ("left knee") is ignored. Returns nil if the alias is not recognized.
 func GetMuscleGroupsForAlias(alias string) []MuscleGroup {
 	if groups, ok := BodyAliasToMuscleGroup[alias]; ok {
 		return groups
 	}
+	if side, base := SplitSideAlias(alias); side != "" {
+		return BodyAliasToMuscleGroup[base]
+	}
 	return nil
 }
 
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// BODY STATUS AGGREGATION
+// =============================================================================
+//
+// The daily body status combines muscle fatigue (decayed, with body issue
+// modifiers), active body issues (including those recorded from echo joint
+// deltas) and today's HRV, sleep and recovery into one picture: per-joint
+// integrity and a 0-10 systemic load. The solver's prompt and the UI read it;
+// a snapshot is kept per day.
+
+// jointMuscles maps each tracked joint to the muscle groups around it. Joint
+// names match the joint stress keys on catalog movements.
+var jointMuscles = map[string][]MuscleGroup{
+	"shoulder":   {MuscleFrontDelt, MuscleSideDelt, MuscleRearDelt},
+	"elbow":      {MuscleBiceps, MuscleTriceps},
+	"wrist":      {MuscleForearms},
+	"lower_back": {MuscleLowerBack, MuscleCore, MuscleTraps},
+	"hip":        {MuscleGlutes},
+	"knee":       {MuscleQuads, MuscleHamstrings},
+	"ankle":      {MuscleCalves},
+}
+
+// IssueJointPenalty is the integrity lost by the joints around an issue's
+// muscle on the day it is reported; it decays linearly over IssueDecayDays
+// like the fatigue modifier. Healing reports give a little back.
+var IssueJointPenalty = map[IssueSeverity]float64{
+	IssueSeverityHealing:  -0.05,
+	IssueSeverityMinor:    0.10,
+	IssueSeverityModerate: 0.20,
+	IssueSeveritySevere:   0.35,
+}
+
+// TrackedJoints returns the joints covered by joint integrity, sorted.
+func TrackedJoints() []string {
+	joints := make([]string, 0, len(jointMuscles))
+	for j := range jointMuscles {
+		joints = append(joints, j)
+	}
+	sort.Strings(joints)
+	return joints
+}
+
+// JointIntegrityFromMuscles estimates joint integrity (0-1, 1 = intact) as
+// the inverse of the average fatigue of the muscles around each joint.
+// Joints without fatigue data are intact.
+func JointIntegrityFromMuscles(muscles []MuscleFatigueState) map[string]float64 {
+	fatigue := make(map[MuscleGroup]float64, len(muscles))
+	for _, m := range muscles {
+		fatigue[m.Muscle] = m.FatiguePercent
+	}
+
+	integrity := make(map[string]float64, len(jointMuscles))
+	for joint, groups := range jointMuscles {
+		sum, n := 0.0, 0
+		for _, g := range groups {
+			if f, ok := fatigue[g]; ok {
+				sum += f
+				n++
+			}
+		}
+		integrity[joint] = 1.0
+		if n > 0 {
+			integrity[joint] = roundJoint(1.0 - sum/float64(n)/100)
+		}
+	}
+	return integrity
+}
+
+// ApplyIssuesToJoints lowers the integrity of the joints around each issue's
+// muscle by IssueJointPenalty, decayed by the issue's age. Results stay in 0-1.
+// Issues created from echo deltas are skipped; the joint series covers them.
+func ApplyIssuesToJoints(integrity map[string]float64, issues []BodyPartIssue, asOf time.Time) map[string]float64 {
+	result := make(map[string]float64, len(integrity))
+	for j, v := range integrity {
+		result[j] = v
+	}
+	for _, issue := range issues {
+		if issue.RawText == EchoIssueRawText {
+			continue
+		}
+		issueDate, err := time.Parse("2006-01-02", issue.Date)
+		if err != nil {
+			continue
+		}
+		daysSince := int(asOf.Sub(issueDate).Hours() / 24)
+		if daysSince < 0 || daysSince >= IssueDecayDays {
+			continue
+		}
+		penalty := IssueJointPenalty[issue.Severity] * (1.0 - float64(daysSince)/float64(IssueDecayDays))
+		for joint, groups := range jointMuscles {
+			for _, g := range groups {
+				if g == issue.BodyPart {
+					result[joint] = roundJoint(clampFloat(result[joint]-penalty, 0, 1))
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+func roundJoint(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// BodyStatusInput gathers everything the daily body status is derived from.
+type BodyStatusInput struct {
+	Fatigue       *BodyStatus        // Muscle fatigue with decay and issue modifiers applied
+	Issues        []BodyPartIssue    // Active issues, including those from echo joint deltas
+	JointSeries   map[string]float64 // Today's joint integrity series; nil when unavailable
+	TodayLog      *DailyLog          // nil when today isn't logged
+	NeuralBattery *NeuralBattery     // From today's HRV; nil without
+	AsOf          time.Time
+}
+
+// DailyBodyStatus is the aggregated body status for a day.
+type DailyBodyStatus struct {
+	Date         string        `json:"date"`
+	Status       BodyStatus    `json:"status"`
+	Load         *SystemicLoad `json:"load,omitempty"` // Neural and mechanical axes; nil without a log for the day
+	ActiveIssues int           `json:"activeIssues"`
+	HRVAvailable bool          `json:"hrvAvailable"`
+}
+
+// AggregateBodyStatus derives the day's body status. Joint integrity starts
+// from muscle fatigue, is lowered by active issues, and is capped by the joint
+// series, which tracks echo deltas and their recovery. Systemic load (0-10)
+// is the mean of the neural and mechanical loads; without a log for the day
+// the neural side is unknown and the mechanical load stands alone.
+func AggregateBodyStatus(in BodyStatusInput) DailyBodyStatus {
+	status := BodyStatus{AsOfTime: in.AsOf.Format(time.RFC3339)}
+	if in.Fatigue != nil {
+		status = *in.Fatigue
+		status.AsOfTime = in.AsOf.Format(time.RFC3339)
+	}
+	status.JointIntegrity = ApplyIssuesToJoints(JointIntegrityFromMuscles(status.Muscles), in.Issues, in.AsOf)
+	if in.JointSeries != nil {
+		status.JointIntegrity = LowestJointIntegrity(status.JointIntegrity, in.JointSeries)
+	}
+
+	daily := DailyBodyStatus{
+		Date:         in.AsOf.Format("2006-01-02"),
+		ActiveIssues: len(in.Issues),
+		HRVAvailable: in.NeuralBattery != nil,
+	}
+
+	combined := CalculateMechanicalLoad(&status)
+	if in.TodayLog != nil {
+		load := CalculateSystemicLoad(SystemicLoadInput{
+			NeuralBattery: in.NeuralBattery,
+			SleepQuality:  in.TodayLog.SleepQuality,
+			RecoveryScore: in.TodayLog.RecoveryScore,
+			BodyStatus:    &status,
+		})
+		daily.Load = &load
+		combined = (load.NeuralLoadPct + load.MechanicalLoadPct) / 2
+	}
+	status.SystemicLoad = math.Round(combined) / 10
+	daily.Status = status
+	return daily
+}
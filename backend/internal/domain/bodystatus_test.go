@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Joint integrity and the 0-10 systemic load feed the solver's
+// BIO-REPAIR and digestibility rules; tests pin the joint mapping, how issues
+// decay out of it, and which axes the load combines when today isn't logged.
+type BodyStatusSuite struct {
+	suite.Suite
+	asOf    time.Time
+	fatigue *BodyStatus
+}
+
+func TestBodyStatusSuite(t *testing.T) {
+	suite.Run(t, new(BodyStatusSuite))
+}
+
+func (s *BodyStatusSuite) SetupTest() {
+	s.asOf = time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	s.fatigue = &BodyStatus{
+		Muscles: []MuscleFatigueState{
+			{Muscle: MuscleQuads, FatiguePercent: 40},
+			{Muscle: MuscleHamstrings, FatiguePercent: 20},
+		},
+		OverallScore: 30,
+	}
+}
+
+func (s *BodyStatusSuite) TestJointIntegrityFromMuscles() {
+	integrity := JointIntegrityFromMuscles(s.fatigue.Muscles)
+
+	s.Len(integrity, len(TrackedJoints()))
+	s.Equal(0.7, integrity["knee"], "average of quads and hamstrings")
+	s.Equal(1.0, integrity["shoulder"], "no fatigue data is intact")
+}
+
+func (s *BodyStatusSuite) TestIssuesLowerJointsAndDecay() {
+	integrity := map[string]float64{"knee": 0.7, "ankle": 1.0, "shoulder": 1.0}
+	issues := []BodyPartIssue{
+		{BodyPart: MuscleQuads, Severity: IssueSeverityModerate, Date: "2026-03-10"},
+		{BodyPart: MuscleCalves, Severity: IssueSeverityModerate, Date: "2026-03-08"},
+		{BodyPart: MuscleFrontDelt, Severity: IssueSeveritySevere, Date: "2026-03-01"},
+	}
+
+	result := ApplyIssuesToJoints(integrity, issues, s.asOf)
+
+	s.Equal(0.5, result["knee"])
+	s.Equal(0.88, result["ankle"], "two days in, 60% of the penalty is left")
+	s.Equal(1.0, result["shoulder"], "decayed issues no longer count")
+	s.Equal(0.7, integrity["knee"], "input map is left untouched")
+}
+
+func (s *BodyStatusSuite) TestHealingStaysWithinRange() {
+	issues := []BodyPartIssue{{BodyPart: MuscleCalves, Severity: IssueSeverityHealing, Date: "2026-03-10"}}
+
+	result := ApplyIssuesToJoints(map[string]float64{"ankle": 1.0}, issues, s.asOf)
+
+	s.Equal(1.0, result["ankle"])
+}
+
+func (s *BodyStatusSuite) TestAggregateWithoutLogUsesMechanicalLoad() {
+	daily := AggregateBodyStatus(BodyStatusInput{Fatigue: s.fatigue, AsOf: s.asOf})
+
+	s.Equal("2026-03-10", daily.Date)
+	s.Nil(daily.Load, "neural side unknown without a log")
+	s.Equal(3.0, daily.Status.SystemicLoad)
+	s.False(daily.HRVAvailable)
+}
+
+func (s *BodyStatusSuite) TestAggregateCombinesNeuralAndMechanical() {
+	issues := []BodyPartIssue{{BodyPart: MuscleHamstrings, Severity: IssueSeverityMinor, Date: "2026-03-10"}}
+	daily := AggregateBodyStatus(BodyStatusInput{
+		Fatigue:  s.fatigue,
+		Issues:   issues,
+		TodayLog: &DailyLog{SleepQuality: 20},
+		AsOf:     s.asOf,
+	})
+
+	s.Require().NotNil(daily.Load)
+	s.Greater(daily.Load.NeuralLoadPct, daily.Load.MechanicalLoadPct, "poor sleep dominates")
+	expected := math.Round((daily.Load.NeuralLoadPct+daily.Load.MechanicalLoadPct)/2) / 10
+	s.Equal(expected, daily.Status.SystemicLoad)
+	s.Equal(0.6, daily.Status.JointIntegrity["knee"])
+	s.Equal(1, daily.ActiveIssues)
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// CAFFEINE AND SLEEP
+// =============================================================================
+//
+// Caffeine is logged per drink or dose with the time it was taken. A daily
+// log's sleep quality and HRV describe the night before that morning, so
+// intake on a day is paired with the sleep and HRV on the next day's log.
+// Correlations use up to CaffeineAnalysisDays days, as a single week has too
+// few nights to say much; warnings look at the debrief week only.
+
+const (
+	// MaxCaffeineMg caps a single entry, catching typos (a double espresso is ~130mg).
+	MaxCaffeineMg = 1000.0
+	// CaffeineLateHour is the hour of day from which intake counts as late.
+	CaffeineLateHour = 14
+	// CaffeineDailyLimitMg is the commonly cited safe daily intake for adults.
+	CaffeineDailyLimitMg = 400.0
+	// CaffeinePoorSleepScore is the sleep quality below which a night counts as poor.
+	CaffeinePoorSleepScore = 60
+	// CaffeineLatePoorSleepNights is how many late-intake nights followed by poor
+	// sleep in one week raise a warning.
+	CaffeineLatePoorSleepNights = 2
+	// CaffeineAnalysisDays is the length of the correlation window.
+	CaffeineAnalysisDays = 28
+	// CaffeineMinPairs is the number of paired days needed before a correlation is reported.
+	CaffeineMinPairs = 7
+)
+
+// CaffeineSource is what the caffeine came from.
+type CaffeineSource string
+
+const (
+	CaffeineSourceCoffee      CaffeineSource = "coffee"
+	CaffeineSourceEspresso    CaffeineSource = "espresso"
+	CaffeineSourceTea         CaffeineSource = "tea"
+	CaffeineSourceEnergyDrink CaffeineSource = "energy_drink"
+	CaffeineSourceSoda        CaffeineSource = "soda"
+	CaffeineSourcePreWorkout  CaffeineSource = "pre_workout"
+	CaffeineSourceSupplement  CaffeineSource = "supplement" // Pills, gum
+	CaffeineSourceOther       CaffeineSource = "other"
+)
+
+// ValidCaffeineSources contains all valid caffeine source values.
+var ValidCaffeineSources = map[CaffeineSource]bool{
+	CaffeineSourceCoffee:      true,
+	CaffeineSourceEspresso:    true,
+	CaffeineSourceTea:         true,
+	CaffeineSourceEnergyDrink: true,
+	CaffeineSourceSoda:        true,
+	CaffeineSourcePreWorkout:  true,
+	CaffeineSourceSupplement:  true,
+	CaffeineSourceOther:       true,
+}
+
+// CaffeineEntry is one logged caffeine intake.
+type CaffeineEntry struct {
+	ID        int64          `json:"id"`
+	Date      string         `json:"date"` // YYYY-MM-DD
+	Time      string         `json:"time"` // HH:MM, local
+	Source    CaffeineSource `json:"source"`
+	Mg        float64        `json:"mg"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// Validate checks the date, time, source and amount.
+func (e CaffeineEntry) Validate() error {
+	if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if _, ok := caffeineHour(e.Time); !ok {
+		return ErrInvalidCaffeineTime
+	}
+	if !ValidCaffeineSources[e.Source] {
+		return ErrInvalidCaffeineSource
+	}
+	if e.Mg <= 0 || e.Mg > MaxCaffeineMg || math.IsNaN(e.Mg) {
+		return ErrInvalidCaffeineAmount
+	}
+	return nil
+}
+
+// IsLate reports whether the intake was at or after CaffeineLateHour.
+func (e CaffeineEntry) IsLate() bool {
+	hour, ok := caffeineHour(e.Time)
+	return ok && hour >= CaffeineLateHour
+}
+
+// caffeineHour parses an HH:MM time and returns the hour.
+func caffeineHour(hhmm string) (int, bool) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour(), true
+}
+
+// CaffeineDay pairs a day's intake with the following night's sleep.
+type CaffeineDay struct {
+	Date             string   `json:"date"`
+	TotalMg          float64  `json:"totalMg"`
+	LateMg           float64  `json:"lateMg"`                     // Taken at or after CaffeineLateHour
+	LastIntake       string   `json:"lastIntake,omitempty"`       // HH:MM of the last entry
+	NextSleepQuality *int     `json:"nextSleepQuality,omitempty"` // From the next day's log
+	NextHRVMs        *int     `json:"nextHrvMs,omitempty"`
+	NextSleepHours   *float64 `json:"nextSleepHours,omitempty"`
+}
+
+// CaffeineWarning flags a pattern worth acting on.
+type CaffeineWarning struct {
+	Code    string `json:"code"` // "late_intake_poor_sleep", "high_daily_intake"
+	Message string `json:"message"`
+}
+
+// CaffeineSleepAnalysis relates caffeine intake to the following night's
+// sleep quality and HRV. Correlations are Pearson r (-1 to 1), nil when
+// fewer than CaffeineMinPairs days pair intake with a measurement.
+type CaffeineSleepAnalysis struct {
+	WindowStart         string            `json:"windowStart"` // Start of the correlation window (no earlier than the first entry)
+	WindowEnd           string            `json:"windowEnd"`
+	Week                []CaffeineDay     `json:"week"` // The debrief week's days
+	AvgDailyMg          float64           `json:"avgDailyMg"`
+	LateIntakeDays      int               `json:"lateIntakeDays"` // Days in the week with late intake
+	SleepPairs          int               `json:"sleepPairs"`     // Days in the window with a next-night sleep score
+	TotalVsSleep        *float64          `json:"totalVsSleep,omitempty"`
+	LateVsSleep         *float64          `json:"lateVsSleep,omitempty"`
+	HRVPairs            int               `json:"hrvPairs"`
+	TotalVsHRV          *float64          `json:"totalVsHrv,omitempty"`
+	LatePoorSleepNights int               `json:"latePoorSleepNights"` // Late intake followed by poor sleep, this week
+	Warnings            []CaffeineWarning `json:"warnings"`
+}
+
+// AnalyzeCaffeineSleep builds the caffeine analysis for the week from
+// weekStart to weekEnd. entries and logs should cover the correlation window
+// ending at weekEnd plus the morning after it. Returns nil when nothing was
+// logged in the window.
+func AnalyzeCaffeineSleep(entries []CaffeineEntry, logs []DailyLog, weekStart, weekEnd string) *CaffeineSleepAnalysis {
+	end, err := time.Parse("2006-01-02", weekEnd)
+	if err != nil {
+		return nil
+	}
+	windowStart := end.AddDate(0, 0, -(CaffeineAnalysisDays - 1)).Format("2006-01-02")
+
+	logsByDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		logsByDate[l.Date] = l
+	}
+
+	days := make(map[string]*CaffeineDay)
+	firstDate := ""
+	for _, e := range entries {
+		if e.Date < windowStart || e.Date > weekEnd {
+			continue
+		}
+		if firstDate == "" || e.Date < firstDate {
+			firstDate = e.Date
+		}
+		day, ok := days[e.Date]
+		if !ok {
+			day = &CaffeineDay{Date: e.Date}
+			days[e.Date] = day
+		}
+		day.TotalMg += e.Mg
+		if e.IsLate() {
+			day.LateMg += e.Mg
+		}
+		if e.Time > day.LastIntake {
+			day.LastIntake = e.Time
+		}
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	// Days before the first entry are untracked rather than caffeine-free
+	if firstDate > windowStart {
+		windowStart = firstDate
+	}
+
+	analysis := &CaffeineSleepAnalysis{
+		WindowStart: windowStart,
+		WindowEnd:   weekEnd,
+		Week:        []CaffeineDay{},
+		Warnings:    []CaffeineWarning{},
+	}
+
+	// Every day of the window counts, so caffeine-free days pair with their
+	// sleep too; without them a correlation would only compare doses
+	var totals, lates, sleeps, hrvTotals, hrvs []float64
+	weekMg, weekDays := 0.0, 0
+	var highDays []string
+	for d := range CaffeineAnalysisDays {
+		date := end.AddDate(0, 0, d-(CaffeineAnalysisDays-1))
+		dateStr := date.Format("2006-01-02")
+		day := CaffeineDay{Date: dateStr}
+		if logged, ok := days[dateStr]; ok {
+			day = *logged
+		}
+		day.TotalMg = math.Round(day.TotalMg)
+		day.LateMg = math.Round(day.LateMg)
+
+		if next, ok := logsByDate[date.AddDate(0, 0, 1).Format("2006-01-02")]; ok {
+			if next.SleepQuality > 0 {
+				q := int(next.SleepQuality)
+				day.NextSleepQuality = &q
+			}
+			if next.HRVMs != nil && *next.HRVMs > 0 {
+				day.NextHRVMs = next.HRVMs
+			}
+			day.NextSleepHours = next.SleepHours
+		}
+
+		if dateStr >= windowStart {
+			if day.NextSleepQuality != nil {
+				totals = append(totals, day.TotalMg)
+				lates = append(lates, day.LateMg)
+				sleeps = append(sleeps, float64(*day.NextSleepQuality))
+			}
+			if day.NextHRVMs != nil {
+				hrvTotals = append(hrvTotals, day.TotalMg)
+				hrvs = append(hrvs, float64(*day.NextHRVMs))
+			}
+		}
+
+		if dateStr < weekStart {
+			continue
+		}
+		analysis.Week = append(analysis.Week, day)
+		weekMg += day.TotalMg
+		weekDays++
+		if day.LateMg > 0 {
+			analysis.LateIntakeDays++
+			if day.NextSleepQuality != nil && *day.NextSleepQuality < CaffeinePoorSleepScore {
+				analysis.LatePoorSleepNights++
+			}
+		}
+		if day.TotalMg > CaffeineDailyLimitMg {
+			highDays = append(highDays, date.Format("Mon"))
+		}
+	}
+
+	if weekDays > 0 {
+		analysis.AvgDailyMg = math.Round(weekMg / float64(weekDays))
+	}
+	analysis.SleepPairs = len(sleeps)
+	analysis.HRVPairs = len(hrvs)
+	if len(sleeps) >= CaffeineMinPairs {
+		analysis.TotalVsSleep = pearson(totals, sleeps)
+		analysis.LateVsSleep = pearson(lates, sleeps)
+	}
+	if len(hrvs) >= CaffeineMinPairs {
+		analysis.TotalVsHRV = pearson(hrvTotals, hrvs)
+	}
+
+	if analysis.LatePoorSleepNights >= CaffeineLatePoorSleepNights {
+		analysis.Warnings = append(analysis.Warnings, CaffeineWarning{
+			Code: "late_intake_poor_sleep",
+			Message: fmt.Sprintf("Caffeine after %d:00 preceded %d nights of poor sleep (below %d) this week. Try moving your last caffeine earlier.",
+				CaffeineLateHour, analysis.LatePoorSleepNights, CaffeinePoorSleepScore),
+		})
+	}
+	if len(highDays) > 0 {
+		analysis.Warnings = append(analysis.Warnings, CaffeineWarning{
+			Code: "high_daily_intake",
+			Message: fmt.Sprintf("Caffeine went over %.0fmg on %s.",
+				CaffeineDailyLimitMg, strings.Join(highDays, ", ")),
+		})
+	}
+	return analysis
+}
+
+// pearson returns the correlation coefficient of two equal-length series,
+// rounded to two decimals. Returns nil when either series is constant.
+func pearson(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	if len(xs) != len(ys) || len(xs) < 2 {
+		return nil
+	}
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+	r := math.Round(cov/math.Sqrt(varX*varY)*100) / 100
+	return &r
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The caffeine analysis pairs each day with the next morning's
+// log, an off-by-one that is easy to get wrong; tests pin the pairing, the
+// correlation window and when warnings fire.
+type CaffeineSuite struct {
+	suite.Suite
+}
+
+func TestCaffeineSuite(t *testing.T) {
+	suite.Run(t, new(CaffeineSuite))
+}
+
+func (s *CaffeineSuite) TestValidate() {
+	valid := CaffeineEntry{Date: "2026-03-02", Time: "08:30", Source: CaffeineSourceCoffee, Mg: 95}
+	s.NoError(valid.Validate())
+
+	bad := valid
+	bad.Time = "8.30"
+	s.ErrorIs(bad.Validate(), ErrInvalidCaffeineTime)
+	bad = valid
+	bad.Source = "chocolate"
+	s.ErrorIs(bad.Validate(), ErrInvalidCaffeineSource)
+	bad = valid
+	bad.Mg = MaxCaffeineMg + 1
+	s.ErrorIs(bad.Validate(), ErrInvalidCaffeineAmount)
+	bad = valid
+	bad.Date = "02/03/2026"
+	s.ErrorIs(bad.Validate(), ErrInvalidDate)
+}
+
+func (s *CaffeineSuite) TestIsLate() {
+	s.False(CaffeineEntry{Time: "13:59"}.IsLate())
+	s.True(CaffeineEntry{Time: "14:00"}.IsLate())
+}
+
+func (s *CaffeineSuite) TestIntakePairsWithNextMorningsSleep() {
+	entries := []CaffeineEntry{
+		{Date: "2026-03-02", Time: "08:00", Source: CaffeineSourceCoffee, Mg: 95},
+		{Date: "2026-03-02", Time: "16:30", Source: CaffeineSourceEnergyDrink, Mg: 160},
+	}
+	hrv := 48
+	logs := []DailyLog{
+		{Date: "2026-03-02", SleepQuality: 90}, // The night before: not paired
+		{Date: "2026-03-03", SleepQuality: 52, HRVMs: &hrv},
+	}
+
+	analysis := AnalyzeCaffeineSleep(entries, logs, "2026-03-02", "2026-03-08")
+
+	s.Require().NotNil(analysis)
+	s.Require().Len(analysis.Week, 7)
+	monday := analysis.Week[0]
+	s.Equal(255.0, monday.TotalMg)
+	s.Equal(160.0, monday.LateMg)
+	s.Equal("16:30", monday.LastIntake)
+	s.Require().NotNil(monday.NextSleepQuality)
+	s.Equal(52, *monday.NextSleepQuality)
+	s.Equal(&hrv, monday.NextHRVMs)
+	s.Equal(1, analysis.LateIntakeDays)
+	s.Equal(1, analysis.LatePoorSleepNights)
+	s.Empty(analysis.Warnings, "one poor night isn't a pattern")
+	s.Equal("2026-03-02", analysis.WindowStart, "window starts at the first entry")
+	s.Nil(analysis.TotalVsSleep, "too few pairs")
+}
+
+func (s *CaffeineSuite) TestNoEntriesMeansNoAnalysis() {
+	s.Nil(AnalyzeCaffeineSleep(nil, []DailyLog{{Date: "2026-03-03", SleepQuality: 80}}, "2026-03-02", "2026-03-08"))
+}
+
+func (s *CaffeineSuite) TestRepeatedLateIntakeBeforePoorSleepWarns() {
+	var entries []CaffeineEntry
+	var logs []DailyLog
+	start := time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)
+	for d := range 21 {
+		date := start.AddDate(0, 0, d)
+		late := d%2 == 0
+		timeOfDay, quality := "09:00", SleepQuality(82)
+		if late {
+			timeOfDay, quality = "17:00", SleepQuality(48)
+		}
+		entries = append(entries, CaffeineEntry{Date: date.Format("2006-01-02"), Time: timeOfDay, Source: CaffeineSourceCoffee, Mg: 120})
+		logs = append(logs, DailyLog{Date: date.AddDate(0, 0, 1).Format("2006-01-02"), SleepQuality: quality})
+	}
+	entries = append(entries, CaffeineEntry{Date: "2026-03-04", Time: "10:00", Source: CaffeineSourcePreWorkout, Mg: 300})
+
+	analysis := AnalyzeCaffeineSleep(entries, logs, "2026-03-02", "2026-03-08")
+
+	s.Require().NotNil(analysis)
+	s.Equal(21, analysis.SleepPairs)
+	s.Require().NotNil(analysis.LateVsSleep)
+	s.Equal(-1.0, *analysis.LateVsSleep)
+	s.Equal(4, analysis.LatePoorSleepNights)
+	codes := []string{}
+	for _, w := range analysis.Warnings {
+		codes = append(codes, w.Code)
+	}
+	s.Equal([]string{"late_intake_poor_sleep", "high_daily_intake"}, codes)
+	s.Contains(analysis.Warnings[1].Message, "Wed")
+}
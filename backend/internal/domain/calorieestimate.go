@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// SESSION CALORIE ESTIMATION
+// =============================================================================
+//
+// Active calories come from a wearable when one is synced. Without one, they
+// are estimated from the day's actual sessions so TDEE still sees training:
+//   - MET: (MET - 1) × weight × hours, with MET from training_configs
+//     (1 is subtracted so resting burn isn't counted twice with BMR),
+//   - heart rate: when a session has an average HR, the Keytel et al. (2005)
+//     prediction from HR, weight, age and sex, less resting burn.
+// An estimate never replaces a wearable value.
+
+// ActiveCaloriesSource records where a day's active calories came from.
+type ActiveCaloriesSource string
+
+const (
+	ActiveCaloriesWearable  ActiveCaloriesSource = "wearable"  // Synced or entered from a device
+	ActiveCaloriesEstimated ActiveCaloriesSource = "estimated" // Estimated from logged sessions
+)
+
+// CalorieEstimateMethod names how a session's calories were estimated.
+type CalorieEstimateMethod string
+
+const (
+	CalorieEstimateMET       CalorieEstimateMethod = "met"
+	CalorieEstimateHeartRate CalorieEstimateMethod = "heart_rate"
+)
+
+const (
+	// MinSessionHeartRate and MaxSessionHeartRate bound a session's average HR.
+	MinSessionHeartRate = 30
+	MaxSessionHeartRate = 250
+	// MinHeartRateForEstimate is the lowest average HR the Keytel prediction is
+	// used for; it was fitted on exercise HRs and overshoots at low intensity.
+	MinHeartRateForEstimate = 90
+)
+
+// SessionCalorieEstimate is the estimated active burn of one session.
+type SessionCalorieEstimate struct {
+	SessionOrder int
+	Type         TrainingType
+	DurationMin  int
+	AvgHeartRate *int
+	MET          float64
+	Method       CalorieEstimateMethod
+	Kcal         int
+}
+
+// ActiveCalorieEstimate is the estimated active burn of a day's sessions.
+type ActiveCalorieEstimate struct {
+	Date     string
+	WeightKg float64
+	Kcal     int
+	Sessions []SessionCalorieEstimate
+}
+
+// EstimateActiveCalories estimates the active burn of sessions at weightKg.
+// mets gives the MET per training type; types missing from it use the
+// built-in Compendium value. profile supplies age and sex for the HR method
+// and may be nil, in which case every session uses MET.
+func EstimateActiveCalories(date string, sessions []TrainingSession, mets map[TrainingType]float64, weightKg float64, profile *UserProfile, now time.Time) ActiveCalorieEstimate {
+	estimate := ActiveCalorieEstimate{
+		Date:     date,
+		WeightKg: weightKg,
+		Sessions: make([]SessionCalorieEstimate, 0, len(sessions)),
+	}
+
+	var total float64
+	for _, session := range sessions {
+		met, ok := mets[session.Type]
+		if !ok {
+			met = GetTrainingConfig(session.Type).MET
+		}
+		kcal := metActiveCalories(met, weightKg, session.DurationMin)
+		method := CalorieEstimateMET
+		if hrKcal, ok := heartRateActiveCalories(session, weightKg, profile, now); ok {
+			kcal, method = hrKcal, CalorieEstimateHeartRate
+		}
+
+		total += kcal
+		estimate.Sessions = append(estimate.Sessions, SessionCalorieEstimate{
+			SessionOrder: session.SessionOrder,
+			Type:         session.Type,
+			DurationMin:  session.DurationMin,
+			AvgHeartRate: session.AvgHeartRate,
+			MET:          met,
+			Method:       method,
+			Kcal:         RoundInt(kcal),
+		})
+	}
+	estimate.Kcal = RoundInt(total)
+	return estimate
+}
+
+// metActiveCalories is the burn above resting for a MET, weight and duration.
+func metActiveCalories(met, weightKg float64, durationMin int) float64 {
+	return math.Max(met-1, 0) * weightKg * float64(durationMin) / 60
+}
+
+// heartRateActiveCalories applies the Keytel prediction to a session's average
+// HR and subtracts resting burn (1 MET). Returns false when the session has no
+// usable HR or the profile lacks age and sex.
+func heartRateActiveCalories(session TrainingSession, weightKg float64, profile *UserProfile, now time.Time) (float64, bool) {
+	if session.AvgHeartRate == nil || *session.AvgHeartRate < MinHeartRateForEstimate || profile == nil || profile.BirthDate.IsZero() {
+		return 0, false
+	}
+	hr := float64(*session.AvgHeartRate)
+	age := float64(calculateAge(profile.BirthDate, now))
+
+	var kjPerMin float64
+	switch profile.Sex {
+	case SexMale:
+		kjPerMin = -55.0969 + 0.6309*hr + 0.1988*weightKg + 0.2017*age
+	case SexFemale:
+		kjPerMin = -20.4022 + 0.4472*hr - 0.1263*weightKg + 0.074*age
+	default:
+		return 0, false
+	}
+	grossPerMin := kjPerMin / 4.184
+	restingPerMin := weightKg / 60 // 1 MET = 1 kcal/kg/h
+	return math.Max(grossPerMin-restingPerMin, 0) * float64(session.DurationMin), true
+}
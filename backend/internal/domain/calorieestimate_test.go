@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Estimated active calories feed TDEE when no wearable is
+// synced; tests pin the MET arithmetic, when the heart-rate method takes
+// over, and the fallbacks when HR, age or sex is missing.
+type CalorieEstimateSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+	mets    map[TrainingType]float64
+}
+
+func TestCalorieEstimateSuite(t *testing.T) {
+	suite.Run(t, new(CalorieEstimateSuite))
+}
+
+func (s *CalorieEstimateSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	s.profile = &UserProfile{
+		BirthDate: time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), // 30
+		Sex:       SexMale,
+	}
+	s.mets = map[TrainingType]float64{
+		TrainingTypeRun:      9.8,
+		TrainingTypeStrength: 5.0,
+	}
+}
+
+func (s *CalorieEstimateSuite) TestMETEstimate() {
+	sessions := []TrainingSession{
+		{SessionOrder: 1, Type: TrainingTypeRun, DurationMin: 60},
+		{SessionOrder: 2, Type: TrainingTypeStrength, DurationMin: 45},
+	}
+	estimate := EstimateActiveCalories("2026-03-02", sessions, s.mets, 80, s.profile, s.now)
+
+	s.Require().Len(estimate.Sessions, 2)
+	s.Equal(704, estimate.Sessions[0].Kcal) // (9.8 - 1) × 80 × 1h
+	s.Equal(240, estimate.Sessions[1].Kcal) // (5.0 - 1) × 80 × 0.75h
+	s.Equal(944, estimate.Kcal)
+	for _, session := range estimate.Sessions {
+		s.Equal(CalorieEstimateMET, session.Method)
+	}
+}
+
+func (s *CalorieEstimateSuite) TestConfiguredMETOverridesDefault() {
+	sessions := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 60}}
+	estimate := EstimateActiveCalories("2026-03-02", sessions, map[TrainingType]float64{TrainingTypeRun: 7}, 80, nil, s.now)
+	s.Equal(480, estimate.Kcal)
+
+	// Types missing from the config use the built-in MET
+	estimate = EstimateActiveCalories("2026-03-02", sessions, nil, 80, nil, s.now)
+	s.Equal(704, estimate.Kcal)
+}
+
+func (s *CalorieEstimateSuite) TestHeartRateEstimate() {
+	hr := 150
+	sessions := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 60, AvgHeartRate: &hr}}
+	estimate := EstimateActiveCalories("2026-03-02", sessions, s.mets, 80, s.profile, s.now)
+
+	// Keytel male: (-55.0969 + 0.6309×150 + 0.1988×80 + 0.2017×30) / 4.184
+	// = 14.70 kcal/min, less 80/60 resting = 13.36 kcal/min × 60
+	s.Equal(CalorieEstimateHeartRate, estimate.Sessions[0].Method)
+	s.Equal(802, estimate.Kcal)
+
+	female := *s.profile
+	female.Sex = SexFemale
+	estimate = EstimateActiveCalories("2026-03-02", sessions, s.mets, 80, &female, s.now)
+	s.Equal(CalorieEstimateHeartRate, estimate.Sessions[0].Method)
+	s.Less(estimate.Kcal, 802, "same HR burns less for the female equation")
+}
+
+func (s *CalorieEstimateSuite) TestHeartRateFallsBackToMET() {
+	low := MinHeartRateForEstimate - 1
+	hr := 150
+	cases := []struct {
+		name    string
+		hr      *int
+		profile *UserProfile
+	}{
+		{"no heart rate", nil, s.profile},
+		{"heart rate below threshold", &low, s.profile},
+		{"no profile", &hr, nil},
+		{"no birth date", &hr, &UserProfile{Sex: SexMale}},
+		{"no sex", &hr, &UserProfile{BirthDate: s.profile.BirthDate}},
+	}
+	for _, tc := range cases {
+		sessions := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 60, AvgHeartRate: tc.hr}}
+		estimate := EstimateActiveCalories("2026-03-02", sessions, s.mets, 80, tc.profile, s.now)
+		s.Equal(CalorieEstimateMET, estimate.Sessions[0].Method, tc.name)
+		s.Equal(704, estimate.Kcal, tc.name)
+	}
+}
+
+func (s *CalorieEstimateSuite) TestSessionHeartRateValidation() {
+	hr := MaxSessionHeartRate + 1
+	err := ValidateTrainingSessions([]TrainingSession{{SessionOrder: 1, Type: TrainingTypeRun, DurationMin: 30, AvgHeartRate: &hr}})
+	s.ErrorIs(err, ErrInvalidSessionHeartRate)
+
+	hr = MinSessionHeartRate
+	s.NoError(ValidateTrainingSessions([]TrainingSession{{SessionOrder: 1, Type: TrainingTypeRun, DurationMin: 30, AvgHeartRate: &hr}}))
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// CHALLENGES
+// =============================================================================
+//
+// A challenge is a short, time-boxed goal the user sets themselves ("30 days
+// protein ≥ target", "4 weeks no missed sessions"). Progress is evaluated day
+// by day from the daily logs; nothing extra is logged for it. A challenge
+// fails once it has more missed days than it allows, and completes when its
+// last day is met without that happening.
+
+// ChallengeType is what a challenge day is judged on.
+type ChallengeType string
+
+const (
+	ChallengeProteinTarget    ChallengeType = "protein_target"     // Protein eaten at or above the day's target
+	ChallengeNoMissedSessions ChallengeType = "no_missed_sessions" // Every planned session done
+	ChallengeDailyLogging     ChallengeType = "daily_logging"      // A daily log every day
+)
+
+// ValidChallengeTypes lists the challenge types.
+var ValidChallengeTypes = map[ChallengeType]bool{
+	ChallengeProteinTarget:    true,
+	ChallengeNoMissedSessions: true,
+	ChallengeDailyLogging:     true,
+}
+
+// ChallengeStatus is where a challenge stands.
+type ChallengeStatus string
+
+const (
+	ChallengeActive    ChallengeStatus = "active"
+	ChallengeCompleted ChallengeStatus = "completed"
+	ChallengeFailed    ChallengeStatus = "failed"    // More misses than allowed
+	ChallengeAbandoned ChallengeStatus = "abandoned" // Stopped by the user
+)
+
+const (
+	// MinChallengeDays and MaxChallengeDays bound a challenge's length.
+	MinChallengeDays = 7
+	MaxChallengeDays = 90
+	// MaxChallengeNameLength caps a challenge's name.
+	MaxChallengeNameLength = 60
+)
+
+// Challenge is a time-boxed self-challenge.
+type Challenge struct {
+	ID                  int64           `json:"id"`
+	Name                string          `json:"name"`
+	Type                ChallengeType   `json:"type"`
+	StartDate           string          `json:"startDate"` // YYYY-MM-DD, first day of the challenge
+	DurationDays        int             `json:"durationDays"`
+	AllowedMisses       int             `json:"allowedMisses"` // Missed days tolerated before it fails
+	Status              ChallengeStatus `json:"status"`
+	EndedDate           string          `json:"endedDate,omitempty"` // Day it completed, failed or was abandoned
+	NotificationPending bool            `json:"notificationPending"` // Completion or failure not yet acknowledged
+	CreatedAt           time.Time       `json:"createdAt"`
+}
+
+// EndDate returns the challenge's last day (YYYY-MM-DD).
+func (c Challenge) EndDate() string {
+	start, err := time.Parse("2006-01-02", c.StartDate)
+	if err != nil {
+		return c.StartDate
+	}
+	return start.AddDate(0, 0, c.DurationDays-1).Format("2006-01-02")
+}
+
+// Normalize checks a new challenge and names it after its type and length
+// when no name is given.
+func (c *Challenge) Normalize() error {
+	if !ValidChallengeTypes[c.Type] {
+		return ErrInvalidChallengeType
+	}
+	if _, err := time.Parse("2006-01-02", c.StartDate); err != nil {
+		return ErrInvalidDate
+	}
+	if c.DurationDays < MinChallengeDays || c.DurationDays > MaxChallengeDays {
+		return ErrInvalidChallengeDuration
+	}
+	if c.AllowedMisses < 0 || c.AllowedMisses >= c.DurationDays {
+		return ErrInvalidChallengeMisses
+	}
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		c.Name = DefaultChallengeName(c.Type, c.DurationDays)
+	}
+	if utf8.RuneCountInString(c.Name) > MaxChallengeNameLength {
+		return ErrInvalidChallengeName
+	}
+	return nil
+}
+
+// DefaultChallengeName describes a challenge by its type and length, in weeks
+// when the length is whole weeks.
+func DefaultChallengeName(t ChallengeType, days int) string {
+	span := fmt.Sprintf("%d days", days)
+	if days%7 == 0 {
+		span = fmt.Sprintf("%d weeks", days/7)
+	}
+	switch t {
+	case ChallengeProteinTarget:
+		return span + " protein ≥ target"
+	case ChallengeNoMissedSessions:
+		return span + " no missed sessions"
+	default:
+		return span + " logging every day"
+	}
+}
+
+// ChallengeDayResult is how a challenge day went.
+type ChallengeDayResult string
+
+const (
+	ChallengeDayMet     ChallengeDayResult = "met"
+	ChallengeDayMissed  ChallengeDayResult = "missed"
+	ChallengeDayPending ChallengeDayResult = "pending" // Today, not met yet
+)
+
+// ChallengeDay is one evaluated day of a challenge.
+type ChallengeDay struct {
+	Date   string             `json:"date"`
+	Result ChallengeDayResult `json:"result"`
+}
+
+// ChallengeProgress is a challenge evaluated as of a date.
+type ChallengeProgress struct {
+	Challenge
+	DayNumber     int            `json:"dayNumber"` // Days of the challenge reached, 0 before it starts
+	DaysMet       int            `json:"daysMet"`
+	DaysMissed    int            `json:"daysMissed"`
+	DaysRemaining int            `json:"daysRemaining"` // Days after the evaluated one
+	Days          []ChallengeDay `json:"days"`
+}
+
+// ChallengeDayMeets reports whether a day meets the challenge. A day without a
+// log misses every type; a day's sessions count as done when no non-rest
+// session is planned, and short training on days tagged with environment
+// conditions is excused as in training adherence.
+func ChallengeDayMeets(t ChallengeType, log *DailyLog) bool {
+	if log == nil {
+		return false
+	}
+	switch t {
+	case ChallengeProteinTarget:
+		return log.CalculatedTargets.TotalProteinG > 0 && log.ConsumedProteinG >= log.CalculatedTargets.TotalProteinG
+	case ChallengeNoMissedSessions:
+		planned := countNonRestSessions(log.PlannedSessions)
+		return countNonRestSessions(log.ActualSessions) >= planned || len(log.DayEnvironment()) > 0
+	default:
+		return true
+	}
+}
+
+// EvaluateChallenge judges each challenge day up to asOf from the logs, which
+// may span any dates. asOf itself stays pending rather than missed while it
+// isn't met, since the day isn't over. Active challenges take the status the
+// evaluation reaches; ended ones keep theirs and stop at their EndedDate.
+func EvaluateChallenge(c Challenge, logs []DailyLog, asOf string) ChallengeProgress {
+	byDate := make(map[string]*DailyLog, len(logs))
+	for i := range logs {
+		byDate[logs[i].Date] = &logs[i]
+	}
+
+	progress := ChallengeProgress{Challenge: c, Days: []ChallengeDay{}}
+	start, err := time.Parse("2006-01-02", c.StartDate)
+	if err != nil {
+		return progress
+	}
+	end := c.EndDate()
+	last := asOf
+	if end < last {
+		last = end
+	}
+	if c.EndedDate != "" && c.EndedDate < last {
+		last = c.EndedDate
+	}
+
+	failedOn := ""
+	for d := start; d.Format("2006-01-02") <= last; d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		result := ChallengeDayMet
+		if !ChallengeDayMeets(c.Type, byDate[date]) {
+			result = ChallengeDayMissed
+			if date == asOf {
+				result = ChallengeDayPending
+			}
+		}
+		switch result {
+		case ChallengeDayMet:
+			progress.DaysMet++
+		case ChallengeDayMissed:
+			progress.DaysMissed++
+			if progress.DaysMissed == c.AllowedMisses+1 {
+				failedOn = date
+			}
+		}
+		progress.Days = append(progress.Days, ChallengeDay{Date: date, Result: result})
+	}
+	progress.DayNumber = len(progress.Days)
+	progress.DaysRemaining = c.DurationDays - progress.DayNumber
+
+	if c.Status != ChallengeActive {
+		return progress
+	}
+	switch {
+	case failedOn != "":
+		progress.Status = ChallengeFailed
+		progress.EndedDate = failedOn
+	case progress.DayNumber == c.DurationDays && progress.Days[len(progress.Days)-1].Result != ChallengeDayPending:
+		progress.Status = ChallengeCompleted
+		progress.EndedDate = end
+	}
+	return progress
+}
+
+// ChallengeEventTitle is the notification title for a challenge's completion
+// or failure.
+func ChallengeEventTitle(p ChallengeProgress) string {
+	if p.Status == ChallengeCompleted {
+		return "Challenge complete: " + p.Name
+	}
+	return "Challenge ended: " + p.Name
+}
+
+// ChallengeEventText summarizes the days met and missed.
+func ChallengeEventText(p ChallengeProgress) string {
+	if p.Status == ChallengeCompleted {
+		return fmt.Sprintf("You met %d of %d days.", p.DaysMet, p.DurationDays)
+	}
+	return fmt.Sprintf("Day %d of %d: %d missed days, %d allowed.", p.DayNumber, p.DurationDays, p.DaysMissed, p.AllowedMisses)
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Challenge status drives completion notifications; tests pin
+// when a day counts, that today isn't a miss yet, and when a challenge
+// completes or fails.
+type ChallengeSuite struct {
+	suite.Suite
+}
+
+func TestChallengeSuite(t *testing.T) {
+	suite.Run(t, new(ChallengeSuite))
+}
+
+func (s *ChallengeSuite) proteinLog(date string, consumed int) DailyLog {
+	return DailyLog{Date: date, ConsumedProteinG: consumed, CalculatedTargets: DailyTargets{TotalProteinG: 150}}
+}
+
+func (s *ChallengeSuite) TestNormalize() {
+	c := Challenge{Type: ChallengeProteinTarget, StartDate: "2026-03-02", DurationDays: 28}
+	s.Require().NoError(c.Normalize())
+	s.Equal("4 weeks protein ≥ target", c.Name)
+
+	c = Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 30, Name: "  Log it  "}
+	s.Require().NoError(c.Normalize())
+	s.Equal("Log it", c.Name)
+	s.Equal("30 days logging every day", DefaultChallengeName(ChallengeDailyLogging, 30))
+
+	bad := Challenge{Type: "steps", StartDate: "2026-03-02", DurationDays: 30}
+	s.ErrorIs(bad.Normalize(), ErrInvalidChallengeType)
+	bad = Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 6}
+	s.ErrorIs(bad.Normalize(), ErrInvalidChallengeDuration)
+	bad = Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 7, AllowedMisses: 7}
+	s.ErrorIs(bad.Normalize(), ErrInvalidChallengeMisses)
+	bad = Challenge{Type: ChallengeDailyLogging, StartDate: "March 2", DurationDays: 7}
+	s.ErrorIs(bad.Normalize(), ErrInvalidDate)
+}
+
+func (s *ChallengeSuite) TestDayMeets() {
+	s.False(ChallengeDayMeets(ChallengeDailyLogging, nil))
+	s.True(ChallengeDayMeets(ChallengeDailyLogging, &DailyLog{}))
+
+	met := s.proteinLog("2026-03-02", 150)
+	short := s.proteinLog("2026-03-02", 149)
+	s.True(ChallengeDayMeets(ChallengeProteinTarget, &met))
+	s.False(ChallengeDayMeets(ChallengeProteinTarget, &short))
+	s.False(ChallengeDayMeets(ChallengeProteinTarget, &DailyLog{ConsumedProteinG: 100}), "no target to meet")
+
+	strength := TrainingSession{Type: TrainingTypeStrength, DurationMin: 60}
+	skipped := DailyLog{PlannedSessions: []TrainingSession{strength}}
+	s.False(ChallengeDayMeets(ChallengeNoMissedSessions, &skipped))
+	skipped.ActualSessions = []TrainingSession{strength}
+	s.True(ChallengeDayMeets(ChallengeNoMissedSessions, &skipped))
+	rest := DailyLog{PlannedSessions: []TrainingSession{{Type: TrainingTypeRest}}}
+	s.True(ChallengeDayMeets(ChallengeNoMissedSessions, &rest), "rest days have nothing to miss")
+}
+
+func (s *ChallengeSuite) TestProgressTodayStaysOpen() {
+	c := Challenge{Type: ChallengeProteinTarget, StartDate: "2026-03-02", DurationDays: 7, Status: ChallengeActive}
+	logs := []DailyLog{s.proteinLog("2026-03-02", 160), s.proteinLog("2026-03-03", 155)}
+
+	p := EvaluateChallenge(c, logs, "2026-03-04")
+	s.Equal(ChallengeActive, p.Status)
+	s.Equal(3, p.DayNumber)
+	s.Equal(2, p.DaysMet)
+	s.Zero(p.DaysMissed)
+	s.Equal(4, p.DaysRemaining)
+	s.Equal(ChallengeDayPending, p.Days[2].Result)
+
+	s.Zero(EvaluateChallenge(c, logs, "2026-03-01").DayNumber, "not started")
+}
+
+func (s *ChallengeSuite) TestFailsPastAllowedMisses() {
+	c := Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 7, AllowedMisses: 1, Status: ChallengeActive}
+	logs := []DailyLog{{Date: "2026-03-02"}, {Date: "2026-03-04"}}
+
+	s.Equal(ChallengeActive, EvaluateChallenge(c, logs, "2026-03-05").Status, "one miss allowed, today still open")
+
+	p := EvaluateChallenge(c, logs, "2026-03-07")
+	s.Equal(ChallengeFailed, p.Status)
+	s.Equal("2026-03-05", p.EndedDate, "the second miss")
+}
+
+func (s *ChallengeSuite) TestCompletes() {
+	c := Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 7, Status: ChallengeActive}
+	var logs []DailyLog
+	for _, d := range []string{"02", "03", "04", "05", "06", "07"} {
+		logs = append(logs, DailyLog{Date: "2026-03-" + d})
+	}
+
+	s.Equal(ChallengeActive, EvaluateChallenge(c, logs, "2026-03-08").Status, "last day still open")
+
+	logs = append(logs, DailyLog{Date: "2026-03-08"})
+	p := EvaluateChallenge(c, logs, "2026-03-08")
+	s.Equal(ChallengeCompleted, p.Status)
+	s.Equal("2026-03-08", p.EndedDate)
+	s.Equal(7, p.DaysMet)
+	s.Equal(p, EvaluateChallenge(c, logs, "2026-03-20"), "days after the end don't count")
+	s.Equal("Challenge complete: "+c.Name, ChallengeEventTitle(p))
+	s.Equal("You met 7 of 7 days.", ChallengeEventText(p))
+}
+
+func (s *ChallengeSuite) TestEndedChallengeKeepsStatus() {
+	c := Challenge{Type: ChallengeDailyLogging, StartDate: "2026-03-02", DurationDays: 7, Status: ChallengeAbandoned, EndedDate: "2026-03-03"}
+	p := EvaluateChallenge(c, nil, "2026-03-06")
+	s.Equal(ChallengeAbandoned, p.Status)
+	s.Equal(2, p.DayNumber, "stops at the day it ended")
+}
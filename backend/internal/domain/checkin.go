@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// SUBJECTIVE CHECK-IN
+// =============================================================================
+//
+// A daily check-in records how the day feels on three 1-5 scales. It fills in
+// readiness on days without HRV and can escalate an otherwise optimized CNS
+// status, since HRV misses some stress (a bad night's news, a sore back). The
+// monthly rollup relates the check-in to what followed: stress against calorie
+// adherence the same day, soreness against the effort reported in the next
+// day's training. Hunger is optional and feeds the diet fatigue index.
+
+const (
+	// CheckInMin and CheckInMax bound each check-in scale.
+	CheckInMin = 1
+	CheckInMax = 5
+	// CheckInLowMood is the mood at or below which the day counts as low.
+	CheckInLowMood = 2
+	// CheckInHighScore is the stress or soreness at or above which it counts as high.
+	CheckInHighScore = 4
+	// CheckInMinPairs is the number of paired days needed before a correlation is reported.
+	CheckInMinPairs = 7
+)
+
+// CheckIn is the subjective part of a daily log. Mood runs from 1 (awful)
+// to 5 (great); stress, soreness and hunger from 1 (none) to 5 (severe).
+type CheckIn struct {
+	Mood     int  `json:"mood"`
+	Stress   int  `json:"stress"`
+	Soreness int  `json:"soreness"`
+	Hunger   *int `json:"hunger,omitempty"` // Optional
+}
+
+// Validate checks that each scale is between CheckInMin and CheckInMax.
+func (c CheckIn) Validate() error {
+	scales := []int{c.Mood, c.Stress, c.Soreness}
+	if c.Hunger != nil {
+		scales = append(scales, *c.Hunger)
+	}
+	for _, v := range scales {
+		if v < CheckInMin || v > CheckInMax {
+			return ErrInvalidCheckIn
+		}
+	}
+	return nil
+}
+
+// IsStrained reports whether at least two of low mood, high stress and high
+// soreness are present. One bad scale on its own is an ordinary day.
+func (c CheckIn) IsStrained() bool {
+	flags := 0
+	if c.Mood <= CheckInLowMood {
+		flags++
+	}
+	if c.Stress >= CheckInHighScore {
+		flags++
+	}
+	if c.Soreness >= CheckInHighScore {
+		flags++
+	}
+	return flags >= 2
+}
+
+// ApplyCheckInToCNS folds the check-in into an HRV-based CNS result. A
+// strained check-in escalates optimized to strained; it never escalates to
+// depleted, which stays reserved for the objective HRV and RHR criteria.
+func ApplyCheckInToCNS(result *CNSResult, c *CheckIn) {
+	if result == nil || c == nil || !c.IsStrained() {
+		return
+	}
+	result.SubjectiveStrain = true
+	if result.Status == CNSStatusOptimized {
+		result.Status = CNSStatusStrained
+		result.DepletionReason = "Check-in reports low mood, high stress or high soreness"
+	}
+}
+
+// DayReadiness returns the readiness status for a logged day: the CNS status
+// when HRV was logged, otherwise strained for a strained check-in. Returns ""
+// when neither says anything.
+func DayReadiness(log *DailyLog) CNSStatus {
+	if log == nil {
+		return ""
+	}
+	if log.CNSResult != nil {
+		return log.CNSResult.Status
+	}
+	if log.CheckIn != nil && log.CheckIn.IsStrained() {
+		return CNSStatusStrained
+	}
+	return ""
+}
+
+// CheckInRollup summarizes a month of check-ins. Correlations are Pearson r
+// (-1 to 1), nil when fewer than CheckInMinPairs days pair the two measures.
+type CheckInRollup struct {
+	Month         string  `json:"month"` // YYYY-MM
+	DaysCheckedIn int     `json:"daysCheckedIn"`
+	AvgMood       float64 `json:"avgMood"`
+	AvgStress     float64 `json:"avgStress"`
+	AvgSoreness   float64 `json:"avgSoreness"`
+	// StressVsAdherence pairs stress with the same day's calorie accuracy
+	// (100 at target, 0 at 100% off).
+	StressVsAdherence *float64 `json:"stressVsAdherence,omitempty"`
+	StressPairs       int      `json:"stressPairs"`
+	// SorenessVsNextDayRPE pairs soreness with the average RPE of the next
+	// day's actual training.
+	SorenessVsNextDayRPE *float64 `json:"sorenessVsNextDayRpe,omitempty"`
+	SorenessPairs        int      `json:"sorenessPairs"`
+}
+
+// BuildCheckInRollup builds the rollup for month (YYYY-MM). logs should cover
+// the month plus the day after it, with actual sessions loaded.
+func BuildCheckInRollup(month string, logs []DailyLog) (*CheckInRollup, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, ErrInvalidRollupMonth
+	}
+	end := start.AddDate(0, 1, -1).Format("2006-01-02")
+	first := start.Format("2006-01-02")
+
+	logsByDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		logsByDate[l.Date] = l
+	}
+
+	rollup := &CheckInRollup{Month: start.Format("2006-01")}
+	var moodSum, stressSum, sorenessSum float64
+	var stresses, adherence, soreness, nextRPE []float64
+	for _, l := range logs {
+		if l.CheckIn == nil || l.Date < first || l.Date > end {
+			continue
+		}
+		c := l.CheckIn
+		rollup.DaysCheckedIn++
+		moodSum += float64(c.Mood)
+		stressSum += float64(c.Stress)
+		sorenessSum += float64(c.Soreness)
+
+		if score, ok := calorieAccuracy(l); ok {
+			stresses = append(stresses, float64(c.Stress))
+			adherence = append(adherence, score)
+		}
+
+		date, err := time.Parse("2006-01-02", l.Date)
+		if err != nil {
+			continue
+		}
+		if next, ok := logsByDate[date.AddDate(0, 0, 1).Format("2006-01-02")]; ok {
+			if rpe, ok := averageRPE(next.ActualSessions); ok {
+				soreness = append(soreness, float64(c.Soreness))
+				nextRPE = append(nextRPE, rpe)
+			}
+		}
+	}
+
+	if rollup.DaysCheckedIn > 0 {
+		n := float64(rollup.DaysCheckedIn)
+		rollup.AvgMood = math.Round(moodSum/n*10) / 10
+		rollup.AvgStress = math.Round(stressSum/n*10) / 10
+		rollup.AvgSoreness = math.Round(sorenessSum/n*10) / 10
+	}
+	rollup.StressPairs = len(stresses)
+	rollup.SorenessPairs = len(soreness)
+	if len(stresses) >= CheckInMinPairs {
+		rollup.StressVsAdherence = pearson(stresses, adherence)
+	}
+	if len(soreness) >= CheckInMinPairs {
+		rollup.SorenessVsNextDayRPE = pearson(soreness, nextRPE)
+	}
+	return rollup, nil
+}
+
+// calorieAccuracy scores how close consumed calories came to target, from 100
+// on target down to 0 at 100% off. Unlogged days have no score.
+func calorieAccuracy(log DailyLog) (float64, bool) {
+	if ClassifyDayAdherence(log) == AdherenceUnlogged {
+		return 0, false
+	}
+	target := float64(log.CalculatedTargets.TotalCalories)
+	deviation := math.Abs(float64(log.ConsumedCalories)-target) / target
+	return math.Max(0, 100-deviation*100), true
+}
+
+// averageRPE averages the reported RPE of non-rest sessions.
+func averageRPE(sessions []TrainingSession) (float64, bool) {
+	sum, n := 0, 0
+	for _, s := range sessions {
+		if s.Type == TrainingTypeRest || s.PerceivedIntensity == nil {
+			continue
+		}
+		sum += *s.PerceivedIntensity
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return float64(sum) / float64(n), true
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The check-in can change a day's CNS status and the double-day
+// load ceiling, and the rollup pairs soreness with the next day's training;
+// tests pin when a check-in counts as strained, that it never reaches
+// depleted, and the day pairing behind the correlations.
+type CheckInSuite struct {
+	suite.Suite
+}
+
+func TestCheckInSuite(t *testing.T) {
+	suite.Run(t, new(CheckInSuite))
+}
+
+func (s *CheckInSuite) TestValidate() {
+	s.NoError(CheckIn{Mood: 3, Stress: 1, Soreness: 5}.Validate())
+	s.ErrorIs(CheckIn{Mood: 0, Stress: 3, Soreness: 3}.Validate(), ErrInvalidCheckIn)
+	s.ErrorIs(CheckIn{Mood: 3, Stress: 6, Soreness: 3}.Validate(), ErrInvalidCheckIn)
+	s.ErrorIs(CheckIn{Mood: 3, Stress: 3}.Validate(), ErrInvalidCheckIn)
+
+	hunger := 4
+	s.NoError(CheckIn{Mood: 3, Stress: 2, Soreness: 2, Hunger: &hunger}.Validate())
+	hunger = 6
+	s.ErrorIs(CheckIn{Mood: 3, Stress: 2, Soreness: 2, Hunger: &hunger}.Validate(), ErrInvalidCheckIn)
+}
+
+func (s *CheckInSuite) TestStrainNeedsTwoSignals() {
+	s.False(CheckIn{Mood: 2, Stress: 3, Soreness: 3}.IsStrained(), "low mood alone")
+	s.False(CheckIn{Mood: 4, Stress: 5, Soreness: 2}.IsStrained(), "high stress alone")
+	s.True(CheckIn{Mood: 2, Stress: 4, Soreness: 1}.IsStrained())
+	s.True(CheckIn{Mood: 4, Stress: 4, Soreness: 4}.IsStrained())
+}
+
+func (s *CheckInSuite) TestApplyToCNSEscalatesOptimizedOnly() {
+	strained := &CheckIn{Mood: 1, Stress: 5, Soreness: 2}
+
+	optimized := &CNSResult{Status: CNSStatusOptimized}
+	ApplyCheckInToCNS(optimized, strained)
+	s.Equal(CNSStatusStrained, optimized.Status)
+	s.True(optimized.SubjectiveStrain)
+	s.NotEmpty(optimized.DepletionReason)
+
+	hrvStrained := &CNSResult{Status: CNSStatusStrained, DepletionReason: "HRV low"}
+	ApplyCheckInToCNS(hrvStrained, strained)
+	s.Equal(CNSStatusStrained, hrvStrained.Status, "never escalates past strained")
+	s.Equal("HRV low", hrvStrained.DepletionReason)
+	s.True(hrvStrained.SubjectiveStrain)
+
+	calm := &CNSResult{Status: CNSStatusOptimized}
+	ApplyCheckInToCNS(calm, &CheckIn{Mood: 4, Stress: 2, Soreness: 2})
+	s.Equal(CNSStatusOptimized, calm.Status)
+	s.False(calm.SubjectiveStrain)
+}
+
+func (s *CheckInSuite) TestDayReadiness() {
+	strained := &CheckIn{Mood: 2, Stress: 4, Soreness: 3}
+	s.Equal(CNSStatusDepleted, DayReadiness(&DailyLog{CNSResult: &CNSResult{Status: CNSStatusDepleted}, CheckIn: strained}))
+	s.Equal(CNSStatusStrained, DayReadiness(&DailyLog{CheckIn: strained}), "check-in stands in without HRV")
+	s.Equal(CNSStatus(""), DayReadiness(&DailyLog{CheckIn: &CheckIn{Mood: 4, Stress: 2, Soreness: 2}}))
+	s.Equal(CNSStatus(""), DayReadiness(&DailyLog{}))
+}
+
+func (s *CheckInSuite) TestRollupPairsSorenessWithNextDayRPE() {
+	var logs []DailyLog
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for d := range 32 {
+		log := DailyLog{Date: start.AddDate(0, 0, d).Format("2006-01-02")}
+		if d > 0 {
+			// Training follows the previous day's soreness
+			rpe := 4 + logs[d-1].CheckIn.Soreness
+			log.ActualSessions = []TrainingSession{
+				{Type: TrainingTypeStrength, PerceivedIntensity: &rpe},
+				{Type: TrainingTypeRest},
+			}
+		}
+		if d < 31 { // April 1st only supplies March 31st's next day
+			stress := 1 + d%5
+			log.CheckIn = &CheckIn{Mood: 3, Stress: stress, Soreness: 1 + d%4}
+			log.CalculatedTargets = DailyTargets{TotalCalories: 2000}
+			log.ConsumedCalories = 2000 + stress*100 // More stress, further off target
+		}
+		logs = append(logs, log)
+	}
+
+	rollup, err := BuildCheckInRollup("2026-03", logs)
+
+	s.Require().NoError(err)
+	s.Equal("2026-03", rollup.Month)
+	s.Equal(31, rollup.DaysCheckedIn)
+	s.Equal(3.0, rollup.AvgMood)
+	s.Equal(31, rollup.StressPairs)
+	s.Require().NotNil(rollup.StressVsAdherence)
+	s.Equal(-1.0, *rollup.StressVsAdherence)
+	s.Equal(31, rollup.SorenessPairs, "March 31st pairs with April 1st")
+	s.Require().NotNil(rollup.SorenessVsNextDayRPE)
+	s.Equal(1.0, *rollup.SorenessVsNextDayRPE)
+}
+
+func (s *CheckInSuite) TestRollupSkipsCorrelationsWithFewPairs() {
+	logs := []DailyLog{
+		{Date: "2026-03-02", CheckIn: &CheckIn{Mood: 4, Stress: 2, Soreness: 3}},
+		{Date: "2026-03-03", CheckIn: &CheckIn{Mood: 2, Stress: 4, Soreness: 1}},
+		{Date: "2026-02-28", CheckIn: &CheckIn{Mood: 1, Stress: 5, Soreness: 5}}, // Before the month
+	}
+
+	rollup, err := BuildCheckInRollup("2026-03", logs)
+
+	s.Require().NoError(err)
+	s.Equal(2, rollup.DaysCheckedIn)
+	s.Equal(3.0, rollup.AvgMood)
+	s.Equal(0, rollup.StressPairs, "nothing eaten was logged")
+	s.Nil(rollup.StressVsAdherence)
+	s.Nil(rollup.SorenessVsNextDayRPE)
+
+	_, err = BuildCheckInRollup("March", logs)
+	s.ErrorIs(err, ErrInvalidRollupMonth)
+}
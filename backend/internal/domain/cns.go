@@ -20,6 +20,12 @@ const (
 	MinRestingHRPoints    = 3     // Minimum RHR values for baseline
 )
 
+// CNS reference range sources.
+const (
+	CNSReferencePersonal = "personal" // Percentiles of the user's trailing 90 days
+	CNSReferenceGarmin   = "garmin"   // Garmin's age/fitness-adjusted range
+)
+
 // CNSResult contains the HRV analysis result.
 type CNSResult struct {
 	CurrentHRV             int       `json:"currentHrv"`             // Today's HRV in ms
@@ -30,10 +36,12 @@ type CNSResult struct {
 	RestingHRChangePercent *float64  `json:"restingHrChangePercent"` // RHR change from baseline
 	Status                 CNSStatus `json:"status"`                 // optimized, strained, depleted
 	DepletionReason        string    `json:"depletionReason"`        // Why status is depleted (diagnostic)
-	ReferenceMin           *int      `json:"referenceMin"`           // Garmin reference range minimum (may be nil)
-	ReferenceMax           *int      `json:"referenceMax"`           // Garmin reference range maximum (may be nil)
+	ReferenceMin           *int      `json:"referenceMin"`           // Reference range minimum (may be nil)
+	ReferenceMax           *int      `json:"referenceMax"`           // Reference range maximum (may be nil)
+	ReferenceSource        string    `json:"referenceSource"`        // "personal" or "garmin" ("" without a range)
 	BelowReference         bool      `json:"belowReference"`         // True if 7-day average is below reference minimum
 	ReferenceRatio         *float64  `json:"referenceRatio"`         // 7-day average / reference min (may be nil)
+	SubjectiveStrain       bool      `json:"subjectiveStrain"`       // True if the day's check-in reports strain (see ApplyCheckInToCNS)
 }
 
 // CNSInput contains data for CNS calculation.
@@ -44,6 +52,9 @@ type CNSInput struct {
 	RestingHRHistory []int // Last N days of RHR values (oldest to newest, not including today)
 	ReferenceMin     *int  // Garmin HRV reference range minimum (optional, nil if not available)
 	ReferenceMax     *int  // Garmin HRV reference range maximum (optional, nil if not available)
+	// Personal ranges (optional). PersonalHRV replaces the Garmin range.
+	PersonalHRV       *PersonalReferenceRange
+	PersonalRestingHR *PersonalReferenceRange
 }
 
 // CalculateCNSStatus computes CNS status from HRV data and optional RHR validation.
@@ -53,6 +64,10 @@ type CNSInput struct {
 // 1. HRV drops >20% below baseline
 // 2. Stays low for 3+ consecutive days
 // 3. Resting HR increases 5-10% from baseline
+//
+// With personal ranges, the HRV drop must also land below the user's 25th
+// percentile, an RHR above their 90th percentile confirms it, and the 7-day
+// average is checked against their 10th percentile instead of the Garmin range.
 func CalculateCNSStatus(input CNSInput) *CNSResult {
 	if input.CurrentHRV <= 0 {
 		return nil
@@ -83,6 +98,10 @@ func CalculateCNSStatus(input CNSInput) *CNSResult {
 
 	// Check if HRV drops >20% below baseline
 	isHRVDropped := hrvDeviation < HRVDropThreshold
+	if input.PersonalHRV != nil {
+		// Falling back to normal after an unusually high week isn't fatigue
+		isHRVDropped = isHRVDropped && float64(input.CurrentHRV) < input.PersonalHRV.P25
+	}
 
 	// Check if HRV stays low for 3+ consecutive days
 	consecutiveLowDays := countConsecutiveLowDays(validHRVHistory, input.CurrentHRV, hrvBaseline)
@@ -115,6 +134,10 @@ func CalculateCNSStatus(input CNSInput) *CNSResult {
 
 		// Check if RHR increased 5-10%
 		isRestingHRIncreased = rhrChange >= RestingHRIncreaseMin && rhrChange <= RestingHRIncreaseMax
+		if input.PersonalRestingHR != nil && rhrChange >= RestingHRIncreaseMin &&
+			float64(*input.CurrentRestingHR) > input.PersonalRestingHR.P90 {
+			isRestingHRIncreased = true
+		}
 	}
 
 	// Determine status based on personal baseline deviation
@@ -135,21 +158,34 @@ func CalculateCNSStatus(input CNSInput) *CNSResult {
 		// If RHR doesn't meet criteria, don't flag as depleted
 	}
 
-	// Check reference range (if available)
+	// Check reference range (if available): the personal range when there is
+	// one, otherwise Garmin's
 	belowReference := false
 	var referenceRatio *float64
+	referenceMin, referenceMax := input.ReferenceMin, input.ReferenceMax
+	referenceSource := ""
+	if input.PersonalHRV != nil {
+		low, high := RoundInt(input.PersonalHRV.P10), RoundInt(input.PersonalHRV.P90)
+		referenceMin, referenceMax = &low, &high
+		referenceSource = CNSReferencePersonal
+	} else if referenceMin != nil {
+		referenceSource = CNSReferenceGarmin
+	}
 
-	if input.ReferenceMin != nil && *input.ReferenceMin > 0 {
+	if referenceMin != nil && *referenceMin > 0 {
 		// Compare 7-day average against reference minimum
-		if hrvBaseline < float64(*input.ReferenceMin) {
+		if hrvBaseline < float64(*referenceMin) {
 			belowReference = true
-			ratio := hrvBaseline / float64(*input.ReferenceMin)
+			ratio := hrvBaseline / float64(*referenceMin)
 			referenceRatio = &ratio
 
 			// If currently optimized but below reference, upgrade to strained
 			if status == CNSStatusOptimized {
 				status = CNSStatusStrained
 				depletionReason = "7-day HRV average below reference range minimum"
+				if referenceSource == CNSReferencePersonal {
+					depletionReason = "7-day HRV average below your usual range (10th percentile of 90 days)"
+				}
 			} else {
 				// Already strained/depleted from baseline check, append reference violation
 				depletionReason += " (also below reference range)"
@@ -166,8 +202,9 @@ func CalculateCNSStatus(input CNSInput) *CNSResult {
 		RestingHRChangePercent: restingHRChangePercent,
 		Status:                 status,
 		DepletionReason:        depletionReason,
-		ReferenceMin:           input.ReferenceMin,
-		ReferenceMax:           input.ReferenceMax,
+		ReferenceMin:           referenceMin,
+		ReferenceMax:           referenceMax,
+		ReferenceSource:        referenceSource,
 		BelowReference:         belowReference,
 		ReferenceRatio:         referenceRatio,
 	}
@@ -13,6 +13,7 @@ type DailyLog struct {
 	ID                int64  // Database ID
 	Date              string // YYYY-MM-DD format
 	WeightKg          float64
+	WeighInTime       *string // HH:MM of the weigh-in (nil = unknown), for time-of-day correction
 	BodyFatPercent    *float64
 	RestingHeartRate  *int
 	HRVMs             *int // Heart Rate Variability in milliseconds (rMSSD)
@@ -20,6 +21,7 @@ type DailyLog struct {
 	HRVReferenceMax   *int // Garmin HRV reference range maximum (age/fitness adjusted)
 	SleepQuality      SleepQuality
 	SleepHours        *float64
+	Bedtime           *string           // HH:MM bedtime of the night the sleep fields describe (nil = unknown)
 	PlannedSessions   []TrainingSession // Multiple training sessions per day
 	ActualSessions    []TrainingSession // Actual training logged after completion
 	DayType           DayType
@@ -33,12 +35,16 @@ type DailyLog struct {
 	AdjustmentMultipliers *AdjustmentMultipliers // Adjustment multipliers breakdown (nil if not calculated)
 	CNSResult             *CNSResult             // CNS status from HRV analysis (nil if HRV not provided)
 	TrainingOverrides     []TrainingOverride     // Recommended training adjustments when CNS depleted
-	ActiveCaloriesBurned  *int                   // User-entered active calories from wearable
+	ActiveCaloriesBurned  *int                   // Active calories from a wearable, or estimated from sessions
+	ActiveCaloriesSource  ActiveCaloriesSource   // Where ActiveCaloriesBurned came from (empty when unset)
 	Steps                 *int                   // Daily step count from wearable
 	BMRPrecisionMode      bool                   // True if Katch-McArdle was auto-selected using recent body fat
 	BodyFatUsedDate       *string                // Date of body fat measurement used for precision BMR
 	Notes                 string                 // Daily notes/observations for LLM pattern recognition
 	FastingOverride       *FastingProtocol       // Override for fasting protocol (nil = use profile default)
+	CheckIn               *CheckIn               // Subjective mood/stress/soreness check-in (nil = not checked in)
+	RecoveryActivities    []RecoveryActivity     // Sauna, cold, massage... (not stored on the log; attached where recovery is scored)
+	Environment           []EnvironmentCondition // Conditions for the whole day (heat, humidity, altitude)
 	FastedItemsKcal       int                    // Calories logged during fasting window (for <50kcal exception)
 	ConsumedCalories      int                    // Total consumed calories (from logged meals)
 	ConsumedProteinG      int                    // Total consumed protein in grams
@@ -84,14 +90,17 @@ type MealConsumed struct {
 type DailyLogInput struct {
 	Date             string
 	WeightKg         float64
+	WeighInTime      *string // HH:MM
 	BodyFatPercent   *float64
 	RestingHeartRate *int
 	HRVMs            *int // Heart Rate Variability in milliseconds (rMSSD)
 	SleepQuality     SleepQuality
 	SleepHours       *float64
+	Bedtime          *string // HH:MM
 	PlannedSessions  []TrainingSession
 	DayType          DayType
 	Notes            string
+	Environment      []EnvironmentCondition
 }
 
 // NewDailyLogFromInput creates a DailyLog from the input using the builder.
@@ -104,12 +113,18 @@ func NewDailyLogFromInput(input DailyLogInput, now time.Time) (*DailyLog, error)
 		input.DayType,
 	)
 
+	if input.WeighInTime != nil {
+		builder.WithWeighInTime(*input.WeighInTime)
+	}
 	if input.BodyFatPercent != nil {
 		builder.WithBodyFat(*input.BodyFatPercent)
 	}
 	if input.SleepHours != nil {
 		builder.WithSleepHours(*input.SleepHours)
 	}
+	if input.Bedtime != nil {
+		builder.WithBedtime(*input.Bedtime)
+	}
 	if input.RestingHeartRate != nil {
 		builder.WithRestingHeartRate(*input.RestingHeartRate)
 	}
@@ -119,6 +134,9 @@ func NewDailyLogFromInput(input DailyLogInput, now time.Time) (*DailyLog, error)
 	if input.Notes != "" {
 		builder.WithNotes(input.Notes)
 	}
+	if len(input.Environment) > 0 {
+		builder.WithEnvironment(input.Environment)
+	}
 
 	return builder.Build(now)
 }
@@ -172,6 +190,18 @@ func NewDailyLogBuilder(
 	}
 }
 
+// WithWeighInTime sets the optional weigh-in time (HH:MM).
+func (b *DailyLogBuilder) WithWeighInTime(hhmm string) *DailyLogBuilder {
+	b.log.WeighInTime = &hhmm
+	return b
+}
+
+// WithBedtime sets the optional bedtime (HH:MM) of the night before.
+func (b *DailyLogBuilder) WithBedtime(hhmm string) *DailyLogBuilder {
+	b.log.Bedtime = &hhmm
+	return b
+}
+
 // WithBodyFat sets the optional body fat percentage.
 func (b *DailyLogBuilder) WithBodyFat(percent float64) *DailyLogBuilder {
 	b.log.BodyFatPercent = &percent
@@ -202,6 +232,12 @@ func (b *DailyLogBuilder) WithNotes(notes string) *DailyLogBuilder {
 	return b
 }
 
+// WithEnvironment sets the day's environment conditions.
+func (b *DailyLogBuilder) WithEnvironment(conditions []EnvironmentCondition) *DailyLogBuilder {
+	b.log.Environment = conditions
+	return b
+}
+
 // Build finalizes the DailyLog, applies defaults, and validates.
 // Returns an error if validation fails.
 func (b *DailyLogBuilder) Build(now time.Time) (*DailyLog, error) {
@@ -229,6 +265,20 @@ func (d *DailyLog) Validate() error {
 		return ErrInvalidWeight
 	}
 
+	// Weigh-in time validation (optional, HH:MM)
+	if d.WeighInTime != nil {
+		if err := ValidateWeighInTime(*d.WeighInTime); err != nil {
+			return err
+		}
+	}
+
+	// Bedtime validation (optional, HH:MM)
+	if d.Bedtime != nil {
+		if err := ValidateBedtime(*d.Bedtime); err != nil {
+			return err
+		}
+	}
+
 	// Body fat validation (optional)
 	if d.BodyFatPercent != nil {
 		if *d.BodyFatPercent < 3 || *d.BodyFatPercent > 70 {
@@ -275,14 +325,18 @@ func (d *DailyLog) Validate() error {
 		return ErrInvalidDayType
 	}
 
+	if err := ValidateEnvironment(d.Environment); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // LoadScore returns the RPE-weighted training load for this day.
 // Uses actual sessions if present, otherwise planned sessions.
-// Formula per session: loadScore × (durationMin/60) × (RPE/3)
+// Formula per session: loadScore × (durationMin/60) × (RPE/3) × environment factor
 func (d *DailyLog) LoadScore() float64 {
-	return DailyLoad(d.ActualSessions, d.PlannedSessions)
+	return DailyLoad(WithDayEnvironment(d.ActualSessions, d.Environment), WithDayEnvironment(d.PlannedSessions, d.Environment))
 }
 
 // EffectiveSessions returns actual sessions if any are logged, otherwise planned.
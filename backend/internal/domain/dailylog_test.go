@@ -229,6 +229,29 @@ func (s *DailyLogSuite) TestSleepHoursValidation() {
 	})
 }
 
+func (s *DailyLogSuite) TestBedtimeAndSessionStartValidation() {
+	s.Run("accepts HH:MM bedtime and session start", func() {
+		log := s.validLog()
+		bedtime := "23:45"
+		log.Bedtime = &bedtime
+		log.ActualSessions = []TrainingSession{{SessionOrder: 1, Type: TrainingTypeRun, DurationMin: 30, StartTime: "07:15"}}
+		s.Require().NoError(log.Validate())
+	})
+
+	s.Run("rejects malformed bedtime", func() {
+		log := s.validLog()
+		bedtime := "11pm"
+		log.Bedtime = &bedtime
+		s.Require().ErrorIs(log.Validate(), ErrInvalidBedtime)
+	})
+
+	s.Run("rejects malformed session start", func() {
+		log := s.validLog()
+		log.ActualSessions = []TrainingSession{{SessionOrder: 1, Type: TrainingTypeRun, DurationMin: 30, StartTime: "7am"}}
+		s.Require().ErrorIs(log.Validate(), ErrInvalidSessionStartTime)
+	})
+}
+
 func (s *DailyLogSuite) TestTrainingTypeValidation() {
 	s.Run("accepts all valid training types", func() {
 		validTypes := []TrainingType{
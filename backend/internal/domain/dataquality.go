@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DataMetric identifies an input that contributes to daily data completeness.
+type DataMetric string
+
+const (
+	DataMetricWeight   DataMetric = "weight"
+	DataMetricSleep    DataMetric = "sleep"
+	DataMetricHRV      DataMetric = "hrv"
+	DataMetricMeals    DataMetric = "meals"
+	DataMetricTraining DataMetric = "training"
+)
+
+// DataMetricWeights sets each metric's contribution to the completeness score.
+// Weight and meals dominate because adaptive TDEE depends on both.
+var DataMetricWeights = map[DataMetric]float64{
+	DataMetricWeight:   0.25,
+	DataMetricMeals:    0.25,
+	DataMetricSleep:    0.20,
+	DataMetricHRV:      0.15,
+	DataMetricTraining: 0.15,
+}
+
+// dataMetricOrder is the stable iteration order for metrics.
+var dataMetricOrder = []DataMetric{
+	DataMetricWeight, DataMetricMeals, DataMetricSleep, DataMetricHRV, DataMetricTraining,
+}
+
+// DataMetricNudges are the prompts shown for the most frequently missing metric.
+var DataMetricNudges = map[DataMetric]string{
+	DataMetricWeight:   "Weigh in each morning — adaptive TDEE needs consistent weight data.",
+	DataMetricMeals:    "Log meals daily so intake can be compared against targets.",
+	DataMetricSleep:    "Record sleep hours to sharpen recovery scoring.",
+	DataMetricHRV:      "Sync HRV from your wearable to enable CNS readiness checks.",
+	DataMetricTraining: "Log actual training after sessions so compliance and load stay accurate.",
+}
+
+// DataQualityNudgeThreshold is the missing fraction above which a metric gets a nudge.
+const DataQualityNudgeThreshold = 0.3
+
+// DayDataPresence records which inputs were captured for a day.
+type DayDataPresence struct {
+	Date        string
+	HasLog      bool
+	HasWeight   bool
+	HasSleep    bool
+	HasHRV      bool
+	HasMeals    bool
+	HasTraining bool
+}
+
+// DayCompleteness is the scored completeness for a single day.
+type DayCompleteness struct {
+	Date    string       `json:"date"`
+	Score   float64      `json:"score"` // 0-100
+	Missing []DataMetric `json:"missing"`
+}
+
+// MetricMissingRate summarizes how often a metric was missing over the range.
+type MetricMissingRate struct {
+	Metric         DataMetric `json:"metric"`
+	MissingDays    int        `json:"missingDays"`
+	MissingPercent float64    `json:"missingPercent"`
+}
+
+// DataQualityReport rolls up completeness over a date range.
+type DataQualityReport struct {
+	StartDate    string              `json:"startDate"`
+	EndDate      string              `json:"endDate"`
+	DaysInRange  int                 `json:"daysInRange"`
+	DaysLogged   int                 `json:"daysLogged"`
+	AverageScore float64             `json:"averageScore"`
+	Days         []DayCompleteness   `json:"days"`
+	MissingRates []MetricMissingRate `json:"missingRates"` // Most often missing first
+	Nudges       []string            `json:"nudges"`
+}
+
+// HasTrainingData reports whether a day's training is accounted for:
+// actual sessions were logged, or nothing but rest was planned.
+func HasTrainingData(planned, actual []TrainingSession) bool {
+	if len(actual) > 0 {
+		return true
+	}
+	for _, s := range planned {
+		if s.Type != TrainingTypeRest {
+			return false
+		}
+	}
+	return true
+}
+
+// present reports whether a metric was captured for the day.
+func (p DayDataPresence) present(m DataMetric) bool {
+	switch m {
+	case DataMetricWeight:
+		return p.HasWeight
+	case DataMetricSleep:
+		return p.HasSleep
+	case DataMetricHRV:
+		return p.HasHRV
+	case DataMetricMeals:
+		return p.HasMeals
+	case DataMetricTraining:
+		return p.HasTraining
+	}
+	return false
+}
+
+// ScoreDayCompleteness computes a 0-100 weighted completeness score for one day.
+// Days without a log score zero with every metric missing.
+func ScoreDayCompleteness(p DayDataPresence) DayCompleteness {
+	result := DayCompleteness{Date: p.Date, Missing: make([]DataMetric, 0)}
+	var score float64
+	for _, m := range dataMetricOrder {
+		if p.HasLog && p.present(m) {
+			score += DataMetricWeights[m]
+		} else {
+			result.Missing = append(result.Missing, m)
+		}
+	}
+	result.Score = math.Round(score * 100)
+	return result
+}
+
+// BuildDataQualityReport scores every day from start to end (inclusive). Days absent
+// from presence are treated as unlogged.
+func BuildDataQualityReport(presence []DayDataPresence, start, end time.Time) DataQualityReport {
+	byDate := make(map[string]DayDataPresence, len(presence))
+	for _, p := range presence {
+		byDate[p.Date] = p
+	}
+
+	report := DataQualityReport{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Days:      make([]DayCompleteness, 0),
+		Nudges:    make([]string, 0),
+	}
+
+	missingCounts := make(map[DataMetric]int)
+	var totalScore float64
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		p, ok := byDate[date]
+		if !ok {
+			p = DayDataPresence{Date: date}
+		}
+		if p.HasLog {
+			report.DaysLogged++
+		}
+
+		day := ScoreDayCompleteness(p)
+		for _, m := range day.Missing {
+			missingCounts[m]++
+		}
+		totalScore += day.Score
+		report.Days = append(report.Days, day)
+	}
+
+	report.DaysInRange = len(report.Days)
+	if report.DaysInRange == 0 {
+		return report
+	}
+	report.AverageScore = math.Round(totalScore/float64(report.DaysInRange)*10) / 10
+
+	for _, m := range dataMetricOrder {
+		report.MissingRates = append(report.MissingRates, MetricMissingRate{
+			Metric:         m,
+			MissingDays:    missingCounts[m],
+			MissingPercent: math.Round(float64(missingCounts[m])/float64(report.DaysInRange)*1000) / 10,
+		})
+	}
+	sort.SliceStable(report.MissingRates, func(i, j int) bool {
+		return report.MissingRates[i].MissingDays > report.MissingRates[j].MissingDays
+	})
+
+	for _, rate := range report.MissingRates {
+		if rate.MissingPercent/100 > DataQualityNudgeThreshold {
+			report.Nudges = append(report.Nudges, DataMetricNudges[rate.Metric])
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Completeness weighting drives nudges and the confidence users
+// place in adaptive TDEE; tests lock the scoring weights and missing-rate ranking.
+
+type DataQualitySuite struct {
+	suite.Suite
+}
+
+func TestDataQualitySuite(t *testing.T) {
+	suite.Run(t, new(DataQualitySuite))
+}
+
+func (s *DataQualitySuite) TestScoreDayCompleteness() {
+	s.Run("all metrics present scores 100", func() {
+		day := ScoreDayCompleteness(DayDataPresence{
+			Date: "2026-01-01", HasLog: true,
+			HasWeight: true, HasSleep: true, HasHRV: true, HasMeals: true, HasTraining: true,
+		})
+		s.Equal(100.0, day.Score)
+		s.Empty(day.Missing)
+	})
+
+	s.Run("missing HRV and training scores 70", func() {
+		day := ScoreDayCompleteness(DayDataPresence{
+			Date: "2026-01-01", HasLog: true,
+			HasWeight: true, HasSleep: true, HasMeals: true,
+		})
+		s.Equal(70.0, day.Score)
+		s.ElementsMatch([]DataMetric{DataMetricHRV, DataMetricTraining}, day.Missing)
+	})
+
+	s.Run("unlogged day scores zero", func() {
+		day := ScoreDayCompleteness(DayDataPresence{Date: "2026-01-01", HasWeight: true})
+		s.Equal(0.0, day.Score)
+		s.Len(day.Missing, 5)
+	})
+}
+
+func (s *DataQualitySuite) TestHasTrainingData() {
+	s.Run("actual sessions count as complete", func() {
+		s.True(HasTrainingData(nil, []TrainingSession{{Type: TrainingTypeStrength}}))
+	})
+
+	s.Run("rest-only plan needs no actuals", func() {
+		s.True(HasTrainingData([]TrainingSession{{Type: TrainingTypeRest}}, nil))
+	})
+
+	s.Run("planned training without actuals is missing", func() {
+		s.False(HasTrainingData([]TrainingSession{{Type: TrainingTypeStrength}}, nil))
+	})
+}
+
+func (s *DataQualitySuite) TestBuildDataQualityReport() {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 3)
+	presence := []DayDataPresence{
+		{Date: "2026-01-01", HasLog: true, HasWeight: true, HasSleep: true, HasMeals: true, HasTraining: true},
+		{Date: "2026-01-02", HasLog: true, HasWeight: true, HasMeals: true, HasTraining: true},
+	}
+
+	report := BuildDataQualityReport(presence, start, end)
+
+	s.Equal(4, report.DaysInRange)
+	s.Equal(2, report.DaysLogged)
+	s.Require().Len(report.Days, 4)
+	s.Equal(85.0, report.Days[0].Score)
+	s.Equal(0.0, report.Days[3].Score)
+	s.Equal(37.5, report.AverageScore) // (85 + 65 + 0 + 0) / 4
+
+	s.Run("missing rates sorted most missing first", func() {
+		s.Require().Len(report.MissingRates, 5)
+		s.Equal(DataMetricHRV, report.MissingRates[0].Metric)
+		s.Equal(4, report.MissingRates[0].MissingDays)
+		s.Equal(100.0, report.MissingRates[0].MissingPercent)
+		s.Equal(DataMetricSleep, report.MissingRates[1].Metric)
+	})
+
+	s.Run("nudges emitted for metrics above threshold", func() {
+		// Every metric is missing on at least half the days
+		s.Len(report.Nudges, 5)
+		s.Equal(DataMetricNudges[DataMetricHRV], report.Nudges[0])
+	})
+}
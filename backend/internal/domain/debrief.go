@@ -15,24 +15,35 @@ import (
 
 // WeeklyDebrief represents a complete weekly summary.
 type WeeklyDebrief struct {
-	WeekStartDate   string                   // Monday YYYY-MM-DD
-	WeekEndDate     string                   // Sunday YYYY-MM-DD
-	VitalityScore   VitalityScore            // Module A: composite weekly health score
-	Narrative       DebriefNarrative         // Module B: LLM or template-generated text
-	Recommendations []TacticalRecommendation // Module C: 3 actionable bullet points
-	DailyBreakdown  []DebriefDayPoint        // Per-day data for the weekly breakdown
-	GeneratedAt     string                   // ISO8601 timestamp
+	WeekStartDate    string                   // Monday YYYY-MM-DD
+	WeekEndDate      string                   // Sunday YYYY-MM-DD
+	VitalityScore    VitalityScore            // Module A: composite weekly health score
+	Narrative        DebriefNarrative         // Module B: LLM or template-generated text
+	Recommendations  []TacticalRecommendation // Module C: 3 actionable bullet points
+	DailyBreakdown   []DebriefDayPoint        // Per-day data for the weekly breakdown
+	AutoClosedDrafts int                      // Draft sessions finalized with default RPE (data quality)
+	Caffeine         *CaffeineSleepAnalysis   // Caffeine vs sleep (nil when no caffeine is logged)
+	EnvironmentNotes []EnvironmentNote        // Tagged days whose training fell short of the plan
+	Substitutions    []SubstitutionDecision   // Fatigue substitutions proposed in the runner and the user's call
+	MacroCompliance  MacroComplianceSummary   // Days each macro stayed inside its band
+	Tolerances       AdherenceTolerances      // Bands the week was scored with
+	Challenges       []ChallengeProgress      // Self-challenges running during the week, as they stood at its end
+	// Metabolic adaptation detected during the week (nil when none)
+	MetabolicAdaptation *MetabolicAdaptation
+	GeneratedAt         string // ISO8601 timestamp
 }
 
 // VitalityScore is the composite weekly health score (Module A).
 // Components are weighted to create a 0-100 overall score.
 type VitalityScore struct {
 	Overall           float64                // 0-100 composite score
+	ScoringProfile    VitalityProfileKind    // Phase the score was judged against
 	MealAdherence     float64                // Percentage of meals logged within targets (0-100)
 	TrainingAdherence float64                // Percentage of planned sessions completed (0-100)
 	WeightDelta       float64                // kg change from week start to end
-	TrendWeight       float64                // EMA-filtered trend weight at week end
+	TrendWeight       float64                // Smoothed trend weight at week end
 	MetabolicFlux     MetabolicFluxIndicator // TDEE up/down/stable
+	HabitAdherence    *float64               // Habit checklist completion 0-100 (nil when no habit counts)
 }
 
 // MetabolicFluxIndicator summarizes TDEE changes for the week.
@@ -61,24 +72,25 @@ type TacticalRecommendation struct {
 
 // DebriefDayPoint contains per-day data for the weekly breakdown.
 type DebriefDayPoint struct {
-	Date             string     // YYYY-MM-DD
-	DayName          string     // "Monday", "Tuesday", etc.
-	DayType          DayType    // performance, fatburner, metabolize
-	TargetCalories   int        // Calculated target
-	ConsumedCalories int        // Actual consumed
-	CalorieDelta     int        // consumed - target (positive = surplus)
-	TargetProteinG   int        // Target protein in grams
-	ConsumedProteinG int        // Actual protein consumed
-	ProteinPercent   float64    // Percentage of target achieved
-	PlannedSessions  int        // Number of planned training sessions
-	ActualSessions   int        // Number of completed training sessions
-	TrainingLoad     float64    // Daily training load score
-	AvgRPE           *float64   // Average RPE if sessions have it
-	HRVMs            *int       // Heart Rate Variability
-	CNSStatus        *CNSStatus // CNS status (nil if no HRV data)
-	SleepQuality     int        // 1-100 scale
-	SleepHours       *float64   // Hours of sleep
-	Notes            string     // User notes for the day
+	Date             string                 // YYYY-MM-DD
+	DayName          string                 // "Monday", "Tuesday", etc.
+	DayType          DayType                // performance, fatburner, metabolize
+	TargetCalories   int                    // Calculated target
+	ConsumedCalories int                    // Actual consumed
+	CalorieDelta     int                    // consumed - target (positive = surplus)
+	TargetProteinG   int                    // Target protein in grams
+	ConsumedProteinG int                    // Actual protein consumed
+	ProteinPercent   float64                // Percentage of target achieved
+	PlannedSessions  int                    // Number of planned training sessions
+	ActualSessions   int                    // Number of completed training sessions
+	TrainingLoad     float64                // Daily training load score
+	AvgRPE           *float64               // Average RPE if sessions have it
+	HRVMs            *int                   // Heart Rate Variability
+	CNSStatus        *CNSStatus             // CNS status (nil if no HRV data)
+	SleepQuality     int                    // 1-100 scale
+	SleepHours       *float64               // Hours of sleep
+	Notes            string                 // User notes for the day
+	Environment      []EnvironmentCondition // Conditions of the day and its sessions
 }
 
 // DebriefInput contains the data needed to generate a weekly debrief.
@@ -90,9 +102,12 @@ type DebriefInput struct {
 	DailyLogs     []DailyLog
 	WeightTrend   *WeightTrend
 	FluxHistory   []FluxChartPoint
+	Tolerances    AdherenceTolerances     // Zero fields use the defaults
+	References    PersonalReferenceRanges // Personal ranges replace generic thresholds
 }
 
-// VitalityScore component weights (total = 100).
+// VitalityScore component weights (total = 100) for the maintenance profile.
+// Cut and bulk profiles override these; see vitality_profile.go.
 const (
 	VitalityMealAdherenceWeight     = 35.0 // Meal tracking is primary goal
 	VitalityTrainingAdherenceWeight = 30.0 // Training consistency
@@ -100,29 +115,38 @@ const (
 	VitalityTrendWeight             = 15.0 // Weight moving in right direction
 )
 
-// CalculateVitalityScore computes the weekly vitality score from daily logs.
-func CalculateVitalityScore(logs []DailyLog, fluxHistory []FluxChartPoint, profile *UserProfile) VitalityScore {
+// CalculateVitalityScore computes the weekly vitality score from daily logs,
+// weighting components according to the selected scoring profile.
+func CalculateVitalityScore(logs []DailyLog, fluxHistory []FluxChartPoint, scoring VitalityScoringProfile) VitalityScore {
 	if len(logs) == 0 {
 		return VitalityScore{}
 	}
 
-	// Calculate meal adherence (% of calories within ±10% of target)
-	mealAdherence := calculateMealAdherence(logs)
+	// Calculate meal adherence (% of calories within tolerance of target)
+	mealAdherence := calculateMealAdherence(logs, scoring.Tolerances)
 
 	// Calculate training adherence (% of planned sessions completed)
 	trainingAdherence := calculateTrainingAdherence(logs)
 
-	// Calculate recovery component (average sleep quality + CNS status)
+	// Calculate recovery component (average sleep quality + CNS status + recovery activities)
 	recoveryScore := calculateRecoveryComponent(logs)
 
-	// Calculate trend score (weight moving toward goal)
-	trendScore := calculateTrendScore(logs, profile)
+	// Calculate trend score (weight change within the profile's band)
+	trendScore := calculateTrendScore(logs, scoring)
 
 	// Weighted composite
-	overall := mealAdherence*VitalityMealAdherenceWeight/100 +
-		trainingAdherence*VitalityTrainingAdherenceWeight/100 +
-		recoveryScore*VitalityRecoveryWeight/100 +
-		trendScore*VitalityTrendWeight/100
+	overall := mealAdherence*scoring.MealAdherenceWeight/100 +
+		trainingAdherence*scoring.TrainingAdherenceWeight/100 +
+		recoveryScore*scoring.RecoveryWeight/100 +
+		trendScore*scoring.TrendWeight/100
+
+	// Habits take VitalityHabitWeight of the score when any counts
+	var habitAdherence *float64
+	if scoring.HabitAdherence != nil {
+		overall = overall*(100-VitalityHabitWeight)/100 + *scoring.HabitAdherence*VitalityHabitWeight/100
+		rounded := math.Round(*scoring.HabitAdherence*10) / 10
+		habitAdherence = &rounded
+	}
 
 	// Clamp to 0-100
 	overall = math.Max(0, math.Min(100, overall))
@@ -133,24 +157,27 @@ func CalculateVitalityScore(logs []DailyLog, fluxHistory []FluxChartPoint, profi
 		weightDelta = logs[len(logs)-1].WeightKg - logs[0].WeightKg
 	}
 
-	// Calculate EMA trend weight
-	trendWeight := calculateTrendWeight(logs)
+	// Calculate trend weight with the user's smoothing method
+	trendWeight := calculateTrendWeight(logs, scoring.WeightSmoothing)
 
 	// Calculate metabolic flux
 	metabolicFlux := calculateMetabolicFlux(fluxHistory)
 
 	return VitalityScore{
 		Overall:           math.Round(overall*10) / 10,
+		ScoringProfile:    scoring.Kind,
 		MealAdherence:     math.Round(mealAdherence*10) / 10,
 		TrainingAdherence: math.Round(trainingAdherence*10) / 10,
 		WeightDelta:       math.Round(weightDelta*100) / 100,
 		TrendWeight:       math.Round(trendWeight*100) / 100,
 		MetabolicFlux:     metabolicFlux,
+		HabitAdherence:    habitAdherence,
 	}
 }
 
-// calculateMealAdherence returns the percentage of days where calories were within ±10% of target.
-func calculateMealAdherence(logs []DailyLog) float64 {
+// calculateMealAdherence returns the percentage of days where calories were within tolerance of target.
+// Days with a target but nothing logged count against adherence (see ClassifyDayAdherence).
+func calculateMealAdherence(logs []DailyLog, tolerances AdherenceTolerances) float64 {
 	if len(logs) == 0 {
 		return 0
 	}
@@ -165,13 +192,7 @@ func calculateMealAdherence(logs []DailyLog) float64 {
 		}
 		daysWithData++
 
-		target := log.CalculatedTargets.TotalCalories
-		if target == 0 {
-			continue
-		}
-
-		deviation := math.Abs(float64(log.ConsumedCalories-target)) / float64(target)
-		if deviation <= 0.10 { // Within 10%
+		if ClassifyDayAdherenceWithin(log, tolerances.WithDefaults().Calories) == AdherenceOnTarget {
 			adherentDays++
 		}
 	}
@@ -183,24 +204,20 @@ func calculateMealAdherence(logs []DailyLog) float64 {
 }
 
 // calculateTrainingAdherence returns the percentage of planned sessions that were completed.
+// Sessions missed on days tagged with environment conditions don't count
+// against it; the debrief notes them instead (see BuildEnvironmentNotes).
 func calculateTrainingAdherence(logs []DailyLog) float64 {
 	totalPlanned := 0
 	totalCompleted := 0
 
 	for _, log := range logs {
-		// Count non-rest planned sessions
-		for _, session := range log.PlannedSessions {
-			if session.Type != TrainingTypeRest {
-				totalPlanned++
-			}
-		}
-
-		// Count actual sessions
-		for _, session := range log.ActualSessions {
-			if session.Type != TrainingTypeRest {
-				totalCompleted++
-			}
+		planned := countNonRestSessions(log.PlannedSessions)
+		completed := countNonRestSessions(log.ActualSessions)
+		if completed < planned && len(log.DayEnvironment()) > 0 {
+			planned = completed
 		}
+		totalPlanned += planned
+		totalCompleted += completed
 	}
 
 	if totalPlanned == 0 {
@@ -214,6 +231,7 @@ func calculateTrainingAdherence(logs []DailyLog) float64 {
 }
 
 // calculateRecoveryComponent returns a 0-100 score based on sleep and CNS status.
+// Recovery activities move a day's score by up to RecoveryActivityVitalityMax.
 func calculateRecoveryComponent(logs []DailyLog) float64 {
 	if len(logs) == 0 {
 		return 50 // Neutral
@@ -249,6 +267,10 @@ func calculateRecoveryComponent(logs []DailyLog) float64 {
 		}
 
 		if hasData {
+			if len(log.RecoveryActivities) > 0 {
+				dayScore += clampRecoveryPoints(RecoveryActivityPoints(log.RecoveryActivities), RecoveryActivityVitalityMax)
+				dayScore = math.Max(0, math.Min(100, dayScore))
+			}
 			totalScore += dayScore
 			daysWithData++
 		}
@@ -261,55 +283,18 @@ func calculateRecoveryComponent(logs []DailyLog) float64 {
 	return totalScore / float64(daysWithData)
 }
 
-// calculateTrendScore returns a 0-100 score based on weight trend direction vs goal.
-func calculateTrendScore(logs []DailyLog, profile *UserProfile) float64 {
-	if len(logs) < 2 || profile == nil {
+// calculateTrendScore returns a 0-100 score based on weight change vs the profile's target band.
+func calculateTrendScore(logs []DailyLog, scoring VitalityScoringProfile) float64 {
+	if len(logs) < 2 {
 		return 50 // Neutral
 	}
 
-	// Calculate weight change
 	weightChange := logs[len(logs)-1].WeightKg - logs[0].WeightKg
-
-	// Score based on goal alignment
-	switch profile.Goal {
-	case GoalLoseWeight:
-		if weightChange < -0.5 {
-			return 100 // Great progress
-		} else if weightChange < 0 {
-			return 75 // Some progress
-		} else if weightChange < 0.5 {
-			return 50 // Maintenance
-		}
-		return 25 // Going wrong direction
-
-	case GoalGainWeight:
-		if weightChange > 0.5 {
-			return 100 // Great progress
-		} else if weightChange > 0 {
-			return 75 // Some progress
-		} else if weightChange > -0.5 {
-			return 50 // Maintenance
-		}
-		return 25 // Going wrong direction
-
-	case GoalMaintain:
-		absChange := math.Abs(weightChange)
-		if absChange < 0.3 {
-			return 100 // Great stability
-		} else if absChange < 0.5 {
-			return 75 // Good stability
-		} else if absChange < 1.0 {
-			return 50 // Some fluctuation
-		}
-		return 25 // Too much change
-
-	default:
-		return 50
-	}
+	return scoring.ScoreWeightTrend(weightChange)
 }
 
-// calculateTrendWeight returns the EMA-smoothed weight from the logs.
-func calculateTrendWeight(logs []DailyLog) float64 {
+// calculateTrendWeight returns the smoothed weight from the logs.
+func calculateTrendWeight(logs []DailyLog, smoothing WeightSmoothing) float64 {
 	if len(logs) == 0 {
 		return 0
 	}
@@ -319,7 +304,7 @@ func calculateTrendWeight(logs []DailyLog) float64 {
 		weights[i] = log.WeightKg
 	}
 
-	smoothed := CalculateEMAWeight(weights, 0.3)
+	smoothed := smoothing.Smooth(weights)
 	return smoothed[len(smoothed)-1]
 }
 
@@ -364,10 +349,11 @@ func BuildDebriefDayPoints(logs []DailyLog) []DebriefDayPoint {
 			ConsumedProteinG: log.ConsumedProteinG,
 			PlannedSessions:  countNonRestSessions(log.PlannedSessions),
 			ActualSessions:   countNonRestSessions(log.ActualSessions),
-			TrainingLoad:     CalculateDailyLoad(log.ActualSessions),
+			TrainingLoad:     CalculateDailyLoad(WithDayEnvironment(log.ActualSessions, log.Environment)),
 			SleepQuality:     int(log.SleepQuality),
 			SleepHours:       log.SleepHours,
 			Notes:            log.Notes,
+			Environment:      log.DayEnvironment(),
 		}
 
 		// Calculate protein percentage
@@ -472,10 +458,10 @@ func GenerateTacticalRecommendations(input DebriefInput) []TacticalRecommendatio
 	var recommendations []TacticalRecommendation
 
 	// Analyze patterns in the data
-	mealAdherence := calculateMealAdherence(input.DailyLogs)
+	mealAdherence := calculateMealAdherence(input.DailyLogs, input.Tolerances)
 	trainingAdherence := calculateTrainingAdherence(input.DailyLogs)
 	avgSleepQuality := calculateAverageSleepQuality(input.DailyLogs)
-	proteinAdherence := calculateProteinAdherence(input.DailyLogs)
+	proteinCompliance := WeekMacroCompliance(input.DailyLogs, input.Tolerances).ProteinPercent
 	depletedDays := countDepletedDays(input.DailyLogs)
 
 	// Priority 1: Address most critical issue
@@ -527,14 +513,14 @@ func GenerateTacticalRecommendations(input DebriefInput) []TacticalRecommendatio
 	}
 
 	// Priority 2: Secondary issue
-	if proteinAdherence < 80 && len(recommendations) < 3 {
+	if proteinCompliance != nil && *proteinCompliance < 80 && len(recommendations) < 3 {
 		recommendations = append(recommendations, TacticalRecommendation{
 			Priority: 2,
 			Category: "nutrition",
 			Summary:  "Protein intake below target",
 			Rationale: formatRecommendationRationale(
-				"Your average protein intake was %.0f%% of target. Adequate protein is essential for muscle retention.",
-				proteinAdherence,
+				"You reached your protein floor on %.0f%% of logged days. Adequate protein is essential for muscle retention.",
+				*proteinCompliance,
 			),
 			ActionItems: []string{
 				"Include a protein source with every meal",
@@ -544,15 +530,22 @@ func GenerateTacticalRecommendations(input DebriefInput) []TacticalRecommendatio
 		})
 	}
 
-	if avgSleepQuality < 60 && len(recommendations) < 3 {
+	if sleepBelowNormal(avgSleepQuality, input.References.SleepQuality) && len(recommendations) < 3 {
+		rationale := formatRecommendationRationale(
+			"Your average sleep quality was %.0f/100. Poor sleep impairs recovery and increases hunger hormones.",
+			avgSleepQuality,
+		)
+		if ref := input.References.SleepQuality; ref != nil {
+			rationale = formatRecommendationRationale(
+				"Your average sleep quality was %.0f/100, below your usual %.0f. Poor sleep impairs recovery and increases hunger hormones.",
+				avgSleepQuality, ref.P50,
+			)
+		}
 		recommendations = append(recommendations, TacticalRecommendation{
-			Priority: 2,
-			Category: "recovery",
-			Summary:  "Sleep quality affecting recovery",
-			Rationale: formatRecommendationRationale(
-				"Your average sleep quality was %.0f/100. Poor sleep impairs recovery and increases hunger hormones.",
-				avgSleepQuality,
-			),
+			Priority:  2,
+			Category:  "recovery",
+			Summary:   "Sleep quality affecting recovery",
+			Rationale: rationale,
 			ActionItems: []string{
 				"Establish a consistent sleep schedule",
 				"Limit screen time 1 hour before bed",
@@ -618,6 +611,20 @@ func GenerateTacticalRecommendations(input DebriefInput) []TacticalRecommendatio
 
 // Helper functions for recommendations
 
+// DebriefPoorSleepQuality is the generic weekly sleep quality average below
+// which debriefs flag sleep.
+const DebriefPoorSleepQuality = 60
+
+// sleepBelowNormal reports whether a week's sleep quality average should be
+// flagged: below the user's 25th percentile with a personal range, otherwise
+// below DebriefPoorSleepQuality.
+func sleepBelowNormal(avg float64, ref *PersonalReferenceRange) bool {
+	if ref != nil {
+		return avg < ref.P25
+	}
+	return avg < DebriefPoorSleepQuality
+}
+
 func calculateAverageSleepQuality(logs []DailyLog) float64 {
 	if len(logs) == 0 {
 		return 0
@@ -636,23 +643,14 @@ func calculateAverageSleepQuality(logs []DailyLog) float64 {
 	return total / float64(count)
 }
 
-func calculateProteinAdherence(logs []DailyLog) float64 {
-	if len(logs) == 0 {
-		return 0
-	}
-	var totalPercent float64
-	count := 0
+// WeekMacroCompliance judges each logged day of the week against the
+// macro bands (see macrocompliance.go).
+func WeekMacroCompliance(logs []DailyLog, tolerances AdherenceTolerances) MacroComplianceSummary {
+	days := make([]MacroDayCompliance, 0, len(logs))
 	for _, log := range logs {
-		if log.CalculatedTargets.TotalProteinG > 0 && log.ConsumedProteinG > 0 {
-			percent := float64(log.ConsumedProteinG) / float64(log.CalculatedTargets.TotalProteinG) * 100
-			totalPercent += math.Min(percent, 100) // Cap at 100%
-			count++
-		}
-	}
-	if count == 0 {
-		return 0
+		days = append(days, ClassifyMacroDay(log, tolerances))
 	}
-	return totalPercent / float64(count)
+	return SummarizeMacroCompliance(days)
 }
 
 func countDepletedDays(logs []DailyLog) int {
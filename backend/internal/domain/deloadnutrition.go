@@ -0,0 +1,64 @@
+package domain
+
+import "sort"
+
+// =============================================================================
+// DELOAD NUTRITION
+// =============================================================================
+//
+// A program's nutrition days are written for its loading weeks. In a deload
+// week (IsDeload) training drops off, so the scheduled-session generator
+// biases the week's nutrition days away from performance: only the heaviest
+// session keeps a performance day, the week's last other performance day
+// becomes a metabolize refeed ahead of the next block, and the rest become
+// fatburner days. Fatburner and metabolize days are left as written. An
+// installation can override this to keep the program's days as written.
+
+// DeloadPerformanceDays is how many performance days a deload week keeps.
+const DeloadPerformanceDays = 1
+
+// applyDeloadDayTypes biases one deload week's sessions in place and marks
+// the ones it changed.
+func applyDeloadDayTypes(week []ScheduledSession) {
+	performance := make([]int, 0, len(week))
+	for i, session := range week {
+		if session.NutritionDay == DayTypePerformance {
+			performance = append(performance, i)
+		}
+	}
+	if len(performance) <= DeloadPerformanceDays {
+		return
+	}
+
+	// Heaviest sessions keep performance; the earliest wins a tie
+	byLoad := append([]int(nil), performance...)
+	sort.SliceStable(byLoad, func(a, b int) bool {
+		x, y := week[byLoad[a]], week[byLoad[b]]
+		if x.LoadScore != y.LoadScore {
+			return x.LoadScore > y.LoadScore
+		}
+		return x.Date.Before(y.Date)
+	})
+	kept := make(map[int]bool, DeloadPerformanceDays)
+	for _, i := range byLoad[:DeloadPerformanceDays] {
+		kept[i] = true
+	}
+
+	// The last downgraded day (by date) refeeds before loading resumes
+	refeed := -1
+	for _, i := range performance {
+		if !kept[i] && (refeed < 0 || week[i].Date.After(week[refeed].Date)) {
+			refeed = i
+		}
+	}
+	for _, i := range performance {
+		if kept[i] {
+			continue
+		}
+		week[i].NutritionDay = DayTypeFatburner
+		if i == refeed {
+			week[i].NutritionDay = DayTypeMetabolize
+		}
+		week[i].DeloadAdjusted = true
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Deload biasing rewrites the planned day types of a whole
+// week; tests pin which performance day survives, where the refeed lands,
+// that loading weeks are untouched, and that the override keeps the program.
+type DeloadNutritionSuite struct {
+	suite.Suite
+}
+
+func TestDeloadNutritionSuite(t *testing.T) {
+	suite.Run(t, new(DeloadNutritionSuite))
+}
+
+// installation runs a loading week and a deload week of four days from
+// Monday 2 March.
+func (s *DeloadNutritionSuite) installation() *ProgramInstallation {
+	days := []ProgramDay{
+		{DayNumber: 1, Label: "Upper", TrainingType: TrainingTypeStrength, LoadScore: 4, NutritionDay: DayTypePerformance},
+		{DayNumber: 2, Label: "Lower", TrainingType: TrainingTypeStrength, LoadScore: 5, NutritionDay: DayTypePerformance},
+		{DayNumber: 3, Label: "Zone 2", TrainingType: TrainingTypeCycle, LoadScore: 2, NutritionDay: DayTypeFatburner},
+		{DayNumber: 4, Label: "Full Body", TrainingType: TrainingTypeStrength, LoadScore: 4, NutritionDay: DayTypePerformance},
+	}
+	return &ProgramInstallation{
+		Program: &TrainingProgram{
+			DurationWeeks: 2,
+			Weeks: []ProgramWeek{
+				{WeekNumber: 1, VolumeScale: 1, Days: days},
+				{WeekNumber: 2, VolumeScale: 1, IsDeload: true, Days: days},
+			},
+		},
+		StartDate:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		WeekDayMapping: []int{1, 2, 4, 5},
+	}
+}
+
+func (s *DeloadNutritionSuite) dayTypes(sessions []ScheduledSession, week int) []DayType {
+	var types []DayType
+	for _, session := range sessions {
+		if session.WeekNumber == week {
+			types = append(types, session.NutritionDay)
+		}
+	}
+	return types
+}
+
+func (s *DeloadNutritionSuite) TestDeloadWeekKeepsOnePerformanceDay() {
+	sessions := s.installation().GetScheduledSessions()
+
+	s.Equal([]DayType{DayTypePerformance, DayTypePerformance, DayTypeFatburner, DayTypePerformance},
+		s.dayTypes(sessions, 1), "loading weeks are untouched")
+	s.Equal([]DayType{DayTypeFatburner, DayTypePerformance, DayTypeFatburner, DayTypeMetabolize},
+		s.dayTypes(sessions, 2), "the heaviest day keeps performance and the last downgraded day refeeds")
+
+	var adjusted []int
+	for _, session := range sessions {
+		if session.DeloadAdjusted {
+			adjusted = append(adjusted, session.DayNumber)
+		}
+	}
+	s.Equal([]int{1, 4}, adjusted)
+}
+
+func (s *DeloadNutritionSuite) TestTiesKeepTheEarliestDay() {
+	week := []ScheduledSession{
+		{Date: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), LoadScore: 3, NutritionDay: DayTypePerformance},
+		{Date: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), LoadScore: 3, NutritionDay: DayTypePerformance},
+	}
+	applyDeloadDayTypes(week)
+	s.Equal(DayTypeMetabolize, week[0].NutritionDay)
+	s.Equal(DayTypePerformance, week[1].NutritionDay, "a day moved earlier wins the tie by date")
+}
+
+func (s *DeloadNutritionSuite) TestSinglePerformanceDayIsLeftAlone() {
+	week := []ScheduledSession{
+		{LoadScore: 4, NutritionDay: DayTypePerformance},
+		{LoadScore: 2, NutritionDay: DayTypeFatburner},
+	}
+	applyDeloadDayTypes(week)
+	s.Equal(DayTypePerformance, week[0].NutritionDay)
+	s.False(week[0].DeloadAdjusted)
+}
+
+func (s *DeloadNutritionSuite) TestOverrideKeepsProgramDays() {
+	installation := s.installation()
+	installation.KeepDeloadDayTypes = true
+	sessions := installation.GetScheduledSessions()
+
+	s.Equal([]DayType{DayTypePerformance, DayTypePerformance, DayTypeFatburner, DayTypePerformance},
+		s.dayTypes(sessions, 2))
+}
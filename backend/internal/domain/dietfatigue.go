@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// DIET FATIGUE INDEX
+// =============================================================================
+//
+// Long cuts wear people down well before adherence visibly breaks. The index
+// scores accumulated diet fatigue from 0 to 100 using four signals:
+//   - how many weeks the current run of deficit weeks has lasted
+//   - how deep the deficit is relative to TDEE
+//   - recent hunger check-ins
+//   - how far adaptive TDEE has dropped across the run
+// Weeks are judged from intake against the day's estimated TDEE. A
+// maintenance or surplus week ends the run; a week with too few logged days
+// is skipped rather than ending it. Hunger and downregulation need data of
+// their own, and when either is missing the score is scaled over the
+// signals that are present. Crossing a level threshold brings a refeed or
+// diet-break recommendation.
+
+// Diet fatigue windows and thresholds.
+const (
+	DietFatigueLookbackWeeks  = 26  // Longest run of deficit weeks looked at
+	DietFatigueMinDeficitKcal = 150 // Average kcal/day under TDEE for a week to count as a deficit
+	DietFatigueMinLoggedDays  = 3   // Logged days needed to judge a week
+	DietFatigueHungerDays     = 14  // Hunger check-ins averaged over this many days
+	DietFatigueMinHungerDays  = 3   // Check-ins with hunger needed to use the signal
+	DietFatigueMinTDEEPoints  = 14  // Adaptive TDEE points needed to measure downregulation
+	DietFatigueModerateScore  = 40
+	DietFatigueHighScore      = 60
+	DietFatigueCriticalScore  = 80
+	dietFatigueFullWeeks      = 16   // Run length at which the duration signal maxes out
+	dietFatigueFullDeficitPct = 0.25 // Deficit (fraction of TDEE) at which the deficit signal maxes out
+	dietFatigueFullTDEEDrop   = 300  // kcal drop at which the downregulation signal maxes out
+	dietFatigueDurationPoints = 30
+	dietFatigueDeficitPoints  = 25
+	dietFatigueHungerPoints   = 25
+	dietFatigueDownregPoints  = 20
+)
+
+// DietFatigueLevel buckets the diet fatigue score.
+type DietFatigueLevel string
+
+const (
+	DietFatigueLow      DietFatigueLevel = "low"
+	DietFatigueModerate DietFatigueLevel = "moderate"
+	DietFatigueHigh     DietFatigueLevel = "high"
+	DietFatigueCritical DietFatigueLevel = "critical"
+)
+
+// DietFatigueComponents is the points each signal contributed before
+// scaling. Hunger and Downregulation are nil when their data was missing.
+type DietFatigueComponents struct {
+	Duration       float64  `json:"duration"`       // 0-30
+	Deficit        float64  `json:"deficit"`        // 0-25
+	Hunger         *float64 `json:"hunger"`         // 0-25
+	Downregulation *float64 `json:"downregulation"` // 0-20
+}
+
+// DietFatigueIndex is the diet fatigue assessment as of a date.
+type DietFatigueIndex struct {
+	AsOf              string                `json:"asOf"`  // YYYY-MM-DD
+	Score             float64               `json:"score"` // 0-100
+	Level             DietFatigueLevel      `json:"level"`
+	WeeksInDeficit    int                   `json:"weeksInDeficit"`
+	AvgDeficitKcal    int                   `json:"avgDeficitKcal"`
+	AvgDeficitPercent float64               `json:"avgDeficitPercent"`      // % of TDEE
+	AvgHunger         *float64              `json:"avgHunger,omitempty"`    // 1-5, last DietFatigueHungerDays days
+	TDEEDropKcal      *int                  `json:"tdeeDropKcal,omitempty"` // Adaptive TDEE lost across the run (0 if it held or rose)
+	Components        DietFatigueComponents `json:"components"`
+	Recommendations   []PlateauBreaker      `json:"recommendations"`
+}
+
+// CalculateDietFatigue scores diet fatigue as of asOf. logs should cover the
+// DietFatigueLookbackWeeks weeks ending asOf, with consumed calories,
+// estimated TDEE and check-ins; points is the adaptive TDEE history for
+// the same window.
+func CalculateDietFatigue(logs []DailyLog, points []FluxChartPoint, asOf time.Time) DietFatigueIndex {
+	asOfDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	index := DietFatigueIndex{
+		AsOf:            asOfDate.Format("2006-01-02"),
+		Level:           DietFatigueLow,
+		Recommendations: []PlateauBreaker{},
+	}
+
+	logsByDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		logsByDate[l.Date] = l
+	}
+
+	// Walk back week by week from asOf until a judged week isn't a deficit
+	var deficitSum, tdeeSum float64
+	var deficitDays int
+	runStart := asOfDate
+	for week := 0; week < DietFatigueLookbackWeeks; week++ {
+		weekEnd := asOfDate.AddDate(0, 0, -7*week)
+		var weekDeficit, weekTDEE float64
+		var days int
+		for d := 0; d < 7; d++ {
+			l, ok := logsByDate[weekEnd.AddDate(0, 0, -d).Format("2006-01-02")]
+			if !ok || l.ConsumedCalories <= 0 || l.EstimatedTDEE <= 0 {
+				continue
+			}
+			weekDeficit += float64(l.EstimatedTDEE - l.ConsumedCalories)
+			weekTDEE += float64(l.EstimatedTDEE)
+			days++
+		}
+		if days < DietFatigueMinLoggedDays {
+			continue
+		}
+		if weekDeficit/float64(days) < DietFatigueMinDeficitKcal {
+			break
+		}
+		index.WeeksInDeficit++
+		deficitSum += weekDeficit
+		tdeeSum += weekTDEE
+		deficitDays += days
+		runStart = weekEnd.AddDate(0, 0, -6)
+	}
+
+	if index.WeeksInDeficit == 0 {
+		return index
+	}
+
+	index.AvgDeficitKcal = int(math.Round(deficitSum / float64(deficitDays)))
+	deficitPct := deficitSum / tdeeSum
+	index.AvgDeficitPercent = math.Round(deficitPct*1000) / 10
+
+	components := &index.Components
+	components.Duration = dietFatigueDurationPoints * math.Min(float64(index.WeeksInDeficit)/dietFatigueFullWeeks, 1)
+	components.Deficit = dietFatigueDeficitPoints * math.Min(deficitPct/dietFatigueFullDeficitPct, 1)
+	maxPoints := float64(dietFatigueDurationPoints + dietFatigueDeficitPoints)
+	total := components.Duration + components.Deficit
+
+	if avg, ok := recentHunger(logsByDate, asOfDate); ok {
+		avg = math.Round(avg*10) / 10
+		index.AvgHunger = &avg
+		hunger := dietFatigueHungerPoints * (avg - CheckInMin) / (CheckInMax - CheckInMin)
+		components.Hunger = &hunger
+		maxPoints += dietFatigueHungerPoints
+		total += hunger
+	}
+
+	if drop, ok := tdeeDropSince(points, runStart.Format("2006-01-02")); ok {
+		index.TDEEDropKcal = &drop
+		downreg := dietFatigueDownregPoints * math.Min(float64(drop)/dietFatigueFullTDEEDrop, 1)
+		components.Downregulation = &downreg
+		maxPoints += dietFatigueDownregPoints
+		total += downreg
+	}
+
+	index.Score = math.Round(total/maxPoints*1000) / 10
+	index.Level = dietFatigueLevel(index.Score)
+	index.Recommendations = dietFatigueRecommendations(index.Level)
+	return index
+}
+
+// recentHunger averages the hunger check-ins in the DietFatigueHungerDays
+// days ending asOf.
+func recentHunger(logsByDate map[string]DailyLog, asOf time.Time) (float64, bool) {
+	var sum, n int
+	for d := 0; d < DietFatigueHungerDays; d++ {
+		l, ok := logsByDate[asOf.AddDate(0, 0, -d).Format("2006-01-02")]
+		if !ok || l.CheckIn == nil || l.CheckIn.Hunger == nil {
+			continue
+		}
+		sum += *l.CheckIn.Hunger
+		n++
+	}
+	if n < DietFatigueMinHungerDays {
+		return 0, false
+	}
+	return float64(sum) / float64(n), true
+}
+
+// tdeeDropSince returns how far adaptive TDEE fell from the first to the
+// last week of the points on or after start, or false with too few points.
+func tdeeDropSince(points []FluxChartPoint, start string) (int, bool) {
+	var run []FluxChartPoint
+	for _, p := range points {
+		if p.Date >= start {
+			run = append(run, p)
+		}
+	}
+	if len(run) < DietFatigueMinTDEEPoints {
+		return 0, false
+	}
+	_, delta := DetermineTrend(run)
+	if delta >= 0 {
+		return 0, true
+	}
+	return -delta, true
+}
+
+func dietFatigueLevel(score float64) DietFatigueLevel {
+	switch {
+	case score >= DietFatigueCriticalScore:
+		return DietFatigueCritical
+	case score >= DietFatigueHighScore:
+		return DietFatigueHigh
+	case score >= DietFatigueModerateScore:
+		return DietFatigueModerate
+	default:
+		return DietFatigueLow
+	}
+}
+
+func dietFatigueRecommendations(level DietFatigueLevel) []PlateauBreaker {
+	switch level {
+	case DietFatigueCritical:
+		return []PlateauBreaker{
+			{
+				Type:      PlateauBreakerDietBreak,
+				Title:     "Take a diet break now",
+				Action:    "Eat at estimated maintenance for 10-14 days before resuming the deficit.",
+				Rationale: "Time in deficit, hunger and metabolic signals together point to fatigue that usually ends in lost adherence.",
+			},
+			{
+				Type:      PlateauBreakerDeload,
+				Title:     "Pair with a training deload",
+				Action:    "Cut training volume by ~40% during the break.",
+				Rationale: "Recovery is limited after a long cut; lowering volume lets the break restore training quality.",
+			},
+		}
+	case DietFatigueHigh:
+		return []PlateauBreaker{{
+			Type:      PlateauBreakerDietBreak,
+			Title:     "Plan a diet break within two weeks",
+			Action:    "Schedule 7-14 days at estimated maintenance in the next two weeks.",
+			Rationale: "Diet fatigue is building; a planned break now is easier to hold to than an unplanned one later.",
+		}}
+	case DietFatigueModerate:
+		return []PlateauBreaker{{
+			Type:      PlateauBreakerRefeed,
+			Title:     "Add refeed days",
+			Action:    "Set one or two non-consecutive days this week to maintenance calories with extra carbohydrates.",
+			Rationale: "Early signs of diet fatigue; short refeeds ease hunger and training quality without stopping the cut.",
+		}}
+	default:
+		return []PlateauBreaker{}
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The index decides when to recommend refeeds and diet
+// breaks; tests pin how the deficit run is counted, the scaling when
+// hunger or TDEE history is missing, and the level thresholds.
+type DietFatigueSuite struct {
+	suite.Suite
+	asOf time.Time
+}
+
+func TestDietFatigueSuite(t *testing.T) {
+	suite.Run(t, new(DietFatigueSuite))
+}
+
+func (s *DietFatigueSuite) SetupTest() {
+	s.asOf = time.Date(2026, 6, 30, 9, 0, 0, 0, time.UTC)
+}
+
+// cutLogs returns daily logs for the days ending asOf, eating deficit kcal
+// under a 2500 kcal TDEE.
+func (s *DietFatigueSuite) cutLogs(days, deficit int) []DailyLog {
+	logs := make([]DailyLog, 0, days)
+	for d := days - 1; d >= 0; d-- {
+		logs = append(logs, DailyLog{
+			Date:             s.asOf.AddDate(0, 0, -d).Format("2006-01-02"),
+			EstimatedTDEE:    2500,
+			ConsumedCalories: 2500 - deficit,
+		})
+	}
+	return logs
+}
+
+func (s *DietFatigueSuite) TestNoDeficitIsLow() {
+	index := CalculateDietFatigue(s.cutLogs(28, 50), nil, s.asOf)
+	s.Equal("2026-06-30", index.AsOf)
+	s.Equal(0, index.WeeksInDeficit)
+	s.Equal(0.0, index.Score)
+	s.Equal(DietFatigueLow, index.Level)
+	s.Empty(index.Recommendations)
+}
+
+func (s *DietFatigueSuite) TestMaintenanceWeekEndsRun() {
+	logs := s.cutLogs(70, 500)
+	// The fourth week back was at maintenance
+	for i := range logs {
+		if logs[i].Date >= "2026-06-03" && logs[i].Date <= "2026-06-09" {
+			logs[i].ConsumedCalories = logs[i].EstimatedTDEE
+		}
+	}
+
+	index := CalculateDietFatigue(logs, nil, s.asOf)
+	s.Equal(3, index.WeeksInDeficit)
+	s.Equal(500, index.AvgDeficitKcal)
+	s.Equal(20.0, index.AvgDeficitPercent)
+}
+
+func (s *DietFatigueSuite) TestSparseWeekSkipped() {
+	logs := s.cutLogs(28, 500)
+	// Keep only two days of the second week back (June 17-23)
+	var kept []DailyLog
+	for _, l := range logs {
+		if l.Date >= "2026-06-19" && l.Date <= "2026-06-23" {
+			continue
+		}
+		kept = append(kept, l)
+	}
+
+	index := CalculateDietFatigue(kept, nil, s.asOf)
+	s.Equal(3, index.WeeksInDeficit, "sparse week neither counts nor ends the run")
+}
+
+func (s *DietFatigueSuite) TestScoreScalesOverAvailableSignals() {
+	// 8 weeks at a 12.5% deficit: duration 15/30, deficit 12.5/25
+	index := CalculateDietFatigue(s.cutLogs(56, 312), nil, s.asOf)
+	s.Equal(8, index.WeeksInDeficit)
+	s.Nil(index.AvgHunger)
+	s.Nil(index.Components.Hunger)
+	s.Nil(index.TDEEDropKcal)
+	s.InDelta(15.0, index.Components.Duration, 0.01)
+	s.InDelta(12.5, index.Components.Deficit, 0.1)
+	s.InDelta(50.0, index.Score, 0.2)
+	s.Equal(DietFatigueModerate, index.Level)
+	s.Require().Len(index.Recommendations, 1)
+	s.Equal(PlateauBreakerRefeed, index.Recommendations[0].Type)
+}
+
+func (s *DietFatigueSuite) TestHungerAndDownregulationRaiseLevel() {
+	logs := s.cutLogs(112, 500)
+	hunger := 5
+	for i := len(logs) - 5; i < len(logs); i++ {
+		logs[i].CheckIn = &CheckIn{Mood: 3, Stress: 3, Soreness: 2, Hunger: &hunger}
+	}
+	var points []FluxChartPoint
+	for d := 111; d >= 0; d-- {
+		points = append(points, FluxChartPoint{
+			Date:           s.asOf.AddDate(0, 0, -d).Format("2006-01-02"),
+			CalculatedTDEE: 2500 - (111-d)*3, // Loses ~330 kcal over the run
+		})
+	}
+
+	index := CalculateDietFatigue(logs, points, s.asOf)
+	s.Equal(16, index.WeeksInDeficit)
+	s.Require().NotNil(index.AvgHunger)
+	s.Equal(5.0, *index.AvgHunger)
+	s.Require().NotNil(index.TDEEDropKcal)
+	s.Greater(*index.TDEEDropKcal, 300)
+	s.Equal(95.0, index.Score, "20% deficit is 20 of 25 points; the rest max out")
+	s.Equal(DietFatigueCritical, index.Level)
+	s.Require().Len(index.Recommendations, 2)
+	s.Equal(PlateauBreakerDietBreak, index.Recommendations[0].Type)
+}
+
+func (s *DietFatigueSuite) TestTooFewHungerCheckInsIgnored() {
+	logs := s.cutLogs(28, 500)
+	hunger := 5
+	logs[len(logs)-1].CheckIn = &CheckIn{Mood: 3, Stress: 3, Soreness: 2, Hunger: &hunger}
+
+	index := CalculateDietFatigue(logs, nil, s.asOf)
+	s.Nil(index.AvgHunger)
+}
+
+func (s *DietFatigueSuite) TestLevels() {
+	s.Equal(DietFatigueLow, dietFatigueLevel(39.9))
+	s.Equal(DietFatigueModerate, dietFatigueLevel(DietFatigueModerateScore))
+	s.Equal(DietFatigueHigh, dietFatigueLevel(DietFatigueHighScore))
+	s.Equal(DietFatigueCritical, dietFatigueLevel(DietFatigueCriticalScore))
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// DAILY DIGEST
+// =============================================================================
+//
+// An optional end-of-day notification: what was logged, what is left of the
+// day's macro budget, tomorrow's plan and any draft sessions still waiting
+// for enrichment. The digest is plain data; rendering it as a short text
+// message is shared by every delivery channel (ntfy, email).
+
+// DefaultDigestTime is the local time the digest is sent when none is configured.
+const DefaultDigestTime = "21:00"
+
+// DigestMacros is a calorie and macro total.
+type DigestMacros struct {
+	Calories int `json:"calories"`
+	ProteinG int `json:"proteinG"`
+	CarbsG   int `json:"carbsG"`
+	FatG     int `json:"fatG"`
+}
+
+// DigestSession is a training session logged on the digest's day.
+type DigestSession struct {
+	TrainingType TrainingType `json:"trainingType"`
+	DurationMin  int          `json:"durationMin"`
+	IsDraft      bool         `json:"isDraft"`
+}
+
+// DigestTomorrow is the next day's plan.
+type DigestTomorrow struct {
+	Date     string               `json:"date"`
+	DayType  DayType              `json:"dayType"`
+	Sessions []WeekPreviewSession `json:"sessions"`
+}
+
+// DigestDraft is a draft session still waiting for RPE and notes.
+type DigestDraft struct {
+	SessionID    int64        `json:"sessionId"`
+	Date         string       `json:"date"`
+	TrainingType TrainingType `json:"trainingType"`
+	DurationMin  int          `json:"durationMin"`
+}
+
+// DailyDigest is the end-of-day summary.
+type DailyDigest struct {
+	Date          string          `json:"date"`
+	Logged        bool            `json:"logged"` // False when the day has no log
+	Target        DigestMacros    `json:"target"`
+	Consumed      DigestMacros    `json:"consumed"`
+	Remaining     DigestMacros    `json:"remaining"` // Negative when over target
+	Sessions      []DigestSession `json:"sessions"`
+	Tomorrow      *DigestTomorrow `json:"tomorrow,omitempty"`
+	PendingDrafts []DigestDraft   `json:"pendingDrafts"`
+}
+
+// BuildDailyDigest assembles the digest for date from its log (nil if none),
+// tomorrow's preview day (nil if unavailable) and the open draft sessions.
+func BuildDailyDigest(date string, log *DailyLog, tomorrow *WeekPreviewDay, drafts []DraftSessionState) DailyDigest {
+	digest := DailyDigest{
+		Date:          date,
+		Sessions:      make([]DigestSession, 0),
+		PendingDrafts: make([]DigestDraft, 0, len(drafts)),
+	}
+
+	if log != nil {
+		digest.Logged = true
+		t := log.CalculatedTargets
+		digest.Target = DigestMacros{Calories: t.TotalCalories, ProteinG: t.TotalProteinG, CarbsG: t.TotalCarbsG, FatG: t.TotalFatsG}
+		digest.Consumed = DigestMacros{Calories: log.ConsumedCalories, ProteinG: log.ConsumedProteinG, CarbsG: log.ConsumedCarbsG, FatG: log.ConsumedFatG}
+		digest.Remaining = DigestMacros{
+			Calories: digest.Target.Calories - digest.Consumed.Calories,
+			ProteinG: digest.Target.ProteinG - digest.Consumed.ProteinG,
+			CarbsG:   digest.Target.CarbsG - digest.Consumed.CarbsG,
+			FatG:     digest.Target.FatG - digest.Consumed.FatG,
+		}
+		for _, s := range log.ActualSessions {
+			if s.Type == TrainingTypeRest {
+				continue
+			}
+			digest.Sessions = append(digest.Sessions, DigestSession{TrainingType: s.Type, DurationMin: s.DurationMin, IsDraft: s.IsDraft})
+		}
+	}
+
+	if tomorrow != nil {
+		digest.Tomorrow = &DigestTomorrow{Date: tomorrow.Date, DayType: tomorrow.DayType, Sessions: tomorrow.Sessions}
+	}
+
+	for _, d := range drafts {
+		digest.PendingDrafts = append(digest.PendingDrafts, DigestDraft{
+			SessionID:    d.Session.ID,
+			Date:         d.LogDate,
+			TrainingType: d.Session.Type,
+			DurationMin:  d.Session.DurationMin,
+		})
+	}
+	return digest
+}
+
+// Title returns the notification title, e.g. "Victus digest - Mon 2 Mar".
+func (d DailyDigest) Title() string {
+	if t, err := time.Parse("2006-01-02", d.Date); err == nil {
+		return "Victus digest - " + t.Format("Mon 2 Jan")
+	}
+	return "Victus digest - " + d.Date
+}
+
+// Text renders the digest as a short plain-text message, one topic per line.
+func (d DailyDigest) Text() string {
+	var lines []string
+
+	if !d.Logged {
+		lines = append(lines, "Nothing logged today.")
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("Eaten: %d of %d kcal (P %d/%dg, C %d/%dg, F %d/%dg)",
+				d.Consumed.Calories, d.Target.Calories,
+				d.Consumed.ProteinG, d.Target.ProteinG,
+				d.Consumed.CarbsG, d.Target.CarbsG,
+				d.Consumed.FatG, d.Target.FatG),
+			"Remaining: "+remainingText(d.Remaining))
+
+		if len(d.Sessions) == 0 {
+			lines = append(lines, "Training: none logged")
+		} else {
+			parts := make([]string, len(d.Sessions))
+			for i, s := range d.Sessions {
+				parts[i] = fmt.Sprintf("%s %d min", s.TrainingType, s.DurationMin)
+			}
+			lines = append(lines, "Training: "+strings.Join(parts, ", "))
+		}
+	}
+
+	if d.Tomorrow != nil {
+		line := fmt.Sprintf("Tomorrow: %s day", d.Tomorrow.DayType)
+		var parts []string
+		for _, s := range d.Tomorrow.Sessions {
+			if s.TrainingType == TrainingTypeRest {
+				continue
+			}
+			part := fmt.Sprintf("%s %d min", s.TrainingType, s.DurationMin)
+			if s.Label != "" {
+				part = fmt.Sprintf("%s (%s)", s.Label, part)
+			}
+			parts = append(parts, part)
+		}
+		if len(parts) > 0 {
+			line += " - " + strings.Join(parts, ", ")
+		} else {
+			line += ", no training planned"
+		}
+		lines = append(lines, line)
+	}
+
+	if n := len(d.PendingDrafts); n > 0 {
+		noun := "session needs"
+		if n > 1 {
+			noun = "sessions need"
+		}
+		lines = append(lines, fmt.Sprintf("Drafts: %d %s RPE and notes", n, noun))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// remainingText describes the remaining budget, calling out anything over target.
+func remainingText(r DigestMacros) string {
+	macro := func(name string, v int) string {
+		if v < 0 {
+			return fmt.Sprintf("%s %dg over", name, -v)
+		}
+		return fmt.Sprintf("%s %dg", name, v)
+	}
+	kcal := fmt.Sprintf("%d kcal", r.Calories)
+	if r.Calories < 0 {
+		kcal = fmt.Sprintf("%d kcal over", -r.Calories)
+	}
+	return strings.Join([]string{kcal, macro("P", r.ProteinG), macro("C", r.CarbsG), macro("F", r.FatG)}, ", ")
+}
+
+// ParseDigestTime parses a digest send time (HH:MM) into minutes since midnight.
+func ParseDigestTime(hhmm string) (int, bool) {
+	return minuteOfDay(hhmm)
+}
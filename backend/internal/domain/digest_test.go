@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The digest is the only view of the day some users read; tests
+// pin the remaining-budget arithmetic (including going over), the skipped rest
+// sessions and the rendered message for logged and unlogged days.
+type DailyDigestSuite struct {
+	suite.Suite
+}
+
+func TestDailyDigestSuite(t *testing.T) {
+	suite.Run(t, new(DailyDigestSuite))
+}
+
+func (s *DailyDigestSuite) log() *DailyLog {
+	return &DailyLog{
+		Date: "2026-03-02",
+		CalculatedTargets: DailyTargets{
+			TotalCalories: 2300, TotalProteinG: 180, TotalCarbsG: 250, TotalFatsG: 70,
+		},
+		ConsumedCalories: 1850, ConsumedProteinG: 140, ConsumedCarbsG: 200, ConsumedFatG: 78,
+		ActualSessions: []TrainingSession{
+			{Type: TrainingTypeStrength, DurationMin: 60},
+			{Type: TrainingTypeRest, DurationMin: 0},
+		},
+	}
+}
+
+func (s *DailyDigestSuite) TestBuild() {
+	tomorrow := &WeekPreviewDay{
+		Date: "2026-03-03", DayType: DayTypePerformance,
+		Sessions: []WeekPreviewSession{{Label: "Upper A", TrainingType: TrainingTypeStrength, DurationMin: 60}},
+	}
+	drafts := []DraftSessionState{{Session: TrainingSession{ID: 9, Type: TrainingTypeRun, DurationMin: 30}, LogDate: "2026-03-01"}}
+
+	digest := BuildDailyDigest("2026-03-02", s.log(), tomorrow, drafts)
+	s.True(digest.Logged)
+	s.Equal(DigestMacros{Calories: 450, ProteinG: 40, CarbsG: 50, FatG: -8}, digest.Remaining)
+	s.Equal([]DigestSession{{TrainingType: TrainingTypeStrength, DurationMin: 60}}, digest.Sessions)
+	s.Equal(&DigestTomorrow{Date: "2026-03-03", DayType: DayTypePerformance, Sessions: tomorrow.Sessions}, digest.Tomorrow)
+	s.Equal([]DigestDraft{{SessionID: 9, Date: "2026-03-01", TrainingType: TrainingTypeRun, DurationMin: 30}}, digest.PendingDrafts)
+
+	s.Equal("Victus digest - Mon 2 Mar", digest.Title())
+	s.Equal("Eaten: 1850 of 2300 kcal (P 140/180g, C 200/250g, F 78/70g)\n"+
+		"Remaining: 450 kcal, P 40g, C 50g, F 8g over\n"+
+		"Training: strength 60 min\n"+
+		"Tomorrow: performance day - Upper A (strength 60 min)\n"+
+		"Drafts: 1 session needs RPE and notes", digest.Text())
+}
+
+func (s *DailyDigestSuite) TestNothingLogged() {
+	digest := BuildDailyDigest("2026-03-02", nil, &WeekPreviewDay{Date: "2026-03-03", DayType: DayTypeFatburner}, nil)
+	s.False(digest.Logged)
+	s.Empty(digest.Sessions)
+	s.NotNil(digest.PendingDrafts)
+	s.Equal("Nothing logged today.\nTomorrow: fatburner day, no training planned", digest.Text())
+
+	s.Equal("Nothing logged today.", BuildDailyDigest("2026-03-02", nil, nil, nil).Text())
+}
+
+func (s *DailyDigestSuite) TestParseDigestTime() {
+	minute, ok := ParseDigestTime("21:30")
+	s.True(ok)
+	s.Equal(21*60+30, minute)
+	_, ok = ParseDigestTime("9pm")
+	s.False(ok)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "math"
+
+// =============================================================================
+// DOUBLE-DAY LOAD DISTRIBUTION
+// =============================================================================
+//
+// Two hard sessions on one day stack load faster than the ACR model notices.
+// When a day holds two or more hard sessions and their combined load exceeds a
+// per-day ceiling scaled by readiness, warn and suggest moving the second one.
+
+const (
+	// DoubleDayHardSessionLoad is the session load at or above which a session counts as hard.
+	// A 45-minute strength session at RPE 5 scores ~6.3; a 45-minute run at RPE 7 scores ~5.3.
+	DoubleDayHardSessionLoad = 5.0
+	// DoubleDayBaseLoadCeiling is the combined daily load allowed at full readiness.
+	DoubleDayBaseLoadCeiling = 14.0
+)
+
+// doubleDayReadinessFactors scales the ceiling by CNS status.
+// Unknown readiness (no HRV or check-in) uses the full ceiling.
+var doubleDayReadinessFactors = map[CNSStatus]float64{
+	CNSStatusOptimized: 1.0,
+	CNSStatusStrained:  0.8,
+	CNSStatusDepleted:  0.6,
+}
+
+// DoubleDayWarning flags a day whose hard sessions exceed the readiness-adjusted ceiling.
+type DoubleDayWarning struct {
+	Date              string    `json:"date"`
+	HardSessions      int       `json:"hardSessions"`
+	CombinedLoad      float64   `json:"combinedLoad"`
+	Ceiling           float64   `json:"ceiling"`
+	Readiness         CNSStatus `json:"readiness,omitempty"` // Empty when readiness is unknown
+	MoveSessionIndex  int       `json:"moveSessionIndex"`    // Index of the session to move (second hard session)
+	SuggestedMoveDate string    `json:"suggestedMoveDate,omitempty"`
+	Message           string    `json:"message"`
+}
+
+// DailyLoadCeiling returns the combined daily load allowed for the given readiness.
+func DailyLoadCeiling(readiness CNSStatus) float64 {
+	factor, ok := doubleDayReadinessFactors[readiness]
+	if !ok {
+		factor = 1.0
+	}
+	return DoubleDayBaseLoadCeiling * factor
+}
+
+// EvaluateDoubleDay checks a day's sessions against the per-day ceiling.
+// Returns nil unless there are at least two hard sessions and their combined
+// load exceeds the ceiling for the given readiness.
+func EvaluateDoubleDay(date string, sessions []TrainingSession, readiness CNSStatus) *DoubleDayWarning {
+	hard := 0
+	moveIndex := -1
+	for i, s := range sessions {
+		if SessionLoad(s.Type, s.DurationMin, s.PerceivedIntensity) < DoubleDayHardSessionLoad {
+			continue
+		}
+		hard++
+		if hard == 2 {
+			moveIndex = i
+		}
+	}
+	if hard < 2 {
+		return nil
+	}
+
+	combined := TotalSessionLoad(sessions)
+	ceiling := DailyLoadCeiling(readiness)
+	if combined <= ceiling {
+		return nil
+	}
+
+	move := sessions[moveIndex]
+	return &DoubleDayWarning{
+		Date:             date,
+		HardSessions:     hard,
+		CombinedLoad:     math.Round(combined*10) / 10,
+		Ceiling:          math.Round(ceiling*10) / 10,
+		Readiness:        readiness,
+		MoveSessionIndex: moveIndex,
+		Message: "Two hard sessions total " + formatFloat(combined) + " load (ceiling " + formatFloat(ceiling) +
+			") — consider moving the " + string(move.Type) + " session to another day",
+	}
+}
+
+// SuggestDoubleDayMove picks the first later day whose load leaves room for the
+// moved session under the base ceiling. days must be ordered oldest first.
+// Returns "" when no later day fits.
+func SuggestDoubleDayMove(warning *DoubleDayWarning, movedLoad float64, days []DailyLoadDataPoint) string {
+	if warning == nil {
+		return ""
+	}
+	for _, d := range days {
+		if d.Date <= warning.Date {
+			continue
+		}
+		if d.DailyLoad < DoubleDayHardSessionLoad && d.DailyLoad+movedLoad <= DoubleDayBaseLoadCeiling {
+			return d.Date
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DoubleDaySuite struct {
+	suite.Suite
+}
+
+func TestDoubleDaySuite(t *testing.T) {
+	suite.Run(t, new(DoubleDaySuite))
+}
+
+func doubleDaySession(trainingType TrainingType, durationMin, rpe int) TrainingSession {
+	return TrainingSession{Type: trainingType, DurationMin: durationMin, PerceivedIntensity: &rpe}
+}
+
+func (s *DoubleDaySuite) TestDailyLoadCeilingScalesWithReadiness() {
+	s.InDelta(14.0, DailyLoadCeiling(""), 0.001, "unknown readiness uses full ceiling")
+	s.InDelta(14.0, DailyLoadCeiling(CNSStatusOptimized), 0.001)
+	s.InDelta(11.2, DailyLoadCeiling(CNSStatusStrained), 0.001)
+	s.InDelta(8.4, DailyLoadCeiling(CNSStatusDepleted), 0.001)
+}
+
+func (s *DoubleDaySuite) TestSingleHardSessionNeverWarns() {
+	sessions := []TrainingSession{
+		doubleDaySession(TrainingTypeStrength, 90, 9),
+		doubleDaySession(TrainingTypeMobility, 30, 3),
+	}
+	s.Nil(EvaluateDoubleDay("2025-01-06", sessions, CNSStatusDepleted))
+}
+
+func (s *DoubleDaySuite) TestTwoHardSessionsOverCeilingWarn() {
+	sessions := []TrainingSession{
+		doubleDaySession(TrainingTypeStrength, 60, 5), // 8.3
+		doubleDaySession(TrainingTypeMobility, 20, 3), // 0.2
+		doubleDaySession(TrainingTypeHIIT, 60, 5),     // 8.3
+	}
+
+	warning := EvaluateDoubleDay("2025-01-06", sessions, "")
+	s.Require().NotNil(warning)
+	s.Equal(2, warning.HardSessions)
+	s.Equal(2, warning.MoveSessionIndex, "suggests moving the second hard session")
+	s.InDelta(16.8, warning.CombinedLoad, 0.05)
+	s.InDelta(14.0, warning.Ceiling, 0.001)
+	s.Contains(warning.Message, "hiit")
+}
+
+func (s *DoubleDaySuite) TestReadinessLowersCeiling() {
+	sessions := []TrainingSession{
+		doubleDaySession(TrainingTypeStrength, 60, 5), // 8.3
+		doubleDaySession(TrainingTypeRun, 45, 7),      // 5.25
+	}
+
+	s.Nil(EvaluateDoubleDay("2025-01-06", sessions, CNSStatusOptimized), "13.6 fits under 14")
+
+	warning := EvaluateDoubleDay("2025-01-06", sessions, CNSStatusStrained)
+	s.Require().NotNil(warning)
+	s.Equal(CNSStatusStrained, warning.Readiness)
+}
+
+func (s *DoubleDaySuite) TestSuggestDoubleDayMove() {
+	warning := &DoubleDayWarning{Date: "2025-01-07"}
+	days := []DailyLoadDataPoint{
+		{Date: "2025-01-06", DailyLoad: 0},
+		{Date: "2025-01-07", DailyLoad: 16},
+		{Date: "2025-01-08", DailyLoad: 8},
+		{Date: "2025-01-09", DailyLoad: 2},
+	}
+
+	s.Equal("2025-01-09", SuggestDoubleDayMove(warning, 8, days), "skips earlier and already-hard days")
+	s.Equal("", SuggestDoubleDayMove(warning, 13, days), "no day has room")
+	s.Equal("", SuggestDoubleDayMove(nil, 8, days))
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// =============================================================================
+// DRAFT SESSION LIFECYCLE
+// =============================================================================
+//
+// Quick voice submits create draft sessions awaiting an echo (RPE, notes).
+// Left alone they linger forever and leave holes in RPE data, so drafts get a
+// reminder after a short delay and are auto-finalized with defaults later.
+
+// DraftSessionAction is the lifecycle step due for a draft session.
+type DraftSessionAction string
+
+const (
+	DraftActionNone      DraftSessionAction = "none"
+	DraftActionRemind    DraftSessionAction = "remind"
+	DraftActionAutoClose DraftSessionAction = "auto_close"
+)
+
+// DraftSessionPolicy configures when drafts are reminded and auto-closed.
+type DraftSessionPolicy struct {
+	RemindAfter        time.Duration
+	AutoCloseAfter     time.Duration
+	DefaultRPE         int // Applied when the draft has no RPE
+	DefaultDurationMin int // Applied when the draft has no duration
+}
+
+// DefaultDraftSessionPolicy reminds after 2 hours and auto-closes after 24 hours.
+// The default RPE matches the load model's fallback (SessionLoad uses 5).
+var DefaultDraftSessionPolicy = DraftSessionPolicy{
+	RemindAfter:        2 * time.Hour,
+	AutoCloseAfter:     24 * time.Hour,
+	DefaultRPE:         5,
+	DefaultDurationMin: 30,
+}
+
+// DraftSessionState is a draft session with its lifecycle timestamps.
+type DraftSessionState struct {
+	Session    TrainingSession
+	LogDate    string
+	CreatedAt  time.Time
+	RemindedAt *time.Time
+}
+
+// NextDraftAction returns the lifecycle step due for a draft at now.
+// Auto-close takes precedence; a draft is only reminded once.
+func NextDraftAction(draft DraftSessionState, policy DraftSessionPolicy, now time.Time) DraftSessionAction {
+	age := now.Sub(draft.CreatedAt)
+	if policy.AutoCloseAfter > 0 && age >= policy.AutoCloseAfter {
+		return DraftActionAutoClose
+	}
+	if draft.RemindedAt == nil && policy.RemindAfter > 0 && age >= policy.RemindAfter {
+		return DraftActionRemind
+	}
+	return DraftActionNone
+}
+
+// ApplyDraftDefaults fills missing RPE and duration on an auto-closed draft.
+func ApplyDraftDefaults(session TrainingSession, policy DraftSessionPolicy) TrainingSession {
+	if session.PerceivedIntensity == nil && policy.DefaultRPE > 0 {
+		rpe := policy.DefaultRPE
+		session.PerceivedIntensity = &rpe
+	}
+	if session.DurationMin == 0 && session.Type != TrainingTypeRest {
+		session.DurationMin = policy.DefaultDurationMin
+	}
+	session.IsDraft = false
+	return session
+}
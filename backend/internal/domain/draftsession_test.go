@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Draft lifecycle thresholds decide when user data is overwritten
+// with defaults; boundary behavior must be exact.
+type DraftSessionSuite struct {
+	suite.Suite
+	created time.Time
+}
+
+func TestDraftSessionSuite(t *testing.T) {
+	suite.Run(t, new(DraftSessionSuite))
+}
+
+func (s *DraftSessionSuite) SetupTest() {
+	s.created = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+}
+
+func (s *DraftSessionSuite) draft(reminded *time.Time) DraftSessionState {
+	return DraftSessionState{
+		Session:    TrainingSession{ID: 1, Type: TrainingTypeRun, IsDraft: true},
+		LogDate:    "2026-03-02",
+		CreatedAt:  s.created,
+		RemindedAt: reminded,
+	}
+}
+
+func (s *DraftSessionSuite) TestFreshDraftNeedsNoAction() {
+	action := NextDraftAction(s.draft(nil), DefaultDraftSessionPolicy, s.created.Add(time.Hour))
+	s.Equal(DraftActionNone, action)
+}
+
+func (s *DraftSessionSuite) TestRemindsAfterThreshold() {
+	action := NextDraftAction(s.draft(nil), DefaultDraftSessionPolicy, s.created.Add(2*time.Hour))
+	s.Equal(DraftActionRemind, action)
+}
+
+func (s *DraftSessionSuite) TestRemindsOnlyOnce() {
+	reminded := s.created.Add(2 * time.Hour)
+	action := NextDraftAction(s.draft(&reminded), DefaultDraftSessionPolicy, s.created.Add(5*time.Hour))
+	s.Equal(DraftActionNone, action)
+}
+
+func (s *DraftSessionSuite) TestAutoClosesAfterThreshold() {
+	reminded := s.created.Add(2 * time.Hour)
+	action := NextDraftAction(s.draft(&reminded), DefaultDraftSessionPolicy, s.created.Add(24*time.Hour))
+	s.Equal(DraftActionAutoClose, action)
+}
+
+func (s *DraftSessionSuite) TestAutoCloseWinsOverUnsentReminder() {
+	action := NextDraftAction(s.draft(nil), DefaultDraftSessionPolicy, s.created.Add(30*time.Hour))
+	s.Equal(DraftActionAutoClose, action)
+}
+
+func (s *DraftSessionSuite) TestCustomPolicy() {
+	policy := DefaultDraftSessionPolicy
+	policy.AutoCloseAfter = 6 * time.Hour
+	action := NextDraftAction(s.draft(nil), policy, s.created.Add(6*time.Hour))
+	s.Equal(DraftActionAutoClose, action)
+}
+
+func (s *DraftSessionSuite) TestApplyDefaultsFillsMissingFields() {
+	closed := ApplyDraftDefaults(s.draft(nil).Session, DefaultDraftSessionPolicy)
+	s.False(closed.IsDraft)
+	s.Require().NotNil(closed.PerceivedIntensity)
+	s.Equal(5, *closed.PerceivedIntensity)
+	s.Equal(30, closed.DurationMin)
+}
+
+func (s *DraftSessionSuite) TestApplyDefaultsKeepsUserValues() {
+	rpe := 8
+	session := s.draft(nil).Session
+	session.PerceivedIntensity = &rpe
+	session.DurationMin = 45
+
+	closed := ApplyDraftDefaults(session, DefaultDraftSessionPolicy)
+	s.Equal(8, *closed.PerceivedIntensity)
+	s.Equal(45, closed.DurationMin)
+}
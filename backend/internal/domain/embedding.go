@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// SEMANTIC SEARCH
+// =============================================================================
+//
+// Foods and movements are indexed as short descriptive documents, embedded by
+// Ollama and stored in pgvector. A free-text query ("something crunchy high
+// protein", "knee-friendly leg exercise") is embedded the same way and matched
+// by cosine similarity. The documents spell out the traits people search by
+// (texture, macro profile, joint stress) since names alone carry little of it.
+
+const (
+	// SemanticSearchDefaultLimit is the number of hits returned when no limit is given.
+	SemanticSearchDefaultLimit = 10
+	// SemanticSearchMaxLimit caps the number of hits per query.
+	SemanticSearchMaxLimit = 50
+	// semanticQueryMaxLength caps query length in characters.
+	semanticQueryMaxLength = 200
+)
+
+// EmbeddingKind is the catalog an indexed document belongs to.
+type EmbeddingKind string
+
+const (
+	EmbeddingKindFood     EmbeddingKind = "food"
+	EmbeddingKindMovement EmbeddingKind = "movement"
+)
+
+// EmbeddingDocument is the text indexed for one catalog item.
+type EmbeddingDocument struct {
+	ItemID string // food_reference.id or movements.id
+	Text   string
+}
+
+// Hash fingerprints the document so unchanged items aren't re-embedded.
+func (d EmbeddingDocument) Hash() string {
+	sum := sha256.Sum256([]byte(d.Text))
+	return hex.EncodeToString(sum[:])
+}
+
+// SemanticMatch is an indexed item scored against a query.
+type SemanticMatch struct {
+	ItemID     string
+	Similarity float64 // Cosine similarity, 1 is identical
+}
+
+// FoodSearchHit is a food returned by semantic search.
+type FoodSearchHit struct {
+	Food       FoodNutrition
+	Similarity float64
+}
+
+// MovementSearchHit is a movement returned by semantic search.
+type MovementSearchHit struct {
+	Movement   Movement
+	Similarity float64
+}
+
+// ValidateSemanticQuery trims a search query and checks its length and limit.
+func ValidateSemanticQuery(query string, limit int) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > semanticQueryMaxLength {
+		return "", ErrInvalidSemanticQuery
+	}
+	if limit < 1 || limit > SemanticSearchMaxLimit {
+		return "", ErrInvalidSemanticLimit
+	}
+	return query, nil
+}
+
+// foodCategoryLabels describe food categories in searchable words.
+var foodCategoryLabels = map[FoodCategory]string{
+	FoodCategoryHighCarb:    "carbohydrate source",
+	FoodCategoryHighProtein: "protein source",
+	FoodCategoryHighFat:     "fat source",
+	FoodCategoryVegetable:   "vegetable",
+	FoodCategoryFruit:       "fruit",
+}
+
+// FoodEmbeddingDocument describes a food by name, category, culinary profile
+// and macro profile.
+func FoodEmbeddingDocument(f FoodNutrition) EmbeddingDocument {
+	profile := ProfileFood(f)
+	parts := []string{f.FoodItem}
+	if label, ok := foodCategoryLabels[f.Category]; ok {
+		parts = append(parts, label)
+	}
+	if profile.Texture != "" {
+		parts = append(parts, string(profile.Texture)+" texture")
+	}
+	parts = append(parts, string(profile.Pairing)+" flavor")
+	switch profile.Prep {
+	case PrepCooked:
+		parts = append(parts, "served cooked")
+	case PrepRaw:
+		parts = append(parts, "eaten fresh")
+	case PrepReady:
+		parts = append(parts, "ready to eat")
+	}
+	parts = append(parts, macroDescriptors(f)...)
+	if f.IsPantryStaple {
+		parts = append(parts, "pantry staple")
+	}
+	parts = append(parts, fmt.Sprintf("per 100g: %.0fg protein, %.0fg carbs, %.0fg fat",
+		f.ProteinGPer100, f.CarbsGPer100, f.FatGPer100))
+
+	return EmbeddingDocument{ItemID: fmt.Sprint(f.ID), Text: strings.Join(parts, ". ")}
+}
+
+// macroDescriptors names the macro traits people search for.
+func macroDescriptors(f FoodNutrition) []string {
+	var out []string
+	if f.ProteinGPer100 >= 20 {
+		out = append(out, "high protein")
+	}
+	if f.CarbsGPer100 >= 40 {
+		out = append(out, "high carb")
+	} else if f.CarbsGPer100 < 5 {
+		out = append(out, "low carb")
+	}
+	if f.FatGPer100 >= 20 {
+		out = append(out, "high fat")
+	} else if f.FatGPer100 < 3 {
+		out = append(out, "low fat")
+	}
+	kcal := f.ProteinGPer100*4 + f.CarbsGPer100*4 + f.FatGPer100*9
+	if kcal < 60 {
+		out = append(out, "low calorie")
+	} else if kcal >= 400 {
+		out = append(out, "calorie dense")
+	}
+	return out
+}
+
+// MovementEmbeddingDocument describes a movement by name, pattern, load,
+// difficulty, joint stress and equipment.
+func MovementEmbeddingDocument(m Movement) EmbeddingDocument {
+	parts := []string{
+		m.Name,
+		string(m.Category) + " movement",
+		"works " + strings.ToLower(strings.ReplaceAll(m.PrimaryLoad, "/", " and ")),
+		difficultyLabel(m.Difficulty),
+	}
+
+	joints := make([]string, 0, len(m.JointStress))
+	for joint := range m.JointStress {
+		joints = append(joints, joint)
+	}
+	sort.Strings(joints)
+	for _, joint := range joints {
+		switch stress := m.JointStress[joint]; {
+		case stress >= 0.6:
+			parts = append(parts, "high "+joint+" stress")
+		case stress <= 0.3:
+			parts = append(parts, joint+"-friendly, low "+joint+" stress")
+		default:
+			parts = append(parts, "moderate "+joint+" stress")
+		}
+	}
+
+	if len(m.Equipment) == 0 {
+		parts = append(parts, "bodyweight only, no equipment")
+	} else {
+		equipment := make([]string, len(m.Equipment))
+		for i, e := range m.Equipment {
+			equipment[i] = strings.ReplaceAll(string(e), "_", " ")
+		}
+		parts = append(parts, "needs "+strings.Join(equipment, ", "))
+	}
+	if len(m.Tags) > 0 {
+		parts = append(parts, strings.Join(m.Tags, ", "))
+	}
+
+	return EmbeddingDocument{ItemID: m.ID, Text: strings.Join(parts, ". ")}
+}
+
+// difficultyLabel buckets the 1-10 movement difficulty.
+func difficultyLabel(difficulty int) string {
+	switch {
+	case difficulty <= 3:
+		return "beginner"
+	case difficulty <= 6:
+		return "intermediate"
+	default:
+		return "advanced"
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Semantic search only finds what the indexed documents
+// describe; tests pin the traits people search by (texture, macros, joint
+// stress) and query validation.
+type EmbeddingDocumentSuite struct {
+	suite.Suite
+}
+
+func TestEmbeddingDocumentSuite(t *testing.T) {
+	suite.Run(t, new(EmbeddingDocumentSuite))
+}
+
+func (s *EmbeddingDocumentSuite) TestFoodDocumentDescribesTextureAndMacros() {
+	almonds := FoodNutrition{ID: 42, Category: FoodCategoryHighFat, FoodItem: "Almonds", ProteinGPer100: 21, CarbsGPer100: 22, FatGPer100: 50, IsPantryStaple: true}
+
+	doc := FoodEmbeddingDocument(almonds)
+	s.Equal("42", doc.ItemID)
+	for _, trait := range []string{"Almonds", "fat source", "crunchy texture", "high protein", "high fat", "calorie dense", "pantry staple"} {
+		s.Contains(doc.Text, trait)
+	}
+}
+
+func (s *EmbeddingDocumentSuite) TestMovementDocumentDescribesJointStress() {
+	squat := Movement{
+		ID: "cali_squat_air", Name: "Air Squat", Category: MovementCategoryLegs, Difficulty: 2,
+		PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.3, "ankle": 0.7},
+	}
+
+	doc := MovementEmbeddingDocument(squat)
+	s.Equal("cali_squat_air", doc.ItemID)
+	for _, trait := range []string{"legs movement", "works quads and glutes", "beginner", "knee-friendly", "high ankle stress", "bodyweight only"} {
+		s.Contains(doc.Text, trait)
+	}
+	s.Less(strings.Index(doc.Text, "ankle"), strings.Index(doc.Text, "knee"), "joints in stable order")
+}
+
+func (s *EmbeddingDocumentSuite) TestHashTracksContent() {
+	food := FoodNutrition{ID: 1, Category: FoodCategoryHighCarb, FoodItem: "Oats", CarbsGPer100: 60}
+	s.Equal(FoodEmbeddingDocument(food).Hash(), FoodEmbeddingDocument(food).Hash())
+
+	food.ProteinGPer100 = 13
+	s.NotEqual(FoodEmbeddingDocument(FoodNutrition{ID: 1, Category: FoodCategoryHighCarb, FoodItem: "Oats", CarbsGPer100: 60}).Hash(),
+		FoodEmbeddingDocument(food).Hash(), "edited macros re-embed")
+}
+
+func (s *EmbeddingDocumentSuite) TestValidateSemanticQuery() {
+	query, err := ValidateSemanticQuery("  something crunchy  ", SemanticSearchDefaultLimit)
+	s.NoError(err)
+	s.Equal("something crunchy", query)
+
+	_, err = ValidateSemanticQuery("   ", 10)
+	s.ErrorIs(err, ErrInvalidSemanticQuery)
+	_, err = ValidateSemanticQuery(strings.Repeat("a", 201), 10)
+	s.ErrorIs(err, ErrInvalidSemanticQuery)
+	_, err = ValidateSemanticQuery("oats", 0)
+	s.ErrorIs(err, ErrInvalidSemanticLimit)
+	_, err = ValidateSemanticQuery("oats", SemanticSearchMaxLimit+1)
+	s.ErrorIs(err, ErrInvalidSemanticLimit)
+}
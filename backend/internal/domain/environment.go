@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// =============================================================================
+// ENVIRONMENT CONDITIONS
+// =============================================================================
+//
+// Days and sessions can be tagged with the conditions they happened in. Heat,
+// humidity and altitude raise fluid losses and make the same session cost
+// more, so tagged days get a higher water target and tagged sessions a higher
+// load. A day's tags apply to all of its sessions. The weekly debrief notes a
+// tagged day's missed, shortened or harder-than-planned training instead of
+// counting it against training adherence.
+
+// EnvironmentCondition is a condition a day or session took place in.
+type EnvironmentCondition string
+
+const (
+	EnvironmentHot      EnvironmentCondition = "hot"
+	EnvironmentHumid    EnvironmentCondition = "humid"
+	EnvironmentAltitude EnvironmentCondition = "altitude"
+)
+
+// environmentOrder is the canonical order conditions are stored and shown in.
+var environmentOrder = []EnvironmentCondition{EnvironmentHot, EnvironmentHumid, EnvironmentAltitude}
+
+// ValidEnvironmentConditions contains all valid environment condition values.
+var ValidEnvironmentConditions = map[EnvironmentCondition]bool{
+	EnvironmentHot:      true,
+	EnvironmentHumid:    true,
+	EnvironmentAltitude: true,
+}
+
+// environmentHydrationFactors scale the water target; factors multiply when
+// conditions combine.
+var environmentHydrationFactors = map[EnvironmentCondition]float64{
+	EnvironmentHot:      1.25, // Sweat losses roughly double in heat; most of that is during activity
+	EnvironmentHumid:    1.10, // Sweat evaporates less, so more is produced for the same cooling
+	EnvironmentAltitude: 1.15, // Drier air and faster breathing raise respiratory losses
+}
+
+// environmentLoadFactors scale session load; factors multiply when conditions combine.
+var environmentLoadFactors = map[EnvironmentCondition]float64{
+	EnvironmentHot:      1.10,
+	EnvironmentHumid:    1.05,
+	EnvironmentAltitude: 1.10,
+}
+
+const (
+	// EnvironmentShortenedRatio is the fraction of planned duration below which
+	// a tagged day's training counts as shortened.
+	EnvironmentShortenedRatio = 0.8
+)
+
+// ParseEnvironment validates condition names and returns them deduplicated
+// in canonical order. Returns ErrInvalidEnvironmentCondition for unknown names.
+func ParseEnvironment(names []string) ([]EnvironmentCondition, error) {
+	conditions := make([]EnvironmentCondition, 0, len(names))
+	for _, name := range names {
+		c := EnvironmentCondition(name)
+		if !ValidEnvironmentConditions[c] {
+			return nil, ErrInvalidEnvironmentCondition
+		}
+		conditions = append(conditions, c)
+	}
+	return MergeEnvironment(conditions), nil
+}
+
+// ValidateEnvironment checks that every condition is known.
+func ValidateEnvironment(conditions []EnvironmentCondition) error {
+	for _, c := range conditions {
+		if !ValidEnvironmentConditions[c] {
+			return ErrInvalidEnvironmentCondition
+		}
+	}
+	return nil
+}
+
+// MergeEnvironment returns the union of the given condition sets in canonical
+// order. Unknown conditions are dropped.
+func MergeEnvironment(sets ...[]EnvironmentCondition) []EnvironmentCondition {
+	seen := make(map[EnvironmentCondition]bool)
+	for _, set := range sets {
+		for _, c := range set {
+			seen[c] = true
+		}
+	}
+	merged := []EnvironmentCondition{}
+	for _, c := range environmentOrder {
+		if seen[c] {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// EnvironmentHydrationFactor returns the water target multiplier for the conditions.
+func EnvironmentHydrationFactor(conditions []EnvironmentCondition) float64 {
+	return environmentFactor(conditions, environmentHydrationFactors)
+}
+
+// EnvironmentLoadFactor returns the session load multiplier for the conditions.
+func EnvironmentLoadFactor(conditions []EnvironmentCondition) float64 {
+	return environmentFactor(conditions, environmentLoadFactors)
+}
+
+func environmentFactor(conditions []EnvironmentCondition, factors map[EnvironmentCondition]float64) float64 {
+	factor := 1.0
+	for _, c := range MergeEnvironment(conditions) {
+		factor *= factors[c]
+	}
+	return factor
+}
+
+// CalculateWaterTarget returns the day's water target in liters, scaled for
+// the conditions.
+func CalculateWaterTarget(weightKg float64, conditions []EnvironmentCondition) float64 {
+	return RoundTo(weightKg*WaterLPerKg*EnvironmentHydrationFactor(conditions), 1)
+}
+
+// DayEnvironment returns the conditions of the day and any of its sessions.
+func (d *DailyLog) DayEnvironment() []EnvironmentCondition {
+	sets := [][]EnvironmentCondition{d.Environment}
+	for _, s := range d.PlannedSessions {
+		sets = append(sets, s.Environment)
+	}
+	for _, s := range d.ActualSessions {
+		sets = append(sets, s.Environment)
+	}
+	return MergeEnvironment(sets...)
+}
+
+// WithDayEnvironment returns copies of the sessions with the day's conditions
+// added to their own.
+func WithDayEnvironment(sessions []TrainingSession, day []EnvironmentCondition) []TrainingSession {
+	if len(day) == 0 {
+		return sessions
+	}
+	tagged := make([]TrainingSession, len(sessions))
+	for i, s := range sessions {
+		s.Environment = MergeEnvironment(day, s.Environment)
+		tagged[i] = s
+	}
+	return tagged
+}
+
+// EnvironmentNote explains a tagged day's training shortfall in the debrief.
+type EnvironmentNote struct {
+	Date       string                 `json:"date"`
+	Conditions []EnvironmentCondition `json:"conditions"`
+	Message    string                 `json:"message"`
+}
+
+// environmentShortfall reports whether a tagged day's training fell short of
+// the plan: fewer sessions, under EnvironmentShortenedRatio of the planned
+// duration, or a higher average RPE than planned. Returns the reasons.
+func environmentShortfall(log DailyLog) []string {
+	planned := countNonRestSessions(log.PlannedSessions)
+	if planned == 0 {
+		return nil
+	}
+	var reasons []string
+	if actual := countNonRestSessions(log.ActualSessions); actual < planned {
+		reasons = append(reasons, fmt.Sprintf("%d of %d planned sessions done", actual, planned))
+	}
+	plannedMin := TotalDurationMin(log.PlannedSessions)
+	actualMin := TotalDurationMin(log.ActualSessions)
+	if len(log.ActualSessions) > 0 && float64(actualMin) < float64(plannedMin)*EnvironmentShortenedRatio {
+		reasons = append(reasons, fmt.Sprintf("%d of %d planned minutes", actualMin, plannedMin))
+	}
+	plannedRPE := calculateAverageRPE(log.PlannedSessions)
+	actualRPE := calculateAverageRPE(log.ActualSessions)
+	if plannedRPE > 0 && actualRPE > plannedRPE {
+		reasons = append(reasons, fmt.Sprintf("RPE %.0f against %.0f planned", math.Round(actualRPE), math.Round(plannedRPE)))
+	}
+	return reasons
+}
+
+// BuildEnvironmentNotes returns a note for every tagged day whose training
+// fell short of the plan.
+func BuildEnvironmentNotes(logs []DailyLog) []EnvironmentNote {
+	notes := []EnvironmentNote{}
+	for _, log := range logs {
+		conditions := log.DayEnvironment()
+		if len(conditions) == 0 {
+			continue
+		}
+		reasons := environmentShortfall(log)
+		if len(reasons) == 0 {
+			continue
+		}
+		names := make([]string, len(conditions))
+		for i, c := range conditions {
+			names[i] = string(c)
+		}
+		notes = append(notes, EnvironmentNote{
+			Date:       log.Date,
+			Conditions: conditions,
+			Message: fmt.Sprintf("%s (%s): %s. Expected in these conditions, so not counted against training adherence.",
+				getDayName(log.Date), strings.Join(names, ", "), strings.Join(reasons, ", ")),
+		})
+	}
+	return notes
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Environment tags change the water target, every load figure
+// fed to ACR, and what the debrief counts as missed training; tests pin the
+// factors, how day and session tags combine, and which shortfalls are excused.
+type EnvironmentSuite struct {
+	suite.Suite
+}
+
+func TestEnvironmentSuite(t *testing.T) {
+	suite.Run(t, new(EnvironmentSuite))
+}
+
+func (s *EnvironmentSuite) TestParseDeduplicatesInCanonicalOrder() {
+	conditions, err := ParseEnvironment([]string{"altitude", "hot", "altitude"})
+	s.Require().NoError(err)
+	s.Equal([]EnvironmentCondition{EnvironmentHot, EnvironmentAltitude}, conditions)
+
+	_, err = ParseEnvironment([]string{"windy"})
+	s.ErrorIs(err, ErrInvalidEnvironmentCondition)
+}
+
+func (s *EnvironmentSuite) TestWaterTargetScalesWithConditions() {
+	s.Equal(3.2, CalculateWaterTarget(80, nil))
+	s.Equal(4.0, CalculateWaterTarget(80, []EnvironmentCondition{EnvironmentHot}))
+	s.Equal(4.4, CalculateWaterTarget(80, []EnvironmentCondition{EnvironmentHot, EnvironmentHumid}), "factors multiply")
+}
+
+func (s *EnvironmentSuite) TestDayTagsApplyToEverySession() {
+	rpe := 6
+	session := TrainingSession{Type: TrainingTypeRun, DurationMin: 60, PerceivedIntensity: &rpe}
+	base := TotalSessionLoad([]TrainingSession{session})
+
+	log := DailyLog{ActualSessions: []TrainingSession{session}}
+	s.InDelta(base, log.LoadScore(), 1e-9)
+
+	log.Environment = []EnvironmentCondition{EnvironmentHot}
+	s.InDelta(base*1.10, log.LoadScore(), 1e-9)
+	s.Nil(log.ActualSessions[0].Environment, "the log's sessions aren't modified")
+
+	log.ActualSessions[0].Environment = []EnvironmentCondition{EnvironmentHot, EnvironmentAltitude}
+	s.InDelta(base*1.10*1.10, log.LoadScore(), 1e-9, "a condition on both the day and session counts once")
+}
+
+func (s *EnvironmentSuite) TestDebriefExcusesShortfallOnTaggedDays() {
+	planned, hard := 6, 8
+	hotDay := DailyLog{
+		Date:            "2026-07-15", // Wednesday
+		Environment:     []EnvironmentCondition{EnvironmentHot},
+		PlannedSessions: []TrainingSession{{Type: TrainingTypeRun, DurationMin: 60, PerceivedIntensity: &planned}, {Type: TrainingTypeStrength, DurationMin: 45}},
+		ActualSessions:  []TrainingSession{{Type: TrainingTypeRun, DurationMin: 40, PerceivedIntensity: &hard}},
+	}
+	ordinaryMiss := DailyLog{
+		Date:            "2026-07-16",
+		PlannedSessions: []TrainingSession{{Type: TrainingTypeStrength, DurationMin: 45}},
+	}
+
+	notes := BuildEnvironmentNotes([]DailyLog{hotDay, ordinaryMiss})
+
+	s.Require().Len(notes, 1)
+	s.Equal("2026-07-15", notes[0].Date)
+	s.Equal([]EnvironmentCondition{EnvironmentHot}, notes[0].Conditions)
+	s.Contains(notes[0].Message, "Wednesday (hot)")
+	s.Contains(notes[0].Message, "1 of 2 planned sessions done")
+	s.Contains(notes[0].Message, "40 of 105 planned minutes")
+	s.Contains(notes[0].Message, "RPE 8 against 6 planned")
+
+	// The hot day's missed session is excused; the ordinary miss still counts
+	s.InDelta(50.0, calculateTrainingAdherence([]DailyLog{hotDay, ordinaryMiss}), 1e-9)
+}
+
+func (s *EnvironmentSuite) TestSessionTagMarksTheDay() {
+	log := DailyLog{
+		PlannedSessions: []TrainingSession{{Type: TrainingTypeRun, DurationMin: 30, Environment: []EnvironmentCondition{EnvironmentAltitude}}},
+	}
+	s.Equal([]EnvironmentCondition{EnvironmentAltitude}, log.DayEnvironment())
+	s.Empty(BuildEnvironmentNotes([]DailyLog{{Date: "2026-07-15"}}))
+}
@@ -0,0 +1,258 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// EQUIPMENT AVAILABILITY
+// =============================================================================
+//
+// The user's equipment inventory (with an optional temporary travel override)
+// decides which movements and programs are usable. Movements needing missing
+// equipment are swapped for bodyweight/band alternatives from the same
+// progression family, falling back to the same movement category.
+
+// EquipmentProfile is the user's equipment inventory plus an optional travel override.
+type EquipmentProfile struct {
+	Inventory  []EquipmentType     `json:"inventory"`
+	TravelMode *TravelModeOverride `json:"travelMode,omitempty"`
+}
+
+// TravelModeOverride temporarily replaces the inventory until (and including) Until.
+type TravelModeOverride struct {
+	Equipment []EquipmentType `json:"equipment"`
+	Until     string          `json:"until"` // YYYY-MM-DD, inclusive
+}
+
+// EquipmentAvailability is the set of equipment usable today.
+// A nil set means the inventory is unknown and nothing is restricted.
+type EquipmentAvailability map[EquipmentType]bool
+
+// Has reports whether a piece of equipment is usable. Bodyweight is always available.
+func (a EquipmentAvailability) Has(e EquipmentType) bool {
+	if a == nil || e == EquipmentTypeBodyweight {
+		return true
+	}
+	return a[e]
+}
+
+// HasAll reports whether every piece of equipment in the list is usable.
+func (a EquipmentAvailability) HasAll(required []EquipmentType) bool {
+	for _, e := range required {
+		if !a.Has(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the inventory and travel override.
+func (p EquipmentProfile) Validate() error {
+	for _, e := range p.Inventory {
+		if !ValidEquipmentTypes[e] {
+			return ErrInvalidEquipmentType
+		}
+	}
+	if p.TravelMode != nil {
+		if _, err := time.Parse("2006-01-02", p.TravelMode.Until); err != nil {
+			return ErrInvalidTravelModeUntil
+		}
+		for _, e := range p.TravelMode.Equipment {
+			if !ValidEquipmentTypes[e] {
+				return ErrInvalidEquipmentType
+			}
+		}
+	}
+	return nil
+}
+
+// IsTraveling reports whether the travel override applies on the given date.
+func (p EquipmentProfile) IsTraveling(today string) bool {
+	return p.TravelMode != nil && today <= p.TravelMode.Until
+}
+
+// AvailableOn returns the equipment usable on the given date (YYYY-MM-DD).
+// An active travel override replaces the inventory. An empty inventory with no
+// travel override is treated as unknown (unrestricted).
+func (p EquipmentProfile) AvailableOn(today string) EquipmentAvailability {
+	items := p.Inventory
+	if p.IsTraveling(today) {
+		items = p.TravelMode.Equipment
+	} else if len(items) == 0 {
+		return nil
+	}
+
+	avail := EquipmentAvailability{EquipmentTypeBodyweight: true}
+	for _, e := range items {
+		avail[e] = true
+	}
+	return avail
+}
+
+// IsMovementAvailable reports whether a movement's required equipment is usable.
+func IsMovementAvailable(m Movement, avail EquipmentAvailability) bool {
+	return avail.HasAll(m.Equipment)
+}
+
+// ProgressionFamily returns the progression chain a movement belongs to,
+// i.e. its ProgressionID without the trailing step number ("pull_vert_02" → "pull_vert").
+func ProgressionFamily(progressionID string) string {
+	i := strings.LastIndex(progressionID, "_")
+	if i <= 0 {
+		return progressionID
+	}
+	return progressionID[:i]
+}
+
+// isPortableMovement reports whether a movement needs only bodyweight or bands.
+func isPortableMovement(m Movement) bool {
+	for _, e := range m.Equipment {
+		if e != EquipmentTypeBodyweight && e != EquipmentTypeBands {
+			return false
+		}
+	}
+	return true
+}
+
+// SubstituteMovement returns an available alternative for a movement whose
+// equipment is missing. Candidates from the same progression family are
+// preferred over the same category; within a tier, bodyweight/band movements
+// closest in difficulty (easier first on ties) win. Returns nil if the movement
+// is already available or nothing fits.
+func SubstituteMovement(m Movement, catalog []Movement, avail EquipmentAvailability) *Movement {
+	if IsMovementAvailable(m, avail) {
+		return nil
+	}
+
+	family := ProgressionFamily(m.ProgressionID)
+	var best *Movement
+	bestRank := [3]int{}
+	for i := range catalog {
+		c := catalog[i]
+		if c.ID == m.ID || !IsMovementAvailable(c, avail) {
+			continue
+		}
+
+		tier := 2
+		switch {
+		case ProgressionFamily(c.ProgressionID) == family:
+			tier = 0
+		case c.Category == m.Category:
+			tier = 1
+		}
+		if tier == 2 {
+			continue
+		}
+
+		portable := 1
+		if isPortableMovement(c) {
+			portable = 0
+		}
+		diff := c.Difficulty - m.Difficulty
+		distance := 2 * diff
+		if diff < 0 {
+			distance = -2*diff - 1 // Prefer easier over harder at the same distance
+		}
+
+		rank := [3]int{tier, portable, distance}
+		if best == nil || lessRank(rank, bestRank) {
+			best = &catalog[i]
+			bestRank = rank
+		}
+	}
+	return best
+}
+
+func lessRank(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// SubstituteMovements replaces unavailable movements with available alternatives.
+// Movements without a usable substitute are dropped; duplicates are removed.
+// Order follows the input list.
+func SubstituteMovements(movements, catalog []Movement, avail EquipmentAvailability) []Movement {
+	if avail == nil {
+		return movements
+	}
+	result := make([]Movement, 0, len(movements))
+	seen := make(map[string]bool)
+	for _, m := range movements {
+		chosen := &m
+		if !IsMovementAvailable(m, avail) {
+			chosen = SubstituteMovement(m, catalog, avail)
+			if chosen == nil {
+				continue
+			}
+		}
+		if seen[chosen.ID] {
+			continue
+		}
+		seen[chosen.ID] = true
+		result = append(result, *chosen)
+	}
+	return result
+}
+
+// SubstituteSessionExercises swaps catalog movements in a runner's exercise flow
+// for available alternatives. Exercises not in the movement catalog are kept as-is.
+// Exercises without a usable substitute are kept so the runner flow stays intact.
+func SubstituteSessionExercises(exercises []SessionExercise, catalog []Movement, avail EquipmentAvailability) []SessionExercise {
+	if avail == nil || len(exercises) == 0 {
+		return exercises
+	}
+	byID := make(map[string]Movement, len(catalog))
+	for _, m := range catalog {
+		byID[m.ID] = m
+	}
+
+	result := make([]SessionExercise, len(exercises))
+	for i, ex := range exercises {
+		result[i] = ex
+		m, ok := byID[ex.ExerciseID]
+		if !ok {
+			continue
+		}
+		if sub := SubstituteMovement(m, catalog, avail); sub != nil {
+			result[i].ExerciseID = sub.ID
+		}
+	}
+	return result
+}
+
+// ProgramEquipmentFit describes how well a program matches the available equipment.
+type ProgramEquipmentFit struct {
+	Program          *TrainingProgram `json:"-"`
+	Available        bool             `json:"available"`
+	MissingEquipment []EquipmentType  `json:"missingEquipment"`
+}
+
+// RankProgramsByEquipment orders programs so those fully usable with the
+// available equipment come first, then by fewest missing items. Stable for ties.
+func RankProgramsByEquipment(programs []*TrainingProgram, avail EquipmentAvailability) []ProgramEquipmentFit {
+	fits := make([]ProgramEquipmentFit, len(programs))
+	for i, p := range programs {
+		missing := make([]EquipmentType, 0)
+		for _, e := range p.Equipment {
+			if !avail.Has(e) {
+				missing = append(missing, e)
+			}
+		}
+		fits[i] = ProgramEquipmentFit{
+			Program:          p,
+			Available:        len(missing) == 0,
+			MissingEquipment: missing,
+		}
+	}
+	sort.SliceStable(fits, func(i, j int) bool {
+		return len(fits[i].MissingEquipment) < len(fits[j].MissingEquipment)
+	})
+	return fits
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EquipmentSuite struct {
+	suite.Suite
+	catalog []Movement
+}
+
+func TestEquipmentSuite(t *testing.T) {
+	suite.Run(t, new(EquipmentSuite))
+}
+
+func (s *EquipmentSuite) SetupTest() {
+	s.catalog = SeedMovements()
+}
+
+func (s *EquipmentSuite) movement(id string) Movement {
+	for _, m := range s.catalog {
+		if m.ID == id {
+			return m
+		}
+	}
+	s.FailNow("movement not in catalog: " + id)
+	return Movement{}
+}
+
+func (s *EquipmentSuite) TestAvailableOn() {
+	home := EquipmentProfile{Inventory: []EquipmentType{EquipmentTypePullupBar, EquipmentTypeDumbbell}}
+
+	s.Run("empty inventory is unrestricted", func() {
+		avail := EquipmentProfile{}.AvailableOn("2025-03-01")
+		s.Nil(avail)
+		s.True(avail.Has(EquipmentTypeBarbell))
+	})
+
+	s.Run("inventory plus bodyweight", func() {
+		avail := home.AvailableOn("2025-03-01")
+		s.True(avail.Has(EquipmentTypePullupBar))
+		s.True(avail.Has(EquipmentTypeBodyweight))
+		s.False(avail.Has(EquipmentTypeBarbell))
+	})
+
+	s.Run("travel override replaces inventory through its end date", func() {
+		traveling := home
+		traveling.TravelMode = &TravelModeOverride{Equipment: []EquipmentType{EquipmentTypeBands}, Until: "2025-03-10"}
+
+		during := traveling.AvailableOn("2025-03-10")
+		s.True(traveling.IsTraveling("2025-03-10"))
+		s.True(during.Has(EquipmentTypeBands))
+		s.False(during.Has(EquipmentTypePullupBar))
+
+		after := traveling.AvailableOn("2025-03-11")
+		s.False(traveling.IsTraveling("2025-03-11"))
+		s.True(after.Has(EquipmentTypePullupBar))
+	})
+
+	s.Run("bodyweight-only travel restricts even with empty inventory", func() {
+		ep := EquipmentProfile{TravelMode: &TravelModeOverride{Until: "2025-03-10"}}
+		avail := ep.AvailableOn("2025-03-05")
+		s.NotNil(avail)
+		s.False(avail.Has(EquipmentTypePullupBar))
+	})
+}
+
+func (s *EquipmentSuite) TestValidate() {
+	s.NoError(EquipmentProfile{Inventory: []EquipmentType{EquipmentTypeBands}}.Validate())
+	s.ErrorIs(EquipmentProfile{Inventory: []EquipmentType{"trampoline"}}.Validate(), ErrInvalidEquipmentType)
+	s.ErrorIs(EquipmentProfile{TravelMode: &TravelModeOverride{Until: "next week"}}.Validate(), ErrInvalidTravelModeUntil)
+}
+
+func (s *EquipmentSuite) TestProgressionFamily() {
+	s.Equal("pull_vert", ProgressionFamily("pull_vert_02"))
+	s.Equal("loco", ProgressionFamily("loco_01"))
+	s.Equal("skill", ProgressionFamily("skill"))
+}
+
+func (s *EquipmentSuite) TestSubstituteMovement() {
+	noBars := EquipmentAvailability{EquipmentTypeBodyweight: true}
+
+	s.Run("available movement needs no substitute", func() {
+		s.Nil(SubstituteMovement(s.movement("cali_pushup_std"), s.catalog, noBars))
+	})
+
+	s.Run("prefers same progression family", func() {
+		withBench := EquipmentAvailability{EquipmentTypeBench: true}
+		sub := SubstituteMovement(s.movement("cali_dips_pbar"), s.catalog, withBench)
+		s.Require().NotNil(sub)
+		s.Equal("cali_dips_bench", sub.ID)
+	})
+
+	s.Run("falls back to same category closest in difficulty", func() {
+		sub := SubstituteMovement(s.movement("cali_dips_pbar"), s.catalog, noBars)
+		s.Require().NotNil(sub)
+		s.Equal("cali_pike_press", sub.ID, "difficulty 6 push without bars")
+	})
+
+	s.Run("nothing fits when the whole category needs missing equipment", func() {
+		s.Nil(SubstituteMovement(s.movement("cali_pullup_std"), s.catalog, noBars))
+	})
+}
+
+func (s *EquipmentSuite) TestSubstituteMovements() {
+	noBars := EquipmentAvailability{EquipmentTypeBodyweight: true}
+	input := []Movement{s.movement("cali_dips_pbar"), s.movement("cali_pike_press"), s.movement("cali_pullup_std")}
+
+	result := SubstituteMovements(input, s.catalog, noBars)
+	s.Require().Len(result, 1, "substitute deduplicated against the original, pull-up dropped")
+	s.Equal("cali_pike_press", result[0].ID)
+
+	s.Equal(input, SubstituteMovements(input, s.catalog, nil), "unknown inventory leaves movements untouched")
+}
+
+func (s *EquipmentSuite) TestSubstituteSessionExercises() {
+	exercises := []SessionExercise{
+		{ExerciseID: "hip_circles", Phase: SessionPhasePrepare, Order: 1},
+		{ExerciseID: "cali_dips_pbar", Phase: SessionPhasePush, Order: 1},
+		{ExerciseID: "cali_pullup_std", Phase: SessionPhasePush, Order: 2},
+	}
+
+	result := SubstituteSessionExercises(exercises, s.catalog, EquipmentAvailability{EquipmentTypeBodyweight: true})
+	s.Equal("hip_circles", result[0].ExerciseID, "non-catalog exercises are kept")
+	s.Equal("cali_pike_press", result[1].ExerciseID)
+	s.Equal("cali_pullup_std", result[2].ExerciseID, "kept when no substitute exists")
+	s.Equal("cali_dips_pbar", exercises[1].ExerciseID, "input is not mutated")
+}
+
+func (s *EquipmentSuite) TestRankProgramsByEquipment() {
+	barbell := &TrainingProgram{Name: "5x5", Equipment: []EquipmentType{EquipmentTypeBarbell}}
+	gym := &TrainingProgram{Name: "Gym", Equipment: []EquipmentType{EquipmentTypeBarbell, EquipmentTypeMachine}}
+	bodyweight := &TrainingProgram{Name: "GMB", Equipment: []EquipmentType{EquipmentTypeBodyweight}}
+
+	fits := RankProgramsByEquipment([]*TrainingProgram{gym, barbell, bodyweight}, EquipmentAvailability{EquipmentTypeBands: true})
+	s.Require().Len(fits, 3)
+	s.Equal("GMB", fits[0].Program.Name)
+	s.True(fits[0].Available)
+	s.Equal("5x5", fits[1].Program.Name)
+	s.Equal([]EquipmentType{EquipmentTypeBarbell}, fits[1].MissingEquipment)
+	s.Equal("Gym", fits[2].Program.Name)
+	s.False(fits[2].Available)
+}
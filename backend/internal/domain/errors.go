@@ -45,14 +45,22 @@ var (
 	ErrInvalidTDEESource             = newValidationError("TDEE source must be 'formula', 'manual', or 'adaptive'")
 	ErrInvalidManualTDEE             = newValidationError("manual TDEE must be between 800 and 10000 kcal when source is 'manual'")
 	ErrInvalidRecalibrationTolerance = newValidationError("recalibration tolerance must be between 1 and 10%")
+	ErrInvalidCalorieTolerance       = newValidationError("calorie adherence tolerance must be between 2 and 30%")
+	ErrInvalidProteinFloor           = newValidationError("protein floor must be between 50 and 100% of target")
+	ErrInvalidFatMinimum             = newValidationError("fat minimum must be between 30 and 100% of target")
+	ErrInvalidCarbTolerance          = newValidationError("carb adherence tolerance must be between 5 and 50%")
 	ErrInvalidFastingProtocol        = newValidationError("fasting protocol must be 'standard', '16_8', or '20_4'")
 	ErrInvalidEatingWindow           = newValidationError("eating window times must be in HH:MM format")
+	ErrInvalidWeightTrendMethod      = newValidationError("weight trend method must be 'ema', 'hull', or 'loess'")
+	ErrInvalidWeightTrendWindow      = newValidationError("weight trend window must be between 3 and 60 samples")
+	ErrInvalidLogLockDays            = newValidationError("log lock days must be between 0 (off) and 90")
 )
 
 // DailyLog validation errors
 var (
 	ErrInvalidDate               = newValidationError("date must be in YYYY-MM-DD format")
 	ErrInvalidWeight             = newValidationError("weight must be between 30 and 300 kg")
+	ErrInvalidWeighInTime        = newValidationError("weigh-in time must be in HH:MM format")
 	ErrInvalidBodyFat            = newValidationError("body fat must be between 3 and 70%")
 	ErrInvalidHeartRate          = newValidationError("resting heart rate must be between 30 and 200 bpm")
 	ErrInvalidHRV                = newValidationError("HRV must be between 10 and 200 ms")
@@ -64,20 +72,37 @@ var (
 	ErrInvalidSessionOrder       = newValidationError("session order must be sequential starting at 1")
 	ErrInvalidPerceivedIntensity = newValidationError("perceived intensity must be between 1 and 10")
 	ErrTooManySessions           = newValidationError("maximum 10 training sessions allowed per day")
+	ErrInvalidBedtime            = newValidationError("bedtime must be in HH:MM format")
+	ErrInvalidSessionStartTime   = newValidationError("session start time must be in HH:MM format")
+	ErrInvalidSessionHeartRate   = newValidationError("session average heart rate must be between 30 and 250 bpm")
+	ErrInvalidMealTime           = newValidationError("meal time must be in HH:MM format")
 )
 
 // NutritionPlan validation errors
 var (
-	ErrInvalidPlanStatus        = newValidationError("plan status must be 'active', 'completed', 'abandoned', or 'paused'")
-	ErrInvalidPlanStartDate     = newValidationError("plan start date must be in YYYY-MM-DD format")
-	ErrPlanStartDateTooOld      = newValidationError("plan start date cannot be more than 7 days in the past")
-	ErrInvalidPlanStartWeight   = newValidationError("plan start weight must be between 30 and 300 kg")
-	ErrInvalidPlanGoalWeight    = newValidationError("plan goal weight must be between 30 and 300 kg")
-	ErrInvalidPlanDuration      = newValidationError("plan duration must be between 4 and 104 weeks")
-	ErrPlanDeficitTooAggressive = newValidationError("plan deficit exceeds safe limit of 750 kcal/day (~0.75 kg/week loss)")
-	ErrPlanSurplusTooAggressive = newValidationError("plan surplus exceeds safe limit of 500 kcal/day (~0.5 kg/week gain)")
-	ErrActivePlanExists         = newValidationError("an active nutrition plan already exists")
-	ErrPlanNotFound             = newValidationError("nutrition plan not found")
+	ErrInvalidPlanStatus                  = newValidationError("plan status must be 'active', 'completed', 'abandoned', or 'paused'")
+	ErrInvalidPlanStartDate               = newValidationError("plan start date must be in YYYY-MM-DD format")
+	ErrPlanStartDateTooOld                = newValidationError("plan start date cannot be more than 7 days in the past")
+	ErrInvalidPlanStartWeight             = newValidationError("plan start weight must be between 30 and 300 kg")
+	ErrInvalidPlanGoalWeight              = newValidationError("plan goal weight must be between 30 and 300 kg")
+	ErrInvalidPlanDuration                = newValidationError("plan duration must be between 4 and 104 weeks")
+	ErrPlanDeficitTooAggressive           = newValidationError("plan deficit exceeds safe limit of 750 kcal/day (~0.75 kg/week loss)")
+	ErrPlanSurplusTooAggressive           = newValidationError("plan surplus exceeds safe limit of 500 kcal/day (~0.5 kg/week gain)")
+	ErrInvalidKcalFactor                  = newValidationError("kcal factor must be between 22 and 40 kcal/kg")
+	ErrKcalFactorAutoTuneRequiresOverride = newValidationError("kcal factor auto-tuning requires a kcal factor override")
+	ErrInvalidPlanMode                    = newValidationError("plan mode must be 'weight', 'recomp', or 'event'")
+	ErrRecompBodyFatRequired              = newValidationError("recomp plans require start and goal body fat percentages")
+	ErrInvalidPlanBodyFat                 = newValidationError("plan body fat must be between 3 and 70 percent")
+	ErrRecompGoalNotLower                 = newValidationError("recomp goal body fat must be lower than start body fat")
+	ErrRecompTooAggressive                = newValidationError("recomp body fat goal exceeds 0.25 percentage points per week")
+	ErrRecompWeightOutsideBand            = newValidationError("recomp goal weight must be within 2 kg of start weight")
+	ErrEventDateRequired                  = newValidationError("event plans require an event date")
+	ErrInvalidEventDate                   = newValidationError("event date must be in YYYY-MM-DD format")
+	ErrEventDateOutsidePlan               = newValidationError("event date must fall in the final week of the plan")
+	ErrEventTimelineFixed                 = newValidationError("event plans cannot extend their timeline past the event date")
+	ErrPlanNotEvent                       = newValidationError("plan is not an event plan")
+	ErrActivePlanExists                   = newValidationError("an active nutrition plan already exists")
+	ErrPlanNotFound                       = newValidationError("nutrition plan not found")
 )
 
 // Dual-Track Analysis errors
@@ -91,6 +116,8 @@ var (
 var (
 	ErrInvalidMuscleGroup = newValidationError("invalid muscle group")
 	ErrInvalidArchetype   = newValidationError("invalid workout archetype")
+	ErrInvalidBodySide    = newValidationError("side must be left or right")
+	ErrInvalidSideShare   = newValidationError("left shares must be between 0 and 1 and only for limb muscles")
 )
 
 // Progression Pattern validation errors
@@ -106,6 +133,7 @@ var (
 	ErrInvalidProgramDifficulty     = newValidationError("program difficulty must be 'beginner', 'intermediate', or 'advanced'")
 	ErrInvalidProgramFocus          = newValidationError("program focus must be 'hypertrophy', 'strength', 'conditioning', or 'general'")
 	ErrInvalidEquipmentType         = newValidationError("invalid equipment type")
+	ErrInvalidTravelModeUntil       = newValidationError("travel mode end date must be in YYYY-MM-DD format")
 	ErrInvalidProgramStatus         = newValidationError("program status must be 'template', 'draft', or 'published'")
 	ErrInvalidInstallationStatus    = newValidationError("installation status must be 'active', 'completed', or 'abandoned'")
 	ErrInvalidProgramName           = newValidationError("program name is required")
@@ -124,6 +152,16 @@ var (
 	ErrProgramNotFound              = newValidationError("training program not found")
 	ErrActiveInstallationExists     = newValidationError("an active program installation already exists")
 	ErrInstallationNotFound         = newValidationError("program installation not found")
+	ErrInstallationNotActive        = newValidationError("only an active program installation can be rescheduled")
+	ErrInvalidRescheduleDates       = newValidationError("reschedule dates must be two different dates in YYYY-MM-DD format")
+	ErrRescheduleOutsideWeek        = newValidationError("program days can only be swapped within the current program week")
+	ErrRescheduleInPast             = newValidationError("program days cannot be moved to or from a past date")
+	ErrRescheduleDayTrained         = newValidationError("training is already logged on that day")
+	ErrRescheduleDayOccupied        = newValidationError("tomorrow already has a program session; swap the days instead")
+	ErrNoSessionToReschedule        = newValidationError("no program session is scheduled on the given days")
+	ErrInvalidAutoregulationBound   = newValidationError("autoregulation bound must be 0 (off) or between 0.05 and 0.25")
+	ErrInvalidWaveShape             = newValidationError("wave shape must be 'linear', 'undulating', or 'step'")
+	ErrInvalidDeloadCadence         = newValidationError("deload cadence must be 0 (none) or every 3 to 8 weeks")
 
 	// Session exercise (Block Constructor) validation errors
 	ErrInvalidSessionPhase           = newValidationError("session phase must be 'prepare', 'practice', or 'push'")
@@ -145,7 +183,235 @@ var (
 // Voice command parsing errors
 var (
 	ErrNilVoiceCommand    = newValidationError("voice command result is nil")
-	ErrInvalidVoiceIntent = newValidationError("voice intent must be 'TRAINING', 'NUTRITION', or 'BIOMETRICS'")
+	ErrInvalidVoiceIntent = newValidationError("voice intent must be 'TRAINING', 'NUTRITION', 'BIOMETRICS', or 'PLANNING'")
 	ErrMissingVoiceData   = newValidationError("missing required data for voice command intent")
 	ErrInvalidVoiceData   = newValidationError("invalid voice command data")
+
+	ErrUnclearVoiceAnswer       = newValidationError("answer doesn't fill in what the question asked for")
+	ErrVoiceClarificationClosed = newValidationError("voice command is no longer waiting for an answer")
+)
+
+// Food matching errors
+var (
+	ErrEmptyFoodQuery = newValidationError("food name is required")
+)
+
+// Solver scoring and feedback errors
+var (
+	ErrSolverIngredientsRequired     = newValidationError("at least one ingredient is required to score")
+	ErrInvalidSolverIngredientAmount = newValidationError("ingredient amounts must be between 0 and 2000 g")
+	ErrInvalidSolverFeedbackAction   = newValidationError("solver feedback action must be 'accepted', 'rejected', or 'edited'")
+	ErrSolverFeedbackFoodsRequired   = newValidationError("solver feedback requires the suggested foods, and the logged foods for edits")
+	ErrInvalidMealHunger             = newValidationError("meal hunger must be between 1 and 5")
+)
+
+// Meal template errors
+var (
+	ErrMealTemplateNameRequired = newValidationError("meal template name is required")
+	ErrInvalidMealName          = newValidationError("meal must be 'breakfast', 'lunch', or 'dinner'")
+	ErrMealTemplateEmpty        = newValidationError("meal template must contain at least one food")
+	ErrMealTemplateTooManyItems = newValidationError("meal template can contain at most 20 foods")
+	ErrInvalidMealTemplateItem  = newValidationError("meal template items require a foodReferenceId and grams > 0")
+	ErrNothingToCopy            = newValidationError("source day has no logged meals to copy")
+)
+
+// Meal item errors
+var (
+	ErrMealItemFoodRequired = newValidationError("foodReferenceId is required")
+	ErrInvalidMealItemGrams = newValidationError("grams must be greater than 0 and at most 5000")
+)
+
+// Adherence calendar errors
+var (
+	ErrInvalidExemptionReason = newValidationError("exemption reason must be 'sick' or 'travel'")
+)
+
+// Weekly target editing errors
+var (
+	ErrWeeklyTargetInPast        = newValidationError("weeks that have already ended cannot be edited")
+	ErrInvalidWeeklyTargetMacros = newValidationError("weekly target macros cannot be negative")
+	ErrWeeklyTargetMacroMismatch = newValidationError("weekly target macros do not add up to the target intake (within 5%)")
+	ErrWeeklyTargetUnsafe        = newValidationError("weekly target intake must stay within the safe deficit (750 kcal) and surplus (500 kcal) of projected TDEE")
+)
+
+// Session template errors
+var (
+	ErrSessionTemplateNameRequired     = newValidationError("session template name is required")
+	ErrSessionTemplateTooManyExercises = newValidationError("session template can contain at most 30 exercises")
+	ErrInvalidSessionTemplateExercise  = newValidationError("session template exercises require a name and non-negative sets and reps")
+	ErrSessionNotFoundOnDay            = newValidationError("no session with that order is logged on the source day")
+)
+
+// API token errors
+var (
+	ErrInvalidAPITokenName    = newValidationError("token name is required and can be at most 100 characters")
+	ErrAPITokenScopesRequired = newValidationError("token requires at least one scope")
+	ErrInvalidTokenScope      = newValidationError("token scope must be 'read', 'logs:write', or 'admin'")
+	ErrAPITokenExpiryInPast   = newValidationError("token expiry must be in the future")
+)
+
+// Semantic search errors
+var (
+	ErrInvalidSemanticQuery = newValidationError("search query is required and can be at most 200 characters")
+	ErrInvalidSemanticLimit = newValidationError("search limit must be between 1 and 50")
+)
+
+// Cross-entity search errors
+var (
+	ErrInvalidSearchQuery = newValidationError("search query is required and can be at most 200 characters")
+	ErrInvalidSearchLimit = newValidationError("search limit must be between 1 and 50")
+	ErrInvalidSearchKind  = newValidationError("search types must be from: note, session, program_day, session_template, food, movement, program, day")
+)
+
+// Annual review errors
+var (
+	ErrInvalidReviewYear = newValidationError("review year must be between 2000 and the current year, and the year must have started")
+)
+
+// Food price errors
+var (
+	ErrInvalidFoodPrice  = newValidationError("price must be greater than 0 and at most 10000")
+	ErrInvalidPriceBasis = newValidationError("price basis must be 'serving' or '100g'")
+	ErrInvalidMaxCost    = newValidationError("max cost must be greater than 0")
+	ErrInvalidCostWeeks  = newValidationError("weeks must be between 1 and 52")
+)
+
+// Grocery list errors
+var (
+	ErrInvalidGroceryItems      = newValidationError("grocery list must have between 1 and 100 items")
+	ErrInvalidGroceryItemAmount = newValidationError("grocery item amount must be greater than 0 and at most 20000g")
+	ErrFuturePurchaseDate       = newValidationError("purchase date cannot be in the future")
+)
+
+// Caffeine errors
+var (
+	ErrInvalidCaffeineTime   = newValidationError("caffeine time must be in HH:MM format")
+	ErrInvalidCaffeineSource = newValidationError("caffeine source must be one of: coffee, espresso, tea, energy_drink, soda, pre_workout, supplement, other")
+	ErrInvalidCaffeineAmount = newValidationError("caffeine amount must be greater than 0 and at most 1000mg")
+)
+
+// Check-in errors
+var (
+	ErrInvalidCheckIn     = newValidationError("mood, stress, soreness and hunger must each be between 1 and 5")
+	ErrInvalidRollupMonth = newValidationError("month must be in YYYY-MM format")
+)
+
+// Environment errors
+var (
+	ErrInvalidEnvironmentCondition = newValidationError("environment condition must be one of: hot, humid, altitude")
+)
+
+// Personal record errors
+var (
+	ErrInvalidRecordMovement = newValidationError("movementId is required")
+	ErrInvalidRecordKind     = newValidationError("record kind must be one of: heaviest_load, longest_hold, fastest_circuit")
+	ErrInvalidRecordSource   = newValidationError("record source must be one of: set_log, echo, manual")
+	ErrInvalidRecordDate     = newValidationError("record date must be in YYYY-MM-DD format")
+	ErrInvalidRecordValue    = newValidationError("record value must be greater than 0 and at most 500kg or 7200 seconds")
+)
+
+// Substitution errors
+var (
+	ErrInvalidSubstitutionDate      = newValidationError("substitution date must be in YYYY-MM-DD format")
+	ErrInvalidSubstitutionMovements = newValidationError("originalId and substituteId are required and must differ")
+	ErrInvalidSubstitutionMuscle    = newValidationError("fatiguedMuscles must be valid muscle groups")
+)
+
+// Logging lock errors
+var (
+	ErrDayLocked              = newValidationError("day is locked; open a retro-edit with a reason to change it")
+	ErrDayNotLocked           = newValidationError("day is not locked and can be edited directly")
+	ErrInvalidRetroEditReason = newValidationError("retro-edit reason is required and can be at most 500 characters")
+)
+
+// Note sync errors
+var (
+	ErrInvalidNoteResolution = newValidationError("resolution must be one of: keep, restore, merge, custom")
+	ErrNoteConflictResolved  = newValidationError("note conflict is already resolved")
+)
+
+// Archetype inference errors
+var (
+	ErrInvalidArchetypeConfidence = newValidationError("archetype confidence must be between 0 and 1")
+	ErrArchetypeRequired          = newValidationError("archetype is required when the review has no suggestion")
+	ErrArchetypeReviewResolved    = newValidationError("archetype review is already resolved")
+)
+
+// Strength rotation errors
+var (
+	ErrInvalidStrengthSplit = newValidationError("split must be one of: push_pull_legs, upper_lower, full_body")
+	ErrArchetypeNotInSplit  = newValidationError("next archetype must be part of the split")
+)
+
+// Monthly summary errors
+var (
+	ErrInvalidMonthRange = newValidationError("from and to must be in YYYY-MM format with from not after to")
+)
+
+// Plan completion errors
+var (
+	ErrPlanNotCompletable = newValidationError("only an active or paused plan can be completed")
+)
+
+// Plan exit survey errors
+var (
+	ErrInvalidAbandonReason = newValidationError("abandon reasons must be one of: too_aggressive, life_event, injury, illness, lost_motivation, no_progress, other")
+	ErrAbandonNoteTooLong   = newValidationError("abandon note must be 500 characters or fewer")
+)
+
+// Recovery activity errors
+var (
+	ErrInvalidRecoveryActivityType      = newValidationError("recovery activity type must be one of: sauna, cold_plunge, massage, foam_rolling, compression")
+	ErrInvalidRecoveryActivityDuration  = newValidationError("recovery activity duration must be greater than 0 and at most the type's maxDurationMin")
+	ErrInvalidRecoveryActivityIntensity = newValidationError("recovery activity intensity must be between 1 and 5")
+)
+
+// Habit errors
+var (
+	ErrInvalidHabitName = newValidationError("habit name must be between 1 and 60 characters")
+	ErrHabitArchived    = newValidationError("archived habits can't be checked off")
+)
+
+// Challenge errors
+var (
+	ErrInvalidChallengeType     = newValidationError("challenge type must be one of: protein_target, no_missed_sessions, daily_logging")
+	ErrInvalidChallengeDuration = newValidationError("challenge duration must be between 7 and 90 days")
+	ErrInvalidChallengeMisses   = newValidationError("allowed misses must be at least 0 and fewer than the challenge's days")
+	ErrInvalidChallengeName     = newValidationError("challenge name must be 60 characters or fewer")
+	ErrChallengeNotActive       = newValidationError("only active challenges can be abandoned")
+)
+
+// Injury risk errors
+var (
+	ErrInvalidInjuryRiskMonth = newValidationError("month must be in YYYY-MM format and not after the current month")
+)
+
+// Wearable sync errors
+var (
+	ErrInvalidWearableSource   = newValidationError("source must be one of: garmin, apple_health")
+	ErrEmptyWearablePayload    = newValidationError("payload must contain at least one day or activity")
+	ErrTooManyWearableRecords  = newValidationError("payload must contain at most 1000 days and activities")
+	ErrInvalidWearableMetric   = newValidationError("wearable metric out of range: hrvMs 1-300, restingHr 20-150, sleepHours 0-24, sleepScore 1-100, calories 0-10000")
+	ErrInvalidWearableActivity = newValidationError("activity type is required")
+)
+
+// Export errors
+var (
+	ErrInvalidExportFormat = newValidationError("format must be one of: json, csv")
+)
+
+// Restore errors
+var (
+	ErrInvalidExportArchive     = newValidationError("file is not a readable export archive")
+	ErrUnsupportedExportVersion = newValidationError("export archive version is newer than this server supports")
+	ErrInvalidRestoreTable      = newValidationError("conflict strategies can only name exported tables")
+	ErrInvalidRestoreStrategy   = newValidationError("conflict strategy must be one of: skip, overwrite, merge")
+)
+
+// Onboarding errors
+var (
+	ErrOnboardingStepOutOfOrder        = newValidationError("onboarding steps must be answered in order: basic_info, goal, ratios, first_plan")
+	ErrOnboardingComplete              = newValidationError("onboarding is already complete")
+	ErrOnboardingTargetDirection       = newValidationError("target weight must be below the current weight to lose weight and above it to gain")
+	ErrOnboardingWeeklyChangeDirection = newValidationError("weekly change must be negative to lose weight, positive to gain and zero to maintain")
 )
@@ -0,0 +1,345 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =============================================================================
+// EVENT-TARGETING PLANS
+// =============================================================================
+//
+// An event plan has to make a goal weight by a fixed weigh-in date, e.g. for
+// a weight-class competition. The date can't move, so:
+//   - extending the timeline is never offered as a recalibration,
+//   - the recalibration tolerance tightens as the event gets closer,
+//   - in the final week, any weight left to lose is treated as a water cut and
+//     flagged once it becomes unsafe,
+//   - the projection estimates the probability of making weight on the
+//     current trend, from the scatter of the weigh-ins around it.
+
+// EventUrgency grades how close an event plan is to its weigh-in.
+type EventUrgency string
+
+const (
+	EventUrgencyLow      EventUrgency = "low"      // More than 8 weeks out
+	EventUrgencyModerate EventUrgency = "moderate" // 4-8 weeks out
+	EventUrgencyHigh     EventUrgency = "high"     // 1-4 weeks out
+	EventUrgencyCritical EventUrgency = "critical" // Final week
+)
+
+// WaterCutSeverity grades a final-week water cut.
+type WaterCutSeverity string
+
+const (
+	WaterCutInfo    WaterCutSeverity = "info"
+	WaterCutCaution WaterCutSeverity = "caution"
+	WaterCutDanger  WaterCutSeverity = "danger"
+)
+
+const (
+	// EventFinalWeekDays is the countdown below which the event is in its final week.
+	EventFinalWeekDays = 7
+	// WaterCutCautionPercent and WaterCutDangerPercent are the share of body
+	// weight above which a final-week water cut is flagged.
+	WaterCutCautionPercent = 3.0
+	WaterCutDangerPercent  = 5.0
+	// MinEventProjectionSamples is the minimum weigh-ins for an event projection.
+	MinEventProjectionSamples = 5
+	// EventProjectionMinUncertaintyKg floors the projection's spread so a few
+	// unusually consistent weigh-ins don't claim certainty.
+	EventProjectionMinUncertaintyKg = 0.3
+)
+
+// eventToleranceFactor scales the recalibration tolerance by urgency: there is
+// less time to absorb a miss, so smaller variances need acting on.
+var eventToleranceFactor = map[EventUrgency]float64{
+	EventUrgencyLow:      1.0,
+	EventUrgencyModerate: 0.75,
+	EventUrgencyHigh:     0.5,
+	EventUrgencyCritical: 0.5,
+}
+
+// IsEvent returns true if the plan targets a weight by an event date.
+func (p *NutritionPlan) IsEvent() bool {
+	return p.Mode == PlanModeEvent
+}
+
+// setEvent parses the event date onto the plan. Without a duration the plan
+// runs up to the week containing the event.
+func (p *NutritionPlan) setEvent(eventDate, eventName string) error {
+	if eventDate == "" {
+		return ErrEventDateRequired
+	}
+	date, err := time.Parse("2006-01-02", eventDate)
+	if err != nil {
+		return ErrInvalidEventDate
+	}
+	p.EventDate = &date
+	p.EventName = eventName
+	if p.DurationWeeks == 0 {
+		days := int(date.Sub(p.StartDate).Hours() / 24)
+		p.DurationWeeks = int(math.Ceil(float64(days) / 7))
+	}
+	return nil
+}
+
+// validateEvent checks the event date falls in the plan's final week, so the
+// goal weight is due on the weigh-in.
+func (p *NutritionPlan) validateEvent() error {
+	if p.EventDate == nil {
+		return ErrEventDateRequired
+	}
+	finalWeekStart := p.StartDate.AddDate(0, 0, (p.DurationWeeks-1)*7)
+	planEnd := p.StartDate.AddDate(0, 0, p.DurationWeeks*7)
+	if p.EventDate.Before(finalWeekStart) || p.EventDate.After(planEnd) {
+		return ErrEventDateOutsidePlan
+	}
+	return nil
+}
+
+// DaysToEvent returns the whole days from now until the event date.
+// Negative once the event has passed; 0 for non-event plans.
+func (p *NutritionPlan) DaysToEvent(now time.Time) int {
+	if p.EventDate == nil {
+		return 0
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, p.EventDate.Location())
+	return int(math.Round(p.EventDate.Sub(today).Hours() / 24))
+}
+
+// EventUrgencyFor grades the countdown to an event.
+func EventUrgencyFor(daysToEvent int) EventUrgency {
+	switch {
+	case daysToEvent <= EventFinalWeekDays:
+		return EventUrgencyCritical
+	case daysToEvent <= 28:
+		return EventUrgencyHigh
+	case daysToEvent <= 56:
+		return EventUrgencyModerate
+	default:
+		return EventUrgencyLow
+	}
+}
+
+// WaterCutWarning flags the weight that would have to come off as water
+// before the weigh-in.
+type WaterCutWarning struct {
+	Severity           WaterCutSeverity
+	RequiredCutKg      float64
+	RequiredCutPercent float64 // Share of current body weight
+	Message            string
+}
+
+// WaterCutWarnings returns the final-week warning for making goalKg from
+// currentKg with daysToEvent left. Returns nil outside the final week, when
+// already at weight, or for weight-gain goals (there is nothing to cut).
+func WaterCutWarnings(currentKg, goalKg float64, daysToEvent int) []WaterCutWarning {
+	if daysToEvent < 0 || daysToEvent > EventFinalWeekDays || currentKg <= 0 {
+		return nil
+	}
+	cutKg := currentKg - goalKg
+	if cutKg <= 0 {
+		return nil
+	}
+	cutPercent := cutKg / currentKg * 100
+	warning := WaterCutWarning{
+		RequiredCutKg:      math.Round(cutKg*10) / 10,
+		RequiredCutPercent: math.Round(cutPercent*10) / 10,
+	}
+	switch {
+	case cutPercent > WaterCutDangerPercent:
+		warning.Severity = WaterCutDanger
+		warning.Message = fmt.Sprintf("Cutting %.1f kg (%.1f%% of body weight) in %d days is unsafe; move up a weight class rather than cut this much water", warning.RequiredCutKg, warning.RequiredCutPercent, daysToEvent)
+	case cutPercent > WaterCutCautionPercent:
+		warning.Severity = WaterCutCaution
+		warning.Message = fmt.Sprintf("Cutting %.1f kg (%.1f%% of body weight) as water will cost performance; cut only with supervision and rehydrate straight after weigh-in", warning.RequiredCutKg, warning.RequiredCutPercent)
+	default:
+		warning.Severity = WaterCutInfo
+		warning.Message = fmt.Sprintf("%.1f kg left to make weight; a short water cut in the last 24 hours covers this, rehydrate fully after weigh-in", warning.RequiredCutKg)
+	}
+	return []WaterCutWarning{warning}
+}
+
+// EventCountdown is the event section of a dual-track analysis.
+type EventCountdown struct {
+	EventName        string
+	EventDate        time.Time
+	DaysToEvent      int
+	Urgency          EventUrgency
+	TolerancePercent float64 // Recalibration tolerance after tightening for urgency
+	Warnings         []WaterCutWarning
+}
+
+// applyEventCountdown tightens an analysis for an event plan: the tolerance
+// shrinks with urgency, extending the timeline is dropped from the options,
+// and the final week carries water-cut warnings. Current weight is the
+// smoothed trend when available, else the rolling average.
+func applyEventCountdown(analysis *DualTrackAnalysis, input AnalysisInput) {
+	plan := input.Plan
+	if plan.EventDate == nil {
+		return
+	}
+	days := plan.DaysToEvent(input.AnalysisDate)
+	urgency := EventUrgencyFor(days)
+	tolerance := math.Round(analysis.TolerancePercent*eventToleranceFactor[urgency]*100) / 100
+
+	analysis.TolerancePercent = tolerance
+	if !analysis.GracePeriod && math.Abs(analysis.VariancePercent) >= tolerance {
+		analysis.RecalibrationNeeded = true
+		if analysis.Options == nil {
+			analysis.Options = generateRecalibrationOptions(plan, input.ActualWeightKg, analysis.VarianceKg, analysis.CurrentWeek)
+		}
+	}
+
+	options := analysis.Options[:0]
+	for _, opt := range analysis.Options {
+		if opt.Type != RecalibrationExtendTimeline {
+			options = append(options, opt)
+		}
+	}
+	if len(options) == 0 {
+		options = nil
+	}
+	analysis.Options = options
+
+	current := input.ActualWeightKg
+	if input.SmoothedWeight != nil && input.SmoothedWeight.CurrentTrendKg > 0 {
+		current = input.SmoothedWeight.CurrentTrendKg
+	}
+	analysis.Event = &EventCountdown{
+		EventName:        plan.EventName,
+		EventDate:        *plan.EventDate,
+		DaysToEvent:      days,
+		Urgency:          urgency,
+		TolerancePercent: tolerance,
+		Warnings:         WaterCutWarnings(current, plan.GoalWeightKg, days),
+	}
+}
+
+// EventProjection estimates where the current weight trend lands on the
+// event date and how likely that is to make weight.
+type EventProjection struct {
+	PlanID                 int64
+	EventName              string
+	EventDate              time.Time
+	DaysToEvent            int
+	Urgency                EventUrgency
+	CurrentWeightKg        float64 // Trend value today
+	GoalWeightKg           float64
+	ProjectedWeightKg      float64 // Trend value on the event date
+	WeeklyChangeKg         float64 // Current trend rate
+	RequiredWeeklyChangeKg float64 // Rate needed from today to make weight
+	UncertaintyKg          float64 // One standard deviation of the projection
+	MakeWeightProbability  float64 // 0-1
+	Samples                int
+	Points                 []ProjectionPoint // Weekly from today to the event
+	Warnings               []WaterCutWarning
+}
+
+// ProjectEvent fits a linear trend to the weigh-ins (ordered by date) and
+// extends it to the event date. The probability of making weight treats the
+// projection as normal with the regression's prediction error, so noisy
+// weigh-ins and far-off events both widen it. Making weight means at or below
+// the goal, or at or above it for a weight-gain plan.
+func ProjectEvent(plan *NutritionPlan, samples []WeightSample, now time.Time) (*EventProjection, error) {
+	if !plan.IsEvent() || plan.EventDate == nil {
+		return nil, ErrPlanNotEvent
+	}
+	days := plan.DaysToEvent(now)
+	if days < 0 {
+		return nil, ErrPlanEnded
+	}
+	if len(samples) < MinEventProjectionSamples {
+		return nil, ErrInsufficientWeightData
+	}
+
+	first, err := time.Parse("2006-01-02", samples[0].Date)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]regressionPoint, len(samples))
+	for i, sample := range samples {
+		d, err := time.Parse("2006-01-02", sample.Date)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = regressionPoint{x: d.Sub(first).Hours() / 24, y: sample.WeightKg}
+	}
+	regression := calculateLinearRegression(points)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, first.Location())
+	xNow := today.Sub(first).Hours() / 24
+	xEvent := xNow + float64(days)
+	current := regression.predict(xNow)
+	projected := regression.predict(xEvent)
+	uncertainty := predictionError(points, regression, xEvent)
+
+	z := (plan.GoalWeightKg - projected) / uncertainty
+	if plan.GoalWeightKg > plan.StartWeightKg {
+		z = -z
+	}
+	probability := 0.5 * (1 + math.Erf(z/math.Sqrt2))
+
+	required := plan.GoalWeightKg - current
+	if days > 0 {
+		required = required / float64(days) * 7
+	}
+
+	projection := &EventProjection{
+		PlanID:                 plan.ID,
+		EventName:              plan.EventName,
+		EventDate:              *plan.EventDate,
+		DaysToEvent:            days,
+		Urgency:                EventUrgencyFor(days),
+		CurrentWeightKg:        math.Round(current*10) / 10,
+		GoalWeightKg:           plan.GoalWeightKg,
+		ProjectedWeightKg:      math.Round(projected*10) / 10,
+		WeeklyChangeKg:         math.Round(regression.slope*7*100) / 100,
+		RequiredWeeklyChangeKg: math.Round(required*100) / 100,
+		UncertaintyKg:          math.Round(uncertainty*100) / 100,
+		MakeWeightProbability:  math.Round(probability*100) / 100,
+		Samples:                len(samples),
+		Warnings:               WaterCutWarnings(current, plan.GoalWeightKg, days),
+	}
+	for offset := 0; ; offset += 7 {
+		if offset > days {
+			offset = days
+		}
+		date := today.AddDate(0, 0, offset)
+		projection.Points = append(projection.Points, ProjectionPoint{
+			WeekNumber: plan.GetCurrentWeek(date),
+			Date:       date,
+			WeightKg:   math.Round(regression.predict(xNow+float64(offset))*10) / 10,
+		})
+		if offset == days {
+			break
+		}
+	}
+	return projection, nil
+}
+
+// predictionError returns the standard error of a single new observation at
+// x: residual scatter, widened by how far x sits from the fitted data.
+func predictionError(points []regressionPoint, regression regressionResult, x float64) float64 {
+	n := float64(len(points))
+	var sumX float64
+	for _, p := range points {
+		sumX += p.x
+	}
+	meanX := sumX / n
+
+	var ssRes, sxx float64
+	for _, p := range points {
+		residual := p.y - regression.predict(p.x)
+		ssRes += residual * residual
+		sxx += (p.x - meanX) * (p.x - meanX)
+	}
+	sigma := math.Sqrt(ssRes / (n - 2))
+	spread := 1 + 1/n
+	if sxx > 0 {
+		spread += (x - meanX) * (x - meanX) / sxx
+	}
+	return math.Max(sigma*math.Sqrt(spread), EventProjectionMinUncertaintyKg)
+}
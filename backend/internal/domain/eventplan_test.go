@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Event plans gate a competitor's weigh-in; tests pin the
+// event-date validation, the urgency bands and tolerance tightening, the
+// water-cut thresholds, and the make-weight probability's direction.
+type EventPlanSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+}
+
+func TestEventPlanSuite(t *testing.T) {
+	suite.Run(t, new(EventPlanSuite))
+}
+
+func (s *EventPlanSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	s.profile = &UserProfile{
+		HeightCM:     178,
+		BirthDate:    time.Date(1995, 4, 10, 0, 0, 0, 0, time.UTC),
+		Sex:          SexMale,
+		Goal:         GoalLoseWeight,
+		CarbRatio:    0.45,
+		ProteinRatio: 0.30,
+		FatRatio:     0.25,
+		BMREquation:  BMREquationMifflinStJeor,
+	}
+}
+
+func (s *EventPlanSuite) input() NutritionPlanInput {
+	return NutritionPlanInput{
+		StartDate:     s.now.Format("2006-01-02"),
+		StartWeightKg: 80,
+		GoalWeightKg:  76,
+		Mode:          PlanModeEvent,
+		EventDate:     s.now.AddDate(0, 0, 60).Format("2006-01-02"),
+		EventName:     "Regional Open",
+	}
+}
+
+func (s *EventPlanSuite) TestDurationDerivedFromEventDate() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+	s.Equal(9, plan.DurationWeeks) // 60 days falls in week 9
+	s.Equal("Regional Open", plan.EventName)
+	s.Equal(60, plan.DaysToEvent(s.now))
+}
+
+func (s *EventPlanSuite) TestValidation() {
+	in := s.input()
+	in.EventDate = ""
+	_, err := NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrEventDateRequired)
+
+	in = s.input()
+	in.EventDate = "next month"
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrInvalidEventDate)
+
+	in = s.input()
+	in.DurationWeeks = 12 // event lands in week 9, not the final week
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrEventDateOutsidePlan)
+
+	in = s.input()
+	in.DurationWeeks = 9
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.NoError(err)
+}
+
+func (s *EventPlanSuite) TestExtendTimelineRejected() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+	_, err = ApplyRecalibration(plan, s.profile, RecalibrationExtendTimeline, s.now.AddDate(0, 0, 14))
+	s.ErrorIs(err, ErrEventTimelineFixed)
+}
+
+func (s *EventPlanSuite) TestUrgencyBands() {
+	cases := []struct {
+		days int
+		want EventUrgency
+	}{
+		{90, EventUrgencyLow},
+		{57, EventUrgencyLow},
+		{56, EventUrgencyModerate},
+		{29, EventUrgencyModerate},
+		{28, EventUrgencyHigh},
+		{8, EventUrgencyHigh},
+		{7, EventUrgencyCritical},
+		{0, EventUrgencyCritical},
+	}
+	for _, tc := range cases {
+		s.Equal(tc.want, EventUrgencyFor(tc.days), "days=%d", tc.days)
+	}
+}
+
+func (s *EventPlanSuite) TestAnalysisTightensToleranceNearEvent() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+	plan.ID = 1
+
+	// Three weeks out: tolerance halves, so a 2% variance now needs acting on
+	date := s.now.AddDate(0, 0, 39)
+	planned := plan.GetWeeklyTarget(plan.GetCurrentWeek(date)).ProjectedWeightKg
+	analysis, err := CalculateDualTrackAnalysis(AnalysisInput{
+		Plan:             plan,
+		ActualWeightKg:   planned * 1.02,
+		TolerancePercent: 3,
+		AnalysisDate:     date,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(analysis.Event)
+	s.Equal(EventUrgencyHigh, analysis.Event.Urgency)
+	s.Equal(21, analysis.Event.DaysToEvent)
+	s.InDelta(1.5, analysis.TolerancePercent, 0.001)
+	s.True(analysis.RecalibrationNeeded)
+	s.NotEmpty(analysis.Options)
+	for _, opt := range analysis.Options {
+		s.NotEqual(RecalibrationExtendTimeline, opt.Type)
+	}
+	s.Empty(analysis.Event.Warnings, "no water-cut warnings before the final week")
+}
+
+func (s *EventPlanSuite) TestWaterCutWarnings() {
+	s.Nil(WaterCutWarnings(80, 76, 10), "outside the final week")
+	s.Nil(WaterCutWarnings(75.5, 76, 3), "already on weight")
+
+	cases := []struct {
+		current float64
+		want    WaterCutSeverity
+	}{
+		{77.5, WaterCutInfo},    // 1.9%
+		{79.0, WaterCutCaution}, // 3.8%
+		{81.0, WaterCutDanger},  // 6.2%
+	}
+	for _, tc := range cases {
+		warnings := WaterCutWarnings(tc.current, 76, 5)
+		s.Require().Len(warnings, 1, "current=%.1f", tc.current)
+		s.Equal(tc.want, warnings[0].Severity, "current=%.1f", tc.current)
+		s.InDelta(tc.current-76, warnings[0].RequiredCutKg, 0.05)
+	}
+}
+
+func (s *EventPlanSuite) samples(startKg, dailyChange float64, days int) []WeightSample {
+	samples := make([]WeightSample, days)
+	for i := range samples {
+		noise := 0.2
+		if i%2 == 0 {
+			noise = -0.2
+		}
+		samples[i] = WeightSample{
+			Date:     s.now.AddDate(0, 0, i).Format("2006-01-02"),
+			WeightKg: startKg + dailyChange*float64(i) + noise,
+		}
+	}
+	return samples
+}
+
+func (s *EventPlanSuite) TestProjectionProbability() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+	asOf := s.now.AddDate(0, 0, 27)
+
+	// Losing ~0.7 kg/week lands well under 76 kg by the event
+	onTrack, err := ProjectEvent(plan, s.samples(80, -0.1, 28), asOf)
+	s.Require().NoError(err)
+	s.Equal(33, onTrack.DaysToEvent)
+	s.Less(onTrack.ProjectedWeightKg, 76.0)
+	s.Greater(onTrack.MakeWeightProbability, 0.9)
+	s.Equal(asOf, onTrack.Points[0].Date)
+	s.Equal(*plan.EventDate, onTrack.Points[len(onTrack.Points)-1].Date)
+
+	// A flat trend stays near 80 kg and almost certainly misses
+	flat, err := ProjectEvent(plan, s.samples(80, 0, 28), asOf)
+	s.Require().NoError(err)
+	s.Less(flat.MakeWeightProbability, 0.05)
+	s.Less(flat.RequiredWeeklyChangeKg, -0.8)
+}
+
+func (s *EventPlanSuite) TestProjectionErrors() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+
+	_, err = ProjectEvent(plan, s.samples(80, -0.1, MinEventProjectionSamples-1), s.now)
+	s.ErrorIs(err, ErrInsufficientWeightData)
+
+	_, err = ProjectEvent(plan, s.samples(80, -0.1, 10), s.now.AddDate(0, 0, 61))
+	s.ErrorIs(err, ErrPlanEnded)
+
+	in := s.input()
+	in.Mode = PlanModeWeight
+	in.DurationWeeks = 9
+	weightPlan, err := NewNutritionPlan(in, s.profile, s.now)
+	s.Require().NoError(err)
+	_, err = ProjectEvent(weightPlan, s.samples(80, -0.1, 10), s.now)
+	s.ErrorIs(err, ErrPlanNotEvent)
+}
+
+func (s *EventPlanSuite) TestWeightPlanIgnoresEvent() {
+	in := s.input()
+	in.Mode = PlanModeWeight
+	in.DurationWeeks = 9
+	plan, err := NewNutritionPlan(in, s.profile, s.now)
+	s.Require().NoError(err)
+	s.Nil(plan.EventDate)
+}
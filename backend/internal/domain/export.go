@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// ExportFormat is the file format of a full-history export.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON is one JSON document holding every table.
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatCSV is a ZIP archive with one CSV file per table.
+	ExportFormatCSV ExportFormat = "csv"
+)
+
+// ExportArchiveName identifies a Victus export; ExportVersion changes when
+// the layout does, so an import can refuse archives it can't read.
+const (
+	ExportArchiveName = "victus-export"
+	ExportVersion     = 1
+)
+
+// ExportTable is a table included in a full-history export.
+type ExportTable string
+
+const (
+	ExportDailyLogs        ExportTable = "daily_logs"
+	ExportTrainingSessions ExportTable = "training_sessions"
+	ExportNutritionPlans   ExportTable = "nutrition_plans"
+	ExportWeeklyTargets    ExportTable = "weekly_targets"
+	ExportMetabolicHistory ExportTable = "metabolic_history"
+	ExportFatigueEvents    ExportTable = "fatigue_events"
+)
+
+// ExportTables lists the exported tables, each after the tables its rows
+// refer to. Weekly targets belong to a plan, so plans are exported too.
+var ExportTables = []ExportTable{
+	ExportDailyLogs,
+	ExportTrainingSessions,
+	ExportNutritionPlans,
+	ExportWeeklyTargets,
+	ExportMetabolicHistory,
+	ExportFatigueEvents,
+}
+
+// IsExportTable reports whether t is one of ExportTables.
+func IsExportTable(t ExportTable) bool {
+	for _, table := range ExportTables {
+		if table == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseExportFormat parses the format query parameter. Empty means JSON.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch ExportFormat(s) {
+	case "", ExportFormatJSON:
+		return ExportFormatJSON, nil
+	case ExportFormatCSV:
+		return ExportFormatCSV, nil
+	}
+	return "", ErrInvalidExportFormat
+}
+
+// Extension returns the downloaded file's extension.
+func (f ExportFormat) Extension() string {
+	if f == ExportFormatCSV {
+		return "zip"
+	}
+	return "json"
+}
+
+// ContentType returns the response's content type.
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+// ExportFilename is the download name for an export taken at the given time.
+func ExportFilename(format ExportFormat, at time.Time) string {
+	return ExportArchiveName + "-" + at.Format("2006-01-02") + "." + format.Extension()
+}
+
+// ExportTableSummary is a table's entry in an export manifest.
+type ExportTableSummary struct {
+	Name    ExportTable `json:"name"`
+	File    string      `json:"file,omitempty"`
+	Columns []string    `json:"columns"`
+	Rows    int         `json:"rows"`
+}
+
+// ExportManifest describes an export archive. It heads a JSON export and is
+// manifest.json in a CSV archive.
+type ExportManifest struct {
+	Format     string               `json:"format"`
+	Version    int                  `json:"version"`
+	ExportedAt string               `json:"exportedAt"`
+	Tables     []ExportTableSummary `json:"tables,omitempty"`
+}
+
+// NewExportManifest starts the manifest of an export taken at the given time.
+func NewExportManifest(at time.Time) ExportManifest {
+	return ExportManifest{
+		Format:     ExportArchiveName,
+		Version:    ExportVersion,
+		ExportedAt: at.UTC().Format(time.RFC3339),
+	}
+}
+
+// FormatExportCell renders an exported value as a CSV cell. NULL is an empty
+// cell; numbers use the shortest form that reads back the same, and
+// timestamps keep their fractional seconds so a restore can match them.
+func FormatExportCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
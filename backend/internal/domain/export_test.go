@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Exports are read back by spreadsheets and by imports; tests
+// pin the accepted formats, file naming, how each value type becomes a cell,
+// and which archives and conflict strategies a restore accepts.
+type ExportSuite struct {
+	suite.Suite
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (s *ExportSuite) TestParseExportFormat() {
+	format, err := ParseExportFormat("")
+	s.NoError(err)
+	s.Equal(ExportFormatJSON, format, "defaults to JSON")
+
+	format, err = ParseExportFormat("csv")
+	s.NoError(err)
+	s.Equal(ExportFormatCSV, format)
+
+	_, err = ParseExportFormat("xlsx")
+	s.ErrorIs(err, ErrInvalidExportFormat)
+}
+
+func (s *ExportSuite) TestFilename() {
+	at := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+	s.Equal("victus-export-2026-03-02.json", ExportFilename(ExportFormatJSON, at))
+	s.Equal("victus-export-2026-03-02.zip", ExportFilename(ExportFormatCSV, at), "CSV files are zipped")
+	s.Equal("application/zip", ExportFormatCSV.ContentType())
+}
+
+func (s *ExportSuite) TestTablesFollowTheirParents() {
+	position := make(map[ExportTable]int)
+	for i, t := range ExportTables {
+		position[t] = i
+	}
+	s.Less(position[ExportDailyLogs], position[ExportTrainingSessions])
+	s.Less(position[ExportTrainingSessions], position[ExportFatigueEvents])
+	s.Less(position[ExportNutritionPlans], position[ExportWeeklyTargets])
+	s.Less(position[ExportDailyLogs], position[ExportMetabolicHistory])
+}
+
+func (s *ExportSuite) TestFormatExportCell() {
+	s.Equal("", FormatExportCell(nil), "NULL is an empty cell")
+	s.Equal("Push day", FormatExportCell("Push day"))
+	s.Equal("true", FormatExportCell(true))
+	s.Equal("2450", FormatExportCell(int64(2450)))
+	s.Equal("82.3", FormatExportCell(82.3))
+	s.Equal("0.85", FormatExportCell(0.85))
+	s.Equal("2026-03-02T07:10:00Z", FormatExportCell(time.Date(2026, 3, 2, 8, 10, 0, 0, time.FixedZone("CET", 3600))))
+	s.Equal("2026-03-02T07:10:00.123456Z", FormatExportCell(time.Date(2026, 3, 2, 7, 10, 0, 123456000, time.UTC)))
+}
+
+func (s *ExportSuite) TestParseRestoreStrategies() {
+	strategies, err := ParseRestoreStrategies(map[string]string{"daily_logs": "merge", "weekly_targets": "overwrite"})
+	s.Require().NoError(err)
+	s.Equal(RestoreMerge, strategies[ExportDailyLogs])
+	s.Equal(RestoreOverwrite, strategies[ExportWeeklyTargets])
+	s.Equal(RestoreSkip, strategies[ExportFatigueEvents], "tables left out are skipped")
+
+	_, err = ParseRestoreStrategies(map[string]string{"profile": "overwrite"})
+	s.ErrorIs(err, ErrInvalidRestoreTable)
+	_, err = ParseRestoreStrategies(map[string]string{"daily_logs": "replace"})
+	s.ErrorIs(err, ErrInvalidRestoreStrategy)
+}
+
+func (s *ExportSuite) TestArchiveValidateNeedsIDs() {
+	archive := &ExportArchive{
+		Manifest: NewExportManifest(time.Now()),
+		Tables:   map[ExportTable]*ExportTableData{ExportDailyLogs: NewExportTableData([]string{"log_date"})},
+	}
+	s.NoError(archive.Validate(), "an empty table needs no ids")
+
+	date := "2026-03-01"
+	archive.Tables[ExportDailyLogs].Rows = [][]*string{{&date}}
+	s.ErrorIs(archive.Validate(), ErrInvalidExportArchive, "rows need ids to remap references")
+
+	archive.Tables[ExportDailyLogs] = NewExportTableData([]string{"id"})
+	archive.Tables["profile"] = NewExportTableData([]string{"id"})
+	s.ErrorIs(archive.Validate(), ErrInvalidExportArchive)
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "math"
+import (
+	"math"
+	"time"
+)
 
 // MuscleGroup represents a trackable muscle region for body map visualization.
 type MuscleGroup string
@@ -158,6 +161,8 @@ type MuscleFatigueState struct {
 	Status         FatigueStatus `json:"status"`
 	Color          string      `json:"color"`
 	LastUpdated    string      `json:"lastUpdated"`
+	Sides          *SideFatigue `json:"sides,omitempty"`          // Left/right split, when one is tracked
+	OverloadedSide BodySide     `json:"overloadedSide,omitempty"` // Side FatigueAsymmetryThreshold or more above the other
 }
 
 // BodyStatus represents the complete body fatigue state.
@@ -165,8 +170,8 @@ type BodyStatus struct {
 	Muscles        []MuscleFatigueState `json:"muscles"`
 	OverallScore   float64              `json:"overallScore"`
 	AsOfTime       string               `json:"asOfTime"`
-	JointIntegrity map[string]float64   `json:"jointIntegrity"`
-	SystemicLoad   float64              `json:"systemicLoad"`
+	JointIntegrity map[string]float64   `json:"jointIntegrity"` // 0-1 per joint, 1 = intact
+	SystemicLoad   float64              `json:"systemicLoad"`   // 0-10
 }
 
 // FatigueInjection represents the fatigue added to a single muscle by a workout.
@@ -176,6 +181,7 @@ type FatigueInjection struct {
 	InjectedPercent float64       `json:"injectedPercent"`
 	NewTotal        float64       `json:"newTotal"`
 	Status          FatigueStatus `json:"status"`
+	NewSides        *SideFatigue  `json:"newSides,omitempty"` // Set when the muscle's sides differ
 }
 
 // SessionFatigueReport summarizes fatigue impact from a workout.
@@ -310,3 +316,107 @@ func BuildFatigueInjection(muscle MuscleGroup, injectedPercent float64, newTotal
 		Status:          status,
 	}
 }
+
+// FatigueTrend describes the direction fatigue moved over a comparison window.
+type FatigueTrend string
+
+const (
+	FatigueTrendAccumulating FatigueTrend = "accumulating"
+	FatigueTrendRecovering   FatigueTrend = "recovering"
+	FatigueTrendStable       FatigueTrend = "stable"
+)
+
+// FatigueTrendThreshold is the minimum change (percentage points) treated as movement.
+const FatigueTrendThreshold = 2.0
+
+// MaxHeatmapCompareDays bounds how far back the heatmap comparison can look.
+const MaxHeatmapCompareDays = 90
+
+// MuscleFatigueDelta compares a muscle's current fatigue against a past value.
+type MuscleFatigueDelta struct {
+	MuscleGroupID   int           `json:"muscleGroupId"`
+	Muscle          MuscleGroup   `json:"muscle"`
+	DisplayName     string        `json:"displayName"`
+	SVGPathID       string        `json:"svgPathId"`
+	CurrentPercent  float64       `json:"currentPercent"`
+	PreviousPercent float64       `json:"previousPercent"`
+	DeltaPercent    float64       `json:"deltaPercent"`
+	Trend           FatigueTrend  `json:"trend"`
+	Status          FatigueStatus `json:"status"`
+	Color           string        `json:"color"`
+	Sides           *SideFatigue  `json:"sides,omitempty"`          // Current left/right split
+	OverloadedSide  BodySide      `json:"overloadedSide,omitempty"` // Currently overloaded side
+}
+
+// FatigueHeatmap is the body map with per-muscle change over a comparison window.
+type FatigueHeatmap struct {
+	Muscles              []MuscleFatigueDelta `json:"muscles"`
+	CompareDays          int                  `json:"compareDays"`
+	OverallScore         float64              `json:"overallScore"`
+	PreviousOverallScore float64              `json:"previousOverallScore"`
+	OverallDelta         float64              `json:"overallDelta"`
+	AsOfTime             string               `json:"asOfTime"`
+	CompareTime          string               `json:"compareTime"`
+}
+
+// ClassifyFatigueTrend maps a fatigue delta to a trend direction.
+func ClassifyFatigueTrend(delta float64) FatigueTrend {
+	switch {
+	case delta >= FatigueTrendThreshold:
+		return FatigueTrendAccumulating
+	case delta <= -FatigueTrendThreshold:
+		return FatigueTrendRecovering
+	default:
+		return FatigueTrendStable
+	}
+}
+
+// BuildFatigueHeatmap pairs current and previous muscle states by muscle group,
+// preserving each group's SVG path ID. Muscles missing from previous are treated as fresh.
+// Left/right splits are carried from the current state.
+func BuildFatigueHeatmap(groups []MuscleGroupConfig, current, previous []MuscleFatigueState, compareDays int, asOf, compareAt time.Time) FatigueHeatmap {
+	currentByID := make(map[int]MuscleFatigueState, len(current))
+	for _, m := range current {
+		currentByID[m.MuscleGroupID] = m
+	}
+	previousByID := make(map[int]MuscleFatigueState, len(previous))
+	for _, m := range previous {
+		previousByID[m.MuscleGroupID] = m
+	}
+
+	muscles := make([]MuscleFatigueDelta, 0, len(groups))
+	for _, g := range groups {
+		state := currentByID[g.ID]
+		cur := state.FatiguePercent
+		prev := previousByID[g.ID].FatiguePercent
+		delta := math.Round((cur-prev)*10) / 10
+		status := GetFatigueStatus(cur)
+		muscles = append(muscles, MuscleFatigueDelta{
+			MuscleGroupID:   g.ID,
+			Muscle:          g.Name,
+			DisplayName:     g.DisplayName,
+			SVGPathID:       g.SVGPathID,
+			CurrentPercent:  cur,
+			PreviousPercent: prev,
+			DeltaPercent:    delta,
+			Trend:           ClassifyFatigueTrend(delta),
+			Status:          status,
+			Color:           FatigueStatusColors[status],
+			Sides:           state.Sides,
+			OverloadedSide:  state.OverloadedSide,
+		})
+	}
+
+	overall := CalculateOverallFatigueScore(current)
+	previousOverall := CalculateOverallFatigueScore(previous)
+
+	return FatigueHeatmap{
+		Muscles:              muscles,
+		CompareDays:          compareDays,
+		OverallScore:         overall,
+		PreviousOverallScore: previousOverall,
+		OverallDelta:         math.Round((overall-previousOverall)*10) / 10,
+		AsOfTime:             asOf.Format(time.RFC3339),
+		CompareTime:          compareAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The heatmap delta drives the body map's recovery/accumulation
+// arrows; these tests lock trend thresholds and the fresh-muscle defaults.
+
+type FatigueHeatmapSuite struct {
+	suite.Suite
+}
+
+func TestFatigueHeatmapSuite(t *testing.T) {
+	suite.Run(t, new(FatigueHeatmapSuite))
+}
+
+func (s *FatigueHeatmapSuite) TestClassifyFatigueTrend() {
+	s.Equal(FatigueTrendAccumulating, ClassifyFatigueTrend(2.0))
+	s.Equal(FatigueTrendRecovering, ClassifyFatigueTrend(-2.0))
+	s.Equal(FatigueTrendStable, ClassifyFatigueTrend(1.9))
+	s.Equal(FatigueTrendStable, ClassifyFatigueTrend(-1.9))
+}
+
+func (s *FatigueHeatmapSuite) TestBuildFatigueHeatmap() {
+	groups := []MuscleGroupConfig{
+		{ID: 1, Name: MuscleChest, DisplayName: "Chest", SVGPathID: "chest-path"},
+		{ID: 2, Name: MuscleQuads, DisplayName: "Quads", SVGPathID: "quads-path"},
+		{ID: 3, Name: MuscleCalves, DisplayName: "Calves", SVGPathID: "calves-path"},
+	}
+	current := []MuscleFatigueState{
+		BuildMuscleFatigueState(1, MuscleChest, 60, ""),
+		BuildMuscleFatigueState(2, MuscleQuads, 10, ""),
+		BuildMuscleFatigueState(3, MuscleCalves, 5, ""),
+	}
+	previous := []MuscleFatigueState{
+		BuildMuscleFatigueState(1, MuscleChest, 20, ""),
+		BuildMuscleFatigueState(2, MuscleQuads, 50, ""),
+	}
+	asOf := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	heatmap := BuildFatigueHeatmap(groups, current, previous, 7, asOf, asOf.AddDate(0, 0, -7))
+
+	s.Require().Len(heatmap.Muscles, 3)
+	s.Equal(7, heatmap.CompareDays)
+	s.Equal("2026-03-03T12:00:00Z", heatmap.CompareTime)
+
+	s.Run("accumulating muscle keeps svg path", func() {
+		chest := heatmap.Muscles[0]
+		s.Equal("chest-path", chest.SVGPathID)
+		s.Equal(40.0, chest.DeltaPercent)
+		s.Equal(FatigueTrendAccumulating, chest.Trend)
+		s.Equal(FatigueStatusFatigued, chest.Status)
+	})
+
+	s.Run("recovering muscle", func() {
+		quads := heatmap.Muscles[1]
+		s.Equal(-40.0, quads.DeltaPercent)
+		s.Equal(FatigueTrendRecovering, quads.Trend)
+	})
+
+	s.Run("missing previous state treated as fresh", func() {
+		calves := heatmap.Muscles[2]
+		s.Equal(0.0, calves.PreviousPercent)
+		s.Equal(FatigueTrendAccumulating, calves.Trend)
+	})
+}
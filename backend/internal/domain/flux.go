@@ -53,8 +53,9 @@ type FluxInput struct {
 
 // WeightDataPoint represents a single weight measurement.
 type WeightDataPoint struct {
-	Date     string
-	WeightKg float64
+	Date        string
+	WeightKg    float64
+	WeighInTime string // HH:MM, empty when unknown
 }
 
 // FluxResult contains the calculated TDEE and audit metadata.
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// FOOD MATCHING & NORMALIZATION
+// =============================================================================
+//
+// Free-text food names ("greek yoghurt", "chix breast") resolve to food_reference
+// rows in three steps: canonicalize the name (spelling variants, shorthand,
+// plurals), look it up in the synonym table (seeded aliases plus aliases learned
+// from user confirmations), then fall back to trigram similarity. Close or weak
+// fuzzy matches are flagged as ambiguous so the user can confirm them once.
+
+const (
+	// FoodConfidentMatchScore is the similarity above which a fuzzy match needs no confirmation.
+	FoodConfidentMatchScore = 0.8
+	// FoodAmbiguityMargin flags a match as ambiguous when the runner-up scores within this margin.
+	FoodAmbiguityMargin = 0.1
+	// FoodCandidateMinScore is the lowest similarity offered as a confirmation candidate.
+	FoodCandidateMinScore = 0.3
+	// FoodMatchMaxCandidates caps the candidates returned for confirmation.
+	FoodMatchMaxCandidates = 3
+)
+
+// FoodMatchVia describes how a food was matched.
+type FoodMatchVia string
+
+const (
+	FoodMatchViaSynonym FoodMatchVia = "synonym"
+	FoodMatchViaFuzzy   FoodMatchVia = "fuzzy"
+)
+
+// FoodSynonymSource records where an alias came from.
+type FoodSynonymSource string
+
+const (
+	FoodSynonymSourceSeed FoodSynonymSource = "seed"
+	FoodSynonymSourceUser FoodSynonymSource = "user" // Learned from a confirmed match
+)
+
+// FoodSynonym maps an alias to a food_reference row.
+type FoodSynonym struct {
+	Alias           string            `json:"alias"`
+	FoodReferenceID int64             `json:"foodReferenceId"`
+	Source          FoodSynonymSource `json:"source"`
+}
+
+// FoodMatchCandidate is a scored food for a query.
+type FoodMatchCandidate struct {
+	Food  FoodNutrition `json:"food"`
+	Score float64       `json:"score"`
+	Via   FoodMatchVia  `json:"via"`
+}
+
+// FoodMatchResult is the outcome of resolving a food name.
+type FoodMatchResult struct {
+	Query      string               `json:"query"`
+	Canonical  string               `json:"canonical"`
+	Best       *FoodMatchCandidate  `json:"best,omitempty"` // nil when nothing reaches FoodMatchThreshold
+	Candidates []FoodMatchCandidate `json:"candidates"`
+	Ambiguous  bool                 `json:"ambiguous"` // True when the user should confirm the match
+}
+
+// foodWordSynonyms maps shorthand and spelling variants to the word used in food_reference.
+var foodWordSynonyms = map[string]string{
+	"chix":      "chicken",
+	"chkn":      "chicken",
+	"yogurt":    "yoghurt",
+	"yoghourt":  "yoghurt",
+	"oatmeal":   "oats",
+	"porridge":  "oats",
+	"pb":        "nut butter",
+	"evoo":      "olive oil",
+	"tatties":   "potatoes",
+	"spuds":     "potatoes",
+	"courgette": "zucchini",
+	"aubergine": "eggplant",
+	"rocket":    "arugula",
+	"capsicum":  "bell pepper",
+}
+
+// singularizeFoodWord strips simple English plural endings.
+func singularizeFoodWord(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 4 && strings.HasSuffix(w, "oes"):
+		return w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// CanonicalFoodName normalizes a food name and rewrites shorthand, spelling
+// variants and plurals so equivalent names compare equal:
+// "Chix Breasts" → "chicken breast", "Greek Yogurt" → "greek yoghurt".
+func CanonicalFoodName(name string) string {
+	words := strings.Fields(NormalizeFoodName(name))
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if syn, ok := foodWordSynonyms[w]; ok {
+			w = syn
+		}
+		for _, part := range strings.Fields(w) {
+			out = append(out, singularizeFoodWord(part))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// IndexFoodSynonyms keys synonyms by canonical alias for ResolveFood.
+// User-learned aliases win over seeded ones that canonicalize to the same name.
+func IndexFoodSynonyms(list []FoodSynonym) map[string]int64 {
+	index := make(map[string]int64, len(list))
+	fromUser := make(map[string]bool, len(list))
+	for _, syn := range list {
+		key := CanonicalFoodName(syn.Alias)
+		if key == "" || (fromUser[key] && syn.Source != FoodSynonymSourceUser) {
+			continue
+		}
+		index[key] = syn.FoodReferenceID
+		fromUser[key] = syn.Source == FoodSynonymSourceUser
+	}
+	return index
+}
+
+// ResolveFood matches a free-text name against the food list.
+// synonyms maps canonical aliases to food IDs; an alias hit is returned with
+// score 1 and never needs confirmation.
+func ResolveFood(query string, foods []FoodNutrition, synonyms map[string]int64) FoodMatchResult {
+	canonical := CanonicalFoodName(query)
+	result := FoodMatchResult{
+		Query:      query,
+		Canonical:  canonical,
+		Candidates: []FoodMatchCandidate{},
+	}
+	if canonical == "" {
+		return result
+	}
+
+	if id, ok := synonyms[canonical]; ok {
+		for _, f := range foods {
+			if f.ID == id {
+				best := FoodMatchCandidate{Food: f, Score: 1, Via: FoodMatchViaSynonym}
+				result.Best = &best
+				result.Candidates = append(result.Candidates, best)
+				return result
+			}
+		}
+	}
+
+	scored := make([]FoodMatchCandidate, 0, len(foods))
+	for _, f := range foods {
+		score := FoodNameSimilarity(query, f.FoodItem)
+		if score < FoodCandidateMinScore {
+			continue
+		}
+		scored = append(scored, FoodMatchCandidate{Food: f, Score: math.Round(score*100) / 100, Via: FoodMatchViaFuzzy})
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if len(scored) > FoodMatchMaxCandidates {
+		scored = scored[:FoodMatchMaxCandidates]
+	}
+	result.Candidates = scored
+
+	if len(scored) == 0 {
+		return result
+	}
+	if scored[0].Score >= FoodMatchThreshold {
+		best := scored[0]
+		result.Best = &best
+	}
+	result.Ambiguous = result.Best == nil ||
+		result.Best.Score < FoodConfidentMatchScore ||
+		(len(scored) > 1 && scored[0].Score-scored[1].Score < FoodAmbiguityMargin)
+	return result
+}
+
+// foodCountUnits are units that mean "one standard serving" of a food.
+var foodCountUnits = map[string]bool{
+	"":         true,
+	"serving":  true,
+	"servings": true,
+	"whole":    true,
+	"piece":    true,
+	"pieces":   true,
+	"large":    true,
+	"medium":   true,
+	"small":    true,
+}
+
+// ServingToGrams converts a quantity and unit to grams for a matched food.
+// Count units ("2 eggs", "1 serving") and the food's own serving unit
+// ("1 tbsp" of olive oil, "2 slices" of bread) use the food's serving size;
+// weight and volume units fall back to ConvertToGrams. Foods measured in grams
+// treat a bare number as grams.
+func ServingToGrams(quantity float64, unit string, food *FoodNutrition) float64 {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if food == nil || food.ServingSizeG <= 0 || food.ServingUnit == "" || food.ServingUnit == "g" {
+		return ConvertToGrams(quantity, unit)
+	}
+
+	servingUnit := strings.ToLower(food.ServingUnit)
+	if foodCountUnits[unit] || singularizeFoodWord(unit) == singularizeFoodWord(servingUnit) {
+		return quantity * food.ServingSizeG
+	}
+	return ConvertToGrams(quantity, unit)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FoodMatchSuite struct {
+	suite.Suite
+	foods []FoodNutrition
+}
+
+func TestFoodMatchSuite(t *testing.T) {
+	suite.Run(t, new(FoodMatchSuite))
+}
+
+func (s *FoodMatchSuite) SetupTest() {
+	s.foods = []FoodNutrition{
+		{ID: 1, FoodItem: "Brown Rice", ServingUnit: "g", ServingSizeG: 100},
+		{ID: 2, FoodItem: "Chicken/Turkey Breast", ServingUnit: "g", ServingSizeG: 120},
+		{ID: 3, FoodItem: "Low-fat Greek Yoghurt", ServingUnit: "g", ServingSizeG: 150},
+		{ID: 4, FoodItem: "Eggs", ServingUnit: "large", ServingSizeG: 50},
+		{ID: 5, FoodItem: "Olive Oil", ServingUnit: "tbsp", ServingSizeG: 14},
+		{ID: 6, FoodItem: "Salmon/Tuna/Perch", ServingUnit: "g", ServingSizeG: 120},
+		{ID: 7, FoodItem: "Wholegrain Bread", ServingUnit: "slice", ServingSizeG: 40},
+	}
+}
+
+func (s *FoodMatchSuite) TestCanonicalFoodName() {
+	s.Equal("chicken breast", CanonicalFoodName("Chix Breasts"))
+	s.Equal("greek yoghurt", CanonicalFoodName("Greek Yogurt"))
+	s.Equal("potato", CanonicalFoodName("Potatoes"))
+	s.Equal("blueberry", CanonicalFoodName("Blueberries"))
+	s.Equal("nut butter", CanonicalFoodName("PB"))
+	s.Equal("swiss chard", CanonicalFoodName("Swiss Chard"), "double-s words keep their ending")
+}
+
+func (s *FoodMatchSuite) TestShorthandResolvesConfidently() {
+	result := ResolveFood("chix breast", s.foods, nil)
+
+	s.Require().NotNil(result.Best)
+	s.Equal(int64(2), result.Best.Food.ID)
+	s.Equal(FoodMatchViaFuzzy, result.Best.Via)
+	s.False(result.Ambiguous)
+}
+
+func (s *FoodMatchSuite) TestPluralsMatchExactly() {
+	result := ResolveFood("egg", s.foods, nil)
+
+	s.Require().NotNil(result.Best)
+	s.Equal(int64(4), result.Best.Food.ID)
+	s.Equal(1.0, result.Best.Score)
+	s.False(result.Ambiguous)
+}
+
+func (s *FoodMatchSuite) TestWeakMatchNeedsConfirmation() {
+	result := ResolveFood("rice", s.foods, nil)
+
+	s.Require().NotNil(result.Best)
+	s.Equal(int64(1), result.Best.Food.ID)
+	s.True(result.Ambiguous, "a partial name should be confirmed")
+}
+
+func (s *FoodMatchSuite) TestNoMatchOffersCandidates() {
+	result := ResolveFood("tuna", s.foods, nil)
+
+	s.Nil(result.Best)
+	s.True(result.Ambiguous)
+	s.LessOrEqual(len(result.Candidates), FoodMatchMaxCandidates)
+}
+
+func (s *FoodMatchSuite) TestSynonymWinsOverFuzzy() {
+	synonyms := IndexFoodSynonyms([]FoodSynonym{
+		{Alias: "Tuna", FoodReferenceID: 6, Source: FoodSynonymSourceSeed},
+	})
+
+	result := ResolveFood("tuna", s.foods, synonyms)
+
+	s.Require().NotNil(result.Best)
+	s.Equal(int64(6), result.Best.Food.ID)
+	s.Equal(FoodMatchViaSynonym, result.Best.Via)
+	s.False(result.Ambiguous)
+}
+
+func (s *FoodMatchSuite) TestSynonymToMissingFoodFallsBackToFuzzy() {
+	synonyms := map[string]int64{"egg": 99}
+
+	result := ResolveFood("eggs", s.foods, synonyms)
+
+	s.Require().NotNil(result.Best)
+	s.Equal(int64(4), result.Best.Food.ID)
+	s.Equal(FoodMatchViaFuzzy, result.Best.Via)
+}
+
+func (s *FoodMatchSuite) TestUserSynonymOverridesSeed() {
+	index := IndexFoodSynonyms([]FoodSynonym{
+		{Alias: "yogurt", FoodReferenceID: 9, Source: FoodSynonymSourceUser},
+		{Alias: "yoghurt", FoodReferenceID: 3, Source: FoodSynonymSourceSeed},
+	})
+
+	s.Equal(int64(9), index["yoghurt"], "learned correction wins over the seed")
+}
+
+func (s *FoodMatchSuite) TestServingToGrams() {
+	eggs := &s.foods[3]
+	oil := &s.foods[4]
+	bread := &s.foods[6]
+	rice := &s.foods[0]
+
+	s.InDelta(100, ServingToGrams(2, "", eggs), 0.01, "bare count uses serving size")
+	s.InDelta(150, ServingToGrams(3, "large", eggs), 0.01)
+	s.InDelta(28, ServingToGrams(2, "tbsp", oil), 0.01, "food's own unit beats generic tbsp")
+	s.InDelta(80, ServingToGrams(2, "slices", bread), 0.01)
+	s.InDelta(200, ServingToGrams(200, "", rice), 0.01, "gram foods treat bare numbers as grams")
+	s.InDelta(240, ServingToGrams(1, "cup", rice), 0.01)
+	s.InDelta(56.7, ServingToGrams(2, "oz", nil), 0.01)
+}
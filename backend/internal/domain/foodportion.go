@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// LEARNED PORTION DEFAULTS
+// =============================================================================
+//
+// When a food is logged without a quantity ("eggs for breakfast"), the portion
+// is filled from the user's own history: the median of recent portions of that
+// food in the same meal slot, then across all slots, then the food's standard
+// serving, and only then a flat 100g.
+
+const (
+	// PortionHistoryWindow is the number of most recent portions considered per food.
+	PortionHistoryWindow = 20
+	// PortionMinSamples is the minimum number of logged portions before a median is trusted.
+	PortionMinSamples = 2
+	// PortionFallbackG is used when there is no history and no serving size.
+	PortionFallbackG = 100.0
+)
+
+// PortionSource describes where a default portion came from.
+type PortionSource string
+
+const (
+	PortionSourceMealHistory PortionSource = "meal_history" // Median for this food in this meal slot
+	PortionSourceFoodHistory PortionSource = "food_history" // Median for this food across all meals
+	PortionSourceServing     PortionSource = "serving"      // The food's standard serving size
+	PortionSourceFallback    PortionSource = "fallback"     // Flat PortionFallbackG
+)
+
+// FoodPortionEntry is one logged portion of a food.
+type FoodPortionEntry struct {
+	FoodReferenceID int64     `json:"foodReferenceId"`
+	Meal            *MealName `json:"meal,omitempty"` // nil for snacks/unknown slot
+	QuantityG       float64   `json:"quantityG"`
+	Date            string    `json:"date"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// PortionDefault is the portion to assume for a food logged without a quantity.
+type PortionDefault struct {
+	FoodReferenceID int64         `json:"foodReferenceId"`
+	Meal            *MealName     `json:"meal,omitempty"`
+	QuantityG       float64       `json:"quantityG"`
+	Source          PortionSource `json:"source"`
+	SampleSize      int           `json:"sampleSize"` // Portions the median was taken over (0 for serving/fallback)
+}
+
+// MealForHour infers the meal slot from the local hour of day.
+// Returns nil outside the usual meal windows (snacks).
+func MealForHour(hour int) *MealName {
+	var meal MealName
+	switch {
+	case hour >= 5 && hour < 11:
+		meal = MealBreakfast
+	case hour >= 11 && hour < 15:
+		meal = MealLunch
+	case hour >= 17 && hour < 22:
+		meal = MealDinner
+	default:
+		return nil
+	}
+	return &meal
+}
+
+// DefaultPortion picks a personal default portion for a food.
+// history holds the food's logged portions, most recent first; only the first
+// PortionHistoryWindow entries are considered.
+func DefaultPortion(food FoodNutrition, meal *MealName, history []FoodPortionEntry) PortionDefault {
+	result := PortionDefault{FoodReferenceID: food.ID, Meal: meal}
+
+	if len(history) > PortionHistoryWindow {
+		history = history[:PortionHistoryWindow]
+	}
+
+	var all, inMeal []float64
+	for _, e := range history {
+		if e.QuantityG <= 0 {
+			continue
+		}
+		all = append(all, e.QuantityG)
+		if meal != nil && e.Meal != nil && *e.Meal == *meal {
+			inMeal = append(inMeal, e.QuantityG)
+		}
+	}
+
+	switch {
+	case len(inMeal) >= PortionMinSamples:
+		result.QuantityG = portionMedian(inMeal)
+		result.Source = PortionSourceMealHistory
+		result.SampleSize = len(inMeal)
+	case len(all) >= PortionMinSamples:
+		result.QuantityG = portionMedian(all)
+		result.Source = PortionSourceFoodHistory
+		result.SampleSize = len(all)
+	case food.ServingSizeG > 0:
+		result.QuantityG = food.ServingSizeG
+		result.Source = PortionSourceServing
+	default:
+		result.QuantityG = PortionFallbackG
+		result.Source = PortionSourceFallback
+	}
+	return result
+}
+
+// portionMedian returns the median of the values, rounded to the nearest gram.
+func portionMedian(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return float64(int(median + 0.5))
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FoodPortionSuite struct {
+	suite.Suite
+	eggs FoodNutrition
+}
+
+func TestFoodPortionSuite(t *testing.T) {
+	suite.Run(t, new(FoodPortionSuite))
+}
+
+func (s *FoodPortionSuite) SetupTest() {
+	s.eggs = FoodNutrition{ID: 4, FoodItem: "Eggs", ServingUnit: "large", ServingSizeG: 50}
+}
+
+func portion(meal *MealName, grams float64) FoodPortionEntry {
+	return FoodPortionEntry{FoodReferenceID: 4, Meal: meal, QuantityG: grams}
+}
+
+func mealPtr(m MealName) *MealName { return &m }
+
+func (s *FoodPortionSuite) TestMealSlotMedianWins() {
+	breakfast, dinner := mealPtr(MealBreakfast), mealPtr(MealDinner)
+	history := []FoodPortionEntry{
+		portion(breakfast, 150), portion(dinner, 50), portion(breakfast, 100),
+		portion(breakfast, 150), portion(dinner, 50),
+	}
+
+	got := DefaultPortion(s.eggs, breakfast, history)
+
+	s.Equal(150.0, got.QuantityG)
+	s.Equal(PortionSourceMealHistory, got.Source)
+	s.Equal(3, got.SampleSize)
+}
+
+func (s *FoodPortionSuite) TestEvenSampleAveragesMiddlePair() {
+	lunch := mealPtr(MealLunch)
+	got := DefaultPortion(s.eggs, lunch, []FoodPortionEntry{portion(lunch, 100), portion(lunch, 150)})
+
+	s.Equal(125.0, got.QuantityG)
+}
+
+func (s *FoodPortionSuite) TestFallsBackToFoodWideMedian() {
+	history := []FoodPortionEntry{portion(mealPtr(MealDinner), 100), portion(nil, 50), portion(mealPtr(MealBreakfast), 100)}
+
+	got := DefaultPortion(s.eggs, mealPtr(MealLunch), history)
+
+	s.Equal(100.0, got.QuantityG)
+	s.Equal(PortionSourceFoodHistory, got.Source)
+}
+
+func (s *FoodPortionSuite) TestSingleSampleUsesServing() {
+	breakfast := mealPtr(MealBreakfast)
+	got := DefaultPortion(s.eggs, breakfast, []FoodPortionEntry{portion(breakfast, 300)})
+
+	s.Equal(50.0, got.QuantityG, "one odd log should not become the default")
+	s.Equal(PortionSourceServing, got.Source)
+}
+
+func (s *FoodPortionSuite) TestNoServingFallsBackTo100g() {
+	got := DefaultPortion(FoodNutrition{ID: 9, FoodItem: "Mystery"}, nil, nil)
+
+	s.Equal(PortionFallbackG, got.QuantityG)
+	s.Equal(PortionSourceFallback, got.Source)
+}
+
+func (s *FoodPortionSuite) TestOnlyRecentWindowCounts() {
+	breakfast := mealPtr(MealBreakfast)
+	var history []FoodPortionEntry
+	for i := 0; i < PortionHistoryWindow; i++ {
+		history = append(history, portion(breakfast, 100))
+	}
+	for i := 0; i < PortionHistoryWindow; i++ {
+		history = append(history, portion(breakfast, 200)) // Older habit
+	}
+
+	got := DefaultPortion(s.eggs, breakfast, history)
+
+	s.Equal(100.0, got.QuantityG)
+	s.Equal(PortionHistoryWindow, got.SampleSize)
+}
+
+func (s *FoodPortionSuite) TestMealForHour() {
+	s.Equal(MealBreakfast, *MealForHour(7))
+	s.Equal(MealLunch, *MealForHour(12))
+	s.Equal(MealDinner, *MealForHour(19))
+	s.Nil(MealForHour(16), "afternoon snack has no slot")
+	s.Nil(MealForHour(23))
+}
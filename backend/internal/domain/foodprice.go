@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// FOOD PRICES AND COST ESTIMATION
+// =============================================================================
+//
+// Prices are optional and entered by the user per food, either per standard
+// serving or per 100g, in whatever currency they shop in (amounts carry no
+// currency). Estimates cover what can be priced and say how much couldn't be,
+// so a half-priced grocery list is never mistaken for a cheap one.
+
+const (
+	// MaxFoodPrice caps a single price entry, catching misplaced decimals.
+	MaxFoodPrice = 10000.0
+	// FoodCostTrendDefaultWeeks is the number of weeks in a cost trend when none is given.
+	FoodCostTrendDefaultWeeks = 12
+	// FoodCostTrendMaxWeeks caps the cost trend length.
+	FoodCostTrendMaxWeeks = 52
+)
+
+// PriceBasis is the quantity a price is quoted for.
+type PriceBasis string
+
+const (
+	PriceBasisServing PriceBasis = "serving" // Per the food's standard serving (serving_size_g)
+	PriceBasisPer100g PriceBasis = "100g"
+)
+
+// FoodPrice is the user's price for one food.
+type FoodPrice struct {
+	FoodReferenceID int64      `json:"foodReferenceId"`
+	Amount          float64    `json:"amount"`
+	Basis           PriceBasis `json:"basis"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// Validate checks the amount and basis.
+func (p FoodPrice) Validate() error {
+	if p.Amount <= 0 || p.Amount > MaxFoodPrice || math.IsNaN(p.Amount) {
+		return ErrInvalidFoodPrice
+	}
+	if p.Basis != PriceBasisServing && p.Basis != PriceBasisPer100g {
+		return ErrInvalidPriceBasis
+	}
+	return nil
+}
+
+// CostForGrams prices a quantity of the food. A serving price needs the
+// food's serving size; without one, a serving is taken as 100g.
+func (p FoodPrice) CostForGrams(grams, servingSizeG float64) float64 {
+	per := 100.0
+	if p.Basis == PriceBasisServing && servingSizeG > 0 {
+		per = servingSizeG
+	}
+	return p.Amount * grams / per
+}
+
+// FoodPrices maps food reference IDs to prices.
+type FoodPrices map[int64]FoodPrice
+
+// CostLine is the cost of one item in an estimate.
+type CostLine struct {
+	FoodReferenceID int64    `json:"foodReferenceId"`
+	FoodItem        string   `json:"foodItem"`
+	AmountG         float64  `json:"amountG"`
+	Cost            *float64 `json:"cost"` // nil when the food has no price
+}
+
+// CostEstimate is the estimated cost of a list of foods.
+type CostEstimate struct {
+	Total    float64    `json:"total"` // Sum over priced items
+	Lines    []CostLine `json:"lines"`
+	Complete bool       `json:"complete"` // Every item has a price
+}
+
+// EstimateIngredientCost prices a solver solution or grocery list.
+func EstimateIngredientCost(ingredients []SolverIngredient, prices FoodPrices) CostEstimate {
+	estimate := CostEstimate{Lines: make([]CostLine, 0, len(ingredients)), Complete: true}
+	total := 0.0
+	for _, ing := range ingredients {
+		line := CostLine{FoodReferenceID: ing.Food.ID, FoodItem: ing.Food.FoodItem, AmountG: ing.AmountG}
+		if price, ok := prices[ing.Food.ID]; ok {
+			cost := price.CostForGrams(ing.AmountG, ing.Food.ServingSizeG)
+			total += cost
+			rounded := roundCents(cost)
+			line.Cost = &rounded
+		} else {
+			estimate.Complete = false
+		}
+		estimate.Lines = append(estimate.Lines, line)
+	}
+	estimate.Total = roundCents(total)
+	return estimate
+}
+
+// WithinBudget reports whether the estimate is complete and costs at most maxCost.
+// An incomplete estimate can't be shown to fit a budget.
+func (e CostEstimate) WithinBudget(maxCost float64) bool {
+	return e.Complete && e.Total <= maxCost
+}
+
+// LoggedFoodQuantity is the total quantity of a food logged on one day.
+type LoggedFoodQuantity struct {
+	Date            string // YYYY-MM-DD
+	FoodReferenceID int64
+	QuantityG       float64
+	ServingSizeG    float64
+}
+
+// WeeklyFoodCost is the estimated cost of the food logged in one week.
+type WeeklyFoodCost struct {
+	WeekStart   string  `json:"weekStart"` // Monday YYYY-MM-DD
+	Total       float64 `json:"total"`     // Cost of the priced foods
+	LoggedG     float64 `json:"loggedG"`
+	PricedG     float64 `json:"pricedG"`
+	CoveragePct float64 `json:"coveragePct"` // Share of logged grams that had a price
+}
+
+// BuildWeeklyFoodCosts totals logged food cost per Monday-to-Sunday week,
+// from the week of firstMonday for the given number of weeks. Weeks without
+// logs are included with zero totals so the trend has no gaps.
+func BuildWeeklyFoodCosts(logged []LoggedFoodQuantity, prices FoodPrices, firstMonday time.Time, weeks int) []WeeklyFoodCost {
+	byWeek := make(map[string]*WeeklyFoodCost, weeks)
+	result := make([]WeeklyFoodCost, weeks)
+	for i := range weeks {
+		result[i].WeekStart = firstMonday.AddDate(0, 0, 7*i).Format("2006-01-02")
+		byWeek[result[i].WeekStart] = &result[i]
+	}
+
+	for _, entry := range logged {
+		monday, ok := weekStartOf(entry.Date)
+		if !ok {
+			continue
+		}
+		week, ok := byWeek[monday]
+		if !ok {
+			continue
+		}
+		week.LoggedG += entry.QuantityG
+		if price, ok := prices[entry.FoodReferenceID]; ok {
+			week.PricedG += entry.QuantityG
+			week.Total += price.CostForGrams(entry.QuantityG, entry.ServingSizeG)
+		}
+	}
+
+	for i := range result {
+		w := &result[i]
+		w.Total = roundCents(w.Total)
+		if w.LoggedG > 0 {
+			w.CoveragePct = math.Round(w.PricedG/w.LoggedG*1000) / 10
+		}
+		w.LoggedG = math.Round(w.LoggedG)
+		w.PricedG = math.Round(w.PricedG)
+	}
+	return result
+}
+
+// SortedFoodPrices lists prices by food ID for stable output.
+func SortedFoodPrices(prices FoodPrices) []FoodPrice {
+	list := make([]FoodPrice, 0, len(prices))
+	for _, p := range prices {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].FoodReferenceID < list[j].FoodReferenceID })
+	return list
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
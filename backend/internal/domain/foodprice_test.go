@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Cost estimates gate which solver solutions a user sees and
+// feed the weekly spend trend; tests pin price conversion, the treatment of
+// unpriced foods and the weekly bucketing.
+type FoodPriceSuite struct {
+	suite.Suite
+}
+
+func TestFoodPriceSuite(t *testing.T) {
+	suite.Run(t, new(FoodPriceSuite))
+}
+
+func (s *FoodPriceSuite) TestValidate() {
+	s.NoError(FoodPrice{Amount: 2.5, Basis: PriceBasisServing}.Validate())
+	s.ErrorIs(FoodPrice{Amount: 0, Basis: PriceBasisServing}.Validate(), ErrInvalidFoodPrice)
+	s.ErrorIs(FoodPrice{Amount: MaxFoodPrice + 1, Basis: PriceBasisPer100g}.Validate(), ErrInvalidFoodPrice)
+	s.ErrorIs(FoodPrice{Amount: 1, Basis: "kg"}.Validate(), ErrInvalidPriceBasis)
+}
+
+func (s *FoodPriceSuite) TestCostForGrams() {
+	perServing := FoodPrice{Amount: 3, Basis: PriceBasisServing}
+	s.InDelta(4.5, perServing.CostForGrams(225, 150), 1e-9)
+	s.InDelta(6.75, perServing.CostForGrams(225, 0), 1e-9, "no serving size falls back to 100g")
+
+	per100g := FoodPrice{Amount: 1.2, Basis: PriceBasisPer100g}
+	s.InDelta(3.0, per100g.CostForGrams(250, 150), 1e-9, "serving size is ignored")
+}
+
+func (s *FoodPriceSuite) TestEstimateMarksUnpricedFoods() {
+	chicken := FoodNutrition{ID: 1, FoodItem: "Chicken Breast", ServingSizeG: 120}
+	rice := FoodNutrition{ID: 2, FoodItem: "Brown Rice", ServingSizeG: 100}
+	ingredients := []SolverIngredient{{Food: chicken, AmountG: 180}, {Food: rice, AmountG: 80}}
+
+	estimate := EstimateIngredientCost(ingredients, FoodPrices{
+		1: {FoodReferenceID: 1, Amount: 2, Basis: PriceBasisServing},
+	})
+
+	s.False(estimate.Complete)
+	s.Equal(3.0, estimate.Total)
+	s.Require().Len(estimate.Lines, 2)
+	s.Require().NotNil(estimate.Lines[0].Cost)
+	s.Equal(3.0, *estimate.Lines[0].Cost)
+	s.Nil(estimate.Lines[1].Cost)
+	s.False(estimate.WithinBudget(10), "an incomplete estimate can't fit a budget")
+
+	estimate = EstimateIngredientCost(ingredients, FoodPrices{
+		1: {FoodReferenceID: 1, Amount: 2, Basis: PriceBasisServing},
+		2: {FoodReferenceID: 2, Amount: 0.4, Basis: PriceBasisPer100g},
+	})
+	s.True(estimate.Complete)
+	s.Equal(3.32, estimate.Total)
+	s.True(estimate.WithinBudget(3.32))
+	s.False(estimate.WithinBudget(3.31))
+}
+
+func (s *FoodPriceSuite) TestWeeklyCostsFillGapsAndReportCoverage() {
+	firstMonday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	prices := FoodPrices{1: {FoodReferenceID: 1, Amount: 1, Basis: PriceBasisPer100g}}
+	logged := []LoggedFoodQuantity{
+		{Date: "2026-03-02", FoodReferenceID: 1, QuantityG: 300},
+		{Date: "2026-03-08", FoodReferenceID: 2, QuantityG: 100}, // Sunday, unpriced
+		{Date: "2026-03-18", FoodReferenceID: 1, QuantityG: 50},
+		{Date: "2026-02-27", FoodReferenceID: 1, QuantityG: 500}, // Before the range
+	}
+
+	weeks := BuildWeeklyFoodCosts(logged, prices, firstMonday, 3)
+
+	s.Equal([]WeeklyFoodCost{
+		{WeekStart: "2026-03-02", Total: 3, LoggedG: 400, PricedG: 300, CoveragePct: 75},
+		{WeekStart: "2026-03-09"},
+		{WeekStart: "2026-03-16", Total: 0.5, LoggedG: 50, PricedG: 50, CoveragePct: 100},
+	}, weeks)
+}
+
+func (s *FoodPriceSuite) TestSolverDropsSolutionsOverMaxCost() {
+	chicken := FoodNutrition{ID: 1, Category: FoodCategoryHighProtein, FoodItem: "Chicken Breast",
+		ProteinGPer100: 31, FatGPer100: 3.6, ServingUnit: "g", ServingSizeG: 120, IsPantryStaple: true}
+	rice := FoodNutrition{ID: 2, Category: FoodCategoryHighCarb, FoodItem: "Brown Rice",
+		ProteinGPer100: 7.5, CarbsGPer100: 76.2, FatGPer100: 2.7, ServingUnit: "g", ServingSizeG: 100, IsPantryStaple: true}
+	broccoli := FoodNutrition{ID: 3, Category: FoodCategoryVegetable, FoodItem: "Broccoli",
+		ProteinGPer100: 2.8, CarbsGPer100: 7, FatGPer100: 0.4, ServingUnit: "g", ServingSizeG: 100, IsPantryStaple: true}
+	req := SolverRequest{
+		RemainingBudget: MacroBudget{ProteinG: 45, CarbsG: 60, FatG: 10, CaloriesKcal: 500},
+		PantryFoods:     []FoodNutrition{chicken, rice, broccoli},
+		MinIngredients:  3,
+		MaxIngredients:  3,
+		MealTime:        "dinner",
+		Prices: FoodPrices{
+			1: {FoodReferenceID: 1, Amount: 1.5, Basis: PriceBasisPer100g},
+			2: {FoodReferenceID: 2, Amount: 0.3, Basis: PriceBasisPer100g},
+			3: {FoodReferenceID: 3, Amount: 0.5, Basis: PriceBasisPer100g},
+		},
+	}
+
+	priced := SolveMacros(req)
+	s.Require().True(priced.Computed)
+	for _, sol := range priced.Solutions {
+		s.Require().NotNil(sol.Cost)
+		s.True(sol.Cost.Complete)
+	}
+
+	limit := 0.01
+	req.MaxCost = &limit
+	s.False(SolveMacros(req).Computed, "nothing costs a cent")
+
+	delete(req.Prices, 3)
+	limit = 1000
+	s.False(SolveMacros(req).Computed, "unpriced broccoli can't be shown to fit")
+}
@@ -81,7 +81,7 @@ type MonthlySummary struct {
 	SessionCount          int          // Number of sessions
 	TotalCalories         int          // Total kcal burned
 	AvgCaloriesPerSession int          // Derived: total/count
-	DataSource            string       // e.g., "garmin_import"
+	DataSource            string       // MonthlySummarySourceGarmin or MonthlySummarySourceComputed
 	RawActivityName       string       // Original source name
 	CreatedAt             time.Time
 }
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// GROCERY LISTS AND SHOPPING ROLLUP
+// =============================================================================
+//
+// A grocery list is saved for a week and its items are ticked off as they are
+// bought. Each purchase is matched against the portions logged for that food
+// in the GroceryUseDays days from the purchase date. Logged grams are used up
+// oldest purchase first, so one portion never counts against two purchases.
+// Whatever a purchase's window didn't cover is counted as wasted once the
+// window has closed; purchases still inside their window are reported as
+// pending rather than guessed at.
+
+const (
+	// GroceryUseDays is how many days from purchase logged portions count towards an item.
+	GroceryUseDays = 10
+	// MaxGroceryListItems caps the number of items on one list.
+	MaxGroceryListItems = 100
+	// MaxGroceryItemG caps a single item's quantity, catching unit mistakes.
+	MaxGroceryItemG = 20000.0
+)
+
+// GroceryItem is one food on a grocery list.
+type GroceryItem struct {
+	ID              int64   `json:"id"`
+	FoodReferenceID int64   `json:"foodReferenceId"`
+	AmountG         float64 `json:"amountG"`
+	PurchasedOn     *string `json:"purchasedOn,omitempty"` // YYYY-MM-DD; nil until bought
+}
+
+// GroceryList is the shopping list for a week.
+type GroceryList struct {
+	ID        int64         `json:"id"`
+	WeekStart string        `json:"weekStart"` // YYYY-MM-DD, the first day the list shops for
+	Items     []GroceryItem `json:"items"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// Validate checks the week start and items. Purchase dates are set as items
+// are bought, not on creation.
+func (l GroceryList) Validate() error {
+	if _, err := time.Parse("2006-01-02", l.WeekStart); err != nil {
+		return ErrInvalidDate
+	}
+	if len(l.Items) == 0 || len(l.Items) > MaxGroceryListItems {
+		return ErrInvalidGroceryItems
+	}
+	for _, item := range l.Items {
+		if item.AmountG <= 0 || item.AmountG > MaxGroceryItemG || math.IsNaN(item.AmountG) {
+			return ErrInvalidGroceryItemAmount
+		}
+	}
+	return nil
+}
+
+// ValidatePurchaseDate checks a purchase date (YYYY-MM-DD) isn't after today.
+func ValidatePurchaseDate(date string, now time.Time) error {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	if d.Format("2006-01-02") > now.Format("2006-01-02") {
+		return ErrFuturePurchaseDate
+	}
+	return nil
+}
+
+// PurchasedGroceryItem is a bought grocery item with the food details the
+// rollup reports and prices.
+type PurchasedGroceryItem struct {
+	ItemID          int64
+	FoodReferenceID int64
+	FoodItem        string
+	AmountG         float64
+	ServingSizeG    float64
+	PurchasedOn     string // YYYY-MM-DD
+}
+
+// ShoppingFood is what happened to one food bought during the month.
+type ShoppingFood struct {
+	FoodReferenceID int64    `json:"foodReferenceId"`
+	FoodItem        string   `json:"foodItem"`
+	Purchases       int      `json:"purchases"`
+	BoughtG         float64  `json:"boughtG"`
+	EatenG          float64  `json:"eatenG"`
+	WastedG         float64  `json:"wastedG"`
+	WastedPct       float64  `json:"wastedPct"`
+	WastedCost      *float64 `json:"wastedCost"` // nil when the food has no price
+}
+
+// ShoppingRollup compares a month's purchases with what was logged. Totals
+// cover purchases whose use window has closed; PendingItems counts the rest.
+type ShoppingRollup struct {
+	Month              string         `json:"month"` // YYYY-MM
+	ItemsBought        int            `json:"itemsBought"`
+	PendingItems       int            `json:"pendingItems"`
+	BoughtG            float64        `json:"boughtG"`
+	EatenG             float64        `json:"eatenG"`
+	WastedG            float64        `json:"wastedG"`
+	WastagePct         float64        `json:"wastagePct"`
+	WastedCost         float64        `json:"wastedCost"`         // Cost of the priced wasted grams
+	WastedCostComplete bool           `json:"wastedCostComplete"` // Every wasted food has a price
+	Foods              []ShoppingFood `json:"foods"`              // Most wasted first
+	BoughtNeverEaten   []ShoppingFood `json:"boughtNeverEaten"`   // Foods with no logged portion after any purchase
+}
+
+// BuildShoppingRollup builds the rollup for month (YYYY-MM). purchases should
+// be the items bought during the month; logged the daily food totals from the
+// month's start through GroceryUseDays days after its end. Windows ending on
+// or after today are pending.
+func BuildShoppingRollup(month string, purchases []PurchasedGroceryItem, logged []LoggedFoodQuantity, prices FoodPrices, today time.Time) (*ShoppingRollup, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, ErrInvalidRollupMonth
+	}
+	first := start.Format("2006-01-02")
+	end := start.AddDate(0, 1, -1).Format("2006-01-02")
+	todayStr := today.Format("2006-01-02")
+
+	// Grams logged per food per day, drawn down as purchases use them
+	remaining := make(map[int64]map[string]float64)
+	for _, l := range logged {
+		if remaining[l.FoodReferenceID] == nil {
+			remaining[l.FoodReferenceID] = make(map[string]float64)
+		}
+		remaining[l.FoodReferenceID][l.Date] += l.QuantityG
+	}
+
+	inMonth := make([]PurchasedGroceryItem, 0, len(purchases))
+	for _, p := range purchases {
+		if p.PurchasedOn >= first && p.PurchasedOn <= end {
+			inMonth = append(inMonth, p)
+		}
+	}
+	sort.Slice(inMonth, func(i, j int) bool {
+		if inMonth[i].PurchasedOn != inMonth[j].PurchasedOn {
+			return inMonth[i].PurchasedOn < inMonth[j].PurchasedOn
+		}
+		return inMonth[i].ItemID < inMonth[j].ItemID
+	})
+
+	rollup := &ShoppingRollup{
+		Month:              start.Format("2006-01"),
+		WastedCostComplete: true,
+		Foods:              []ShoppingFood{},
+		BoughtNeverEaten:   []ShoppingFood{},
+	}
+	byFood := make(map[int64]*ShoppingFood)
+	var order []int64
+	var wastedCost float64
+	for _, p := range inMonth {
+		purchased, err := time.Parse("2006-01-02", p.PurchasedOn)
+		if err != nil {
+			continue
+		}
+		rollup.ItemsBought++
+		windowEnd := purchased.AddDate(0, 0, GroceryUseDays-1)
+		if windowEnd.Format("2006-01-02") >= todayStr {
+			rollup.PendingItems++
+			continue
+		}
+
+		eaten := 0.0
+		days := remaining[p.FoodReferenceID]
+		for d := purchased; !d.After(windowEnd) && eaten < p.AmountG; d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			use := math.Min(days[date], p.AmountG-eaten)
+			if use > 0 {
+				days[date] -= use
+				eaten += use
+			}
+		}
+
+		food, ok := byFood[p.FoodReferenceID]
+		if !ok {
+			food = &ShoppingFood{FoodReferenceID: p.FoodReferenceID, FoodItem: p.FoodItem}
+			byFood[p.FoodReferenceID] = food
+			order = append(order, p.FoodReferenceID)
+		}
+		wasted := p.AmountG - eaten
+		food.Purchases++
+		food.BoughtG += p.AmountG
+		food.EatenG += eaten
+		food.WastedG += wasted
+		if wasted > 0 {
+			if price, ok := prices[p.FoodReferenceID]; ok {
+				cost := price.CostForGrams(wasted, p.ServingSizeG)
+				wastedCost += cost
+				if food.WastedCost == nil {
+					food.WastedCost = new(float64)
+				}
+				*food.WastedCost += cost
+			} else {
+				rollup.WastedCostComplete = false
+			}
+		}
+	}
+
+	for _, id := range order {
+		food := byFood[id]
+		rollup.BoughtG += food.BoughtG
+		rollup.EatenG += food.EatenG
+		rollup.WastedG += food.WastedG
+		if food.BoughtG > 0 {
+			food.WastedPct = math.Round(food.WastedG/food.BoughtG*1000) / 10
+		}
+		if food.WastedCost != nil {
+			rounded := roundCents(*food.WastedCost)
+			food.WastedCost = &rounded
+		}
+		food.BoughtG = math.Round(food.BoughtG)
+		food.EatenG = math.Round(food.EatenG)
+		food.WastedG = math.Round(food.WastedG)
+		rollup.Foods = append(rollup.Foods, *food)
+		if food.EatenG == 0 {
+			rollup.BoughtNeverEaten = append(rollup.BoughtNeverEaten, *food)
+		}
+	}
+	sort.SliceStable(rollup.Foods, func(i, j int) bool { return rollup.Foods[i].WastedG > rollup.Foods[j].WastedG })
+	sort.SliceStable(rollup.BoughtNeverEaten, func(i, j int) bool {
+		return rollup.BoughtNeverEaten[i].BoughtG > rollup.BoughtNeverEaten[j].BoughtG
+	})
+
+	if rollup.BoughtG > 0 {
+		rollup.WastagePct = math.Round(rollup.WastedG/rollup.BoughtG*1000) / 10
+	}
+	rollup.BoughtG = math.Round(rollup.BoughtG)
+	rollup.EatenG = math.Round(rollup.EatenG)
+	rollup.WastedG = math.Round(rollup.WastedG)
+	rollup.WastedCost = roundCents(wastedCost)
+	return rollup, nil
+}
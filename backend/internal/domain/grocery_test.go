@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The shopping rollup matches purchases to later logs through
+// a use window and a shared pool of logged grams; tests pin the window edges,
+// that a logged portion is never counted twice, pending purchases and the
+// wasted-cost coverage.
+type GrocerySuite struct {
+	suite.Suite
+}
+
+func TestGrocerySuite(t *testing.T) {
+	suite.Run(t, new(GrocerySuite))
+}
+
+func (s *GrocerySuite) TestValidate() {
+	list := GroceryList{WeekStart: "2026-03-02", Items: []GroceryItem{{FoodReferenceID: 1, AmountG: 500}}}
+	s.NoError(list.Validate())
+
+	s.ErrorIs(GroceryList{WeekStart: "next week", Items: list.Items}.Validate(), ErrInvalidDate)
+	s.ErrorIs(GroceryList{WeekStart: "2026-03-02"}.Validate(), ErrInvalidGroceryItems)
+	s.ErrorIs(GroceryList{WeekStart: "2026-03-02", Items: []GroceryItem{{FoodReferenceID: 1}}}.Validate(), ErrInvalidGroceryItemAmount)
+
+	now := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	s.NoError(ValidatePurchaseDate("2026-03-04", now))
+	s.ErrorIs(ValidatePurchaseDate("2026-03-05", now), ErrFuturePurchaseDate)
+	s.ErrorIs(ValidatePurchaseDate("4 March", now), ErrInvalidDate)
+}
+
+func (s *GrocerySuite) purchases() []PurchasedGroceryItem {
+	return []PurchasedGroceryItem{
+		{ItemID: 1, FoodReferenceID: 1, FoodItem: "Chicken Breast", AmountG: 1000, ServingSizeG: 120, PurchasedOn: "2026-03-02"},
+		{ItemID: 2, FoodReferenceID: 1, FoodItem: "Chicken Breast", AmountG: 500, ServingSizeG: 120, PurchasedOn: "2026-03-15"},
+		{ItemID: 3, FoodReferenceID: 2, FoodItem: "Spinach", AmountG: 200, ServingSizeG: 30, PurchasedOn: "2026-03-10"},
+		{ItemID: 4, FoodReferenceID: 3, FoodItem: "Brown Rice", AmountG: 1000, ServingSizeG: 100, PurchasedOn: "2026-03-28"},
+		{ItemID: 5, FoodReferenceID: 3, FoodItem: "Brown Rice", AmountG: 1000, ServingSizeG: 100, PurchasedOn: "2026-02-27"},
+	}
+}
+
+func (s *GrocerySuite) logged() []LoggedFoodQuantity {
+	return []LoggedFoodQuantity{
+		{Date: "2026-03-03", FoodReferenceID: 1, QuantityG: 300},
+		{Date: "2026-03-05", FoodReferenceID: 1, QuantityG: 400},
+		{Date: "2026-03-20", FoodReferenceID: 1, QuantityG: 500}, // Outside the first purchase's window
+		{Date: "2026-04-02", FoodReferenceID: 3, QuantityG: 400},
+	}
+}
+
+func (s *GrocerySuite) TestRollup() {
+	prices := FoodPrices{
+		1: {FoodReferenceID: 1, Amount: 1, Basis: PriceBasisPer100g},
+		3: {FoodReferenceID: 3, Amount: 0.5, Basis: PriceBasisServing},
+	}
+	today := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	rollup, err := BuildShoppingRollup("2026-03", s.purchases(), s.logged(), prices, today)
+	s.Require().NoError(err)
+	s.Equal(4, rollup.ItemsBought, "February's purchase is left out")
+	s.Equal(0, rollup.PendingItems)
+	s.Equal(2700.0, rollup.BoughtG)
+	s.Equal(1600.0, rollup.EatenG)
+	s.Equal(1100.0, rollup.WastedG)
+	s.Equal(40.7, rollup.WastagePct)
+	s.Equal(6.0, rollup.WastedCost) // 300g chicken at 1/100g + 600g rice at 0.5/serving
+	s.False(rollup.WastedCostComplete, "spinach has no price")
+
+	s.Require().Len(rollup.Foods, 3)
+	s.Equal("Brown Rice", rollup.Foods[0].FoodItem, "most wasted first")
+	chicken := rollup.Foods[1]
+	s.Equal(2, chicken.Purchases)
+	s.Equal(1500.0, chicken.BoughtG)
+	s.Equal(1200.0, chicken.EatenG)
+	s.Equal(20.0, chicken.WastedPct)
+	s.Equal(3.0, *chicken.WastedCost)
+	s.Nil(rollup.Foods[2].WastedCost)
+
+	s.Require().Len(rollup.BoughtNeverEaten, 1)
+	s.Equal("Spinach", rollup.BoughtNeverEaten[0].FoodItem)
+}
+
+func (s *GrocerySuite) TestOpenWindowsArePending() {
+	today := time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC)
+	rollup, err := BuildShoppingRollup("2026-03", s.purchases(), s.logged(), nil, today)
+	s.Require().NoError(err)
+	s.Equal(4, rollup.ItemsBought)
+	s.Equal(1, rollup.PendingItems, "the 28th's window runs to April 6th")
+	s.Equal(1700.0, rollup.BoughtG, "pending purchases stay out of the totals")
+}
+
+func (s *GrocerySuite) TestLoggedPortionsCountOnce() {
+	purchases := []PurchasedGroceryItem{
+		{ItemID: 1, FoodReferenceID: 4, FoodItem: "Eggs", AmountG: 200, PurchasedOn: "2026-03-01"},
+		{ItemID: 2, FoodReferenceID: 4, FoodItem: "Eggs", AmountG: 200, PurchasedOn: "2026-03-02"},
+	}
+	logged := []LoggedFoodQuantity{{Date: "2026-03-03", FoodReferenceID: 4, QuantityG: 300}}
+
+	rollup, err := BuildShoppingRollup("2026-03", purchases, logged, nil, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Equal(300.0, rollup.EatenG)
+	s.Equal(100.0, rollup.WastedG)
+	s.Empty(rollup.BoughtNeverEaten)
+}
+
+func (s *GrocerySuite) TestInvalidMonth() {
+	_, err := BuildShoppingRollup("2026-3", nil, nil, nil, time.Now())
+	s.ErrorIs(err, ErrInvalidRollupMonth)
+}
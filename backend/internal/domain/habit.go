@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// DAILY HABITS
+// =============================================================================
+//
+// Habits are user-defined yes/no items checked off each day (creatine taken,
+// 10k steps, 10 minutes of mobility). Each has a streak, and habits marked
+// CountInVitality feed a small component of the weekly vitality score.
+
+const (
+	// MaxHabitNameLength caps a habit's name.
+	MaxHabitNameLength = 60
+	// VitalityHabitWeight is the share of the vitality score habits take when
+	// any habit counts; the other components are scaled down to make room.
+	VitalityHabitWeight = 5.0
+)
+
+// Habit is a daily yes/no item on the checklist.
+type Habit struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	CountInVitality bool      `json:"countInVitality"`
+	Archived        bool      `json:"archived"`  // Hidden from the checklist, history kept
+	StartDate       string    `json:"startDate"` // YYYY-MM-DD; days before it don't count against the habit
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// Normalize trims the name and checks its length.
+func (h *Habit) Normalize() error {
+	h.Name = strings.TrimSpace(h.Name)
+	if h.Name == "" || utf8.RuneCountInString(h.Name) > MaxHabitNameLength {
+		return ErrInvalidHabitName
+	}
+	return nil
+}
+
+// HabitCheck records a habit done on a day.
+type HabitCheck struct {
+	HabitID int64
+	Date    string // YYYY-MM-DD
+}
+
+// HabitStreak is a habit's run of consecutive days done.
+type HabitStreak struct {
+	Current int `json:"currentStreak"` // Ending on the day, or the day before while the day is still open
+	Best    int `json:"bestStreak"`    // Longest run up to the day
+}
+
+// CalculateHabitStreak computes the streak as of a date from the dates the
+// habit was done. Dates after asOf are ignored. A day not yet checked doesn't
+// break the current streak; it's still running from the day before.
+func CalculateHabitStreak(doneDates []string, asOf string) HabitStreak {
+	done := make(map[string]bool, len(doneDates))
+	for _, d := range doneDates {
+		if d <= asOf {
+			done[d] = true
+		}
+	}
+
+	var streak HabitStreak
+	day, err := time.Parse("2006-01-02", asOf)
+	if err != nil {
+		return streak
+	}
+	if !done[asOf] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for done[day.Format("2006-01-02")] {
+		streak.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	// Best: walk each run from its first day
+	for d := range done {
+		t, _ := time.Parse("2006-01-02", d)
+		if done[t.AddDate(0, 0, -1).Format("2006-01-02")] {
+			continue // Not the start of a run
+		}
+		run := 0
+		for done[t.Format("2006-01-02")] {
+			run++
+			t = t.AddDate(0, 0, 1)
+		}
+		if run > streak.Best {
+			streak.Best = run
+		}
+	}
+	return streak
+}
+
+// ChecklistItem is one habit on a day's checklist.
+type ChecklistItem struct {
+	HabitID         int64  `json:"habitId"`
+	Name            string `json:"name"`
+	Done            bool   `json:"done"`
+	CountInVitality bool   `json:"countInVitality"`
+	HabitStreak
+}
+
+// BuildChecklist lists the active habits for a date with whether each was done
+// and its streak. checks may span any dates; only those up to date are used.
+func BuildChecklist(habits []Habit, checks []HabitCheck, date string) []ChecklistItem {
+	byHabit := make(map[int64][]string)
+	for _, c := range checks {
+		byHabit[c.HabitID] = append(byHabit[c.HabitID], c.Date)
+	}
+
+	items := make([]ChecklistItem, 0, len(habits))
+	for _, h := range habits {
+		if h.Archived {
+			continue
+		}
+		item := ChecklistItem{
+			HabitID:         h.ID,
+			Name:            h.Name,
+			CountInVitality: h.CountInVitality,
+			HabitStreak:     CalculateHabitStreak(byHabit[h.ID], date),
+		}
+		for _, d := range byHabit[h.ID] {
+			if d == date {
+				item.Done = true
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// HabitAdherence returns the percentage of habit-days done between start and
+// end (inclusive) over the active habits that count toward vitality. Days
+// before a habit's StartDate don't count. Returns nil when no habit-day counts.
+func HabitAdherence(habits []Habit, checks []HabitCheck, start, end string) *float64 {
+	startDay, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return nil
+	}
+	endDay, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return nil
+	}
+
+	done := make(map[HabitCheck]bool, len(checks))
+	for _, c := range checks {
+		done[c] = true
+	}
+
+	expected, completed := 0, 0
+	for _, h := range habits {
+		if !h.CountInVitality || h.Archived {
+			continue
+		}
+		for d := startDay; !d.After(endDay); d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			if date < h.StartDate {
+				continue
+			}
+			expected++
+			if done[HabitCheck{HabitID: h.ID, Date: date}] {
+				completed++
+			}
+		}
+	}
+	if expected == 0 {
+		return nil
+	}
+	pct := float64(completed) / float64(expected) * 100
+	return &pct
+}
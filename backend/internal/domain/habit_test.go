@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Streaks are easy to break with off-by-one day handling, and
+// habit adherence shifts the vitality score; tests pin both.
+type HabitSuite struct {
+	suite.Suite
+}
+
+func TestHabitSuite(t *testing.T) {
+	suite.Run(t, new(HabitSuite))
+}
+
+func (s *HabitSuite) TestNormalize() {
+	h := Habit{Name: "  Creatine  "}
+	s.Require().NoError(h.Normalize())
+	s.Equal("Creatine", h.Name)
+
+	s.ErrorIs((&Habit{Name: "   "}).Normalize(), ErrInvalidHabitName)
+	long := Habit{Name: string(make([]rune, MaxHabitNameLength+1))}
+	s.ErrorIs(long.Normalize(), ErrInvalidHabitName)
+}
+
+func (s *HabitSuite) TestStreak() {
+	dates := []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-07", "2026-03-08", "2026-03-10"}
+
+	s.Equal(HabitStreak{Current: 2, Best: 4}, CalculateHabitStreak(dates, "2026-03-08"))
+	s.Equal(HabitStreak{Current: 2, Best: 4}, CalculateHabitStreak(dates, "2026-03-09"), "today still open")
+	s.Equal(HabitStreak{Current: 1, Best: 4}, CalculateHabitStreak(dates, "2026-03-10"))
+	s.Equal(HabitStreak{Current: 0, Best: 4}, CalculateHabitStreak(dates, "2026-03-12"), "a missed day breaks it")
+	s.Equal(HabitStreak{Current: 2, Best: 2}, CalculateHabitStreak(dates, "2026-03-02"), "later checks are ignored")
+	s.Equal(HabitStreak{}, CalculateHabitStreak(nil, "2026-03-02"))
+}
+
+func (s *HabitSuite) TestChecklist() {
+	habits := []Habit{
+		{ID: 1, Name: "Creatine", CountInVitality: true},
+		{ID: 2, Name: "10k steps"},
+		{ID: 3, Name: "Old", Archived: true},
+	}
+	checks := []HabitCheck{{1, "2026-03-01"}, {1, "2026-03-02"}, {2, "2026-03-01"}, {3, "2026-03-02"}}
+
+	items := BuildChecklist(habits, checks, "2026-03-02")
+	s.Require().Len(items, 2, "archived habits are left off")
+	s.Equal(ChecklistItem{HabitID: 1, Name: "Creatine", Done: true, CountInVitality: true, HabitStreak: HabitStreak{Current: 2, Best: 2}}, items[0])
+	s.False(items[1].Done)
+	s.Equal(1, items[1].Current, "not done yet today")
+}
+
+func (s *HabitSuite) TestAdherence() {
+	habits := []Habit{
+		{ID: 1, CountInVitality: true, StartDate: "2026-01-01"},
+		{ID: 2, CountInVitality: true, StartDate: "2026-03-06"}, // Added mid-week
+		{ID: 3, StartDate: "2026-01-01"},                         // Not counted
+	}
+	checks := []HabitCheck{
+		{1, "2026-03-02"}, {1, "2026-03-03"}, {1, "2026-03-04"}, {1, "2026-03-05"},
+		{2, "2026-03-06"}, {2, "2026-03-07"},
+		{3, "2026-03-02"},
+	}
+
+	pct := HabitAdherence(habits, checks, "2026-03-02", "2026-03-08")
+	s.Require().NotNil(pct)
+	s.InDelta(60.0, *pct, 0.001, "6 of 7 + 3 habit-days")
+
+	s.Nil(HabitAdherence(habits[2:], checks, "2026-03-02", "2026-03-08"), "no habit counts")
+}
+
+func (s *HabitSuite) TestVitalityComponent() {
+	logs := []DailyLog{{Date: "2026-03-02", WeightKg: 80, SleepQuality: 80}}
+	scoring := GetVitalityProfile(VitalityProfileMaintenance)
+	without := CalculateVitalityScore(logs, nil, scoring)
+	s.Nil(without.HabitAdherence)
+
+	full := 100.0
+	scoring.HabitAdherence = &full
+	with := CalculateVitalityScore(logs, nil, scoring)
+	s.Require().NotNil(with.HabitAdherence)
+	s.InDelta(without.Overall*0.95+5, with.Overall, 0.1)
+
+	none := 0.0
+	scoring.HabitAdherence = &none
+	s.InDelta(without.Overall*0.95, CalculateVitalityScore(logs, nil, scoring).Overall, 0.1)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// SERVICE HEALTH
+// =============================================================================
+//
+// Liveness and readiness probes report the status of each dependency so
+// container orchestration can restart or drain the server. Critical
+// components (the database and its schema) make the server unready when down;
+// optional ones (Ollama, background jobs) only degrade it.
+
+// ComponentStatus is the health of one dependency.
+type ComponentStatus string
+
+const (
+	ComponentOK       ComponentStatus = "ok"
+	ComponentDegraded ComponentStatus = "degraded"
+	ComponentDown     ComponentStatus = "down"
+)
+
+// JobLagTolerance is how many intervals a background job may miss before it
+// is reported as lagging.
+const JobLagTolerance = 2
+
+// HealthComponent is the probe result for one dependency.
+type HealthComponent struct {
+	Name      string          `json:"name"`
+	Status    ComponentStatus `json:"status"`
+	Critical  bool            `json:"critical"`
+	Detail    string          `json:"detail,omitempty"`
+	LatencyMs int64           `json:"latencyMs"`
+}
+
+// HealthReport aggregates component probes.
+type HealthReport struct {
+	Status     ComponentStatus   `json:"status"`
+	Components []HealthComponent `json:"components"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+}
+
+// NewHealthReport aggregates components: down if any critical component is
+// down, degraded if any other component isn't ok, otherwise ok.
+func NewHealthReport(components []HealthComponent, now time.Time) *HealthReport {
+	report := &HealthReport{Status: ComponentOK, Components: components, CheckedAt: now}
+	for _, c := range components {
+		switch {
+		case c.Status == ComponentDown && c.Critical:
+			report.Status = ComponentDown
+		case c.Status != ComponentOK && report.Status == ComponentOK:
+			report.Status = ComponentDegraded
+		}
+	}
+	return report
+}
+
+// IsReady reports whether the server can take traffic.
+func (r *HealthReport) IsReady() bool {
+	return r.Status != ComponentDown
+}
+
+// JobHeartbeat tracks when a background job last completed a run.
+type JobHeartbeat struct {
+	Name      string
+	Interval  time.Duration // Expected time between runs
+	StartedAt time.Time
+	LastRun   time.Time // Zero until the first run completes
+	LastError string
+	Standby   bool // Another replica holds the job's lease
+}
+
+// Lag returns how overdue the job is: the time since its last run (or since
+// it started, if it hasn't run yet) beyond the expected interval.
+func (h JobHeartbeat) Lag(now time.Time) time.Duration {
+	since := h.StartedAt
+	if !h.LastRun.IsZero() {
+		since = h.LastRun
+	}
+	lag := now.Sub(since) - h.Interval
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+// Component reports the job as degraded once it has missed JobLagTolerance
+// intervals, or when its last run failed. Standby jobs are ok: another
+// replica runs them.
+func (h JobHeartbeat) Component(now time.Time) HealthComponent {
+	c := HealthComponent{Name: "job:" + h.Name, Status: ComponentOK}
+	if h.Standby {
+		c.Detail = "standby: another replica holds the lease"
+		return c
+	}
+	if lag := h.Lag(now); lag > time.Duration(JobLagTolerance-1)*h.Interval {
+		c.Status = ComponentDegraded
+		c.Detail = fmt.Sprintf("last run overdue by %s", lag.Round(time.Second))
+		return c
+	}
+	if h.LastError != "" {
+		c.Status = ComponentDegraded
+		c.Detail = "last run failed: " + h.LastError
+	}
+	return c
+}
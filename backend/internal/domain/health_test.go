@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+func (s *HealthSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *HealthSuite) TestReportAggregation() {
+	db := HealthComponent{Name: "database", Status: ComponentOK, Critical: true}
+	ollama := HealthComponent{Name: "ollama", Status: ComponentOK}
+
+	report := NewHealthReport([]HealthComponent{db, ollama}, s.now)
+	s.Equal(ComponentOK, report.Status)
+
+	ollama.Status = ComponentDegraded
+	report = NewHealthReport([]HealthComponent{db, ollama}, s.now)
+	s.Equal(ComponentDegraded, report.Status)
+	s.True(report.IsReady(), "optional components don't make the server unready")
+
+	db.Status = ComponentDown
+	report = NewHealthReport([]HealthComponent{ollama, db}, s.now)
+	s.Equal(ComponentDown, report.Status)
+	s.False(report.IsReady())
+}
+
+func (s *HealthSuite) TestJobLag() {
+	hb := JobHeartbeat{Name: "draft_lifecycle", Interval: 15 * time.Minute, StartedAt: s.now.Add(-20 * time.Minute)}
+
+	s.Equal(5*time.Minute, hb.Lag(s.now), "measured from start until the first run")
+	s.Equal(ComponentOK, hb.Component(s.now).Status)
+
+	hb.LastRun = s.now.Add(-31 * time.Minute)
+	c := hb.Component(s.now)
+	s.Equal(ComponentDegraded, c.Status, "two missed intervals")
+	s.Equal("job:draft_lifecycle", c.Name)
+	s.False(c.Critical)
+
+	hb.LastRun = s.now.Add(-time.Minute)
+	s.Equal(time.Duration(0), hb.Lag(s.now))
+	hb.LastError = "connection refused"
+	s.Equal(ComponentDegraded, hb.Component(s.now).Status)
+}
+
+func (s *HealthSuite) TestStandbyJobIsNotLagging() {
+	hb := JobHeartbeat{Name: "kcal_factor_tune", Interval: time.Hour, StartedAt: s.now.Add(-24 * time.Hour), Standby: true}
+
+	c := hb.Component(s.now)
+	s.Equal(ComponentOK, c.Status)
+	s.Contains(c.Detail, "standby")
+}
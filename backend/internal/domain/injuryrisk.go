@@ -0,0 +1,388 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// INJURY RISK REPORT
+// =============================================================================
+//
+// A monthly look at the warning signs that precede overuse injuries, apart
+// from the weekly debrief's adherence view. Each sign becomes a risk factor
+// with a specific mitigation:
+//   - load spikes: runs of days with the acute:chronic workload ratio above
+//     the optimal zone;
+//   - recurring issues: the same joint reported sore, tight or painful on
+//     several days of the month;
+//   - fatigue asymmetry: one side of a limb muscle overloaded day after day.
+// Issues that start within a week of a load spike are escalated, since load
+// piled onto an already irritated joint is the classic route to injury, as is
+// asymmetry on a limb whose joint keeps being reported.
+
+// InjuryRiskLevel grades a risk factor or the whole month.
+type InjuryRiskLevel string
+
+const (
+	InjuryRiskLow      InjuryRiskLevel = "low"
+	InjuryRiskModerate InjuryRiskLevel = "moderate"
+	InjuryRiskHigh     InjuryRiskLevel = "high"
+)
+
+// InjuryRiskFactorKind is the kind of warning sign.
+type InjuryRiskFactorKind string
+
+const (
+	InjuryRiskLoadSpike      InjuryRiskFactorKind = "load_spike"
+	InjuryRiskRecurringIssue InjuryRiskFactorKind = "recurring_issue"
+	InjuryRiskAsymmetry      InjuryRiskFactorKind = "fatigue_asymmetry"
+)
+
+const (
+	// InjuryRiskRecurringIssueDays is the number of days in the month a joint
+	// must be reported on to count as recurring.
+	InjuryRiskRecurringIssueDays = 3
+	// InjuryRiskPersistentIssueDays makes a recurring issue high risk on its own.
+	InjuryRiskPersistentIssueDays = 5
+	// InjuryRiskSpikeFollowDays is how long after a load spike a joint issue is
+	// taken to be related to it.
+	InjuryRiskSpikeFollowDays = 7
+	// InjuryRiskAsymmetryDays is the number of days one side of a muscle must
+	// end overloaded to count as a risk factor.
+	InjuryRiskAsymmetryDays = 7
+	// InjuryRiskPersistentAsymmetryDays makes an asymmetry high risk on its own.
+	InjuryRiskPersistentAsymmetryDays = 14
+)
+
+// InjuryRiskFactor is one warning sign found in the month.
+type InjuryRiskFactor struct {
+	Kind           InjuryRiskFactorKind `json:"kind"`
+	Level          InjuryRiskLevel      `json:"level"`
+	StartDate      string               `json:"startDate"`
+	EndDate        string               `json:"endDate"`
+	Joint          string               `json:"joint,omitempty"`     // Recurring issues
+	IssueDays      int                  `json:"issueDays,omitempty"` // Recurring issues: days reported
+	Symptoms       []string             `json:"symptoms,omitempty"`  // Recurring issues: distinct symptoms, sorted
+	AfterSpike     bool                 `json:"afterSpike,omitempty"`
+	PeakACR        float64              `json:"peakAcr,omitempty"`       // Load spikes
+	Muscle         MuscleGroup          `json:"muscle,omitempty"`        // Fatigue asymmetry
+	Side           BodySide             `json:"side,omitempty"`          // Fatigue asymmetry: the overloaded side
+	AsymmetryDays  int                  `json:"asymmetryDays,omitempty"` // Fatigue asymmetry: days ended overloaded
+	PeakGap        float64              `json:"peakGap,omitempty"`       // Fatigue asymmetry: widest gap between sides
+	Detail         string               `json:"detail"`
+	Recommendation string               `json:"recommendation"`
+}
+
+// InjuryRiskReport is the injury risk analysis of one month.
+type InjuryRiskReport struct {
+	Month            string             `json:"month"` // YYYY-MM
+	StartDate        string             `json:"startDate"`
+	EndDate          string             `json:"endDate"` // Month end, or the day it was analyzed to
+	Level            InjuryRiskLevel    `json:"level"`
+	PeakACR          float64            `json:"peakAcr"`
+	DaysAboveOptimal int                `json:"daysAboveOptimal"` // Logged days with ACR above ACROptimalUpper
+	Factors          []InjuryRiskFactor `json:"factors"`          // Highest risk first
+}
+
+// InjuryRiskInput is the data a report is built from.
+type InjuryRiskInput struct {
+	Month     string               // YYYY-MM
+	StartDate string               // First day of the month
+	EndDate   string               // Last day analyzed
+	Loads     []DailyLoadDataPoint // Oldest first, from 28 days before StartDate to EndDate
+	Issues    []BodyPartIssue      // Reported between StartDate and EndDate
+	Sides     []DailySideFatigue   // Oldest first, StartDate to EndDate
+}
+
+// DailySideFatigue is the left/right split of the limb muscles whose sides
+// differed at the end of a day.
+type DailySideFatigue struct {
+	Date  string
+	Sides map[MuscleGroup]SideFatigue
+}
+
+// InjuryRiskPeriod returns the first day of month (YYYY-MM) and the last day
+// to analyze: the month's end, or today for the current month.
+func InjuryRiskPeriod(month string, today time.Time) (start, end string, err error) {
+	first, err := time.Parse("2006-01", month)
+	if err != nil {
+		return "", "", ErrInvalidInjuryRiskMonth
+	}
+	todayStr := today.Format("2006-01-02")
+	start = first.Format("2006-01-02")
+	if start > todayStr {
+		return "", "", ErrInvalidInjuryRiskMonth
+	}
+	end = first.AddDate(0, 1, -1).Format("2006-01-02")
+	if end > todayStr {
+		end = todayStr
+	}
+	return start, end, nil
+}
+
+// BuildInjuryRiskReport analyzes a month for load spikes, recurring joint
+// issues and fatigue asymmetry. The month's level is the highest factor level, raised to high when
+// two or more factors are moderate.
+func BuildInjuryRiskReport(in InjuryRiskInput) InjuryRiskReport {
+	report := InjuryRiskReport{
+		Month:     in.Month,
+		StartDate: in.StartDate,
+		EndDate:   in.EndDate,
+		Level:     InjuryRiskLow,
+		Factors:   []InjuryRiskFactor{},
+	}
+
+	spikes := injuryRiskLoadSpikes(in, &report)
+	report.Factors = append(report.Factors, spikes...)
+	recurring := injuryRiskRecurringIssues(in.Issues, spikes)
+	report.Factors = append(report.Factors, recurring...)
+	report.Factors = append(report.Factors, injuryRiskAsymmetries(in.Sides, recurring)...)
+
+	sort.SliceStable(report.Factors, func(i, j int) bool {
+		return injuryRiskRank(report.Factors[i].Level) > injuryRiskRank(report.Factors[j].Level)
+	})
+
+	moderate := 0
+	for _, f := range report.Factors {
+		if injuryRiskRank(f.Level) > injuryRiskRank(report.Level) {
+			report.Level = f.Level
+		}
+		if f.Level == InjuryRiskModerate {
+			moderate++
+		}
+	}
+	if moderate >= 2 {
+		report.Level = InjuryRiskHigh
+	}
+	return report
+}
+
+// injuryRiskLoadSpikes finds runs of logged days in the month with ACR above
+// the optimal zone, and records the month's peak ACR on the report.
+func injuryRiskLoadSpikes(in InjuryRiskInput, report *InjuryRiskReport) []InjuryRiskFactor {
+	var spikes []InjuryRiskFactor
+	var current *InjuryRiskFactor
+	var chronicAtPeak float64
+
+	closeSpike := func() {
+		if current == nil {
+			return
+		}
+		current.PeakACR = RoundTo(current.PeakACR, 2)
+		current.Level = InjuryRiskModerate
+		current.Detail = fmt.Sprintf("Acute:chronic workload ratio above %.1f from %s to %s, peaking at %.2f.",
+			ACROptimalUpper, current.StartDate, current.EndDate, current.PeakACR)
+		current.Recommendation = "Hold training load steady for a week before adding more, and raise it by no more than 10% a week after that."
+		if current.PeakACR > ACRHighUpper {
+			current.Level = InjuryRiskHigh
+			current.Recommendation = fmt.Sprintf("Bring the next week's load back to about your chronic average (%.0f per day), then raise it by no more than 10%% a week.", chronicAtPeak)
+		}
+		spikes = append(spikes, *current)
+		current = nil
+	}
+
+	for i, p := range in.Loads {
+		if p.Date < in.StartDate || p.Date > in.EndDate {
+			continue
+		}
+		window := in.Loads[:i+1]
+		chronic := CalculateChronicLoad(window)
+		acr := CalculateACR(CalculateAcuteLoad(window), chronic)
+		if acr > report.PeakACR {
+			report.PeakACR = RoundTo(acr, 2)
+		}
+		if acr <= ACROptimalUpper {
+			closeSpike()
+			continue
+		}
+
+		report.DaysAboveOptimal++
+		if current == nil {
+			current = &InjuryRiskFactor{Kind: InjuryRiskLoadSpike, StartDate: p.Date}
+		}
+		current.EndDate = p.Date
+		if acr > current.PeakACR {
+			current.PeakACR = acr
+			chronicAtPeak = chronic
+		}
+	}
+	closeSpike()
+	return spikes
+}
+
+// injuryRiskRecurringIssues groups the month's issues by joint and flags the
+// joints reported on InjuryRiskRecurringIssueDays or more. Healing reports
+// don't count.
+func injuryRiskRecurringIssues(issues []BodyPartIssue, spikes []InjuryRiskFactor) []InjuryRiskFactor {
+	type jointIssues struct {
+		dates    map[string]bool
+		symptoms map[string]bool
+		severe   bool
+	}
+	byJoint := make(map[string]*jointIssues)
+	for _, issue := range issues {
+		if issue.Severity == IssueSeverityHealing {
+			continue
+		}
+		for _, joint := range JointsForMuscle(issue.BodyPart) {
+			ji := byJoint[joint]
+			if ji == nil {
+				ji = &jointIssues{dates: map[string]bool{}, symptoms: map[string]bool{}}
+				byJoint[joint] = ji
+			}
+			ji.dates[issue.Date] = true
+			ji.symptoms[issue.Symptom] = true
+			ji.severe = ji.severe || issue.Severity == IssueSeveritySevere
+		}
+	}
+
+	var factors []InjuryRiskFactor
+	for _, joint := range TrackedJoints() {
+		ji := byJoint[joint]
+		if ji == nil || len(ji.dates) < InjuryRiskRecurringIssueDays {
+			continue
+		}
+		dates := sortedKeys(ji.dates)
+		symptoms := sortedKeys(ji.symptoms)
+		name := strings.ReplaceAll(joint, "_", " ")
+
+		f := InjuryRiskFactor{
+			Kind:       InjuryRiskRecurringIssue,
+			Level:      InjuryRiskModerate,
+			StartDate:  dates[0],
+			EndDate:    dates[len(dates)-1],
+			Joint:      joint,
+			IssueDays:  len(dates),
+			Symptoms:   symptoms,
+			AfterSpike: issueFollowsSpike(dates, spikes),
+			Detail: fmt.Sprintf("%s issues reported on %d days (%s).",
+				strings.ToUpper(name[:1])+name[1:], len(dates), strings.Join(symptoms, ", ")),
+			Recommendation: fmt.Sprintf("Cut volume on movements that load the %s or swap them for variations that spare it, and warm it up specifically before training.", name),
+		}
+		if ji.severe || len(dates) >= InjuryRiskPersistentIssueDays || f.AfterSpike {
+			f.Level = InjuryRiskHigh
+			f.Recommendation += fmt.Sprintf(" Have the %s assessed if it doesn't settle after a week of lighter training.", name)
+		}
+		if f.AfterSpike {
+			f.Detail += " They started within a week of a load spike."
+		}
+		factors = append(factors, f)
+	}
+	return factors
+}
+
+// injuryRiskAsymmetries flags muscles whose same side ended
+// InjuryRiskAsymmetryDays or more days of the month overloaded. They're high
+// risk when it persists or when the muscle's joint has a recurring issue.
+func injuryRiskAsymmetries(days []DailySideFatigue, recurring []InjuryRiskFactor) []InjuryRiskFactor {
+	type sideKey struct {
+		muscle MuscleGroup
+		side   BodySide
+	}
+	byKey := make(map[sideKey]*InjuryRiskFactor)
+	var keys []sideKey
+	for _, day := range days {
+		for muscle, sides := range day.Sides {
+			side := sides.Overloaded()
+			if side == "" {
+				continue
+			}
+			key := sideKey{muscle, side}
+			f := byKey[key]
+			if f == nil {
+				f = &InjuryRiskFactor{Kind: InjuryRiskAsymmetry, Muscle: muscle, Side: side, StartDate: day.Date}
+				byKey[key] = f
+				keys = append(keys, key)
+			}
+			f.EndDate = day.Date
+			f.AsymmetryDays++
+			f.PeakGap = math.Max(f.PeakGap, RoundTo(sides.Gap(), 1))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].muscle != keys[j].muscle {
+			return keys[i].muscle < keys[j].muscle
+		}
+		return keys[i].side < keys[j].side
+	})
+
+	troubledJoints := make(map[string]bool)
+	for _, f := range recurring {
+		troubledJoints[f.Joint] = true
+	}
+
+	var factors []InjuryRiskFactor
+	for _, key := range keys {
+		f := byKey[key]
+		if f.AsymmetryDays < InjuryRiskAsymmetryDays {
+			continue
+		}
+		name := strings.ReplaceAll(string(f.Muscle), "_", " ")
+		f.Level = InjuryRiskModerate
+		f.Detail = fmt.Sprintf("%s %s carried more fatigue than the %s on %d days, up to %.0f points more.",
+			strings.ToUpper(string(f.Side)[:1])+string(f.Side)[1:], name, oppositeSide(f.Side), f.AsymmetryDays, f.PeakGap)
+		f.Recommendation = fmt.Sprintf("Lead unilateral work with the %s side and match its volume on the %s, or swap in bilateral variations until the sides even out.",
+			oppositeSide(f.Side), f.Side)
+		for _, joint := range JointsForMuscle(f.Muscle) {
+			if troubledJoints[joint] {
+				f.Level = InjuryRiskHigh
+				f.Detail += fmt.Sprintf(" The %s has recurring issues this month too.", strings.ReplaceAll(joint, "_", " "))
+				break
+			}
+		}
+		if f.AsymmetryDays >= InjuryRiskPersistentAsymmetryDays {
+			f.Level = InjuryRiskHigh
+		}
+		factors = append(factors, *f)
+	}
+	return factors
+}
+
+func oppositeSide(side BodySide) BodySide {
+	if side == SideLeft {
+		return SideRight
+	}
+	return SideLeft
+}
+
+// issueFollowsSpike reports whether any issue date falls within a load spike
+// or the InjuryRiskSpikeFollowDays after it.
+func issueFollowsSpike(dates []string, spikes []InjuryRiskFactor) bool {
+	for _, spike := range spikes {
+		end, err := time.Parse("2006-01-02", spike.EndDate)
+		if err != nil {
+			continue
+		}
+		until := end.AddDate(0, 0, InjuryRiskSpikeFollowDays).Format("2006-01-02")
+		for _, d := range dates {
+			if d >= spike.StartDate && d <= until {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func injuryRiskRank(level InjuryRiskLevel) int {
+	switch level {
+	case InjuryRiskHigh:
+		return 2
+	case InjuryRiskModerate:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The injury risk level is what prompts a deload or an
+// assessment; tests pin when a load spike or a recurring joint issue is
+// flagged, when it escalates, and how factors combine into the month's level.
+type InjuryRiskSuite struct {
+	suite.Suite
+}
+
+func TestInjuryRiskSuite(t *testing.T) {
+	suite.Run(t, new(InjuryRiskSuite))
+}
+
+// loads builds a daily load series from 2026-02-01 (28 days before March),
+// 100 a day except for the overrides.
+func (s *InjuryRiskSuite) loads(through string, overrides map[string]float64) []DailyLoadDataPoint {
+	var points []DailyLoadDataPoint
+	end, _ := time.Parse("2006-01-02", through)
+	for day, _ := time.Parse("2006-01-02", "2026-02-01"); !day.After(end); day = day.AddDate(0, 0, 1) {
+		d := day.Format("2006-01-02")
+		load := 100.0
+		if v, ok := overrides[d]; ok {
+			load = v
+		}
+		points = append(points, DailyLoadDataPoint{Date: d, DailyLoad: load})
+	}
+	return points
+}
+
+func (s *InjuryRiskSuite) input(loads []DailyLoadDataPoint, issues []BodyPartIssue) InjuryRiskInput {
+	return InjuryRiskInput{Month: "2026-03", StartDate: "2026-03-01", EndDate: "2026-03-31", Loads: loads, Issues: issues}
+}
+
+func (s *InjuryRiskSuite) issue(date string, part MuscleGroup, severity IssueSeverity) BodyPartIssue {
+	return BodyPartIssue{Date: date, BodyPart: part, Symptom: "sore", Severity: severity}
+}
+
+func (s *InjuryRiskSuite) TestSteadyLoadIsLowRisk() {
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", nil), nil))
+	s.Equal(InjuryRiskLow, r.Level)
+	s.Empty(r.Factors)
+	s.Equal(1.0, r.PeakACR)
+	s.Zero(r.DaysAboveOptimal)
+}
+
+func (s *InjuryRiskSuite) TestLoadSpike() {
+	spike := map[string]float64{}
+	for d := 10; d <= 13; d++ {
+		spike[fmt.Sprintf("2026-03-%02d", d)] = 250
+	}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", spike), nil))
+
+	s.Require().Len(r.Factors, 1)
+	f := r.Factors[0]
+	s.Equal(InjuryRiskLoadSpike, f.Kind)
+	s.Equal(InjuryRiskHigh, f.Level, "peaks above 1.5")
+	s.Equal("2026-03-12", f.StartDate, "ACR only clears 1.3 on the third heavy day")
+	s.Greater(f.PeakACR, ACRHighUpper)
+	s.Equal(f.PeakACR, r.PeakACR)
+	s.Equal(InjuryRiskHigh, r.Level)
+	s.Contains(f.Recommendation, "chronic average")
+}
+
+func (s *InjuryRiskSuite) TestModerateSpike() {
+	spike := map[string]float64{"2026-03-10": 250, "2026-03-11": 250, "2026-03-12": 250}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", spike), nil))
+
+	s.Require().Len(r.Factors, 1)
+	s.Equal(InjuryRiskModerate, r.Factors[0].Level)
+	s.LessOrEqual(r.Factors[0].PeakACR, ACRHighUpper)
+	s.Equal(InjuryRiskModerate, r.Level)
+}
+
+func (s *InjuryRiskSuite) TestRecurringIssueGroupsByJoint() {
+	issues := []BodyPartIssue{
+		s.issue("2026-03-03", MuscleQuads, IssueSeverityMinor),
+		s.issue("2026-03-08", MuscleHamstrings, IssueSeverityMinor),
+		s.issue("2026-03-08", MuscleQuads, IssueSeverityMinor),
+		s.issue("2026-03-20", MuscleQuads, IssueSeverityModerate),
+		s.issue("2026-03-25", MuscleQuads, IssueSeverityHealing),
+		s.issue("2026-03-04", MuscleBiceps, IssueSeverityMinor),
+		s.issue("2026-03-05", MuscleBiceps, IssueSeverityMinor),
+	}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", nil), issues))
+
+	s.Require().Len(r.Factors, 1, "elbow only reported on two days")
+	f := r.Factors[0]
+	s.Equal(InjuryRiskRecurringIssue, f.Kind)
+	s.Equal("knee", f.Joint)
+	s.Equal(3, f.IssueDays, "same-day reports count once, healing doesn't count")
+	s.Equal("2026-03-03", f.StartDate)
+	s.Equal("2026-03-20", f.EndDate)
+	s.Equal(InjuryRiskModerate, f.Level)
+	s.False(f.AfterSpike)
+}
+
+func (s *InjuryRiskSuite) TestRecurringIssueEscalates() {
+	severe := []BodyPartIssue{
+		s.issue("2026-03-03", MuscleLowerBack, IssueSeverityMinor),
+		s.issue("2026-03-05", MuscleLowerBack, IssueSeverityMinor),
+		s.issue("2026-03-07", MuscleLowerBack, IssueSeveritySevere),
+	}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", nil), severe))
+	s.Require().Len(r.Factors, 1)
+	s.Equal(InjuryRiskHigh, r.Factors[0].Level)
+	s.Contains(r.Factors[0].Recommendation, "assessed")
+
+	// Minor issues starting right after a spike
+	spike := map[string]float64{}
+	for d := 10; d <= 13; d++ {
+		spike[fmt.Sprintf("2026-03-%02d", d)] = 250
+	}
+	afterSpike := []BodyPartIssue{
+		s.issue("2026-03-15", MuscleFrontDelt, IssueSeverityMinor),
+		s.issue("2026-03-17", MuscleFrontDelt, IssueSeverityMinor),
+		s.issue("2026-03-19", MuscleSideDelt, IssueSeverityMinor),
+	}
+	r = BuildInjuryRiskReport(s.input(s.loads("2026-03-31", spike), afterSpike))
+	var shoulder *InjuryRiskFactor
+	for i := range r.Factors {
+		if r.Factors[i].Joint == "shoulder" {
+			shoulder = &r.Factors[i]
+		}
+	}
+	s.Require().NotNil(shoulder)
+	s.True(shoulder.AfterSpike)
+	s.Equal(InjuryRiskHigh, shoulder.Level)
+}
+
+func (s *InjuryRiskSuite) TestTwoModerateFactorsAreHighRisk() {
+	spike := map[string]float64{"2026-03-10": 250, "2026-03-11": 250, "2026-03-12": 250}
+	issues := []BodyPartIssue{
+		s.issue("2026-03-01", MuscleCalves, IssueSeverityMinor),
+		s.issue("2026-03-02", MuscleCalves, IssueSeverityMinor),
+		s.issue("2026-03-03", MuscleCalves, IssueSeverityMinor),
+	}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", spike), issues))
+
+	s.Require().Len(r.Factors, 2)
+	for _, f := range r.Factors {
+		s.Equal(InjuryRiskModerate, f.Level)
+	}
+	s.Equal(InjuryRiskHigh, r.Level)
+}
+
+// sides marks muscle's side overloaded (by gap points) on the days from..to
+// of March.
+func (s *InjuryRiskSuite) sides(muscle MuscleGroup, left bool, gap float64, from, to int) []DailySideFatigue {
+	var days []DailySideFatigue
+	for d := 1; d <= 31; d++ {
+		day := DailySideFatigue{Date: fmt.Sprintf("2026-03-%02d", d), Sides: map[MuscleGroup]SideFatigue{}}
+		if d >= from && d <= to {
+			split := SideFatigue{Left: 40 + gap, Right: 40}
+			if !left {
+				split = SideFatigue{Left: 40, Right: 40 + gap}
+			}
+			day.Sides[muscle] = split
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func (s *InjuryRiskSuite) TestFatigueAsymmetry() {
+	in := s.input(s.loads("2026-03-31", nil), nil)
+	in.Sides = s.sides(MuscleQuads, true, 20, 5, 12)
+	r := BuildInjuryRiskReport(in)
+
+	s.Require().Len(r.Factors, 1)
+	f := r.Factors[0]
+	s.Equal(InjuryRiskAsymmetry, f.Kind)
+	s.Equal(MuscleQuads, f.Muscle)
+	s.Equal(SideLeft, f.Side)
+	s.Equal(8, f.AsymmetryDays)
+	s.Equal("2026-03-05", f.StartDate)
+	s.Equal("2026-03-12", f.EndDate)
+	s.Equal(20.0, f.PeakGap)
+	s.Equal(InjuryRiskModerate, f.Level)
+	s.Contains(f.Recommendation, "right side")
+
+	// Gaps under the threshold and short runs aren't flagged
+	in.Sides = s.sides(MuscleQuads, true, 10, 1, 31)
+	s.Empty(BuildInjuryRiskReport(in).Factors)
+	in.Sides = s.sides(MuscleQuads, true, 20, 1, 6)
+	s.Empty(BuildInjuryRiskReport(in).Factors)
+
+	// Persistent asymmetry is high risk
+	in.Sides = s.sides(MuscleCalves, false, 25, 1, 14)
+	r = BuildInjuryRiskReport(in)
+	s.Require().Len(r.Factors, 1)
+	s.Equal(SideRight, r.Factors[0].Side)
+	s.Equal(InjuryRiskHigh, r.Factors[0].Level)
+}
+
+func (s *InjuryRiskSuite) TestAsymmetryOnTroubledJointIsHighRisk() {
+	issues := []BodyPartIssue{
+		s.issue("2026-03-03", MuscleQuads, IssueSeverityMinor),
+		s.issue("2026-03-08", MuscleQuads, IssueSeverityMinor),
+		s.issue("2026-03-10", MuscleQuads, IssueSeverityMinor),
+	}
+	in := s.input(s.loads("2026-03-31", nil), issues)
+	in.Sides = s.sides(MuscleQuads, true, 20, 5, 12)
+	r := BuildInjuryRiskReport(in)
+
+	s.Require().Len(r.Factors, 2)
+	s.Equal(InjuryRiskAsymmetry, r.Factors[0].Kind, "high risk sorts first")
+	s.Equal(InjuryRiskHigh, r.Factors[0].Level)
+	s.Contains(r.Factors[0].Detail, "knee has recurring issues")
+	s.Equal(InjuryRiskModerate, r.Factors[1].Level)
+}
+
+func (s *InjuryRiskSuite) TestDaysBeforeMonthOnlySetTheBaseline() {
+	spike := map[string]float64{"2026-02-25": 400, "2026-02-26": 400, "2026-02-27": 400}
+	r := BuildInjuryRiskReport(s.input(s.loads("2026-03-31", spike), nil))
+	for _, f := range r.Factors {
+		s.GreaterOrEqual(f.StartDate, "2026-03-01")
+	}
+}
+
+func (s *InjuryRiskSuite) TestPeriod() {
+	today := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+	start, end, err := InjuryRiskPeriod("2026-02", today)
+	s.Require().NoError(err)
+	s.Equal("2026-02-01", start)
+	s.Equal("2026-02-28", end)
+
+	start, end, err = InjuryRiskPeriod("2026-03", today)
+	s.Require().NoError(err)
+	s.Equal("2026-03-01", start)
+	s.Equal("2026-03-14", end, "current month runs to today")
+
+	_, _, err = InjuryRiskPeriod("2026-04", today)
+	s.ErrorIs(err, ErrInvalidInjuryRiskMonth)
+	_, _, err = InjuryRiskPeriod("March", today)
+	s.ErrorIs(err, ErrInvalidInjuryRiskMonth)
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Target generation combines rounding, day-type cycling, safety
+// caps and clamps; example-based tests cover known inputs, these properties
+// check the guarantees hold across randomized ones.
+type InvariantsSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestInvariantsSuite(t *testing.T) {
+	suite.Run(t, new(InvariantsSuite))
+}
+
+func (s *InvariantsSuite) SetupTest() {
+	s.now = time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+}
+
+// check runs a property with a fixed seed so failures are reproducible.
+func (s *InvariantsSuite) check(property interface{}) {
+	config := &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(42))}
+	s.NoError(quick.Check(property, config))
+}
+
+// between maps an arbitrary generated value onto [lo, hi].
+func between(v uint16, lo, hi float64) float64 {
+	return lo + (hi-lo)*float64(v)/65535
+}
+
+var invariantDayTypes = []DayType{DayTypePerformance, DayTypeFatburner, DayTypeMetabolize}
+var invariantGoals = []Goal{GoalLoseWeight, GoalMaintain, GoalGainWeight}
+var invariantTrainingTypes = []TrainingType{TrainingTypeRest, TrainingTypeWalking, TrainingTypeRun, TrainingTypeHIIT, TrainingTypeStrength}
+
+func invariantProfile(goal Goal) *UserProfile {
+	profile := &UserProfile{
+		HeightCM:  175,
+		BirthDate: time.Date(1988, 3, 10, 0, 0, 0, 0, time.UTC),
+		Sex:       SexFemale,
+		Goal:      goal,
+	}
+	profile.SetDefaults()
+	return profile
+}
+
+func (s *InvariantsSuite) TestDailyTargetsAverageToWeeklyTarget() {
+	s.check(func(carbs, protein, fats uint16, days [7]uint8) bool {
+		week := WeeklyTarget{
+			StartDate:      s.now,
+			TargetCarbsG:   int(carbs % 600),
+			TargetProteinG: int(protein % 300),
+			TargetFatsG:    int(fats % 200),
+		}
+		pattern := WeeklyDayPattern{
+			Day1: invariantDayTypes[days[0]%3], Day2: invariantDayTypes[days[1]%3],
+			Day3: invariantDayTypes[days[2]%3], Day4: invariantDayTypes[days[3]%3],
+			Day5: invariantDayTypes[days[4]%3], Day6: invariantDayTypes[days[5]%3],
+			Day7: invariantDayTypes[days[6]%3],
+		}
+
+		var sumCarbs, sumProtein, sumFats, sumCalories int
+		for _, day := range week.GenerateDailyTargets(pattern) {
+			if day.CarbsG < 0 || day.ProteinG < 0 || day.FatsG < 0 {
+				return false
+			}
+			sumCarbs += day.CarbsG
+			sumProtein += day.ProteinG
+			sumFats += day.FatsG
+			sumCalories += day.Calories
+		}
+
+		weeklyCalories := week.TargetCarbsG*4 + week.TargetProteinG*4 + week.TargetFatsG*9
+		return sumCarbs == 7*week.TargetCarbsG &&
+			sumProtein == 7*week.TargetProteinG &&
+			sumFats == 7*week.TargetFatsG &&
+			sumCalories == 7*weeklyCalories
+	})
+}
+
+func (s *InvariantsSuite) TestMealPointsAreMultiplesOfFive() {
+	s.check(func(weight, tdee uint16, goal, dayType, training, duration uint8) bool {
+		log := &DailyLog{
+			WeightKg:      between(weight, 40, 200),
+			EstimatedTDEE: int(between(tdee, 1200, 5000)),
+			DayType:       invariantDayTypes[dayType%3],
+			PlannedSessions: []TrainingSession{{
+				Type:        invariantTrainingTypes[int(training)%len(invariantTrainingTypes)],
+				DurationMin: int(duration%180) + 1,
+			}},
+		}
+		targets := CalculateDailyTargets(invariantProfile(invariantGoals[goal%3]), log, s.now)
+
+		for _, meal := range []MacroPoints{targets.Meals.Breakfast, targets.Meals.Lunch, targets.Meals.Dinner} {
+			if meal.Carbs%5 != 0 || meal.Protein%5 != 0 || meal.Fats%5 != 0 {
+				return false
+			}
+		}
+		return targets.FruitG%5 == 0 && targets.VeggiesG%5 == 0
+	})
+}
+
+func (s *InvariantsSuite) TestDeficitNeverExceedsSafetyLimit() {
+	withinLimit := func(plan *NutritionPlan) bool {
+		if plan.RequiredDailyDeficitKcal < -MaxSafeDeficitKcal {
+			return false
+		}
+		for _, week := range plan.WeeklyTargets {
+			if week.TargetIntakeKcal < week.ProjectedTDEE-MaxSafeDeficitKcal {
+				return false
+			}
+		}
+		return true
+	}
+
+	s.check(func(start, loss, actual uint16, duration, weeksIn uint8) bool {
+		startWeight := RoundTo(between(start, 60, 200), 1)
+		input := NutritionPlanInput{
+			StartDate:     s.now.Format("2006-01-02"),
+			StartWeightKg: startWeight,
+			GoalWeightKg:  RoundTo(startWeight-between(loss, 0.5, 30), 1),
+			DurationWeeks: MinPlanDurationWeeks + int(duration%49),
+		}
+		plan, err := NewNutritionPlan(input, invariantProfile(GoalLoseWeight), s.now)
+		if err != nil {
+			return errors.Is(err, ErrPlanDeficitTooAggressive) // Rejected, never created unsafe
+		}
+		if !withinLimit(plan) {
+			return false
+		}
+
+		// Falling behind and recalibrating must stay capped too
+		currentWeek := 1 + int(weeksIn)%plan.DurationWeeks
+		if currentWeek > 1 {
+			behind := RoundTo(startWeight+between(actual, -2, 5), 1)
+			plan.WeeklyTargets[currentWeek-2].ActualWeightKg = &behind
+		}
+		later := s.now.AddDate(0, 0, 7*(currentWeek-1))
+		recalibrated, err := ApplyRecalibration(plan, invariantProfile(GoalLoseWeight), RecalibrationIncreaseDeficit, later)
+		return err == nil && withinLimit(recalibrated)
+	})
+}
+
+func (s *InvariantsSuite) TestFatigueStaysWithinBounds() {
+	s.check(func(sessions []struct {
+		Duration, RPE, Coefficient, Hours uint8
+	}) bool {
+		fatigue := 0.0
+		for _, session := range sessions {
+			rpe := int(session.RPE%10) + 1
+			load := CalculateFatigueSessionLoad(int(session.Duration), &rpe)
+			injection := CalculateFatigueInjection(load, float64(session.Coefficient%101)/100)
+			fatigue = AddFatigue(ApplyFatigueDecay(fatigue, float64(session.Hours)), injection)
+			if fatigue < 0 || fatigue > 100 {
+				return false
+			}
+		}
+
+		overall := CalculateOverallFatigueScore([]MuscleFatigueState{
+			{Muscle: MuscleQuads, FatiguePercent: fatigue},
+			{Muscle: MuscleChest, FatiguePercent: ApplyFatigueDecay(fatigue, 12)},
+		})
+		return overall >= 0 && overall <= 100
+	})
+}
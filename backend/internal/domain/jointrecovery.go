@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// JOINT INTEGRITY RECOVERY
+// =============================================================================
+//
+// Joint integrity deltas from echo logs feed a daily series per joint. Each
+// day a joint first recovers part of its lost integrity (exponential, with a
+// configurable half-life), then takes that day's deltas. Rest days speed the
+// recovery up; pain reported around the joint slows it down.
+
+// EchoIssueRawText marks body issues created from echo joint deltas. Those
+// deltas are tracked by the joint series, so the issues don't lower joint
+// integrity a second time.
+const EchoIssueRawText = "from echo log"
+
+// JointRecoveryPolicy controls how fast joints recover.
+type JointRecoveryPolicy struct {
+	HalfLife      time.Duration // Time for half of the lost integrity to return
+	RestDayFactor float64       // Recovery speed multiplier on days without training
+	PainFactor    float64       // Recovery speed multiplier while pain is reported around the joint
+}
+
+// DefaultJointRecoveryPolicy halves lost integrity every 3 days, half again
+// as fast on rest days and at half speed while the joint hurts.
+var DefaultJointRecoveryPolicy = JointRecoveryPolicy{
+	HalfLife:      72 * time.Hour,
+	RestDayFactor: 1.5,
+	PainFactor:    0.5,
+}
+
+// JointDayInput is what happened to the joints on one day.
+type JointDayInput struct {
+	Date       string
+	RestDay    bool
+	PainJoints map[string]bool
+	Deltas     map[string]float64 // Joint -> summed echo delta (-1 to +1)
+}
+
+// JointIntegrityDay is one day of the joint integrity series.
+type JointIntegrityDay struct {
+	Date       string             `json:"date"`
+	Joints     map[string]float64 `json:"joints"` // 0-1 per joint, 1 = intact
+	RestDay    bool               `json:"restDay"`
+	PainJoints []string           `json:"painJoints,omitempty"`
+}
+
+// IntactJoints returns full integrity for every tracked joint.
+func IntactJoints() map[string]float64 {
+	joints := make(map[string]float64, len(jointMuscles))
+	for joint := range jointMuscles {
+		joints[joint] = 1.0
+	}
+	return joints
+}
+
+// RecoverJoint returns integrity after recovering for the given number of
+// days at the given speed multiplier.
+func RecoverJoint(integrity float64, days float64, speed float64, halfLife time.Duration) float64 {
+	halfLifeDays := halfLife.Hours() / 24
+	if halfLifeDays <= 0 {
+		return 1.0
+	}
+	lost := 1.0 - clampFloat(integrity, 0, 1)
+	return 1.0 - lost*math.Pow(0.5, days*speed/halfLifeDays)
+}
+
+// StepJointIntegrity advances the series by one day: recovery first, then
+// the day's deltas. Missing joints start intact.
+func StepJointIntegrity(prev map[string]float64, day JointDayInput, policy JointRecoveryPolicy) JointIntegrityDay {
+	joints := make(map[string]float64, len(jointMuscles))
+	for joint := range jointMuscles {
+		integrity, ok := prev[joint]
+		if !ok {
+			integrity = 1.0
+		}
+
+		speed := 1.0
+		if day.RestDay {
+			speed *= policy.RestDayFactor
+		}
+		if day.PainJoints[joint] {
+			speed *= policy.PainFactor
+		}
+		integrity = RecoverJoint(integrity, 1, speed, policy.HalfLife)
+		integrity += day.Deltas[joint]
+		joints[joint] = roundJoint(clampFloat(integrity, 0, 1))
+	}
+
+	var pain []string
+	for joint, hurts := range day.PainJoints {
+		if hurts {
+			pain = append(pain, joint)
+		}
+	}
+	sort.Strings(pain)
+
+	return JointIntegrityDay{Date: day.Date, Joints: joints, RestDay: day.RestDay, PainJoints: pain}
+}
+
+// BuildJointIntegritySeries steps through consecutive days from a starting
+// state, returning one entry per day.
+func BuildJointIntegritySeries(start map[string]float64, days []JointDayInput, policy JointRecoveryPolicy) []JointIntegrityDay {
+	series := make([]JointIntegrityDay, 0, len(days))
+	current := start
+	for _, day := range days {
+		next := StepJointIntegrity(current, day, policy)
+		series = append(series, next)
+		current = next.Joints
+	}
+	return series
+}
+
+// JointsForAlias returns the tracked joints a body alias refers to: the joint
+// itself when the alias names one, otherwise the joints around its muscles.
+func JointsForAlias(alias string) []string {
+	_, alias = SplitSideAlias(alias)
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(alias)), " ", "_")
+	if _, ok := jointMuscles[key]; ok {
+		return []string{key}
+	}
+	if _, ok := jointMuscles[strings.TrimSuffix(key, "s")]; ok {
+		return []string{strings.TrimSuffix(key, "s")}
+	}
+	return jointsForMuscles(GetMuscleGroupsForAlias(alias))
+}
+
+// JointsForMuscle returns the tracked joints around a muscle group.
+func JointsForMuscle(muscle MuscleGroup) []string {
+	return jointsForMuscles([]MuscleGroup{muscle})
+}
+
+func jointsForMuscles(muscles []MuscleGroup) []string {
+	var joints []string
+	for joint, groups := range jointMuscles {
+		for _, g := range groups {
+			if containsMuscle(muscles, g) {
+				joints = append(joints, joint)
+				break
+			}
+		}
+	}
+	sort.Strings(joints)
+	return joints
+}
+
+func containsMuscle(muscles []MuscleGroup, m MuscleGroup) bool {
+	for _, candidate := range muscles {
+		if candidate == m {
+			return true
+		}
+	}
+	return false
+}
+
+// EchoDeltasByJoint converts echo deltas keyed by body alias to deltas per
+// tracked joint. When several aliases reach one joint the strongest delta
+// wins. Aliases that reach no joint are dropped.
+func EchoDeltasByJoint(deltas map[string]float64) map[string]float64 {
+	byJoint := make(map[string]float64)
+	for alias, delta := range deltas {
+		for _, joint := range JointsForAlias(alias) {
+			if current, ok := byJoint[joint]; !ok || math.Abs(delta) > math.Abs(current) {
+				byJoint[joint] = delta
+			}
+		}
+	}
+	return byJoint
+}
+
+// PainJointsOn returns the joints with pain reported around them that is
+// still active on the given date. Healing reports and issues created from
+// echo deltas don't count.
+func PainJointsOn(issues []BodyPartIssue, date time.Time) map[string]bool {
+	pain := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Severity == IssueSeverityHealing || issue.RawText == EchoIssueRawText {
+			continue
+		}
+		issueDate, err := time.Parse("2006-01-02", issue.Date)
+		if err != nil {
+			continue
+		}
+		daysSince := int(date.Sub(issueDate).Hours() / 24)
+		if daysSince < 0 || daysSince >= IssueDecayDays {
+			continue
+		}
+		for _, joint := range JointsForMuscle(issue.BodyPart) {
+			pain[joint] = true
+		}
+	}
+	return pain
+}
+
+// LowestJointIntegrity merges two joint integrity maps, keeping the lower
+// value per joint.
+func LowestJointIntegrity(a, b map[string]float64) map[string]float64 {
+	merged := make(map[string]float64, len(a)+len(b))
+	for joint, v := range a {
+		merged[joint] = v
+	}
+	for joint, v := range b {
+		if current, ok := merged[joint]; !ok || v < current {
+			merged[joint] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The joint series drives movement exclusion and BIO-REPAIR
+// for days after an echo; tests pin the half-life, how rest and pain change
+// it, and how echo aliases land on tracked joints.
+type JointRecoverySuite struct {
+	suite.Suite
+	policy JointRecoveryPolicy
+}
+
+func TestJointRecoverySuite(t *testing.T) {
+	suite.Run(t, new(JointRecoverySuite))
+}
+
+func (s *JointRecoverySuite) SetupTest() {
+	s.policy = JointRecoveryPolicy{HalfLife: 48 * time.Hour, RestDayFactor: 2, PainFactor: 0.5}
+}
+
+func (s *JointRecoverySuite) TestHalfLife() {
+	s.InDelta(0.8, RecoverJoint(0.6, 2, 1, s.policy.HalfLife), 1e-9, "half the lost 0.4 returns in one half-life")
+	s.Equal(1.0, RecoverJoint(1.0, 5, 1, s.policy.HalfLife))
+}
+
+func (s *JointRecoverySuite) TestSeriesAppliesDeltasAfterRecovery() {
+	days := []JointDayInput{
+		{Date: "2026-03-01", Deltas: map[string]float64{"knee": -0.4}},
+		{Date: "2026-03-02"},
+		{Date: "2026-03-03"},
+	}
+
+	series := BuildJointIntegritySeries(IntactJoints(), days, s.policy)
+
+	s.Require().Len(series, 3)
+	s.Equal(0.6, series[0].Joints["knee"])
+	s.Equal(1.0, series[0].Joints["shoulder"])
+	s.Equal(0.72, series[1].Joints["knee"])
+	s.Equal(0.8, series[2].Joints["knee"], "one half-life after the delta")
+}
+
+func (s *JointRecoverySuite) TestRestAndPainChangeRecoverySpeed() {
+	start := map[string]float64{"knee": 0.6, "ankle": 0.6}
+	day := JointDayInput{Date: "2026-03-02", PainJoints: map[string]bool{"ankle": true}}
+
+	working := StepJointIntegrity(start, day, s.policy)
+	day.RestDay = true
+	resting := StepJointIntegrity(start, day, s.policy)
+
+	s.Greater(resting.Joints["knee"], working.Joints["knee"])
+	s.Less(working.Joints["ankle"], working.Joints["knee"], "pain slows recovery")
+	s.Equal([]string{"ankle"}, working.PainJoints)
+}
+
+func (s *JointRecoverySuite) TestEchoDeltasByJoint() {
+	byJoint := EchoDeltasByJoint(map[string]float64{
+		"knees":      -0.3,
+		"lower back": -0.5,
+		"hamstrings": -0.6,
+		"chest":      -0.2,
+	})
+
+	s.Equal(-0.6, byJoint["knee"], "strongest delta wins")
+	s.Equal(-0.5, byJoint["lower_back"])
+	s.Len(byJoint, 2, "chest reaches no tracked joint")
+}
+
+func (s *JointRecoverySuite) TestPainIgnoresEchoAndHealingIssues() {
+	date := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	issues := []BodyPartIssue{
+		{BodyPart: MuscleCalves, Severity: IssueSeverityModerate, Date: "2026-03-01"},
+		{BodyPart: MuscleQuads, Severity: IssueSeverityModerate, Date: "2026-03-01", RawText: EchoIssueRawText},
+		{BodyPart: MuscleForearms, Severity: IssueSeverityHealing, Date: "2026-03-02"},
+		{BodyPart: MuscleFrontDelt, Severity: IssueSeveritySevere, Date: "2026-02-20"},
+	}
+
+	s.Equal(map[string]bool{"ankle": true}, PainJointsOn(issues, date))
+}
+
+func (s *JointRecoverySuite) TestSeriesCapsAggregatedIntegrity() {
+	daily := AggregateBodyStatus(BodyStatusInput{
+		Fatigue:     &BodyStatus{},
+		Issues:      []BodyPartIssue{{BodyPart: MuscleQuads, Severity: IssueSeveritySevere, Date: "2026-03-03", RawText: EchoIssueRawText}},
+		JointSeries: map[string]float64{"knee": 0.45},
+		AsOf:        time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC),
+	})
+
+	s.Equal(0.45, daily.Status.JointIntegrity["knee"], "echo issues count only through the series")
+}
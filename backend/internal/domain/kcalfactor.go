@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// KCAL FACTOR AUTO-TUNING
+// =============================================================================
+//
+// A plan with KcalFactorOverride estimates TDEE as weight × factor. A static
+// factor drifts from reality as metabolism adapts, so plans can opt into
+// auto-tuning: every two weeks the factor implied by measured weight change
+// versus intake is compared with the current one and the factor moves towards
+// it, limited per step and to a plausible range.
+
+const (
+	// KcalFactorTuneIntervalDays is how often the factor is re-evaluated.
+	KcalFactorTuneIntervalDays = 14
+	// KcalFactorTuneMinDays is the minimum logged days in the window.
+	KcalFactorTuneMinDays = 10
+	// KcalFactorMaxStep is the largest change per adjustment (fraction of the current factor).
+	KcalFactorMaxStep = 0.05
+	// KcalFactorMin and KcalFactorMax bound the factor (kcal per kg of body weight).
+	KcalFactorMin = 22.0
+	KcalFactorMax = 40.0
+	// kcalFactorMinChange ignores adjustments smaller than this (factor is stored to 0.1).
+	kcalFactorMinChange = 0.1
+)
+
+// KcalFactorTuning is the outcome of one auto-tuning evaluation.
+type KcalFactorTuning struct {
+	BeforeFactor    float64
+	AfterFactor     float64
+	ObservedTDEE    int     // Average intake corrected for measured weight change
+	MeasuredFactor  float64 // ObservedTDEE / average weight, before safety rails
+	WeightChangeKg  float64 // Trend change over the window
+	CurrentWeightKg float64 // Trend weight at the end of the window
+	DaysUsed        int
+	Adjusted        bool // False when the factor was already close enough
+	WindowStartDate string
+	WindowEndDate   string
+}
+
+// KcalFactorTuneDue reports whether an auto-tuning plan is due for re-evaluation.
+// The first evaluation happens once the plan has run for a full interval.
+func (p *NutritionPlan) KcalFactorTuneDue(now time.Time) bool {
+	if !p.KcalFactorAutoTune || p.KcalFactorOverride == nil || *p.KcalFactorOverride <= 0 {
+		return false
+	}
+	last := p.StartDate
+	if p.KcalFactorTunedAt != nil {
+		last = *p.KcalFactorTunedAt
+	}
+	return now.Sub(last) >= KcalFactorTuneIntervalDays*24*time.Hour
+}
+
+// TuneKcalFactor evaluates the plan's factor against the data points logged
+// in the KcalFactorTuneIntervalDays before now. Returns nil when there is not
+// enough data to judge. points must be ordered oldest first.
+func TuneKcalFactor(plan *NutritionPlan, points []AdaptiveDataPoint, now time.Time) *KcalFactorTuning {
+	if plan.KcalFactorOverride == nil || *plan.KcalFactorOverride <= 0 {
+		return nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -KcalFactorTuneIntervalDays)
+	windowStart := start.Format("2006-01-02")
+	windowEnd := today.AddDate(0, 0, -1).Format("2006-01-02")
+
+	// Least-squares weight trend (kg/day) smooths out daily water fluctuations
+	var n, sumX, sumY, sumXY, sumXX, intake float64
+	for _, p := range points {
+		if p.Date < windowStart || p.Date > windowEnd || p.WeightKg <= 0 {
+			continue
+		}
+		kcal, _ := pointIntake(p)
+		if kcal <= 0 {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			continue
+		}
+		x := d.Sub(start).Hours() / 24
+		n++
+		sumX += x
+		sumY += p.WeightKg
+		sumXY += x * p.WeightKg
+		sumXX += x * x
+		intake += kcal
+	}
+	if int(n) < KcalFactorTuneMinDays {
+		return nil
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return nil
+	}
+	slope := (n*sumXY - sumX*sumY) / denom
+	avgWeight := sumY / n
+	observedTDEE := intake/n - slope*7700
+
+	current := *plan.KcalFactorOverride
+	measured := observedTDEE / avgWeight
+	step := math.Max(-current*KcalFactorMaxStep, math.Min(current*KcalFactorMaxStep, measured-current))
+	next := math.Max(KcalFactorMin, math.Min(KcalFactorMax, current+step))
+	next = math.Round(next*10) / 10
+
+	return &KcalFactorTuning{
+		BeforeFactor:    current,
+		AfterFactor:     next,
+		ObservedTDEE:    int(math.Round(observedTDEE)),
+		MeasuredFactor:  math.Round(measured*10) / 10,
+		WeightChangeKg:  math.Round(slope*KcalFactorTuneIntervalDays*100) / 100,
+		CurrentWeightKg: math.Round((avgWeight+slope*(KcalFactorTuneIntervalDays-sumX/n))*10) / 10,
+		DaysUsed:        int(n),
+		Adjusted:        math.Abs(next-current) >= kcalFactorMinChange,
+		WindowStartDate: windowStart,
+		WindowEndDate:   windowEnd,
+	}
+}
+
+// ApplyKcalFactorTuning stores the evaluation on the plan and, when the factor
+// changed, regenerates targets from the current week with the new factor,
+// projecting from the trend weight. Pinned weeks are kept.
+func ApplyKcalFactorTuning(plan *NutritionPlan, profile *UserProfile, tuning KcalFactorTuning, now time.Time) {
+	plan.KcalFactorTunedAt = &now
+	if !tuning.Adjusted {
+		return
+	}
+	factor := tuning.AfterFactor
+	plan.KcalFactorOverride = &factor
+	plan.UpdatedAt = now
+	plan.WeeklyTargets = regenerateWeeklyTargets(plan, profile, tuning.CurrentWeightKg, now)
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type KcalFactorSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+}
+
+func TestKcalFactorSuite(t *testing.T) {
+	suite.Run(t, new(KcalFactorSuite))
+}
+
+func (s *KcalFactorSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	s.profile = &UserProfile{
+		HeightCM:     180,
+		BirthDate:    time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		Sex:          SexMale,
+		Goal:         GoalLoseWeight,
+		CarbRatio:    0.45,
+		ProteinRatio: 0.30,
+		FatRatio:     0.25,
+		BMREquation:  BMREquationMifflinStJeor,
+	}
+}
+
+func (s *KcalFactorSuite) plan(factor float64) *NutritionPlan {
+	start := s.now.AddDate(0, 0, -21)
+	plan, err := NewNutritionPlan(NutritionPlanInput{
+		StartDate:          start.Format("2006-01-02"),
+		StartWeightKg:      90,
+		GoalWeightKg:       80,
+		DurationWeeks:      20,
+		KcalFactorOverride: &factor,
+		KcalFactorAutoTune: true,
+	}, s.profile, start)
+	s.Require().NoError(err)
+	return plan
+}
+
+// window builds 14 days of logs ending yesterday with a linear weight change.
+func (s *KcalFactorSuite) window(startWeight, kgPerDay float64, intake int) []AdaptiveDataPoint {
+	var points []AdaptiveDataPoint
+	for i := 14; i >= 1; i-- {
+		day := s.now.AddDate(0, 0, -i)
+		points = append(points, AdaptiveDataPoint{
+			Date:           day.Format("2006-01-02"),
+			WeightKg:       startWeight + kgPerDay*float64(14-i),
+			TargetCalories: intake,
+		})
+	}
+	return points
+}
+
+func (s *KcalFactorSuite) TestSlowerLossLowersFactorWithinStep() {
+	// 2200 kcal/day at 90 kg with no weight change -> measured factor ~24.4
+	plan := s.plan(30)
+
+	tuning := TuneKcalFactor(plan, s.window(90, 0, 2200), s.now)
+
+	s.Require().NotNil(tuning)
+	s.True(tuning.Adjusted)
+	s.Equal(2200, tuning.ObservedTDEE)
+	s.InDelta(24.4, tuning.MeasuredFactor, 0.1)
+	s.Equal(28.5, tuning.AfterFactor, "limited to a 5% step")
+	s.Equal(14, tuning.DaysUsed)
+}
+
+func (s *KcalFactorSuite) TestMatchingResultsKeepFactor() {
+	// Losing 0.5 kg/week on 2150 kcal -> TDEE ~2700 at ~89.5 kg -> factor ~30.2
+	plan := s.plan(30)
+
+	tuning := TuneKcalFactor(plan, s.window(90, -0.5/7, 2150), s.now)
+
+	s.Require().NotNil(tuning)
+	s.InDelta(30.2, tuning.MeasuredFactor, 0.2)
+	s.InDelta(-1.0, tuning.WeightChangeKg, 0.01)
+}
+
+func (s *KcalFactorSuite) TestFactorStaysWithinSafetyRails() {
+	plan := s.plan(KcalFactorMax)
+
+	tuning := TuneKcalFactor(plan, s.window(90, -0.3, 3500), s.now)
+
+	s.Require().NotNil(tuning)
+	s.Equal(KcalFactorMax, tuning.AfterFactor)
+	s.False(tuning.Adjusted)
+}
+
+func (s *KcalFactorSuite) TestSparseDataIsNotJudged() {
+	plan := s.plan(30)
+
+	s.Nil(TuneKcalFactor(plan, s.window(90, 0, 2200)[:KcalFactorTuneMinDays-1], s.now))
+}
+
+func (s *KcalFactorSuite) TestTuneDue() {
+	plan := s.plan(30)
+	s.True(plan.KcalFactorTuneDue(s.now))
+
+	tuned := s.now.AddDate(0, 0, -3)
+	plan.KcalFactorTunedAt = &tuned
+	s.False(plan.KcalFactorTuneDue(s.now))
+
+	plan.KcalFactorTunedAt = nil
+	plan.KcalFactorAutoTune = false
+	s.False(plan.KcalFactorTuneDue(s.now))
+}
+
+func (s *KcalFactorSuite) TestApplyRegeneratesWithNewFactor() {
+	plan := s.plan(30)
+	before := plan.Clone()
+	tuning := TuneKcalFactor(plan, s.window(90, 0, 2200), s.now)
+
+	ApplyKcalFactorTuning(plan, s.profile, *tuning, s.now)
+
+	s.Equal(28.5, *plan.KcalFactorOverride)
+	s.Equal(s.now, *plan.KcalFactorTunedAt)
+	current := plan.GetCurrentWeek(s.now)
+	s.Less(plan.WeeklyTargets[current-1].ProjectedTDEE, before.WeeklyTargets[current-1].ProjectedTDEE)
+	s.Equal(before.WeeklyTargets[0], plan.WeeklyTargets[0], "past weeks are kept")
+}
+
+func (s *KcalFactorSuite) TestValidation() {
+	factor := 50.0
+	_, err := NewNutritionPlan(NutritionPlanInput{
+		StartDate: s.now.Format("2006-01-02"), StartWeightKg: 90, GoalWeightKg: 80, DurationWeeks: 20,
+		KcalFactorOverride: &factor,
+	}, s.profile, s.now)
+	s.ErrorIs(err, ErrInvalidKcalFactor)
+
+	_, err = NewNutritionPlan(NutritionPlanInput{
+		StartDate: s.now.Format("2006-01-02"), StartWeightKg: 90, GoalWeightKg: 80, DurationWeeks: 20,
+		KcalFactorAutoTune: true,
+	}, s.profile, s.now)
+	s.ErrorIs(err, ErrKcalFactorAutoTuneRequiresOverride)
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"math"
+	"strings"
+)
+
+// =============================================================================
+// LEFT/RIGHT FATIGUE
+// =============================================================================
+//
+// Limb muscles can carry a left/right split when one side works harder: a
+// session done on one side only, unilateral movements worked unevenly, or
+// echo feedback naming a side ("left knee sore"). Without a split both sides
+// share the muscle's fatigue. With one, the muscle's fatigue is the average
+// of its sides, so whole-body scores read the same as before, while the
+// heatmap, substitutions and the injury risk report can see one side
+// overloaded. Sides that decay back to within sideMergeGap of each other are
+// merged again.
+
+// BodySide is the left or right side of a limb muscle.
+type BodySide string
+
+const (
+	SideLeft  BodySide = "left"
+	SideRight BodySide = "right"
+)
+
+// ParseBodySide safely converts a string to BodySide with validation.
+func ParseBodySide(s string) (BodySide, error) {
+	side := BodySide(s)
+	if side != SideLeft && side != SideRight {
+		return "", ErrInvalidBodySide
+	}
+	return side, nil
+}
+
+// LateralMuscles are the limb muscle groups tracked per side. Trunk muscles
+// (chest, lats, traps, lower back, core) span both sides and aren't split.
+var LateralMuscles = map[MuscleGroup]bool{
+	MuscleFrontDelt:  true,
+	MuscleSideDelt:   true,
+	MuscleRearDelt:   true,
+	MuscleBiceps:     true,
+	MuscleTriceps:    true,
+	MuscleForearms:   true,
+	MuscleQuads:      true,
+	MuscleGlutes:     true,
+	MuscleHamstrings: true,
+	MuscleCalves:     true,
+}
+
+const (
+	// FatigueAsymmetryThreshold is the gap between sides (percentage points)
+	// at which the more fatigued side counts as overloaded.
+	FatigueAsymmetryThreshold = 15.0
+	// sideMergeGap is the gap below which a split is dropped.
+	sideMergeGap = 1.0
+)
+
+// SideFatigue is a muscle's fatigue per side (0-100%).
+type SideFatigue struct {
+	Left  float64 `json:"left"`
+	Right float64 `json:"right"`
+}
+
+// Average is the muscle's fatigue as a whole.
+func (s SideFatigue) Average() float64 {
+	return (s.Left + s.Right) / 2
+}
+
+// Peak is the more fatigued side's value.
+func (s SideFatigue) Peak() float64 {
+	return math.Max(s.Left, s.Right)
+}
+
+// Gap is how far apart the sides are, in percentage points.
+func (s SideFatigue) Gap() float64 {
+	return math.Abs(s.Left - s.Right)
+}
+
+// Overloaded returns the side FatigueAsymmetryThreshold or more above the
+// other, or "" when the sides are close enough.
+func (s SideFatigue) Overloaded() BodySide {
+	switch {
+	case s.Left-s.Right >= FatigueAsymmetryThreshold:
+		return SideLeft
+	case s.Right-s.Left >= FatigueAsymmetryThreshold:
+		return SideRight
+	default:
+		return ""
+	}
+}
+
+// SideSplit is the share of each muscle's work done by the left side (0-1).
+// Muscles not listed are worked evenly.
+type SideSplit map[MuscleGroup]float64
+
+// Validate checks that only lateral muscles are split and shares are 0-1.
+func (s SideSplit) Validate() error {
+	for muscle, share := range s {
+		if !LateralMuscles[muscle] || share < 0 || share > 1 {
+			return ErrInvalidSideShare
+		}
+	}
+	return nil
+}
+
+// OneSidedSplit returns the split for a session done on one side only: every
+// lateral muscle the archetype loads is worked by that side.
+func (a ArchetypeConfig) OneSidedSplit(side BodySide) SideSplit {
+	share := 1.0
+	if side == SideRight {
+		share = 0
+	}
+	split := make(SideSplit)
+	for muscle, coef := range a.Coefficients {
+		if coef > 0 && LateralMuscles[muscle] {
+			split[muscle] = share
+		}
+	}
+	return split
+}
+
+// AddSidedFatigue adds an injection to a muscle at current fatigue, with its
+// sides when a split is tracked. An uneven split gives each side its share of
+// twice the injection, so the average still rises by the injection. Returns
+// the new fatigue and sides (nil when the sides are even).
+func AddSidedFatigue(muscle MuscleGroup, current float64, sides *SideFatigue, injection float64, split SideSplit) (float64, *SideFatigue) {
+	share, uneven := split[muscle]
+	if sides == nil && !uneven {
+		return clampFatigue(current + injection), nil
+	}
+	left, right := injection, injection
+	if uneven {
+		left, right = 2*injection*share, 2*injection*(1-share)
+	}
+	return addToSides(current, sides, left, right)
+}
+
+// AddSideFatigue adds fatigue to one side of a muscle, e.g. from an issue
+// reported on that side.
+func AddSideFatigue(current float64, sides *SideFatigue, side BodySide, amount float64) (float64, *SideFatigue) {
+	if side == SideLeft {
+		return addToSides(current, sides, amount, 0)
+	}
+	return addToSides(current, sides, 0, amount)
+}
+
+// DecaySidedFatigue applies decay to each side independently.
+func DecaySidedFatigue(sides SideFatigue, hoursElapsed float64) (float64, *SideFatigue) {
+	return settleSides(SideFatigue{
+		Left:  ApplyFatigueDecay(sides.Left, hoursElapsed),
+		Right: ApplyFatigueDecay(sides.Right, hoursElapsed),
+	})
+}
+
+func addToSides(current float64, sides *SideFatigue, left, right float64) (float64, *SideFatigue) {
+	next := SideFatigue{Left: current, Right: current}
+	if sides != nil {
+		next = *sides
+	}
+	next.Left = clampFatigue(next.Left + left)
+	next.Right = clampFatigue(next.Right + right)
+	return settleSides(next)
+}
+
+// settleSides returns the average and the sides, dropping sides that have
+// evened out.
+func settleSides(s SideFatigue) (float64, *SideFatigue) {
+	if s.Gap() < sideMergeGap {
+		return s.Average(), nil
+	}
+	return s.Average(), &s
+}
+
+func clampFatigue(percent float64) float64 {
+	return math.Min(math.Max(percent, 0), 100)
+}
+
+// WithSides attaches a muscle's left/right split to its state, flagging the
+// overloaded side if any.
+func (m MuscleFatigueState) WithSides(sides *SideFatigue) MuscleFatigueState {
+	if sides == nil {
+		return m
+	}
+	rounded := SideFatigue{Left: math.Round(sides.Left*10) / 10, Right: math.Round(sides.Right*10) / 10}
+	m.Sides = &rounded
+	m.OverloadedSide = rounded.Overloaded()
+	return m
+}
+
+// WithSides attaches the muscle's left/right split after an injection.
+func (f FatigueInjection) WithSides(sides *SideFatigue) FatigueInjection {
+	if sides != nil {
+		rounded := SideFatigue{Left: math.Round(sides.Left*10) / 10, Right: math.Round(sides.Right*10) / 10}
+		f.NewSides = &rounded
+	}
+	return f
+}
+
+// PeakPercent is the fatigue of the more fatigued side, or the muscle's
+// fatigue when it has no split.
+func (m MuscleFatigueState) PeakPercent() float64 {
+	if m.Sides == nil {
+		return m.FatiguePercent
+	}
+	return m.Sides.Peak()
+}
+
+// SideForAlias returns the side named in a body alias when it applies to
+// muscle, so "left knee" marks the left quads but not the lower back.
+func SideForAlias(alias string, muscle MuscleGroup) BodySide {
+	side, _ := SplitSideAlias(alias)
+	if !LateralMuscles[muscle] {
+		return ""
+	}
+	return side
+}
+
+// SplitSideAlias separates a leading side from a body alias, e.g.
+// "left knee" or "right_shoulder". Returns "" and the alias when none is named.
+func SplitSideAlias(alias string) (BodySide, string) {
+	key := strings.ToLower(strings.TrimSpace(alias))
+	for _, side := range []BodySide{SideLeft, SideRight} {
+		for _, sep := range []string{" ", "_", "-"} {
+			if rest, ok := strings.CutPrefix(key, string(side)+sep); ok && rest != "" {
+				return side, rest
+			}
+		}
+	}
+	return "", alias
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The left/right split drives the overloaded-side flag on the
+// heatmap and in substitutions; tests pin how injections are split, that the
+// muscle's fatigue stays the average of its sides, and when sides merge back.
+type LateralitySuite struct {
+	suite.Suite
+}
+
+func TestLateralitySuite(t *testing.T) {
+	suite.Run(t, new(LateralitySuite))
+}
+
+func (s *LateralitySuite) TestEvenInjectionKeepsNoSplit() {
+	total, sides := AddSidedFatigue(MuscleQuads, 20, nil, 30, nil)
+	s.Equal(50.0, total)
+	s.Nil(sides)
+}
+
+func (s *LateralitySuite) TestUnevenInjectionSplitsSides() {
+	total, sides := AddSidedFatigue(MuscleQuads, 20, nil, 30, SideSplit{MuscleQuads: 0.75})
+	s.Require().NotNil(sides)
+	s.Equal(65.0, sides.Left, "left does three quarters of twice the injection")
+	s.Equal(35.0, sides.Right)
+	s.Equal(50.0, total, "the muscle rises by the injection either way")
+	s.Equal(SideLeft, sides.Overloaded())
+}
+
+func (s *LateralitySuite) TestTrackedSplitCarriesThroughEvenInjections() {
+	_, sides := AddSidedFatigue(MuscleBiceps, 0, nil, 20, SideSplit{MuscleBiceps: 0})
+	total, sides := AddSidedFatigue(MuscleBiceps, 20, sides, 10, nil)
+	s.Require().NotNil(sides)
+	s.Equal(10.0, sides.Left)
+	s.Equal(50.0, sides.Right)
+	s.Equal(30.0, total)
+}
+
+func (s *LateralitySuite) TestSidesClampAtFull() {
+	total, sides := AddSidedFatigue(MuscleCalves, 80, nil, 40, SideSplit{MuscleCalves: 1})
+	s.Require().NotNil(sides)
+	s.Equal(100.0, sides.Left)
+	s.Equal(80.0, sides.Right)
+	s.Equal(90.0, total)
+}
+
+func (s *LateralitySuite) TestDecayMergesEvenedSides() {
+	total, sides := DecaySidedFatigue(SideFatigue{Left: 70, Right: 50}, 10)
+	s.Require().NotNil(sides)
+	s.Equal(SideFatigue{Left: 50, Right: 30}, *sides, "each side decays on its own")
+	s.Equal(40.0, total)
+
+	total, sides = DecaySidedFatigue(SideFatigue{Left: 10, Right: 5}, 12)
+	s.Nil(sides, "both sides decayed to zero")
+	s.Zero(total)
+}
+
+func (s *LateralitySuite) TestAddSideFatigue() {
+	total, sides := AddSideFatigue(40, nil, SideRight, 20)
+	s.Require().NotNil(sides)
+	s.Equal(SideFatigue{Left: 40, Right: 60}, *sides)
+	s.Equal(50.0, total)
+}
+
+func (s *LateralitySuite) TestOneSidedSplitOnlyCoversLimbs() {
+	legs := ArchetypeConfig{Coefficients: map[MuscleGroup]float64{MuscleQuads: 1.0, MuscleLowerBack: 0.4, MuscleCalves: 0}}
+	s.Equal(SideSplit{MuscleQuads: 0}, legs.OneSidedSplit(SideRight))
+	s.Equal(SideSplit{MuscleQuads: 1}, legs.OneSidedSplit(SideLeft))
+}
+
+func (s *LateralitySuite) TestSplitValidate() {
+	s.NoError(SideSplit{MuscleQuads: 0.6}.Validate())
+	s.ErrorIs(SideSplit{MuscleChest: 0.6}.Validate(), ErrInvalidSideShare)
+	s.ErrorIs(SideSplit{MuscleQuads: 1.2}.Validate(), ErrInvalidSideShare)
+}
+
+func (s *LateralitySuite) TestStateWithSides() {
+	state := BuildMuscleFatigueState(1, MuscleHamstrings, 50, "").WithSides(&SideFatigue{Left: 41.04, Right: 58.96})
+	s.Equal(SideRight, state.OverloadedSide)
+	s.Equal(59.0, state.PeakPercent())
+
+	even := BuildMuscleFatigueState(1, MuscleHamstrings, 50, "").WithSides(&SideFatigue{Left: 45, Right: 55})
+	s.Empty(even.OverloadedSide, "a 10 point gap is below the threshold")
+}
+
+func (s *LateralitySuite) TestSideAliases() {
+	side, rest := SplitSideAlias("Left Knee")
+	s.Equal(SideLeft, side)
+	s.Equal("knee", rest)
+
+	side, rest = SplitSideAlias("right_shoulder")
+	s.Equal(SideRight, side)
+	s.Equal("shoulder", rest)
+
+	side, rest = SplitSideAlias("lower_back")
+	s.Empty(side)
+	s.Equal("lower_back", rest)
+
+	s.ElementsMatch(GetMuscleGroupsForAlias("knee"), GetMuscleGroupsForAlias("left knee"))
+	s.Equal(SideLeft, SideForAlias("left knee", MuscleQuads))
+	s.Empty(SideForAlias("left back", MuscleLowerBack), "trunk muscles aren't split")
+}
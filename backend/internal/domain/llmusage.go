@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// LLM USAGE ACCOUNTING
+// =============================================================================
+//
+// Every Ollama generate call is recorded with its feature, model, token counts
+// and duration. Optional daily budgets cap calls and GPU compute per feature,
+// so one noisy client (e.g. re-running the solver in a loop) can't saturate
+// the local GPU; calls over budget fall back as if Ollama were down.
+
+// LLMFeature is the feature an LLM call serves.
+type LLMFeature string
+
+const (
+	LLMFeatureRecipeName       LLMFeature = "recipe_name"
+	LLMFeatureSolverRefinement LLMFeature = "solver_refinement"
+	LLMFeatureDebrief          LLMFeature = "debrief"
+	LLMFeatureDayInsight       LLMFeature = "day_insight"
+	LLMFeaturePhaseInsight     LLMFeature = "phase_insight"
+	LLMFeatureSystemicRx       LLMFeature = "systemic_rx"
+	LLMFeatureFormCorrection   LLMFeature = "form_correction"
+	LLMFeatureEcho             LLMFeature = "echo"
+	LLMFeatureVoiceCommand     LLMFeature = "voice_command"
+	LLMFeatureSemanticIndex    LLMFeature = "semantic_index"  // Embedding the food and movement catalogs
+	LLMFeatureSemanticSearch   LLMFeature = "semantic_search" // Embedding search queries
+	LLMFeatureAnnualReview     LLMFeature = "annual_review"
+	LLMFeatureArchetype        LLMFeature = "archetype_inference"
+)
+
+// ValidLLMFeatures contains all valid LLM features.
+var ValidLLMFeatures = map[LLMFeature]bool{
+	LLMFeatureRecipeName:       true,
+	LLMFeatureSolverRefinement: true,
+	LLMFeatureDebrief:          true,
+	LLMFeatureDayInsight:       true,
+	LLMFeaturePhaseInsight:     true,
+	LLMFeatureSystemicRx:       true,
+	LLMFeatureFormCorrection:   true,
+	LLMFeatureEcho:             true,
+	LLMFeatureVoiceCommand:     true,
+	LLMFeatureSemanticIndex:    true,
+	LLMFeatureSemanticSearch:   true,
+	LLMFeatureAnnualReview:     true,
+	LLMFeatureArchetype:        true,
+}
+
+// LLMUsage is one recorded LLM call.
+type LLMUsage struct {
+	ID               int64
+	Feature          LLMFeature
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	DurationMs       int64 // Ollama's reported compute time, or wall time when it didn't answer
+	Success          bool
+	CreatedAt        time.Time
+}
+
+// LLMUsageTotals is a feature's usage over a period, as counted against budgets.
+type LLMUsageTotals struct {
+	Calls     int   `json:"calls"`
+	ComputeMs int64 `json:"computeMs"`
+}
+
+// LLMUsageSummary aggregates calls per feature and model for the usage report.
+type LLMUsageSummary struct {
+	Feature          LLMFeature `json:"feature"`
+	Model            string     `json:"model"`
+	Calls            int        `json:"calls"`
+	Failures         int        `json:"failures"`
+	PromptTokens     int64      `json:"promptTokens"`
+	CompletionTokens int64      `json:"completionTokens"`
+	ComputeMs        int64      `json:"computeMs"`
+	AvgDurationMs    int64      `json:"avgDurationMs"`
+}
+
+// LLMBudget caps one feature's daily usage. Zero limits are unlimited.
+type LLMBudget struct {
+	Feature           LLMFeature `json:"feature"`
+	MaxCalls          int        `json:"maxCalls"`
+	MaxComputeSeconds int        `json:"maxComputeSeconds"`
+}
+
+// Allows reports whether another call fits within the budget given today's usage.
+func (b LLMBudget) Allows(today LLMUsageTotals) bool {
+	if b.MaxCalls > 0 && today.Calls >= b.MaxCalls {
+		return false
+	}
+	if b.MaxComputeSeconds > 0 && today.ComputeMs >= int64(b.MaxComputeSeconds)*1000 {
+		return false
+	}
+	return true
+}
+
+// ParseLLMBudgets parses a budget list such as
+// "solver_refinement=200/600,debrief=10": feature=maxCalls[/maxComputeSeconds].
+// An empty string means no budgets.
+func ParseLLMBudgets(s string) (map[LLMFeature]LLMBudget, error) {
+	budgets := make(map[LLMFeature]LLMBudget)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limits, ok := strings.Cut(entry, "=")
+		feature := LLMFeature(strings.TrimSpace(name))
+		if !ok || !ValidLLMFeatures[feature] {
+			return nil, fmt.Errorf("llm budget %q: unknown feature", entry)
+		}
+
+		calls, compute, hasCompute := strings.Cut(limits, "/")
+		budget := LLMBudget{Feature: feature}
+		var err error
+		if budget.MaxCalls, err = strconv.Atoi(strings.TrimSpace(calls)); err != nil || budget.MaxCalls < 0 {
+			return nil, fmt.Errorf("llm budget %q: calls must be a non-negative integer", entry)
+		}
+		if hasCompute {
+			if budget.MaxComputeSeconds, err = strconv.Atoi(strings.TrimSpace(compute)); err != nil || budget.MaxComputeSeconds < 0 {
+				return nil, fmt.Errorf("llm budget %q: compute seconds must be a non-negative integer", entry)
+			}
+		}
+		budgets[feature] = budget
+	}
+	return budgets, nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Budgets are parsed from an operator-written env var and gate
+// every LLM call; tests pin the accepted syntax and the limit boundaries.
+type LLMBudgetSuite struct {
+	suite.Suite
+}
+
+func TestLLMBudgetSuite(t *testing.T) {
+	suite.Run(t, new(LLMBudgetSuite))
+}
+
+func (s *LLMBudgetSuite) TestParsesCallsAndComputeLimits() {
+	budgets, err := ParseLLMBudgets(" solver_refinement=200/600, debrief=10 ,")
+	s.Require().NoError(err)
+
+	s.Equal(map[LLMFeature]LLMBudget{
+		LLMFeatureSolverRefinement: {Feature: LLMFeatureSolverRefinement, MaxCalls: 200, MaxComputeSeconds: 600},
+		LLMFeatureDebrief:          {Feature: LLMFeatureDebrief, MaxCalls: 10},
+	}, budgets)
+}
+
+func (s *LLMBudgetSuite) TestEmptyMeansNoBudgets() {
+	budgets, err := ParseLLMBudgets("")
+	s.Require().NoError(err)
+	s.Empty(budgets)
+}
+
+func (s *LLMBudgetSuite) TestRejectsInvalidEntries() {
+	for _, input := range []string{
+		"solver=10",           // Unknown feature
+		"debrief",             // Missing limits
+		"debrief=ten",         // Non-numeric calls
+		"debrief=-1",          // Negative calls
+		"debrief=10/",         // Empty compute limit
+		"debrief=10/-5",       // Negative compute limit
+		"debrief=10,echo=1/x", // One bad entry fails the list
+	} {
+		_, err := ParseLLMBudgets(input)
+		s.Error(err, input)
+	}
+}
+
+func (s *LLMBudgetSuite) TestAllows() {
+	budget := LLMBudget{Feature: LLMFeatureDebrief, MaxCalls: 3, MaxComputeSeconds: 10}
+
+	s.True(budget.Allows(LLMUsageTotals{Calls: 2, ComputeMs: 9999}))
+	s.False(budget.Allows(LLMUsageTotals{Calls: 3}), "call limit reached")
+	s.False(budget.Allows(LLMUsageTotals{Calls: 1, ComputeMs: 10000}), "compute limit reached")
+
+	s.True(LLMBudget{Feature: LLMFeatureEcho}.Allows(LLMUsageTotals{Calls: 1000, ComputeMs: 1e9}), "zero limits are unlimited")
+}
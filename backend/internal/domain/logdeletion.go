@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// DAILY LOG DELETION
+// =============================================================================
+//
+// Deleting a day's log cascades to its sessions and metabolic history, but
+// values derived from it live on elsewhere: muscle fatigue its sessions
+// injected, the EMA trend weight and TDEE flux of later calculations, the plan
+// week's actuals and the week's debrief. Deletion removes the dependents in
+// one transaction, reversing the fatigue the day's sessions still contribute,
+// then recomputes what was derived from the day.
+
+// LogDeletionResult describes what deleting a daily log removed and recomputed.
+type LogDeletionResult struct {
+	Date                  string
+	SessionsRemoved       int
+	FatigueEventsReversed int
+	MetabolicRecords      int                     // Metabolic history rows removed
+	EntriesRemoved        int                     // Meal, hunger, caffeine, portion and recovery rows removed
+	FatigueReversed       map[MuscleGroup]float64 // Percentage points taken off each muscle
+	FluxRecalculated      bool                    // Flux re-run for the latest remaining log
+	PlanWeekUpdated       *int                    // Active plan week whose actuals were recomputed
+	StaleDebriefWeek      string                  // Monday of the debrief week flagged for regeneration
+}
+
+// FatigueEventResidual is one recorded fatigue injection being reversed.
+type FatigueEventResidual struct {
+	Coefficients map[MuscleGroup]float64
+	TotalLoad    float64
+	AppliedAt    time.Time
+}
+
+// ResidualFatigue returns how much fatigue the events still contribute to
+// each muscle at now: each injection less the decay since it was applied.
+func ResidualFatigue(events []FatigueEventResidual, now time.Time) map[MuscleGroup]float64 {
+	residual := make(map[MuscleGroup]float64)
+	for _, e := range events {
+		hours := now.Sub(e.AppliedAt).Hours()
+		for muscle, coefficient := range e.Coefficients {
+			if coefficient <= 0 {
+				continue
+			}
+			left := ApplyFatigueDecay(CalculateFatigueInjection(e.TotalLoad, coefficient), math.Max(hours, 0))
+			if left > 0 {
+				residual[muscle] += left
+			}
+		}
+	}
+	return residual
+}
+
+// WeeklyActuals are a plan week's logged averages.
+type WeeklyActuals struct {
+	WeightKg     *float64 // Mean of explicit weigh-ins (nil if none)
+	IntakeKcal   *int     // Mean intake of days with food logged (nil if none)
+	DaysLogged   int
+	DaysExpected int // Days on or after the plan's tracking start
+}
+
+// WeekContaining returns the plan week covering date (YYYY-MM-DD), or nil.
+func (p *NutritionPlan) WeekContaining(date string) *WeeklyTarget {
+	for i := range p.WeeklyTargets {
+		w := &p.WeeklyTargets[i]
+		if date >= w.StartDate.Format("2006-01-02") && date <= w.EndDate.Format("2006-01-02") {
+			return w
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Deleting a log takes fatigue back off the body map; tests pin
+// that only the undecayed part of each injection is reversed and which plan
+// week a deleted day's actuals belong to.
+type LogDeletionSuite struct {
+	suite.Suite
+}
+
+func TestLogDeletionSuite(t *testing.T) {
+	suite.Run(t, new(LogDeletionSuite))
+}
+
+func (s *LogDeletionSuite) TestResidualFatigueSubtractsDecay() {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	events := []FatigueEventResidual{
+		{
+			// 0.5 load × 0.6 = 30%, applied 5h ago → 20% left
+			Coefficients: map[MuscleGroup]float64{MuscleChest: 0.6, MuscleTriceps: 0.1, MuscleQuads: 0},
+			TotalLoad:    0.5,
+			AppliedAt:    now.Add(-5 * time.Hour),
+		},
+		{
+			// 0.5 load × 0.4 = 20%, applied 1h ago → 18% left
+			Coefficients: map[MuscleGroup]float64{MuscleChest: 0.4},
+			TotalLoad:    0.5,
+			AppliedAt:    now.Add(-time.Hour),
+		},
+	}
+
+	residual := ResidualFatigue(events, now)
+	s.InDelta(38.0, residual[MuscleChest], 0.001)
+	s.NotContains(residual, MuscleTriceps, "5% decayed away completely")
+	s.NotContains(residual, MuscleQuads)
+}
+
+func (s *LogDeletionSuite) TestResidualFatigueFutureEventNotOverDecayed() {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	events := []FatigueEventResidual{{
+		Coefficients: map[MuscleGroup]float64{MuscleLats: 0.5},
+		TotalLoad:    0.4,
+		AppliedAt:    now.Add(time.Hour), // Clock skew
+	}}
+	s.InDelta(20.0, ResidualFatigue(events, now)[MuscleLats], 0.001)
+}
+
+func (s *LogDeletionSuite) TestWeekContaining() {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	plan := &NutritionPlan{WeeklyTargets: []WeeklyTarget{
+		{WeekNumber: 1, StartDate: start, EndDate: start.AddDate(0, 0, 6)},
+		{WeekNumber: 2, StartDate: start.AddDate(0, 0, 7), EndDate: start.AddDate(0, 0, 13)},
+	}}
+
+	s.Equal(1, plan.WeekContaining("2026-03-02").WeekNumber)
+	s.Equal(1, plan.WeekContaining("2026-03-08").WeekNumber)
+	s.Equal(2, plan.WeekContaining("2026-03-09").WeekNumber)
+	s.Nil(plan.WeekContaining("2026-03-16"))
+	s.Nil(plan.WeekContaining("2026-03-01"))
+}
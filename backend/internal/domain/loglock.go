@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// LOGGING LOCK
+// =============================================================================
+//
+// Adaptive TDEE learns from the weight and intake history, so quietly
+// rewriting old days skews it. With integrity mode on (LogLockDays > 0), days
+// older than the lock window are locked. Changing one takes an explicit
+// retro-edit: the user opens it with a reason, it stays open for
+// RetroEditWindow, and each change made through it is appended to its audit
+// trail. Weeks containing a retro-edited day don't count towards streaks.
+
+const (
+	// MaxLogLockDays is the longest configurable lock window.
+	MaxLogLockDays = 90
+	// RetroEditWindow is how long an opened retro-edit accepts changes.
+	RetroEditWindow = 30 * time.Minute
+	// retroEditMaxReasonLength caps the reason recorded with a retro-edit.
+	retroEditMaxReasonLength = 500
+)
+
+// LogEditKind names the change made to a day's log.
+type LogEditKind string
+
+const (
+	LogEditCreate          LogEditKind = "create"
+	LogEditActualTraining  LogEditKind = "actual_training"
+	LogEditActiveCalories  LogEditKind = "active_calories"
+	LogEditFastingOverride LogEditKind = "fasting_override"
+	LogEditCheckIn         LogEditKind = "check_in"
+	LogEditEnvironment     LogEditKind = "environment"
+	LogEditConsumedMacros  LogEditKind = "consumed_macros"
+	LogEditClearMeal       LogEditKind = "clear_meal"
+	LogEditMealItem        LogEditKind = "meal_item"
+	LogEditNotes           LogEditKind = "notes"
+	LogEditDelete          LogEditKind = "delete"
+)
+
+// RetroEditAction is one change made to a locked day under a retro-edit.
+type RetroEditAction struct {
+	Kind LogEditKind `json:"kind"`
+	At   time.Time   `json:"at"`
+}
+
+// RetroEdit is an approval to change a locked day, with the audit trail of
+// the changes made through it.
+type RetroEdit struct {
+	ID        int64             `json:"id"`
+	Date      string            `json:"date"`
+	Reason    string            `json:"reason"`
+	OpenedAt  time.Time         `json:"openedAt"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	Actions   []RetroEditAction `json:"actions"`
+}
+
+// IsDayLocked reports whether date (YYYY-MM-DD) is older than lockDays days
+// as of now. A lockDays of 0 turns the lock off.
+func IsDayLocked(date string, lockDays int, now time.Time) bool {
+	if lockDays <= 0 {
+		return false
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return day.Before(today.AddDate(0, 0, -lockDays))
+}
+
+// NewRetroEdit opens a retro-edit for a locked day. The reason is required
+// so the audit trail says why history changed.
+func NewRetroEdit(date, reason string, lockDays int, now time.Time) (*RetroEdit, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, ErrInvalidDate
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > retroEditMaxReasonLength {
+		return nil, ErrInvalidRetroEditReason
+	}
+	if !IsDayLocked(date, lockDays, now) {
+		return nil, ErrDayNotLocked
+	}
+	return &RetroEdit{
+		Date:      date,
+		Reason:    reason,
+		OpenedAt:  now,
+		ExpiresAt: now.Add(RetroEditWindow),
+		Actions:   []RetroEditAction{},
+	}, nil
+}
+
+// IsOpen reports whether the retro-edit still accepts changes at now.
+func (e RetroEdit) IsOpen(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// RetroEditedDates returns the dates actually changed through a retro-edit.
+// An approval that was opened but never used leaves the day untouched.
+func RetroEditedDates(edits []RetroEdit) map[string]bool {
+	dates := make(map[string]bool)
+	for _, e := range edits {
+		if len(e.Actions) > 0 {
+			dates[e.Date] = true
+		}
+	}
+	return dates
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The logging lock guards the history adaptive TDEE learns
+// from; tests pin where the lock boundary falls, what a retro-edit needs to
+// open, and which days count as retro-edited.
+type LogLockSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestLogLockSuite(t *testing.T) {
+	suite.Run(t, new(LogLockSuite))
+}
+
+func (s *LogLockSuite) SetupTest() {
+	s.now = time.Date(2026, 10, 15, 18, 30, 0, 0, time.UTC)
+}
+
+func (s *LogLockSuite) TestLockBoundary() {
+	s.False(IsDayLocked("2026-10-08", 7, s.now), "exactly 7 days old is still editable")
+	s.True(IsDayLocked("2026-10-07", 7, s.now))
+	s.False(IsDayLocked("2026-01-01", 0, s.now), "0 turns the lock off")
+	s.False(IsDayLocked("not-a-date", 7, s.now))
+}
+
+func (s *LogLockSuite) TestRetroEditOpensForLockedDay() {
+	edit, err := NewRetroEdit("2026-09-01", "  forgot to log dinner  ", 14, s.now)
+	s.Require().NoError(err)
+
+	s.Equal("forgot to log dinner", edit.Reason)
+	s.Equal(s.now.Add(RetroEditWindow), edit.ExpiresAt)
+	s.Empty(edit.Actions)
+	s.True(edit.IsOpen(s.now.Add(RetroEditWindow - time.Second)))
+	s.False(edit.IsOpen(s.now.Add(RetroEditWindow)))
+}
+
+func (s *LogLockSuite) TestRetroEditRejections() {
+	_, err := NewRetroEdit("2026-09-01", "   ", 14, s.now)
+	s.ErrorIs(err, ErrInvalidRetroEditReason)
+
+	_, err = NewRetroEdit("2026-10-10", "typo", 14, s.now)
+	s.ErrorIs(err, ErrDayNotLocked)
+
+	_, err = NewRetroEdit("2026-09-01", "typo", 0, s.now)
+	s.ErrorIs(err, ErrDayNotLocked, "nothing is locked with the lock off")
+
+	_, err = NewRetroEdit("09/01/2026", "typo", 14, s.now)
+	s.ErrorIs(err, ErrInvalidDate)
+}
+
+func (s *LogLockSuite) TestRetroEditedDatesNeedAnAction() {
+	edits := []RetroEdit{
+		{Date: "2026-09-01", Actions: []RetroEditAction{{Kind: LogEditConsumedMacros, At: s.now}}},
+		{Date: "2026-09-02"}, // Opened but never used
+	}
+
+	s.Equal(map[string]bool{"2026-09-01": true}, RetroEditedDates(edits))
+}
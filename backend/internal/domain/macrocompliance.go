@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// MACRO COMPLIANCE
+// =============================================================================
+//
+// Each logged day is judged per macro against its own band: protein has a
+// floor, fat a minimum, and carbs a tolerance either side of target (see
+// AdherenceTolerances). A rolling 7-day rate per macro counts the days inside
+// the band, so one bad day shows up instead of being averaged away by a good
+// one.
+
+// MacroComplianceWindowDays is the length of the rolling compliance window.
+const MacroComplianceWindowDays = 7
+
+// MacroComplianceMaxDays caps the range of a compliance report.
+const MacroComplianceMaxDays = 90
+
+// MacroStatus classifies one macro on one day.
+type MacroStatus string
+
+const (
+	MacroMet      MacroStatus = "met"
+	MacroUnder    MacroStatus = "under"
+	MacroOver     MacroStatus = "over"     // Carbs only; protein and fat have no ceiling
+	MacroUnjudged MacroStatus = "unjudged" // No target, nothing logged, or an exempt day
+)
+
+// MacroDayCompliance is one day's per-macro classification.
+type MacroDayCompliance struct {
+	Date    string      `json:"date"`
+	Protein MacroStatus `json:"protein"`
+	Carbs   MacroStatus `json:"carbs"`
+	Fat     MacroStatus `json:"fat"`
+}
+
+// MacroRollingDay holds the rolling compliance rates for the window ending
+// on Date. A rate is the % of judged days in the window inside the band;
+// nil when no day in the window could be judged.
+type MacroRollingDay struct {
+	Date    string   `json:"date"`
+	Protein *float64 `json:"protein,omitempty"`
+	Carbs   *float64 `json:"carbs,omitempty"`
+	Fat     *float64 `json:"fat,omitempty"`
+}
+
+// MacroComplianceSummary counts days in band per macro over a range.
+type MacroComplianceSummary struct {
+	ProteinDaysMet int      `json:"proteinDaysMet"`
+	CarbsDaysMet   int      `json:"carbsDaysMet"`
+	FatDaysMet     int      `json:"fatDaysMet"`
+	DaysJudged     int      `json:"daysJudged"`
+	ProteinPercent *float64 `json:"proteinPercent,omitempty"` // Nil when no day was judged
+	CarbsPercent   *float64 `json:"carbsPercent,omitempty"`
+	FatPercent     *float64 `json:"fatPercent,omitempty"`
+}
+
+// MacroComplianceReport is the per-day and rolling view for a date range.
+type MacroComplianceReport struct {
+	StartDate string                 `json:"startDate"`
+	EndDate   string                 `json:"endDate"`
+	Days      []MacroDayCompliance   `json:"days"`
+	Rolling   []MacroRollingDay      `json:"rolling"`
+	Summary   MacroComplianceSummary `json:"summary"`
+}
+
+// ClassifyMacroDay judges each macro of a day against its band. A day is
+// only judged when it has a calorie target and food logged (the same rule
+// as ClassifyDayAdherence); a macro without a target stays unjudged.
+func ClassifyMacroDay(log DailyLog, tolerances AdherenceTolerances) MacroDayCompliance {
+	t := tolerances.WithDefaults()
+	day := MacroDayCompliance{Date: log.Date, Protein: MacroUnjudged, Carbs: MacroUnjudged, Fat: MacroUnjudged}
+	if ClassifyDayAdherence(log) == AdherenceUnlogged {
+		return day
+	}
+	targets := log.CalculatedTargets
+
+	if targets.TotalProteinG > 0 {
+		day.Protein = macroAtLeast(log.ConsumedProteinG, targets.TotalProteinG, t.ProteinFloor)
+	}
+	if targets.TotalFatsG > 0 {
+		day.Fat = macroAtLeast(log.ConsumedFatG, targets.TotalFatsG, t.FatMinimum)
+	}
+	if targets.TotalCarbsG > 0 {
+		deviation := float64(log.ConsumedCarbsG-targets.TotalCarbsG) / float64(targets.TotalCarbsG)
+		switch {
+		case math.Abs(deviation) <= t.Carbs:
+			day.Carbs = MacroMet
+		case deviation > 0:
+			day.Carbs = MacroOver
+		default:
+			day.Carbs = MacroUnder
+		}
+	}
+	return day
+}
+
+func macroAtLeast(consumed, target int, fraction float64) MacroStatus {
+	if float64(consumed) >= float64(target)*fraction {
+		return MacroMet
+	}
+	return MacroUnder
+}
+
+// SummarizeMacroCompliance counts the days in band per macro. A day counts
+// as judged when at least one of its macros was judged; each macro's
+// percent is over the days that macro was judged.
+func SummarizeMacroCompliance(days []MacroDayCompliance) MacroComplianceSummary {
+	var summary MacroComplianceSummary
+	var proteinJudged, carbsJudged, fatJudged int
+	for _, d := range days {
+		if d.Protein == MacroUnjudged && d.Carbs == MacroUnjudged && d.Fat == MacroUnjudged {
+			continue
+		}
+		summary.DaysJudged++
+		countMacro(d.Protein, &summary.ProteinDaysMet, &proteinJudged)
+		countMacro(d.Carbs, &summary.CarbsDaysMet, &carbsJudged)
+		countMacro(d.Fat, &summary.FatDaysMet, &fatJudged)
+	}
+	summary.ProteinPercent = compliancePercent(summary.ProteinDaysMet, proteinJudged)
+	summary.CarbsPercent = compliancePercent(summary.CarbsDaysMet, carbsJudged)
+	summary.FatPercent = compliancePercent(summary.FatDaysMet, fatJudged)
+	return summary
+}
+
+func countMacro(status MacroStatus, met, judged *int) {
+	if status == MacroUnjudged {
+		return
+	}
+	*judged++
+	if status == MacroMet {
+		*met++
+	}
+}
+
+func compliancePercent(met, judged int) *float64 {
+	if judged == 0 {
+		return nil
+	}
+	pct := math.Round(float64(met)/float64(judged)*1000) / 10
+	return &pct
+}
+
+// BuildMacroCompliance classifies every day from start to end (inclusive)
+// and the rolling 7-day rates ending on each of them. Logs from the 6 days
+// before start fill the first windows. Sick and travel days are unjudged.
+func BuildMacroCompliance(start, end time.Time, logs []DailyLog, exemptions []DayExemption, tolerances AdherenceTolerances) MacroComplianceReport {
+	byDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		byDate[l.Date] = l
+	}
+	exempt := make(map[string]bool, len(exemptions))
+	for _, e := range exemptions {
+		exempt[e.Date] = true
+	}
+
+	classify := func(date string) MacroDayCompliance {
+		l, ok := byDate[date]
+		if !ok || exempt[date] {
+			return MacroDayCompliance{Date: date, Protein: MacroUnjudged, Carbs: MacroUnjudged, Fat: MacroUnjudged}
+		}
+		day := ClassifyMacroDay(l, tolerances)
+		day.Date = date
+		return day
+	}
+
+	// Classify the lead-in days too so the first window is full.
+	var all []MacroDayCompliance
+	for d := start.AddDate(0, 0, -(MacroComplianceWindowDays - 1)); !d.After(end); d = d.AddDate(0, 0, 1) {
+		all = append(all, classify(d.Format("2006-01-02")))
+	}
+	lead := MacroComplianceWindowDays - 1
+
+	report := MacroComplianceReport{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Days:      all[lead:],
+		Rolling:   make([]MacroRollingDay, 0, len(all)-lead),
+	}
+	for i := lead; i < len(all); i++ {
+		window := SummarizeMacroCompliance(all[i-lead : i+1])
+		report.Rolling = append(report.Rolling, MacroRollingDay{
+			Date:    all[i].Date,
+			Protein: window.ProteinPercent,
+			Carbs:   window.CarbsPercent,
+			Fat:     window.FatPercent,
+		})
+	}
+	report.Summary = SummarizeMacroCompliance(report.Days)
+	return report
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Macro bands decide "protein adherence" in the debrief; tests
+// pin each band's edge and that a bad day stays visible in the rolling rate.
+type MacroComplianceSuite struct {
+	suite.Suite
+}
+
+func TestMacroComplianceSuite(t *testing.T) {
+	suite.Run(t, new(MacroComplianceSuite))
+}
+
+func macroLog(date string, protein, carbs, fat int) DailyLog {
+	return DailyLog{
+		Date:              date,
+		ConsumedCalories:  2000,
+		ConsumedProteinG:  protein,
+		ConsumedCarbsG:    carbs,
+		ConsumedFatG:      fat,
+		CalculatedTargets: DailyTargets{TotalCalories: 2000, TotalProteinG: 150, TotalCarbsG: 200, TotalFatsG: 60},
+	}
+}
+
+func (s *MacroComplianceSuite) TestClassifyMacroDayBands() {
+	policy := DefaultAdherenceTolerances
+
+	onEdge := ClassifyMacroDay(macroLog("", 135, 240, 42), policy)
+	s.Equal(MacroMet, onEdge.Protein, "90% of target is the floor")
+	s.Equal(MacroMet, onEdge.Carbs, "20% over is within tolerance")
+	s.Equal(MacroMet, onEdge.Fat, "70% of target is the minimum")
+
+	off := ClassifyMacroDay(macroLog("", 134, 250, 41), policy)
+	s.Equal(MacroUnder, off.Protein)
+	s.Equal(MacroOver, off.Carbs)
+	s.Equal(MacroUnder, off.Fat)
+
+	s.Equal(MacroMet, ClassifyMacroDay(macroLog("", 300, 200, 200), policy).Fat, "fat has no ceiling")
+	s.Equal(MacroUnder, ClassifyMacroDay(macroLog("", 150, 150, 60), policy).Carbs)
+
+	unlogged := macroLog("", 150, 200, 60)
+	unlogged.ConsumedCalories = 0
+	s.Equal(MacroUnjudged, ClassifyMacroDay(unlogged, policy).Protein)
+}
+
+func (s *MacroComplianceSuite) TestRollingWindowKeepsBadDaysVisible() {
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	logs := []DailyLog{
+		macroLog("2026-03-02", 150, 200, 60), // Lead-in day before start
+		macroLog("2026-03-03", 40, 200, 60),  // One very low protein day
+		macroLog("2026-03-04", 150, 200, 60),
+		macroLog("2026-03-05", 150, 200, 60),
+		macroLog("2026-03-08", 300, 200, 60),
+		macroLog("2026-03-09", 60, 200, 60), // Sick day: not judged
+	}
+	exemptions := []DayExemption{{Date: "2026-03-09", Reason: DayExemptionSick}}
+
+	report := BuildMacroCompliance(start, start.AddDate(0, 0, 2), logs, exemptions, DefaultAdherenceTolerances)
+
+	s.Require().Len(report.Days, 3)
+	s.Equal("2026-03-08", report.Days[0].Date)
+	s.Equal(MacroUnjudged, report.Days[1].Protein)
+	s.Require().Len(report.Rolling, 3)
+	s.Require().NotNil(report.Rolling[0].Protein)
+	s.Equal(80.0, *report.Rolling[0].Protein, "the surplus on 03-08 doesn't offset the miss on 03-03")
+	s.Equal(75.0, *report.Rolling[1].Protein, "the sick day isn't judged")
+	s.Equal(100.0, *report.Rolling[2].Protein, "03-03 has left the window")
+	s.Equal(1, report.Summary.DaysJudged)
+	s.Equal(100.0, *report.Summary.ProteinPercent)
+}
+
+func (s *MacroComplianceSuite) TestWeekComplianceDrivesProteinRecommendation() {
+	logs := []DailyLog{
+		macroLog("2026-03-02", 150, 200, 60),
+		macroLog("2026-03-03", 150, 200, 60),
+		macroLog("2026-03-04", 60, 200, 60),
+		macroLog("2026-03-05", 60, 200, 60),
+	}
+
+	summary := WeekMacroCompliance(logs, AdherenceTolerances{})
+	s.Equal(50.0, *summary.ProteinPercent)
+
+	var found bool
+	for _, rec := range GenerateTacticalRecommendations(DebriefInput{DailyLogs: logs}) {
+		if rec.Summary == "Protein intake below target" {
+			found = true
+			s.Contains(rec.Rationale, "protein floor on 50")
+		}
+	}
+	s.True(found)
+
+	s.Nil(WeekMacroCompliance(nil, AdherenceTolerances{}).ProteinPercent, "no logged days, nothing to judge")
+}
+
+func (s *MacroComplianceSuite) TestCustomProteinFloor() {
+	log := macroLog("", 120, 200, 60) // 80% of the protein target
+
+	s.Equal(MacroUnder, ClassifyMacroDay(log, AdherenceTolerances{}).Protein)
+	s.Equal(MacroMet, ClassifyMacroDay(log, AdherenceTolerances{ProteinFloor: 0.8}).Protein)
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// ITEMIZED MEAL LOGGING
+// =============================================================================
+//
+// Food can be logged one item at a time: a food from the reference table, a
+// weight in grams and an optional meal slot. The item's macros come from the
+// food's per-100g values and are added to the day's consumed totals and its
+// slot. Editing or deleting an item moves the totals with it, so a single
+// item can be corrected without recomputing the day by hand. Macros logged as
+// plain numbers keep adding to the same totals; the items' share is
+// recomputed from the stored items on every change.
+
+// MealItemMaxGrams caps a single logged item.
+const MealItemMaxGrams = 5000.0
+
+// MealItemInput is a food item to log or the replacement for a logged one.
+type MealItemInput struct {
+	FoodReferenceID int64
+	Grams           float64
+	Meal            *MealName // nil for food eaten outside a meal slot
+	EatenAt         string    // Optional HH:MM, feeds meal timing
+}
+
+// Validate checks the food, weight, meal slot and time.
+func (in MealItemInput) Validate() error {
+	if in.FoodReferenceID <= 0 {
+		return ErrMealItemFoodRequired
+	}
+	if in.Grams <= 0 || in.Grams > MealItemMaxGrams {
+		return ErrInvalidMealItemGrams
+	}
+	if in.Meal != nil && !ValidMealNames[*in.Meal] {
+		return ErrInvalidMealName
+	}
+	if in.EatenAt != "" {
+		return ValidateMealTime(in.EatenAt)
+	}
+	return nil
+}
+
+// MealItem is one logged food with the macros it added to the day.
+type MealItem struct {
+	ID              int64     `json:"id"`
+	Date            string    `json:"date"` // YYYY-MM-DD
+	Meal            *MealName `json:"meal,omitempty"`
+	FoodReferenceID int64     `json:"foodReferenceId"`
+	FoodItem        string    `json:"foodItem"` // Filled from food_reference on read
+	Grams           float64   `json:"grams"`
+	EatenAt         string    `json:"eatenAt,omitempty"`
+	Calories        int       `json:"calories"`
+	ProteinG        int       `json:"proteinG"`
+	CarbsG          int       `json:"carbsG"`
+	FatG            int       `json:"fatG"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// NewMealItem builds the item logged on date from its input and the food's
+// nutrition, rounding each macro to a whole number.
+func NewMealItem(date string, in MealItemInput, food FoodNutrition) MealItem {
+	m := in.Grams / 100
+	return MealItem{
+		Date:            date,
+		Meal:            in.Meal,
+		FoodReferenceID: food.ID,
+		FoodItem:        food.FoodItem,
+		Grams:           in.Grams,
+		EatenAt:         in.EatenAt,
+		Calories:        int(math.Round(calculateCaloriesPer100(food) * m)),
+		ProteinG:        int(math.Round(food.ProteinGPer100 * m)),
+		CarbsG:          int(math.Round(food.CarbsGPer100 * m)),
+		FatG:            int(math.Round(food.FatGPer100 * m)),
+	}
+}
+
+// Macros returns what the item adds to the day's consumed totals.
+func (i MealItem) Macros() ConsumedMacros {
+	return ConsumedMacros{Calories: i.Calories, ProteinG: i.ProteinG, CarbsG: i.CarbsG, FatG: i.FatG}
+}
+
+// MealSlotMacros is the macros of one meal slot: the items logged in it, or a
+// move of its consumed totals. Meal is nil outside a slot.
+type MealSlotMacros struct {
+	Meal   *MealName
+	Macros ConsumedMacros
+}
+
+// MealItemChanges returns the moves to the day's consumed macros that replace
+// the items' share of the totals, summed per slot before a change, with their
+// share after it. The totals always include every logged item, so the moves
+// leave the macros logged as plain numbers as they were. A slot whose items
+// didn't change is left out.
+func MealItemChanges(before, after []MealSlotMacros) []MealSlotMacros {
+	var changes []MealSlotMacros
+	add := func(meal *MealName, m ConsumedMacros, sign int) {
+		for i := range changes {
+			if sameMeal(changes[i].Meal, meal) {
+				c := &changes[i].Macros
+				c.Calories += sign * m.Calories
+				c.ProteinG += sign * m.ProteinG
+				c.CarbsG += sign * m.CarbsG
+				c.FatG += sign * m.FatG
+				return
+			}
+		}
+		changes = append(changes, MealSlotMacros{Meal: meal, Macros: ConsumedMacros{
+			Calories: sign * m.Calories,
+			ProteinG: sign * m.ProteinG,
+			CarbsG:   sign * m.CarbsG,
+			FatG:     sign * m.FatG,
+		}})
+	}
+	for _, b := range before {
+		add(b.Meal, b.Macros, -1)
+	}
+	for _, a := range after {
+		add(a.Meal, a.Macros, 1)
+	}
+
+	moved := changes[:0]
+	for _, c := range changes {
+		if c.Macros != (ConsumedMacros{}) {
+			moved = append(moved, c)
+		}
+	}
+	return moved
+}
+
+// sameMeal reports whether two optional meal slots are the same.
+func sameMeal(a, b *MealName) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
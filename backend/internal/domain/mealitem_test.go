@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Item macros are added to and taken from the day's totals,
+// so the per-item rounding and the moves an edit makes must balance exactly.
+type MealItemSuite struct {
+	suite.Suite
+	oats FoodNutrition
+}
+
+func TestMealItemSuite(t *testing.T) {
+	suite.Run(t, new(MealItemSuite))
+}
+
+func (s *MealItemSuite) SetupTest() {
+	s.oats = FoodNutrition{ID: 3, FoodItem: "Oats", ProteinGPer100: 13.2, CarbsGPer100: 67.7, FatGPer100: 6.5}
+}
+
+func (s *MealItemSuite) item(meal MealName, grams float64) *MealItem {
+	item := NewMealItem("2026-03-02", MealItemInput{FoodReferenceID: 3, Grams: grams, Meal: &meal}, s.oats)
+	return &item
+}
+
+func (s *MealItemSuite) TestValidate() {
+	lunch, snack := MealLunch, MealName("snack")
+	s.NoError(MealItemInput{FoodReferenceID: 3, Grams: 80, Meal: &lunch, EatenAt: "12:30"}.Validate())
+	s.NoError(MealItemInput{FoodReferenceID: 3, Grams: 80}.Validate(), "no slot is fine")
+	s.ErrorIs(MealItemInput{Grams: 80}.Validate(), ErrMealItemFoodRequired)
+	s.ErrorIs(MealItemInput{FoodReferenceID: 3}.Validate(), ErrInvalidMealItemGrams)
+	s.ErrorIs(MealItemInput{FoodReferenceID: 3, Grams: 5001}.Validate(), ErrInvalidMealItemGrams)
+	s.ErrorIs(MealItemInput{FoodReferenceID: 3, Grams: 80, Meal: &snack}.Validate(), ErrInvalidMealName)
+	s.ErrorIs(MealItemInput{FoodReferenceID: 3, Grams: 80, EatenAt: "noon"}.Validate(), ErrInvalidMealTime)
+}
+
+func (s *MealItemSuite) TestMacrosScaleWithGrams() {
+	item := s.item(MealBreakfast, 80)
+	s.Equal("Oats", item.FoodItem)
+	s.Equal(ConsumedMacros{Calories: 306, ProteinG: 11, CarbsG: 54, FatG: 5}, item.Macros())
+}
+
+// slot returns the item totals of a slot holding the given items.
+func (s *MealItemSuite) slot(items ...*MealItem) MealSlotMacros {
+	t := MealSlotMacros{Meal: items[0].Meal}
+	for _, item := range items {
+		m := item.Macros()
+		t.Macros.Calories += m.Calories
+		t.Macros.ProteinG += m.ProteinG
+		t.Macros.CarbsG += m.CarbsG
+		t.Macros.FatG += m.FatG
+	}
+	return t
+}
+
+func (s *MealItemSuite) TestChangesWithinASlot() {
+	eggs := s.item(MealBreakfast, 40)
+	before := []MealSlotMacros{s.slot(s.item(MealBreakfast, 80), eggs)}
+	after := []MealSlotMacros{s.slot(s.item(MealBreakfast, 60), eggs)}
+
+	changes := MealItemChanges(before, after)
+	s.Require().Len(changes, 1)
+	s.Equal(MealBreakfast, *changes[0].Meal)
+	s.Equal(ConsumedMacros{Calories: -77, ProteinG: -3, CarbsG: -13, FatG: -1}, changes[0].Macros)
+
+	s.Empty(MealItemChanges(before, before), "nothing moved")
+}
+
+func (s *MealItemSuite) TestChangesAcrossSlots() {
+	before := []MealSlotMacros{s.slot(s.item(MealBreakfast, 80))}
+	after := []MealSlotMacros{s.slot(s.item(MealDinner, 80))}
+
+	changes := MealItemChanges(before, after)
+	s.Require().Len(changes, 2)
+	s.Equal(MealBreakfast, *changes[0].Meal)
+	s.Equal(-306, changes[0].Macros.Calories)
+	s.Equal(MealDinner, *changes[1].Meal)
+	s.Equal(306, changes[1].Macros.Calories)
+}
+
+func (s *MealItemSuite) TestAddAndDelete() {
+	item := s.item(MealLunch, 80)
+	s.Equal([]MealSlotMacros{{Meal: item.Meal, Macros: item.Macros()}}, MealItemChanges(nil, []MealSlotMacros{s.slot(item)}))
+
+	deleted := MealItemChanges([]MealSlotMacros{s.slot(item)}, nil)
+	s.Require().Len(deleted, 1)
+	s.Equal(-item.Calories, deleted[0].Macros.Calories)
+}
+
+func (s *MealItemSuite) TestUnslottedItems() {
+	snack := NewMealItem("2026-03-02", MealItemInput{FoodReferenceID: 3, Grams: 80}, s.oats)
+	changes := MealItemChanges(nil, []MealSlotMacros{{Macros: snack.Macros()}})
+	s.Require().Len(changes, 1)
+	s.Nil(changes[0].Meal, "day totals only")
+	s.Equal(306, changes[0].Macros.Calories)
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// MEAL REUSE: DAY COPY & MEAL TEMPLATES
+// =============================================================================
+//
+// Most people eat the same few meals. A previous day's meals or a saved
+// template ("standard breakfast") can be logged in one call; the flexible part
+// of the meal is rescaled to today's point targets so a repeated breakfast on a
+// rest day comes out smaller than on a performance day.
+//
+// Protein is treated as fixed (the anchor of the meal) while carbs and fats are
+// the flexible macros. For day copies each meal's carbs and fats scale with the
+// ratio of today's meal points to the source day's; for templates the items
+// marked flexible scale together so the meal's carb+fat points meet today's.
+
+const (
+	// MealScaleMin and MealScaleMax bound how far a reused meal is rescaled,
+	// so a copied meal stays recognizable when targets differ a lot.
+	MealScaleMin = 0.5
+	MealScaleMax = 2.0
+	// MealTemplateMaxItems caps the number of foods in a template.
+	MealTemplateMaxItems = 20
+	// mealTemplateGramStep rounds scaled item weights to a practical amount.
+	mealTemplateGramStep = 5.0
+)
+
+// MealTemplateItem is one food in a meal template.
+type MealTemplateItem struct {
+	FoodReferenceID int64   `json:"foodReferenceId"`
+	FoodItem        string  `json:"foodItem,omitempty"` // Filled from food_reference on read
+	Grams           float64 `json:"grams"`
+	Flexible        bool    `json:"flexible"` // Rescaled to today's targets when applied
+}
+
+// MealTemplate is a saved meal that can be logged in one call.
+type MealTemplate struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Meal      MealName           `json:"meal"`
+	Items     []MealTemplateItem `json:"items"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// Validate checks the template's name, meal slot and items.
+func (t *MealTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrMealTemplateNameRequired
+	}
+	if !ValidMealNames[t.Meal] {
+		return ErrInvalidMealName
+	}
+	if len(t.Items) == 0 {
+		return ErrMealTemplateEmpty
+	}
+	if len(t.Items) > MealTemplateMaxItems {
+		return ErrMealTemplateTooManyItems
+	}
+	for _, item := range t.Items {
+		if item.FoodReferenceID <= 0 || item.Grams <= 0 {
+			return ErrInvalidMealTemplateItem
+		}
+	}
+	return nil
+}
+
+// ScaledMealItem is a template item after rescaling.
+type ScaledMealItem struct {
+	MealTemplateItem
+	ScaledGrams float64 `json:"scaledGrams"`
+}
+
+// ScaledMeal is a reused meal ready to be added to a day's consumed macros.
+type ScaledMeal struct {
+	Meal        MealName         `json:"meal"`
+	ScaleFactor float64          `json:"scaleFactor"` // Applied to the flexible part (1 = unchanged)
+	Items       []ScaledMealItem `json:"items,omitempty"`
+	Macros      ConsumedMacros   `json:"-"`
+}
+
+// MealPointsFor returns the point targets for a meal slot.
+func (m MealTargets) MealPointsFor(meal MealName) MacroPoints {
+	switch meal {
+	case MealBreakfast:
+		return m.Breakfast
+	case MealLunch:
+		return m.Lunch
+	case MealDinner:
+		return m.Dinner
+	}
+	return MacroPoints{}
+}
+
+// MacrosFor returns the consumed macros for a meal slot.
+func (m MealConsumed) MacrosFor(meal MealName) ConsumedMacros {
+	switch meal {
+	case MealBreakfast:
+		return m.Breakfast
+	case MealLunch:
+		return m.Lunch
+	case MealDinner:
+		return m.Dinner
+	}
+	return ConsumedMacros{}
+}
+
+// clampMealScale bounds a scale factor; non-finite or non-positive input means "don't scale".
+func clampMealScale(f float64) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f <= 0 {
+		return 1
+	}
+	return math.Max(MealScaleMin, math.Min(MealScaleMax, f))
+}
+
+// pointsRatio returns today/source for a macro's points, or 1 when either is unset.
+func pointsRatio(today, source int) float64 {
+	if today <= 0 || source <= 0 {
+		return 1
+	}
+	return clampMealScale(float64(today) / float64(source))
+}
+
+// ScaleCopiedMeal rescales a meal eaten on another day to today's point targets.
+// Protein is kept; carbs and fats scale with today's meal points relative to
+// the source day's. Calories follow the macro changes.
+func ScaleCopiedMeal(meal MealName, source ConsumedMacros, sourceTargets, todayTargets MacroPoints) ScaledMeal {
+	carbScale := pointsRatio(todayTargets.Carbs, sourceTargets.Carbs)
+	fatScale := pointsRatio(todayTargets.Fats, sourceTargets.Fats)
+
+	carbs := int(math.Round(float64(source.CarbsG) * carbScale))
+	fats := int(math.Round(float64(source.FatG) * fatScale))
+	calories := source.Calories + 4*(carbs-source.CarbsG) + 9*(fats-source.FatG)
+	if calories < 0 {
+		calories = 0
+	}
+
+	// Report the calorie-weighted scale of the flexible macros
+	scale := 1.0
+	if flexKcal := float64(4*source.CarbsG + 9*source.FatG); flexKcal > 0 {
+		scale = math.Round(float64(4*carbs+9*fats)/flexKcal*100) / 100
+	}
+
+	return ScaledMeal{
+		Meal:        meal,
+		ScaleFactor: scale,
+		Macros: ConsumedMacros{
+			Calories: calories,
+			ProteinG: source.ProteinG,
+			CarbsG:   carbs,
+			FatG:     fats,
+		},
+	}
+}
+
+// ScaleMealTemplate sizes a template for today's point targets.
+// Fixed items keep their weight; flexible items share one scale factor chosen
+// so the meal's carb+fat points meet the target. With no flexible items or no
+// target the template is logged as saved. foods must contain every item's food.
+func ScaleMealTemplate(t MealTemplate, foods map[int64]FoodNutrition, target MacroPoints, points PointsConfig) ScaledMeal {
+	flexPoints := func(f FoodNutrition, grams float64) float64 {
+		return grams / 100 * (f.CarbsGPer100*points.CarbMultiplier + f.FatGPer100*points.FatMultiplier)
+	}
+
+	var fixedPts, flexPts float64
+	for _, item := range t.Items {
+		f := foods[item.FoodReferenceID]
+		if item.Flexible {
+			flexPts += flexPoints(f, item.Grams)
+		} else {
+			fixedPts += flexPoints(f, item.Grams)
+		}
+	}
+
+	scale := 1.0
+	targetPts := float64(target.Carbs + target.Fats)
+	if flexPts > 0 && targetPts > 0 {
+		// Fixed items alone may already exceed the target; shrink as far as allowed
+		scale = clampMealScale(math.Max((targetPts-fixedPts)/flexPts, MealScaleMin))
+	}
+
+	result := ScaledMeal{
+		Meal:        t.Meal,
+		ScaleFactor: math.Round(scale*100) / 100,
+		Items:       make([]ScaledMealItem, len(t.Items)),
+	}
+	var kcal, protein, carbs, fat float64
+	for i, item := range t.Items {
+		grams := item.Grams
+		if item.Flexible && scale != 1 {
+			grams = math.Max(mealTemplateGramStep, math.Round(item.Grams*scale/mealTemplateGramStep)*mealTemplateGramStep)
+		}
+		result.Items[i] = ScaledMealItem{MealTemplateItem: item, ScaledGrams: grams}
+
+		f := foods[item.FoodReferenceID]
+		m := grams / 100
+		protein += f.ProteinGPer100 * m
+		carbs += f.CarbsGPer100 * m
+		fat += f.FatGPer100 * m
+		kcal += (f.ProteinGPer100*4 + f.CarbsGPer100*4 + f.FatGPer100*9) * m
+	}
+	result.Macros = ConsumedMacros{
+		Calories: int(math.Round(kcal)),
+		ProteinG: int(math.Round(protein)),
+		CarbsG:   int(math.Round(carbs)),
+		FatG:     int(math.Round(fat)),
+	}
+	return result
+}
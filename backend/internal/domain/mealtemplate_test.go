@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MealTemplateSuite struct {
+	suite.Suite
+	foods  map[int64]FoodNutrition
+	points PointsConfig
+}
+
+func TestMealTemplateSuite(t *testing.T) {
+	suite.Run(t, new(MealTemplateSuite))
+}
+
+func (s *MealTemplateSuite) SetupTest() {
+	s.foods = map[int64]FoodNutrition{
+		1: {ID: 1, FoodItem: "Oats", ProteinGPer100: 13, CarbsGPer100: 60, FatGPer100: 7},
+		2: {ID: 2, FoodItem: "Whey", ProteinGPer100: 80, CarbsGPer100: 5, FatGPer100: 5},
+		3: {ID: 3, FoodItem: "Banana", ProteinGPer100: 1, CarbsGPer100: 23, FatGPer100: 0},
+	}
+	s.points = PointsConfig{CarbMultiplier: 1.15, ProteinMultiplier: 4.35, FatMultiplier: 3.5}
+}
+
+func (s *MealTemplateSuite) breakfast() MealTemplate {
+	return MealTemplate{
+		Name: "Standard breakfast",
+		Meal: MealBreakfast,
+		Items: []MealTemplateItem{
+			{FoodReferenceID: 1, Grams: 80, Flexible: true},
+			{FoodReferenceID: 2, Grams: 30},
+			{FoodReferenceID: 3, Grams: 100, Flexible: true},
+		},
+	}
+}
+
+func (s *MealTemplateSuite) TestValidate() {
+	t := s.breakfast()
+	s.NoError(t.Validate())
+
+	t.Name = " "
+	s.ErrorIs(t.Validate(), ErrMealTemplateNameRequired)
+
+	t = s.breakfast()
+	t.Meal = "brunch"
+	s.ErrorIs(t.Validate(), ErrInvalidMealName)
+
+	t = s.breakfast()
+	t.Items = nil
+	s.ErrorIs(t.Validate(), ErrMealTemplateEmpty)
+
+	t = s.breakfast()
+	t.Items[0].Grams = 0
+	s.ErrorIs(t.Validate(), ErrInvalidMealTemplateItem)
+}
+
+func (s *MealTemplateSuite) TestTemplateScalesFlexibleItemsOnly() {
+	// Saved template: oats 80g + banana 100g ≈ 55 + 28 flexible points, whey ≈ 4 fixed.
+	// Doubling the carb target should roughly double the flexible items.
+	target := MacroPoints{Carbs: 150, Fats: 25}
+
+	scaled := ScaleMealTemplate(s.breakfast(), s.foods, target, s.points)
+
+	s.Greater(scaled.ScaleFactor, 1.5)
+	s.Equal(30.0, scaled.Items[1].ScaledGrams, "fixed protein item is untouched")
+	s.Greater(scaled.Items[0].ScaledGrams, 80.0)
+	s.Greater(scaled.Items[2].ScaledGrams, 100.0)
+	s.Zero(int(scaled.Items[0].ScaledGrams)%5, "scaled grams are rounded to 5g")
+	s.Greater(scaled.Macros.CarbsG, 100)
+}
+
+func (s *MealTemplateSuite) TestTemplateScaleIsClamped() {
+	scaled := ScaleMealTemplate(s.breakfast(), s.foods, MacroPoints{Carbs: 5, Fats: 0}, s.points)
+
+	s.Equal(MealScaleMin, scaled.ScaleFactor)
+	s.Equal(40.0, scaled.Items[0].ScaledGrams)
+	s.Equal(50.0, scaled.Items[2].ScaledGrams)
+}
+
+func (s *MealTemplateSuite) TestTemplateWithoutTargetIsLoggedAsSaved() {
+	scaled := ScaleMealTemplate(s.breakfast(), s.foods, MacroPoints{}, s.points)
+
+	s.Equal(1.0, scaled.ScaleFactor)
+	for i, item := range scaled.Items {
+		s.Equal(s.breakfast().Items[i].Grams, item.ScaledGrams)
+	}
+	// 80g oats + 30g whey + 100g banana
+	s.Equal(35, scaled.Macros.ProteinG)
+	s.Equal(73, scaled.Macros.CarbsG)
+}
+
+func (s *MealTemplateSuite) TestCopiedMealScalesCarbsAndFatsToTodaysPoints() {
+	eaten := ConsumedMacros{Calories: 600, ProteinG: 40, CarbsG: 70, FatG: 18}
+	source := MacroPoints{Carbs: 100, Protein: 120, Fats: 40}
+	today := MacroPoints{Carbs: 60, Protein: 120, Fats: 40} // Rest day: fewer carbs
+
+	scaled := ScaleCopiedMeal(MealLunch, eaten, source, today)
+
+	s.Equal(40, scaled.Macros.ProteinG, "protein is kept")
+	s.Equal(42, scaled.Macros.CarbsG)
+	s.Equal(18, scaled.Macros.FatG)
+	s.Equal(600-4*28, scaled.Macros.Calories)
+	s.Less(scaled.ScaleFactor, 1.0)
+}
+
+func (s *MealTemplateSuite) TestCopiedMealWithoutTargetsIsUnchanged() {
+	eaten := ConsumedMacros{Calories: 600, ProteinG: 40, CarbsG: 70, FatG: 18}
+
+	scaled := ScaleCopiedMeal(MealDinner, eaten, MacroPoints{}, MacroPoints{Carbs: 80, Fats: 30})
+
+	s.Equal(eaten, scaled.Macros)
+	s.Equal(1.0, scaled.ScaleFactor)
+}
@@ -0,0 +1,307 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// MEAL TIMING
+// =============================================================================
+//
+// Food logged with the time it was eaten gives each day an eating window
+// (first to last meal), and, with the bedtime on the next day's log, the gap
+// between the last meal and bed. Actual sessions logged with a start time add
+// the fueling gaps either side of training: last meal before the session
+// starts, first meal after it ends. As with caffeine, a log's sleep describes
+// the night before it, so a day's timing is paired with the next day's sleep
+// quality and with the effort reported in the next day's training. The
+// monthly rollup lists the correlations strongest first.
+
+const (
+	// MealTimingMinPairs is the number of paired days needed before a correlation is reported.
+	MealTimingMinPairs = 7
+	// MealTimingMaxBedGapHours drops last-meal-to-bed gaps too long to be the same evening.
+	MealTimingMaxBedGapHours = 12
+)
+
+// MealEntry is one timed food log: when it was eaten and what it added.
+type MealEntry struct {
+	Date     string    `json:"date"`           // YYYY-MM-DD
+	Meal     *MealName `json:"meal,omitempty"` // Slot, when logged against one
+	EatenAt  string    `json:"eatenAt"`        // HH:MM, local
+	Calories int       `json:"calories"`
+}
+
+// ValidateMealTime checks a meal time is in HH:MM format.
+func ValidateMealTime(hhmm string) error {
+	if !isValidTimeFormat(hhmm) {
+		return ErrInvalidMealTime
+	}
+	return nil
+}
+
+// ValidateBedtime checks a bedtime is in HH:MM format.
+func ValidateBedtime(hhmm string) error {
+	if !isValidTimeFormat(hhmm) {
+		return ErrInvalidBedtime
+	}
+	return nil
+}
+
+// MealTimingMetric names a timing measure.
+type MealTimingMetric string
+
+const (
+	MealTimingEatingWindow   MealTimingMetric = "eating_window"    // Hours, first to last meal
+	MealTimingLastMealToBed  MealTimingMetric = "last_meal_to_bed" // Hours
+	MealTimingPreWorkoutGap  MealTimingMetric = "pre_workout_gap"  // Minutes, last meal to session start
+	MealTimingPostWorkoutGap MealTimingMetric = "post_workout_gap" // Minutes, session end to first meal
+)
+
+// MealTimingOutcome names what a timing measure is paired with.
+type MealTimingOutcome string
+
+const (
+	MealTimingNextSleepQuality MealTimingOutcome = "next_sleep_quality" // From the next day's log
+	MealTimingNextDayRPE       MealTimingOutcome = "next_day_rpe"       // Average RPE of the next day's actual training
+)
+
+// MealTimingDay is one day's timing. Measures are nil when the day lacks
+// the times they need.
+type MealTimingDay struct {
+	Date               string   `json:"date"`
+	FirstMeal          string   `json:"firstMeal"` // HH:MM
+	LastMeal           string   `json:"lastMeal"`  // HH:MM
+	EatingWindowHours  *float64 `json:"eatingWindowHours,omitempty"`
+	LastMealToBedHours *float64 `json:"lastMealToBedHours,omitempty"`
+	PreWorkoutGapMin   *int     `json:"preWorkoutGapMin,omitempty"`
+	PostWorkoutGapMin  *int     `json:"postWorkoutGapMin,omitempty"`
+	NextSleepQuality   *int     `json:"nextSleepQuality,omitempty"`
+	NextDayRPE         *float64 `json:"nextDayRpe,omitempty"`
+}
+
+// MealTimingCorrelation is Pearson r (-1 to 1) between a measure and an outcome.
+type MealTimingCorrelation struct {
+	Metric  MealTimingMetric  `json:"metric"`
+	Outcome MealTimingOutcome `json:"outcome"`
+	R       float64           `json:"r"`
+	Pairs   int               `json:"pairs"`
+}
+
+// MealTimingRollup summarizes a month of meal timing. Averages are nil when
+// no day had the measure; correlations need MealTimingMinPairs paired days
+// and are sorted strongest (largest |r|) first.
+type MealTimingRollup struct {
+	Month                 string                  `json:"month"` // YYYY-MM
+	DaysTimed             int                     `json:"daysTimed"`
+	AvgEatingWindowHours  *float64                `json:"avgEatingWindowHours,omitempty"`
+	AvgLastMealToBedHours *float64                `json:"avgLastMealToBedHours,omitempty"`
+	AvgPreWorkoutGapMin   *float64                `json:"avgPreWorkoutGapMin,omitempty"`
+	AvgPostWorkoutGapMin  *float64                `json:"avgPostWorkoutGapMin,omitempty"`
+	Days                  []MealTimingDay         `json:"days"`
+	Correlations          []MealTimingCorrelation `json:"correlations"`
+}
+
+// BuildMealTimingRollup builds the rollup for month (YYYY-MM). entries
+// should cover the month; logs the month plus the day after it, with actual
+// sessions loaded.
+func BuildMealTimingRollup(month string, entries []MealEntry, logs []DailyLog) (*MealTimingRollup, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, ErrInvalidRollupMonth
+	}
+	first := start.Format("2006-01-02")
+	end := start.AddDate(0, 1, -1).Format("2006-01-02")
+
+	logsByDate := make(map[string]DailyLog, len(logs))
+	for _, l := range logs {
+		logsByDate[l.Date] = l
+	}
+	timesByDate := make(map[string][]int)
+	for _, e := range entries {
+		minute, ok := minuteOfDay(e.EatenAt)
+		if !ok || e.Date < first || e.Date > end {
+			continue
+		}
+		timesByDate[e.Date] = append(timesByDate[e.Date], minute)
+	}
+	dates := make([]string, 0, len(timesByDate))
+	for date := range timesByDate {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	rollup := &MealTimingRollup{
+		Month:        start.Format("2006-01"),
+		Days:         []MealTimingDay{},
+		Correlations: []MealTimingCorrelation{},
+	}
+	for _, date := range dates {
+		day := buildMealTimingDay(date, timesByDate[date], logsByDate)
+		rollup.Days = append(rollup.Days, day)
+	}
+	rollup.DaysTimed = len(rollup.Days)
+
+	measures := []struct {
+		metric MealTimingMetric
+		value  func(MealTimingDay) *float64
+		avg    **float64
+	}{
+		{MealTimingEatingWindow, func(d MealTimingDay) *float64 { return d.EatingWindowHours }, &rollup.AvgEatingWindowHours},
+		{MealTimingLastMealToBed, func(d MealTimingDay) *float64 { return d.LastMealToBedHours }, &rollup.AvgLastMealToBedHours},
+		{MealTimingPreWorkoutGap, func(d MealTimingDay) *float64 { return intToFloat(d.PreWorkoutGapMin) }, &rollup.AvgPreWorkoutGapMin},
+		{MealTimingPostWorkoutGap, func(d MealTimingDay) *float64 { return intToFloat(d.PostWorkoutGapMin) }, &rollup.AvgPostWorkoutGapMin},
+	}
+	outcomes := []struct {
+		outcome MealTimingOutcome
+		value   func(MealTimingDay) *float64
+	}{
+		{MealTimingNextSleepQuality, func(d MealTimingDay) *float64 { return intToFloat(d.NextSleepQuality) }},
+		{MealTimingNextDayRPE, func(d MealTimingDay) *float64 { return d.NextDayRPE }},
+	}
+
+	for _, m := range measures {
+		var sum float64
+		var n int
+		for _, d := range rollup.Days {
+			if v := m.value(d); v != nil {
+				sum += *v
+				n++
+			}
+		}
+		if n > 0 {
+			avg := math.Round(sum/float64(n)*10) / 10
+			*m.avg = &avg
+		}
+
+		for _, o := range outcomes {
+			var xs, ys []float64
+			for _, d := range rollup.Days {
+				x, y := m.value(d), o.value(d)
+				if x != nil && y != nil {
+					xs = append(xs, *x)
+					ys = append(ys, *y)
+				}
+			}
+			if len(xs) < MealTimingMinPairs {
+				continue
+			}
+			if r := pearson(xs, ys); r != nil {
+				rollup.Correlations = append(rollup.Correlations, MealTimingCorrelation{
+					Metric: m.metric, Outcome: o.outcome, R: *r, Pairs: len(xs),
+				})
+			}
+		}
+	}
+	sort.SliceStable(rollup.Correlations, func(i, j int) bool {
+		return math.Abs(rollup.Correlations[i].R) > math.Abs(rollup.Correlations[j].R)
+	})
+	return rollup, nil
+}
+
+// buildMealTimingDay measures one day from its meal times (minutes of day).
+func buildMealTimingDay(date string, times []int, logsByDate map[string]DailyLog) MealTimingDay {
+	sort.Ints(times)
+	firstMeal, lastMeal := times[0], times[len(times)-1]
+	day := MealTimingDay{
+		Date:      date,
+		FirstMeal: formatMinuteOfDay(firstMeal),
+		LastMeal:  formatMinuteOfDay(lastMeal),
+	}
+	if len(times) > 1 {
+		hours := math.Round(float64(lastMeal-firstMeal)/60*10) / 10
+		day.EatingWindowHours = &hours
+	}
+
+	if session, start, ok := firstTimedSession(logsByDate[date].ActualSessions); ok {
+		sessionEnd := start + session.DurationMin
+		for i := len(times) - 1; i >= 0; i-- {
+			if times[i] <= start {
+				gap := start - times[i]
+				day.PreWorkoutGapMin = &gap
+				break
+			}
+		}
+		for _, t := range times {
+			if t >= sessionEnd {
+				gap := t - sessionEnd
+				day.PostWorkoutGapMin = &gap
+				break
+			}
+		}
+	}
+
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return day
+	}
+	next, ok := logsByDate[d.AddDate(0, 0, 1).Format("2006-01-02")]
+	if !ok {
+		return day
+	}
+	if next.Bedtime != nil {
+		if bed, ok := minuteOfDay(*next.Bedtime); ok {
+			// A bedtime earlier in the clock than the last meal is after midnight
+			gap := bed - lastMeal
+			if gap < 0 {
+				gap += 24 * 60
+			}
+			if gap <= MealTimingMaxBedGapHours*60 {
+				hours := math.Round(float64(gap)/60*10) / 10
+				day.LastMealToBedHours = &hours
+			}
+		}
+	}
+	if next.SleepQuality > 0 {
+		q := int(next.SleepQuality)
+		day.NextSleepQuality = &q
+	}
+	if rpe, ok := averageRPE(next.ActualSessions); ok {
+		rpe = math.Round(rpe*10) / 10
+		day.NextDayRPE = &rpe
+	}
+	return day
+}
+
+// firstTimedSession returns the earliest non-rest session logged with a
+// start time, and that time in minutes of day.
+func firstTimedSession(sessions []TrainingSession) (TrainingSession, int, bool) {
+	var first TrainingSession
+	firstStart, found := 0, false
+	for _, s := range sessions {
+		if s.Type == TrainingTypeRest {
+			continue
+		}
+		start, ok := minuteOfDay(s.StartTime)
+		if !ok {
+			continue
+		}
+		if !found || start < firstStart {
+			first, firstStart, found = s, start, true
+		}
+	}
+	return first, firstStart, found
+}
+
+// minuteOfDay parses an HH:MM time into minutes since midnight.
+func minuteOfDay(hhmm string) (int, bool) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatMinuteOfDay(minute int) string {
+	return time.Date(0, 1, 1, minute/60, minute%60, 0, 0, time.UTC).Format("15:04")
+}
+
+func intToFloat(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Meal timing pairs each day with the next log's bedtime,
+// sleep and training, and bedtimes wrap past midnight; tests pin the gap
+// arithmetic, the day pairing and which correlations are reported.
+type MealTimingSuite struct {
+	suite.Suite
+}
+
+func TestMealTimingSuite(t *testing.T) {
+	suite.Run(t, new(MealTimingSuite))
+}
+
+func (s *MealTimingSuite) TestValidateTimes() {
+	s.NoError(ValidateMealTime("07:30"))
+	s.ErrorIs(ValidateMealTime("7:30pm"), ErrInvalidMealTime)
+	s.NoError(ValidateBedtime("23:15"))
+	s.ErrorIs(ValidateBedtime("25:00"), ErrInvalidBedtime)
+}
+
+func (s *MealTimingSuite) TestDayMeasures() {
+	bedtime := "00:30"
+	entries := []MealEntry{
+		{Date: "2026-03-10", EatenAt: "20:00"},
+		{Date: "2026-03-10", EatenAt: "08:00"},
+		{Date: "2026-03-10", EatenAt: "16:30"},
+		{Date: "2026-03-10", EatenAt: "12:00"},
+	}
+	logs := []DailyLog{
+		{Date: "2026-03-10", ActualSessions: []TrainingSession{
+			{Type: TrainingTypeRest, StartTime: "06:00"},
+			{Type: TrainingTypeRun, DurationMin: 60, StartTime: "14:00"},
+		}},
+		{Date: "2026-03-11", Bedtime: &bedtime, SleepQuality: 72},
+	}
+
+	rollup, err := BuildMealTimingRollup("2026-03", entries, logs)
+	s.Require().NoError(err)
+	s.Require().Len(rollup.Days, 1)
+	day := rollup.Days[0]
+	s.Equal("08:00", day.FirstMeal)
+	s.Equal("20:00", day.LastMeal)
+	s.Equal(12.0, *day.EatingWindowHours)
+	s.Equal(4.5, *day.LastMealToBedHours, "bedtime after midnight")
+	s.Equal(120, *day.PreWorkoutGapMin, "rest sessions are skipped")
+	s.Equal(90, *day.PostWorkoutGapMin)
+	s.Equal(72, *day.NextSleepQuality)
+	s.Nil(day.NextDayRPE)
+}
+
+func (s *MealTimingSuite) TestSingleMealAndMissingNextDay() {
+	entries := []MealEntry{{Date: "2026-03-31", EatenAt: "13:00"}}
+
+	rollup, err := BuildMealTimingRollup("2026-03", entries, nil)
+	s.Require().NoError(err)
+	s.Require().Len(rollup.Days, 1)
+	s.Nil(rollup.Days[0].EatingWindowHours)
+	s.Nil(rollup.Days[0].LastMealToBedHours)
+	s.Nil(rollup.Days[0].NextSleepQuality)
+	s.Nil(rollup.AvgEatingWindowHours)
+}
+
+func (s *MealTimingSuite) TestEntriesOutsideMonthIgnored() {
+	entries := []MealEntry{
+		{Date: "2026-02-28", EatenAt: "09:00"},
+		{Date: "2026-04-01", EatenAt: "09:00"},
+		{Date: "2026-03-05", EatenAt: "bad"},
+	}
+	rollup, err := BuildMealTimingRollup("2026-03", entries, nil)
+	s.Require().NoError(err)
+	s.Equal(0, rollup.DaysTimed)
+	s.Empty(rollup.Correlations)
+}
+
+func (s *MealTimingSuite) TestLateMealsCorrelateWithWorseSleep() {
+	var entries []MealEntry
+	var logs []DailyLog
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for d := range 11 {
+		date := start.AddDate(0, 0, d).Format("2006-01-02")
+		bedtime := "23:00"
+		// The later the previous day's dinner, the worse the night
+		log := DailyLog{Date: date, Bedtime: &bedtime, SleepQuality: SleepQuality(90 - 5*((d+9)%10))}
+		logs = append(logs, log)
+		if d < 10 {
+			entries = append(entries,
+				MealEntry{Date: date, EatenAt: "08:00"},
+				MealEntry{Date: date, EatenAt: fmt.Sprintf("%02d:00", 18+d%5)},
+			)
+		}
+	}
+
+	rollup, err := BuildMealTimingRollup("2026-03", entries, logs)
+	s.Require().NoError(err)
+	s.Equal(10, rollup.DaysTimed)
+	s.Require().NotEmpty(rollup.Correlations)
+	strongest := rollup.Correlations[0]
+	s.Equal(10, strongest.Pairs)
+	s.Equal(MealTimingNextSleepQuality, strongest.Outcome)
+	s.Less(strongest.R, 0.0)
+	for _, c := range rollup.Correlations {
+		s.NotEqual(MealTimingPreWorkoutGap, c.Metric, "no timed sessions")
+	}
+}
+
+func (s *MealTimingSuite) TestInvalidMonth() {
+	_, err := BuildMealTimingRollup("March", nil, nil)
+	s.ErrorIs(err, ErrInvalidRollupMonth)
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// =============================================================================
+// MESOCYCLE DESIGNER
+// =============================================================================
+//
+// Builds a custom program from a handful of parameters instead of hand-entered
+// weeks. The weeks between deloads form a block; within a block volume and
+// intensity move from the focus's starting scales towards its peak following
+// the wave shape, and each block starts a little higher than the last. Deload
+// weeks drop volume and intensity. Day templates come from the focus and the
+// number of training days.
+
+// WaveShape is how volume and intensity move through a block.
+type WaveShape string
+
+const (
+	WaveShapeLinear     WaveShape = "linear"     // Rises steadily week to week
+	WaveShapeUndulating WaveShape = "undulating" // Light, medium, heavy weeks in turn
+	WaveShapeStep       WaveShape = "step"       // Holds for a few weeks, then steps up
+)
+
+// ParseWaveShape safely converts a string to WaveShape with validation.
+func ParseWaveShape(s string) (WaveShape, error) {
+	switch WaveShape(s) {
+	case WaveShapeLinear, WaveShapeUndulating, WaveShapeStep:
+		return WaveShape(s), nil
+	}
+	return "", ErrInvalidWaveShape
+}
+
+const (
+	// MinDeloadEvery and MaxDeloadEvery bound the deload cadence (0 = no deloads).
+	MinDeloadEvery = 3
+	MaxDeloadEvery = 8
+	// mesocycleBlockStep is how much each block raises its scales over the last.
+	mesocycleBlockStep = 0.05
+	// mesocycleMaxBlockLift caps the total lift across blocks.
+	mesocycleMaxBlockLift = 0.25
+	// Deload week scales.
+	mesocycleDeloadVolume    = 0.6
+	mesocycleDeloadIntensity = 0.8
+)
+
+// MesocycleInput contains the parameters of a generated program.
+type MesocycleInput struct {
+	Name                string
+	Description         string
+	DurationWeeks       int
+	TrainingDaysPerWeek int
+	Focus               string
+	Difficulty          string // Defaults to intermediate
+	DeloadEvery         int    // Every Nth week is a deload; 0 = none
+	WaveShape           string // Defaults to linear
+	Equipment           []string
+	Tags                []string
+}
+
+// waveRange is where a focus's volume and intensity start and peak in a block.
+type waveRange struct {
+	volumeStart, volumeEnd       float64
+	intensityStart, intensityEnd float64
+}
+
+var mesocycleWaves = map[ProgramFocus]waveRange{
+	ProgramFocusHypertrophy:  {0.9, 1.2, 0.7, 0.85},
+	ProgramFocusStrength:     {0.9, 0.8, 0.8, 1.0}, // Volume tapers as intensity peaks
+	ProgramFocusConditioning: {0.8, 1.1, 0.75, 0.95},
+	ProgramFocusGeneral:      {0.85, 1.05, 0.75, 0.9},
+}
+
+// dayTemplate is a generated program day before it is numbered.
+type dayTemplate struct {
+	label        string
+	trainingType TrainingType
+	durationMin  int
+	loadScore    float64
+}
+
+// GenerateMesocycle builds the program input for a mesocycle. The result is
+// validated like any other program input when the program is created.
+func GenerateMesocycle(input MesocycleInput) (TrainingProgramInput, error) {
+	focus, err := ParseProgramFocus(input.Focus)
+	if err != nil {
+		return TrainingProgramInput{}, err
+	}
+	if input.DurationWeeks < MinProgramDurationWeeks || input.DurationWeeks > MaxProgramDurationWeeks {
+		return TrainingProgramInput{}, ErrInvalidProgramDuration
+	}
+	if input.TrainingDaysPerWeek < MinTrainingDaysPerWeek || input.TrainingDaysPerWeek > MaxTrainingDaysPerWeek {
+		return TrainingProgramInput{}, ErrInvalidTrainingDaysPerWeek
+	}
+	if input.DeloadEvery != 0 && (input.DeloadEvery < MinDeloadEvery || input.DeloadEvery > MaxDeloadEvery) {
+		return TrainingProgramInput{}, ErrInvalidDeloadCadence
+	}
+	shape := WaveShapeLinear
+	if input.WaveShape != "" {
+		if shape, err = ParseWaveShape(input.WaveShape); err != nil {
+			return TrainingProgramInput{}, err
+		}
+	}
+	difficulty := input.Difficulty
+	if difficulty == "" {
+		difficulty = string(ProgramDifficultyIntermediate)
+	}
+	name := input.Name
+	if name == "" {
+		name = fmt.Sprintf("%d-Week %s Mesocycle", input.DurationWeeks, focusTitle(focus))
+	}
+
+	days := mesocycleDays(focus, input.TrainingDaysPerWeek)
+	weeks := make([]ProgramWeekInput, 0, input.DurationWeeks)
+	// Weeks repeat every period: a block of loading weeks, then the deload
+	period, blockLen := input.DurationWeeks, input.DurationWeeks
+	if input.DeloadEvery > 0 {
+		period, blockLen = input.DeloadEvery, input.DeloadEvery-1
+	}
+	wave := mesocycleWaves[focus]
+	for w := 1; w <= input.DurationWeeks; w++ {
+		week := ProgramWeekInput{WeekNumber: w, Days: days}
+		block, pos := (w-1)/period, (w-1)%period
+		if input.DeloadEvery > 0 && pos == blockLen {
+			week.IsDeload = true
+			week.Label = fmt.Sprintf("Deload Week %d", block+1)
+			week.VolumeScale = mesocycleDeloadVolume
+			week.IntensityScale = mesocycleDeloadIntensity
+			weeks = append(weeks, week)
+			continue
+		}
+
+		// The last block may be cut short by the program's end
+		n := min(blockLen, input.DurationWeeks-block*period)
+		t := waveProgress(shape, pos, n)
+		lift := math.Min(float64(block)*mesocycleBlockStep, mesocycleMaxBlockLift)
+
+		week.Label = fmt.Sprintf("Block %d - Week %d", block+1, pos+1)
+		week.VolumeScale = waveScale(wave.volumeStart, wave.volumeEnd, t, lift, MinVolumeScale, MaxVolumeScale)
+		week.IntensityScale = waveScale(wave.intensityStart, wave.intensityEnd, t, lift, MinIntensityScale, MaxIntensityScale)
+		weeks = append(weeks, week)
+	}
+
+	return TrainingProgramInput{
+		Name:                name,
+		Description:         input.Description,
+		DurationWeeks:       input.DurationWeeks,
+		TrainingDaysPerWeek: input.TrainingDaysPerWeek,
+		Difficulty:          difficulty,
+		Focus:               string(focus),
+		Equipment:           input.Equipment,
+		Tags:                input.Tags,
+		Weeks:               weeks,
+	}, nil
+}
+
+// waveProgress returns how far (0-1) week pos of an n-week block is towards its peak.
+func waveProgress(shape WaveShape, pos, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	switch shape {
+	case WaveShapeUndulating:
+		return []float64{0, 0.5, 1}[pos%3]
+	case WaveShapeStep:
+		return math.Floor(float64(pos)*3/float64(n)) / 2
+	default:
+		return float64(pos) / float64(n-1)
+	}
+}
+
+// waveScale interpolates from start to end, adds the block lift and clamps to
+// the week's limits, rounded to two decimals.
+func waveScale(start, end, t, lift, lo, hi float64) float64 {
+	v := start + (end-start)*t + lift
+	return math.Round(math.Max(lo, math.Min(v, hi))*100) / 100
+}
+
+// mesocycleDays returns the numbered day templates for a week.
+func mesocycleDays(focus ProgramFocus, daysPerWeek int) []ProgramDayInput {
+	templates := mesocycleDayTemplates(focus, daysPerWeek)
+	days := make([]ProgramDayInput, len(templates))
+	for i, t := range templates {
+		nutrition := DayTypePerformance
+		if t.loadScore <= 2 {
+			nutrition = DayTypeFatburner
+		}
+		days[i] = ProgramDayInput{
+			DayNumber:    i + 1,
+			Label:        t.label,
+			TrainingType: string(t.trainingType),
+			DurationMin:  t.durationMin,
+			LoadScore:    t.loadScore,
+			NutritionDay: string(nutrition),
+		}
+	}
+	return days
+}
+
+// mesocycleDayTemplates picks the week's sessions for a focus. Lifting focuses
+// use the usual splits for the number of days; a seventh day is always active
+// recovery.
+func mesocycleDayTemplates(focus ProgramFocus, daysPerWeek int) []dayTemplate {
+	recovery := dayTemplate{"Active Recovery", TrainingTypeWalking, 30, 1.5}
+
+	var pool []dayTemplate
+	switch focus {
+	case ProgramFocusConditioning:
+		pool = []dayTemplate{
+			{"Intervals", TrainingTypeHIIT, 35, 4.0},
+			{"Tempo Run", TrainingTypeRun, 40, 3.0},
+			{"Long Aerobic", TrainingTypeCycle, 60, 2.5},
+			{"Row Intervals", TrainingTypeRow, 35, 3.5},
+		}
+	case ProgramFocusGeneral:
+		pool = []dayTemplate{
+			{"Full Body Strength", TrainingTypeStrength, 50, 3.0},
+			{"Conditioning", TrainingTypeHIIT, 35, 3.5},
+			{"Skill & Mobility", TrainingTypeGMB, 40, 2.5},
+		}
+	default:
+		duration, load := 60, 3.5
+		if focus == ProgramFocusStrength {
+			load = 4.0
+		}
+		labels := map[int][]string{
+			1: {"Full Body"},
+			2: {"Upper", "Lower"},
+			3: {"Full Body A", "Full Body B", "Full Body C"},
+			4: {"Upper A", "Lower A", "Upper B", "Lower B"},
+			5: {"Push", "Pull", "Legs", "Upper", "Lower"},
+		}[min(daysPerWeek, 5)]
+		if daysPerWeek >= 6 {
+			labels = []string{"Push A", "Pull A", "Legs A", "Push B", "Pull B", "Legs B"}
+		}
+		for _, label := range labels {
+			pool = append(pool, dayTemplate{label, TrainingTypeStrength, duration, load})
+		}
+	}
+
+	templates := make([]dayTemplate, 0, daysPerWeek)
+	for i := 0; i < daysPerWeek; i++ {
+		if i == 6 {
+			templates = append(templates, recovery)
+			continue
+		}
+		t := pool[i%len(pool)]
+		if i >= len(pool) {
+			t.label = fmt.Sprintf("%s %d", t.label, i/len(pool)+1)
+		}
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+// focusTitle returns a focus as a title-cased word for generated names.
+func focusTitle(f ProgramFocus) string {
+	s := string(f)
+	if s == "" {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The designer turns a few parameters into every week's scales
+// and days; tests pin the deload cadence, each wave shape, the block-to-block
+// lift, the day splits and that the result passes program validation.
+type MesocycleSuite struct {
+	suite.Suite
+}
+
+func TestMesocycleSuite(t *testing.T) {
+	suite.Run(t, new(MesocycleSuite))
+}
+
+func (s *MesocycleSuite) input() MesocycleInput {
+	return MesocycleInput{DurationWeeks: 8, TrainingDaysPerWeek: 4, Focus: "hypertrophy", DeloadEvery: 4}
+}
+
+func (s *MesocycleSuite) scales(weeks []ProgramWeekInput) (volume, intensity []float64) {
+	for _, w := range weeks {
+		volume = append(volume, w.VolumeScale)
+		intensity = append(intensity, w.IntensityScale)
+	}
+	return volume, intensity
+}
+
+func (s *MesocycleSuite) TestLinearWithDeloads() {
+	program, err := GenerateMesocycle(s.input())
+	s.Require().NoError(err)
+	s.Equal("8-Week Hypertrophy Mesocycle", program.Name)
+	s.Equal("intermediate", program.Difficulty)
+	s.Require().Len(program.Weeks, 8)
+
+	volume, intensity := s.scales(program.Weeks)
+	s.Equal([]float64{0.9, 1.05, 1.2, 0.6, 0.95, 1.1, 1.25, 0.6}, volume)
+	s.Equal([]float64{0.7, 0.77, 0.85, 0.8, 0.75, 0.83, 0.9, 0.8}, intensity)
+	s.True(program.Weeks[3].IsDeload)
+	s.Equal("Deload Week 1", program.Weeks[3].Label)
+	s.Equal("Block 2 - Week 3", program.Weeks[6].Label)
+	s.False(program.Weeks[6].IsDeload)
+
+	built, err := NewTrainingProgram(program, false, time.Now())
+	s.Require().NoError(err)
+	s.Len(built.Weeks[0].Days, 4)
+}
+
+func (s *MesocycleSuite) TestWaveShapes() {
+	in := s.input()
+	in.DurationWeeks, in.DeloadEvery = 6, 0
+
+	in.WaveShape = "undulating"
+	program, err := GenerateMesocycle(in)
+	s.Require().NoError(err)
+	volume, _ := s.scales(program.Weeks)
+	s.Equal([]float64{0.9, 1.05, 1.2, 0.9, 1.05, 1.2}, volume)
+
+	in.WaveShape = "step"
+	program, err = GenerateMesocycle(in)
+	s.Require().NoError(err)
+	volume, _ = s.scales(program.Weeks)
+	s.Equal([]float64{0.9, 0.9, 1.05, 1.05, 1.2, 1.2}, volume)
+
+	in.Focus = "strength"
+	in.WaveShape = "linear"
+	program, err = GenerateMesocycle(in)
+	s.Require().NoError(err)
+	volume, intensity := s.scales(program.Weeks)
+	s.Equal(0.8, volume[5], "strength tapers volume")
+	s.Equal(1.0, intensity[5])
+}
+
+func (s *MesocycleSuite) TestShortFinalBlock() {
+	in := s.input()
+	in.DurationWeeks = 6 // The second block is cut to two loading weeks
+	program, err := GenerateMesocycle(in)
+	s.Require().NoError(err)
+	volume, _ := s.scales(program.Weeks)
+	s.Equal([]float64{0.9, 1.05, 1.2, 0.6, 0.95, 1.25}, volume)
+}
+
+func (s *MesocycleSuite) TestDaySplits() {
+	days := mesocycleDays(ProgramFocusStrength, 4)
+	s.Equal("Upper A", days[0].Label)
+	s.Equal(4.0, days[3].LoadScore)
+
+	days = mesocycleDays(ProgramFocusHypertrophy, 7)
+	s.Require().Len(days, 7)
+	s.Equal("Legs B", days[5].Label)
+	s.Equal("Active Recovery", days[6].Label)
+	s.Equal(string(DayTypeFatburner), days[6].NutritionDay)
+
+	days = mesocycleDays(ProgramFocusGeneral, 5)
+	s.Equal("Full Body Strength 2", days[3].Label)
+	s.Equal(7, mesocycleDays(ProgramFocusConditioning, 7)[6].DayNumber)
+}
+
+func (s *MesocycleSuite) TestValidation() {
+	in := s.input()
+	in.DeloadEvery = 2
+	_, err := GenerateMesocycle(in)
+	s.ErrorIs(err, ErrInvalidDeloadCadence)
+
+	in = s.input()
+	in.WaveShape = "sine"
+	_, err = GenerateMesocycle(in)
+	s.ErrorIs(err, ErrInvalidWaveShape)
+
+	in = s.input()
+	in.DurationWeeks = 53
+	_, err = GenerateMesocycle(in)
+	s.ErrorIs(err, ErrInvalidProgramDuration)
+
+	in = s.input()
+	in.Focus = "powerlifting"
+	_, err = GenerateMesocycle(in)
+	s.ErrorIs(err, ErrInvalidProgramFocus)
+}
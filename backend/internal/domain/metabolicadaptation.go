@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =============================================================================
+// METABOLIC ADAPTATION DETECTOR
+// =============================================================================
+//
+// Losing weight lowers TDEE: a lighter body burns less. The formula TDEE
+// follows the weight down, so it is what weight loss alone predicts. The
+// adaptive TDEE is what intake and the weight trend say was actually burned.
+// Adaptive thermogenesis shows as the adaptive TDEE falling further than the
+// formula does.
+//
+// Each day is read as the ratio of adaptive to formula TDEE, which removes
+// the formula's fixed error for the person. The oldest weeks of the lookback
+// set the baseline ratio. A recent week's unexplained drop is its fall from
+// that ratio, in kcal of the week's formula TDEE. Adaptation is flagged when
+// each of the last AdaptationSustainedWeeks weeks is down by at least
+// AdaptationMinKcal and AdaptationMinPercent. Only adaptive days with usable
+// confidence count, and a week needs AdaptationMinDaysPerWeek of them.
+
+// Metabolic adaptation windows and thresholds.
+const (
+	AdaptationLookbackWeeks  = 8    // Weeks of logs the detector reads
+	AdaptationBaselineWeeks  = 3    // Oldest weeks of the lookback that set the baseline ratio
+	AdaptationSustainedWeeks = 3    // Most recent weeks that must all be down
+	AdaptationMinDaysPerWeek = 4    // Adaptive days needed to judge a week
+	AdaptationMinConfidence  = 0.3  // Adaptive confidence a day needs to count (same as the flux gate)
+	AdaptationMinKcal        = 150  // Unexplained drop (kcal/day) every recent week must reach
+	AdaptationMinPercent     = 0.05 // ...and as a fraction of the week's formula TDEE
+	AdaptationSevereKcal     = 300  // Average unexplained drop at which adaptation is severe
+	AdaptationSeverePercent  = 0.10
+	AdaptationNearGoalKg     = 2.0 // Within this of the goal weight, reverse instead of pausing
+	AdaptationRealertDays    = 14  // A new detection within this many days continues the last event
+)
+
+// AdaptationSeverity grades a detected adaptation.
+type AdaptationSeverity string
+
+const (
+	AdaptationModerate AdaptationSeverity = "moderate"
+	AdaptationSevere   AdaptationSeverity = "severe"
+)
+
+// MetabolicAdaptation is a detected stretch of TDEE downregulation beyond
+// what weight loss predicts, with the recommended response.
+type MetabolicAdaptation struct {
+	ID                  int64              `json:"id,omitempty"`
+	DetectedOn          string             `json:"detectedOn"` // YYYY-MM-DD
+	Severity            AdaptationSeverity `json:"severity"`
+	WeeksSustained      int                `json:"weeksSustained"`
+	BaselineRatio       float64            `json:"baselineRatio"`     // Adaptive / formula TDEE in the baseline weeks
+	RecentRatio         float64            `json:"recentRatio"`       // ...and in the sustained weeks
+	UnexplainedKcal     int                `json:"unexplainedKcal"`   // Average daily drop weight loss doesn't explain
+	ObservedDropKcal    int                `json:"observedDropKcal"`  // Adaptive TDEE, baseline minus recent
+	PredictedDropKcal   int                `json:"predictedDropKcal"` // Formula TDEE, baseline minus recent
+	WeightChangeKg      float64            `json:"weightChangeKg"`    // Recent minus baseline average weight
+	MaintenanceKcal     int                `json:"maintenanceKcal"`   // Recent adaptive TDEE, what maintenance is now
+	Recommendation      PlateauBreaker     `json:"recommendation"`
+	NotificationPending bool               `json:"notificationPending"`
+}
+
+// adaptationWeek aggregates a week's qualifying adaptive days.
+type adaptationWeek struct {
+	days       int
+	ratioSum   float64
+	formulaSum int
+	adaptSum   int
+	weightSum  float64
+}
+
+func (w adaptationWeek) ratio() float64   { return w.ratioSum / float64(w.days) }
+func (w adaptationWeek) formula() float64 { return float64(w.formulaSum) / float64(w.days) }
+
+// DetectMetabolicAdaptation looks for adaptation as of asOf. logs should
+// cover the AdaptationLookbackWeeks weeks ending asOf. A gain goal is never
+// flagged, since TDEE rising or falling in a surplus isn't the signal.
+// Returns nil when nothing is detected.
+func DetectMetabolicAdaptation(logs []DailyLog, goal Goal, goalWeightKg float64, asOf time.Time) *MetabolicAdaptation {
+	if goal == GoalGainWeight {
+		return nil
+	}
+
+	// Week 0 is the 7 days ending asOf
+	end := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	weeks := make([]adaptationWeek, AdaptationLookbackWeeks)
+	for _, l := range logs {
+		if l.TDEESourceUsed != TDEESourceAdaptive || l.TDEEConfidence < AdaptationMinConfidence ||
+			l.EstimatedTDEE <= 0 || l.FormulaTDEE <= 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", l.Date)
+		if err != nil {
+			continue
+		}
+		daysAgo := int(end.Sub(date).Hours() / 24)
+		if daysAgo < 0 || daysAgo >= 7*AdaptationLookbackWeeks {
+			continue
+		}
+		w := &weeks[daysAgo/7]
+		w.days++
+		w.ratioSum += float64(l.EstimatedTDEE) / float64(l.FormulaTDEE)
+		w.formulaSum += l.FormulaTDEE
+		w.adaptSum += l.EstimatedTDEE
+		w.weightSum += l.WeightKg
+	}
+
+	baseline := mergeAdaptationWeeks(weeks[AdaptationLookbackWeeks-AdaptationBaselineWeeks:])
+	if baseline.days < AdaptationMinDaysPerWeek {
+		return nil
+	}
+
+	var unexplainedSum float64
+	for _, w := range weeks[:AdaptationSustainedWeeks] {
+		if w.days < AdaptationMinDaysPerWeek {
+			return nil
+		}
+		unexplained := (baseline.ratio() - w.ratio()) * w.formula()
+		if unexplained < AdaptationMinKcal || unexplained < AdaptationMinPercent*w.formula() {
+			return nil
+		}
+		unexplainedSum += unexplained
+	}
+
+	recent := mergeAdaptationWeeks(weeks[:AdaptationSustainedWeeks])
+	avgUnexplained := unexplainedSum / AdaptationSustainedWeeks
+	recentWeight := recent.weightSum / float64(recent.days)
+	a := &MetabolicAdaptation{
+		DetectedOn:        asOf.Format("2006-01-02"),
+		Severity:          AdaptationModerate,
+		WeeksSustained:    AdaptationSustainedWeeks,
+		BaselineRatio:     math.Round(baseline.ratio()*1000) / 1000,
+		RecentRatio:       math.Round(recent.ratio()*1000) / 1000,
+		UnexplainedKcal:   int(math.Round(avgUnexplained)),
+		ObservedDropKcal:  (baseline.adaptSum / baseline.days) - (recent.adaptSum / recent.days),
+		PredictedDropKcal: (baseline.formulaSum / baseline.days) - (recent.formulaSum / recent.days),
+		WeightChangeKg:    math.Round((recentWeight-baseline.weightSum/float64(baseline.days))*10) / 10,
+		MaintenanceKcal:   recent.adaptSum / recent.days,
+	}
+	if avgUnexplained >= AdaptationSevereKcal || avgUnexplained >= AdaptationSeverePercent*recent.formula() {
+		a.Severity = AdaptationSevere
+	}
+
+	nearGoal := goal == GoalMaintain || (goalWeightKg > 0 && recentWeight-goalWeightKg <= AdaptationNearGoalKg)
+	a.Recommendation = adaptationRecommendation(a, nearGoal, int(math.Round(recent.formula())))
+	return a
+}
+
+func mergeAdaptationWeeks(weeks []adaptationWeek) adaptationWeek {
+	var merged adaptationWeek
+	for _, w := range weeks {
+		merged.days += w.days
+		merged.ratioSum += w.ratioSum
+		merged.formulaSum += w.formulaSum
+		merged.adaptSum += w.adaptSum
+		merged.weightSum += w.weightSum
+	}
+	return merged
+}
+
+// adaptationRecommendation picks the response: a reverse phase near the goal,
+// a diet break when severe, otherwise refeeds.
+func adaptationRecommendation(a *MetabolicAdaptation, nearGoal bool, formulaKcal int) PlateauBreaker {
+	rationale := fmt.Sprintf(
+		"Your TDEE has run about %d kcal/day below what your weight change predicts for %d weeks.",
+		a.UnexplainedKcal, a.WeeksSustained)
+	switch {
+	case nearGoal:
+		return PlateauBreaker{
+			Type:      PlateauBreakerReversePhase,
+			Title:     "Start a reverse phase",
+			Action:    fmt.Sprintf("Raise intake by about 100 kcal a week from %d kcal until you reach %d kcal.", a.MaintenanceKcal, formulaKcal),
+			Rationale: rationale + " You're close to your goal, so bring intake back up gradually and let expenditure recover with it.",
+		}
+	case a.Severity == AdaptationSevere:
+		return PlateauBreaker{
+			Type:      PlateauBreakerDietBreak,
+			Title:     "Take a two-week diet break",
+			Action:    fmt.Sprintf("Eat at maintenance, about %d kcal a day, for 14 days, then resume the deficit.", a.MaintenanceKcal),
+			Rationale: rationale + " Continuing the same deficit now buys less fat loss for the same effort.",
+		}
+	default:
+		return PlateauBreaker{
+			Type:      PlateauBreakerRefeed,
+			Title:     "Add refeed days",
+			Action:    "Make two non-consecutive days a week metabolize days for the next two weeks, with the extra calories from carbohydrates.",
+			Rationale: rationale + " Refeeds can ease the downregulation without stopping the cut.",
+		}
+	}
+}
+
+// AdaptationNotificationTitle is the pushed notification's title.
+func AdaptationNotificationTitle(a MetabolicAdaptation) string {
+	if a.Severity == AdaptationSevere {
+		return "Metabolic adaptation: act now"
+	}
+	return "Metabolic adaptation detected"
+}
+
+// AdaptationNotificationText is the pushed notification's body.
+func AdaptationNotificationText(a MetabolicAdaptation) string {
+	return fmt.Sprintf("%s\nRecommended: %s. %s",
+		a.Recommendation.Rationale, a.Recommendation.Title, a.Recommendation.Action)
+}
+
+// AdaptationDebriefRecommendation turns a detection into the debrief's top
+// recommendation.
+func AdaptationDebriefRecommendation(a MetabolicAdaptation) TacticalRecommendation {
+	return TacticalRecommendation{
+		Priority:  1,
+		Category:  "nutrition",
+		Summary:   fmt.Sprintf("Metabolic adaptation (%s): %s", a.Severity, a.Recommendation.Title),
+		Rationale: a.Recommendation.Rationale,
+		ActionItems: []string{
+			a.Recommendation.Action,
+			"Keep protein at target and training as planned",
+			"Keep weighing in daily so the next check can see the response",
+		},
+	}
+}
+
+// PrependRecommendation puts r first, keeping the debrief's three
+// recommendations.
+func PrependRecommendation(recs []TacticalRecommendation, r TacticalRecommendation) []TacticalRecommendation {
+	out := append([]TacticalRecommendation{r}, recs...)
+	if len(out) > 3 {
+		out = out[:3]
+	}
+	return out
+}
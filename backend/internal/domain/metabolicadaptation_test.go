@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The detector decides when to tell a dieter to stop cutting;
+// tests pin that weight loss alone never triggers it, that the drop must hold
+// for the sustained weeks, and which response each case gets.
+type MetabolicAdaptationSuite struct {
+	suite.Suite
+	asOf time.Time
+}
+
+func TestMetabolicAdaptationSuite(t *testing.T) {
+	suite.Run(t, new(MetabolicAdaptationSuite))
+}
+
+func (s *MetabolicAdaptationSuite) SetupTest() {
+	s.asOf = time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+}
+
+// cut builds 8 weeks of adaptive logs ending asOf. The formula TDEE falls
+// from 2500 to 2400 as weight drops; recentAdaptive is the adaptive TDEE in
+// the last three weeks, against 2400 before.
+func (s *MetabolicAdaptationSuite) cut(recentAdaptive int) []DailyLog {
+	var logs []DailyLog
+	for daysAgo := 7*AdaptationLookbackWeeks - 1; daysAgo >= 0; daysAgo-- {
+		l := DailyLog{
+			Date:           s.asOf.AddDate(0, 0, -daysAgo).Format("2006-01-02"),
+			WeightKg:       90 - float64(7*AdaptationLookbackWeeks-daysAgo)*0.07,
+			TDEESourceUsed: TDEESourceAdaptive,
+			TDEEConfidence: 0.7,
+			FormulaTDEE:    2500,
+			EstimatedTDEE:  2400,
+		}
+		if daysAgo < 7*AdaptationSustainedWeeks {
+			l.FormulaTDEE, l.EstimatedTDEE = 2400, recentAdaptive
+		}
+		logs = append(logs, l)
+	}
+	return logs
+}
+
+func (s *MetabolicAdaptationSuite) TestWeightLossAloneIsNotAdaptation() {
+	// Adaptive follows the formula down, ratio unchanged
+	s.Nil(DetectMetabolicAdaptation(s.cut(2304), GoalLoseWeight, 75, s.asOf))
+}
+
+func (s *MetabolicAdaptationSuite) TestModerateAdaptationGetsRefeeds() {
+	a := DetectMetabolicAdaptation(s.cut(2100), GoalLoseWeight, 75, s.asOf)
+	s.Require().NotNil(a)
+	s.Equal("2026-03-29", a.DetectedOn)
+	s.Equal(AdaptationModerate, a.Severity)
+	s.Equal(3, a.WeeksSustained)
+	s.Equal(0.96, a.BaselineRatio)
+	s.Equal(0.875, a.RecentRatio)
+	s.Equal(204, a.UnexplainedKcal)
+	s.Equal(300, a.ObservedDropKcal)
+	s.Equal(100, a.PredictedDropKcal, "what weight loss explains")
+	s.Equal(2100, a.MaintenanceKcal)
+	s.Less(a.WeightChangeKg, 0.0)
+	s.Equal(PlateauBreakerRefeed, a.Recommendation.Type)
+}
+
+func (s *MetabolicAdaptationSuite) TestSevereAdaptationGetsDietBreak() {
+	a := DetectMetabolicAdaptation(s.cut(1950), GoalLoseWeight, 75, s.asOf)
+	s.Require().NotNil(a)
+	s.Equal(AdaptationSevere, a.Severity)
+	s.Equal(PlateauBreakerDietBreak, a.Recommendation.Type)
+	s.Contains(a.Recommendation.Action, "about 1950 kcal")
+	s.Equal("Metabolic adaptation: act now", AdaptationNotificationTitle(*a))
+}
+
+func (s *MetabolicAdaptationSuite) TestNearGoalGetsReversePhase() {
+	a := DetectMetabolicAdaptation(s.cut(1950), GoalLoseWeight, 85, s.asOf)
+	s.Require().NotNil(a)
+	s.Equal(PlateauBreakerReversePhase, a.Recommendation.Type)
+	s.Contains(a.Recommendation.Action, "from 1950 kcal until you reach 2400 kcal")
+}
+
+func (s *MetabolicAdaptationSuite) TestDropMustBeSustained() {
+	logs := s.cut(2100)
+	// The oldest sustained week held its baseline ratio
+	for i := range logs {
+		if logs[i].Date <= s.asOf.AddDate(0, 0, -14).Format("2006-01-02") && logs[i].FormulaTDEE == 2400 {
+			logs[i].EstimatedTDEE = 2304
+		}
+	}
+	s.Nil(DetectMetabolicAdaptation(logs, GoalLoseWeight, 75, s.asOf))
+}
+
+func (s *MetabolicAdaptationSuite) TestNeedsConfidentAdaptiveDays() {
+	logs := s.cut(2100)
+	for i := len(logs) - 4; i < len(logs); i++ {
+		logs[i].TDEEConfidence = 0.2
+	}
+	s.Nil(DetectMetabolicAdaptation(logs, GoalLoseWeight, 75, s.asOf), "only 3 usable days in the latest week")
+
+	s.Nil(DetectMetabolicAdaptation(s.cut(1950), GoalGainWeight, 0, s.asOf), "never flagged in a surplus")
+}
+
+func (s *MetabolicAdaptationSuite) TestDebriefRecommendationGoesFirst() {
+	a := DetectMetabolicAdaptation(s.cut(2100), GoalLoseWeight, 75, s.asOf)
+	s.Require().NotNil(a)
+	recs := []TacticalRecommendation{{Priority: 1}, {Priority: 2}, {Priority: 3}}
+	out := PrependRecommendation(recs, AdaptationDebriefRecommendation(*a))
+	s.Require().Len(out, 3)
+	s.Equal("Metabolic adaptation (moderate): Add refeed days", out[0].Summary)
+	s.Equal(recs[1], out[2])
+}
@@ -0,0 +1,118 @@
+package domain
+
+import "sort"
+
+// =============================================================================
+// MONTHLY SUMMARY COMPUTATION
+// =============================================================================
+//
+// monthly_summaries holds per-activity session counts and calorie totals per
+// month. Garmin imports supply them for history recorded before Victus; for
+// months logged in Victus they are computed from the actual training sessions,
+// with calories estimated per session (see EstimateActiveCalories).
+//
+// Priority rules when both exist for a month and activity:
+//   - an imported row is never overwritten by a computed one,
+//   - a computed row is replaced when computed again, and removed once its
+//     sessions are gone,
+//   - an import over a computed row takes it over.
+
+const (
+	MonthlySummarySourceGarmin   = "garmin_import" // Garmin Connect monthly export
+	MonthlySummarySourceComputed = "computed"      // Aggregated from logged training sessions
+)
+
+// MonthlySummaryChanges is what applying a computation to monthly_summaries
+// writes and removes.
+type MonthlySummaryChanges struct {
+	Upserts []MonthlySummary // Computed rows to insert or replace
+	Removed []MonthlySummary // Computed rows whose sessions are gone
+	Skipped []MonthlySummary // Computed rows left out because an import owns the row
+}
+
+// MonthlySummaryComputation reports a computation run over a range of months.
+type MonthlySummaryComputation struct {
+	From    string // YYYY-MM
+	To      string // YYYY-MM
+	Upserts int
+	Removed int
+	Skipped int
+}
+
+// AggregateMonthlySummaries sums the per-session estimates of logged days into
+// computed monthly summaries per activity, ordered by month then activity.
+// Rest sessions are not counted.
+func AggregateMonthlySummaries(days []ActiveCalorieEstimate) []MonthlySummary {
+	type key struct {
+		month    string
+		activity TrainingType
+	}
+	totals := make(map[key]*MonthlySummary)
+	for _, day := range days {
+		if len(day.Date) < 7 {
+			continue
+		}
+		month := day.Date[:7]
+		for _, session := range day.Sessions {
+			if session.Type == TrainingTypeRest {
+				continue
+			}
+			k := key{month, session.Type}
+			summary, ok := totals[k]
+			if !ok {
+				summary = &MonthlySummary{
+					YearMonth:    month,
+					ActivityType: session.Type,
+					DataSource:   MonthlySummarySourceComputed,
+				}
+				totals[k] = summary
+			}
+			summary.SessionCount++
+			summary.TotalCalories += session.Kcal
+		}
+	}
+
+	summaries := make([]MonthlySummary, 0, len(totals))
+	for _, summary := range totals {
+		summary.ComputeAvgCalories()
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].YearMonth != summaries[j].YearMonth {
+			return summaries[i].YearMonth < summaries[j].YearMonth
+		}
+		return summaries[i].ActivityType < summaries[j].ActivityType
+	})
+	return summaries
+}
+
+// MergeMonthlySummaries applies the priority rules to freshly computed
+// summaries and the rows already stored for the same months.
+func MergeMonthlySummaries(existing, computed []MonthlySummary) MonthlySummaryChanges {
+	type key struct {
+		month    string
+		activity TrainingType
+	}
+	stored := make(map[key]MonthlySummary, len(existing))
+	for _, row := range existing {
+		stored[key{row.YearMonth, row.ActivityType}] = row
+	}
+
+	var changes MonthlySummaryChanges
+	seen := make(map[key]bool, len(computed))
+	for _, summary := range computed {
+		k := key{summary.YearMonth, summary.ActivityType}
+		seen[k] = true
+		if row, ok := stored[k]; ok && row.DataSource != MonthlySummarySourceComputed {
+			changes.Skipped = append(changes.Skipped, summary)
+			continue
+		}
+		changes.Upserts = append(changes.Upserts, summary)
+	}
+	for _, row := range existing {
+		if row.DataSource == MonthlySummarySourceComputed && !seen[key{row.YearMonth, row.ActivityType}] {
+			changes.Removed = append(changes.Removed, row)
+		}
+	}
+	return changes
+}
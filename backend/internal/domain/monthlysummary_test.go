@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Computed monthly summaries share a table with Garmin imports;
+// tests pin the per-activity aggregation and that imports keep priority while
+// stale computed rows are removed.
+type MonthlySummarySuite struct {
+	suite.Suite
+}
+
+func TestMonthlySummarySuite(t *testing.T) {
+	suite.Run(t, new(MonthlySummarySuite))
+}
+
+func (s *MonthlySummarySuite) TestAggregateGroupsByMonthAndActivity() {
+	days := []ActiveCalorieEstimate{
+		{Date: "2026-01-30", Sessions: []SessionCalorieEstimate{
+			{Type: TrainingTypeRun, Kcal: 400},
+			{Type: TrainingTypeStrength, Kcal: 250},
+		}},
+		{Date: "2026-01-31", Sessions: []SessionCalorieEstimate{
+			{Type: TrainingTypeRun, Kcal: 301},
+			{Type: TrainingTypeRest},
+		}},
+		{Date: "2026-02-01", Sessions: []SessionCalorieEstimate{
+			{Type: TrainingTypeRun, Kcal: 350},
+		}},
+	}
+
+	summaries := AggregateMonthlySummaries(days)
+	s.Require().Len(summaries, 3, "rest sessions are not counted")
+
+	s.Equal("2026-01", summaries[0].YearMonth)
+	s.Equal(TrainingTypeRun, summaries[0].ActivityType)
+	s.Equal(2, summaries[0].SessionCount)
+	s.Equal(701, summaries[0].TotalCalories)
+	s.Equal(350, summaries[0].AvgCaloriesPerSession)
+	s.Equal(MonthlySummarySourceComputed, summaries[0].DataSource)
+
+	s.Equal(TrainingTypeStrength, summaries[1].ActivityType)
+	s.Equal(1, summaries[1].SessionCount)
+
+	s.Equal("2026-02", summaries[2].YearMonth)
+	s.Equal(350, summaries[2].TotalCalories)
+}
+
+func (s *MonthlySummarySuite) TestMergeImportsKeepPriority() {
+	existing := []MonthlySummary{
+		{YearMonth: "2026-01", ActivityType: TrainingTypeRun, SessionCount: 9, DataSource: MonthlySummarySourceGarmin},
+		{YearMonth: "2026-01", ActivityType: TrainingTypeStrength, SessionCount: 3, DataSource: MonthlySummarySourceComputed},
+		{YearMonth: "2026-01", ActivityType: TrainingTypeCycle, SessionCount: 2, DataSource: MonthlySummarySourceComputed},
+		{YearMonth: "2026-01", ActivityType: TrainingTypeWalking, SessionCount: 5, DataSource: MonthlySummarySourceGarmin},
+	}
+	computed := []MonthlySummary{
+		{YearMonth: "2026-01", ActivityType: TrainingTypeRun, SessionCount: 8, DataSource: MonthlySummarySourceComputed},
+		{YearMonth: "2026-01", ActivityType: TrainingTypeStrength, SessionCount: 4, DataSource: MonthlySummarySourceComputed},
+		{YearMonth: "2026-01", ActivityType: TrainingTypeGMB, SessionCount: 1, DataSource: MonthlySummarySourceComputed},
+	}
+
+	changes := MergeMonthlySummaries(existing, computed)
+
+	s.Require().Len(changes.Upserts, 2)
+	s.Equal(TrainingTypeStrength, changes.Upserts[0].ActivityType, "computed rows are replaced")
+	s.Equal(4, changes.Upserts[0].SessionCount)
+	s.Equal(TrainingTypeGMB, changes.Upserts[1].ActivityType, "activities imports don't cover are filled")
+
+	s.Require().Len(changes.Skipped, 1)
+	s.Equal(TrainingTypeRun, changes.Skipped[0].ActivityType, "imported rows are never overwritten")
+
+	s.Require().Len(changes.Removed, 1, "imported walking without sessions stays")
+	s.Equal(TrainingTypeCycle, changes.Removed[0].ActivityType)
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // MovementCategory represents the primary movement pattern.
 type MovementCategory string
@@ -37,6 +40,16 @@ type Movement struct {
 	PrimaryLoad   string             `json:"primaryLoad"`
 	JointStress   map[string]float64 `json:"jointStress"`
 	ProgressionID string             `json:"progressionId"`
+	Equipment     []EquipmentType    `json:"equipment"` // Required equipment; empty means bodyweight only
+}
+
+// MovementTagUnilateral tags movements worked one side at a time. Sides
+// worked unevenly give the session's fatigue a left/right split.
+const MovementTagUnilateral = "unilateral"
+
+// IsUnilateral reports whether a movement is worked one side at a time.
+func (m Movement) IsUnilateral() bool {
+	return slices.Contains(m.Tags, MovementTagUnilateral)
 }
 
 // UserMovementProgress tracks a user's progression for a specific movement.
@@ -54,11 +67,15 @@ const ProgressionThreshold = 3
 const MaxMovementDifficulty = 10
 
 // MovementProgressionInput captures a session completion for progression calculation.
+// Load, hold and circuit time are optional and feed the personal record registry.
 type MovementProgressionInput struct {
-	CompletedReps  int  `json:"completedReps"`
-	TargetReps     int  `json:"targetReps"`
-	RPE            int  `json:"rpe"`
-	HadFormIssue   bool `json:"hadFormIssue"`
+	CompletedReps int     `json:"completedReps"`
+	TargetReps    int     `json:"targetReps"`
+	RPE           int     `json:"rpe"`
+	HadFormIssue  bool    `json:"hadFormIssue"`
+	LoadKg        float64 `json:"loadKg,omitempty"`     // Load moved, e.g. a weighted pull-up
+	HoldSec       int     `json:"holdSec,omitempty"`    // Longest hold in the set
+	CircuitSec    int     `json:"circuitSec,omitempty"` // Time to complete the circuit
 }
 
 // CalculateMovementProgression determines if a movement should progress in difficulty.
@@ -175,9 +192,10 @@ type FormCorrectionRequest struct {
 
 // FormCorrectionResult is the output from Ollama form analysis.
 type FormCorrectionResult struct {
-	MechanicalError string  `json:"mechanicalError"`
-	TacticalCue     string  `json:"tacticalCue"`
-	Regression      *string `json:"regression,omitempty"`
+	MechanicalError    string  `json:"mechanicalError"`
+	TacticalCue        string  `json:"tacticalCue"`
+	Regression         *string `json:"regression,omitempty"`
+	StalledProgression bool    `json:"stalledProgression,omitempty"` // Set when usage analytics detect a stall
 }
 
 // SeedMovements returns the initial movement taxonomy from the PRD.
@@ -188,18 +206,18 @@ func SeedMovements() []Movement {
 		{ID: "gmb_frogger", Name: "Frogger", Category: MovementCategoryLocomotion, Tags: []string{"GMB"}, Difficulty: 4, PrimaryLoad: "Wrist/Knee", JointStress: map[string]float64{"wrist": 0.8, "knee": 0.4}, ProgressionID: "loco_03"},
 		{ID: "cali_pushup_knees", Name: "Knee Push-ups", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 2, PrimaryLoad: "Chest/Triceps", JointStress: map[string]float64{"wrist": 0.4, "elbow": 0.3}, ProgressionID: "push_horiz_01"},
 		{ID: "cali_pushup_std", Name: "Standard Push-up", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 4, PrimaryLoad: "Chest/Triceps", JointStress: map[string]float64{"wrist": 0.6, "elbow": 0.4}, ProgressionID: "push_horiz_02"},
-		{ID: "cali_dips_bench", Name: "Bench Dips", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 3, PrimaryLoad: "Triceps/Shoulder", JointStress: map[string]float64{"shoulder": 0.7, "elbow": 0.5}, ProgressionID: "push_vert_01"},
-		{ID: "cali_dips_pbar", Name: "Parallel Bar Dips", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 6, PrimaryLoad: "Triceps/Chest", JointStress: map[string]float64{"shoulder": 0.8, "elbow": 0.6}, ProgressionID: "push_vert_02"},
-		{ID: "cali_pullup_neg", Name: "Negative Pull-ups", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 4, PrimaryLoad: "Lats/Biceps", JointStress: map[string]float64{"elbow": 0.6, "shoulder": 0.4}, ProgressionID: "pull_vert_01"},
-		{ID: "cali_pullup_std", Name: "Standard Pull-up", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 6, PrimaryLoad: "Lats/Biceps", JointStress: map[string]float64{"elbow": 0.5, "shoulder": 0.4}, ProgressionID: "pull_vert_02"},
-		{ID: "cali_rows_inv", Name: "Inverted Rows", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 3, PrimaryLoad: "Upper Back", JointStress: map[string]float64{"elbow": 0.3, "shoulder": 0.2}, ProgressionID: "pull_horiz_01"},
-		{ID: "cali_rows_arch", Name: "Archer Rows", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 7, PrimaryLoad: "Upper Back", JointStress: map[string]float64{"elbow": 0.7, "shoulder": 0.6}, ProgressionID: "pull_horiz_02"},
+		{ID: "cali_dips_bench", Name: "Bench Dips", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 3, PrimaryLoad: "Triceps/Shoulder", JointStress: map[string]float64{"shoulder": 0.7, "elbow": 0.5}, ProgressionID: "push_vert_01", Equipment: []EquipmentType{EquipmentTypeBench}},
+		{ID: "cali_dips_pbar", Name: "Parallel Bar Dips", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 6, PrimaryLoad: "Triceps/Chest", JointStress: map[string]float64{"shoulder": 0.8, "elbow": 0.6}, ProgressionID: "push_vert_02", Equipment: []EquipmentType{EquipmentTypeDipBars}},
+		{ID: "cali_pullup_neg", Name: "Negative Pull-ups", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 4, PrimaryLoad: "Lats/Biceps", JointStress: map[string]float64{"elbow": 0.6, "shoulder": 0.4}, ProgressionID: "pull_vert_01", Equipment: []EquipmentType{EquipmentTypePullupBar}},
+		{ID: "cali_pullup_std", Name: "Standard Pull-up", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 6, PrimaryLoad: "Lats/Biceps", JointStress: map[string]float64{"elbow": 0.5, "shoulder": 0.4}, ProgressionID: "pull_vert_02", Equipment: []EquipmentType{EquipmentTypePullupBar}},
+		{ID: "cali_rows_inv", Name: "Inverted Rows", Category: MovementCategoryPull, Tags: []string{"CaliMove"}, Difficulty: 3, PrimaryLoad: "Upper Back", JointStress: map[string]float64{"elbow": 0.3, "shoulder": 0.2}, ProgressionID: "pull_horiz_01", Equipment: []EquipmentType{EquipmentTypePullupBar}},
+		{ID: "cali_rows_arch", Name: "Archer Rows", Category: MovementCategoryPull, Tags: []string{"CaliMove", "unilateral"}, Difficulty: 7, PrimaryLoad: "Upper Back", JointStress: map[string]float64{"elbow": 0.7, "shoulder": 0.6}, ProgressionID: "pull_horiz_02", Equipment: []EquipmentType{EquipmentTypePullupBar}},
 		{ID: "cali_squat_air", Name: "Air Squat", Category: MovementCategoryLegs, Tags: []string{"CaliMove"}, Difficulty: 2, PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.3, "ankle": 0.2}, ProgressionID: "legs_01"},
-		{ID: "cali_squat_pistol", Name: "Pistol Squat", Category: MovementCategoryLegs, Tags: []string{"CaliMove"}, Difficulty: 8, PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.8, "ankle": 0.7}, ProgressionID: "legs_02"},
-		{ID: "cali_lunge_std", Name: "Reverse Lunge", Category: MovementCategoryLegs, Tags: []string{"CaliMove"}, Difficulty: 3, PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.4, "hip": 0.2}, ProgressionID: "legs_03"},
+		{ID: "cali_squat_pistol", Name: "Pistol Squat", Category: MovementCategoryLegs, Tags: []string{"CaliMove", "unilateral"}, Difficulty: 8, PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.8, "ankle": 0.7}, ProgressionID: "legs_02"},
+		{ID: "cali_lunge_std", Name: "Reverse Lunge", Category: MovementCategoryLegs, Tags: []string{"CaliMove", "unilateral"}, Difficulty: 3, PrimaryLoad: "Quads/Glutes", JointStress: map[string]float64{"knee": 0.4, "hip": 0.2}, ProgressionID: "legs_03"},
 		{ID: "cali_plank_elbow", Name: "Elbow Plank", Category: MovementCategoryCore, Tags: []string{"CaliMove"}, Difficulty: 2, PrimaryLoad: "Core", JointStress: map[string]float64{"lower_back": 0.4}, ProgressionID: "core_01"},
 		{ID: "cali_hollow_body", Name: "Hollow Body Hold", Category: MovementCategoryCore, Tags: []string{"CaliMove"}, Difficulty: 5, PrimaryLoad: "Core", JointStress: map[string]float64{"lower_back": 0.6}, ProgressionID: "core_02"},
-		{ID: "cali_leg_raises", Name: "Hanging Leg Raises", Category: MovementCategoryCore, Tags: []string{"CaliMove"}, Difficulty: 7, PrimaryLoad: "Core/Hip Flexors", JointStress: map[string]float64{"shoulder": 0.5, "lower_back": 0.4}, ProgressionID: "core_03"},
+		{ID: "cali_leg_raises", Name: "Hanging Leg Raises", Category: MovementCategoryCore, Tags: []string{"CaliMove"}, Difficulty: 7, PrimaryLoad: "Core/Hip Flexors", JointStress: map[string]float64{"shoulder": 0.5, "lower_back": 0.4}, ProgressionID: "core_03", Equipment: []EquipmentType{EquipmentTypePullupBar}},
 		{ID: "cali_lsit_floor", Name: "Floor L-Sit", Category: MovementCategoryCore, Tags: []string{"CaliMove"}, Difficulty: 8, PrimaryLoad: "Core/Triceps", JointStress: map[string]float64{"wrist": 0.9, "elbow": 0.4}, ProgressionID: "core_04"},
 		{ID: "cali_pike_press", Name: "Pike Push-up", Category: MovementCategoryPush, Tags: []string{"CaliMove"}, Difficulty: 6, PrimaryLoad: "Shoulders", JointStress: map[string]float64{"shoulder": 0.7, "wrist": 0.7}, ProgressionID: "push_ovh_01"},
 		{ID: "cali_handstand_wall", Name: "Wall Handstand", Category: MovementCategorySkill, Tags: []string{"CaliMove"}, Difficulty: 7, PrimaryLoad: "Shoulders/Core", JointStress: map[string]float64{"wrist": 0.9, "shoulder": 0.6}, ProgressionID: "skill_01"},
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MovementStallWindow is the number of consecutive sessions at the same difficulty
+// after which a movement is considered stalled if success stays low.
+const MovementStallWindow = 6
+
+// MovementStallSuccessRate is the success rate below which a stall window counts as stalled.
+const MovementStallSuccessRate = 0.5
+
+// MostUsedMovementsLimit caps the "most used" list in movement analytics.
+const MostUsedMovementsLimit = 5
+
+// MovementSessionRecord is a single logged completion of a movement.
+type MovementSessionRecord struct {
+	MovementID    string    `json:"movementId"`
+	Difficulty    int       `json:"difficulty"`
+	CompletedReps int       `json:"completedReps"`
+	TargetReps    int       `json:"targetReps"`
+	RPE           int       `json:"rpe"`
+	HadFormIssue  bool      `json:"hadFormIssue"`
+	Successful    bool      `json:"successful"`
+	PerformedAt   time.Time `json:"performedAt"`
+}
+
+// MovementUsage summarizes how a movement has been used across logged sessions.
+type MovementUsage struct {
+	MovementID      string           `json:"movementId"`
+	Name            string           `json:"name"`
+	Category        MovementCategory `json:"category"`
+	SessionCount    int              `json:"sessionCount"`
+	SuccessCount    int              `json:"successCount"`
+	SuccessRate     float64          `json:"successRate"`
+	LastPerformedAt *time.Time       `json:"lastPerformedAt,omitempty"`
+	IsStalled       bool             `json:"isStalled"`
+	Regression      *Movement        `json:"regression,omitempty"`
+}
+
+// MovementAnalytics is the library-wide usage report.
+type MovementAnalytics struct {
+	Usage                    []MovementUsage    `json:"usage"`
+	MostUsed                 []MovementUsage    `json:"mostUsed"`
+	Stalled                  []MovementUsage    `json:"stalled"`
+	NeverAttemptedCategories []MovementCategory `json:"neverAttemptedCategories"`
+}
+
+// IsSuccessfulMovementSession reports whether a completion counts toward progression:
+// clean form, RPE at or below 8, and target reps met.
+func IsSuccessfulMovementSession(input MovementProgressionInput) bool {
+	if input.HadFormIssue || input.RPE > 8 {
+		return false
+	}
+	return input.TargetReps > 0 && input.CompletedReps >= input.TargetReps
+}
+
+// IsMovementStalled checks the most recent sessions (ordered oldest first) for a stall:
+// at least MovementStallWindow consecutive sessions at the latest difficulty with a
+// success rate below MovementStallSuccessRate.
+func IsMovementStalled(records []MovementSessionRecord) bool {
+	if len(records) < MovementStallWindow {
+		return false
+	}
+
+	latest := records[len(records)-1].Difficulty
+	window := records[len(records)-MovementStallWindow:]
+	successes := 0
+	for _, r := range window {
+		if r.Difficulty != latest {
+			return false
+		}
+		if r.Successful {
+			successes++
+		}
+	}
+	return float64(successes)/float64(len(window)) < MovementStallSuccessRate
+}
+
+// SuggestMovementRegression returns the hardest movement in the same category that is
+// easier than the given one, or nil if none exists.
+func SuggestMovementRegression(movement Movement, library []Movement) *Movement {
+	var best *Movement
+	for i := range library {
+		candidate := library[i]
+		if candidate.ID == movement.ID || candidate.Category != movement.Category {
+			continue
+		}
+		if candidate.Difficulty >= movement.Difficulty {
+			continue
+		}
+		if best == nil || candidate.Difficulty > best.Difficulty {
+			best = &library[i]
+		}
+	}
+	return best
+}
+
+// BuildMovementAnalytics aggregates session records into per-movement usage, the most
+// used movements, stalled progressions, and categories that have never been attempted.
+func BuildMovementAnalytics(movements []Movement, records []MovementSessionRecord) MovementAnalytics {
+	byMovement := make(map[string][]MovementSessionRecord)
+	for _, r := range records {
+		byMovement[r.MovementID] = append(byMovement[r.MovementID], r)
+	}
+
+	attemptedCategories := make(map[MovementCategory]bool)
+	usage := make([]MovementUsage, 0, len(byMovement))
+	for _, m := range movements {
+		history := byMovement[m.ID]
+		if len(history) == 0 {
+			continue
+		}
+		attemptedCategories[m.Category] = true
+
+		sort.Slice(history, func(i, j int) bool {
+			return history[i].PerformedAt.Before(history[j].PerformedAt)
+		})
+
+		successes := 0
+		for _, r := range history {
+			if r.Successful {
+				successes++
+			}
+		}
+		last := history[len(history)-1].PerformedAt
+
+		u := MovementUsage{
+			MovementID:      m.ID,
+			Name:            m.Name,
+			Category:        m.Category,
+			SessionCount:    len(history),
+			SuccessCount:    successes,
+			SuccessRate:     math.Round(float64(successes)/float64(len(history))*100) / 100,
+			LastPerformedAt: &last,
+			IsStalled:       IsMovementStalled(history),
+		}
+		if u.IsStalled {
+			u.Regression = SuggestMovementRegression(m, movements)
+		}
+		usage = append(usage, u)
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].SessionCount > usage[j].SessionCount
+	})
+
+	mostUsed := usage
+	if len(mostUsed) > MostUsedMovementsLimit {
+		mostUsed = mostUsed[:MostUsedMovementsLimit]
+	}
+
+	stalled := make([]MovementUsage, 0)
+	for _, u := range usage {
+		if u.IsStalled {
+			stalled = append(stalled, u)
+		}
+	}
+
+	neverAttempted := make([]MovementCategory, 0)
+	for _, c := range []MovementCategory{
+		MovementCategoryLocomotion, MovementCategoryPush, MovementCategoryPull,
+		MovementCategoryLegs, MovementCategoryCore, MovementCategorySkill, MovementCategoryPower,
+	} {
+		if !attemptedCategories[c] {
+			neverAttempted = append(neverAttempted, c)
+		}
+	}
+
+	return MovementAnalytics{
+		Usage:                    usage,
+		MostUsed:                 mostUsed,
+		Stalled:                  stalled,
+		NeverAttemptedCategories: neverAttempted,
+	}
+}
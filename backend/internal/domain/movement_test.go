@@ -141,3 +141,98 @@ func TestSeedMovements_Count(t *testing.T) {
 		}
 	}
 }
+
+func TestSeedMovements_Unilateral(t *testing.T) {
+	var unilateral []string
+	for _, m := range SeedMovements() {
+		if m.IsUnilateral() {
+			unilateral = append(unilateral, m.ID)
+		}
+	}
+	want := []string{"cali_rows_arch", "cali_squat_pistol", "cali_lunge_std"}
+	if len(unilateral) != len(want) {
+		t.Fatalf("unilateral = %v, want %v", unilateral, want)
+	}
+	for i, id := range want {
+		if unilateral[i] != id {
+			t.Errorf("unilateral[%d] = %s, want %s", i, unilateral[i], id)
+		}
+	}
+}
+
+func stallRecords(difficulty int, successes []bool, start time.Time) []MovementSessionRecord {
+	records := make([]MovementSessionRecord, len(successes))
+	for i, ok := range successes {
+		records[i] = MovementSessionRecord{
+			MovementID:  "cali_pullup_std",
+			Difficulty:  difficulty,
+			Successful:  ok,
+			PerformedAt: start.AddDate(0, 0, i),
+		}
+	}
+	return records
+}
+
+func TestIsSuccessfulMovementSession(t *testing.T) {
+	if !IsSuccessfulMovementSession(MovementProgressionInput{CompletedReps: 8, TargetReps: 8, RPE: 7}) {
+		t.Error("expected clean session meeting target to be successful")
+	}
+	if IsSuccessfulMovementSession(MovementProgressionInput{CompletedReps: 8, TargetReps: 8, RPE: 9}) {
+		t.Error("expected grinding session (RPE 9) to be unsuccessful")
+	}
+	if IsSuccessfulMovementSession(MovementProgressionInput{CompletedReps: 8, TargetReps: 8, RPE: 6, HadFormIssue: true}) {
+		t.Error("expected form issue to be unsuccessful")
+	}
+}
+
+func TestIsMovementStalled(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if IsMovementStalled(stallRecords(6, []bool{false, false, false, false, false}, start)) {
+		t.Error("expected fewer than window sessions to not be stalled")
+	}
+	if !IsMovementStalled(stallRecords(6, []bool{false, true, false, false, true, false}, start)) {
+		t.Error("expected 2/6 successes at same difficulty to be stalled")
+	}
+	if IsMovementStalled(stallRecords(6, []bool{true, true, true, false, false, false}, start)) {
+		t.Error("expected 50% success to not be stalled")
+	}
+
+	mixed := append(stallRecords(5, []bool{false, false}, start), stallRecords(6, []bool{false, false, false, false}, start.AddDate(0, 0, 2))...)
+	if IsMovementStalled(mixed) {
+		t.Error("expected a difficulty change inside the window to reset the stall")
+	}
+}
+
+func TestBuildMovementAnalytics(t *testing.T) {
+	library := SeedMovements()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := stallRecords(6, []bool{false, false, false, false, false, false}, start)
+	records = append(records, MovementSessionRecord{MovementID: "cali_squat_air", Difficulty: 2, Successful: true, PerformedAt: start})
+
+	a := BuildMovementAnalytics(library, records)
+
+	if len(a.Usage) != 2 {
+		t.Fatalf("usage len = %d, want 2", len(a.Usage))
+	}
+	if a.MostUsed[0].MovementID != "cali_pullup_std" {
+		t.Errorf("most used = %s, want cali_pullup_std", a.MostUsed[0].MovementID)
+	}
+	if len(a.Stalled) != 1 || a.Stalled[0].Regression == nil {
+		t.Fatalf("expected stalled pull-up with a regression, got %+v", a.Stalled)
+	}
+	if a.Stalled[0].Regression.ID != "cali_pullup_neg" {
+		t.Errorf("regression = %s, want cali_pullup_neg (hardest easier pull)", a.Stalled[0].Regression.ID)
+	}
+
+	never := make(map[MovementCategory]bool)
+	for _, c := range a.NeverAttemptedCategories {
+		never[c] = true
+	}
+	if never[MovementCategoryPull] || never[MovementCategoryLegs] {
+		t.Error("attempted categories should not be listed as never attempted")
+	}
+	if !never[MovementCategoryCore] || !never[MovementCategoryPower] {
+		t.Error("expected core and power to be never attempted")
+	}
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// NOTE SYNC
+// =============================================================================
+//
+// Day and session notes are edited on more than one device. A note edit
+// carries the text the device started from (its base). If the stored note
+// still equals the base, the edit simply saves. If another device changed it
+// in between and both only added text after the base, the additions are
+// merged. Otherwise the last writer wins, but the text it replaced is kept in
+// a note conflict, with a suggested merge, until the user resolves it.
+
+// NoteTarget is the kind of note being edited.
+type NoteTarget string
+
+const (
+	NoteTargetDay     NoteTarget = "day"
+	NoteTargetSession NoteTarget = "session"
+)
+
+// NoteRef identifies a note: a day's note, or a session's note on that day.
+type NoteRef struct {
+	Target    NoteTarget
+	Date      string // YYYY-MM-DD
+	SessionID int64  // Set for session notes
+}
+
+// NoteEditOutcome is what a note edit did.
+type NoteEditOutcome string
+
+const (
+	NoteEditUnchanged NoteEditOutcome = "unchanged" // Nothing new to save
+	NoteEditSaved     NoteEditOutcome = "saved"     // Base matched, or no base given
+	NoteEditMerged    NoteEditOutcome = "merged"    // Both devices' additions kept
+	NoteEditConflict  NoteEditOutcome = "conflict"  // Saved over a concurrent edit; see the conflict
+)
+
+// NoteMerge is the result of applying an edit to a stored note.
+type NoteMerge struct {
+	Text        string          // Text to store
+	Outcome     NoteEditOutcome //
+	Overwritten string          // On conflict, the concurrent text that was replaced
+}
+
+// MergeNote applies an edit (incoming, started from base) to the stored note
+// (current). A nil base is an unconditional save.
+func MergeNote(base *string, current, incoming string) NoteMerge {
+	if incoming == current {
+		return NoteMerge{Text: current, Outcome: NoteEditUnchanged}
+	}
+	if base == nil || *base == current {
+		return NoteMerge{Text: incoming, Outcome: NoteEditSaved}
+	}
+	if incoming == *base {
+		// This device changed nothing; keep the other device's edit
+		return NoteMerge{Text: current, Outcome: NoteEditUnchanged}
+	}
+	if strings.HasPrefix(current, *base) && strings.HasPrefix(incoming, *base) {
+		return NoteMerge{Text: appendNote(current, incoming[len(*base):]), Outcome: NoteEditMerged}
+	}
+	return NoteMerge{Text: incoming, Outcome: NoteEditConflict, Overwritten: current}
+}
+
+// appendNote adds text to a note on a new line.
+func appendNote(note, addition string) string {
+	addition = strings.TrimLeft(addition, " \n")
+	if addition == "" {
+		return note
+	}
+	if note == "" || strings.HasSuffix(note, "\n") {
+		return note + addition
+	}
+	return note + "\n" + addition
+}
+
+// SuggestNoteMerge combines two conflicting versions: the kept text, then any
+// lines of the overwritten text it doesn't already contain.
+func SuggestNoteMerge(kept, overwritten string) string {
+	have := make(map[string]bool)
+	for _, line := range strings.Split(kept, "\n") {
+		have[strings.TrimSpace(line)] = true
+	}
+	merged := kept
+	for _, line := range strings.Split(overwritten, "\n") {
+		if t := strings.TrimSpace(line); t != "" && !have[t] {
+			have[t] = true
+			merged = appendNote(merged, line)
+		}
+	}
+	return merged
+}
+
+// NoteConflict records a note edit that replaced a concurrent edit.
+type NoteConflict struct {
+	ID          int64          `json:"id"`
+	Target      NoteTarget     `json:"target"`
+	Date        string         `json:"date"`                // Day of the note (YYYY-MM-DD)
+	SessionID   *int64         `json:"sessionId,omitempty"` // Set for session notes
+	Base        string         `json:"base"`                // Text the winning edit started from
+	Kept        string         `json:"kept"`                // Winning (last written) text
+	Overwritten string         `json:"overwritten"`         // Concurrent text it replaced
+	Suggested   string         `json:"suggested"`           // Kept plus the overwritten text's extra lines
+	CreatedAt   time.Time      `json:"createdAt"`
+	ResolvedAt  *time.Time     `json:"resolvedAt,omitempty"`
+	Resolution  NoteResolution `json:"resolution,omitempty"`
+}
+
+// NewNoteConflict records a conflicting merge.
+func NewNoteConflict(ref NoteRef, base string, merge NoteMerge, now time.Time) *NoteConflict {
+	var sessionID *int64
+	if ref.Target == NoteTargetSession {
+		id := ref.SessionID
+		sessionID = &id
+	}
+	return &NoteConflict{
+		Target:      ref.Target,
+		Date:        ref.Date,
+		SessionID:   sessionID,
+		Base:        base,
+		Kept:        merge.Text,
+		Overwritten: merge.Overwritten,
+		Suggested:   SuggestNoteMerge(merge.Text, merge.Overwritten),
+		CreatedAt:   now,
+	}
+}
+
+// NoteResolution is how a note conflict was settled.
+type NoteResolution string
+
+const (
+	NoteResolutionKeep    NoteResolution = "keep"    // Keep the last written text
+	NoteResolutionRestore NoteResolution = "restore" // Bring back the overwritten text
+	NoteResolutionMerge   NoteResolution = "merge"   // Use the suggested merge
+	NoteResolutionCustom  NoteResolution = "custom"  // Use text supplied by the user
+)
+
+// ParseNoteResolution safely converts a string to NoteResolution with validation.
+func ParseNoteResolution(s string) (NoteResolution, error) {
+	switch NoteResolution(s) {
+	case NoteResolutionKeep, NoteResolutionRestore, NoteResolutionMerge, NoteResolutionCustom:
+		return NoteResolution(s), nil
+	}
+	return "", ErrInvalidNoteResolution
+}
+
+// Resolve marks the conflict resolved and returns the note text the
+// resolution chooses. The caller saves it as an edit based on Kept, so a
+// further change made since the conflict is merged rather than lost.
+func (c *NoteConflict) Resolve(resolution NoteResolution, text string, now time.Time) (string, error) {
+	if c.ResolvedAt != nil {
+		return "", ErrNoteConflictResolved
+	}
+	var chosen string
+	switch resolution {
+	case NoteResolutionKeep:
+		chosen = c.Kept
+	case NoteResolutionRestore:
+		chosen = c.Overwritten
+	case NoteResolutionMerge:
+		chosen = c.Suggested
+	case NoteResolutionCustom:
+		chosen = text
+	default:
+		return "", ErrInvalidNoteResolution
+	}
+	c.ResolvedAt = &now
+	c.Resolution = resolution
+	return chosen, nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: A note edit from a second device must never silently drop
+// the first device's text; tests pin when an edit saves, merges or records a
+// conflict, the suggested merge, and what each resolution restores.
+type NoteSyncSuite struct {
+	suite.Suite
+}
+
+func TestNoteSyncSuite(t *testing.T) {
+	suite.Run(t, new(NoteSyncSuite))
+}
+
+func notePtr(s string) *string { return &s }
+
+func (s *NoteSyncSuite) TestMergeNote() {
+	s.Equal(NoteMerge{Text: "knee ok", Outcome: NoteEditSaved}, MergeNote(nil, "knee sore", "knee ok"), "no base overwrites")
+	s.Equal(NoteMerge{Text: "knee ok", Outcome: NoteEditSaved}, MergeNote(notePtr("knee sore"), "knee sore", "knee ok"))
+	s.Equal(NoteMerge{Text: "same", Outcome: NoteEditUnchanged}, MergeNote(notePtr("old"), "same", "same"))
+	s.Equal(NoteMerge{Text: "desktop edit", Outcome: NoteEditUnchanged}, MergeNote(notePtr("old"), "desktop edit", "old"),
+		"an untouched stale copy keeps the other device's edit")
+
+	merged := MergeNote(notePtr("Squats felt heavy."), "Squats felt heavy.\nLeft knee twinge.", "Squats felt heavy. Slept 5h.")
+	s.Equal(NoteMerge{Text: "Squats felt heavy.\nLeft knee twinge.\nSlept 5h.", Outcome: NoteEditMerged}, merged)
+
+	conflict := MergeNote(notePtr("Squats felt heavy."), "Squats felt fine.", "Squats heavy, cut a set.")
+	s.Equal(NoteMerge{Text: "Squats heavy, cut a set.", Outcome: NoteEditConflict, Overwritten: "Squats felt fine."}, conflict)
+}
+
+func (s *NoteSyncSuite) TestSuggestNoteMerge() {
+	s.Equal("Cut a set.\nKnee ok\nSlept 5h", SuggestNoteMerge("Cut a set.\nKnee ok", "Knee ok\n\nSlept 5h"))
+	s.Equal("kept", SuggestNoteMerge("kept", ""))
+}
+
+func (s *NoteSyncSuite) TestResolve() {
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	merge := NoteMerge{Text: "phone", Outcome: NoteEditConflict, Overwritten: "desktop"}
+	conflict := NewNoteConflict(NoteRef{Target: NoteTargetSession, Date: "2026-03-02", SessionID: 7}, "base", merge, now)
+	s.Require().NotNil(conflict.SessionID)
+	s.Equal(int64(7), *conflict.SessionID)
+	s.Equal("phone\ndesktop", conflict.Suggested)
+
+	for resolution, want := range map[NoteResolution]string{
+		NoteResolutionKeep:    "phone",
+		NoteResolutionRestore: "desktop",
+		NoteResolutionMerge:   "phone\ndesktop",
+		NoteResolutionCustom:  "typed",
+	} {
+		c := *conflict
+		text, err := c.Resolve(resolution, "typed", now)
+		s.Require().NoError(err)
+		s.Equal(want, text, resolution)
+		s.Equal(resolution, c.Resolution)
+		s.NotNil(c.ResolvedAt)
+
+		_, err = c.Resolve(resolution, "", now)
+		s.ErrorIs(err, ErrNoteConflictResolved)
+	}
+
+	_, err := ParseNoteResolution("theirs")
+	s.ErrorIs(err, ErrInvalidNoteResolution)
+	s.Nil(NewNoteConflict(NoteRef{Target: NoteTargetDay, Date: "2026-03-02"}, "", merge, now).SessionID)
+}
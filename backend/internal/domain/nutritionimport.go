@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// =============================================================================
+// HISTORICAL NUTRITION IMPORT
+// =============================================================================
+//
+// MyFitnessPal and Cronometer exports are imported as food entries on past
+// dates. Each row's exported macros feed the daily consumed totals (and thus
+// the adaptive TDEE engine); rows are additionally linked to food_reference by
+// name (see ResolveFood), with unmatched names queued for manual review.
+
+// NutritionImportSource identifies the app an export came from.
+type NutritionImportSource string
+
+const (
+	NutritionImportSourceMFP        NutritionImportSource = "mfp"
+	NutritionImportSourceCronometer NutritionImportSource = "cronometer"
+)
+
+// FoodMatchThreshold is the minimum similarity (0-1) for an automatic food_reference match.
+const FoodMatchThreshold = 0.55
+
+// NutritionImportRow is one parsed row of an export.
+type NutritionImportRow struct {
+	Date     string    // YYYY-MM-DD
+	Meal     *MealName // nil for snacks/uncategorized (counted in totals only)
+	FoodName string    // Empty for meal-level summary rows
+	Amount   string    // Raw quantity text from the export (e.g. "150 g", "1 cup")
+	Calories float64
+	ProteinG float64
+	CarbsG   float64
+	FatG     float64
+}
+
+// ImportedFoodEntry is a persisted food entry from a historical import.
+type ImportedFoodEntry struct {
+	ID              int64                 `json:"id"`
+	Source          NutritionImportSource `json:"source"`
+	Date            string                `json:"date"`
+	Meal            *MealName             `json:"meal,omitempty"`
+	RawName         string                `json:"rawName"`
+	Amount          string                `json:"amount,omitempty"`
+	FoodReferenceID *int64                `json:"foodReferenceId,omitempty"`
+	MatchScore      float64               `json:"matchScore"`
+	Calories        int                   `json:"calories"`
+	ProteinG        int                   `json:"proteinG"`
+	CarbsG          int                   `json:"carbsG"`
+	FatG            int                   `json:"fatG"`
+	CreatedAt       time.Time             `json:"createdAt"`
+}
+
+// NeedsReview reports whether the entry names a food that has not been linked to food_reference.
+func (e ImportedFoodEntry) NeedsReview() bool {
+	return e.RawName != "" && e.FoodReferenceID == nil
+}
+
+// NutritionImportResult contains the outcome of a nutrition export import.
+type NutritionImportResult struct {
+	Source            NutritionImportSource `json:"source"`
+	RowsProcessed     int                   `json:"rowsProcessed"`
+	EntriesImported   int                   `json:"entriesImported"`
+	DuplicatesSkipped int                   `json:"duplicatesSkipped"` // Rows already imported by an earlier upload
+	RowsSkipped       int                   `json:"rowsSkipped"`       // Unparseable rows or dates without a daily log
+	DaysUpdated       int                   `json:"daysUpdated"`
+	MatchedItems      int                   `json:"matchedItems"`
+	UnmatchedItems    int                   `json:"unmatchedItems"` // Queued for review
+	Warnings          []string              `json:"warnings"`
+	Errors            []string              `json:"errors"`
+}
+
+// ImportMealName maps an export's meal/group label to a meal slot.
+// Returns nil for snacks and anything else without a slot.
+func ImportMealName(label string) *MealName {
+	var meal MealName
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "breakfast":
+		meal = MealBreakfast
+	case "lunch":
+		meal = MealLunch
+	case "dinner":
+		meal = MealDinner
+	default:
+		return nil
+	}
+	return &meal
+}
+
+// NormalizeFoodName lowercases a food name and strips brand suffixes, serving
+// descriptions and punctuation: "Chicken Breast - Grilled, 100 g" → "chicken breast".
+func NormalizeFoodName(name string) string {
+	name = strings.ToLower(name)
+	if i := strings.Index(name, " - "); i > 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, ","); i > 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "("); i > 0 {
+		name = name[:i]
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_' || r == '/':
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// foodNameTrigrams returns the set of padded character trigrams for each word.
+func foodNameTrigrams(s string) map[string]bool {
+	grams := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			grams[string(padded[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// FoodNameSimilarity scores two food names from 0 (unrelated) to 1 (same after
+// canonicalization) using the Dice coefficient over character trigrams.
+func FoodNameSimilarity(a, b string) float64 {
+	a, b = CanonicalFoodName(a), CanonicalFoodName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	ga, gb := foodNameTrigrams(a), foodNameTrigrams(b)
+	shared := 0
+	for g := range ga {
+		if gb[g] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ga)+len(gb))
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NutritionImportSuite struct {
+	suite.Suite
+}
+
+func TestNutritionImportSuite(t *testing.T) {
+	suite.Run(t, new(NutritionImportSuite))
+}
+
+func (s *NutritionImportSuite) TestImportMealName() {
+	s.Equal(MealBreakfast, *ImportMealName("Breakfast"))
+	s.Equal(MealLunch, *ImportMealName(" lunch "))
+	s.Equal(MealDinner, *ImportMealName("DINNER"))
+	s.Nil(ImportMealName("Snacks"))
+	s.Nil(ImportMealName("Uncategorized"))
+}
+
+func (s *NutritionImportSuite) TestNormalizeFoodName() {
+	s.Equal("chicken breast", NormalizeFoodName("Chicken Breast - Grilled, 100 g"))
+	s.Equal("oats", NormalizeFoodName("Oats (Rolled)"))
+	s.Equal("chicken turkey breast", NormalizeFoodName("Chicken/Turkey Breast"))
+	s.Equal("low fat greek yoghurt", NormalizeFoodName("Low-fat Greek Yoghurt"))
+}
+
+func (s *NutritionImportSuite) TestFoodNameSimilarity() {
+	s.Equal(1.0, FoodNameSimilarity("Olive Oil", "olive oil"))
+	s.Equal(0.0, FoodNameSimilarity("", "olive oil"))
+	s.Greater(FoodNameSimilarity("banana", "Bananas"), FoodMatchThreshold)
+	s.Less(FoodNameSimilarity("apple", "Pineapple"), FoodMatchThreshold, "substring alone is not a match")
+}
+
+func (s *NutritionImportSuite) TestNeedsReview() {
+	id := int64(4)
+	s.True(ImportedFoodEntry{RawName: "Mystery Snack"}.NeedsReview())
+	s.False(ImportedFoodEntry{RawName: "Oats", FoodReferenceID: &id}.NeedsReview())
+	s.False(ImportedFoodEntry{}.NeedsReview(), "meal-level summary rows have nothing to match")
+}
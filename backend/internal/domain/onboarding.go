@@ -0,0 +1,417 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// ONBOARDING
+// =============================================================================
+//
+// A new install is set up in four steps: basic info, then the goal, then the
+// macro ratios (or the defaults for the goal), then the first plan. Each step
+// is validated on its own and the next step's fields come with defaults
+// derived from the answers so far. The profile is saved once the ratios are
+// in; the first plan step creates a plan or skips it. Answering an earlier
+// step again clears the answers after it, so later defaults follow the change.
+
+// OnboardingStep names a step of the onboarding wizard.
+type OnboardingStep string
+
+const (
+	OnboardingStepBasicInfo OnboardingStep = "basic_info"
+	OnboardingStepGoal      OnboardingStep = "goal"
+	OnboardingStepRatios    OnboardingStep = "ratios"
+	OnboardingStepFirstPlan OnboardingStep = "first_plan"
+	OnboardingStepComplete  OnboardingStep = "complete"
+)
+
+// onboardingSteps lists the steps in order.
+var onboardingSteps = []OnboardingStep{
+	OnboardingStepBasicInfo,
+	OnboardingStepGoal,
+	OnboardingStepRatios,
+	OnboardingStepFirstPlan,
+	OnboardingStepComplete,
+}
+
+const (
+	OnboardingCutTargetPercent  = 0.05   // Default target: 5% below the current weight
+	OnboardingGainTargetPercent = 0.03   // ...and 3% above it
+	OnboardingCutRatePercent    = 0.005  // Default weekly loss: 0.5% of body weight
+	OnboardingGainRatePercent   = 0.0025 // Default weekly gain: 0.25% of body weight
+	OnboardingMaintenanceWeeks  = 8      // First plan length when maintaining
+)
+
+// OnboardingBasicInfo is the first step's answers.
+type OnboardingBasicInfo struct {
+	HeightCM        float64 `json:"heightCm"`
+	BirthDate       string  `json:"birthDate"` // YYYY-MM-DD
+	Sex             Sex     `json:"sex"`
+	CurrentWeightKg float64 `json:"currentWeightKg"`
+	BodyFatPercent  float64 `json:"bodyFatPercent,omitempty"` // 0 if unknown
+}
+
+// Validate checks the basic info with the profile's rules.
+func (b OnboardingBasicInfo) Validate(now time.Time) error {
+	if b.HeightCM < 100 || b.HeightCM > 250 {
+		return ErrInvalidHeight
+	}
+	birthDate, err := time.Parse("2006-01-02", b.BirthDate)
+	if err != nil || birthDate.After(now.AddDate(-13, 0, 0)) {
+		return ErrInvalidBirthDate
+	}
+	if b.Sex != SexMale && b.Sex != SexFemale {
+		return ErrInvalidSex
+	}
+	if b.CurrentWeightKg < 30 || b.CurrentWeightKg > 300 {
+		return ErrInvalidCurrentWeight
+	}
+	if b.BodyFatPercent != 0 && (b.BodyFatPercent < 3 || b.BodyFatPercent > 70) {
+		return ErrInvalidBodyFatPercent
+	}
+	return nil
+}
+
+// OnboardingGoal is the second step's answers. Zero target weight, weekly
+// change and timeframe are derived from the goal and current weight.
+type OnboardingGoal struct {
+	Goal                 Goal    `json:"goal"`
+	TargetWeightKg       float64 `json:"targetWeightKg"`
+	TargetWeeklyChangeKg float64 `json:"targetWeeklyChangeKg"`
+	TimeframeWeeks       int     `json:"timeframeWeeks"`
+}
+
+// OnboardingRatios is the third step's answers: the macro split, or the
+// goal's defaults when UseDefaults is set.
+type OnboardingRatios struct {
+	UseDefaults  bool    `json:"useDefaults"`
+	CarbRatio    float64 `json:"carbRatio"`
+	ProteinRatio float64 `json:"proteinRatio"`
+	FatRatio     float64 `json:"fatRatio"`
+}
+
+// OnboardingPlan is the last step's answers. Zero fields take the defaults
+// derived from the goal; Skip finishes onboarding without a plan.
+type OnboardingPlan struct {
+	Skip          bool    `json:"skip"`
+	Name          string  `json:"name,omitempty"`
+	StartDate     string  `json:"startDate,omitempty"` // YYYY-MM-DD, default today
+	GoalWeightKg  float64 `json:"goalWeightKg,omitempty"`
+	DurationWeeks int     `json:"durationWeeks,omitempty"`
+}
+
+// OnboardingState is the wizard's progress: the step to answer next and the
+// answers so far.
+type OnboardingState struct {
+	Step        OnboardingStep       `json:"step"`
+	BasicInfo   *OnboardingBasicInfo `json:"basicInfo,omitempty"`
+	Goal        *OnboardingGoal      `json:"goal,omitempty"`
+	Ratios      *OnboardingRatios    `json:"ratios,omitempty"`
+	PlanID      *int64               `json:"planId,omitempty"` // First plan, unless skipped
+	StartedAt   time.Time            `json:"startedAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+	CompletedAt *time.Time           `json:"completedAt,omitempty"`
+}
+
+// NewOnboardingState starts onboarding at the basic info step.
+func NewOnboardingState(now time.Time) *OnboardingState {
+	return &OnboardingState{Step: OnboardingStepBasicInfo, StartedAt: now, UpdatedAt: now}
+}
+
+// CompletedOnboardingState is the state of an install set up before the
+// wizard existed, or by hand.
+func CompletedOnboardingState(now time.Time) *OnboardingState {
+	return &OnboardingState{Step: OnboardingStepComplete, StartedAt: now, UpdatedAt: now, CompletedAt: &now}
+}
+
+// IsComplete reports whether onboarding has finished.
+func (s *OnboardingState) IsComplete() bool {
+	return s.Step == OnboardingStepComplete
+}
+
+// SubmitBasicInfo answers the basic info step.
+func (s *OnboardingState) SubmitBasicInfo(in OnboardingBasicInfo, now time.Time) error {
+	if err := s.enter(OnboardingStepBasicInfo); err != nil {
+		return err
+	}
+	if err := in.Validate(now); err != nil {
+		return err
+	}
+	s.BasicInfo = &in
+	s.advance(OnboardingStepBasicInfo, now)
+	return nil
+}
+
+// SubmitGoal answers the goal step, filling unset fields with the defaults
+// for the goal. The target weight and weekly change must point the way the
+// goal does.
+func (s *OnboardingState) SubmitGoal(in OnboardingGoal, now time.Time) error {
+	if err := s.enter(OnboardingStepGoal); err != nil {
+		return err
+	}
+	if in.Goal != GoalLoseWeight && in.Goal != GoalMaintain && in.Goal != GoalGainWeight {
+		return ErrInvalidGoal
+	}
+	current := s.BasicInfo.CurrentWeightKg
+	defaults := DefaultOnboardingGoal(in.Goal, current)
+	if in.TargetWeightKg == 0 {
+		in.TargetWeightKg = defaults.TargetWeightKg
+	}
+	if in.TargetWeightKg < 30 || in.TargetWeightKg > 300 {
+		return ErrInvalidTargetWeight
+	}
+	if (in.Goal == GoalLoseWeight && in.TargetWeightKg >= current) ||
+		(in.Goal == GoalGainWeight && in.TargetWeightKg <= current) {
+		return ErrOnboardingTargetDirection
+	}
+
+	if in.TargetWeeklyChangeKg == 0 && in.Goal != GoalMaintain {
+		in.TargetWeeklyChangeKg = defaults.TargetWeeklyChangeKg
+	}
+	if in.TargetWeeklyChangeKg < -2.0 || in.TargetWeeklyChangeKg > 2.0 {
+		return ErrInvalidWeeklyChange
+	}
+	switch in.Goal {
+	case GoalLoseWeight:
+		if in.TargetWeeklyChangeKg >= 0 {
+			return ErrOnboardingWeeklyChangeDirection
+		}
+	case GoalGainWeight:
+		if in.TargetWeeklyChangeKg <= 0 {
+			return ErrOnboardingWeeklyChangeDirection
+		}
+	default:
+		if in.TargetWeeklyChangeKg != 0 {
+			return ErrOnboardingWeeklyChangeDirection
+		}
+	}
+
+	if in.TimeframeWeeks == 0 {
+		in.TimeframeWeeks = onboardingTimeframe(current, in.TargetWeightKg, in.TargetWeeklyChangeKg)
+	}
+	if in.TimeframeWeeks < 0 || in.TimeframeWeeks > 520 {
+		return ErrInvalidTimeframeWeeks
+	}
+	s.Goal = &in
+	s.advance(OnboardingStepGoal, now)
+	return nil
+}
+
+// SubmitRatios answers the ratios step. The caller saves the profile built
+// by ApplyToProfile before keeping the state.
+func (s *OnboardingState) SubmitRatios(in OnboardingRatios, now time.Time) error {
+	if err := s.enter(OnboardingStepRatios); err != nil {
+		return err
+	}
+	if in.UseDefaults {
+		in = DefaultOnboardingRatios(s.Goal.Goal)
+	}
+	if in.CarbRatio < 0 || in.CarbRatio > 1 ||
+		in.ProteinRatio < 0 || in.ProteinRatio > 1 ||
+		in.FatRatio < 0 || in.FatRatio > 1 {
+		return ErrInvalidRatio
+	}
+	if !floatEquals(in.CarbRatio+in.ProteinRatio+in.FatRatio, 1.0, 0.01) {
+		return ErrMacroRatiosNotSum100
+	}
+	s.Ratios = &in
+	s.advance(OnboardingStepRatios, now)
+	return nil
+}
+
+// ApplyToProfile writes the answers into p, leaving the fields onboarding
+// doesn't ask about as they are. The ratios step must have been answered.
+func (s *OnboardingState) ApplyToProfile(p *UserProfile) {
+	birthDate, _ := time.Parse("2006-01-02", s.BasicInfo.BirthDate)
+	p.HeightCM = s.BasicInfo.HeightCM
+	p.BirthDate = birthDate
+	p.Sex = s.BasicInfo.Sex
+	p.CurrentWeightKg = s.BasicInfo.CurrentWeightKg
+	p.BodyFatPercent = s.BasicInfo.BodyFatPercent
+	p.Goal = s.Goal.Goal
+	p.TargetWeightKg = s.Goal.TargetWeightKg
+	p.TargetWeeklyChangeKg = s.Goal.TargetWeeklyChangeKg
+	p.TimeframeWeeks = s.Goal.TimeframeWeeks
+	p.CarbRatio = s.Ratios.CarbRatio
+	p.ProteinRatio = s.Ratios.ProteinRatio
+	p.FatRatio = s.Ratios.FatRatio
+}
+
+// FirstPlanInput returns the plan to create for the first plan step, with
+// unset fields taken from the defaults. Returns ok false when the step is
+// skipped.
+func (s *OnboardingState) FirstPlanInput(in OnboardingPlan, now time.Time) (NutritionPlanInput, bool, error) {
+	if err := s.enter(OnboardingStepFirstPlan); err != nil {
+		return NutritionPlanInput{}, false, err
+	}
+	if in.Skip {
+		return NutritionPlanInput{}, false, nil
+	}
+	input := s.DefaultPlan(now)
+	if in.Name != "" {
+		input.Name = in.Name
+	}
+	if in.StartDate != "" {
+		input.StartDate = in.StartDate
+	}
+	if in.GoalWeightKg != 0 {
+		input.GoalWeightKg = in.GoalWeightKg
+	}
+	if in.DurationWeeks != 0 {
+		input.DurationWeeks = in.DurationWeeks
+	}
+	return input, true, nil
+}
+
+// Complete finishes onboarding after the first plan step; planID is nil when
+// the plan was skipped.
+func (s *OnboardingState) Complete(planID *int64, now time.Time) {
+	s.PlanID = planID
+	s.Step = OnboardingStepComplete
+	s.UpdatedAt = now
+	s.CompletedAt = &now
+}
+
+// enter checks step can be answered now: it is the current step or an
+// earlier one, and onboarding hasn't finished.
+func (s *OnboardingState) enter(step OnboardingStep) error {
+	if s.IsComplete() {
+		return ErrOnboardingComplete
+	}
+	if onboardingStepIndex(step) > onboardingStepIndex(s.Step) {
+		return ErrOnboardingStepOutOfOrder
+	}
+	return nil
+}
+
+// advance moves past an answered step and clears the answers after it.
+func (s *OnboardingState) advance(step OnboardingStep, now time.Time) {
+	i := onboardingStepIndex(step)
+	s.Step = onboardingSteps[i+1]
+	if i < onboardingStepIndex(OnboardingStepGoal) {
+		s.Goal = nil
+	}
+	if i < onboardingStepIndex(OnboardingStepRatios) {
+		s.Ratios = nil
+	}
+	s.UpdatedAt = now
+}
+
+func onboardingStepIndex(step OnboardingStep) int {
+	for i, s := range onboardingSteps {
+		if s == step {
+			return i
+		}
+	}
+	return len(onboardingSteps)
+}
+
+// OnboardingDefaults are the suggested answers for the steps whose earlier
+// steps are answered.
+type OnboardingDefaults struct {
+	Goals  []OnboardingGoal  `json:"goals,omitempty"` // One per goal
+	Ratios *OnboardingRatios `json:"ratios,omitempty"`
+	Plan   *OnboardingPlan   `json:"plan,omitempty"`
+}
+
+// Defaults derives the suggested answers from the answers so far.
+func (s *OnboardingState) Defaults(now time.Time) OnboardingDefaults {
+	var d OnboardingDefaults
+	if s.IsComplete() || s.BasicInfo == nil {
+		return d
+	}
+	for _, goal := range []Goal{GoalLoseWeight, GoalMaintain, GoalGainWeight} {
+		d.Goals = append(d.Goals, DefaultOnboardingGoal(goal, s.BasicInfo.CurrentWeightKg))
+	}
+	if s.Goal != nil {
+		ratios := DefaultOnboardingRatios(s.Goal.Goal)
+		d.Ratios = &ratios
+		plan := s.DefaultPlan(now)
+		d.Plan = &OnboardingPlan{
+			Name:          plan.Name,
+			StartDate:     plan.StartDate,
+			GoalWeightKg:  plan.GoalWeightKg,
+			DurationWeeks: plan.DurationWeeks,
+		}
+	}
+	return d
+}
+
+// DefaultOnboardingGoal suggests a target and a sustainable weekly change for
+// a goal: 5% down at 0.5% of body weight a week for a cut, 3% up at 0.25% a
+// week for a gain, both within the plan's safe limits.
+func DefaultOnboardingGoal(goal Goal, currentKg float64) OnboardingGoal {
+	g := OnboardingGoal{Goal: goal, TargetWeightKg: currentKg}
+	switch goal {
+	case GoalLoseWeight:
+		g.TargetWeightKg = RoundTo(currentKg*(1-OnboardingCutTargetPercent), 1)
+		rate := math.Min(currentKg*OnboardingCutRatePercent, MaxSafeDeficitKcal*7/7700.0)
+		g.TargetWeeklyChangeKg = -RoundTo(rate, 2)
+	case GoalGainWeight:
+		g.TargetWeightKg = RoundTo(currentKg*(1+OnboardingGainTargetPercent), 1)
+		rate := math.Min(currentKg*OnboardingGainRatePercent, MaxSafeSurplusKcal*7/7700.0)
+		g.TargetWeeklyChangeKg = RoundTo(rate, 2)
+	}
+	g.TimeframeWeeks = onboardingTimeframe(currentKg, g.TargetWeightKg, g.TargetWeeklyChangeKg)
+	return g
+}
+
+// DefaultOnboardingRatios returns the default macro split for a goal: more
+// protein in a deficit, more carbs in a surplus, otherwise the profile's
+// defaults.
+func DefaultOnboardingRatios(goal Goal) OnboardingRatios {
+	switch goal {
+	case GoalLoseWeight:
+		return OnboardingRatios{UseDefaults: true, CarbRatio: 0.40, ProteinRatio: 0.35, FatRatio: 0.25}
+	case GoalGainWeight:
+		return OnboardingRatios{UseDefaults: true, CarbRatio: 0.50, ProteinRatio: 0.25, FatRatio: 0.25}
+	}
+	return OnboardingRatios{UseDefaults: true, CarbRatio: 0.45, ProteinRatio: 0.30, FatRatio: 0.25}
+}
+
+// DefaultPlan suggests the first plan: from today at the current weight to
+// the target over the goal's timeframe, kept within the plan's duration and
+// safe rate limits. Maintaining holds the weight for 8 weeks.
+func (s *OnboardingState) DefaultPlan(now time.Time) NutritionPlanInput {
+	current := s.BasicInfo.CurrentWeightKg
+	input := NutritionPlanInput{
+		Name:          "First plan",
+		StartDate:     now.Format("2006-01-02"),
+		StartWeightKg: current,
+		GoalWeightKg:  s.Goal.TargetWeightKg,
+		DurationWeeks: OnboardingMaintenanceWeeks,
+	}
+	if s.Goal.Goal == GoalMaintain {
+		input.GoalWeightKg = current
+		return input
+	}
+
+	maxRate := MaxSafeDeficitKcal * 7 / 7700.0
+	if s.Goal.Goal == GoalGainWeight {
+		maxRate = MaxSafeSurplusKcal * 7 / 7700.0
+	}
+	change := math.Abs(s.Goal.TargetWeightKg - current)
+	weeks := max(s.Goal.TimeframeWeeks, ceilWeeks(change/maxRate))
+	input.DurationWeeks = max(MinPlanDurationWeeks, min(weeks, MaxPlanDurationWeeks))
+	// A target too far for the longest plan is approached as far as it safely goes
+	if reach := maxRate * float64(input.DurationWeeks); change > reach {
+		input.GoalWeightKg = RoundTo(current+math.Copysign(math.Floor(reach*10)/10, s.Goal.TargetWeightKg-current), 1)
+	}
+	return input
+}
+
+// onboardingTimeframe is the weeks needed to reach the target at the weekly
+// change, or 0 when maintaining.
+func onboardingTimeframe(currentKg, targetKg, weeklyChangeKg float64) int {
+	if weeklyChangeKg == 0 {
+		return 0
+	}
+	return min(ceilWeeks(math.Abs(targetKg-currentKg)/math.Abs(weeklyChangeKg)), 520)
+}
+
+// ceilWeeks rounds a week count up, ignoring float noise (2.4/0.2 is 12).
+func ceilWeeks(weeks float64) int {
+	return int(math.Ceil(RoundTo(weeks, 6)))
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The wizard writes the profile and first plan for a new
+// install, so the step order, the rewinding of later answers and the derived
+// defaults must hold.
+type OnboardingSuite struct {
+	suite.Suite
+	now   time.Time
+	basic OnboardingBasicInfo
+}
+
+func TestOnboardingSuite(t *testing.T) {
+	suite.Run(t, new(OnboardingSuite))
+}
+
+func (s *OnboardingSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	s.basic = OnboardingBasicInfo{HeightCM: 180, BirthDate: "1990-05-14", Sex: SexMale, CurrentWeightKg: 80}
+}
+
+func (s *OnboardingSuite) atRatios(goal Goal) *OnboardingState {
+	state := NewOnboardingState(s.now)
+	s.Require().NoError(state.SubmitBasicInfo(s.basic, s.now))
+	s.Require().NoError(state.SubmitGoal(OnboardingGoal{Goal: goal}, s.now))
+	return state
+}
+
+func (s *OnboardingSuite) TestBasicInfoValidation() {
+	state := NewOnboardingState(s.now)
+	s.Equal(OnboardingStepBasicInfo, state.Step)
+
+	tooYoung := s.basic
+	tooYoung.BirthDate = "2015-01-01"
+	s.ErrorIs(state.SubmitBasicInfo(tooYoung, s.now), ErrInvalidBirthDate)
+	noWeight := s.basic
+	noWeight.CurrentWeightKg = 0
+	s.ErrorIs(state.SubmitBasicInfo(noWeight, s.now), ErrInvalidCurrentWeight)
+	s.Equal(OnboardingStepBasicInfo, state.Step, "a rejected answer doesn't advance")
+
+	s.NoError(state.SubmitBasicInfo(s.basic, s.now))
+	s.Equal(OnboardingStepGoal, state.Step)
+}
+
+func (s *OnboardingSuite) TestStepsInOrder() {
+	state := NewOnboardingState(s.now)
+	s.ErrorIs(state.SubmitGoal(OnboardingGoal{Goal: GoalMaintain}, s.now), ErrOnboardingStepOutOfOrder)
+	s.ErrorIs(state.SubmitRatios(OnboardingRatios{UseDefaults: true}, s.now), ErrOnboardingStepOutOfOrder)
+	_, _, err := state.FirstPlanInput(OnboardingPlan{}, s.now)
+	s.ErrorIs(err, ErrOnboardingStepOutOfOrder)
+}
+
+func (s *OnboardingSuite) TestGoalDefaults() {
+	state := s.atRatios(GoalLoseWeight)
+	s.Equal(OnboardingGoal{Goal: GoalLoseWeight, TargetWeightKg: 76, TargetWeeklyChangeKg: -0.4, TimeframeWeeks: 10}, *state.Goal)
+
+	state = s.atRatios(GoalGainWeight)
+	s.Equal(OnboardingGoal{Goal: GoalGainWeight, TargetWeightKg: 82.4, TargetWeeklyChangeKg: 0.2, TimeframeWeeks: 12}, *state.Goal)
+
+	state = s.atRatios(GoalMaintain)
+	s.Equal(OnboardingGoal{Goal: GoalMaintain, TargetWeightKg: 80}, *state.Goal)
+}
+
+func (s *OnboardingSuite) TestGoalDirection() {
+	state := NewOnboardingState(s.now)
+	s.Require().NoError(state.SubmitBasicInfo(s.basic, s.now))
+
+	s.ErrorIs(state.SubmitGoal(OnboardingGoal{Goal: GoalLoseWeight, TargetWeightKg: 85}, s.now), ErrOnboardingTargetDirection)
+	s.ErrorIs(state.SubmitGoal(OnboardingGoal{Goal: GoalLoseWeight, TargetWeeklyChangeKg: 0.5}, s.now), ErrOnboardingWeeklyChangeDirection)
+	s.ErrorIs(state.SubmitGoal(OnboardingGoal{Goal: GoalMaintain, TargetWeeklyChangeKg: 0.2}, s.now), ErrOnboardingWeeklyChangeDirection)
+	s.ErrorIs(state.SubmitGoal(OnboardingGoal{Goal: "bulk"}, s.now), ErrInvalidGoal)
+
+	s.NoError(state.SubmitGoal(OnboardingGoal{Goal: GoalLoseWeight, TargetWeightKg: 75, TargetWeeklyChangeKg: -0.5}, s.now))
+	s.Equal(10, state.Goal.TimeframeWeeks, "derived from the distance and rate")
+}
+
+func (s *OnboardingSuite) TestRatios() {
+	state := s.atRatios(GoalLoseWeight)
+	s.ErrorIs(state.SubmitRatios(OnboardingRatios{CarbRatio: 0.5, ProteinRatio: 0.3, FatRatio: 0.3}, s.now), ErrMacroRatiosNotSum100)
+
+	s.NoError(state.SubmitRatios(OnboardingRatios{UseDefaults: true}, s.now))
+	s.Equal(DefaultOnboardingRatios(GoalLoseWeight), *state.Ratios)
+	s.Equal(0.35, state.Ratios.ProteinRatio, "more protein in a deficit")
+	s.Equal(OnboardingStepFirstPlan, state.Step)
+
+	var p UserProfile
+	state.ApplyToProfile(&p)
+	p.SetDefaults()
+	s.NoError(p.ValidateAt(s.now), "the answers make a valid profile")
+	s.Equal(76.0, p.TargetWeightKg)
+	s.Equal(1990, p.BirthDate.Year())
+}
+
+func (s *OnboardingSuite) TestEarlierAnswerClearsLaterOnes() {
+	state := s.atRatios(GoalLoseWeight)
+	s.Require().NoError(state.SubmitRatios(OnboardingRatios{UseDefaults: true}, s.now))
+
+	heavier := s.basic
+	heavier.CurrentWeightKg = 90
+	s.NoError(state.SubmitBasicInfo(heavier, s.now))
+	s.Equal(OnboardingStepGoal, state.Step)
+	s.Nil(state.Goal)
+	s.Nil(state.Ratios)
+	s.Equal(85.5, state.Defaults(s.now).Goals[0].TargetWeightKg, "defaults follow the new weight")
+}
+
+func (s *OnboardingSuite) TestFirstPlan() {
+	state := s.atRatios(GoalLoseWeight)
+	s.Require().NoError(state.SubmitRatios(OnboardingRatios{UseDefaults: true}, s.now))
+
+	input, ok, err := state.FirstPlanInput(OnboardingPlan{DurationWeeks: 12}, s.now)
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("2026-03-02", input.StartDate)
+	s.Equal(80.0, input.StartWeightKg)
+	s.Equal(76.0, input.GoalWeightKg)
+	s.Equal(12, input.DurationWeeks, "answers override the defaults")
+
+	_, ok, err = state.FirstPlanInput(OnboardingPlan{Skip: true}, s.now)
+	s.NoError(err)
+	s.False(ok)
+
+	planID := int64(4)
+	state.Complete(&planID, s.now)
+	s.True(state.IsComplete())
+	s.ErrorIs(state.SubmitBasicInfo(s.basic, s.now), ErrOnboardingComplete)
+	s.Empty(state.Defaults(s.now).Goals)
+}
+
+func (s *OnboardingSuite) TestDefaultPlanStaysSafe() {
+	state := s.atRatios(GoalMaintain)
+	plan := state.DefaultPlan(s.now)
+	s.Equal(OnboardingMaintenanceWeeks, plan.DurationWeeks)
+	s.Equal(80.0, plan.GoalWeightKg)
+
+	state = NewOnboardingState(s.now)
+	heavy := s.basic
+	heavy.CurrentWeightKg = 150
+	s.Require().NoError(state.SubmitBasicInfo(heavy, s.now))
+	s.Require().NoError(state.SubmitGoal(OnboardingGoal{Goal: GoalLoseWeight, TargetWeightKg: 60, TargetWeeklyChangeKg: -0.5}, s.now))
+	s.Equal(180, state.Goal.TimeframeWeeks)
+
+	plan = state.DefaultPlan(s.now)
+	s.Equal(MaxPlanDurationWeeks, plan.DurationWeeks)
+	s.Equal(79.1, plan.GoalWeightKg, "as far as the longest plan safely goes")
+
+	d := state.Defaults(s.now)
+	s.Require().NotNil(d.Plan)
+	s.Equal(79.1, d.Plan.GoalWeightKg)
+}
@@ -0,0 +1,307 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// PERSONAL RECORDS
+// =============================================================================
+//
+// The registry keeps the user's own bests per movement: heaviest load, longest
+// hold and fastest circuit time. There is no comparison against anyone else.
+// Entries come from set logging, from achievements parsed out of session
+// echoes, and from manual entry. An entry that beats the current best is
+// flagged for celebration until dismissed; the first entry for a movement and
+// kind sets the baseline without one. The current best is always derived from
+// the history, so correcting or deleting an entry is enough to fix the registry.
+
+// RecordKind is what a personal record measures.
+type RecordKind string
+
+const (
+	RecordKindHeaviestLoad   RecordKind = "heaviest_load"   // kg
+	RecordKindLongestHold    RecordKind = "longest_hold"    // seconds
+	RecordKindFastestCircuit RecordKind = "fastest_circuit" // seconds
+)
+
+// ValidRecordKinds contains all valid record kind values.
+var ValidRecordKinds = map[RecordKind]bool{
+	RecordKindHeaviestLoad:   true,
+	RecordKindLongestHold:    true,
+	RecordKindFastestCircuit: true,
+}
+
+// RecordSource is where a personal record entry came from.
+type RecordSource string
+
+const (
+	RecordSourceSetLog RecordSource = "set_log"
+	RecordSourceEcho   RecordSource = "echo"
+	RecordSourceManual RecordSource = "manual"
+)
+
+// ValidRecordSources contains all valid record source values.
+var ValidRecordSources = map[RecordSource]bool{
+	RecordSourceSetLog: true,
+	RecordSourceEcho:   true,
+	RecordSourceManual: true,
+}
+
+const (
+	// MaxRecordLoadKg bounds heaviest_load values.
+	MaxRecordLoadKg = 500
+	// MaxRecordSeconds bounds longest_hold and fastest_circuit values (2 hours).
+	MaxRecordSeconds = 7200
+)
+
+// PersonalRecord is one entry in a movement's record history.
+type PersonalRecord struct {
+	ID         int64        `json:"id"`
+	MovementID string       `json:"movementId"`
+	Kind       RecordKind   `json:"kind"`
+	Value      float64      `json:"value"` // kg for heaviest_load, seconds otherwise
+	Source     RecordSource `json:"source"`
+	SessionID  *int64       `json:"sessionId,omitempty"`
+	Date       string       `json:"date"` // YYYY-MM-DD the record was set
+	Note       string       `json:"note,omitempty"`
+	// CelebrationPending is set when the entry beat the previous best and
+	// cleared once the celebration is dismissed.
+	CelebrationPending bool      `json:"celebrationPending"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+// Validate checks the kind, source, date and that the value is in range.
+func (r PersonalRecord) Validate() error {
+	if r.MovementID == "" {
+		return ErrInvalidRecordMovement
+	}
+	if !ValidRecordKinds[r.Kind] {
+		return ErrInvalidRecordKind
+	}
+	if !ValidRecordSources[r.Source] {
+		return ErrInvalidRecordSource
+	}
+	if _, err := time.Parse("2006-01-02", r.Date); err != nil {
+		return ErrInvalidRecordDate
+	}
+	limit := float64(MaxRecordSeconds)
+	if r.Kind == RecordKindHeaviestLoad {
+		limit = MaxRecordLoadKg
+	}
+	if r.Value <= 0 || r.Value > limit {
+		return ErrInvalidRecordValue
+	}
+	return nil
+}
+
+// Beats reports whether value a is better than b for the kind. Circuits are
+// better when faster; loads and holds when larger.
+func (k RecordKind) Beats(a, b float64) bool {
+	if k == RecordKindFastestCircuit {
+		return a < b
+	}
+	return a > b
+}
+
+// recordKey identifies one line of the registry.
+type recordKey struct {
+	movementID string
+	kind       RecordKind
+}
+
+// BestRecords returns the best entry per movement and kind, ordered by
+// movement then kind. On a tie the earliest entry keeps the record.
+func BestRecords(history []PersonalRecord) []PersonalRecord {
+	best := bestByKey(history)
+	records := make([]PersonalRecord, 0, len(best))
+	for _, r := range best {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].MovementID != records[j].MovementID {
+			return records[i].MovementID < records[j].MovementID
+		}
+		return records[i].Kind < records[j].Kind
+	})
+	return records
+}
+
+func bestByKey(history []PersonalRecord) map[recordKey]PersonalRecord {
+	sorted := append([]PersonalRecord(nil), history...)
+	sortRecordHistory(sorted)
+
+	best := make(map[recordKey]PersonalRecord)
+	for _, r := range sorted {
+		key := recordKey{r.MovementID, r.Kind}
+		if current, ok := best[key]; !ok || r.Kind.Beats(r.Value, current.Value) {
+			best[key] = r
+		}
+	}
+	return best
+}
+
+// sortRecordHistory orders entries by date, then by creation.
+func sortRecordHistory(history []PersonalRecord) {
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].Date != history[j].Date {
+			return history[i].Date < history[j].Date
+		}
+		return history[i].ID < history[j].ID
+	})
+}
+
+// SelectNewRecords returns the candidates worth adding to the history: those
+// that beat the current best, flagged for celebration, and the first entry
+// for a movement and kind, which sets the baseline without a celebration.
+// Candidates are considered in order, so only improving ones in a batch count.
+func SelectNewRecords(candidates, history []PersonalRecord) []PersonalRecord {
+	best := bestByKey(history)
+	var selected []PersonalRecord
+	for _, c := range candidates {
+		key := recordKey{c.MovementID, c.Kind}
+		current, ok := best[key]
+		if ok && !c.Kind.Beats(c.Value, current.Value) {
+			continue
+		}
+		c.CelebrationPending = ok
+		best[key] = c
+		selected = append(selected, c)
+	}
+	return selected
+}
+
+// RecordCandidatesFromSet turns a logged set into record candidates. Sets
+// logged with a form issue don't count.
+func RecordCandidatesFromSet(movementID string, input MovementProgressionInput, date string) []PersonalRecord {
+	if input.HadFormIssue {
+		return nil
+	}
+	var candidates []PersonalRecord
+	add := func(kind RecordKind, value float64) {
+		if value > 0 {
+			candidates = append(candidates, PersonalRecord{
+				MovementID: movementID, Kind: kind, Value: value, Source: RecordSourceSetLog, Date: date,
+			})
+		}
+	}
+	add(RecordKindHeaviestLoad, input.LoadKg)
+	add(RecordKindLongestHold, float64(input.HoldSec))
+	add(RecordKindFastestCircuit, float64(input.CircuitSec))
+	return candidates
+}
+
+var (
+	achievementLoad    = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*kg\b`)
+	achievementClock   = regexp.MustCompile(`\b(\d{1,2}):([0-5]\d)\b`)
+	achievementSeconds = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:s|secs?|seconds?)\b`)
+	achievementMinutes = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:mins?|minutes?)\b`)
+	achievementWord    = regexp.MustCompile(`[a-z]+`)
+)
+
+// achievementStopWords are words that say nothing about which movement is meant.
+var achievementStopWords = map[string]bool{
+	"new": true, "pr": true, "personal": true, "record": true, "best": true,
+	"hold": true, "held": true, "circuit": true, "standard": true, "in": true,
+	"a": true, "the": true, "with": true, "for": true, "of": true, "first": true,
+}
+
+// ParseAchievementRecord reads a record candidate from an echo achievement
+// such as "30s handstand", "1:45 bear crawl circuit" or "24kg pistol squat".
+// Loads need kg, circuit times m:ss, holds seconds or minutes; plain rep
+// counts aren't tracked. The movement is the catalog entry whose name best
+// covers the remaining words. Returns false when either part can't be read.
+func ParseAchievementRecord(text string, movements []Movement, date string) (PersonalRecord, bool) {
+	lower := strings.ToLower(text)
+	candidate := PersonalRecord{Source: RecordSourceEcho, Date: date, Note: text}
+
+	var match []int
+	switch {
+	case achievementLoad.MatchString(lower):
+		match = achievementLoad.FindStringSubmatchIndex(lower)
+		candidate.Kind = RecordKindHeaviestLoad
+		candidate.Value, _ = strconv.ParseFloat(lower[match[2]:match[3]], 64)
+	case achievementClock.MatchString(lower):
+		match = achievementClock.FindStringSubmatchIndex(lower)
+		minutes, _ := strconv.Atoi(lower[match[2]:match[3]])
+		seconds, _ := strconv.Atoi(lower[match[4]:match[5]])
+		candidate.Kind = RecordKindFastestCircuit
+		candidate.Value = float64(minutes*60 + seconds)
+	case achievementSeconds.MatchString(lower):
+		match = achievementSeconds.FindStringSubmatchIndex(lower)
+		candidate.Kind = RecordKindLongestHold
+		candidate.Value, _ = strconv.ParseFloat(lower[match[2]:match[3]], 64)
+	case achievementMinutes.MatchString(lower):
+		match = achievementMinutes.FindStringSubmatchIndex(lower)
+		minutes, _ := strconv.ParseFloat(lower[match[2]:match[3]], 64)
+		candidate.Kind = RecordKindLongestHold
+		candidate.Value = minutes * 60
+	default:
+		return PersonalRecord{}, false
+	}
+
+	rest := lower[:match[0]] + " " + lower[match[1]:]
+	movement, ok := matchAchievementMovement(rest, movements)
+	if !ok {
+		return PersonalRecord{}, false
+	}
+	candidate.MovementID = movement.ID
+	return candidate, true
+}
+
+// matchAchievementMovement picks the movement whose name words are best
+// covered by the text, preferring the larger share of the name matched, then
+// more words matched. Ties are ambiguous and match nothing.
+func matchAchievementMovement(text string, movements []Movement) (Movement, bool) {
+	words := make(map[string]bool)
+	for _, w := range achievementWords(text) {
+		words[w] = true
+	}
+
+	var best Movement
+	bestCoverage, bestMatched, tied := 0.0, 0, false
+	for _, m := range movements {
+		nameWords := achievementWords(m.Name)
+		if len(nameWords) == 0 {
+			continue
+		}
+		matched := 0
+		for _, w := range nameWords {
+			if words[w] {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+		coverage := float64(matched) / float64(len(nameWords))
+		switch {
+		case coverage > bestCoverage || (coverage == bestCoverage && matched > bestMatched):
+			best, bestCoverage, bestMatched, tied = m, coverage, matched, false
+		case coverage == bestCoverage && matched == bestMatched:
+			tied = true
+		}
+	}
+	return best, bestMatched > 0 && !tied
+}
+
+// achievementWords lowercases, joins hyphenated words ("pull-ups" becomes
+// "pullups"), drops stop words and strips a plural s.
+func achievementWords(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "-", "")
+	var words []string
+	for _, w := range achievementWord.FindAllString(text, -1) {
+		if achievementStopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		words = append(words, w)
+	}
+	return words
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The registry is derived from its history and fed by free-text
+// echo achievements; tests pin which direction counts as better per kind, when
+// an entry is celebrated, and how achievements are read and matched to the
+// catalog.
+type PersonalRecordSuite struct {
+	suite.Suite
+	movements []Movement
+}
+
+func TestPersonalRecordSuite(t *testing.T) {
+	suite.Run(t, new(PersonalRecordSuite))
+}
+
+func (s *PersonalRecordSuite) SetupTest() {
+	s.movements = []Movement{
+		{ID: "gmb_bear", Name: "Bear Crawl"},
+		{ID: "cali_pullup_neg", Name: "Negative Pull-ups"},
+		{ID: "cali_pullup_std", Name: "Standard Pull-up"},
+		{ID: "cali_squat_air", Name: "Air Squat"},
+		{ID: "cali_squat_pistol", Name: "Pistol Squat"},
+		{ID: "cali_hollow_body", Name: "Hollow Body Hold"},
+		{ID: "cali_handstand_wall", Name: "Wall Handstand"},
+	}
+}
+
+func (s *PersonalRecordSuite) TestBestRecordsPerKind() {
+	history := []PersonalRecord{
+		{ID: 1, MovementID: "gmb_bear", Kind: RecordKindFastestCircuit, Value: 120, Date: "2026-03-01"},
+		{ID: 2, MovementID: "gmb_bear", Kind: RecordKindFastestCircuit, Value: 105, Date: "2026-03-08"},
+		{ID: 3, MovementID: "cali_pullup_std", Kind: RecordKindHeaviestLoad, Value: 10, Date: "2026-03-02"},
+		{ID: 4, MovementID: "cali_pullup_std", Kind: RecordKindHeaviestLoad, Value: 10, Date: "2026-03-09"},
+		{ID: 5, MovementID: "cali_pullup_std", Kind: RecordKindHeaviestLoad, Value: 7.5, Date: "2026-03-10"},
+	}
+
+	best := BestRecords(history)
+
+	s.Require().Len(best, 2)
+	s.Equal(int64(3), best[0].ID, "a tie keeps the earlier record")
+	s.Equal(int64(2), best[1].ID, "faster circuit wins")
+}
+
+func (s *PersonalRecordSuite) TestSelectNewRecordsCelebratesImprovementsOnly() {
+	history := []PersonalRecord{
+		{MovementID: "cali_hollow_body", Kind: RecordKindLongestHold, Value: 45, Date: "2026-03-01"},
+	}
+	candidates := []PersonalRecord{
+		{MovementID: "cali_hollow_body", Kind: RecordKindLongestHold, Value: 40},
+		{MovementID: "cali_hollow_body", Kind: RecordKindLongestHold, Value: 50},
+		{MovementID: "cali_hollow_body", Kind: RecordKindLongestHold, Value: 48},
+		{MovementID: "cali_hollow_body", Kind: RecordKindHeaviestLoad, Value: 5},
+	}
+
+	selected := SelectNewRecords(candidates, history)
+
+	s.Require().Len(selected, 2)
+	s.Equal(50.0, selected[0].Value)
+	s.True(selected[0].CelebrationPending)
+	s.Equal(RecordKindHeaviestLoad, selected[1].Kind)
+	s.False(selected[1].CelebrationPending, "the first entry sets a baseline")
+}
+
+func (s *PersonalRecordSuite) TestCandidatesFromSet() {
+	input := MovementProgressionInput{CompletedReps: 5, TargetReps: 5, LoadKg: 12.5, HoldSec: 20}
+
+	candidates := RecordCandidatesFromSet("cali_pullup_std", input, "2026-03-01")
+
+	s.Require().Len(candidates, 2)
+	s.Equal(RecordKindHeaviestLoad, candidates[0].Kind)
+	s.Equal(12.5, candidates[0].Value)
+	s.Equal(RecordSourceSetLog, candidates[0].Source)
+	s.Equal(RecordKindLongestHold, candidates[1].Kind)
+
+	input.HadFormIssue = true
+	s.Empty(RecordCandidatesFromSet("cali_pullup_std", input, "2026-03-01"))
+}
+
+func (s *PersonalRecordSuite) TestParseAchievementRecord() {
+	cases := []struct {
+		text     string
+		movement string
+		kind     RecordKind
+		value    float64
+	}{
+		{"30s handstand", "cali_handstand_wall", RecordKindLongestHold, 30},
+		{"held the hollow body for 1.5 min", "cali_hollow_body", RecordKindLongestHold, 90},
+		{"1:45 bear crawl circuit", "gmb_bear", RecordKindFastestCircuit, 105},
+		{"New PR: 24kg pistol squats", "cali_squat_pistol", RecordKindHeaviestLoad, 24},
+		{"10kg weighted pull-ups", "cali_pullup_std", RecordKindHeaviestLoad, 10},
+	}
+	for _, tc := range cases {
+		record, ok := ParseAchievementRecord(tc.text, s.movements, "2026-03-01")
+		s.Require().True(ok, tc.text)
+		s.Equal(tc.movement, record.MovementID, tc.text)
+		s.Equal(tc.kind, record.Kind, tc.text)
+		s.Equal(tc.value, record.Value, tc.text)
+		s.Equal(RecordSourceEcho, record.Source)
+		s.Equal(tc.text, record.Note)
+	}
+}
+
+func (s *PersonalRecordSuite) TestParseAchievementSkipsUnreadable() {
+	for _, text := range []string{
+		"10 pull-ups PR",        // Rep counts aren't tracked
+		"60s plank",             // Not in the catalog
+		"20kg squats",           // Air or pistol squat
+		"felt strong all round", // No value
+	} {
+		_, ok := ParseAchievementRecord(text, s.movements, "2026-03-01")
+		s.False(ok, text)
+	}
+}
+
+func (s *PersonalRecordSuite) TestValidate() {
+	valid := PersonalRecord{MovementID: "gmb_bear", Kind: RecordKindHeaviestLoad, Value: 20, Source: RecordSourceManual, Date: "2026-03-01"}
+	s.NoError(valid.Validate())
+
+	tooHeavy := valid
+	tooHeavy.Value = 600
+	s.ErrorIs(tooHeavy.Validate(), ErrInvalidRecordValue)
+
+	badDate := valid
+	badDate.Date = "March 1st"
+	s.ErrorIs(badDate.Validate(), ErrInvalidRecordDate)
+
+	badKind := valid
+	badKind.Kind = "most_reps"
+	s.ErrorIs(badKind.Validate(), ErrInvalidRecordKind)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// PERSONAL REFERENCE RANGES
+// =============================================================================
+//
+// Generic thresholds (a wearable's population HRV range, a fixed sleep quality
+// cut-off) misjudge users whose normal sits outside them: someone with
+// naturally low HRV reads as perpetually strained. Personal reference ranges
+// are percentiles of the user's own trailing 90 days, and replace the generic
+// thresholds wherever they are available.
+
+// ReferenceMetric identifies a metric with a personal reference range.
+type ReferenceMetric string
+
+const (
+	ReferenceMetricHRV          ReferenceMetric = "hrv"
+	ReferenceMetricRestingHR    ReferenceMetric = "resting_hr"
+	ReferenceMetricSleepQuality ReferenceMetric = "sleep_quality"
+)
+
+// ReferenceMetrics lists the metrics with personal reference ranges.
+var ReferenceMetrics = []ReferenceMetric{
+	ReferenceMetricHRV,
+	ReferenceMetricRestingHR,
+	ReferenceMetricSleepQuality,
+}
+
+const (
+	// PersonalReferenceWindowDays is the trailing window ranges are computed over.
+	PersonalReferenceWindowDays = 90
+	// MinPersonalReferenceSamples is how many logged values a metric needs in
+	// the window before its personal range replaces the generic thresholds.
+	MinPersonalReferenceSamples = 21
+)
+
+// PersonalReferenceRange is the distribution of one metric over the trailing
+// window.
+type PersonalReferenceRange struct {
+	Metric      ReferenceMetric
+	P10         float64
+	P25         float64
+	P50         float64
+	P75         float64
+	P90         float64
+	SampleCount int
+	WindowStart string // YYYY-MM-DD
+	WindowEnd   string // YYYY-MM-DD
+	ComputedAt  time.Time
+}
+
+// ComputePersonalReferenceRange builds a metric's range from the values
+// logged between windowStart and windowEnd. Non-positive values are ignored.
+// Returns nil when fewer than MinPersonalReferenceSamples values remain.
+func ComputePersonalReferenceRange(metric ReferenceMetric, values []float64, windowStart, windowEnd string) *PersonalReferenceRange {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if v > 0 {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) < MinPersonalReferenceSamples {
+		return nil
+	}
+	sort.Float64s(sorted)
+
+	return &PersonalReferenceRange{
+		Metric:      metric,
+		P10:         percentileOf(sorted, 0.10),
+		P25:         percentileOf(sorted, 0.25),
+		P50:         percentileOf(sorted, 0.50),
+		P75:         percentileOf(sorted, 0.75),
+		P90:         percentileOf(sorted, 0.90),
+		SampleCount: len(sorted),
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+	}
+}
+
+// percentileOf returns the q-th quantile of sorted values, interpolating
+// linearly between the closest ranks, rounded to 1 decimal.
+func percentileOf(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	v := sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+	return RoundTo(v, 1)
+}
+
+// PersonalReferenceRanges holds the available ranges; a nil range means the
+// generic thresholds apply to that metric.
+type PersonalReferenceRanges struct {
+	HRV          *PersonalReferenceRange
+	RestingHR    *PersonalReferenceRange
+	SleepQuality *PersonalReferenceRange
+}
+
+// NewPersonalReferenceRanges indexes stored ranges by metric.
+func NewPersonalReferenceRanges(ranges []PersonalReferenceRange) PersonalReferenceRanges {
+	var refs PersonalReferenceRanges
+	for i := range ranges {
+		r := &ranges[i]
+		switch r.Metric {
+		case ReferenceMetricHRV:
+			refs.HRV = r
+		case ReferenceMetricRestingHR:
+			refs.RestingHR = r
+		case ReferenceMetricSleepQuality:
+			refs.SleepQuality = r
+		}
+	}
+	return refs
+}
+
+// SleepQualityRatio maps a sleep quality average onto 0-1 against the user's
+// range: the 10th percentile and below score 0, the median and above 1.
+// Returns false when the range is too narrow to scale against.
+func (r *PersonalReferenceRange) SleepQualityRatio(avg float64) (float64, bool) {
+	if r == nil || r.P50 <= r.P10 {
+		return 0, false
+	}
+	return math.Max(0, math.Min((avg-r.P10)/(r.P50-r.P10), 1)), true
+}
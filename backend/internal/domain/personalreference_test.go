@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Personal ranges replace the generic HRV and sleep thresholds
+// in CNS classification, readiness and debriefs; tests pin the percentiles and
+// that a naturally low-HRV user isn't flagged for being themselves.
+type PersonalReferenceSuite struct {
+	suite.Suite
+}
+
+func TestPersonalReferenceSuite(t *testing.T) {
+	suite.Run(t, new(PersonalReferenceSuite))
+}
+
+// lowHRVRange is a user whose HRV normally sits around 30 ms, below a
+// typical wearable reference minimum.
+func lowHRVRange() *PersonalReferenceRange {
+	values := make([]float64, 0, 30)
+	for i := 0; i < 30; i++ {
+		values = append(values, float64(25+i%11)) // 25-35
+	}
+	return ComputePersonalReferenceRange(ReferenceMetricHRV, values, "2026-01-01", "2026-03-31")
+}
+
+func (s *PersonalReferenceSuite) TestPercentiles() {
+	values := make([]float64, 0, MinPersonalReferenceSamples)
+	for i := 1; i <= MinPersonalReferenceSamples; i++ {
+		values = append(values, float64(i*10))
+	}
+	values = append(values, 0) // Unlogged days are ignored
+
+	r := ComputePersonalReferenceRange(ReferenceMetricSleepQuality, values, "2026-01-01", "2026-03-31")
+	s.Require().NotNil(r)
+	s.Equal(MinPersonalReferenceSamples, r.SampleCount)
+	s.Equal(30.0, r.P10)
+	s.Equal(60.0, r.P25)
+	s.Equal(110.0, r.P50)
+	s.Equal(160.0, r.P75)
+	s.Equal(190.0, r.P90)
+
+	s.Nil(ComputePersonalReferenceRange(ReferenceMetricHRV, values[:MinPersonalReferenceSamples-1], "", ""),
+		"too few samples keeps the generic thresholds")
+}
+
+func (s *PersonalReferenceSuite) TestLowHRVUserIsNotStrainedByGenericRange() {
+	input := CNSInput{
+		CurrentHRV:   30,
+		HRVHistory:   []int{29, 31, 30, 28, 32, 30, 31},
+		ReferenceMin: cnsIntPtr(45),
+		ReferenceMax: cnsIntPtr(75),
+	}
+	generic := CalculateCNSStatus(input)
+	s.Require().NotNil(generic)
+	s.Equal(CNSStatusStrained, generic.Status, "below the wearable's population range")
+	s.Equal(CNSReferenceGarmin, generic.ReferenceSource)
+
+	input.PersonalHRV = lowHRVRange()
+	personal := CalculateCNSStatus(input)
+	s.Require().NotNil(personal)
+	s.Equal(CNSStatusOptimized, personal.Status)
+	s.Equal(CNSReferencePersonal, personal.ReferenceSource)
+	s.Equal(26, *personal.ReferenceMin)
+	s.False(personal.BelowReference)
+}
+
+func (s *PersonalReferenceSuite) TestDropMustLeaveUsualRange() {
+	// A week well above normal, then back to a usual value: >20% below the
+	// 7-day baseline, but not low for this user
+	input := CNSInput{
+		CurrentHRV: 32,
+		HRVHistory: []int{46, 46, 46, 46, 46, 31, 31},
+	}
+	s.Equal(CNSStatusStrained, CalculateCNSStatus(input).Status)
+
+	input.PersonalHRV = lowHRVRange()
+	s.Equal(CNSStatusOptimized, CalculateCNSStatus(input).Status)
+}
+
+func (s *PersonalReferenceSuite) TestRecoverySleepAgainstPersonalRange() {
+	sleep := &PersonalReferenceRange{Metric: ReferenceMetricSleepQuality, P10: 40, P25: 45, P50: 55, P75: 60, P90: 65}
+	input := RecoveryScoreInput{AvgSleepQualityL7: 55}
+
+	s.InDelta(11.0, CalculateRecoveryScore(input).SleepComponent, 0.001, "55/100 against the generic scale")
+	input.PersonalSleep = sleep
+	s.InDelta(SleepComponentMax, CalculateRecoveryScore(input).SleepComponent, 0.001, "a usual night for this user")
+
+	input.AvgSleepQualityL7 = 40
+	s.InDelta(0.0, CalculateRecoveryScore(input).SleepComponent, 0.001)
+}
+
+func (s *PersonalReferenceSuite) TestDebriefSleepFlag() {
+	s.True(sleepBelowNormal(55, nil))
+	s.False(sleepBelowNormal(55, &PersonalReferenceRange{P25: 50}))
+	s.True(sleepBelowNormal(70, &PersonalReferenceRange{P25: 75}), "flagged when below a high sleeper's usual range")
+}
@@ -28,6 +28,10 @@ type RecalibrationDetails struct {
 	ActualWeightKg               float64 `json:"actualWeightKg"`
 	FeasibilityTag               string  `json:"feasibilityTag,omitempty"`
 	Impact                       string  `json:"impact,omitempty"`
+	// Kcal factor auto-tuning
+	BeforeKcalFactor *float64 `json:"beforeKcalFactor,omitempty"`
+	AfterKcalFactor  *float64 `json:"afterKcalFactor,omitempty"`
+	ObservedTDEE     int      `json:"observedTDEE,omitempty"`
 }
 
 // NewRecalibrationRecord builds a record from the before/after plan state.
@@ -101,9 +105,19 @@ type NutritionPlan struct {
 	RequiredWeeklyChangeKg   float64    // Calculated: (goalWeight - startWeight) / durationWeeks
 	RequiredDailyDeficitKcal float64    // Calculated: requiredWeeklyChange * 7700 / 7
 	KcalFactorOverride       *float64   // Optional: if set, TDEE = Weight × KcalFactor instead of BMR-based
+	KcalFactorAutoTune       bool       // Adjust KcalFactorOverride every 2 weeks from observed results
+	KcalFactorTunedAt        *time.Time // When auto-tuning last evaluated the factor (nil if never)
+	Mode                     PlanMode   // weight (default) or recomp
+	StartBodyFatPercent      *float64   // Recomp: body fat at plan start
+	GoalBodyFatPercent       *float64   // Recomp: target body fat at plan end
+	EventDate                *time.Time // Event: weigh-in date the goal weight must be made by
+	EventName                string     // Event: competition name (optional)
 	Status                   PlanStatus
 	WeeklyTargets            []WeeklyTarget
-	LastRecalibratedAt       *time.Time // When the plan was last recalibrated (nil if never)
+	LastRecalibratedAt       *time.Time           // When the plan was last recalibrated (nil if never)
+	AbandonReasons           []PlanAbandonReason  // Exit survey reasons (abandoned plans only)
+	AbandonNote              string               // Exit survey free text
+	HistoryWarnings          []PlanHistoryWarning // Not persisted: set when creating, from abandoned plans
 	CreatedAt                time.Time
 	UpdatedAt                time.Time
 }
@@ -124,17 +138,20 @@ type WeeklyTarget struct {
 	ActualWeightKg   *float64 // Logged weight for the week (nil if not logged)
 	ActualIntakeKcal *int     // Average actual intake for the week
 	DaysLogged       int      // Number of days with logs in this week
+	DaysExpected     int      // Days that could be logged: 7, fewer before tracking started
+	Pinned           bool     // Manually edited; regeneration keeps these targets
 }
 
 // DailyPlanTarget represents the macro targets for a single day within a plan week.
 type DailyPlanTarget struct {
-	DayNumber int     // 1-7 within week (Monday=1)
-	DayType   DayType // performance, fatburner, metabolize
-	Date      time.Time
-	CarbsG    int
-	ProteinG  int
-	FatsG     int
-	Calories  int
+	WeekNumber int     // Plan week the day belongs to
+	DayNumber  int     // 1-7 within week (Monday=1)
+	DayType    DayType // performance, fatburner, metabolize
+	Date       time.Time
+	CarbsG     int
+	ProteinG   int
+	FatsG      int
+	Calories   int
 }
 
 // GenerateDailyTargets creates 7 daily targets from a weekly target using day type cycling.
@@ -153,30 +170,63 @@ func (w *WeeklyTarget) GenerateDailyTargets(pattern WeeklyDayPattern) []DailyPla
 		pattern,
 	)
 
-	dailyTargets := make([]DailyPlanTarget, 7)
+	// Round the week as a whole so the daily grams add up to 7× the weekly
+	// target; rounding each day on its own drifts the average by a gram or two.
+	var carbs, protein, fats [7]float64
 	for day := 1; day <= 7; day++ {
-		dayType := pattern.GetDayType(day)
-		mult := getDayTypeModifiers(dayType)
+		mult := getDayTypeModifiers(pattern.GetDayType(day))
+		carbs[day-1] = baseMacros.CarbsG * mult.Carbs
+		protein[day-1] = baseMacros.ProteinG * mult.Protein
+		fats[day-1] = baseMacros.FatsG * mult.Fats
+	}
+	carbsG := RoundPreservingSum(carbs[:])
+	proteinG := RoundPreservingSum(protein[:])
+	fatsG := RoundPreservingSum(fats[:])
 
-		carbsG := int(math.Round(baseMacros.CarbsG * mult.Carbs))
-		proteinG := int(math.Round(baseMacros.ProteinG * mult.Protein))
-		fatsG := int(math.Round(baseMacros.FatsG * mult.Fats))
-		calories := (carbsG * 4) + (proteinG * 4) + (fatsG * 9)
-
-		dailyTargets[day-1] = DailyPlanTarget{
-			DayNumber: day,
-			DayType:   dayType,
-			Date:      w.StartDate.AddDate(0, 0, day-1),
-			CarbsG:    carbsG,
-			ProteinG:  proteinG,
-			FatsG:     fatsG,
-			Calories:  calories,
+	dailyTargets := make([]DailyPlanTarget, 7)
+	for day := 1; day <= 7; day++ {
+		i := day - 1
+		calories := (carbsG[i] * 4) + (proteinG[i] * 4) + (fatsG[i] * 9)
+
+		dailyTargets[i] = DailyPlanTarget{
+			WeekNumber: w.WeekNumber,
+			DayNumber:  day,
+			DayType:    pattern.GetDayType(day),
+			Date:       w.StartDate.AddDate(0, 0, i),
+			CarbsG:     carbsG[i],
+			ProteinG:   proteinG[i],
+			FatsG:      fatsG[i],
+			Calories:   calories,
 		}
 	}
 
 	return dailyTargets
 }
 
+// DayPattern returns the week's day type pattern: pattern, with the days that
+// have a type in dayTypes (keyed by YYYY-MM-DD) taking that type instead.
+func (w *WeeklyTarget) DayPattern(pattern WeeklyDayPattern, dayTypes map[string]DayType) WeeklyDayPattern {
+	for day := 1; day <= 7; day++ {
+		if dayType, ok := dayTypes[w.StartDate.AddDate(0, 0, day-1).Format("2006-01-02")]; ok {
+			pattern.SetDayType(day, dayType)
+		}
+	}
+	return pattern
+}
+
+// GenerateDailyTargets creates the daily targets of every week of the plan,
+// in date order. Days with a type in dayTypes (keyed by YYYY-MM-DD) use it,
+// the rest follow pattern. These are materialized when the plan's weekly
+// targets are saved so past days keep the targets they were planned with.
+func (p *NutritionPlan) GenerateDailyTargets(pattern WeeklyDayPattern, dayTypes map[string]DayType) []DailyPlanTarget {
+	targets := make([]DailyPlanTarget, 0, len(p.WeeklyTargets)*7)
+	for i := range p.WeeklyTargets {
+		week := &p.WeeklyTargets[i]
+		targets = append(targets, week.GenerateDailyTargets(week.DayPattern(pattern, dayTypes))...)
+	}
+	return targets
+}
+
 // calculateBaseMacrosForCycling determines the base macros that, when day type multipliers
 // are applied across the week, will average to the target weekly macros.
 func calculateBaseMacrosForCycling(targetCarbsG, targetProteinG, targetFatsG float64, pattern WeeklyDayPattern) MacroAllocation {
@@ -204,12 +254,18 @@ func calculateBaseMacrosForCycling(targetCarbsG, targetProteinG, targetFatsG flo
 
 // NutritionPlanInput contains the required fields to create a new plan.
 type NutritionPlanInput struct {
-	Name               string   // User-defined plan name (optional)
-	StartDate          string   // YYYY-MM-DD format
-	StartWeightKg      float64
-	GoalWeightKg       float64
-	DurationWeeks      int
-	KcalFactorOverride *float64 // Optional: if set, TDEE = Weight × KcalFactor instead of BMR-based
+	Name                string   // User-defined plan name (optional)
+	StartDate           string   // YYYY-MM-DD format
+	StartWeightKg       float64
+	GoalWeightKg        float64
+	DurationWeeks       int
+	KcalFactorOverride  *float64 // Optional: if set, TDEE = Weight × KcalFactor instead of BMR-based
+	KcalFactorAutoTune  bool     // Optional: tune the override from observed results (requires KcalFactorOverride)
+	Mode                PlanMode // Optional: weight (default) or recomp
+	StartBodyFatPercent *float64 // Recomp only: current body fat
+	GoalBodyFatPercent  *float64 // Recomp only: target body fat
+	EventDate           string   // Event only: weigh-in date (YYYY-MM-DD); sets duration when DurationWeeks is 0
+	EventName           string   // Event only: competition name (optional)
 }
 
 // Plan validation constants
@@ -229,13 +285,25 @@ func NewNutritionPlan(input NutritionPlanInput, profile *UserProfile, now time.T
 	}
 
 	plan := &NutritionPlan{
-		Name:               input.Name,
-		StartDate:          startDate,
-		StartWeightKg:      input.StartWeightKg,
-		GoalWeightKg:       input.GoalWeightKg,
-		DurationWeeks:      input.DurationWeeks,
-		KcalFactorOverride: input.KcalFactorOverride,
-		Status:             PlanStatusActive,
+		Name:                input.Name,
+		StartDate:           startDate,
+		StartWeightKg:       input.StartWeightKg,
+		GoalWeightKg:        input.GoalWeightKg,
+		DurationWeeks:       input.DurationWeeks,
+		KcalFactorOverride:  input.KcalFactorOverride,
+		KcalFactorAutoTune:  input.KcalFactorAutoTune,
+		Mode:                input.Mode,
+		StartBodyFatPercent: input.StartBodyFatPercent,
+		GoalBodyFatPercent:  input.GoalBodyFatPercent,
+		Status:              PlanStatusActive,
+	}
+	if plan.Mode == "" {
+		plan.Mode = PlanModeWeight
+	}
+	if plan.IsEvent() {
+		if err := plan.setEvent(input.EventDate, input.EventName); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := plan.Validate(now); err != nil {
@@ -288,6 +356,30 @@ func (p *NutritionPlan) Validate(now time.Time) error {
 		return ErrPlanSurplusTooAggressive
 	}
 
+	// Kcal factor validation (zero or unset means BMR-based TDEE)
+	hasKcalFactor := p.KcalFactorOverride != nil && *p.KcalFactorOverride > 0
+	if hasKcalFactor && (*p.KcalFactorOverride < KcalFactorMin || *p.KcalFactorOverride > KcalFactorMax) {
+		return ErrInvalidKcalFactor
+	}
+	if p.KcalFactorAutoTune && !hasKcalFactor {
+		return ErrKcalFactorAutoTuneRequiresOverride
+	}
+
+	// Plan mode validation
+	if p.Mode != "" && !ValidPlanModes[p.Mode] {
+		return ErrInvalidPlanMode
+	}
+	if p.IsRecomp() {
+		if err := p.validateRecomp(); err != nil {
+			return err
+		}
+	}
+	if p.IsEvent() {
+		if err := p.validateEvent(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -310,18 +402,16 @@ func (p *NutritionPlan) generateWeeklyTargets(profile *UserProfile, now time.Tim
 
 		// Calculate projected weight (linear interpolation)
 		projectedWeight := p.StartWeightKg + (p.RequiredWeeklyChangeKg * float64(weekNum))
-		projectedWeight = math.Round(projectedWeight*10) / 10 // Round to 0.1 kg
+		projectedWeight = RoundTo(projectedWeight, 1) // Round to 0.1 kg
 
 		// Calculate projected TDEE for this weight
 		projectedTDEE := calculateProjectedTDEE(profile, p, projectedWeight, now)
 
 		// Calculate target intake (TDEE - deficit)
-		targetIntake := int(math.Round(float64(projectedTDEE) + p.RequiredDailyDeficitKcal))
+		targetIntake := RoundInt(float64(projectedTDEE) + p.RequiredDailyDeficitKcal)
 
-		// Calculate macro targets based on profile ratios
-		targetCarbsG, targetProteinG, targetFatsG := calculateMacroTargets(
-			targetIntake, profile.CarbRatio, profile.ProteinRatio, profile.FatRatio,
-		)
+		// Calculate macro targets (profile ratios, or protein-forward for recomp)
+		targetCarbsG, targetProteinG, targetFatsG := p.macroTargets(profile, targetIntake, projectedWeight)
 
 		targets[week] = WeeklyTarget{
 			PlanID:            p.ID,
@@ -335,6 +425,7 @@ func (p *NutritionPlan) generateWeeklyTargets(profile *UserProfile, now time.Tim
 			TargetProteinG:    targetProteinG,
 			TargetFatsG:       targetFatsG,
 			DaysLogged:        0,
+			DaysExpected:      DaysPerPlanWeek,
 		}
 	}
 
@@ -347,7 +438,7 @@ func (p *NutritionPlan) generateWeeklyTargets(profile *UserProfile, now time.Tim
 func calculateProjectedTDEE(profile *UserProfile, plan *NutritionPlan, weightKg float64, now time.Time) int {
 	// If KcalFactor override is set, use simple calculation
 	if plan != nil && plan.KcalFactorOverride != nil && *plan.KcalFactorOverride > 0 {
-		return int(math.Round(weightKg * *plan.KcalFactorOverride))
+		return RoundInt(weightKg * *plan.KcalFactorOverride)
 	}
 
 	// Default: BMR-based calculation
@@ -360,7 +451,7 @@ func calculateProjectedTDEE(profile *UserProfile, plan *NutritionPlan, weightKg
 	// Use NEAT multiplier (sedentary baseline)
 	tdee := bmr * NEATMultiplier
 
-	return int(math.Round(tdee))
+	return RoundInt(tdee)
 }
 
 // calculateMacroTargets computes gram targets from calorie target and ratios.
@@ -371,9 +462,9 @@ func calculateMacroTargets(targetCalories int, carbRatio, proteinRatio, fatRatio
 	proteinCalories := totalCalories * proteinRatio
 	fatCalories := totalCalories * fatRatio
 
-	carbsG = int(math.Round(carbCalories / CaloriesPerGramCarb))
-	proteinG = int(math.Round(proteinCalories / CaloriesPerGramProtein))
-	fatsG = int(math.Round(fatCalories / CaloriesPerGramFat))
+	carbsG = RoundInt(carbCalories / CaloriesPerGramCarb)
+	proteinG = RoundInt(proteinCalories / CaloriesPerGramProtein)
+	fatsG = RoundInt(fatCalories / CaloriesPerGramFat)
 
 	return carbsG, proteinG, fatsG
 }
@@ -413,6 +504,11 @@ func (p *NutritionPlan) GetWeeklyTarget(weekNum int) *WeeklyTarget {
 // ApplyRecalibration modifies a plan based on the selected recalibration strategy.
 // Returns a new plan with updated parameters and regenerated weekly targets.
 func ApplyRecalibration(plan *NutritionPlan, profile *UserProfile, optionType RecalibrationOptionType, now time.Time) (*NutritionPlan, error) {
+	// The event date is fixed, so the plan can't run past it
+	if plan.IsEvent() && optionType == RecalibrationExtendTimeline {
+		return nil, ErrEventTimelineFixed
+	}
+
 	currentWeek := plan.GetCurrentWeek(now)
 	weeksRemaining := plan.DurationWeeks - currentWeek
 	if weeksRemaining < 1 {
@@ -543,6 +639,7 @@ func applyReviseGoal(plan *NutritionPlan, profile *UserProfile, actualWeight flo
 }
 
 // regenerateWeeklyTargets creates new weekly targets from current week onwards.
+// Pinned weeks are carried over unchanged.
 func regenerateWeeklyTargets(plan *NutritionPlan, profile *UserProfile, currentWeight float64, now time.Time) []WeeklyTarget {
 	currentWeek := plan.GetCurrentWeek(now)
 
@@ -563,18 +660,16 @@ func regenerateWeeklyTargets(plan *NutritionPlan, profile *UserProfile, currentW
 
 		// Calculate projected weight (linear interpolation from current)
 		projectedWeight := currentWeight + (plan.RequiredWeeklyChangeKg * float64(weeksFromNow+1))
-		projectedWeight = math.Round(projectedWeight*10) / 10
+		projectedWeight = RoundTo(projectedWeight, 1)
 
 		// Calculate projected TDEE for this weight
 		projectedTDEE := calculateProjectedTDEE(profile, plan, projectedWeight, now)
 
 		// Calculate target intake (TDEE + deficit/surplus)
-		targetIntake := int(math.Round(float64(projectedTDEE) + plan.RequiredDailyDeficitKcal))
+		targetIntake := RoundInt(float64(projectedTDEE) + plan.RequiredDailyDeficitKcal)
 
-		// Calculate macro targets
-		targetCarbsG, targetProteinG, targetFatsG := calculateMacroTargets(
-			targetIntake, profile.CarbRatio, profile.ProteinRatio, profile.FatRatio,
-		)
+		// Calculate macro targets (profile ratios, or protein-forward for recomp)
+		targetCarbsG, targetProteinG, targetFatsG := plan.macroTargets(profile, targetIntake, projectedWeight)
 
 		target := WeeklyTarget{
 			PlanID:            plan.ID,
@@ -588,6 +683,13 @@ func regenerateWeeklyTargets(plan *NutritionPlan, profile *UserProfile, currentW
 			TargetProteinG:    targetProteinG,
 			TargetFatsG:       targetFatsG,
 			DaysLogged:        0,
+			DaysExpected:      DaysPerPlanWeek,
+		}
+
+		// Manually pinned weeks are kept as the user set them
+		if weekIndex < len(plan.WeeklyTargets) && plan.WeeklyTargets[weekIndex].Pinned {
+			targets = append(targets, plan.WeeklyTargets[weekIndex])
+			continue
 		}
 
 		// Preserve existing ID if we're updating an existing target
@@ -596,6 +698,7 @@ func regenerateWeeklyTargets(plan *NutritionPlan, profile *UserProfile, currentW
 			target.ActualWeightKg = plan.WeeklyTargets[weekIndex].ActualWeightKg
 			target.ActualIntakeKcal = plan.WeeklyTargets[weekIndex].ActualIntakeKcal
 			target.DaysLogged = plan.WeeklyTargets[weekIndex].DaysLogged
+			target.DaysExpected = plan.WeeklyTargets[weekIndex].DaysExpected
 		}
 
 		targets = append(targets, target)
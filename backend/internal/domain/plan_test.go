@@ -324,6 +324,36 @@ func (s *PlanSuite) TestWeeklyTargetsGeneration() {
 	})
 }
 
+func (s *PlanSuite) TestPlanDailyTargetsGeneration() {
+	plan, err := NewNutritionPlan(s.validInput(), s.profile, s.now)
+	s.Require().NoError(err)
+
+	daily := plan.GenerateDailyTargets(DefaultWeeklyPattern, nil)
+	s.Require().Len(daily, len(plan.WeeklyTargets)*7)
+	for i, day := range daily {
+		s.Equal(plan.StartDate.AddDate(0, 0, i), day.Date, "days are contiguous from the plan start")
+		s.Equal(i/7+1, day.WeekNumber)
+		s.Equal(i%7+1, day.DayNumber)
+	}
+
+	// Each week's days match that week's own generation
+	week := plan.WeeklyTargets[1]
+	s.Equal(week.GenerateDailyTargets(DefaultWeeklyPattern), daily[7:14])
+
+	s.Run("planned day types replace the pattern's", func() {
+		// Week 2's first day is a performance day in the default pattern
+		date := week.StartDate.Format("2006-01-02")
+		planned := plan.GenerateDailyTargets(DefaultWeeklyPattern, map[string]DayType{date: DayTypeMetabolize})
+
+		s.Equal(DayTypeMetabolize, planned[7].DayType)
+		s.Equal(daily[:7], planned[:7], "other weeks keep the pattern")
+
+		pattern := DefaultWeeklyPattern
+		pattern.SetDayType(1, DayTypeMetabolize)
+		s.Equal(week.GenerateDailyTargets(pattern), planned[7:14], "the week is rebalanced around the planned day")
+	})
+}
+
 func (s *PlanSuite) TestWeeklyTargetsWeightProjection() {
 	s.Run("projected weight decreases linearly for weight loss", func() {
 		input := s.validInput()
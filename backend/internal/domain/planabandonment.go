@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// PLAN ABANDONMENT EXIT SURVEY
+// =============================================================================
+//
+// Abandoning a plan can record why. New plans are checked against abandoned
+// plans with recorded reasons, so repeating a plan that was given up on
+// produces a warning before it is saved.
+
+// PlanAbandonReason is a structured reason for abandoning a plan.
+type PlanAbandonReason string
+
+const (
+	AbandonReasonTooAggressive  PlanAbandonReason = "too_aggressive"
+	AbandonReasonLifeEvent      PlanAbandonReason = "life_event"
+	AbandonReasonInjury         PlanAbandonReason = "injury"
+	AbandonReasonIllness        PlanAbandonReason = "illness"
+	AbandonReasonLostMotivation PlanAbandonReason = "lost_motivation"
+	AbandonReasonNoProgress     PlanAbandonReason = "no_progress"
+	AbandonReasonOther          PlanAbandonReason = "other"
+)
+
+// ValidAbandonReasons contains all valid abandonment reasons.
+var ValidAbandonReasons = map[PlanAbandonReason]bool{
+	AbandonReasonTooAggressive:  true,
+	AbandonReasonLifeEvent:      true,
+	AbandonReasonInjury:         true,
+	AbandonReasonIllness:        true,
+	AbandonReasonLostMotivation: true,
+	AbandonReasonNoProgress:     true,
+	AbandonReasonOther:          true,
+}
+
+// abandonReasonLabels describe each reason in warning messages.
+var abandonReasonLabels = map[PlanAbandonReason]string{
+	AbandonReasonTooAggressive:  "too aggressive",
+	AbandonReasonLifeEvent:      "a life event",
+	AbandonReasonInjury:         "an injury",
+	AbandonReasonIllness:        "illness",
+	AbandonReasonLostMotivation: "lost motivation",
+	AbandonReasonNoProgress:     "no progress",
+	AbandonReasonOther:          "other reasons",
+}
+
+const (
+	// MaxAbandonNoteLength caps the free-text note of an exit survey.
+	MaxAbandonNoteLength = 500
+
+	// AggressiveRepeatRateRatio is the share of a plan abandoned as too
+	// aggressive's weekly rate at which a new plan is just as aggressive.
+	AggressiveRepeatRateRatio = 0.9
+	// SimilarPlanRateTolerance and SimilarPlanDurationTolerance bound how far
+	// a new plan's weekly rate and duration may differ (as a share of the
+	// abandoned plan's) for it to repeat that plan.
+	SimilarPlanRateTolerance     = 0.2
+	SimilarPlanDurationTolerance = 0.25
+)
+
+// PlanExitSurvey records why a plan was abandoned.
+type PlanExitSurvey struct {
+	Reasons []PlanAbandonReason
+	Note    string // Optional free text
+}
+
+// Validate checks the reasons are known and the note fits. Duplicate reasons
+// are dropped. An empty survey is valid: the survey can be skipped.
+func (s *PlanExitSurvey) Validate() error {
+	seen := make(map[PlanAbandonReason]bool, len(s.Reasons))
+	reasons := s.Reasons[:0]
+	for _, r := range s.Reasons {
+		if !ValidAbandonReasons[r] {
+			return ErrInvalidAbandonReason
+		}
+		if !seen[r] {
+			seen[r] = true
+			reasons = append(reasons, r)
+		}
+	}
+	s.Reasons = reasons
+	s.Note = strings.TrimSpace(s.Note)
+	if len(s.Note) > MaxAbandonNoteLength {
+		return ErrAbandonNoteTooLong
+	}
+	return nil
+}
+
+// PlanHistoryWarning flags a new plan that repeats plans abandoned for the
+// same reason.
+type PlanHistoryWarning struct {
+	Reason      PlanAbandonReason
+	PlanIDs     []int64 // Abandoned plans the new plan repeats
+	Occurrences int     // How many abandoned plans it repeats for this reason
+	Message     string
+}
+
+// PlanHistoryWarnings checks a new plan against abandoned plans with exit
+// reasons. A plan repeats one abandoned as too aggressive when it goes the
+// same way at least AggressiveRepeatRateRatio of its weekly rate; for other
+// reasons when it goes the same way at a similar rate over a similar
+// duration. Repeats are aggregated into one warning per reason, most
+// frequent first.
+func PlanHistoryWarnings(plan *NutritionPlan, history []*NutritionPlan) []PlanHistoryWarning {
+	byReason := make(map[PlanAbandonReason][]*NutritionPlan)
+	for _, past := range history {
+		if past.Status != PlanStatusAbandoned || past.ID == plan.ID {
+			continue
+		}
+		for _, reason := range past.AbandonReasons {
+			if repeatsAbandonedPlan(plan, past, reason) {
+				byReason[reason] = append(byReason[reason], past)
+			}
+		}
+	}
+
+	warnings := make([]PlanHistoryWarning, 0, len(byReason))
+	for reason, plans := range byReason {
+		warnings = append(warnings, newPlanHistoryWarning(plan, reason, plans))
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].Occurrences != warnings[j].Occurrences {
+			return warnings[i].Occurrences > warnings[j].Occurrences
+		}
+		return warnings[i].Reason < warnings[j].Reason
+	})
+	return warnings
+}
+
+// repeatsAbandonedPlan reports whether plan repeats past for the given reason.
+func repeatsAbandonedPlan(plan, past *NutritionPlan, reason PlanAbandonReason) bool {
+	if plan.Mode != past.Mode || !sameDirection(plan.RequiredWeeklyChangeKg, past.RequiredWeeklyChangeKg) {
+		return false
+	}
+	rate := math.Abs(plan.RequiredWeeklyChangeKg)
+	pastRate := math.Abs(past.RequiredWeeklyChangeKg)
+	if reason == AbandonReasonTooAggressive {
+		return pastRate > 0 && rate >= pastRate*AggressiveRepeatRateRatio
+	}
+	return withinShare(rate, pastRate, SimilarPlanRateTolerance) &&
+		withinShare(float64(plan.DurationWeeks), float64(past.DurationWeeks), SimilarPlanDurationTolerance)
+}
+
+// sameDirection reports whether two weekly changes both lose, both gain or
+// both maintain weight.
+func sameDirection(a, b float64) bool {
+	sign := func(v float64) int {
+		switch {
+		case v > 0:
+			return 1
+		case v < 0:
+			return -1
+		}
+		return 0
+	}
+	return sign(a) == sign(b)
+}
+
+// withinShare reports whether v is within share of ref.
+func withinShare(v, ref, share float64) bool {
+	return math.Abs(v-ref) <= ref*share
+}
+
+// newPlanHistoryWarning aggregates the abandoned plans repeated for one reason.
+func newPlanHistoryWarning(plan *NutritionPlan, reason PlanAbandonReason, plans []*NutritionPlan) PlanHistoryWarning {
+	ids := make([]int64, len(plans))
+	for i, p := range plans {
+		ids[i] = p.ID
+	}
+	warning := PlanHistoryWarning{Reason: reason, PlanIDs: ids, Occurrences: len(plans)}
+
+	label := abandonReasonLabels[reason]
+	rate := math.Abs(plan.RequiredWeeklyChangeKg)
+	past := plans[0]
+	switch {
+	case reason == AbandonReasonTooAggressive && len(plans) == 1:
+		warning.Message = fmt.Sprintf("You abandoned %s as too aggressive at %.2f kg/week; this plan asks for %.2f kg/week. Consider a slower rate or a longer timeline",
+			planLabel(past), math.Abs(past.RequiredWeeklyChangeKg), rate)
+	case reason == AbandonReasonTooAggressive:
+		warning.Message = fmt.Sprintf("%d earlier plans at this rate or slower were abandoned as too aggressive; this plan asks for %.2f kg/week. Consider a slower rate or a longer timeline",
+			len(plans), rate)
+	case len(plans) == 1:
+		warning.Message = fmt.Sprintf("This plan is similar to %s (%.2f kg/week over %d weeks), which was abandoned because of %s",
+			planLabel(past), math.Abs(past.RequiredWeeklyChangeKg), past.DurationWeeks, label)
+	default:
+		warning.Message = fmt.Sprintf("%d earlier plans similar to this one were abandoned because of %s", len(plans), label)
+	}
+	return warning
+}
+
+// planLabel names a plan in messages.
+func planLabel(p *NutritionPlan) string {
+	if p.Name != "" {
+		return fmt.Sprintf("%q", p.Name)
+	}
+	return fmt.Sprintf("the plan started %s", p.StartDate.Format("2006-01-02"))
+}
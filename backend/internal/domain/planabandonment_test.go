@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Abandonment reasons feed plan creation; tests pin survey
+// validation and which earlier plans a new plan is judged to repeat.
+type PlanAbandonmentSuite struct {
+	suite.Suite
+}
+
+func TestPlanAbandonmentSuite(t *testing.T) {
+	suite.Run(t, new(PlanAbandonmentSuite))
+}
+
+func abandonedPlan(id int64, weeklyChange float64, weeks int, reasons ...PlanAbandonReason) *NutritionPlan {
+	return &NutritionPlan{
+		ID:                     id,
+		Name:                   "Cut",
+		Mode:                   PlanModeWeight,
+		RequiredWeeklyChangeKg: weeklyChange,
+		DurationWeeks:          weeks,
+		Status:                 PlanStatusAbandoned,
+		AbandonReasons:         reasons,
+	}
+}
+
+func (s *PlanAbandonmentSuite) TestSurveyValidation() {
+	survey := PlanExitSurvey{
+		Reasons: []PlanAbandonReason{AbandonReasonInjury, AbandonReasonInjury, AbandonReasonLifeEvent},
+		Note:    "  knee  ",
+	}
+	s.Require().NoError(survey.Validate())
+	s.Equal([]PlanAbandonReason{AbandonReasonInjury, AbandonReasonLifeEvent}, survey.Reasons)
+	s.Equal("knee", survey.Note)
+
+	empty := PlanExitSurvey{}
+	s.NoError(empty.Validate(), "the survey can be skipped")
+
+	unknown := PlanExitSurvey{Reasons: []PlanAbandonReason{"bored"}}
+	s.ErrorIs(unknown.Validate(), ErrInvalidAbandonReason)
+
+	long := PlanExitSurvey{Note: strings.Repeat("x", MaxAbandonNoteLength+1)}
+	s.ErrorIs(long.Validate(), ErrAbandonNoteTooLong)
+}
+
+func (s *PlanAbandonmentSuite) TestTooAggressiveRepeats() {
+	history := []*NutritionPlan{abandonedPlan(1, -0.8, 12, AbandonReasonTooAggressive)}
+
+	slower := abandonedPlan(0, -0.5, 12)
+	slower.Status = PlanStatusActive
+	s.Empty(PlanHistoryWarnings(slower, history), "a clearly slower plan is fine")
+
+	same := abandonedPlan(0, -0.75, 20)
+	same.Status = PlanStatusActive
+	warnings := PlanHistoryWarnings(same, history)
+	s.Require().Len(warnings, 1, "duration doesn't matter when the rate was the problem")
+	s.Equal(AbandonReasonTooAggressive, warnings[0].Reason)
+	s.Equal([]int64{1}, warnings[0].PlanIDs)
+	s.Contains(warnings[0].Message, "0.80 kg/week")
+
+	gain := abandonedPlan(0, 0.8, 12)
+	gain.Status = PlanStatusActive
+	s.Empty(PlanHistoryWarnings(gain, history), "opposite direction")
+}
+
+func (s *PlanAbandonmentSuite) TestSimilarPlansAggregateByReason() {
+	history := []*NutritionPlan{
+		abandonedPlan(1, -0.5, 12, AbandonReasonInjury),
+		abandonedPlan(2, -0.55, 10, AbandonReasonInjury, AbandonReasonLifeEvent),
+		abandonedPlan(3, -0.5, 30, AbandonReasonInjury),
+		abandonedPlan(4, -0.5, 12),
+	}
+	completed := abandonedPlan(5, -0.5, 12, AbandonReasonInjury)
+	completed.Status = PlanStatusCompleted
+	history = append(history, completed)
+
+	plan := abandonedPlan(0, -0.5, 12)
+	plan.Status = PlanStatusActive
+	warnings := PlanHistoryWarnings(plan, history)
+
+	s.Require().Len(warnings, 2)
+	s.Equal(AbandonReasonInjury, warnings[0].Reason, "most frequent reason first")
+	s.Equal(2, warnings[0].Occurrences, "different durations and plans without reasons don't count")
+	s.Equal([]int64{1, 2}, warnings[0].PlanIDs)
+	s.Equal(AbandonReasonLifeEvent, warnings[1].Reason)
+	s.Contains(warnings[1].Message, "a life event")
+}
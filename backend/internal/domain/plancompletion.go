@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =============================================================================
+// PLAN COMPLETION
+// =============================================================================
+//
+// A plan whose final week has passed is completed: its last weekly actuals are
+// rolled up and archived as they stand, a retrospective compares what was
+// planned with what happened, and the next phase is offered as maintenance, a
+// reverse phase or a new cut. Each option comes with plan inputs for the
+// simulator, starting today from the plan's final weight. Options are built
+// when asked for, since a plan can't start more than a week in the past.
+
+// Next phase defaults.
+const (
+	NextPhaseMaintenanceWeeks = 8    // Maintenance phase length
+	NextPhaseReverseWeeks     = 6    // Reverse phase length
+	NextPhaseReverseRateKg    = 0.1  // Weekly change of a reverse phase (~110 kcal/day)
+	NextPhaseCutPercent       = 0.05 // New cut target when the last goal was reached: 5% of weight
+	NextPhaseCutRatePercent   = 0.005
+	NextPhaseMaxCutWeeks      = 16
+	NextPhaseLongCutWeeks     = 12   // From this length, a maintenance phase comes first
+	RetrospectiveGoalMarginKg = 0.5  // Within this of the goal counts as reaching it
+	minNextPhaseCutRateKg     = 0.25 // Slowest weekly loss a new cut is planned at
+)
+
+// PlanRetrospective compares a completed plan's targets with what happened.
+type PlanRetrospective struct {
+	PlanID              int64    `json:"planId"`
+	PlanName            string   `json:"planName,omitempty"`
+	StartDate           string   `json:"startDate"`   // YYYY-MM-DD
+	EndDate             string   `json:"endDate"`     // Last day of the final week
+	CompletedOn         string   `json:"completedOn"` // YYYY-MM-DD
+	DurationWeeks       int      `json:"durationWeeks"`
+	StartWeightKg       float64  `json:"startWeightKg"`
+	GoalWeightKg        float64  `json:"goalWeightKg"`
+	FinalWeightKg       *float64 `json:"finalWeightKg,omitempty"` // Latest weekly weight (nil if never weighed)
+	PlannedChangeKg     float64  `json:"plannedChangeKg"`
+	ActualChangeKg      *float64 `json:"actualChangeKg,omitempty"`
+	GoalProgressPercent *float64 `json:"goalProgressPercent,omitempty"` // Actual over planned change
+	GoalReached         bool     `json:"goalReached"`
+	AvgTargetKcal       int      `json:"avgTargetKcal"`
+	AvgIntakeKcal       *int     `json:"avgIntakeKcal,omitempty"` // Over weeks with intake logged
+	DaysLogged          int      `json:"daysLogged"`
+	DaysExpected        int      `json:"daysExpected"`
+	LoggingAdherence    *float64 `json:"loggingAdherence,omitempty"` // Logged over expected days (0-1)
+	WeeksWeighed        int      `json:"weeksWeighed"`
+	WeeksOnTrack        int      `json:"weeksOnTrack"` // Weighed weeks within tolerance of the projection
+	Recalibrations      int      `json:"recalibrations"`
+	Highlights          []string `json:"highlights"`
+}
+
+// PlanCompletionDue reports whether the plan is active and its final week
+// ended before now.
+func (p *NutritionPlan) PlanCompletionDue(now time.Time) bool {
+	if !p.IsActive() || len(p.WeeklyTargets) == 0 {
+		return false
+	}
+	last := p.WeeklyTargets[len(p.WeeklyTargets)-1]
+	return dateOnly(now).After(dateOnly(last.EndDate))
+}
+
+// BuildPlanRetrospective summarizes a plan from its final weekly actuals.
+// tolerancePercent is the recalibration tolerance weeks are judged on track
+// with (0 means the default 3%).
+func BuildPlanRetrospective(plan *NutritionPlan, recalibrations int, tolerancePercent float64, now time.Time) PlanRetrospective {
+	if tolerancePercent == 0 {
+		tolerancePercent = 3
+	}
+	r := PlanRetrospective{
+		PlanID:          plan.ID,
+		PlanName:        plan.Name,
+		StartDate:       plan.StartDate.Format("2006-01-02"),
+		EndDate:         plan.StartDate.AddDate(0, 0, 7*plan.DurationWeeks-1).Format("2006-01-02"),
+		CompletedOn:     now.Format("2006-01-02"),
+		DurationWeeks:   plan.DurationWeeks,
+		StartWeightKg:   plan.StartWeightKg,
+		GoalWeightKg:    plan.GoalWeightKg,
+		PlannedChangeKg: RoundTo(plan.GoalWeightKg-plan.StartWeightKg, 1),
+		Recalibrations:  recalibrations,
+		Highlights:      []string{},
+	}
+	if n := len(plan.WeeklyTargets); n > 0 {
+		r.EndDate = plan.WeeklyTargets[n-1].EndDate.Format("2006-01-02")
+	}
+
+	var targetSum, intakeSum, intakeWeeks int
+	for _, w := range plan.WeeklyTargets {
+		targetSum += w.TargetIntakeKcal
+		if w.ActualIntakeKcal != nil {
+			intakeSum += *w.ActualIntakeKcal
+			intakeWeeks++
+		}
+		r.DaysLogged += w.DaysLogged
+		r.DaysExpected += w.DaysExpected
+		if w.ActualWeightKg != nil {
+			r.WeeksWeighed++
+			final := *w.ActualWeightKg
+			r.FinalWeightKg = &final
+			if math.Abs(final-w.ProjectedWeightKg)/w.ProjectedWeightKg*100 < tolerancePercent {
+				r.WeeksOnTrack++
+			}
+		}
+	}
+	if n := len(plan.WeeklyTargets); n > 0 {
+		r.AvgTargetKcal = int(math.Round(float64(targetSum) / float64(n)))
+	}
+	if intakeWeeks > 0 {
+		avg := int(math.Round(float64(intakeSum) / float64(intakeWeeks)))
+		r.AvgIntakeKcal = &avg
+	}
+	if r.DaysExpected > 0 {
+		adherence := RoundTo(math.Min(float64(r.DaysLogged)/float64(r.DaysExpected), 1), 2)
+		r.LoggingAdherence = &adherence
+	}
+
+	if r.FinalWeightKg != nil {
+		change := RoundTo(*r.FinalWeightKg-plan.StartWeightKg, 1)
+		r.ActualChangeKg = &change
+		if r.PlannedChangeKg != 0 {
+			progress := RoundTo(change/r.PlannedChangeKg*100, 0)
+			r.GoalProgressPercent = &progress
+		}
+		remaining := plan.GoalWeightKg - *r.FinalWeightKg
+		switch {
+		case r.PlannedChangeKg < 0:
+			r.GoalReached = remaining >= -RetrospectiveGoalMarginKg
+		case r.PlannedChangeKg > 0:
+			r.GoalReached = remaining <= RetrospectiveGoalMarginKg
+		default:
+			r.GoalReached = math.Abs(remaining) <= RetrospectiveGoalMarginKg
+		}
+	}
+
+	r.Highlights = retrospectiveHighlights(r)
+	return r
+}
+
+// retrospectiveHighlights writes the retrospective's summary lines.
+func retrospectiveHighlights(r PlanRetrospective) []string {
+	var lines []string
+	switch {
+	case r.ActualChangeKg == nil:
+		lines = append(lines, "No weigh-ins were logged, so the result can't be judged.")
+	case r.GoalReached:
+		lines = append(lines, fmt.Sprintf("Goal reached: %+.1f kg against %+.1f kg planned.", *r.ActualChangeKg, r.PlannedChangeKg))
+	default:
+		lines = append(lines, fmt.Sprintf("Finished %.1f kg from the goal: %+.1f kg against %+.1f kg planned.",
+			math.Abs(r.GoalWeightKg-*r.FinalWeightKg), *r.ActualChangeKg, r.PlannedChangeKg))
+	}
+	if r.WeeksWeighed > 0 {
+		lines = append(lines, fmt.Sprintf("On track in %d of %d weighed weeks.", r.WeeksOnTrack, r.WeeksWeighed))
+	}
+	if r.AvgIntakeKcal != nil {
+		lines = append(lines, fmt.Sprintf("Average intake %d kcal against a %d kcal target.", *r.AvgIntakeKcal, r.AvgTargetKcal))
+	}
+	if r.LoggingAdherence != nil {
+		lines = append(lines, fmt.Sprintf("Logged %d of %d days (%.0f%%).", r.DaysLogged, r.DaysExpected, *r.LoggingAdherence*100))
+	}
+	if r.Recalibrations > 0 {
+		lines = append(lines, fmt.Sprintf("Recalibrated %d times along the way.", r.Recalibrations))
+	}
+	return lines
+}
+
+// NextPhaseType is a phase offered after a plan completes.
+type NextPhaseType string
+
+const (
+	NextPhaseMaintenance NextPhaseType = "maintenance"
+	NextPhaseReverse     NextPhaseType = "reverse"
+	NextPhaseNewCut      NextPhaseType = "new_cut"
+)
+
+// NextPhaseOption is one phase offered after a plan completes, with the plan
+// inputs to open the simulator with.
+type NextPhaseOption struct {
+	Type        NextPhaseType
+	Title       string
+	Rationale   string
+	Recommended bool
+	Input       NutritionPlanInput
+}
+
+// SuggestNextPhases offers maintenance, a reverse phase and a new cut after
+// plan, each starting today from the retrospective's final weight. The plan's
+// kcal factor carries over. One option is recommended: maintenance after a
+// reached goal, a long cut or a gain, otherwise another cut.
+func SuggestNextPhases(plan *NutritionPlan, r PlanRetrospective, now time.Time) []NextPhaseOption {
+	weight := plan.LatestActualWeight()
+	if r.FinalWeightKg != nil {
+		weight = *r.FinalWeightKg
+	}
+	weight = RoundTo(weight, 1)
+	base := NutritionPlanInput{
+		StartDate:          now.Format("2006-01-02"),
+		StartWeightKg:      weight,
+		KcalFactorOverride: plan.KcalFactorOverride,
+		KcalFactorAutoTune: plan.KcalFactorAutoTune,
+		Mode:               PlanModeWeight,
+	}
+	named := func(phase string) string {
+		if plan.Name == "" {
+			return phase
+		}
+		return fmt.Sprintf("%s after %s", phase, plan.Name)
+	}
+	wasCut := r.PlannedChangeKg < 0
+
+	maintenance := base
+	maintenance.Name = named("Maintenance")
+	maintenance.GoalWeightKg = weight
+	maintenance.DurationWeeks = NextPhaseMaintenanceWeeks
+
+	// A reverse phase eases intake back towards maintenance, so it runs a
+	// small surplus after a cut and a small deficit after a gain
+	reverse := base
+	reverse.Name = named("Reverse")
+	reverse.DurationWeeks = NextPhaseReverseWeeks
+	reverseChange := NextPhaseReverseRateKg * NextPhaseReverseWeeks
+	if !wasCut {
+		reverseChange = -reverseChange
+	}
+	reverse.GoalWeightKg = RoundTo(weight+reverseChange, 1)
+
+	cut := base
+	cut.Name = named("Cut")
+	rate := weight * NextPhaseCutRatePercent
+	if wasCut {
+		rate = -plan.RequiredWeeklyChangeKg
+	}
+	rate = math.Max(math.Min(rate, MaxSafeDeficitKcal*7/7700.0), minNextPhaseCutRateKg)
+	goal := weight * (1 - NextPhaseCutPercent)
+	if wasCut && !r.GoalReached && plan.GoalWeightKg < weight {
+		goal = plan.GoalWeightKg
+	}
+	weeks := int(math.Ceil((weight - goal) / rate))
+	weeks = max(MinPlanDurationWeeks, min(weeks, NextPhaseMaxCutWeeks))
+	cut.DurationWeeks = weeks
+	cut.GoalWeightKg = RoundTo(math.Max(goal, weight-rate*float64(weeks)), 1)
+
+	options := []NextPhaseOption{
+		{
+			Type:      NextPhaseMaintenance,
+			Title:     "Maintenance",
+			Rationale: fmt.Sprintf("Hold %.1f kg for %d weeks so the new weight settles before the next change.", weight, NextPhaseMaintenanceWeeks),
+			Input:     maintenance,
+		},
+		{
+			Type:      NextPhaseReverse,
+			Title:     "Reverse phase",
+			Rationale: fmt.Sprintf("Move intake back towards maintenance over %d weeks, accepting %+.1f kg.", NextPhaseReverseWeeks, reverseChange),
+			Input:     reverse,
+		},
+		{
+			Type:      NextPhaseNewCut,
+			Title:     "New cut",
+			Rationale: fmt.Sprintf("Lose %.1f kg over %d weeks at about %.2f kg a week.", weight-cut.GoalWeightKg, weeks, rate),
+			Input:     cut,
+		},
+	}
+
+	recommended := NextPhaseNewCut
+	if !wasCut || r.GoalReached || plan.DurationWeeks >= NextPhaseLongCutWeeks {
+		recommended = NextPhaseMaintenance
+	}
+	for i := range options {
+		options[i].Recommended = options[i].Type == recommended
+	}
+	return options
+}
+
+// PlanCompletion is a completed plan's retrospective with its archived weeks
+// and the next phases on offer.
+type PlanCompletion struct {
+	Retrospective PlanRetrospective
+	ArchivedWeeks []WeeklyTarget // Weekly targets as they stood at completion
+	NextPhases    []NextPhaseOption
+	PromptPending bool // The next phase prompt hasn't been answered or dismissed
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The retrospective is the record a finished plan leaves
+// behind, and every next phase it offers must open the simulator with inputs
+// the plan validator accepts; tests pin both without a database.
+type PlanCompletionSuite struct {
+	suite.Suite
+	start   time.Time
+	profile *UserProfile
+}
+
+func TestPlanCompletionSuite(t *testing.T) {
+	suite.Run(t, new(PlanCompletionSuite))
+}
+
+func (s *PlanCompletionSuite) SetupTest() {
+	s.start = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC) // Monday
+	s.profile = &UserProfile{
+		HeightCM:     180,
+		BirthDate:    time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		Sex:          SexMale,
+		Goal:         GoalLoseWeight,
+		CarbRatio:    0.45,
+		ProteinRatio: 0.30,
+		FatRatio:     0.25,
+		BMREquation:  BMREquationMifflinStJeor,
+	}
+}
+
+// cut builds an 8-week plan from 90 to 86 kg with every week logged; the
+// last week's weight is final.
+func (s *PlanCompletionSuite) cut(final float64) *NutritionPlan {
+	plan, err := NewNutritionPlan(NutritionPlanInput{
+		Name:          "Winter Cut",
+		StartDate:     s.start.Format("2006-01-02"),
+		StartWeightKg: 90,
+		GoalWeightKg:  86,
+		DurationWeeks: 8,
+	}, s.profile, s.start)
+	s.Require().NoError(err)
+	plan.ID = 4
+	for i := range plan.WeeklyTargets {
+		w := &plan.WeeklyTargets[i]
+		weight := w.ProjectedWeightKg
+		if i == len(plan.WeeklyTargets)-1 {
+			weight = final
+		}
+		intake := w.TargetIntakeKcal + 100
+		w.ActualWeightKg, w.ActualIntakeKcal = &weight, &intake
+		w.DaysLogged, w.DaysExpected = 6, 7
+	}
+	return plan
+}
+
+func (s *PlanCompletionSuite) TestDueOnceTheFinalWeekHasPassed() {
+	plan := s.cut(86)
+	lastDay := s.start.AddDate(0, 0, 55)
+	s.False(plan.PlanCompletionDue(lastDay.Add(20*time.Hour)), "final Sunday is still part of the plan")
+	s.True(plan.PlanCompletionDue(lastDay.AddDate(0, 0, 1)))
+
+	plan.Status = PlanStatusPaused
+	s.False(plan.PlanCompletionDue(lastDay.AddDate(0, 0, 1)), "a paused plan waits for the user")
+}
+
+func (s *PlanCompletionSuite) TestRetrospective() {
+	plan := s.cut(86.2)
+	r := BuildPlanRetrospective(plan, 2, 0, s.start.AddDate(0, 0, 56))
+
+	s.Equal("2026-03-01", r.EndDate)
+	s.Equal("2026-03-02", r.CompletedOn)
+	s.Equal(-4.0, r.PlannedChangeKg)
+	s.Require().NotNil(r.ActualChangeKg)
+	s.Equal(-3.8, *r.ActualChangeKg)
+	s.Equal(95.0, *r.GoalProgressPercent)
+	s.True(r.GoalReached, "within half a kilo of the goal")
+	s.Equal(8, r.WeeksWeighed)
+	s.Equal(8, r.WeeksOnTrack)
+	s.Equal(r.AvgTargetKcal+100, *r.AvgIntakeKcal)
+	s.Equal(48, r.DaysLogged)
+	s.Equal(0.86, *r.LoggingAdherence)
+	s.Contains(r.Highlights, "Recalibrated 2 times along the way.")
+
+	missed := BuildPlanRetrospective(s.cut(89), 0, 0, s.start.AddDate(0, 0, 56))
+	s.False(missed.GoalReached)
+	s.Equal(7, missed.WeeksOnTrack, "the final week was 3 kg off")
+	s.Equal("Finished 3.0 kg from the goal: -1.0 kg against -4.0 kg planned.", missed.Highlights[0])
+}
+
+func (s *PlanCompletionSuite) TestRetrospectiveWithoutWeighIns() {
+	plan := s.cut(86)
+	for i := range plan.WeeklyTargets {
+		plan.WeeklyTargets[i].ActualWeightKg = nil
+	}
+	r := BuildPlanRetrospective(plan, 0, 0, s.start.AddDate(0, 0, 56))
+	s.Nil(r.FinalWeightKg)
+	s.Nil(r.ActualChangeKg)
+	s.False(r.GoalReached)
+	s.Equal("No weigh-ins were logged, so the result can't be judged.", r.Highlights[0])
+}
+
+func (s *PlanCompletionSuite) TestNextPhasesAreValidPlans() {
+	now := s.start.AddDate(0, 0, 57)
+	for _, final := range []float64{86, 88} {
+		plan := s.cut(final)
+		r := BuildPlanRetrospective(plan, 0, 0, now)
+		options := SuggestNextPhases(plan, r, now)
+		s.Require().Len(options, 3)
+		for _, o := range options {
+			s.Equal(final, o.Input.StartWeightKg)
+			s.Equal("2026-03-03", o.Input.StartDate)
+			_, err := NewNutritionPlan(o.Input, s.profile, now)
+			s.NoError(err, "%s after finishing at %.0f kg", o.Type, final)
+		}
+	}
+}
+
+func (s *PlanCompletionSuite) TestNextPhaseRecommendation() {
+	now := s.start.AddDate(0, 0, 57)
+	recommended := func(plan *NutritionPlan) NextPhaseOption {
+		options := SuggestNextPhases(plan, BuildPlanRetrospective(plan, 0, 0, now), now)
+		for _, o := range options {
+			if o.Recommended {
+				return o
+			}
+		}
+		s.FailNow("no option recommended")
+		return NextPhaseOption{}
+	}
+
+	s.Equal(NextPhaseMaintenance, recommended(s.cut(86)).Type, "goal reached")
+
+	short := recommended(s.cut(88))
+	s.Equal(NextPhaseNewCut, short.Type, "a short cut that missed continues")
+	s.Equal(86.0, short.Input.GoalWeightKg, "towards the old goal")
+	s.Equal(4, short.Input.DurationWeeks)
+	s.Equal("Cut after Winter Cut", short.Input.Name)
+
+	long := s.cut(88)
+	long.DurationWeeks = NextPhaseLongCutWeeks
+	s.Equal(NextPhaseMaintenance, recommended(long).Type, "a long cut takes a break first")
+}
+
+func (s *PlanCompletionSuite) TestReversePhaseFollowsThePlanDirection() {
+	now := s.start.AddDate(0, 0, 57)
+	plan := s.cut(86)
+	reverse := SuggestNextPhases(plan, BuildPlanRetrospective(plan, 0, 0, now), now)[1]
+	s.Equal(NextPhaseReverse, reverse.Type)
+	s.Equal(86.6, reverse.Input.GoalWeightKg, "a small surplus after a cut")
+
+	gain := s.cut(86)
+	gain.GoalWeightKg = 94
+	r := BuildPlanRetrospective(gain, 0, 0, now)
+	options := SuggestNextPhases(gain, r, now)
+	s.Equal(85.4, options[1].Input.GoalWeightKg, "a small deficit after a gain")
+	s.True(options[0].Recommended)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// LATE-START PLAN PRORATION
+// =============================================================================
+//
+// Plan weeks run 7 days from the plan's start date, but logging can begin
+// later: a plan may be created with a start date earlier in the week, or
+// joined mid-plan with imported history. Days before tracking started can't
+// have been logged, so each week's logging is judged against the days it
+// could have been logged (DaysExpected) rather than all 7.
+
+const (
+	// DaysPerPlanWeek is the length of a plan week.
+	DaysPerPlanWeek = 7
+	// CompleteWeekLoggedDays is how many of a full week's days must be logged
+	// for the week to count as complete; partial weeks need the same share.
+	CompleteWeekLoggedDays = 5
+)
+
+// PlanTrackingStart returns the first day the plan's logging is expected:
+// the earlier of the day the plan was created and the first day logged on or
+// after its start (firstLogDate, YYYY-MM-DD, empty if none), never before the
+// plan's start date.
+func PlanTrackingStart(plan *NutritionPlan, firstLogDate string) time.Time {
+	start := dateOnly(plan.StartDate)
+	tracking := dateOnly(plan.CreatedAt)
+	if plan.CreatedAt.IsZero() {
+		tracking = start
+	}
+	if first, err := time.Parse("2006-01-02", firstLogDate); err == nil && first.Before(tracking) {
+		tracking = first
+	}
+	if tracking.Before(start) {
+		return start
+	}
+	return tracking
+}
+
+// ExpectedDays returns how many of the week's days fall on or after
+// trackingStart.
+func (w WeeklyTarget) ExpectedDays(trackingStart time.Time) int {
+	tracking := dateOnly(trackingStart)
+	start := dateOnly(w.StartDate)
+	if !tracking.After(start) {
+		return DaysPerPlanWeek
+	}
+	skipped := int(tracking.Sub(start).Hours() / 24)
+	return int(math.Max(float64(DaysPerPlanWeek-skipped), 0))
+}
+
+// Prorated reports whether the week started before tracking did.
+func (w WeeklyTarget) Prorated() bool {
+	return w.DaysExpected < DaysPerPlanWeek
+}
+
+// RequiredLoggedDays is how many days of the week must be logged for it to
+// count as complete: CompleteWeekLoggedDays of 7, scaled to the expected days.
+func (w WeeklyTarget) RequiredLoggedDays() int {
+	if w.DaysExpected <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(CompleteWeekLoggedDays*w.DaysExpected) / DaysPerPlanWeek))
+}
+
+// LoggingComplete reports whether enough of the week's expected days were
+// logged. Weeks that ended before tracking started are never complete; they
+// have nothing to judge.
+func (w WeeklyTarget) LoggingComplete() bool {
+	return w.DaysExpected > 0 && w.DaysLogged >= w.RequiredLoggedDays()
+}
+
+// LoggingAdherence returns the fraction of the week's expected days that were
+// logged (capped at 1), or nil when no day was expected.
+func (w WeeklyTarget) LoggingAdherence() *float64 {
+	if w.DaysExpected <= 0 {
+		return nil
+	}
+	adherence := math.Min(float64(w.DaysLogged)/float64(w.DaysExpected), 1)
+	adherence = RoundTo(adherence, 2)
+	return &adherence
+}
+
+// dateOnly truncates t to midnight UTC of its calendar date.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
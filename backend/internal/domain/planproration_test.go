@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Weeks that began before logging did must not read as missed
+// in the plan overview; tests pin the tracking start, the expected days of a
+// partial week and the prorated completeness threshold.
+type PlanProrationSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func TestPlanProrationSuite(t *testing.T) {
+	suite.Run(t, new(PlanProrationSuite))
+}
+
+func (s *PlanProrationSuite) SetupTest() {
+	s.start = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *PlanProrationSuite) TestTrackingStart() {
+	plan := &NutritionPlan{StartDate: s.start, CreatedAt: time.Date(2026, 3, 4, 19, 30, 0, 0, time.UTC)}
+
+	s.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), PlanTrackingStart(plan, ""),
+		"backdated plan without logs is tracked from creation")
+	s.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), PlanTrackingStart(plan, "2026-03-03"),
+		"logs before creation count")
+	s.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), PlanTrackingStart(plan, "2026-03-20"),
+		"later first log doesn't delay tracking")
+
+	imported := &NutritionPlan{StartDate: s.start, CreatedAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)}
+	s.Equal(time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC), PlanTrackingStart(imported, "2026-03-12"),
+		"joined mid-plan with imported history")
+
+	early := &NutritionPlan{StartDate: s.start, CreatedAt: time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)}
+	s.Equal(s.start, PlanTrackingStart(early, ""), "never before the plan start")
+}
+
+func (s *PlanProrationSuite) TestExpectedDays() {
+	week1 := WeeklyTarget{WeekNumber: 1, StartDate: s.start, EndDate: s.start.AddDate(0, 0, 6)}
+	week2 := WeeklyTarget{WeekNumber: 2, StartDate: s.start.AddDate(0, 0, 7), EndDate: s.start.AddDate(0, 0, 13)}
+
+	s.Equal(7, week1.ExpectedDays(s.start))
+	s.Equal(5, week1.ExpectedDays(s.start.AddDate(0, 0, 2)))
+	s.Equal(7, week2.ExpectedDays(s.start.AddDate(0, 0, 2)))
+	s.Equal(0, week1.ExpectedDays(s.start.AddDate(0, 0, 10)), "week ended before tracking")
+	s.Equal(4, week2.ExpectedDays(s.start.AddDate(0, 0, 10)))
+}
+
+func (s *PlanProrationSuite) TestPartialWeekIsNotMissed() {
+	full := WeeklyTarget{DaysExpected: 7, DaysLogged: 4}
+	s.False(full.Prorated())
+	s.Equal(5, full.RequiredLoggedDays())
+	s.False(full.LoggingComplete())
+	s.InDelta(0.57, *full.LoggingAdherence(), 0.001)
+
+	partial := WeeklyTarget{DaysExpected: 3, DaysLogged: 3}
+	s.True(partial.Prorated())
+	s.Equal(3, partial.RequiredLoggedDays(), "5/7 of 3 days rounds up")
+	s.True(partial.LoggingComplete())
+	s.InDelta(1.0, *partial.LoggingAdherence(), 0.001)
+
+	untracked := WeeklyTarget{DaysExpected: 0}
+	s.False(untracked.LoggingComplete())
+	s.Nil(untracked.LoggingAdherence())
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// PlateauType identifies what kind of progress has stalled.
+type PlateauType string
+
+const (
+	PlateauTypeWeight   PlateauType = "weight"
+	PlateauTypeStrength PlateauType = "strength"
+)
+
+// PlateauBreakerType identifies a targeted intervention for a plateau.
+type PlateauBreakerType string
+
+const (
+	PlateauBreakerDietBreak         PlateauBreakerType = "diet_break"
+	PlateauBreakerRefeed            PlateauBreakerType = "refeed"
+	PlateauBreakerDeload            PlateauBreakerType = "deload"
+	PlateauBreakerExerciseVariation PlateauBreakerType = "exercise_variation"
+	PlateauBreakerReversePhase      PlateauBreakerType = "reverse_phase"
+)
+
+// Plateau detection thresholds.
+const (
+	WeightPlateauMinDays          = 21   // Flat trend must span at least 3 weeks
+	WeightPlateauMaxWeeklyChange  = 0.1  // |kg/week| at or below this is considered flat
+	WeightPlateauMinAdherence     = 0.8  // Fraction of logged days within intake tolerance
+	WeightPlateauIntakeTolerance  = 0.10 // ±10% of target calories counts as adherent
+	StrengthPlateauSessions       = 8    // Sessions at unchanged difficulty before flagging
+	WeightPlateauDietBreakMinDays = 42   // Long plateaus on a cut warrant a full diet break
+)
+
+// SubjectBodyweight is the plateau subject used for weight plateaus.
+const SubjectBodyweight = "bodyweight"
+
+// PlateauBreaker is a targeted recommendation for breaking a specific plateau.
+type PlateauBreaker struct {
+	Type      PlateauBreakerType `json:"type"`
+	Title     string             `json:"title"`
+	Action    string             `json:"action"`
+	Rationale string             `json:"rationale"`
+}
+
+// PlateauDetection records a detected plateau and its suggested breakers.
+type PlateauDetection struct {
+	ID              int64            `json:"id,omitempty"`
+	Type            PlateauType      `json:"type"`
+	Subject         string           `json:"subject"`    // "bodyweight" or a movement ID
+	DetectedOn      string           `json:"detectedOn"` // YYYY-MM-DD
+	DurationDays    int              `json:"durationDays,omitempty"`
+	SessionCount    int              `json:"sessionCount,omitempty"`
+	Metric          float64          `json:"metric"` // kg/week for weight, difficulty for strength
+	Recommendations []PlateauBreaker `json:"recommendations"`
+}
+
+// CalculateIntakeAdherence returns the fraction of logs with consumed calories
+// within WeightPlateauIntakeTolerance of the day's target. Logs without a target
+// or without any logged intake are ignored. Returns 0 if nothing is comparable.
+func CalculateIntakeAdherence(logs []DailyLog) float64 {
+	var comparable, adherent int
+	for _, l := range logs {
+		target := l.CalculatedTargets.TotalCalories
+		if target <= 0 || l.ConsumedCalories <= 0 {
+			continue
+		}
+		comparable++
+		deviation := math.Abs(float64(l.ConsumedCalories-target)) / float64(target)
+		if deviation <= WeightPlateauIntakeTolerance {
+			adherent++
+		}
+	}
+	if comparable == 0 {
+		return 0
+	}
+	return float64(adherent) / float64(comparable)
+}
+
+// DetectWeightPlateau flags a flat weight trend over at least WeightPlateauMinDays
+// while the user is adherent. Only applies to goals that expect weight change.
+// Samples must be ordered by date. Returns nil when no plateau is detected.
+func DetectWeightPlateau(samples []WeightSample, adherence float64, goal Goal, now time.Time) *PlateauDetection {
+	if goal == GoalMaintain || len(samples) < 2 || adherence < WeightPlateauMinAdherence {
+		return nil
+	}
+
+	first, err := time.Parse("2006-01-02", samples[0].Date)
+	if err != nil {
+		return nil
+	}
+	last, err := time.Parse("2006-01-02", samples[len(samples)-1].Date)
+	if err != nil {
+		return nil
+	}
+	spanDays := int(last.Sub(first).Hours() / 24)
+	if spanDays < WeightPlateauMinDays {
+		return nil
+	}
+
+	trend := CalculateWeightTrend(samples)
+	if trend == nil || math.Abs(trend.WeeklyChangeKg) > WeightPlateauMaxWeeklyChange {
+		return nil
+	}
+
+	return &PlateauDetection{
+		Type:            PlateauTypeWeight,
+		Subject:         SubjectBodyweight,
+		DetectedOn:      now.Format("2006-01-02"),
+		DurationDays:    spanDays,
+		Metric:          math.Round(trend.WeeklyChangeKg*100) / 100,
+		Recommendations: weightPlateauBreakers(goal, spanDays),
+	}
+}
+
+func weightPlateauBreakers(goal Goal, spanDays int) []PlateauBreaker {
+	if goal == GoalGainWeight {
+		return []PlateauBreaker{{
+			Type:      PlateauBreakerRefeed,
+			Title:     "Raise intake",
+			Action:    "Add 150-200 kcal/day, mostly from carbohydrates around training.",
+			Rationale: "Weight has held flat despite hitting targets, so maintenance has likely risen.",
+		}}
+	}
+
+	if spanDays >= WeightPlateauDietBreakMinDays {
+		return []PlateauBreaker{
+			{
+				Type:      PlateauBreakerDietBreak,
+				Title:     "Take a 1-2 week diet break",
+				Action:    "Eat at estimated maintenance for 7-14 days before resuming the deficit.",
+				Rationale: "A plateau this long on a cut suggests accumulated adaptation; a break restores training quality and adherence.",
+			},
+			{
+				Type:      PlateauBreakerDeload,
+				Title:     "Pair with a training deload",
+				Action:    "Cut training volume by ~40% during the break.",
+				Rationale: "Reducing fatigue alongside the break helps separate water retention from true stalls.",
+			},
+		}
+	}
+
+	return []PlateauBreaker{{
+		Type:      PlateauBreakerRefeed,
+		Title:     "Schedule two refeed days",
+		Action:    "Set two non-consecutive days this week to maintenance calories with extra carbohydrates.",
+		Rationale: "Short refeeds can flush water retention that masks fat loss during a stall.",
+	}}
+}
+
+// DetectStrengthPlateau flags a movement whose difficulty has not changed across the
+// last StrengthPlateauSessions sessions (records ordered oldest first).
+// Returns nil when no plateau is detected.
+func DetectStrengthPlateau(movement Movement, records []MovementSessionRecord, library []Movement, now time.Time) *PlateauDetection {
+	if len(records) < StrengthPlateauSessions {
+		return nil
+	}
+
+	window := records[len(records)-StrengthPlateauSessions:]
+	difficulty := window[0].Difficulty
+	for _, r := range window[1:] {
+		if r.Difficulty != difficulty {
+			return nil
+		}
+	}
+
+	breakers := []PlateauBreaker{{
+		Type:      PlateauBreakerDeload,
+		Title:     "Deload " + movement.Name,
+		Action:    "Drop to ~60% of usual volume for one week, then retest.",
+		Rationale: "Difficulty has not moved in " + itoa(StrengthPlateauSessions) + " sessions; residual fatigue may be masking progress.",
+	}}
+	if regression := SuggestMovementRegression(movement, library); regression != nil {
+		breakers = append(breakers, PlateauBreaker{
+			Type:      PlateauBreakerExerciseVariation,
+			Title:     "Rotate in " + regression.Name,
+			Action:    "Swap " + movement.Name + " for " + regression.Name + " for 2-3 sessions and build volume.",
+			Rationale: "A nearby variation keeps the pattern trained while changing the stimulus.",
+		})
+	}
+
+	return &PlateauDetection{
+		Type:            PlateauTypeStrength,
+		Subject:         movement.ID,
+		DetectedOn:      now.Format("2006-01-02"),
+		SessionCount:    len(window),
+		Metric:          float64(difficulty),
+		Recommendations: breakers,
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Plateau detection gates diet-break and deload advice; these tests
+// lock the adherence gate, minimum window, and escalation thresholds.
+
+type PlateauSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestPlateauSuite(t *testing.T) {
+	suite.Run(t, new(PlateauSuite))
+}
+
+func (s *PlateauSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+}
+
+func (s *PlateauSuite) samples(days int, start, weeklyChange float64) []WeightSample {
+	out := make([]WeightSample, 0, days+1)
+	for i := 0; i <= days; i++ {
+		date := s.now.AddDate(0, 0, i-days).Format("2006-01-02")
+		out = append(out, WeightSample{Date: date, WeightKg: start + weeklyChange*float64(i)/7})
+	}
+	return out
+}
+
+func (s *PlateauSuite) TestCalculateIntakeAdherence() {
+	logs := []DailyLog{
+		{ConsumedCalories: 2000, CalculatedTargets: DailyTargets{TotalCalories: 2000}},
+		{ConsumedCalories: 2150, CalculatedTargets: DailyTargets{TotalCalories: 2000}},
+		{ConsumedCalories: 2600, CalculatedTargets: DailyTargets{TotalCalories: 2000}},
+		{ConsumedCalories: 0, CalculatedTargets: DailyTargets{TotalCalories: 2000}}, // not logged
+	}
+	s.InDelta(2.0/3.0, CalculateIntakeAdherence(logs), 0.001)
+	s.Equal(0.0, CalculateIntakeAdherence(nil))
+}
+
+func (s *PlateauSuite) TestDetectWeightPlateau() {
+	s.Run("flat trend with adherence on a cut is a plateau", func() {
+		d := DetectWeightPlateau(s.samples(21, 82, 0), 0.9, GoalLoseWeight, s.now)
+		s.Require().NotNil(d)
+		s.Equal(PlateauTypeWeight, d.Type)
+		s.Equal(SubjectBodyweight, d.Subject)
+		s.Equal(PlateauBreakerRefeed, d.Recommendations[0].Type)
+	})
+
+	s.Run("long plateau on a cut escalates to diet break", func() {
+		d := DetectWeightPlateau(s.samples(42, 82, 0), 0.9, GoalLoseWeight, s.now)
+		s.Require().NotNil(d)
+		s.Equal(PlateauBreakerDietBreak, d.Recommendations[0].Type)
+	})
+
+	s.Run("low adherence is not a plateau", func() {
+		s.Nil(DetectWeightPlateau(s.samples(21, 82, 0), 0.5, GoalLoseWeight, s.now))
+	})
+
+	s.Run("window shorter than three weeks is ignored", func() {
+		s.Nil(DetectWeightPlateau(s.samples(14, 82, 0), 0.9, GoalLoseWeight, s.now))
+	})
+
+	s.Run("still losing is not a plateau", func() {
+		s.Nil(DetectWeightPlateau(s.samples(21, 82, -0.5), 0.9, GoalLoseWeight, s.now))
+	})
+
+	s.Run("maintenance goal never plateaus", func() {
+		s.Nil(DetectWeightPlateau(s.samples(21, 82, 0), 0.9, GoalMaintain, s.now))
+	})
+}
+
+func (s *PlateauSuite) TestDetectStrengthPlateau() {
+	library := SeedMovements()
+	var pullup Movement
+	for _, m := range library {
+		if m.ID == "cali_pullup_std" {
+			pullup = m
+		}
+	}
+
+	records := make([]MovementSessionRecord, StrengthPlateauSessions)
+	for i := range records {
+		records[i] = MovementSessionRecord{MovementID: pullup.ID, Difficulty: 6, Successful: true}
+	}
+
+	s.Run("unchanged difficulty across window is a plateau", func() {
+		d := DetectStrengthPlateau(pullup, records, library, s.now)
+		s.Require().NotNil(d)
+		s.Equal(PlateauTypeStrength, d.Type)
+		s.Equal(pullup.ID, d.Subject)
+		s.Require().Len(d.Recommendations, 2)
+		s.Equal(PlateauBreakerDeload, d.Recommendations[0].Type)
+		s.Equal(PlateauBreakerExerciseVariation, d.Recommendations[1].Type)
+	})
+
+	s.Run("progression inside window is not a plateau", func() {
+		progressed := append([]MovementSessionRecord{}, records...)
+		progressed[len(progressed)-1].Difficulty = 7
+		s.Nil(DetectStrengthPlateau(pullup, progressed, library, s.now))
+	})
+
+	s.Run("too few sessions is not a plateau", func() {
+		s.Nil(DetectStrengthPlateau(pullup, records[:StrengthPlateauSessions-1], library, s.now))
+	})
+}
@@ -32,8 +32,13 @@ type UserProfile struct {
 	FastingProtocol   FastingProtocol // standard, 16_8, or 20_4
 	EatingWindowStart string          // HH:MM format (e.g., "12:00")
 	EatingWindowEnd   string          // HH:MM format (e.g., "20:00")
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	WeightSmoothing   WeightSmoothing // Trend method used by charts, debrief and plan analysis
+	// Adherence bands for calories and each macro (zero fields = defaults)
+	AdherenceTolerances AdherenceTolerances
+	// Integrity mode: days older than this need a retro-edit (0 = off, max 90)
+	LogLockDays int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // NewUserProfile creates a new UserProfile with the given required fields.
@@ -187,6 +192,21 @@ func (p *UserProfile) ValidateAt(now time.Time) error {
 		return ErrInvalidEatingWindow
 	}
 
+	// Weight trend smoothing validation (empty method is allowed, defaults to EMA)
+	if err := p.WeightSmoothing.Validate(); err != nil {
+		return err
+	}
+
+	// Adherence tolerances validation (zero fields use the defaults)
+	if err := p.AdherenceTolerances.Validate(); err != nil {
+		return err
+	}
+
+	// Logging lock validation (0 = off)
+	if p.LogLockDays < 0 || p.LogLockDays > MaxLogLockDays {
+		return ErrInvalidLogLockDays
+	}
+
 	return nil
 }
 
@@ -261,6 +281,12 @@ func (p *UserProfile) SetDefaults() {
 	if p.EatingWindowEnd == "" {
 		p.EatingWindowEnd = "20:00"
 	}
+
+	if p.WeightSmoothing.Method == "" {
+		p.WeightSmoothing.Method = WeightTrendMethodEMA
+	}
+
+	p.AdherenceTolerances = p.AdherenceTolerances.WithDefaults()
 }
 
 // GetEffectiveMealRatios returns meal ratios adjusted for the fasting protocol.
@@ -346,6 +346,26 @@ func (s *ProfileSuite) TestFruitVeggieTargetValidation() {
 	})
 }
 
+func (s *ProfileSuite) TestLogLockDaysValidation() {
+	s.Run("accepts lock off and at maximum", func() {
+		p := s.validProfile()
+		p.LogLockDays = 0
+		s.Require().NoError(p.ValidateAt(s.now))
+
+		p.LogLockDays = MaxLogLockDays
+		s.Require().NoError(p.ValidateAt(s.now))
+	})
+
+	s.Run("rejects lock days out of range", func() {
+		p := s.validProfile()
+		p.LogLockDays = -1
+		s.Require().ErrorIs(p.ValidateAt(s.now), ErrInvalidLogLockDays)
+
+		p.LogLockDays = MaxLogLockDays + 1
+		s.Require().ErrorIs(p.ValidateAt(s.now), ErrInvalidLogLockDays)
+	})
+}
+
 func (s *ProfileSuite) TestDefaultsApplication() {
 	s.Run("defaults macro ratios to 45/30/25", func() {
 		p := &UserProfile{}
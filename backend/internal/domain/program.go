@@ -68,6 +68,9 @@ const (
 	EquipmentTypeMachine    EquipmentType = "machine"
 	EquipmentTypeKettlebell EquipmentType = "kettlebell"
 	EquipmentTypeBands      EquipmentType = "bands"
+	EquipmentTypePullupBar  EquipmentType = "pullup_bar"
+	EquipmentTypeDipBars    EquipmentType = "dip_bars"
+	EquipmentTypeBench      EquipmentType = "bench"
 )
 
 // ValidEquipmentTypes contains all valid equipment type values.
@@ -78,6 +81,9 @@ var ValidEquipmentTypes = map[EquipmentType]bool{
 	EquipmentTypeMachine:    true,
 	EquipmentTypeKettlebell: true,
 	EquipmentTypeBands:      true,
+	EquipmentTypePullupBar:  true,
+	EquipmentTypeDipBars:    true,
+	EquipmentTypeBench:      true,
 }
 
 // ParseEquipmentType safely converts a string to EquipmentType with validation.
@@ -201,15 +207,18 @@ type ProgramDay struct {
 // ProgramInstallation represents a user's active program assignment.
 // Links a program template to the user's calendar with day mapping.
 type ProgramInstallation struct {
-	ID             int64
-	ProgramID      int64
-	Program        *TrainingProgram // Populated when fetching
-	StartDate      time.Time
-	WeekDayMapping []int // Maps program day numbers to weekdays (1=Mon, 7=Sun, 0=skip)
-	CurrentWeek    int
-	Status         InstallationStatus
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID                  int64
+	ProgramID           int64
+	Program             *TrainingProgram // Populated when fetching
+	StartDate           time.Time
+	WeekDayMapping      []int // Maps program day numbers to weekdays (1=Mon, 7=Sun, 0=skip)
+	CurrentWeek         int
+	Status              InstallationStatus
+	Reschedules         []ProgramReschedule // Program days moved off their mapped date, oldest first
+	AutoregulationBound float64             // Readiness scaling limit (e.g. 0.15 = ±15%); 0 = off
+	KeepDeloadDayTypes  bool                // Override: deload weeks keep the program's nutrition days
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
 // =============================================================================
@@ -332,9 +341,11 @@ func ValidateSessionExercises(exercises []SessionExercise) error {
 
 // InstallProgramInput contains the fields to install a program.
 type InstallProgramInput struct {
-	ProgramID      int64  `json:"programId"`
-	StartDate      string `json:"startDate"` // YYYY-MM-DD
-	WeekDayMapping []int  `json:"weekDayMapping"`
+	ProgramID           int64   `json:"programId"`
+	StartDate           string  `json:"startDate"` // YYYY-MM-DD
+	WeekDayMapping      []int   `json:"weekDayMapping"`
+	AutoregulationBound float64 `json:"autoregulationBound"` // 0 = off
+	KeepDeloadDayTypes  bool    `json:"keepDeloadDayTypes"`  // Don't bias deload weeks' nutrition days
 }
 
 // =============================================================================
@@ -518,14 +529,20 @@ func NewProgramInstallation(input InstallProgramInput, now time.Time) (*ProgramI
 		}
 	}
 
+	if err := ValidateAutoregulationBound(input.AutoregulationBound); err != nil {
+		return nil, err
+	}
+
 	installation := &ProgramInstallation{
-		ProgramID:      input.ProgramID,
-		StartDate:      startDate,
-		WeekDayMapping: input.WeekDayMapping,
-		CurrentWeek:    1,
-		Status:         InstallationStatusActive,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		ProgramID:           input.ProgramID,
+		StartDate:           startDate,
+		WeekDayMapping:      input.WeekDayMapping,
+		AutoregulationBound: input.AutoregulationBound,
+		KeepDeloadDayTypes:  input.KeepDeloadDayTypes,
+		CurrentWeek:         1,
+		Status:              InstallationStatusActive,
+		CreatedAt:           now,
+		UpdatedAt:           now,
 	}
 
 	return installation, nil
@@ -651,7 +668,8 @@ func (i *ProgramInstallation) IsActive() bool {
 }
 
 // GetScheduledSessions returns all planned training sessions for the installation.
-// Maps program weeks/days to actual calendar dates based on start date and day mapping.
+// Maps program weeks/days to actual calendar dates based on start date and day mapping,
+// then applies any reschedules.
 func (i *ProgramInstallation) GetScheduledSessions() []ScheduledSession {
 	if i.Program == nil {
 		return nil
@@ -661,6 +679,7 @@ func (i *ProgramInstallation) GetScheduledSessions() []ScheduledSession {
 
 	for _, week := range i.Program.Weeks {
 		weekStart := i.StartDate.AddDate(0, 0, (week.WeekNumber-1)*7)
+		first := len(sessions)
 
 		for _, day := range week.Days {
 			// Find which weekday this program day maps to
@@ -677,6 +696,9 @@ func (i *ProgramInstallation) GetScheduledSessions() []ScheduledSession {
 			// Go's Weekday: 0=Sun, 1=Mon, ... 6=Sat
 			weekdayOffset := mappedWeekday - 1 // 0-indexed from Monday
 			sessionDate := weekStart.AddDate(0, 0, weekdayOffset)
+			if moved, ok := i.rescheduledDate(week.WeekNumber, day.DayNumber); ok {
+				sessionDate = moved
+			}
 
 			sessions = append(sessions, ScheduledSession{
 				Date:               sessionDate,
@@ -688,8 +710,13 @@ func (i *ProgramInstallation) GetScheduledSessions() []ScheduledSession {
 				LoadScore:          day.LoadScore * week.VolumeScale, // Scale by week volume
 				NutritionDay:       day.NutritionDay,
 				ProgressionPattern: day.ProgressionPattern,
+				SessionExercises:   day.SessionExercises,
 			})
 		}
+
+		if week.IsDeload && !i.KeepDeloadDayTypes {
+			applyDeloadDayTypes(sessions[first:])
+		}
 	}
 
 	return sessions
@@ -705,7 +732,10 @@ type ScheduledSession struct {
 	DurationMin        int
 	LoadScore          float64
 	NutritionDay       DayType
+	DeloadAdjusted     bool // NutritionDay was biased away from performance for a deload week
 	ProgressionPattern *ProgressionPattern
+	SessionExercises   []SessionExercise      // Runner exercise flow (may include a generated warm-up)
+	Autoregulation     *SessionAutoregulation // Readiness scaling applied; nil when not autoregulated
 }
 
 // TotalSessionCount returns the total number of sessions in the installation.
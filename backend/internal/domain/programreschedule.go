@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// PROGRAM RESCHEDULING
+// =============================================================================
+//
+// Program days follow the installation's week-day mapping unless they have
+// been moved. A move is recorded on the installation as a reschedule of one
+// program day (week and day number) to a new date, and the scheduled-session
+// generator applies the latest reschedule of each day. Days can be swapped
+// within the current program week, or today's day pushed to tomorrow. Neither
+// touches the past, nor a day that already has training logged.
+
+// RescheduleKind is how a program day was moved.
+type RescheduleKind string
+
+const (
+	RescheduleKindSwap RescheduleKind = "swap"
+	RescheduleKindPush RescheduleKind = "push"
+)
+
+// ProgramReschedule records one program day moving to a new date.
+type ProgramReschedule struct {
+	Kind       RescheduleKind `json:"kind"`
+	WeekNumber int            `json:"weekNumber"`
+	DayNumber  int            `json:"dayNumber"`
+	FromDate   string         `json:"fromDate"` // YYYY-MM-DD
+	ToDate     string         `json:"toDate"`   // YYYY-MM-DD
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+// rescheduledDate returns the date a program day was last moved to, if any.
+func (i *ProgramInstallation) rescheduledDate(weekNumber, dayNumber int) (time.Time, bool) {
+	for j := len(i.Reschedules) - 1; j >= 0; j-- {
+		r := i.Reschedules[j]
+		if r.WeekNumber != weekNumber || r.DayNumber != dayNumber {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", r.ToDate)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+// SwapDays swaps the program sessions on dateA and dateB (YYYY-MM-DD). Both
+// dates must fall in the current program week, from today on, and at least one
+// must have a session. trainedDates are the days with training already logged.
+// The moves are appended to the installation's reschedules and returned.
+func (i *ProgramInstallation) SwapDays(dateA, dateB string, now time.Time, trainedDates map[string]bool) ([]ProgramReschedule, error) {
+	if err := i.checkReschedulable(); err != nil {
+		return nil, err
+	}
+	a, errA := time.Parse("2006-01-02", dateA)
+	b, errB := time.Parse("2006-01-02", dateB)
+	if errA != nil || errB != nil || dateA == dateB {
+		return nil, ErrInvalidRescheduleDates
+	}
+
+	week := i.GetCurrentWeek(now)
+	if week < 1 {
+		week = 1
+	}
+	weekStart := i.StartDate.AddDate(0, 0, (week-1)*7)
+	weekEnd := weekStart.AddDate(0, 0, 6)
+	for _, d := range []time.Time{a, b} {
+		if d.Before(weekStart) || d.After(weekEnd) {
+			return nil, ErrRescheduleOutsideWeek
+		}
+	}
+	today := now.Format("2006-01-02")
+	if dateA < today || dateB < today {
+		return nil, ErrRescheduleInPast
+	}
+	if trainedDates[dateA] || trainedDates[dateB] {
+		return nil, ErrRescheduleDayTrained
+	}
+
+	var moves []ProgramReschedule
+	for _, session := range i.GetScheduledSessions() {
+		from := session.Date.Format("2006-01-02")
+		var to string
+		switch from {
+		case dateA:
+			to = dateB
+		case dateB:
+			to = dateA
+		default:
+			continue
+		}
+		moves = append(moves, ProgramReschedule{
+			Kind:       RescheduleKindSwap,
+			WeekNumber: session.WeekNumber,
+			DayNumber:  session.DayNumber,
+			FromDate:   from,
+			ToDate:     to,
+			CreatedAt:  now,
+		})
+	}
+	if len(moves) == 0 {
+		return nil, ErrNoSessionToReschedule
+	}
+
+	i.Reschedules = append(i.Reschedules, moves...)
+	return moves, nil
+}
+
+// PushToday moves today's program sessions to tomorrow, turning today into a
+// rest day. Tomorrow must not already have a session of its own; swap the days
+// instead. trainedDates are the days with training already logged.
+// The moves are appended to the installation's reschedules and returned.
+func (i *ProgramInstallation) PushToday(now time.Time, trainedDates map[string]bool) ([]ProgramReschedule, error) {
+	if err := i.checkReschedulable(); err != nil {
+		return nil, err
+	}
+	today := now.Format("2006-01-02")
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
+	if trainedDates[today] || trainedDates[tomorrow] {
+		return nil, ErrRescheduleDayTrained
+	}
+
+	var moves []ProgramReschedule
+	occupied := false
+	for _, session := range i.GetScheduledSessions() {
+		switch session.Date.Format("2006-01-02") {
+		case tomorrow:
+			occupied = true
+		case today:
+			moves = append(moves, ProgramReschedule{
+				Kind:       RescheduleKindPush,
+				WeekNumber: session.WeekNumber,
+				DayNumber:  session.DayNumber,
+				FromDate:   today,
+				ToDate:     tomorrow,
+				CreatedAt:  now,
+			})
+		}
+	}
+	if len(moves) == 0 {
+		return nil, ErrNoSessionToReschedule
+	}
+	if occupied {
+		return nil, ErrRescheduleDayOccupied
+	}
+
+	i.Reschedules = append(i.Reschedules, moves...)
+	return moves, nil
+}
+
+// checkReschedulable checks the installation is active with its program loaded.
+func (i *ProgramInstallation) checkReschedulable() error {
+	if !i.IsActive() || i.Program == nil {
+		return ErrInstallationNotActive
+	}
+	return nil
+}
+
+// RescheduledDates returns the distinct dates the moves touched, sorted.
+func RescheduledDates(moves []ProgramReschedule) []string {
+	seen := make(map[string]bool)
+	var dates []string
+	for _, m := range moves {
+		for _, d := range []string{m.FromDate, m.ToDate} {
+			if !seen[d] {
+				seen[d] = true
+				dates = append(dates, d)
+			}
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
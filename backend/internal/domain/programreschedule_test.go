@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Reschedules override the week-day mapping and stack on top
+// of each other; tests pin the current-week and past-day limits, the conflict
+// checks against logged training and occupied days, and that the latest move
+// of a program day wins.
+type ProgramRescheduleSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestProgramRescheduleSuite(t *testing.T) {
+	suite.Run(t, new(ProgramRescheduleSuite))
+}
+
+func (s *ProgramRescheduleSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC) // Wednesday of week 2
+}
+
+// installation runs a two-week, three-day program from Monday 2 March.
+func (s *ProgramRescheduleSuite) installation(mapping ...int) *ProgramInstallation {
+	days := []ProgramDay{
+		{DayNumber: 1, Label: "Upper", TrainingType: TrainingTypeStrength, NutritionDay: DayTypePerformance},
+		{DayNumber: 2, Label: "Lower", TrainingType: TrainingTypeStrength, NutritionDay: DayTypePerformance},
+		{DayNumber: 3, Label: "Conditioning", TrainingType: TrainingTypeHIIT, NutritionDay: DayTypeFatburner},
+	}
+	return &ProgramInstallation{
+		ID: 1,
+		Program: &TrainingProgram{
+			DurationWeeks: 2,
+			Weeks: []ProgramWeek{
+				{WeekNumber: 1, VolumeScale: 1, Days: days},
+				{WeekNumber: 2, VolumeScale: 1, Days: days},
+			},
+		},
+		StartDate:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		WeekDayMapping: mapping,
+		Status:         InstallationStatusActive,
+	}
+}
+
+// labelsByDate maps each scheduled date to its session label.
+func (s *ProgramRescheduleSuite) labelsByDate(i *ProgramInstallation) map[string]string {
+	labels := make(map[string]string)
+	for _, session := range i.GetScheduledSessions() {
+		labels[session.Date.Format("2006-01-02")] = session.Label
+	}
+	return labels
+}
+
+func (s *ProgramRescheduleSuite) TestSwapIntoRestDay() {
+	inst := s.installation(1, 3, 5)
+	moves, err := inst.SwapDays("2026-03-11", "2026-03-12", s.now, nil)
+	s.Require().NoError(err)
+	s.Require().Len(moves, 1)
+	s.Equal(ProgramReschedule{
+		Kind: RescheduleKindSwap, WeekNumber: 2, DayNumber: 2,
+		FromDate: "2026-03-11", ToDate: "2026-03-12", CreatedAt: s.now,
+	}, moves[0])
+	s.Equal(moves, inst.Reschedules)
+
+	labels := s.labelsByDate(inst)
+	s.NotContains(labels, "2026-03-11")
+	s.Equal("Lower", labels["2026-03-12"])
+	s.Equal("Lower", labels["2026-03-04"], "week 1 keeps its mapping")
+	s.Equal([]string{"2026-03-11", "2026-03-12"}, RescheduledDates(moves))
+}
+
+func (s *ProgramRescheduleSuite) TestLatestMoveWins() {
+	inst := s.installation(1, 3, 5)
+	_, err := inst.SwapDays("2026-03-11", "2026-03-12", s.now, nil)
+	s.Require().NoError(err)
+	moves, err := inst.SwapDays("2026-03-12", "2026-03-13", s.now, nil)
+	s.Require().NoError(err)
+	s.Len(moves, 2)
+
+	labels := s.labelsByDate(inst)
+	s.Equal("Conditioning", labels["2026-03-12"])
+	s.Equal("Lower", labels["2026-03-13"])
+	s.Len(inst.Reschedules, 3)
+}
+
+func (s *ProgramRescheduleSuite) TestSwapLimits() {
+	inst := s.installation(1, 3, 5)
+	cases := []struct {
+		dateA, dateB string
+		trained      map[string]bool
+		want         error
+	}{
+		{"2026-03-11", "2026-03-11", nil, ErrInvalidRescheduleDates},
+		{"2026-03-11", "Friday", nil, ErrInvalidRescheduleDates},
+		{"2026-03-11", "2026-03-16", nil, ErrRescheduleOutsideWeek},
+		{"2026-03-04", "2026-03-11", nil, ErrRescheduleOutsideWeek},
+		{"2026-03-09", "2026-03-12", nil, ErrRescheduleInPast},
+		{"2026-03-12", "2026-03-14", nil, ErrNoSessionToReschedule},
+		{"2026-03-11", "2026-03-13", map[string]bool{"2026-03-11": true}, ErrRescheduleDayTrained},
+	}
+	for _, c := range cases {
+		_, err := inst.SwapDays(c.dateA, c.dateB, s.now, c.trained)
+		s.ErrorIs(err, c.want, "%s <-> %s", c.dateA, c.dateB)
+	}
+	s.Empty(inst.Reschedules, "failed swaps record nothing")
+
+	inst.Status = InstallationStatusAbandoned
+	_, err := inst.SwapDays("2026-03-11", "2026-03-12", s.now, nil)
+	s.ErrorIs(err, ErrInstallationNotActive)
+}
+
+func (s *ProgramRescheduleSuite) TestPushToday() {
+	inst := s.installation(1, 3, 5)
+	moves, err := inst.PushToday(s.now, nil)
+	s.Require().NoError(err)
+	s.Require().Len(moves, 1)
+	s.Equal(RescheduleKindPush, moves[0].Kind)
+	s.Equal("Lower", s.labelsByDate(inst)["2026-03-12"])
+
+	_, err = inst.PushToday(s.now, nil)
+	s.ErrorIs(err, ErrNoSessionToReschedule, "today is now a rest day")
+
+	_, err = s.installation(1, 3, 5).PushToday(s.now, map[string]bool{"2026-03-11": true})
+	s.ErrorIs(err, ErrRescheduleDayTrained)
+
+	_, err = s.installation(1, 3, 4).PushToday(s.now, nil)
+	s.ErrorIs(err, ErrRescheduleDayOccupied)
+}
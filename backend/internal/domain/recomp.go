@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =============================================================================
+// BODY RECOMPOSITION PLANS
+// =============================================================================
+//
+// A recomp plan targets slow fat loss while building muscle, so scale weight
+// barely moves and is a poor progress signal. Instead:
+//   - weight only has to stay within a band around the plan's projection,
+//   - macros are protein-forward (grams per kg body weight, carbs fill the rest),
+//   - the dual-track analysis follows the body-fat trend rather than weight.
+
+// PlanMode selects how a plan sets targets and measures progress.
+type PlanMode string
+
+const (
+	PlanModeWeight PlanMode = "weight" // Reach a goal weight (default)
+	PlanModeRecomp PlanMode = "recomp" // Reduce body fat while holding weight
+	PlanModeEvent  PlanMode = "event"  // Make a target weight by a fixed event date
+)
+
+// ValidPlanModes contains all valid plan modes.
+var ValidPlanModes = map[PlanMode]bool{
+	PlanModeWeight: true,
+	PlanModeRecomp: true,
+	PlanModeEvent:  true,
+}
+
+// ParsePlanMode safely converts a string to PlanMode with validation.
+func ParsePlanMode(s string) (PlanMode, error) {
+	if s == "" {
+		return PlanModeWeight, nil
+	}
+	mode := PlanMode(s)
+	if !ValidPlanModes[mode] {
+		return "", ErrInvalidPlanMode
+	}
+	return mode, nil
+}
+
+const (
+	// RecompWeightBandKg is how far weight may drift from the projection (either way).
+	RecompWeightBandKg = 2.0
+	// RecompMaxWeeklyBodyFatChange is the fastest realistic recomp (percentage points per week).
+	RecompMaxWeeklyBodyFatChange = 0.25
+	// RecompBodyFatTolerance is how far body fat may lag the plan (percentage points)
+	// before recalibration is suggested.
+	RecompBodyFatTolerance = 1.0
+	// RecompProteinGPerKg and RecompFatGPerKg set protein and fat from body weight;
+	// carbs take the remaining calories.
+	RecompProteinGPerKg = 2.2
+	RecompFatGPerKg     = 0.8
+	// MinBodyFatSamplesForTrend is the minimum body-fat readings for a trend.
+	MinBodyFatSamplesForTrend = 3
+)
+
+// IsRecomp returns true if the plan is a body recomposition plan.
+func (p *NutritionPlan) IsRecomp() bool {
+	return p.Mode == PlanModeRecomp
+}
+
+// validateRecomp checks the body-fat goal and weight band of a recomp plan.
+func (p *NutritionPlan) validateRecomp() error {
+	if p.StartBodyFatPercent == nil || p.GoalBodyFatPercent == nil {
+		return ErrRecompBodyFatRequired
+	}
+	start, goal := *p.StartBodyFatPercent, *p.GoalBodyFatPercent
+	if start < 3 || start > 70 || goal < 3 || goal > 70 {
+		return ErrInvalidPlanBodyFat
+	}
+	if goal >= start {
+		return ErrRecompGoalNotLower
+	}
+	if (start-goal)/float64(p.DurationWeeks) > RecompMaxWeeklyBodyFatChange {
+		return ErrRecompTooAggressive
+	}
+	if math.Abs(p.GoalWeightKg-p.StartWeightKg) > RecompWeightBandKg {
+		return ErrRecompWeightOutsideBand
+	}
+	return nil
+}
+
+// macroTargets returns the gram targets for a week's intake: profile ratios
+// for weight plans, protein-forward per-kg targets for recomp plans.
+func (p *NutritionPlan) macroTargets(profile *UserProfile, targetIntake int, weightKg float64) (carbsG, proteinG, fatsG int) {
+	if !p.IsRecomp() {
+		return calculateMacroTargets(targetIntake, profile.CarbRatio, profile.ProteinRatio, profile.FatRatio)
+	}
+	proteinG = RoundInt(weightKg * RecompProteinGPerKg)
+	fatsG = RoundInt(weightKg * RecompFatGPerKg)
+	remaining := float64(targetIntake) - float64(proteinG)*CaloriesPerGramProtein - float64(fatsG)*CaloriesPerGramFat
+	if remaining > 0 {
+		carbsG = RoundInt(remaining / CaloriesPerGramCarb)
+	}
+	return carbsG, proteinG, fatsG
+}
+
+// PlannedBodyFatPercent returns the body fat the plan expects at a date,
+// interpolated linearly from start to goal. Returns nil for weight plans.
+func (p *NutritionPlan) PlannedBodyFatPercent(at time.Time) *float64 {
+	if !p.IsRecomp() || p.StartBodyFatPercent == nil || p.GoalBodyFatPercent == nil {
+		return nil
+	}
+	elapsed := at.Sub(p.StartDate).Hours() / 24 / float64(p.DurationWeeks*7)
+	elapsed = math.Max(0, math.Min(1, elapsed))
+	planned := *p.StartBodyFatPercent + (*p.GoalBodyFatPercent-*p.StartBodyFatPercent)*elapsed
+	planned = math.Round(planned*10) / 10
+	return &planned
+}
+
+// BodyFatSample is a single body-fat reading.
+type BodyFatSample struct {
+	Date    string
+	Percent float64
+}
+
+// BodyFatTrend is the regression trend of body-fat readings.
+type BodyFatTrend struct {
+	WeeklyChangePercent float64 // Percentage points per week
+	RSquared            float64
+	StartPercent        float64
+	EndPercent          float64 // Smoothed current value
+	Samples             int
+}
+
+// CalculateBodyFatTrend returns the regression trend for samples ordered by date.
+// Returns nil with fewer than MinBodyFatSamplesForTrend readings, since
+// single scale readings are too noisy to judge progress.
+func CalculateBodyFatTrend(samples []BodyFatSample) *BodyFatTrend {
+	if len(samples) < MinBodyFatSamplesForTrend {
+		return nil
+	}
+	start, err := time.Parse("2006-01-02", samples[0].Date)
+	if err != nil {
+		return nil
+	}
+
+	points := make([]regressionPoint, len(samples))
+	for i, sample := range samples {
+		d, err := time.Parse("2006-01-02", sample.Date)
+		if err != nil {
+			return nil
+		}
+		points[i] = regressionPoint{x: d.Sub(start).Hours() / 24, y: sample.Percent}
+	}
+
+	regression := calculateLinearRegression(points)
+	return &BodyFatTrend{
+		WeeklyChangePercent: regression.slope * 7,
+		RSquared:            regression.rSquared,
+		StartPercent:        regression.predict(0),
+		EndPercent:          regression.predict(points[len(points)-1].x),
+		Samples:             len(samples),
+	}
+}
+
+// ProgressTrack names the primary signal the analysis judges progress on.
+type ProgressTrack string
+
+const (
+	ProgressTrackWeight  ProgressTrack = "weight"
+	ProgressTrackBodyFat ProgressTrack = "body_fat"
+)
+
+// applyRecompTrack switches an analysis to the body-fat track. Weight only
+// matters when it leaves the band; body fat lagging the plan by more than
+// RecompBodyFatTolerance or trending upwards flags the plan. The weight-based
+// recalibration options don't apply to recomp plans and are dropped.
+func applyRecompTrack(analysis *DualTrackAnalysis, input AnalysisInput) {
+	plan := input.Plan
+	analysis.Track = ProgressTrackBodyFat
+	analysis.WeightBandMinKg = math.Round((analysis.PlannedWeightKg-RecompWeightBandKg)*10) / 10
+	analysis.WeightBandMaxKg = math.Round((analysis.PlannedWeightKg+RecompWeightBandKg)*10) / 10
+	analysis.WeightInBand = math.Abs(input.ActualWeightKg-analysis.PlannedWeightKg) <= RecompWeightBandKg
+	analysis.PlannedBodyFatPercent = plan.PlannedBodyFatPercent(input.AnalysisDate)
+
+	actual := input.LatestBodyFatPercent
+	if input.BodyFatTrend != nil {
+		smoothed := math.Round(input.BodyFatTrend.EndPercent*10) / 10
+		actual = &smoothed
+	}
+	analysis.ActualBodyFatPercent = actual
+
+	bodyFatBehind := false
+	if actual != nil && analysis.PlannedBodyFatPercent != nil {
+		variance := math.Round((*actual-*analysis.PlannedBodyFatPercent)*10) / 10
+		analysis.BodyFatVariancePercent = &variance
+		bodyFatBehind = variance > RecompBodyFatTolerance
+	}
+	analysis.RecalibrationNeeded = !analysis.GracePeriod && (bodyFatBehind || !analysis.WeightInBand)
+
+	analysis.TrendDiverging = false
+	analysis.TrendDivergingMsg = ""
+	trend := input.BodyFatTrend
+	if trend != nil && trend.WeeklyChangePercent > 0 && !analysis.GracePeriod &&
+		plan.StartBodyFatPercent != nil && plan.GoalBodyFatPercent != nil {
+		required := (*plan.GoalBodyFatPercent - *plan.StartBodyFatPercent) / float64(plan.DurationWeeks)
+		analysis.TrendDiverging = true
+		analysis.TrendDivergingMsg = fmt.Sprintf("Body fat trending %+.2f%%/wk, plan requires %+.2f%%/wk", trend.WeeklyChangePercent, required)
+	}
+	analysis.Options = nil
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecompSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+}
+
+func TestRecompSuite(t *testing.T) {
+	suite.Run(t, new(RecompSuite))
+}
+
+func (s *RecompSuite) SetupTest() {
+	s.now = time.Date(2026, 1, 24, 12, 0, 0, 0, time.UTC)
+	s.profile = &UserProfile{
+		HeightCM:     180,
+		BirthDate:    time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		Sex:          SexMale,
+		Goal:         GoalMaintain,
+		CarbRatio:    0.45,
+		ProteinRatio: 0.30,
+		FatRatio:     0.25,
+		BMREquation:  BMREquationMifflinStJeor,
+	}
+}
+
+func (s *RecompSuite) input() NutritionPlanInput {
+	start, goal := 22.0, 18.0
+	return NutritionPlanInput{
+		StartDate:           s.now.Format("2006-01-02"),
+		StartWeightKg:       80,
+		GoalWeightKg:        80,
+		DurationWeeks:       20,
+		Mode:                PlanModeRecomp,
+		StartBodyFatPercent: &start,
+		GoalBodyFatPercent:  &goal,
+	}
+}
+
+func (s *RecompSuite) TestValidation() {
+	_, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.NoError(err)
+
+	in := s.input()
+	in.GoalBodyFatPercent = nil
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrRecompBodyFatRequired)
+
+	in = s.input()
+	higher := 25.0
+	in.GoalBodyFatPercent = &higher
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrRecompGoalNotLower)
+
+	in = s.input()
+	in.DurationWeeks = 8 // 4 points in 8 weeks
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrRecompTooAggressive)
+
+	in = s.input()
+	in.GoalWeightKg = 75
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrRecompWeightOutsideBand)
+
+	in = s.input()
+	in.Mode = "bulk"
+	_, err = NewNutritionPlan(in, s.profile, s.now)
+	s.ErrorIs(err, ErrInvalidPlanMode)
+}
+
+func (s *RecompSuite) TestProteinForwardMacros() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+
+	week := plan.WeeklyTargets[0]
+	s.Equal(176, week.TargetProteinG, "2.2 g/kg of 80 kg")
+	s.Equal(64, week.TargetFatsG, "0.8 g/kg of 80 kg")
+	s.InDelta(week.TargetIntakeKcal, 4*week.TargetCarbsG+4*week.TargetProteinG+9*week.TargetFatsG, 4)
+	s.Equal(PlanModeRecomp, plan.Mode)
+
+	weightPlan := s.input()
+	weightPlan.Mode = ""
+	weightPlan.GoalWeightKg = 76
+	wp, err := NewNutritionPlan(weightPlan, s.profile, s.now)
+	s.Require().NoError(err)
+	s.Equal(PlanModeWeight, wp.Mode)
+	s.Less(wp.WeeklyTargets[0].TargetProteinG, week.TargetProteinG)
+}
+
+func (s *RecompSuite) TestPlannedBodyFat() {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+
+	s.Equal(22.0, *plan.PlannedBodyFatPercent(s.now))
+	s.Equal(20.0, *plan.PlannedBodyFatPercent(s.now.AddDate(0, 0, 70)))
+	s.Equal(18.0, *plan.PlannedBodyFatPercent(s.now.AddDate(1, 0, 0)), "clamped at goal")
+}
+
+func (s *RecompSuite) TestBodyFatTrend() {
+	s.Nil(CalculateBodyFatTrend([]BodyFatSample{{"2026-01-01", 22}, {"2026-01-08", 21.8}}))
+
+	trend := CalculateBodyFatTrend([]BodyFatSample{
+		{"2026-01-01", 22.0},
+		{"2026-01-08", 21.8},
+		{"2026-01-15", 21.6},
+	})
+	s.Require().NotNil(trend)
+	s.InDelta(-0.2, trend.WeeklyChangePercent, 0.001)
+	s.InDelta(21.6, trend.EndPercent, 0.001)
+}
+
+func (s *RecompSuite) analyze(actualWeight float64, trend *BodyFatTrend) *DualTrackAnalysis {
+	plan, err := NewNutritionPlan(s.input(), s.profile, s.now)
+	s.Require().NoError(err)
+
+	analysis, err := CalculateDualTrackAnalysis(AnalysisInput{
+		Plan:             plan,
+		ActualWeightKg:   actualWeight,
+		TolerancePercent: 3,
+		AnalysisDate:     s.now.AddDate(0, 0, 70),
+		BodyFatTrend:     trend,
+	})
+	s.Require().NoError(err)
+	return analysis
+}
+
+func (s *RecompSuite) TestAnalysisOnTrackByBodyFat() {
+	// Weight unchanged, body fat on plan (20% at week 10)
+	analysis := s.analyze(80.5, &BodyFatTrend{WeeklyChangePercent: -0.2, EndPercent: 20.2})
+
+	s.Equal(ProgressTrackBodyFat, analysis.Track)
+	s.True(analysis.WeightInBand)
+	s.Equal(0.2, *analysis.BodyFatVariancePercent)
+	s.False(analysis.RecalibrationNeeded)
+	s.False(analysis.TrendDiverging)
+	s.Empty(analysis.Options)
+}
+
+func (s *RecompSuite) TestAnalysisFlagsLaggingBodyFatAndWeightDrift() {
+	lagging := s.analyze(80, &BodyFatTrend{WeeklyChangePercent: 0.1, EndPercent: 21.8})
+	s.True(lagging.RecalibrationNeeded)
+	s.True(lagging.TrendDiverging)
+	s.Contains(lagging.TrendDivergingMsg, "Body fat trending")
+
+	drifted := s.analyze(83, &BodyFatTrend{WeeklyChangePercent: -0.2, EndPercent: 20})
+	s.False(drifted.WeightInBand)
+	s.True(drifted.RecalibrationNeeded)
+}
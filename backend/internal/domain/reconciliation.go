@@ -0,0 +1,135 @@
+package domain
+
+import "time"
+
+// =============================================================================
+// LATE DATA RECONCILIATION
+// =============================================================================
+//
+// Wearables often deliver HRV and active calories a day late. When data lands
+// on a past day, derived values (CNS status, TDEE flux) for that day and any
+// day whose baseline includes it must be re-run, and debriefs covering those
+// days become stale.
+
+// LateDataField identifies a metric that arrived after its day.
+type LateDataField string
+
+const (
+	LateDataHRV            LateDataField = "hrv"
+	LateDataRestingHR      LateDataField = "resting_hr"
+	LateDataSleep          LateDataField = "sleep"
+	LateDataActiveCalories LateDataField = "active_calories"
+	LateDataWeight         LateDataField = "weight"
+)
+
+// AllLateDataFields lists every reconcilable field, used for manual reconciliation.
+var AllLateDataFields = []LateDataField{
+	LateDataHRV, LateDataRestingHR, LateDataSleep, LateDataActiveCalories, LateDataWeight,
+}
+
+// CNSReclassification records a CNS status recomputed during reconciliation.
+type CNSReclassification struct {
+	Date   string    `json:"date"`
+	Status CNSStatus `json:"status"`
+}
+
+// ReconciliationResult describes what a reconciliation pass patched.
+type ReconciliationResult struct {
+	Date              string                `json:"date"`
+	Fields            []LateDataField       `json:"fields"`
+	CNSReclassified   []CNSReclassification `json:"cnsReclassified"`
+	FluxRecalculated  bool                  `json:"fluxRecalculated"`
+	StaleDebriefWeeks []string              `json:"staleDebriefWeeks"` // Monday dates flagged for regeneration
+}
+
+// StaleDebrief is a weekly debrief flagged for regeneration after late data.
+type StaleDebrief struct {
+	WeekStartDate string    `json:"weekStartDate"`
+	Reason        string    `json:"reason"`
+	FlaggedAt     time.Time `json:"flaggedAt"`
+}
+
+// IsLateArrival reports whether data for date arrived after that day ended.
+func IsLateArrival(date string, now time.Time) bool {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return d.Before(today)
+}
+
+// NeedsCNSReclassification reports whether any field feeds the CNS baseline.
+func NeedsCNSReclassification(fields []LateDataField) bool {
+	for _, f := range fields {
+		if f == LateDataHRV || f == LateDataRestingHR {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsFluxRecalculation reports whether any field feeds TDEE estimation.
+func NeedsFluxRecalculation(fields []LateDataField) bool {
+	for _, f := range fields {
+		if f == LateDataActiveCalories || f == LateDataWeight {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconciliationAffectedDates returns the days whose derived values may change
+// when late data lands on date. CNS fields ripple forward through every day whose
+// HRV/RHR baseline window includes date (capped at yesterday); other fields only
+// affect date itself.
+func ReconciliationAffectedDates(date string, fields []LateDataField, now time.Time) []string {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	if !NeedsCNSReclassification(fields) {
+		return []string{date}
+	}
+
+	window := HRVBaselineWindowDays
+	if RestingHRWindowDays > window {
+		window = RestingHRWindowDays
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	dates := make([]string, 0, window+1)
+	for i := 0; i <= window; i++ {
+		day := d.AddDate(0, 0, i)
+		if !day.Before(today) {
+			break
+		}
+		dates = append(dates, day.Format("2006-01-02"))
+	}
+	return dates
+}
+
+// DebriefWeekStart returns the Monday (YYYY-MM-DD) of the week containing date.
+func DebriefWeekStart(date string) (string, bool) {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", false
+	}
+	offset := (int(d.Weekday()) + 6) % 7 // Monday = 0
+	return d.AddDate(0, 0, -offset).Format("2006-01-02"), true
+}
+
+// StaleDebriefWeeks returns the distinct debrief weeks covering the given dates.
+func StaleDebriefWeeks(dates []string) []string {
+	seen := make(map[string]bool)
+	weeks := make([]string, 0)
+	for _, date := range dates {
+		week, ok := DebriefWeekStart(date)
+		if !ok || seen[week] {
+			continue
+		}
+		seen[week] = true
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Reconciliation scope decides which derived values get re-run and
+// which debriefs are invalidated; tests lock the ripple window and week mapping.
+
+type ReconciliationSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestReconciliationSuite(t *testing.T) {
+	suite.Run(t, new(ReconciliationSuite))
+}
+
+func (s *ReconciliationSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC) // Thursday
+}
+
+func (s *ReconciliationSuite) TestIsLateArrival() {
+	s.True(IsLateArrival("2026-03-11", s.now))
+	s.False(IsLateArrival("2026-03-12", s.now))
+	s.False(IsLateArrival("not-a-date", s.now))
+}
+
+func (s *ReconciliationSuite) TestFieldClassification() {
+	s.True(NeedsCNSReclassification([]LateDataField{LateDataHRV}))
+	s.True(NeedsCNSReclassification([]LateDataField{LateDataSleep, LateDataRestingHR}))
+	s.False(NeedsCNSReclassification([]LateDataField{LateDataActiveCalories}))
+
+	s.True(NeedsFluxRecalculation([]LateDataField{LateDataActiveCalories}))
+	s.True(NeedsFluxRecalculation([]LateDataField{LateDataWeight}))
+	s.False(NeedsFluxRecalculation([]LateDataField{LateDataHRV, LateDataSleep}))
+}
+
+func (s *ReconciliationSuite) TestReconciliationAffectedDates() {
+	s.Run("non-CNS fields affect only the day", func() {
+		dates := ReconciliationAffectedDates("2026-03-01", []LateDataField{LateDataActiveCalories}, s.now)
+		s.Equal([]string{"2026-03-01"}, dates)
+	})
+
+	s.Run("HRV ripples through the baseline window", func() {
+		dates := ReconciliationAffectedDates("2026-03-01", []LateDataField{LateDataHRV}, s.now)
+		s.Len(dates, HRVBaselineWindowDays+1)
+		s.Equal("2026-03-01", dates[0])
+		s.Equal("2026-03-08", dates[len(dates)-1])
+	})
+
+	s.Run("ripple stops before today", func() {
+		dates := ReconciliationAffectedDates("2026-03-10", []LateDataField{LateDataHRV}, s.now)
+		s.Equal([]string{"2026-03-10", "2026-03-11"}, dates)
+	})
+}
+
+func (s *ReconciliationSuite) TestStaleDebriefWeeks() {
+	s.Run("maps days to Monday week starts", func() {
+		week, ok := DebriefWeekStart("2026-03-08") // Sunday
+		s.True(ok)
+		s.Equal("2026-03-02", week)
+
+		week, _ = DebriefWeekStart("2026-03-09") // Monday
+		s.Equal("2026-03-09", week)
+	})
+
+	s.Run("deduplicates weeks across the ripple", func() {
+		dates := ReconciliationAffectedDates("2026-03-01", []LateDataField{LateDataHRV}, s.now)
+		s.Equal([]string{"2026-02-23", "2026-03-02"}, StaleDebriefWeeks(dates))
+	})
+}
@@ -23,20 +23,23 @@ const RestDaysForMaxScore = 3
 
 // RecoveryScoreInput contains data for recovery score calculation.
 type RecoveryScoreInput struct {
-	RestDaysLast7     int      // Number of rest days in last 7 days
-	ACR               float64  // Acute:Chronic Workload Ratio
-	AvgSleepQualityL7 float64  // Average sleep quality (1-100) over last 7 days
-	CurrentRHR        *int     // Today's resting heart rate (nil if not available)
-	AvgRHRLast30      *float64 // 30-day RHR average (nil if not available)
+	RestDaysLast7     int                     // Number of rest days in last 7 days
+	ACR               float64                 // Acute:Chronic Workload Ratio
+	AvgSleepQualityL7 float64                 // Average sleep quality (1-100) over last 7 days
+	CurrentRHR        *int                    // Today's resting heart rate (nil if not available)
+	AvgRHRLast30      *float64                // 30-day RHR average (nil if not available)
+	PersonalSleep     *PersonalReferenceRange // Personal sleep quality range (nil: score against 100)
+	ActivityPointsL7  float64                 // Net recovery activity points over the last 7 days
 }
 
 // RecoveryScore represents the calculated recovery state with component breakdown.
 type RecoveryScore struct {
-	Score          float64 // Total score 0-100, clamped
-	RestComponent  float64 // Rest days component (0-35)
-	ACRComponent   float64 // ACR zone component (0-30)
-	SleepComponent float64 // Sleep quality component (0-20)
-	RHRComponent   float64 // RHR deviation component (0-15)
+	Score             float64 // Total score 0-100, clamped
+	RestComponent     float64 // Rest days component (0-35)
+	ACRComponent      float64 // ACR zone component (0-30)
+	SleepComponent    float64 // Sleep quality component (0-20)
+	RHRComponent      float64 // RHR deviation component (0-15)
+	ActivityComponent float64 // Recovery activities component (-10 to +10, on top of the 100)
 }
 
 // AdjustmentInput contains data for calculating daily adjustment multipliers.
@@ -69,24 +72,32 @@ func CalculateRecoveryScore(input RecoveryScoreInput) RecoveryScore {
 	acrComponent := acrRatio * ACRComponentMax
 
 	// Sleep quality component (0-20 points)
-	// Maps average sleep quality (1-100) to 0-20 points
+	// Maps average sleep quality (1-100) to 0-20 points, or against the
+	// user's own range when there is one
 	sleepRatio := math.Max(0, math.Min(input.AvgSleepQualityL7/100.0, 1.0))
+	if ratio, ok := input.PersonalSleep.SleepQualityRatio(input.AvgSleepQualityL7); ok {
+		sleepRatio = ratio
+	}
 	sleepComponent := sleepRatio * SleepComponentMax
 
 	// RHR component (0-15 points)
 	// Based on deviation from 30-day average
 	rhrComponent := calculateRHRComponent(input.CurrentRHR, input.AvgRHRLast30)
 
+	// Recovery activity component (±10 points)
+	activityComponent := clampRecoveryPoints(input.ActivityPointsL7, RecoveryActivityReadinessMax)
+
 	// Calculate total and clamp to [0, 100]
-	total := restComponent + acrComponent + sleepComponent + rhrComponent
+	total := restComponent + acrComponent + sleepComponent + rhrComponent + activityComponent
 	total = math.Max(0, math.Min(total, 100))
 
 	return RecoveryScore{
-		Score:          total,
-		RestComponent:  restComponent,
-		ACRComponent:   acrComponent,
-		SleepComponent: sleepComponent,
-		RHRComponent:   rhrComponent,
+		Score:             total,
+		RestComponent:     restComponent,
+		ACRComponent:      acrComponent,
+		SleepComponent:    sleepComponent,
+		RHRComponent:      rhrComponent,
+		ActivityComponent: activityComponent,
 	}
 }
 
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// RECOVERY ACTIVITIES
+// =============================================================================
+//
+// Sauna, cold exposure, massage and similar work aid recovery without being
+// training, so they never showed up anywhere. Each logged activity earns
+// recovery points: minutes × the type's benefit per minute, scaled by
+// intensity, less minutes × its load per minute, scaled by intensity squared.
+// A hard enough session (a long, hot sauna) can therefore cost more than it
+// gives. Net points feed the readiness score and the weekly vitality recovery
+// component, each capped so they can nudge but not dominate either.
+
+// RecoveryActivityType is the kind of recovery work.
+type RecoveryActivityType string
+
+const (
+	RecoveryActivitySauna       RecoveryActivityType = "sauna"
+	RecoveryActivityColdPlunge  RecoveryActivityType = "cold_plunge" // Ice bath, cold shower
+	RecoveryActivityMassage     RecoveryActivityType = "massage"
+	RecoveryActivityFoamRolling RecoveryActivityType = "foam_rolling"
+	RecoveryActivityCompression RecoveryActivityType = "compression" // Boots, garments
+)
+
+const (
+	// MinRecoveryIntensity and MaxRecoveryIntensity bound the 1-5 intensity scale
+	// (heat, cold or pressure as perceived).
+	MinRecoveryIntensity = 1
+	MaxRecoveryIntensity = 5
+	// DefaultRecoveryIntensity is a moderate session, where the coefficients apply unscaled.
+	DefaultRecoveryIntensity = 3
+	// RecoveryActivityReadinessMax caps the readiness score's activity component (±points).
+	RecoveryActivityReadinessMax = 10.0
+	// RecoveryActivityVitalityMax caps a day's activity points in the vitality recovery component.
+	RecoveryActivityVitalityMax = 10.0
+)
+
+// RecoveryActivityCoefficients are a type's points per minute at
+// DefaultRecoveryIntensity.
+type RecoveryActivityCoefficients struct {
+	BenefitPerMin  float64 `json:"benefitPerMin"`
+	LoadPerMin     float64 `json:"loadPerMin"`
+	MaxDurationMin int     `json:"maxDurationMin"` // Longest plausible single session
+}
+
+// RecoveryActivityLibrary holds the coefficients of each activity type.
+var RecoveryActivityLibrary = map[RecoveryActivityType]RecoveryActivityCoefficients{
+	RecoveryActivitySauna:       {BenefitPerMin: 0.25, LoadPerMin: 0.16, MaxDurationMin: 60},  // Heat stress adds cardiovascular load
+	RecoveryActivityColdPlunge:  {BenefitPerMin: 0.80, LoadPerMin: 0.30, MaxDurationMin: 20},  // Short exposures do most of the work
+	RecoveryActivityMassage:     {BenefitPerMin: 0.15, LoadPerMin: 0.02, MaxDurationMin: 120}, // Deep tissue carries some soreness
+	RecoveryActivityFoamRolling: {BenefitPerMin: 0.20, LoadPerMin: 0.02, MaxDurationMin: 60},
+	RecoveryActivityCompression: {BenefitPerMin: 0.05, LoadPerMin: 0, MaxDurationMin: 240},
+}
+
+// RecoveryActivity is one logged recovery session.
+type RecoveryActivity struct {
+	ID          int64                `json:"id"`
+	Date        string               `json:"date"` // YYYY-MM-DD
+	Type        RecoveryActivityType `json:"type"`
+	DurationMin int                  `json:"durationMin"`
+	Intensity   int                  `json:"intensity"` // 1-5
+	CreatedAt   time.Time            `json:"createdAt"`
+}
+
+// Validate checks the date, type, duration and intensity.
+func (a RecoveryActivity) Validate() error {
+	if _, err := time.Parse("2006-01-02", a.Date); err != nil {
+		return ErrInvalidDate
+	}
+	coeffs, ok := RecoveryActivityLibrary[a.Type]
+	if !ok {
+		return ErrInvalidRecoveryActivityType
+	}
+	if a.DurationMin <= 0 || a.DurationMin > coeffs.MaxDurationMin {
+		return ErrInvalidRecoveryActivityDuration
+	}
+	if a.Intensity < MinRecoveryIntensity || a.Intensity > MaxRecoveryIntensity {
+		return ErrInvalidRecoveryActivityIntensity
+	}
+	return nil
+}
+
+// Benefit returns the activity's recovery benefit in points.
+func (a RecoveryActivity) Benefit() float64 {
+	return float64(a.DurationMin) * RecoveryActivityLibrary[a.Type].BenefitPerMin * a.intensityFactor()
+}
+
+// Load returns the stress the activity itself adds, in points.
+func (a RecoveryActivity) Load() float64 {
+	f := a.intensityFactor()
+	return float64(a.DurationMin) * RecoveryActivityLibrary[a.Type].LoadPerMin * f * f
+}
+
+// NetPoints returns benefit less load, rounded to 1 decimal.
+func (a RecoveryActivity) NetPoints() float64 {
+	return RoundTo(a.Benefit()-a.Load(), 1)
+}
+
+// intensityFactor scales the coefficients relative to a moderate session.
+func (a RecoveryActivity) intensityFactor() float64 {
+	return float64(a.Intensity) / DefaultRecoveryIntensity
+}
+
+// RecoveryActivityPoints sums the net points of activities.
+func RecoveryActivityPoints(activities []RecoveryActivity) float64 {
+	total := 0.0
+	for _, a := range activities {
+		total += a.Benefit() - a.Load()
+	}
+	return RoundTo(total, 1)
+}
+
+// clampRecoveryPoints bounds activity points to ±limit.
+func clampRecoveryPoints(points, limit float64) float64 {
+	return math.Max(-limit, math.Min(points, limit))
+}
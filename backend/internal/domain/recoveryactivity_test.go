@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Recovery activity points move readiness and vitality; tests
+// pin the coefficient math, that hard sessions can cost more than they give,
+// and the caps that keep activities from dominating either score.
+type RecoveryActivitySuite struct {
+	suite.Suite
+}
+
+func TestRecoveryActivitySuite(t *testing.T) {
+	suite.Run(t, new(RecoveryActivitySuite))
+}
+
+func (s *RecoveryActivitySuite) TestValidate() {
+	valid := RecoveryActivity{Date: "2026-03-02", Type: RecoveryActivitySauna, DurationMin: 20, Intensity: 3}
+	s.NoError(valid.Validate())
+
+	bad := valid
+	bad.Type = "yoga"
+	s.ErrorIs(bad.Validate(), ErrInvalidRecoveryActivityType)
+	bad = valid
+	bad.DurationMin = 0
+	s.ErrorIs(bad.Validate(), ErrInvalidRecoveryActivityDuration)
+	bad = valid
+	bad.Type, bad.DurationMin = RecoveryActivityColdPlunge, 30
+	s.ErrorIs(bad.Validate(), ErrInvalidRecoveryActivityDuration, "longer than a cold plunge can plausibly last")
+	bad = valid
+	bad.Intensity = 6
+	s.ErrorIs(bad.Validate(), ErrInvalidRecoveryActivityIntensity)
+	bad = valid
+	bad.Date = "02/03/2026"
+	s.ErrorIs(bad.Validate(), ErrInvalidDate)
+}
+
+func (s *RecoveryActivitySuite) TestNetPoints() {
+	moderate := RecoveryActivity{Type: RecoveryActivitySauna, DurationMin: 20, Intensity: 3}
+	s.InDelta(5.0, moderate.Benefit(), 0.001)
+	s.InDelta(3.2, moderate.Load(), 0.001)
+	s.Equal(1.8, moderate.NetPoints())
+
+	// Load grows with the square of intensity, benefit linearly
+	hard := RecoveryActivity{Type: RecoveryActivitySauna, DurationMin: 60, Intensity: 5}
+	s.Less(hard.NetPoints(), 0.0, "a long, hot sauna costs more than it gives")
+
+	massage := RecoveryActivity{Type: RecoveryActivityMassage, DurationMin: 60, Intensity: 3}
+	s.Equal(9.6, RecoveryActivityPoints([]RecoveryActivity{moderate, massage}))
+	s.Zero(RecoveryActivityPoints(nil))
+}
+
+func (s *RecoveryActivitySuite) TestReadinessComponent() {
+	input := RecoveryScoreInput{RestDaysLast7: 1, ACR: 1.0, AvgSleepQualityL7: 60}
+	base := CalculateRecoveryScore(input)
+	s.Zero(base.ActivityComponent)
+
+	input.ActivityPointsL7 = 6
+	withActivities := CalculateRecoveryScore(input)
+	s.Equal(6.0, withActivities.ActivityComponent)
+	s.InDelta(base.Score+6, withActivities.Score, 0.001)
+
+	input.ActivityPointsL7 = 40
+	s.Equal(RecoveryActivityReadinessMax, CalculateRecoveryScore(input).ActivityComponent, "capped")
+	input.ActivityPointsL7 = -40
+	s.Equal(-RecoveryActivityReadinessMax, CalculateRecoveryScore(input).ActivityComponent, "capped")
+}
+
+func (s *RecoveryActivitySuite) TestVitalityRecoveryComponent() {
+	logs := []DailyLog{{Date: "2026-03-02", SleepQuality: 60}}
+	s.InDelta(60.0, calculateRecoveryComponent(logs), 0.001)
+
+	logs[0].RecoveryActivities = []RecoveryActivity{{Type: RecoveryActivityColdPlunge, DurationMin: 5, Intensity: 3}}
+	s.InDelta(62.5, calculateRecoveryComponent(logs), 0.001)
+
+	logs[0].RecoveryActivities = []RecoveryActivity{{Type: RecoveryActivityMassage, DurationMin: 120, Intensity: 3}}
+	s.InDelta(60+RecoveryActivityVitalityMax, calculateRecoveryComponent(logs), 0.001, "capped per day")
+
+	noData := []DailyLog{{Date: "2026-03-02", RecoveryActivities: logs[0].RecoveryActivities}}
+	s.InDelta(50.0, calculateRecoveryComponent(noData), 0.001, "activities alone don't make a day with recovery data")
+}
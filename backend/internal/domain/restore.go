@@ -0,0 +1,124 @@
+package domain
+
+import "fmt"
+
+// RestoreStrategy decides what happens when an archive row matches a row the
+// database already has.
+type RestoreStrategy string
+
+const (
+	// RestoreSkip keeps the existing row and drops the archive's.
+	RestoreSkip RestoreStrategy = "skip"
+	// RestoreOverwrite replaces the existing row's values with the archive's.
+	RestoreOverwrite RestoreStrategy = "overwrite"
+	// RestoreMerge keeps the existing row's values and fills its NULLs from
+	// the archive.
+	RestoreMerge RestoreStrategy = "merge"
+)
+
+// ParseRestoreStrategies reads per-table strategies keyed by table name.
+// Tables left out are skipped on conflict.
+func ParseRestoreStrategies(raw map[string]string) (map[ExportTable]RestoreStrategy, error) {
+	strategies := make(map[ExportTable]RestoreStrategy, len(ExportTables))
+	for _, table := range ExportTables {
+		strategies[table] = RestoreSkip
+	}
+	for name, value := range raw {
+		table := ExportTable(name)
+		if _, ok := strategies[table]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRestoreTable, name)
+		}
+		switch strategy := RestoreStrategy(value); strategy {
+		case RestoreSkip, RestoreOverwrite, RestoreMerge:
+			strategies[table] = strategy
+		default:
+			return nil, fmt.Errorf("%w: %q for %s", ErrInvalidRestoreStrategy, value, name)
+		}
+	}
+	return strategies, nil
+}
+
+// ExportArchive is an export read back for a restore. Values are text, as
+// Postgres reads them; nil is NULL.
+type ExportArchive struct {
+	Manifest ExportManifest
+	Tables   map[ExportTable]*ExportTableData
+	// EmptyIsNull is set for CSV archives, where NULL and an empty string
+	// are both an empty cell.
+	EmptyIsNull bool
+}
+
+// ExportTableData holds one table's columns and rows.
+type ExportTableData struct {
+	Columns []string
+	Rows    [][]*string
+	index   map[string]int
+}
+
+// NewExportTableData creates an empty table with the given columns.
+func NewExportTableData(columns []string) *ExportTableData {
+	index := make(map[string]int, len(columns))
+	for i, c := range columns {
+		index[c] = i
+	}
+	return &ExportTableData{Columns: columns, index: index}
+}
+
+// HasColumn reports whether the archive has the column.
+func (t *ExportTableData) HasColumn(column string) bool {
+	_, ok := t.index[column]
+	return ok
+}
+
+// Value returns a row's value for a column, and false when the archive has
+// no such column.
+func (t *ExportTableData) Value(row []*string, column string) (*string, bool) {
+	i, ok := t.index[column]
+	if !ok || i >= len(row) {
+		return nil, false
+	}
+	return row[i], true
+}
+
+// Validate checks the archive was written by a Victus export this version
+// can read, and holds no table it doesn't know.
+func (a *ExportArchive) Validate() error {
+	if a.Manifest.Format != ExportArchiveName {
+		return fmt.Errorf("%w: not a %s archive", ErrInvalidExportArchive, ExportArchiveName)
+	}
+	if a.Manifest.Version < 1 || a.Manifest.Version > ExportVersion {
+		return ErrUnsupportedExportVersion
+	}
+	for table, data := range a.Tables {
+		if !IsExportTable(table) {
+			return fmt.Errorf("%w: unknown table %q", ErrInvalidExportArchive, table)
+		}
+		if len(data.Rows) > 0 {
+			if _, ok := data.index["id"]; !ok {
+				return fmt.Errorf("%w: %s has no id column", ErrInvalidExportArchive, table)
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreTableResult counts what happened to one table's rows. Conflicts are
+// rows matching an existing row, each then skipped, overwritten or merged.
+type RestoreTableResult struct {
+	Table       ExportTable     `json:"table"`
+	Strategy    RestoreStrategy `json:"strategy"`
+	Rows        int             `json:"rows"`
+	Inserted    int             `json:"inserted"`
+	Conflicts   int             `json:"conflicts"`
+	Skipped     int             `json:"skipped"`
+	Overwritten int             `json:"overwritten"`
+	Merged      int             `json:"merged"`
+	// IgnoredColumns are archive columns this database doesn't have.
+	IgnoredColumns []string `json:"ignoredColumns,omitempty"`
+}
+
+// RestoreResult summarizes a restore.
+type RestoreResult struct {
+	ExportedAt string               `json:"exportedAt"`
+	Tables     []RestoreTableResult `json:"tables"`
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// =============================================================================
+// ROUNDING POLICY
+// =============================================================================
+//
+// Macro math is done in float64 and reported as whole grams, kcal and meal
+// points. Every conversion from a computed float to a reported integer goes
+// through these helpers so targets, points and weekly averages agree:
+//
+//   - Ties round half to even (banker's rounding), so rounding many .5 values
+//     doesn't bias totals upward.
+//   - A value within roundingEpsilon of a tie is treated as the tie, so binary
+//     noise (2.675 stored as 2.67499999...) rounds like the decimal it stands for.
+//   - MRound rounds meal points and produce grams to a multiple like the
+//     spreadsheet's MROUND, except that ties go to the even multiple where
+//     MROUND rounds them away from zero.
+//   - Values that are reported together and must add up (a week of daily
+//     grams) use RoundPreservingSum instead of rounding each one independently.
+
+// roundingEpsilon is how close to .5 a fraction must be to count as a tie.
+const roundingEpsilon = 1e-9
+
+// RoundHalfEven rounds x to the nearest integer, ties to even.
+func RoundHalfEven(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	floor := math.Floor(x)
+	diff := x - floor
+	switch {
+	case math.Abs(diff-0.5) < roundingEpsilon:
+		if math.Mod(floor, 2) == 0 {
+			return floor
+		}
+		return floor + 1
+	case diff < 0.5:
+		return floor
+	default:
+		return floor + 1
+	}
+}
+
+// RoundInt rounds x half to even and converts it to an int.
+func RoundInt(x float64) int {
+	return int(RoundHalfEven(x))
+}
+
+// RoundTo rounds x half to even at the given number of decimal places.
+func RoundTo(x float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return RoundHalfEven(x*scale) / scale
+}
+
+// MRound rounds x to the nearest multiple, ties to the even multiple (MROUND
+// would round 12.5 to 15 with a multiple of 5; MRound gives 10). A zero
+// multiple returns 0, as MROUND does.
+func MRound(x, multiple float64) float64 {
+	if multiple == 0 {
+		return 0
+	}
+	return RoundHalfEven(x/multiple) * multiple
+}
+
+// MRoundInt rounds x to the nearest integer multiple.
+func MRoundInt(x float64, multiple int) int {
+	return int(MRound(x, float64(multiple)))
+}
+
+// RoundPreservingSum rounds each value to an integer such that the results add
+// up to the rounded sum of the inputs (largest remainder method). Units left
+// over after flooring go to the largest fractional parts; equal fractions go
+// to the earlier value, so the result is deterministic.
+func RoundPreservingSum(values []float64) []int {
+	result := make([]int, len(values))
+	if len(values) == 0 {
+		return result
+	}
+
+	var sum float64
+	floorSum := 0
+	for i, v := range values {
+		sum += v
+		result[i] = int(math.Floor(v + roundingEpsilon))
+		floorSum += result[i]
+	}
+
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	frac := func(i int) float64 { return values[i] - float64(result[i]) }
+	sort.SliceStable(order, func(a, b int) bool {
+		return frac(order[a]) > frac(order[b])+roundingEpsilon
+	})
+
+	remaining := RoundInt(sum) - floorSum
+	for k := 0; k < remaining && k < len(order); k++ {
+		result[order[k]]++
+	}
+	return result
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// sampleDocPath is docs/sample.md relative to this package.
+const sampleDocPath = "../../../docs/sample.md"
+
+// sampleFixtures holds the reference tables of docs/sample.md, parsed at test
+// time so a new reference week only needs adding to the doc.
+type sampleFixtures struct {
+	Weeks    []sampleWeekEntry   // "### Plan"
+	Days     []sampleDayEntry    // "### Macros per day", 7 rows per week
+	Calendar []sampleCalendarDay // "### Example Week 1 Calendar"
+}
+
+// sampleCalendarDay is one row of the example calendar: per-meal ratios and
+// points, plus the day's produce.
+type sampleCalendarDay struct {
+	Day    int
+	Ratios MealRatios
+	Carbs  [3]int // breakfast, lunch, dinner
+	Prot   [3]int
+	Fats   [3]int
+	FruitG float64
+	VegG   float64
+}
+
+// loadSampleFixtures parses the reference tables from docs/sample.md.
+func loadSampleFixtures(path string) (*sampleFixtures, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseSampleFixtures(f)
+}
+
+var (
+	sampleMealCell = regexp.MustCompile(`^(\d+)%(\d+) / (\d+) / (\d+)$`)
+	sampleFruit    = regexp.MustCompile(`Fruits: (\d+) g`)
+	sampleVeg      = regexp.MustCompile(`Veggies: (\d+) g`)
+)
+
+// parseSampleFixtures reads the sections it knows and skips the rest. The
+// tables are spreadsheet pastes: tab-separated with decimal commas, except
+// the calendar, which is CSV.
+func parseSampleFixtures(r io.Reader) (*sampleFixtures, error) {
+	fx := &sampleFixtures{}
+	section := ""
+	var dayRows [][3]int
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			section = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var err error
+		switch section {
+		case "Plan":
+			if strings.HasPrefix(line, "Week") {
+				continue // Header
+			}
+			var week sampleWeekEntry
+			week, err = parseSampleWeek(line)
+			fx.Weeks = append(fx.Weeks, week)
+		case "Macros per day":
+			if strings.HasPrefix(line, "Carbs") {
+				continue // Header, repeated every few weeks
+			}
+			var row [3]int
+			row, err = parseSampleDayRow(line)
+			dayRows = append(dayRows, row)
+		case "Example Week 1 Calendar":
+			if strings.HasPrefix(line, "Day,") {
+				continue // Header
+			}
+			var day sampleCalendarDay
+			day, err = parseSampleCalendarDay(line)
+			fx.Calendar = append(fx.Calendar, day)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(dayRows)%7 != 0 {
+		return nil, fmt.Errorf("macros per day: %d rows is not whole weeks", len(dayRows))
+	}
+	for i := 0; i < len(dayRows); i += 7 {
+		fx.Days = append(fx.Days, sampleWeekDays(i/7+1, dayRows[i:i+7])...)
+	}
+	return fx, nil
+}
+
+// parseSampleWeek parses a plan row: week, kcal factor, weight, kcal, then
+// percentage, grams and grams per kg for each macro.
+func parseSampleWeek(line string) (sampleWeekEntry, error) {
+	cols := strings.Split(line, "\t")
+	if len(cols) != 13 {
+		return sampleWeekEntry{}, fmt.Errorf("plan row has %d columns, want 13", len(cols))
+	}
+	v := make([]float64, len(cols))
+	for i, col := range cols {
+		col = strings.ReplaceAll(strings.TrimSpace(col), ",", ".")
+		scale := 1.0
+		if strings.HasSuffix(col, "%") {
+			col, scale = strings.TrimSuffix(col, "%"), 0.01
+		}
+		f, err := strconv.ParseFloat(col, 64)
+		if err != nil {
+			return sampleWeekEntry{}, err
+		}
+		v[i] = RoundTo(f*scale, 2)
+	}
+	return sampleWeekEntry{
+		Week:       int(v[0]),
+		Weight:     v[2],
+		Kcal:       int(v[3]),
+		FatPct:     v[4],
+		FatG:       int(v[5]),
+		FatGPerKg:  v[6],
+		ProtPct:    v[7],
+		ProtG:      int(v[8]),
+		ProtGPerKg: v[9],
+		CarbPct:    v[10],
+		CarbG:      int(v[11]),
+		CarbGPerKg: v[12],
+	}, nil
+}
+
+// parseSampleDayRow parses "317 g<TAB>229 g<TAB>123 g" into carbs, protein
+// and fat grams.
+func parseSampleDayRow(line string) ([3]int, error) {
+	var row [3]int
+	cols := strings.Split(line, "\t")
+	if len(cols) != 3 {
+		return row, fmt.Errorf("macros row has %d columns, want 3", len(cols))
+	}
+	for i, col := range cols {
+		g, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(col), "g")))
+		if err != nil {
+			return row, err
+		}
+		row[i] = g
+	}
+	return row, nil
+}
+
+// sampleWeekDays labels a week's rows with day types, which the doc leaves
+// implicit: the highest-carb day is metabolize, the lowest fatburner and any
+// other performance.
+func sampleWeekDays(week int, rows [][3]int) []sampleDayEntry {
+	carbs := make([]int, len(rows))
+	for i, row := range rows {
+		carbs[i] = row[0]
+	}
+	sort.Ints(carbs)
+
+	days := make([]sampleDayEntry, len(rows))
+	for i, row := range rows {
+		dayType := DayTypePerformance
+		switch row[0] {
+		case carbs[len(carbs)-1]:
+			dayType = DayTypeMetabolize
+		case carbs[0]:
+			dayType = DayTypeFatburner
+		}
+		days[i] = sampleDayEntry{Week: week, Day: i + 1, DayType: dayType, CarbsG: row[0], ProteinG: row[1], FatG: row[2]}
+	}
+	return days
+}
+
+// parseSampleCalendarDay parses "Day 1,30%90 / 190 / 130,...,Water: ...".
+// Each meal cell is the meal's ratio followed by its carb/protein/fat points.
+func parseSampleCalendarDay(line string) (sampleCalendarDay, error) {
+	cols := strings.Split(line, ",")
+	if len(cols) != 5 {
+		return sampleCalendarDay{}, fmt.Errorf("calendar row has %d columns, want 5", len(cols))
+	}
+
+	day := sampleCalendarDay{}
+	if _, err := fmt.Sscanf(cols[0], "Day %d", &day.Day); err != nil {
+		return day, err
+	}
+	ratios := [3]*float64{&day.Ratios.Breakfast, &day.Ratios.Lunch, &day.Ratios.Dinner}
+	for i, cell := range cols[1:4] {
+		m := sampleMealCell.FindStringSubmatch(strings.TrimSpace(cell))
+		if m == nil {
+			return day, fmt.Errorf("meal cell %q", cell)
+		}
+		n := make([]int, 4)
+		for j := range n {
+			n[j], _ = strconv.Atoi(m[j+1])
+		}
+		*ratios[i] = float64(n[0]) / 100
+		day.Carbs[i], day.Prot[i], day.Fats[i] = n[1], n[2], n[3]
+	}
+
+	fruit, veg := sampleFruit.FindStringSubmatch(cols[4]), sampleVeg.FindStringSubmatch(cols[4])
+	if fruit == nil || veg == nil {
+		return day, fmt.Errorf("totals cell %q", cols[4])
+	}
+	day.FruitG, _ = strconv.ParseFloat(fruit[1], 64)
+	day.VegG, _ = strconv.ParseFloat(veg[1], 64)
+	return day, nil
+}
+
+// Justification: The sample suites read their reference data through this
+// parser; tests pin the doc's spreadsheet formats so a malformed paste fails
+// loudly instead of silently dropping rows.
+type SampleFixturesSuite struct {
+	suite.Suite
+}
+
+func TestSampleFixturesSuite(t *testing.T) {
+	suite.Run(t, new(SampleFixturesSuite))
+}
+
+func (s *SampleFixturesSuite) TestParsesSpreadsheetFormats() {
+	doc := strings.Join([]string{
+		"### Plan",
+		"Week| Kcal Factor|Weight| Kcal| Fat%| Fat g| Fat Kcal/g| Prot%| Prot g| Prot Kcal/g| Carb%| Carb g| Carb Kcal/g",
+		"1\t33,0\t89,5\t2951\t38%\t277\t3,09\t28%\t200\t2,23\t34%\t107\t1,20",
+		"",
+		"### Macros per day",
+		"Carbs\tProtein\tFats",
+		"317 g\t229 g\t123 g",
+		"207 g\t150 g\t80 g",
+		"207 g\t150 g\t80 g",
+		"317 g\t229 g\t123 g",
+		"207 g\t150 g\t80 g",
+		"207 g\t150 g\t80 g",
+		"376 g\t270 g\t145 g",
+		"\t\t",
+		"### Example Week 1 Calendar",
+		"Day,Breakfast,Lunch,Dinner,Daily Supplements & Totals",
+		"Day 7,30%115 / 290 / 150,40%150 / 390 / 205,30%115 / 290 / 150,Water: 3.6 LFruits: 224 gVeggies: 835 gMalto PoWo: 0 g",
+		"## Starting Values",
+		"Nutrient,Total Grams,Amount per kg (Bodyweight)",
+	}, "\n")
+
+	fx, err := parseSampleFixtures(strings.NewReader(doc))
+	s.Require().NoError(err)
+
+	s.Equal([]sampleWeekEntry{{
+		Week: 1, Weight: 89.5, Kcal: 2951,
+		FatPct: 0.38, FatG: 277, FatGPerKg: 3.09,
+		ProtPct: 0.28, ProtG: 200, ProtGPerKg: 2.23,
+		CarbPct: 0.34, CarbG: 107, CarbGPerKg: 1.20,
+	}}, fx.Weeks)
+
+	s.Require().Len(fx.Days, 7)
+	s.Equal(sampleDayEntry{Week: 1, Day: 1, DayType: DayTypePerformance, CarbsG: 317, ProteinG: 229, FatG: 123}, fx.Days[0])
+	s.Equal(DayTypeFatburner, fx.Days[1].DayType)
+	s.Equal(DayTypeMetabolize, fx.Days[6].DayType)
+
+	s.Equal([]sampleCalendarDay{{
+		Day:    7,
+		Ratios: MealRatios{Breakfast: 0.30, Lunch: 0.40, Dinner: 0.30},
+		Carbs:  [3]int{115, 150, 115},
+		Prot:   [3]int{290, 390, 290},
+		Fats:   [3]int{150, 205, 150},
+		FruitG: 224,
+		VegG:   835,
+	}}, fx.Calendar)
+}
+
+func (s *SampleFixturesSuite) TestRejectsMalformedTables() {
+	_, err := parseSampleFixtures(strings.NewReader("### Macros per day\n317 g\t229 g\t123 g\n"))
+	s.ErrorContains(err, "not whole weeks")
+
+	_, err = parseSampleFixtures(strings.NewReader("### Plan\n1\t33,0\t89,5\n"))
+	s.ErrorContains(err, "line 2")
+}
+
+func (s *SampleFixturesSuite) TestLoadsSampleDoc() {
+	fx, err := loadSampleFixtures(sampleDocPath)
+	s.Require().NoError(err)
+	s.Len(fx.Weeks, 19)
+	s.Len(fx.Days, 19*7)
+	s.Len(fx.Calendar, 7)
+	for i, week := range fx.Weeks {
+		s.Equal(i+1, week.Week)
+	}
+}
@@ -44,6 +44,18 @@ type sampleDayEntry struct {
 	FatG    int
 }
 
+// sampleDays returns the daily macros of one sample week, in day order.
+func (s *SamplePlanSuite) sampleDays(week int) []sampleDayEntry {
+	var days []sampleDayEntry
+	for _, day := range s.sampleDayData {
+		if day.Week == week {
+			days = append(days, day)
+		}
+	}
+	s.Require().Len(days, 7, "week %d", week)
+	return days
+}
+
 func TestSamplePlanSuite(t *testing.T) {
 	suite.Run(t, new(SamplePlanSuite))
 }
@@ -68,25 +80,11 @@ func (s *SamplePlanSuite) SetupTest() {
 		VeggieTargetG: 500,
 	}
 
-	// Weekly summary data from sample.md (selected weeks)
-	s.sampleWeekData = []sampleWeekEntry{
-		{Week: 1, Weight: 89.5, Kcal: 2951, FatPct: 0.38, FatG: 277, FatGPerKg: 3.09, ProtPct: 0.28, ProtG: 200, ProtGPerKg: 2.23, CarbPct: 0.34, CarbG: 107, CarbGPerKg: 1.20},
-		{Week: 2, Weight: 89.1, Kcal: 2936, FatPct: 0.38, FatG: 273, FatGPerKg: 3.07, ProtPct: 0.28, ProtG: 199, ProtGPerKg: 2.24, CarbPct: 0.34, CarbG: 107, CarbGPerKg: 1.21},
-		{Week: 10, Weight: 85.6, Kcal: 2822, FatPct: 0.35, FatG: 244, FatGPerKg: 2.85, ProtPct: 0.28, ProtG: 194, ProtGPerKg: 2.27, CarbPct: 0.36, CarbG: 111, CarbGPerKg: 1.29},
-		{Week: 19, Weight: 81.8, Kcal: 2699, FatPct: 0.32, FatG: 213, FatGPerKg: 2.60, ProtPct: 0.29, ProtG: 188, ProtGPerKg: 2.30, CarbPct: 0.39, CarbG: 114, CarbGPerKg: 1.39},
-	}
-
-	// Daily macro data from sample.md Week 1
-	// Days 1,4 = Performance (high), Days 2,3,5,6 = Fatburner (low), Day 7 = Metabolize (refeed)
-	s.sampleDayData = []sampleDayEntry{
-		{Week: 1, Day: 1, DayType: DayTypePerformance, CarbsG: 317, ProteinG: 229, FatG: 123},
-		{Week: 1, Day: 2, DayType: DayTypeFatburner, CarbsG: 207, ProteinG: 150, FatG: 80},
-		{Week: 1, Day: 3, DayType: DayTypeFatburner, CarbsG: 207, ProteinG: 150, FatG: 80},
-		{Week: 1, Day: 4, DayType: DayTypePerformance, CarbsG: 317, ProteinG: 229, FatG: 123},
-		{Week: 1, Day: 5, DayType: DayTypeFatburner, CarbsG: 207, ProteinG: 150, FatG: 80},
-		{Week: 1, Day: 6, DayType: DayTypeFatburner, CarbsG: 207, ProteinG: 150, FatG: 80},
-		{Week: 1, Day: 7, DayType: DayTypeMetabolize, CarbsG: 376, ProteinG: 270, FatG: 145},
-	}
+	// Weekly summary and daily macros for all 19 weeks, read from sample.md
+	fixtures, err := loadSampleFixtures(sampleDocPath)
+	s.Require().NoError(err)
+	s.sampleWeekData = fixtures.Weeks
+	s.sampleDayData = fixtures.Days
 }
 
 // =============================================================================
@@ -207,29 +205,34 @@ func (s *SamplePlanSuite) TestDailyMacroCycling() {
 	})
 
 	s.Run("performance days have higher carbs than fatburner days", func() {
-		var perfCarbs, fatburnerCarbs int
-		for _, day := range s.sampleDayData {
-			if day.DayType == DayTypePerformance {
-				perfCarbs = day.CarbsG
-			} else if day.DayType == DayTypeFatburner {
-				fatburnerCarbs = day.CarbsG
+		for _, week := range s.sampleWeekData {
+			var perfCarbs, fatburnerCarbs int
+			for _, day := range s.sampleDays(week.Week) {
+				if day.DayType == DayTypePerformance {
+					perfCarbs = day.CarbsG
+				} else if day.DayType == DayTypeFatburner {
+					fatburnerCarbs = day.CarbsG
+				}
 			}
+			s.Greater(perfCarbs, fatburnerCarbs,
+				"Week %d: Performance carbs (%d) should be > Fatburner carbs (%d)", week.Week, perfCarbs, fatburnerCarbs)
 		}
-		s.Greater(perfCarbs, fatburnerCarbs,
-			"Performance carbs (%d) should be > Fatburner carbs (%d)", perfCarbs, fatburnerCarbs)
 	})
 
 	s.Run("metabolize day has highest carbs", func() {
-		var maxCarbs int
-		var maxDay sampleDayEntry
-		for _, day := range s.sampleDayData {
-			if day.CarbsG > maxCarbs {
-				maxCarbs = day.CarbsG
-				maxDay = day
+		for _, week := range s.sampleWeekData {
+			var maxCarbs int
+			var maxDay sampleDayEntry
+			for _, day := range s.sampleDays(week.Week) {
+				if day.CarbsG > maxCarbs {
+					maxCarbs = day.CarbsG
+					maxDay = day
+				}
 			}
+			s.Equal(DayTypeMetabolize, maxDay.DayType,
+				"Week %d: Day with highest carbs (%d) should be Metabolize", week.Week, maxCarbs)
+			s.Equal(7, maxDay.Day, "Week %d: the refeed closes the week", week.Week)
 		}
-		s.Equal(DayTypeMetabolize, maxDay.DayType,
-			"Day with highest carbs (%d) should be Metabolize", maxCarbs)
 	})
 
 	s.Run("carb ratio between day types matches multipliers", func() {
@@ -261,29 +264,30 @@ func (s *SamplePlanSuite) TestDailyMacroCycling() {
 
 func (s *SamplePlanSuite) TestWeeklyAverageCalculation() {
 	s.Run("daily macros average to weekly baseline", func() {
-		// Calculate weekly totals from daily data
-		var totalCarbs, totalProtein, totalFat int
-		for _, day := range s.sampleDayData {
-			totalCarbs += day.CarbsG
-			totalProtein += day.ProteinG
-			totalFat += day.FatG
-		}
+		for _, week := range s.sampleWeekData {
+			// Calculate weekly totals from daily data
+			var totalCarbs, totalProtein, totalFat int
+			for _, day := range s.sampleDays(week.Week) {
+				totalCarbs += day.CarbsG
+				totalProtein += day.ProteinG
+				totalFat += day.FatG
+			}
 
-		avgCarbs := float64(totalCarbs) / 7.0
-		avgProtein := float64(totalProtein) / 7.0
-		avgFat := float64(totalFat) / 7.0
+			avgCarbs := float64(totalCarbs) / 7.0
+			avgProtein := float64(totalProtein) / 7.0
+			avgFat := float64(totalFat) / 7.0
 
-		// Calculate average daily calories
-		avgCals := (avgCarbs * 4) + (avgProtein * 4) + (avgFat * 9)
+			// Calculate average daily calories
+			avgCals := (avgCarbs * 4) + (avgProtein * 4) + (avgFat * 9)
 
-		// The daily cycling should average to less than TDEE (deficit)
-		week1TDEE := float64(s.sampleWeekData[0].Kcal)
-		s.Less(avgCals, week1TDEE,
-			"Average daily calories (%.0f) should be less than TDEE (%.0f)", avgCals, week1TDEE)
+			// The daily cycling should average to less than TDEE (deficit)
+			s.Less(avgCals, float64(week.Kcal),
+				"Week %d: Average daily calories (%.0f) should be less than TDEE (%d)", week.Week, avgCals, week.Kcal)
 
-		// Document the actual averages
-		s.T().Logf("Week 1 daily averages: Carbs=%.1fg, Protein=%.1fg, Fat=%.1fg, Calories=%.0f",
-			avgCarbs, avgProtein, avgFat, avgCals)
+			// Document the actual averages
+			s.T().Logf("Week %d daily averages: Carbs=%.1fg, Protein=%.1fg, Fat=%.1fg, Calories=%.0f",
+				week.Week, avgCarbs, avgProtein, avgFat, avgCals)
+		}
 	})
 }
 
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// SATIETY LEARNING
+// =============================================================================
+//
+// After a meal the user can rate how hungry they got before the next one,
+// from 1 (satisfied) to 5 (ravenous). Solver suggestions logged against a
+// meal slot say which foods that meal held. A food, or a food category, that
+// shows up in meals rated less hungry than the user's usual earns a positive
+// satiety score; one followed by more hunger earns a negative one. Scores
+// need a few rated meals and are pulled towards neutral until there are
+// more. When hunger has been running high, the solver uses them to favor
+// filling combinations, but only on fatburner days, when the deficit makes
+// hunger the limiting factor.
+
+const (
+	// SatietyWindowDays is how far back ratings and logged meals are read.
+	SatietyWindowDays = 56
+	// SatietyMinMeals is the number of rated meals a food or category needs for a score.
+	SatietyMinMeals = 3
+	// SatietyTrendDays is the window for recent hunger.
+	SatietyTrendDays = 7
+	// SatietyTrendMinRatings is the number of recent ratings needed to judge the trend.
+	SatietyTrendMinRatings = 3
+	// SatietyHighHunger is the recent average hunger at or above which hunger is trending high.
+	SatietyHighHunger = 3.5
+	// satietyShrinkMeals is the pseudo-count pulling small samples towards neutral.
+	satietyShrinkMeals = 5.0
+	// satietyFullGap is the hunger gap from the usual (on the 1-5 scale) at which a score reaches ±1.
+	satietyFullGap = 2.0
+	// satietyMaxPoints is the most the satiety bonus moves a match score.
+	satietyMaxPoints = 8.0
+)
+
+// MealHunger is the hunger rating that followed a meal.
+type MealHunger struct {
+	Date   string   `json:"date"` // YYYY-MM-DD
+	Meal   MealName `json:"meal"`
+	Hunger int      `json:"hunger"` // 1 (satisfied) to 5 (ravenous) before the next meal
+}
+
+// Validate checks the date, meal slot and rating.
+func (h MealHunger) Validate() error {
+	if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if !ValidMealNames[h.Meal] {
+		return ErrInvalidMealName
+	}
+	if h.Hunger < CheckInMin || h.Hunger > CheckInMax {
+		return ErrInvalidMealHunger
+	}
+	return nil
+}
+
+// LoggedMealFood is a food logged in a meal slot through the solver.
+type LoggedMealFood struct {
+	Date            string
+	Meal            MealName
+	FoodReferenceID int64
+	Category        FoodCategory
+}
+
+// SatietyScore is the learned satiety of a food or category: -1 (followed by
+// more hunger than usual) to 1 (less).
+type SatietyScore struct {
+	Score float64 `json:"score"`
+	Meals int     `json:"meals"` // Rated meals it appeared in
+}
+
+// SatietyProfile is what the solver knows about satiety.
+type SatietyProfile struct {
+	Foods              map[int64]SatietyScore
+	Categories         map[FoodCategory]SatietyScore
+	RecentHunger       *float64 // Average rating over the last SatietyTrendDays days
+	HungerTrendingHigh bool
+}
+
+// BuildSatietyProfile learns satiety from the ratings and logged meal foods
+// in the SatietyWindowDays days before now.
+func BuildSatietyProfile(ratings []MealHunger, foods []LoggedMealFood, now time.Time) SatietyProfile {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	windowStart := today.AddDate(0, 0, -SatietyWindowDays).Format("2006-01-02")
+	trendStart := today.AddDate(0, 0, -SatietyTrendDays+1).Format("2006-01-02")
+
+	profile := SatietyProfile{
+		Foods:      map[int64]SatietyScore{},
+		Categories: map[FoodCategory]SatietyScore{},
+	}
+
+	type mealKey struct {
+		date string
+		meal MealName
+	}
+	hungerByMeal := make(map[mealKey]int)
+	var trendSum, trendCount int
+	for _, r := range ratings {
+		if r.Date < windowStart {
+			continue
+		}
+		hungerByMeal[mealKey{r.Date, r.Meal}] = r.Hunger
+		if r.Date >= trendStart {
+			trendSum += r.Hunger
+			trendCount++
+		}
+	}
+	if trendCount > 0 {
+		avg := math.Round(float64(trendSum)/float64(trendCount)*10) / 10
+		profile.RecentHunger = &avg
+		profile.HungerTrendingHigh = trendCount >= SatietyTrendMinRatings && avg >= SatietyHighHunger
+	}
+
+	// Collect the foods and categories of each rated meal, once per meal
+	foodMeals := make(map[int64]map[mealKey]bool)
+	categoryMeals := make(map[FoodCategory]map[mealKey]bool)
+	ratedMeals := make(map[mealKey]bool)
+	for _, f := range foods {
+		key := mealKey{f.Date, f.Meal}
+		if _, rated := hungerByMeal[key]; !rated {
+			continue
+		}
+		ratedMeals[key] = true
+		if foodMeals[f.FoodReferenceID] == nil {
+			foodMeals[f.FoodReferenceID] = make(map[mealKey]bool)
+		}
+		foodMeals[f.FoodReferenceID][key] = true
+		if categoryMeals[f.Category] == nil {
+			categoryMeals[f.Category] = make(map[mealKey]bool)
+		}
+		categoryMeals[f.Category][key] = true
+	}
+	if len(ratedMeals) == 0 {
+		return profile
+	}
+
+	var usual float64
+	for key := range ratedMeals {
+		usual += float64(hungerByMeal[key])
+	}
+	usual /= float64(len(ratedMeals))
+
+	score := func(meals map[mealKey]bool) (SatietyScore, bool) {
+		if len(meals) < SatietyMinMeals {
+			return SatietyScore{}, false
+		}
+		var sum float64
+		for key := range meals {
+			sum += float64(hungerByMeal[key])
+		}
+		n := float64(len(meals))
+		gap := usual - sum/n
+		s := math.Max(-1, math.Min(1, gap/satietyFullGap)) * n / (n + satietyShrinkMeals)
+		return SatietyScore{Score: math.Round(s*100) / 100, Meals: len(meals)}, true
+	}
+	for id, meals := range foodMeals {
+		if s, ok := score(meals); ok {
+			profile.Foods[id] = s
+		}
+	}
+	for category, meals := range categoryMeals {
+		if s, ok := score(meals); ok {
+			profile.Categories[category] = s
+		}
+	}
+	return profile
+}
+
+// FavorsSatiety reports whether the solver should favor filling foods on a
+// day of the given type.
+func (p SatietyProfile) FavorsSatiety(dayType DayType) bool {
+	return dayType == DayTypeFatburner && p.HungerTrendingHigh
+}
+
+// foodScore returns a food's satiety score, falling back to its category's.
+func (p SatietyProfile) foodScore(f FoodNutrition) float64 {
+	if s, ok := p.Foods[f.ID]; ok {
+		return s.Score
+	}
+	return p.Categories[f.Category].Score
+}
+
+// bonus returns the satiety points for a set of ingredients: the mean
+// ingredient score scaled to ±satietyMaxPoints.
+func (p *SatietyProfile) bonus(ingredients []SolverIngredient) float64 {
+	if p == nil || len(ingredients) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, ing := range ingredients {
+		sum += p.foodScore(ing.Food)
+	}
+	return sum / float64(len(ingredients)) * satietyMaxPoints
+}
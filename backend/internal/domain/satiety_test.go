@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Satiety learning compares each food's meals against the
+// user's usual hunger and shrinks small samples towards neutral; tests pin
+// the scoring, the category fallback, the hunger trend and when the solver
+// acts on it.
+type SatietySuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestSatietySuite(t *testing.T) {
+	suite.Run(t, new(SatietySuite))
+}
+
+func (s *SatietySuite) SetupTest() {
+	s.now = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+}
+
+// rotation logs four days of a filling lunch (chicken, broccoli) rated 2 and
+// a lighter dinner (rice, berries) rated 4.
+func (s *SatietySuite) rotation() ([]MealHunger, []LoggedMealFood) {
+	var ratings []MealHunger
+	var foods []LoggedMealFood
+	for d := 1; d <= 4; d++ {
+		date := time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		ratings = append(ratings,
+			MealHunger{Date: date, Meal: MealLunch, Hunger: 2},
+			MealHunger{Date: date, Meal: MealDinner, Hunger: 4},
+		)
+		foods = append(foods,
+			LoggedMealFood{Date: date, Meal: MealLunch, FoodReferenceID: 1, Category: FoodCategoryHighProtein},
+			LoggedMealFood{Date: date, Meal: MealLunch, FoodReferenceID: 4, Category: FoodCategoryVegetable},
+			LoggedMealFood{Date: date, Meal: MealDinner, FoodReferenceID: 2, Category: FoodCategoryHighCarb},
+			LoggedMealFood{Date: date, Meal: MealDinner, FoodReferenceID: 3, Category: FoodCategoryFruit},
+		)
+	}
+	return ratings, foods
+}
+
+func (s *SatietySuite) TestValidate() {
+	s.NoError(MealHunger{Date: "2026-03-04", Meal: MealBreakfast, Hunger: 3}.Validate())
+	s.ErrorIs(MealHunger{Date: "03/04/2026", Meal: MealBreakfast, Hunger: 3}.Validate(), ErrInvalidDate)
+	s.ErrorIs(MealHunger{Date: "2026-03-04", Meal: "snack", Hunger: 3}.Validate(), ErrInvalidMealName)
+	s.ErrorIs(MealHunger{Date: "2026-03-04", Meal: MealLunch, Hunger: 6}.Validate(), ErrInvalidMealHunger)
+
+	lunch := MealLunch
+	feedback := SolverFeedback{Action: SolverFeedbackAccepted, FoodIDs: []int64{1}, Meal: &lunch}
+	s.ErrorIs(feedback.Validate(), ErrInvalidDate, "a meal needs its date")
+	feedback.Date = "2026-03-04"
+	s.Require().NoError(feedback.Validate())
+	for _, sig := range feedback.Signals(s.now) {
+		s.Equal("2026-03-04", sig.Date)
+		s.Equal(&lunch, sig.Meal)
+	}
+}
+
+func (s *SatietySuite) TestScores() {
+	ratings, foods := s.rotation()
+	// One meal isn't enough for a food score of its own
+	foods = append(foods, LoggedMealFood{Date: "2026-03-04", Meal: MealDinner, FoodReferenceID: 5, Category: FoodCategoryHighCarb})
+	// Foods from unrated meals are ignored
+	foods = append(foods, LoggedMealFood{Date: "2026-03-04", Meal: MealBreakfast, FoodReferenceID: 1, Category: FoodCategoryHighProtein})
+
+	profile := BuildSatietyProfile(ratings, foods, s.now)
+
+	// Usual hunger is 3; lunch foods run 1 below it over 4 meals: 0.5 * 4/9
+	s.Equal(SatietyScore{Score: 0.22, Meals: 4}, profile.Foods[1])
+	s.Equal(SatietyScore{Score: -0.22, Meals: 4}, profile.Foods[2])
+	s.NotContains(profile.Foods, int64(5))
+	s.Equal(0.22, profile.Categories[FoodCategoryVegetable].Score)
+	s.Equal(-0.22, profile.Categories[FoodCategoryHighCarb].Score)
+
+	s.Equal(-0.22, profile.foodScore(FoodNutrition{ID: 5, Category: FoodCategoryHighCarb}), "falls back to the category")
+	s.Equal(0.0, profile.foodScore(FoodNutrition{ID: 6, Category: FoodCategoryHighFat}))
+}
+
+func (s *SatietySuite) TestHungerTrend() {
+	ratings, foods := s.rotation()
+	profile := BuildSatietyProfile(ratings, foods, s.now)
+	s.Require().NotNil(profile.RecentHunger)
+	s.Equal(3.0, *profile.RecentHunger)
+	s.False(profile.HungerTrendingHigh)
+
+	high := []MealHunger{
+		{Date: "2026-03-02", Meal: MealLunch, Hunger: 4},
+		{Date: "2026-03-03", Meal: MealLunch, Hunger: 4},
+		{Date: "2026-03-04", Meal: MealBreakfast, Hunger: 3},
+		{Date: "2026-02-20", Meal: MealLunch, Hunger: 1}, // Before the trend window
+	}
+	profile = BuildSatietyProfile(high, nil, s.now)
+	s.Equal(3.7, *profile.RecentHunger)
+	s.True(profile.HungerTrendingHigh)
+	s.True(profile.FavorsSatiety(DayTypeFatburner))
+	s.False(profile.FavorsSatiety(DayTypePerformance), "only fatburner days trade macro fit for fullness")
+
+	profile = BuildSatietyProfile(high[:2], nil, s.now)
+	s.False(profile.HungerTrendingHigh, "too few recent ratings")
+}
+
+func (s *SatietySuite) TestSolverBonus() {
+	fixtures := new(SolverSuite)
+	target := MacroBudget{ProteinG: 40, CarbsG: 45, FatG: 8, CaloriesKcal: 420}
+	sol, err := ScoreIngredients([]SolverIngredient{
+		{Food: fixtures.chicken(), AmountG: 100},
+		{Food: fixtures.broccoli(), AmountG: 100},
+	}, target, nil)
+	s.Require().NoError(err)
+
+	applySatiety(&sol, nil)
+	s.Equal(0.0, sol.Breakdown.SatietyBonus)
+	base := sol.MatchScore
+
+	profile := &SatietyProfile{
+		Foods:      map[int64]SatietyScore{1: {Score: 0.5, Meals: 6}},
+		Categories: map[FoodCategory]SatietyScore{FoodCategoryVegetable: {Score: 0.25, Meals: 8}},
+	}
+	applySatiety(&sol, profile)
+	s.InDelta(3.0, sol.Breakdown.SatietyBonus, 1e-9) // Mean score 0.375 of the two foods
+	s.InDelta(base+3, sol.MatchScore, 1e-9)
+}
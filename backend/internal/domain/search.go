@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// CROSS-ENTITY SEARCH
+// =============================================================================
+//
+// One keyword search over the text people remember things by: day notes,
+// session notes, program day labels, session templates, foods, movements,
+// programs and tagged days (environment conditions, sick and travel days).
+// Postgres full-text search does the matching and ranking; each result links
+// to the API resource that holds it. Unlike semantic search this needs no
+// embeddings model, so it works offline and finds exact words.
+
+const (
+	// SearchDefaultLimit is the number of results returned when no limit is given.
+	SearchDefaultLimit = 20
+	// SearchMaxLimit caps the number of results per query.
+	SearchMaxLimit = 50
+	// searchQueryMaxLength caps query length in characters.
+	searchQueryMaxLength = 200
+)
+
+// SearchKind is the kind of entity a search result points at.
+type SearchKind string
+
+const (
+	SearchKindNote            SearchKind = "note"             // Daily log notes
+	SearchKindSession         SearchKind = "session"          // Logged or planned training session notes
+	SearchKindProgramDay      SearchKind = "program_day"      // Program day labels and notes
+	SearchKindSessionTemplate SearchKind = "session_template" // Saved session templates
+	SearchKindFood            SearchKind = "food"             // Food reference items
+	SearchKindMovement        SearchKind = "movement"         // Movement names and tags
+	SearchKindProgram         SearchKind = "program"          // Program names, descriptions and tags
+	SearchKindDay             SearchKind = "day"              // Days tagged with conditions or exemptions
+)
+
+// AllSearchKinds lists every kind, in the order results are documented.
+var AllSearchKinds = []SearchKind{
+	SearchKindNote, SearchKindSession, SearchKindProgramDay, SearchKindSessionTemplate,
+	SearchKindFood, SearchKindMovement, SearchKindProgram, SearchKindDay,
+}
+
+// ParseSearchKinds parses a comma-separated kind filter. An empty filter
+// searches every kind; duplicates are dropped.
+func ParseSearchKinds(s string) ([]SearchKind, error) {
+	if strings.TrimSpace(s) == "" {
+		return AllSearchKinds, nil
+	}
+	seen := make(map[SearchKind]bool)
+	var kinds []SearchKind
+	for _, part := range strings.Split(s, ",") {
+		kind := SearchKind(strings.TrimSpace(part))
+		if !isValidSearchKind(kind) {
+			return nil, ErrInvalidSearchKind
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+func isValidSearchKind(kind SearchKind) bool {
+	for _, k := range AllSearchKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSearchQuery trims a search query and checks its length and limit.
+func ValidateSearchQuery(query string, limit int) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > searchQueryMaxLength {
+		return "", ErrInvalidSearchQuery
+	}
+	if limit < 1 || limit > SearchMaxLimit {
+		return "", ErrInvalidSearchLimit
+	}
+	return query, nil
+}
+
+// SearchResult is one ranked match.
+type SearchResult struct {
+	Kind    SearchKind `json:"kind"`
+	ID      string     `json:"id"` // Entity ID; the date for notes and tagged days
+	Title   string     `json:"title"`
+	Snippet string     `json:"snippet"`        // Matching excerpt or a short description
+	Date    string     `json:"date,omitempty"` // YYYY-MM-DD for dated entities
+	Link    string     `json:"link"`           // API path of the entity
+	Rank    float64    `json:"rank"`           // Full-text rank, higher is better
+}
+
+// SearchLink returns the API path for a result. Program days link to their
+// program (parentID); foods and session templates have no single-item
+// endpoint and link to their list.
+func SearchLink(kind SearchKind, id, date string, parentID int64) string {
+	switch kind {
+	case SearchKindNote, SearchKindSession, SearchKindDay:
+		return "/api/logs/" + date
+	case SearchKindProgramDay:
+		return fmt.Sprintf("/api/training-programs/%d", parentID)
+	case SearchKindProgram:
+		return "/api/training-programs/" + id
+	case SearchKindMovement:
+		return "/api/movements/" + id
+	case SearchKindFood:
+		return "/api/food-reference"
+	case SearchKindSessionTemplate:
+		return "/api/session-templates"
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The kind filter and query limits are user input passed
+// straight to the search store, and links must point at each kind's resource.
+type SearchSuite struct {
+	suite.Suite
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(SearchSuite))
+}
+
+func (s *SearchSuite) TestValidateSearchQuery() {
+	query, err := ValidateSearchQuery("  knee pain ", SearchDefaultLimit)
+	s.NoError(err)
+	s.Equal("knee pain", query)
+
+	_, err = ValidateSearchQuery(" ", 10)
+	s.ErrorIs(err, ErrInvalidSearchQuery)
+	_, err = ValidateSearchQuery(strings.Repeat("a", 201), 10)
+	s.ErrorIs(err, ErrInvalidSearchQuery)
+	_, err = ValidateSearchQuery("squat", 0)
+	s.ErrorIs(err, ErrInvalidSearchLimit)
+	_, err = ValidateSearchQuery("squat", SearchMaxLimit+1)
+	s.ErrorIs(err, ErrInvalidSearchLimit)
+}
+
+func (s *SearchSuite) TestParseSearchKinds() {
+	kinds, err := ParseSearchKinds("")
+	s.NoError(err)
+	s.Equal(AllSearchKinds, kinds)
+
+	kinds, err = ParseSearchKinds("note, food,note")
+	s.NoError(err)
+	s.Equal([]SearchKind{SearchKindNote, SearchKindFood}, kinds)
+
+	_, err = ParseSearchKinds("note,recipe")
+	s.ErrorIs(err, ErrInvalidSearchKind)
+	_, err = ParseSearchKinds("note,")
+	s.ErrorIs(err, ErrInvalidSearchKind)
+}
+
+func (s *SearchSuite) TestSearchLink() {
+	s.Equal("/api/logs/2026-03-02", SearchLink(SearchKindNote, "2026-03-02", "2026-03-02", 0))
+	s.Equal("/api/logs/2026-03-02", SearchLink(SearchKindSession, "41", "2026-03-02", 0))
+	s.Equal("/api/training-programs/7", SearchLink(SearchKindProgramDay, "93", "", 7))
+	s.Equal("/api/training-programs/7", SearchLink(SearchKindProgram, "7", "", 0))
+	s.Equal("/api/movements/pistol_squat", SearchLink(SearchKindMovement, "pistol_squat", "", 0))
+	s.Equal("/api/food-reference", SearchLink(SearchKindFood, "12", "", 0))
+}
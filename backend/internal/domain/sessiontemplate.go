@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// TRAINING SESSION TEMPLATES
+// =============================================================================
+//
+// Users with a stable routine outside formal programs log the same sessions
+// over and over. Any session can be saved as a personal template (type,
+// duration, typical RPE and an exercise list) and logged as an actual session
+// in one call. Favorites are listed first, then the most used templates.
+
+const (
+	// SessionTemplateMaxExercises caps the exercise list of a template.
+	SessionTemplateMaxExercises = 30
+)
+
+// SessionTemplateExercise is one exercise in a session template.
+type SessionTemplateExercise struct {
+	Name string `json:"name"`
+	Sets int    `json:"sets,omitempty"`
+	Reps int    `json:"reps,omitempty"`
+}
+
+// SessionTemplate is a saved training session that can be logged in one call.
+type SessionTemplate struct {
+	ID          int64                     `json:"id"`
+	Name        string                    `json:"name"`
+	Type        TrainingType              `json:"type"`
+	DurationMin int                       `json:"durationMin"`
+	TypicalRPE  *int                      `json:"typicalRpe,omitempty"`
+	Exercises   []SessionTemplateExercise `json:"exercises"`
+	Notes       string                    `json:"notes,omitempty"`
+	Favorite    bool                      `json:"favorite"`
+	UseCount    int                       `json:"useCount"`
+	LastUsedAt  *time.Time                `json:"lastUsedAt,omitempty"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
+}
+
+// NewSessionTemplateFromSession builds a template from a logged session.
+// Exercises can't be recovered from a session, so the list starts empty.
+func NewSessionTemplateFromSession(name string, session TrainingSession) *SessionTemplate {
+	return &SessionTemplate{
+		Name:        name,
+		Type:        session.Type,
+		DurationMin: session.DurationMin,
+		TypicalRPE:  session.PerceivedIntensity,
+		Exercises:   []SessionTemplateExercise{},
+		Notes:       session.Notes,
+	}
+}
+
+// Validate checks the template's name, session fields and exercises.
+func (t *SessionTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrSessionTemplateNameRequired
+	}
+	if !ValidTrainingTypes[t.Type] {
+		return ErrInvalidTrainingType
+	}
+	if t.DurationMin < 0 || t.DurationMin > 480 {
+		return ErrInvalidTrainingDuration
+	}
+	if t.TypicalRPE != nil && (*t.TypicalRPE < 1 || *t.TypicalRPE > 10) {
+		return ErrInvalidPerceivedIntensity
+	}
+	if len(t.Exercises) > SessionTemplateMaxExercises {
+		return ErrSessionTemplateTooManyExercises
+	}
+	for _, e := range t.Exercises {
+		if strings.TrimSpace(e.Name) == "" || e.Sets < 0 || e.Reps < 0 {
+			return ErrInvalidSessionTemplateExercise
+		}
+	}
+	return nil
+}
+
+// ToSession returns an actual session from the template. The exercise list
+// is written into the notes, since sessions don't store exercises.
+func (t *SessionTemplate) ToSession() TrainingSession {
+	session := TrainingSession{
+		Type:        t.Type,
+		DurationMin: t.DurationMin,
+		Notes:       t.Notes,
+	}
+	if t.TypicalRPE != nil {
+		rpe := *t.TypicalRPE
+		session.PerceivedIntensity = &rpe
+	}
+
+	if len(t.Exercises) > 0 {
+		parts := make([]string, len(t.Exercises))
+		for i, e := range t.Exercises {
+			parts[i] = e.Name
+			switch {
+			case e.Sets > 0 && e.Reps > 0:
+				parts[i] += fmt.Sprintf(" %dx%d", e.Sets, e.Reps)
+			case e.Sets > 0:
+				parts[i] += fmt.Sprintf(" %d sets", e.Sets)
+			}
+		}
+		exercises := strings.Join(parts, ", ")
+		if session.Notes != "" {
+			session.Notes += "\n"
+		}
+		session.Notes += exercises
+	}
+	return session
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionTemplateSuite struct {
+	suite.Suite
+}
+
+func TestSessionTemplateSuite(t *testing.T) {
+	suite.Run(t, new(SessionTemplateSuite))
+}
+
+func (s *SessionTemplateSuite) template() *SessionTemplate {
+	rpe := 7
+	return &SessionTemplate{
+		Name:        "Push day",
+		Type:        TrainingTypeStrength,
+		DurationMin: 60,
+		TypicalRPE:  &rpe,
+		Exercises: []SessionTemplateExercise{
+			{Name: "Bench press", Sets: 4, Reps: 6},
+			{Name: "Dips", Sets: 3},
+		},
+	}
+}
+
+func (s *SessionTemplateSuite) TestValidTemplate() {
+	s.NoError(s.template().Validate())
+}
+
+func (s *SessionTemplateSuite) TestValidationErrors() {
+	cases := []struct {
+		name   string
+		mutate func(*SessionTemplate)
+		err    error
+	}{
+		{"blank name", func(t *SessionTemplate) { t.Name = "  " }, ErrSessionTemplateNameRequired},
+		{"unknown type", func(t *SessionTemplate) { t.Type = "juggling" }, ErrInvalidTrainingType},
+		{"too long", func(t *SessionTemplate) { t.DurationMin = 481 }, ErrInvalidTrainingDuration},
+		{"rpe out of range", func(t *SessionTemplate) { rpe := 11; t.TypicalRPE = &rpe }, ErrInvalidPerceivedIntensity},
+		{"unnamed exercise", func(t *SessionTemplate) { t.Exercises[0].Name = "" }, ErrInvalidSessionTemplateExercise},
+		{"negative reps", func(t *SessionTemplate) { t.Exercises[1].Reps = -1 }, ErrInvalidSessionTemplateExercise},
+		{"too many exercises", func(t *SessionTemplate) {
+			t.Exercises = make([]SessionTemplateExercise, SessionTemplateMaxExercises+1)
+		}, ErrSessionTemplateTooManyExercises},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			t := s.template()
+			tc.mutate(t)
+			s.ErrorIs(t.Validate(), tc.err)
+		})
+	}
+}
+
+func (s *SessionTemplateSuite) TestToSessionWritesExercisesIntoNotes() {
+	t := s.template()
+	t.Notes = "Slow eccentrics"
+
+	session := t.ToSession()
+
+	s.Equal(TrainingTypeStrength, session.Type)
+	s.Equal(60, session.DurationMin)
+	s.Require().NotNil(session.PerceivedIntensity)
+	s.Equal(7, *session.PerceivedIntensity)
+	s.Equal("Slow eccentrics\nBench press 4x6, Dips 3 sets", session.Notes)
+
+	*session.PerceivedIntensity = 9
+	s.Equal(7, *t.TypicalRPE, "session must not share the template's RPE")
+}
+
+func (s *SessionTemplateSuite) TestFromSession() {
+	rpe := 5
+	session := TrainingSession{Type: TrainingTypeRun, DurationMin: 40, PerceivedIntensity: &rpe, Notes: "Easy loop"}
+
+	t := NewSessionTemplateFromSession("Easy run", session)
+
+	s.NoError(t.Validate())
+	s.Equal(TrainingTypeRun, t.Type)
+	s.Equal(40, t.DurationMin)
+	s.Equal(5, *t.TypicalRPE)
+	s.Empty(t.Exercises)
+	s.Equal(session.Type, t.ToSession().Type)
+}
@@ -33,8 +33,14 @@ func SolveMacros(req SolverRequest) SolverResponse {
 	// Normalize meal time
 	mealTime := strings.ToLower(req.MealTime)
 
-	// Filter and Prune pantry foods aggressively based on meal time
-	validFoods := pruneFoodsForMealTime(req.PantryFoods, mealTime)
+	// Drop foods the user keeps removing, then prune based on meal time
+	pantry := make([]FoodNutrition, 0, len(req.PantryFoods))
+	for _, f := range req.PantryFoods {
+		if req.Preferences.Allows(f.ID) {
+			pantry = append(pantry, f)
+		}
+	}
+	validFoods := pruneFoodsForMealTime(pantry, mealTime)
 
 	if len(validFoods) == 0 {
 		return SolverResponse{Computed: false}
@@ -43,6 +49,26 @@ func SolveMacros(req SolverRequest) SolverResponse {
 	// Use template-based generator
 	solutions := generateSolutionsByTemplates(validFoods, req.RemainingBudget, mealTime, minIngredients, maxIngredients)
 
+	// Favor foods the user has been choosing, and filling ones when asked
+	for i := range solutions {
+		applyPreferences(&solutions[i], req.Preferences)
+		applySatiety(&solutions[i], req.Satiety)
+	}
+
+	// Price solutions and keep those within the cost limit
+	if len(req.Prices) > 0 || req.MaxCost != nil {
+		affordable := solutions[:0]
+		for _, sol := range solutions {
+			cost := EstimateIngredientCost(sol.Ingredients, req.Prices)
+			if req.MaxCost != nil && !cost.WithinBudget(*req.MaxCost) {
+				continue
+			}
+			sol.Cost = &cost
+			affordable = append(affordable, sol)
+		}
+		solutions = affordable
+	}
+
 	// Sort by match score (descending)
 	sort.Slice(solutions, func(i, j int) bool {
 		return solutions[i].MatchScore > solutions[j].MatchScore
@@ -412,23 +438,73 @@ func buildSolution(foods []FoodNutrition, amounts []float64, target MacroBudget)
 		addMacros(&total, f, amt)
 	}
 
-	score := calculateAdvancedScore(total, target, ingredients)
-	if score < 60 {
+	// Block culinary clashes deterministically rather than leaving them to the LLM
+	if CheckCompatibility(ingredients).Blocked {
+		return nil
+	}
+
+	breakdown := calculateScoreBreakdown(total, target, ingredients)
+	if breakdown.Total < 60 {
 		return nil
 	}
 
 	return &SolverSolution{
 		Ingredients: ingredients,
 		TotalMacros: total,
-		MatchScore:  score,
+		MatchScore:  breakdown.Total,
+		Breakdown:   breakdown,
 		WhyText:     generateWhyText(total, target),
 		RecipeName:  generateFallbackNameFromIngredients(ingredients),
 	}
 }
 
-// calculateAdvancedScore implements: (MacroAccuracy * 0.6) + (IngredientCount * 0.2) + (FiberContent * 0.2)
-func calculateAdvancedScore(actual, target MacroBudget, ingredients []SolverIngredient) float64 {
-	macroScore := calculateMatchScore(actual, target)
+// ScoreIngredients scores a hand-edited ingredient list against a target with
+// the same rules the solver uses. Amounts are taken as given: no rounding to
+// serving sizes and no minimum score.
+func ScoreIngredients(ingredients []SolverIngredient, target MacroBudget, prefs FoodPreferences) (SolverSolution, error) {
+	if len(ingredients) == 0 {
+		return SolverSolution{}, ErrSolverIngredientsRequired
+	}
+
+	var total MacroBudget
+	scored := make([]SolverIngredient, len(ingredients))
+	for i, ing := range ingredients {
+		if ing.AmountG <= 0 || ing.AmountG > maxScoredIngredientG {
+			return SolverSolution{}, ErrInvalidSolverIngredientAmount
+		}
+		if ing.Display == "" {
+			ing.Display = fmt.Sprintf("%.0fg", ing.AmountG)
+		}
+		scored[i] = ing
+		addMacros(&total, ing.Food, ing.AmountG)
+	}
+
+	sol := SolverSolution{
+		Ingredients: scored,
+		TotalMacros: total,
+		Breakdown:   calculateScoreBreakdown(total, target, scored),
+		WhyText:     generateWhyText(total, target),
+		RecipeName:  generateFallbackNameFromIngredients(scored),
+	}
+	applyPreferences(&sol, prefs)
+	return sol, nil
+}
+
+// Score weights and penalties. MatchScore = MacroPoints + IngredientCountPoints
+// + FiberPoints + PreferenceBonus + SatietyBonus - AbsurdityPenalty, clamped to 0-100.
+const (
+	macroAccuracyWeight   = 0.6
+	ingredientCountWeight = 0.2
+	fiberWeight           = 0.2
+	absurdityPenaltyPts   = 10.0
+	maxScoredIngredientG  = 2000.0 // Upper bound for a hand-edited amount
+)
+
+// calculateScoreBreakdown implements: (MacroAccuracy * 0.6) + (IngredientCount * 0.2) + (FiberContent * 0.2),
+// less a fixed penalty when the combination trips an absurdity check.
+func calculateScoreBreakdown(actual, target MacroBudget, ingredients []SolverIngredient) SolverScoreBreakdown {
+	b := macroScoreComponents(actual, target)
+	b.MacroPoints = b.MacroAccuracy * macroAccuracyWeight
 
 	count := len(ingredients)
 	countScore := 0.0
@@ -441,17 +517,44 @@ func calculateAdvancedScore(actual, target MacroBudget, ingredients []SolverIngr
 	} else {
 		countScore = 20.0
 	}
+	b.IngredientCountPoints = countScore * ingredientCountWeight
+	b.IngredientCountPenalty = 100*ingredientCountWeight - b.IngredientCountPoints
 
 	estFiber := 0.0
 	for _, ing := range ingredients {
 		estFiber += estimateFiber(ing.Food, ing.AmountG)
 	}
+	b.EstimatedFiberG = estFiber
+	b.FiberPoints = math.Min(100, estFiber*10.0) * fiberWeight
+
+	b.Compatibility = CheckCompatibility(ingredients).Score
+	if warning := CheckAbsurdity(SolverSolution{Ingredients: ingredients, TotalMacros: actual}); warning != nil {
+		b.AbsurdityCode = warning.Code
+		b.AbsurdityPenalty = absurdityPenaltyPts
+	}
+
+	b.total()
+	return b
+}
 
-	fiberScore := math.Min(100, estFiber*10.0)
+// total sums the components into Total.
+func (b *SolverScoreBreakdown) total() {
+	sum := b.MacroPoints + b.IngredientCountPoints + b.FiberPoints + b.PreferenceBonus + b.SatietyBonus - b.AbsurdityPenalty
+	b.Total = math.Max(0, math.Min(100, sum))
+}
 
-	finalScore := (macroScore * 0.6) + (countScore * 0.2) + (fiberScore * 0.2)
+// applyPreferences rescores a solution with the learned food preferences.
+func applyPreferences(sol *SolverSolution, prefs FoodPreferences) {
+	sol.Breakdown.PreferenceBonus = prefs.bonus(sol.Ingredients)
+	sol.Breakdown.total()
+	sol.MatchScore = sol.Breakdown.Total
+}
 
-	return finalScore
+// applySatiety rescores a solution with the learned satiety scores.
+func applySatiety(sol *SolverSolution, satiety *SatietyProfile) {
+	sol.Breakdown.SatietyBonus = satiety.bonus(sol.Ingredients)
+	sol.Breakdown.total()
+	sol.MatchScore = sol.Breakdown.Total
 }
 
 func estimateFiber(f FoodNutrition, amountG float64) float64 {
@@ -544,20 +647,36 @@ func addMacros(budget *MacroBudget, food FoodNutrition, amountG float64) {
 }
 
 func calculateMatchScore(actual, target MacroBudget) float64 {
+	return macroScoreComponents(actual, target).MacroAccuracy
+}
+
+// macroScoreComponents scores each macro by its relative error (zero once it
+// is 50% off) and weights them into MacroAccuracy.
+func macroScoreComponents(actual, target MacroBudget) SolverScoreBreakdown {
 	if target.CaloriesKcal == 0 {
-		return 0
+		return SolverScoreBreakdown{}
+	}
+	component := func(actual, target, diff, weight float64) MacroScoreComponent {
+		return MacroScoreComponent{
+			Target:       target,
+			Actual:       actual,
+			ErrorPercent: diff * 100,
+			Score:        math.Max(0, 100*(1-diff*2)),
+			Weight:       weight,
+		}
 	}
-	calDiff := math.Abs(float64(actual.CaloriesKcal-target.CaloriesKcal)) / float64(target.CaloriesKcal)
-	protDiff := safePercentDiff(actual.ProteinG, target.ProteinG)
-	carbDiff := safePercentDiff(actual.CarbsG, target.CarbsG)
-	fatDiff := safePercentDiff(actual.FatG, target.FatG)
-
-	calScore := math.Max(0, 100*(1-calDiff*2))
-	protScore := math.Max(0, 100*(1-protDiff*2))
-	carbScore := math.Max(0, 100*(1-carbDiff*2))
-	fatScore := math.Max(0, 100*(1-fatDiff*2))
 
-	return calScore*0.40 + protScore*0.30 + carbScore*0.20 + fatScore*0.10
+	calDiff := math.Abs(float64(actual.CaloriesKcal-target.CaloriesKcal)) / float64(target.CaloriesKcal)
+	b := SolverScoreBreakdown{
+		Calories: component(float64(actual.CaloriesKcal), float64(target.CaloriesKcal), calDiff, 0.40),
+		Protein:  component(actual.ProteinG, target.ProteinG, safePercentDiff(actual.ProteinG, target.ProteinG), 0.30),
+		Carbs:    component(actual.CarbsG, target.CarbsG, safePercentDiff(actual.CarbsG, target.CarbsG), 0.20),
+		Fat:      component(actual.FatG, target.FatG, safePercentDiff(actual.FatG, target.FatG), 0.10),
+	}
+	for _, c := range []MacroScoreComponent{b.Calories, b.Protein, b.Carbs, b.Fat} {
+		b.MacroAccuracy += c.Score * c.Weight
+	}
+	return b
 }
 
 func safePercentDiff(actual, target float64) float64 {
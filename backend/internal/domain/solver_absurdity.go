@@ -28,6 +28,12 @@ var highFiberFoods = map[string]float64{
 // Returns nil if no concerns detected, otherwise returns the most severe warning.
 // This is a pure domain function with no I/O dependencies.
 func CheckAbsurdity(solution SolverSolution) *AbsurdityWarning {
+	// Check 0: Foods that don't belong in one dish
+	compatibility := CheckCompatibility(solution.Ingredients)
+	if compatibility.Blocked {
+		return compatibilityWarning(compatibility)
+	}
+
 	// Check 1: Single ingredient exceeding threshold
 	for _, ing := range solution.Ingredients {
 		if ing.AmountG > maxSingleIngredientG {
@@ -68,7 +74,8 @@ func CheckAbsurdity(solution SolverSolution) *AbsurdityWarning {
 		}
 	}
 
-	return nil
+	// Check 5: Unusual but edible pairing
+	return compatibilityWarning(compatibility)
 }
 
 // estimateFiberContent estimates the fiber content of a solution.
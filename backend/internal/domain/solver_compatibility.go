@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// CULINARY COMPATIBILITY
+// =============================================================================
+//
+// Each food gets a culinary profile: a pairing class (sweet, savory, neutral),
+// a prep state (cooked, raw, ready) and a texture class. Every pair of
+// ingredients in a combination is scored by multiplying the pairing, texture
+// and prep factors below; the combination scores as its worst pair. The solver
+// drops combinations under CompatibilityBlockThreshold before they ever reach
+// the LLM, and flags those under CompatibilityWarnThreshold.
+
+const (
+	// CompatibilityBlockThreshold is the score below which a combination is never suggested.
+	CompatibilityBlockThreshold = 0.35
+	// CompatibilityWarnThreshold is the score below which a combination is flagged as an odd pairing.
+	CompatibilityWarnThreshold = 0.6
+)
+
+// PairingClass is a food's flavor direction.
+type PairingClass string
+
+const (
+	PairingSweet   PairingClass = "sweet"
+	PairingSavory  PairingClass = "savory"
+	PairingNeutral PairingClass = "neutral" // Goes either way (grains, eggs, most fats)
+)
+
+// PrepState is how a food is normally served.
+type PrepState string
+
+const (
+	PrepCooked PrepState = "cooked" // Needs cooking; served warm
+	PrepRaw    PrepState = "raw"    // Fresh and served cold
+	PrepReady  PrepState = "ready"  // Shelf-ready; fits hot or cold dishes
+)
+
+// TextureClass is a food's dominant texture.
+type TextureClass string
+
+const (
+	TexturePowder  TextureClass = "powder"
+	TextureCreamy  TextureClass = "creamy"
+	TextureTender  TextureClass = "tender" // Meat, fish, tofu, legumes
+	TextureGrain   TextureClass = "grain"  // Cooked grains
+	TextureFlake   TextureClass = "flake"  // Oats, bread
+	TextureStarchy TextureClass = "starchy"
+	TextureLeafy   TextureClass = "leafy"
+	TextureCrunchy TextureClass = "crunchy"
+	TextureJuicy   TextureClass = "juicy"
+	TextureOil     TextureClass = "oil"
+)
+
+// CulinaryProfile describes how a food behaves in a dish.
+type CulinaryProfile struct {
+	Pairing PairingClass
+	Prep    PrepState
+	Texture TextureClass
+}
+
+// culinaryProfileRule assigns a profile to foods whose name contains Match.
+type culinaryProfileRule struct {
+	Match   string
+	Profile CulinaryProfile
+}
+
+// culinaryProfileRules is checked in order, so more specific names come first.
+var culinaryProfileRules = []culinaryProfileRule{
+	{"sweet potato", CulinaryProfile{PairingNeutral, PrepCooked, TextureStarchy}},
+	{"potato", CulinaryProfile{PairingSavory, PrepCooked, TextureStarchy}},
+	{"whey", CulinaryProfile{PairingSweet, PrepReady, TexturePowder}},
+	{"casein", CulinaryProfile{PairingSweet, PrepReady, TexturePowder}},
+	{"yoghurt", CulinaryProfile{PairingSweet, PrepRaw, TextureCreamy}},
+	{"yogurt", CulinaryProfile{PairingSweet, PrepRaw, TextureCreamy}},
+	{"egg", CulinaryProfile{PairingNeutral, PrepCooked, TextureTender}},
+	{"tofu", CulinaryProfile{PairingNeutral, PrepCooked, TextureTender}},
+	{"oats", CulinaryProfile{PairingNeutral, PrepReady, TextureFlake}},
+	{"bread", CulinaryProfile{PairingNeutral, PrepReady, TextureFlake}},
+	{"olive oil", CulinaryProfile{PairingSavory, PrepReady, TextureOil}},
+	{"oil", CulinaryProfile{PairingNeutral, PrepReady, TextureOil}},
+	{"nut butter", CulinaryProfile{PairingNeutral, PrepReady, TextureCreamy}},
+	{"avocado", CulinaryProfile{PairingNeutral, PrepRaw, TextureCreamy}},
+	{"nut", CulinaryProfile{PairingNeutral, PrepReady, TextureCrunchy}},
+	{"almond", CulinaryProfile{PairingNeutral, PrepReady, TextureCrunchy}},
+	{"seed", CulinaryProfile{PairingNeutral, PrepReady, TextureCrunchy}},
+	{"goji", CulinaryProfile{PairingSweet, PrepReady, TextureCrunchy}},
+	{"spinach", CulinaryProfile{PairingNeutral, PrepRaw, TextureLeafy}},
+	{"arugula", CulinaryProfile{PairingSavory, PrepRaw, TextureLeafy}},
+	{"kale", CulinaryProfile{PairingSavory, PrepCooked, TextureLeafy}},
+	{"chard", CulinaryProfile{PairingSavory, PrepCooked, TextureLeafy}},
+	{"bok choy", CulinaryProfile{PairingSavory, PrepCooked, TextureLeafy}},
+	{"cabbage", CulinaryProfile{PairingSavory, PrepCooked, TextureLeafy}},
+	{"lentil", CulinaryProfile{PairingSavory, PrepCooked, TextureTender}},
+	{"bean", CulinaryProfile{PairingSavory, PrepCooked, TextureTender}},
+	{"chickpea", CulinaryProfile{PairingSavory, PrepCooked, TextureTender}},
+}
+
+// culinaryCategoryDefaults profile foods no rule matches.
+var culinaryCategoryDefaults = map[FoodCategory]CulinaryProfile{
+	FoodCategoryHighCarb:    {PairingNeutral, PrepCooked, TextureGrain},
+	FoodCategoryHighProtein: {PairingSavory, PrepCooked, TextureTender},
+	FoodCategoryHighFat:     {PairingNeutral, PrepReady, TextureCrunchy},
+	FoodCategoryVegetable:   {PairingSavory, PrepCooked, TextureCrunchy},
+	FoodCategoryFruit:       {PairingSweet, PrepRaw, TextureJuicy},
+}
+
+// pairingFactors scores flavor directions that fight each other.
+// Pairs not listed score 1.
+var pairingFactors = map[[2]PairingClass]float64{
+	{PairingSweet, PairingSavory}: 0.4,
+}
+
+// textureFactors scores textures that don't belong in one dish.
+// Pairs not listed score 1.
+var textureFactors = map[[2]TextureClass]float64{
+	{TexturePowder, TextureTender}:  0.2, // Protein powder on meat or fish
+	{TexturePowder, TextureLeafy}:   0.2,
+	{TexturePowder, TextureStarchy}: 0.4,
+	{TexturePowder, TextureOil}:     0.3,
+	{TextureOil, TextureJuicy}:      0.3, // Oil over fresh fruit
+	{TextureOil, TextureCreamy}:     0.6,
+	{TextureFlake, TextureTender}:   0.7, // Fine side by side, odd once combined with another mismatch
+	{TextureFlake, TextureLeafy}:    0.7,
+	{TextureTender, TextureJuicy}:   0.7,
+}
+
+// prepFactors scores serving states that clash on one plate.
+// Pairs not listed score 1.
+var prepFactors = map[[2]PrepState]float64{
+	{PrepCooked, PrepRaw}: 0.8, // Hot and cold together
+}
+
+// ProfileFood returns the culinary profile for a food.
+func ProfileFood(f FoodNutrition) CulinaryProfile {
+	name := strings.ToLower(f.FoodItem)
+	for _, rule := range culinaryProfileRules {
+		if strings.Contains(name, rule.Match) {
+			return rule.Profile
+		}
+	}
+	if profile, ok := culinaryCategoryDefaults[f.Category]; ok {
+		return profile
+	}
+	return CulinaryProfile{Pairing: PairingNeutral, Prep: PrepReady}
+}
+
+// symmetricFactor looks up an unordered pair in a factor table.
+func symmetricFactor[T comparable](table map[[2]T]float64, a, b T) float64 {
+	if f, ok := table[[2]T{a, b}]; ok {
+		return f
+	}
+	if f, ok := table[[2]T{b, a}]; ok {
+		return f
+	}
+	return 1
+}
+
+// PairCompatibility scores how well two foods go together, from 0 to 1.
+func PairCompatibility(a, b FoodNutrition) float64 {
+	pa, pb := ProfileFood(a), ProfileFood(b)
+	return symmetricFactor(pairingFactors, pa.Pairing, pb.Pairing) *
+		symmetricFactor(textureFactors, pa.Texture, pb.Texture) *
+		symmetricFactor(prepFactors, pa.Prep, pb.Prep)
+}
+
+// CompatibilityResult is the culinary verdict on a combination.
+type CompatibilityResult struct {
+	Score   float64 // 0-1; the worst pair's score
+	FoodA   string  // Worst pair (empty when there is no pair)
+	FoodB   string
+	Blocked bool // Score below CompatibilityBlockThreshold
+}
+
+// CheckCompatibility scores a combination by its worst-matched pair of foods.
+func CheckCompatibility(ingredients []SolverIngredient) CompatibilityResult {
+	result := CompatibilityResult{Score: 1}
+	for i := 0; i < len(ingredients); i++ {
+		for j := i + 1; j < len(ingredients); j++ {
+			a, b := ingredients[i].Food, ingredients[j].Food
+			if score := PairCompatibility(a, b); score < result.Score {
+				result = CompatibilityResult{Score: score, FoodA: a.FoodItem, FoodB: b.FoodItem}
+			}
+		}
+	}
+	result.Blocked = result.Score < CompatibilityBlockThreshold
+	return result
+}
+
+// compatibilityWarning turns a low compatibility score into an absurdity warning.
+// Returns nil for combinations at or above CompatibilityWarnThreshold.
+func compatibilityWarning(result CompatibilityResult) *AbsurdityWarning {
+	switch {
+	case result.Blocked:
+		return &AbsurdityWarning{
+			Code:        "CULINARY_CLASH",
+			Description: fmt.Sprintf("%s and %s don't belong in one dish. Eat them as separate meals.", result.FoodA, result.FoodB),
+			Ingredient:  result.FoodB,
+		}
+	case result.Score < CompatibilityWarnThreshold:
+		return &AbsurdityWarning{
+			Code:        "ODD_PAIRING",
+			Description: fmt.Sprintf("%s with %s is an unusual pairing. Serve them side by side rather than mixed.", result.FoodA, result.FoodB),
+			Ingredient:  result.FoodB,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compatFood builds a pantry food with just the fields profiling reads.
+func compatFood(category FoodCategory, name string) FoodNutrition {
+	return FoodNutrition{Category: category, FoodItem: name}
+}
+
+func compatIngredients(foods ...FoodNutrition) []SolverIngredient {
+	ingredients := make([]SolverIngredient, len(foods))
+	for i, f := range foods {
+		ingredients[i] = SolverIngredient{Food: f, AmountG: 100}
+	}
+	return ingredients
+}
+
+var (
+	compatWhey        = compatFood(FoodCategoryHighProtein, "Whey Protein")
+	compatChicken     = compatFood(FoodCategoryHighProtein, "Chicken/Turkey Breast")
+	compatSalmon      = compatFood(FoodCategoryHighProtein, "Salmon/Tuna/Perch")
+	compatYoghurt     = compatFood(FoodCategoryHighProtein, "Low-fat Greek Yoghurt")
+	compatEggs        = compatFood(FoodCategoryHighProtein, "Eggs")
+	compatOats        = compatFood(FoodCategoryHighCarb, "Oats")
+	compatRice        = compatFood(FoodCategoryHighCarb, "Brown Rice")
+	compatSweetPotato = compatFood(FoodCategoryHighCarb, "Sweet Potatoes")
+	compatBread       = compatFood(FoodCategoryHighCarb, "Wholegrain Bread")
+	compatOliveOil    = compatFood(FoodCategoryHighFat, "Olive Oil")
+	compatAvocado     = compatFood(FoodCategoryHighFat, "Avocado")
+	compatChia        = compatFood(FoodCategoryHighFat, "Chia Seeds")
+	compatBroccoli    = compatFood(FoodCategoryVegetable, "Broccoli")
+	compatSpinach     = compatFood(FoodCategoryVegetable, "Spinach")
+	compatBlueberries = compatFood(FoodCategoryFruit, "Blueberries")
+	compatBanana      = compatFood(FoodCategoryFruit, "Banana")
+)
+
+func TestProfileFood(t *testing.T) {
+	t.Run("name rules win over category", func(t *testing.T) {
+		assert.Equal(t, CulinaryProfile{PairingSweet, PrepReady, TexturePowder}, ProfileFood(compatWhey))
+		assert.Equal(t, TextureStarchy, ProfileFood(compatSweetPotato).Texture)
+		assert.Equal(t, PairingNeutral, ProfileFood(compatSweetPotato).Pairing, "sweet potato isn't matched as plain potato")
+	})
+
+	t.Run("falls back to category", func(t *testing.T) {
+		assert.Equal(t, CulinaryProfile{PairingSavory, PrepCooked, TextureTender}, ProfileFood(compatChicken))
+		assert.Equal(t, CulinaryProfile{PairingSweet, PrepRaw, TextureJuicy}, ProfileFood(compatBlueberries))
+	})
+
+	t.Run("unknown foods are neutral", func(t *testing.T) {
+		profile := ProfileFood(FoodNutrition{FoodItem: "Mystery"})
+		assert.Equal(t, PairingNeutral, profile.Pairing)
+		assert.Equal(t, 1.0, PairCompatibility(FoodNutrition{FoodItem: "Mystery"}, compatWhey))
+	})
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	compatible := []struct {
+		name  string
+		foods []FoodNutrition
+	}{
+		{"chicken rice broccoli", []FoodNutrition{compatChicken, compatRice, compatBroccoli, compatOliveOil}},
+		{"whey oats berries", []FoodNutrition{compatWhey, compatOats, compatBlueberries, compatChia}},
+		{"yoghurt bowl", []FoodNutrition{compatYoghurt, compatBlueberries, compatOats, compatBanana}},
+		{"eggs on toast", []FoodNutrition{compatEggs, compatBread, compatAvocado, compatSpinach}},
+		{"salmon sweet potato", []FoodNutrition{compatSalmon, compatSweetPotato, compatBroccoli}},
+	}
+	for _, tc := range compatible {
+		t.Run(tc.name, func(t *testing.T) {
+			result := CheckCompatibility(compatIngredients(tc.foods...))
+			assert.False(t, result.Blocked)
+			assert.GreaterOrEqual(t, result.Score, CompatibilityWarnThreshold)
+		})
+	}
+
+	blocked := []struct {
+		name  string
+		foods []FoodNutrition
+	}{
+		{"protein powder on chicken", []FoodNutrition{compatChicken, compatRice, compatWhey}},
+		{"salmon with blueberries", []FoodNutrition{compatSalmon, compatBlueberries}},
+		{"olive oil over fruit", []FoodNutrition{compatOliveOil, compatBanana}},
+		{"protein powder on spinach", []FoodNutrition{compatSpinach, compatWhey}},
+	}
+	for _, tc := range blocked {
+		t.Run(tc.name, func(t *testing.T) {
+			result := CheckCompatibility(compatIngredients(tc.foods...))
+			assert.True(t, result.Blocked, "score %.2f", result.Score)
+			assert.NotEmpty(t, result.FoodA)
+			assert.NotEmpty(t, result.FoodB)
+		})
+	}
+
+	t.Run("single ingredient is always compatible", func(t *testing.T) {
+		assert.Equal(t, 1.0, CheckCompatibility(compatIngredients(compatWhey)).Score)
+	})
+
+	t.Run("pair scores are symmetric", func(t *testing.T) {
+		assert.Equal(t, PairCompatibility(compatWhey, compatChicken), PairCompatibility(compatChicken, compatWhey))
+	})
+}
+
+func TestCompatibilityWarnings(t *testing.T) {
+	t.Run("clash is the most severe absurdity", func(t *testing.T) {
+		solution := SolverSolution{
+			Ingredients: []SolverIngredient{
+				{Food: compatChicken, AmountG: 400}, // Also SINGLE_LARGE
+				{Food: compatWhey, AmountG: 30},
+			},
+		}
+
+		warning := CheckAbsurdity(solution)
+		require.NotNil(t, warning)
+		assert.Equal(t, "CULINARY_CLASH", warning.Code)
+		assert.Contains(t, warning.Description, "Whey Protein")
+	})
+
+	t.Run("odd pairing is the least severe absurdity", func(t *testing.T) {
+		ingredients := compatIngredients(compatFood(FoodCategoryVegetable, "Arugula"), compatBanana)
+		result := CheckCompatibility(ingredients)
+		require.False(t, result.Blocked)
+		require.Less(t, result.Score, CompatibilityWarnThreshold)
+
+		warning := CheckAbsurdity(SolverSolution{Ingredients: ingredients})
+		require.NotNil(t, warning)
+		assert.Equal(t, "ODD_PAIRING", warning.Code)
+
+		ingredients[0].AmountG = 350
+		assert.Equal(t, "SINGLE_LARGE", CheckAbsurdity(SolverSolution{Ingredients: ingredients}).Code)
+	})
+
+	t.Run("solver never suggests a clash", func(t *testing.T) {
+		whey := compatWhey
+		whey.ID, whey.ProteinGPer100, whey.CarbsGPer100, whey.FatGPer100, whey.ServingUnit, whey.ServingSizeG = 10, 80, 7, 3, "scoop", 30
+		fixtures := new(SolverSuite)
+
+		res := SolveMacros(SolverRequest{
+			RemainingBudget: MacroBudget{ProteinG: 50, CarbsG: 45, FatG: 8, CaloriesKcal: 450},
+			PantryFoods:     []FoodNutrition{whey, fixtures.chicken(), fixtures.rice(), fixtures.broccoli()},
+			MinIngredients:  1,
+			MaxIngredients:  4,
+		})
+		require.NotEmpty(t, res.Solutions)
+		for _, sol := range res.Solutions {
+			assert.False(t, CheckCompatibility(sol.Ingredients).Blocked, sol.RecipeName)
+			assert.GreaterOrEqual(t, sol.Breakdown.Compatibility, CompatibilityBlockThreshold)
+		}
+	})
+}
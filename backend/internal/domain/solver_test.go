@@ -226,6 +226,61 @@ func (s *SolverSuite) TestMatchScoring() {
 	})
 }
 
+func (s *SolverSuite) TestScoreBreakdown() {
+	target := MacroBudget{ProteinG: 40, CarbsG: 45, FatG: 8, CaloriesKcal: 420}
+
+	s.Run("components add up to the match score", func() {
+		res := SolveMacros(SolverRequest{
+			RemainingBudget: target,
+			PantryFoods:     []FoodNutrition{s.chicken(), s.rice(), s.broccoli(), s.berries()},
+			MinIngredients:  1,
+			MaxIngredients:  4,
+		})
+		s.Require().NotEmpty(res.Solutions)
+
+		for _, sol := range res.Solutions {
+			b := sol.Breakdown
+			s.InDelta(sol.MatchScore, b.Total, 1e-9)
+			s.InDelta(b.Total, b.MacroPoints+b.IngredientCountPoints+b.FiberPoints+b.PreferenceBonus-b.AbsurdityPenalty, 1e-9)
+			s.InDelta(20.0, b.IngredientCountPoints+b.IngredientCountPenalty, 1e-9)
+			s.InDelta(calculateMatchScore(sol.TotalMacros, target), b.MacroAccuracy, 1e-9)
+		}
+	})
+
+	s.Run("per-macro error is reported as a percent", func() {
+		sol, err := ScoreIngredients([]SolverIngredient{{Food: s.chicken(), AmountG: 100}}, target, nil)
+		s.Require().NoError(err)
+
+		s.InDelta(31.0, sol.Breakdown.Protein.Actual, 1e-9)
+		s.InDelta(22.5, sol.Breakdown.Protein.ErrorPercent, 1e-9) // 31g of 40g
+		s.InDelta(55.0, sol.Breakdown.Protein.Score, 1e-9)
+		s.InDelta(100.0, sol.Breakdown.Carbs.ErrorPercent, 1e-9)
+		s.Zero(sol.Breakdown.Carbs.Score)
+		s.InDelta(16.0, sol.Breakdown.IngredientCountPenalty, 1e-9)
+		s.Equal("100g", sol.Ingredients[0].Display)
+	})
+
+	s.Run("absurd combinations are penalized", func() {
+		sol, err := ScoreIngredients([]SolverIngredient{
+			{Food: s.chicken(), AmountG: 350},
+			{Food: s.rice(), AmountG: 60},
+			{Food: s.broccoli(), AmountG: 100},
+		}, target, nil)
+		s.Require().NoError(err)
+
+		s.Equal("SINGLE_LARGE", sol.Breakdown.AbsurdityCode)
+		s.Equal(absurdityPenaltyPts, sol.Breakdown.AbsurdityPenalty)
+	})
+
+	s.Run("rejects empty lists and bad amounts", func() {
+		_, err := ScoreIngredients(nil, target, nil)
+		s.ErrorIs(err, ErrSolverIngredientsRequired)
+
+		_, err = ScoreIngredients([]SolverIngredient{{Food: s.chicken(), AmountG: 0}}, target, nil)
+		s.ErrorIs(err, ErrInvalidSolverIngredientAmount)
+	})
+}
+
 func (s *SolverSuite) TestServingSizeRounding() {
 	s.Run("egg rounds to whole", func() {
 		egg := FoodNutrition{
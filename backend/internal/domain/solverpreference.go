@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// SOLVER PREFERENCE LEARNING
+// =============================================================================
+//
+// Accepting, rejecting or editing a solver suggestion leaves a signal on each
+// food involved. Signals decay with a two-week half-life, so a food's weight
+// follows what the user has actually been eating over the last few weeks.
+// Liked foods earn a scoring bonus, tolerated foods are neutral, and foods the
+// user keeps removing stop being suggested until the signal fades.
+
+const (
+	// SolverPreferenceWindowDays is how far back feedback is read.
+	SolverPreferenceWindowDays = 56
+	// solverPreferenceHalfLifeDays is the age at which a signal counts half.
+	solverPreferenceHalfLifeDays = 14.0
+	// solverPreferenceSaturation is the decayed score at which the weight reaches ±1.
+	solverPreferenceSaturation = 4.0
+	// solverLikedThreshold is the decayed score from which a food counts as liked.
+	solverLikedThreshold = 2.0
+	// solverPreferenceMaxPoints is the most the preference bonus moves a match score.
+	solverPreferenceMaxPoints = 10.0
+)
+
+// SolverFeedbackAction is what the user did with a suggested solution.
+type SolverFeedbackAction string
+
+const (
+	SolverFeedbackAccepted SolverFeedbackAction = "accepted" // Logged as suggested
+	SolverFeedbackRejected SolverFeedbackAction = "rejected" // Dismissed
+	SolverFeedbackEdited   SolverFeedbackAction = "edited"   // Logged after swapping foods
+)
+
+var validSolverFeedbackActions = map[SolverFeedbackAction]bool{
+	SolverFeedbackAccepted: true,
+	SolverFeedbackRejected: true,
+	SolverFeedbackEdited:   true,
+}
+
+// ParseSolverFeedbackAction safely converts a string to SolverFeedbackAction with validation.
+func ParseSolverFeedbackAction(s string) (SolverFeedbackAction, error) {
+	a := SolverFeedbackAction(s)
+	if !validSolverFeedbackActions[a] {
+		return "", ErrInvalidSolverFeedbackAction
+	}
+	return a, nil
+}
+
+// SolverFoodSignal is the per-food signal derived from feedback.
+type SolverFoodSignal string
+
+const (
+	SolverSignalAccepted SolverFoodSignal = "accepted" // Part of an accepted solution, or kept in an edit
+	SolverSignalRejected SolverFoodSignal = "rejected" // Part of a rejected solution
+	SolverSignalRemoved  SolverFoodSignal = "removed"  // Taken out of a solution in an edit
+	SolverSignalAdded    SolverFoodSignal = "added"    // Put into a solution in an edit
+)
+
+// solverSignalValues weighs each signal. An edit is a deliberate choice about
+// one food, so it counts more than accepting or rejecting a whole combination.
+var solverSignalValues = map[SolverFoodSignal]float64{
+	SolverSignalAccepted: 1,
+	SolverSignalRejected: -0.5,
+	SolverSignalRemoved:  -2,
+	SolverSignalAdded:    2,
+}
+
+// SolverFeedback is the user's response to one suggested solution.
+type SolverFeedback struct {
+	Action        SolverFeedbackAction
+	FoodIDs       []int64   // Foods in the suggestion
+	EditedFoodIDs []int64   // Foods actually logged (edited only)
+	Date          string    // Day the meal was logged (with Meal; optional)
+	Meal          *MealName // Slot the meal was logged in, for satiety learning (optional)
+}
+
+// Validate checks the action and that the feedback names its foods.
+// EditedFoodIDs is ignored unless the action is edited.
+func (f SolverFeedback) Validate() error {
+	if !validSolverFeedbackActions[f.Action] {
+		return ErrInvalidSolverFeedbackAction
+	}
+	if len(f.FoodIDs) == 0 {
+		return ErrSolverFeedbackFoodsRequired
+	}
+	if f.Action == SolverFeedbackEdited && len(f.EditedFoodIDs) == 0 {
+		return ErrSolverFeedbackFoodsRequired
+	}
+	if f.Meal != nil {
+		if !ValidMealNames[*f.Meal] {
+			return ErrInvalidMealName
+		}
+		if _, err := time.Parse("2006-01-02", f.Date); err != nil {
+			return ErrInvalidDate
+		}
+	}
+	return nil
+}
+
+// SolverFoodFeedback is one food's signal from a piece of feedback.
+type SolverFoodFeedback struct {
+	FoodReferenceID int64
+	Signal          SolverFoodSignal
+	CreatedAt       time.Time
+	Date            string    // Set with Meal when the feedback named a meal slot
+	Meal            *MealName // Slot the food was logged in, if any
+}
+
+// Signals breaks feedback down into per-food signals recorded at now.
+// Each food gets at most one signal per piece of feedback.
+func (f SolverFeedback) Signals(now time.Time) []SolverFoodFeedback {
+	var signals []SolverFoodFeedback
+	seen := make(map[int64]bool)
+	add := func(id int64, signal SolverFoodSignal) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		signals = append(signals, SolverFoodFeedback{
+			FoodReferenceID: id, Signal: signal, CreatedAt: now, Date: f.Date, Meal: f.Meal,
+		})
+	}
+
+	switch f.Action {
+	case SolverFeedbackAccepted:
+		for _, id := range f.FoodIDs {
+			add(id, SolverSignalAccepted)
+		}
+	case SolverFeedbackRejected:
+		for _, id := range f.FoodIDs {
+			add(id, SolverSignalRejected)
+		}
+	case SolverFeedbackEdited:
+		suggested := make(map[int64]bool, len(f.FoodIDs))
+		for _, id := range f.FoodIDs {
+			suggested[id] = true
+		}
+		logged := make(map[int64]bool, len(f.EditedFoodIDs))
+		for _, id := range f.EditedFoodIDs {
+			logged[id] = true
+			if suggested[id] {
+				add(id, SolverSignalAccepted)
+			} else {
+				add(id, SolverSignalAdded)
+			}
+		}
+		for _, id := range f.FoodIDs {
+			if !logged[id] {
+				add(id, SolverSignalRemoved)
+			}
+		}
+	}
+	return signals
+}
+
+// FoodPreferenceLevel buckets a learned food weight.
+type FoodPreferenceLevel string
+
+const (
+	FoodPreferenceLiked        FoodPreferenceLevel = "liked"
+	FoodPreferenceTolerated    FoodPreferenceLevel = "tolerated"
+	FoodPreferenceNeverSuggest FoodPreferenceLevel = "never_suggest"
+)
+
+// FoodPreference is the learned preference for one food.
+type FoodPreference struct {
+	FoodReferenceID int64
+	Level           FoodPreferenceLevel
+	Weight          float64 // -1 to 1; -1 means never suggest
+	Score           float64 // Decayed sum of signals
+	SignalCount     int
+	LastSignalAt    time.Time
+}
+
+// FoodPreferences maps food reference IDs to learned preferences.
+// Foods without feedback are absent and count as tolerated.
+type FoodPreferences map[int64]FoodPreference
+
+// BuildFoodPreferences folds feedback signals into per-food preferences as of now.
+// Signals older than SolverPreferenceWindowDays are ignored.
+func BuildFoodPreferences(signals []SolverFoodFeedback, now time.Time) FoodPreferences {
+	prefs := make(FoodPreferences)
+	for _, sig := range signals {
+		ageDays := now.Sub(sig.CreatedAt).Hours() / 24
+		if ageDays > SolverPreferenceWindowDays {
+			continue
+		}
+		if ageDays < 0 {
+			ageDays = 0
+		}
+
+		p := prefs[sig.FoodReferenceID]
+		p.FoodReferenceID = sig.FoodReferenceID
+		p.Score += solverSignalValues[sig.Signal] * math.Pow(0.5, ageDays/solverPreferenceHalfLifeDays)
+		p.SignalCount++
+		if sig.CreatedAt.After(p.LastSignalAt) {
+			p.LastSignalAt = sig.CreatedAt
+		}
+		prefs[sig.FoodReferenceID] = p
+	}
+
+	for id, p := range prefs {
+		p.Weight = math.Max(-1, math.Min(1, p.Score/solverPreferenceSaturation))
+		switch {
+		case p.Weight <= -1:
+			p.Level = FoodPreferenceNeverSuggest
+		case p.Score >= solverLikedThreshold:
+			p.Level = FoodPreferenceLiked
+		default:
+			p.Level = FoodPreferenceTolerated
+		}
+		prefs[id] = p
+	}
+	return prefs
+}
+
+// Allows reports whether the food may be suggested.
+func (p FoodPreferences) Allows(foodID int64) bool {
+	return p[foodID].Level != FoodPreferenceNeverSuggest
+}
+
+// bonus returns the preference points for a set of ingredients: the mean
+// ingredient weight scaled to ±solverPreferenceMaxPoints.
+func (p FoodPreferences) bonus(ingredients []SolverIngredient) float64 {
+	if len(p) == 0 || len(ingredients) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, ing := range ingredients {
+		sum += p[ing.Food.ID].Weight
+	}
+	return sum / float64(len(ingredients)) * solverPreferenceMaxPoints
+}
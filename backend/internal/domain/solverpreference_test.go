@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Preference learning turns raw accept/reject/edit events into
+// solver weights through decay and thresholds; tests pin the signal mapping,
+// the level boundaries and their effect on solver output.
+type SolverPreferenceSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestSolverPreferenceSuite(t *testing.T) {
+	suite.Run(t, new(SolverPreferenceSuite))
+}
+
+func (s *SolverPreferenceSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *SolverPreferenceSuite) signal(foodID int64, signal SolverFoodSignal, daysAgo int) SolverFoodFeedback {
+	return SolverFoodFeedback{FoodReferenceID: foodID, Signal: signal, CreatedAt: s.now.AddDate(0, 0, -daysAgo)}
+}
+
+func (s *SolverPreferenceSuite) TestEditSignals() {
+	feedback := SolverFeedback{
+		Action:        SolverFeedbackEdited,
+		FoodIDs:       []int64{1, 2, 3},
+		EditedFoodIDs: []int64{1, 3, 4, 4},
+	}
+	s.Require().NoError(feedback.Validate())
+
+	got := make(map[int64]SolverFoodSignal)
+	for _, sig := range feedback.Signals(s.now) {
+		s.Equal(s.now, sig.CreatedAt)
+		got[sig.FoodReferenceID] = sig.Signal
+	}
+	s.Equal(map[int64]SolverFoodSignal{
+		1: SolverSignalAccepted,
+		2: SolverSignalRemoved,
+		3: SolverSignalAccepted,
+		4: SolverSignalAdded,
+	}, got)
+}
+
+func (s *SolverPreferenceSuite) TestValidate() {
+	s.ErrorIs(SolverFeedback{Action: "maybe", FoodIDs: []int64{1}}.Validate(), ErrInvalidSolverFeedbackAction)
+	s.ErrorIs(SolverFeedback{Action: SolverFeedbackAccepted}.Validate(), ErrSolverFeedbackFoodsRequired)
+	s.ErrorIs(SolverFeedback{Action: SolverFeedbackEdited, FoodIDs: []int64{1}}.Validate(), ErrSolverFeedbackFoodsRequired)
+	s.NoError(SolverFeedback{Action: SolverFeedbackRejected, FoodIDs: []int64{1}}.Validate())
+
+	_, err := ParseSolverFeedbackAction("liked")
+	s.ErrorIs(err, ErrInvalidSolverFeedbackAction)
+}
+
+func (s *SolverPreferenceSuite) TestLevels() {
+	prefs := BuildFoodPreferences([]SolverFoodFeedback{
+		s.signal(1, SolverSignalAccepted, 1),
+		s.signal(1, SolverSignalAccepted, 2),
+		s.signal(1, SolverSignalAdded, 3),
+		s.signal(2, SolverSignalRejected, 1),
+		s.signal(3, SolverSignalRemoved, 1),
+		s.signal(3, SolverSignalRemoved, 2),
+		s.signal(3, SolverSignalRemoved, 4),
+	}, s.now)
+
+	s.Equal(FoodPreferenceLiked, prefs[1].Level)
+	s.Greater(prefs[1].Weight, 0.5)
+	s.Equal(3, prefs[1].SignalCount)
+	s.Equal(s.now.AddDate(0, 0, -1), prefs[1].LastSignalAt)
+
+	s.Equal(FoodPreferenceTolerated, prefs[2].Level, "one rejected combination isn't a verdict on the food")
+	s.Less(prefs[2].Weight, 0.0)
+
+	s.Equal(FoodPreferenceNeverSuggest, prefs[3].Level)
+	s.Equal(-1.0, prefs[3].Weight)
+	s.False(prefs.Allows(3))
+	s.True(prefs.Allows(99), "foods without feedback are tolerated")
+}
+
+func (s *SolverPreferenceSuite) TestSignalsFade() {
+	removed := []SolverFoodFeedback{
+		s.signal(1, SolverSignalRemoved, 42),
+		s.signal(1, SolverSignalRemoved, 43),
+		s.signal(1, SolverSignalRemoved, 44),
+		s.signal(2, SolverSignalAdded, SolverPreferenceWindowDays+1),
+	}
+	prefs := BuildFoodPreferences(removed, s.now)
+
+	s.Equal(FoodPreferenceTolerated, prefs[1].Level, "six-week-old removals no longer block the food")
+	s.InDelta(-0.18, prefs[1].Weight, 0.02)
+	s.NotContains(prefs, int64(2), "signals outside the window are ignored")
+}
+
+func (s *SolverPreferenceSuite) TestConvergesOnWhatIsEaten() {
+	// Three weeks of swapping rice (2) for berries (3) in the suggestion
+	var signals []SolverFoodFeedback
+	for day := 21; day > 0; day -= 3 {
+		feedback := SolverFeedback{
+			Action:        SolverFeedbackEdited,
+			FoodIDs:       []int64{1, 2, 4},
+			EditedFoodIDs: []int64{1, 3, 4},
+		}
+		signals = append(signals, feedback.Signals(s.now.AddDate(0, 0, -day))...)
+	}
+	prefs := BuildFoodPreferences(signals, s.now)
+
+	s.Equal(FoodPreferenceLiked, prefs[1].Level)
+	s.Equal(FoodPreferenceLiked, prefs[3].Level)
+	s.Equal(FoodPreferenceNeverSuggest, prefs[2].Level)
+}
+
+func (s *SolverPreferenceSuite) TestSolverUsesPreferences() {
+	fixtures := new(SolverSuite)
+	target := MacroBudget{ProteinG: 40, CarbsG: 45, FatG: 8, CaloriesKcal: 420}
+	req := SolverRequest{
+		RemainingBudget: target,
+		PantryFoods:     []FoodNutrition{fixtures.chicken(), fixtures.rice(), fixtures.broccoli(), fixtures.berries()},
+		MinIngredients:  1,
+		MaxIngredients:  4,
+	}
+	usesRice := func(res SolverResponse) bool {
+		for _, sol := range res.Solutions {
+			for _, ing := range sol.Ingredients {
+				if ing.Food.ID == 2 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	s.Require().True(usesRice(SolveMacros(req)))
+
+	s.Run("never-suggest foods are left out", func() {
+		req.Preferences = FoodPreferences{2: {FoodReferenceID: 2, Level: FoodPreferenceNeverSuggest, Weight: -1}}
+		s.False(usesRice(SolveMacros(req)))
+	})
+
+	s.Run("liked foods raise the score", func() {
+		sol, err := ScoreIngredients([]SolverIngredient{
+			{Food: fixtures.chicken(), AmountG: 100},
+			{Food: fixtures.broccoli(), AmountG: 100},
+		}, target, nil)
+		s.Require().NoError(err)
+
+		liked := FoodPreferences{4: {FoodReferenceID: 4, Level: FoodPreferenceLiked, Weight: 1}}
+		withPrefs, err := ScoreIngredients(sol.Ingredients, target, liked)
+		s.Require().NoError(err)
+
+		s.InDelta(5.0, withPrefs.Breakdown.PreferenceBonus, 1e-9) // Mean weight 0.5 of the two foods
+		s.InDelta(sol.MatchScore+5, withPrefs.MatchScore, 1e-9)
+	})
+}
@@ -0,0 +1,116 @@
+package domain
+
+import "time"
+
+// =============================================================================
+// STRENGTH ROTATION
+// =============================================================================
+//
+// Strength training usually follows a split: push → pull → legs, upper ↔
+// lower, or full body every session. The rotation remembers where the user is
+// in their split so new strength sessions can be pre-filled with the next
+// expected archetype. Each logged resistance archetype moves the rotation to
+// the one after it, so skipping or repeating a day re-syncs rather than
+// drifting.
+
+// StrengthSplit names a strength training split.
+type StrengthSplit string
+
+const (
+	StrengthSplitPushPullLegs StrengthSplit = "push_pull_legs"
+	StrengthSplitUpperLower   StrengthSplit = "upper_lower"
+	StrengthSplitFullBody     StrengthSplit = "full_body"
+)
+
+// StrengthSplitSequences lists each split's archetypes in order.
+var StrengthSplitSequences = map[StrengthSplit][]Archetype{
+	StrengthSplitPushPullLegs: {ArchetypePush, ArchetypePull, ArchetypeLegs},
+	StrengthSplitUpperLower:   {ArchetypeUpper, ArchetypeLower},
+	StrengthSplitFullBody:     {ArchetypeFullBody},
+}
+
+// ParseStrengthSplit safely converts a string to StrengthSplit with validation.
+func ParseStrengthSplit(s string) (StrengthSplit, error) {
+	split := StrengthSplit(s)
+	if _, ok := StrengthSplitSequences[split]; !ok {
+		return "", ErrInvalidStrengthSplit
+	}
+	return split, nil
+}
+
+// StrengthRotation is where the user is in their strength split.
+type StrengthRotation struct {
+	Split         StrengthSplit
+	Position      int       // Index of the next expected archetype in the split
+	LastArchetype Archetype // Most recent resistance archetype logged (empty if none)
+	LastDate      string    // YYYY-MM-DD of LastArchetype
+	UpdatedAt     time.Time
+}
+
+// DefaultStrengthRotation is the rotation before anything is logged:
+// push/pull/legs, starting with push.
+func DefaultStrengthRotation() StrengthRotation {
+	return StrengthRotation{Split: StrengthSplitPushPullLegs}
+}
+
+// NextArchetype returns the archetype expected for the next strength session.
+func (r StrengthRotation) NextArchetype() Archetype {
+	return r.Upcoming(1)[0]
+}
+
+// Upcoming returns the next n expected archetypes, for days with several
+// strength sessions.
+func (r StrengthRotation) Upcoming(n int) []Archetype {
+	sequence := StrengthSplitSequences[r.Split]
+	if len(sequence) == 0 {
+		sequence = StrengthSplitSequences[StrengthSplitPushPullLegs]
+	}
+	upcoming := make([]Archetype, n)
+	for i := range upcoming {
+		upcoming[i] = sequence[(r.Position+i)%len(sequence)]
+	}
+	return upcoming
+}
+
+// Record moves the rotation past a logged archetype. Archetypes outside the
+// split (cardio, or "upper" on a push/pull/legs split) and sessions older than
+// the last one recorded leave it unchanged. Reports whether it changed.
+func (r *StrengthRotation) Record(archetype Archetype, date string) bool {
+	if r.LastDate != "" && date < r.LastDate {
+		return false
+	}
+	for i, a := range StrengthSplitSequences[r.Split] {
+		if a == archetype {
+			r.Position = (i + 1) % len(StrengthSplitSequences[r.Split])
+			r.LastArchetype = archetype
+			r.LastDate = date
+			return true
+		}
+	}
+	return false
+}
+
+// Configure switches the split. next picks the next expected archetype; empty
+// starts at the beginning of the split. Returns ErrArchetypeNotInSplit if next
+// isn't part of the split.
+func (r *StrengthRotation) Configure(split StrengthSplit, next Archetype) error {
+	sequence, ok := StrengthSplitSequences[split]
+	if !ok {
+		return ErrInvalidStrengthSplit
+	}
+	position := -1
+	if next == "" {
+		position = 0
+	}
+	for i, a := range sequence {
+		if a == next {
+			position = i
+		}
+	}
+	if position < 0 {
+		return ErrArchetypeNotInSplit
+	}
+	r.Split = split
+	r.Position = position
+	return nil
+}
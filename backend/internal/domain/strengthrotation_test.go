@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The rotation pre-fills archetypes that later drive fatigue;
+// tests pin that logging re-syncs the position, that backfills and cardio
+// don't move it, and that configuring rejects archetypes outside the split.
+type StrengthRotationSuite struct {
+	suite.Suite
+}
+
+func TestStrengthRotationSuite(t *testing.T) {
+	suite.Run(t, new(StrengthRotationSuite))
+}
+
+func (s *StrengthRotationSuite) TestDefaultStartsWithPush() {
+	r := DefaultStrengthRotation()
+	s.Equal(ArchetypePush, r.NextArchetype())
+	s.Equal([]Archetype{ArchetypePush, ArchetypePull, ArchetypeLegs, ArchetypePush}, r.Upcoming(4))
+}
+
+func (s *StrengthRotationSuite) TestRecordAdvancesPastLoggedArchetype() {
+	r := DefaultStrengthRotation()
+	s.True(r.Record(ArchetypePush, "2026-03-02"))
+	s.Equal(ArchetypePull, r.NextArchetype())
+
+	// Skipping pull re-syncs to what was actually done
+	s.True(r.Record(ArchetypeLegs, "2026-03-04"))
+	s.Equal(ArchetypePush, r.NextArchetype())
+	s.Equal(ArchetypeLegs, r.LastArchetype)
+	s.Equal("2026-03-04", r.LastDate)
+
+	// Recording the same session twice doesn't skip ahead
+	s.True(r.Record(ArchetypeLegs, "2026-03-04"))
+	s.Equal(ArchetypePush, r.NextArchetype())
+}
+
+func (s *StrengthRotationSuite) TestRecordIgnoresOtherArchetypes() {
+	r := DefaultStrengthRotation()
+	s.False(r.Record(ArchetypeCardioImpact, "2026-03-02"))
+	s.False(r.Record(ArchetypeUpper, "2026-03-02"))
+	s.Equal(ArchetypePush, r.NextArchetype())
+	s.Empty(r.LastDate)
+}
+
+func (s *StrengthRotationSuite) TestRecordIgnoresBackfills() {
+	r := DefaultStrengthRotation()
+	s.True(r.Record(ArchetypePull, "2026-03-05"))
+	s.False(r.Record(ArchetypePush, "2026-03-01"))
+	s.Equal(ArchetypeLegs, r.NextArchetype())
+}
+
+func (s *StrengthRotationSuite) TestConfigure() {
+	r := DefaultStrengthRotation()
+	s.Require().NoError(r.Configure(StrengthSplitUpperLower, ArchetypeLower))
+	s.Equal(ArchetypeLower, r.NextArchetype())
+	s.True(r.Record(ArchetypeLower, "2026-03-02"))
+	s.Equal(ArchetypeUpper, r.NextArchetype())
+
+	s.Require().NoError(r.Configure(StrengthSplitFullBody, ""))
+	s.Equal([]Archetype{ArchetypeFullBody, ArchetypeFullBody}, r.Upcoming(2))
+
+	s.ErrorIs(r.Configure(StrengthSplitPushPullLegs, ArchetypeUpper), ErrArchetypeNotInSplit)
+	s.ErrorIs(r.Configure("bro_split", ""), ErrInvalidStrengthSplit)
+	s.Equal(StrengthSplitFullBody, r.Split, "failed configure leaves the rotation unchanged")
+}
+
+func (s *StrengthRotationSuite) TestParseStrengthSplit() {
+	split, err := ParseStrengthSplit("upper_lower")
+	s.NoError(err)
+	s.Equal(StrengthSplitUpperLower, split)
+
+	_, err = ParseStrengthSplit("")
+	s.ErrorIs(err, ErrInvalidStrengthSplit)
+}
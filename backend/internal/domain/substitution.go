@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// FATIGUE-AWARE SUBSTITUTION
+// =============================================================================
+//
+// When today's session loads a muscle that is already overreached, the
+// session runner proposes swapping those exercises for catalog movements that
+// work fresher muscles (e.g. legs volume for an upper pull). A movement's
+// muscle load comes from the archetype coefficients of its category. The
+// user's accept/decline decisions are logged for the weekly debrief.
+
+const (
+	// FatigueSubstitutionThreshold is the muscle fatigue (%) above which a
+	// targeted muscle triggers a substitution; the overreached boundary.
+	FatigueSubstitutionThreshold = 75.0
+	// SubstitutionTargetCoefficient is the archetype coefficient at which a
+	// movement counts as targeting a muscle.
+	SubstitutionTargetCoefficient = 0.7
+)
+
+// movementCategoryArchetypes maps catalog categories to the archetype whose
+// coefficients describe their muscle load. Core and skill work is too local
+// to map and is never swapped.
+var movementCategoryArchetypes = map[MovementCategory]Archetype{
+	MovementCategoryPush:       ArchetypePush,
+	MovementCategoryPull:       ArchetypePull,
+	MovementCategoryLegs:       ArchetypeLegs,
+	MovementCategoryLocomotion: ArchetypeFullBody,
+	MovementCategoryPower:      ArchetypeFullBody,
+}
+
+// FatigueSubstitutionInput holds everything needed to propose substitutions.
+type FatigueSubstitutionInput struct {
+	Catalog        []Movement
+	Archetypes     []ArchetypeConfig
+	Fatigue        map[MuscleGroup]float64  // Current fatigue % per muscle, the worse side for split limbs
+	Equipment      EquipmentAvailability    // nil = unrestricted
+	JointIntegrity map[string]float64       // nil when unavailable
+	Overloaded     map[MuscleGroup]BodySide // Limbs with one side overloaded; nil when none
+}
+
+// SubstitutionProposal is a suggested swap for one exercise in the runner flow.
+type SubstitutionProposal struct {
+	Phase           SessionPhase  `json:"phase"`
+	Order           int           `json:"order"`
+	OriginalID      string        `json:"originalId"`
+	OriginalName    string        `json:"originalName"`
+	SubstituteID    string        `json:"substituteId"`
+	SubstituteName  string        `json:"substituteName"`
+	FatiguedMuscles []MuscleGroup `json:"fatiguedMuscles"`
+	Reason          string        `json:"reason"`
+}
+
+// TodaySubstitutions lists the proposals for today's scheduled session.
+type TodaySubstitutions struct {
+	Date         string                 `json:"date"`
+	SessionLabel string                 `json:"sessionLabel,omitempty"` // Empty when nothing is scheduled today
+	Proposals    []SubstitutionProposal `json:"proposals"`
+}
+
+// MovementMuscleLoad returns the archetype coefficients for a movement's
+// category, or nil when the category has no archetype.
+func MovementMuscleLoad(m Movement, archetypes []ArchetypeConfig) map[MuscleGroup]float64 {
+	archetype, ok := movementCategoryArchetypes[m.Category]
+	if !ok {
+		return nil
+	}
+	for _, a := range archetypes {
+		if a.Name == archetype {
+			return a.Coefficients
+		}
+	}
+	return nil
+}
+
+// FatiguedTargets returns the muscles a movement targets that are above the
+// substitution threshold, most fatigued first.
+func FatiguedTargets(m Movement, archetypes []ArchetypeConfig, fatigue map[MuscleGroup]float64) []MuscleGroup {
+	var targets []MuscleGroup
+	for muscle, coef := range MovementMuscleLoad(m, archetypes) {
+		if coef >= SubstitutionTargetCoefficient && fatigue[muscle] > FatigueSubstitutionThreshold {
+			targets = append(targets, muscle)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if fatigue[targets[i]] != fatigue[targets[j]] {
+			return fatigue[targets[i]] > fatigue[targets[j]]
+		}
+		return targets[i] < targets[j]
+	})
+	return targets
+}
+
+// fatigueExposure weighs a movement's muscle load by current fatigue.
+func fatigueExposure(load map[MuscleGroup]float64, fatigue map[MuscleGroup]float64) float64 {
+	exposure := 0.0
+	for muscle, coef := range load {
+		exposure += coef * fatigue[muscle]
+	}
+	return exposure
+}
+
+// FatigueSubstitute picks the catalog movement to replace m: one that
+// targets no overreached muscle, is usable with the equipment and today's
+// joints, and puts the least load on fatigued muscles. Ties go to the
+// closest difficulty, easier first. Returns nil when nothing qualifies.
+func FatigueSubstitute(m Movement, in FatigueSubstitutionInput) *Movement {
+	var best *Movement
+	bestExposure, bestDistance := 0.0, 0
+	for i := range in.Catalog {
+		c := in.Catalog[i]
+		if c.ID == m.ID || c.Category == m.Category {
+			continue
+		}
+		load := MovementMuscleLoad(c, in.Archetypes)
+		if load == nil || len(FatiguedTargets(c, in.Archetypes, in.Fatigue)) > 0 {
+			continue
+		}
+		if !IsMovementAvailable(c, in.Equipment) || len(FilterMovementsByJointIntegrity([]Movement{c}, in.JointIntegrity, MaxMovementDifficulty)) == 0 {
+			continue
+		}
+
+		exposure := math.Round(fatigueExposure(load, in.Fatigue))
+		diff := c.Difficulty - m.Difficulty
+		distance := 2 * diff
+		if diff < 0 {
+			distance = -2*diff - 1 // Prefer easier over harder at the same distance
+		}
+		if best == nil || exposure < bestExposure || (exposure == bestExposure && distance < bestDistance) {
+			best = &in.Catalog[i]
+			bestExposure, bestDistance = exposure, distance
+		}
+	}
+	return best
+}
+
+// ProposeFatigueSubstitutions proposes swaps for catalog exercises in a
+// runner flow that target an overreached muscle. The prepare phase is left
+// alone since warm-ups are already fatigue- and joint-aware. Exercises
+// without a qualifying substitute get no proposal.
+func ProposeFatigueSubstitutions(exercises []SessionExercise, in FatigueSubstitutionInput) []SubstitutionProposal {
+	byID := make(map[string]Movement, len(in.Catalog))
+	for _, m := range in.Catalog {
+		byID[m.ID] = m
+	}
+
+	proposals := make([]SubstitutionProposal, 0)
+	for _, ex := range exercises {
+		if ex.Phase == SessionPhasePrepare {
+			continue
+		}
+		m, ok := byID[ex.ExerciseID]
+		if !ok {
+			continue
+		}
+		targets := FatiguedTargets(m, in.Archetypes, in.Fatigue)
+		if len(targets) == 0 {
+			continue
+		}
+		sub := FatigueSubstitute(m, in)
+		if sub == nil {
+			continue
+		}
+		proposals = append(proposals, SubstitutionProposal{
+			Phase:           ex.Phase,
+			Order:           ex.Order,
+			OriginalID:      m.ID,
+			OriginalName:    m.Name,
+			SubstituteID:    sub.ID,
+			SubstituteName:  sub.Name,
+			FatiguedMuscles: targets,
+			Reason:          substitutionReason(m, *sub, targets, in),
+		})
+	}
+	return proposals
+}
+
+func substitutionReason(original, sub Movement, targets []MuscleGroup, in FatigueSubstitutionInput) string {
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = fmt.Sprintf("%s %.0f%%", t, in.Fatigue[t])
+		if side := in.Overloaded[t]; side != "" {
+			names[i] += fmt.Sprintf(" (%s side)", side)
+		}
+	}
+	return fmt.Sprintf("%s over %.0f%% fatigue; swap %s work for %s",
+		strings.Join(names, ", "), FatigueSubstitutionThreshold, original.Category, sub.Category)
+}
+
+// SubstitutionDecision records whether a proposed swap was taken.
+type SubstitutionDecision struct {
+	ID              int64         `json:"id"`
+	Date            string        `json:"date"` // YYYY-MM-DD
+	SessionLabel    string        `json:"sessionLabel"`
+	OriginalID      string        `json:"originalId"`
+	SubstituteID    string        `json:"substituteId"`
+	FatiguedMuscles []MuscleGroup `json:"fatiguedMuscles"`
+	Accepted        bool          `json:"accepted"`
+	CreatedAt       time.Time     `json:"createdAt"`
+}
+
+// Validate checks a substitution decision.
+func (d SubstitutionDecision) Validate() error {
+	if _, err := time.Parse("2006-01-02", d.Date); err != nil {
+		return ErrInvalidSubstitutionDate
+	}
+	if d.OriginalID == "" || d.SubstituteID == "" || d.OriginalID == d.SubstituteID {
+		return ErrInvalidSubstitutionMovements
+	}
+	for _, m := range d.FatiguedMuscles {
+		if !ValidMuscleGroups[m] {
+			return ErrInvalidSubstitutionMuscle
+		}
+	}
+	return nil
+}
+
+// SubstitutionSummaryLines describes the week's decisions for the debrief
+// narrative, e.g. "2026-03-04 Legs A: cali_squat_pistol -> cali_pullup_std
+// (quads), accepted".
+func SubstitutionSummaryLines(decisions []SubstitutionDecision) []string {
+	lines := make([]string, 0, len(decisions))
+	for _, d := range decisions {
+		muscles := make([]string, len(d.FatiguedMuscles))
+		for i, m := range d.FatiguedMuscles {
+			muscles[i] = string(m)
+		}
+		outcome := "declined"
+		if d.Accepted {
+			outcome = "accepted"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s -> %s (%s), %s",
+			d.Date, d.SessionLabel, d.OriginalID, d.SubstituteID, strings.Join(muscles, ", "), outcome))
+	}
+	return lines
+}
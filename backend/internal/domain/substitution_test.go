@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Substitutions rewrite the runner's plan for the day; tests
+// pin when an exercise counts as hitting an overreached muscle and which
+// catalog movement replaces it under equipment and joint limits.
+type SubstitutionSuite struct {
+	suite.Suite
+	input FatigueSubstitutionInput
+}
+
+func TestSubstitutionSuite(t *testing.T) {
+	suite.Run(t, new(SubstitutionSuite))
+}
+
+func (s *SubstitutionSuite) SetupTest() {
+	s.input = FatigueSubstitutionInput{
+		Catalog: []Movement{
+			{ID: "squat", Name: "Air Squat", Category: MovementCategoryLegs, Difficulty: 3},
+			{ID: "lunge", Name: "Lunge", Category: MovementCategoryLegs, Difficulty: 4},
+			{ID: "rows", Name: "Inverted Rows", Category: MovementCategoryPull, Difficulty: 3, Equipment: []EquipmentType{EquipmentTypePullupBar}},
+			{ID: "pullup", Name: "Pull-up", Category: MovementCategoryPull, Difficulty: 6, Equipment: []EquipmentType{EquipmentTypePullupBar}},
+			{ID: "pushup", Name: "Push-up", Category: MovementCategoryPush, Difficulty: 4, JointStress: map[string]float64{"wrist": 0.7}},
+			{ID: "hollow", Name: "Hollow Body", Category: MovementCategoryCore, Difficulty: 3},
+		},
+		Archetypes: []ArchetypeConfig{
+			{Name: ArchetypePush, Coefficients: map[MuscleGroup]float64{MuscleChest: 1.0, MuscleTriceps: 0.7, MuscleCore: 0.4}},
+			{Name: ArchetypePull, Coefficients: map[MuscleGroup]float64{MuscleLats: 1.0, MuscleBiceps: 0.7}},
+			{Name: ArchetypeLegs, Coefficients: map[MuscleGroup]float64{MuscleQuads: 1.0, MuscleGlutes: 1.0, MuscleHamstrings: 0.7}},
+		},
+		Fatigue: map[MuscleGroup]float64{MuscleQuads: 85, MuscleGlutes: 80, MuscleChest: 40, MuscleLats: 10},
+	}
+}
+
+func (s *SubstitutionSuite) TestFatiguedTargets() {
+	legs := s.input.Catalog[0]
+
+	s.Equal([]MuscleGroup{MuscleQuads, MuscleGlutes}, FatiguedTargets(legs, s.input.Archetypes, s.input.Fatigue))
+	s.Empty(FatiguedTargets(s.input.Catalog[5], s.input.Archetypes, s.input.Fatigue), "core has no archetype")
+}
+
+func (s *SubstitutionSuite) TestSwapsLegsForLeastFatiguedPattern() {
+	exercises := []SessionExercise{
+		{ExerciseID: "lunge", Phase: SessionPhasePrepare, Order: 1},
+		{ExerciseID: "squat", Phase: SessionPhasePush, Order: 1},
+		{ExerciseID: "hollow", Phase: SessionPhasePush, Order: 2},
+		{ExerciseID: "gmb_bear", Phase: SessionPhasePractice, Order: 1},
+	}
+
+	proposals := ProposeFatigueSubstitutions(exercises, s.input)
+
+	s.Require().Len(proposals, 1, "warm-ups, unmapped and non-catalog exercises stay")
+	p := proposals[0]
+	s.Equal("squat", p.OriginalID)
+	s.Equal("rows", p.SubstituteID, "pull is freshest; closest difficulty wins")
+	s.Equal([]MuscleGroup{MuscleQuads, MuscleGlutes}, p.FatiguedMuscles)
+	s.Contains(p.Reason, "quads 85%")
+}
+
+func (s *SubstitutionSuite) TestReasonNamesOverloadedSide() {
+	s.input.Overloaded = map[MuscleGroup]BodySide{MuscleQuads: SideLeft}
+	proposals := ProposeFatigueSubstitutions([]SessionExercise{{ExerciseID: "squat", Phase: SessionPhasePush, Order: 1}}, s.input)
+
+	s.Require().Len(proposals, 1)
+	s.Contains(proposals[0].Reason, "quads 85% (left side)")
+	s.NotContains(proposals[0].Reason, "glutes 80% (")
+}
+
+func (s *SubstitutionSuite) TestRespectsEquipmentAndJoints() {
+	s.input.Equipment = EquipmentAvailability{}
+	s.input.JointIntegrity = map[string]float64{"wrist": 0.4}
+
+	s.Nil(FatigueSubstitute(s.input.Catalog[0], s.input), "no bar for pulls, push-ups load a weak wrist")
+
+	s.input.JointIntegrity = nil
+	sub := FatigueSubstitute(s.input.Catalog[0], s.input)
+	s.Require().NotNil(sub)
+	s.Equal("pushup", sub.ID)
+}
+
+func (s *SubstitutionSuite) TestDecisionValidate() {
+	d := SubstitutionDecision{Date: "2026-03-04", OriginalID: "squat", SubstituteID: "rows", FatiguedMuscles: []MuscleGroup{MuscleQuads}}
+	s.NoError(d.Validate())
+
+	same := d
+	same.SubstituteID = "squat"
+	s.ErrorIs(same.Validate(), ErrInvalidSubstitutionMovements)
+
+	badMuscle := d
+	badMuscle.FatiguedMuscles = []MuscleGroup{"knees"}
+	s.ErrorIs(badMuscle.Validate(), ErrInvalidSubstitutionMuscle)
+
+	s.Equal([]string{"2026-03-04 : squat -> rows (quads), declined"}, SubstitutionSummaryLines([]SubstitutionDecision{d}))
+}
@@ -203,15 +203,15 @@ func CalculateDailyTargets(profile *UserProfile, log *DailyLog, now time.Time) D
 		dayType, profile.SupplementConfig,
 	)
 
-	// 9. Calculate water target (0.04 L per kg body weight)
-	waterL := math.Round(log.WeightKg*WaterLPerKg*10) / 10
+	// 9. Calculate water target (0.04 L per kg body weight, scaled for heat/humidity/altitude)
+	waterL := CalculateWaterTarget(log.WeightKg, log.DayEnvironment())
 
 	return DailyTargets{
-		TotalCarbsG:   int(math.Round(macros.CarbsG)),
-		TotalProteinG: int(math.Round(macros.ProteinG)),
-		TotalFatsG:    int(math.Round(macros.FatsG)),
-		TotalCalories: int(math.Round(totalCalories)),
-		EstimatedTDEE: int(math.Round(effectiveTDEE)),
+		TotalCarbsG:   RoundInt(macros.CarbsG),
+		TotalProteinG: RoundInt(macros.ProteinG),
+		TotalFatsG:    RoundInt(macros.FatsG),
+		TotalCalories: RoundInt(totalCalories),
+		EstimatedTDEE: RoundInt(effectiveTDEE),
 		Meals:         meals,
 		FruitG:        fruitG,
 		VeggiesG:      veggiesG,
@@ -231,7 +231,7 @@ func CalculateEstimatedTDEE(profile *UserProfile, weightKg float64, sessions []T
 	bmr := CalculateBMR(profile, weightKg, now, bmrEquation)
 	exerciseCalories := CalculateTotalExerciseCalories(sessions, weightKg)
 	tdee := bmr*1.2 + exerciseCalories
-	return int(math.Round(tdee))
+	return RoundInt(tdee)
 }
 
 // =============================================================================
@@ -575,9 +575,9 @@ func calculateMealPoints(
 	}
 }
 
-// roundToNearest5 rounds a float to the nearest multiple of 5.
+// roundToNearest5 rounds a float to the nearest multiple of 5 (MROUND).
 func roundToNearest5(n float64) int {
-	return int(math.Round(n/5) * 5)
+	return MRoundInt(n, 5)
 }
 
 // =============================================================================
@@ -594,11 +594,12 @@ type AdaptiveTDEEResult struct {
 
 // AdaptiveDataPoint represents a single day's data for adaptive TDEE calculation.
 type AdaptiveDataPoint struct {
-	Date           string
-	WeightKg       float64
-	TargetCalories int // Planned intake for the day (used as intake proxy)
-	EstimatedTDEE  int // Effective TDEE used when targets were generated
-	FormulaTDEE    int // Formula-based TDEE for transparency and fallback
+	Date             string
+	WeightKg         float64
+	TargetCalories   int // Planned intake for the day (used as intake proxy)
+	ConsumedCalories int // Logged intake; used only for days without targets (e.g. imported history)
+	EstimatedTDEE    int // Effective TDEE used when targets were generated
+	FormulaTDEE      int // Formula-based TDEE for transparency and fallback
 }
 
 // MinDataPointsForAdaptive is the minimum number of days needed for adaptive TDEE.
@@ -621,6 +622,16 @@ func pointBaselineTDEE(point AdaptiveDataPoint) float64 {
 	return 0
 }
 
+// pointIntake returns the day's intake: the target when one was generated, otherwise
+// logged calories. Live days keep the target since meal logging is often partial;
+// historical days imported from other apps have no target.
+func pointIntake(point AdaptiveDataPoint) (float64, bool) {
+	if point.TargetCalories <= 0 && point.ConsumedCalories > 0 {
+		return float64(point.ConsumedCalories), true
+	}
+	return float64(point.TargetCalories), false
+}
+
 func adjustIntake(avgTarget, avgBaseline, observedDeficit float64) (float64, float64) {
 	if avgTarget <= 0 || avgBaseline <= 0 {
 		return avgTarget, 0
@@ -675,9 +686,14 @@ func calculateWeeklyTDEE(dataPoints []AdaptiveDataPoint, startIdx, endIdx, weekN
 	var totalCalories float64
 	var totalBaseline float64
 	daysInWeek := 0
+	loggedDays := 0
 	baselineCount := 0
 	for i := startIdx; i < startIdx+7 && i < len(dataPoints); i++ {
-		totalCalories += float64(dataPoints[i].TargetCalories)
+		intake, logged := pointIntake(dataPoints[i])
+		totalCalories += intake
+		if logged {
+			loggedDays++
+		}
 		daysInWeek++
 		baseline := pointBaselineTDEE(dataPoints[i])
 		if baseline > 0 {
@@ -703,7 +719,11 @@ func calculateWeeklyTDEE(dataPoints []AdaptiveDataPoint, startIdx, endIdx, weekN
 	}
 
 	dailyCalorieDeficit := (weightChangeKg / daysBetween) * 7700.0
-	adjustedIntake, adjustmentAbs := adjustIntake(avgDailyIntake, avgBaseline, dailyCalorieDeficit)
+	adjustedIntake, adjustmentAbs := avgDailyIntake, 0.0
+	if loggedDays < daysInWeek {
+		// Only target-proxy intake needs the adherence adjustment
+		adjustedIntake, adjustmentAbs = adjustIntake(avgDailyIntake, avgBaseline, dailyCalorieDeficit)
+	}
 	estimatedTDEE := adjustedIntake + dailyCalorieDeficit
 
 	// Sanity check - TDEE should be reasonable (800-6000 range)
@@ -718,28 +738,95 @@ func calculateWeeklyTDEE(dataPoints []AdaptiveDataPoint, startIdx, endIdx, weekN
 	}
 }
 
-// calculateAdaptiveConfidence computes confidence level for adaptive TDEE calculation.
-// Based on data point count, consistency of estimates (CV), and adherence error penalty.
-func calculateAdaptiveConfidence(dataPointCount int, weeklyTDEEEstimates []float64, adaptiveTDEE float64, adherenceErrorSum float64, adherenceSamples int) float64 {
+// AdaptiveCoverage describes the logging around the data points an adaptive
+// estimate is built from. Zero counts are taken from the data points
+// themselves, and an empty AsOfDate means the last data point's date.
+type AdaptiveCoverage struct {
+	AsOfDate    string // Day the estimate is for (YYYY-MM-DD)
+	WeighInDays int    // Days with an explicit weigh-in from the first data point to AsOfDate
+	IntakeDays  int    // Days with intake (targets or logged food) over the same window
+}
+
+const (
+	// adaptiveRecentWindowDays is the window before the estimate's day that
+	// recency is judged over.
+	adaptiveRecentWindowDays = 14
+	// adaptiveRecentPointsForFull is how many data points that window needs
+	// for full recency confidence.
+	adaptiveRecentPointsForFull = 7
+	// adaptiveWeighInRateForFull is the weigh-in frequency (per day) with full
+	// confidence: 4 a week.
+	adaptiveWeighInRateForFull = 4.0 / 7.0
+	// adaptiveIntakeRateForFull is the share of days with intake for full
+	// confidence: 6 a week.
+	adaptiveIntakeRateForFull = 6.0 / 7.0
+	// adaptiveResidualCVForZero is the spread of weekly estimates around the
+	// average (coefficient of variation) at which consistency confidence is 0.
+	adaptiveResidualCVForZero = 0.15
+)
+
+// adaptiveRecency returns 0-1 by how many data points fall in the 14 days up
+// to asOf. After a gap (a vacation, a week without weighing) confidence falls
+// to zero and rebuilds over the first week of logging again.
+func adaptiveRecency(dataPoints []AdaptiveDataPoint, asOf string) float64 {
+	end, ok := parseDate(asOf)
+	if !ok {
+		return 1
+	}
+	start := end.AddDate(0, 0, -adaptiveRecentWindowDays)
+	recent := 0
+	for _, p := range dataPoints {
+		if d, ok := parseDate(p.Date); ok && d.After(start) && !d.After(end) {
+			recent++
+		}
+	}
+	return math.Min(float64(recent)/adaptiveRecentPointsForFull, 1)
+}
+
+// adaptiveCoverageFactor returns 0-1 from how often weight and intake were
+// logged across the window, the geometric mean of the weigh-in frequency and
+// logging completeness factors.
+func adaptiveCoverageFactor(dataPoints []AdaptiveDataPoint, coverage AdaptiveCoverage) float64 {
+	windowDays := daysBetweenDates(dataPoints[0].Date, coverage.AsOfDate) + 1
+	if windowDays <= 0 {
+		return 1
+	}
+	weighIns, intakeDays := coverage.WeighInDays, coverage.IntakeDays
+	if weighIns == 0 {
+		weighIns = len(dataPoints)
+	}
+	if intakeDays == 0 {
+		intakeDays = len(dataPoints)
+	}
+	weighInFactor := math.Min(float64(weighIns)/windowDays/adaptiveWeighInRateForFull, 1)
+	intakeFactor := math.Min(float64(intakeDays)/windowDays/adaptiveIntakeRateForFull, 1)
+	return math.Sqrt(weighInFactor * intakeFactor)
+}
+
+// calculateAdaptiveConfidence computes the confidence (0-1) of an adaptive TDEE.
+// The volume of data and the consistency of the weekly estimates (their
+// residuals around the average) form the base, as a geometric mean. It is then
+// scaled by recency, so confidence drops after a gap instead of coasting on
+// old data, and by weigh-in and intake coverage across the window. Target-proxy
+// intake that needed a large adherence adjustment costs up to 20% more.
+func calculateAdaptiveConfidence(dataPoints []AdaptiveDataPoint, coverage AdaptiveCoverage, weeklyTDEEEstimates []float64, adaptiveTDEE float64, adherenceErrorSum float64, adherenceSamples int) float64 {
 	// 1. Data point confidence (more = higher)
-	dataPointConfidence := math.Min(float64(dataPointCount)/float64(MaxDataPointsForAdaptive), 1.0)
+	dataPointConfidence := math.Min(float64(len(dataPoints))/float64(MaxDataPointsForAdaptive), 1.0)
 
-	// 2. Calculate variance in estimates for consistency confidence
+	// 2. Residuals of the weekly estimates around the average
 	var sumSquaredDiff float64
 	for _, tdee := range weeklyTDEEEstimates {
 		diff := tdee - adaptiveTDEE
 		sumSquaredDiff += diff * diff
 	}
 	variance := sumSquaredDiff / float64(len(weeklyTDEEEstimates))
-	stdDev := math.Sqrt(variance)
-
-	// Lower coefficient of variation = higher consistency confidence
-	// CV > 0.15 (15%) reduces confidence
-	cv := stdDev / adaptiveTDEE
-	consistencyConfidence := math.Max(0, 1.0-(cv/0.15))
+	cv := math.Sqrt(variance) / adaptiveTDEE
+	consistencyConfidence := math.Max(0, 1.0-(cv/adaptiveResidualCVForZero))
 
-	// 3. Combined confidence (geometric mean of factors)
+	// 3. Base confidence (geometric mean), scaled by recency and coverage
 	confidence := math.Sqrt(dataPointConfidence * consistencyConfidence)
+	confidence *= adaptiveRecency(dataPoints, coverage.AsOfDate)
+	confidence *= adaptiveCoverageFactor(dataPoints, coverage)
 
 	// 4. Apply adherence penalty
 	if adherenceSamples > 0 {
@@ -790,7 +877,8 @@ func daysBetweenDates(startDate, endDate string) float64 {
 }
 
 // CalculateAdaptiveTDEE calculates TDEE from historical weight and calorie data.
-// Uses target calories as an intake proxy with a light adherence adjustment.
+// Uses target calories as an intake proxy with a light adherence adjustment;
+// days without targets (imported food history) use their logged calories as-is.
 //
 // The algorithm:
 //  1. Calculates weekly weight changes from the data points
@@ -799,8 +887,12 @@ func daysBetweenDates(startDate, endDate string) float64 {
 //     where 1100 = 7700 kcal per kg / 7 days
 //  4. Weights recent weeks more heavily than older weeks
 //
+// Confidence also reflects coverage: how recent the data is relative to
+// coverage.AsOfDate and how often weight and intake were logged (see
+// calculateAdaptiveConfidence).
+//
 // Returns nil if insufficient data (less than MinDataPointsForAdaptive days).
-func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint) *AdaptiveTDEEResult {
+func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint, coverage AdaptiveCoverage) *AdaptiveTDEEResult {
 	if len(dataPoints) < 2 {
 		return nil
 	}
@@ -809,6 +901,9 @@ func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint) *AdaptiveTDEEResult {
 	if len(dataPoints) > MaxDataPointsForAdaptive {
 		dataPoints = dataPoints[len(dataPoints)-MaxDataPointsForAdaptive:]
 	}
+	if coverage.AsOfDate == "" {
+		coverage.AsOfDate = dataPoints[len(dataPoints)-1].Date
+	}
 
 	spanDays, spanOK := adaptiveSpanDays(dataPoints)
 	if len(dataPoints) < MinDataPointsForAdaptive && (!spanOK || spanDays < MinDataPointsForAdaptive) {
@@ -816,14 +911,14 @@ func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint) *AdaptiveTDEEResult {
 	}
 
 	if len(dataPoints) < MinDataPointsForAdaptive {
-		return calculateSparseAdaptiveTDEE(dataPoints, spanDays)
+		return calculateSparseAdaptiveTDEE(dataPoints, spanDays, coverage)
 	}
 
 	// Group data into weeks for more stable calculations
 	numWeeks := len(dataPoints) / 7
 	if numWeeks < 2 {
 		if spanOK && spanDays >= MinDataPointsForAdaptive {
-			return calculateSparseAdaptiveTDEE(dataPoints, spanDays)
+			return calculateSparseAdaptiveTDEE(dataPoints, spanDays, coverage)
 		}
 		return nil
 	}
@@ -856,8 +951,8 @@ func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint) *AdaptiveTDEEResult {
 	}
 	adaptiveTDEE := weightedSum / totalWeight
 
-	// Calculate confidence from data quality, consistency, and adherence
-	confidence := calculateAdaptiveConfidence(len(dataPoints), tdeeValues, adaptiveTDEE, adherenceErrorSum, len(estimates))
+	// Calculate confidence from volume, consistency, recency, coverage and adherence
+	confidence := calculateAdaptiveConfidence(dataPoints, coverage, tdeeValues, adaptiveTDEE, adherenceErrorSum, len(estimates))
 
 	return &AdaptiveTDEEResult{
 		TDEE:           math.Round(adaptiveTDEE),
@@ -867,16 +962,24 @@ func CalculateAdaptiveTDEE(dataPoints []AdaptiveDataPoint) *AdaptiveTDEEResult {
 	}
 }
 
-func calculateSparseAdaptiveTDEE(dataPoints []AdaptiveDataPoint, spanDays float64) *AdaptiveTDEEResult {
+// calculateSparseAdaptiveTDEE estimates TDEE over the whole span when there are
+// too few points for weekly estimates. Confidence starts at 0.3, the adaptive
+// gate, so any loss of recency or coverage falls back to another source.
+func calculateSparseAdaptiveTDEE(dataPoints []AdaptiveDataPoint, spanDays float64, coverage AdaptiveCoverage) *AdaptiveTDEEResult {
 	if len(dataPoints) < 2 || spanDays <= 0 {
 		return nil
 	}
 
 	var totalCalories float64
 	var totalBaseline float64
+	loggedDays := 0
 	baselineCount := 0
 	for _, point := range dataPoints {
-		totalCalories += float64(point.TargetCalories)
+		intake, logged := pointIntake(point)
+		totalCalories += intake
+		if logged {
+			loggedDays++
+		}
 		baseline := pointBaselineTDEE(point)
 		if baseline > 0 {
 			totalBaseline += baseline
@@ -897,7 +1000,10 @@ func calculateSparseAdaptiveTDEE(dataPoints []AdaptiveDataPoint, spanDays float6
 	endWeight := dataPoints[len(dataPoints)-1].WeightKg
 	weightChangeKg := startWeight - endWeight
 	dailyCalorieDeficit := (weightChangeKg / spanDays) * 7700.0
-	adjustedIntake, adjustmentAbs := adjustIntake(avgDailyIntake, avgBaseline, dailyCalorieDeficit)
+	adjustedIntake, adjustmentAbs := avgDailyIntake, 0.0
+	if loggedDays < len(dataPoints) {
+		adjustedIntake, adjustmentAbs = adjustIntake(avgDailyIntake, avgBaseline, dailyCalorieDeficit)
+	}
 	estimatedTDEE := adjustedIntake + dailyCalorieDeficit
 
 	if estimatedTDEE < 800 || estimatedTDEE > 6000 {
@@ -905,6 +1011,8 @@ func calculateSparseAdaptiveTDEE(dataPoints []AdaptiveDataPoint, spanDays float6
 	}
 
 	confidence := 0.3
+	confidence *= adaptiveRecency(dataPoints, coverage.AsOfDate)
+	confidence *= adaptiveCoverageFactor(dataPoints, coverage)
 	confidence *= 1 - adherencePenalty(adjustmentAbs)
 	confidence = math.Round(confidence*100) / 100
 
@@ -927,18 +1035,18 @@ func GetEffectiveTDEE(profile *UserProfile, formulaTDEE int, adaptiveResult *Ada
 	switch profile.TDEESource {
 	case TDEESourceManual:
 		if profile.ManualTDEE > 0 {
-			return int(profile.ManualTDEE), TDEESourceManual, 0.8, 0
+			return RoundInt(profile.ManualTDEE), TDEESourceManual, 0.8, 0
 		}
 		// Fall back to formula if manual not set
 		return formulaTDEE, TDEESourceFormula, fallbackConfidence, 0
 
 	case TDEESourceAdaptive:
 		if adaptiveResult != nil && adaptiveResult.Confidence >= 0.3 {
-			return int(adaptiveResult.TDEE), TDEESourceAdaptive, adaptiveResult.Confidence, adaptiveResult.DataPointsUsed
+			return RoundInt(adaptiveResult.TDEE), TDEESourceAdaptive, adaptiveResult.Confidence, adaptiveResult.DataPointsUsed
 		}
 		// Fall back to manual if set, else formula
 		if profile.ManualTDEE > 0 {
-			return int(profile.ManualTDEE), TDEESourceManual, fallbackConfidence, 0
+			return RoundInt(profile.ManualTDEE), TDEESourceManual, fallbackConfidence, 0
 		}
 		return formulaTDEE, TDEESourceFormula, fallbackConfidence, 0
 
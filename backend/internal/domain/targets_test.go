@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -802,7 +803,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 	}
 
 	s.Run("returns nil with empty data", func() {
-		result := CalculateAdaptiveTDEE(nil)
+		result := CalculateAdaptiveTDEE(nil, AdaptiveCoverage{})
 		s.Nil(result, "Should return nil for empty data")
 	})
 
@@ -810,13 +811,13 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		points := []AdaptiveDataPoint{
 			{Date: "2025-01-01", WeightKg: 85, TargetCalories: 2000},
 		}
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 		s.Nil(result, "Should return nil for single data point")
 	})
 
 	s.Run("returns nil with fewer than 14 data points", func() {
 		points := createDataPoints(10, 85.0, 0.05, 2000)
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 		s.Nil(result, "Should return nil when less than MinDataPointsForAdaptive")
 	})
 
@@ -824,7 +825,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		// 28 days of data: losing 0.5 kg/week = ~0.071 kg/day
 		// Eating 1700 cal/day, expected TDEE ~2200 (deficit of 500 = 0.5kg/week)
 		points := createDataPoints(28, 85.0, 0.071, 1700)
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		s.NotNil(result, "Should return result with sufficient data")
 		s.Equal(TDEESourceAdaptive, result.Source)
@@ -838,7 +839,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		// 28 days of data: gaining 0.35 kg/week = ~0.05 kg/day
 		// Eating 2500 cal/day, surplus of ~385 kcal/day
 		points := createDataPoints(28, 85.0, -0.05, 2500)
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		s.NotNil(result, "Should return result with sufficient data")
 		// TDEE should be roughly intake - surplus calories
@@ -849,13 +850,38 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		// 28 days of data: no weight change
 		// Eating 2200 cal/day = maintenance
 		points := createDataPoints(28, 85.0, 0, 2200)
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		s.NotNil(result, "Should return result with sufficient data")
 		// TDEE should equal intake when weight is stable
 		s.InDelta(2200, result.TDEE, 100, "TDEE should match intake when weight stable")
 	})
 
+	s.Run("uses logged intake for imported days without targets", func() {
+		// Imported history: weight from a scale export, intake from a food diary, no targets
+		points := createDataPoints(28, 85.0, 0.071, 0)
+		for i := range points {
+			points[i].ConsumedCalories = 1700
+			points[i].EstimatedTDEE = 0
+			points[i].FormulaTDEE = 0
+		}
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
+
+		s.Require().NotNil(result)
+		s.InDelta(2247, result.TDEE, 10, "Logged intake plus deficit, no adherence adjustment")
+	})
+
+	s.Run("prefers targets over partial meal logging", func() {
+		points := createDataPoints(28, 85.0, 0, 2200)
+		for i := range points {
+			points[i].ConsumedCalories = 600 // Only breakfast logged
+		}
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
+
+		s.Require().NotNil(result)
+		s.InDelta(2200, result.TDEE, 100, "Partial logging should not drag TDEE down")
+	})
+
 	s.Run("weights recent weeks more heavily", func() {
 		// Create 28 days where first 2 weeks suggest TDEE=2000, last 2 weeks suggest TDEE=2400
 		points := make([]AdaptiveDataPoint, 28)
@@ -874,7 +900,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 				FormulaTDEE:    2200,
 			}
 		}
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		s.NotNil(result, "Should return result")
 		// Result should be biased toward recent weeks (higher TDEE)
@@ -886,9 +912,9 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		points28 := createDataPoints(28, 85.0, 0.071, 1700)
 		points56 := createDataPoints(56, 85.0, 0.071, 1700)
 
-		result14 := CalculateAdaptiveTDEE(points14)
-		result28 := CalculateAdaptiveTDEE(points28)
-		result56 := CalculateAdaptiveTDEE(points56)
+		result14 := CalculateAdaptiveTDEE(points14, AdaptiveCoverage{})
+		result28 := CalculateAdaptiveTDEE(points28, AdaptiveCoverage{})
+		result56 := CalculateAdaptiveTDEE(points56, AdaptiveCoverage{})
 
 		s.NotNil(result14)
 		s.NotNil(result28)
@@ -904,7 +930,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 	s.Run("limits data points to MaxDataPointsForAdaptive", func() {
 		// Create 100 days of data (more than MaxDataPointsForAdaptive=56)
 		points := createDataPoints(100, 85.0, 0.071, 1700)
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		s.NotNil(result)
 		s.Equal(MaxDataPointsForAdaptive, result.DataPointsUsed,
@@ -924,7 +950,7 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 				FormulaTDEE:    2200,
 			}
 		}
-		result := CalculateAdaptiveTDEE(points)
+		result := CalculateAdaptiveTDEE(points, AdaptiveCoverage{})
 
 		// The sanity check should filter out unreasonable weekly estimates
 		// If all estimates are unreasonable, returns nil
@@ -933,3 +959,76 @@ func (s *TargetsSuite) TestCalculateAdaptiveTDEE() {
 		}
 	})
 }
+
+func (s *TargetsSuite) TestAdaptiveConfidenceCoverage() {
+	generateDate := func(dayOffset int) string {
+		base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		return base.AddDate(0, 0, dayOffset).Format("2006-01-02")
+	}
+	// Maintenance at 2200 kcal, one data point per given day offset
+	pointsOn := func(offsets []int) []AdaptiveDataPoint {
+		points := make([]AdaptiveDataPoint, len(offsets))
+		for i, day := range offsets {
+			points[i] = AdaptiveDataPoint{
+				Date:           generateDate(day),
+				WeightKg:       85.0 - float64(day)*0.071,
+				TargetCalories: 1700,
+				EstimatedTDEE:  2200,
+				FormulaTDEE:    2200,
+			}
+		}
+		return points
+	}
+	days := func(from, to int) []int {
+		var offsets []int
+		for d := from; d <= to; d++ {
+			offsets = append(offsets, d)
+		}
+		return offsets
+	}
+
+	dense := pointsOn(days(0, 55))
+	current := CalculateAdaptiveTDEE(dense, AdaptiveCoverage{})
+	s.Require().NotNil(current)
+	s.Greater(current.Confidence, 0.8, "56 consistent, daily, current days")
+
+	s.Run("as of the last data point matches the default", func() {
+		result := CalculateAdaptiveTDEE(dense, AdaptiveCoverage{AsOfDate: generateDate(55)})
+		s.Require().NotNil(result)
+		s.Equal(current.Confidence, result.Confidence)
+	})
+
+	s.Run("drops to zero when the data is two weeks old", func() {
+		result := CalculateAdaptiveTDEE(dense, AdaptiveCoverage{AsOfDate: generateDate(70)})
+		s.Require().NotNil(result)
+		s.Equal(current.TDEE, result.TDEE, "only confidence changes")
+		s.Zero(result.Confidence)
+	})
+
+	s.Run("rebuilds over the first week back from a vacation", func() {
+		beforeTrip := days(0, 41)
+		backOne := pointsOn(append(append([]int{}, beforeTrip...), 56))
+		backWeek := pointsOn(append(append([]int{}, beforeTrip...), days(56, 62)...))
+
+		one := CalculateAdaptiveTDEE(backOne, AdaptiveCoverage{})
+		week := CalculateAdaptiveTDEE(backWeek, AdaptiveCoverage{})
+		s.Require().NotNil(one)
+		s.Require().NotNil(week)
+		s.Less(one.Confidence, 0.3, "below the adaptive gate on the first day back")
+		s.Greater(week.Confidence, 0.3)
+		s.Less(week.Confidence, current.Confidence, "the gap still costs coverage")
+	})
+
+	s.Run("infrequent weigh-ins lower confidence", func() {
+		// 2 weigh-ins a week across the 56 days, intake logged daily
+		result := CalculateAdaptiveTDEE(dense, AdaptiveCoverage{AsOfDate: generateDate(55), WeighInDays: 16, IntakeDays: 56})
+		s.Require().NotNil(result)
+		s.InDelta(current.Confidence*math.Sqrt(16.0/56/(4.0/7)), result.Confidence, 0.01)
+	})
+
+	s.Run("missing intake days lower confidence", func() {
+		result := CalculateAdaptiveTDEE(dense, AdaptiveCoverage{AsOfDate: generateDate(55), WeighInDays: 56, IntakeDays: 24})
+		s.Require().NotNil(result)
+		s.Less(result.Confidence, current.Confidence)
+	})
+}
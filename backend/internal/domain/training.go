@@ -37,6 +37,15 @@ func ValidateTrainingSessions(sessions []TrainingSession) error {
 				return ErrInvalidPerceivedIntensity
 			}
 		}
+		if err := ValidateEnvironment(session.Environment); err != nil {
+			return err
+		}
+		if session.StartTime != "" && !isValidTimeFormat(session.StartTime) {
+			return ErrInvalidSessionStartTime
+		}
+		if session.AvgHeartRate != nil && (*session.AvgHeartRate < MinSessionHeartRate || *session.AvgHeartRate > MaxSessionHeartRate) {
+			return ErrInvalidSessionHeartRate
+		}
 	}
 
 	return nil
@@ -68,12 +77,13 @@ func SessionLoad(trainingType TrainingType, durationMin int, rpe *int) float64 {
 	return config.LoadScore * durationFactor * rpeFactor
 }
 
-// TotalSessionLoad sums RPE-weighted load scores across all sessions.
+// TotalSessionLoad sums RPE-weighted load scores across all sessions,
+// scaled by each session's environment conditions.
 // This is the single source of truth for "load of a session slice".
 func TotalSessionLoad(sessions []TrainingSession) float64 {
 	var total float64
 	for _, s := range sessions {
-		total += SessionLoad(s.Type, s.DurationMin, s.PerceivedIntensity)
+		total += SessionLoad(s.Type, s.DurationMin, s.PerceivedIntensity) * EnvironmentLoadFactor(s.Environment)
 	}
 	return total
 }
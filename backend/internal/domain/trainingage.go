@@ -0,0 +1,142 @@
+package domain
+
+import "time"
+
+// =============================================================================
+// TRAINING AGE
+// =============================================================================
+//
+// Training experience is derived from the training log instead of asking the
+// user to self-classify. Training age counts the weeks with consistent
+// training; progression velocity (personal records per month) tells a lifter
+// still making novice gains from one whose progress has slowed. The level
+// gates program recommendations and sets default RPE targets.
+
+const (
+	// ExperienceActiveWeekSessions is how many non-rest sessions make a week
+	// count towards training age.
+	ExperienceActiveWeekSessions = 2
+	// ExperienceRecentWeeks is the window for recent weekly training volume.
+	ExperienceRecentWeeks = 12
+	// ExperienceVelocityDays is the window for progression velocity.
+	ExperienceVelocityDays = 90
+	// ExperienceRefreshInterval is how long a stored experience model stays current.
+	ExperienceRefreshInterval = 24 * time.Hour
+
+	experienceBeginnerWeeks    = 26  // About 6 months of consistent training
+	experienceNoviceGainsWeeks = 52  // Fast progress within the first year still reads as beginner
+	experienceAdvancedWeeks    = 104 // About 2 years
+	// PRs per month at or above which progress counts as novice gains.
+	experienceNoviceGainsPRsPerMonth = 4.0
+	// PRs per month below which progress has slowed enough to read as advanced.
+	experienceSlowedPRsPerMonth = 2.0
+	// Mean weekly minutes an advanced trainee sustains.
+	experienceAdvancedWeeklyMin = 150.0
+)
+
+// TrainingWeek is one calendar week's logged (actual, non-rest) training.
+type TrainingWeek struct {
+	WeekStart string // Monday, YYYY-MM-DD
+	Sessions  int
+	Minutes   int
+}
+
+// TrainingExperience is the derived experience model stored on the profile.
+type TrainingExperience struct {
+	Level               ProgramDifficulty `json:"level"`
+	TrainingAgeWeeks    int               `json:"trainingAgeWeeks"`            // Weeks with at least ExperienceActiveWeekSessions sessions
+	FirstTrainingWeek   string            `json:"firstTrainingWeek,omitempty"` // Monday of the first logged training week
+	RecentWeeklyMinutes float64           `json:"recentWeeklyMinutes"`         // Mean over the last ExperienceRecentWeeks weeks
+	PRsPerMonth         float64           `json:"prsPerMonth"`                 // Progression velocity over the last ExperienceVelocityDays days
+	DefaultRPE          int               `json:"defaultRpe"`                  // RPE target for sessions planned without one
+	ComputedAt          time.Time         `json:"computedAt"`
+}
+
+// IsStale reports whether the model should be recomputed at now.
+func (e *TrainingExperience) IsStale(now time.Time) bool {
+	return now.Sub(e.ComputedAt) >= ExperienceRefreshInterval
+}
+
+// DeriveTrainingExperience builds the experience model from weekly training
+// volume and the dates personal records were set (YYYY-MM-DD).
+func DeriveTrainingExperience(weeks []TrainingWeek, recordDates []string, now time.Time) TrainingExperience {
+	exp := TrainingExperience{ComputedAt: now}
+
+	today := now.Format("2006-01-02")
+	recentStart := weekMonday(now).AddDate(0, 0, -7*(ExperienceRecentWeeks-1)).Format("2006-01-02")
+	recentMinutes := 0
+	for _, w := range weeks {
+		if w.WeekStart > today {
+			continue
+		}
+		if w.Sessions >= ExperienceActiveWeekSessions {
+			exp.TrainingAgeWeeks++
+		}
+		if w.Sessions > 0 && (exp.FirstTrainingWeek == "" || w.WeekStart < exp.FirstTrainingWeek) {
+			exp.FirstTrainingWeek = w.WeekStart
+		}
+		if w.WeekStart >= recentStart {
+			recentMinutes += w.Minutes
+		}
+	}
+	exp.RecentWeeklyMinutes = float64(recentMinutes) / ExperienceRecentWeeks
+
+	velocityStart := now.AddDate(0, 0, -ExperienceVelocityDays).Format("2006-01-02")
+	records := 0
+	for _, d := range recordDates {
+		if d > velocityStart && d <= today {
+			records++
+		}
+	}
+	exp.PRsPerMonth = float64(records) * 30 / ExperienceVelocityDays
+
+	exp.Level = experienceLevel(exp)
+	exp.DefaultRPE = ExperienceDefaultRPE(exp.Level)
+	return exp
+}
+
+func experienceLevel(exp TrainingExperience) ProgramDifficulty {
+	switch {
+	case exp.TrainingAgeWeeks < experienceBeginnerWeeks:
+		return ProgramDifficultyBeginner
+	case exp.TrainingAgeWeeks < experienceNoviceGainsWeeks && exp.PRsPerMonth >= experienceNoviceGainsPRsPerMonth:
+		return ProgramDifficultyBeginner
+	case exp.TrainingAgeWeeks >= experienceAdvancedWeeks &&
+		exp.PRsPerMonth < experienceSlowedPRsPerMonth &&
+		exp.RecentWeeklyMinutes >= experienceAdvancedWeeklyMin:
+		return ProgramDifficultyAdvanced
+	default:
+		return ProgramDifficultyIntermediate
+	}
+}
+
+// weekMonday returns the Monday of t's week at midnight.
+func weekMonday(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// ExperienceDefaultRPE is the RPE target for sessions planned without one:
+// beginners leave more in reserve while they learn the movements.
+func ExperienceDefaultRPE(level ProgramDifficulty) int {
+	switch level {
+	case ProgramDifficultyBeginner:
+		return 6
+	case ProgramDifficultyAdvanced:
+		return 8
+	default:
+		return 7
+	}
+}
+
+var difficultyRank = map[ProgramDifficulty]int{
+	ProgramDifficultyBeginner:     1,
+	ProgramDifficultyIntermediate: 2,
+	ProgramDifficultyAdvanced:     3,
+}
+
+// SuitsExperience reports whether a program of difficulty d suits a trainee
+// at level: programs above the trainee's level are held back.
+func (d ProgramDifficulty) SuitsExperience(level ProgramDifficulty) bool {
+	return difficultyRank[d] <= difficultyRank[level]
+}
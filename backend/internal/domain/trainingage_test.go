@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The derived level replaces self-classification and gates
+// which programs are recommended; tests pin the training-age and velocity
+// thresholds, the windows they are measured over, and the RPE defaults.
+type TrainingAgeSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestTrainingAgeSuite(t *testing.T) {
+	suite.Run(t, new(TrainingAgeSuite))
+}
+
+func (s *TrainingAgeSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC) // Wednesday
+}
+
+// weeks returns n consecutive training weeks ending with the current week.
+func (s *TrainingAgeSuite) weeks(n, sessions, minutes int) []TrainingWeek {
+	monday := weekMonday(s.now)
+	weeks := make([]TrainingWeek, n)
+	for i := range weeks {
+		start := monday.AddDate(0, 0, -7*(n-1-i))
+		weeks[i] = TrainingWeek{WeekStart: start.Format("2006-01-02"), Sessions: sessions, Minutes: minutes}
+	}
+	return weeks
+}
+
+// records returns n record dates spread over the velocity window.
+func (s *TrainingAgeSuite) records(n int) []string {
+	dates := make([]string, n)
+	for i := range dates {
+		dates[i] = s.now.AddDate(0, 0, -i*ExperienceVelocityDays/(n+1)).Format("2006-01-02")
+	}
+	return dates
+}
+
+func (s *TrainingAgeSuite) TestLevels() {
+	cases := []struct {
+		name    string
+		weeks   []TrainingWeek
+		records []string
+		want    ProgramDifficulty
+	}{
+		{"new to training", s.weeks(10, 3, 180), nil, ProgramDifficultyBeginner},
+		{"one session a week doesn't age", s.weeks(60, 1, 60), nil, ProgramDifficultyBeginner},
+		{"consistent for a year", s.weeks(40, 3, 180), s.records(3), ProgramDifficultyIntermediate},
+		{"novice gains within the first year", s.weeks(40, 3, 180), s.records(12), ProgramDifficultyBeginner},
+		{"years of training, progress slowed", s.weeks(120, 4, 240), s.records(3), ProgramDifficultyAdvanced},
+		{"years of training, still setting records", s.weeks(120, 4, 240), s.records(9), ProgramDifficultyIntermediate},
+		{"years of training, low recent volume", s.weeks(120, 2, 100), nil, ProgramDifficultyIntermediate},
+	}
+	for _, tc := range cases {
+		exp := DeriveTrainingExperience(tc.weeks, tc.records, s.now)
+		s.Equal(tc.want, exp.Level, tc.name)
+		s.Equal(ExperienceDefaultRPE(tc.want), exp.DefaultRPE, tc.name)
+	}
+}
+
+func (s *TrainingAgeSuite) TestWindows() {
+	weeks := append(s.weeks(30, 3, 120), TrainingWeek{WeekStart: "2026-03-09", Sessions: 5, Minutes: 500})
+	records := []string{"2025-11-01", "2026-01-15", "2026-03-04", "2026-03-05"}
+
+	exp := DeriveTrainingExperience(weeks, records, s.now)
+	s.Equal(30, exp.TrainingAgeWeeks, "future weeks are ignored")
+	s.Equal(weeks[0].WeekStart, exp.FirstTrainingWeek)
+	s.InDelta(120.0, exp.RecentWeeklyMinutes, 0.001)
+	s.InDelta(2*30.0/ExperienceVelocityDays, exp.PRsPerMonth, 0.001, "only records in the last 90 days count")
+	s.Equal(s.now, exp.ComputedAt)
+	s.False(exp.IsStale(s.now.Add(time.Hour)))
+	s.True(exp.IsStale(s.now.Add(ExperienceRefreshInterval)))
+}
+
+func (s *TrainingAgeSuite) TestSuitsExperience() {
+	s.True(ProgramDifficultyBeginner.SuitsExperience(ProgramDifficultyBeginner))
+	s.False(ProgramDifficultyIntermediate.SuitsExperience(ProgramDifficultyBeginner))
+	s.True(ProgramDifficultyIntermediate.SuitsExperience(ProgramDifficultyAdvanced))
+	s.False(ProgramDifficultyAdvanced.SuitsExperience(ProgramDifficultyIntermediate))
+	s.Equal([]int{6, 7, 8}, []int{
+		ExperienceDefaultRPE(ProgramDifficultyBeginner),
+		ExperienceDefaultRPE(ProgramDifficultyIntermediate),
+		ExperienceDefaultRPE(ProgramDifficultyAdvanced),
+	})
+}
@@ -166,6 +166,27 @@ func (w WeeklyDayPattern) GetDayType(dayNum int) DayType {
 	}
 }
 
+// SetDayType sets the day type for a given day number (1-7, Monday=1).
+// Other day numbers are ignored.
+func (w *WeeklyDayPattern) SetDayType(dayNum int, dayType DayType) {
+	switch dayNum {
+	case 1:
+		w.Day1 = dayType
+	case 2:
+		w.Day2 = dayType
+	case 3:
+		w.Day3 = dayType
+	case 4:
+		w.Day4 = dayType
+	case 5:
+		w.Day5 = dayType
+	case 6:
+		w.Day6 = dayType
+	case 7:
+		w.Day7 = dayType
+	}
+}
+
 // BMREquation represents available BMR calculation methods.
 type BMREquation string
 
@@ -305,16 +326,20 @@ type SupplementConfig struct {
 // TrainingSession represents a single training session within a day.
 // A day can have multiple sessions (e.g., morning Qigong + afternoon strength).
 type TrainingSession struct {
-	ID                 int64                 // Database ID (0 for new sessions)
-	SessionOrder       int                   // 1-based order within the day
-	IsPlanned          bool                  // true for planned, false for actual
-	IsDraft            bool                  // true for quick-submitted sessions pending echo enrichment
-	Type               TrainingType          // Type of training activity
-	DurationMin        int                   // Duration in minutes
-	PerceivedIntensity *int                  // Optional RPE 1-10
-	Notes              string                // Optional notes
-	RawEchoLog         *string               // Raw natural language echo text from user
-	ExtraMetadata      *SessionExtraMetadata // Parsed echo metadata (achievements, RPE offset, etc.)
+	ID                 int64                  // Database ID (0 for new sessions)
+	SessionOrder       int                    // 1-based order within the day
+	IsPlanned          bool                   // true for planned, false for actual
+	IsDraft            bool                   // true for quick-submitted sessions pending echo enrichment
+	Type               TrainingType           // Type of training activity
+	DurationMin        int                    // Duration in minutes
+	PerceivedIntensity *int                   // Optional RPE 1-10
+	Notes              string                 // Optional notes
+	RawEchoLog         *string                // Raw natural language echo text from user
+	ExtraMetadata      *SessionExtraMetadata  // Parsed echo metadata (achievements, RPE offset, etc.)
+	Environment        []EnvironmentCondition // Conditions for this session (the day's also apply)
+	StartTime          string                 // HH:MM the session started (actual sessions; empty = unknown)
+	AvgHeartRate       *int                   // Average HR in bpm (actual sessions; nil = unknown)
+	Archetype          Archetype              // Planned archetype, or the one fatigue was applied for (empty = unassigned)
 }
 
 // SessionExtraMetadata holds parsed data from an echo log.
@@ -504,11 +529,45 @@ type SolverIngredient struct {
 // SolverSolution represents a combination of foods that fills the macro budget.
 type SolverSolution struct {
 	Ingredients []SolverIngredient
-	TotalMacros MacroBudget         // Actual macros provided by this solution
-	MatchScore  float64             // 0-100 where 100 is perfect match
-	RecipeName  string              // Generated or fallback name
-	WhyText     string              // Explanation of why this combo works
-	Refinement  *SemanticRefinement // AI-enhanced recipe presentation (nil if not refined)
+	TotalMacros MacroBudget          // Actual macros provided by this solution
+	MatchScore  float64              // 0-100 where 100 is perfect match
+	Breakdown   SolverScoreBreakdown // How MatchScore was reached
+	RecipeName  string               // Generated or fallback name
+	WhyText     string               // Explanation of why this combo works
+	Refinement  *SemanticRefinement  // AI-enhanced recipe presentation (nil if not refined)
+	Cost        *CostEstimate        // Estimated cost (nil when no prices are entered)
+}
+
+// MacroScoreComponent is one macro's contribution to MacroAccuracy.
+type MacroScoreComponent struct {
+	Target       float64
+	Actual       float64
+	ErrorPercent float64 // |actual - target| / target, as a percent
+	Score        float64 // 0-100; reaches 0 at 50% error
+	Weight       float64 // Share of MacroAccuracy
+}
+
+// SolverScoreBreakdown explains a MatchScore:
+// Total = MacroPoints + IngredientCountPoints + FiberPoints + PreferenceBonus + SatietyBonus - AbsurdityPenalty,
+// clamped to 0-100.
+type SolverScoreBreakdown struct {
+	Calories MacroScoreComponent
+	Protein  MacroScoreComponent
+	Carbs    MacroScoreComponent
+	Fat      MacroScoreComponent
+
+	MacroAccuracy          float64 // 0-100 weighted macro score
+	MacroPoints            float64 // MacroAccuracy's 60% share of the total
+	IngredientCountPoints  float64 // Up to 20 points; full marks at 5+ ingredients
+	IngredientCountPenalty float64 // Points lost for having fewer than 5 ingredients
+	EstimatedFiberG        float64
+	FiberPoints            float64 // Up to 20 points for fiber-dense (vegetable, fruit, starch) picks
+	PreferenceBonus        float64 // -10 to +10 points from learned food preferences
+	SatietyBonus           float64 // -8 to +8 points from learned satiety (when the solver favors it)
+	Compatibility          float64 // 0-1 culinary compatibility of the worst-matched pair
+	AbsurdityCode          string  // CheckAbsurdity code, empty if none
+	AbsurdityPenalty       float64 // Points deducted when AbsurdityCode is set
+	Total                  float64
 }
 
 // SolverRequest contains input parameters for the macro solver.
@@ -519,6 +578,10 @@ type SolverRequest struct {
 	TolerancePercent float64         // Acceptable deviation from target (default 0.10)
 	PantryFoods      []FoodNutrition // Available foods to choose from
 	MealTime         string          // "breakfast", "lunch", "dinner" for category locking
+	Preferences      FoodPreferences // Learned food preferences (nil for none)
+	Satiety          *SatietyProfile // Favor filling foods (nil for none)
+	Prices           FoodPrices      // User-entered food prices (nil for none)
+	MaxCost          *float64        // Drop solutions that cost more or can't be fully priced (nil for no limit)
 }
 
 // SolverResponse contains the solver output.
@@ -551,7 +614,7 @@ type TrainingContextForSolver struct {
 // AbsurdityWarning represents a logistic alert for excessive ingredient amounts.
 // Detected by pure domain logic before being styled by Ollama.
 type AbsurdityWarning struct {
-	Code        string // Alert code: "SINGLE_LARGE", "HIGH_FIBER", "HIGH_FAT", "HIGH_PROTEIN"
+	Code        string // Alert code: "CULINARY_CLASH", "SINGLE_LARGE", "HIGH_FIBER", "HIGH_FAT", "HIGH_PROTEIN", "ODD_PAIRING"
 	Description string // Human-readable description of the concern
 	Ingredient  string // Which ingredient triggered the warning (optional)
 }
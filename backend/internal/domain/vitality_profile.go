@@ -0,0 +1,132 @@
+package domain
+
+import "math"
+
+// =============================================================================
+// VITALITY SCORING PROFILES
+// =============================================================================
+//
+// A bulking user shouldn't be scored identically to a cutting user. Each
+// profile reweights the vitality components and defines the weekly weight
+// change band that counts as "on track" for that phase.
+
+// VitalityProfileKind identifies the training phase a vitality score is judged against.
+type VitalityProfileKind string
+
+const (
+	VitalityProfileCut         VitalityProfileKind = "cut"
+	VitalityProfileBulk        VitalityProfileKind = "bulk"
+	VitalityProfileMaintenance VitalityProfileKind = "maintenance"
+)
+
+// VitalityPlanMaintenanceBandKg is the |weekly change| below which an active plan
+// is treated as maintenance rather than a cut or bulk.
+const VitalityPlanMaintenanceBandKg = 0.05
+
+// VitalityScoringProfile holds component weights (total = 100) and the trend band.
+type VitalityScoringProfile struct {
+	Kind                    VitalityProfileKind
+	MealAdherenceWeight     float64
+	TrainingAdherenceWeight float64
+	RecoveryWeight          float64
+	TrendWeight             float64
+	TargetWeeklyChangeKg    float64             // Ideal weight change per week (negative = loss)
+	TrendToleranceKg        float64             // Deviation from target that still scores 100
+	WeightSmoothing         WeightSmoothing     // Trend method for the reported trend weight (zero = EMA)
+	Tolerances              AdherenceTolerances // On-target bands for meal adherence (zero = defaults)
+	HabitAdherence          *float64            // Weekly habit completion 0-100 (nil = habits not counted)
+}
+
+// vitalityProfiles are the defaults for each phase.
+var vitalityProfiles = map[VitalityProfileKind]VitalityScoringProfile{
+	// Cutting: intake discipline carries the deficit
+	VitalityProfileCut: {
+		Kind:                    VitalityProfileCut,
+		MealAdherenceWeight:     40,
+		TrainingAdherenceWeight: 25,
+		RecoveryWeight:          20,
+		TrendWeight:             15,
+		TargetWeeklyChangeKg:    -0.5,
+		TrendToleranceKg:        0.25,
+	},
+	// Bulking: hypertrophy focus, so training consistency dominates and
+	// gaining faster than the band is penalized as excess fat gain
+	VitalityProfileBulk: {
+		Kind:                    VitalityProfileBulk,
+		MealAdherenceWeight:     25,
+		TrainingAdherenceWeight: 40,
+		RecoveryWeight:          20,
+		TrendWeight:             15,
+		TargetWeeklyChangeKg:    0.25,
+		TrendToleranceKg:        0.15,
+	},
+	VitalityProfileMaintenance: {
+		Kind:                    VitalityProfileMaintenance,
+		MealAdherenceWeight:     VitalityMealAdherenceWeight,
+		TrainingAdherenceWeight: VitalityTrainingAdherenceWeight,
+		RecoveryWeight:          VitalityRecoveryWeight,
+		TrendWeight:             VitalityTrendWeight,
+		TargetWeeklyChangeKg:    0,
+		TrendToleranceKg:        0.3,
+	},
+}
+
+// GetVitalityProfile returns the default scoring profile for a kind.
+// Unknown kinds fall back to maintenance.
+func GetVitalityProfile(kind VitalityProfileKind) VitalityScoringProfile {
+	if p, ok := vitalityProfiles[kind]; ok {
+		return p
+	}
+	return vitalityProfiles[VitalityProfileMaintenance]
+}
+
+// SelectVitalityProfile picks the scoring profile for the user.
+// An active plan takes precedence: its required weekly change sets both the
+// phase and the trend target. Otherwise the profile goal decides.
+func SelectVitalityProfile(profile *UserProfile, plan *NutritionPlan) VitalityScoringProfile {
+	if plan != nil && plan.Status == PlanStatusActive {
+		change := plan.RequiredWeeklyChangeKg
+		var scoring VitalityScoringProfile
+		switch {
+		case change < -VitalityPlanMaintenanceBandKg:
+			scoring = GetVitalityProfile(VitalityProfileCut)
+		case change > VitalityPlanMaintenanceBandKg:
+			scoring = GetVitalityProfile(VitalityProfileBulk)
+		default:
+			return GetVitalityProfile(VitalityProfileMaintenance)
+		}
+		scoring.TargetWeeklyChangeKg = change
+		return scoring
+	}
+
+	if profile == nil {
+		return GetVitalityProfile(VitalityProfileMaintenance)
+	}
+	switch profile.Goal {
+	case GoalLoseWeight:
+		return GetVitalityProfile(VitalityProfileCut)
+	case GoalGainWeight:
+		return GetVitalityProfile(VitalityProfileBulk)
+	default:
+		return GetVitalityProfile(VitalityProfileMaintenance)
+	}
+}
+
+// ScoreWeightTrend returns a 0-100 score for a weekly weight change relative to
+// the profile's target. Each tolerance width away from target drops one band.
+func (p VitalityScoringProfile) ScoreWeightTrend(weeklyChangeKg float64) float64 {
+	if p.TrendToleranceKg <= 0 {
+		return 50
+	}
+	bands := math.Abs(weeklyChangeKg-p.TargetWeeklyChangeKg) / p.TrendToleranceKg
+	switch {
+	case bands <= 1:
+		return 100
+	case bands <= 2:
+		return 75
+	case bands <= 3:
+		return 50
+	default:
+		return 25
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// VitalityProfileSuite checks that goal-specific scoring profiles judge the same
+// week differently. The cut plan mirrors docs/sample.md: a 19-week cut from 89.5kg to 81.8kg.
+type VitalityProfileSuite struct {
+	suite.Suite
+	now         time.Time
+	cutProfile  *UserProfile
+	bulkProfile *UserProfile
+	samplePlan  *NutritionPlan
+}
+
+func TestVitalityProfileSuite(t *testing.T) {
+	suite.Run(t, new(VitalityProfileSuite))
+}
+
+func (s *VitalityProfileSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.cutProfile = &UserProfile{Goal: GoalLoseWeight}
+	s.bulkProfile = &UserProfile{Goal: GoalGainWeight}
+	s.samplePlan = &NutritionPlan{
+		StartDate:              s.now,
+		StartWeightKg:          89.5,
+		GoalWeightKg:           81.8,
+		DurationWeeks:          19,
+		RequiredWeeklyChangeKg: (81.8 - 89.5) / 19, // ≈ -0.405 kg/week
+		Status:                 PlanStatusActive,
+	}
+}
+
+// weekOfLogs builds 7 days of logs moving linearly from startKg by weeklyChangeKg,
+// with perfect meal adherence and the given fraction of planned sessions completed.
+func (s *VitalityProfileSuite) weekOfLogs(startKg, weeklyChangeKg float64, completedSessions int) []DailyLog {
+	logs := make([]DailyLog, 7)
+	for i := range logs {
+		logs[i] = DailyLog{
+			Date:              s.now.AddDate(0, 0, i).Format("2006-01-02"),
+			WeightKg:          startKg + weeklyChangeKg*float64(i)/6,
+			SleepQuality:      80,
+			ConsumedCalories:  2500,
+			CalculatedTargets: DailyTargets{TotalCalories: 2500},
+		}
+		if i < 4 {
+			logs[i].PlannedSessions = []TrainingSession{{Type: TrainingTypeStrength}}
+			if i < completedSessions {
+				logs[i].ActualSessions = []TrainingSession{{Type: TrainingTypeStrength}}
+			}
+		}
+	}
+	return logs
+}
+
+func (s *VitalityProfileSuite) TestWeightsSumToHundred() {
+	for _, kind := range []VitalityProfileKind{VitalityProfileCut, VitalityProfileBulk, VitalityProfileMaintenance} {
+		p := GetVitalityProfile(kind)
+		total := p.MealAdherenceWeight + p.TrainingAdherenceWeight + p.RecoveryWeight + p.TrendWeight
+		s.InDelta(100.0, total, 0.001, "profile %s", kind)
+	}
+}
+
+func (s *VitalityProfileSuite) TestSelectVitalityProfile() {
+	s.Run("goal selects profile without a plan", func() {
+		s.Equal(VitalityProfileCut, SelectVitalityProfile(s.cutProfile, nil).Kind)
+		s.Equal(VitalityProfileBulk, SelectVitalityProfile(s.bulkProfile, nil).Kind)
+		s.Equal(VitalityProfileMaintenance, SelectVitalityProfile(&UserProfile{Goal: GoalMaintain}, nil).Kind)
+		s.Equal(VitalityProfileMaintenance, SelectVitalityProfile(nil, nil).Kind)
+	})
+
+	s.Run("active sample plan overrides goal and sets target", func() {
+		p := SelectVitalityProfile(s.bulkProfile, s.samplePlan)
+		s.Equal(VitalityProfileCut, p.Kind)
+		s.InDelta(-0.405, p.TargetWeeklyChangeKg, 0.001)
+	})
+
+	s.Run("inactive plan is ignored", func() {
+		s.samplePlan.Status = PlanStatusPaused
+		s.Equal(VitalityProfileBulk, SelectVitalityProfile(s.bulkProfile, s.samplePlan).Kind)
+	})
+
+	s.Run("near-zero plan change is maintenance", func() {
+		plan := &NutritionPlan{Status: PlanStatusActive, RequiredWeeklyChangeKg: 0.02}
+		s.Equal(VitalityProfileMaintenance, SelectVitalityProfile(s.cutProfile, plan).Kind)
+	})
+}
+
+func (s *VitalityProfileSuite) TestScoreWeightTrend() {
+	cut := GetVitalityProfile(VitalityProfileCut)
+	bulk := GetVitalityProfile(VitalityProfileBulk)
+
+	s.Run("cut rewards loss within band", func() {
+		s.Equal(100.0, cut.ScoreWeightTrend(-0.4))
+		s.Equal(75.0, cut.ScoreWeightTrend(-0.1))
+		s.Equal(25.0, cut.ScoreWeightTrend(0.5))
+	})
+
+	s.Run("cut penalizes crash loss", func() {
+		s.Less(cut.ScoreWeightTrend(-1.5), cut.ScoreWeightTrend(-0.5))
+	})
+
+	s.Run("bulk penalizes gaining too fast", func() {
+		s.Equal(100.0, bulk.ScoreWeightTrend(0.3))
+		s.Less(bulk.ScoreWeightTrend(0.8), 100.0)
+		s.Equal(25.0, bulk.ScoreWeightTrend(-0.5))
+	})
+}
+
+func (s *VitalityProfileSuite) TestSameWeekScoredByGoal() {
+	s.Run("sample cut week scores higher under cut profile", func() {
+		// Week 1 → 2 of the sample: 89.5 → 89.1kg
+		logs := s.weekOfLogs(89.5, -0.4, 4)
+		cutScore := CalculateVitalityScore(logs, nil, SelectVitalityProfile(s.cutProfile, s.samplePlan))
+		bulkScore := CalculateVitalityScore(logs, nil, SelectVitalityProfile(s.bulkProfile, nil))
+
+		s.Equal(VitalityProfileCut, cutScore.ScoringProfile)
+		s.Greater(cutScore.Overall, bulkScore.Overall)
+	})
+
+	s.Run("missed training costs more when bulking", func() {
+		full := s.weekOfLogs(80, 0.25, 4)
+		missed := s.weekOfLogs(80, 0.25, 1)
+		cut := GetVitalityProfile(VitalityProfileCut)
+		bulk := GetVitalityProfile(VitalityProfileBulk)
+
+		cutDrop := CalculateVitalityScore(full, nil, cut).Overall - CalculateVitalityScore(missed, nil, cut).Overall
+		bulkDrop := CalculateVitalityScore(full, nil, bulk).Overall - CalculateVitalityScore(missed, nil, bulk).Overall
+		s.Greater(bulkDrop, cutDrop)
+	})
+}
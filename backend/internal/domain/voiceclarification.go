@@ -0,0 +1,398 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// VOICE CLARIFICATION
+// =============================================================================
+//
+// A voice command that parses with low confidence or without a field it needs
+// isn't persisted as a partial draft. It's held as a pending clarification
+// with one question for the client ("How long was the row?"). The answer is
+// merged into the held command, and the command is persisted once nothing
+// more is missing. A low-confidence command with every field present gets a
+// yes/no confirmation instead, as does every planning command.
+
+// VoiceClarificationConfidence is the parser confidence below which a command
+// is confirmed before it's persisted.
+const VoiceClarificationConfidence = 0.6
+
+// VoiceClarificationField is the field a clarification question asks for.
+type VoiceClarificationField string
+
+const (
+	VoiceFieldActivity VoiceClarificationField = "activity"
+	VoiceFieldDuration VoiceClarificationField = "duration_min"
+	VoiceFieldFood     VoiceClarificationField = "food"
+	VoiceFieldMetric   VoiceClarificationField = "metric"
+	VoiceFieldValue    VoiceClarificationField = "value"
+	VoiceFieldDay      VoiceClarificationField = "day"
+	VoiceFieldTarget   VoiceClarificationField = "target_day"
+	VoiceFieldDayType  VoiceClarificationField = "day_type"
+	VoiceFieldConfirm  VoiceClarificationField = "confirm" // Yes/no on the whole command
+)
+
+// VoiceClarificationStatus is where a held command stands.
+type VoiceClarificationStatus string
+
+const (
+	VoiceClarificationPending   VoiceClarificationStatus = "pending"
+	VoiceClarificationResolved  VoiceClarificationStatus = "resolved"  // Complete and persisted
+	VoiceClarificationDismissed VoiceClarificationStatus = "dismissed" // Dropped, or rejected at confirmation
+)
+
+// VoiceClarification is a voice command held until the user answers the
+// question about it.
+type VoiceClarification struct {
+	ID        int64                    `json:"id"`
+	Date      string                   `json:"date"` // Log date the command applies to
+	RawInput  string                   `json:"raw_input"`
+	Command   VoiceCommandResult       `json:"command"` // With the answers merged so far
+	Field     VoiceClarificationField  `json:"field,omitempty"`
+	Question  string                   `json:"question,omitempty"`
+	Answers   []string                 `json:"answers"`
+	Status    VoiceClarificationStatus `json:"status"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+// NextVoiceClarification returns the question to ask about a parsed command:
+// its first missing critical field, or a confirmation when the parser wasn't
+// confident. Returns an empty field when the command can be persisted.
+func NextVoiceClarification(r *VoiceCommandResult) (VoiceClarificationField, string) {
+	switch r.Intent {
+	case VoiceIntentTraining:
+		if r.Training == nil || strings.TrimSpace(r.Training.Activity) == "" {
+			return VoiceFieldActivity, "What activity did you do?"
+		}
+		if r.Training.DurationMin == nil {
+			return VoiceFieldDuration, fmt.Sprintf("How long was the %s?", spokenActivity(r.Training.Activity))
+		}
+	case VoiceIntentNutrition:
+		if r.Nutrition == nil || len(r.Nutrition.Items) == 0 {
+			return VoiceFieldFood, "What did you eat?"
+		}
+		for _, item := range r.Nutrition.Items {
+			if strings.TrimSpace(item.Food) == "" {
+				return VoiceFieldFood, "What else did you eat?"
+			}
+		}
+	case VoiceIntentBiometrics:
+		if r.Biometrics == nil || strings.TrimSpace(r.Biometrics.Metric) == "" {
+			return VoiceFieldMetric, "What did you want to record: weight, sleep or how your body feels?"
+		}
+		if r.Biometrics.Value == nil && !isBodyStatusMetric(r.Biometrics.Metric) {
+			return VoiceFieldValue, fmt.Sprintf("What was your %s?", strings.ToLower(r.Biometrics.Metric))
+		}
+	case VoiceIntentPlanning:
+		p := r.Planning
+		if p == nil {
+			break
+		}
+		if p.Day == "" {
+			if p.Action == PlanningActionSetDayType {
+				return VoiceFieldDay, "Which day should I change?"
+			}
+			return VoiceFieldDay, "Which day is the session planned for now?"
+		}
+		if p.Action == PlanningActionMoveSession && p.TargetDay == "" {
+			return VoiceFieldTarget, "Which day should it move to?"
+		}
+		if p.Action == PlanningActionSetDayType && p.DayType == "" {
+			return VoiceFieldDayType, fmt.Sprintf("What kind of day should %s be: performance, fatburner or metabolize?", spokenDate(p.Day))
+		}
+		if !p.Confirmed {
+			return VoiceFieldConfirm, fmt.Sprintf("Should I %s?", p.Summary())
+		}
+	}
+	if r.Confidence < VoiceClarificationConfidence {
+		return VoiceFieldConfirm, fmt.Sprintf("Did I get that right: %s?", r.Summary())
+	}
+	return "", ""
+}
+
+// NewVoiceClarification holds a parsed command for clarification, or returns
+// nil when it can be persisted as is. A planning command's spoken days are
+// resolved against date here.
+func NewVoiceClarification(date string, r VoiceCommandResult) *VoiceClarification {
+	if r.Planning != nil {
+		planning := *r.Planning
+		planning.resolveDays(date)
+		r.Planning = &planning
+	}
+	field, question := NextVoiceClarification(&r)
+	if field == "" {
+		return nil
+	}
+	return &VoiceClarification{
+		Date:     date,
+		RawInput: r.RawInput,
+		Command:  r,
+		Field:    field,
+		Question: question,
+		Answers:  []string{},
+		Status:   VoiceClarificationPending,
+	}
+}
+
+// Answer merges the user's answer into the held command and moves on to the
+// next question, resolving the clarification when nothing is left to ask. A
+// "no" at confirmation dismisses it. Returns ErrUnclearVoiceAnswer, leaving
+// the clarification unchanged, when the answer doesn't fill in the field.
+func (c *VoiceClarification) Answer(answer string) error {
+	if c.Status != VoiceClarificationPending {
+		return ErrVoiceClarificationClosed
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return ErrUnclearVoiceAnswer
+	}
+
+	merged := c.Command
+	switch c.Field {
+	case VoiceFieldConfirm:
+		yes, ok := parseYesNo(answer)
+		if !ok {
+			return ErrUnclearVoiceAnswer
+		}
+		c.Answers = append(c.Answers, answer)
+		if !yes {
+			c.Status = VoiceClarificationDismissed
+			c.Field, c.Question = "", ""
+			return nil
+		}
+		if merged.Planning != nil {
+			planning := *merged.Planning
+			planning.Confirmed = true
+			merged.Planning = &planning
+		}
+	case VoiceFieldActivity:
+		training := TrainingVoiceData{}
+		if merged.Training != nil {
+			training = *merged.Training
+		}
+		training.Activity = answer
+		merged.Training = &training
+	case VoiceFieldDuration:
+		minutes, ok := ParseSpokenDuration(answer)
+		if !ok || merged.Training == nil {
+			return ErrUnclearVoiceAnswer
+		}
+		training := *merged.Training
+		training.DurationMin = &minutes
+		merged.Training = &training
+	case VoiceFieldFood:
+		nutrition := NutritionData{}
+		if merged.Nutrition != nil {
+			nutrition = *merged.Nutrition
+		}
+		nutrition.Items = fillFoodItem(nutrition.Items, answer)
+		merged.Nutrition = &nutrition
+	case VoiceFieldMetric:
+		biometrics := BiometricData{}
+		if merged.Biometrics != nil {
+			biometrics = *merged.Biometrics
+		}
+		biometrics.Metric = answer
+		merged.Biometrics = &biometrics
+	case VoiceFieldValue:
+		value, unit, ok := parseSpokenValue(answer)
+		if !ok || merged.Biometrics == nil {
+			return ErrUnclearVoiceAnswer
+		}
+		biometrics := *merged.Biometrics
+		biometrics.Value = &value
+		if unit != "" {
+			biometrics.Unit = &unit
+		}
+		merged.Biometrics = &biometrics
+	case VoiceFieldDay, VoiceFieldTarget:
+		date, ok := ResolveSpokenDay(answer, c.Date)
+		if !ok || merged.Planning == nil {
+			return ErrUnclearVoiceAnswer
+		}
+		planning := *merged.Planning
+		if c.Field == VoiceFieldDay {
+			planning.Day = date
+		} else {
+			planning.TargetDay = date
+		}
+		merged.Planning = &planning
+	case VoiceFieldDayType:
+		dayType, ok := parseSpokenDayType(answer)
+		if !ok || merged.Planning == nil {
+			return ErrUnclearVoiceAnswer
+		}
+		planning := *merged.Planning
+		planning.DayType = dayType
+		merged.Planning = &planning
+	}
+	if c.Field != VoiceFieldConfirm {
+		if err := ValidateVoiceCommandResult(&merged); err != nil && !isMissingVoiceData(err) {
+			return ErrUnclearVoiceAnswer
+		}
+		c.Answers = append(c.Answers, answer)
+	}
+
+	// Answering is the user confirming what they meant
+	merged.Confidence = 1
+	c.Command = merged
+	c.Field, c.Question = NextVoiceClarification(&c.Command)
+	if c.Field == "" {
+		c.Status = VoiceClarificationResolved
+	}
+	return nil
+}
+
+// Summary describes the command in a few words for a confirmation question.
+func (r *VoiceCommandResult) Summary() string {
+	switch {
+	case r.Intent == VoiceIntentTraining && r.Training != nil:
+		if r.Training.DurationMin != nil {
+			return fmt.Sprintf("%s for %d minutes", r.Training.Activity, *r.Training.DurationMin)
+		}
+		return r.Training.Activity
+	case r.Intent == VoiceIntentNutrition && r.Nutrition != nil:
+		foods := make([]string, 0, len(r.Nutrition.Items))
+		for _, item := range r.Nutrition.Items {
+			food := item.Food
+			if item.Quantity != nil {
+				food = strings.Join(strings.Fields(fmt.Sprintf("%g %s %s", *item.Quantity, derefString(item.Unit), item.Food)), " ")
+			}
+			foods = append(foods, food)
+		}
+		summary := strings.Join(foods, ", ")
+		if r.Nutrition.Meal != nil {
+			summary += " for " + string(*r.Nutrition.Meal)
+		}
+		return summary
+	case r.Intent == VoiceIntentBiometrics && r.Biometrics != nil:
+		if r.Biometrics.Value != nil {
+			return strings.TrimSpace(fmt.Sprintf("%s %g %s", r.Biometrics.Metric, *r.Biometrics.Value, derefString(r.Biometrics.Unit)))
+		}
+		if r.Biometrics.Sensation != nil {
+			return fmt.Sprintf("%s: %s", r.Biometrics.Metric, *r.Biometrics.Sensation)
+		}
+		return r.Biometrics.Metric
+	case r.Intent == VoiceIntentPlanning && r.Planning != nil:
+		return r.Planning.Summary()
+	}
+	return r.RawInput
+}
+
+var (
+	spokenDurationPart = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m)?`)
+	spokenNumber       = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+)
+
+// ParseSpokenDuration reads a duration answer such as "20", "20 minutes",
+// "1.5 hours", "1 hour 15", "1h30" or "half an hour" as whole minutes.
+// A bare number is minutes, or the minutes after an hour count.
+func ParseSpokenDuration(answer string) (int, bool) {
+	text := strings.ToLower(answer)
+	text = strings.ReplaceAll(text, "half an hour", "30 minutes")
+	text = strings.ReplaceAll(text, "an hour", "1 hour")
+	text = strings.ReplaceAll(text, "a hour", "1 hour")
+
+	var minutes float64
+	matched := false
+	for _, m := range spokenDurationPart.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		if strings.HasPrefix(m[2], "h") {
+			n *= 60
+		}
+		minutes += n
+		matched = true
+	}
+	total := int(minutes + 0.5)
+	if !matched || total < 1 || total > 480 {
+		return 0, false
+	}
+	return total, true
+}
+
+// parseSpokenValue reads a number and an optional unit word after it, as in
+// "82.5 kg" or "7 hours".
+func parseSpokenValue(answer string) (float64, string, bool) {
+	loc := spokenNumber.FindStringIndex(answer)
+	if loc == nil {
+		return 0, "", false
+	}
+	value, err := strconv.ParseFloat(answer[loc[0]:loc[1]], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	unit := ""
+	if rest := strings.Fields(answer[loc[1]:]); len(rest) > 0 {
+		unit = strings.ToLower(strings.Trim(rest[0], ".,!"))
+	}
+	return value, unit, true
+}
+
+// parseYesNo reads a confirmation answer.
+func parseYesNo(answer string) (yes bool, ok bool) {
+	word := strings.ToLower(strings.Trim(strings.Fields(answer)[0], ".,!"))
+	switch word {
+	case "yes", "yeah", "yep", "yup", "y", "correct", "right", "sure", "ok", "okay":
+		return true, true
+	case "no", "nope", "nah", "n", "wrong":
+		return false, true
+	}
+	return false, false
+}
+
+// fillFoodItem puts a food answer into the first item missing its food, or
+// adds it as a new item.
+func fillFoodItem(items []NutritionItem, food string) []NutritionItem {
+	filled := append([]NutritionItem(nil), items...)
+	for i := range filled {
+		if strings.TrimSpace(filled[i].Food) == "" {
+			filled[i].Food = food
+			return filled
+		}
+	}
+	return append(filled, NutritionItem{Food: food})
+}
+
+// spokenActivity turns an activity into the noun used in a question, so
+// "Rowing" asks "How long was the row?".
+func spokenActivity(activity string) string {
+	a := strings.ToLower(strings.TrimSpace(activity))
+	switch a {
+	case "rowing":
+		return "row"
+	case "running":
+		return "run"
+	case "walking":
+		return "walk"
+	case "cycling":
+		return "ride"
+	case "swimming":
+		return "swim"
+	}
+	return a + " session"
+}
+
+func isBodyStatusMetric(metric string) bool {
+	return strings.EqualFold(strings.TrimSpace(metric), "body status")
+}
+
+func isMissingVoiceData(err error) bool {
+	return errors.Is(err, ErrMissingVoiceData)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Clarifications decide whether a voice command is persisted,
+// held or dropped; tests pin which fields trigger a question, how spoken
+// answers are merged, and when a held command resolves.
+type VoiceClarificationSuite struct {
+	suite.Suite
+}
+
+func TestVoiceClarificationSuite(t *testing.T) {
+	suite.Run(t, new(VoiceClarificationSuite))
+}
+
+func (s *VoiceClarificationSuite) training(activity string, duration *int) VoiceCommandResult {
+	return VoiceCommandResult{
+		Intent:     VoiceIntentTraining,
+		Training:   &TrainingVoiceData{Activity: activity, DurationMin: duration},
+		RawInput:   "just did some rowing",
+		Confidence: 0.8,
+	}
+}
+
+func (s *VoiceClarificationSuite) TestCompleteCommandIsNotHeld() {
+	s.Nil(NewVoiceClarification("2026-03-10", s.training("Rowing", intPtr(20))))
+}
+
+func (s *VoiceClarificationSuite) TestMissingDurationAsksAndResolves() {
+	c := NewVoiceClarification("2026-03-10", s.training("Rowing", nil))
+	s.Require().NotNil(c)
+	s.Equal(VoiceFieldDuration, c.Field)
+	s.Equal("How long was the row?", c.Question)
+	s.Equal(VoiceClarificationPending, c.Status)
+
+	s.ErrorIs(c.Answer("a while"), ErrUnclearVoiceAnswer)
+	s.Equal(VoiceClarificationPending, c.Status, "unclear answers leave it unchanged")
+	s.Empty(c.Answers)
+
+	s.Require().NoError(c.Answer("about 25 minutes"))
+	s.Equal(VoiceClarificationResolved, c.Status)
+	s.Equal(25, *c.Command.Training.DurationMin)
+	s.Equal("Rowing", c.Command.Training.Activity)
+	s.Equal([]string{"about 25 minutes"}, c.Answers)
+	s.Empty(c.Question)
+
+	s.ErrorIs(c.Answer("30"), ErrVoiceClarificationClosed)
+}
+
+func (s *VoiceClarificationSuite) TestMissingActivityThenDuration() {
+	c := NewVoiceClarification("2026-03-10", s.training("", nil))
+	s.Require().NotNil(c)
+	s.Equal(VoiceFieldActivity, c.Field)
+
+	s.Require().NoError(c.Answer("Running"))
+	s.Equal(VoiceFieldDuration, c.Field)
+	s.Equal("How long was the run?", c.Question)
+
+	s.Require().NoError(c.Answer("half an hour"))
+	s.Equal(VoiceClarificationResolved, c.Status)
+	s.Equal(30, *c.Command.Training.DurationMin)
+}
+
+func (s *VoiceClarificationSuite) TestLowConfidenceConfirms() {
+	r := s.training("Rowing", intPtr(20))
+	r.Confidence = 0.5
+	c := NewVoiceClarification("2026-03-10", r)
+	s.Require().NotNil(c)
+	s.Equal(VoiceFieldConfirm, c.Field)
+	s.Equal("Did I get that right: Rowing for 20 minutes?", c.Question)
+
+	s.ErrorIs(c.Answer("maybe"), ErrUnclearVoiceAnswer)
+	s.Require().NoError(c.Answer("Yes."))
+	s.Equal(VoiceClarificationResolved, c.Status)
+
+	c = NewVoiceClarification("2026-03-10", r)
+	s.Require().NoError(c.Answer("no"))
+	s.Equal(VoiceClarificationDismissed, c.Status)
+}
+
+func (s *VoiceClarificationSuite) TestNutritionAndBiometrics() {
+	food := NewVoiceClarification("2026-03-10", VoiceCommandResult{Intent: VoiceIntentNutrition, Nutrition: &NutritionData{}, Confidence: 0.8})
+	s.Require().NotNil(food)
+	s.Equal(VoiceFieldFood, food.Field)
+	s.Require().NoError(food.Answer("greek yogurt"))
+	s.Equal(VoiceClarificationResolved, food.Status)
+	s.Equal("greek yogurt", food.Command.Nutrition.Items[0].Food)
+
+	weight := NewVoiceClarification("2026-03-10", VoiceCommandResult{Intent: VoiceIntentBiometrics, Biometrics: &BiometricData{Metric: "Weight"}, Confidence: 0.8})
+	s.Require().NotNil(weight)
+	s.Equal("What was your weight?", weight.Question)
+	s.Require().NoError(weight.Answer("82.5 kg"))
+	s.Equal(82.5, *weight.Command.Biometrics.Value)
+	s.Equal("kg", *weight.Command.Biometrics.Unit)
+
+	status := VoiceCommandResult{Intent: VoiceIntentBiometrics, Biometrics: &BiometricData{Metric: "Body Status"}, Confidence: 0.8}
+	s.Nil(NewVoiceClarification("2026-03-10", status), "body status notes need no value")
+}
+
+func (s *VoiceClarificationSuite) TestParseSpokenDuration() {
+	cases := map[string]int{
+		"20":            20,
+		"20 minutes":    20,
+		"45 mins":       45,
+		"1.5 hours":     90,
+		"1 hour 15":     75,
+		"1h30":          90,
+		"an hour":       60,
+		"half an hour":  30,
+		"about 40 min.": 40,
+	}
+	for answer, want := range cases {
+		got, ok := ParseSpokenDuration(answer)
+		s.True(ok, answer)
+		s.Equal(want, got, answer)
+	}
+	for _, answer := range []string{"", "a while", "0", "600 minutes"} {
+		_, ok := ParseSpokenDuration(answer)
+		s.False(ok, answer)
+	}
+}
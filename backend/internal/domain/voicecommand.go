@@ -13,6 +13,7 @@ const (
 	VoiceIntentTraining   VoiceCommandIntent = "TRAINING"
 	VoiceIntentNutrition  VoiceCommandIntent = "NUTRITION"
 	VoiceIntentBiometrics VoiceCommandIntent = "BIOMETRICS"
+	VoiceIntentPlanning   VoiceCommandIntent = "PLANNING"
 )
 
 // ValidVoiceIntents contains all valid voice command intent values.
@@ -20,6 +21,7 @@ var ValidVoiceIntents = map[VoiceCommandIntent]bool{
 	VoiceIntentTraining:   true,
 	VoiceIntentNutrition:  true,
 	VoiceIntentBiometrics: true,
+	VoiceIntentPlanning:   true,
 }
 
 // ParseVoiceIntent safely converts a string to VoiceCommandIntent with validation.
@@ -32,13 +34,14 @@ func ParseVoiceIntent(s string) (VoiceCommandIntent, error) {
 }
 
 // VoiceCommandResult represents the parsed output from a voice command.
-// Uses a polymorphic wrapper pattern where only one of Training/Nutrition/Biometrics
+// Uses a polymorphic wrapper pattern where only one of Training/Nutrition/Biometrics/Planning
 // will be populated based on the identified Intent.
 type VoiceCommandResult struct {
 	Intent     VoiceCommandIntent `json:"intent"`
 	Training   *TrainingVoiceData `json:"training_data,omitempty"`
 	Nutrition  *NutritionData     `json:"nutrition_data,omitempty"`
 	Biometrics *BiometricData     `json:"biometric_data,omitempty"`
+	Planning   *PlanningVoiceData `json:"planning_data,omitempty"`
 	ParsedAt   time.Time          `json:"parsed_at"`
 	RawInput   string             `json:"raw_input"`
 	Confidence float64            `json:"confidence"` // 0.0-1.0 parser confidence
@@ -57,6 +60,7 @@ type TrainingVoiceData struct {
 // NutritionData represents nutrition-specific data extracted from voice.
 type NutritionData struct {
 	Items []NutritionItem `json:"items"`
+	Meal  *MealName       `json:"meal,omitempty"` // nil if not mentioned (inferred from time of day)
 }
 
 // NutritionItem represents a single food item from a nutrition voice command.
@@ -127,6 +131,9 @@ func ValidateVoiceCommandResult(result *VoiceCommandResult) error {
 			return fmt.Errorf("%w: biometric metric is required", ErrMissingVoiceData)
 		}
 		// Value can be nil for body status notes (e.g., "knee feels clicky")
+
+	case VoiceIntentPlanning:
+		return validatePlanningVoiceData(result.Planning)
 	}
 
 	return nil
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// VOICE PLANNING
+// =============================================================================
+//
+// PLANNING commands change the plan rather than log what happened: "move
+// tomorrow's strength to Friday" moves planned sessions between days, "make
+// Saturday a metabolize day" sets a planned day type. Spoken days are resolved
+// against the day the command was given. A planning command is never applied
+// straight from the parser; it's always held for a yes/no confirmation that
+// names the resolved dates.
+
+// PlanningVoiceAction is the change a planning command asks for.
+type PlanningVoiceAction string
+
+const (
+	PlanningActionMoveSession PlanningVoiceAction = "move_session"
+	PlanningActionSetDayType  PlanningVoiceAction = "set_day_type"
+)
+
+// ValidPlanningVoiceActions contains all valid planning action values.
+var ValidPlanningVoiceActions = map[PlanningVoiceAction]bool{
+	PlanningActionMoveSession: true,
+	PlanningActionSetDayType:  true,
+}
+
+// PlanningVoiceData represents planning-specific data extracted from voice.
+// Days hold the spoken words ("tomorrow", "Friday") until the command is held,
+// then YYYY-MM-DD.
+type PlanningVoiceData struct {
+	Action      PlanningVoiceAction `json:"action"`
+	Day         string              `json:"day"`                    // Day the session is on, or the day to change
+	TargetDay   string              `json:"target_day,omitempty"`   // move_session: day the session moves to
+	SessionType string              `json:"session_type,omitempty"` // move_session: e.g. "strength"; empty moves every session
+	DayType     DayType             `json:"day_type,omitempty"`     // set_day_type: performance, fatburner or metabolize
+	Confirmed   bool                `json:"confirmed,omitempty"`    // User said yes to the change
+}
+
+// validatePlanningVoiceData checks a PLANNING command's data.
+func validatePlanningVoiceData(p *PlanningVoiceData) error {
+	if p == nil {
+		return fmt.Errorf("%w: PLANNING intent requires planning_data", ErrInvalidVoiceData)
+	}
+	if !ValidPlanningVoiceActions[p.Action] {
+		return fmt.Errorf("%w: planning action must be 'move_session' or 'set_day_type'", ErrInvalidVoiceData)
+	}
+	if p.Day == "" {
+		return fmt.Errorf("%w: planning day is required", ErrMissingVoiceData)
+	}
+	switch p.Action {
+	case PlanningActionMoveSession:
+		if p.TargetDay == "" {
+			return fmt.Errorf("%w: planning target_day is required to move a session", ErrMissingVoiceData)
+		}
+	case PlanningActionSetDayType:
+		if p.DayType == "" {
+			return fmt.Errorf("%w: planning day_type is required", ErrMissingVoiceData)
+		}
+		if !ValidDayTypes[p.DayType] {
+			return fmt.Errorf("%w: planning day_type must be performance, fatburner or metabolize", ErrInvalidVoiceData)
+		}
+	}
+	return nil
+}
+
+// resolveDays turns the spoken days into dates relative to today. A day that
+// can't be resolved is cleared, so the clarification asks for it.
+func (p *PlanningVoiceData) resolveDays(today string) {
+	p.Day = resolveOrClear(p.Day, today)
+	p.TargetDay = resolveOrClear(p.TargetDay, today)
+}
+
+func resolveOrClear(spoken, today string) string {
+	if spoken == "" {
+		return ""
+	}
+	date, ok := ResolveSpokenDay(spoken, today)
+	if !ok {
+		return ""
+	}
+	return date
+}
+
+// Summary describes the planning change for a confirmation question.
+func (p *PlanningVoiceData) Summary() string {
+	if p.Action == PlanningActionSetDayType {
+		return fmt.Sprintf("make %s a %s day", spokenDate(p.Day), p.DayType)
+	}
+	sessions := "the sessions"
+	if p.SessionType != "" {
+		sessions = "the " + strings.ToLower(p.SessionType) + " session"
+	}
+	return fmt.Sprintf("move %s on %s to %s", sessions, spokenDate(p.Day), spokenDate(p.TargetDay))
+}
+
+var spokenWeekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+// ResolveSpokenDay reads a spoken day such as "tomorrow", "Friday", "next
+// monday" or "2026-03-14" as a date relative to today (YYYY-MM-DD). A bare
+// weekday is the nearest one from today on; "next" skips today.
+func ResolveSpokenDay(spoken, today string) (string, bool) {
+	base, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return "", false
+	}
+	text := strings.ToLower(strings.Trim(strings.TrimSpace(spoken), ".,!?"))
+	if date, err := time.Parse("2006-01-02", text); err == nil {
+		return date.Format("2006-01-02"), true
+	}
+	text = strings.TrimSuffix(text, "'s")
+	text = strings.TrimPrefix(text, "on ")
+	text = strings.TrimPrefix(text, "the ")
+
+	switch text {
+	case "today", "tonight":
+		return today, true
+	case "tomorrow":
+		return base.AddDate(0, 0, 1).Format("2006-01-02"), true
+	case "day after tomorrow":
+		return base.AddDate(0, 0, 2).Format("2006-01-02"), true
+	case "yesterday":
+		return base.AddDate(0, 0, -1).Format("2006-01-02"), true
+	}
+
+	skipToday := false
+	for _, prefix := range []string{"this ", "coming ", "next "} {
+		if strings.HasPrefix(text, prefix) {
+			skipToday = prefix == "next "
+			text = strings.TrimPrefix(text, prefix)
+		}
+	}
+	weekday, ok := spokenWeekdays[text]
+	if !ok {
+		return "", false
+	}
+	ahead := (int(weekday) - int(base.Weekday()) + 7) % 7
+	if ahead == 0 && skipToday {
+		ahead = 7
+	}
+	return base.AddDate(0, 0, ahead).Format("2006-01-02"), true
+}
+
+// parseSpokenDayType reads a day type answer such as "metabolize" or "a fat
+// burner day".
+func parseSpokenDayType(answer string) (DayType, bool) {
+	text := strings.ReplaceAll(strings.ToLower(answer), " ", "")
+	for _, dayType := range []DayType{DayTypePerformance, DayTypeFatburner, DayTypeMetabolize} {
+		if strings.Contains(text, string(dayType)) {
+			return dayType, true
+		}
+	}
+	return "", false
+}
+
+// MovePlannedSessions takes the sessions matching the spoken session type
+// (every session when it's empty) off one day's plan and appends them to
+// another's. Returns both days' new plans and how many sessions moved; the
+// planner store renumbers session order when a day is saved.
+func MovePlannedSessions(from, to []PlannerSession, sessionType, toDate string) (fromPlan, toPlan []PlannerSession, moved int) {
+	fromPlan = make([]PlannerSession, 0, len(from))
+	toPlan = append(make([]PlannerSession, 0, len(to)+len(from)), to...)
+	for _, session := range from {
+		if !matchesSpokenSessionType(session, sessionType) {
+			fromPlan = append(fromPlan, session)
+			continue
+		}
+		session.ID = 0
+		session.Date = toDate
+		toPlan = append(toPlan, session)
+		moved++
+	}
+	return fromPlan, toPlan, moved
+}
+
+// matchesSpokenSessionType reports whether a planned session is the one the
+// user named, reusing the activity mapping voice-logged sessions go through.
+func matchesSpokenSessionType(session PlannerSession, spoken string) bool {
+	spoken = strings.TrimSpace(spoken)
+	if spoken == "" || strings.EqualFold(spoken, string(session.TrainingType)) {
+		return true
+	}
+	mapped := (&TrainingVoiceData{Activity: spoken}).ToTrainingSession(0).Type
+	return mapped != TrainingTypeMixed && mapped == session.TrainingType
+}
+
+// spokenDate formats a date for a question, as "Friday 13 March".
+func spokenDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("Monday 2 January")
+}
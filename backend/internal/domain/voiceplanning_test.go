@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Planning commands rewrite the plan, so tests pin how spoken
+// days resolve, that nothing is applied without an explicit yes, and which
+// sessions a move takes along.
+type VoicePlanningSuite struct {
+	suite.Suite
+}
+
+func TestVoicePlanningSuite(t *testing.T) {
+	suite.Run(t, new(VoicePlanningSuite))
+}
+
+func (s *VoicePlanningSuite) planning(data PlanningVoiceData) VoiceCommandResult {
+	return VoiceCommandResult{Intent: VoiceIntentPlanning, Planning: &data, Confidence: 0.8}
+}
+
+func (s *VoicePlanningSuite) TestResolveSpokenDay() {
+	// 2026-03-10 is a Tuesday
+	cases := map[string]string{
+		"today":                  "2026-03-10",
+		"tomorrow":               "2026-03-11",
+		"tomorrow's":             "2026-03-11",
+		"the day after tomorrow": "2026-03-12",
+		"Friday":                 "2026-03-13",
+		"on sat":                 "2026-03-14",
+		"this coming Monday":     "2026-03-16",
+		"tuesday":                "2026-03-10",
+		"next Tuesday":           "2026-03-17",
+		"2026-04-01":             "2026-04-01",
+	}
+	for spoken, want := range cases {
+		got, ok := ResolveSpokenDay(spoken, "2026-03-10")
+		s.True(ok, spoken)
+		s.Equal(want, got, spoken)
+	}
+	for _, spoken := range []string{"", "someday", "the 14th"} {
+		_, ok := ResolveSpokenDay(spoken, "2026-03-10")
+		s.False(ok, spoken)
+	}
+}
+
+func (s *VoicePlanningSuite) TestValidation() {
+	s.NoError(ValidateVoiceCommandResult(&VoiceCommandResult{Intent: VoiceIntentPlanning, Planning: &PlanningVoiceData{
+		Action: PlanningActionSetDayType, Day: "Saturday", DayType: DayTypeMetabolize,
+	}}))
+	s.ErrorIs(ValidateVoiceCommandResult(&VoiceCommandResult{Intent: VoiceIntentPlanning}), ErrInvalidVoiceData)
+	s.ErrorIs(ValidateVoiceCommandResult(&VoiceCommandResult{Intent: VoiceIntentPlanning, Planning: &PlanningVoiceData{
+		Action: "cancel", Day: "Saturday",
+	}}), ErrInvalidVoiceData)
+	s.ErrorIs(ValidateVoiceCommandResult(&VoiceCommandResult{Intent: VoiceIntentPlanning, Planning: &PlanningVoiceData{
+		Action: PlanningActionMoveSession, Day: "tomorrow",
+	}}), ErrMissingVoiceData)
+	s.ErrorIs(ValidateVoiceCommandResult(&VoiceCommandResult{Intent: VoiceIntentPlanning, Planning: &PlanningVoiceData{
+		Action: PlanningActionSetDayType, Day: "Saturday", DayType: "rest",
+	}}), ErrInvalidVoiceData)
+}
+
+func (s *VoicePlanningSuite) TestCompleteMoveStillNeedsConfirmation() {
+	c := NewVoiceClarification("2026-03-10", s.planning(PlanningVoiceData{
+		Action: PlanningActionMoveSession, Day: "tomorrow", TargetDay: "Friday", SessionType: "Strength",
+	}))
+	s.Require().NotNil(c, "planning commands are never applied unconfirmed")
+	s.Equal(VoiceFieldConfirm, c.Field)
+	s.Equal("Should I move the strength session on Wednesday 11 March to Friday 13 March?", c.Question)
+	s.Equal("2026-03-11", c.Command.Planning.Day)
+	s.Equal("2026-03-13", c.Command.Planning.TargetDay)
+
+	s.Require().NoError(c.Answer("yes"))
+	s.Equal(VoiceClarificationResolved, c.Status)
+	s.True(c.Command.Planning.Confirmed)
+}
+
+func (s *VoicePlanningSuite) TestRejectedChangeIsDismissed() {
+	c := NewVoiceClarification("2026-03-10", s.planning(PlanningVoiceData{
+		Action: PlanningActionSetDayType, Day: "Saturday", DayType: DayTypeMetabolize,
+	}))
+	s.Require().NotNil(c)
+	s.Equal("Should I make Saturday 14 March a metabolize day?", c.Question)
+	s.Require().NoError(c.Answer("no"))
+	s.Equal(VoiceClarificationDismissed, c.Status)
+	s.False(c.Command.Planning.Confirmed)
+}
+
+func (s *VoicePlanningSuite) TestMissingFieldsAreAskedFor() {
+	c := NewVoiceClarification("2026-03-10", s.planning(PlanningVoiceData{
+		Action: PlanningActionMoveSession, Day: "someday", SessionType: "run",
+	}))
+	s.Require().NotNil(c)
+	s.Equal(VoiceFieldDay, c.Field, "an unresolvable day is asked for again")
+
+	s.ErrorIs(c.Answer("whenever"), ErrUnclearVoiceAnswer)
+	s.Require().NoError(c.Answer("Thursday"))
+	s.Equal(VoiceFieldTarget, c.Field)
+	s.Require().NoError(c.Answer("next tuesday"))
+	s.Equal(VoiceFieldConfirm, c.Field)
+	s.Equal("Should I move the run session on Thursday 12 March to Tuesday 17 March?", c.Question)
+
+	dayType := NewVoiceClarification("2026-03-10", s.planning(PlanningVoiceData{
+		Action: PlanningActionSetDayType, Day: "sunday",
+	}))
+	s.Require().NotNil(dayType)
+	s.Equal(VoiceFieldDayType, dayType.Field)
+	s.Equal("What kind of day should Sunday 15 March be: performance, fatburner or metabolize?", dayType.Question)
+	s.ErrorIs(dayType.Answer("a rest day"), ErrUnclearVoiceAnswer)
+	s.Require().NoError(dayType.Answer("a fat burner day"))
+	s.Equal(DayTypeFatburner, dayType.Command.Planning.DayType)
+	s.Equal(VoiceFieldConfirm, dayType.Field)
+}
+
+func (s *VoicePlanningSuite) TestMovePlannedSessions() {
+	from := []PlannerSession{
+		{ID: 1, Date: "2026-03-11", SessionOrder: 1, TrainingType: TrainingTypeRun, DurationMin: 30},
+		{ID: 2, Date: "2026-03-11", SessionOrder: 2, TrainingType: TrainingTypeStrength, DurationMin: 60},
+	}
+	to := []PlannerSession{{ID: 3, Date: "2026-03-13", SessionOrder: 1, TrainingType: TrainingTypeMobility, DurationMin: 20}}
+
+	fromPlan, toPlan, moved := MovePlannedSessions(from, to, "lifting", "2026-03-13")
+	s.Equal(1, moved)
+	s.Require().Len(fromPlan, 1)
+	s.Equal(TrainingTypeRun, fromPlan[0].TrainingType)
+	s.Require().Len(toPlan, 2)
+	s.Equal(TrainingTypeStrength, toPlan[1].TrainingType)
+	s.Equal("2026-03-13", toPlan[1].Date)
+	s.Equal(60, toPlan[1].DurationMin)
+	s.Equal(int64(2), from[1].ID, "the source plan is left untouched")
+
+	fromPlan, toPlan, moved = MovePlannedSessions(from, to, "", "2026-03-13")
+	s.Equal(2, moved, "no session type moves the whole day")
+	s.Empty(fromPlan)
+	s.Len(toPlan, 3)
+
+	_, _, moved = MovePlannedSessions(from, to, "swimming", "2026-03-13")
+	s.Zero(moved)
+}
@@ -0,0 +1,180 @@
+package domain
+
+import "sort"
+
+// =============================================================================
+// WARM-UP GENERATOR
+// =============================================================================
+//
+// Builds a phase="prepare" exercise list for a session from the movement
+// catalog: easy movements matching the session's archetype, skipping anything
+// that loads a joint the fatigue map currently flags. User-pinned movements are
+// placed first when they are safe for today's joints. Movements needing
+// unavailable equipment are skipped.
+
+const (
+	// WarmupMaxDifficulty is the hardest catalog movement considered for a warm-up.
+	WarmupMaxDifficulty = 3
+	// WarmupMaxExercises caps the generated prepare block.
+	WarmupMaxExercises = 3
+	// WarmupDefaultDurationSec is the work interval for each warm-up movement.
+	WarmupDefaultDurationSec = 30
+	// WarmupProblemJointIntegrity marks a joint as a problem area below this integrity.
+	// Stricter than the movement filter's 0.5 since warm-ups should never aggravate.
+	WarmupProblemJointIntegrity = 0.7
+	// WarmupMaxProblemJointStress is the most stress a warm-up may put on a problem joint.
+	WarmupMaxProblemJointStress = 0.3
+)
+
+// warmupArchetypeCategories lists the movement categories that prime each archetype.
+var warmupArchetypeCategories = map[Archetype][]MovementCategory{
+	ArchetypePush:         {MovementCategoryPush, MovementCategoryCore},
+	ArchetypePull:         {MovementCategoryPull, MovementCategoryCore},
+	ArchetypeLegs:         {MovementCategoryLegs, MovementCategoryCore},
+	ArchetypeUpper:        {MovementCategoryPush, MovementCategoryPull},
+	ArchetypeLower:        {MovementCategoryLegs, MovementCategoryCore},
+	ArchetypeFullBody:     {MovementCategoryLocomotion, MovementCategoryLegs, MovementCategoryCore},
+	ArchetypeCardioImpact: {MovementCategoryLegs, MovementCategoryLocomotion},
+	ArchetypeCardioLow:    {MovementCategoryLocomotion, MovementCategoryCore},
+}
+
+// warmupTrainingTypeArchetypes maps scheduled training types to the archetype they resemble.
+var warmupTrainingTypeArchetypes = map[TrainingType]Archetype{
+	TrainingTypeStrength:     ArchetypeFullBody,
+	TrainingTypeCalisthenics: ArchetypeFullBody,
+	TrainingTypeGMB:          ArchetypeFullBody,
+	TrainingTypeMixed:        ArchetypeFullBody,
+	TrainingTypeHIIT:         ArchetypeCardioImpact,
+	TrainingTypeRun:          ArchetypeCardioImpact,
+	TrainingTypeRow:          ArchetypeCardioLow,
+	TrainingTypeCycle:        ArchetypeCardioLow,
+	TrainingTypeWalking:      ArchetypeCardioLow,
+}
+
+// WarmupInput holds everything needed to generate a warm-up.
+type WarmupInput struct {
+	Archetype         Archetype
+	Movements         []Movement            // Movement catalog
+	JointIntegrity    map[string]float64    // From BodyStatus (nil when unavailable)
+	PinnedMovementIDs []string              // User-preferred warm-up movements, in pin order
+	Equipment         EquipmentAvailability // Usable equipment (nil = unrestricted)
+}
+
+// WarmupArchetypeForTrainingType returns the archetype used to pick a warm-up for a
+// training type. Returns false for types that need no warm-up (rest, mobility, qigong).
+func WarmupArchetypeForTrainingType(t TrainingType) (Archetype, bool) {
+	a, ok := warmupTrainingTypeArchetypes[t]
+	return a, ok
+}
+
+// IsWarmupSafe reports whether a movement stays under the stress limit on every problem joint.
+func IsWarmupSafe(m Movement, jointIntegrity map[string]float64) bool {
+	for joint, stress := range m.JointStress {
+		integrity, ok := jointIntegrity[joint]
+		if ok && integrity < WarmupProblemJointIntegrity && stress > WarmupMaxProblemJointStress {
+			return false
+		}
+	}
+	return true
+}
+
+// GenerateWarmup builds the prepare-phase exercise list for a session.
+// Pinned movements come first (if safe), then archetype-relevant easy movements
+// ordered by difficulty and total joint stress. Returns an empty slice when
+// nothing in the catalog qualifies.
+func GenerateWarmup(input WarmupInput) []SessionExercise {
+	byID := make(map[string]Movement, len(input.Movements))
+	for _, m := range input.Movements {
+		byID[m.ID] = m
+	}
+
+	chosen := make([]string, 0, WarmupMaxExercises)
+	used := make(map[string]bool)
+
+	for _, id := range input.PinnedMovementIDs {
+		if len(chosen) == WarmupMaxExercises {
+			break
+		}
+		m, ok := byID[id]
+		if !ok || used[id] || !IsWarmupSafe(m, input.JointIntegrity) || !IsMovementAvailable(m, input.Equipment) {
+			continue
+		}
+		chosen = append(chosen, id)
+		used[id] = true
+	}
+
+	relevant := make(map[MovementCategory]bool)
+	for _, c := range warmupArchetypeCategories[input.Archetype] {
+		relevant[c] = true
+	}
+
+	candidates := make([]Movement, 0, len(input.Movements))
+	for _, m := range input.Movements {
+		if used[m.ID] || !relevant[m.Category] || m.Difficulty > WarmupMaxDifficulty {
+			continue
+		}
+		if !IsWarmupSafe(m, input.JointIntegrity) || !IsMovementAvailable(m, input.Equipment) {
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Difficulty != candidates[j].Difficulty {
+			return candidates[i].Difficulty < candidates[j].Difficulty
+		}
+		si, sj := totalJointStress(candidates[i]), totalJointStress(candidates[j])
+		if si != sj {
+			return si < sj
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	for _, m := range candidates {
+		if len(chosen) == WarmupMaxExercises {
+			break
+		}
+		chosen = append(chosen, m.ID)
+	}
+
+	exercises := make([]SessionExercise, len(chosen))
+	for i, id := range chosen {
+		exercises[i] = SessionExercise{
+			ExerciseID:  id,
+			Phase:       SessionPhasePrepare,
+			Order:       i + 1,
+			DurationSec: WarmupDefaultDurationSec,
+		}
+	}
+	return exercises
+}
+
+// AttachWarmup prepends a generated warm-up to a session's exercises.
+// Sessions that already define a prepare phase keep their own; the total
+// never exceeds MaxSessionExercises.
+func AttachWarmup(exercises, warmup []SessionExercise) []SessionExercise {
+	for _, ex := range exercises {
+		if ex.Phase == SessionPhasePrepare {
+			return exercises
+		}
+	}
+
+	room := MaxSessionExercises - len(exercises)
+	if room <= 0 || len(warmup) == 0 {
+		return exercises
+	}
+	if len(warmup) > room {
+		warmup = warmup[:room]
+	}
+
+	result := make([]SessionExercise, 0, len(warmup)+len(exercises))
+	result = append(result, warmup...)
+	return append(result, exercises...)
+}
+
+func totalJointStress(m Movement) float64 {
+	var total float64
+	for _, s := range m.JointStress {
+		total += s
+	}
+	return total
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Warm-ups run before every session; a movement that loads an
+// injured joint or an ordering bug reaches the user directly.
+type WarmupSuite struct {
+	suite.Suite
+	catalog []Movement
+}
+
+func TestWarmupSuite(t *testing.T) {
+	suite.Run(t, new(WarmupSuite))
+}
+
+func (s *WarmupSuite) SetupTest() {
+	s.catalog = SeedMovements()
+}
+
+func warmupIDs(exercises []SessionExercise) []string {
+	ids := make([]string, len(exercises))
+	for i, ex := range exercises {
+		ids[i] = ex.ExerciseID
+	}
+	return ids
+}
+
+func (s *WarmupSuite) TestGeneratesPreparePhaseForArchetype() {
+	warmup := GenerateWarmup(WarmupInput{Archetype: ArchetypePush, Movements: s.catalog})
+
+	s.Require().NotEmpty(warmup)
+	s.LessOrEqual(len(warmup), WarmupMaxExercises)
+	for i, ex := range warmup {
+		s.Equal(SessionPhasePrepare, ex.Phase)
+		s.Equal(i+1, ex.Order)
+		s.Equal(WarmupDefaultDurationSec, ex.DurationSec)
+	}
+	s.Equal([]string{"cali_plank_elbow", "cali_pushup_knees", "cali_dips_bench"}, warmupIDs(warmup))
+}
+
+func (s *WarmupSuite) TestSkipsMovementsStressingProblemJoints() {
+	warmup := GenerateWarmup(WarmupInput{
+		Archetype:      ArchetypePush,
+		Movements:      s.catalog,
+		JointIntegrity: map[string]float64{"wrist": 0.6, "shoulder": 0.4},
+	})
+
+	ids := warmupIDs(warmup)
+	s.NotContains(ids, "cali_pushup_knees", "wrist stress 0.4 on a problem wrist")
+	s.NotContains(ids, "cali_dips_bench", "shoulder stress 0.7 on a problem shoulder")
+	s.Contains(ids, "cali_plank_elbow")
+}
+
+func (s *WarmupSuite) TestHealthyJointsDoNotFilter() {
+	withIntegrity := GenerateWarmup(WarmupInput{
+		Archetype:      ArchetypePush,
+		Movements:      s.catalog,
+		JointIntegrity: map[string]float64{"wrist": 0.9, "shoulder": 1.0},
+	})
+	without := GenerateWarmup(WarmupInput{Archetype: ArchetypePush, Movements: s.catalog})
+	s.Equal(without, withIntegrity)
+}
+
+func (s *WarmupSuite) TestPinnedMovementsComeFirst() {
+	warmup := GenerateWarmup(WarmupInput{
+		Archetype:         ArchetypeLegs,
+		Movements:         s.catalog,
+		PinnedMovementIDs: []string{"gmb_bear", "missing_movement", "gmb_bear"},
+	})
+
+	ids := warmupIDs(warmup)
+	s.Require().NotEmpty(ids)
+	s.Equal("gmb_bear", ids[0], "pins override archetype and difficulty filters")
+	s.Len(ids, WarmupMaxExercises)
+	s.NotContains(ids[1:], "gmb_bear", "pins are not duplicated")
+}
+
+func (s *WarmupSuite) TestUnsafePinIsSkipped() {
+	warmup := GenerateWarmup(WarmupInput{
+		Archetype:         ArchetypeLegs,
+		Movements:         s.catalog,
+		JointIntegrity:    map[string]float64{"wrist": 0.3},
+		PinnedMovementIDs: []string{"gmb_bear"},
+	})
+	s.NotContains(warmupIDs(warmup), "gmb_bear")
+}
+
+func (s *WarmupSuite) TestSkipsMovementsNeedingMissingEquipment() {
+	warmup := GenerateWarmup(WarmupInput{
+		Archetype:         ArchetypePush,
+		Movements:         s.catalog,
+		PinnedMovementIDs: []string{"cali_rows_inv"},
+		Equipment:         EquipmentAvailability{EquipmentTypeBodyweight: true},
+	})
+
+	ids := warmupIDs(warmup)
+	s.NotContains(ids, "cali_rows_inv", "pinned movement needs a bar")
+	s.NotContains(ids, "cali_dips_bench", "needs a bench")
+	s.Contains(ids, "cali_pushup_knees")
+}
+
+func (s *WarmupSuite) TestAttachWarmup() {
+	warmup := []SessionExercise{{ExerciseID: "cali_squat_air", Phase: SessionPhasePrepare, Order: 1}}
+	main := []SessionExercise{{ExerciseID: "frogger", Phase: SessionPhasePush, Order: 1}}
+
+	s.Run("prepends when no prepare phase exists", func() {
+		result := AttachWarmup(main, warmup)
+		s.Equal([]string{"cali_squat_air", "frogger"}, warmupIDs(result))
+	})
+
+	s.Run("keeps an authored prepare phase", func() {
+		authored := append([]SessionExercise{{ExerciseID: "hip_circles", Phase: SessionPhasePrepare, Order: 1}}, main...)
+		s.Equal(authored, AttachWarmup(authored, warmup))
+	})
+
+	s.Run("respects the exercise cap", func() {
+		full := make([]SessionExercise, MaxSessionExercises)
+		s.Len(AttachWarmup(full, warmup), MaxSessionExercises)
+	})
+}
+
+func (s *WarmupSuite) TestWarmupArchetypeForTrainingType() {
+	a, ok := WarmupArchetypeForTrainingType(TrainingTypeRun)
+	s.True(ok)
+	s.Equal(ArchetypeCardioImpact, a)
+
+	_, ok = WarmupArchetypeForTrainingType(TrainingTypeRest)
+	s.False(ok)
+	_, ok = WarmupArchetypeForTrainingType(TrainingTypeMobility)
+	s.False(ok)
+}
@@ -0,0 +1,339 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// WEARABLE SYNC
+// =============================================================================
+//
+// Garmin Connect and Apple Health exports carry the same daily metrics the
+// user otherwise types in by hand: HRV, resting HR, sleep and active calories,
+// plus the day's recorded activities. A sync payload is mapped onto daily logs
+// (metrics) and actual training sessions (activities).
+//
+// Every imported record is remembered per source so re-sending an export is
+// harmless: a day whose metrics are unchanged is skipped, and an activity is
+// imported once per source ID. An activity without a source ID is keyed by
+// date, start, type and duration. Activities that match a session the day
+// already has (same training type, starting within 15 minutes, or of similar
+// length when either start is unknown) are skipped too, so the same workout
+// arriving from both sources, or already logged by hand, isn't counted twice.
+
+// WearableSource identifies the device platform an import came from.
+type WearableSource string
+
+const (
+	WearableSourceGarmin      WearableSource = "garmin"
+	WearableSourceAppleHealth WearableSource = "apple_health"
+)
+
+// ValidWearableSources lists the accepted import sources.
+var ValidWearableSources = map[WearableSource]bool{
+	WearableSourceGarmin:      true,
+	WearableSourceAppleHealth: true,
+}
+
+// WearableRecordKind is the kind of an imported record.
+type WearableRecordKind string
+
+const (
+	WearableRecordDay      WearableRecordKind = "day"      // A day's metrics
+	WearableRecordActivity WearableRecordKind = "activity" // A recorded workout
+)
+
+const (
+	// MaxWearableSyncRecords bounds days plus activities in one payload.
+	MaxWearableSyncRecords = 1000
+	// wearableStartToleranceMin is how far apart two starts can be for the
+	// same workout.
+	wearableStartToleranceMin = 15
+	// wearableDurationTolerance is the relative duration difference allowed
+	// for the same workout when a start time is unknown.
+	wearableDurationTolerance = 0.15
+)
+
+// WearableDay is one day's metrics from a device. Nil fields weren't recorded.
+type WearableDay struct {
+	Date           string   `json:"date"` // YYYY-MM-DD
+	HRVMs          *int     `json:"hrvMs,omitempty"`
+	RestingHR      *int     `json:"restingHr,omitempty"`
+	SleepHours     *float64 `json:"sleepHours,omitempty"`
+	SleepScore     *int     `json:"sleepScore,omitempty"` // 1-100
+	ActiveCalories *int     `json:"activeCalories,omitempty"`
+}
+
+// WearableActivity is one recorded workout from a device.
+type WearableActivity struct {
+	ExternalID   string `json:"externalId,omitempty"` // Source's activity ID
+	Date         string `json:"date"`                 // YYYY-MM-DD
+	StartTime    string `json:"startTime,omitempty"`  // HH:MM
+	ActivityType string `json:"activityType"`         // Source's activity name, e.g. "Running" or "HKWorkoutActivityTypeRunning"
+	DurationMin  int    `json:"durationMin"`
+	Calories     *int   `json:"calories,omitempty"`
+	AvgHeartRate *int   `json:"avgHeartRate,omitempty"`
+}
+
+// WearablePayload is an export from one source.
+type WearablePayload struct {
+	Source     WearableSource     `json:"source"`
+	Days       []WearableDay      `json:"days"`
+	Activities []WearableActivity `json:"activities"`
+}
+
+// WearableImportRecord remembers an imported record and where it came from.
+type WearableImportRecord struct {
+	Source      WearableSource
+	Kind        WearableRecordKind
+	ExternalID  string // Date for days; activity key for activities
+	Date        string
+	Fingerprint string // Imported values, to detect re-sent unchanged data
+	ImportedAt  time.Time
+}
+
+// WearableSyncResult describes what a sync imported and skipped.
+type WearableSyncResult struct {
+	Source             WearableSource `json:"source"`
+	DaysImported       int            `json:"daysImported"`
+	DaysSkipped        int            `json:"daysSkipped"` // Unchanged since the last import
+	ActivitiesImported int            `json:"activitiesImported"`
+	ActivitiesSkipped  int            `json:"activitiesSkipped"` // Already imported or already logged
+	ReconciledDates    []string       `json:"reconciledDates,omitempty"`
+	Warnings           []string       `json:"warnings,omitempty"`
+	Errors             []string       `json:"errors,omitempty"` // Records that were rejected or failed to store
+}
+
+// Validate checks the source and that the payload holds a bounded number of records.
+func (p WearablePayload) Validate() error {
+	if !ValidWearableSources[p.Source] {
+		return ErrInvalidWearableSource
+	}
+	n := len(p.Days) + len(p.Activities)
+	if n == 0 {
+		return ErrEmptyWearablePayload
+	}
+	if n > MaxWearableSyncRecords {
+		return ErrTooManyWearableRecords
+	}
+	return nil
+}
+
+// Validate checks the date and that each recorded metric is plausible.
+func (d WearableDay) Validate() error {
+	if _, err := time.Parse("2006-01-02", d.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if d.HRVMs != nil && (*d.HRVMs < 1 || *d.HRVMs > 300) {
+		return ErrInvalidWearableMetric
+	}
+	if d.RestingHR != nil && (*d.RestingHR < 20 || *d.RestingHR > 150) {
+		return ErrInvalidWearableMetric
+	}
+	if d.SleepHours != nil && (*d.SleepHours < 0 || *d.SleepHours > 24) {
+		return ErrInvalidWearableMetric
+	}
+	if d.SleepScore != nil && (*d.SleepScore < 1 || *d.SleepScore > 100) {
+		return ErrInvalidWearableMetric
+	}
+	if d.ActiveCalories != nil && (*d.ActiveCalories < 0 || *d.ActiveCalories > 10000) {
+		return ErrInvalidWearableMetric
+	}
+	return nil
+}
+
+// HasSleepData reports whether the day carries any metric stored with sleep.
+func (d WearableDay) HasSleepData() bool {
+	return d.HRVMs != nil || d.RestingHR != nil || d.SleepHours != nil || d.SleepScore != nil
+}
+
+// Fingerprint encodes the recorded metrics, so an unchanged day re-sent in a
+// later export can be skipped.
+func (d WearableDay) Fingerprint() string {
+	return strings.Join([]string{
+		fingerprintInt(d.HRVMs),
+		fingerprintInt(d.RestingHR),
+		fingerprintFloat(d.SleepHours),
+		fingerprintInt(d.SleepScore),
+		fingerprintInt(d.ActiveCalories),
+	}, "|")
+}
+
+// LateDataFields lists the recorded metrics that feed derived values.
+func (d WearableDay) LateDataFields() []LateDataField {
+	var fields []LateDataField
+	if d.HRVMs != nil {
+		fields = append(fields, LateDataHRV)
+	}
+	if d.RestingHR != nil {
+		fields = append(fields, LateDataRestingHR)
+	}
+	if d.SleepHours != nil || d.SleepScore != nil {
+		fields = append(fields, LateDataSleep)
+	}
+	if d.ActiveCalories != nil {
+		fields = append(fields, LateDataActiveCalories)
+	}
+	return fields
+}
+
+// Validate checks the date, start time, duration and heart rate.
+func (a WearableActivity) Validate() error {
+	if _, err := time.Parse("2006-01-02", a.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if a.StartTime != "" && !isValidTimeFormat(a.StartTime) {
+		return ErrInvalidSessionStartTime
+	}
+	if strings.TrimSpace(a.ActivityType) == "" {
+		return ErrInvalidWearableActivity
+	}
+	if a.DurationMin <= 0 || a.DurationMin > 480 {
+		return ErrInvalidTrainingDuration
+	}
+	if a.AvgHeartRate != nil && (*a.AvgHeartRate < MinSessionHeartRate || *a.AvgHeartRate > MaxSessionHeartRate) {
+		return ErrInvalidSessionHeartRate
+	}
+	if a.Calories != nil && (*a.Calories < 0 || *a.Calories > 10000) {
+		return ErrInvalidWearableMetric
+	}
+	return nil
+}
+
+// Key identifies the activity within its source: the source's ID when given,
+// otherwise date, start, type and duration.
+func (a WearableActivity) Key() string {
+	if a.ExternalID != "" {
+		return a.ExternalID
+	}
+	return fmt.Sprintf("%s|%s|%s|%d", a.Date, a.StartTime, a.ActivityType, a.DurationMin)
+}
+
+// Fingerprint encodes the activity's values for the import record.
+func (a WearableActivity) Fingerprint() string {
+	return fmt.Sprintf("%s|%s|%d|%s|%s",
+		a.StartTime, a.ActivityType, a.DurationMin, fingerprintInt(a.Calories), fingerprintInt(a.AvgHeartRate))
+}
+
+// ToSession converts the activity to an actual training session, its training
+// type mapped from the source's activity name.
+func (a WearableActivity) ToSession(source WearableSource) TrainingSession {
+	notes := fmt.Sprintf("Imported from %s: %s", source.Label(), a.ActivityType)
+	if a.Calories != nil {
+		notes += fmt.Sprintf(" (%d kcal)", *a.Calories)
+	}
+	return TrainingSession{
+		Type:         MapWearableActivityType(source, a.ActivityType),
+		DurationMin:  a.DurationMin,
+		Notes:        notes,
+		StartTime:    a.StartTime,
+		AvgHeartRate: a.AvgHeartRate,
+	}
+}
+
+// DuplicatesSession reports whether a session the day already has is this
+// workout: same training type, with starts within 15 minutes, or durations
+// within 15% when either start is unknown.
+func (a WearableActivity) DuplicatesSession(source WearableSource, sessions []TrainingSession) bool {
+	t := MapWearableActivityType(source, a.ActivityType)
+	for _, s := range sessions {
+		if s.IsPlanned || s.Type != t {
+			continue
+		}
+		start, ok := minuteOfDay(a.StartTime)
+		existing, existingOK := minuteOfDay(s.StartTime)
+		if ok && existingOK {
+			if abs(start-existing) <= wearableStartToleranceMin {
+				return true
+			}
+			continue
+		}
+		longer := max(a.DurationMin, s.DurationMin)
+		if longer > 0 && float64(abs(a.DurationMin-s.DurationMin)) <= wearableDurationTolerance*float64(longer) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the source's display name.
+func (s WearableSource) Label() string {
+	switch s {
+	case WearableSourceGarmin:
+		return "Garmin"
+	case WearableSourceAppleHealth:
+		return "Apple Health"
+	}
+	return string(s)
+}
+
+// AppleHealthActivityMapping maps Apple Health workout types to Victus
+// TrainingTypes. Keys drop the "HKWorkoutActivityType" prefix.
+var AppleHealthActivityMapping = map[string]TrainingType{
+	"Running":                       TrainingTypeRun,
+	"Cycling":                       TrainingTypeCycle,
+	"Walking":                       TrainingTypeWalking,
+	"Hiking":                        TrainingTypeWalking,
+	"Rowing":                        TrainingTypeRow,
+	"TraditionalStrengthTraining":   TrainingTypeStrength,
+	"FunctionalStrengthTraining":    TrainingTypeStrength,
+	"HighIntensityIntervalTraining": TrainingTypeHIIT,
+	"Yoga":                          TrainingTypeMobility,
+	"Pilates":                       TrainingTypeMobility,
+	"Flexibility":                   TrainingTypeMobility,
+	"TaiChi":                        TrainingTypeQigong,
+	"MindAndBody":                   TrainingTypeQigong,
+	"Gymnastics":                    TrainingTypeCalisthenics,
+	"CrossTraining":                 TrainingTypeMixed,
+	"Swimming":                      TrainingTypeMixed,
+}
+
+// MapWearableActivityType converts a source's activity name to a Victus
+// TrainingType. Returns TrainingTypeMixed for unknown activities.
+func MapWearableActivityType(source WearableSource, name string) TrainingType {
+	if source == WearableSourceAppleHealth {
+		if t, ok := AppleHealthActivityMapping[strings.TrimPrefix(name, "HKWorkoutActivityType")]; ok {
+			return t
+		}
+		return TrainingTypeMixed
+	}
+	return MapGarminActivityType(name)
+}
+
+// GroupWearableActivitiesByDate groups activities by date, each day's in start
+// order (unknown starts last), and returns the dates in order.
+func GroupWearableActivitiesByDate(activities []WearableActivity) ([]string, map[string][]WearableActivity) {
+	byDate := make(map[string][]WearableActivity)
+	for _, a := range activities {
+		byDate[a.Date] = append(byDate[a.Date], a)
+	}
+	dates := make([]string, 0, len(byDate))
+	for date, day := range byDate {
+		sort.SliceStable(day, func(i, j int) bool {
+			if (day[i].StartTime == "") != (day[j].StartTime == "") {
+				return day[j].StartTime == ""
+			}
+			return day[i].StartTime < day[j].StartTime
+		})
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates, byDate
+}
+
+func fingerprintInt(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+func fingerprintFloat(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *v)
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Wearable imports write into daily logs and sessions on every
+// re-sync; tests pin the validation, the fingerprints and keys that make
+// re-sent exports harmless, and cross-source duplicate detection.
+type WearableSuite struct {
+	suite.Suite
+}
+
+func TestWearableSuite(t *testing.T) {
+	suite.Run(t, new(WearableSuite))
+}
+
+func (s *WearableSuite) TestPayloadValidate() {
+	day := WearableDay{Date: "2026-03-02"}
+	s.NoError(WearablePayload{Source: WearableSourceGarmin, Days: []WearableDay{day}}.Validate())
+	s.ErrorIs(WearablePayload{Source: "fitbit", Days: []WearableDay{day}}.Validate(), ErrInvalidWearableSource)
+	s.ErrorIs(WearablePayload{Source: WearableSourceAppleHealth}.Validate(), ErrEmptyWearablePayload)
+	s.ErrorIs(WearablePayload{Source: WearableSourceGarmin, Days: make([]WearableDay, MaxWearableSyncRecords+1)}.Validate(),
+		ErrTooManyWearableRecords)
+}
+
+func (s *WearableSuite) TestDayValidate() {
+	hrv, rhr, score := 45, 52, 80
+	sleep := 7.5
+	valid := WearableDay{Date: "2026-03-02", HRVMs: &hrv, RestingHR: &rhr, SleepHours: &sleep, SleepScore: &score}
+	s.NoError(valid.Validate())
+
+	bad := valid
+	bad.Date = "2026/03/02"
+	s.ErrorIs(bad.Validate(), ErrInvalidDate)
+	bad = valid
+	tooLow := 10
+	bad.RestingHR = &tooLow
+	s.ErrorIs(bad.Validate(), ErrInvalidWearableMetric)
+	bad = valid
+	tooLong := 25.0
+	bad.SleepHours = &tooLong
+	s.ErrorIs(bad.Validate(), ErrInvalidWearableMetric)
+}
+
+func (s *WearableSuite) TestDayFingerprint() {
+	hrv, kcal := 45, 600
+	day := WearableDay{Date: "2026-03-02", HRVMs: &hrv, ActiveCalories: &kcal}
+	same := WearableDay{Date: "2026-03-02", HRVMs: &hrv, ActiveCalories: &kcal}
+	s.Equal(day.Fingerprint(), same.Fingerprint(), "re-sent unchanged day")
+
+	more := 650
+	changed := day
+	changed.ActiveCalories = &more
+	s.NotEqual(day.Fingerprint(), changed.Fingerprint())
+
+	// A metric becoming known is a change, even at zero
+	zero := 0
+	zeroKcal := WearableDay{Date: "2026-03-02", HRVMs: &hrv, ActiveCalories: &zero}
+	s.NotEqual(WearableDay{Date: "2026-03-02", HRVMs: &hrv}.Fingerprint(), zeroKcal.Fingerprint())
+
+	s.Equal([]LateDataField{LateDataHRV, LateDataActiveCalories}, day.LateDataFields())
+	s.False(WearableDay{Date: "2026-03-02", ActiveCalories: &kcal}.HasSleepData())
+	s.True(day.HasSleepData())
+}
+
+func (s *WearableSuite) TestActivityKey() {
+	a := WearableActivity{ExternalID: "12345", Date: "2026-03-02", ActivityType: "Running", DurationMin: 40}
+	s.Equal("12345", a.Key())
+
+	a.ExternalID = ""
+	a.StartTime = "07:10"
+	s.Equal("2026-03-02|07:10|Running|40", a.Key())
+}
+
+func (s *WearableSuite) TestActivityValidate() {
+	valid := WearableActivity{Date: "2026-03-02", StartTime: "07:10", ActivityType: "Running", DurationMin: 40}
+	s.NoError(valid.Validate())
+
+	bad := valid
+	bad.ActivityType = " "
+	s.ErrorIs(bad.Validate(), ErrInvalidWearableActivity)
+	bad = valid
+	bad.DurationMin = 0
+	s.ErrorIs(bad.Validate(), ErrInvalidTrainingDuration)
+	bad = valid
+	bad.StartTime = "7am"
+	s.ErrorIs(bad.Validate(), ErrInvalidSessionStartTime)
+}
+
+func (s *WearableSuite) TestMapActivityType() {
+	s.Equal(TrainingTypeRun, MapWearableActivityType(WearableSourceGarmin, "Trail Running"))
+	s.Equal(TrainingTypeStrength, MapWearableActivityType(WearableSourceGarmin, "Gimnasio y equipo de fitness"))
+	s.Equal(TrainingTypeStrength, MapWearableActivityType(WearableSourceAppleHealth, "HKWorkoutActivityTypeTraditionalStrengthTraining"))
+	s.Equal(TrainingTypeHIIT, MapWearableActivityType(WearableSourceAppleHealth, "HighIntensityIntervalTraining"))
+	s.Equal(TrainingTypeMixed, MapWearableActivityType(WearableSourceAppleHealth, "HKWorkoutActivityTypeCurling"))
+}
+
+func (s *WearableSuite) TestToSession() {
+	kcal, hr := 420, 148
+	a := WearableActivity{Date: "2026-03-02", StartTime: "07:10", ActivityType: "HKWorkoutActivityTypeRunning",
+		DurationMin: 40, Calories: &kcal, AvgHeartRate: &hr}
+	session := a.ToSession(WearableSourceAppleHealth)
+	s.Equal(TrainingTypeRun, session.Type)
+	s.Equal(40, session.DurationMin)
+	s.Equal("07:10", session.StartTime)
+	s.Equal(&hr, session.AvgHeartRate)
+	s.False(session.IsPlanned)
+	s.Equal("Imported from Apple Health: HKWorkoutActivityTypeRunning (420 kcal)", session.Notes)
+}
+
+func (s *WearableSuite) TestDuplicatesSession() {
+	run := WearableActivity{Date: "2026-03-02", StartTime: "07:10", ActivityType: "Running", DurationMin: 40}
+	logged := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 42, StartTime: "07:00"}}
+	s.True(run.DuplicatesSession(WearableSourceGarmin, logged), "same run from the other source")
+
+	later := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 40, StartTime: "18:00"}}
+	s.False(run.DuplicatesSession(WearableSourceGarmin, later), "a second run that day")
+
+	other := []TrainingSession{{Type: TrainingTypeCycle, DurationMin: 40, StartTime: "07:10"}}
+	s.False(run.DuplicatesSession(WearableSourceGarmin, other))
+
+	planned := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 40, StartTime: "07:10", IsPlanned: true}}
+	s.False(run.DuplicatesSession(WearableSourceGarmin, planned), "planned sessions aren't what was done")
+
+	// Without a start time, durations must be close
+	noStart := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 45}}
+	s.True(run.DuplicatesSession(WearableSourceGarmin, noStart))
+	short := []TrainingSession{{Type: TrainingTypeRun, DurationMin: 20}}
+	s.False(run.DuplicatesSession(WearableSourceGarmin, short))
+}
+
+func (s *WearableSuite) TestGroupByDate() {
+	dates, byDate := GroupWearableActivitiesByDate([]WearableActivity{
+		{Date: "2026-03-03", ActivityType: "Walking"},
+		{Date: "2026-03-02", ActivityType: "Cycling"},
+		{Date: "2026-03-02", StartTime: "18:00", ActivityType: "Running"},
+		{Date: "2026-03-02", StartTime: "06:30", ActivityType: "Yoga"},
+	})
+	s.Equal([]string{"2026-03-02", "2026-03-03"}, dates)
+	s.Require().Len(byDate["2026-03-02"], 3)
+	s.Equal("Yoga", byDate["2026-03-02"][0].ActivityType)
+	s.Equal("Running", byDate["2026-03-02"][1].ActivityType)
+	s.Equal("Cycling", byDate["2026-03-02"][2].ActivityType, "unknown starts go last")
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// WEEKLY TARGET EDITING & REGENERATION PREVIEW
+// =============================================================================
+//
+// Regenerating a plan (after recalibration or a profile change) rewrites every
+// week from the current one onwards. Users can preview that rewrite as a
+// per-week diff before applying it, and can pin individual weeks with manual
+// targets; pinned weeks are carried over untouched by regeneration.
+
+// WeeklyTargetMacroTolerance is how far the macros' calories may drift from the
+// edited intake (fraction of intake) before the edit is rejected as inconsistent.
+const WeeklyTargetMacroTolerance = 0.05
+
+// WeeklyTargetEdit is a manual change to one week's targets. Nil fields are unchanged.
+// Editing any value pins the week unless Pinned is explicitly false.
+type WeeklyTargetEdit struct {
+	TargetIntakeKcal *int
+	TargetCarbsG     *int
+	TargetProteinG   *int
+	TargetFatsG      *int
+	Pinned           *bool
+}
+
+// ApplyEdit validates and applies a manual edit.
+//   - Intake only: protein is kept and carbs/fats are rescaled to fit the new intake.
+//   - Macros only: intake becomes the macros' calories.
+//   - Both: the macros' calories must be within WeeklyTargetMacroTolerance of the intake.
+//
+// Intake must stay within the plan's safe deficit/surplus of the projected TDEE,
+// and weeks that have already ended cannot be edited.
+func (t *WeeklyTarget) ApplyEdit(edit WeeklyTargetEdit, now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if t.EndDate.Before(today) {
+		return ErrWeeklyTargetInPast
+	}
+
+	edited := *t
+	macrosSet := edit.TargetCarbsG != nil || edit.TargetProteinG != nil || edit.TargetFatsG != nil
+	if edit.TargetCarbsG != nil {
+		edited.TargetCarbsG = *edit.TargetCarbsG
+	}
+	if edit.TargetProteinG != nil {
+		edited.TargetProteinG = *edit.TargetProteinG
+	}
+	if edit.TargetFatsG != nil {
+		edited.TargetFatsG = *edit.TargetFatsG
+	}
+	if edited.TargetCarbsG < 0 || edited.TargetProteinG < 0 || edited.TargetFatsG < 0 {
+		return ErrInvalidWeeklyTargetMacros
+	}
+
+	switch {
+	case macrosSet && edit.TargetIntakeKcal != nil:
+		edited.TargetIntakeKcal = *edit.TargetIntakeKcal
+		if edited.TargetIntakeKcal <= 0 {
+			return ErrWeeklyTargetUnsafe
+		}
+		drift := math.Abs(float64(weeklyMacroKcal(edited)-edited.TargetIntakeKcal)) / float64(edited.TargetIntakeKcal)
+		if drift > WeeklyTargetMacroTolerance {
+			return ErrWeeklyTargetMacroMismatch
+		}
+	case macrosSet:
+		edited.TargetIntakeKcal = weeklyMacroKcal(edited)
+	case edit.TargetIntakeKcal != nil:
+		edited.TargetIntakeKcal = *edit.TargetIntakeKcal
+		flexKcal := 4*t.TargetCarbsG + 9*t.TargetFatsG
+		remaining := edited.TargetIntakeKcal - 4*t.TargetProteinG
+		if remaining <= 0 || flexKcal <= 0 {
+			return ErrWeeklyTargetMacroMismatch
+		}
+		scale := float64(remaining) / float64(flexKcal)
+		edited.TargetCarbsG = RoundInt(float64(t.TargetCarbsG) * scale)
+		edited.TargetFatsG = RoundInt(float64(t.TargetFatsG) * scale)
+	}
+
+	if edited.TargetIntakeKcal < edited.ProjectedTDEE-MaxSafeDeficitKcal ||
+		edited.TargetIntakeKcal > edited.ProjectedTDEE+MaxSafeSurplusKcal {
+		return ErrWeeklyTargetUnsafe
+	}
+
+	switch {
+	case edit.Pinned != nil:
+		edited.Pinned = *edit.Pinned
+	case macrosSet || edit.TargetIntakeKcal != nil:
+		edited.Pinned = true
+	}
+
+	*t = edited
+	return nil
+}
+
+// weeklyMacroKcal returns the calories implied by a week's macro targets.
+func weeklyMacroKcal(t WeeklyTarget) int {
+	return 4*t.TargetCarbsG + 4*t.TargetProteinG + 9*t.TargetFatsG
+}
+
+// Clone returns a copy of the plan whose weekly targets can be modified
+// without affecting the original (used for previews).
+func (p *NutritionPlan) Clone() *NutritionPlan {
+	c := *p
+	c.WeeklyTargets = append([]WeeklyTarget(nil), p.WeeklyTargets...)
+	return &c
+}
+
+// LatestActualWeight returns the most recent logged weekly weight, or the start weight.
+func (p *NutritionPlan) LatestActualWeight() float64 {
+	for i := len(p.WeeklyTargets) - 1; i >= 0; i-- {
+		if p.WeeklyTargets[i].ActualWeightKg != nil {
+			return *p.WeeklyTargets[i].ActualWeightKg
+		}
+	}
+	return p.StartWeightKg
+}
+
+// RegenerateWeeklyTargets rebuilds the plan's targets from the current week
+// using the current profile (e.g. after macro ratios or activity changed),
+// without changing the plan's goal or rate. Pinned weeks are kept.
+func RegenerateWeeklyTargets(plan *NutritionPlan, profile *UserProfile, now time.Time) []WeeklyTarget {
+	return regenerateWeeklyTargets(plan, profile, plan.LatestActualWeight(), now)
+}
+
+// WeeklyTargetChange describes how regeneration would change one week.
+type WeeklyTargetChange string
+
+const (
+	WeeklyTargetUnchanged WeeklyTargetChange = "unchanged"
+	WeeklyTargetChanged   WeeklyTargetChange = "changed"
+	WeeklyTargetAdded     WeeklyTargetChange = "added"
+	WeeklyTargetRemoved   WeeklyTargetChange = "removed"
+	WeeklyTargetKept      WeeklyTargetChange = "pinned" // Pinned week left as-is
+)
+
+// WeeklyTargetDiff compares one week before and after regeneration.
+type WeeklyTargetDiff struct {
+	WeekNumber  int
+	Change      WeeklyTargetChange
+	Before      *WeeklyTarget // nil for added weeks
+	After       *WeeklyTarget // nil for removed weeks
+	IntakeDelta int           // After - Before intake (0 for added/removed)
+}
+
+// DiffWeeklyTargets compares targets by week number.
+func DiffWeeklyTargets(before, after []WeeklyTarget) []WeeklyTargetDiff {
+	byWeek := make(map[int]*WeeklyTarget, len(before))
+	for i := range before {
+		byWeek[before[i].WeekNumber] = &before[i]
+	}
+
+	var diffs []WeeklyTargetDiff
+	seen := make(map[int]bool, len(after))
+	for i := range after {
+		a := &after[i]
+		seen[a.WeekNumber] = true
+		b, ok := byWeek[a.WeekNumber]
+		d := WeeklyTargetDiff{WeekNumber: a.WeekNumber, Before: b, After: a}
+		switch {
+		case !ok:
+			d.Change = WeeklyTargetAdded
+		case b.Pinned:
+			d.Change = WeeklyTargetKept
+		case weeklyTargetsEqual(*b, *a):
+			d.Change = WeeklyTargetUnchanged
+		default:
+			d.Change = WeeklyTargetChanged
+			d.IntakeDelta = a.TargetIntakeKcal - b.TargetIntakeKcal
+		}
+		diffs = append(diffs, d)
+	}
+	for i := range before {
+		if !seen[before[i].WeekNumber] {
+			diffs = append(diffs, WeeklyTargetDiff{
+				WeekNumber: before[i].WeekNumber,
+				Change:     WeeklyTargetRemoved,
+				Before:     &before[i],
+			})
+		}
+	}
+	return diffs
+}
+
+// weeklyTargetsEqual compares the generated values of two weeks.
+func weeklyTargetsEqual(a, b WeeklyTarget) bool {
+	return a.ProjectedWeightKg == b.ProjectedWeightKg &&
+		a.ProjectedTDEE == b.ProjectedTDEE &&
+		a.TargetIntakeKcal == b.TargetIntakeKcal &&
+		a.TargetCarbsG == b.TargetCarbsG &&
+		a.TargetProteinG == b.TargetProteinG &&
+		a.TargetFatsG == b.TargetFatsG
+}
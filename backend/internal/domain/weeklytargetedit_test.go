@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WeeklyTargetEditSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+	plan    *NutritionPlan
+}
+
+func TestWeeklyTargetEditSuite(t *testing.T) {
+	suite.Run(t, new(WeeklyTargetEditSuite))
+}
+
+func (s *WeeklyTargetEditSuite) SetupTest() {
+	s.now = time.Date(2026, 1, 24, 12, 0, 0, 0, time.UTC)
+	s.profile = &UserProfile{
+		HeightCM:     180,
+		BirthDate:    time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC),
+		Sex:          SexMale,
+		Goal:         GoalLoseWeight,
+		CarbRatio:    0.45,
+		ProteinRatio: 0.30,
+		FatRatio:     0.25,
+		BMREquation:  BMREquationMifflinStJeor,
+	}
+	plan, err := NewNutritionPlan(NutritionPlanInput{
+		StartDate:     s.now.Format("2006-01-02"),
+		StartWeightKg: 90.0,
+		GoalWeightKg:  80.0,
+		DurationWeeks: 20,
+	}, s.profile, s.now)
+	s.Require().NoError(err)
+	s.plan = plan
+}
+
+func (s *WeeklyTargetEditSuite) TestIntakeOnlyKeepsProteinAndPins() {
+	week := s.plan.WeeklyTargets[2]
+	newIntake := week.TargetIntakeKcal - 200
+
+	s.Require().NoError(week.ApplyEdit(WeeklyTargetEdit{TargetIntakeKcal: &newIntake}, s.now))
+
+	s.Equal(newIntake, week.TargetIntakeKcal)
+	s.Equal(s.plan.WeeklyTargets[2].TargetProteinG, week.TargetProteinG)
+	s.Less(week.TargetCarbsG, s.plan.WeeklyTargets[2].TargetCarbsG)
+	s.InDelta(newIntake, weeklyMacroKcal(week), 10)
+	s.True(week.Pinned)
+}
+
+func (s *WeeklyTargetEditSuite) TestMacrosOnlyDeriveIntake() {
+	week := s.plan.WeeklyTargets[2]
+	carbs := week.TargetCarbsG - 20
+
+	s.Require().NoError(week.ApplyEdit(WeeklyTargetEdit{TargetCarbsG: &carbs}, s.now))
+
+	s.Equal(weeklyMacroKcal(week), week.TargetIntakeKcal)
+}
+
+func (s *WeeklyTargetEditSuite) TestRejectsInconsistentOrUnsafeEdits() {
+	week := s.plan.WeeklyTargets[2]
+
+	s.ErrorIs(week.ApplyEdit(WeeklyTargetEdit{TargetIntakeKcal: intPtr(week.TargetIntakeKcal), TargetCarbsG: intPtr(0)}, s.now), ErrWeeklyTargetMacroMismatch)
+	s.ErrorIs(week.ApplyEdit(WeeklyTargetEdit{TargetFatsG: intPtr(-1)}, s.now), ErrInvalidWeeklyTargetMacros)
+	s.ErrorIs(week.ApplyEdit(WeeklyTargetEdit{TargetIntakeKcal: intPtr(week.ProjectedTDEE - MaxSafeDeficitKcal - 50)}, s.now), ErrWeeklyTargetUnsafe)
+	s.ErrorIs(week.ApplyEdit(WeeklyTargetEdit{TargetIntakeKcal: intPtr(week.ProjectedTDEE + MaxSafeSurplusKcal + 50)}, s.now), ErrWeeklyTargetUnsafe)
+	s.Equal(s.plan.WeeklyTargets[2], week, "rejected edits leave the week untouched")
+
+	past := s.plan.WeeklyTargets[0]
+	s.ErrorIs(past.ApplyEdit(WeeklyTargetEdit{Pinned: new(bool)}, s.now.AddDate(0, 0, 14)), ErrWeeklyTargetInPast)
+}
+
+func (s *WeeklyTargetEditSuite) TestRegenerationKeepsPinnedWeeks() {
+	pinned := &s.plan.WeeklyTargets[3]
+	s.Require().NoError(pinned.ApplyEdit(WeeklyTargetEdit{TargetIntakeKcal: intPtr(pinned.TargetIntakeKcal - 150)}, s.now))
+	before := append([]WeeklyTarget(nil), s.plan.WeeklyTargets...)
+
+	updated, err := ApplyRecalibration(s.plan.Clone(), s.profile, RecalibrationExtendTimeline, s.now.AddDate(0, 0, 7))
+	s.Require().NoError(err)
+
+	s.Equal(before[3], updated.WeeklyTargets[3])
+	diffs := DiffWeeklyTargets(before, updated.WeeklyTargets)
+	s.Equal(WeeklyTargetKept, diffs[3].Change)
+	s.Equal(before, s.plan.WeeklyTargets, "preview works on a clone")
+}
+
+func (s *WeeklyTargetEditSuite) TestDiffWeeklyTargets() {
+	before := s.plan.WeeklyTargets[:3]
+	after := append([]WeeklyTarget(nil), s.plan.WeeklyTargets[:2]...)
+	after[1].TargetIntakeKcal += 100
+	after = append(after, WeeklyTarget{WeekNumber: 4})
+
+	diffs := DiffWeeklyTargets(before, after)
+
+	s.Require().Len(diffs, 4)
+	s.Equal(WeeklyTargetUnchanged, diffs[0].Change)
+	s.Equal(WeeklyTargetChanged, diffs[1].Change)
+	s.Equal(100, diffs[1].IntakeDelta)
+	s.Equal(WeeklyTargetAdded, diffs[2].Change)
+	s.Equal(WeeklyTargetRemoved, diffs[3].Change)
+	s.Equal(3, diffs[3].WeekNumber)
+}
+
+func (s *WeeklyTargetEditSuite) TestRegenerateFromProfile() {
+	s.profile.ProteinRatio, s.profile.CarbRatio = 0.40, 0.35
+
+	targets := RegenerateWeeklyTargets(s.plan.Clone(), s.profile, s.now)
+
+	s.Len(targets, len(s.plan.WeeklyTargets))
+	s.Greater(targets[0].TargetProteinG, s.plan.WeeklyTargets[0].TargetProteinG)
+}
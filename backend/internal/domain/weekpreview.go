@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// WEEKLY PLANNING PREVIEW
+// =============================================================================
+//
+// A forward-looking debrief for the coming week: projected daily targets per
+// day type, scheduled sessions with expected load, the ACR trajectory those
+// sessions produce, and conflicts the user can fix before Monday.
+
+// WeekPreviewSessionSource identifies where a previewed session came from.
+type WeekPreviewSessionSource string
+
+const (
+	WeekPreviewSourcePlanner WeekPreviewSessionSource = "planner" // Ad-hoc workout planner session
+	WeekPreviewSourceProgram WeekPreviewSessionSource = "program" // Active program installation
+)
+
+// WeekPreviewDayTypeSource identifies how a previewed day's type was chosen.
+type WeekPreviewDayTypeSource string
+
+const (
+	WeekPreviewDayTypePlanned WeekPreviewDayTypeSource = "planned" // Explicit planned day type
+	WeekPreviewDayTypeProgram WeekPreviewDayTypeSource = "program" // Program day's nutrition strategy
+	WeekPreviewDayTypeDefault WeekPreviewDayTypeSource = "default" // Inferred from sessions
+)
+
+// WeekPreviewConflictType classifies a planning conflict.
+type WeekPreviewConflictType string
+
+const (
+	WeekPreviewConflictUnderfueled  WeekPreviewConflictType = "underfueled_training" // Hard training on a fatburner day
+	WeekPreviewConflictUnusedFuel   WeekPreviewConflictType = "unused_fuel"          // Performance day without training
+	WeekPreviewConflictDoubleBooked WeekPreviewConflictType = "double_booked"        // Planner and program sessions on the same day
+	WeekPreviewConflictLoadSpike    WeekPreviewConflictType = "load_spike"           // Projected ACR above the high zone
+	WeekPreviewConflictNoRecovery   WeekPreviewConflictType = "no_recovery"          // Too many consecutive hard days
+	WeekPreviewConflictDoubleDay    WeekPreviewConflictType = "double_day"           // Two hard sessions exceed the daily ceiling
+)
+
+const (
+	// WeekPreviewHardDayLoad is the daily load at or above which a day counts as hard.
+	// A 60-minute strength session at RPE 5 scores ~8.3.
+	WeekPreviewHardDayLoad = 8.0
+	// WeekPreviewMaxConsecutiveHardDays is the longest run of hard days before flagging.
+	WeekPreviewMaxConsecutiveHardDays = 3
+)
+
+// WeekPreviewSession is a session scheduled for a previewed day.
+type WeekPreviewSession struct {
+	Label        string                   `json:"label,omitempty"`
+	TrainingType TrainingType             `json:"trainingType"`
+	DurationMin  int                      `json:"durationMin"`
+	RPE          *int                     `json:"rpe,omitempty"`
+	ExpectedLoad float64                  `json:"expectedLoad"`
+	Source       WeekPreviewSessionSource `json:"source"`
+}
+
+// WeekPreviewDayInput is the raw schedule for one day of the preview.
+type WeekPreviewDayInput struct {
+	Date           string
+	PlannedDayType *DayType // Explicit planned day type, if set
+	ProgramDayType *DayType // Nutrition day from the program, if scheduled
+	Sessions       []WeekPreviewSession
+}
+
+// WeekPreviewDay is the projected plan for a single day.
+type WeekPreviewDay struct {
+	Date          string                   `json:"date"`
+	DayName       string                   `json:"dayName"`
+	DayType       DayType                  `json:"dayType"`
+	DayTypeSource WeekPreviewDayTypeSource `json:"dayTypeSource"`
+	Targets       DailyTargets             `json:"targets"`
+	Sessions      []WeekPreviewSession     `json:"sessions"`
+	ExpectedLoad  float64                  `json:"expectedLoad"`
+	ProjectedACR  float64                  `json:"projectedAcr"`
+	DoubleDay     *DoubleDayWarning        `json:"doubleDay,omitempty"`
+}
+
+// WeekPreviewConflict is a planning issue the user may want to resolve.
+type WeekPreviewConflict struct {
+	Date    string                  `json:"date"`
+	Type    WeekPreviewConflictType `json:"type"`
+	Message string                  `json:"message"`
+}
+
+// WeekPreview is the forward-looking summary of the coming week.
+type WeekPreview struct {
+	WeekStartDate string                `json:"weekStartDate"`
+	WeekEndDate   string                `json:"weekEndDate"`
+	WeightKg      float64               `json:"weightKg"` // Weight used for target projection
+	Days          []WeekPreviewDay      `json:"days"`
+	TotalLoad     float64               `json:"totalLoad"`
+	PeakACR       float64               `json:"peakAcr"`
+	Conflicts     []WeekPreviewConflict `json:"conflicts"`
+}
+
+// ResolvePreviewDayType picks the day type for a previewed day.
+// Explicit plans win, then the program's nutrition day, then training presence.
+func ResolvePreviewDayType(in WeekPreviewDayInput) (DayType, WeekPreviewDayTypeSource) {
+	if in.PlannedDayType != nil {
+		return *in.PlannedDayType, WeekPreviewDayTypePlanned
+	}
+	if in.ProgramDayType != nil && *in.ProgramDayType != "" {
+		return *in.ProgramDayType, WeekPreviewDayTypeProgram
+	}
+	for _, s := range in.Sessions {
+		if s.TrainingType != TrainingTypeRest {
+			return DayTypePerformance, WeekPreviewDayTypeDefault
+		}
+	}
+	return DayTypeFatburner, WeekPreviewDayTypeDefault
+}
+
+// BuildWeekPreview projects targets, load, and conflicts for the given days.
+// history holds past daily loads (oldest first) used to seed the ACR trajectory.
+func BuildWeekPreview(profile *UserProfile, weightKg float64, days []WeekPreviewDayInput, history []DailyLoadDataPoint, now time.Time) WeekPreview {
+	preview := WeekPreview{
+		WeightKg:  weightKg,
+		Days:      make([]WeekPreviewDay, 0, len(days)),
+		Conflicts: make([]WeekPreviewConflict, 0),
+	}
+	if len(days) == 0 {
+		return preview
+	}
+	preview.WeekStartDate = days[0].Date
+	preview.WeekEndDate = days[len(days)-1].Date
+
+	loadSeries := append([]DailyLoadDataPoint(nil), history...)
+	hardStreak := 0
+
+	for _, in := range days {
+		dayType, source := ResolvePreviewDayType(in)
+
+		sessions := make([]TrainingSession, 0, len(in.Sessions))
+		hasPlanner, hasProgram := false, false
+		var dayLoad float64
+		for _, ps := range in.Sessions {
+			sessions = append(sessions, TrainingSession{
+				IsPlanned:          true,
+				Type:               ps.TrainingType,
+				DurationMin:        ps.DurationMin,
+				PerceivedIntensity: ps.RPE,
+			})
+			dayLoad += ps.ExpectedLoad
+			switch ps.Source {
+			case WeekPreviewSourcePlanner:
+				hasPlanner = true
+			case WeekPreviewSourceProgram:
+				hasProgram = true
+			}
+		}
+
+		targets := CalculateDailyTargets(profile, &DailyLog{
+			Date:            in.Date,
+			WeightKg:        weightKg,
+			DayType:         dayType,
+			PlannedSessions: sessions,
+		}, now)
+
+		loadSeries = append(loadSeries, DailyLoadDataPoint{Date: in.Date, DailyLoad: dayLoad})
+		acr := CalculateACR(CalculateAcuteLoad(loadSeries), CalculateChronicLoad(loadSeries))
+
+		dayName := in.Date
+		if d, err := time.Parse("2006-01-02", in.Date); err == nil {
+			dayName = d.Weekday().String()
+		}
+
+		day := WeekPreviewDay{
+			Date:          in.Date,
+			DayName:       dayName,
+			DayType:       dayType,
+			DayTypeSource: source,
+			Targets:       targets,
+			Sessions:      in.Sessions,
+			ExpectedLoad:  math.Round(dayLoad*10) / 10,
+			ProjectedACR:  math.Round(acr*100) / 100,
+			DoubleDay:     EvaluateDoubleDay(in.Date, sessions, ""), // Readiness is unknown for future days
+		}
+		if day.Sessions == nil {
+			day.Sessions = make([]WeekPreviewSession, 0)
+		}
+		preview.Days = append(preview.Days, day)
+		preview.TotalLoad += dayLoad
+		preview.PeakACR = math.Max(preview.PeakACR, day.ProjectedACR)
+
+		// Conflicts
+		isTraining := HasNonRestSession(sessions)
+		if dayType == DayTypeFatburner && dayLoad >= WeekPreviewHardDayLoad {
+			preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+				Date: in.Date, Type: WeekPreviewConflictUnderfueled,
+				Message: "Hard training scheduled on a fatburner day — consider a performance day",
+			})
+		}
+		if dayType == DayTypePerformance && !isTraining {
+			preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+				Date: in.Date, Type: WeekPreviewConflictUnusedFuel,
+				Message: "Performance day with no training scheduled",
+			})
+		}
+		if hasPlanner && hasProgram {
+			preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+				Date: in.Date, Type: WeekPreviewConflictDoubleBooked,
+				Message: "Planner sessions overlap with a program session",
+			})
+		}
+		if acr > ACRHighUpper {
+			preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+				Date: in.Date, Type: WeekPreviewConflictLoadSpike,
+				Message: "Projected acute:chronic load ratio exceeds " + formatFloat(ACRHighUpper),
+			})
+		}
+
+		if dayLoad >= WeekPreviewHardDayLoad {
+			hardStreak++
+			if hardStreak == WeekPreviewMaxConsecutiveHardDays+1 {
+				preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+					Date: in.Date, Type: WeekPreviewConflictNoRecovery,
+					Message: "More than " + itoa(WeekPreviewMaxConsecutiveHardDays) + " hard days in a row without recovery",
+				})
+			}
+		} else {
+			hardStreak = 0
+		}
+	}
+
+	// Double days need the whole week's loads to suggest where to move a session
+	weekLoads := loadSeries[len(history):]
+	for i := range preview.Days {
+		day := &preview.Days[i]
+		if day.DoubleDay == nil {
+			continue
+		}
+		movedLoad := day.Sessions[day.DoubleDay.MoveSessionIndex].ExpectedLoad
+		day.DoubleDay.SuggestedMoveDate = SuggestDoubleDayMove(day.DoubleDay, movedLoad, weekLoads)
+
+		message := day.DoubleDay.Message
+		if day.DoubleDay.SuggestedMoveDate != "" {
+			message += " (e.g. " + day.DoubleDay.SuggestedMoveDate + ")"
+		}
+		preview.Conflicts = append(preview.Conflicts, WeekPreviewConflict{
+			Date: day.Date, Type: WeekPreviewConflictDoubleDay, Message: message,
+		})
+	}
+	sort.SliceStable(preview.Conflicts, func(i, j int) bool {
+		return preview.Conflicts[i].Date < preview.Conflicts[j].Date
+	})
+
+	preview.TotalLoad = math.Round(preview.TotalLoad*10) / 10
+	return preview
+}
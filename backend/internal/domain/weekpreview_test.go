@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WeekPreviewSuite struct {
+	suite.Suite
+	now     time.Time
+	profile *UserProfile
+}
+
+func TestWeekPreviewSuite(t *testing.T) {
+	suite.Run(t, new(WeekPreviewSuite))
+}
+
+func (s *WeekPreviewSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 5, 18, 0, 0, 0, time.UTC) // Sunday
+	s.profile = &UserProfile{
+		HeightCM:      180,
+		BirthDate:     time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sex:           SexMale,
+		Goal:          GoalLoseWeight,
+		CarbRatio:     0.45,
+		ProteinRatio:  0.30,
+		FatRatio:      0.25,
+		MealRatios:    MealRatios{Breakfast: 0.30, Lunch: 0.30, Dinner: 0.40},
+		PointsConfig:  PointsConfig{CarbMultiplier: 1.15, ProteinMultiplier: 4.35, FatMultiplier: 3.5},
+		FruitTargetG:  600,
+		VeggieTargetG: 500,
+	}
+}
+
+// week returns 7 empty day inputs starting Monday 2025-01-06.
+func (s *WeekPreviewSuite) week() []WeekPreviewDayInput {
+	days := make([]WeekPreviewDayInput, 7)
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	for i := range days {
+		days[i].Date = start.AddDate(0, 0, i).Format("2006-01-02")
+	}
+	return days
+}
+
+func strengthSession(source WeekPreviewSessionSource) WeekPreviewSession {
+	return WeekPreviewSession{
+		TrainingType: TrainingTypeStrength,
+		DurationMin:  60,
+		ExpectedLoad: SessionLoad(TrainingTypeStrength, 60, nil),
+		Source:       source,
+	}
+}
+
+func (s *WeekPreviewSuite) TestResolvePreviewDayType() {
+	performance := DayTypePerformance
+	metabolize := DayTypeMetabolize
+
+	s.Run("planned day type wins over program", func() {
+		dt, src := ResolvePreviewDayType(WeekPreviewDayInput{PlannedDayType: &metabolize, ProgramDayType: &performance})
+		s.Equal(DayTypeMetabolize, dt)
+		s.Equal(WeekPreviewDayTypePlanned, src)
+	})
+
+	s.Run("program nutrition day used when not planned", func() {
+		dt, src := ResolvePreviewDayType(WeekPreviewDayInput{ProgramDayType: &performance})
+		s.Equal(DayTypePerformance, dt)
+		s.Equal(WeekPreviewDayTypeProgram, src)
+	})
+
+	s.Run("defaults follow training presence", func() {
+		dt, _ := ResolvePreviewDayType(WeekPreviewDayInput{Sessions: []WeekPreviewSession{strengthSession(WeekPreviewSourcePlanner)}})
+		s.Equal(DayTypePerformance, dt)
+		dt, src := ResolvePreviewDayType(WeekPreviewDayInput{})
+		s.Equal(DayTypeFatburner, dt)
+		s.Equal(WeekPreviewDayTypeDefault, src)
+	})
+}
+
+func (s *WeekPreviewSuite) TestBuildWeekPreview() {
+	s.Run("projects targets and load per day", func() {
+		days := s.week()
+		days[0].Sessions = []WeekPreviewSession{strengthSession(WeekPreviewSourceProgram)}
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+
+		s.Equal("2025-01-06", preview.WeekStartDate)
+		s.Equal("2025-01-12", preview.WeekEndDate)
+		s.Require().Len(preview.Days, 7)
+		s.Equal("Monday", preview.Days[0].DayName)
+		s.Equal(DayTypePerformance, preview.Days[0].DayType)
+		s.Greater(preview.Days[0].Targets.TotalCalories, preview.Days[1].Targets.TotalCalories)
+		s.InDelta(8.3, preview.Days[0].ExpectedLoad, 0.05)
+		s.Empty(preview.Conflicts)
+	})
+
+	s.Run("flags hard training on fatburner day", func() {
+		days := s.week()
+		fatburner := DayTypeFatburner
+		days[2].PlannedDayType = &fatburner
+		days[2].Sessions = []WeekPreviewSession{strengthSession(WeekPreviewSourcePlanner)}
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+		s.Require().NotEmpty(preview.Conflicts)
+		s.Equal(WeekPreviewConflictUnderfueled, preview.Conflicts[0].Type)
+		s.Equal("2025-01-08", preview.Conflicts[0].Date)
+	})
+
+	s.Run("flags performance day without training", func() {
+		days := s.week()
+		performance := DayTypePerformance
+		days[4].PlannedDayType = &performance
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+		s.Require().Len(preview.Conflicts, 1)
+		s.Equal(WeekPreviewConflictUnusedFuel, preview.Conflicts[0].Type)
+	})
+
+	s.Run("flags planner and program double booking", func() {
+		days := s.week()
+		days[1].Sessions = []WeekPreviewSession{
+			{TrainingType: TrainingTypeWalking, DurationMin: 30, Source: WeekPreviewSourcePlanner},
+			{TrainingType: TrainingTypeMobility, DurationMin: 20, Source: WeekPreviewSourceProgram},
+		}
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+		s.Require().Len(preview.Conflicts, 1)
+		s.Equal(WeekPreviewConflictDoubleBooked, preview.Conflicts[0].Type)
+	})
+
+	s.Run("flags consecutive hard days once", func() {
+		days := s.week()
+		for i := 0; i < 5; i++ {
+			days[i].Sessions = []WeekPreviewSession{strengthSession(WeekPreviewSourceProgram)}
+		}
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+		count := 0
+		for _, c := range preview.Conflicts {
+			if c.Type == WeekPreviewConflictNoRecovery {
+				count++
+				s.Equal("2025-01-09", c.Date)
+			}
+		}
+		s.Equal(1, count)
+	})
+
+	s.Run("flags double day and suggests a lighter day", func() {
+		days := s.week()
+		days[0].Sessions = []WeekPreviewSession{strengthSession(WeekPreviewSourceProgram)}
+		days[1].Sessions = []WeekPreviewSession{
+			strengthSession(WeekPreviewSourcePlanner),
+			strengthSession(WeekPreviewSourcePlanner),
+		}
+
+		preview := BuildWeekPreview(s.profile, 85, days, nil, s.now)
+		s.Require().NotNil(preview.Days[1].DoubleDay)
+		s.Equal(1, preview.Days[1].DoubleDay.MoveSessionIndex)
+		s.Equal("2025-01-08", preview.Days[1].DoubleDay.SuggestedMoveDate)
+
+		var found bool
+		for _, c := range preview.Conflicts {
+			if c.Type == WeekPreviewConflictDoubleDay {
+				found = true
+				s.Equal("2025-01-07", c.Date)
+				s.Contains(c.Message, "2025-01-08")
+			}
+		}
+		s.True(found)
+		s.IsNonDecreasing(conflictDates(preview.Conflicts))
+	})
+
+	s.Run("projects load spike against light history", func() {
+		history := make([]DailyLoadDataPoint, 28)
+		for i := range history {
+			history[i] = DailyLoadDataPoint{Date: "2024-12-" + itoa(i+1), DailyLoad: 1}
+		}
+		days := s.week()
+		for i := range days {
+			days[i].Sessions = []WeekPreviewSession{strengthSession(WeekPreviewSourceProgram)}
+		}
+
+		preview := BuildWeekPreview(s.profile, 85, days, history, s.now)
+		s.Greater(preview.PeakACR, ACRHighUpper)
+		spikes := 0
+		for _, c := range preview.Conflicts {
+			if c.Type == WeekPreviewConflictLoadSpike {
+				spikes++
+			}
+		}
+		s.Positive(spikes)
+	})
+}
+
+func conflictDates(conflicts []WeekPreviewConflict) []string {
+	dates := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		dates[i] = c.Date
+	}
+	return dates
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// =============================================================================
+// WEIGH-IN TIME-OF-DAY CORRECTION
+// =============================================================================
+//
+// Body weight rises through the day with food and water, so an afternoon
+// weigh-in reads heavier than a morning one and spikes the trend. When logs
+// record the weigh-in time, each timed weigh-in is compared with the average
+// of its neighbouring days; averaging those residuals per time-of-day block
+// gives a personal offset curve relative to the morning block. Weights are
+// shifted back to their morning-equivalent before trend smoothing and flux.
+
+const (
+	// WeighInBlockHours is the width of each time-of-day block.
+	WeighInBlockHours = 3
+	// WeighInReferenceHour is the start of the reference (morning) block.
+	WeighInReferenceHour = 6
+	// WeighInMinBlockSamples is the minimum timed weigh-ins to trust a block's offset.
+	WeighInMinBlockSamples = 4
+	// WeighInNeighbourDays is how many days either side form a weigh-in's baseline.
+	WeighInNeighbourDays = 3
+	// WeighInLearningDays is how much history the offset curve is learned from.
+	WeighInLearningDays = 90
+	// WeighInMaxOffsetKg caps a block's offset to reject implausible curves.
+	WeighInMaxOffsetKg = 2.5
+
+	weighInBlocks = 24 / WeighInBlockHours
+)
+
+// WeighInOffsetBlock is the learned offset for one time-of-day block.
+type WeighInOffsetBlock struct {
+	StartHour int
+	EndHour   int     // Exclusive
+	OffsetKg  float64 // Heavier than the morning block by this much (0 when untrusted)
+	Samples   int
+	Trusted   bool // Enough samples to apply the offset
+}
+
+// WeighInOffsetCurve is the personal time-of-day offset curve.
+type WeighInOffsetCurve struct {
+	Blocks       []WeighInOffsetBlock
+	TimedSamples int
+	HasReference bool // Morning block has enough samples to anchor the curve
+}
+
+// ValidateWeighInTime checks a weigh-in time is in HH:MM format.
+func ValidateWeighInTime(hhmm string) error {
+	if !isValidTimeFormat(hhmm) {
+		return ErrInvalidWeighInTime
+	}
+	return nil
+}
+
+// weighInBlock returns the block index for an HH:MM time, or -1 if invalid.
+func weighInBlock(hhmm string) int {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return -1
+	}
+	return t.Hour() / WeighInBlockHours
+}
+
+// LearnWeighInOffsets learns the offset curve from weight samples ordered by date.
+// Samples without a weigh-in time still count towards neighbours' baselines.
+func LearnWeighInOffsets(samples []WeightSample) *WeighInOffsetCurve {
+	var sums [weighInBlocks]float64
+	var counts [weighInBlocks]int
+	curve := &WeighInOffsetCurve{}
+
+	for i, sample := range samples {
+		block := weighInBlock(sample.WeighInTime)
+		if block < 0 {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", sample.Date)
+		if err != nil {
+			continue
+		}
+
+		var neighbourSum float64
+		var neighbours int
+		for j, other := range samples {
+			if j == i {
+				continue
+			}
+			otherDay, err := time.Parse("2006-01-02", other.Date)
+			if err != nil {
+				continue
+			}
+			if math.Abs(otherDay.Sub(day).Hours()/24) <= WeighInNeighbourDays {
+				neighbourSum += other.WeightKg
+				neighbours++
+			}
+		}
+		if neighbours < 2 {
+			continue
+		}
+
+		sums[block] += sample.WeightKg - neighbourSum/float64(neighbours)
+		counts[block]++
+		curve.TimedSamples++
+	}
+
+	// Anchor on the morning block; without it, residuals are relative to the
+	// average of all timed weigh-ins
+	reference := WeighInReferenceHour / WeighInBlockHours
+	var baseline float64
+	if counts[reference] >= WeighInMinBlockSamples {
+		baseline = sums[reference] / float64(counts[reference])
+		curve.HasReference = true
+	} else if curve.TimedSamples > 0 {
+		var total float64
+		for _, sum := range sums {
+			total += sum
+		}
+		baseline = total / float64(curve.TimedSamples)
+	}
+
+	curve.Blocks = make([]WeighInOffsetBlock, weighInBlocks)
+	for b := range curve.Blocks {
+		block := WeighInOffsetBlock{
+			StartHour: b * WeighInBlockHours,
+			EndHour:   (b + 1) * WeighInBlockHours,
+			Samples:   counts[b],
+			Trusted:   counts[b] >= WeighInMinBlockSamples,
+		}
+		if block.Trusted && b != reference {
+			offset := sums[b]/float64(counts[b]) - baseline
+			offset = math.Max(-WeighInMaxOffsetKg, math.Min(WeighInMaxOffsetKg, offset))
+			block.OffsetKg = math.Round(offset*100) / 100
+		}
+		curve.Blocks[b] = block
+	}
+	return curve
+}
+
+// OffsetAt returns the offset for an HH:MM weigh-in time.
+// Returns 0 for unknown times and blocks without enough samples.
+func (c *WeighInOffsetCurve) OffsetAt(hhmm string) float64 {
+	block := weighInBlock(hhmm)
+	if c == nil || block < 0 || block >= len(c.Blocks) {
+		return 0
+	}
+	return c.Blocks[block].OffsetKg
+}
+
+// Correct returns a copy of samples shifted to their morning-equivalent weight.
+func (c *WeighInOffsetCurve) Correct(samples []WeightSample) []WeightSample {
+	corrected := make([]WeightSample, len(samples))
+	for i, sample := range samples {
+		corrected[i] = sample
+		corrected[i].WeightKg = math.Round((sample.WeightKg-c.OffsetAt(sample.WeighInTime))*100) / 100
+	}
+	return corrected
+}
+
+// CorrectWeighInTimes learns the offset curve from samples and applies it to them.
+func CorrectWeighInTimes(samples []WeightSample) []WeightSample {
+	return LearnWeighInOffsets(samples).Correct(samples)
+}
+
+// CorrectWeightHistory applies CorrectWeighInTimes to flux weight history.
+func CorrectWeightHistory(points []WeightDataPoint) []WeightDataPoint {
+	samples := make([]WeightSample, len(points))
+	for i, p := range points {
+		samples[i] = WeightSample{Date: p.Date, WeightKg: p.WeightKg, WeighInTime: p.WeighInTime}
+	}
+	corrected := make([]WeightDataPoint, len(points))
+	for i, sample := range CorrectWeighInTimes(samples) {
+		corrected[i] = WeightDataPoint{Date: sample.Date, WeightKg: sample.WeightKg, WeighInTime: sample.WeighInTime}
+	}
+	return corrected
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WeighInCorrectionSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func TestWeighInCorrectionSuite(t *testing.T) {
+	suite.Run(t, new(WeighInCorrectionSuite))
+}
+
+func (s *WeighInCorrectionSuite) SetupTest() {
+	s.start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// samples builds flat 80 kg weigh-ins; every third day is weighed in the
+// afternoon and reads 1.2 kg heavier.
+func (s *WeighInCorrectionSuite) samples(days int) []WeightSample {
+	samples := make([]WeightSample, days)
+	for i := range samples {
+		sample := WeightSample{
+			Date:        s.start.AddDate(0, 0, i).Format("2006-01-02"),
+			WeightKg:    80,
+			WeighInTime: "07:15",
+		}
+		if i%3 == 2 {
+			sample.WeightKg = 81.2
+			sample.WeighInTime = "16:30"
+		}
+		samples[i] = sample
+	}
+	return samples
+}
+
+func (s *WeighInCorrectionSuite) TestLearnsAfternoonOffset() {
+	curve := LearnWeighInOffsets(s.samples(30))
+
+	s.True(curve.HasReference)
+	s.Equal(30, curve.TimedSamples)
+	s.Equal(0.0, curve.OffsetAt("07:00"), "morning is the reference")
+	s.InDelta(1.2, curve.OffsetAt("16:30"), 0.1)
+	s.True(curve.Blocks[5].Trusted)
+	s.Equal(0.0, curve.OffsetAt("21:00"), "no weigh-ins in the evening block")
+	s.Equal(0.0, curve.OffsetAt(""))
+}
+
+func (s *WeighInCorrectionSuite) TestCorrectionFlattensTrend() {
+	corrected := CorrectWeighInTimes(s.samples(30))
+
+	for _, sample := range corrected {
+		s.InDelta(80, sample.WeightKg, 0.15, sample.Date)
+	}
+
+	raw := CalculateSmoothedWeightTrend(s.samples(30), WeightSmoothing{})
+	fixed := CalculateSmoothedWeightTrend(corrected, WeightSmoothing{})
+	s.Less(fixed.ResidualStdDevKg, raw.ResidualStdDevKg)
+}
+
+func (s *WeighInCorrectionSuite) TestSparseBlocksAreNotCorrected() {
+	samples := s.samples(6) // Only two afternoon weigh-ins
+
+	s.Equal(samples, CorrectWeighInTimes(samples))
+}
+
+func (s *WeighInCorrectionSuite) TestUntimedWeighInsPassThrough() {
+	samples := s.samples(30)
+	samples[11].WeighInTime = ""
+
+	corrected := CorrectWeighInTimes(samples)
+
+	s.Equal(samples[11].WeightKg, corrected[11].WeightKg)
+}
+
+func (s *WeighInCorrectionSuite) TestValidation() {
+	s.NoError(ValidateWeighInTime("06:45"))
+	s.ErrorIs(ValidateWeighInTime("6:45"), ErrInvalidWeighInTime)
+
+	log, err := NewDailyLogFromInput(DailyLogInput{
+		Date:         "2026-01-05",
+		WeightKg:     80,
+		WeighInTime:  func() *string { t := "25:00"; return &t }(),
+		SleepQuality: 70,
+	}, s.start)
+	s.Nil(log)
+	s.ErrorIs(err, ErrInvalidWeighInTime)
+}
@@ -0,0 +1,233 @@
+package domain
+
+import "math"
+
+// =============================================================================
+// WEIGHT TREND SMOOTHING
+// =============================================================================
+//
+// Daily scale weight swings with water and sodium, so charts and analysis use
+// a smoothed trend line. Users pick the algorithm once (on the profile) and
+// the weight trend endpoint, debrief and plan analysis all apply it:
+//   - ema:   exponential moving average, cheap and stable but lags turns
+//   - hull:  Hull moving average, reacts quickly with little lag
+//   - loess: locally weighted linear regression, smooth and centred
+
+// WeightTrendMethod selects the smoothing algorithm for weight trends.
+type WeightTrendMethod string
+
+const (
+	WeightTrendMethodEMA   WeightTrendMethod = "ema"
+	WeightTrendMethodHull  WeightTrendMethod = "hull"
+	WeightTrendMethodLOESS WeightTrendMethod = "loess"
+)
+
+// ValidWeightTrendMethods contains all valid weight trend methods.
+var ValidWeightTrendMethods = map[WeightTrendMethod]bool{
+	WeightTrendMethodEMA:   true,
+	WeightTrendMethodHull:  true,
+	WeightTrendMethodLOESS: true,
+}
+
+// ParseWeightTrendMethod safely converts a string to WeightTrendMethod with validation.
+func ParseWeightTrendMethod(s string) (WeightTrendMethod, error) {
+	method := WeightTrendMethod(s)
+	if !ValidWeightTrendMethods[method] {
+		return "", ErrInvalidWeightTrendMethod
+	}
+	return method, nil
+}
+
+const (
+	// DefaultEMAAlpha is the EMA smoothing factor used when no window is set.
+	DefaultEMAAlpha = 0.3
+	// DefaultHullWindow and DefaultLOESSWindow are the window sizes (samples)
+	// used when no window is set.
+	DefaultHullWindow  = 9
+	DefaultLOESSWindow = 14
+	// MinWeightTrendWindow and MaxWeightTrendWindow bound a custom window.
+	MinWeightTrendWindow = 3
+	MaxWeightTrendWindow = 60
+	// weightTrendBandZ is the z-score for the 95% confidence band.
+	weightTrendBandZ = 1.96
+)
+
+// WeightSmoothing is a smoothing method with its window size.
+// A zero Window uses the method's default; the zero value is EMA with DefaultEMAAlpha.
+type WeightSmoothing struct {
+	Method WeightTrendMethod
+	Window int
+}
+
+// Validate checks the method and custom window.
+func (s WeightSmoothing) Validate() error {
+	if s.Method != "" && !ValidWeightTrendMethods[s.Method] {
+		return ErrInvalidWeightTrendMethod
+	}
+	if s.Window != 0 && (s.Window < MinWeightTrendWindow || s.Window > MaxWeightTrendWindow) {
+		return ErrInvalidWeightTrendWindow
+	}
+	return nil
+}
+
+// EffectiveMethod returns the method, defaulting to EMA.
+func (s WeightSmoothing) EffectiveMethod() WeightTrendMethod {
+	if s.Method == "" {
+		return WeightTrendMethodEMA
+	}
+	return s.Method
+}
+
+// Smooth returns the trend value for each weight, ordered oldest first.
+func (s WeightSmoothing) Smooth(weights []float64) []float64 {
+	switch s.EffectiveMethod() {
+	case WeightTrendMethodHull:
+		window := s.Window
+		if window == 0 {
+			window = DefaultHullWindow
+		}
+		return hullMovingAverage(weights, window)
+	case WeightTrendMethodLOESS:
+		window := s.Window
+		if window == 0 {
+			window = DefaultLOESSWindow
+		}
+		return loessSmooth(weights, window)
+	default:
+		alpha := DefaultEMAAlpha
+		if s.Window > 0 {
+			alpha = 2 / float64(s.Window+1)
+		}
+		return CalculateEMAWeight(weights, alpha)
+	}
+}
+
+// SmoothedWeightPoint is one day of a smoothed weight trend.
+type SmoothedWeightPoint struct {
+	Date     string
+	WeightKg float64 // Raw scale weight
+	TrendKg  float64
+	LowerKg  float64 // 95% confidence band
+	UpperKg  float64
+}
+
+// SmoothedWeightTrend is a smoothed trend line over weight samples.
+type SmoothedWeightTrend struct {
+	Smoothing        WeightSmoothing
+	Points           []SmoothedWeightPoint
+	ResidualStdDevKg float64 // Scatter of raw weights around the trend
+	CurrentTrendKg   float64 // Trend value at the latest sample
+}
+
+// CalculateSmoothedWeightTrend smooths ordered samples and adds a confidence
+// band from the residual scatter around the trend. Returns nil without samples.
+func CalculateSmoothedWeightTrend(samples []WeightSample, smoothing WeightSmoothing) *SmoothedWeightTrend {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	weights := make([]float64, len(samples))
+	for i, sample := range samples {
+		weights[i] = sample.WeightKg
+	}
+	trend := smoothing.Smooth(weights)
+
+	var sumSq float64
+	for i, w := range weights {
+		sumSq += (w - trend[i]) * (w - trend[i])
+	}
+	stdDev := 0.0
+	if len(weights) > 1 {
+		stdDev = math.Sqrt(sumSq / float64(len(weights)-1))
+	}
+	band := weightTrendBandZ * stdDev
+
+	points := make([]SmoothedWeightPoint, len(samples))
+	for i, sample := range samples {
+		points[i] = SmoothedWeightPoint{
+			Date:     sample.Date,
+			WeightKg: sample.WeightKg,
+			TrendKg:  RoundTo(trend[i], 2),
+			LowerKg:  RoundTo(trend[i]-band, 2),
+			UpperKg:  RoundTo(trend[i]+band, 2),
+		}
+	}
+
+	return &SmoothedWeightTrend{
+		Smoothing:        WeightSmoothing{Method: smoothing.EffectiveMethod(), Window: smoothing.Window},
+		Points:           points,
+		ResidualStdDevKg: RoundTo(stdDev, 2),
+		CurrentTrendKg:   points[len(points)-1].TrendKg,
+	}
+}
+
+// weightedMovingAverage returns the linearly weighted moving average over
+// the trailing window (shorter at the start of the series).
+func weightedMovingAverage(values []float64, window int) []float64 {
+	result := make([]float64, len(values))
+	for i := range values {
+		var sum, weightSum float64
+		for j := 0; j < window && i-j >= 0; j++ {
+			weight := float64(window - j)
+			sum += values[i-j] * weight
+			weightSum += weight
+		}
+		result[i] = sum / weightSum
+	}
+	return result
+}
+
+// hullMovingAverage computes HMA(n) = WMA(2·WMA(n/2) − WMA(n), √n).
+func hullMovingAverage(values []float64, window int) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	half := weightedMovingAverage(values, max(1, window/2))
+	full := weightedMovingAverage(values, window)
+	diff := make([]float64, len(values))
+	for i := range values {
+		diff[i] = 2*half[i] - full[i]
+	}
+	return weightedMovingAverage(diff, max(1, int(math.Round(math.Sqrt(float64(window))))))
+}
+
+// loessSmooth fits a tricube-weighted linear regression around each point
+// using its window nearest neighbours.
+func loessSmooth(values []float64, window int) []float64 {
+	n := len(values)
+	result := make([]float64, n)
+	if n < 3 {
+		copy(result, values)
+		return result
+	}
+	window = min(window, n)
+
+	for i := range values {
+		// Slide the neighbourhood so it stays inside the series
+		lo := max(0, min(i-window/2, n-window))
+		hi := lo + window - 1
+		maxDist := math.Max(float64(i-lo), float64(hi-i)) + 1
+
+		var sumW, sumWX, sumWY, sumWXX, sumWXY float64
+		for j := lo; j <= hi; j++ {
+			d := math.Abs(float64(j-i)) / maxDist
+			w := math.Pow(1-d*d*d, 3)
+			x := float64(j - i)
+			sumW += w
+			sumWX += w * x
+			sumWY += w * values[j]
+			sumWXX += w * x * x
+			sumWXY += w * x * values[j]
+		}
+
+		// Evaluated at x = 0, the fit is the intercept
+		denom := sumW*sumWXX - sumWX*sumWX
+		if denom == 0 {
+			result[i] = sumWY / sumW
+			continue
+		}
+		slope := (sumW*sumWXY - sumWX*sumWY) / denom
+		result[i] = (sumWY - slope*sumWX) / sumW
+	}
+	return result
+}
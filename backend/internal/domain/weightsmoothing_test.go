@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WeightSmoothingSuite struct {
+	suite.Suite
+}
+
+func TestWeightSmoothingSuite(t *testing.T) {
+	suite.Run(t, new(WeightSmoothingSuite))
+}
+
+// samples returns a linear decline with alternating ±0.4 kg water noise.
+func (s *WeightSmoothingSuite) samples(days int) []WeightSample {
+	samples := make([]WeightSample, days)
+	for i := range samples {
+		noise := 0.4
+		if i%2 == 1 {
+			noise = -0.4
+		}
+		samples[i] = WeightSample{
+			Date:     fmt.Sprintf("2026-01-%02d", i+1),
+			WeightKg: 90 - 0.1*float64(i) + noise,
+		}
+	}
+	return samples
+}
+
+func (s *WeightSmoothingSuite) TestDefaultMatchesLegacyEMA() {
+	weights := []float64{80, 81, 79.5, 80.2}
+
+	s.Equal(CalculateEMAWeight(weights, DefaultEMAAlpha), WeightSmoothing{}.Smooth(weights))
+	s.Equal(CalculateEMAWeight(weights, 0.2), WeightSmoothing{Method: WeightTrendMethodEMA, Window: 9}.Smooth(weights))
+}
+
+func (s *WeightSmoothingSuite) TestMethodsFilterNoise() {
+	samples := s.samples(28)
+	expected := 90 - 0.1*27
+
+	for _, method := range []WeightTrendMethod{WeightTrendMethodEMA, WeightTrendMethodHull, WeightTrendMethodLOESS} {
+		s.Run(string(method), func() {
+			trend := CalculateSmoothedWeightTrend(samples, WeightSmoothing{Method: method})
+
+			s.Require().NotNil(trend)
+			s.Equal(method, trend.Smoothing.Method)
+			s.Len(trend.Points, len(samples))
+			s.InDelta(expected, trend.CurrentTrendKg, 0.5)
+			s.Greater(trend.ResidualStdDevKg, 0.0)
+			last := trend.Points[len(trend.Points)-1]
+			s.Less(last.LowerKg, last.TrendKg)
+			s.Greater(last.UpperKg, last.TrendKg)
+		})
+	}
+}
+
+func (s *WeightSmoothingSuite) TestLOESSTracksLinearDataExactly() {
+	weights := []float64{90, 89.8, 89.6, 89.4, 89.2, 89.0}
+
+	smoothed := WeightSmoothing{Method: WeightTrendMethodLOESS, Window: 4}.Smooth(weights)
+
+	for i := range weights {
+		s.InDelta(weights[i], smoothed[i], 0.001)
+	}
+}
+
+func (s *WeightSmoothingSuite) TestHullLagsLessThanEMA() {
+	samples := s.samples(28)
+	ema := CalculateSmoothedWeightTrend(samples, WeightSmoothing{Method: WeightTrendMethodEMA, Window: 9})
+	hull := CalculateSmoothedWeightTrend(samples, WeightSmoothing{Method: WeightTrendMethodHull, Window: 9})
+
+	// A falling series: a lagging average sits above the true line
+	expected := 90 - 0.1*27
+	s.Less(hull.CurrentTrendKg-expected, ema.CurrentTrendKg-expected)
+}
+
+func (s *WeightSmoothingSuite) TestValidation() {
+	s.NoError(WeightSmoothing{}.Validate())
+	s.ErrorIs(WeightSmoothing{Method: "sma"}.Validate(), ErrInvalidWeightTrendMethod)
+	s.ErrorIs(WeightSmoothing{Method: WeightTrendMethodHull, Window: 2}.Validate(), ErrInvalidWeightTrendWindow)
+
+	_, err := ParseWeightTrendMethod("median")
+	s.ErrorIs(err, ErrInvalidWeightTrendMethod)
+	s.Nil(CalculateSmoothedWeightTrend(nil, WeightSmoothing{}))
+}
@@ -3,8 +3,9 @@ package domain
 import "time"
 
 type WeightSample struct {
-	Date     string
-	WeightKg float64
+	Date        string
+	WeightKg    float64
+	WeighInTime string // HH:MM, empty when unknown
 }
 
 type WeightTrend struct {
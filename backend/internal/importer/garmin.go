@@ -488,7 +488,7 @@ func (g *GarminImporter) importActivities(ctx context.Context, reader io.Reader)
 			YearMonth:       yearMonth,
 			ActivityType:    activityType,
 			SessionCount:    *count,
-			DataSource:      "garmin_import",
+			DataSource:      domain.MonthlySummarySourceGarmin,
 			RawActivityName: rawActivityName,
 		}
 		summary.ComputeAvgCalories()
@@ -0,0 +1,329 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+const (
+	FileTypeMFPNutrition       FileType = "mfp_nutrition"       // MyFitnessPal Nutrition-Summary / food diary export
+	FileTypeCronometerServings FileType = "cronometer_servings" // Cronometer servings.csv export
+)
+
+// NutritionImporter handles importing historical food logs from MyFitnessPal and Cronometer CSV exports.
+type NutritionImporter struct {
+	dailyLogStore      *store.DailyLogStore
+	foodReferenceStore *store.FoodReferenceStore
+	entryStore         *store.NutritionImportStore
+}
+
+// NewNutritionImporter creates a new nutrition importer.
+func NewNutritionImporter(dls *store.DailyLogStore, frs *store.FoodReferenceStore, nis *store.NutritionImportStore) *NutritionImporter {
+	return &NutritionImporter{
+		dailyLogStore:      dls,
+		foodReferenceStore: frs,
+		entryStore:         nis,
+	}
+}
+
+// DetectFileType inspects the header row to tell MyFitnessPal and Cronometer exports apart.
+func (n *NutritionImporter) DetectFileType(reader io.Reader) (FileType, error) {
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, reader); err != nil {
+		return FileTypeUnknown, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	firstLine, _, _ := strings.Cut(stripBOM(buf.String()), "\n")
+	header := strings.ToLower(firstLine)
+
+	// Cronometer: "Day","Time","Group","Food Name","Amount",...,"Energy (kcal)"
+	if strings.Contains(header, "food name") && strings.Contains(header, "energy (kcal)") {
+		return FileTypeCronometerServings, nil
+	}
+
+	// MyFitnessPal: Date,Meal,Time,Calories,Fat (g),...,Protein (g)
+	if strings.Contains(header, "meal") && strings.Contains(header, "calories") {
+		return FileTypeMFPNutrition, nil
+	}
+
+	return FileTypeUnknown, nil
+}
+
+// Import parses a nutrition export and records its rows as imported food entries.
+// Rows are added to the consumed totals of existing daily logs only; dates
+// without a log are skipped with a warning. Rows already imported are skipped,
+// so re-uploading the same export is safe.
+func (n *NutritionImporter) Import(ctx context.Context, fileType FileType, reader io.Reader) (*domain.NutritionImportResult, error) {
+	var source domain.NutritionImportSource
+	switch fileType {
+	case FileTypeMFPNutrition:
+		source = domain.NutritionImportSourceMFP
+	case FileTypeCronometerServings:
+		source = domain.NutritionImportSourceCronometer
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", fileType)
+	}
+
+	result := &domain.NutritionImportResult{
+		Source:   source,
+		Warnings: []string{},
+		Errors:   []string{},
+	}
+
+	rows, err := n.parseRows(reader, result)
+	if err != nil {
+		return nil, err
+	}
+
+	foods, err := n.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load food reference: %w", err)
+	}
+	synonymList, err := n.foodReferenceStore.ListSynonyms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load food synonyms: %w", err)
+	}
+	synonyms := domain.IndexFoodSynonyms(synonymList)
+
+	type mealKey struct {
+		date string
+		meal domain.MealName
+	}
+	totals := make(map[mealKey]*store.ConsumedMacros)
+	logExists := make(map[string]bool)
+
+	for _, row := range rows {
+		exists, checked := logExists[row.Date]
+		if !checked {
+			_, err := n.dailyLogStore.GetIDByDate(ctx, row.Date)
+			if err != nil && !errors.Is(err, store.ErrDailyLogNotFound) {
+				return nil, fmt.Errorf("failed to look up daily log %s: %w", row.Date, err)
+			}
+			exists = err == nil
+			logExists[row.Date] = exists
+			if !exists {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("No daily log for %s - skipped", row.Date))
+			}
+		}
+		if !exists {
+			result.RowsSkipped++
+			continue
+		}
+
+		entry := domain.ImportedFoodEntry{
+			Source:   source,
+			Date:     row.Date,
+			Meal:     row.Meal,
+			RawName:  row.FoodName,
+			Amount:   row.Amount,
+			Calories: int(math.Round(row.Calories)),
+			ProteinG: int(math.Round(row.ProteinG)),
+			CarbsG:   int(math.Round(row.CarbsG)),
+			FatG:     int(math.Round(row.FatG)),
+		}
+		if row.FoodName != "" {
+			match := domain.ResolveFood(row.FoodName, foods, synonyms)
+			if match.Best != nil {
+				entry.FoodReferenceID = &match.Best.Food.ID
+				entry.MatchScore = match.Best.Score
+			} else if len(match.Candidates) > 0 {
+				entry.MatchScore = match.Candidates[0].Score
+			}
+		}
+
+		inserted, err := n.entryStore.InsertEntry(ctx, &entry)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to store %s entry %q: %v", row.Date, row.FoodName, err))
+			continue
+		}
+		if !inserted {
+			result.DuplicatesSkipped++
+			continue
+		}
+
+		result.EntriesImported++
+		if entry.FoodReferenceID != nil {
+			result.MatchedItems++
+		} else if entry.NeedsReview() {
+			result.UnmatchedItems++
+		}
+
+		key := mealKey{date: row.Date}
+		if row.Meal != nil {
+			key.meal = *row.Meal
+		}
+		t, ok := totals[key]
+		if !ok {
+			t = &store.ConsumedMacros{Meal: row.Meal}
+			totals[key] = t
+		}
+		t.Calories += entry.Calories
+		t.ProteinG += entry.ProteinG
+		t.CarbsG += entry.CarbsG
+		t.FatG += entry.FatG
+	}
+
+	// Apply consumed totals once per date and meal slot
+	keys := make([]mealKey, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].date != keys[j].date {
+			return keys[i].date < keys[j].date
+		}
+		return keys[i].meal < keys[j].meal
+	})
+	updatedDays := make(map[string]bool)
+	for _, k := range keys {
+		if err := n.dailyLogStore.AddConsumedMacros(ctx, k.date, *totals[k]); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to update consumed totals for %s: %v", k.date, err))
+			continue
+		}
+		updatedDays[k.date] = true
+	}
+	result.DaysUpdated = len(updatedDays)
+
+	return result, nil
+}
+
+// parseRows reads every data row of an export. Unparseable rows are counted as skipped.
+func (n *NutritionImporter) parseRows(reader io.Reader, result *domain.NutritionImportResult) ([]domain.NutritionImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // Allow variable field count
+
+	headers, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	colMap := makeNutritionColumnMap(headers)
+	if _, ok := colMap["date"]; !ok {
+		return nil, fmt.Errorf("missing date column")
+	}
+	if _, ok := colMap["calories"]; !ok {
+		return nil, fmt.Errorf("missing calories column")
+	}
+
+	var rows []domain.NutritionImportRow
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("CSV read error: %v", err))
+			continue
+		}
+
+		result.RowsProcessed++
+
+		row, err := parseNutritionRow(record, colMap)
+		if err != nil {
+			result.RowsSkipped++
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Row %d: %v", result.RowsProcessed, err))
+			continue
+		}
+		rows = append(rows, *row)
+	}
+	return rows, nil
+}
+
+// parseNutritionRow parses a single export row.
+func parseNutritionRow(record []string, colMap map[string]int) (*domain.NutritionImportRow, error) {
+	getValue := func(col string) string {
+		if idx, ok := colMap[col]; ok && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+	getFloat := func(col string) float64 {
+		if v := ParseFloat(strings.ReplaceAll(getValue(col), ",", "")); v != nil {
+			return *v
+		}
+		return 0
+	}
+
+	date, err := parseExportDate(getValue("date"))
+	if err != nil {
+		return nil, err
+	}
+
+	calories := ParseFloat(strings.ReplaceAll(getValue("calories"), ",", ""))
+	if calories == nil {
+		return nil, fmt.Errorf("missing calories")
+	}
+	if *calories < 0 {
+		return nil, fmt.Errorf("negative calories")
+	}
+
+	return &domain.NutritionImportRow{
+		Date:     date,
+		Meal:     domain.ImportMealName(getValue("meal")),
+		FoodName: getValue("food"),
+		Amount:   getValue("amount"),
+		Calories: *calories,
+		ProteinG: getFloat("protein"),
+		CarbsG:   getFloat("carbs"),
+		FatG:     getFloat("fat"),
+	}, nil
+}
+
+// exportDateLayouts are the date formats seen in MyFitnessPal and Cronometer exports.
+var exportDateLayouts = []string{"2006-01-02", "1/2/2006", "01/02/2006", "2006/01/02"}
+
+// parseExportDate normalizes an export date to YYYY-MM-DD.
+func parseExportDate(s string) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("missing date")
+	}
+	for _, layout := range exportDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q", s)
+}
+
+// makeNutritionColumnMap maps MyFitnessPal/Cronometer column names to standard keys.
+func makeNutritionColumnMap(headers []string) map[string]int {
+	m := make(map[string]int)
+	set := func(key string, i int) {
+		if _, exists := m[key]; !exists {
+			m[key] = i
+		}
+	}
+	for i, h := range headers {
+		h = strings.ToLower(strings.TrimSpace(stripBOM(h)))
+
+		switch {
+		case h == "date" || h == "day":
+			set("date", i)
+		case h == "meal" || h == "group":
+			set("meal", i)
+		case h == "food name" || h == "food" || h == "name":
+			set("food", i)
+		case h == "amount" || h == "quantity" || h == "serving":
+			set("amount", i)
+		case h == "calories" || h == "energy (kcal)":
+			set("calories", i)
+		case strings.HasPrefix(h, "protein"):
+			set("protein", i)
+		case strings.HasPrefix(h, "carbohydrates") || strings.HasPrefix(h, "carbs"):
+			set("carbs", i)
+		case h == "fat (g)" || h == "fat":
+			set("fat", i)
+		}
+	}
+	return m
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"victus/internal/domain"
+)
+
+// Justification: Export parsing is pure format handling; unit tests lock the
+// MyFitnessPal and Cronometer column layouts without a database.
+
+type NutritionImportSuite struct {
+	suite.Suite
+}
+
+func TestNutritionImportSuite(t *testing.T) {
+	suite.Run(t, new(NutritionImportSuite))
+}
+
+const cronometerHeader = `Day,Time,Group,Food Name,Amount,Category,Energy (kcal),Alcohol (g),Caffeine (mg),Water (g),Carbs (g),Fiber (g),Net Carbs (g),Fat (g),Protein (g)`
+const mfpHeader = `Date,Meal,Time,Calories,Fat (g),Saturated Fat,Cholesterol,Sodium (mg),Carbohydrates (g),Fiber,Sugar,Protein (g),Note`
+
+func (s *NutritionImportSuite) TestDetectFileType() {
+	n := &NutritionImporter{}
+
+	got, err := n.DetectFileType(strings.NewReader("\ufeff" + cronometerHeader + "\n"))
+	s.Require().NoError(err)
+	s.Equal(FileTypeCronometerServings, got)
+
+	got, err = n.DetectFileType(strings.NewReader(mfpHeader + "\n"))
+	s.Require().NoError(err)
+	s.Equal(FileTypeMFPNutrition, got)
+
+	got, err = n.DetectFileType(strings.NewReader("Fecha,Peso,IMC\n"))
+	s.Require().NoError(err)
+	s.Equal(FileTypeUnknown, got)
+}
+
+func (s *NutritionImportSuite) TestParseCronometerRows() {
+	data := cronometerHeader + "\n" +
+		`2024-03-04,08:10,Breakfast,"Oats, Rolled",40.00 g,Cereal,150.4,0,0,4,27.1,4,23.1,2.6,5.3` + "\n" +
+		`2024-03-04,15:00,Snacks,Banana,1 medium,Fruit,105,0,0,89,27,3.1,23.9,0.4,1.3` + "\n" +
+		`not-a-date,15:00,Snacks,Banana,1 medium,Fruit,105,0,0,89,27,3.1,23.9,0.4,1.3` + "\n"
+
+	result := &domain.NutritionImportResult{}
+	rows, err := (&NutritionImporter{}).parseRows(strings.NewReader(data), result)
+	s.Require().NoError(err)
+	s.Equal(3, result.RowsProcessed)
+	s.Equal(1, result.RowsSkipped)
+	s.Require().Len(rows, 2)
+
+	s.Equal("2024-03-04", rows[0].Date)
+	s.Require().NotNil(rows[0].Meal)
+	s.Equal(domain.MealBreakfast, *rows[0].Meal)
+	s.Equal("Oats, Rolled", rows[0].FoodName)
+	s.Equal("40.00 g", rows[0].Amount)
+	s.InDelta(150.4, rows[0].Calories, 0.01)
+	s.InDelta(27.1, rows[0].CarbsG, 0.01, "net carbs column must not shadow carbs")
+	s.InDelta(2.6, rows[0].FatG, 0.01)
+	s.InDelta(5.3, rows[0].ProteinG, 0.01)
+
+	s.Nil(rows[1].Meal, "snacks count toward totals without a meal slot")
+}
+
+func (s *NutritionImportSuite) TestParseMFPSummaryRows() {
+	data := mfpHeader + "\n" +
+		`1/15/2024,Dinner,7:30 PM,"1,050",40,12,100,900,110,8,10,65,` + "\n"
+
+	result := &domain.NutritionImportResult{}
+	rows, err := (&NutritionImporter{}).parseRows(strings.NewReader(data), result)
+	s.Require().NoError(err)
+	s.Require().Len(rows, 1)
+
+	s.Equal("2024-01-15", rows[0].Date)
+	s.Equal(domain.MealDinner, *rows[0].Meal)
+	s.Empty(rows[0].FoodName, "meal summary rows carry no food name")
+	s.InDelta(1050, rows[0].Calories, 0.01)
+	s.InDelta(40, rows[0].FatG, 0.01, "saturated fat must not shadow fat")
+	s.InDelta(110, rows[0].CarbsG, 0.01)
+	s.InDelta(65, rows[0].ProteinG, 0.01)
+}
+
+func (s *NutritionImportSuite) TestParseRowsRequiresCaloriesColumn() {
+	result := &domain.NutritionImportResult{}
+	_, err := (&NutritionImporter{}).parseRows(strings.NewReader("Date,Meal,Protein (g)\n"), result)
+	s.Error(err)
+}
@@ -0,0 +1,365 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// Page layout, in points.
+const (
+	marginX      = 48.0
+	marginTop    = 56.0
+	marginBottom = 64.0
+	contentWidth = PageWidth - 2*marginX
+)
+
+var (
+	colorText   = Color{0.12, 0.13, 0.16}
+	colorMuted  = Color{0.42, 0.45, 0.50}
+	colorRule   = Color{0.85, 0.87, 0.90}
+	colorBand   = Color{0.96, 0.97, 0.98}
+	colorTarget = Color{0.78, 0.81, 0.86}
+	colorEaten  = Color{0.23, 0.51, 0.96}
+	colorOver   = Color{0.94, 0.27, 0.27}
+)
+
+// layout tracks the write position and breaks pages as content flows down.
+type layout struct {
+	doc *Document
+	y   float64
+}
+
+func (l *layout) newPage() {
+	l.doc.AddPage()
+	l.y = marginTop
+}
+
+// ensure starts a new page unless h points still fit on this one.
+func (l *layout) ensure(h float64) {
+	if l.y+h > PageHeight-marginBottom {
+		l.newPage()
+	}
+}
+
+func (l *layout) heading(title string) {
+	l.ensure(48)
+	l.y += 18
+	l.doc.Text(marginX, l.y, FontBold, 13, colorText, title)
+	l.y += 6
+	l.doc.Line(marginX, l.y, marginX+contentWidth, l.y, 0.75, colorRule)
+	l.y += 14
+}
+
+func (l *layout) paragraph(font Font, size float64, c Color, indent float64, s string) {
+	lineHeight := size * 1.4
+	for _, line := range WrapText(font, size, contentWidth-indent, s) {
+		l.ensure(lineHeight)
+		l.y += lineHeight
+		l.doc.Text(marginX+indent, l.y, font, size, c, line)
+	}
+}
+
+// tableColumn is a column of a text table; Right aligns numbers.
+type tableColumn struct {
+	Title string
+	Width float64
+	Right bool
+}
+
+func (l *layout) table(columns []tableColumn, rows [][]string) {
+	const rowHeight = 16.0
+	header := func() {
+		l.ensure(rowHeight * 2)
+		l.y += rowHeight
+		l.row(columns, nil, FontBold, colorMuted)
+		l.doc.Line(marginX, l.y+4, marginX+contentWidth, l.y+4, 0.5, colorRule)
+	}
+	header()
+	for i, cells := range rows {
+		if l.y+rowHeight > PageHeight-marginBottom {
+			l.newPage()
+			header()
+		}
+		if i%2 == 1 {
+			l.doc.FillRect(marginX, l.y+4, contentWidth, rowHeight, colorBand)
+		}
+		l.y += rowHeight
+		l.row(columns, cells, FontRegular, colorText)
+	}
+}
+
+// row draws one table row at the current baseline; nil cells draw the titles.
+func (l *layout) row(columns []tableColumn, cells []string, font Font, c Color) {
+	x := marginX
+	for i, col := range columns {
+		text := col.Title
+		if cells != nil {
+			text = cells[i]
+		}
+		if col.Right {
+			l.doc.TextRight(x+col.Width-4, l.y, font, 8.5, c, text)
+		} else {
+			l.doc.Text(x+4, l.y, font, 8.5, c, text)
+		}
+		x += col.Width
+	}
+}
+
+// WeeklyDebriefPDF renders a weekly debrief as a printable PDF: vitality
+// score, narrative, calorie chart, daily adherence table, fatigue snapshot
+// and recommendations. heatmap may be nil, which omits the fatigue section.
+func WeeklyDebriefPDF(debrief *domain.WeeklyDebrief, heatmap *domain.FatigueHeatmap, generatedAt time.Time) ([]byte, error) {
+	title := "Weekly Debrief " + debrief.WeekStartDate + " to " + debrief.WeekEndDate
+	l := &layout{doc: NewDocument(title, generatedAt)}
+	l.newPage()
+
+	writeDebriefHeader(l, debrief, generatedAt)
+	writeVitality(l, debrief.VitalityScore)
+
+	l.heading("Summary")
+	l.paragraph(FontRegular, 10, colorText, 0, debrief.Narrative.Text)
+	if !debrief.Narrative.GeneratedByLLM {
+		l.paragraph(FontRegular, 8, colorMuted, 0, "Template summary (AI narrative unavailable).")
+	}
+
+	if len(debrief.DailyBreakdown) > 0 {
+		l.heading("Calories: target vs eaten")
+		writeCalorieChart(l, debrief.DailyBreakdown)
+		l.heading("Daily adherence")
+		writeDailyTable(l, debrief.DailyBreakdown)
+	}
+
+	if heatmap != nil && len(heatmap.Muscles) > 0 {
+		l.heading("Fatigue snapshot")
+		writeFatigueSnapshot(l, heatmap)
+	}
+
+	if len(debrief.Recommendations) > 0 {
+		l.heading("Recommendations for next week")
+		writeRecommendations(l, debrief.Recommendations)
+	}
+
+	if debrief.AutoClosedDrafts > 0 {
+		l.y += 8
+		l.paragraph(FontRegular, 8, colorMuted, 0, fmt.Sprintf(
+			"Data quality: %d draft session(s) were closed with a default RPE and may understate effort.", debrief.AutoClosedDrafts))
+	}
+
+	writeFooters(l.doc)
+	return l.doc.Bytes()
+}
+
+func writeDebriefHeader(l *layout, debrief *domain.WeeklyDebrief, generatedAt time.Time) {
+	l.y += 22
+	l.doc.Text(marginX, l.y, FontBold, 22, colorText, "Weekly Debrief")
+	l.y += 18
+	l.doc.Text(marginX, l.y, FontRegular, 11, colorMuted, formatWeekRange(debrief.WeekStartDate, debrief.WeekEndDate))
+	l.doc.TextRight(marginX+contentWidth, l.y, FontRegular, 8, colorMuted, "Generated "+generatedAt.Format("2 Jan 2006 15:04"))
+	l.y += 6
+}
+
+// formatWeekRange renders "Mon 3 Mar – Sun 9 Mar 2026", or the raw dates if they don't parse.
+func formatWeekRange(start, end string) string {
+	s, err1 := time.Parse("2006-01-02", start)
+	e, err2 := time.Parse("2006-01-02", end)
+	if err1 != nil || err2 != nil {
+		return start + " – " + end
+	}
+	return s.Format("Mon 2 Jan") + " – " + e.Format("Mon 2 Jan 2006")
+}
+
+func writeVitality(l *layout, v domain.VitalityScore) {
+	l.heading("Vitality score")
+	top := l.y
+
+	// Score badge on the left, components on the right
+	l.doc.FillRect(marginX, top, 110, 62, colorBand)
+	l.doc.Text(marginX+12, top+38, FontBold, 30, colorText, fmt.Sprintf("%.0f", v.Overall))
+	caption := "out of 100"
+	if v.ScoringProfile != "" {
+		caption += ", " + string(v.ScoringProfile) + " profile"
+	}
+	l.doc.Text(marginX+12, top+54, FontRegular, 8, colorMuted, caption)
+
+	flux := fmt.Sprintf("%s (%+d kcal)", v.MetabolicFlux.Trend, v.MetabolicFlux.DeltaKcal)
+	if v.MetabolicFlux.Trend == "" {
+		flux = "no data"
+	}
+	components := [][2]string{
+		{"Meal adherence", fmt.Sprintf("%.0f%%", v.MealAdherence)},
+		{"Training adherence", fmt.Sprintf("%.0f%%", v.TrainingAdherence)},
+		{"Weight change", fmt.Sprintf("%+.1f kg", v.WeightDelta)},
+		{"Trend weight", fmt.Sprintf("%.1f kg", v.TrendWeight)},
+		{"Metabolic flux", flux},
+	}
+	x := marginX + 130
+	y := top + 4
+	for _, c := range components {
+		y += 12
+		l.doc.Text(x, y, FontRegular, 9, colorMuted, c[0])
+		l.doc.Text(x+110, y, FontBold, 9, colorText, c[1])
+	}
+	l.y = math.Max(top+62, y) + 4
+}
+
+func writeCalorieChart(l *layout, days []domain.DebriefDayPoint) {
+	const chartHeight = 120.0
+	l.ensure(chartHeight + 40)
+	top := l.y + 4
+	base := top + chartHeight
+
+	maxKcal := 0
+	for _, d := range days {
+		maxKcal = max(maxKcal, d.TargetCalories, d.ConsumedCalories)
+	}
+	if maxKcal == 0 {
+		l.paragraph(FontRegular, 9, colorMuted, 0, "No calorie data this week.")
+		return
+	}
+	scale := chartHeight / (math.Ceil(float64(maxKcal)/500) * 500)
+
+	// Gridlines every 500 kcal
+	for kcal := 500; float64(kcal)*scale <= chartHeight+0.1; kcal += 500 {
+		y := base - float64(kcal)*scale
+		l.doc.Line(marginX+36, y, marginX+contentWidth, y, 0.3, colorRule)
+		l.doc.TextRight(marginX+32, y+3, FontRegular, 7, colorMuted, fmt.Sprint(kcal))
+	}
+	l.doc.Line(marginX+36, base, marginX+contentWidth, base, 0.75, colorMuted)
+
+	slot := (contentWidth - 40) / float64(len(days))
+	barWidth := math.Min(18, slot/3)
+	for i, d := range days {
+		x := marginX + 40 + float64(i)*slot + slot/2
+		target := float64(d.TargetCalories) * scale
+		eaten := float64(d.ConsumedCalories) * scale
+		l.doc.FillRect(x-barWidth-1, base-target, barWidth, target, colorTarget)
+		eatenColor := colorEaten
+		if d.TargetCalories > 0 && d.CalorieDelta > d.TargetCalories/10 {
+			eatenColor = colorOver // More than 10% over target
+		}
+		l.doc.FillRect(x+1, base-eaten, barWidth, eaten, eatenColor)
+		label := shortDayName(d)
+		l.doc.Text(x-TextWidth(FontRegular, 8, label)/2, base+12, FontRegular, 8, colorMuted, label)
+	}
+
+	// Legend
+	legendY := base + 28
+	l.doc.FillRect(marginX+40, legendY-7, 8, 8, colorTarget)
+	l.doc.Text(marginX+52, legendY, FontRegular, 8, colorMuted, "Target")
+	l.doc.FillRect(marginX+100, legendY-7, 8, 8, colorEaten)
+	l.doc.Text(marginX+112, legendY, FontRegular, 8, colorMuted, "Eaten")
+	l.doc.FillRect(marginX+160, legendY-7, 8, 8, colorOver)
+	l.doc.Text(marginX+172, legendY, FontRegular, 8, colorMuted, "Over target by 10%+")
+	l.y = legendY + 4
+}
+
+// shortDayName is "Mon", "Tue", ... falling back to the date.
+func shortDayName(d domain.DebriefDayPoint) string {
+	if len(d.DayName) >= 3 {
+		return d.DayName[:3]
+	}
+	return d.Date
+}
+
+// shortDate renders "2026-03-09" as "9 Mar".
+func shortDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2 Jan")
+}
+
+func writeDailyTable(l *layout, days []domain.DebriefDayPoint) {
+	columns := []tableColumn{
+		{"Day", 62, false},
+		{"Type", 70, false},
+		{"Target kcal", 58, true},
+		{"Eaten", 50, true},
+		{"Delta", 48, true},
+		{"Protein", 48, true},
+		{"Sessions", 50, true},
+		{"Load", 40, true},
+		{"RPE", 32, true},
+		{"Sleep", 41.28, true},
+	}
+	rows := make([][]string, len(days))
+	for i, d := range days {
+		rpe := "–"
+		if d.AvgRPE != nil {
+			rpe = fmt.Sprintf("%.1f", *d.AvgRPE)
+		}
+		sleep := "–"
+		if d.SleepHours != nil {
+			sleep = fmt.Sprintf("%.1fh", *d.SleepHours)
+		}
+		rows[i] = []string{
+			shortDayName(d) + " " + shortDate(d.Date),
+			string(d.DayType),
+			fmt.Sprint(d.TargetCalories),
+			fmt.Sprint(d.ConsumedCalories),
+			fmt.Sprintf("%+d", d.CalorieDelta),
+			fmt.Sprintf("%.0f%%", d.ProteinPercent),
+			fmt.Sprintf("%d/%d", d.ActualSessions, d.PlannedSessions),
+			fmt.Sprintf("%.1f", d.TrainingLoad),
+			rpe,
+			sleep,
+		}
+	}
+	l.table(columns, rows)
+}
+
+// fatigueSnapshotMuscles caps the fatigue section to the most loaded muscles.
+const fatigueSnapshotMuscles = 10
+
+func writeFatigueSnapshot(l *layout, heatmap *domain.FatigueHeatmap) {
+	muscles := append([]domain.MuscleFatigueDelta{}, heatmap.Muscles...)
+	sort.SliceStable(muscles, func(i, j int) bool { return muscles[i].CurrentPercent > muscles[j].CurrentPercent })
+	muscles = muscles[:min(fatigueSnapshotMuscles, len(muscles))]
+
+	l.paragraph(FontRegular, 8, colorMuted, 0, fmt.Sprintf(
+		"Overall fatigue %.0f%% (%+.0f vs %d days earlier). Most loaded muscles at week end:",
+		heatmap.OverallScore, heatmap.OverallDelta, heatmap.CompareDays))
+	l.y += 4
+
+	const barMax = 260.0
+	for _, m := range muscles {
+		l.ensure(16)
+		l.y += 16
+		l.doc.Text(marginX, l.y, FontRegular, 9, colorText, m.DisplayName)
+		l.doc.FillRect(marginX+110, l.y-8, barMax, 9, colorBand)
+		l.doc.FillRect(marginX+110, l.y-8, barMax*math.Min(m.CurrentPercent, 100)/100, 9, ParseHexColor(m.Color))
+		l.doc.Text(marginX+110+barMax+8, l.y, FontBold, 9, colorText, fmt.Sprintf("%.0f%%", m.CurrentPercent))
+		l.doc.Text(marginX+110+barMax+44, l.y, FontRegular, 8, colorMuted, fmt.Sprintf("%+.0f  %s", m.DeltaPercent, m.Status))
+	}
+}
+
+func writeRecommendations(l *layout, recs []domain.TacticalRecommendation) {
+	for i, rec := range recs {
+		l.ensure(40)
+		if i > 0 {
+			l.y += 6
+		}
+		l.paragraph(FontBold, 10, colorText, 0, fmt.Sprintf("%d. %s", i+1, rec.Summary))
+		l.paragraph(FontRegular, 8, colorMuted, 12, strings.ToUpper(rec.Category)+" – "+rec.Rationale)
+		for _, item := range rec.ActionItems {
+			l.paragraph(FontRegular, 9, colorText, 12, "• "+item)
+		}
+	}
+}
+
+// writeFooters stamps "Page n of m" on every page once the page count is known.
+func writeFooters(doc *Document) {
+	total := doc.PageCount()
+	for i := range total {
+		doc.SetPage(i)
+		y := PageHeight - marginBottom/2
+		doc.Text(marginX, y, FontRegular, 7.5, colorMuted, "Victus · Weekly Debrief")
+		doc.TextRight(marginX+contentWidth, y, FontRegular, 7.5, colorMuted, fmt.Sprintf("Page %d of %d", i+1, total))
+	}
+}
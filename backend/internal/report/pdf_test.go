@@ -0,0 +1,125 @@
+package report
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Coaches open these PDFs in whatever reader they have, and
+// readers are strict about the cross-reference table; tests check the file
+// structure and that debrief content lands on the pages.
+type PDFSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestPDFSuite(t *testing.T) {
+	suite.Run(t, new(PDFSuite))
+}
+
+func (s *PDFSuite) SetupTest() {
+	s.now = time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC)
+}
+
+// pageContents inflates every content stream in a PDF.
+func (s *PDFSuite) pageContents(pdf []byte) []string {
+	var pages []string
+	re := regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	for _, m := range re.FindAllSubmatchIndex(pdf, -1) {
+		n, _ := strconv.Atoi(string(pdf[m[2]:m[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(pdf[m[1] : m[1]+n]))
+		s.Require().NoError(err)
+		content, err := io.ReadAll(zr)
+		s.Require().NoError(err)
+		pages = append(pages, string(content))
+	}
+	return pages
+}
+
+func (s *PDFSuite) TestCrossReferenceOffsetsPointAtObjects() {
+	doc := NewDocument("Test", s.now)
+	doc.AddPage()
+	doc.Text(50, 50, FontBold, 12, Color{}, "Hello (world)")
+	doc.AddPage()
+	pdf, err := doc.Bytes()
+	s.Require().NoError(err)
+
+	s.True(bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	s.True(bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	s.Require().NotNil(startxref)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	s.True(bytes.HasPrefix(pdf[xref:], []byte("xref\n0 10\n")), "5 fixed objects + 2 per page")
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	s.Require().Len(entries, 9)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		s.True(bytes.HasPrefix(pdf[off:], fmt.Appendf(nil, "%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+
+	s.Contains(s.pageContents(pdf)[0], `(Hello \(world\)) Tj`)
+}
+
+func (s *PDFSuite) TestTextEncoding() {
+	s.Equal(`a\\b \(c\) caf`+"\xe9 \x96 ?", escapeText(`a\b (c) café – ✓`))
+}
+
+func (s *PDFSuite) TestWrapText() {
+	lines := WrapText(FontRegular, 10, 100, "the quick brown fox jumps over the lazy dog\nsecond paragraph")
+	s.Greater(len(lines), 3)
+	for _, line := range lines {
+		s.LessOrEqual(TextWidth(FontRegular, 10, line), 100.0, line)
+	}
+	s.Equal("second paragraph", lines[len(lines)-1])
+	s.Less(TextWidth(FontRegular, 10, "iiii"), TextWidth(FontBold, 10, "MMMM"))
+}
+
+func (s *PDFSuite) TestWeeklyDebriefPDF() {
+	rpe := 7.5
+	debrief := &domain.WeeklyDebrief{
+		WeekStartDate: "2026-03-02",
+		WeekEndDate:   "2026-03-08",
+		VitalityScore: domain.VitalityScore{Overall: 78, MealAdherence: 85, TrainingAdherence: 100},
+		Narrative:     domain.DebriefNarrative{Text: "Solid week of training.", Model: "template"},
+		DailyBreakdown: []domain.DebriefDayPoint{
+			{Date: "2026-03-02", DayName: "Monday", DayType: domain.DayTypePerformance, TargetCalories: 2600, ConsumedCalories: 2500, CalorieDelta: -100, AvgRPE: &rpe},
+			{Date: "2026-03-03", DayName: "Tuesday", DayType: domain.DayTypeFatburner, TargetCalories: 2000, ConsumedCalories: 2400, CalorieDelta: 400},
+		},
+		AutoClosedDrafts: 1,
+	}
+	for i := range 12 { // Enough to spill onto a second page
+		debrief.Recommendations = append(debrief.Recommendations, domain.TacticalRecommendation{
+			Priority: 1, Category: "nutrition", Summary: fmt.Sprintf("Recommendation %d", i+1),
+			Rationale: "Protein fell short on rest days.", ActionItems: []string{"Add a shake", "Prep lunches"},
+		})
+	}
+	heatmap := &domain.FatigueHeatmap{CompareDays: 7, OverallScore: 40, Muscles: []domain.MuscleFatigueDelta{
+		{DisplayName: "Quads", CurrentPercent: 65, Color: "#f97316"},
+		{DisplayName: "Chest", CurrentPercent: 20, Color: "#22c55e"},
+	}}
+
+	pdf, err := WeeklyDebriefPDF(debrief, heatmap, s.now)
+	s.Require().NoError(err)
+
+	pages := s.pageContents(pdf)
+	s.Require().GreaterOrEqual(len(pages), 2)
+	all := strings.Join(pages, "\n")
+	for _, want := range []string{"(Weekly Debrief)", "(78)", "(Solid week of training.)", "(Mon 2 Mar)", "(fatburner)", "(+400)", "(7.5)", "(Quads)", "(12. Recommendation 12)", "(Data quality:"} {
+		s.Contains(all, want)
+	}
+	s.Contains(pages[len(pages)-1], fmt.Sprintf("(Page %d of %d)", len(pages), len(pages)))
+	s.Less(strings.Index(all, "(Quads)"), strings.Index(all, "(Chest)"), "most fatigued first")
+}
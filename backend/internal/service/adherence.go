@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// AdherenceService builds the adherence heatmap and manages sick/travel day markers.
+type AdherenceService struct {
+	dailyLogStore     *store.DailyLogStore
+	dayExemptionStore *store.DayExemptionStore
+	profileStore      *store.ProfileStore
+}
+
+// NewAdherenceService creates a new AdherenceService.
+func NewAdherenceService(dailyLogStore *store.DailyLogStore, dayExemptionStore *store.DayExemptionStore) *AdherenceService {
+	return &AdherenceService{
+		dailyLogStore:     dailyLogStore,
+		dayExemptionStore: dayExemptionStore,
+	}
+}
+
+// SetProfileStore applies the user's adherence tolerances; without it (or
+// without a profile) the defaults are used.
+func (s *AdherenceService) SetProfileStore(ps *store.ProfileStore) {
+	s.profileStore = ps
+}
+
+// tolerances returns the user's adherence tolerances, or the defaults.
+func (s *AdherenceService) tolerances(ctx context.Context) (domain.AdherenceTolerances, error) {
+	if s.profileStore == nil {
+		return domain.DefaultAdherenceTolerances, nil
+	}
+	profile, err := s.profileStore.Get(ctx)
+	if errors.Is(err, store.ErrProfileNotFound) {
+		return domain.DefaultAdherenceTolerances, nil
+	}
+	if err != nil {
+		return domain.AdherenceTolerances{}, err
+	}
+	return profile.AdherenceTolerances.WithDefaults(), nil
+}
+
+// GetCalendar classifies every day of the last `months` months up to and including now.
+func (s *AdherenceService) GetCalendar(ctx context.Context, months int, now time.Time) (*domain.AdherenceCalendar, error) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -months, 1)
+	startDate, endDate := start.Format("2006-01-02"), end.Format("2006-01-02")
+
+	logs, err := s.dailyLogStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	exemptions, err := s.dayExemptionStore.ListRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerances, err := s.tolerances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := domain.BuildAdherenceCalendar(start, end, logs, exemptions, tolerances)
+	return &cal, nil
+}
+
+// GetMacroCompliance judges each macro for the last `days` days up to and
+// including now, with rolling 7-day rates.
+func (s *AdherenceService) GetMacroCompliance(ctx context.Context, days int, now time.Time) (*domain.MacroComplianceReport, error) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -(days - 1))
+	// The first rolling window reaches back before start.
+	fromDate := start.AddDate(0, 0, -(domain.MacroComplianceWindowDays - 1)).Format("2006-01-02")
+	endDate := end.Format("2006-01-02")
+
+	logs, err := s.dailyLogStore.ListByDateRange(ctx, fromDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	exemptions, err := s.dayExemptionStore.ListRange(ctx, fromDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerances, err := s.tolerances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.BuildMacroCompliance(start, end, logs, exemptions, tolerances)
+	return &report, nil
+}
+
+// SetExemption marks a day as sick or travelling.
+func (s *AdherenceService) SetExemption(ctx context.Context, e domain.DayExemption) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	return s.dayExemptionStore.Upsert(ctx, e)
+}
+
+// ClearExemption removes a day's sick/travel marker.
+// Returns store.ErrDayExemptionNotFound if the day had none.
+func (s *AdherenceService) ClearExemption(ctx context.Context, date string) error {
+	return s.dayExemptionStore.Delete(ctx, date)
+}
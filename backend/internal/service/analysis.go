@@ -52,15 +52,23 @@ func (s *AnalysisService) AnalyzePlan(ctx context.Context, planID int64, analysi
 	}
 
 	// Get weight trend for trend projection (last 30 days, in-plan logs only)
-	weightTrend, _ := s.getWeightTrend(ctx, analysisDate, 30, plan.StartDate)
+	samples, _ := s.getWeightSamples(ctx, analysisDate, 30, plan.StartDate)
 
 	// Perform analysis
 	input := domain.AnalysisInput{
 		Plan:             plan,
 		ActualWeightKg:   actualWeight,
 		TolerancePercent: profile.RecalibrationTolerance,
-		WeightTrend:      weightTrend,
+		WeightTrend:      domain.CalculateWeightTrend(samples),
 		AnalysisDate:     analysisDate,
+		SmoothedWeight:   domain.CalculateSmoothedWeightTrend(samples, profile.WeightSmoothing),
+	}
+
+	// Recomp plans are judged on body fat since plan start
+	if plan.IsRecomp() {
+		if err := s.addBodyFatTrack(ctx, &input); err != nil {
+			return nil, err
+		}
 	}
 
 	return domain.CalculateDualTrackAnalysis(input)
@@ -77,6 +85,61 @@ func (s *AnalysisService) AnalyzeActivePlan(ctx context.Context, analysisDate ti
 	return s.AnalyzePlan(ctx, plan.ID, analysisDate)
 }
 
+// eventProjectionDays is the weigh-in window the event projection fits its trend to.
+const eventProjectionDays = 28
+
+// ProjectEvent projects an active event plan's weight trend to the event date
+// and estimates the probability of making weight. Fits the last 4 weeks of
+// in-plan weigh-ins, corrected for weigh-in time.
+func (s *AnalysisService) ProjectEvent(ctx context.Context, planID int64, asOf time.Time) (*domain.EventProjection, error) {
+	plan, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive() {
+		return nil, domain.ErrPlanNotFound
+	}
+
+	samples, err := s.getWeightSamples(ctx, asOf, eventProjectionDays, plan.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ProjectEvent(plan, samples, asOf)
+}
+
+// ProjectActiveEvent projects the currently active plan to its event date.
+func (s *AnalysisService) ProjectActiveEvent(ctx context.Context, asOf time.Time) (*domain.EventProjection, error) {
+	plan, err := s.planStore.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ProjectEvent(ctx, plan.ID, asOf)
+}
+
+// addBodyFatTrack loads in-plan body fat readings up to the analysis date
+// and sets the trend and latest reading on the input.
+func (s *AnalysisService) addBodyFatTrack(ctx context.Context, input *domain.AnalysisInput) error {
+	samples, err := s.logStore.ListBodyFat(ctx, input.Plan.StartDate.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	endDateStr := input.AnalysisDate.Format("2006-01-02")
+	var inRange []domain.BodyFatSample
+	for _, sample := range samples {
+		if sample.Date <= endDateStr {
+			inRange = append(inRange, sample)
+		}
+	}
+	if len(inRange) == 0 {
+		return nil
+	}
+
+	input.LatestBodyFatPercent = &inRange[len(inRange)-1].Percent
+	input.BodyFatTrend = domain.CalculateBodyFatTrend(inRange)
+	return nil
+}
+
 // getRolling7DayWeight calculates the rolling 7-day average weight using
 // only samples logged on or after planStartDate.
 // Returns error if insufficient data (fewer than 1 weight entry in last 7 days).
@@ -116,10 +179,10 @@ func (s *AnalysisService) getRolling7DayWeight(ctx context.Context, asOfDate, pl
 	return sum / float64(len(validSamples)), nil
 }
 
-// getWeightTrend calculates the weight trend over the specified number of days
-// using only samples logged on or after planStartDate.
-// Returns nil if insufficient data for trend calculation.
-func (s *AnalysisService) getWeightTrend(ctx context.Context, asOfDate time.Time, days int, planStartDate time.Time) (*domain.WeightTrend, error) {
+// getWeightSamples returns the weight samples over the specified number of days
+// using only samples logged on or after planStartDate, corrected for weigh-in
+// time of day.
+func (s *AnalysisService) getWeightSamples(ctx context.Context, asOfDate time.Time, days int, planStartDate time.Time) ([]domain.WeightSample, error) {
 	startDate := asOfDate.AddDate(0, 0, -(days - 1))
 	if planStartDate.After(startDate) {
 		startDate = planStartDate
@@ -127,7 +190,7 @@ func (s *AnalysisService) getWeightTrend(ctx context.Context, asOfDate time.Time
 	startDateStr := startDate.Format("2006-01-02")
 	endDateStr := asOfDate.Format("2006-01-02")
 
-	samples, err := s.logStore.ListWeights(ctx, startDateStr)
+	samples, err := correctedWeights(ctx, s.logStore, startDateStr)
 	if err != nil {
 		return nil, err
 	}
@@ -140,5 +203,5 @@ func (s *AnalysisService) getWeightTrend(ctx context.Context, asOfDate time.Time
 		}
 	}
 
-	return domain.CalculateWeightTrend(validSamples), nil
+	return validSamples, nil
 }
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// AnnualReviewService generates and persists year-in-review summaries.
+type AnnualReviewService struct {
+	logStore       *store.DailyLogStore
+	sessionStore   *store.TrainingSessionStore
+	profileStore   *store.ProfileStore
+	planStore      *store.NutritionPlanStore
+	reviewStore    *store.AnnualReviewStore
+	retroEditStore *store.RetroEditStore
+	ollamaService  *OllamaService
+	clocked
+}
+
+// NewAnnualReviewService creates a new AnnualReviewService.
+func NewAnnualReviewService(
+	ls *store.DailyLogStore,
+	ss *store.TrainingSessionStore,
+	ps *store.ProfileStore,
+	plans *store.NutritionPlanStore,
+	rs *store.AnnualReviewStore,
+	os *OllamaService,
+) *AnnualReviewService {
+	return &AnnualReviewService{
+		logStore:      ls,
+		sessionStore:  ss,
+		profileStore:  ps,
+		planStore:     plans,
+		reviewStore:   rs,
+		ollamaService: os,
+	}
+}
+
+// SetRetroEditStore sets the store of retro-edits to locked days. This is
+// optional - if not set, no week is excluded from vitality streaks.
+func (s *AnnualReviewService) SetRetroEditStore(rs *store.RetroEditStore) {
+	s.retroEditStore = rs
+}
+
+// LastCompletedYear returns the most recent year that has ended.
+func (s *AnnualReviewService) LastCompletedYear() int {
+	return s.now().Year() - 1
+}
+
+// GetAnnualReview returns the review for a year. A stored review generated
+// after the year ended is returned as is; otherwise (no review yet, a
+// year-to-date review, or refresh) the review is generated and stored.
+func (s *AnnualReviewService) GetAnnualReview(ctx context.Context, year int, refresh bool) (*domain.AnnualReview, error) {
+	if !refresh {
+		stored, err := s.reviewStore.Get(ctx, year)
+		if err == nil && domain.IsAnnualReviewFinal(stored) {
+			return stored, nil
+		}
+		if err != nil && !errors.Is(err, store.ErrAnnualReviewNotFound) {
+			return nil, err
+		}
+	}
+	return s.GenerateAnnualReview(ctx, year)
+}
+
+// GenerateAnnualReview builds the review for a year (the year to date for
+// the current year), generates its narrative and stores it.
+func (s *AnnualReviewService) GenerateAnnualReview(ctx context.Context, year int) (*domain.AnnualReview, error) {
+	now := s.now()
+	startDate, endDate, err := domain.AnnualReviewPeriod(year, now)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.logStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// One query for the year's sessions rather than two per log
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]store.SessionsByDate, len(sessions))
+	for _, day := range sessions {
+		byDate[day.Date] = day
+	}
+	for i := range logs {
+		day := byDate[logs[i].Date]
+		logs[i].PlannedSessions = day.PlannedSessions
+		logs[i].ActualSessions = day.ActualSessions
+	}
+
+	plans, err := s.plansStartedBy(ctx, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score weeks against the profile goal; plans change within a year, so
+	// the currently active one doesn't describe past weeks
+	scoring := domain.SelectVitalityProfile(profile, nil)
+	if profile != nil {
+		scoring.WeightSmoothing = profile.WeightSmoothing
+		scoring.Tolerances = profile.AdherenceTolerances
+	}
+
+	var retroEdited map[string]bool
+	if s.retroEditStore != nil {
+		edits, err := s.retroEditStore.ListByDateRange(ctx, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		retroEdited = domain.RetroEditedDates(edits)
+	}
+
+	review := domain.BuildAnnualReview(domain.AnnualReviewInput{
+		Year:             year,
+		EndDate:          endDate,
+		DailyLogs:        logs,
+		Plans:            plans,
+		Scoring:          scoring,
+		RetroEditedDates: retroEdited,
+	})
+
+	narrative := s.ollamaService.GenerateAnnualReviewNarrative(ctx, &review)
+	review.Narrative = narrative.Text
+	review.GeneratedByLLM = narrative.GeneratedByLLM
+	review.NarrativeModel = narrative.Model
+	review.GeneratedAt = now.UTC().Format(time.RFC3339)
+
+	// Storage is a cache for the narrative; a failed save still returns the review
+	if err := s.reviewStore.Save(ctx, &review, now); err != nil {
+		log.Printf("annual review: failed to store %d review: %v", year, err)
+	}
+	return &review, nil
+}
+
+// plansStartedBy loads, with weekly targets, every plan that started on or
+// before date. Whether a plan overlaps the year is decided in the domain.
+func (s *AnnualReviewService) plansStartedBy(ctx context.Context, date string) ([]*domain.NutritionPlan, error) {
+	summaries, err := s.planStore.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []*domain.NutritionPlan
+	for _, summary := range summaries {
+		if summary.StartDate.Format("2006-01-02") > date {
+			continue
+		}
+		plan, err := s.planStore.GetByID(ctx, summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
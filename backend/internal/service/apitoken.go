@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ErrInvalidAPIToken is returned when a bearer token is unknown, expired or revoked.
+var ErrInvalidAPIToken = errors.New("invalid, expired or revoked api token")
+
+// APITokenService creates, authenticates and revokes personal API tokens.
+type APITokenService struct {
+	tokenStore *store.APITokenStore
+}
+
+// NewAPITokenService creates a new APITokenService.
+func NewAPITokenService(tokenStore *store.APITokenStore) *APITokenService {
+	return &APITokenService{tokenStore: tokenStore}
+}
+
+// Create generates and stores a token. The returned plaintext is not
+// recoverable afterwards; the token's signing secret is returned with it.
+func (s *APITokenService) Create(ctx context.Context, name string, scopes []domain.TokenScope, expiresAt *time.Time, requireSignature bool, now time.Time) (*domain.APIToken, string, error) {
+	token, plaintext, err := domain.NewAPIToken(name, scopes, expiresAt, requireSignature, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.tokenStore.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, plaintext, nil
+}
+
+// List returns all tokens, without their secrets.
+func (s *APITokenService) List(ctx context.Context) ([]domain.APIToken, error) {
+	return s.tokenStore.List(ctx)
+}
+
+// HasActiveAdminToken reports whether any unrevoked, unexpired token carries
+// the admin scope. Until one does, tokens can be managed without a token.
+func (s *APITokenService) HasActiveAdminToken(ctx context.Context, now time.Time) (bool, error) {
+	tokens, err := s.tokenStore.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range tokens {
+		if t.IsActive(now) && t.HasScope(domain.TokenScopeAdmin) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Revoke stops a token from authenticating, effective from the next request.
+func (s *APITokenService) Revoke(ctx context.Context, id int64, now time.Time) error {
+	return s.tokenStore.Revoke(ctx, id, now)
+}
+
+// GetUsage returns a token with its per-endpoint request counts.
+func (s *APITokenService) GetUsage(ctx context.Context, id int64) (*domain.APIToken, []domain.APITokenEndpointUsage, error) {
+	token, err := s.tokenStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	usage, err := s.tokenStore.ListUsage(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, usage, nil
+}
+
+// Authenticate resolves a plaintext bearer token. When the request is signed,
+// or the token requires signing, the signature is verified against the
+// token's signing secret and its nonce claimed so the request can't be
+// replayed.
+// Returns ErrInvalidAPIToken if the token is unknown, expired or revoked, or
+// one of the domain signed-request errors.
+func (s *APITokenService) Authenticate(ctx context.Context, plaintext string, signed domain.SignedAPIRequest, now time.Time) (*domain.APIToken, error) {
+	token, err := s.tokenStore.GetByHash(ctx, domain.HashAPIToken(plaintext))
+	if errors.Is(err, store.ErrAPITokenNotFound) {
+		return nil, ErrInvalidAPIToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !token.IsActive(now) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	if !signed.IsSigned() {
+		if token.RequireSignature {
+			return nil, domain.ErrAPIRequestUnsigned
+		}
+		return token, nil
+	}
+	if err := signed.Verify(token.SigningSecret, now); err != nil {
+		return nil, err
+	}
+	claimed, err := s.tokenStore.ClaimNonce(ctx, token.ID, signed.Nonce, now, now.Add(-2*domain.APITokenSignatureWindow))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, domain.ErrAPIRequestReplayed
+	}
+	return token, nil
+}
+
+// RecordUsage audits an authenticated request against the token.
+func (s *APITokenService) RecordUsage(ctx context.Context, token *domain.APIToken, endpoint, ip string, now time.Time) error {
+	return s.tokenStore.RecordUsage(ctx, token.ID, endpoint, ip, now)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// sessionLoadApplier applies an archetype's fatigue for a logged session.
+// Implemented by FatigueService.
+type sessionLoadApplier interface {
+	ApplySessionLoad(ctx context.Context, sessionID int64, archetype domain.Archetype, durationMin int, rpe *int) (*domain.SessionFatigueReport, error)
+}
+
+// archetypeClassifier refines archetype inferences the rules aren't sure of.
+// Implemented by OllamaService.
+type archetypeClassifier interface {
+	InferArchetype(ctx context.Context, session domain.TrainingSession, recent []domain.Archetype) *domain.ArchetypeInference
+}
+
+// archetypeInferrer infers archetypes for a day's unassigned sessions.
+// Implemented by ArchetypeInferenceService.
+type archetypeInferrer interface {
+	InferForDate(ctx context.Context, date string) ([]ArchetypeAssignment, error)
+}
+
+// ArchetypeAssignment is the outcome of inferring one session's archetype:
+// either its fatigue was applied or it was queued for review.
+type ArchetypeAssignment struct {
+	SessionID    int64
+	SessionOrder int
+	Inference    domain.ArchetypeInference
+	Applied      bool
+	Queued       bool
+	Report       *domain.SessionFatigueReport // Set when Applied
+}
+
+// ArchetypeInferenceService assigns archetypes to logged sessions that lack
+// one and applies their fatigue, queueing low-confidence inferences for review.
+type ArchetypeInferenceService struct {
+	sessionStore *store.TrainingSessionStore
+	reviewStore  *store.ArchetypeReviewStore
+	logStore     *store.DailyLogStore
+	fatigue      sessionLoadApplier
+	classifier   archetypeClassifier
+	clocked
+}
+
+// NewArchetypeInferenceService creates a new ArchetypeInferenceService.
+func NewArchetypeInferenceService(ss *store.TrainingSessionStore, rs *store.ArchetypeReviewStore, ls *store.DailyLogStore, fatigue sessionLoadApplier) *ArchetypeInferenceService {
+	return &ArchetypeInferenceService{
+		sessionStore: ss,
+		reviewStore:  rs,
+		logStore:     ls,
+		fatigue:      fatigue,
+	}
+}
+
+// SetClassifier enables the LLM fallback for low-confidence inferences.
+// This is optional - if not set, only the rules are used.
+func (s *ArchetypeInferenceService) SetClassifier(c archetypeClassifier) {
+	s.classifier = c
+}
+
+// InferForDate infers archetypes for a day's actual sessions that have none
+// and haven't been queued before. Confident inferences apply fatigue; the
+// rest are queued for review. Returns store.ErrDailyLogNotFound if no log
+// exists for that date.
+func (s *ArchetypeInferenceService) InferForDate(ctx context.Context, date string) ([]ArchetypeAssignment, error) {
+	log, err := s.logStore.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionStore.GetActualByLogID(ctx, log.ID)
+	if err != nil {
+		return nil, err
+	}
+	reviewed, err := s.reviewStore.ReviewedSessionIDs(ctx, log.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]ArchetypeAssignment, 0)
+	for _, session := range sessions {
+		if session.Archetype != "" || !domain.NeedsArchetype(session.Type) || reviewed[session.ID] {
+			continue
+		}
+		// Earlier sessions of the day were assigned above, so they count
+		recent, err := s.sessionStore.RecentArchetypes(ctx, date, session.SessionOrder, domain.ArchetypeRotationLookback)
+		if err != nil {
+			return nil, err
+		}
+
+		assignment := ArchetypeAssignment{
+			SessionID:    session.ID,
+			SessionOrder: session.SessionOrder,
+			Inference:    s.infer(ctx, session, recent),
+		}
+		if assignment.Inference.Confident() {
+			assignment.Report, assignment.Applied, err = s.apply(ctx, session, assignment.Inference.Archetype)
+		} else {
+			assignment.Queued, err = s.reviewStore.Create(ctx, session.ID, assignment.Inference)
+		}
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+// infer runs the rules, asking the classifier when they aren't confident.
+// The classifier's answer is kept only if it is more confident.
+func (s *ArchetypeInferenceService) infer(ctx context.Context, session domain.TrainingSession, recent []domain.Archetype) domain.ArchetypeInference {
+	inference := domain.InferArchetype(session, recent)
+	if inference.Confident() || s.classifier == nil {
+		return inference
+	}
+	if llm := s.classifier.InferArchetype(ctx, session, recent); llm != nil && llm.Confidence > inference.Confidence {
+		return *llm
+	}
+	return inference
+}
+
+// apply assigns the archetype and applies its fatigue. Sessions that already
+// have an archetype are left alone so fatigue is never applied twice.
+func (s *ArchetypeInferenceService) apply(ctx context.Context, session domain.TrainingSession, archetype domain.Archetype) (*domain.SessionFatigueReport, bool, error) {
+	assigned, err := s.sessionStore.SetArchetype(ctx, session.ID, archetype)
+	if err != nil || !assigned {
+		return nil, false, err
+	}
+	report, err := s.fatigue.ApplySessionLoad(ctx, session.ID, archetype, session.DurationMin, session.PerceivedIntensity)
+	if err != nil {
+		return nil, false, err
+	}
+	return report, true, nil
+}
+
+// ListPendingReviews returns the inferences awaiting review, oldest first.
+func (s *ArchetypeInferenceService) ListPendingReviews(ctx context.Context) ([]domain.ArchetypeReview, error) {
+	return s.reviewStore.ListPending(ctx)
+}
+
+// ConfirmReview applies fatigue for a queued session. An empty archetype
+// accepts the suggestion. Returns store.ErrArchetypeReviewNotFound,
+// domain.ErrArchetypeReviewResolved or domain.ErrArchetypeRequired.
+func (s *ArchetypeInferenceService) ConfirmReview(ctx context.Context, id int64, archetype domain.Archetype) (*domain.ArchetypeReview, *domain.SessionFatigueReport, error) {
+	review, err := s.reviewStore.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if review.Status != domain.ArchetypeReviewPending {
+		return nil, nil, domain.ErrArchetypeReviewResolved
+	}
+	if archetype == "" {
+		archetype = review.Inference.Archetype
+	}
+	if archetype == "" {
+		return nil, nil, domain.ErrArchetypeRequired
+	}
+
+	if err := s.reviewStore.Resolve(ctx, id, domain.ArchetypeReviewConfirmed, archetype, s.now()); err != nil {
+		return nil, nil, err
+	}
+	session, err := s.sessionStore.GetByID(ctx, review.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	report, _, err := s.apply(ctx, *session, archetype)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	review, err = s.reviewStore.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return review, report, nil
+}
+
+// DismissReview closes a review without applying fatigue.
+// Returns store.ErrArchetypeReviewNotFound or domain.ErrArchetypeReviewResolved.
+func (s *ArchetypeInferenceService) DismissReview(ctx context.Context, id int64) (*domain.ArchetypeReview, error) {
+	if _, err := s.reviewStore.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.reviewStore.Resolve(ctx, id, domain.ArchetypeReviewDismissed, "", s.now()); err != nil {
+		return nil, err
+	}
+	return s.reviewStore.Get(ctx, id)
+}
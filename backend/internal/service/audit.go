@@ -60,6 +60,7 @@ type AuditService struct {
 	ollamaURL           string
 	ollamaClient        *http.Client
 	cache               *explanationCache
+	clocked
 }
 
 // NewAuditService creates a new AuditService.
@@ -111,13 +112,13 @@ func (s *AuditService) GetAuditStatus(ctx context.Context) (*domain.AuditStatus,
 		HasMismatch: len(mismatches) > 0,
 		Severity:    severity,
 		Mismatches:  mismatches,
-		CheckedAt:   time.Now().UTC().Format(time.RFC3339),
+		CheckedAt:   s.now().UTC().Format(time.RFC3339),
 	}, nil
 }
 
 // buildAuditContext gathers all data needed for rule evaluation.
 func (s *AuditService) buildAuditContext(ctx context.Context) (*domain.AuditContext, error) {
-	now := time.Now()
+	now := s.now()
 	today := now.Format("2006-01-02")
 
 	auditCtx := &domain.AuditContext{}
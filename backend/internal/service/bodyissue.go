@@ -11,6 +11,7 @@ import (
 // BodyIssueService handles business logic for body part issues.
 type BodyIssueService struct {
 	bodyIssueStore *store.BodyIssueStore
+	clocked
 }
 
 // NewBodyIssueService creates a new BodyIssueService.
@@ -68,7 +69,7 @@ func (s *BodyIssueService) CalculateFatigueModifiers(ctx context.Context) (map[d
 		return nil, err
 	}
 
-	today := time.Now()
+	today := s.now()
 	modifiers := make(map[domain.MuscleGroup]float64)
 
 	for _, issue := range issues {
@@ -98,7 +99,7 @@ func (s *BodyIssueService) GetFatigueModifiersList(ctx context.Context) ([]Muscl
 		return nil, err
 	}
 
-	today := time.Now()
+	today := s.now()
 
 	// Group issues by muscle and calculate modifiers
 	muscleData := make(map[domain.MuscleGroup]struct {
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// BodyStatusService aggregates muscle fatigue, body issues and today's
+// readiness into the daily body status, keeping a snapshot per day.
+type BodyStatusService struct {
+	fatigueService  *FatigueService
+	dailyLogService *DailyLogService
+	bodyIssueStore  *store.BodyIssueStore
+	snapshotStore   *store.BodyStatusStore
+	joints          jointIntegritySource // Optional: joint recovery series
+	clocked
+}
+
+// NewBodyStatusService creates a new BodyStatusService.
+func NewBodyStatusService(fs *FatigueService, dls *DailyLogService, bis *store.BodyIssueStore, ss *store.BodyStatusStore) *BodyStatusService {
+	return &BodyStatusService{
+		fatigueService:  fs,
+		dailyLogService: dls,
+		bodyIssueStore:  bis,
+		snapshotStore:   ss,
+	}
+}
+
+// SetJointIntegritySource caps joint integrity by the joint recovery series.
+func (s *BodyStatusService) SetJointIntegritySource(js jointIntegritySource) {
+	s.joints = js
+}
+
+// GetToday returns today's aggregated body status and stores it as the
+// day's snapshot. A missing daily log leaves the neural side out.
+func (s *BodyStatusService) GetToday(ctx context.Context) (*domain.DailyBodyStatus, error) {
+	now := s.now()
+
+	fatigue, err := s.fatigueService.GetBodyStatus(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.bodyIssueStore.GetActiveIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	todayLog, err := s.dailyLogService.GetToday(ctx, now)
+	if errors.Is(err, store.ErrDailyLogNotFound) {
+		todayLog = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	input := domain.BodyStatusInput{
+		Fatigue:       fatigue,
+		Issues:        issues,
+		TodayLog:      todayLog,
+		NeuralBattery: s.dailyLogService.GetNeuralBattery(ctx),
+		AsOf:          now,
+	}
+	if s.joints != nil {
+		series, err := s.joints.CurrentJointIntegrity(ctx)
+		if err != nil {
+			return nil, err
+		}
+		input.JointSeries = series
+	}
+	status := domain.AggregateBodyStatus(input)
+
+	// The snapshot is history only; a failed save doesn't fail the read
+	if err := s.snapshotStore.Save(ctx, &status, now); err != nil {
+		log.Printf("body status: failed to store %s snapshot: %v", status.Date, err)
+	}
+	return &status, nil
+}
+
+// GetBodyStatus returns today's aggregated body status for consumers that
+// take a plain BodyStatus, such as the solver's prompt.
+func (s *BodyStatusService) GetBodyStatus(ctx context.Context) (*domain.BodyStatus, error) {
+	daily, err := s.GetToday(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &daily.Status, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// CaffeineService handles caffeine intake logging.
+type CaffeineService struct {
+	caffeineStore *store.CaffeineStore
+	clocked
+}
+
+// NewCaffeineService creates a new CaffeineService.
+func NewCaffeineService(cs *store.CaffeineStore) *CaffeineService {
+	return &CaffeineService{caffeineStore: cs}
+}
+
+// Log validates and stores an entry. An entry for today without a time is
+// taken as just now.
+func (s *CaffeineService) Log(ctx context.Context, entry domain.CaffeineEntry) (*domain.CaffeineEntry, error) {
+	now := s.now()
+	if entry.Time == "" && entry.Date == now.Format("2006-01-02") {
+		entry.Time = now.Format("15:04")
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.caffeineStore.Create(ctx, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListForDate returns the entries logged on a date, by time.
+func (s *CaffeineService) ListForDate(ctx context.Context, date string) ([]domain.CaffeineEntry, error) {
+	return s.caffeineStore.ListByDateRange(ctx, date, date)
+}
+
+// Delete removes an entry.
+func (s *CaffeineService) Delete(ctx context.Context, id int64) error {
+	return s.caffeineStore.Delete(ctx, id)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// CalorieEstimationService estimates active calories from logged sessions for
+// days without wearable data.
+type CalorieEstimationService struct {
+	configStore  *store.TrainingConfigStore
+	logStore     *store.DailyLogStore
+	sessionStore *store.TrainingSessionStore
+	profileStore *store.ProfileStore
+	clocked
+}
+
+// NewCalorieEstimationService creates a new CalorieEstimationService.
+func NewCalorieEstimationService(cs *store.TrainingConfigStore, ls *store.DailyLogStore, ss *store.TrainingSessionStore, ps *store.ProfileStore) *CalorieEstimationService {
+	return &CalorieEstimationService{
+		configStore:  cs,
+		logStore:     ls,
+		sessionStore: ss,
+		profileStore: ps,
+	}
+}
+
+// Estimate estimates the active burn of sessions at weightKg, using the METs
+// in training_configs. Without a profile every session is estimated from MET,
+// since the heart-rate method needs age and sex.
+func (s *CalorieEstimationService) Estimate(ctx context.Context, date string, weightKg float64, sessions []domain.TrainingSession) (domain.ActiveCalorieEstimate, error) {
+	mets, profile, err := s.estimationInputs(ctx)
+	if err != nil {
+		return domain.ActiveCalorieEstimate{}, err
+	}
+	return domain.EstimateActiveCalories(date, sessions, mets, weightKg, profile, s.now()), nil
+}
+
+// EstimateDays estimates the active burn of each day's sessions at that day's
+// weight, loading METs and the profile once for all of them.
+func (s *CalorieEstimationService) EstimateDays(ctx context.Context, days []store.SessionDay) ([]domain.ActiveCalorieEstimate, error) {
+	mets, profile, err := s.estimationInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	estimates := make([]domain.ActiveCalorieEstimate, len(days))
+	for i, day := range days {
+		estimates[i] = domain.EstimateActiveCalories(day.Date, day.Sessions, mets, day.WeightKg, profile, now)
+	}
+	return estimates, nil
+}
+
+// estimationInputs loads the configured METs per training type and the
+// profile, which is nil when none exists.
+func (s *CalorieEstimationService) estimationInputs(ctx context.Context) (map[domain.TrainingType]float64, *domain.UserProfile, error) {
+	configs, err := s.configStore.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	mets := make(map[domain.TrainingType]float64, len(configs))
+	for _, cfg := range configs {
+		mets[cfg.Type] = cfg.MET
+	}
+
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		if !errors.Is(err, store.ErrProfileNotFound) {
+			return nil, nil, err
+		}
+		profile = nil
+	}
+	return mets, profile, nil
+}
+
+// EstimateForDate estimates a logged day's active burn from its actual sessions.
+// Returns store.ErrDailyLogNotFound if no log exists for that date.
+func (s *CalorieEstimationService) EstimateForDate(ctx context.Context, date string) (*domain.ActiveCalorieEstimate, error) {
+	log, err := s.logStore.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionStore.GetActualByLogID(ctx, log.ID)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.Estimate(ctx, date, log.WeightKg, sessions)
+	if err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// ApplyForDate estimates a day's active burn and stores it unless the day
+// already has wearable data. Reports whether the estimate was stored.
+func (s *CalorieEstimationService) ApplyForDate(ctx context.Context, date string) (*domain.ActiveCalorieEstimate, bool, error) {
+	estimate, err := s.EstimateForDate(ctx, date)
+	if err != nil {
+		return nil, false, err
+	}
+	applied, err := s.logStore.UpdateEstimatedActiveCalories(ctx, date, estimate.Kcal)
+	if err != nil {
+		return nil, false, err
+	}
+	return estimate, applied, nil
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// challengeRefreshHour is the local hour the nightly evaluation runs, just
+// after the previous day closes.
+const challengeRefreshHour = 1
+
+// challengeSource provides the challenges running during a date range.
+// Implemented by ChallengeService.
+type challengeSource interface {
+	During(ctx context.Context, start, end string) ([]domain.ChallengeProgress, error)
+}
+
+// ChallengeService runs time-boxed self-challenges, evaluating their
+// progress from the daily logs and announcing completions and failures.
+type ChallengeService struct {
+	challengeStore *store.ChallengeStore
+	logStore       *store.DailyLogStore
+	sessionStore   *store.TrainingSessionStore
+	notifiers      []notifier
+	jobs           *JobMonitor
+	clocked
+}
+
+// NewChallengeService creates a new ChallengeService. Completions and
+// failures go to the digest's delivery channels when any is configured.
+func NewChallengeService(cs *store.ChallengeStore, ls *store.DailyLogStore, ss *store.TrainingSessionStore) *ChallengeService {
+	return &ChallengeService{
+		challengeStore: cs,
+		logStore:       ls,
+		sessionStore:   ss,
+		notifiers:      notifiersFromEnv(),
+	}
+}
+
+// SetJobMonitor enables heartbeats for the nightly evaluation.
+func (s *ChallengeService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Create validates and stores a challenge. It starts today unless a start
+// date is given.
+func (s *ChallengeService) Create(ctx context.Context, c domain.Challenge) (*domain.ChallengeProgress, error) {
+	if c.StartDate == "" {
+		c.StartDate = s.today()
+	}
+	if err := c.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.challengeStore.Create(ctx, &c); err != nil {
+		return nil, err
+	}
+	return s.evaluateOne(ctx, c)
+}
+
+// List evaluates the active challenges, then returns every challenge with its
+// progress, newest first.
+func (s *ChallengeService) List(ctx context.Context) ([]domain.ChallengeProgress, error) {
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	challenges, err := s.challengeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.evaluate(ctx, challenges, s.today())
+}
+
+// Get returns a challenge with its progress.
+func (s *ChallengeService) Get(ctx context.Context, id int64) (*domain.ChallengeProgress, error) {
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	c, err := s.challengeStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.evaluateOne(ctx, *c)
+}
+
+// Abandon stops an active challenge today.
+func (s *ChallengeService) Abandon(ctx context.Context, id int64) (*domain.ChallengeProgress, error) {
+	c, err := s.challengeStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != domain.ChallengeActive {
+		return nil, domain.ErrChallengeNotActive
+	}
+	c.Status, c.EndedDate = domain.ChallengeAbandoned, s.today()
+	if err := s.challengeStore.End(ctx, id, c.Status, c.EndedDate); err != nil {
+		return nil, err
+	}
+	return s.evaluateOne(ctx, *c)
+}
+
+// Delete removes a challenge.
+func (s *ChallengeService) Delete(ctx context.Context, id int64) error {
+	return s.challengeStore.Delete(ctx, id)
+}
+
+// PendingNotifications returns the completions and failures not yet acknowledged.
+func (s *ChallengeService) PendingNotifications(ctx context.Context) ([]domain.Challenge, error) {
+	return s.challengeStore.ListPendingNotifications(ctx)
+}
+
+// DismissNotification acknowledges a challenge's completion or failure.
+func (s *ChallengeService) DismissNotification(ctx context.Context, id int64) error {
+	return s.challengeStore.DismissNotification(ctx, id)
+}
+
+// Refresh evaluates the active challenges as of today and records the ones
+// that completed or failed. Each one raises a pending notification and is
+// pushed to the configured channels (best-effort).
+func (s *ChallengeService) Refresh(ctx context.Context) error {
+	active, err := s.challengeStore.ListByStatus(ctx, domain.ChallengeActive)
+	if err != nil || len(active) == 0 {
+		return err
+	}
+	progress, err := s.evaluate(ctx, active, s.today())
+	if err != nil {
+		return err
+	}
+
+	for _, p := range progress {
+		if p.Status == domain.ChallengeActive {
+			continue
+		}
+		if err := s.challengeStore.End(ctx, p.ID, p.Status, p.EndedDate); err != nil {
+			return err
+		}
+		for _, n := range s.notifiers {
+			if err := n.Notify(ctx, domain.ChallengeEventTitle(p), domain.ChallengeEventText(p)); err != nil {
+				log.Printf("challenge: %s delivery failed: %v", n.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// During returns the challenges running at some point between start and end
+// (inclusive), evaluated as of end or today, whichever is earlier. Challenges
+// that ended later are shown as they stood then; abandoned ones are left out.
+func (s *ChallengeService) During(ctx context.Context, start, end string) ([]domain.ChallengeProgress, error) {
+	challenges, err := s.challengeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	asOf := end
+	if today := s.today(); today < asOf {
+		asOf = today
+	}
+
+	running := make([]domain.Challenge, 0)
+	for _, c := range challenges {
+		if c.Status == domain.ChallengeAbandoned || c.StartDate > end || c.EndDate() < start {
+			continue
+		}
+		if c.EndedDate != "" && c.EndedDate < start {
+			continue
+		}
+		if c.EndedDate > asOf {
+			c.Status, c.EndedDate = domain.ChallengeActive, ""
+		}
+		running = append(running, c)
+	}
+	// Oldest first for the debrief
+	for i, j := 0, len(running)-1; i < j; i, j = i+1, j-1 {
+		running[i], running[j] = running[j], running[i]
+	}
+	return s.evaluate(ctx, running, asOf)
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, evaluating the active
+// challenges once at startup and then nightly at challengeRefreshHour.
+func (s *ChallengeService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "challenges", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "challenges", time.Now()) {
+			err := s.Refresh(ctx)
+			s.jobs.Beat("challenges", time.Now(), err)
+			if err != nil {
+				log.Printf("challenge: nightly evaluation failed: %v", err)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), challengeRefreshHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *ChallengeService) evaluateOne(ctx context.Context, c domain.Challenge) (*domain.ChallengeProgress, error) {
+	progress, err := s.evaluate(ctx, []domain.Challenge{c}, s.today())
+	if err != nil {
+		return nil, err
+	}
+	return &progress[0], nil
+}
+
+// evaluate judges challenges as of a date, loading the logs and sessions
+// their days span in one pass.
+func (s *ChallengeService) evaluate(ctx context.Context, challenges []domain.Challenge, asOf string) ([]domain.ChallengeProgress, error) {
+	progress := make([]domain.ChallengeProgress, 0, len(challenges))
+	if len(challenges) == 0 {
+		return progress, nil
+	}
+
+	start, end := challenges[0].StartDate, challenges[0].EndDate()
+	for _, c := range challenges[1:] {
+		start = minDate(start, c.StartDate)
+		if e := c.EndDate(); e > end {
+			end = e
+		}
+	}
+	end = minDate(end, asOf)
+
+	var logs []domain.DailyLog
+	if start <= end {
+		var err error
+		if logs, err = s.logStore.ListByDateRange(ctx, start, end); err != nil {
+			return nil, fmt.Errorf("loading logs: %w", err)
+		}
+		sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("loading sessions: %w", err)
+		}
+		byDate := make(map[string]store.SessionsByDate, len(sessions))
+		for _, day := range sessions {
+			byDate[day.Date] = day
+		}
+		for i := range logs {
+			day := byDate[logs[i].Date]
+			logs[i].PlannedSessions = day.PlannedSessions
+			logs[i].ActualSessions = day.ActualSessions
+		}
+	}
+
+	for _, c := range challenges {
+		progress = append(progress, domain.EvaluateChallenge(c, logs, asOf))
+	}
+	return progress, nil
+}
+
+func (s *ChallengeService) today() string {
+	return s.now().Format("2006-01-02")
+}
+
+// minDate returns the earlier of two YYYY-MM-DD dates.
+func minDate(a, b string) string {
+	if b < a {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock supplies the current time for date-dependent logic: which day is
+// "today", which plan week is current, how far fatigue has recovered.
+// Wall-clock concerns (latency, job heartbeats, lease expiry, token expiry)
+// keep using time.Now so a simulated date never affects them.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the real wall clock.
+var SystemClock Clock = systemClock{}
+
+// SimClock is a Clock that only moves when told to. It lets tests and demos
+// step through multi-week flows (plan weeks, debriefs, recovery) without
+// waiting. Safe for concurrent use.
+type SimClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewSimClock creates a SimClock set to start.
+func NewSimClock(start time.Time) *SimClock {
+	return &SimClock{now: start}
+}
+
+// Now returns the simulated time.
+func (c *SimClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Advance moves the simulated time forward by d and returns the new time.
+func (c *SimClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// Set moves the simulated time to t, forwards or backwards.
+func (c *SimClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// clocked gives a service an injectable clock. The zero value uses the
+// system clock, so services embedding it need no constructor changes.
+type clocked struct {
+	clock Clock
+}
+
+// SetClock replaces the service's clock, e.g. with a SimClock.
+func (c *clocked) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+func (c *clocked) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Multi-week end-to-end scenarios depend on the simulated clock
+// moving only when told to and reaching every service that reads the time.
+type ClockSuite struct {
+	suite.Suite
+}
+
+func TestClockSuite(t *testing.T) {
+	suite.Run(t, new(ClockSuite))
+}
+
+func (s *ClockSuite) TestSimClockMovesOnlyWhenTold() {
+	start := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	clock := NewSimClock(start)
+
+	s.Equal(start, clock.Now())
+	s.Equal(start.Add(7*24*time.Hour), clock.Advance(7*24*time.Hour))
+	s.Equal(start.Add(7*24*time.Hour), clock.Now())
+
+	clock.Set(start)
+	s.Equal(start, clock.Now(), "Set can move backwards")
+}
+
+func (s *ClockSuite) TestServicesUseInjectedClock() {
+	var svc FatigueService
+	s.WithinDuration(time.Now(), svc.now(), time.Minute, "zero value uses the system clock")
+
+	start := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	svc.SetClock(NewSimClock(start))
+	s.Equal(start, svc.now())
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -17,7 +19,21 @@ type DailyLogService struct {
 	sessionStore   *store.TrainingSessionStore
 	profileStore   *store.ProfileStore
 	metabolicStore *store.MetabolicStore
+	retroEditStore *store.RetroEditStore
 	ollamaService  *OllamaService
+	estimator      calorieEstimator
+	rotation       rotationForecaster
+	rollup         weeklyRollup
+	dailyTargets   dailyTargetRefresher
+	references     referenceRangeSource
+	activityStore  *store.RecoveryActivityStore
+	clocked
+}
+
+// calorieEstimator estimates a day's active burn from its logged sessions.
+// Implemented by CalorieEstimationService.
+type calorieEstimator interface {
+	Estimate(ctx context.Context, date string, weightKg float64, sessions []domain.TrainingSession) (domain.ActiveCalorieEstimate, error)
 }
 
 // NewDailyLogService creates a new DailyLogService.
@@ -35,12 +51,98 @@ func (s *DailyLogService) SetMetabolicStore(ms *store.MetabolicStore) {
 	s.metabolicStore = ms
 }
 
+// SetRetroEditStore enables the logging lock. This is optional - if not set,
+// every day stays editable regardless of the profile's lock window.
+func (s *DailyLogService) SetRetroEditStore(rs *store.RetroEditStore) {
+	s.retroEditStore = rs
+}
+
 // SetOllamaService sets the Ollama service for AI-generated insights.
 // This is optional - if not set, insights will use templated fallbacks.
 func (s *DailyLogService) SetOllamaService(os *OllamaService) {
 	s.ollamaService = os
 }
 
+// SetCalorieEstimator enables active calorie estimation from actual sessions.
+// This is optional - if not set, active calories only come from wearables.
+func (s *DailyLogService) SetCalorieEstimator(e calorieEstimator) {
+	s.estimator = e
+}
+
+// SetStrengthRotation pre-fills planned strength sessions with the next
+// archetypes in the user's split. This is optional - if not set, planned
+// sessions keep whatever archetype they were given.
+func (s *DailyLogService) SetStrengthRotation(r rotationForecaster) {
+	s.rotation = r
+}
+
+// SetWeeklyRollup keeps the active plan's weekly actuals live as logs change.
+// This is optional - if not set, weekly actuals are left as they are.
+func (s *DailyLogService) SetWeeklyRollup(r weeklyRollup) {
+	s.rollup = r
+}
+
+// SetDailyTargetRefresher keeps the active plan's daily targets following
+// logged day types. This is optional - if not set, a logged day type reaches
+// them when the plan is next saved.
+func (s *DailyLogService) SetDailyTargetRefresher(r dailyTargetRefresher) {
+	s.dailyTargets = r
+}
+
+// SetReferenceRanges judges HRV, resting HR and sleep against the user's own
+// ranges. This is optional - if not set, the generic thresholds apply.
+func (s *DailyLogService) SetReferenceRanges(r referenceRangeSource) {
+	s.references = r
+}
+
+// SetRecoveryActivityStore counts sauna, cold, massage and similar work in the
+// readiness score. This is optional - if not set, readiness ignores them.
+func (s *DailyLogService) SetRecoveryActivityStore(as *store.RecoveryActivityStore) {
+	s.activityStore = as
+}
+
+// referenceRanges returns the personal reference ranges. Missing ranges or a
+// failed lookup fall back to the generic thresholds.
+func (s *DailyLogService) referenceRanges(ctx context.Context) domain.PersonalReferenceRanges {
+	if s.references == nil {
+		return domain.PersonalReferenceRanges{}
+	}
+	refs, err := s.references.Ranges(ctx)
+	if err != nil {
+		log.Printf("dailylog: personal reference ranges unavailable: %v", err)
+		return domain.PersonalReferenceRanges{}
+	}
+	return refs
+}
+
+// cnsInput gathers a log's HRV and RHR history and reference ranges for CNS
+// classification. log.HRVMs must be set.
+func (s *DailyLogService) cnsInput(ctx context.Context, log *domain.DailyLog, refs domain.PersonalReferenceRanges) domain.CNSInput {
+	hrvHistory, _ := s.logStore.GetHRVHistory(ctx, log.Date, domain.HRVBaselineWindowDays)
+	rhrHistory, _ := s.logStore.GetRHRHistory(ctx, log.Date, domain.RestingHRWindowDays)
+	return domain.CNSInput{
+		CurrentHRV:        *log.HRVMs,
+		HRVHistory:        hrvHistory,
+		CurrentRestingHR:  log.RestingHeartRate,
+		RestingHRHistory:  rhrHistory,
+		ReferenceMin:      log.HRVReferenceMin,
+		ReferenceMax:      log.HRVReferenceMax,
+		PersonalHRV:       refs.HRV,
+		PersonalRestingHR: refs.RestingHR,
+	}
+}
+
+// rollupWeek refreshes the plan week containing date after its log changed.
+// Best-effort: the log change has been saved, so a failure is only logged.
+func (s *DailyLogService) rollupWeek(ctx context.Context, date string) {
+	if s.rollup == nil {
+		return
+	}
+	if _, err := s.rollup.RollupDate(ctx, date); err != nil {
+		log.Printf("weekly actuals rollup failed for %s: %v", date, err)
+	}
+}
+
 // Create creates a new daily log with calculated targets.
 // Returns store.ErrProfileNotFound if no profile exists.
 func (s *DailyLogService) Create(ctx context.Context, input domain.DailyLogInput, now time.Time) (*domain.DailyLog, error) {
@@ -54,38 +156,19 @@ func (s *DailyLogService) Create(ctx context.Context, input domain.DailyLogInput
 	if err != nil {
 		return nil, err
 	}
-
-	// Check for recent body fat data for BMR auto-tuning (Precision Mode)
-	// This enables Katch-McArdle equation which is more accurate when body fat is known
-	const bmrBodyFatLookbackDays = 7
-	recentBodyFat, bodyFatDate, _ := s.logStore.GetRecentBodyFat(ctx, log.Date, bmrBodyFatLookbackDays)
-
-	// Use auto-tune for BMR calculation
-	bmrEquation := profile.BMREquation
-	if bmrEquation == "" {
-		bmrEquation = domain.BMREquationMifflinStJeor
+	retroEdit, err := s.checkDayLock(ctx, log.Date)
+	if err != nil {
+		return nil, err
 	}
-	bmrResult := domain.CalculateBMRWithAutoTune(profile, log.WeightKg, now, bmrEquation, recentBodyFat, bodyFatDate)
+	s.prefillStrengthArchetypes(ctx, log.PlannedSessions)
+
+	bmrResult, formulaTDEE, adaptiveResult := s.calculateTDEEInputs(ctx, profile, log, now)
 
 	// Store precision mode metadata
 	log.BMRPrecisionMode = bmrResult.IsPrecisionMode
 	log.BodyFatUsedDate = bmrResult.BodyFatDate
-
-	// Calculate formula-based TDEE using the auto-tuned BMR
-	exerciseCalories := domain.CalculateTotalExerciseCalories(log.PlannedSessions, log.WeightKg)
-	formulaTDEE := int(bmrResult.BMR*1.2 + exerciseCalories)
 	log.FormulaTDEE = formulaTDEE
 
-	// Try to calculate adaptive TDEE if profile uses adaptive source
-	var adaptiveResult *domain.AdaptiveTDEEResult
-	if profile.TDEESource == domain.TDEESourceAdaptive {
-		// Fetch historical data for adaptive calculation
-		dataPoints, err := s.logStore.ListAdaptiveDataPoints(ctx, log.Date, domain.MaxDataPointsForAdaptive)
-		if err == nil && len(dataPoints) >= domain.MinDataPointsForAdaptive {
-			adaptiveResult = domain.CalculateAdaptiveTDEE(dataPoints)
-		}
-	}
-
 	// Get effective TDEE based on profile settings
 	effectiveTDEE, tdeeSource, confidence, dataPointsUsed := domain.GetEffectiveTDEE(
 		profile, formulaTDEE, adaptiveResult,
@@ -97,7 +180,8 @@ func (s *DailyLogService) Create(ctx context.Context, input domain.DailyLogInput
 	log.DataPointsUsed = dataPointsUsed
 
 	// Calculate recovery score and adjustment multipliers
-	recoveryScore, adjustmentMultipliers := s.calculateRecoveryAndAdjustments(ctx, log.Date, int(log.SleepQuality), log.RestingHeartRate)
+	refs := s.referenceRanges(ctx)
+	recoveryScore, adjustmentMultipliers := s.calculateRecoveryAndAdjustments(ctx, log.Date, int(log.SleepQuality), log.RestingHeartRate, refs)
 
 	if recoveryScore != nil {
 		log.RecoveryScore = recoveryScore
@@ -105,22 +189,12 @@ func (s *DailyLogService) Create(ctx context.Context, input domain.DailyLogInput
 	if adjustmentMultipliers != nil {
 		log.AdjustmentMultipliers = adjustmentMultipliers
 		// Apply adjustment multiplier to effective TDEE
-		log.EstimatedTDEE = int(float64(effectiveTDEE) * adjustmentMultipliers.Total)
+		log.EstimatedTDEE = domain.RoundInt(float64(effectiveTDEE) * adjustmentMultipliers.Total)
 	}
 
 	// Calculate CNS status if HRV is provided
 	if log.HRVMs != nil {
-		hrvHistory, _ := s.logStore.GetHRVHistory(ctx, log.Date, domain.HRVBaselineWindowDays)
-		rhrHistory, _ := s.logStore.GetRHRHistory(ctx, log.Date, domain.RestingHRWindowDays)
-		cnsInput := domain.CNSInput{
-			CurrentHRV:       *log.HRVMs,
-			HRVHistory:       hrvHistory,
-			CurrentRestingHR: log.RestingHeartRate,
-			RestingHRHistory: rhrHistory,
-			ReferenceMin:     log.HRVReferenceMin,
-			ReferenceMax:     log.HRVReferenceMax,
-		}
-		cnsResult := domain.CalculateCNSStatus(cnsInput)
+		cnsResult := domain.CalculateCNSStatus(s.cnsInput(ctx, log, refs))
 		if cnsResult != nil {
 			log.CNSResult = cnsResult
 
@@ -148,16 +222,113 @@ func (s *DailyLogService) Create(ctx context.Context, input domain.DailyLogInput
 	}); err != nil {
 		return nil, err
 	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditCreate)
+	s.rollupWeek(ctx, log.Date)
+	refreshDailyTargets(ctx, s.dailyTargets, log.Date)
 
 	// Record Flux calculation if metabolic store is configured
 	if s.metabolicStore != nil {
-		s.recordFluxCalculation(ctx, createdLogID, bmrResult.BMR, formulaTDEE, adaptiveResult)
+		s.recordFluxCalculation(ctx, createdLogID, bmrResult.BMR, formulaTDEE, adaptiveResult, now)
 	}
 
 	log.ID = createdLogID
 	return log, nil
 }
 
+// prefillStrengthArchetypes gives planned strength sessions without an
+// archetype the next ones in the rotation, in session order. Best-effort: a
+// rotation lookup failure leaves them unassigned.
+func (s *DailyLogService) prefillStrengthArchetypes(ctx context.Context, sessions []domain.TrainingSession) {
+	if s.rotation == nil {
+		return
+	}
+	var open []int
+	for i := range sessions {
+		if sessions[i].Type == domain.TrainingTypeStrength && sessions[i].Archetype == "" {
+			open = append(open, i)
+		}
+	}
+	if len(open) == 0 {
+		return
+	}
+	upcoming, err := s.rotation.Upcoming(ctx, len(open))
+	if err != nil {
+		log.Printf("strength rotation lookup failed: %v", err)
+		return
+	}
+	for n, i := range open {
+		sessions[i].Archetype = upcoming[n]
+	}
+}
+
+// calculateTDEEInputs computes the auto-tuned BMR, formula TDEE, and (when the profile
+// uses adaptive TDEE) the adaptive estimate for a log.
+func (s *DailyLogService) calculateTDEEInputs(
+	ctx context.Context,
+	profile *domain.UserProfile,
+	log *domain.DailyLog,
+	now time.Time,
+) (domain.BMRCalculationResult, int, *domain.AdaptiveTDEEResult) {
+	// Check for recent body fat data for BMR auto-tuning (Precision Mode)
+	// This enables Katch-McArdle equation which is more accurate when body fat is known
+	const bmrBodyFatLookbackDays = 7
+	recentBodyFat, bodyFatDate, _ := s.logStore.GetRecentBodyFat(ctx, log.Date, bmrBodyFatLookbackDays)
+
+	// Use auto-tune for BMR calculation
+	bmrEquation := profile.BMREquation
+	if bmrEquation == "" {
+		bmrEquation = domain.BMREquationMifflinStJeor
+	}
+	bmrResult := domain.CalculateBMRWithAutoTune(profile, log.WeightKg, now, bmrEquation, recentBodyFat, bodyFatDate)
+
+	// Calculate formula-based TDEE using the auto-tuned BMR. Recorded active
+	// calories (wearable or estimated from actual sessions) beat the planned ones.
+	exerciseCalories := domain.CalculateTotalExerciseCalories(log.PlannedSessions, log.WeightKg)
+	if log.ActiveCaloriesBurned != nil {
+		exerciseCalories = float64(*log.ActiveCaloriesBurned)
+	}
+	formulaTDEE := int(bmrResult.BMR*1.2 + exerciseCalories)
+
+	// Try to calculate adaptive TDEE if profile uses adaptive source
+	var adaptiveResult *domain.AdaptiveTDEEResult
+	if profile.TDEESource == domain.TDEESourceAdaptive {
+		// Fetch historical data for adaptive calculation
+		dataPoints, err := s.logStore.ListAdaptiveDataPoints(ctx, log.Date, domain.MaxDataPointsForAdaptive)
+		if err == nil && len(dataPoints) >= domain.MinDataPointsForAdaptive {
+			// Confidence is judged as of the log's date, so backfilled and
+			// recalculated past days see only the data they had
+			coverage, err := s.logStore.GetAdaptiveCoverage(ctx, dataPoints[0].Date, log.Date)
+			if err != nil {
+				coverage = domain.AdaptiveCoverage{AsOfDate: log.Date}
+			}
+			adaptiveResult = domain.CalculateAdaptiveTDEE(dataPoints, coverage)
+		}
+	}
+
+	return bmrResult, formulaTDEE, adaptiveResult
+}
+
+// RecalculateFlux re-runs the Flux Engine for an existing log, e.g. after late
+// wearable data changed its inputs. Returns false if the Flux Engine is not configured.
+func (s *DailyLogService) RecalculateFlux(ctx context.Context, date string, now time.Time) (bool, error) {
+	if s.metabolicStore == nil {
+		return false, nil
+	}
+
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	log, err := s.GetByDate(ctx, date)
+	if err != nil {
+		return false, err
+	}
+
+	bmrResult, formulaTDEE, adaptiveResult := s.calculateTDEEInputs(ctx, profile, log, now)
+	s.recordFluxCalculation(ctx, log.ID, bmrResult.BMR, formulaTDEE, adaptiveResult, now)
+	return true, nil
+}
+
 // recordFluxCalculation calculates and persists Flux Engine data.
 // Errors are logged but don't fail the main operation.
 func (s *DailyLogService) recordFluxCalculation(
@@ -166,8 +337,10 @@ func (s *DailyLogService) recordFluxCalculation(
 	currentBMR float64,
 	formulaTDEE int,
 	adaptiveResult *domain.AdaptiveTDEEResult,
+	now time.Time,
 ) {
 	config := domain.DefaultFluxConfig
+	today := now.Format("2006-01-02")
 
 	// Get previous TDEE for swing constraint
 	previousTDEE, err := s.metabolicStore.GetPreviousTDEE(ctx)
@@ -176,13 +349,13 @@ func (s *DailyLogService) recordFluxCalculation(
 	}
 
 	// Get adherence (days logged in last 7 days)
-	adherenceDays, err := s.metabolicStore.CountRecentLogs(ctx, config.AdherenceWindowDays)
+	adherenceDays, err := s.metabolicStore.CountRecentLogs(ctx, config.AdherenceWindowDays, today)
 	if err != nil {
 		return
 	}
 
 	// Get recent weight history for EMA smoothing
-	weightHistory, err := s.metabolicStore.ListRecentWeights(ctx, 14) // 2 weeks for smoothing
+	weightHistory, err := recentCorrectedWeights(ctx, s.metabolicStore, 14, now) // 2 weeks for smoothing
 	if err != nil {
 		return
 	}
@@ -206,6 +379,7 @@ func (s *DailyLogService) recordFluxCalculation(
 	// Build history record
 	record := &domain.MetabolicHistoryRecord{
 		DailyLogID:          dailyLogID,
+		CalculatedAt:        now.Format("2006-01-02 15:04:05"),
 		CalculatedTDEE:      result.TDEE,
 		PreviousTDEE:        result.PreviousTDEE,
 		DeltaKcal:           result.DeltaKcal,
@@ -248,18 +422,9 @@ func (s *DailyLogService) GetByDate(ctx context.Context, date string) (*domain.D
 
 	// Calculate CNS status if HRV is present
 	if log.HRVMs != nil {
-		hrvHistory, _ := s.logStore.GetHRVHistory(ctx, log.Date, domain.HRVBaselineWindowDays)
-		rhrHistory, _ := s.logStore.GetRHRHistory(ctx, log.Date, domain.RestingHRWindowDays)
-		cnsInput := domain.CNSInput{
-			CurrentHRV:       *log.HRVMs,
-			HRVHistory:       hrvHistory,
-			CurrentRestingHR: log.RestingHeartRate,
-			RestingHRHistory: rhrHistory,
-			ReferenceMin:     log.HRVReferenceMin,
-			ReferenceMax:     log.HRVReferenceMax,
-		}
-		cnsResult := domain.CalculateCNSStatus(cnsInput)
+		cnsResult := domain.CalculateCNSStatus(s.cnsInput(ctx, log, s.referenceRanges(ctx)))
 		if cnsResult != nil {
+			domain.ApplyCheckInToCNS(cnsResult, log.CheckIn)
 			log.CNSResult = cnsResult
 			if cnsResult.Status == domain.CNSStatusDepleted {
 				log.TrainingOverrides = domain.CalculateTrainingOverride(cnsResult.Status, log.PlannedSessions)
@@ -277,31 +442,34 @@ func (s *DailyLogService) GetToday(ctx context.Context, now time.Time) (*domain.
 	return s.GetByDate(ctx, today)
 }
 
+// ReadinessScore returns a logged day's recovery score, the morning readiness
+// autoregulated program sessions scale by. Returns nil when the day isn't
+// logged or the score can't be calculated.
+func (s *DailyLogService) ReadinessScore(ctx context.Context, date string) (*float64, error) {
+	log, err := s.logStore.GetByDate(ctx, date)
+	if errors.Is(err, store.ErrDailyLogNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	recovery, _ := s.calculateRecoveryAndAdjustments(ctx, log.Date, int(log.SleepQuality), log.RestingHeartRate, s.referenceRanges(ctx))
+	if recovery == nil {
+		return nil, nil
+	}
+	return &recovery.Score, nil
+}
+
 // GetNeuralBattery computes the Neural Battery from today's HRV and recent history.
 // Returns nil if no HRV data is available.
 func (s *DailyLogService) GetNeuralBattery(ctx context.Context) *domain.NeuralBattery {
-	now := time.Now()
+	now := s.now()
 	log, err := s.GetToday(ctx, now)
 	if err != nil || log.HRVMs == nil || *log.HRVMs <= 0 {
 		return nil
 	}
 
-	today := now.Format("2006-01-02")
-	hrvHistory, err := s.logStore.GetHRVHistory(ctx, today, domain.HRVBaselineWindowDays)
-	if err != nil {
-		return nil
-	}
-
-	rhrHistory, _ := s.logStore.GetRHRHistory(ctx, today, domain.RestingHRWindowDays)
-
-	cnsResult := domain.CalculateCNSStatus(domain.CNSInput{
-		CurrentHRV:       *log.HRVMs,
-		HRVHistory:       hrvHistory,
-		CurrentRestingHR: log.RestingHeartRate,
-		RestingHRHistory: rhrHistory,
-		ReferenceMin:     log.HRVReferenceMin,
-		ReferenceMax:     log.HRVReferenceMax,
-	})
+	cnsResult := domain.CalculateCNSStatus(s.cnsInput(ctx, log, s.referenceRanges(ctx)))
 	return domain.CalculateNeuralBattery(cnsResult)
 }
 
@@ -309,7 +477,7 @@ func (s *DailyLogService) GetNeuralBattery(ctx context.Context) *domain.NeuralBa
 // Returns store.ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogService) UpdateActualTraining(ctx context.Context, date string, sessions []domain.TrainingSession) (*domain.DailyLog, error) {
 	// Get existing log to validate it exists and get ID
-	log, err := s.logStore.GetByDate(ctx, date)
+	existing, err := s.logStore.GetByDate(ctx, date)
 	if err != nil {
 		return nil, err
 	}
@@ -323,48 +491,79 @@ func (s *DailyLogService) UpdateActualTraining(ctx context.Context, date string,
 	if err := domain.ValidateTrainingSessions(sessions); err != nil {
 		return nil, err
 	}
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-saved sessions keep their archetype, so inference doesn't apply
+	// their fatigue a second time
+	previous, err := s.sessionStore.GetActualByLogID(ctx, existing.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		for _, p := range previous {
+			if sessions[i].Archetype == "" && p.SessionOrder == sessions[i].SessionOrder && p.Type == sessions[i].Type {
+				sessions[i].Archetype = p.Archetype
+			}
+		}
+	}
 
+	// Estimate active burn from the new sessions; it is only stored when the
+	// day has no wearable value.
+	var estimate *domain.ActiveCalorieEstimate
+	if s.estimator != nil {
+		e, err := s.estimator.Estimate(ctx, date, existing.WeightKg, sessions)
+		if err != nil {
+			return nil, err
+		}
+		estimate = &e
+	}
+
+	estimateApplied := false
 	if err := s.logStore.WithTx(ctx, func(tx *sql.Tx) error {
 		// Delete existing actual sessions
-		if err := s.sessionStore.DeleteActualByLogIDWithTx(ctx, tx, log.ID); err != nil {
+		if err := s.sessionStore.DeleteActualByLogIDWithTx(ctx, tx, existing.ID); err != nil {
 			return err
 		}
 
 		// Insert new actual sessions
-		if err := s.sessionStore.CreateForLogWithTx(ctx, tx, log.ID, sessions); err != nil {
+		if err := s.sessionStore.CreateForLogWithTx(ctx, tx, existing.ID, sessions); err != nil {
 			return err
 		}
 
-		// Active Fuel Bridge: Calculate active burn based on load
-		loadScore := domain.TotalSessionLoad(sessions)
-		estimatedBurn := int(loadScore * log.WeightKg * 0.25)
-
-		// Update persistent storage with calculated burn
-		// Note: We always update calculation here. If the user wants to manually override,
-		// they can do so explicitly via UpdateActiveCaloriesBurned endpoint/method.
-		// Taking the stance that changing the sessions should re-trigger the calculation.
-		return s.logStore.UpdateActiveCaloriesBurnedWithTx(ctx, tx, log.Date, &estimatedBurn)
+		if estimate == nil {
+			return nil
+		}
+		applied, err := s.logStore.UpdateEstimatedActiveCaloriesWithTx(ctx, tx, existing.Date, estimate.Kcal)
+		estimateApplied = applied
+		return err
 	}); err != nil {
 		return nil, err
 	}
+	if estimateApplied {
+		if _, err := s.RecalculateFlux(ctx, date, s.now()); err != nil {
+			log.Printf("flux recalculation after calorie estimate failed for %s: %v", date, err)
+		}
+	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditActualTraining)
 
 	// Return updated log with all sessions
 	return s.GetByDate(ctx, date)
 }
 
-// DeleteToday removes today's daily log.
-// Training sessions are deleted automatically via ON DELETE CASCADE.
-func (s *DailyLogService) DeleteToday(ctx context.Context, now time.Time) error {
-	today := now.Format("2006-01-02")
-	return s.logStore.DeleteByDate(ctx, today)
-}
-
 // UpdateActiveCaloriesBurned updates the active calories burned for a given date.
 // Returns store.ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogService) UpdateActiveCaloriesBurned(ctx context.Context, date string, calories *int) (*domain.DailyLog, error) {
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
 	if err := s.logStore.UpdateActiveCaloriesBurned(ctx, date, calories); err != nil {
 		return nil, err
 	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditActiveCalories)
 	return s.GetByDate(ctx, date)
 }
 
@@ -379,12 +578,130 @@ func (s *DailyLogService) UpdateFastingOverride(ctx context.Context, date string
 		}
 	}
 
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
 	if err := s.logStore.UpdateFastingOverride(ctx, date, override); err != nil {
 		return nil, err
 	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditFastingOverride)
+	return s.GetByDate(ctx, date)
+}
+
+// UpdateCheckIn records the mood, stress and soreness check-in for a given date.
+// Returns store.ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogService) UpdateCheckIn(ctx context.Context, date string, checkIn domain.CheckIn) (*domain.DailyLog, error) {
+	if err := checkIn.Validate(); err != nil {
+		return nil, err
+	}
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.logStore.UpdateCheckIn(ctx, date, checkIn); err != nil {
+		return nil, err
+	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditCheckIn)
+	return s.GetByDate(ctx, date)
+}
+
+// UpdateEnvironment sets the day's environment conditions and recalculates
+// its water target for them. Session load picks the conditions up when read.
+// Returns store.ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogService) UpdateEnvironment(ctx context.Context, date string, conditions []domain.EnvironmentCondition) (*domain.DailyLog, error) {
+	if err := domain.ValidateEnvironment(conditions); err != nil {
+		return nil, err
+	}
+	log, err := s.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Environment = conditions
+	waterL := domain.CalculateWaterTarget(log.WeightKg, log.DayEnvironment())
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.logStore.UpdateEnvironment(ctx, date, conditions, waterL); err != nil {
+		return nil, err
+	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditEnvironment)
 	return s.GetByDate(ctx, date)
 }
 
+// GetCheckInRollup summarizes the check-ins for month (YYYY-MM, empty for
+// the current month) and their correlations with adherence and next-day RPE.
+func (s *DailyLogService) GetCheckInRollup(ctx context.Context, month string) (*domain.CheckInRollup, error) {
+	if month == "" {
+		month = s.now().Format("2006-01")
+	}
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, domain.ErrInvalidRollupMonth
+	}
+	startDate := start.Format("2006-01-02")
+	// The day after the month pairs the last day's soreness with its RPE
+	endDate := start.AddDate(0, 1, 0).Format("2006-01-02")
+
+	logs, err := s.logStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]store.SessionsByDate, len(sessions))
+	for _, day := range sessions {
+		byDate[day.Date] = day
+	}
+	for i := range logs {
+		logs[i].ActualSessions = byDate[logs[i].Date].ActualSessions
+	}
+
+	return domain.BuildCheckInRollup(month, logs)
+}
+
+// GetMealTimingRollup returns meal timing for a month (YYYY-MM, default
+// current) correlated with the following nights' sleep and training.
+func (s *DailyLogService) GetMealTimingRollup(ctx context.Context, month string) (*domain.MealTimingRollup, error) {
+	if month == "" {
+		month = s.now().Format("2006-01")
+	}
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, domain.ErrInvalidRollupMonth
+	}
+	startDate := start.Format("2006-01-02")
+	monthEnd := start.AddDate(0, 1, -1).Format("2006-01-02")
+	// The day after the month carries the last night's bedtime and sleep
+	endDate := start.AddDate(0, 1, 0).Format("2006-01-02")
+
+	entries, err := s.logStore.ListMealEntries(ctx, startDate, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.logStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]store.SessionsByDate, len(sessions))
+	for _, day := range sessions {
+		byDate[day.Date] = day
+	}
+	for i := range logs {
+		logs[i].ActualSessions = byDate[logs[i].Date].ActualSessions
+	}
+
+	return domain.BuildMealTimingRollup(month, entries, logs)
+}
+
 // UpsertHealthKitMetrics creates or updates a daily log with HealthKit data.
 // If a log exists for the date, only non-nil fields are updated.
 // If no log exists, a new minimal log is created with defaults.
@@ -393,6 +710,7 @@ func (s *DailyLogService) UpsertHealthKitMetrics(ctx context.Context, date strin
 	if err := s.logStore.UpsertHealthKitMetrics(ctx, date, metrics); err != nil {
 		return nil, err
 	}
+	s.rollupWeek(ctx, date)
 	return s.GetByDate(ctx, date)
 }
 
@@ -400,21 +718,111 @@ func (s *DailyLogService) UpsertHealthKitMetrics(ctx context.Context, date strin
 // This is additive - it increments the existing values rather than replacing them.
 // Returns store.ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogService) AddConsumedMacros(ctx context.Context, date string, macros store.ConsumedMacros) (*domain.DailyLog, error) {
+	if macros.EatenAt != "" {
+		if err := domain.ValidateMealTime(macros.EatenAt); err != nil {
+			return nil, err
+		}
+	}
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
 	if err := s.logStore.AddConsumedMacros(ctx, date, macros); err != nil {
 		return nil, err
 	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditConsumedMacros)
+	s.rollupWeek(ctx, date)
 	return s.GetByDate(ctx, date)
 }
 
 // ClearMealConsumedMacros clears the consumed macros for a specific meal slot.
 // Returns store.ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogService) ClearMealConsumedMacros(ctx context.Context, date string, meal domain.MealName) (*domain.DailyLog, error) {
+	retroEdit, err := s.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
 	if err := s.logStore.ClearMealConsumedMacros(ctx, date, meal); err != nil {
 		return nil, err
 	}
+	s.recordRetroEdit(ctx, retroEdit, domain.LogEditClearMeal)
+	s.rollupWeek(ctx, date)
 	return s.GetByDate(ctx, date)
 }
 
+// checkDayLock enforces the logging lock for an edit to date. A locked day
+// needs an open retro-edit, which is returned so the edit can be recorded
+// on it; nil means the day is editable as is. Health sync and imports write
+// through their own paths and aren't locked.
+func (s *DailyLogService) checkDayLock(ctx context.Context, date string) (*domain.RetroEdit, error) {
+	if s.retroEditStore == nil {
+		return nil, nil
+	}
+	profile, err := s.profileStore.Get(ctx)
+	if errors.Is(err, store.ErrProfileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if !domain.IsDayLocked(date, profile.LogLockDays, now) {
+		return nil, nil
+	}
+	edit, err := s.retroEditStore.GetOpen(ctx, date, now)
+	if err != nil {
+		return nil, err
+	}
+	if edit == nil {
+		return nil, domain.ErrDayLocked
+	}
+	return edit, nil
+}
+
+// recordRetroEdit appends a completed edit to the retro-edit's audit trail.
+// The edit is already saved, so a failure is logged rather than returned.
+func (s *DailyLogService) recordRetroEdit(ctx context.Context, edit *domain.RetroEdit, kind domain.LogEditKind) {
+	if edit == nil {
+		return
+	}
+	action := domain.RetroEditAction{Kind: kind, At: s.now()}
+	if err := s.retroEditStore.AppendAction(ctx, edit.ID, action); err != nil {
+		log.Printf("retro-edit: failed to record %s on %s: %v", kind, edit.Date, err)
+	}
+}
+
+// OpenRetroEdit approves edits to a locked day for domain.RetroEditWindow.
+// Returns domain.ErrDayNotLocked when the lock is off or the day is recent.
+func (s *DailyLogService) OpenRetroEdit(ctx context.Context, date, reason string) (*domain.RetroEdit, error) {
+	lockDays := 0
+	if s.retroEditStore != nil {
+		profile, err := s.profileStore.Get(ctx)
+		if err != nil && !errors.Is(err, store.ErrProfileNotFound) {
+			return nil, err
+		}
+		if profile != nil {
+			lockDays = profile.LogLockDays
+		}
+	}
+	edit, err := domain.NewRetroEdit(date, reason, lockDays, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.retroEditStore.Create(ctx, edit); err != nil {
+		return nil, err
+	}
+	return edit, nil
+}
+
+// ListRetroEdits returns the retro-edit audit trail for days from startDate
+// to endDate (inclusive).
+func (s *DailyLogService) ListRetroEdits(ctx context.Context, startDate, endDate string) ([]domain.RetroEdit, error) {
+	if s.retroEditStore == nil {
+		return []domain.RetroEdit{}, nil
+	}
+	return s.retroEditStore.ListByDateRange(ctx, startDate, endDate)
+}
+
 // GetWeightTrend returns weight samples and regression trend for the given start date.
 // If startDate is empty, all samples are returned.
 func (s *DailyLogService) GetWeightTrend(ctx context.Context, startDate string) ([]domain.WeightSample, *domain.WeightTrend, error) {
@@ -427,6 +835,72 @@ func (s *DailyLogService) GetWeightTrend(ctx context.Context, startDate string)
 	return samples, trend, nil
 }
 
+// GetSmoothedWeightTrend returns the smoothed weight trend (never nil) and
+// regression trend for the given start date, with weigh-ins corrected for
+// time of day. Unset fields of override fall back to the profile's
+// smoothing preference, or EMA when there is no profile.
+func (s *DailyLogService) GetSmoothedWeightTrend(ctx context.Context, startDate string, override domain.WeightSmoothing) (*domain.SmoothedWeightTrend, *domain.WeightTrend, error) {
+	smoothing := domain.WeightSmoothing{Method: domain.WeightTrendMethodEMA}
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil && !errors.Is(err, store.ErrProfileNotFound) {
+		return nil, nil, err
+	}
+	if profile != nil {
+		smoothing = profile.WeightSmoothing
+	}
+	if override.Method != "" {
+		// A different method's window doesn't carry over
+		if override.Method != smoothing.EffectiveMethod() {
+			smoothing.Window = 0
+		}
+		smoothing.Method = override.Method
+	}
+	if override.Window != 0 {
+		smoothing.Window = override.Window
+	}
+
+	samples, err := correctedWeights(ctx, s.logStore, startDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	smoothed := domain.CalculateSmoothedWeightTrend(samples, smoothing)
+	if smoothed == nil {
+		smoothed = &domain.SmoothedWeightTrend{Smoothing: smoothing}
+	}
+	return smoothed, domain.CalculateWeightTrend(samples), nil
+}
+
+// GetWeighInOffsets returns the learned time-of-day offset curve.
+func (s *DailyLogService) GetWeighInOffsets(ctx context.Context, now time.Time) (*domain.WeighInOffsetCurve, error) {
+	samples, err := s.logStore.ListWeights(ctx, now.AddDate(0, 0, -domain.WeighInLearningDays).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	return domain.LearnWeighInOffsets(samples), nil
+}
+
+// correctedWeights returns weights since startDate (all if empty) corrected
+// for weigh-in time of day. The offset curve is learned from a further
+// WeighInLearningDays of history before startDate.
+func correctedWeights(ctx context.Context, logStore *store.DailyLogStore, startDate string) ([]domain.WeightSample, error) {
+	learnFrom := ""
+	if start, err := time.Parse("2006-01-02", startDate); err == nil {
+		learnFrom = start.AddDate(0, 0, -domain.WeighInLearningDays).Format("2006-01-02")
+	}
+	samples, err := logStore.ListWeights(ctx, learnFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	corrected := domain.CorrectWeighInTimes(samples)
+	for i, sample := range corrected {
+		if sample.Date >= startDate {
+			return corrected[i:], nil
+		}
+	}
+	return nil, nil
+}
+
 // GetHistorySummary returns history points, weight trend, and training aggregates for a range.
 func (s *DailyLogService) GetHistorySummary(ctx context.Context, startDate, endDate string) (*domain.HistorySummary, error) {
 	points, err := s.logStore.ListHistoryPoints(ctx, startDate)
@@ -609,17 +1083,24 @@ func (s *DailyLogService) GetTrainingLoadMetrics(ctx context.Context, date strin
 	}
 
 	// Convert to daily load data points
+	var dayEnvironment []domain.EnvironmentCondition
 	dataPoints := make([]domain.DailyLoadDataPoint, len(sessionsData))
 	for i, sd := range sessionsData {
 		dataPoints[i] = domain.DailyLoadDataPoint{
 			Date:      sd.Date,
 			DailyLoad: domain.DailyLoad(sd.ActualSessions, sd.PlannedSessions),
 		}
+		if sd.Date == date {
+			dayEnvironment = sd.Environment
+		}
 	}
 
 	// Calculate today's load from provided sessions (not from historical data)
 	// This allows the API response to reflect current session state accurately
-	todayLoad := domain.DailyLoad(actualSessions, plannedSessions)
+	todayLoad := domain.DailyLoad(
+		domain.WithDayEnvironment(actualSessions, dayEnvironment),
+		domain.WithDayEnvironment(plannedSessions, dayEnvironment),
+	)
 
 	// Calculate ACR metrics
 	result := domain.CalculateTrainingLoadResult(todayLoad, dataPoints)
@@ -657,6 +1138,7 @@ type recoveryDataset struct {
 	avgRHR          *float64
 	yesterdayDate   string
 	trainingLoadACR float64
+	activityPoints  float64 // Net recovery activity points over the lookback
 }
 
 // fetchRecoveryDataset retrieves historical sleep and training data for recovery calculations.
@@ -698,12 +1180,21 @@ func (s *DailyLogService) fetchRecoveryDataset(ctx context.Context, date string,
 		acr = trainingLoadResult.ACR
 	}
 
+	// Recovery activities over the same days as the sessions (best-effort)
+	var activityPoints float64
+	if s.activityStore != nil {
+		if activities, err := s.activityStore.ListByDateRange(ctx, startDate, yesterdayDate); err == nil {
+			activityPoints = domain.RecoveryActivityPoints(activities)
+		}
+	}
+
 	return &recoveryDataset{
 		sleepScores:     sleepScores,
 		sessionsData:    sessionsData,
 		avgRHR:          avgRHR,
 		yesterdayDate:   yesterdayDate,
 		trainingLoadACR: acr,
+		activityPoints:  activityPoints,
 	}
 }
 
@@ -722,7 +1213,7 @@ func averageSleepQuality(scores []int) float64 {
 
 // calculateRecoveryAndAdjustments computes recovery score and adjustment multipliers
 // using historical training and sleep data. Returns nil for both if insufficient data.
-func (s *DailyLogService) calculateRecoveryAndAdjustments(ctx context.Context, date string, todaySleepQuality int, currentRHR *int) (*domain.RecoveryScore, *domain.AdjustmentMultipliers) {
+func (s *DailyLogService) calculateRecoveryAndAdjustments(ctx context.Context, date string, todaySleepQuality int, currentRHR *int, refs domain.PersonalReferenceRanges) (*domain.RecoveryScore, *domain.AdjustmentMultipliers) {
 	dataset := s.fetchRecoveryDataset(ctx, date, currentRHR)
 	if dataset == nil {
 		return nil, nil
@@ -748,6 +1239,8 @@ func (s *DailyLogService) calculateRecoveryAndAdjustments(ctx context.Context, d
 		AvgSleepQualityL7: avgSleep,
 		CurrentRHR:        currentRHR,
 		AvgRHRLast30:      dataset.avgRHR,
+		PersonalSleep:     refs.SleepQuality,
+		ActivityPointsL7:  dataset.activityPoints,
 	}
 	recoveryScore := domain.CalculateRecoveryScore(recoveryInput)
 
@@ -965,7 +1458,7 @@ func (s *DailyLogService) GetDayInsight(ctx context.Context, date string) (*DayI
 		insightCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
 
-		insight, err := s.ollamaService.Generate(insightCtx, prompt)
+		insight, err := s.ollamaService.Generate(insightCtx, domain.LLMFeatureDayInsight, prompt)
 		if err == nil && len(insight) > 0 {
 			return &DayInsight{
 				Insight:   insight,
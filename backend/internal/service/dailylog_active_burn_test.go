@@ -6,10 +6,10 @@ import (
 	"victus/internal/domain"
 )
 
-// TestActiveBurnCalculation verifies that active calories are correctly calculated
-// and persisted when actual training sessions are updated.
+// TestActiveBurnCalculation verifies that active calories are estimated from
+// MET and persisted when actual training sessions are updated.
 func (s *DailyLogServiceSuite) TestActiveBurnCalculation() {
-	s.Run("calculates active burn based on load score", func() {
+	s.Run("estimates active burn from session METs", func() {
 		// 1. Create Profile (80kg)
 		profile := s.validProfile()
 		profile.CurrentWeightKg = 80
@@ -31,8 +31,8 @@ func (s *DailyLogServiceSuite) TestActiveBurnCalculation() {
 		s.Nil(log.ActiveCaloriesBurned, "ActiveCaloriesBurned should be nil initially")
 
 		// 3. Update Actual Training
-		// Session 1: Run (LoadScore 3), 60 min, RPE 5
-		// Load = 3 * (60/60) * (5/3) = 5.0
+		// Session 1: Run (MET 9.8), 60 min
+		// Burn = (9.8 - 1) * 80 * 1 = 704
 		rpe5 := 5
 		session1 := domain.TrainingSession{
 			Type:               domain.TrainingTypeRun,
@@ -40,8 +40,8 @@ func (s *DailyLogServiceSuite) TestActiveBurnCalculation() {
 			PerceivedIntensity: &rpe5,
 		}
 
-		// Session 2: Strength (LoadScore 5), 45 min, RPE 7
-		// Load = 5 * (45/60) * (7/3) = 5 * 0.75 * 2.333... = 8.75
+		// Session 2: Strength (MET 5.0), 45 min
+		// Burn = (5.0 - 1) * 80 * 0.75 = 240
 		rpe7 := 7
 		session2 := domain.TrainingSession{
 			Type:               domain.TrainingTypeStrength,
@@ -49,20 +49,40 @@ func (s *DailyLogServiceSuite) TestActiveBurnCalculation() {
 			PerceivedIntensity: &rpe7,
 		}
 
-		// Total Load = 13.75
-		// Expected Burn = 13.75 * 80 * 0.25 = 275
+		// Expected Burn = 704 + 240 = 944
 
 		updatedLog, err := s.logService.UpdateActualTraining(s.ctx, date, []domain.TrainingSession{session1, session2})
 		s.Require().NoError(err)
 
 		// 4. Verify Active Burn
 		s.Require().NotNil(updatedLog.ActiveCaloriesBurned, "ActiveCaloriesBurned should be calculated")
-		s.Equal(275, *updatedLog.ActiveCaloriesBurned, "Expected 275 active calories")
+		s.Equal(944, *updatedLog.ActiveCaloriesBurned, "Expected 944 active calories")
+		s.Equal(domain.ActiveCaloriesEstimated, updatedLog.ActiveCaloriesSource)
 
 		// 5. Verify Persistence by re-fetching
 		fetchedLog, err := s.logService.GetByDate(s.ctx, date)
 		s.Require().NoError(err)
 		s.Require().NotNil(fetchedLog.ActiveCaloriesBurned)
-		s.Equal(275, *fetchedLog.ActiveCaloriesBurned)
+		s.Equal(944, *fetchedLog.ActiveCaloriesBurned)
+	})
+
+	s.Run("keeps wearable active calories", func() {
+		_, err := s.profileService.Upsert(s.ctx, s.validProfile(), s.now)
+		s.Require().NoError(err)
+
+		date := "2025-06-02"
+		_, err = s.logService.Create(s.ctx, domain.DailyLogInput{Date: date, WeightKg: 80}, time.Time{})
+		s.Require().NoError(err)
+		wearable := 500
+		_, err = s.logService.UpdateActiveCaloriesBurned(s.ctx, date, &wearable)
+		s.Require().NoError(err)
+
+		updatedLog, err := s.logService.UpdateActualTraining(s.ctx, date, []domain.TrainingSession{
+			{Type: domain.TrainingTypeRun, DurationMin: 60},
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(updatedLog.ActiveCaloriesBurned)
+		s.Equal(500, *updatedLog.ActiveCaloriesBurned)
+		s.Equal(domain.ActiveCaloriesWearable, updatedLog.ActiveCaloriesSource)
 	})
 }
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// DataQualityService scores how complete the user's daily inputs are.
+type DataQualityService struct {
+	dailyLogStore *store.DailyLogStore
+	sessionStore  *store.TrainingSessionStore
+}
+
+// NewDataQualityService creates a new DataQualityService.
+func NewDataQualityService(dls *store.DailyLogStore, ss *store.TrainingSessionStore) *DataQualityService {
+	return &DataQualityService{
+		dailyLogStore: dls,
+		sessionStore:  ss,
+	}
+}
+
+// GetReport builds a completeness report for the last `days` days ending on now.
+func (s *DataQualityService) GetReport(ctx context.Context, now time.Time, days int) (*domain.DataQualityReport, error) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -(days - 1))
+	startDate := start.Format("2006-01-02")
+	endDate := end.Format("2006-01-02")
+
+	logs, err := s.dailyLogStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Placeholder weights from non-weight imports must not count as weigh-ins
+	historyPoints, err := s.dailyLogStore.ListHistoryPoints(ctx, startDate)
+	if err != nil {
+		return nil, err
+	}
+	explicitWeight := make(map[string]bool, len(historyPoints))
+	for _, p := range historyPoints {
+		explicitWeight[p.Date] = p.HasExplicitWeight
+	}
+
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	sessionsByDate := make(map[string]store.SessionsByDate, len(sessions))
+	for _, sd := range sessions {
+		sessionsByDate[sd.Date] = sd
+	}
+
+	presence := make([]domain.DayDataPresence, 0, len(logs))
+	for _, l := range logs {
+		sd := sessionsByDate[l.Date]
+		presence = append(presence, domain.DayDataPresence{
+			Date:        l.Date,
+			HasLog:      true,
+			HasWeight:   explicitWeight[l.Date],
+			HasSleep:    l.SleepHours != nil,
+			HasHRV:      l.HRVMs != nil,
+			HasMeals:    l.ConsumedCalories > 0,
+			HasTraining: domain.HasTrainingData(sd.PlannedSessions, sd.ActualSessions),
+		})
+	}
+
+	report := domain.BuildDataQualityReport(presence, start, end)
+	return &report, nil
+}
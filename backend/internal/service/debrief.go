@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"victus/internal/domain"
@@ -14,7 +15,19 @@ type WeeklyDebriefService struct {
 	sessionStore   *store.TrainingSessionStore
 	profileStore   *store.ProfileStore
 	metabolicStore *store.MetabolicStore
+	planStore      *store.NutritionPlanStore
+	reconStore     *store.ReconciliationStore
+	fatigueService *FatigueService
+	caffeineStore  *store.CaffeineStore
+	substitutions  *store.SubstitutionStore
+	toleranceStore *store.DebriefToleranceStore
+	references     referenceRangeSource
+	activityStore  *store.RecoveryActivityStore
+	habits         habitAdherenceSource
+	challenges     challengeSource
+	adaptation     adaptationSource
 	ollamaService  *OllamaService
+	clocked
 }
 
 // NewWeeklyDebriefService creates a new WeeklyDebriefService.
@@ -34,6 +47,66 @@ func NewWeeklyDebriefService(
 	}
 }
 
+// SetPlanStore enables plan-aware vitality scoring profiles.
+func (s *WeeklyDebriefService) SetPlanStore(ps *store.NutritionPlanStore) {
+	s.planStore = ps
+}
+
+// SetReconciliationStore enables clearing regeneration flags raised by late data.
+func (s *WeeklyDebriefService) SetReconciliationStore(rs *store.ReconciliationStore) {
+	s.reconStore = rs
+}
+
+// SetFatigueService adds the fatigue heatmap snapshot to PDF reports.
+func (s *WeeklyDebriefService) SetFatigueService(fs *FatigueService) {
+	s.fatigueService = fs
+}
+
+// SetCaffeineStore adds the caffeine and sleep analysis to debriefs.
+func (s *WeeklyDebriefService) SetCaffeineStore(cs *store.CaffeineStore) {
+	s.caffeineStore = cs
+}
+
+// SetSubstitutionStore adds the week's fatigue substitution decisions to debriefs.
+func (s *WeeklyDebriefService) SetSubstitutionStore(ss *store.SubstitutionStore) {
+	s.substitutions = ss
+}
+
+// SetToleranceStore freezes the adherence tolerances of completed weeks, so
+// a settings change doesn't rescore past debriefs.
+func (s *WeeklyDebriefService) SetToleranceStore(ts *store.DebriefToleranceStore) {
+	s.toleranceStore = ts
+}
+
+// SetRecoveryActivityStore counts sauna, cold, massage and similar work in
+// the vitality recovery component.
+func (s *WeeklyDebriefService) SetRecoveryActivityStore(as *store.RecoveryActivityStore) {
+	s.activityStore = as
+}
+
+// SetHabitAdherence adds the habits marked to count toward vitality as a
+// small vitality component.
+func (s *WeeklyDebriefService) SetHabitAdherence(h habitAdherenceSource) {
+	s.habits = h
+}
+
+// SetChallenges adds a card for each self-challenge running during the week.
+func (s *WeeklyDebriefService) SetChallenges(c challengeSource) {
+	s.challenges = c
+}
+
+// SetMetabolicAdaptation puts a metabolic adaptation detected during the
+// week at the top of the recommendations.
+func (s *WeeklyDebriefService) SetMetabolicAdaptation(a adaptationSource) {
+	s.adaptation = a
+}
+
+// SetReferenceRanges flags sleep against the user's own range rather than a
+// fixed threshold.
+func (s *WeeklyDebriefService) SetReferenceRanges(r referenceRangeSource) {
+	s.references = r
+}
+
 // GenerateWeeklyDebrief generates a complete weekly debrief for the specified week.
 // If weekEndDate is zero, uses the most recent completed week (last Sunday).
 func (s *WeeklyDebriefService) GenerateWeeklyDebrief(
@@ -42,7 +115,7 @@ func (s *WeeklyDebriefService) GenerateWeeklyDebrief(
 ) (*domain.WeeklyDebrief, error) {
 	// Calculate week boundaries (Monday to Sunday)
 	if weekEndDate.IsZero() {
-		weekEndDate = getMostRecentSunday(time.Now())
+		weekEndDate = getMostRecentSunday(s.now())
 	}
 	weekStartDate := getWeekStartDate(weekEndDate)
 
@@ -73,15 +146,31 @@ func (s *WeeklyDebriefService) GenerateWeeklyDebrief(
 		}
 	}
 
-	// Get flux history for metabolic trend (1 week = 7 days)
+	// Attach recovery activities to their days (best-effort)
+	if s.activityStore != nil {
+		activities, err := s.activityStore.ListByDateRange(ctx, startDateStr, endDateStr)
+		if err == nil {
+			byDate := make(map[string][]domain.RecoveryActivity)
+			for _, a := range activities {
+				byDate[a.Date] = append(byDate[a.Date], a)
+			}
+			for i := range logs {
+				logs[i].RecoveryActivities = byDate[logs[i].Date]
+			}
+		}
+	}
+
+	// Get flux history for metabolic trend (the week up to its Sunday)
 	var fluxHistory []domain.FluxChartPoint
 	if s.metabolicStore != nil {
-		points, err := s.metabolicStore.ListForChart(ctx, 1)
+		points, err := s.metabolicStore.ListForChart(ctx, 1, endDateStr)
 		if err == nil {
 			fluxHistory = points
 		}
 	}
 
+	tolerances := s.weekTolerances(ctx, profile, startDateStr, endDateStr)
+
 	// Build the debrief input for calculations and LLM
 	debriefInput := domain.DebriefInput{
 		WeekStartDate: startDateStr,
@@ -89,37 +178,162 @@ func (s *WeeklyDebriefService) GenerateWeeklyDebrief(
 		Profile:       profile,
 		DailyLogs:     logs,
 		FluxHistory:   fluxHistory,
+		Tolerances:    tolerances,
+	}
+	if s.references != nil {
+		if refs, err := s.references.Ranges(ctx); err == nil {
+			debriefInput.References = refs
+		} else {
+			log.Printf("debrief: personal reference ranges unavailable: %v", err)
+		}
+	}
+
+	// Select scoring profile from goal and active plan (no plan is fine)
+	var activePlan *domain.NutritionPlan
+	if s.planStore != nil {
+		plan, err := s.planStore.GetActive(ctx)
+		if err == nil {
+			activePlan = plan
+		}
 	}
 
 	// Calculate vitality score
-	vitalityScore := domain.CalculateVitalityScore(logs, fluxHistory, profile)
+	scoring := domain.SelectVitalityProfile(profile, activePlan)
+	if profile != nil {
+		scoring.WeightSmoothing = profile.WeightSmoothing
+	}
+	scoring.Tolerances = tolerances
+	if s.habits != nil {
+		if pct, err := s.habits.Adherence(ctx, startDateStr, endDateStr); err == nil {
+			scoring.HabitAdherence = pct
+		} else {
+			log.Printf("debrief: habit adherence unavailable: %v", err)
+		}
+	}
+	vitalityScore := domain.CalculateVitalityScore(logs, fluxHistory, scoring)
 
 	// Build daily breakdown
 	dailyBreakdown := domain.BuildDebriefDayPoints(logs)
+	environmentNotes := domain.BuildEnvironmentNotes(logs)
 
 	// Generate tactical recommendations
 	recommendations := domain.GenerateTacticalRecommendations(debriefInput)
 
 	// Build the debrief
 	debrief := &domain.WeeklyDebrief{
-		WeekStartDate:   startDateStr,
-		WeekEndDate:     endDateStr,
-		VitalityScore:   vitalityScore,
-		Recommendations: recommendations,
-		DailyBreakdown:  dailyBreakdown,
-		GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+		WeekStartDate:    startDateStr,
+		WeekEndDate:      endDateStr,
+		VitalityScore:    vitalityScore,
+		Recommendations:  recommendations,
+		DailyBreakdown:   dailyBreakdown,
+		EnvironmentNotes: environmentNotes,
+		MacroCompliance:  domain.WeekMacroCompliance(logs, tolerances),
+		Tolerances:       tolerances,
+		GeneratedAt:      s.now().UTC().Format(time.RFC3339),
+	}
+
+	// Surface RPE data quality: drafts closed with defaults rather than by the user
+	if autoClosed, err := s.sessionStore.CountAutoClosed(ctx, startDateStr, endDateStr); err == nil {
+		debrief.AutoClosedDrafts = autoClosed
+	}
+
+	// Relate caffeine to sleep (best-effort; the debrief stands without it)
+	if s.caffeineStore != nil {
+		if analysis, err := s.analyzeCaffeine(ctx, weekEndDate, startDateStr, endDateStr); err == nil {
+			debrief.Caffeine = analysis
+		}
+	}
+
+	// Fatigue substitutions taken or declined in the runner (best-effort)
+	if s.substitutions != nil {
+		if decisions, err := s.substitutions.ListByDateRange(ctx, startDateStr, endDateStr); err == nil {
+			debrief.Substitutions = decisions
+		}
+	}
+
+	// Self-challenges running during the week (best-effort)
+	if s.challenges != nil {
+		if progress, err := s.challenges.During(ctx, startDateStr, endDateStr); err == nil {
+			debrief.Challenges = progress
+		} else {
+			log.Printf("debrief: challenges unavailable: %v", err)
+		}
+	}
+
+	// Metabolic adaptation detected during the week leads the recommendations (best-effort)
+	if s.adaptation != nil {
+		if adaptation, err := s.adaptation.During(ctx, startDateStr, endDateStr); err == nil && adaptation != nil {
+			debrief.MetabolicAdaptation = adaptation
+			debrief.Recommendations = domain.PrependRecommendation(debrief.Recommendations, domain.AdaptationDebriefRecommendation(*adaptation))
+		} else if err != nil {
+			log.Printf("debrief: metabolic adaptation unavailable: %v", err)
+		}
 	}
 
 	// Generate narrative (LLM with fallback)
 	debrief.Narrative = s.ollamaService.GenerateDebriefNarrative(ctx, debriefInput, debrief)
 
+	// A fresh debrief supersedes any late-data regeneration flag (best-effort)
+	if s.reconStore != nil {
+		_ = s.reconStore.ClearDebriefStale(ctx, startDateStr)
+	}
+
 	return debrief, nil
 }
 
+// weekTolerances returns the adherence tolerances to score a week with: the
+// ones recorded for it if any, otherwise the profile's. A completed week's
+// tolerances are recorded the first time it is scored.
+func (s *WeeklyDebriefService) weekTolerances(ctx context.Context, profile *domain.UserProfile, startDateStr, endDateStr string) domain.AdherenceTolerances {
+	tolerances := domain.DefaultAdherenceTolerances
+	if profile != nil {
+		tolerances = profile.AdherenceTolerances.WithDefaults()
+	}
+	if s.toleranceStore == nil {
+		return tolerances
+	}
+
+	recorded, err := s.toleranceStore.Get(ctx, startDateStr)
+	if err != nil {
+		log.Printf("debrief: failed to load tolerances for week %s: %v", startDateStr, err)
+		return tolerances
+	}
+	if recorded != nil {
+		return recorded.WithDefaults()
+	}
+	if s.now().Format("2006-01-02") > endDateStr {
+		if err := s.toleranceStore.Record(ctx, startDateStr, tolerances, s.now()); err != nil {
+			log.Printf("debrief: failed to record tolerances for week %s: %v", startDateStr, err)
+		}
+	}
+	return tolerances
+}
+
+// analyzeCaffeine loads the caffeine correlation window ending at the week's
+// end, with the sleep logged the morning after each day.
+func (s *WeeklyDebriefService) analyzeCaffeine(
+	ctx context.Context,
+	weekEndDate time.Time,
+	startDateStr, endDateStr string,
+) (*domain.CaffeineSleepAnalysis, error) {
+	windowStart := weekEndDate.AddDate(0, 0, -(domain.CaffeineAnalysisDays - 1)).Format("2006-01-02")
+	entries, err := s.caffeineStore.ListByDateRange(ctx, windowStart, endDateStr)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	nextMorning := weekEndDate.AddDate(0, 0, 1).Format("2006-01-02")
+	sleepLogs, err := s.logStore.ListByDateRange(ctx, windowStart, nextMorning)
+	if err != nil {
+		return nil, err
+	}
+	return domain.AnalyzeCaffeineSleep(entries, sleepLogs, startDateStr, endDateStr), nil
+}
+
 // GetCurrentWeekInProgress returns a partial debrief for the current incomplete week.
 // Useful for "sneak peek" functionality mid-week.
 func (s *WeeklyDebriefService) GetCurrentWeekInProgress(ctx context.Context) (*domain.WeeklyDebrief, error) {
-	now := time.Now()
+	now := s.now()
 	weekStartDate := getWeekStartDate(now)
 	yesterday := now.AddDate(0, 0, -1)
 
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/report"
+)
+
+// reportHeatmapCompareDays compares the report's fatigue snapshot to the
+// start of the week.
+const reportHeatmapCompareDays = 7
+
+// GenerateWeeklyReport renders the debrief for the week containing date as a
+// printable PDF. The fatigue snapshot is taken at the end of the week (or now,
+// for the current week); if it can't be loaded the report is still produced
+// without it.
+func (s *WeeklyDebriefService) GenerateWeeklyReport(ctx context.Context, date time.Time) ([]byte, *domain.WeeklyDebrief, error) {
+	debrief, err := s.GenerateWeeklyDebrief(ctx, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var heatmap *domain.FatigueHeatmap
+	if s.fatigueService != nil {
+		if weekEnd, err := time.Parse("2006-01-02", debrief.WeekEndDate); err == nil {
+			asOf := weekEnd.AddDate(0, 0, 1).Add(-time.Second)
+			if now := s.now(); asOf.After(now) {
+				asOf = now
+			}
+			heatmap, err = s.fatigueService.GetFatigueHeatmap(ctx, asOf, reportHeatmapCompareDays)
+			if err != nil {
+				log.Printf("weekly report: fatigue heatmap unavailable: %v", err)
+				heatmap = nil
+			}
+		}
+	}
+
+	pdf, err := report.WeeklyDebriefPDF(debrief, heatmap, s.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return pdf, debrief, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ErrDigestNotConfigured is returned when sending a digest with no delivery
+// channel configured.
+var ErrDigestNotConfigured = errors.New("no digest delivery channel configured")
+
+// DigestSendResult reports a digest delivery.
+type DigestSendResult struct {
+	Digest   domain.DailyDigest `json:"digest"`
+	Channels []string           `json:"channels"` // Channels the digest was delivered to
+}
+
+// DigestService builds the end-of-day digest and sends it on a schedule.
+type DigestService struct {
+	dailyLogStore      *store.DailyLogStore
+	sessionStore       *store.TrainingSessionStore
+	weekPreviewService *WeekPreviewService
+	notifiers          []notifier
+	sendMinute         int // Minutes after local midnight
+	jobs               *JobMonitor
+	clocked
+}
+
+// NewDigestService creates a new DigestService. Delivery channels come from
+// the DIGEST_NTFY_* and DIGEST_SMTP_* variables; DIGEST_TIME (HH:MM, local)
+// sets when the scheduled digest goes out.
+func NewDigestService(dls *store.DailyLogStore, ss *store.TrainingSessionStore, wps *WeekPreviewService) *DigestService {
+	minute, _ := domain.ParseDigestTime(domain.DefaultDigestTime)
+	if v := os.Getenv("DIGEST_TIME"); v != "" {
+		if m, ok := domain.ParseDigestTime(v); ok {
+			minute = m
+		} else {
+			log.Printf("digest: invalid DIGEST_TIME %q, using %s", v, domain.DefaultDigestTime)
+		}
+	}
+	return &DigestService{
+		dailyLogStore:      dls,
+		sessionStore:       ss,
+		weekPreviewService: wps,
+		notifiers:          notifiersFromEnv(),
+		sendMinute:         minute,
+	}
+}
+
+// SetJobMonitor enables heartbeats for the scheduled digest.
+func (s *DigestService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Build assembles the digest for a date (YYYY-MM-DD). Tomorrow's plan is left
+// out when it can't be projected (no profile or weight yet).
+func (s *DigestService) Build(ctx context.Context, date string) (*domain.DailyDigest, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyLog, err := s.dailyLogStore.GetByDate(ctx, date)
+	if errors.Is(err, store.ErrDailyLogNotFound) {
+		dailyLog = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var tomorrow *domain.WeekPreviewDay
+	preview, err := s.weekPreviewService.GetPreview(ctx, day.AddDate(0, 0, 1), s.now())
+	switch {
+	case errors.Is(err, store.ErrProfileNotFound), errors.Is(err, domain.ErrInsufficientWeightData):
+	case err != nil:
+		return nil, err
+	case len(preview.Days) > 0:
+		tomorrow = &preview.Days[0]
+	}
+
+	drafts, err := s.sessionStore.ListDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := domain.BuildDailyDigest(date, dailyLog, tomorrow, drafts)
+	return &digest, nil
+}
+
+// Send builds the digest for a date and delivers it to every configured
+// channel. Delivery continues past a failing channel; the first failure is
+// returned alongside whatever was delivered.
+func (s *DigestService) Send(ctx context.Context, date string) (*DigestSendResult, error) {
+	if len(s.notifiers) == 0 {
+		return nil, ErrDigestNotConfigured
+	}
+	digest, err := s.Build(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DigestSendResult{Digest: *digest, Channels: make([]string, 0, len(s.notifiers))}
+	var sendErr error
+	for _, n := range s.notifiers {
+		if err := n.Notify(ctx, digest.Title(), digest.Text()); err != nil {
+			log.Printf("digest: %s delivery failed: %v", n.Name(), err)
+			if sendErr == nil {
+				sendErr = fmt.Errorf("%s: %w", n.Name(), err)
+			}
+			continue
+		}
+		result.Channels = append(result.Channels, n.Name())
+	}
+	return result, sendErr
+}
+
+// RunDailySchedule blocks until ctx is cancelled, sending today's digest every
+// day at the configured time. Does nothing when no channel is configured.
+func (s *DigestService) RunDailySchedule(ctx context.Context) {
+	if len(s.notifiers) == 0 {
+		return
+	}
+
+	log.Printf("digest: enabled, sending daily at %02d:%02d", s.sendMinute/60, s.sendMinute%60)
+	s.jobs.Start(ctx, "daily_digest", 24*time.Hour, time.Now())
+
+	for {
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), s.sendMinute/60, s.sendMinute%60, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+
+		if !s.jobs.ShouldRun(ctx, "daily_digest", time.Now()) {
+			continue
+		}
+		_, err := s.Send(ctx, s.now().Format("2006-01-02"))
+		s.jobs.Beat("daily_digest", time.Now(), err)
+		if err != nil {
+			log.Printf("digest: send failed: %v", err)
+		}
+	}
+}
@@ -2,21 +2,44 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
+	"os"
 	"time"
 
 	"victus/internal/domain"
 	"victus/internal/store"
 )
 
+// achievementRecorder adds personal records named in echo achievements.
+// Implemented by PersonalRecordService.
+type achievementRecorder interface {
+	RecordAchievements(ctx context.Context, achievements []string, sessionID int64) ([]domain.PersonalRecord, error)
+}
+
+// jointDeltaRecorder feeds echo joint deltas into the joint integrity series.
+// Implemented by JointIntegrityService.
+type jointDeltaRecorder interface {
+	RecordEchoDeltas(ctx context.Context, deltas map[string]float64, sessionID int64) error
+}
+
 // EchoService handles business logic for session echo processing.
 type EchoService struct {
 	sessionStore   *store.TrainingSessionStore
 	bodyIssueStore *store.BodyIssueStore
 	dailyLogStore  *store.DailyLogStore
 	ollamaService  *OllamaService
+	draftPolicy    domain.DraftSessionPolicy
+	jobs           *JobMonitor
+	records        achievementRecorder
+	joints         jointDeltaRecorder
+	archetypes     archetypeInferrer
+	clocked
 }
 
 // NewEchoService creates a new EchoService.
+// Draft lifecycle timings can be overridden with DRAFT_REMIND_AFTER and
+// DRAFT_AUTO_CLOSE_AFTER (Go duration strings, e.g. "90m", "36h").
 func NewEchoService(
 	ss *store.TrainingSessionStore,
 	bis *store.BodyIssueStore,
@@ -28,7 +51,28 @@ func NewEchoService(
 		bodyIssueStore: bis,
 		dailyLogStore:  dls,
 		ollamaService:  os,
+		draftPolicy:    draftPolicyFromEnv(),
+	}
+}
+
+// draftPolicyFromEnv applies env overrides to the default draft lifecycle policy.
+func draftPolicyFromEnv() domain.DraftSessionPolicy {
+	policy := domain.DefaultDraftSessionPolicy
+	if v := os.Getenv("DRAFT_REMIND_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			policy.RemindAfter = d
+		} else {
+			log.Printf("echo: invalid DRAFT_REMIND_AFTER %q, using default", v)
+		}
 	}
+	if v := os.Getenv("DRAFT_AUTO_CLOSE_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			policy.AutoCloseAfter = d
+		} else {
+			log.Printf("echo: invalid DRAFT_AUTO_CLOSE_AFTER %q, using default", v)
+		}
+	}
+	return policy
 }
 
 // EchoProcessResult contains the results of processing an echo log.
@@ -36,19 +80,20 @@ type EchoProcessResult struct {
 	Session           *domain.TrainingSession `json:"session"`
 	EchoResult        *domain.EchoLogResult   `json:"echoResult,omitempty"`
 	BodyIssuesCreated []domain.BodyPartIssue  `json:"bodyIssuesCreated,omitempty"`
+	RecordsSet        []domain.PersonalRecord `json:"recordsSet,omitempty"`
 }
 
 // QuickSubmitSession creates a draft session for a daily log.
 // The session will have is_draft=true and can be enriched later via ProcessEcho.
 func (s *EchoService) QuickSubmitSession(ctx context.Context, date string, session domain.TrainingSession) (*domain.TrainingSession, error) {
 	// Get the daily log for this date
-	log, err := s.dailyLogStore.GetByDate(ctx, date)
+	dailyLog, err := s.dailyLogStore.GetByDate(ctx, date)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get existing sessions to determine next session order
-	existingSessions, err := s.sessionStore.GetActualByLogID(ctx, log.ID)
+	existingSessions, err := s.sessionStore.GetActualByLogID(ctx, dailyLog.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +104,24 @@ func (s *EchoService) QuickSubmitSession(ctx context.Context, date string, sessi
 	session.IsDraft = true
 
 	// Create the draft session
-	return s.sessionStore.CreateDraft(ctx, log.ID, session)
+	created, err := s.sessionStore.CreateDraft(ctx, dailyLog.ID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quick entries rarely name an archetype; infer one so fatigue still applies
+	if s.archetypes != nil {
+		assignments, err := s.archetypes.InferForDate(ctx, date)
+		if err != nil {
+			log.Printf("echo: archetype inference failed for %s: %v", date, err)
+		}
+		for _, a := range assignments {
+			if a.SessionID == created.ID && a.Applied {
+				created.Archetype = a.Inference.Archetype
+			}
+		}
+	}
+	return created, nil
 }
 
 // ProcessEcho parses an echo log and updates the session with extracted data.
@@ -103,7 +165,7 @@ func (s *EchoService) ProcessEcho(ctx context.Context, sessionID int64, rawEcho
 	if echoResult != nil {
 		metadata.Achievements = echoResult.Achievements
 		metadata.RPEOffset = echoResult.PerceivedExertionOffset
-		metadata.EchoModel = "llama3.2"
+		metadata.EchoModel = s.ollamaService.ModelFor(OllamaTaskParse)
 	}
 
 	// Finalize session with echo data
@@ -123,6 +185,19 @@ func (s *EchoService) ProcessEcho(ctx context.Context, sessionID int64, rawEcho
 		if err == nil {
 			result.BodyIssuesCreated = issues
 		}
+		if s.joints != nil {
+			if err := s.joints.RecordEchoDeltas(ctx, echoResult.JointIntegrityDelta, sessionID); err != nil {
+				log.Printf("echo: failed to record joint deltas for session %d: %v", sessionID, err)
+			}
+		}
+	}
+
+	// Add personal records named in the achievements
+	if echoResult != nil && s.records != nil {
+		records, err := s.records.RecordAchievements(ctx, echoResult.Achievements, sessionID)
+		if err == nil {
+			result.RecordsSet = records
+		}
 	}
 
 	return result, nil
@@ -130,7 +205,7 @@ func (s *EchoService) ProcessEcho(ctx context.Context, sessionID int64, rawEcho
 
 // createBodyIssuesFromDeltas converts joint integrity deltas to body issues.
 func (s *EchoService) createBodyIssuesFromDeltas(ctx context.Context, deltas map[string]float64, sessionID int64) ([]domain.BodyPartIssue, error) {
-	today := time.Now().Format("2006-01-02")
+	today := s.now().Format("2006-01-02")
 	var inputs []domain.BodyPartIssueInput
 
 	for bodyAlias, delta := range deltas {
@@ -149,8 +224,9 @@ func (s *EchoService) createBodyIssuesFromDeltas(ctx context.Context, deltas map
 				Date:      today,
 				BodyPart:  muscle,
 				Symptom:   symptom,
-				RawText:   "from echo log",
+				RawText:   domain.EchoIssueRawText,
 				SessionID: &sessionID,
+				Side:      domain.SideForAlias(bodyAlias, muscle),
 			})
 		}
 	}
@@ -174,3 +250,108 @@ func (s *EchoService) FinalizeDraft(ctx context.Context, sessionID int64) error
 func (s *EchoService) GetSession(ctx context.Context, sessionID int64) (*domain.TrainingSession, error) {
 	return s.sessionStore.GetByID(ctx, sessionID)
 }
+
+// DraftLifecycleResult reports what a draft lifecycle pass did.
+type DraftLifecycleResult struct {
+	Reminded   int `json:"reminded"`
+	AutoClosed int `json:"autoClosed"`
+}
+
+// RunDraftLifecycle reminds stale drafts and auto-closes expired ones with defaults.
+func (s *EchoService) RunDraftLifecycle(ctx context.Context, now time.Time) (*DraftLifecycleResult, error) {
+	drafts, err := s.sessionStore.ListDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DraftLifecycleResult{}
+	for _, draft := range drafts {
+		switch domain.NextDraftAction(draft, s.draftPolicy, now) {
+		case domain.DraftActionRemind:
+			if err := s.sessionStore.MarkDraftReminded(ctx, draft.Session.ID, now); err != nil {
+				return nil, err
+			}
+			result.Reminded++
+		case domain.DraftActionAutoClose:
+			closed := domain.ApplyDraftDefaults(draft.Session, s.draftPolicy)
+			err := s.sessionStore.AutoCloseDraft(ctx, closed, now)
+			if errors.Is(err, domain.ErrSessionNotDraft) {
+				continue // Finalized by the user since listing
+			}
+			if err != nil {
+				return nil, err
+			}
+			result.AutoClosed++
+		}
+	}
+
+	return result, nil
+}
+
+// ListDraftReminders returns drafts whose RPE reminder has been raised.
+func (s *EchoService) ListDraftReminders(ctx context.Context) ([]domain.DraftSessionState, error) {
+	drafts, err := s.sessionStore.ListDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]domain.DraftSessionState, 0, len(drafts))
+	for _, d := range drafts {
+		if d.RemindedAt != nil {
+			reminders = append(reminders, d)
+		}
+	}
+	return reminders, nil
+}
+
+// SetRecordKeeper enables personal record tracking from echo achievements.
+func (s *EchoService) SetRecordKeeper(r achievementRecorder) {
+	s.records = r
+}
+
+// SetJointTracker enables the joint integrity series from echo joint deltas.
+func (s *EchoService) SetJointTracker(j jointDeltaRecorder) {
+	s.joints = j
+}
+
+// SetArchetypeInferrer enables archetype inference for quick-submitted sessions.
+func (s *EchoService) SetArchetypeInferrer(a archetypeInferrer) {
+	s.archetypes = a
+}
+
+// SetJobMonitor enables heartbeats for the draft lifecycle job.
+func (s *EchoService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// draftLifecycleInterval is how often the draft lifecycle job runs.
+const draftLifecycleInterval = 15 * time.Minute
+
+// RunDraftLifecycleSchedule blocks until ctx is cancelled, running the draft
+// lifecycle every draftLifecycleInterval.
+func (s *EchoService) RunDraftLifecycleSchedule(ctx context.Context) {
+	ticker := time.NewTicker(draftLifecycleInterval)
+	defer ticker.Stop()
+	s.jobs.Start(ctx, "draft_lifecycle", draftLifecycleInterval, time.Now())
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if !s.jobs.ShouldRun(ctx, "draft_lifecycle", time.Now()) {
+			continue
+		}
+		res, err := s.RunDraftLifecycle(ctx, s.now())
+		s.jobs.Beat("draft_lifecycle", time.Now(), err)
+		if err != nil {
+			log.Printf("echo: draft lifecycle failed: %v", err)
+			continue
+		}
+		if res.Reminded > 0 || res.AutoClosed > 0 {
+			log.Printf("echo: draft lifecycle — reminded=%d auto_closed=%d", res.Reminded, res.AutoClosed)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// EquipmentService manages the equipment inventory and travel mode on the profile.
+type EquipmentService struct {
+	profileStore *store.ProfileStore
+}
+
+// NewEquipmentService creates a new EquipmentService.
+func NewEquipmentService(ps *store.ProfileStore) *EquipmentService {
+	return &EquipmentService{profileStore: ps}
+}
+
+// Get returns the equipment profile.
+// Returns store.ErrProfileNotFound if no profile exists.
+func (s *EquipmentService) Get(ctx context.Context) (*domain.EquipmentProfile, error) {
+	return s.profileStore.GetEquipment(ctx)
+}
+
+// Update validates and saves the equipment profile.
+// Returns store.ErrProfileNotFound if no profile exists.
+func (s *EquipmentService) Update(ctx context.Context, ep domain.EquipmentProfile) (*domain.EquipmentProfile, error) {
+	if err := ep.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.profileStore.UpdateEquipment(ctx, ep); err != nil {
+		return nil, err
+	}
+	return s.profileStore.GetEquipment(ctx)
+}
+
+// Availability returns the equipment usable at now, honoring an active travel override.
+// Without a profile nothing is restricted (nil availability).
+func (s *EquipmentService) Availability(ctx context.Context, now time.Time) (domain.EquipmentAvailability, error) {
+	ep, err := s.profileStore.GetEquipment(ctx)
+	if errors.Is(err, store.ErrProfileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ep.AvailableOn(now.Format("2006-01-02")), nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ExperienceService derives the training experience model from the training
+// log and keeps it on the profile.
+type ExperienceService struct {
+	profileStore *store.ProfileStore
+	sessionStore *store.TrainingSessionStore
+	recordStore  *store.PersonalRecordStore
+	clocked
+}
+
+// NewExperienceService creates a new ExperienceService.
+func NewExperienceService(ps *store.ProfileStore, ss *store.TrainingSessionStore, rs *store.PersonalRecordStore) *ExperienceService {
+	return &ExperienceService{profileStore: ps, sessionStore: ss, recordStore: rs}
+}
+
+// Get returns the stored experience model, deriving it again once it is older
+// than domain.ExperienceRefreshInterval.
+// Returns store.ErrProfileNotFound if no profile exists.
+func (s *ExperienceService) Get(ctx context.Context) (*domain.TrainingExperience, error) {
+	exp, err := s.profileStore.GetTrainingExperience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exp != nil && !exp.IsStale(s.now()) {
+		return exp, nil
+	}
+	return s.Refresh(ctx)
+}
+
+// Refresh derives the experience model from the training log now and stores it.
+// Returns store.ErrProfileNotFound if no profile exists.
+func (s *ExperienceService) Refresh(ctx context.Context) (*domain.TrainingExperience, error) {
+	weeks, err := s.sessionStore.WeeklyTrainingVolume(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.recordStore.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	recordDates := make([]string, len(records))
+	for i, r := range records {
+		recordDates[i] = r.Date
+	}
+
+	exp := domain.DeriveTrainingExperience(weeks, recordDates, s.now())
+	if err := s.profileStore.UpdateTrainingExperience(ctx, exp); err != nil {
+		return nil, err
+	}
+	return &exp, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ExportService writes the full history as a downloadable archive: one JSON
+// document, or a ZIP with a CSV per table for spreadsheets.
+type ExportService struct {
+	exportStore *store.ExportStore
+	clocked
+}
+
+// NewExportService creates a new ExportService.
+func NewExportService(es *store.ExportStore) *ExportService {
+	return &ExportService{exportStore: es}
+}
+
+// Filename returns the download name for an export taken now.
+func (s *ExportService) Filename(format domain.ExportFormat) string {
+	return domain.ExportFilename(format, s.now())
+}
+
+// Export streams every exported table to w. Nothing is written until the
+// first table has been read, so an error before then leaves w untouched.
+func (s *ExportService) Export(ctx context.Context, format domain.ExportFormat, w io.Writer) error {
+	manifest := domain.NewExportManifest(s.now())
+	if format == domain.ExportFormatCSV {
+		sink := &csvExportSink{zw: zip.NewWriter(w), manifest: manifest}
+		if err := s.exportStore.StreamTables(ctx, domain.ExportTables, sink); err != nil {
+			return err
+		}
+		return sink.close()
+	}
+
+	sink := &jsonExportSink{w: w, manifest: manifest}
+	if err := s.exportStore.StreamTables(ctx, domain.ExportTables, sink); err != nil {
+		return err
+	}
+	return sink.close()
+}
+
+// jsonExportSink writes
+//
+//	{"format":…,"version":…,"exportedAt":…,"data":{"daily_logs":[{…},…],…}}
+//
+// with each row an object in column order.
+type jsonExportSink struct {
+	w        io.Writer
+	manifest domain.ExportManifest
+	columns  []string
+	tables   int
+	rows     int
+}
+
+func (s *jsonExportSink) BeginTable(table domain.ExportTable, columns []string) error {
+	var head string
+	if s.tables == 0 {
+		header, err := json.Marshal(s.manifest)
+		if err != nil {
+			return err
+		}
+		head = string(header[:len(header)-1]) + `,"data":{`
+	} else {
+		head = "],"
+	}
+	name, _ := json.Marshal(table)
+	s.columns, s.rows = columns, 0
+	s.tables++
+	_, err := io.WriteString(s.w, head+string(name)+":[")
+	return err
+}
+
+func (s *jsonExportSink) WriteRow(values []any) error {
+	buf := make([]byte, 0, 512)
+	if s.rows > 0 {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, '\n', '{')
+	for i, col := range s.columns {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, _ := json.Marshal(col)
+		val, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		buf = append(append(append(buf, key...), ':'), val...)
+	}
+	buf = append(buf, '}')
+	s.rows++
+	_, err := s.w.Write(buf)
+	return err
+}
+
+func (s *jsonExportSink) close() error {
+	_, err := io.WriteString(s.w, "]}}\n")
+	return err
+}
+
+// csvExportSink writes each table as <table>.csv in a ZIP, with a header row
+// of column names, followed by manifest.json with the row counts.
+type csvExportSink struct {
+	zw       *zip.Writer
+	cw       *csv.Writer
+	manifest domain.ExportManifest
+}
+
+func (s *csvExportSink) BeginTable(table domain.ExportTable, columns []string) error {
+	if err := s.flush(); err != nil {
+		return err
+	}
+	file := string(table) + ".csv"
+	f, err := s.zw.Create(file)
+	if err != nil {
+		return err
+	}
+	s.cw = csv.NewWriter(f)
+	s.manifest.Tables = append(s.manifest.Tables, domain.ExportTableSummary{Name: table, File: file, Columns: columns})
+	return s.cw.Write(columns)
+}
+
+func (s *csvExportSink) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = domain.FormatExportCell(v)
+	}
+	s.manifest.Tables[len(s.manifest.Tables)-1].Rows++
+	return s.cw.Write(record)
+}
+
+func (s *csvExportSink) flush() error {
+	if s.cw == nil {
+		return nil
+	}
+	s.cw.Flush()
+	return s.cw.Error()
+}
+
+func (s *csvExportSink) close() error {
+	if err := s.flush(); err != nil {
+		return err
+	}
+	f, err := s.zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s.manifest); err != nil {
+		return err
+	}
+	return s.zw.Close()
+}
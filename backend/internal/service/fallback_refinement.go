@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	"victus/internal/domain"
+)
+
+// =============================================================================
+// OFFLINE SEMANTIC REFINEMENT
+// =============================================================================
+//
+// When Ollama is down (and for every non-primary solution) the refinement is
+// generated from rules instead: each ingredient gets a prep step from its
+// culinary profile, dry ingredients get a measured liquid, large protein loads
+// get a split strategy and the dish gets a zero-calorie flavor patch that
+// rotates between solutions so the same pantry doesn't always read the same.
+
+// fallbackSplitProteinG mirrors the LLM prompt's protein split threshold.
+const fallbackSplitProteinG = 60.0
+
+// Prep stages order the steps: cook first, then build the base, then top it.
+const (
+	prepStageCook = iota
+	prepStageBase
+	prepStageTop
+)
+
+// fallbackPrepRule describes how to prepare foods matching Match (a name
+// substring) or Texture. Empty fields match anything.
+type fallbackPrepRule struct {
+	Match       string
+	Texture     domain.TextureClass
+	Step        string  // Format string taking the ingredient label (and liquid ml when LiquidPerG > 0)
+	LiquidPerG  float64 // ml of liquid per gram for dry ingredients
+	Stage       int
+	RawOnlyStep bool // Only applies when the food is served raw
+}
+
+// fallbackPrepRules is checked in order, so more specific names come first.
+var fallbackPrepRules = []fallbackPrepRule{
+	{Texture: domain.TexturePowder, Step: "Shake %s with %dml water or almond milk until smooth", LiquidPerG: 8, Stage: prepStageBase},
+	{Match: "oats", Step: "Soak %s in %dml milk or water (overnight, or microwave 2 min)", LiquidPerG: 2, Stage: prepStageBase},
+	{Match: "chia", Step: "Stir %s into %dml liquid and rest 10 min to gel", LiquidPerG: 6, Stage: prepStageBase},
+	{Match: "bread", Step: "Toast %s", Stage: prepStageBase},
+	{Match: "egg", Step: "Scramble %s over medium heat, 3 min", Stage: prepStageCook},
+	{Match: "tofu", Step: "Press %s dry, then pan-fry until golden", Stage: prepStageCook},
+	{Match: "salmon", Step: "Bake %s at 200°C for 12 min", Stage: prepStageCook},
+	{Match: "tuna", Step: "Bake %s at 200°C for 12 min", Stage: prepStageCook},
+	{Match: "fish", Step: "Bake %s at 200°C for 12 min", Stage: prepStageCook},
+	{Match: "lentil", Step: "Simmer %s for 20 min (3:1 water to dry lentils)", Stage: prepStageCook},
+	{Match: "bean", Step: "Rinse and warm %s through", Stage: prepStageCook},
+	{Match: "chickpea", Step: "Rinse and warm %s through", Stage: prepStageCook},
+	{Texture: domain.TextureTender, Step: "Pan-sear %s 5-6 min per side, rest 3 min", Stage: prepStageCook},
+	{Match: "quinoa", Step: "Boil %s for 15 min (2:1 water to dry grain)", Stage: prepStageCook},
+	{Match: "rice", Step: "Boil %s for 20-25 min (2:1 water to dry grain)", Stage: prepStageCook},
+	{Texture: domain.TextureGrain, Step: "Cook %s in salted water until tender", Stage: prepStageCook},
+	{Texture: domain.TextureStarchy, Step: "Cube and roast %s at 200°C for 25 min", Stage: prepStageCook},
+	{Texture: domain.TextureLeafy, Step: "Use %s as a raw base", Stage: prepStageBase, RawOnlyStep: true},
+	{Texture: domain.TextureLeafy, Step: "Wilt %s in the pan for 1 min", Stage: prepStageCook},
+	{Match: "yoghurt", Step: "Spoon %s into a bowl as the base", Stage: prepStageBase},
+	{Match: "yogurt", Step: "Spoon %s into a bowl as the base", Stage: prepStageBase},
+	{Match: "avocado", Step: "Slice %s", Stage: prepStageTop},
+	{Match: "nut butter", Step: "Swirl %s through", Stage: prepStageTop},
+	{Texture: domain.TextureOil, Step: "Cook with or drizzle %s", Stage: prepStageCook},
+	{Match: "banana", Step: "Slice %s on top", Stage: prepStageTop},
+}
+
+// fallbackCategorySteps prepare foods no rule matches, by food category.
+var fallbackCategorySteps = map[domain.FoodCategory]fallbackPrepRule{
+	domain.FoodCategoryHighProtein: {Step: "Cook %s through", Stage: prepStageCook},
+	domain.FoodCategoryHighCarb:    {Step: "Cook %s until tender", Stage: prepStageCook},
+	domain.FoodCategoryHighFat:     {Step: "Sprinkle %s over the top", Stage: prepStageTop},
+	domain.FoodCategoryVegetable:   {Step: "Steam %s for 4-5 min", Stage: prepStageCook},
+	domain.FoodCategoryFruit:       {Step: "Rinse %s and add on top", Stage: prepStageTop},
+}
+
+// Zero-calorie flavor patches, matching the LLM prompt's allowed additives.
+var (
+	sweetFlavorPatches = []string{
+		"Cinnamon",
+		"Stevia",
+		"Cinnamon + pinch of salt",
+	}
+	savoryFlavorPatches = []string{
+		"Salt + black pepper",
+		"Lemon juice",
+		"Hot sauce",
+		"Black pepper + lemon juice",
+	}
+)
+
+// fallbackPrepRuleFor picks the prep rule for one food.
+func fallbackPrepRuleFor(food domain.FoodNutrition) fallbackPrepRule {
+	name := strings.ToLower(food.FoodItem)
+	profile := domain.ProfileFood(food)
+	for _, rule := range fallbackPrepRules {
+		if rule.Match != "" && !strings.Contains(name, rule.Match) {
+			continue
+		}
+		if rule.Texture != "" && rule.Texture != profile.Texture {
+			continue
+		}
+		if rule.RawOnlyStep && profile.Prep != domain.PrepRaw {
+			continue
+		}
+		return rule
+	}
+	if rule, ok := fallbackCategorySteps[food.Category]; ok {
+		return rule
+	}
+	return fallbackPrepRule{Step: "Add %s", Stage: prepStageTop}
+}
+
+// fallbackLiquidML rounds the liquid for a dry ingredient to the nearest 10ml.
+func fallbackLiquidML(amountG, perG float64) int {
+	ml := int(math.Round(amountG*perG/10) * 10)
+	if ml < 50 {
+		ml = 50
+	}
+	return ml
+}
+
+// ingredientLabel renders an ingredient as "150g chicken breast".
+func ingredientLabel(ing domain.SolverIngredient) string {
+	display := ing.Display
+	if display == "" {
+		display = fmt.Sprintf("%.0fg", ing.AmountG)
+	}
+	return fmt.Sprintf("%s %s", display, strings.ToLower(ing.Food.FoodItem))
+}
+
+// buildFallbackPrep writes the prep steps for a solution, cooking first and topping last.
+func buildFallbackPrep(ingredients []domain.SolverIngredient) string {
+	type step struct {
+		text  string
+		stage int
+	}
+	steps := make([]step, 0, len(ingredients)+1)
+	for _, ing := range ingredients {
+		rule := fallbackPrepRuleFor(ing.Food)
+		label := ingredientLabel(ing)
+		text := fmt.Sprintf(rule.Step, label)
+		if rule.LiquidPerG > 0 {
+			text = fmt.Sprintf(rule.Step, label, fallbackLiquidML(ing.AmountG, rule.LiquidPerG))
+		}
+		steps = append(steps, step{text: text, stage: rule.Stage})
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].stage < steps[j].stage })
+
+	texts := make([]string, len(steps))
+	for i, s := range steps {
+		texts[i] = s.text
+	}
+	if len(ingredients) > 1 {
+		texts = append(texts, "Combine and serve")
+	}
+	return strings.Join(texts, ". ") + "."
+}
+
+// buildFallbackAlert combines the absurdity warning with the protein split strategy.
+// The split replaces a HIGH_PROTEIN warning, which says the same thing less precisely.
+func buildFallbackAlert(solution domain.SolverSolution, absurdity *domain.AbsurdityWarning) *string {
+	var parts []string
+	if absurdity != nil && absurdity.Code != "HIGH_PROTEIN" {
+		parts = append(parts, absurdity.Description)
+	}
+	if protein := solution.TotalMacros.ProteinG; protein > fallbackSplitProteinG {
+		parts = append(parts, fmt.Sprintf(
+			"PROTEIN OVERLOAD (%.0fg). Split into 2 servings: consume 50%% now, refrigerate 50%% for +3hr.", protein))
+	} else if absurdity != nil && absurdity.Code == "HIGH_PROTEIN" {
+		parts = append(parts, absurdity.Description)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	alert := strings.Join(parts, " ")
+	return &alert
+}
+
+// buildFallbackFlavorPatch picks a zero-calorie patch for the dish's flavor direction.
+// Rotation shifts the pick so sibling solutions don't all get the same patch.
+func buildFallbackFlavorPatch(ingredients []domain.SolverIngredient, rotation int) *string {
+	if len(ingredients) == 0 {
+		return nil
+	}
+	sweet, savory := false, false
+	seed := rotation
+	for _, ing := range ingredients {
+		switch domain.ProfileFood(ing.Food).Pairing {
+		case domain.PairingSweet:
+			sweet = true
+		case domain.PairingSavory:
+			savory = true
+		}
+		seed += int(ing.Food.ID)
+	}
+
+	patches := savoryFlavorPatches
+	if sweet && !savory {
+		patches = sweetFlavorPatches
+	}
+	if seed < 0 {
+		seed = -seed
+	}
+	patch := patches[seed%len(patches)]
+	return &patch
+}
+
+// buildFallbackTitle generates a simple tactical name from the ingredients.
+func buildFallbackTitle(ingredients []domain.SolverIngredient) string {
+	var missionTitle string
+	switch len(ingredients) {
+	case 0:
+		return "RATION PROTOCOL"
+	case 1:
+		missionTitle = fmt.Sprintf("SIMPLE %s PROTOCOL", strings.ToUpper(ingredients[0].Food.FoodItem))
+	case 2:
+		missionTitle = fmt.Sprintf("%s & %s STACK",
+			strings.ToUpper(ingredients[0].Food.FoodItem),
+			strings.ToUpper(ingredients[1].Food.FoodItem))
+	default:
+		missionTitle = fmt.Sprintf("%s MIX: STANDARD", strings.ToUpper(ingredients[0].Food.FoodItem))
+	}
+
+	// Truncate if too long
+	if len(missionTitle) > 50 {
+		missionTitle = missionTitle[:50]
+	}
+	return missionTitle
+}
+
+// BuildFallbackRefinement creates a rule-based semantic refinement when Ollama is unavailable.
+// Rotation varies the flavor patch between solutions of one solve; pass the solution index.
+// Exported for use by solver service for non-primary solutions.
+func BuildFallbackRefinement(solution domain.SolverSolution, absurdity *domain.AbsurdityWarning, rotation int) domain.SemanticRefinement {
+	log.Printf("[OLLAMA] Using fallback refinement (Ollama unavailable)")
+
+	return domain.SemanticRefinement{
+		MissionTitle:      buildFallbackTitle(solution.Ingredients),
+		TacticalPrep:      buildFallbackPrep(solution.Ingredients),
+		AbsurdityAlert:    buildFallbackAlert(solution, absurdity),
+		FlavorPatch:       buildFallbackFlavorPatch(solution.Ingredients, rotation),
+		ContextualInsight: solution.WhyText,
+		GeneratedByLLM:    false,
+		Model:             "",
+	}
+}
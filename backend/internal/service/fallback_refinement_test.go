@@ -0,0 +1,150 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The offline refinement is what users see whenever Ollama is
+// down; tests pin the prep rules, liquid ratios, split strategy and flavor
+// rotation so the fallback stays useful without an LLM.
+type FallbackRefinementSuite struct {
+	suite.Suite
+}
+
+func TestFallbackRefinementSuite(t *testing.T) {
+	suite.Run(t, new(FallbackRefinementSuite))
+}
+
+func (s *FallbackRefinementSuite) ingredient(id int64, category domain.FoodCategory, name string, amountG float64) domain.SolverIngredient {
+	return domain.SolverIngredient{
+		Food:    domain.FoodNutrition{ID: id, Category: category, FoodItem: name},
+		AmountG: amountG,
+	}
+}
+
+func (s *FallbackRefinementSuite) TestPrepStepsFollowCategory() {
+	solution := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{
+			s.ingredient(1, domain.FoodCategoryVegetable, "Broccoli", 100),
+			s.ingredient(2, domain.FoodCategoryHighProtein, "Chicken Breast", 150),
+			s.ingredient(3, domain.FoodCategoryHighCarb, "Brown Rice", 120),
+		},
+	}
+
+	prep := BuildFallbackRefinement(solution, nil, 0).TacticalPrep
+
+	s.Contains(prep, "Steam 100g broccoli")
+	s.Contains(prep, "Pan-sear 150g chicken breast")
+	s.Contains(prep, "2:1 water to dry grain")
+	s.Contains(prep, "Combine and serve.")
+	s.NotContains(prep, "ml", "nothing here needs a liquid binder")
+}
+
+func (s *FallbackRefinementSuite) TestStepsAreOrderedCookBaseTop() {
+	solution := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{
+			s.ingredient(1, domain.FoodCategoryFruit, "Banana", 100),
+			s.ingredient(2, domain.FoodCategoryHighProtein, "Low-fat Greek Yoghurt", 200),
+			s.ingredient(3, domain.FoodCategoryHighProtein, "Eggs", 100),
+		},
+	}
+
+	prep := BuildFallbackRefinement(solution, nil, 0).TacticalPrep
+
+	s.Less(strings.Index(prep, "Scramble"), strings.Index(prep, "Spoon"))
+	s.Less(strings.Index(prep, "Spoon"), strings.Index(prep, "Slice"))
+}
+
+func (s *FallbackRefinementSuite) TestLiquidRatios() {
+	whey := s.ingredient(1, domain.FoodCategoryHighProtein, "Whey Protein", 30)
+	whey.Display = "1 scoop"
+	solution := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{
+			whey,
+			s.ingredient(2, domain.FoodCategoryHighCarb, "Oats", 40),
+			s.ingredient(3, domain.FoodCategoryHighFat, "Chia Seeds", 15),
+		},
+	}
+
+	prep := BuildFallbackRefinement(solution, nil, 0).TacticalPrep
+
+	s.Contains(prep, "Shake 1 scoop whey protein with 240ml")
+	s.Contains(prep, "Soak 40g oats in 80ml")
+	s.Contains(prep, "Stir 15g chia seeds into 90ml")
+	s.Equal(50, fallbackLiquidML(2, 6), "small amounts still get a usable splash")
+}
+
+func (s *FallbackRefinementSuite) TestProteinSplitStrategy() {
+	solution := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{s.ingredient(1, domain.FoodCategoryHighProtein, "Chicken Breast", 250)},
+		TotalMacros: domain.MacroBudget{ProteinG: 72},
+	}
+
+	s.Run("replaces the high protein warning", func() {
+		absurdity := &domain.AbsurdityWarning{Code: "HIGH_PROTEIN", Description: "High protein (72g)."}
+		alert := BuildFallbackRefinement(solution, absurdity, 0).AbsurdityAlert
+		s.Require().NotNil(alert)
+		s.Equal("PROTEIN OVERLOAD (72g). Split into 2 servings: consume 50% now, refrigerate 50% for +3hr.", *alert)
+	})
+
+	s.Run("is added to other warnings", func() {
+		absurdity := &domain.AbsurdityWarning{Code: "SINGLE_LARGE", Description: "Large portion."}
+		alert := BuildFallbackRefinement(solution, absurdity, 0).AbsurdityAlert
+		s.Require().NotNil(alert)
+		s.Contains(*alert, "Large portion.")
+		s.Contains(*alert, "PROTEIN OVERLOAD")
+	})
+
+	s.Run("not needed at or below 60g", func() {
+		solution.TotalMacros.ProteinG = 60
+		s.Nil(BuildFallbackRefinement(solution, nil, 0).AbsurdityAlert)
+	})
+}
+
+func (s *FallbackRefinementSuite) TestFlavorPatchRotation() {
+	sweet := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{
+			s.ingredient(1, domain.FoodCategoryHighProtein, "Low-fat Greek Yoghurt", 200),
+			s.ingredient(2, domain.FoodCategoryFruit, "Blueberries", 80),
+		},
+	}
+	savory := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{
+			s.ingredient(3, domain.FoodCategoryHighProtein, "Chicken Breast", 150),
+			s.ingredient(4, domain.FoodCategoryVegetable, "Broccoli", 100),
+		},
+	}
+
+	seen := make(map[string]bool)
+	for rotation := 0; rotation < len(sweetFlavorPatches); rotation++ {
+		patch := BuildFallbackRefinement(sweet, nil, rotation).FlavorPatch
+		s.Require().NotNil(patch)
+		s.Contains(sweetFlavorPatches, *patch)
+		seen[*patch] = true
+	}
+	s.Len(seen, len(sweetFlavorPatches), "rotation cycles through every patch")
+
+	first := BuildFallbackRefinement(savory, nil, 1).FlavorPatch
+	again := BuildFallbackRefinement(savory, nil, 1).FlavorPatch
+	s.Require().NotNil(first)
+	s.Contains(savoryFlavorPatches, *first)
+	s.Equal(*first, *again, "same solution and rotation give the same patch")
+}
+
+func (s *FallbackRefinementSuite) TestTitle() {
+	solution := domain.SolverSolution{
+		Ingredients: []domain.SolverIngredient{s.ingredient(1, domain.FoodCategoryHighProtein, "Eggs", 100)},
+		WhyText:     "Covers the protein gap.",
+	}
+
+	refinement := BuildFallbackRefinement(solution, nil, 0)
+
+	s.Equal("SIMPLE EGGS PROTOCOL", refinement.MissionTitle)
+	s.Equal("Covers the protein gap.", refinement.ContextualInsight)
+	s.False(refinement.GeneratedByLLM)
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"database/sql"
+	"log"
+	"math"
 	"time"
 
 	"victus/internal/domain"
@@ -13,6 +15,8 @@ import (
 type FatigueService struct {
 	fatigueStore   *store.FatigueStore
 	bodyIssueStore *store.BodyIssueStore // Optional: for issue-based fatigue modifiers
+	rotation       rotationRecorder      // Optional: advances the strength rotation
+	clocked
 }
 
 // NewFatigueService creates a new FatigueService.
@@ -25,26 +29,50 @@ func (s *FatigueService) SetBodyIssueStore(bs *store.BodyIssueStore) {
 	s.bodyIssueStore = bs
 }
 
+// SetRotationRecorder advances the strength rotation whenever a session's
+// fatigue is applied. This is optional - if not set, the rotation only
+// changes when configured.
+func (s *FatigueService) SetRotationRecorder(r rotationRecorder) {
+	s.rotation = r
+}
+
+// recordRotation advances the strength rotation past an applied archetype.
+// Best-effort: fatigue is already persisted, so a failure is only logged.
+func (s *FatigueService) recordRotation(ctx context.Context, archetype domain.Archetype, now time.Time) {
+	if s.rotation == nil {
+		return
+	}
+	if err := s.rotation.Record(ctx, archetype, now.Format("2006-01-02")); err != nil {
+		log.Printf("strength rotation update failed for %s: %v", archetype, err)
+	}
+}
+
 // ApplyLoadByParams applies fatigue based on archetype, duration, and RPE.
 // This is a simpler version that doesn't require a training session ID.
-// Used by the frontend when logging workouts.
+// Used by the frontend when logging workouts. side is set for a session done
+// on one side only, and empty otherwise.
 func (s *FatigueService) ApplyLoadByParams(
 	ctx context.Context,
 	archetype domain.Archetype,
 	durationMin int,
 	rpe *int,
+	side domain.BodySide,
 ) (*domain.SessionFatigueReport, error) {
 	// Get archetype configuration
 	archetypeConfig, err := s.fatigueStore.GetArchetypeByName(ctx, archetype)
 	if err != nil {
 		return nil, err
 	}
+	var split domain.SideSplit
+	if side != "" {
+		split = archetypeConfig.OneSidedSplit(side)
+	}
 
 	// Calculate total load
 	totalLoad := domain.CalculateFatigueSessionLoad(durationMin, rpe)
 
 	// Get current fatigue for affected muscles and apply injections
-	now := time.Now()
+	now := s.now()
 	injections := make([]domain.FatigueInjection, 0)
 
 	err = s.fatigueStore.WithTx(ctx, func(tx *sql.Tx) error {
@@ -52,42 +80,11 @@ func (s *FatigueService) ApplyLoadByParams(
 			if coefficient <= 0 {
 				continue
 			}
-
-			// Get muscle group ID
-			muscleID, err := s.fatigueStore.GetMuscleGroupIDByName(ctx, muscle)
-			if err != nil {
-				return err
-			}
-
-			// Get current fatigue (with decay applied)
-			row, err := s.fatigueStore.GetMuscleFatigue(ctx, muscleID)
-			if err != nil {
-				return err
-			}
-
-			var currentFatigue float64
-			if row != nil {
-				// Apply decay to current value
-				lastUpdateTime, err := time.Parse("2006-01-02 15:04:05", row.LastUpdated)
-				if err == nil {
-					hoursElapsed := now.Sub(lastUpdateTime).Hours()
-					currentFatigue = domain.ApplyFatigueDecay(row.FatiguePercent, hoursElapsed)
-				} else {
-					currentFatigue = row.FatiguePercent
-				}
-			}
-
-			// Calculate injection and new total
 			injectionPercent := domain.CalculateFatigueInjection(totalLoad, coefficient)
-			newTotal := domain.AddFatigue(currentFatigue, injectionPercent)
-
-			// Persist updated fatigue
-			if err := s.fatigueStore.UpsertMuscleFatigueWithTx(ctx, tx, muscleID, newTotal); err != nil {
+			injection, err := s.injectMuscle(ctx, tx, muscle, injectionPercent, split, now)
+			if err != nil {
 				return err
 			}
-
-			// Build injection record
-			injection := domain.BuildFatigueInjection(muscle, injectionPercent, newTotal)
 			injections = append(injections, injection)
 		}
 
@@ -100,6 +97,7 @@ func (s *FatigueService) ApplyLoadByParams(
 	if err != nil {
 		return nil, err
 	}
+	s.recordRotation(ctx, archetype, now)
 
 	return &domain.SessionFatigueReport{
 		SessionID:  0, // No session ID in this flow
@@ -112,43 +110,23 @@ func (s *FatigueService) ApplyLoadByParams(
 
 // ApplyMuscleFatigue applies pre-computed per-muscle fatigue percentages directly.
 // Used by GMB session completion where exercise-level muscle maps are available.
+// split gives the left side's share for muscles worked unevenly by unilateral
+// movements; it may be nil.
 func (s *FatigueService) ApplyMuscleFatigue(
 	ctx context.Context,
 	muscles map[domain.MuscleGroup]float64,
+	split domain.SideSplit,
 ) (*domain.SessionFatigueReport, error) {
-	now := time.Now()
+	now := s.now()
 	injections := make([]domain.FatigueInjection, 0, len(muscles))
 
 	err := s.fatigueStore.WithTx(ctx, func(tx *sql.Tx) error {
 		for muscle, injectionPercent := range muscles {
-			muscleID, err := s.fatigueStore.GetMuscleGroupIDByName(ctx, muscle)
-			if err != nil {
-				return err
-			}
-
-			row, err := s.fatigueStore.GetMuscleFatigue(ctx, muscleID)
+			injection, err := s.injectMuscle(ctx, tx, muscle, injectionPercent, split, now)
 			if err != nil {
 				return err
 			}
-
-			var currentFatigue float64
-			if row != nil {
-				lastUpdateTime, err := time.Parse("2006-01-02 15:04:05", row.LastUpdated)
-				if err == nil {
-					hoursElapsed := now.Sub(lastUpdateTime).Hours()
-					currentFatigue = domain.ApplyFatigueDecay(row.FatiguePercent, hoursElapsed)
-				} else {
-					currentFatigue = row.FatiguePercent
-				}
-			}
-
-			newTotal := domain.AddFatigue(currentFatigue, injectionPercent)
-
-			if err := s.fatigueStore.UpsertMuscleFatigueWithTx(ctx, tx, muscleID, newTotal); err != nil {
-				return err
-			}
-
-			injections = append(injections, domain.BuildFatigueInjection(muscle, injectionPercent, newTotal))
+			injections = append(injections, injection)
 		}
 		return nil
 	})
@@ -166,6 +144,54 @@ func (s *FatigueService) ApplyMuscleFatigue(
 	}, nil
 }
 
+// injectMuscle adds an injection to a muscle's current (decayed) fatigue,
+// split between its sides when split or a tracked split calls for it, and
+// persists the result.
+func (s *FatigueService) injectMuscle(
+	ctx context.Context,
+	tx *sql.Tx,
+	muscle domain.MuscleGroup,
+	injectionPercent float64,
+	split domain.SideSplit,
+	now time.Time,
+) (domain.FatigueInjection, error) {
+	muscleID, err := s.fatigueStore.GetMuscleGroupIDByName(ctx, muscle)
+	if err != nil {
+		return domain.FatigueInjection{}, err
+	}
+
+	row, err := s.fatigueStore.GetMuscleFatigue(ctx, muscleID)
+	if err != nil {
+		return domain.FatigueInjection{}, err
+	}
+	var currentFatigue float64
+	var sides *domain.SideFatigue
+	if row != nil {
+		currentFatigue, sides = decayedFatigue(*row, now)
+	}
+
+	newTotal, newSides := domain.AddSidedFatigue(muscle, currentFatigue, sides, injectionPercent, split)
+	if err := s.fatigueStore.UpsertMuscleFatigueWithTx(ctx, tx, muscleID, newTotal, newSides); err != nil {
+		return domain.FatigueInjection{}, err
+	}
+	return domain.BuildFatigueInjection(muscle, injectionPercent, newTotal).WithSides(newSides), nil
+}
+
+// decayedFatigue returns a muscle's fatigue and left/right split at a time,
+// with decay applied since the row was recorded.
+func decayedFatigue(row store.MuscleFatigueRow, at time.Time) (float64, *domain.SideFatigue) {
+	recordedAt, err := time.Parse("2006-01-02 15:04:05", row.LastUpdated)
+	if err != nil {
+		// Fallback: raw fatigue without decay
+		return row.FatiguePercent, row.Sides
+	}
+	hoursElapsed := at.Sub(recordedAt).Hours()
+	if row.Sides != nil {
+		return domain.DecaySidedFatigue(*row.Sides, hoursElapsed)
+	}
+	return domain.ApplyFatigueDecay(row.FatiguePercent, hoursElapsed), nil
+}
+
 // GetAllArchetypes retrieves all workout archetypes.
 func (s *FatigueService) GetAllArchetypes(ctx context.Context) ([]domain.ArchetypeConfig, error) {
 	return s.fatigueStore.GetAllArchetypes(ctx)
@@ -198,8 +224,10 @@ func (s *FatigueService) GetBodyStatus(ctx context.Context, asOf time.Time) (*do
 		fatigueMap[row.MuscleGroupID] = row
 	}
 
-	// Get issue-based fatigue modifiers if bodyIssueStore is available
+	// Get issue-based fatigue modifiers if bodyIssueStore is available.
+	// Issues naming a side of a limb muscle only add to that side.
 	issueModifiers := make(map[domain.MuscleGroup]float64)
+	sideModifiers := make(map[domain.MuscleGroup]map[domain.BodySide]float64)
 	if s.bodyIssueStore != nil {
 		issues, err := s.bodyIssueStore.GetActiveIssues(ctx)
 		if err == nil { // Ignore errors, just skip modifiers
@@ -210,9 +238,17 @@ func (s *FatigueService) GetBodyStatus(ctx context.Context, asOf time.Time) (*do
 				}
 				daysSince := int(asOf.Sub(issueDate).Hours() / 24)
 				modifier := domain.CalculateIssueFatigueModifier(issue.Severity, daysSince)
-				if modifier > 0 {
-					issueModifiers[issue.BodyPart] += modifier
+				if modifier <= 0 {
+					continue
+				}
+				if issue.Side != "" && domain.LateralMuscles[issue.BodyPart] {
+					if sideModifiers[issue.BodyPart] == nil {
+						sideModifiers[issue.BodyPart] = make(map[domain.BodySide]float64)
+					}
+					sideModifiers[issue.BodyPart][issue.Side] += modifier
+					continue
 				}
+				issueModifiers[issue.BodyPart] += modifier
 			}
 		}
 	}
@@ -220,87 +256,35 @@ func (s *FatigueService) GetBodyStatus(ctx context.Context, asOf time.Time) (*do
 	// Build complete muscle status list with decay applied
 	muscles := make([]domain.MuscleFatigueState, 0, len(muscleGroups))
 	for _, mg := range muscleGroups {
+		// No fatigue entry = fresh muscle
 		var fatiguePercent float64
+		var sides *domain.SideFatigue
 		var lastUpdated string
 
 		if row, exists := fatigueMap[mg.ID]; exists {
-			// Parse last_updated and calculate hours elapsed
-			lastUpdateTime, err := time.Parse("2006-01-02 15:04:05", row.LastUpdated)
-			if err != nil {
-				// Fallback: use raw fatigue without decay
-				fatiguePercent = row.FatiguePercent
-				lastUpdated = row.LastUpdated
-			} else {
-				hoursElapsed := asOf.Sub(lastUpdateTime).Hours()
-				fatiguePercent = domain.ApplyFatigueDecay(row.FatiguePercent, hoursElapsed)
-				lastUpdated = row.LastUpdated
-			}
-		} else {
-			// No fatigue entry = fresh muscle
-			fatiguePercent = 0
-			lastUpdated = ""
+			fatiguePercent, sides = decayedFatigue(row, asOf)
+			lastUpdated = row.LastUpdated
 		}
 
-		// Apply issue-based modifier if present
+		// Apply issue-based modifiers if present
 		if modifier, exists := issueModifiers[mg.Name]; exists {
-			fatiguePercent = domain.AddFatigue(fatiguePercent, modifier)
+			fatiguePercent, sides = domain.AddSidedFatigue(mg.Name, fatiguePercent, sides, modifier, nil)
+		}
+		for side, modifier := range sideModifiers[mg.Name] {
+			fatiguePercent, sides = domain.AddSideFatigue(fatiguePercent, sides, side, modifier)
 		}
 
-		state := domain.BuildMuscleFatigueState(mg.ID, mg.Name, fatiguePercent, lastUpdated)
+		state := domain.BuildMuscleFatigueState(mg.ID, mg.Name, fatiguePercent, lastUpdated).WithSides(sides)
 		muscles = append(muscles, state)
 	}
 
 	// Calculate overall score
 	overallScore := domain.CalculateOverallFatigueScore(muscles)
 
-	// Calculate joint integrity (estimated from muscle fatigue)
-	// Joint integrity inversely correlates with muscle fatigue around joints
-	jointIntegrity := make(map[string]float64)
-	for _, muscle := range muscles {
-		// Common joint-muscle mappings
-		switch muscle.Muscle {
-		case domain.MuscleFrontDelt, domain.MuscleSideDelt, domain.MuscleRearDelt:
-			val := 1.0 - (muscle.FatiguePercent / 100)
-			if existing, ok := jointIntegrity["shoulder"]; ok {
-				jointIntegrity["shoulder"] = (existing + val) / 2 // Average multiple muscles
-			} else {
-				jointIntegrity["shoulder"] = val
-			}
-		case domain.MuscleCore, domain.MuscleLowerBack, domain.MuscleTraps:
-			val := 1.0 - (muscle.FatiguePercent / 100)
-			if existing, ok := jointIntegrity["spine"]; ok {
-				jointIntegrity["spine"] = (existing + val) / 2 // Average multiple muscles
-			} else {
-				jointIntegrity["spine"] = val
-			}
-		case domain.MuscleQuads, domain.MuscleHamstrings, domain.MuscleGlutes:
-			val := 1.0 - (muscle.FatiguePercent / 100)
-			if existing, ok := jointIntegrity["knee"]; ok {
-				jointIntegrity["knee"] = (existing + val) / 2 // Average multiple muscles
-			} else {
-				jointIntegrity["knee"] = val
-			}
-		case domain.MuscleCalves:
-			jointIntegrity["ankle"] = 1.0 - (muscle.FatiguePercent / 100)
-		}
-	}
-
-	// Ensure all major joints have a value
-	if _, ok := jointIntegrity["shoulder"]; !ok {
-		jointIntegrity["shoulder"] = 1.0
-	}
-	if _, ok := jointIntegrity["spine"]; !ok {
-		jointIntegrity["spine"] = 1.0
-	}
-	if _, ok := jointIntegrity["knee"]; !ok {
-		jointIntegrity["knee"] = 1.0
-	}
-	if _, ok := jointIntegrity["ankle"]; !ok {
-		jointIntegrity["ankle"] = 1.0
-	}
-
-	// Calculate systemic load as average fatigue across all muscles
-	systemicLoad := overallScore
+	// Joint integrity is estimated from the fatigue of the surrounding muscles;
+	// systemic load here is mechanical only (BodyStatusService adds the neural side)
+	jointIntegrity := domain.JointIntegrityFromMuscles(muscles)
+	systemicLoad := math.Round(overallScore) / 10
 
 	return &domain.BodyStatus{
 		Muscles:        muscles,
@@ -330,7 +314,7 @@ func (s *FatigueService) ApplySessionLoad(
 	totalLoad := domain.CalculateFatigueSessionLoad(durationMin, rpe)
 
 	// Get current fatigue for affected muscles and apply injections
-	now := time.Now()
+	now := s.now()
 	injections := make([]domain.FatigueInjection, 0)
 
 	err = s.fatigueStore.WithTx(ctx, func(tx *sql.Tx) error {
@@ -338,42 +322,11 @@ func (s *FatigueService) ApplySessionLoad(
 			if coefficient <= 0 {
 				continue
 			}
-
-			// Get muscle group ID
-			muscleID, err := s.fatigueStore.GetMuscleGroupIDByName(ctx, muscle)
-			if err != nil {
-				return err
-			}
-
-			// Get current fatigue (with decay applied)
-			row, err := s.fatigueStore.GetMuscleFatigue(ctx, muscleID)
-			if err != nil {
-				return err
-			}
-
-			var currentFatigue float64
-			if row != nil {
-				// Apply decay to current value
-				lastUpdateTime, err := time.Parse("2006-01-02 15:04:05", row.LastUpdated)
-				if err == nil {
-					hoursElapsed := now.Sub(lastUpdateTime).Hours()
-					currentFatigue = domain.ApplyFatigueDecay(row.FatiguePercent, hoursElapsed)
-				} else {
-					currentFatigue = row.FatiguePercent
-				}
-			}
-
-			// Calculate injection and new total
 			injectionPercent := domain.CalculateFatigueInjection(totalLoad, coefficient)
-			newTotal := domain.AddFatigue(currentFatigue, injectionPercent)
-
-			// Persist updated fatigue
-			if err := s.fatigueStore.UpsertMuscleFatigueWithTx(ctx, tx, muscleID, newTotal); err != nil {
+			injection, err := s.injectMuscle(ctx, tx, muscle, injectionPercent, nil, now)
+			if err != nil {
 				return err
 			}
-
-			// Build injection record
-			injection := domain.BuildFatigueInjection(muscle, injectionPercent, newTotal)
 			injections = append(injections, injection)
 		}
 
@@ -388,6 +341,7 @@ func (s *FatigueService) ApplySessionLoad(
 	if err != nil {
 		return nil, err
 	}
+	s.recordRotation(ctx, archetype, now)
 
 	return &domain.SessionFatigueReport{
 		SessionID:  sessionID,
@@ -397,3 +351,44 @@ func (s *FatigueService) ApplySessionLoad(
 		AppliedAt:  now.Format(time.RFC3339),
 	}, nil
 }
+
+// GetFatigueHeatmap compares current body fatigue against the reconstructed
+// state compareDays ago. Past values come from the latest snapshot at or before
+// the comparison time, with decay applied up to that moment.
+func (s *FatigueService) GetFatigueHeatmap(ctx context.Context, asOf time.Time, compareDays int) (*domain.FatigueHeatmap, error) {
+	current, err := s.GetBodyStatus(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	muscleGroups, err := s.fatigueStore.GetAllMuscleGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	compareAt := asOf.AddDate(0, 0, -compareDays)
+	pastRows, err := s.fatigueStore.GetMuscleFatigueAsOf(ctx, compareAt)
+	if err != nil {
+		return nil, err
+	}
+
+	pastMap := make(map[int]store.MuscleFatigueRow, len(pastRows))
+	for _, row := range pastRows {
+		pastMap[row.MuscleGroupID] = row
+	}
+
+	previous := make([]domain.MuscleFatigueState, 0, len(muscleGroups))
+	for _, mg := range muscleGroups {
+		var fatiguePercent float64
+		var sides *domain.SideFatigue
+		var lastUpdated string
+		if row, exists := pastMap[mg.ID]; exists {
+			lastUpdated = row.LastUpdated
+			fatiguePercent, sides = decayedFatigue(row, compareAt)
+		}
+		previous = append(previous, domain.BuildMuscleFatigueState(mg.ID, mg.Name, fatiguePercent, lastUpdated).WithSides(sides))
+	}
+
+	heatmap := domain.BuildFatigueHeatmap(muscleGroups, current.Muscles, previous, compareDays, asOf, compareAt)
+	return &heatmap, nil
+}
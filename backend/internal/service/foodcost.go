@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// FoodCostService manages food prices, grocery lists and food cost estimates.
+type FoodCostService struct {
+	priceStore   *store.FoodPriceStore
+	foodStore    *store.FoodReferenceStore
+	portionStore *store.FoodPortionStore
+	groceryStore *store.GroceryStore
+	clocked
+}
+
+// NewFoodCostService creates a new FoodCostService.
+func NewFoodCostService(ps *store.FoodPriceStore, fs *store.FoodReferenceStore, portions *store.FoodPortionStore, groceries *store.GroceryStore) *FoodCostService {
+	return &FoodCostService{priceStore: ps, foodStore: fs, portionStore: portions, groceryStore: groceries}
+}
+
+// ListPrices returns all entered prices, ordered by food.
+func (s *FoodCostService) ListPrices(ctx context.Context) ([]domain.FoodPrice, error) {
+	prices, err := s.priceStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SortedFoodPrices(prices), nil
+}
+
+// SetPrice validates and stores a food's price.
+// Returns store.ErrFoodReferenceNotFound for an unknown food.
+func (s *FoodCostService) SetPrice(ctx context.Context, price domain.FoodPrice) (*domain.FoodPrice, error) {
+	if err := price.Validate(); err != nil {
+		return nil, err
+	}
+	price.UpdatedAt = s.now().UTC()
+	if err := s.priceStore.Upsert(ctx, price); err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// DeletePrice removes a food's price.
+// Returns store.ErrFoodPriceNotFound if the food has none.
+func (s *FoodCostService) DeletePrice(ctx context.Context, foodReferenceID int64) error {
+	return s.priceStore.Delete(ctx, foodReferenceID)
+}
+
+// CostItem is one food and amount in a list to be priced.
+type CostItem struct {
+	FoodID  int64
+	AmountG float64
+}
+
+// Estimate prices a grocery list or hand-built meal.
+// Returns store.ErrFoodReferenceNotFound if an item isn't a known food.
+func (s *FoodCostService) Estimate(ctx context.Context, items []CostItem) (*domain.CostEstimate, error) {
+	pantry, err := s.foodStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods := make(map[int64]domain.FoodNutrition, len(pantry))
+	for _, f := range pantry {
+		foods[f.ID] = f
+	}
+
+	ingredients := make([]domain.SolverIngredient, 0, len(items))
+	for _, item := range items {
+		food, ok := foods[item.FoodID]
+		if !ok {
+			return nil, store.ErrFoodReferenceNotFound
+		}
+		ingredients = append(ingredients, domain.SolverIngredient{Food: food, AmountG: item.AmountG})
+	}
+
+	prices, err := s.priceStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	estimate := domain.EstimateIngredientCost(ingredients, prices)
+	return &estimate, nil
+}
+
+// WeeklyTrend returns the estimated cost of logged food for the last weeks
+// weeks, oldest first, ending with the current week to date.
+func (s *FoodCostService) WeeklyTrend(ctx context.Context, weeks int) ([]domain.WeeklyFoodCost, error) {
+	if weeks < 1 || weeks > domain.FoodCostTrendMaxWeeks {
+		return nil, domain.ErrInvalidCostWeeks
+	}
+
+	now := s.now()
+	firstMonday := getWeekStartDate(now).AddDate(0, 0, -7*(weeks-1))
+	logged, err := s.portionStore.ListDailyTotals(ctx, firstMonday.Format("2006-01-02"), now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	prices, err := s.priceStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildWeeklyFoodCosts(logged, prices, firstMonday, weeks), nil
+}
+
+// CreateGroceryList validates and stores a grocery list.
+// Returns store.ErrFoodReferenceNotFound if an item names an unknown food.
+func (s *FoodCostService) CreateGroceryList(ctx context.Context, list domain.GroceryList) (*domain.GroceryList, error) {
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		list.Items[i].PurchasedOn = nil
+	}
+	if err := s.groceryStore.Create(ctx, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListGroceryLists returns all grocery lists, newest week first.
+func (s *FoodCostService) ListGroceryLists(ctx context.Context) ([]domain.GroceryList, error) {
+	return s.groceryStore.List(ctx)
+}
+
+// SetGroceryItemPurchased marks an item bought on date (YYYY-MM-DD, default
+// today), or not bought when purchased is false, and returns the updated list.
+// Returns store.ErrGroceryListNotFound or store.ErrGroceryItemNotFound.
+func (s *FoodCostService) SetGroceryItemPurchased(ctx context.Context, listID, itemID int64, purchased bool, date string) (*domain.GroceryList, error) {
+	var purchasedOn *string
+	if purchased {
+		now := s.now()
+		if date == "" {
+			date = now.Format("2006-01-02")
+		}
+		if err := domain.ValidatePurchaseDate(date, now); err != nil {
+			return nil, err
+		}
+		purchasedOn = &date
+	}
+
+	if _, err := s.groceryStore.GetByID(ctx, listID); err != nil {
+		return nil, err
+	}
+	if err := s.groceryStore.SetItemPurchased(ctx, listID, itemID, purchasedOn); err != nil {
+		return nil, err
+	}
+	return s.groceryStore.GetByID(ctx, listID)
+}
+
+// DeleteGroceryList removes a grocery list.
+// Returns store.ErrGroceryListNotFound if it doesn't exist.
+func (s *FoodCostService) DeleteGroceryList(ctx context.Context, id int64) error {
+	return s.groceryStore.Delete(ctx, id)
+}
+
+// GetShoppingRollup compares the groceries bought in a month (YYYY-MM,
+// default current) with the portions logged afterwards.
+func (s *FoodCostService) GetShoppingRollup(ctx context.Context, month string) (*domain.ShoppingRollup, error) {
+	now := s.now()
+	if month == "" {
+		month = now.Format("2006-01")
+	}
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, domain.ErrInvalidRollupMonth
+	}
+	monthEnd := start.AddDate(0, 1, -1)
+
+	purchases, err := s.groceryStore.ListPurchasedBetween(ctx, start.Format("2006-01-02"), monthEnd.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	// A purchase late in the month can be eaten into the next one
+	logged, err := s.portionStore.ListDailyTotals(ctx,
+		start.Format("2006-01-02"), monthEnd.AddDate(0, 0, domain.GroceryUseDays).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	prices, err := s.priceStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildShoppingRollup(month, purchases, logged, prices, now)
+}
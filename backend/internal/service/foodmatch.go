@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// FoodMatchService resolves free-text food names to food_reference rows,
+// learns aliases from user-confirmed matches, and learns default portions
+// from logged quantities.
+type FoodMatchService struct {
+	foodReferenceStore *store.FoodReferenceStore
+	foodPortionStore   *store.FoodPortionStore
+}
+
+// NewFoodMatchService creates a new FoodMatchService.
+func NewFoodMatchService(foodReferenceStore *store.FoodReferenceStore, foodPortionStore *store.FoodPortionStore) *FoodMatchService {
+	return &FoodMatchService{
+		foodReferenceStore: foodReferenceStore,
+		foodPortionStore:   foodPortionStore,
+	}
+}
+
+// Match resolves a food name against the food reference and synonym table.
+func (s *FoodMatchService) Match(ctx context.Context, query string) (*domain.FoodMatchResult, error) {
+	foods, synonyms, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := domain.ResolveFood(query, foods, synonyms)
+	return &result, nil
+}
+
+// MatchAll resolves several names with a single load of the food reference.
+func (s *FoodMatchService) MatchAll(ctx context.Context, queries []string) ([]domain.FoodMatchResult, error) {
+	foods, synonyms, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]domain.FoodMatchResult, len(queries))
+	for i, q := range queries {
+		results[i] = domain.ResolveFood(q, foods, synonyms)
+	}
+	return results, nil
+}
+
+// Confirm records the user's choice for a name so future lookups resolve it directly.
+// A correction replaces any earlier alias for the same canonical name.
+// Returns ErrFoodReferenceNotFound for an unknown food.
+func (s *FoodMatchService) Confirm(ctx context.Context, query string, foodReferenceID int64) (*domain.FoodSynonym, error) {
+	alias := domain.CanonicalFoodName(query)
+	if alias == "" {
+		return nil, domain.ErrEmptyFoodQuery
+	}
+
+	syn := domain.FoodSynonym{
+		Alias:           alias,
+		FoodReferenceID: foodReferenceID,
+		Source:          domain.FoodSynonymSourceUser,
+	}
+	if err := s.foodReferenceStore.UpsertSynonym(ctx, syn); err != nil {
+		return nil, err
+	}
+	return &syn, nil
+}
+
+// ListSynonyms returns all known aliases.
+func (s *FoodMatchService) ListSynonyms(ctx context.Context) ([]domain.FoodSynonym, error) {
+	return s.foodReferenceStore.ListSynonyms(ctx)
+}
+
+// DefaultPortion returns the personal default portion for a food in a meal slot
+// (nil meal = unknown slot), learned from previously logged quantities.
+func (s *FoodMatchService) DefaultPortion(ctx context.Context, food domain.FoodNutrition, meal *domain.MealName) (domain.PortionDefault, error) {
+	history, err := s.foodPortionStore.ListRecent(ctx, food.ID, domain.PortionHistoryWindow)
+	if err != nil {
+		return domain.PortionDefault{}, err
+	}
+	return domain.DefaultPortion(food, meal, history), nil
+}
+
+// DefaultPortionByID is DefaultPortion for a food_reference ID.
+// Returns ErrFoodReferenceNotFound when the food is unknown or has no nutrition data.
+func (s *FoodMatchService) DefaultPortionByID(ctx context.Context, foodReferenceID int64, meal *domain.MealName) (*domain.PortionDefault, error) {
+	foods, err := s.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range foods {
+		if f.ID == foodReferenceID {
+			portion, err := s.DefaultPortion(ctx, f, meal)
+			if err != nil {
+				return nil, err
+			}
+			return &portion, nil
+		}
+	}
+	return nil, store.ErrFoodReferenceNotFound
+}
+
+// RecordPortion stores a logged quantity so future defaults reflect it.
+func (s *FoodMatchService) RecordPortion(ctx context.Context, entry domain.FoodPortionEntry) error {
+	return s.foodPortionStore.Record(ctx, entry)
+}
+
+// load fetches the matchable foods and the synonym table keyed by canonical alias.
+func (s *FoodMatchService) load(ctx context.Context) ([]domain.FoodNutrition, map[string]int64, error) {
+	foods, err := s.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := s.foodReferenceStore.ListSynonyms(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return foods, domain.IndexFoodSynonyms(list), nil
+}
@@ -10,15 +10,24 @@ import (
 	"os/exec"
 	"time"
 
+	"victus/internal/domain"
 	"victus/internal/store"
 )
 
+// lateDataReconciler patches derived values when synced data lands on a past day.
+type lateDataReconciler interface {
+	ReconcileDate(ctx context.Context, date string, fields []domain.LateDataField, now time.Time) (*domain.ReconciliationResult, error)
+}
+
 // GarminSyncService fetches health data from Garmin Connect via the Python script
 // and upserts it into the daily log store.
 type GarminSyncService struct {
 	dailyLogStore *store.DailyLogStore
+	reconciler    lateDataReconciler
 	scriptPath    string
 	pythonPath    string
+	jobs          *JobMonitor
+	clocked
 }
 
 // NewGarminSyncService creates a new GarminSyncService.
@@ -44,6 +53,16 @@ func NewGarminSyncService(dailyLogStore *store.DailyLogStore) *GarminSyncService
 	}
 }
 
+// SetReconciler enables late-data reconciliation for syncs of past days.
+func (s *GarminSyncService) SetReconciler(r lateDataReconciler) {
+	s.reconciler = r
+}
+
+// SetJobMonitor enables heartbeats for the daily sync job.
+func (s *GarminSyncService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
 // GarminSyncResult describes what was synced for a given date.
 type GarminSyncResult struct {
 	Date           string   `json:"date"`
@@ -53,6 +72,8 @@ type GarminSyncResult struct {
 	RHRSynced      bool     `json:"rhrSynced"`
 	CaloriesSynced bool     `json:"caloriesSynced"`
 	Errors         []string `json:"errors,omitempty"`
+
+	Reconciliation *domain.ReconciliationResult `json:"reconciliation,omitempty"`
 }
 
 // garminAPIData mirrors the JSON output of scripts/garmin_fetch.py.
@@ -147,12 +168,45 @@ func (s *GarminSyncService) SyncDate(ctx context.Context, date string) (*GarminS
 		}
 	}
 
+	// Wearable data for past days arrives late; patch what depended on it
+	if s.reconciler != nil {
+		if fields := result.lateDataFields(); len(fields) > 0 {
+			rec, err := s.reconciler.ReconcileDate(ctx, date, fields, s.now())
+			if err != nil {
+				result.Errors = append(result.Errors, "reconciliation: "+err.Error())
+			} else {
+				result.Reconciliation = rec
+			}
+		}
+	}
+
 	return result, nil
 }
 
+// lateDataFields lists the synced metrics that feed derived values.
+func (r *GarminSyncResult) lateDataFields() []domain.LateDataField {
+	var fields []domain.LateDataField
+	if r.HRVSynced {
+		fields = append(fields, domain.LateDataHRV)
+	}
+	if r.RHRSynced {
+		fields = append(fields, domain.LateDataRestingHR)
+	}
+	if r.SleepSynced {
+		fields = append(fields, domain.LateDataSleep)
+	}
+	if r.CaloriesSynced {
+		fields = append(fields, domain.LateDataActiveCalories)
+	}
+	if r.WeightSynced {
+		fields = append(fields, domain.LateDataWeight)
+	}
+	return fields
+}
+
 // SyncToday syncs today's data.
 func (s *GarminSyncService) SyncToday(ctx context.Context) (*GarminSyncResult, error) {
-	return s.SyncDate(ctx, time.Now().Format("2006-01-02"))
+	return s.SyncDate(ctx, s.now().Format("2006-01-02"))
 }
 
 // RunDailySchedule blocks until ctx is cancelled, triggering a sync every day at 04:00 local time.
@@ -163,6 +217,7 @@ func (s *GarminSyncService) RunDailySchedule(ctx context.Context) {
 	}
 
 	log.Println("garmin: auto-sync enabled, scheduling daily sync at 04:00")
+	s.jobs.Start(ctx, "garmin_daily_sync", 24*time.Hour, time.Now())
 
 	for {
 		now := time.Now()
@@ -177,17 +232,24 @@ func (s *GarminSyncService) RunDailySchedule(ctx context.Context) {
 			return
 		}
 
-		today := time.Now().Format("2006-01-02")
-		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		if !s.jobs.ShouldRun(ctx, "garmin_daily_sync", time.Now()) {
+			continue
+		}
+
+		today := s.now().Format("2006-01-02")
+		yesterday := s.now().AddDate(0, 0, -1).Format("2006-01-02")
 
+		var syncErr error
 		for _, date := range []string{yesterday, today} {
 			res, err := s.SyncDate(ctx, date)
 			if err != nil {
 				log.Printf("garmin: sync failed for %s: %v", date, err)
+				syncErr = err
 				continue
 			}
 			log.Printf("garmin: synced %s — weight=%v sleep=%v hrv=%v rhr=%v calories=%v errors=%v",
 				date, res.WeightSynced, res.SleepSynced, res.HRVSynced, res.RHRSynced, res.CaloriesSynced, len(res.Errors))
 		}
+		s.jobs.Beat("garmin_daily_sync", time.Now(), syncErr)
 	}
 }
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// habitAdherenceSource provides the share of counted habits done over a range.
+// Implemented by HabitService.
+type habitAdherenceSource interface {
+	Adherence(ctx context.Context, start, end string) (*float64, error)
+}
+
+// allHistory is a lower date bound before any check-off, for loading the
+// full history streaks need.
+const allHistory = ""
+
+// HabitService handles the daily habit checklist.
+type HabitService struct {
+	habitStore *store.HabitStore
+	clocked
+}
+
+// NewHabitService creates a new HabitService.
+func NewHabitService(hs *store.HabitStore) *HabitService {
+	return &HabitService{habitStore: hs}
+}
+
+// List returns all habits, archived included.
+func (s *HabitService) List(ctx context.Context) ([]domain.Habit, error) {
+	return s.habitStore.List(ctx)
+}
+
+// Create validates and stores a habit. It starts today, so earlier days don't
+// count against it.
+func (s *HabitService) Create(ctx context.Context, habit domain.Habit) (*domain.Habit, error) {
+	if err := habit.Normalize(); err != nil {
+		return nil, err
+	}
+	habit.Archived = false
+	habit.StartDate = s.now().Format("2006-01-02")
+	if err := s.habitStore.Create(ctx, &habit); err != nil {
+		return nil, err
+	}
+	return &habit, nil
+}
+
+// Update renames a habit, changes whether it counts toward vitality, or
+// archives/restores it. Its start date and history are kept.
+func (s *HabitService) Update(ctx context.Context, habit domain.Habit) (*domain.Habit, error) {
+	if err := habit.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.habitStore.Update(ctx, &habit); err != nil {
+		return nil, err
+	}
+	return s.habitStore.GetByID(ctx, habit.ID)
+}
+
+// Delete removes a habit and its history.
+func (s *HabitService) Delete(ctx context.Context, id int64) error {
+	return s.habitStore.Delete(ctx, id)
+}
+
+// Checklist returns the active habits for a date with their done state and streaks.
+func (s *HabitService) Checklist(ctx context.Context, date string) ([]domain.ChecklistItem, error) {
+	habits, err := s.habitStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := s.habitStore.ListChecks(ctx, allHistory, date)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildChecklist(habits, checks, date), nil
+}
+
+// SetCheck marks a habit done or not done on a date and returns the updated
+// checklist item. Archived habits can't be checked off.
+func (s *HabitService) SetCheck(ctx context.Context, date string, habitID int64, done bool) (*domain.ChecklistItem, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, domain.ErrInvalidDate
+	}
+	habit, err := s.habitStore.GetByID(ctx, habitID)
+	if err != nil {
+		return nil, err
+	}
+	if habit.Archived {
+		return nil, domain.ErrHabitArchived
+	}
+	if err := s.habitStore.SetCheck(ctx, habitID, date, done); err != nil {
+		return nil, err
+	}
+
+	checks, err := s.habitStore.ListChecks(ctx, allHistory, date)
+	if err != nil {
+		return nil, err
+	}
+	items := domain.BuildChecklist([]domain.Habit{*habit}, checks, date)
+	return &items[0], nil
+}
+
+// Adherence returns the percentage of habit-days done between start and end
+// (inclusive) over the habits that count toward vitality, or nil when none do.
+func (s *HabitService) Adherence(ctx context.Context, start, end string) (*float64, error) {
+	habits, err := s.habitStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := s.habitStore.ListChecks(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return domain.HabitAdherence(habits, checks, start, end), nil
+}
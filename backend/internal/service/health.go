@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"victus/internal/db"
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// JobMonitor records background job heartbeats for readiness probes and,
+// with leases set, elects which replica runs each job.
+// Safe for concurrent use.
+type JobMonitor struct {
+	mu     sync.Mutex
+	jobs   map[string]*domain.JobHeartbeat
+	leases *JobLeases
+}
+
+// NewJobMonitor creates an empty JobMonitor.
+func NewJobMonitor() *JobMonitor {
+	return &JobMonitor{jobs: make(map[string]*domain.JobHeartbeat)}
+}
+
+// SetLeases enables lease-based leadership, so scheduled jobs run on one
+// replica at a time.
+func (m *JobMonitor) SetLeases(l *JobLeases) {
+	m.leases = l
+}
+
+// Start registers a job and its expected interval between runs. With leases
+// set, it also keeps competing for the job's lease until ctx is cancelled.
+func (m *JobMonitor) Start(ctx context.Context, name string, interval time.Duration, now time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.jobs[name] = &domain.JobHeartbeat{Name: name, Interval: interval, StartedAt: now}
+	m.mu.Unlock()
+
+	if m.leases != nil {
+		go m.leases.Hold(ctx, name)
+	}
+}
+
+// ShouldRun reports whether this replica should run the job now: always
+// without leases, otherwise only while it holds the job's lease. Standby
+// replicas are marked so their idle job isn't reported as lagging.
+func (m *JobMonitor) ShouldRun(ctx context.Context, name string, now time.Time) bool {
+	if m == nil || m.leases == nil {
+		return true
+	}
+	leader, err := m.leases.Claim(ctx, name, now)
+	if err != nil {
+		log.Printf("jobs: lease check for %s failed: %v", name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hb, ok := m.jobs[name]; ok {
+		hb.Standby = !leader
+		if !leader {
+			// Restart lag tracking for when this replica takes over
+			hb.StartedAt = now
+			hb.LastRun = time.Time{}
+		}
+	}
+	return leader
+}
+
+// Beat records a completed run; err is the run's failure, if any.
+func (m *JobMonitor) Beat(name string, now time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hb, ok := m.jobs[name]
+	if !ok {
+		return
+	}
+	hb.LastRun = now
+	hb.LastError = ""
+	if err != nil {
+		hb.LastError = err.Error()
+	}
+}
+
+// Heartbeats returns a snapshot of all registered jobs, sorted by name.
+func (m *JobMonitor) Heartbeats() []domain.JobHeartbeat {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	heartbeats := make([]domain.JobHeartbeat, 0, len(m.jobs))
+	for _, hb := range m.jobs {
+		heartbeats = append(heartbeats, *hb)
+	}
+	sort.Slice(heartbeats, func(i, j int) bool { return heartbeats[i].Name < heartbeats[j].Name })
+	return heartbeats
+}
+
+// pinger is implemented by *sql.DB (and db.DB).
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// healthProbeTimeout bounds each dependency probe.
+const healthProbeTimeout = 3 * time.Second
+
+// HealthService probes the server's dependencies for liveness and readiness.
+type HealthService struct {
+	db            store.DBTX
+	ollamaService *OllamaService
+	jobs          *JobMonitor
+}
+
+// NewHealthService creates a new HealthService.
+func NewHealthService(db store.DBTX, ollamaService *OllamaService, jobs *JobMonitor) *HealthService {
+	return &HealthService{db: db, ollamaService: ollamaService, jobs: jobs}
+}
+
+// Liveness probes only the database connection.
+func (s *HealthService) Liveness(ctx context.Context, now time.Time) *domain.HealthReport {
+	return domain.NewHealthReport([]domain.HealthComponent{s.probeDatabase(ctx)}, now)
+}
+
+// Readiness probes the database, pending migrations, Ollama and background
+// job lag. Ollama and jobs are non-critical.
+func (s *HealthService) Readiness(ctx context.Context, now time.Time) *domain.HealthReport {
+	components := []domain.HealthComponent{
+		s.probeDatabase(ctx),
+		s.probeMigrations(ctx),
+		s.probeOllama(ctx),
+	}
+	for _, hb := range s.jobs.Heartbeats() {
+		components = append(components, hb.Component(now))
+	}
+	return domain.NewHealthReport(components, now)
+}
+
+func (s *HealthService) probeDatabase(ctx context.Context) domain.HealthComponent {
+	c := domain.HealthComponent{Name: "database", Status: domain.ComponentOK, Critical: true}
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	if p, ok := s.db.(pinger); ok {
+		err = p.PingContext(ctx)
+	} else {
+		_, err = s.db.ExecContext(ctx, `SELECT 1`)
+	}
+	c.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		c.Status = domain.ComponentDown
+		c.Detail = err.Error()
+	}
+	return c
+}
+
+func (s *HealthService) probeMigrations(ctx context.Context) domain.HealthComponent {
+	c := domain.HealthComponent{Name: "migrations", Status: domain.ComponentOK, Critical: true}
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	pending, err := db.PendingMigrations(ctx, s.db)
+	c.LatencyMs = time.Since(start).Milliseconds()
+	switch {
+	case err != nil:
+		c.Status = domain.ComponentDown
+		c.Detail = err.Error()
+	case pending > 0:
+		c.Status = domain.ComponentDown
+		c.Detail = fmt.Sprintf("%d pending migrations", pending)
+	}
+	return c
+}
+
+func (s *HealthService) probeOllama(ctx context.Context) domain.HealthComponent {
+	c := domain.HealthComponent{Name: "ollama", Status: domain.ComponentOK}
+	if s.ollamaService == nil {
+		c.Status = domain.ComponentDegraded
+		c.Detail = "not configured"
+		return c
+	}
+
+	start := time.Now()
+	err := s.ollamaService.Probe(ctx)
+	c.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		c.Status = domain.ComponentDegraded
+		c.Detail = err.Error()
+		return c
+	}
+
+	// Reachable now, but features still fail fast until their circuit's half-open probe succeeds
+	var open []string
+	for _, stats := range s.ollamaService.CircuitStats() {
+		if stats.State != CircuitClosed {
+			open = append(open, stats.Endpoint)
+		}
+	}
+	if len(open) > 0 {
+		c.Status = domain.ComponentDegraded
+		c.Detail = "circuit open: " + strings.Join(open, ", ")
+	}
+	return c
+}
@@ -8,7 +8,6 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"victus/internal/domain"
 	"victus/internal/importer"
@@ -17,13 +16,23 @@ import (
 
 // ImportService orchestrates data imports from external sources.
 type ImportService struct {
-	garminImporter *importer.GarminImporter
+	garminImporter       *importer.GarminImporter
+	nutritionImporter    *importer.NutritionImporter
+	nutritionImportStore *store.NutritionImportStore
+	clocked
 }
 
 // NewImportService creates a new import service.
-func NewImportService(dailyLogStore *store.DailyLogStore, monthlySummaryStore *store.MonthlySummaryStore) *ImportService {
+func NewImportService(
+	dailyLogStore *store.DailyLogStore,
+	monthlySummaryStore *store.MonthlySummaryStore,
+	foodReferenceStore *store.FoodReferenceStore,
+	nutritionImportStore *store.NutritionImportStore,
+) *ImportService {
 	return &ImportService{
-		garminImporter: importer.NewGarminImporter(dailyLogStore, monthlySummaryStore),
+		garminImporter:       importer.NewGarminImporter(dailyLogStore, monthlySummaryStore),
+		nutritionImporter:    importer.NewNutritionImporter(dailyLogStore, foodReferenceStore, nutritionImportStore),
+		nutritionImportStore: nutritionImportStore,
 	}
 }
 
@@ -33,7 +42,7 @@ func NewImportService(dailyLogStore *store.DailyLogStore, monthlySummaryStore *s
 func (s *ImportService) ProcessGarminUpload(ctx context.Context, filename string, data []byte, year int) (*domain.GarminImportResult, error) {
 	// Default to current year if not specified
 	if year == 0 {
-		year = time.Now().Year()
+		year = s.now().Year()
 	}
 
 	// Check if it's a ZIP file
@@ -156,3 +165,38 @@ func (s *ImportService) mergeResults(dst, src *domain.GarminImportResult) {
 	dst.Warnings = append(dst.Warnings, src.Warnings...)
 	dst.Errors = append(dst.Errors, src.Errors...)
 }
+
+// ProcessNutritionUpload imports a MyFitnessPal or Cronometer CSV export.
+// Food rows become imported entries on their historical dates and are added to
+// those days' consumed totals.
+func (s *ImportService) ProcessNutritionUpload(ctx context.Context, filename string, data []byte) (*domain.NutritionImportResult, error) {
+	if ext := strings.ToLower(filepath.Ext(filename)); ext != ".csv" {
+		return nil, fmt.Errorf("unsupported file extension %q: expected a CSV export", ext)
+	}
+
+	fileType, err := s.nutritionImporter.DetectFileType(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect file type: %w", err)
+	}
+	if fileType == importer.FileTypeUnknown {
+		return nil, fmt.Errorf("unrecognized nutrition export: %s", filename)
+	}
+
+	result, err := s.nutritionImporter.Import(ctx, fileType, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("import failed: %w", err)
+	}
+	return result, nil
+}
+
+// ListUnmatchedFoods returns imported entries awaiting a food_reference match.
+func (s *ImportService) ListUnmatchedFoods(ctx context.Context) ([]domain.ImportedFoodEntry, error) {
+	return s.nutritionImportStore.ListUnmatched(ctx)
+}
+
+// ResolveUnmatchedFood links a queued entry to a food_reference row.
+// With applyToName, other queued entries sharing its raw name are linked too.
+// Returns the number of entries resolved.
+func (s *ImportService) ResolveUnmatchedFood(ctx context.Context, entryID, foodReferenceID int64, applyToName bool) (int, error) {
+	return s.nutritionImportStore.ResolveEntry(ctx, entryID, foodReferenceID, applyToName)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// injuryRiskBaselineDays is how far before the month load is read, so the
+// chronic load is established from the month's first day.
+const injuryRiskBaselineDays = 28
+
+// InjuryRiskService builds the monthly injury risk report from training load,
+// body part issues and left/right fatigue. Reports are computed on demand and
+// not stored.
+type InjuryRiskService struct {
+	sessionStore   *store.TrainingSessionStore
+	bodyIssueStore *store.BodyIssueStore
+	fatigueStore   *store.FatigueStore
+	clocked
+}
+
+// NewInjuryRiskService creates a new InjuryRiskService.
+func NewInjuryRiskService(ss *store.TrainingSessionStore, bs *store.BodyIssueStore, fs *store.FatigueStore) *InjuryRiskService {
+	return &InjuryRiskService{sessionStore: ss, bodyIssueStore: bs, fatigueStore: fs}
+}
+
+// CurrentMonth returns the current month as YYYY-MM.
+func (s *InjuryRiskService) CurrentMonth() string {
+	return s.now().Format("2006-01")
+}
+
+// Report returns the injury risk report for a month (YYYY-MM). The current
+// month is analyzed to date.
+func (s *InjuryRiskService) Report(ctx context.Context, month string) (*domain.InjuryRiskReport, error) {
+	start, end, err := domain.InjuryRiskPeriod(month, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	first, _ := time.Parse("2006-01-02", start)
+	baseline := first.AddDate(0, 0, -injuryRiskBaselineDays).Format("2006-01-02")
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, baseline, end)
+	if err != nil {
+		return nil, fmt.Errorf("loading sessions: %w", err)
+	}
+	loads := make([]domain.DailyLoadDataPoint, len(sessions))
+	for i, day := range sessions {
+		loads[i] = domain.DailyLoadDataPoint{
+			Date:      day.Date,
+			DailyLoad: domain.DailyLoad(day.ActualSessions, day.PlannedSessions),
+		}
+	}
+
+	issues, err := s.bodyIssueStore.GetByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("loading body issues: %w", err)
+	}
+
+	sides, err := s.dailySideFatigue(ctx, first, end)
+	if err != nil {
+		return nil, fmt.Errorf("loading fatigue snapshots: %w", err)
+	}
+
+	report := domain.BuildInjuryRiskReport(domain.InjuryRiskInput{
+		Month:     month,
+		StartDate: start,
+		EndDate:   end,
+		Loads:     loads,
+		Issues:    issues,
+		Sides:     sides,
+	})
+	return &report, nil
+}
+
+// dailySideFatigue reads each day's closing left/right split from the fatigue
+// snapshots, with decay applied up to the end of the day.
+func (s *InjuryRiskService) dailySideFatigue(ctx context.Context, first time.Time, end string) ([]domain.DailySideFatigue, error) {
+	var days []domain.DailySideFatigue
+	for day := first; day.Format("2006-01-02") <= end; day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1).Add(-time.Second)
+		rows, err := s.fatigueStore.GetMuscleFatigueAsOf(ctx, dayEnd)
+		if err != nil {
+			return nil, err
+		}
+		split := make(map[domain.MuscleGroup]domain.SideFatigue)
+		for _, row := range rows {
+			if _, sides := decayedFatigue(row, dayEnd); sides != nil {
+				split[domain.MuscleGroup(row.MuscleName)] = *sides
+			}
+		}
+		days = append(days, domain.DailySideFatigue{Date: day.Format("2006-01-02"), Sides: split})
+	}
+	return days, nil
+}
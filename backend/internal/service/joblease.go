@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"victus/internal/store"
+)
+
+// jobLeaseTTL is how long a job lease lasts without renewal. A crashed
+// leader's jobs are taken over within this time.
+const jobLeaseTTL = 60 * time.Second
+
+// JobLeases elects one replica to run each scheduled job. Each replica
+// renews the leases it holds every third of the TTL; when a holder stops
+// renewing, the next replica to ask takes the lease over.
+type JobLeases struct {
+	leaseStore *store.JobLeaseStore
+	holder     string
+	ttl        time.Duration
+}
+
+// NewJobLeases creates JobLeases with a holder ID unique to this process.
+func NewJobLeases(leaseStore *store.JobLeaseStore) *JobLeases {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return &JobLeases{
+		leaseStore: leaseStore,
+		holder:     fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix)),
+		ttl:        jobLeaseTTL,
+	}
+}
+
+// Holder returns this replica's lease holder ID.
+func (l *JobLeases) Holder() string {
+	return l.holder
+}
+
+// Claim takes or renews the job's lease. Returns false if another replica
+// holds it, or if the database can't be reached.
+func (l *JobLeases) Claim(ctx context.Context, job string, now time.Time) (bool, error) {
+	return l.leaseStore.Acquire(ctx, job, l.holder, now, l.ttl)
+}
+
+// Hold keeps claiming the job's lease every third of the TTL until ctx is
+// cancelled, then releases it so another replica can take over immediately.
+func (l *JobLeases) Hold(ctx context.Context, job string) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		if _, err := l.Claim(ctx, job, time.Now()); err != nil && ctx.Err() == nil {
+			log.Printf("jobs: renewing lease for %s failed: %v", job, err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := l.leaseStore.Release(releaseCtx, job, l.holder); err != nil {
+				log.Printf("jobs: releasing lease for %s failed: %v", job, err)
+			}
+			cancel()
+			return
+		}
+	}
+}
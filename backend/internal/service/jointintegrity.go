@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// jointSeriesMaxCatchUpDays bounds how many missing days are computed in one
+// read; older gaps restart from the last stored day as if nothing happened.
+const jointSeriesMaxCatchUpDays = 90
+
+// JointIntegrityService tracks echo joint deltas and their recovery as a
+// daily joint integrity series.
+type JointIntegrityService struct {
+	jointStore     *store.JointIntegrityStore
+	sessionStore   *store.TrainingSessionStore
+	bodyIssueStore *store.BodyIssueStore
+	policy         domain.JointRecoveryPolicy
+	clocked
+}
+
+// NewJointIntegrityService creates a new JointIntegrityService.
+// The recovery half-life can be overridden with JOINT_RECOVERY_HALF_LIFE
+// (Go duration string, e.g. "48h").
+func NewJointIntegrityService(js *store.JointIntegrityStore, ss *store.TrainingSessionStore, bis *store.BodyIssueStore) *JointIntegrityService {
+	return &JointIntegrityService{
+		jointStore:     js,
+		sessionStore:   ss,
+		bodyIssueStore: bis,
+		policy:         jointRecoveryPolicyFromEnv(),
+	}
+}
+
+// jointRecoveryPolicyFromEnv applies env overrides to the default recovery policy.
+func jointRecoveryPolicyFromEnv() domain.JointRecoveryPolicy {
+	policy := domain.DefaultJointRecoveryPolicy
+	if v := os.Getenv("JOINT_RECOVERY_HALF_LIFE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			policy.HalfLife = d
+		} else {
+			log.Printf("joint integrity: invalid JOINT_RECOVERY_HALF_LIFE %q, using default", v)
+		}
+	}
+	return policy
+}
+
+// RecordEchoDeltas stores an echo's joint integrity deltas (keyed by body
+// alias) against today. Aliases that reach no tracked joint are dropped.
+func (s *JointIntegrityService) RecordEchoDeltas(ctx context.Context, deltas map[string]float64, sessionID int64) error {
+	byJoint := domain.EchoDeltasByJoint(deltas)
+	if len(byJoint) == 0 {
+		return nil
+	}
+	return s.jointStore.AddDeltas(ctx, s.now().Format("2006-01-02"), byJoint, &sessionID)
+}
+
+// CurrentJointIntegrity returns today's joint integrity from the series.
+func (s *JointIntegrityService) CurrentJointIntegrity(ctx context.Context) (map[string]float64, error) {
+	today, err := s.Today(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return today.Joints, nil
+}
+
+// Today brings the series up to date and returns today's entry. Days since
+// the last stored one are computed and stored; today is recomputed on every
+// read so later echoes and issues are picked up.
+func (s *JointIntegrityService) Today(ctx context.Context) (*domain.JointIntegrityDay, error) {
+	now := s.now()
+	today := now.Format("2006-01-02")
+
+	start := domain.IntactJoints()
+	from := now
+	latest, err := s.jointStore.LatestDayBefore(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		start = latest.Joints
+		if d, err := time.Parse("2006-01-02", latest.Date); err == nil {
+			from = d.AddDate(0, 0, 1)
+		}
+	} else {
+		earliest, err := s.jointStore.EarliestDeltaDate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if d, err := time.Parse("2006-01-02", earliest); err == nil && d.Before(from) {
+			from = d
+		}
+	}
+	if oldest := now.AddDate(0, 0, -jointSeriesMaxCatchUpDays); from.Before(oldest) {
+		from = oldest
+	}
+
+	days, err := s.dayInputs(ctx, from, now)
+	if err != nil {
+		return nil, err
+	}
+	series := domain.BuildJointIntegritySeries(start, days, s.policy)
+	for _, day := range series {
+		if err := s.jointStore.SaveDay(ctx, day, now); err != nil {
+			return nil, err
+		}
+	}
+	return &series[len(series)-1], nil
+}
+
+// GetSeries returns the last n days of the series, oldest first.
+func (s *JointIntegrityService) GetSeries(ctx context.Context, days int) ([]domain.JointIntegrityDay, error) {
+	if _, err := s.Today(ctx); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	from := now.AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+	return s.jointStore.ListRange(ctx, from, now.Format("2006-01-02"))
+}
+
+// dayInputs gathers deltas, rest days and reported pain for each day from
+// `from` to `to` (inclusive). Days without a log count as rest days.
+func (s *JointIntegrityService) dayInputs(ctx context.Context, from, to time.Time) ([]domain.JointDayInput, error) {
+	fromDate, toDate := from.Format("2006-01-02"), to.Format("2006-01-02")
+
+	deltas, err := s.jointStore.DeltasByDate(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionStore.GetSessionsForDateRange(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	trained := make(map[string]bool, len(sessions))
+	for _, day := range sessions {
+		trained[day.Date] = domain.HasNonRestSession(day.ActualSessions)
+	}
+	issueFrom := from.AddDate(0, 0, -domain.IssueDecayDays).Format("2006-01-02")
+	issues, err := s.bodyIssueStore.GetByDateRange(ctx, issueFrom, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []domain.JointDayInput
+	for d := from; d.Format("2006-01-02") <= toDate; d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		inputs = append(inputs, domain.JointDayInput{
+			Date:       date,
+			RestDay:    !trained[date],
+			PainJoints: domain.PainJointsOn(issues, d),
+			Deltas:     deltas[date],
+		})
+	}
+	return inputs, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"victus/internal/domain"
+)
+
+// errNoJSONObject is returned when an LLM response holds no recoverable JSON object.
+var errNoJSONObject = errors.New("no JSON object in response")
+
+// extractJSONObject finds the JSON object in an LLM response. Models wrap it
+// in preambles, code fences and trailing notes (which may contain braces of
+// their own), and sometimes stop before closing every brace. Each '{' is tried
+// in turn; an object cut off at the end of the response is closed if that
+// yields valid JSON. Returns false when no object can be recovered.
+func extractJSONObject(text string) (string, bool) {
+	for start := strings.IndexByte(text, '{'); start != -1; {
+		if obj, ok := scanJSONObject(text[start:]); ok {
+			return obj, true
+		}
+		next := strings.IndexByte(text[start+1:], '{')
+		if next == -1 {
+			break
+		}
+		start += next + 1
+	}
+	return "", false
+}
+
+// scanJSONObject reads the object starting at text[0] == '{', tracking strings
+// so braces inside them don't count. A truncated object is closed in place.
+func scanJSONObject(text string) (string, bool) {
+	var closers []byte
+	inString, escaped := false, false
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{':
+			closers = append(closers, '}')
+		case c == '[':
+			closers = append(closers, ']')
+		case c == '}' || c == ']':
+			if len(closers) == 0 || closers[len(closers)-1] != c {
+				return "", false
+			}
+			closers = closers[:len(closers)-1]
+			if len(closers) == 0 {
+				obj := text[:i+1]
+				return obj, json.Valid([]byte(obj))
+			}
+		}
+	}
+
+	// Truncated: close the open string and containers
+	salvaged := text
+	if escaped {
+		salvaged = salvaged[:len(salvaged)-1]
+	}
+	if inString {
+		salvaged += `"`
+	}
+	salvaged = strings.TrimRight(salvaged, " \t\r\n,")
+	for i := len(closers) - 1; i >= 0; i-- {
+		salvaged += string(closers[i])
+	}
+	return salvaged, json.Valid([]byte(salvaged))
+}
+
+// unmarshalLLMObject extracts the JSON object from an LLM response into v.
+func unmarshalLLMObject(text string, v any) error {
+	obj, ok := extractJSONObject(text)
+	if !ok {
+		return errNoJSONObject
+	}
+	return json.Unmarshal([]byte(obj), v)
+}
+
+// parseEchoResponse parses and validates an echo log extraction.
+func parseEchoResponse(text string) (*domain.EchoLogResult, error) {
+	var result domain.EchoLogResult
+	if err := unmarshalLLMObject(text, &result); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateEchoResult(result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// parseVoiceCommandResponse parses and validates a voice command extraction.
+// A result missing required data is returned along with ErrMissingVoiceData.
+func parseVoiceCommandResponse(text, rawInput string) (*domain.VoiceCommandResult, error) {
+	var llmResp voiceCommandLLMResponse
+	if err := unmarshalLLMObject(text, &llmResp); err != nil {
+		return nil, err
+	}
+	result := convertLLMToVoiceResult(llmResp, rawInput)
+	if err := domain.ValidateVoiceCommandResult(result); err != nil {
+		if errors.Is(err, domain.ErrMissingVoiceData) {
+			return result, err // Partial result, completed through a clarification
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// archetypeLLMResponse is the expected JSON response for archetype inference.
+type archetypeLLMResponse struct {
+	Archetype  string  `json:"archetype"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+}
+
+// parseArchetypeResponse parses and validates an archetype inference.
+func parseArchetypeResponse(text string) (*domain.ArchetypeInference, error) {
+	var resp archetypeLLMResponse
+	if err := unmarshalLLMObject(text, &resp); err != nil {
+		return nil, err
+	}
+	inference := domain.ArchetypeInference{
+		Archetype:  domain.Archetype(resp.Archetype),
+		Confidence: resp.Confidence,
+		Source:     domain.ArchetypeSourceLLM,
+		Reason:     resp.Reason,
+	}
+	if err := domain.ValidateArchetypeInference(inference); err != nil {
+		return nil, err
+	}
+	return &inference, nil
+}
+
+// parseSemanticRefinerResponse parses and validates a semantic refinement.
+func parseSemanticRefinerResponse(text string) (*semanticRefinerResponse, error) {
+	var resp semanticRefinerResponse
+	if err := unmarshalLLMObject(text, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.MissionTitle) < 5 || len(resp.MissionTitle) > 100 {
+		return nil, fmt.Errorf("invalid mission title length: %d chars", len(resp.MissionTitle))
+	}
+	if len(resp.OperationalSteps) < 10 || len(resp.OperationalSteps) > 300 {
+		return nil, fmt.Errorf("invalid operational steps length: %d chars", len(resp.OperationalSteps))
+	}
+	return &resp, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: LLM output is untrusted free text. These fuzz targets check
+// the extraction and validation paths never panic and never hand callers an
+// invalid object. Seeds cover the failure modes seen from local models; run
+// longer with e.g. `go test ./internal/service -fuzz FuzzExtractJSONObject`.
+
+var llmResponseSeeds = []string{
+	`{"intent": "TRAINING", "activity": "Rowing", "duration_min": 20}`,
+	"Here is the JSON:\n```json\n{\"achievements\": [\"PR\"], \"joint_integrity_delta\": {\"knee\": -0.5}, \"perceived_exertion_offset\": 1}\n```",
+	`Sure! {"missionTitle": "Operation Oat", "operationalSteps": "Boil water, add oats."} Note: adjust {to taste}.`,
+	`Use the {format} below: {"intent": "NUTRITION", "items": [{"food": "eggs", "quantity": 2}]}`,
+	`{"missionTitle": "Operation Oat", "operationalSteps": "Boil water, add oats`,
+	`{"items": [{"food": "toast"`,
+	`{"achievements": ["closed } inside a string"], "perceived_exertion_offset": 0}`,
+	`{"a": "escaped \" quote and \\ backslash"}`,
+	`{"a": "trailing backslash \`,
+	`}{`,
+	`{ ] }`,
+	`{"intent": "BIOMETRICS", "metric": "Weight", "value": 82.5,`,
+	"",
+	"no json here",
+}
+
+func FuzzExtractJSONObject(f *testing.F) {
+	for _, seed := range llmResponseSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		obj, ok := extractJSONObject(text)
+		if !ok {
+			return
+		}
+		if !strings.HasPrefix(obj, "{") || !json.Valid([]byte(obj)) {
+			t.Fatalf("extracted invalid object %q from %q", obj, text)
+		}
+		var v map[string]any
+		if err := json.Unmarshal([]byte(obj), &v); err != nil {
+			t.Fatalf("extracted non-object %q: %v", obj, err)
+		}
+	})
+}
+
+func FuzzParseEchoResponse(f *testing.F) {
+	for _, seed := range llmResponseSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		result, err := parseEchoResponse(text)
+		if err != nil {
+			return
+		}
+		if err := domain.ValidateEchoResult(*result); err != nil {
+			t.Fatalf("accepted invalid echo %+v: %v", result, err)
+		}
+	})
+}
+
+func FuzzParseVoiceCommandResponse(f *testing.F) {
+	for _, seed := range llmResponseSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		result, err := parseVoiceCommandResponse(text, "raw input")
+		if err != nil {
+			return
+		}
+		if err := domain.ValidateVoiceCommandResult(result); err != nil {
+			t.Fatalf("accepted invalid voice command %+v: %v", result, err)
+		}
+	})
+}
+
+func FuzzParseSemanticRefinerResponse(f *testing.F) {
+	for _, seed := range llmResponseSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		resp, err := parseSemanticRefinerResponse(text)
+		if err != nil {
+			return
+		}
+		if len(resp.MissionTitle) < 5 || len(resp.MissionTitle) > 100 || len(resp.OperationalSteps) < 10 || len(resp.OperationalSteps) > 300 {
+			t.Fatalf("accepted out-of-bounds refinement %+v", resp)
+		}
+	})
+}
+
+type LLMJSONSuite struct {
+	suite.Suite
+}
+
+func TestLLMJSONSuite(t *testing.T) {
+	suite.Run(t, new(LLMJSONSuite))
+}
+
+func (s *LLMJSONSuite) TestRecoversObject() {
+	cases := []struct {
+		name, text, want string
+	}{
+		{"bare object", `{"a": 1}`, `{"a": 1}`},
+		{"code fence", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"braces in preamble", `Use the {format}: {"a": 1}`, `{"a": 1}`},
+		{"braces in trailing note", `{"a": 1} Adjust {to taste}.`, `{"a": 1}`},
+		{"brace inside string", `{"a": "}"}`, `{"a": "}"}`},
+		{"missing closing brace", `{"a": 1, "b": [2`, `{"a": 1, "b": [2]}`},
+		{"cut off in a string", `{"a": "oat`, `{"a": "oat"}`},
+		{"trailing comma", "{\"a\": 1,\n", `{"a": 1}`},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			got, ok := extractJSONObject(tc.text)
+			s.Require().True(ok)
+			s.Equal(tc.want, got)
+		})
+	}
+
+	_, ok := extractJSONObject(`{"a": `)
+	s.False(ok, "a value can't be invented")
+	_, ok = extractJSONObject("no json here")
+	s.False(ok)
+}
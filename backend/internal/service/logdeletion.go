@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// LogDeletionService deletes daily logs together with everything derived
+// from them: dependents are removed in one transaction, then trend weight,
+// TDEE flux and the plan week's actuals are recomputed without the day.
+type LogDeletionService struct {
+	dailyLogService     *DailyLogService
+	logStore            *store.DailyLogStore
+	sessionStore        *store.TrainingSessionStore
+	metabolicStore      *store.MetabolicStore
+	fatigueStore        *store.FatigueStore
+	rollup              weeklyRollup
+	reconciliationStore *store.ReconciliationStore
+	clocked
+}
+
+// NewLogDeletionService creates a new LogDeletionService. Deletions go
+// through the daily log service's logging lock.
+func NewLogDeletionService(
+	dls *DailyLogService,
+	ls *store.DailyLogStore,
+	ss *store.TrainingSessionStore,
+	ms *store.MetabolicStore,
+	fs *store.FatigueStore,
+	rollup weeklyRollup,
+	rs *store.ReconciliationStore,
+) *LogDeletionService {
+	return &LogDeletionService{
+		dailyLogService:     dls,
+		logStore:            ls,
+		sessionStore:        ss,
+		metabolicStore:      ms,
+		fatigueStore:        fs,
+		rollup:              rollup,
+		reconciliationStore: rs,
+	}
+}
+
+// Delete removes a day's log, its sessions, their fatigue events, its
+// metabolic history and the entries logged on the day in one transaction,
+// taking the fatigue the sessions still contribute off the body map.
+// Recomputation afterwards is best-effort.
+// Returns store.ErrDailyLogNotFound if no log exists for that date, or
+// domain.ErrDayLocked if the day is locked without an open retro-edit.
+func (s *LogDeletionService) Delete(ctx context.Context, date string) (*domain.LogDeletionResult, error) {
+	dailyLog, err := s.logStore.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	retroEdit, err := s.dailyLogService.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	archetypes, err := s.fatigueStore.GetAllArchetypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	coefficients := make(map[int]map[domain.MuscleGroup]float64, len(archetypes))
+	for _, a := range archetypes {
+		coefficients[a.ID] = a.Coefficients
+	}
+
+	now := s.now()
+	result := &domain.LogDeletionResult{
+		Date:            date,
+		FatigueReversed: make(map[domain.MuscleGroup]float64),
+	}
+	err = s.logStore.WithTx(ctx, func(tx *sql.Tx) error {
+		events, err := s.fatigueStore.DeleteEventsForLogWithTx(ctx, tx, dailyLog.ID)
+		if err != nil {
+			return err
+		}
+		result.FatigueEventsReversed = len(events)
+		// Read in the transaction, so a concurrent fatigue write isn't overwritten
+		muscles, err := s.fatigueStore.GetAllMuscleFatigueWithTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.reverseFatigue(ctx, tx, events, coefficients, muscles, now, result); err != nil {
+			return err
+		}
+
+		if result.MetabolicRecords, err = s.metabolicStore.DeleteByDailyLogIDWithTx(ctx, tx, dailyLog.ID); err != nil {
+			return err
+		}
+		if result.SessionsRemoved, err = s.sessionStore.DeleteByLogIDWithTx(ctx, tx, dailyLog.ID); err != nil {
+			return err
+		}
+		if result.EntriesRemoved, err = s.logStore.DeleteDayEntriesWithTx(ctx, tx, date); err != nil {
+			return err
+		}
+		return s.logStore.DeleteByDateWithTx(ctx, tx, date)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.dailyLogService.recordRetroEdit(ctx, retroEdit, domain.LogEditDelete)
+
+	s.recompute(ctx, date, now, result)
+	return result, nil
+}
+
+// reverseFatigue takes the residual fatigue of the deleted events off each
+// muscle's current (decayed) level.
+func (s *LogDeletionService) reverseFatigue(
+	ctx context.Context,
+	tx *sql.Tx,
+	events []store.FatigueEventRow,
+	coefficients map[int]map[domain.MuscleGroup]float64,
+	muscles []store.MuscleFatigueRow,
+	now time.Time,
+	result *domain.LogDeletionResult,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+	residuals := make([]domain.FatigueEventResidual, len(events))
+	for i, e := range events {
+		residuals[i] = domain.FatigueEventResidual{
+			Coefficients: coefficients[e.ArchetypeID],
+			TotalLoad:    e.TotalLoad,
+			AppliedAt:    e.AppliedAt,
+		}
+	}
+	residual := domain.ResidualFatigue(residuals, now)
+
+	for _, row := range muscles {
+		muscle := domain.MuscleGroup(row.MuscleName)
+		amount := residual[muscle]
+		if amount <= 0 {
+			continue
+		}
+
+		current, sides := decayedFatigue(row, now)
+		remaining, remainingSides := domain.AddSidedFatigue(muscle, current, sides, -amount, nil)
+		if err := s.fatigueStore.UpsertMuscleFatigueWithTx(ctx, tx, row.MuscleGroupID, remaining, remainingSides); err != nil {
+			return err
+		}
+		result.FatigueReversed[muscle] = current - remaining
+	}
+	return nil
+}
+
+// recompute re-derives what the deleted day fed into. Each step is
+// best-effort: the deletion has committed, so failures are only logged.
+func (s *LogDeletionService) recompute(ctx context.Context, date string, now time.Time, result *domain.LogDeletionResult) {
+	// The latest flux calculation's EMA trend weight and TDEE included the
+	// deleted day's weight
+	latest, err := s.logStore.GetLatestDate(ctx)
+	switch {
+	case errors.Is(err, store.ErrDailyLogNotFound):
+	case err != nil:
+		log.Printf("log deletion: latest log lookup failed after deleting %s: %v", date, err)
+	default:
+		recalculated, err := s.dailyLogService.RecalculateFlux(ctx, latest, now)
+		if err != nil {
+			log.Printf("log deletion: flux recalculation for %s failed after deleting %s: %v", latest, date, err)
+		}
+		result.FluxRecalculated = recalculated
+	}
+
+	if week, err := s.rollup.RollupDate(ctx, date); err != nil {
+		log.Printf("log deletion: plan week actuals update failed after deleting %s: %v", date, err)
+	} else {
+		result.PlanWeekUpdated = week
+	}
+
+	// Without the log, the day shows its planned day type again
+	refreshDailyTargets(ctx, s.dailyLogService.dailyTargets, date)
+
+	if week, ok := domain.DebriefWeekStart(date); ok {
+		if err := s.reconciliationStore.MarkDebriefStale(ctx, week, "daily log deleted: "+date); err != nil {
+			log.Printf("log deletion: marking debrief %s stale failed: %v", week, err)
+		} else {
+			result.StaleDebriefWeek = week
+		}
+	}
+}
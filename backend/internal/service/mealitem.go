@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// MealItemResult is a logged, edited or deleted food item with the updated
+// daily log. Item is nil after a delete.
+type MealItemResult struct {
+	Item *domain.MealItem
+	Log  *domain.DailyLog
+}
+
+// MealItemService logs food one item at a time. Each change to an item
+// recomputes the items' share of the day's consumed totals and meal slot
+// columns from the stored items in the same transaction, so the totals always
+// include every logged item.
+type MealItemService struct {
+	dailyLogService    *DailyLogService
+	logStore           *store.DailyLogStore
+	itemStore          *store.MealItemStore
+	foodReferenceStore *store.FoodReferenceStore
+	portionStore       *store.FoodPortionStore
+}
+
+// NewMealItemService creates a new MealItemService. Changes go through the
+// daily log service's logging lock.
+func NewMealItemService(
+	dls *DailyLogService,
+	ls *store.DailyLogStore,
+	is *store.MealItemStore,
+	fs *store.FoodReferenceStore,
+	ps *store.FoodPortionStore,
+) *MealItemService {
+	return &MealItemService{
+		dailyLogService:    dls,
+		logStore:           ls,
+		itemStore:          is,
+		foodReferenceStore: fs,
+		portionStore:       ps,
+	}
+}
+
+// List returns the items logged on date.
+// Returns store.ErrDailyLogNotFound if no log exists for that date.
+func (s *MealItemService) List(ctx context.Context, date string) ([]domain.MealItem, error) {
+	if _, err := s.logStore.GetByDate(ctx, date); err != nil {
+		return nil, err
+	}
+	return s.itemStore.ListByDate(ctx, date)
+}
+
+// Add logs a food item on date and adds its macros to the day.
+// Returns store.ErrDailyLogNotFound, store.ErrFoodReferenceNotFound, or
+// domain.ErrDayLocked if the day is locked without an open retro-edit.
+func (s *MealItemService) Add(ctx context.Context, date string, in domain.MealItemInput) (*MealItemResult, error) {
+	item, err := s.buildItem(ctx, date, in)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.apply(ctx, date, item, func(ctx context.Context) error {
+		return s.itemStore.Create(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordPortion(ctx, item)
+	return result, nil
+}
+
+// Update replaces a logged item and moves the day's macros by the difference.
+// Returns store.ErrMealItemNotFound, store.ErrFoodReferenceNotFound, or
+// domain.ErrDayLocked if the day is locked without an open retro-edit.
+func (s *MealItemService) Update(ctx context.Context, date string, id int64, in domain.MealItemInput) (*MealItemResult, error) {
+	before, err := s.itemStore.GetByID(ctx, date, id)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.buildItem(ctx, date, in)
+	if err != nil {
+		return nil, err
+	}
+	item.ID, item.CreatedAt = before.ID, before.CreatedAt
+	return s.apply(ctx, date, item, func(ctx context.Context) error {
+		return s.itemStore.Update(ctx, item)
+	})
+}
+
+// Delete removes a logged item and takes its macros off the day.
+// Returns store.ErrMealItemNotFound, or domain.ErrDayLocked if the day is
+// locked without an open retro-edit.
+func (s *MealItemService) Delete(ctx context.Context, date string, id int64) (*MealItemResult, error) {
+	return s.apply(ctx, date, nil, func(ctx context.Context) error {
+		return s.itemStore.Delete(ctx, date, id)
+	})
+}
+
+// buildItem validates the input and computes the item's macros from its food.
+func (s *MealItemService) buildItem(ctx context.Context, date string, in domain.MealItemInput) (*domain.MealItem, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	food, err := s.foodReferenceStore.GetNutrition(ctx, in.FoodReferenceID)
+	if err != nil {
+		return nil, err
+	}
+	item := domain.NewMealItem(date, in, *food)
+	return &item, nil
+}
+
+// apply writes an item change and, in the same transaction, replaces the
+// items' share of the day's consumed macros with the sum of the stored items
+// per slot. It then recomputes the plan week (best-effort).
+func (s *MealItemService) apply(ctx context.Context, date string, item *domain.MealItem, write func(ctx context.Context) error) (*MealItemResult, error) {
+	retroEdit, err := s.dailyLogService.checkDayLock(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.itemStore.InTx(ctx, func(ctx context.Context) error {
+		before, err := s.itemStore.MacrosByMeal(ctx, date)
+		if err != nil {
+			return err
+		}
+		if err := write(ctx); err != nil {
+			return err
+		}
+		after, err := s.itemStore.MacrosByMeal(ctx, date)
+		if err != nil {
+			return err
+		}
+		for _, c := range domain.MealItemChanges(before, after) {
+			err := s.logStore.AddConsumedMacros(ctx, date, store.ConsumedMacros{
+				Meal:     c.Meal,
+				Calories: c.Macros.Calories,
+				ProteinG: c.Macros.ProteinG,
+				CarbsG:   c.Macros.CarbsG,
+				FatG:     c.Macros.FatG,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.dailyLogService.recordRetroEdit(ctx, retroEdit, domain.LogEditMealItem)
+	s.dailyLogService.rollupWeek(ctx, date)
+
+	dailyLog, err := s.dailyLogService.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	return &MealItemResult{Item: item, Log: dailyLog}, nil
+}
+
+// recordPortion stores a newly logged item's weight for learning portion
+// defaults (best-effort).
+func (s *MealItemService) recordPortion(ctx context.Context, item *domain.MealItem) {
+	entry := domain.FoodPortionEntry{
+		FoodReferenceID: item.FoodReferenceID,
+		Meal:            item.Meal,
+		QuantityG:       item.Grams,
+		Date:            item.Date,
+	}
+	if err := s.portionStore.Record(ctx, entry); err != nil {
+		log.Printf("meal items: failed to record portion for %s: %v", item.FoodItem, err)
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// MealReuseResult is the outcome of copying meals or applying a template.
+type MealReuseResult struct {
+	Meals []domain.ScaledMeal
+	Log   *domain.DailyLog
+}
+
+// MealTemplateService handles saved meal templates and copying meals between days.
+type MealTemplateService struct {
+	templateStore      *store.MealTemplateStore
+	foodReferenceStore *store.FoodReferenceStore
+	profileStore       *store.ProfileStore
+	dailyLogService    *DailyLogService
+}
+
+// NewMealTemplateService creates a new MealTemplateService.
+func NewMealTemplateService(
+	templateStore *store.MealTemplateStore,
+	foodReferenceStore *store.FoodReferenceStore,
+	profileStore *store.ProfileStore,
+	dailyLogService *DailyLogService,
+) *MealTemplateService {
+	return &MealTemplateService{
+		templateStore:      templateStore,
+		foodReferenceStore: foodReferenceStore,
+		profileStore:       profileStore,
+		dailyLogService:    dailyLogService,
+	}
+}
+
+// List returns all templates with food names filled in.
+func (s *MealTemplateService) List(ctx context.Context) ([]domain.MealTemplate, error) {
+	templates, err := s.templateStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods, err := s.foodsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		fillTemplateFoodNames(&templates[i], foods)
+	}
+	return templates, nil
+}
+
+// Create validates and stores a template.
+// Returns ErrFoodReferenceNotFound if an item references a food without nutrition data.
+func (s *MealTemplateService) Create(ctx context.Context, t *domain.MealTemplate) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	foods, err := s.foodsByID(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range t.Items {
+		if _, ok := foods[item.FoodReferenceID]; !ok {
+			return store.ErrFoodReferenceNotFound
+		}
+	}
+	if err := s.templateStore.Create(ctx, t); err != nil {
+		return err
+	}
+	fillTemplateFoodNames(t, foods)
+	return nil
+}
+
+// Delete removes a template.
+func (s *MealTemplateService) Delete(ctx context.Context, id int64) error {
+	return s.templateStore.Delete(ctx, id)
+}
+
+// Apply logs a template into the given day's meal slot, scaling its flexible
+// items to that day's point targets.
+// Returns store.ErrDailyLogNotFound or store.ErrMealTemplateNotFound.
+func (s *MealTemplateService) Apply(ctx context.Context, date string, templateID int64) (*MealReuseResult, error) {
+	t, err := s.templateStore.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	log, err := s.dailyLogService.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods, err := s.foodsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range t.Items {
+		if _, ok := foods[item.FoodReferenceID]; !ok {
+			return nil, fmt.Errorf("template %d: %w", t.ID, store.ErrFoodReferenceNotFound)
+		}
+	}
+	fillTemplateFoodNames(t, foods)
+
+	target := log.CalculatedTargets.Meals.MealPointsFor(t.Meal)
+	meal := domain.ScaleMealTemplate(*t, foods, target, profile.PointsConfig)
+	return s.logMeals(ctx, date, []domain.ScaledMeal{meal})
+}
+
+// CopyDay logs the meals eaten on sourceDate into date, scaling carbs and fats
+// to the target day's point targets. meals limits which slots are copied
+// (empty = every slot with logged food). Returns domain.ErrNothingToCopy when
+// the source day has nothing logged in the selected slots.
+func (s *MealTemplateService) CopyDay(ctx context.Context, date, sourceDate string, meals []domain.MealName) (*MealReuseResult, error) {
+	if len(meals) == 0 {
+		meals = []domain.MealName{domain.MealBreakfast, domain.MealLunch, domain.MealDinner}
+	}
+	for _, m := range meals {
+		if !domain.ValidMealNames[m] {
+			return nil, domain.ErrInvalidMealName
+		}
+	}
+
+	source, err := s.dailyLogService.GetByDate(ctx, sourceDate)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.dailyLogService.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var scaled []domain.ScaledMeal
+	for _, m := range meals {
+		eaten := source.MealConsumed.MacrosFor(m)
+		if eaten.Calories <= 0 {
+			continue
+		}
+		scaled = append(scaled, domain.ScaleCopiedMeal(m, eaten,
+			source.CalculatedTargets.Meals.MealPointsFor(m),
+			target.CalculatedTargets.Meals.MealPointsFor(m)))
+	}
+	if len(scaled) == 0 {
+		return nil, domain.ErrNothingToCopy
+	}
+	return s.logMeals(ctx, date, scaled)
+}
+
+// logMeals adds each scaled meal to the day's consumed macros and returns the updated log.
+func (s *MealTemplateService) logMeals(ctx context.Context, date string, meals []domain.ScaledMeal) (*MealReuseResult, error) {
+	var log *domain.DailyLog
+	for _, m := range meals {
+		meal := m.Meal
+		var err error
+		log, err = s.dailyLogService.AddConsumedMacros(ctx, date, store.ConsumedMacros{
+			Meal:     &meal,
+			Calories: m.Macros.Calories,
+			ProteinG: m.Macros.ProteinG,
+			CarbsG:   m.Macros.CarbsG,
+			FatG:     m.Macros.FatG,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &MealReuseResult{Meals: meals, Log: log}, nil
+}
+
+// foodsByID loads the foods with nutrition data keyed by ID.
+func (s *MealTemplateService) foodsByID(ctx context.Context) (map[int64]domain.FoodNutrition, error) {
+	list, err := s.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods := make(map[int64]domain.FoodNutrition, len(list))
+	for _, f := range list {
+		foods[f.ID] = f
+	}
+	return foods, nil
+}
+
+// fillTemplateFoodNames sets each item's display name from the food reference.
+func fillTemplateFoodNames(t *domain.MealTemplate, foods map[int64]domain.FoodNutrition) {
+	for i := range t.Items {
+		if f, ok := foods[t.Items[i].FoodReferenceID]; ok {
+			t.Items[i].FoodItem = f.FoodItem
+		}
+	}
+}
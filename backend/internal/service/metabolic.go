@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"victus/internal/domain"
 	"victus/internal/store"
@@ -11,6 +12,7 @@ import (
 type MetabolicService struct {
 	metabolicStore *store.MetabolicStore
 	dailyLogStore  *store.DailyLogStore
+	clocked
 }
 
 // NewMetabolicService creates a new MetabolicService.
@@ -27,7 +29,7 @@ func (s *MetabolicService) GetChartData(ctx context.Context, weeks int) (*domain
 		weeks = 12 // Default to 12 weeks
 	}
 
-	points, err := s.metabolicStore.ListForChart(ctx, weeks)
+	points, err := s.metabolicStore.ListForChart(ctx, weeks, s.now().Format("2006-01-02"))
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +66,26 @@ func (s *MetabolicService) GetChartData(ctx context.Context, weeks int) (*domain
 	}, nil
 }
 
+// GetDietFatigue scores diet fatigue as of today from the current run of
+// deficit weeks, recent hunger check-ins and adaptive TDEE history.
+func (s *MetabolicService) GetDietFatigue(ctx context.Context) (*domain.DietFatigueIndex, error) {
+	now := s.now()
+	asOf := now.Format("2006-01-02")
+	start := now.AddDate(0, 0, -7*domain.DietFatigueLookbackWeeks+1).Format("2006-01-02")
+
+	logs, err := s.dailyLogStore.ListByDateRange(ctx, start, asOf)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.metabolicStore.ListForChart(ctx, domain.DietFatigueLookbackWeeks, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	index := domain.CalculateDietFatigue(logs, points, now)
+	return &index, nil
+}
+
 // GetPendingNotification returns any pending weekly strategy update notification.
 func (s *MetabolicService) GetPendingNotification(ctx context.Context) (*domain.FluxNotification, error) {
 	return s.metabolicStore.GetPendingNotification(ctx)
@@ -91,13 +113,14 @@ func (s *MetabolicService) CalculateAndRecordFlux(
 	}
 
 	// Get adherence (days logged in last 7 days)
-	adherenceDays, err := s.metabolicStore.CountRecentLogs(ctx, config.AdherenceWindowDays)
+	now := s.now()
+	adherenceDays, err := s.metabolicStore.CountRecentLogs(ctx, config.AdherenceWindowDays, now.Format("2006-01-02"))
 	if err != nil {
 		return nil, err
 	}
 
 	// Get recent weight history for EMA smoothing
-	weightHistory, err := s.metabolicStore.ListRecentWeights(ctx, 14) // 2 weeks for smoothing
+	weightHistory, err := recentCorrectedWeights(ctx, s.metabolicStore, 14, now) // 2 weeks for smoothing
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +144,7 @@ func (s *MetabolicService) CalculateAndRecordFlux(
 	// Build history record
 	record := &domain.MetabolicHistoryRecord{
 		DailyLogID:          dailyLogID,
+		CalculatedAt:        now.Format("2006-01-02 15:04:05"),
 		CalculatedTDEE:      result.TDEE,
 		PreviousTDEE:        result.PreviousTDEE,
 		DeltaKcal:           result.DeltaKcal,
@@ -143,3 +167,21 @@ func (s *MetabolicService) CalculateAndRecordFlux(
 
 	return &result, nil
 }
+
+// recentCorrectedWeights returns the last days of weights corrected for
+// weigh-in time of day, learning the offset curve from WeighInLearningDays.
+func recentCorrectedWeights(ctx context.Context, ms *store.MetabolicStore, days int, now time.Time) ([]domain.WeightDataPoint, error) {
+	history, err := ms.ListRecentWeights(ctx, domain.WeighInLearningDays, now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := now.AddDate(0, 0, -days).Format("2006-01-02")
+	corrected := domain.CorrectWeightHistory(history)
+	for i, point := range corrected {
+		if point.Date >= cutoff {
+			return corrected[i:], nil
+		}
+	}
+	return nil, nil
+}
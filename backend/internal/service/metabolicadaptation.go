@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// adaptationCheckHour is the local hour the nightly check runs, after the
+// previous day's log has its adaptive TDEE.
+const adaptationCheckHour = 2
+
+// adaptationSource provides the metabolic adaptation detected during a date
+// range. Implemented by MetabolicAdaptationService.
+type adaptationSource interface {
+	During(ctx context.Context, start, end string) (*domain.MetabolicAdaptation, error)
+}
+
+// MetabolicAdaptationService watches the adaptive TDEE for downregulation
+// beyond what weight loss explains, records each episode and announces it.
+type MetabolicAdaptationService struct {
+	adaptationStore *store.MetabolicAdaptationStore
+	logStore        *store.DailyLogStore
+	profileStore    *store.ProfileStore
+	notifiers       []notifier
+	jobs            *JobMonitor
+	clocked
+}
+
+// NewMetabolicAdaptationService creates a new MetabolicAdaptationService.
+// Detections go to the digest's delivery channels when any is configured.
+func NewMetabolicAdaptationService(as *store.MetabolicAdaptationStore, ls *store.DailyLogStore, ps *store.ProfileStore) *MetabolicAdaptationService {
+	return &MetabolicAdaptationService{
+		adaptationStore: as,
+		logStore:        ls,
+		profileStore:    ps,
+		notifiers:       notifiersFromEnv(),
+	}
+}
+
+// SetJobMonitor enables heartbeats for the nightly check.
+func (s *MetabolicAdaptationService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Check runs the detector as of today. It returns nil when no adaptation is
+// showing. A detection within domain.AdaptationRealertDays of the last event
+// continues that episode and returns it; otherwise a new event is recorded
+// with a pending notification and pushed to the configured channels
+// (best-effort).
+func (s *MetabolicAdaptationService) Check(ctx context.Context) (*domain.MetabolicAdaptation, error) {
+	profile, err := s.profileStore.Get(ctx)
+	if errors.Is(err, store.ErrProfileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	today := now.Format("2006-01-02")
+	start := now.AddDate(0, 0, -(7*domain.AdaptationLookbackWeeks - 1)).Format("2006-01-02")
+	logs, err := s.logStore.ListByDateRange(ctx, start, today)
+	if err != nil {
+		return nil, err
+	}
+	detected := domain.DetectMetabolicAdaptation(logs, profile.Goal, profile.TargetWeightKg, now)
+	if detected == nil {
+		return nil, nil
+	}
+
+	latest, err := s.adaptationStore.LatestOnOrBefore(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+	realertFrom := now.AddDate(0, 0, -domain.AdaptationRealertDays).Format("2006-01-02")
+	if latest != nil && latest.DetectedOn > realertFrom {
+		return latest, nil
+	}
+
+	if err := s.adaptationStore.Create(ctx, detected); err != nil {
+		return nil, err
+	}
+	for _, n := range s.notifiers {
+		if err := n.Notify(ctx, domain.AdaptationNotificationTitle(*detected), domain.AdaptationNotificationText(*detected)); err != nil {
+			log.Printf("adaptation: %s delivery failed: %v", n.Name(), err)
+		}
+	}
+	return detected, nil
+}
+
+// History returns every recorded adaptation event, newest first.
+func (s *MetabolicAdaptationService) History(ctx context.Context) ([]domain.MetabolicAdaptation, error) {
+	return s.adaptationStore.List(ctx)
+}
+
+// PendingNotifications returns the detections not yet acknowledged.
+func (s *MetabolicAdaptationService) PendingNotifications(ctx context.Context) ([]domain.MetabolicAdaptation, error) {
+	return s.adaptationStore.ListPendingNotifications(ctx)
+}
+
+// DismissNotification acknowledges a detection.
+func (s *MetabolicAdaptationService) DismissNotification(ctx context.Context, id int64) error {
+	return s.adaptationStore.DismissNotification(ctx, id)
+}
+
+// During returns the latest event detected between start and end
+// (inclusive), or nil when there was none.
+func (s *MetabolicAdaptationService) During(ctx context.Context, start, end string) (*domain.MetabolicAdaptation, error) {
+	latest, err := s.adaptationStore.LatestOnOrBefore(ctx, end)
+	if err != nil || latest == nil || latest.DetectedOn < start {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, checking once at startup
+// and then nightly at adaptationCheckHour.
+func (s *MetabolicAdaptationService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "metabolic_adaptation", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "metabolic_adaptation", time.Now()) {
+			_, err := s.Check(ctx)
+			s.jobs.Beat("metabolic_adaptation", time.Now(), err)
+			if err != nil {
+				log.Printf("adaptation: nightly check failed: %v", err)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), adaptationCheckHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// sessionDayEstimator estimates the active burn of logged days' sessions.
+// Implemented by CalorieEstimationService.
+type sessionDayEstimator interface {
+	EstimateDays(ctx context.Context, days []store.SessionDay) ([]domain.ActiveCalorieEstimate, error)
+}
+
+// monthlySummaryHour is the local hour the nightly computation runs.
+const monthlySummaryHour = 4
+
+// MonthlySummaryService computes monthly activity summaries from the logged
+// training sessions, merging them with imported summaries.
+type MonthlySummaryService struct {
+	summaryStore *store.MonthlySummaryStore
+	sessionStore *store.TrainingSessionStore
+	estimator    sessionDayEstimator
+	jobs         *JobMonitor
+	clocked
+}
+
+// NewMonthlySummaryService creates a new MonthlySummaryService.
+func NewMonthlySummaryService(ms *store.MonthlySummaryStore, ss *store.TrainingSessionStore, estimator sessionDayEstimator) *MonthlySummaryService {
+	return &MonthlySummaryService{summaryStore: ms, sessionStore: ss, estimator: estimator}
+}
+
+// SetJobMonitor enables heartbeats for the nightly computation.
+func (s *MonthlySummaryService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Compute recomputes the summaries of the months from..to (YYYY-MM, inclusive)
+// from their actual sessions. Imported rows keep priority over computed ones.
+func (s *MonthlySummaryService) Compute(ctx context.Context, from, to string) (*domain.MonthlySummaryComputation, error) {
+	start, err := time.Parse("2006-01", from)
+	if err != nil {
+		return nil, domain.ErrInvalidMonthRange
+	}
+	end, err := time.Parse("2006-01", to)
+	if err != nil || end.Before(start) {
+		return nil, domain.ErrInvalidMonthRange
+	}
+
+	days, err := s.sessionStore.ListActualSessionDays(ctx,
+		start.Format("2006-01-02"), end.AddDate(0, 1, -1).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	estimates, err := s.estimator.EstimateDays(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.summaryStore.GetRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := domain.MergeMonthlySummaries(existing, domain.AggregateMonthlySummaries(estimates))
+	result := &domain.MonthlySummaryComputation{From: from, To: to, Skipped: len(changes.Skipped)}
+	for _, summary := range changes.Upserts {
+		written, err := s.summaryStore.UpsertComputed(ctx, summary)
+		if err != nil {
+			return nil, err
+		}
+		// An import may have claimed the row since it was read
+		if written {
+			result.Upserts++
+		} else {
+			result.Skipped++
+		}
+	}
+	for _, row := range changes.Removed {
+		if err := s.summaryStore.DeleteComputed(ctx, row.YearMonth, row.ActivityType); err != nil {
+			return nil, err
+		}
+		result.Removed++
+	}
+	return result, nil
+}
+
+// ComputeAll recomputes every month up to and including now's.
+func (s *MonthlySummaryService) ComputeAll(ctx context.Context, now time.Time) (*domain.MonthlySummaryComputation, error) {
+	return s.Compute(ctx, "0001-01", now.Format("2006-01"))
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, recomputing all monthly
+// summaries once at startup and then nightly at monthlySummaryHour, so edits
+// to earlier months' sessions are picked up too.
+func (s *MonthlySummaryService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "monthly_summaries", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "monthly_summaries", time.Now()) {
+			result, err := s.ComputeAll(ctx, s.now())
+			s.jobs.Beat("monthly_summaries", time.Now(), err)
+			if err != nil {
+				log.Printf("monthly summaries: computation failed: %v", err)
+			} else if result.Upserts > 0 || result.Removed > 0 {
+				log.Printf("monthly summaries: %d computed, %d removed, %d left to imports",
+					result.Upserts, result.Removed, result.Skipped)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), monthlySummaryHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
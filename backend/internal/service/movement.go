@@ -8,10 +8,32 @@ import (
 	"victus/internal/store"
 )
 
+// equipmentSource reports the equipment usable at a point in time.
+// Implemented by EquipmentService.
+type equipmentSource interface {
+	Availability(ctx context.Context, now time.Time) (domain.EquipmentAvailability, error)
+}
+
+// setRecorder adds personal records set by a logged movement set.
+// Implemented by PersonalRecordService.
+type setRecorder interface {
+	RecordSet(ctx context.Context, movementID string, input domain.MovementProgressionInput) ([]domain.PersonalRecord, error)
+}
+
+// jointIntegritySource reports today's joint integrity from the recovery series.
+// Implemented by JointIntegrityService.
+type jointIntegritySource interface {
+	CurrentJointIntegrity(ctx context.Context) (map[string]float64, error)
+}
+
 // MovementService handles business logic for the adaptive movement engine.
 type MovementService struct {
 	movementStore  *store.MovementStore
 	fatigueService *FatigueService
+	equipment      equipmentSource
+	records        setRecorder
+	joints         jointIntegritySource
+	clocked
 }
 
 // NewMovementService creates a new MovementService.
@@ -22,6 +44,50 @@ func NewMovementService(ms *store.MovementStore, fs *FatigueService) *MovementSe
 	}
 }
 
+// SetEquipmentSource enables equipment-aware substitution for filtered movements and warm-ups.
+func (s *MovementService) SetEquipmentSource(es equipmentSource) {
+	s.equipment = es
+}
+
+// SetRecordKeeper enables personal record tracking from logged sets.
+func (s *MovementService) SetRecordKeeper(r setRecorder) {
+	s.records = r
+}
+
+// SetJointIntegritySource makes movement filtering and warm-ups respect the
+// joint recovery series alongside fatigue-based joint integrity.
+func (s *MovementService) SetJointIntegritySource(js jointIntegritySource) {
+	s.joints = js
+}
+
+// jointIntegrity returns fatigue-based joint integrity, lowered by the joint
+// recovery series when available. Returns an error only when neither is known.
+func (s *MovementService) jointIntegrity(ctx context.Context, now time.Time) (map[string]float64, error) {
+	bodyStatus, err := s.fatigueService.GetBodyStatus(ctx, now)
+	var integrity map[string]float64
+	if err == nil {
+		integrity = bodyStatus.JointIntegrity
+	}
+	if s.joints != nil {
+		if series, seriesErr := s.joints.CurrentJointIntegrity(ctx); seriesErr == nil {
+			return domain.LowestJointIntegrity(integrity, series), nil
+		}
+	}
+	return integrity, err
+}
+
+// availability returns usable equipment, failing open (nil) when unknown.
+func (s *MovementService) availability(ctx context.Context, now time.Time) domain.EquipmentAvailability {
+	if s.equipment == nil {
+		return nil
+	}
+	avail, err := s.equipment.Availability(ctx, now)
+	if err != nil {
+		return nil
+	}
+	return avail
+}
+
 // ListMovements returns all movements in the taxonomy.
 func (s *MovementService) ListMovements(ctx context.Context) ([]domain.Movement, error) {
 	return s.movementStore.GetAll(ctx)
@@ -44,14 +110,17 @@ func (s *MovementService) GetFilteredMovements(ctx context.Context, intensityCei
 		return nil, err
 	}
 
-	// Get joint integrity from fatigue service
-	bodyStatus, err := s.fatigueService.GetBodyStatus(ctx, time.Now())
-	if err != nil {
-		// Fail open — return unfiltered if fatigue data unavailable
-		return movements, nil
+	now := s.now()
+
+	// Get joint integrity from fatigue and the joint recovery series
+	jointIntegrity, err := s.jointIntegrity(ctx, now)
+	if err == nil {
+		movements = domain.FilterMovementsByJointIntegrity(movements, jointIntegrity, intensityCeiling)
 	}
+	// Fail open — leave unfiltered if fatigue data unavailable
 
-	return domain.FilterMovementsByJointIntegrity(movements, bodyStatus.JointIntegrity, intensityCeiling), nil
+	// Swap movements needing missing equipment for alternatives that passed the filter
+	return domain.SubstituteMovements(movements, movements, s.availability(ctx, now)), nil
 }
 
 // RecordSessionCompletion records a movement session and calculates progression.
@@ -74,13 +143,129 @@ func (s *MovementService) RecordSessionCompletion(ctx context.Context, movementI
 		}
 	}
 
+	// Record any load, hold or circuit PRs; invalid values fail before anything is written
+	if s.records != nil {
+		if _, err := s.records.RecordSet(ctx, movementID, input); err != nil {
+			return nil, err
+		}
+	}
+
 	// Calculate progression (pure domain function)
-	updated := domain.CalculateMovementProgression(*current, input, time.Now())
+	now := s.now()
+	updated := domain.CalculateMovementProgression(*current, input, now)
 
 	// Persist
 	if err := s.movementStore.UpsertUserProgress(ctx, updated); err != nil {
 		return nil, err
 	}
 
+	// Log the completion at the difficulty it was performed for usage analytics
+	record := domain.MovementSessionRecord{
+		MovementID:    movementID,
+		Difficulty:    current.UserDifficulty,
+		CompletedReps: input.CompletedReps,
+		TargetReps:    input.TargetReps,
+		RPE:           input.RPE,
+		HadFormIssue:  input.HadFormIssue,
+		Successful:    domain.IsSuccessfulMovementSession(input),
+		PerformedAt:   now,
+	}
+	if err := s.movementStore.RecordSession(ctx, record); err != nil {
+		return nil, err
+	}
+
 	return &updated, nil
 }
+
+// GetAnalytics returns usage analytics across the movement library.
+func (s *MovementService) GetAnalytics(ctx context.Context) (*domain.MovementAnalytics, error) {
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.movementStore.GetSessionLog(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := domain.BuildMovementAnalytics(movements, records)
+	return &analytics, nil
+}
+
+// GetStallRegression reports whether a movement's progression has stalled and, if so,
+// the suggested regression from the same category. Returns (false, nil, nil) when not stalled.
+func (s *MovementService) GetStallRegression(ctx context.Context, movementID string) (bool, *domain.Movement, error) {
+	mov, err := s.movementStore.GetByID(ctx, movementID)
+	if err != nil {
+		return false, nil, err
+	}
+
+	records, err := s.movementStore.GetSessionLog(ctx, movementID)
+	if err != nil {
+		return false, nil, err
+	}
+	if !domain.IsMovementStalled(records) {
+		return false, nil, nil
+	}
+
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, domain.SuggestMovementRegression(*mov, movements), nil
+}
+
+// WarmupInput gathers the catalog, current joint integrity, pinned movements, and
+// usable equipment for generating warm-ups. Archetype is left for the caller to set.
+// Joint integrity fails open (nil) if fatigue data is unavailable.
+func (s *MovementService) WarmupInput(ctx context.Context, now time.Time) (domain.WarmupInput, error) {
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return domain.WarmupInput{}, err
+	}
+
+	pins, err := s.movementStore.ListWarmupPins(ctx)
+	if err != nil {
+		return domain.WarmupInput{}, err
+	}
+
+	input := domain.WarmupInput{
+		Movements:         movements,
+		PinnedMovementIDs: pins,
+		Equipment:         s.availability(ctx, now),
+	}
+	if jointIntegrity, err := s.jointIntegrity(ctx, now); err == nil {
+		input.JointIntegrity = jointIntegrity
+	}
+	return input, nil
+}
+
+// GenerateWarmup builds a prepare-phase exercise list for the given archetype.
+func (s *MovementService) GenerateWarmup(ctx context.Context, archetype domain.Archetype, now time.Time) ([]domain.SessionExercise, error) {
+	input, err := s.WarmupInput(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	input.Archetype = archetype
+	return domain.GenerateWarmup(input), nil
+}
+
+// ListWarmupPins returns the user's pinned warm-up movement IDs.
+func (s *MovementService) ListWarmupPins(ctx context.Context) ([]string, error) {
+	return s.movementStore.ListWarmupPins(ctx)
+}
+
+// PinWarmupMovement pins a catalog movement as a preferred warm-up.
+// Returns store.ErrMovementNotFound if the movement does not exist.
+func (s *MovementService) PinWarmupMovement(ctx context.Context, movementID string) error {
+	if _, err := s.movementStore.GetByID(ctx, movementID); err != nil {
+		return err
+	}
+	return s.movementStore.PinWarmup(ctx, movementID)
+}
+
+// UnpinWarmupMovement removes a pinned warm-up movement.
+func (s *MovementService) UnpinWarmupMovement(ctx context.Context, movementID string) error {
+	return s.movementStore.UnpinWarmup(ctx, movementID)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// NoteEditResult reports what a note edit saved.
+type NoteEditResult struct {
+	Outcome  domain.NoteEditOutcome `json:"outcome"`
+	Notes    string                 `json:"notes"`              // Note text now stored
+	Conflict *domain.NoteConflict   `json:"conflict,omitempty"` // Set when a concurrent edit was overwritten
+}
+
+// NoteService edits day and session notes from several devices without
+// silently losing a concurrent edit.
+type NoteService struct {
+	noteStore       *store.NoteStore
+	dailyLogService *DailyLogService
+	clocked
+}
+
+// NewNoteService creates a new NoteService. Edits go through the daily log
+// service's logging lock.
+func NewNoteService(ns *store.NoteStore, dls *DailyLogService) *NoteService {
+	return &NoteService{noteStore: ns, dailyLogService: dls}
+}
+
+// UpdateDayNotes saves a day's notes. base is the text the device started
+// from; nil saves unconditionally.
+// Returns store.ErrDailyLogNotFound if no log exists for that date.
+func (s *NoteService) UpdateDayNotes(ctx context.Context, date string, notes string, base *string) (*NoteEditResult, error) {
+	return s.edit(ctx, domain.NoteRef{Target: domain.NoteTargetDay, Date: date}, notes, base)
+}
+
+// UpdateSessionNotes saves a training session's notes. base is the text the
+// device started from; nil saves unconditionally.
+// Returns domain.ErrSessionNotFound if the session doesn't exist.
+func (s *NoteService) UpdateSessionNotes(ctx context.Context, sessionID int64, notes string, base *string) (*NoteEditResult, error) {
+	date, err := s.noteStore.SessionNoteDate(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.edit(ctx, domain.NoteRef{Target: domain.NoteTargetSession, Date: date, SessionID: sessionID}, notes, base)
+}
+
+func (s *NoteService) edit(ctx context.Context, ref domain.NoteRef, notes string, base *string) (*NoteEditResult, error) {
+	retroEdit, err := s.dailyLogService.checkDayLock(ctx, ref.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NoteEditResult{}
+	now := s.now()
+	err = s.noteStore.EditNote(ctx, ref, func(current string) (string, *domain.NoteConflict) {
+		merge := domain.MergeNote(base, current, notes)
+		result.Outcome = merge.Outcome
+		result.Notes = merge.Text
+		result.Conflict = nil
+		if merge.Outcome == domain.NoteEditConflict {
+			result.Conflict = domain.NewNoteConflict(ref, *base, merge, now)
+		}
+		return merge.Text, result.Conflict
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Outcome != domain.NoteEditUnchanged {
+		s.dailyLogService.recordRetroEdit(ctx, retroEdit, domain.LogEditNotes)
+	}
+	return result, nil
+}
+
+// ListConflicts returns the unresolved note conflicts, oldest first.
+func (s *NoteService) ListConflicts(ctx context.Context) ([]domain.NoteConflict, error) {
+	return s.noteStore.ListOpenConflicts(ctx)
+}
+
+// ResolveConflict settles a conflict and saves the chosen text. The text is
+// saved as an edit based on the conflict's kept text, so a change made to the
+// note since the conflict is merged (or itself recorded as a conflict)
+// rather than lost.
+// Returns store.ErrNoteConflictNotFound if the conflict doesn't exist.
+func (s *NoteService) ResolveConflict(ctx context.Context, id int64, resolution domain.NoteResolution, text string) (*NoteEditResult, error) {
+	conflict, err := s.noteStore.GetConflict(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	chosen, err := conflict.Resolve(resolution, text, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	ref := domain.NoteRef{Target: conflict.Target, Date: conflict.Date}
+	if conflict.SessionID != nil {
+		ref.SessionID = *conflict.SessionID
+	}
+	kept := conflict.Kept
+	result, err := s.edit(ctx, ref, chosen, &kept)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.noteStore.MarkConflictResolved(ctx, conflict); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
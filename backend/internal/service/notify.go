@@ -0,0 +1,173 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// notifier delivers a short plain-text message.
+type notifier interface {
+	Name() string
+	Notify(ctx context.Context, title, body string) error
+}
+
+// ntfyNotifier publishes to an ntfy topic (ntfy.sh or self-hosted).
+type ntfyNotifier struct {
+	topicURL string
+	token    string // Optional access token for protected topics
+	client   *http.Client
+}
+
+func (n *ntfyNotifier) Name() string { return "ntfy" }
+
+// Notify posts the body to the topic with the title as the ntfy Title header.
+func (n *ntfyNotifier) Notify(ctx context.Context, title, body string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topicURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", title)
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("ntfy returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// smtpTimeout bounds a whole SMTP exchange, from dialing to QUIT, so an
+// unresponsive server can't hold up the digest.
+var smtpTimeout = 10 * time.Second
+
+// smtpNotifier sends a plain-text email. Authentication is used when a
+// username is set; the connection is upgraded to TLS when the server offers
+// STARTTLS.
+type smtpNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func (n *smtpNotifier) Name() string { return "smtp" }
+
+// Notify sends the message to every recipient, giving up after smtpTimeout
+// or when ctx ends, whichever is first.
+func (n *smtpNotifier) Notify(ctx context.Context, title, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", title)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.host, n.port))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	return n.send(client, msg.Bytes())
+}
+
+// send runs the exchange smtp.SendMail would on an open client.
+func (n *smtpNotifier) send(client *smtp.Client, msg []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	for _, addr := range n.to {
+		if err := client.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// notifiersFromEnv builds the configured delivery channels:
+//   - ntfy: DIGEST_NTFY_URL (topic URL, e.g. https://ntfy.sh/my-topic), optional DIGEST_NTFY_TOKEN
+//   - email: DIGEST_SMTP_HOST, DIGEST_SMTP_FROM and DIGEST_SMTP_TO (comma-separated),
+//     optional DIGEST_SMTP_PORT (default 587), DIGEST_SMTP_USERNAME and DIGEST_SMTP_PASSWORD
+func notifiersFromEnv() []notifier {
+	var notifiers []notifier
+	if url := os.Getenv("DIGEST_NTFY_URL"); url != "" {
+		notifiers = append(notifiers, &ntfyNotifier{
+			topicURL: url,
+			token:    os.Getenv("DIGEST_NTFY_TOKEN"),
+			client:   &http.Client{Timeout: 10 * time.Second},
+		})
+	}
+	if host := os.Getenv("DIGEST_SMTP_HOST"); host != "" {
+		var to []string
+		for _, addr := range strings.Split(os.Getenv("DIGEST_SMTP_TO"), ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				to = append(to, addr)
+			}
+		}
+		port := os.Getenv("DIGEST_SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		from := os.Getenv("DIGEST_SMTP_FROM")
+		if from != "" && len(to) > 0 {
+			notifiers = append(notifiers, &smtpNotifier{
+				host:     host,
+				port:     port,
+				username: os.Getenv("DIGEST_SMTP_USERNAME"),
+				password: os.Getenv("DIGEST_SMTP_PASSWORD"),
+				from:     from,
+				to:       to,
+			})
+		}
+	}
+	return notifiers
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: ntfy reads the title and access token from headers, and a
+// rejected publish must surface as an error so the digest job reports it;
+// tests pin both against a fake ntfy server, an SMTP server that never
+// answers must not hang the digest, plus the env wiring.
+type NotifySuite struct {
+	suite.Suite
+}
+
+func TestNotifySuite(t *testing.T) {
+	suite.Run(t, new(NotifySuite))
+}
+
+func (s *NotifySuite) TestNtfyPublishesTitleBodyAndToken() {
+	var title, auth, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title, auth = r.Header.Get("Title"), r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	n := &ntfyNotifier{topicURL: server.URL + "/victus", token: "tk_secret", client: server.Client()}
+	s.Require().NoError(n.Notify(s.T().Context(), "Victus digest - Mon 2 Mar", "Nothing logged today."))
+	s.Equal("Victus digest - Mon 2 Mar", title)
+	s.Equal("Bearer tk_secret", auth)
+	s.Equal("Nothing logged today.", body)
+}
+
+func (s *NotifySuite) TestNtfyRejectedPublishIsAnError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	n := &ntfyNotifier{topicURL: server.URL, client: server.Client()}
+	s.Error(n.Notify(s.T().Context(), "title", "body"))
+}
+
+func (s *NotifySuite) TestSMTPGivesUpOnASilentServer() {
+	defer func(timeout time.Duration) { smtpTimeout = timeout }(smtpTimeout)
+	smtpTimeout = 100 * time.Millisecond
+
+	// Accepts the connection but never sends the greeting
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer ln.Close()
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	n := &smtpNotifier{host: host, port: port, from: "victus@example.com", to: []string{"me@example.com"}}
+	start := time.Now()
+	s.Error(n.Notify(s.T().Context(), "title", "body"))
+	s.Less(time.Since(start), 5*time.Second)
+}
+
+func (s *NotifySuite) TestNotifiersFromEnv() {
+	s.T().Setenv("DIGEST_NTFY_URL", "")
+	s.T().Setenv("DIGEST_SMTP_HOST", "smtp.example.com")
+	s.T().Setenv("DIGEST_SMTP_FROM", "victus@example.com")
+	s.T().Setenv("DIGEST_SMTP_TO", "")
+	s.Empty(notifiersFromEnv(), "email needs a recipient")
+
+	s.T().Setenv("DIGEST_SMTP_TO", "me@example.com, partner@example.com")
+	s.T().Setenv("DIGEST_NTFY_URL", "https://ntfy.sh/victus")
+	notifiers := notifiersFromEnv()
+	s.Require().Len(notifiers, 2)
+	s.Equal("ntfy", notifiers[0].Name())
+	smtpN := notifiers[1].(*smtpNotifier)
+	s.Equal([]string{"me@example.com", "partner@example.com"}, smtpN.to)
+	s.Equal("587", smtpN.port)
+}
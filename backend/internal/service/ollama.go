@@ -1,23 +1,44 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"victus/internal/domain"
+	"victus/internal/store"
 )
 
 // OllamaService provides AI-generated recipe names via local Ollama.
 type OllamaService struct {
-	baseURL string
-	client  *http.Client
-	enabled bool
+	baseURL    string
+	client     *http.Client
+	pullClient *http.Client // No timeout; model pulls stream for minutes
+
+	// Per-endpoint circuit breakers and retries (see ollama_breaker.go)
+	breakersMu    sync.Mutex
+	breakers      map[string]*circuitBreaker
+	breakerPolicy ollamaBreakerPolicy
+	retry         ollamaRetryPolicy
+
+	// Model management (see ollama_models.go), guarded by mu
+	mu             sync.RWMutex
+	taskModels     map[OllamaTask][]string
+	installed      map[string]bool // Normalized names; nil until detected
+	installedOrder []OllamaModel
+	detectedAt     time.Time
+	pulls          map[string]*OllamaPullProgress
+
+	// Usage accounting and daily budgets (see ollama_usage.go); optional
+	usage   *store.LLMUsageStore
+	budgets map[domain.LLMFeature]domain.LLMBudget
 }
 
 // NewOllamaService creates a new OllamaService.
@@ -26,10 +47,19 @@ func NewOllamaService(baseURL string) *OllamaService {
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
 	}
+	taskModels := make(map[OllamaTask][]string, len(defaultOllamaTaskModels))
+	for task, models := range defaultOllamaTaskModels {
+		taskModels[task] = append([]string{}, models...)
+	}
 	return &OllamaService{
-		baseURL: baseURL,
-		client:  &http.Client{Timeout: 10 * time.Second},
-		enabled: true,
+		baseURL:       baseURL,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		pullClient:    &http.Client{},
+		breakers:      make(map[string]*circuitBreaker),
+		breakerPolicy: defaultOllamaBreakerPolicy,
+		retry:         defaultOllamaRetryPolicy,
+		taskModels:    taskModels,
+		pulls:         make(map[string]*OllamaPullProgress),
 	}
 }
 
@@ -40,7 +70,10 @@ type ollamaRequest struct {
 }
 
 type ollamaResponse struct {
-	Response string `json:"response"`
+	Response        string `json:"response"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	TotalDuration   int64  `json:"total_duration"` // Nanoseconds
 }
 
 // GenerateRecipeName creates a creative name for the ingredient combination.
@@ -48,7 +81,7 @@ type ollamaResponse struct {
 func (s *OllamaService) GenerateRecipeName(ctx context.Context, ingredients []string) string {
 	fallback := generateFallbackName(ingredients)
 
-	if !s.enabled || len(ingredients) == 0 {
+	if len(ingredients) == 0 {
 		return fallback
 	}
 
@@ -62,43 +95,13 @@ Rules:
 - Example responses: "Protein Power Bowl", "Mediterranean Delight", "Quick Energy Mix"`,
 		strings.Join(ingredients, ", "))
 
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return fallback
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
+	text, err := s.complete(ctx, domain.LLMFeatureRecipeName, s.ModelFor(OllamaTaskParse), prompt)
 	if err != nil {
 		return fallback
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.client.Do(httpReq)
-	if err != nil {
-		// Disable for future requests if connection failed
-		s.enabled = false
-		return fallback
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fallback
-	}
-
-	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fallback
-	}
 
 	// Clean up the response
-	name := strings.TrimSpace(result.Response)
-	name = strings.Trim(name, `"'`)
+	name := strings.Trim(text, `"'`)
 	name = strings.Split(name, "\n")[0] // Take only first line
 
 	// Validate the response
@@ -116,22 +119,20 @@ func (s *OllamaService) IsAvailable(ctx context.Context) bool {
 	healthCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(healthCtx, "GET", s.baseURL+"/api/tags", nil)
-	if err != nil {
-		s.enabled = false
-		return false
-	}
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(healthCtx, nil, http.MethodGet, "/api/tags", nil)
 	if err != nil {
 		log.Printf("[OLLAMA] Health check failed: %v", err)
-		s.enabled = false
 		return false
 	}
 	defer resp.Body.Close()
 
 	isAvailable := resp.StatusCode == http.StatusOK
-	s.enabled = isAvailable
+
+	// Refresh installed models while we have the tags
+	var tags ollamaTagsResponse
+	if isAvailable && json.NewDecoder(resp.Body).Decode(&tags) == nil {
+		s.recordInstalled(tags)
+	}
 
 	if isAvailable {
 		log.Printf("[OLLAMA] Health check passed - service is available")
@@ -139,50 +140,35 @@ func (s *OllamaService) IsAvailable(ctx context.Context) bool {
 		log.Printf("[OLLAMA] Health check failed - received status %d", resp.StatusCode)
 	}
 
-	return s.enabled
+	return isAvailable
 }
 
-// Generate sends a generic prompt to Ollama and returns the response.
-// Returns error if Ollama is unavailable or request fails.
-func (s *OllamaService) Generate(ctx context.Context, prompt string) (string, error) {
-	if !s.enabled {
-		return "", fmt.Errorf("ollama service is disabled")
-	}
-
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return "", err
-	}
+// Probe checks Ollama is reachable without logging or going through the
+// circuit breakers, so it can back frequent readiness probes.
+func (s *OllamaService) Probe(ctx context.Context) error {
+	probeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(probeCtx, "GET", s.baseURL+"/api/tags", nil)
 	if err != nil {
-		return "", err
+		return err
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.client.Do(httpReq)
+	resp, err := s.client.Do(req)
 	if err != nil {
-		s.enabled = false
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("ollama returned status %d", resp.StatusCode)
-	}
-
-	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+		return fmt.Errorf("ollama returned status %d", resp.StatusCode)
 	}
+	return nil
+}
 
-	return strings.TrimSpace(result.Response), nil
+// Generate sends a generic prompt to Ollama on behalf of a feature and returns the response.
+// Returns error if Ollama is unavailable, the feature is over budget, or the request fails.
+func (s *OllamaService) Generate(ctx context.Context, feature domain.LLMFeature, prompt string) (string, error) {
+	return s.complete(ctx, feature, s.ModelFor(OllamaTaskNarrative), prompt)
 }
 
 // generateFallbackName creates a simple name when Ollama is unavailable.
@@ -211,6 +197,12 @@ type debriefLLMPayload struct {
 	TDEEDelta         int               `json:"tdeeDelta"`
 	Days              []debriefDayShort `json:"days"`
 	UserNotes         []string          `json:"userNotes,omitempty"`
+	CaffeineWarnings  []string          `json:"caffeineWarnings,omitempty"`
+	EnvironmentNotes  []string          `json:"environmentNotes,omitempty"` // Shortfalls explained by heat/humidity/altitude
+	Substitutions     []string          `json:"substitutions,omitempty"`    // Fatigue swaps offered in the runner and the outcome
+	ProteinDaysMet    *float64          `json:"proteinDaysMet,omitempty"`   // % of logged days at or above the protein floor
+	CarbDaysMet       *float64          `json:"carbDaysMet,omitempty"`      // % of logged days with carbs within tolerance
+	FatDaysMet        *float64          `json:"fatDaysMet,omitempty"`       // % of logged days at or above the fat minimum
 }
 
 type debriefDayShort struct {
@@ -237,10 +229,6 @@ func (s *OllamaService) GenerateDebriefNarrative(
 	// Build fallback first
 	fallback := domain.GenerateFallbackNarrative(debrief)
 
-	if !s.enabled {
-		return fallback
-	}
-
 	// Build the LLM payload
 	payload := buildDebriefPayload(input, debrief)
 
@@ -270,53 +258,75 @@ CONSTRAINTS:
 
 Return ONLY the narrative text, no preamble or explanation.`, string(payloadJSON))
 
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return fallback
-	}
-
 	// Use a longer timeout for narrative generation (30s instead of 10s)
 	narrativeCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	httpReq, err := http.NewRequestWithContext(narrativeCtx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
+	model := s.ModelFor(OllamaTaskNarrative)
+	text, err := s.complete(narrativeCtx, domain.LLMFeatureDebrief, model, prompt)
 	if err != nil {
 		return fallback
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := s.client.Do(httpReq)
-	if err != nil {
-		s.enabled = false
+	if len(text) < 50 || len(text) > 2000 {
 		return fallback
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	return domain.DebriefNarrative{
+		Text:           text,
+		GeneratedByLLM: true,
+		Model:          model,
+	}
+}
+
+// GenerateAnnualReviewNarrative generates the year-in-review narrative using
+// Ollama, falling back to a template.
+func (s *OllamaService) GenerateAnnualReviewNarrative(ctx context.Context, review *domain.AnnualReview) domain.DebriefNarrative {
+	fallback := domain.DebriefNarrative{Text: domain.GenerateFallbackAnnualNarrative(review), Model: "template"}
+
+	payloadJSON, err := json.Marshal(review)
+	if err != nil {
 		return fallback
 	}
 
-	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	prompt := fmt.Sprintf(`You are a direct, slightly dry, performance-oriented fitness coach looking back on a full year of training and nutrition data.
+
+YEAR DATA (JSON):
+%s
+
+Write a year-in-review narrative (3-4 paragraphs) that:
+1. Opens with the headline numbers: sessions, training load and weight change
+2. Calls out the strongest stretch of the year (vitality streaks, peak training week)
+3. Assesses each nutrition plan honestly: reached, close, or abandoned
+4. Ends with one or two priorities for the coming year
+
+TONE: Direct and factual, with occasional dry humor. No excessive enthusiasm or emoji. Address the user as "you".
+
+CONSTRAINTS:
+- Keep under 400 words
+- Use the numbers from the data; do not invent any
+- If the year is still in progress (endDate is not December 31), say so
+
+Return ONLY the narrative text, no preamble or explanation.`, string(payloadJSON))
+
+	// A year of data is a long prompt; allow more time than a weekly debrief
+	narrativeCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	model := s.ModelFor(OllamaTaskNarrative)
+	text, err := s.complete(narrativeCtx, domain.LLMFeatureAnnualReview, model, prompt)
+	if err != nil {
 		return fallback
 	}
 
-	// Clean up the response
-	text := strings.TrimSpace(result.Response)
-	if len(text) < 50 || len(text) > 2000 {
+	if len(text) < 100 || len(text) > 3000 {
 		return fallback
 	}
 
 	return domain.DebriefNarrative{
 		Text:           text,
 		GeneratedByLLM: true,
-		Model:          "llama3.2",
+		Model:          model,
 	}
 }
 
@@ -353,18 +363,7 @@ func (s *OllamaService) GenerateSemanticRefinement(
 	absurdity *domain.AbsurdityWarning,
 	bodyStatus *domain.BodyStatus, // New parameter for bio-status integration
 ) domain.SemanticRefinement {
-	fallback := BuildFallbackRefinement(solution, absurdity)
-
-	// Try to reconnect if previously disabled (don't give up permanently)
-	if !s.enabled {
-		log.Printf("[OLLAMA] Ollama was previously disabled, attempting reconnection...")
-		// Quick health check to see if Ollama is back online
-		if !s.IsAvailable(ctx) {
-			log.Printf("[OLLAMA] Ollama still unavailable, using fallback")
-			return fallback
-		}
-		log.Printf("[OLLAMA] Ollama connection restored!")
-	}
+	fallback := BuildFallbackRefinement(solution, absurdity, 0)
 
 	log.Printf("[OLLAMA] Generating semantic refinement for %d ingredients", len(solution.Ingredients))
 
@@ -379,52 +378,19 @@ func (s *OllamaService) GenerateSemanticRefinement(
 	// Dynamic Prompt Construction based on Bio-Status and Meal Logic
 	prompt := buildTacticalPrompt(string(payloadJSON), trainingCtx, bodyStatus, solution.TotalMacros.ProteinG)
 
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return fallback
-	}
-
 	// Use 8s timeout to prevent frontend hangs (3 solutions × 8s = 24s total, still under typical 30s frontend timeout)
 	refinerCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
 	defer cancel()
 
-	httpReq, err := http.NewRequestWithContext(refinerCtx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
-	if err != nil {
-		log.Printf("[OLLAMA] Failed to create HTTP request: %v", err)
-		return fallback
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
 	log.Printf("[OLLAMA] Sending semantic refinement request to %s (timeout: 8s)", s.baseURL)
 
-	resp, err := s.client.Do(httpReq)
+	model := s.ModelFor(OllamaTaskNarrative)
+	responseText, err := s.complete(refinerCtx, domain.LLMFeatureSolverRefinement, model, prompt)
 	if err != nil {
 		log.Printf("[OLLAMA] Semantic refinement request failed: %v", err)
-		s.enabled = false
-		return fallback
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("[OLLAMA] Semantic refinement returned status %d (expected 200)", resp.StatusCode)
-		return fallback
-	}
-
-	var ollamaResp ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
-		log.Printf("[OLLAMA] Failed to decode Ollama response: %v", err)
 		return fallback
 	}
 
-	// Parse the JSON response from Ollama
-	responseText := strings.TrimSpace(ollamaResp.Response)
-
 	// Log first 200 chars to avoid truncation in logs
 	logPreview := responseText
 	if len(logPreview) > 200 {
@@ -432,41 +398,10 @@ func (s *OllamaService) GenerateSemanticRefinement(
 	}
 	log.Printf("[OLLAMA] Raw response preview: %s", logPreview)
 
-	// Try to extract JSON from the response (in case there's preamble)
-	jsonStart := strings.Index(responseText, "{")
-	jsonEnd := strings.LastIndex(responseText, "}")
-
-	if jsonStart == -1 {
-		log.Printf("[OLLAMA] No opening brace found in response")
-		return fallback
-	}
-
-	// Handle incomplete JSON - if closing brace is missing, try to add it
-	if jsonEnd == -1 || jsonEnd < jsonStart {
-		log.Printf("[OLLAMA] JSON appears incomplete (response length: %d chars), attempting to fix by adding closing brace", len(responseText))
-		// Take everything from the opening brace and add a closing brace
-		responseText = responseText[jsonStart:] + "\n}"
-		log.Printf("[OLLAMA] Fixed JSON: %s", responseText)
-	} else {
-		responseText = responseText[jsonStart : jsonEnd+1]
-	}
-
-	log.Printf("[OLLAMA] Extracted JSON length: %d chars", len(responseText))
-
-	var refinerResp semanticRefinerResponse
-	if err := json.Unmarshal([]byte(responseText), &refinerResp); err != nil {
-		log.Printf("[OLLAMA] Failed to unmarshal semantic refinement JSON: %v", err)
-		log.Printf("[OLLAMA] JSON text: %s", responseText)
-		return fallback
-	}
-
-	// Validate the response
-	if len(refinerResp.MissionTitle) < 5 || len(refinerResp.MissionTitle) > 100 {
-		log.Printf("[OLLAMA] Invalid mission title length: %d chars", len(refinerResp.MissionTitle))
-		return fallback
-	}
-	if len(refinerResp.OperationalSteps) < 10 || len(refinerResp.OperationalSteps) > 300 {
-		log.Printf("[OLLAMA] Invalid operational steps length: %d chars", len(refinerResp.OperationalSteps))
+	// Extract the JSON (tolerates preamble, trailing notes and missing braces)
+	refinerResp, err := parseSemanticRefinerResponse(responseText)
+	if err != nil {
+		log.Printf("[OLLAMA] Invalid semantic refinement: %v", err)
 		return fallback
 	}
 
@@ -479,7 +414,7 @@ func (s *OllamaService) GenerateSemanticRefinement(
 		FlavorPatch:       refinerResp.FlavorPatch,
 		ContextualInsight: refinerResp.ContextualInsight,
 		GeneratedByLLM:    true,
-		Model:             "llama3.2",
+		Model:             model,
 	}
 }
 
@@ -580,86 +515,6 @@ TONE: Military logistics meets sports nutrition. Direct, mechanical, tactical.`
 	return fmt.Sprintf(basePrompt, jsonPayload, contextLogic.String())
 }
 
-// BuildFallbackRefinement creates a semantic refinement when Ollama is unavailable.
-// Includes basic liquid binder logic for dry ingredient combinations.
-// Exported for use by solver service for non-primary solutions.
-func BuildFallbackRefinement(solution domain.SolverSolution, absurdity *domain.AbsurdityWarning) domain.SemanticRefinement {
-	// Generate a simple tactical name from ingredients
-	var missionTitle string
-	if len(solution.Ingredients) == 1 {
-		missionTitle = fmt.Sprintf("SIMPLE %s PROTOCOL", strings.ToUpper(solution.Ingredients[0].Food.FoodItem))
-	} else if len(solution.Ingredients) == 2 {
-		missionTitle = fmt.Sprintf("%s & %s STACK",
-			strings.ToUpper(solution.Ingredients[0].Food.FoodItem),
-			strings.ToUpper(solution.Ingredients[1].Food.FoodItem))
-	} else {
-		missionTitle = fmt.Sprintf("%s MIX: STANDARD", strings.ToUpper(solution.Ingredients[0].Food.FoodItem))
-	}
-
-	// Truncate if too long
-	if len(missionTitle) > 50 {
-		missionTitle = missionTitle[:50]
-	}
-
-	// Check if ingredients are primarily dry (powder/seeds) and need liquid
-	needsLiquid := false
-	for _, ing := range solution.Ingredients {
-		name := strings.ToLower(ing.Food.FoodItem)
-		if strings.Contains(name, "whey") ||
-			strings.Contains(name, "protein") ||
-			strings.Contains(name, "powder") ||
-			strings.Contains(name, "chia") ||
-			strings.Contains(name, "oat") {
-			needsLiquid = true
-			break
-		}
-	}
-
-	// Generate tactical prep with liquid binder if needed
-	var tacticalPrep string
-	if needsLiquid {
-		// Estimate liquid needed: roughly 15ml per 10g of dry ingredients
-		totalDryWeight := 0.0
-		for _, ing := range solution.Ingredients {
-			name := strings.ToLower(ing.Food.FoodItem)
-			if strings.Contains(name, "whey") || strings.Contains(name, "protein") ||
-				strings.Contains(name, "powder") || strings.Contains(name, "chia") ||
-				strings.Contains(name, "oat") {
-				totalDryWeight += ing.AmountG
-			}
-		}
-		liquidML := int(totalDryWeight * 1.5) // 1.5ml per gram of dry ingredients
-		if liquidML < 150 {
-			liquidML = 150
-		}
-		if liquidML > 500 {
-			liquidML = 500
-		}
-		tacticalPrep = fmt.Sprintf("Combine all ingredients with %dml water or almond milk. Mix until uniform consistency.", liquidML)
-	} else {
-		tacticalPrep = "Combine all ingredients and serve."
-	}
-
-	// Use absurdity warning if provided
-	var absurdityAlert *string
-	if absurdity != nil {
-		alert := absurdity.Description
-		absurdityAlert = &alert
-	}
-
-	log.Printf("[OLLAMA] Using fallback refinement (Ollama unavailable)")
-
-	return domain.SemanticRefinement{
-		MissionTitle:      missionTitle,
-		TacticalPrep:      tacticalPrep,
-		AbsurdityAlert:    absurdityAlert,
-		FlavorPatch:       nil,
-		ContextualInsight: solution.WhyText,
-		GeneratedByLLM:    false,
-		Model:             "",
-	}
-}
-
 // buildSemanticRefinerPayload converts solver solution to the LLM payload format.
 func buildSemanticRefinerPayload(
 	solution domain.SolverSolution,
@@ -730,6 +585,23 @@ func buildDebriefPayload(input domain.DebriefInput, debrief *domain.WeeklyDebrie
 		days = append(days, d)
 	}
 
+	var caffeineWarnings []string
+	if debrief.Caffeine != nil {
+		for _, w := range debrief.Caffeine.Warnings {
+			caffeineWarnings = append(caffeineWarnings, w.Message)
+		}
+	}
+
+	var environmentNotes []string
+	for _, n := range debrief.EnvironmentNotes {
+		environmentNotes = append(environmentNotes, n.Message)
+	}
+
+	var substitutions []string
+	if len(debrief.Substitutions) > 0 {
+		substitutions = domain.SubstitutionSummaryLines(debrief.Substitutions)
+	}
+
 	return debriefLLMPayload{
 		WeekStart:         debrief.WeekStartDate,
 		WeekEnd:           debrief.WeekEndDate,
@@ -741,17 +613,18 @@ func buildDebriefPayload(input domain.DebriefInput, debrief *domain.WeeklyDebrie
 		TDEEDelta:         debrief.VitalityScore.MetabolicFlux.DeltaKcal,
 		Days:              days,
 		UserNotes:         userNotes,
+		CaffeineWarnings:  caffeineWarnings,
+		EnvironmentNotes:  environmentNotes,
+		Substitutions:     substitutions,
+		ProteinDaysMet:    debrief.MacroCompliance.ProteinPercent,
+		CarbDaysMet:       debrief.MacroCompliance.CarbsPercent,
+		FatDaysMet:        debrief.MacroCompliance.FatPercent,
 	}
 }
 
 // ParseEchoLog processes a natural language echo log and extracts structured data.
 // Returns nil if Ollama is unavailable or parsing fails (caller should handle gracefully).
 func (s *OllamaService) ParseEchoLog(ctx context.Context, sessionCtx domain.EchoSessionContext, rawEcho string) (*domain.EchoLogResult, error) {
-	if !s.enabled {
-		log.Printf("[OLLAMA] Service disabled, skipping echo parsing")
-		return nil, nil
-	}
-
 	// Build list of valid body aliases for the prompt
 	validAliases := domain.ValidBodyAliases()
 
@@ -780,6 +653,7 @@ RULES:
    - Positive = improvement (feeling better, more mobile, loosened up)
    - Negative = degradation (sore, tight, painful, clicking)
    - Valid body parts: %s
+   - Prefix "left " or "right " when the user names a side (e.g. "left knee")
    - Only include parts explicitly mentioned
 3. perceived_exertion_offset: Integer adjustment (-3 to +3):
    - Positive = felt harder than initial RPE suggests
@@ -795,78 +669,80 @@ Return ONLY valid JSON, no explanation or preamble.`,
 		strings.Join(validAliases, ", "),
 	)
 
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
 	// Use shorter timeout for echo parsing
 	echoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	httpReq, err := http.NewRequestWithContext(echoCtx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.client.Do(httpReq)
+	responseText, err := s.complete(echoCtx, domain.LLMFeatureEcho, s.ModelFor(OllamaTaskParse), prompt)
 	if err != nil {
 		log.Printf("[OLLAMA] Echo parse request failed: %v", err)
-		s.enabled = false
 		return nil, nil
 	}
-	defer resp.Body.Close()
+	log.Printf("[OLLAMA] Echo raw response: %s", responseText[:min(200, len(responseText))])
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("[OLLAMA] Echo parse returned status %d", resp.StatusCode)
+	echoResult, err := parseEchoResponse(responseText)
+	if err != nil {
+		log.Printf("[OLLAMA] Invalid echo response: %v", err)
 		return nil, nil
 	}
 
-	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("[OLLAMA] Failed to decode echo response: %v", err)
-		return nil, nil
-	}
+	log.Printf("[OLLAMA] Successfully parsed echo: %d achievements, %d joint deltas, RPE offset %d",
+		len(echoResult.Achievements),
+		len(echoResult.JointIntegrityDelta),
+		echoResult.PerceivedExertionOffset)
 
-	// Extract JSON from response
-	responseText := strings.TrimSpace(result.Response)
-	log.Printf("[OLLAMA] Echo raw response: %s", responseText[:min(200, len(responseText))])
+	return echoResult, nil
+}
 
-	// Find JSON object in response
-	startIdx := strings.Index(responseText, "{")
-	endIdx := strings.LastIndex(responseText, "}")
-	if startIdx == -1 || endIdx == -1 || endIdx <= startIdx {
-		log.Printf("[OLLAMA] No valid JSON found in echo response")
-		return nil, nil
+// InferArchetype asks the model for a session's workout archetype when the
+// rules aren't confident. Returns nil if Ollama is unavailable or the answer
+// is invalid (caller keeps the rules' inference).
+func (s *OllamaService) InferArchetype(ctx context.Context, session domain.TrainingSession, recent []domain.Archetype) *domain.ArchetypeInference {
+	names := make([]string, 0, len(domain.ValidArchetypes))
+	for a := range domain.ValidArchetypes {
+		names = append(names, string(a))
+	}
+	sort.Strings(names)
+	recentNames := make([]string, len(recent))
+	for i, a := range recent {
+		recentNames[i] = string(a)
 	}
 
-	jsonStr := responseText[startIdx : endIdx+1]
+	prompt := fmt.Sprintf(`Classify this logged workout into one archetype.
 
-	var echoResult domain.EchoLogResult
-	if err := json.Unmarshal([]byte(jsonStr), &echoResult); err != nil {
-		log.Printf("[OLLAMA] Failed to parse echo JSON: %v", err)
-		return nil, nil
-	}
+SESSION:
+- Training Type: %s
+- Duration: %d minutes
+- Notes: %s
+- Recent archetypes (most recent first): %s
 
-	// Validate the result
-	if err := domain.ValidateEchoResult(echoResult); err != nil {
-		log.Printf("[OLLAMA] Echo result validation failed: %v", err)
-		return nil, nil
-	}
+Valid archetypes: %s
 
-	log.Printf("[OLLAMA] Successfully parsed echo: %d achievements, %d joint deltas, RPE offset %d",
-		len(echoResult.Achievements),
-		len(echoResult.JointIntegrityDelta),
-		echoResult.PerceivedExertionOffset)
+Return ONLY valid JSON:
+{"archetype": "one of the valid archetypes", "confidence": 0.0, "reason": "short reason"}
+
+confidence is 0-1: how sure the notes and history make you. Use a low value when guessing.`,
+		session.Type,
+		session.DurationMin,
+		session.Notes,
+		strings.Join(recentNames, ", "),
+		strings.Join(names, ", "),
+	)
+
+	inferCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
 
-	return &echoResult, nil
+	responseText, err := s.complete(inferCtx, domain.LLMFeatureArchetype, s.ModelFor(OllamaTaskParse), prompt)
+	if err != nil {
+		log.Printf("[OLLAMA] Archetype inference request failed: %v", err)
+		return nil
+	}
+	inference, err := parseArchetypeResponse(responseText)
+	if err != nil {
+		log.Printf("[OLLAMA] Invalid archetype response: %v", err)
+		return nil
+	}
+	return inference
 }
 
 // voiceCommandLLMResponse is the expected JSON response from Ollama for voice commands.
@@ -881,6 +757,12 @@ type voiceCommandLLMResponse struct {
 	Metric      *string            `json:"metric,omitempty"`
 	Value       *float64           `json:"value,omitempty"`
 	Unit        *string            `json:"unit,omitempty"`
+	Meal        *string            `json:"meal,omitempty"`
+	Action      *string            `json:"action,omitempty"`
+	Day         *string            `json:"day,omitempty"`
+	TargetDay   *string            `json:"target_day,omitempty"`
+	SessionType *string            `json:"session_type,omitempty"`
+	DayType     *string            `json:"day_type,omitempty"`
 }
 
 type nutritionItemLLM struct {
@@ -893,85 +775,33 @@ type nutritionItemLLM struct {
 // Uses a flexible JSON schema that handles partial data (returns null for missing fields).
 // Returns nil if Ollama is unavailable or parsing fails (caller should handle gracefully).
 func (s *OllamaService) ParseVoiceCommand(ctx context.Context, rawInput string) (*domain.VoiceCommandResult, error) {
-	if !s.enabled {
-		log.Printf("[OLLAMA] Service disabled, skipping voice command parsing")
-		return nil, nil
-	}
-
 	if rawInput == "" {
 		return nil, nil
 	}
 
 	prompt := buildVoiceCommandPrompt(rawInput)
 
-	req := ollamaRequest{
-		Model:  "llama3.2",
-		Prompt: prompt,
-		Stream: false,
-	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
 	// Use 60s timeout for voice command parsing (async background process)
 	voiceCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	httpReq, err := http.NewRequestWithContext(voiceCtx, "POST", s.baseURL+"/api/generate", bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
 	log.Printf("[OLLAMA] Sending voice command parse request (input length: %d chars)", len(rawInput))
 
-	resp, err := s.client.Do(httpReq)
+	responseText, err := s.complete(voiceCtx, domain.LLMFeatureVoiceCommand, s.ModelFor(OllamaTaskParse), prompt)
 	if err != nil {
 		log.Printf("[OLLAMA] Voice command parse request failed: %v", err)
-		s.enabled = false
 		return nil, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("[OLLAMA] Voice command parse returned status %d", resp.StatusCode)
-		return nil, nil
-	}
-
-	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("[OLLAMA] Failed to decode voice command response: %v", err)
-		return nil, nil
-	}
-
-	// Extract JSON from response
-	responseText := strings.TrimSpace(result.Response)
 	log.Printf("[OLLAMA] Voice command raw response: %s", responseText[:min(200, len(responseText))])
 
-	// Find JSON object in response
-	startIdx := strings.Index(responseText, "{")
-	endIdx := strings.LastIndex(responseText, "}")
-	if startIdx == -1 || endIdx == -1 || endIdx <= startIdx {
-		log.Printf("[OLLAMA] No valid JSON found in voice command response")
-		return nil, nil
-	}
-
-	jsonStr := responseText[startIdx : endIdx+1]
-
-	var llmResp voiceCommandLLMResponse
-	if err := json.Unmarshal([]byte(jsonStr), &llmResp); err != nil {
-		log.Printf("[OLLAMA] Failed to parse voice command JSON: %v", err)
-		return nil, nil
+	voiceResult, err := parseVoiceCommandResponse(responseText, rawInput)
+	if errors.Is(err, domain.ErrMissingVoiceData) {
+		// The caller asks the user for what's missing
+		log.Printf("[OLLAMA] Voice command is missing data: %v", err)
+		return voiceResult, nil
 	}
-
-	// Convert LLM response to domain type
-	voiceResult := convertLLMToVoiceResult(llmResp, rawInput)
-
-	// Validate the result
-	if err := domain.ValidateVoiceCommandResult(voiceResult); err != nil {
-		log.Printf("[OLLAMA] Voice command validation failed: %v", err)
+	if err != nil {
+		log.Printf("[OLLAMA] Invalid voice command response: %v", err)
 		return nil, nil
 	}
 
@@ -989,7 +819,7 @@ USER INPUT:
 %s
 
 GLOBAL RULES:
-1. Identify Intent: Is this TRAINING, NUTRITION, or BIOMETRICS?
+1. Identify Intent: Is this TRAINING, NUTRITION, BIOMETRICS, or PLANNING (changing future training or day types)?
 2. Extract Data: Map words to the Schema below.
 3. Handle Missing Data: If a specific field is not mentioned, return null. Do not guess.
 4. Ignore Filler: Ignore words like 'uh', 'maybe', 'I think'.
@@ -1006,6 +836,7 @@ SCHEMA 2: NUTRITION
   - food: String (e.g., 'Greek Yogurt', 'eggs', 'chicken breast')
   - quantity: Number (e.g., 100, 1, 2)
   - unit: String (e.g., 'g', 'cup', 'whole', 'slice')
+- meal: String ('breakfast', 'lunch', 'dinner', 'snack') or null
 
 SCHEMA 3: BIOMETRICS
 - metric: String (e.g., 'Weight', 'Sleep', 'Body Status')
@@ -1013,6 +844,13 @@ SCHEMA 3: BIOMETRICS
 - unit: String (e.g., 'kg', 'hours') or null
 - sensation: String or null (e.g., 'left knee clicky', 'back stiff')
 
+SCHEMA 4: PLANNING
+- action: String ('move_session' or 'set_day_type')
+- day: String, the day as spoken (e.g., 'tomorrow', 'Friday') or null
+- target_day: String, where a moved session goes (e.g., 'Friday') or null
+- session_type: String (e.g., 'strength', 'run') or null
+- day_type: String ('performance', 'fatburner', 'metabolize') or null
+
 EXAMPLES:
 
 Input: 'Did 20 mins of rowing, heart rate was around 145.'
@@ -1028,7 +866,10 @@ Input: 'Weighed in at 82.5 kg this morning'
 Output: {"intent": "BIOMETRICS", "metric": "Weight", "value": 82.5, "unit": "kg", "sensation": null}
 
 Input: 'Had 100g Greek yogurt and 2 eggs for breakfast'
-Output: {"intent": "NUTRITION", "items": [{"food": "Greek yogurt", "quantity": 100, "unit": "g"}, {"food": "eggs", "quantity": 2, "unit": "whole"}]}
+Output: {"intent": "NUTRITION", "items": [{"food": "Greek yogurt", "quantity": 100, "unit": "g"}, {"food": "eggs", "quantity": 2, "unit": "whole"}], "meal": "breakfast"}
+
+Input: 'Eggs and toast'
+Output: {"intent": "NUTRITION", "items": [{"food": "eggs", "quantity": null, "unit": null}, {"food": "toast", "quantity": null, "unit": null}], "meal": null}
 
 Input: 'Slept 7.5 hours last night'
 Output: {"intent": "BIOMETRICS", "metric": "Sleep", "value": 7.5, "unit": "hours", "sensation": null}
@@ -1036,6 +877,12 @@ Output: {"intent": "BIOMETRICS", "metric": "Sleep", "value": 7.5, "unit": "hours
 Input: 'Strength training for 45 minutes, RPE 8, shoulders feeling tight'
 Output: {"intent": "TRAINING", "activity": "Strength", "duration_min": 45, "avg_hr": null, "rpe": 8, "sensation": "shoulders feeling tight"}
 
+Input: 'Move tomorrow's strength to Friday'
+Output: {"intent": "PLANNING", "action": "move_session", "day": "tomorrow", "target_day": "Friday", "session_type": "strength", "day_type": null}
+
+Input: 'Make Saturday a metabolize day'
+Output: {"intent": "PLANNING", "action": "set_day_type", "day": "Saturday", "target_day": null, "session_type": null, "day_type": "metabolize"}
+
 Return ONLY valid JSON with no preamble or explanation.`, rawInput)
 }
 
@@ -1082,6 +929,9 @@ func convertLLMToVoiceResult(llmResp voiceCommandLLMResponse, rawInput string) *
 		result.Nutrition = &domain.NutritionData{
 			Items: items,
 		}
+		if llmResp.Meal != nil {
+			result.Nutrition.Meal = domain.ImportMealName(*llmResp.Meal)
+		}
 
 	case domain.VoiceIntentBiometrics:
 		metric := ""
@@ -1094,15 +944,31 @@ func convertLLMToVoiceResult(llmResp voiceCommandLLMResponse, rawInput string) *
 			Unit:      llmResp.Unit,
 			Sensation: llmResp.Sensation,
 		}
+
+	case domain.VoiceIntentPlanning:
+		result.Planning = &domain.PlanningVoiceData{
+			Action:      domain.PlanningVoiceAction(strings.ToLower(derefLLMString(llmResp.Action))),
+			Day:         derefLLMString(llmResp.Day),
+			TargetDay:   derefLLMString(llmResp.TargetDay),
+			SessionType: derefLLMString(llmResp.SessionType),
+			DayType:     domain.DayType(strings.ToLower(derefLLMString(llmResp.DayType))),
+		}
 	}
 
 	return result
 }
 
+func derefLLMString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
 // GenerateFormCorrection analyzes user feedback about a movement and provides a tactical cue.
 // Returns nil if Ollama is unavailable.
 func (s *OllamaService) GenerateFormCorrection(ctx context.Context, req domain.FormCorrectionRequest) *domain.FormCorrectionResult {
-	if !s.enabled || req.UserFeedback == "" {
+	if req.UserFeedback == "" {
 		return nil
 	}
 
@@ -1118,7 +984,7 @@ INSTRUCTIONS:
 Return ONLY valid JSON:
 {"mechanicalError": "string", "tacticalCue": "string", "regression": null or "string"}`, req.MovementName, req.UserFeedback)
 
-	raw, err := s.Generate(ctx, prompt)
+	raw, err := s.Generate(ctx, domain.LLMFeatureFormCorrection, prompt)
 	if err != nil {
 		log.Printf("[OLLAMA] Form correction failed: %v", err)
 		return nil
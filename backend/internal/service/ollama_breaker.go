@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// OLLAMA CIRCUIT BREAKER AND RETRIES
+// =============================================================================
+//
+// Every call to Ollama goes through do, which keeps one circuit breaker per
+// Ollama endpoint (/api/generate, /api/tags, ...). A call that still fails
+// after its retries counts against the breaker; after Threshold consecutive
+// failures the circuit opens and calls fail fast with ErrOllamaCircuitOpen, so
+// features drop straight to their fallbacks. Once Cooldown has passed a single
+// half-open probe is let through: success closes the circuit, failure re-opens
+// it for another cooldown. Breakers run on the wall clock, never a SimClock.
+
+// ErrOllamaCircuitOpen is returned without calling Ollama while an endpoint's circuit is open.
+var ErrOllamaCircuitOpen = errors.New("ollama circuit open")
+
+// ollamaBreakerPolicy configures the per-endpoint circuit breakers.
+type ollamaBreakerPolicy struct {
+	Threshold int           // Consecutive failed calls that open a circuit
+	Cooldown  time.Duration // How long a circuit stays open before a half-open probe
+}
+
+var defaultOllamaBreakerPolicy = ollamaBreakerPolicy{
+	Threshold: 3,
+	Cooldown:  30 * time.Second,
+}
+
+// ollamaRetryPolicy bounds retries of transient failures within one call.
+type ollamaRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration // Backoff before the second attempt; doubles after that
+	MaxDelay    time.Duration
+}
+
+var defaultOllamaRetryPolicy = ollamaRetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// backoff returns a full-jitter delay before the given retry (1 = first retry).
+func (p ollamaRetryPolicy) backoff(retry int) time.Duration {
+	ceiling := p.BaseDelay << (retry - 1)
+	if ceiling > p.MaxDelay || ceiling <= 0 {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+// CircuitState is an Ollama endpoint's breaker state.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Calls go through
+	CircuitOpen     CircuitState = "open"      // Calls fail fast
+	CircuitHalfOpen CircuitState = "half_open" // One probe call is in flight
+)
+
+// OllamaCircuitStats reports one endpoint's breaker state and transition counts.
+type OllamaCircuitStats struct {
+	Endpoint            string       `json:"endpoint"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	Opens               int64        `json:"opens"`     // closed/half-open -> open
+	HalfOpens           int64        `json:"halfOpens"` // open -> half-open probes
+	Closes              int64        `json:"closes"`    // half-open -> closed
+	Rejected            int64        `json:"rejected"`  // Calls failed fast while open
+	Retries             int64        `json:"retries"`   // Extra attempts after transient failures
+	LastTransitionAt    *time.Time   `json:"lastTransitionAt,omitempty"`
+	OpenUntil           *time.Time   `json:"openUntil,omitempty"`
+}
+
+// circuitBreaker tracks one endpoint. Safe for concurrent use.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	policy   ollamaBreakerPolicy
+	stats    OllamaCircuitStats
+	openedAt time.Time
+}
+
+func newCircuitBreaker(endpoint string, policy ollamaBreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{policy: policy, stats: OllamaCircuitStats{Endpoint: endpoint, State: CircuitClosed}}
+}
+
+// transition moves the breaker to a new state and counts it. Callers must hold b.mu.
+func (b *circuitBreaker) transition(to CircuitState, now time.Time) {
+	from := b.stats.State
+	b.stats.State = to
+	b.stats.LastTransitionAt = &now
+	switch to {
+	case CircuitOpen:
+		b.stats.Opens++
+		b.openedAt = now
+	case CircuitHalfOpen:
+		b.stats.HalfOpens++
+	case CircuitClosed:
+		b.stats.Closes++
+	}
+	log.Printf("[OLLAMA] Circuit %s: %s -> %s", b.stats.Endpoint, from, to)
+}
+
+// allow reports whether a call may go out now. An open circuit past its
+// cooldown lets exactly one call through as the half-open probe.
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.stats.State {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.policy.Cooldown {
+			b.stats.Rejected++
+			return fmt.Errorf("%w for %s", ErrOllamaCircuitOpen, b.stats.Endpoint)
+		}
+		b.transition(CircuitHalfOpen, now)
+		return nil
+	case CircuitHalfOpen:
+		// The probe is still in flight
+		b.stats.Rejected++
+		return fmt.Errorf("%w for %s", ErrOllamaCircuitOpen, b.stats.Endpoint)
+	}
+	return nil
+}
+
+// record applies a call's final outcome.
+func (b *circuitBreaker) record(success bool, retries int, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stats.Retries += int64(retries)
+	if success {
+		b.stats.ConsecutiveFailures = 0
+		if b.stats.State != CircuitClosed {
+			b.transition(CircuitClosed, now)
+		}
+		return
+	}
+
+	b.stats.ConsecutiveFailures++
+	switch {
+	case b.stats.State == CircuitHalfOpen:
+		b.transition(CircuitOpen, now)
+	case b.stats.State == CircuitClosed && b.stats.ConsecutiveFailures >= b.policy.Threshold:
+		b.transition(CircuitOpen, now)
+	}
+}
+
+// abandon handles a call the caller cancelled: it says nothing about Ollama,
+// so a cancelled half-open probe reopens the circuit for an immediate re-probe.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stats.State == CircuitHalfOpen {
+		b.stats.State = CircuitOpen
+	}
+}
+
+func (b *circuitBreaker) snapshot() OllamaCircuitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := b.stats
+	if stats.State == CircuitOpen {
+		until := b.openedAt.Add(b.policy.Cooldown)
+		stats.OpenUntil = &until
+	}
+	return stats
+}
+
+// breaker returns the circuit breaker for an Ollama endpoint, creating it on first use.
+func (s *OllamaService) breaker(endpoint string) *circuitBreaker {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+	b, ok := s.breakers[endpoint]
+	if !ok {
+		b = newCircuitBreaker(endpoint, s.breakerPolicy)
+		s.breakers[endpoint] = b
+	}
+	return b
+}
+
+// CircuitStats returns every endpoint's breaker state, sorted by endpoint.
+func (s *OllamaService) CircuitStats() []OllamaCircuitStats {
+	s.breakersMu.Lock()
+	breakers := make([]*circuitBreaker, 0, len(s.breakers))
+	for _, b := range s.breakers {
+		breakers = append(breakers, b)
+	}
+	s.breakersMu.Unlock()
+
+	stats := make([]OllamaCircuitStats, len(breakers))
+	for i, b := range breakers {
+		stats[i] = b.snapshot()
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Endpoint < stats[j].Endpoint })
+	return stats
+}
+
+// isTransientStatus reports Ollama statuses worth retrying: overloaded or restarting.
+func isTransientStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do sends a request to an Ollama endpoint through its circuit breaker,
+// retrying transient failures with jittered backoff while ctx allows.
+// A nil client uses s.client. The caller closes the returned body.
+// Non-2xx responses that aren't transient are returned as-is and don't count
+// as failures: Ollama answered.
+func (s *OllamaService) do(ctx context.Context, client *http.Client, method, endpoint string, body []byte) (*http.Response, error) {
+	if client == nil {
+		client = s.client
+	}
+	b := s.breaker(endpoint)
+	if err := b.allow(time.Now()); err != nil {
+		return nil, err
+	}
+
+	var (
+		resp    *http.Response
+		err     error
+		retries int
+	)
+	for {
+		resp, err = s.attempt(ctx, client, method, endpoint, body)
+		transient := err != nil && ctx.Err() == nil
+		if err == nil && isTransientStatus(resp.StatusCode) {
+			transient = true
+			err = fmt.Errorf("ollama returned status %d", resp.StatusCode)
+		}
+		if !transient || retries+1 >= s.retry.MaxAttempts {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+			resp = nil
+		}
+
+		retries++
+		delay := s.retry.backoff(retries)
+		log.Printf("[OLLAMA] %s %s failed (%v), retry %d in %dms", method, endpoint, err, retries, delay.Milliseconds())
+		if !sleepCtx(ctx, delay) {
+			err = ctx.Err()
+			break
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		b.abandon()
+	} else {
+		b.record(err == nil, retries, time.Now())
+	}
+	if err != nil && resp != nil {
+		// Out of retries on a transient status: hand back the response for the caller's status check
+		return resp, nil
+	}
+	return resp, err
+}
+
+// sleepCtx waits for d, returning false if ctx is done first.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (s *OllamaService) attempt(ctx context.Context, client *http.Client, method, endpoint string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return client.Do(req)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The breaker decides when every LLM feature fails fast to its
+// fallback and when it tries Ollama again; tests drive a fake Ollama through
+// failure, cooldown and recovery to pin the state machine and retry policy.
+type OllamaBreakerSuite struct {
+	suite.Suite
+	status atomic.Int32 // Status the fake /api/generate answers with
+	hits   atomic.Int32
+	server *httptest.Server
+	ollama *OllamaService
+}
+
+func TestOllamaBreakerSuite(t *testing.T) {
+	suite.Run(t, new(OllamaBreakerSuite))
+}
+
+func (s *OllamaBreakerSuite) SetupTest() {
+	s.status.Store(http.StatusOK)
+	s.hits.Store(0)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/generate", func(w http.ResponseWriter, r *http.Request) {
+		s.hits.Add(1)
+		w.WriteHeader(int(s.status.Load()))
+		w.Write([]byte(`{"response":"ok"}`))
+	})
+	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"models":[]}`))
+	})
+	s.server = httptest.NewServer(mux)
+
+	s.ollama = NewOllamaService(s.server.URL)
+	s.ollama.breakerPolicy = ollamaBreakerPolicy{Threshold: 2, Cooldown: 50 * time.Millisecond}
+	s.ollama.retry = ollamaRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+}
+
+func (s *OllamaBreakerSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *OllamaBreakerSuite) generate() error {
+	_, err := s.ollama.Generate(context.Background(), domain.LLMFeatureDayInsight, "prompt")
+	return err
+}
+
+func (s *OllamaBreakerSuite) circuit(endpoint string) OllamaCircuitStats {
+	for _, stats := range s.ollama.CircuitStats() {
+		if stats.Endpoint == endpoint {
+			return stats
+		}
+	}
+	s.FailNow("no circuit for " + endpoint)
+	return OllamaCircuitStats{}
+}
+
+func (s *OllamaBreakerSuite) TestRetriesTransientStatus() {
+	s.status.Store(http.StatusServiceUnavailable)
+
+	s.Error(s.generate())
+	s.Equal(int32(3), s.hits.Load(), "bounded to MaxAttempts")
+	s.Equal(int64(2), s.circuit("/api/generate").Retries)
+	s.Equal(1, s.circuit("/api/generate").ConsecutiveFailures)
+}
+
+func (s *OllamaBreakerSuite) TestClientErrorsAreNotRetriedOrCounted() {
+	s.status.Store(http.StatusNotFound) // e.g. model not installed
+
+	s.Error(s.generate())
+	s.Error(s.generate())
+	s.Error(s.generate())
+
+	s.Equal(int32(3), s.hits.Load())
+	s.Equal(CircuitClosed, s.circuit("/api/generate").State, "Ollama answered, so it is up")
+}
+
+func (s *OllamaBreakerSuite) TestOpensAfterThresholdAndRecovers() {
+	s.status.Store(http.StatusServiceUnavailable)
+	s.Error(s.generate())
+	s.Error(s.generate())
+	s.Equal(CircuitOpen, s.circuit("/api/generate").State)
+
+	hits := s.hits.Load()
+	err := s.generate()
+	s.ErrorIs(err, ErrOllamaCircuitOpen)
+	s.Equal(hits, s.hits.Load(), "open circuit fails fast without calling Ollama")
+
+	s.Run("other endpoints are unaffected", func() {
+		s.True(s.ollama.IsAvailable(context.Background()))
+		s.Equal(CircuitClosed, s.circuit("/api/tags").State)
+	})
+
+	s.Run("failed half-open probe reopens", func() {
+		time.Sleep(60 * time.Millisecond)
+		s.Error(s.generate())
+		stats := s.circuit("/api/generate")
+		s.Equal(CircuitOpen, stats.State)
+		s.Equal(int64(2), stats.Opens)
+		s.NotNil(stats.OpenUntil)
+	})
+
+	s.Run("successful probe closes", func() {
+		s.status.Store(http.StatusOK)
+		time.Sleep(60 * time.Millisecond)
+		s.NoError(s.generate())
+
+		stats := s.circuit("/api/generate")
+		s.Equal(CircuitClosed, stats.State)
+		s.Equal(int64(2), stats.HalfOpens)
+		s.Equal(int64(1), stats.Closes)
+		s.Equal(int64(1), stats.Rejected)
+		s.Zero(stats.ConsecutiveFailures)
+	})
+}
+
+func (s *OllamaBreakerSuite) TestConnectionFailuresFallBack() {
+	s.server.Close()
+
+	s.Equal(generateFallbackName([]string{"Eggs"}), s.ollama.GenerateRecipeName(context.Background(), []string{"Eggs"}))
+	s.Nil(s.ollama.GenerateFormCorrection(context.Background(), domain.FormCorrectionRequest{MovementName: "Squat", UserFeedback: "knees caved"}))
+	s.Equal(CircuitOpen, s.circuit("/api/generate").State)
+}
+
+func (s *OllamaBreakerSuite) TestCancelledCallsDontCount() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.ollama.Generate(ctx, domain.LLMFeatureDayInsight, "prompt")
+	s.ErrorIs(err, context.Canceled)
+	_, err = s.ollama.Generate(ctx, domain.LLMFeatureDayInsight, "prompt")
+	s.ErrorIs(err, context.Canceled)
+
+	s.Equal(CircuitClosed, s.circuit("/api/generate").State)
+	s.Zero(s.circuit("/api/generate").ConsecutiveFailures)
+}
+
+func (s *OllamaBreakerSuite) TestBackoffIsBounded() {
+	policy := ollamaRetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for retry := 1; retry <= 4; retry++ {
+		for i := 0; i < 20; i++ {
+			delay := policy.backoff(retry)
+			s.GreaterOrEqual(delay, time.Duration(0))
+			s.LessOrEqual(delay, min(policy.BaseDelay<<(retry-1), policy.MaxDelay))
+		}
+	}
+}
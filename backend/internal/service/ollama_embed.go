@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"victus/internal/domain"
+)
+
+type ollamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaEmbedResponse struct {
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	TotalDuration   int64       `json:"total_duration"` // Nanoseconds
+}
+
+// Embed returns one embedding per input text from Ollama's /api/embed, plus
+// the model that produced them. Vectors from different models aren't
+// comparable, so callers store the model alongside. Budgets and usage
+// accounting apply as for generate calls.
+func (s *OllamaService) Embed(ctx context.Context, feature domain.LLMFeature, texts []string) ([][]float32, string, error) {
+	model := s.ModelFor(OllamaTaskEmbed)
+	if len(texts) == 0 {
+		return nil, model, nil
+	}
+	if err := s.checkBudget(ctx, feature); err != nil {
+		return nil, model, err
+	}
+
+	body, err := json.Marshal(ollamaEmbedRequest{Model: model, Input: texts})
+	if err != nil {
+		return nil, model, err
+	}
+
+	start := time.Now()
+	usage := domain.LLMUsage{Feature: feature, Model: model, CreatedAt: start}
+	resp, err := s.do(ctx, nil, http.MethodPost, "/api/embed", body)
+	if errors.Is(err, ErrOllamaCircuitOpen) {
+		return nil, model, err // Never reached Ollama; nothing to account
+	}
+	defer func() {
+		if usage.DurationMs == 0 {
+			usage.DurationMs = time.Since(start).Milliseconds()
+		}
+		s.recordUsage(ctx, usage)
+	}()
+	if err != nil {
+		return nil, model, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, model, fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	var result ollamaEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, model, err
+	}
+	if len(result.Embeddings) != len(texts) {
+		return nil, model, fmt.Errorf("ollama returned %d embeddings for %d inputs", len(result.Embeddings), len(texts))
+	}
+	usage.Success = true
+	usage.PromptTokens = result.PromptEvalCount
+	usage.DurationMs = result.TotalDuration / int64(time.Millisecond)
+
+	return result.Embeddings, model, nil
+}
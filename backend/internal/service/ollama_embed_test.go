@@ -0,0 +1,80 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"victus/internal/domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Embeddings are only comparable within one model, so the
+// model Embed reports must be the one that produced the vectors; tests pin
+// that and the batch shape against a fake Ollama.
+type OllamaEmbedSuite struct {
+	suite.Suite
+	lastModel string
+	short     bool // Answer with one embedding fewer than requested
+	server    *httptest.Server
+	ollama    *OllamaService
+}
+
+func TestOllamaEmbedSuite(t *testing.T) {
+	suite.Run(t, new(OllamaEmbedSuite))
+}
+
+func (s *OllamaEmbedSuite) SetupTest() {
+	s.short = false
+	s.lastModel = ""
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/embed", func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaEmbedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		s.lastModel = req.Model
+		n := len(req.Input)
+		if s.short {
+			n--
+		}
+		embeddings := make([][]float32, n)
+		for i := range embeddings {
+			embeddings[i] = []float32{float32(i), 0.5, -0.25}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings, "prompt_eval_count": 12})
+	})
+	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"models":[{"name":"llama3.2:latest","size":2000000000},{"name":"nomic-embed-text:latest","size":270000000}]}`))
+	})
+	s.server = httptest.NewServer(mux)
+	s.ollama = NewOllamaService(s.server.URL)
+}
+
+func (s *OllamaEmbedSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *OllamaEmbedSuite) TestEmbedsBatchWithEmbeddingModel() {
+	_, err := s.ollama.DetectModels(s.T().Context())
+	s.Require().NoError(err)
+
+	vectors, model, err := s.ollama.Embed(s.T().Context(), domain.LLMFeatureSemanticIndex, []string{"oats", "whey"})
+	s.Require().NoError(err)
+	s.Equal("nomic-embed-text", model, "dedicated embedding model preferred over the larger chat model")
+	s.Equal(model, s.lastModel)
+	s.Equal([][]float32{{0, 0.5, -0.25}, {1, 0.5, -0.25}}, vectors)
+}
+
+func (s *OllamaEmbedSuite) TestMismatchedBatchIsAnError() {
+	s.short = true
+	_, _, err := s.ollama.Embed(s.T().Context(), domain.LLMFeatureSemanticSearch, []string{"crunchy"})
+	s.Error(err)
+}
+
+func (s *OllamaEmbedSuite) TestEmptyInputSkipsOllama() {
+	vectors, _, err := s.ollama.Embed(s.T().Context(), domain.LLMFeatureSemanticIndex, nil)
+	s.NoError(err)
+	s.Nil(vectors)
+	s.Empty(s.lastModel)
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// OLLAMA MODEL MANAGEMENT
+// =============================================================================
+//
+// Each LLM task has an ordered list of candidate models. The installed models
+// are detected from /api/tags at startup and on every health check, and a task
+// runs on its first installed candidate, falling back to any installed model.
+// Until detection succeeds every task uses defaultOllamaModel.
+
+// defaultOllamaModel is used before model detection has succeeded.
+const defaultOllamaModel = "llama3.2"
+
+// OllamaTask groups LLM calls by the model size they need.
+type OllamaTask string
+
+const (
+	// OllamaTaskParse is structured or short output: echo logs, voice commands, recipe names.
+	OllamaTaskParse OllamaTask = "parse"
+	// OllamaTaskNarrative is prose: debriefs, solver refinements, insights.
+	OllamaTaskNarrative OllamaTask = "narrative"
+	// OllamaTaskEmbed is embeddings for semantic search.
+	OllamaTaskEmbed OllamaTask = "embed"
+)
+
+// ollamaTasks lists the tasks in display order.
+var ollamaTasks = []OllamaTask{OllamaTaskParse, OllamaTaskNarrative, OllamaTaskEmbed}
+
+// defaultOllamaTaskModels prefers a small model for parsing and a larger one
+// for narratives, both falling back to the default model. Embeddings prefer
+// dedicated embedding models; chat models can embed too, just less well.
+var defaultOllamaTaskModels = map[OllamaTask][]string{
+	OllamaTaskParse:     {"llama3.2:1b", defaultOllamaModel},
+	OllamaTaskNarrative: {"llama3.1:8b", defaultOllamaModel},
+	OllamaTaskEmbed:     {"nomic-embed-text", "mxbai-embed-large", defaultOllamaModel},
+}
+
+// OllamaModel is a model installed in Ollama.
+type OllamaModel struct {
+	Name       string    `json:"name"`
+	SizeBytes  int64     `json:"sizeBytes"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// OllamaTaskModel is a task's candidate models and the one it currently runs on.
+type OllamaTaskModel struct {
+	Task       OllamaTask `json:"task"`
+	Candidates []string   `json:"candidates"`
+	Model      string     `json:"model"`
+	Installed  bool       `json:"installed"` // False when no candidate or fallback is installed
+}
+
+// OllamaPullProgress reports a model download started through PullModel.
+type OllamaPullProgress struct {
+	Model          string     `json:"model"`
+	Status         string     `json:"status"` // Ollama's latest status line, e.g. "pulling manifest"
+	CompletedBytes int64      `json:"completedBytes"`
+	TotalBytes     int64      `json:"totalBytes"`
+	Percent        float64    `json:"percent"`
+	Done           bool       `json:"done"`
+	Error          string     `json:"error,omitempty"`
+	StartedAt      time.Time  `json:"startedAt"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+}
+
+// OllamaModelStatus is the model management view for the admin endpoint.
+type OllamaModelStatus struct {
+	Detected   bool                 `json:"detected"` // False until /api/tags has been read once
+	DetectedAt *time.Time           `json:"detectedAt,omitempty"`
+	Installed  []OllamaModel        `json:"installed"`
+	Tasks      []OllamaTaskModel    `json:"tasks"`
+	Pulls      []OllamaPullProgress `json:"pulls"`
+}
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name       string    `json:"name"`
+		Size       int64     `json:"size"`
+		ModifiedAt time.Time `json:"modified_at"`
+	} `json:"models"`
+}
+
+type ollamaPullRequest struct {
+	Model  string `json:"model"`
+	Stream bool   `json:"stream"`
+}
+
+type ollamaPullLine struct {
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// ParseOllamaModelList splits a comma-separated model list, e.g. from
+// OLLAMA_PARSE_MODELS. Blank entries are dropped.
+func ParseOllamaModelList(s string) []string {
+	var models []string
+	for _, m := range strings.Split(s, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// normalizeOllamaModel adds the implicit ":latest" tag so "llama3.2" matches
+// Ollama's "llama3.2:latest".
+func normalizeOllamaModel(name string) string {
+	if !strings.Contains(name, ":") {
+		return name + ":latest"
+	}
+	return name
+}
+
+// SetTaskModels overrides the candidate models for a task, most preferred first.
+// An empty list keeps the current candidates.
+func (s *OllamaService) SetTaskModels(task OllamaTask, candidates []string) {
+	if len(candidates) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskModels[task] = candidates
+}
+
+// ModelFor returns the model a task should run on: its first installed
+// candidate, else any installed model, else its first candidate.
+func (s *OllamaService) ModelFor(task OllamaTask) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	model, _ := s.resolveModelLocked(task)
+	return model
+}
+
+// resolveModelLocked resolves a task's model and whether it is installed.
+// Callers must hold s.mu.
+func (s *OllamaService) resolveModelLocked(task OllamaTask) (string, bool) {
+	if s.installed == nil {
+		return defaultOllamaModel, false
+	}
+	candidates := s.taskModels[task]
+	for _, c := range candidates {
+		if s.installed[normalizeOllamaModel(c)] {
+			return c, true
+		}
+	}
+	if len(s.installedOrder) > 0 {
+		return s.installedOrder[0].Name, true
+	}
+	if len(candidates) > 0 {
+		return candidates[0], false
+	}
+	return defaultOllamaModel, false
+}
+
+// DetectModels reads the installed models from Ollama's /api/tags.
+func (s *OllamaService) DetectModels(ctx context.Context) ([]OllamaModel, error) {
+	detectCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := s.do(detectCtx, nil, http.MethodGet, "/api/tags", nil)
+	if err != nil {
+		log.Printf("[OLLAMA] Model detection failed: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	var tags ollamaTagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, err
+	}
+	models := s.recordInstalled(tags)
+
+	names := make([]string, len(models))
+	for i, m := range models {
+		names[i] = m.Name
+	}
+	log.Printf("[OLLAMA] Detected %d models: %s (parse=%s, narrative=%s)",
+		len(models), strings.Join(names, ", "), s.ModelFor(OllamaTaskParse), s.ModelFor(OllamaTaskNarrative))
+	return models, nil
+}
+
+// recordInstalled replaces the installed model list with a /api/tags response.
+// Models are kept largest first, so the any-installed fallback picks the most capable.
+func (s *OllamaService) recordInstalled(tags ollamaTagsResponse) []OllamaModel {
+	models := make([]OllamaModel, len(tags.Models))
+	installed := make(map[string]bool, len(tags.Models))
+	for i, m := range tags.Models {
+		models[i] = OllamaModel{Name: m.Name, SizeBytes: m.Size, ModifiedAt: m.ModifiedAt}
+		installed[normalizeOllamaModel(m.Name)] = true
+	}
+	sort.SliceStable(models, func(i, j int) bool { return models[i].SizeBytes > models[j].SizeBytes })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.installed = installed
+	s.installedOrder = models
+	s.detectedAt = time.Now()
+	return models
+}
+
+// ModelStatus returns installed models, the task mapping and pull progress.
+func (s *OllamaService) ModelStatus() OllamaModelStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := OllamaModelStatus{
+		Detected:  s.installed != nil,
+		Installed: append([]OllamaModel{}, s.installedOrder...),
+		Tasks:     make([]OllamaTaskModel, 0, len(ollamaTasks)),
+		Pulls:     make([]OllamaPullProgress, 0, len(s.pulls)),
+	}
+	if status.Detected {
+		detectedAt := s.detectedAt
+		status.DetectedAt = &detectedAt
+	}
+	for _, task := range ollamaTasks {
+		model, installed := s.resolveModelLocked(task)
+		status.Tasks = append(status.Tasks, OllamaTaskModel{
+			Task:       task,
+			Candidates: append([]string{}, s.taskModels[task]...),
+			Model:      model,
+			Installed:  installed,
+		})
+	}
+	for _, p := range s.pulls {
+		status.Pulls = append(status.Pulls, *p)
+	}
+	sort.Slice(status.Pulls, func(i, j int) bool { return status.Pulls[i].StartedAt.After(status.Pulls[j].StartedAt) })
+	return status
+}
+
+// PullModel starts downloading a model in the background and returns its
+// progress so far. Pulling a model that is already downloading returns the
+// running pull. Progress is reported through ModelStatus.
+func (s *OllamaService) PullModel(model string) OllamaPullProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.pulls[model]; ok && !p.Done {
+		return *p
+	}
+	p := &OllamaPullProgress{Model: model, Status: "starting", StartedAt: time.Now()}
+	s.pulls[model] = p
+
+	go s.runPull(model)
+	return *p
+}
+
+// runPull streams Ollama's /api/pull progress into the pull's record, then
+// re-detects the installed models. It has no timeout: large models take a while.
+func (s *OllamaService) runPull(model string) {
+	err := s.streamPull(model)
+
+	s.mu.Lock()
+	p := s.pulls[model]
+	now := time.Now()
+	p.Done = true
+	p.FinishedAt = &now
+	if err != nil {
+		p.Error = err.Error()
+		log.Printf("[OLLAMA] Pull of %s failed: %v", model, err)
+	} else {
+		p.Status = "success"
+		p.Percent = 100
+		log.Printf("[OLLAMA] Pulled %s", model)
+	}
+	s.mu.Unlock()
+
+	if err == nil {
+		s.DetectModels(context.Background())
+	}
+}
+
+func (s *OllamaService) streamPull(model string) error {
+	body, err := json.Marshal(ollamaPullRequest{Model: model, Stream: true})
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(context.Background(), s.pullClient, http.MethodPost, "/api/pull", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line ollamaPullLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		if line.Error != "" {
+			return fmt.Errorf("%s", line.Error)
+		}
+		s.recordPullLine(model, line)
+	}
+	return scanner.Err()
+}
+
+// recordPullLine applies one streamed progress line to the pull's record.
+func (s *OllamaService) recordPullLine(model string, line ollamaPullLine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.pulls[model]
+	p.Status = line.Status
+	if line.Total > 0 {
+		p.TotalBytes = line.Total
+		p.CompletedBytes = line.Completed
+		p.Percent = float64(line.Completed) / float64(line.Total) * 100
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Task-to-model resolution and pull progress decide which LLM
+// every feature runs on; tests pin the fallback ordering against a fake Ollama
+// without needing a real model server.
+type OllamaModelsSuite struct {
+	suite.Suite
+	mu        sync.Mutex
+	installed []string
+	server    *httptest.Server
+	ollama    *OllamaService
+}
+
+func TestOllamaModelsSuite(t *testing.T) {
+	suite.Run(t, new(OllamaModelsSuite))
+}
+
+func (s *OllamaModelsSuite) SetupTest() {
+	s.installed = nil
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		models := make([]map[string]any, len(s.installed))
+		for i, name := range s.installed {
+			models[i] = map[string]any{"name": name, "size": int64(i+1) << 30, "modified_at": time.Now()}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"models": models})
+	})
+	mux.HandleFunc("POST /api/pull", func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaPullRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model == "missing:404" {
+			fmt.Fprintln(w, `{"error":"pull model manifest: file does not exist"}`)
+			return
+		}
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+		fmt.Fprintln(w, `{"status":"pulling abc","total":1000,"completed":500}`)
+		fmt.Fprintln(w, `{"status":"success"}`)
+		s.mu.Lock()
+		s.installed = append(s.installed, req.Model)
+		s.mu.Unlock()
+	})
+	s.server = httptest.NewServer(mux)
+	s.ollama = NewOllamaService(s.server.URL)
+}
+
+func (s *OllamaModelsSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *OllamaModelsSuite) detect(models ...string) {
+	s.mu.Lock()
+	s.installed = models
+	s.mu.Unlock()
+	_, err := s.ollama.DetectModels(s.T().Context())
+	s.Require().NoError(err)
+}
+
+func (s *OllamaModelsSuite) TestDefaultModelBeforeDetection() {
+	s.Equal(defaultOllamaModel, s.ollama.ModelFor(OllamaTaskParse))
+	s.Equal(defaultOllamaModel, s.ollama.ModelFor(OllamaTaskNarrative))
+	s.False(s.ollama.ModelStatus().Detected)
+}
+
+func (s *OllamaModelsSuite) TestTaskUsesFirstInstalledCandidate() {
+	s.detect("llama3.2:latest", "llama3.1:8b")
+
+	s.Equal("llama3.2", s.ollama.ModelFor(OllamaTaskParse), "untagged candidates match :latest")
+	s.Equal("llama3.1:8b", s.ollama.ModelFor(OllamaTaskNarrative))
+}
+
+func (s *OllamaModelsSuite) TestFallbackOrdering() {
+	s.ollama.SetTaskModels(OllamaTaskParse, ParseOllamaModelList(" qwen2.5:0.5b, ,phi3 "))
+	s.ollama.SetTaskModels(OllamaTaskNarrative, nil)
+
+	s.Run("any installed model, largest first", func() {
+		s.detect("mistral:7b", "gemma2:9b")
+		s.Equal("gemma2:9b", s.ollama.ModelFor(OllamaTaskParse))
+		s.Equal("gemma2:9b", s.ollama.ModelFor(OllamaTaskNarrative), "empty override keeps the defaults")
+	})
+
+	s.Run("nothing installed", func() {
+		s.detect()
+		status := s.ollama.ModelStatus()
+		s.True(status.Detected)
+		s.Require().Len(status.Tasks, 3)
+		s.Equal(OllamaTaskModel{
+			Task:       OllamaTaskParse,
+			Candidates: []string{"qwen2.5:0.5b", "phi3"},
+			Model:      "qwen2.5:0.5b",
+			Installed:  false,
+		}, status.Tasks[0])
+	})
+}
+
+func (s *OllamaModelsSuite) TestHealthCheckRefreshesModels() {
+	s.mu.Lock()
+	s.installed = []string{"llama3.1:8b"}
+	s.mu.Unlock()
+
+	s.Require().True(s.ollama.IsAvailable(s.T().Context()))
+	s.Equal("llama3.1:8b", s.ollama.ModelFor(OllamaTaskNarrative))
+}
+
+func (s *OllamaModelsSuite) TestPullReportsProgress() {
+	s.detect("llama3.2:latest")
+
+	started := s.ollama.PullModel("llama3.1:8b")
+	s.Equal("llama3.1:8b", started.Model)
+	s.False(started.Done)
+
+	s.Eventually(func() bool {
+		pulls := s.ollama.ModelStatus().Pulls
+		return len(pulls) == 1 && pulls[0].Done
+	}, 2*time.Second, 10*time.Millisecond)
+
+	pull := s.ollama.ModelStatus().Pulls[0]
+	s.Equal("success", pull.Status)
+	s.Equal(int64(1000), pull.TotalBytes)
+	s.Equal(100.0, pull.Percent)
+	s.Empty(pull.Error)
+	s.Eventually(func() bool {
+		return s.ollama.ModelFor(OllamaTaskNarrative) == "llama3.1:8b"
+	}, 2*time.Second, 10*time.Millisecond, "installed models are re-detected after the pull")
+}
+
+func (s *OllamaModelsSuite) TestPullFailure() {
+	s.ollama.PullModel("missing:404")
+
+	s.Eventually(func() bool {
+		pulls := s.ollama.ModelStatus().Pulls
+		return len(pulls) == 1 && pulls[0].Done
+	}, 2*time.Second, 10*time.Millisecond)
+
+	pull := s.ollama.ModelStatus().Pulls[0]
+	s.Contains(pull.Error, "file does not exist")
+	s.NotNil(pull.FinishedAt)
+}
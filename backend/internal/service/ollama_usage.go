@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ErrLLMBudgetExceeded is returned without calling Ollama once a feature has
+// used up its daily budget.
+var ErrLLMBudgetExceeded = errors.New("llm daily budget exceeded")
+
+// SetUsageStore enables per-call usage accounting and daily budgets.
+func (s *OllamaService) SetUsageStore(usage *store.LLMUsageStore) {
+	s.usage = usage
+}
+
+// SetBudgets sets the daily per-feature budgets. Budgets need a usage store.
+func (s *OllamaService) SetBudgets(budgets map[domain.LLMFeature]domain.LLMBudget) {
+	s.budgets = budgets
+}
+
+// startOfDay is midnight today, local time: when daily budgets reset.
+// Budgets protect the real GPU, so they run on the wall clock.
+func startOfDay(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+}
+
+// checkBudget returns ErrLLMBudgetExceeded when the feature is over its daily budget.
+// Accounting errors never block a call.
+func (s *OllamaService) checkBudget(ctx context.Context, feature domain.LLMFeature) error {
+	budget, ok := s.budgets[feature]
+	if !ok || s.usage == nil {
+		return nil
+	}
+	today, err := s.usage.TotalsSince(ctx, feature, startOfDay(time.Now()))
+	if err != nil {
+		log.Printf("[OLLAMA] Budget check for %s failed: %v", feature, err)
+		return nil
+	}
+	if !budget.Allows(today) {
+		return fmt.Errorf("%w for %s (%d calls, %ds compute today)", ErrLLMBudgetExceeded, feature, today.Calls, today.ComputeMs/1000)
+	}
+	return nil
+}
+
+// recordUsage stores a call's usage. It runs after the caller's timeout may
+// have fired, so it doesn't inherit cancellation.
+func (s *OllamaService) recordUsage(ctx context.Context, u domain.LLMUsage) {
+	if s.usage == nil {
+		return
+	}
+	if err := s.usage.Record(context.WithoutCancel(ctx), u); err != nil {
+		log.Printf("[OLLAMA] Recording usage for %s failed: %v", u.Feature, err)
+	}
+}
+
+// complete runs one /api/generate call for a feature: budget check, the call
+// itself (through the circuit breaker), and usage accounting. Returns the
+// trimmed response text.
+func (s *OllamaService) complete(ctx context.Context, feature domain.LLMFeature, model, prompt string) (string, error) {
+	if err := s.checkBudget(ctx, feature); err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(ollamaRequest{Model: model, Prompt: prompt, Stream: false})
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	usage := domain.LLMUsage{Feature: feature, Model: model, CreatedAt: start}
+	resp, err := s.do(ctx, nil, http.MethodPost, "/api/generate", body)
+	if errors.Is(err, ErrOllamaCircuitOpen) {
+		return "", err // Never reached Ollama; nothing to account
+	}
+	defer func() {
+		if usage.DurationMs == 0 {
+			usage.DurationMs = time.Since(start).Milliseconds()
+		}
+		s.recordUsage(ctx, usage)
+	}()
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	var result ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	usage.Success = true
+	usage.PromptTokens = result.PromptEvalCount
+	usage.CompletionTokens = result.EvalCount
+	usage.DurationMs = result.TotalDuration / int64(time.Millisecond)
+
+	return strings.TrimSpace(result.Response), nil
+}
+
+// LLMBudgetStatus is a feature's budget with today's usage against it.
+type LLMBudgetStatus struct {
+	domain.LLMBudget
+	Today     domain.LLMUsageTotals `json:"today"`
+	Exhausted bool                  `json:"exhausted"`
+}
+
+// LLMUsageReport is LLM usage over a period plus today's budget standing.
+type LLMUsageReport struct {
+	Since    time.Time                `json:"since"`
+	Features []domain.LLMUsageSummary `json:"features"`
+	Budgets  []LLMBudgetStatus        `json:"budgets"`
+}
+
+// UsageReport summarizes LLM usage over the last days (including today).
+// Returns an empty report when usage accounting is off.
+func (s *OllamaService) UsageReport(ctx context.Context, days int) (*LLMUsageReport, error) {
+	report := &LLMUsageReport{
+		Since:    startOfDay(time.Now()).AddDate(0, 0, -(days - 1)),
+		Features: []domain.LLMUsageSummary{},
+		Budgets:  []LLMBudgetStatus{},
+	}
+	if s.usage == nil {
+		return report, nil
+	}
+
+	features, err := s.usage.SummarizeSince(ctx, report.Since)
+	if err != nil {
+		return nil, err
+	}
+	report.Features = features
+
+	today := startOfDay(time.Now())
+	for _, budget := range s.budgets {
+		totals, err := s.usage.TotalsSince(ctx, budget.Feature, today)
+		if err != nil {
+			return nil, err
+		}
+		report.Budgets = append(report.Budgets, LLMBudgetStatus{
+			LLMBudget: budget,
+			Today:     totals,
+			Exhausted: !budget.Allows(totals),
+		})
+	}
+	sort.Slice(report.Budgets, func(i, j int) bool { return report.Budgets[i].Feature < report.Budgets[j].Feature })
+	return report, nil
+}
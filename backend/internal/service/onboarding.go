@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// OnboardingService guides a new install through setup: basic info, goal,
+// macro ratios and the first plan. The profile is saved once the ratios are
+// answered and the first plan goes through the plan service, so the wizard
+// applies the same validation as the profile and plan endpoints.
+type OnboardingService struct {
+	store          *store.OnboardingStore
+	profileStore   *store.ProfileStore
+	profileService *ProfileService
+	planService    *NutritionPlanService
+}
+
+// NewOnboardingService creates a new OnboardingService.
+func NewOnboardingService(
+	onboardingStore *store.OnboardingStore,
+	profileStore *store.ProfileStore,
+	profileService *ProfileService,
+	planService *NutritionPlanService,
+) *OnboardingService {
+	return &OnboardingService{
+		store:          onboardingStore,
+		profileStore:   profileStore,
+		profileService: profileService,
+		planService:    planService,
+	}
+}
+
+// Get returns the wizard's progress. An install that has a profile but never
+// went through onboarding counts as complete; otherwise onboarding starts at
+// the basic info step.
+func (s *OnboardingService) Get(ctx context.Context, now time.Time) (*domain.OnboardingState, error) {
+	state, err := s.store.Get(ctx)
+	if !errors.Is(err, store.ErrOnboardingNotFound) {
+		return state, err
+	}
+
+	_, err = s.profileStore.Get(ctx)
+	if err == nil {
+		return domain.CompletedOnboardingState(now), nil
+	}
+	if !errors.Is(err, store.ErrProfileNotFound) {
+		return nil, err
+	}
+	return domain.NewOnboardingState(now), nil
+}
+
+// SubmitBasicInfo answers the basic info step. Answering it again clears the
+// later steps.
+// Returns domain.ErrOnboardingComplete once onboarding has finished.
+func (s *OnboardingService) SubmitBasicInfo(ctx context.Context, in domain.OnboardingBasicInfo, now time.Time) (*domain.OnboardingState, error) {
+	return s.submit(ctx, now, func(state *domain.OnboardingState) error {
+		return state.SubmitBasicInfo(in, now)
+	})
+}
+
+// SubmitGoal answers the goal step, deriving the unset target, rate and
+// timeframe.
+// Returns domain.ErrOnboardingStepOutOfOrder before the basic info is in.
+func (s *OnboardingService) SubmitGoal(ctx context.Context, in domain.OnboardingGoal, now time.Time) (*domain.OnboardingState, error) {
+	return s.submit(ctx, now, func(state *domain.OnboardingState) error {
+		return state.SubmitGoal(in, now)
+	})
+}
+
+// SubmitRatios answers the ratios step and saves the profile from the
+// answers so far. Profile settings onboarding doesn't ask about keep their
+// current values.
+// Returns domain.ErrOnboardingStepOutOfOrder before the goal is in.
+func (s *OnboardingService) SubmitRatios(ctx context.Context, in domain.OnboardingRatios, now time.Time) (*domain.OnboardingState, error) {
+	return s.submit(ctx, now, func(state *domain.OnboardingState) error {
+		if err := state.SubmitRatios(in, now); err != nil {
+			return err
+		}
+		profile, err := s.profileStore.Get(ctx)
+		if errors.Is(err, store.ErrProfileNotFound) {
+			profile, err = &domain.UserProfile{}, nil
+		}
+		if err != nil {
+			return err
+		}
+		state.ApplyToProfile(profile)
+		_, err = s.profileService.Upsert(ctx, profile, now)
+		return err
+	})
+}
+
+// SubmitFirstPlan creates the first plan, or skips it, and finishes
+// onboarding.
+// Returns domain.ErrOnboardingStepOutOfOrder before the ratios are in, or
+// store.ErrActivePlanExists if a plan is already active.
+func (s *OnboardingService) SubmitFirstPlan(ctx context.Context, in domain.OnboardingPlan, now time.Time) (*domain.OnboardingState, error) {
+	return s.submit(ctx, now, func(state *domain.OnboardingState) error {
+		input, create, err := state.FirstPlanInput(in, now)
+		if err != nil {
+			return err
+		}
+		var planID *int64
+		if create {
+			plan, err := s.planService.Create(ctx, input, now)
+			if err != nil {
+				return err
+			}
+			planID = &plan.ID
+		}
+		state.Complete(planID, now)
+		return nil
+	})
+}
+
+// submit loads the progress, applies a step and saves the result.
+func (s *OnboardingService) submit(ctx context.Context, now time.Time, step func(state *domain.OnboardingState) error) (*domain.OnboardingState, error) {
+	state, err := s.Get(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := step(state); err != nil {
+		return nil, err
+	}
+	if err := s.store.Save(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// PersonalRecordService maintains the personal record registry.
+type PersonalRecordService struct {
+	recordStore   *store.PersonalRecordStore
+	movementStore *store.MovementStore
+	clocked
+}
+
+// NewPersonalRecordService creates a new PersonalRecordService.
+func NewPersonalRecordService(rs *store.PersonalRecordStore, ms *store.MovementStore) *PersonalRecordService {
+	return &PersonalRecordService{recordStore: rs, movementStore: ms}
+}
+
+// GetRegistry returns the current best per movement and kind.
+func (s *PersonalRecordService) GetRegistry(ctx context.Context) ([]domain.PersonalRecord, error) {
+	history, err := s.recordStore.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return domain.BestRecords(history), nil
+}
+
+// GetHistory returns the record history by date, optionally for one movement.
+func (s *PersonalRecordService) GetHistory(ctx context.Context, movementID string) ([]domain.PersonalRecord, error) {
+	return s.recordStore.List(ctx, movementID)
+}
+
+// RecordSet adds any records set by a logged movement set and returns them.
+func (s *PersonalRecordService) RecordSet(ctx context.Context, movementID string, input domain.MovementProgressionInput) ([]domain.PersonalRecord, error) {
+	candidates := domain.RecordCandidatesFromSet(movementID, input, s.now().Format("2006-01-02"))
+	return s.addNewRecords(ctx, movementID, candidates)
+}
+
+// RecordAchievements adds any records among a session echo's achievements
+// and returns them. Achievements that don't name a load, hold or circuit
+// time for a catalog movement are skipped.
+func (s *PersonalRecordService) RecordAchievements(ctx context.Context, achievements []string, sessionID int64) ([]domain.PersonalRecord, error) {
+	if len(achievements) == 0 {
+		return nil, nil
+	}
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	date := s.now().Format("2006-01-02")
+	var candidates []domain.PersonalRecord
+	for _, a := range achievements {
+		if c, ok := domain.ParseAchievementRecord(a, movements, date); ok && c.Validate() == nil {
+			c.SessionID = &sessionID
+			candidates = append(candidates, c)
+		}
+	}
+	return s.addNewRecords(ctx, "", candidates)
+}
+
+// addNewRecords stores the candidates that set a baseline or beat the
+// current best. movementID narrows the history read when all candidates
+// share one movement. Nothing is stored if any candidate is invalid.
+func (s *PersonalRecordService) addNewRecords(ctx context.Context, movementID string, candidates []domain.PersonalRecord) ([]domain.PersonalRecord, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	for _, c := range candidates {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	history, err := s.recordStore.List(ctx, movementID)
+	if err != nil {
+		return nil, err
+	}
+
+	added := domain.SelectNewRecords(candidates, history)
+	for i := range added {
+		if err := s.recordStore.Create(ctx, &added[i]); err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
+}
+
+// AddManual stores a manually entered record, dated today when no date is
+// given. It is kept even when it doesn't beat the current best, so past
+// records can be back-filled; one that does is celebrated.
+// Returns store.ErrMovementNotFound if the movement does not exist.
+func (s *PersonalRecordService) AddManual(ctx context.Context, r domain.PersonalRecord) (*domain.PersonalRecord, error) {
+	r.Source = domain.RecordSourceManual
+	if r.Date == "" {
+		r.Date = s.now().Format("2006-01-02")
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.movementStore.GetByID(ctx, r.MovementID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.recordStore.List(ctx, r.MovementID)
+	if err != nil {
+		return nil, err
+	}
+	if selected := domain.SelectNewRecords([]domain.PersonalRecord{r}, history); len(selected) == 1 {
+		r = selected[0]
+	}
+	if err := s.recordStore.Create(ctx, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Correct updates an entry's value, date and note, for instance after a
+// misparsed echo. Empty date and note keep the current ones.
+func (s *PersonalRecordService) Correct(ctx context.Context, id int64, value float64, date, note string) (*domain.PersonalRecord, error) {
+	r, err := s.recordStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.Value = value
+	if date != "" {
+		r.Date = date
+	}
+	if note != "" {
+		r.Note = note
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.recordStore.Update(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Delete removes an entry; the registry falls back to the next best.
+func (s *PersonalRecordService) Delete(ctx context.Context, id int64) error {
+	return s.recordStore.Delete(ctx, id)
+}
+
+// ListCelebrations returns records whose celebration hasn't been dismissed.
+func (s *PersonalRecordService) ListCelebrations(ctx context.Context) ([]domain.PersonalRecord, error) {
+	return s.recordStore.ListPendingCelebrations(ctx)
+}
+
+// DismissCelebration marks a record's celebration as seen.
+func (s *PersonalRecordService) DismissCelebration(ctx context.Context, id int64) error {
+	return s.recordStore.DismissCelebration(ctx, id)
+}
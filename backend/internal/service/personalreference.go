@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// referenceRangeSource provides the user's personal reference ranges.
+// Implemented by PersonalReferenceService.
+type referenceRangeSource interface {
+	Ranges(ctx context.Context) (domain.PersonalReferenceRanges, error)
+}
+
+// personalReferenceHour is the local hour the nightly recompute runs.
+const personalReferenceHour = 2
+
+// PersonalReferenceService computes percentiles of the user's trailing 90
+// days of HRV, resting heart rate and sleep quality, and stores them for CNS
+// classification, readiness and debriefs to judge days against.
+type PersonalReferenceService struct {
+	store    *store.PersonalReferenceStore
+	logStore *store.DailyLogStore
+	jobs     *JobMonitor
+	clocked
+}
+
+// NewPersonalReferenceService creates a new PersonalReferenceService.
+func NewPersonalReferenceService(rs *store.PersonalReferenceStore, ls *store.DailyLogStore) *PersonalReferenceService {
+	return &PersonalReferenceService{store: rs, logStore: ls}
+}
+
+// SetJobMonitor enables heartbeats for the nightly recompute.
+func (s *PersonalReferenceService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// List returns the stored ranges.
+func (s *PersonalReferenceService) List(ctx context.Context) ([]domain.PersonalReferenceRange, error) {
+	return s.store.List(ctx)
+}
+
+// Ranges returns the stored ranges indexed by metric.
+func (s *PersonalReferenceService) Ranges(ctx context.Context) (domain.PersonalReferenceRanges, error) {
+	ranges, err := s.store.List(ctx)
+	if err != nil {
+		return domain.PersonalReferenceRanges{}, err
+	}
+	return domain.NewPersonalReferenceRanges(ranges), nil
+}
+
+// Recompute rebuilds the ranges from the PersonalReferenceWindowDays ending
+// on now's date. Metrics without enough values lose their stored range, so
+// the generic thresholds apply to them again. Returns the ranges stored.
+func (s *PersonalReferenceService) Recompute(ctx context.Context, now time.Time) ([]domain.PersonalReferenceRange, error) {
+	end := now.Format("2006-01-02")
+	start := now.AddDate(0, 0, -(domain.PersonalReferenceWindowDays - 1)).Format("2006-01-02")
+
+	metrics, err := s.logStore.ListRecoveryMetrics(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	values := map[domain.ReferenceMetric][]float64{
+		domain.ReferenceMetricHRV:          metrics.HRV,
+		domain.ReferenceMetricRestingHR:    metrics.RestingHR,
+		domain.ReferenceMetricSleepQuality: metrics.SleepQuality,
+	}
+
+	stored := []domain.PersonalReferenceRange{}
+	for _, metric := range domain.ReferenceMetrics {
+		r := domain.ComputePersonalReferenceRange(metric, values[metric], start, end)
+		if r == nil {
+			if err := s.store.Delete(ctx, metric); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := s.store.Upsert(ctx, *r, now); err != nil {
+			return nil, err
+		}
+		r.ComputedAt = now
+		stored = append(stored, *r)
+	}
+	return stored, nil
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, recomputing the ranges
+// once at startup and then nightly at personalReferenceHour.
+func (s *PersonalReferenceService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "personal_reference_ranges", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "personal_reference_ranges", time.Now()) {
+			_, err := s.Recompute(ctx, s.now())
+			s.jobs.Beat("personal_reference_ranges", time.Now(), err)
+			if err != nil {
+				log.Printf("personal reference ranges: recompute failed: %v", err)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), personalReferenceHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"victus/internal/domain"
@@ -14,6 +16,28 @@ type NutritionPlanService struct {
 	planStore     *store.NutritionPlanStore
 	profileStore  *store.ProfileStore
 	ollamaService *OllamaService
+	adaptiveData  adaptiveDataLister
+	dayTypes      dayTypeLister
+	jobs          *JobMonitor
+	rollup        planRollup
+	clocked
+}
+
+// planRollup rolls a new plan's weekly actuals up from existing logs.
+// Implemented by WeeklyActualsService.
+type planRollup interface {
+	RollupActivePlan(ctx context.Context, now time.Time) (int, error)
+}
+
+// adaptiveDataLister provides logged weight and intake for kcal factor auto-tuning.
+type adaptiveDataLister interface {
+	ListAdaptiveDataPoints(ctx context.Context, endDate string, maxDays int) ([]domain.AdaptiveDataPoint, error)
+}
+
+// dayTypeLister provides the day types the dashboard shows, so a plan's daily
+// targets follow them. Implemented by PlannedDayTypeStore.
+type dayTypeLister interface {
+	ListDayTypes(ctx context.Context, startDate, endDate string) (map[string]domain.DayType, error)
 }
 
 // NewNutritionPlanService creates a new NutritionPlanService.
@@ -29,26 +53,75 @@ func NewNutritionPlanService(ps *store.NutritionPlanStore, profileStore *store.P
 // Returns store.ErrActivePlanExists if an active plan already exists.
 // Returns store.ErrProfileNotFound if no profile exists.
 func (s *NutritionPlanService) Create(ctx context.Context, input domain.NutritionPlanInput, now time.Time) (*domain.NutritionPlan, error) {
+	plan, err := s.newPlan(ctx, input, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Persist plan and targets
+	err = s.saveWithDailyTargets(ctx, plan, "", func(ctx context.Context) error {
+		planID, err := s.planStore.Create(ctx, plan)
+		plan.ID = planID
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	planID := plan.ID
+	// A plan starting before today already has logs, and its first weeks may
+	// be prorated; roll them up now rather than at the next log change
+	if s.rollup != nil {
+		if _, err := s.rollup.RollupActivePlan(ctx, now); err != nil {
+			log.Printf("plan: weekly actuals rollup failed for new plan %d: %v", planID, err)
+		}
+	}
+
+	// Return fresh copy with IDs populated
+	created, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	created.HistoryWarnings = plan.HistoryWarnings
+	return created, nil
+}
+
+// PreviewCreate validates a new plan and builds its weekly targets without
+// saving it. It fails the same way Create would, including when an active
+// plan already exists.
+func (s *NutritionPlanService) PreviewCreate(ctx context.Context, input domain.NutritionPlanInput, now time.Time) (*domain.NutritionPlan, error) {
+	plan, err := s.newPlan(ctx, input, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.planStore.GetActive(ctx); err == nil {
+		return nil, store.ErrActivePlanExists
+	} else if !errors.Is(err, store.ErrPlanNotFound) {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// newPlan creates and validates a plan with weekly targets from the profile.
+func (s *NutritionPlanService) newPlan(ctx context.Context, input domain.NutritionPlanInput, now time.Time) (*domain.NutritionPlan, error) {
 	// Get profile for TDEE calculations
 	profile, err := s.profileStore.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create and validate plan with weekly targets
 	plan, err := domain.NewNutritionPlan(input, profile, now)
 	if err != nil {
 		return nil, err
 	}
 
-	// Persist plan and targets
-	planID, err := s.planStore.Create(ctx, plan)
+	// Warn when the plan repeats plans abandoned before
+	history, err := s.planStore.ListAll(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	// Return fresh copy with IDs populated
-	return s.planStore.GetByID(ctx, planID)
+	plan.HistoryWarnings = domain.PlanHistoryWarnings(plan, history)
+	return plan, nil
 }
 
 // GetActive retrieves the currently active nutrition plan.
@@ -69,10 +142,13 @@ func (s *NutritionPlanService) Complete(ctx context.Context, id int64) error {
 	return s.planStore.UpdateStatus(ctx, id, domain.PlanStatusCompleted)
 }
 
-// Abandon marks a plan as abandoned.
+// Abandon marks a plan as abandoned and records why. The survey may be empty.
 // Returns store.ErrPlanNotFound if plan doesn't exist.
-func (s *NutritionPlanService) Abandon(ctx context.Context, id int64) error {
-	return s.planStore.UpdateStatus(ctx, id, domain.PlanStatusAbandoned)
+func (s *NutritionPlanService) Abandon(ctx context.Context, id int64, survey domain.PlanExitSurvey) error {
+	if err := survey.Validate(); err != nil {
+		return err
+	}
+	return s.planStore.Abandon(ctx, id, survey)
 }
 
 // Pause marks a plan as paused.
@@ -200,14 +276,311 @@ func (s *NutritionPlanService) Recalibrate(ctx context.Context, id int64, option
 		now,
 	)
 
-	// Atomic update: plan + targets + history record
-	if err := s.planStore.UpdatePlanWithRecalibration(ctx, updatedPlan, record); err != nil {
+	// Atomic update: plan + targets + history record + daily targets
+	err = s.saveWithDailyTargets(ctx, updatedPlan, now.Format("2006-01-02"), func(ctx context.Context) error {
+		return s.planStore.UpdatePlanWithRecalibration(ctx, updatedPlan, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.planStore.GetByID(ctx, id)
+}
+
+// DailyTargets returns a plan's daily targets between start and end
+// (YYYY-MM-DD, inclusive); empty bounds default to the plan's first and last
+// day. Plans saved before daily targets were materialized have theirs
+// generated without being saved.
+// Returns store.ErrPlanNotFound if plan doesn't exist.
+func (s *NutritionPlanService) DailyTargets(ctx context.Context, planID int64, start, end string) ([]domain.DailyPlanTarget, error) {
+	plan, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.WeeklyTargets) == 0 {
+		return []domain.DailyPlanTarget{}, nil
+	}
+	if start == "" {
+		start = plan.WeeklyTargets[0].StartDate.Format("2006-01-02")
+	}
+	if end == "" {
+		end = plan.WeeklyTargets[len(plan.WeeklyTargets)-1].EndDate.Format("2006-01-02")
+	}
+
+	materialized, err := s.planStore.HasDailyTargets(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if materialized {
+		return s.planStore.ListDailyTargets(ctx, planID, start, end)
+	}
+
+	generated, err := s.generateDailyTargets(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]domain.DailyPlanTarget, 0, len(generated))
+	for _, target := range generated {
+		if date := target.Date.Format("2006-01-02"); date >= start && date <= end {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// RefreshDailyTargets re-materializes the active plan's daily targets from
+// from (YYYY-MM-DD), or from today if that is later, after the day types
+// they follow changed. Without an active plan there is nothing to refresh.
+func (s *NutritionPlanService) RefreshDailyTargets(ctx context.Context, from string) error {
+	plan, err := s.planStore.GetActive(ctx)
+	if errors.Is(err, store.ErrPlanNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if today := s.now().Format("2006-01-02"); from < today {
+		from = today
+	}
+	return s.materializeDailyTargets(ctx, plan, from)
+}
+
+// dailyTargetRefresher keeps the active plan's daily targets following the
+// day types they are generated from. Implemented by NutritionPlanService.
+type dailyTargetRefresher interface {
+	RefreshDailyTargets(ctx context.Context, from string) error
+}
+
+// refreshDailyTargets tells r that day types changed from from onwards.
+// Best-effort: the change has been saved, so a failure is only logged.
+func refreshDailyTargets(ctx context.Context, r dailyTargetRefresher, from string) {
+	if r == nil {
+		return
+	}
+	if err := r.RefreshDailyTargets(ctx, from); err != nil {
+		log.Printf("plan: daily targets refresh from %s failed: %v", from, err)
+	}
+}
+
+// saveWithDailyTargets runs save and then materializes the plan's daily
+// targets from from in one transaction, so a failure can't leave the plan's
+// weekly and daily targets disagreeing.
+func (s *NutritionPlanService) saveWithDailyTargets(ctx context.Context, plan *domain.NutritionPlan, from string, save func(ctx context.Context) error) error {
+	return s.planStore.InTx(ctx, func(ctx context.Context) error {
+		if err := save(ctx); err != nil {
+			return err
+		}
+		return s.materializeDailyTargets(ctx, plan, from)
+	})
+}
+
+// materializeDailyTargets saves the daily targets generated from the plan's
+// weekly targets for days from from (YYYY-MM-DD) onwards, so days already
+// lived keep the targets they were planned with. An empty from saves all days.
+// Each day takes the type the dashboard shows for it (logged, else planned),
+// and the default pattern where there is none.
+func (s *NutritionPlanService) materializeDailyTargets(ctx context.Context, plan *domain.NutritionPlan, from string) error {
+	targets, err := s.generateDailyTargets(ctx, plan)
+	if err != nil {
+		return err
+	}
+	return s.planStore.ReplaceDailyTargets(ctx, plan.ID, targets, from)
+}
+
+// generateDailyTargets generates every day of the plan from its weekly
+// targets and the day types over its range.
+func (s *NutritionPlanService) generateDailyTargets(ctx context.Context, plan *domain.NutritionPlan) ([]domain.DailyPlanTarget, error) {
+	var dayTypes map[string]domain.DayType
+	if s.dayTypes != nil && len(plan.WeeklyTargets) > 0 {
+		start := plan.WeeklyTargets[0].StartDate.Format("2006-01-02")
+		end := plan.WeeklyTargets[len(plan.WeeklyTargets)-1].EndDate.Format("2006-01-02")
+		var err error
+		if dayTypes, err = s.dayTypes.ListDayTypes(ctx, start, end); err != nil {
+			return nil, err
+		}
+	}
+	return plan.GenerateDailyTargets(domain.DefaultWeeklyPattern, dayTypes), nil
+}
+
+// RegenerationPreview is the per-week effect of regenerating a plan, without saving it.
+type RegenerationPreview struct {
+	Plan  *domain.NutritionPlan
+	Diffs []domain.WeeklyTargetDiff
+}
+
+// PreviewRegeneration computes how a plan's weekly targets would change.
+// With an option the preview shows that recalibration; without one it shows a
+// regeneration from the current profile. Pinned weeks are kept either way.
+// Returns store.ErrPlanNotFound if plan doesn't exist.
+func (s *NutritionPlanService) PreviewRegeneration(ctx context.Context, id int64, option *domain.RecalibrationOptionType, now time.Time) (*RegenerationPreview, error) {
+	plan, err := s.planStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// ApplyRecalibration modifies the plan it is given
+	updated := plan.Clone()
+	if option != nil {
+		if updated, err = domain.ApplyRecalibration(updated, profile, *option, now); err != nil {
+			return nil, err
+		}
+	} else {
+		updated.WeeklyTargets = domain.RegenerateWeeklyTargets(updated, profile, now)
+	}
+
+	return &RegenerationPreview{
+		Plan:  updated,
+		Diffs: domain.DiffWeeklyTargets(plan.WeeklyTargets, updated.WeeklyTargets),
+	}, nil
+}
+
+// Regenerate rebuilds the plan's weekly targets from the current profile
+// and saves them. Pinned weeks are kept.
+// Returns store.ErrPlanNotFound if plan doesn't exist.
+func (s *NutritionPlanService) Regenerate(ctx context.Context, id int64, now time.Time) (*domain.NutritionPlan, error) {
+	plan, err := s.planStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
 		return nil, err
 	}
 
+	plan.WeeklyTargets = domain.RegenerateWeeklyTargets(plan, profile, now)
+	plan.UpdatedAt = now
+	err = s.saveWithDailyTargets(ctx, plan, now.Format("2006-01-02"), func(ctx context.Context) error {
+		return s.planStore.UpdatePlan(ctx, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return s.planStore.GetByID(ctx, id)
 }
 
+// EditWeeklyTarget applies a manual edit to one week's targets.
+// Edited weeks are pinned so later regenerations keep them.
+// Returns store.ErrPlanNotFound if plan doesn't exist, or a validation error
+// if the week is out of range or the edit is unsafe.
+func (s *NutritionPlanService) EditWeeklyTarget(ctx context.Context, planID int64, weekNumber int, edit domain.WeeklyTargetEdit, now time.Time) (*domain.WeeklyTarget, error) {
+	plan, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := plan.GetWeeklyTarget(weekNumber)
+	if target == nil {
+		return nil, domain.ErrInvalidWeekNumber
+	}
+	if err := target.ApplyEdit(edit, now); err != nil {
+		return nil, err
+	}
+	err = s.saveWithDailyTargets(ctx, plan, now.Format("2006-01-02"), func(ctx context.Context) error {
+		return s.planStore.UpdateWeeklyTarget(ctx, *target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// AutoTuneKcalFactor re-evaluates the active plan's kcal factor when auto-tuning
+// is enabled and an interval has passed. Adjustments regenerate the remaining
+// weekly targets and are logged in recalibration history. Returns nil when the
+// plan was not due or there was not enough logged data.
+func (s *NutritionPlanService) AutoTuneKcalFactor(ctx context.Context, now time.Time) (*domain.KcalFactorTuning, error) {
+	if s.adaptiveData == nil {
+		return nil, nil
+	}
+	plan, err := s.planStore.GetActive(ctx)
+	if errors.Is(err, store.ErrPlanNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !plan.KcalFactorTuneDue(now) {
+		return nil, nil
+	}
+
+	// Window ends yesterday; today's log may not have a weight yet
+	endDate := now.AddDate(0, 0, -1).Format("2006-01-02")
+	points, err := s.adaptiveData.ListAdaptiveDataPoints(ctx, endDate, domain.KcalFactorTuneIntervalDays)
+	if err != nil {
+		return nil, err
+	}
+	tuning := domain.TuneKcalFactor(plan, points, now)
+	if tuning == nil {
+		return nil, nil
+	}
+
+	if !tuning.Adjusted {
+		if err := s.planStore.UpdateKcalFactorTunedAt(ctx, plan.ID, now); err != nil {
+			return nil, err
+		}
+		return tuning, nil
+	}
+
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	before := plan.Clone()
+	domain.ApplyKcalFactorTuning(plan, profile, *tuning, now)
+
+	record := domain.NewRecalibrationRecord(
+		plan.ID, domain.RecalibrationAutoTuneKcalFactor,
+		before, plan,
+		tuning.CurrentWeightKg,
+		plan.GetCurrentWeek(now),
+		now,
+	)
+	record.Details.BeforeKcalFactor = &tuning.BeforeFactor
+	record.Details.AfterKcalFactor = &tuning.AfterFactor
+	record.Details.ObservedTDEE = tuning.ObservedTDEE
+
+	err = s.saveWithDailyTargets(ctx, plan, now.Format("2006-01-02"), func(ctx context.Context) error {
+		return s.planStore.UpdatePlanWithRecalibration(ctx, plan, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tuning, nil
+}
+
+// kcalFactorTuneCheckInterval is how often the auto-tuning job checks whether a plan is due.
+const kcalFactorTuneCheckInterval = 6 * time.Hour
+
+// RunKcalFactorTuneSchedule blocks until ctx is cancelled, checking the active
+// plan for kcal factor auto-tuning every kcalFactorTuneCheckInterval.
+func (s *NutritionPlanService) RunKcalFactorTuneSchedule(ctx context.Context) {
+	ticker := time.NewTicker(kcalFactorTuneCheckInterval)
+	defer ticker.Stop()
+	s.jobs.Start(ctx, "kcal_factor_tune", kcalFactorTuneCheckInterval, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "kcal_factor_tune", s.now()) {
+			tuning, err := s.AutoTuneKcalFactor(ctx, s.now())
+			s.jobs.Beat("kcal_factor_tune", time.Now(), err)
+			if err != nil {
+				log.Printf("plan: kcal factor auto-tune failed: %v", err)
+			} else if tuning != nil && tuning.Adjusted {
+				log.Printf("plan: kcal factor auto-tuned %.1f -> %.1f (observed TDEE %d)", tuning.BeforeFactor, tuning.AfterFactor, tuning.ObservedTDEE)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // ListRecalibrations retrieves recalibration history for a plan.
 func (s *NutritionPlanService) ListRecalibrations(ctx context.Context, planID int64) ([]domain.RecalibrationRecord, error) {
 	if _, err := s.planStore.GetByID(ctx, planID); err != nil {
@@ -221,6 +594,28 @@ func (s *NutritionPlanService) SetOllamaService(os *OllamaService) {
 	s.ollamaService = os
 }
 
+// SetJobMonitor enables heartbeats for the kcal factor auto-tuning job.
+func (s *NutritionPlanService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// SetWeeklyRollup rolls new plans' weekly actuals up from existing logs.
+// This is optional - if not set, actuals fill in as logs change.
+func (s *NutritionPlanService) SetWeeklyRollup(r planRollup) {
+	s.rollup = r
+}
+
+// SetDayTypeLister sets the source of the day types daily targets follow.
+// Without one, every day follows the default weekly pattern.
+func (s *NutritionPlanService) SetDayTypeLister(l dayTypeLister) {
+	s.dayTypes = l
+}
+
+// SetAdaptiveDataLister enables kcal factor auto-tuning from logged weight and intake.
+func (s *NutritionPlanService) SetAdaptiveDataLister(l adaptiveDataLister) {
+	s.adaptiveData = l
+}
+
 // PhaseInsight represents an AI-generated or templated insight for a plan phase.
 type PhaseInsight struct {
 	Insight   string
@@ -239,7 +634,7 @@ func (s *NutritionPlanService) GetPhaseInsight(ctx context.Context, planID int64
 
 	// Use provided week number or default to current week
 	if weekNumber == 0 {
-		weekNumber = plan.GetCurrentWeek(time.Now())
+		weekNumber = plan.GetCurrentWeek(s.now())
 	}
 
 	// Determine current phase
@@ -256,7 +651,7 @@ func (s *NutritionPlanService) GetPhaseInsight(ctx context.Context, planID int64
 		insightCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
 
-		insight, err := s.ollamaService.Generate(insightCtx, prompt)
+		insight, err := s.ollamaService.Generate(insightCtx, domain.LLMFeaturePhaseInsight, prompt)
 		if err == nil && len(insight) > 0 {
 			return &PhaseInsight{
 				Insight:   insight,
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// planCompletionCheckHour is the local hour the nightly check runs, after
+// the weekly actuals rollup.
+const planCompletionCheckHour = 4
+
+// PlanCompletionService completes plans whose final week has passed: it
+// archives their weekly targets with the final actuals, writes a
+// retrospective and offers the next phase.
+type PlanCompletionService struct {
+	planStore       *store.NutritionPlanStore
+	completionStore *store.PlanCompletionStore
+	profileStore    *store.ProfileStore
+	rollup          planRollup
+	notifiers       []notifier
+	jobs            *JobMonitor
+	clocked
+}
+
+// NewPlanCompletionService creates a new PlanCompletionService. Completions
+// go to the digest's delivery channels when any is configured.
+func NewPlanCompletionService(ps *store.NutritionPlanStore, cs *store.PlanCompletionStore, profileStore *store.ProfileStore, rollup planRollup) *PlanCompletionService {
+	return &PlanCompletionService{
+		planStore:       ps,
+		completionStore: cs,
+		profileStore:    profileStore,
+		rollup:          rollup,
+		notifiers:       notifiersFromEnv(),
+	}
+}
+
+// SetJobMonitor enables heartbeats for the nightly check.
+func (s *PlanCompletionService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Complete completes an active or paused plan now, whether or not its final
+// week has passed.
+// Returns store.ErrPlanNotFound if the plan doesn't exist, or
+// domain.ErrPlanNotCompletable if it already ended.
+func (s *PlanCompletionService) Complete(ctx context.Context, planID int64) (*domain.PlanCompletion, error) {
+	plan, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive() && !plan.IsPaused() {
+		return nil, domain.ErrPlanNotCompletable
+	}
+	return s.complete(ctx, plan)
+}
+
+// CompleteDue completes the active plan if its final week has passed.
+// Returns nil when there was nothing to complete.
+func (s *PlanCompletionService) CompleteDue(ctx context.Context) (*domain.PlanCompletion, error) {
+	plan, err := s.planStore.GetActive(ctx)
+	if errors.Is(err, store.ErrPlanNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !plan.PlanCompletionDue(s.now()) {
+		return nil, nil
+	}
+	return s.complete(ctx, plan)
+}
+
+// complete rolls up the plan's final actuals, then archives its weekly
+// targets, saves the retrospective and marks it completed in one
+// transaction. The completion is pushed to the configured channels
+// (best-effort).
+func (s *PlanCompletionService) complete(ctx context.Context, plan *domain.NutritionPlan) (*domain.PlanCompletion, error) {
+	now := s.now()
+	if plan.IsActive() && s.rollup != nil {
+		if _, err := s.rollup.RollupActivePlan(ctx, now); err != nil {
+			return nil, err
+		}
+		var err error
+		if plan, err = s.planStore.GetByID(ctx, plan.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	recalibrations, err := s.planStore.ListRecalibrations(ctx, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	var tolerance float64
+	profile, err := s.profileStore.Get(ctx)
+	if err == nil {
+		tolerance = profile.RecalibrationTolerance
+	} else if !errors.Is(err, store.ErrProfileNotFound) {
+		return nil, err
+	}
+	retrospective := domain.BuildPlanRetrospective(plan, len(recalibrations), tolerance, now)
+
+	err = s.completionStore.InTx(ctx, func(ctx context.Context) error {
+		if err := s.completionStore.ArchiveWeeklyTargets(ctx, plan.ID, plan.WeeklyTargets); err != nil {
+			return err
+		}
+		if err := s.completionStore.SaveRetrospective(ctx, retrospective); err != nil {
+			return err
+		}
+		return s.planStore.UpdateStatus(ctx, plan.ID, domain.PlanStatusCompleted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	completion := &domain.PlanCompletion{
+		Retrospective: retrospective,
+		ArchivedWeeks: plan.WeeklyTargets,
+		NextPhases:    domain.SuggestNextPhases(plan, retrospective, now),
+		PromptPending: true,
+	}
+	title, body := planCompletionMessage(completion)
+	for _, n := range s.notifiers {
+		if err := n.Notify(ctx, title, body); err != nil {
+			log.Printf("plan completion: %s delivery failed: %v", n.Name(), err)
+		}
+	}
+	return completion, nil
+}
+
+// Get returns a completed plan's retrospective, archived weeks and next
+// phase options.
+// Returns store.ErrPlanRetrospectiveNotFound if the plan wasn't completed
+// through this flow.
+func (s *PlanCompletionService) Get(ctx context.Context, planID int64) (*domain.PlanCompletion, error) {
+	retrospective, pending, err := s.completionStore.GetRetrospective(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.planStore.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	weeks, err := s.completionStore.ListArchivedWeeklyTargets(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PlanCompletion{
+		Retrospective: *retrospective,
+		ArchivedWeeks: weeks,
+		NextPhases:    domain.SuggestNextPhases(plan, *retrospective, s.now()),
+		PromptPending: pending,
+	}, nil
+}
+
+// Pending completes the active plan if it is due, then returns the latest
+// completion whose next phase prompt is pending. Returns nil when there is
+// none, or once a new plan is active.
+func (s *PlanCompletionService) Pending(ctx context.Context) (*domain.PlanCompletion, error) {
+	if _, err := s.CompleteDue(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.planStore.GetActive(ctx); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, store.ErrPlanNotFound) {
+		return nil, err
+	}
+
+	retrospective, err := s.completionStore.LatestPendingRetrospective(ctx)
+	if errors.Is(err, store.ErrPlanRetrospectiveNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, retrospective.PlanID)
+}
+
+// DismissPrompt answers a completed plan's next phase prompt.
+func (s *PlanCompletionService) DismissPrompt(ctx context.Context, planID int64) error {
+	return s.completionStore.DismissPrompt(ctx, planID)
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, checking the active plan
+// once at startup and then nightly at planCompletionCheckHour.
+func (s *PlanCompletionService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "plan_completion", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "plan_completion", time.Now()) {
+			completion, err := s.CompleteDue(ctx)
+			s.jobs.Beat("plan_completion", time.Now(), err)
+			if err != nil {
+				log.Printf("plan completion: nightly check failed: %v", err)
+			} else if completion != nil {
+				log.Printf("plan completion: completed plan %d", completion.Retrospective.PlanID)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), planCompletionCheckHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// planCompletionMessage is the pushed notification for a completion.
+func planCompletionMessage(c *domain.PlanCompletion) (string, string) {
+	name := c.Retrospective.PlanName
+	if name == "" {
+		name = "Your plan"
+	}
+	lines := append([]string(nil), c.Retrospective.Highlights...)
+	for _, o := range c.NextPhases {
+		if o.Recommended {
+			lines = append(lines, fmt.Sprintf("Suggested next: %s. %s", o.Title, o.Rationale))
+		}
+	}
+	return name + " is complete", strings.Join(lines, "\n")
+}
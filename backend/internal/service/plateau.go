@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// plateauHistoryDays is how far back the plateau history endpoint looks.
+const plateauHistoryDays = 90
+
+// PlateauService detects weight and strength plateaus and persists detections.
+type PlateauService struct {
+	plateauStore  *store.PlateauStore
+	dailyLogStore *store.DailyLogStore
+	movementStore *store.MovementStore
+	profileStore  *store.ProfileStore
+}
+
+// NewPlateauService creates a new PlateauService.
+func NewPlateauService(ps *store.PlateauStore, dls *store.DailyLogStore, ms *store.MovementStore, prs *store.ProfileStore) *PlateauService {
+	return &PlateauService{
+		plateauStore:  ps,
+		dailyLogStore: dls,
+		movementStore: ms,
+		profileStore:  prs,
+	}
+}
+
+// Detect evaluates current weight and movement history for plateaus,
+// persists any detections, and returns them.
+func (s *PlateauService) Detect(ctx context.Context, now time.Time) ([]domain.PlateauDetection, error) {
+	detections := make([]domain.PlateauDetection, 0)
+
+	weightPlateau, err := s.detectWeightPlateau(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	if weightPlateau != nil {
+		detections = append(detections, *weightPlateau)
+	}
+
+	strengthPlateaus, err := s.detectStrengthPlateaus(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	detections = append(detections, strengthPlateaus...)
+
+	for i := range detections {
+		id, err := s.plateauStore.Upsert(ctx, detections[i])
+		if err != nil {
+			return nil, err
+		}
+		detections[i].ID = id
+	}
+
+	return detections, nil
+}
+
+// GetHistory returns plateau detections from the last plateauHistoryDays days.
+func (s *PlateauService) GetHistory(ctx context.Context, now time.Time) ([]domain.PlateauDetection, error) {
+	since := now.AddDate(0, 0, -plateauHistoryDays).Format("2006-01-02")
+	return s.plateauStore.ListSince(ctx, since)
+}
+
+// detectWeightPlateau checks the long diet-break window first so extended stalls
+// get escalated recommendations, then falls back to the minimum 3-week window.
+func (s *PlateauService) detectWeightPlateau(ctx context.Context, now time.Time) (*domain.PlateauDetection, error) {
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrProfileNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	today := now.Format("2006-01-02")
+	for _, days := range []int{domain.WeightPlateauDietBreakMinDays, domain.WeightPlateauMinDays} {
+		start := now.AddDate(0, 0, -days).Format("2006-01-02")
+
+		samples, err := s.dailyLogStore.ListWeights(ctx, start)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := s.dailyLogStore.ListByDateRange(ctx, start, today)
+		if err != nil {
+			return nil, err
+		}
+
+		adherence := domain.CalculateIntakeAdherence(logs)
+		if d := domain.DetectWeightPlateau(samples, adherence, profile.Goal, now); d != nil {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *PlateauService) detectStrengthPlateaus(ctx context.Context, now time.Time) ([]domain.PlateauDetection, error) {
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.movementStore.GetSessionLog(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byMovement := make(map[string][]domain.MovementSessionRecord)
+	for _, r := range records {
+		byMovement[r.MovementID] = append(byMovement[r.MovementID], r)
+	}
+
+	var detections []domain.PlateauDetection
+	for _, m := range movements {
+		if d := domain.DetectStrengthPlateau(m, byMovement[m.ID], movements, now); d != nil {
+			detections = append(detections, *d)
+		}
+	}
+	return detections, nil
+}
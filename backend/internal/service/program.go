@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"victus/internal/domain"
@@ -12,6 +13,32 @@ import (
 type TrainingProgramService struct {
 	programStore     *store.TrainingProgramStore
 	plannedDayStore  *store.PlannedDayTypeStore
+	sessionStore     *store.TrainingSessionStore
+	readiness        readinessSource
+	warmups          warmupSource
+	equipment        equipmentSource
+	experience       experienceSource
+	dailyTargets     dailyTargetRefresher
+	clocked
+}
+
+// warmupSource supplies the inputs for generating session warm-ups.
+// Implemented by MovementService; optional so programs work without the movement engine.
+type warmupSource interface {
+	WarmupInput(ctx context.Context, now time.Time) (domain.WarmupInput, error)
+}
+
+// readinessSource supplies a day's morning readiness: its recovery score (0-100).
+// Implemented by DailyLogService; optional so autoregulated programs fall back
+// to their base prescription.
+type readinessSource interface {
+	ReadinessScore(ctx context.Context, date string) (*float64, error)
+}
+
+// experienceSource supplies the derived training experience.
+// Implemented by ExperienceService; optional so programs work without it.
+type experienceSource interface {
+	Get(ctx context.Context) (*domain.TrainingExperience, error)
 }
 
 // NewTrainingProgramService creates a new TrainingProgramService.
@@ -22,6 +49,54 @@ func NewTrainingProgramService(ps *store.TrainingProgramStore, pds *store.Planne
 	}
 }
 
+// SetWarmupSource enables generated warm-ups on scheduled sessions.
+func (s *TrainingProgramService) SetWarmupSource(ws warmupSource) {
+	s.warmups = ws
+}
+
+// SetSessionStore enables conflict checks against logged training when rescheduling.
+func (s *TrainingProgramService) SetSessionStore(ss *store.TrainingSessionStore) {
+	s.sessionStore = ss
+}
+
+// SetReadinessSource enables readiness-scaled sessions on autoregulated installations.
+func (s *TrainingProgramService) SetReadinessSource(rs readinessSource) {
+	s.readiness = rs
+}
+
+// SetDailyTargetRefresher keeps the active plan's daily targets following the
+// day types programs plan.
+func (s *TrainingProgramService) SetDailyTargetRefresher(r dailyTargetRefresher) {
+	s.dailyTargets = r
+}
+
+// SetEquipmentSource enables equipment-aware program recommendations.
+func (s *TrainingProgramService) SetEquipmentSource(es equipmentSource) {
+	s.equipment = es
+}
+
+// SetExperienceSource enables experience-gated recommendations and
+// experience-based default difficulty for generated programs.
+func (s *TrainingProgramService) SetExperienceSource(es experienceSource) {
+	s.experience = es
+}
+
+// experienceLevel returns the derived experience level, or "" when it isn't
+// available (no experience source or no profile yet).
+func (s *TrainingProgramService) experienceLevel(ctx context.Context) (domain.ProgramDifficulty, error) {
+	if s.experience == nil {
+		return "", nil
+	}
+	exp, err := s.experience.Get(ctx)
+	if errors.Is(err, store.ErrProfileNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return exp.Level, nil
+}
+
 // Create creates a new custom training program.
 func (s *TrainingProgramService) Create(ctx context.Context, input domain.TrainingProgramInput, now time.Time) (*domain.TrainingProgram, error) {
 	program, err := domain.NewTrainingProgram(input, false, now)
@@ -37,6 +112,36 @@ func (s *TrainingProgramService) Create(ctx context.Context, input domain.Traini
 	return s.programStore.GetByID(ctx, programID)
 }
 
+// Generate builds a custom program from mesocycle parameters and saves it.
+// Without a difficulty the program matches the derived experience level.
+func (s *TrainingProgramService) Generate(ctx context.Context, input domain.MesocycleInput, now time.Time) (*domain.TrainingProgram, error) {
+	programInput, err := s.generateMesocycle(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, programInput, now)
+}
+
+// PreviewGenerate builds a custom program from mesocycle parameters without saving it.
+func (s *TrainingProgramService) PreviewGenerate(ctx context.Context, input domain.MesocycleInput, now time.Time) (*domain.TrainingProgram, error) {
+	programInput, err := s.generateMesocycle(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewTrainingProgram(programInput, false, now)
+}
+
+func (s *TrainingProgramService) generateMesocycle(ctx context.Context, input domain.MesocycleInput) (domain.TrainingProgramInput, error) {
+	if input.Difficulty == "" {
+		level, err := s.experienceLevel(ctx)
+		if err != nil {
+			return domain.TrainingProgramInput{}, err
+		}
+		input.Difficulty = string(level)
+	}
+	return domain.GenerateMesocycle(input)
+}
+
 // GetByID retrieves a training program by ID.
 // Returns store.ErrProgramNotFound if program doesn't exist.
 func (s *TrainingProgramService) GetByID(ctx context.Context, id int64) (*domain.TrainingProgram, error) {
@@ -48,6 +153,11 @@ func (s *TrainingProgramService) List(ctx context.Context, filters store.Program
 	return s.programStore.List(ctx, filters)
 }
 
+// ListWithWeeks retrieves programs with their weeks and days loaded.
+func (s *TrainingProgramService) ListWithWeeks(ctx context.Context, filters store.ProgramFilters) ([]*domain.TrainingProgram, error) {
+	return s.programStore.ListWithWeeks(ctx, filters)
+}
+
 // ListTemplates retrieves all template programs from the library.
 func (s *TrainingProgramService) ListTemplates(ctx context.Context) ([]*domain.TrainingProgram, error) {
 	isTemplate := true
@@ -57,6 +167,38 @@ func (s *TrainingProgramService) ListTemplates(ctx context.Context) ([]*domain.T
 	})
 }
 
+// Recommend returns template programs ranked by how well they fit the equipment
+// usable at now (including an active travel override). Without equipment data
+// every template is a fit. Templates above the derived experience level are
+// left out.
+func (s *TrainingProgramService) Recommend(ctx context.Context, now time.Time) ([]domain.ProgramEquipmentFit, error) {
+	templates, err := s.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	level, err := s.experienceLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if level != "" {
+		suited := templates[:0]
+		for _, t := range templates {
+			if t.Difficulty.SuitsExperience(level) {
+				suited = append(suited, t)
+			}
+		}
+		templates = suited
+	}
+
+	var avail domain.EquipmentAvailability
+	if s.equipment != nil {
+		if avail, err = s.equipment.Availability(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+	return domain.RankProgramsByEquipment(templates, avail), nil
+}
+
 // Update updates a training program.
 // Returns store.ErrProgramNotFound if program doesn't exist.
 func (s *TrainingProgramService) Update(ctx context.Context, program *domain.TrainingProgram) error {
@@ -115,19 +257,11 @@ func (s *TrainingProgramService) GetWaveformData(ctx context.Context, id int64)
 // Install creates a new program installation and schedules all sessions.
 // Returns store.ErrActiveInstallationExists if an active installation already exists.
 func (s *TrainingProgramService) Install(ctx context.Context, input domain.InstallProgramInput, now time.Time) (*domain.ProgramInstallation, error) {
-	// Validate program exists
-	program, err := s.programStore.GetByID(ctx, input.ProgramID)
+	installation, err := s.newInstallation(ctx, input, now)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create installation
-	installation, err := domain.NewProgramInstallation(input, now)
-	if err != nil {
-		return nil, err
-	}
-	installation.Program = program
-
 	// Create in store
 	installationID, err := s.programStore.CreateInstallation(ctx, installation)
 	if err != nil {
@@ -136,7 +270,6 @@ func (s *TrainingProgramService) Install(ctx context.Context, input domain.Insta
 
 	// Schedule planned day types for each session
 	if s.plannedDayStore != nil {
-		installation.Program = program
 		sessions := installation.GetScheduledSessions()
 		for _, session := range sessions {
 			// Create or update planned day type for this date
@@ -151,11 +284,48 @@ func (s *TrainingProgramService) Install(ctx context.Context, input domain.Insta
 				continue
 			}
 		}
+		refreshDailyTargets(ctx, s.dailyTargets, "")
 	}
 
 	return s.programStore.GetInstallationByID(ctx, installationID)
 }
 
+// PreviewInstall validates an installation and returns it with the sessions
+// it would schedule, without saving anything. It fails the same way Install
+// would, including when an active installation already exists.
+func (s *TrainingProgramService) PreviewInstall(ctx context.Context, input domain.InstallProgramInput, now time.Time) (*domain.ProgramInstallation, []domain.ScheduledSession, error) {
+	installation, err := s.newInstallation(ctx, input, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.programStore.GetActiveInstallation(ctx); err == nil {
+		return nil, nil, store.ErrActiveInstallationExists
+	} else if !errors.Is(err, store.ErrInstallationNotFound) {
+		return nil, nil, err
+	}
+
+	sessions := installation.GetScheduledSessions()
+	s.prepareRunnerExercises(ctx, sessions, now)
+	return installation, sessions, nil
+}
+
+// newInstallation validates an installation of an existing program.
+func (s *TrainingProgramService) newInstallation(ctx context.Context, input domain.InstallProgramInput, now time.Time) (*domain.ProgramInstallation, error) {
+	// Validate program exists
+	program, err := s.programStore.GetByID(ctx, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	installation, err := domain.NewProgramInstallation(input, now)
+	if err != nil {
+		return nil, err
+	}
+	installation.Program = program
+	return installation, nil
+}
+
 // GetActiveInstallation retrieves the currently active program installation.
 // Returns store.ErrInstallationNotFound if no active installation exists.
 func (s *TrainingProgramService) GetActiveInstallation(ctx context.Context) (*domain.ProgramInstallation, error) {
@@ -193,5 +363,246 @@ func (s *TrainingProgramService) GetScheduledSessions(ctx context.Context, insta
 		return nil, err
 	}
 
-	return installation.GetScheduledSessions(), nil
+	now := s.now()
+	sessions := installation.GetScheduledSessions()
+	if err := s.autoregulate(ctx, installation, sessions, now); err != nil {
+		return nil, err
+	}
+	s.prepareRunnerExercises(ctx, sessions, now)
+	return sessions, nil
+}
+
+// SetAutoregulation turns readiness scaling on (with bound, or the default
+// bound when 0) or off for an installation.
+// Returns store.ErrInstallationNotFound if installation doesn't exist.
+func (s *TrainingProgramService) SetAutoregulation(ctx context.Context, installationID int64, enabled bool, bound float64) (*domain.ProgramInstallation, error) {
+	if !enabled {
+		bound = 0
+	} else if bound == 0 {
+		bound = domain.DefaultAutoregulationBound
+	}
+	if err := domain.ValidateAutoregulationBound(bound); err != nil {
+		return nil, err
+	}
+	if err := s.programStore.UpdateInstallationAutoregulation(ctx, installationID, bound); err != nil {
+		return nil, err
+	}
+	return s.programStore.GetInstallationByID(ctx, installationID)
+}
+
+// SetKeepDeloadDayTypes sets whether an installation's deload weeks keep the
+// program's nutrition days, and re-plans the day types of its deload weeks'
+// sessions to match.
+// Returns store.ErrInstallationNotFound if installation doesn't exist.
+func (s *TrainingProgramService) SetKeepDeloadDayTypes(ctx context.Context, installationID int64, keep bool) (*domain.ProgramInstallation, error) {
+	if err := s.programStore.UpdateInstallationDeloadDayTypes(ctx, installationID, keep); err != nil {
+		return nil, err
+	}
+	installation, err := s.programStore.GetInstallationByID(ctx, installationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.plannedDayStore != nil && installation.Program != nil {
+		deload := make(map[int]bool)
+		for _, week := range installation.Program.Weeks {
+			deload[week.WeekNumber] = week.IsDeload
+		}
+		for _, session := range installation.GetScheduledSessions() {
+			if !deload[session.WeekNumber] {
+				continue
+			}
+			// As in Install, planned-day failures don't fail the saved setting
+			_ = s.plannedDayStore.Upsert(ctx, &domain.PlannedDayType{
+				Date:    session.Date.Format("2006-01-02"),
+				DayType: session.NutritionDay,
+			})
+		}
+		refreshDailyTargets(ctx, s.dailyTargets, "")
+	}
+	return installation, nil
+}
+
+// autoregulate scales the sessions of an autoregulated installation: today's
+// by this morning's readiness, recording the scaling, and earlier days' by
+// what was recorded for them. Fails open: without a readiness reading today's
+// sessions keep their base prescription.
+func (s *TrainingProgramService) autoregulate(ctx context.Context, installation *domain.ProgramInstallation, sessions []domain.ScheduledSession, now time.Time) error {
+	if installation.AutoregulationBound == 0 || len(sessions) == 0 {
+		return nil
+	}
+	records, err := s.programStore.ListSessionAutoregulations(ctx, installation.ID)
+	if err != nil {
+		return err
+	}
+	type programDay struct{ week, day int }
+	recorded := make(map[programDay]domain.SessionAutoregulation, len(records))
+	for _, r := range records {
+		recorded[programDay{r.WeekNumber, r.DayNumber}] = r
+	}
+
+	today := now.Format("2006-01-02")
+	var readiness *float64
+	if s.readiness != nil {
+		readiness, _ = s.readiness.ReadinessScore(ctx, today)
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+		date := session.Date.Format("2006-01-02")
+		if date == today && readiness != nil {
+			session.Autoregulate(*readiness, installation.AutoregulationBound)
+			if session.Autoregulation != nil {
+				if err := s.programStore.UpsertSessionAutoregulation(ctx, installation.ID, *session.Autoregulation); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if r, ok := recorded[programDay{session.WeekNumber, session.DayNumber}]; ok && r.Date == date && date <= today {
+			session.ApplyAutoregulation(r)
+		}
+	}
+	return nil
+}
+
+// SwapDays swaps the program sessions on two dates of the active week of an
+// installation and re-plans both days' nutrition. Returns the moves and the
+// rescheduled sessions.
+// Returns store.ErrInstallationNotFound if installation doesn't exist.
+func (s *TrainingProgramService) SwapDays(ctx context.Context, installationID int64, dateA, dateB string) ([]domain.ProgramReschedule, []domain.ScheduledSession, error) {
+	return s.reschedule(ctx, installationID, func(i *domain.ProgramInstallation, now time.Time, trained map[string]bool) ([]domain.ProgramReschedule, error) {
+		return i.SwapDays(dateA, dateB, now, trained)
+	})
+}
+
+// PushToday moves today's program sessions of an installation to tomorrow
+// and re-plans both days' nutrition. Returns the moves and the rescheduled sessions.
+// Returns store.ErrInstallationNotFound if installation doesn't exist.
+func (s *TrainingProgramService) PushToday(ctx context.Context, installationID int64) ([]domain.ProgramReschedule, []domain.ScheduledSession, error) {
+	return s.reschedule(ctx, installationID, func(i *domain.ProgramInstallation, now time.Time, trained map[string]bool) ([]domain.ProgramReschedule, error) {
+		return i.PushToday(now, trained)
+	})
+}
+
+// reschedule applies a move to an installation, saves it and re-derives the
+// planned day types of the dates it touched.
+func (s *TrainingProgramService) reschedule(ctx context.Context, installationID int64, move func(*domain.ProgramInstallation, time.Time, map[string]bool) ([]domain.ProgramReschedule, error)) ([]domain.ProgramReschedule, []domain.ScheduledSession, error) {
+	installation, err := s.programStore.GetInstallationByID(ctx, installationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	trained, err := s.trainedDates(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	moves, err := move(installation, now, trained)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.programStore.UpdateInstallationReschedules(ctx, installation.ID, installation.Reschedules); err != nil {
+		return nil, nil, err
+	}
+
+	sessions := installation.GetScheduledSessions()
+	if err := s.autoregulate(ctx, installation, sessions, now); err != nil {
+		return nil, nil, err
+	}
+	if s.plannedDayStore != nil {
+		for _, date := range domain.RescheduledDates(moves) {
+			var dayType domain.DayType
+			for _, session := range sessions {
+				if session.Date.Format("2006-01-02") == date {
+					dayType = session.NutritionDay
+				}
+			}
+			// As in Install, planned-day failures don't fail the saved reschedule
+			if dayType == "" {
+				_ = s.plannedDayStore.DeleteByDate(ctx, date)
+				continue
+			}
+			_ = s.plannedDayStore.Upsert(ctx, &domain.PlannedDayType{Date: date, DayType: dayType})
+		}
+		refreshDailyTargets(ctx, s.dailyTargets, "")
+	}
+
+	s.prepareRunnerExercises(ctx, sessions, now)
+	return moves, sessions, nil
+}
+
+// trainedDates returns the days around now that already have non-rest
+// training logged; only the current program week and tomorrow can be moved.
+func (s *TrainingProgramService) trainedDates(ctx context.Context, now time.Time) (map[string]bool, error) {
+	trained := make(map[string]bool)
+	if s.sessionStore == nil {
+		return trained, nil
+	}
+	days, err := s.sessionStore.GetSessionsForDateRange(ctx, now.AddDate(0, 0, -7).Format("2006-01-02"), now.AddDate(0, 0, 7).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		if domain.HasNonRestSession(day.ActualSessions) {
+			trained[day.Date] = true
+		}
+	}
+	return trained, nil
+}
+
+// TodaySession returns today's session from the active installation, with
+// its runner exercises prepared, or nil when nothing is scheduled today.
+// Returns store.ErrInstallationNotFound if no installation is active.
+func (s *TrainingProgramService) TodaySession(ctx context.Context) (*domain.ScheduledSession, error) {
+	installation, err := s.programStore.GetActiveInstallation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	today := now.Format("2006-01-02")
+	for _, session := range installation.GetScheduledSessions() {
+		if session.Date.Format("2006-01-02") == today {
+			sessions := []domain.ScheduledSession{session}
+			if err := s.autoregulate(ctx, installation, sessions, now); err != nil {
+				return nil, err
+			}
+			s.prepareRunnerExercises(ctx, sessions, now)
+			return &sessions[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// prepareRunnerExercises prepends a generated prepare phase to each training
+// session's runner exercises and swaps catalog movements needing unavailable
+// equipment. Fails open: sessions are left untouched if inputs are unavailable.
+func (s *TrainingProgramService) prepareRunnerExercises(ctx context.Context, sessions []domain.ScheduledSession, now time.Time) {
+	if s.warmups == nil || len(sessions) == 0 {
+		return
+	}
+	input, err := s.warmups.WarmupInput(ctx, now)
+	if err != nil {
+		return
+	}
+
+	byArchetype := make(map[domain.Archetype][]domain.SessionExercise)
+	for i := range sessions {
+		archetype, ok := domain.WarmupArchetypeForTrainingType(sessions[i].TrainingType)
+		if !ok {
+			continue
+		}
+		warmup, cached := byArchetype[archetype]
+		if !cached {
+			input.Archetype = archetype
+			warmup = domain.GenerateWarmup(input)
+			byArchetype[archetype] = warmup
+		}
+		sessions[i].SessionExercises = domain.AttachWarmup(sessions[i].SessionExercises, warmup)
+	}
+
+	for i := range sessions {
+		sessions[i].SessionExercises = domain.SubstituteSessionExercises(sessions[i].SessionExercises, input.Movements, input.Equipment)
+	}
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ReconciliationService patches derived values on past days when late wearable
+// data arrives, and flags the debriefs that covered those days for regeneration.
+type ReconciliationService struct {
+	dailyLogService     *DailyLogService
+	reconciliationStore *store.ReconciliationStore
+}
+
+// NewReconciliationService creates a new ReconciliationService.
+func NewReconciliationService(dls *DailyLogService, rs *store.ReconciliationStore) *ReconciliationService {
+	return &ReconciliationService{
+		dailyLogService:     dls,
+		reconciliationStore: rs,
+	}
+}
+
+// ReconcileDate re-runs CNS classification and TDEE flux for a past day that
+// received late data. Returns nil without error if the data is not late.
+func (s *ReconciliationService) ReconcileDate(ctx context.Context, date string, fields []domain.LateDataField, now time.Time) (*domain.ReconciliationResult, error) {
+	if !domain.IsLateArrival(date, now) || len(fields) == 0 {
+		return nil, nil
+	}
+
+	result := &domain.ReconciliationResult{
+		Date:              date,
+		Fields:            fields,
+		CNSReclassified:   make([]domain.CNSReclassification, 0),
+		StaleDebriefWeeks: make([]string, 0),
+	}
+
+	affected := domain.ReconciliationAffectedDates(date, fields, now)
+
+	// CNS status is derived on read from the HRV/RHR baseline, so re-reading each
+	// affected day reclassifies it against the patched history.
+	if domain.NeedsCNSReclassification(fields) {
+		for _, d := range affected {
+			log, err := s.dailyLogService.GetByDate(ctx, d)
+			if errors.Is(err, store.ErrDailyLogNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if log.CNSResult != nil {
+				result.CNSReclassified = append(result.CNSReclassified, domain.CNSReclassification{
+					Date:   d,
+					Status: log.CNSResult.Status,
+				})
+			}
+		}
+	}
+
+	if domain.NeedsFluxRecalculation(fields) {
+		recalculated, err := s.dailyLogService.RecalculateFlux(ctx, date, now)
+		if err != nil && !errors.Is(err, store.ErrDailyLogNotFound) {
+			return nil, err
+		}
+		result.FluxRecalculated = recalculated
+	}
+
+	reason := "late data: " + joinLateDataFields(fields)
+	for _, week := range domain.StaleDebriefWeeks(affected) {
+		if err := s.reconciliationStore.MarkDebriefStale(ctx, week, reason); err != nil {
+			return nil, err
+		}
+		result.StaleDebriefWeeks = append(result.StaleDebriefWeeks, week)
+	}
+
+	if err := s.reconciliationStore.RecordRun(ctx, *result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListStaleDebriefs returns weeks whose debriefs need regeneration.
+func (s *ReconciliationService) ListStaleDebriefs(ctx context.Context) ([]domain.StaleDebrief, error) {
+	return s.reconciliationStore.ListStaleDebriefs(ctx)
+}
+
+func joinLateDataFields(fields []domain.LateDataField) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// RecoveryActivityService handles recovery activity logging (sauna, cold,
+// massage and similar).
+type RecoveryActivityService struct {
+	activityStore *store.RecoveryActivityStore
+}
+
+// NewRecoveryActivityService creates a new RecoveryActivityService.
+func NewRecoveryActivityService(as *store.RecoveryActivityStore) *RecoveryActivityService {
+	return &RecoveryActivityService{activityStore: as}
+}
+
+// Log validates and stores an activity. An activity without an intensity is
+// taken as moderate.
+func (s *RecoveryActivityService) Log(ctx context.Context, activity domain.RecoveryActivity) (*domain.RecoveryActivity, error) {
+	if activity.Intensity == 0 {
+		activity.Intensity = domain.DefaultRecoveryIntensity
+	}
+	if err := activity.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.activityStore.Create(ctx, &activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// ListForDate returns the activities logged on a date.
+func (s *RecoveryActivityService) ListForDate(ctx context.Context, date string) ([]domain.RecoveryActivity, error) {
+	return s.activityStore.ListByDateRange(ctx, date, date)
+}
+
+// Delete removes an activity.
+func (s *RecoveryActivityService) Delete(ctx context.Context, id int64) error {
+	return s.activityStore.Delete(ctx, id)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// restoreSpec says how a table's archive rows are matched to existing rows.
+type restoreSpec struct {
+	// key columns identify the same row in another database, read after
+	// parent ids are remapped.
+	key []string
+	// parents maps id columns to the table whose ids they hold.
+	parents map[string]domain.ExportTable
+	// readOnly columns are added by the export for reading and not stored.
+	readOnly []string
+}
+
+var restoreSpecs = map[domain.ExportTable]restoreSpec{
+	domain.ExportDailyLogs: {key: []string{"log_date"}},
+	domain.ExportTrainingSessions: {
+		key:      []string{"daily_log_id", "session_order", "is_planned"},
+		parents:  map[string]domain.ExportTable{"daily_log_id": domain.ExportDailyLogs},
+		readOnly: []string{"log_date"},
+	},
+	domain.ExportNutritionPlans: {key: []string{"start_date", "created_at"}},
+	domain.ExportWeeklyTargets: {
+		key:      []string{"plan_id", "week_number"},
+		parents:  map[string]domain.ExportTable{"plan_id": domain.ExportNutritionPlans},
+		readOnly: []string{"plan_start_date"},
+	},
+	domain.ExportMetabolicHistory: {
+		key:      []string{"daily_log_id", "calculated_at"},
+		parents:  map[string]domain.ExportTable{"daily_log_id": domain.ExportDailyLogs},
+		readOnly: []string{"log_date"},
+	},
+	domain.ExportFatigueEvents: {
+		key:      []string{"training_session_id", "applied_at"},
+		parents:  map[string]domain.ExportTable{"training_session_id": domain.ExportTrainingSessions},
+		readOnly: []string{"log_date", "session_order", "is_planned"},
+	},
+}
+
+// RestoreService reads an export archive back into the database. Rows get
+// new ids; references between them are remapped through the archive's ids.
+// Rows matching an existing row are resolved by each table's strategy.
+type RestoreService struct {
+	restoreStore *store.RestoreStore
+}
+
+// NewRestoreService creates a new RestoreService.
+func NewRestoreService(rs *store.RestoreStore) *RestoreService {
+	return &RestoreService{restoreStore: rs}
+}
+
+// Restore reads a JSON export or a CSV ZIP and writes its tables in one
+// transaction: any failure leaves the database as it was.
+func (s *RestoreService) Restore(ctx context.Context, data []byte, strategies map[domain.ExportTable]domain.RestoreStrategy) (*domain.RestoreResult, error) {
+	archive, err := parseExportArchive(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &domain.RestoreResult{ExportedAt: archive.Manifest.ExportedAt}
+	err = s.restoreStore.InTx(ctx, func(ctx context.Context) error {
+		// archive id -> new id, per table, for remapping references
+		ids := make(map[domain.ExportTable]map[string]int64, len(domain.ExportTables))
+		for _, table := range domain.ExportTables {
+			ids[table] = make(map[string]int64)
+			data, ok := archive.Tables[table]
+			if !ok {
+				continue
+			}
+			tr, err := s.restoreTable(ctx, table, data, archive.EmptyIsNull, strategies[table], ids)
+			if err != nil {
+				return err
+			}
+			result.Tables = append(result.Tables, *tr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *RestoreService) restoreTable(ctx context.Context, table domain.ExportTable, data *domain.ExportTableData, emptyIsNull bool, strategy domain.RestoreStrategy, ids map[domain.ExportTable]map[string]int64) (*domain.RestoreTableResult, error) {
+	if strategy == "" {
+		strategy = domain.RestoreSkip
+	}
+	spec := restoreSpecs[table]
+	tr := &domain.RestoreTableResult{Table: table, Strategy: strategy, Rows: len(data.Rows)}
+
+	columns, err := s.restoreStore.Columns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	writable := make([]store.RestoreColumn, 0, len(columns))
+	known := map[string]bool{"id": true}
+	for _, c := range spec.readOnly {
+		known[c] = true
+	}
+	for _, c := range columns {
+		known[c.Name] = true
+		if c.Name != "id" && data.HasColumn(c.Name) {
+			writable = append(writable, c)
+		}
+	}
+	for _, c := range data.Columns {
+		if !known[c] {
+			tr.IgnoredColumns = append(tr.IgnoredColumns, c)
+		}
+	}
+	for _, k := range spec.key {
+		if !data.HasColumn(k) {
+			return nil, fmt.Errorf("%w: %s has no %s column", domain.ErrInvalidExportArchive, table, k)
+		}
+	}
+
+	for i, row := range data.Rows {
+		values := make([]store.RestoreValue, len(writable))
+		byName := make(map[string]store.RestoreValue, len(writable))
+		for j, c := range writable {
+			v, _ := data.Value(row, c.Name)
+			if parent, ok := spec.parents[c.Name]; ok && v != nil {
+				id, ok := ids[parent][*v]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s row %d refers to %s id %s, which the archive doesn't hold",
+						domain.ErrInvalidExportArchive, table, i+1, parent, *v)
+				}
+				mapped := strconv.FormatInt(id, 10)
+				v = &mapped
+			}
+			if v != nil && *v == "" && emptyIsNull && c.Nullable {
+				v = nil
+			}
+			values[j] = store.RestoreValue{Column: c, Value: v}
+			byName[c.Name] = values[j]
+		}
+		key := make([]store.RestoreValue, len(spec.key))
+		for j, k := range spec.key {
+			key[j] = byName[k]
+		}
+
+		id, found, err := s.restoreStore.FindRow(ctx, table, key)
+		if err != nil {
+			return nil, fmt.Errorf("restore %s row %d: %w", table, i+1, err)
+		}
+		switch {
+		case !found:
+			id, err = s.restoreStore.InsertRow(ctx, table, values)
+			tr.Inserted++
+		case strategy == domain.RestoreOverwrite:
+			err = s.restoreStore.UpdateRow(ctx, table, id, values, false)
+			tr.Overwritten++
+		case strategy == domain.RestoreMerge:
+			err = s.restoreStore.UpdateRow(ctx, table, id, values, true)
+			tr.Merged++
+		default:
+			tr.Skipped++
+		}
+		if err != nil {
+			return nil, fmt.Errorf("restore %s row %d: %w", table, i+1, err)
+		}
+		if found {
+			tr.Conflicts++
+		}
+		if sourceID, _ := data.Value(row, "id"); sourceID != nil {
+			ids[table][*sourceID] = id
+		}
+	}
+	return tr, nil
+}
+
+// parseExportArchive reads a CSV ZIP or a JSON export, told apart by the
+// ZIP signature.
+func parseExportArchive(data []byte) (*domain.ExportArchive, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return parseCSVArchive(data)
+	}
+	return parseJSONArchive(data)
+}
+
+func parseJSONArchive(data []byte) (*domain.ExportArchive, error) {
+	var doc struct {
+		domain.ExportManifest
+		Data map[domain.ExportTable][]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidExportArchive, err)
+	}
+
+	archive := &domain.ExportArchive{Manifest: doc.ExportManifest, Tables: make(map[domain.ExportTable]*domain.ExportTableData)}
+	for table, raw := range doc.Data {
+		rows := make([]map[string]any, len(raw))
+		var columns []string
+		seen := make(map[string]bool)
+		for i, r := range raw {
+			dec := json.NewDecoder(bytes.NewReader(r))
+			dec.UseNumber()
+			if err := dec.Decode(&rows[i]); err != nil {
+				return nil, fmt.Errorf("%w: %s row %d: %v", domain.ErrInvalidExportArchive, table, i+1, err)
+			}
+			for c := range rows[i] {
+				if !seen[c] {
+					seen[c] = true
+					columns = append(columns, c)
+				}
+			}
+		}
+
+		t := domain.NewExportTableData(columns)
+		for _, row := range rows {
+			values := make([]*string, len(columns))
+			for i, c := range columns {
+				values[i] = jsonText(row[c])
+			}
+			t.Rows = append(t.Rows, values)
+		}
+		archive.Tables[table] = t
+	}
+	return archive, nil
+}
+
+// jsonText renders a decoded JSON value as the text Postgres reads.
+func jsonText(v any) *string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		s = string(b)
+	}
+	return &s
+}
+
+func parseCSVArchive(data []byte) (*domain.ExportArchive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidExportArchive, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	manifestFile, ok := files["manifest.json"]
+	if !ok {
+		return nil, fmt.Errorf("%w: manifest.json is missing", domain.ErrInvalidExportArchive)
+	}
+	remaining := maxArchiveSize
+	raw, err := readZipFile(manifestFile, &remaining)
+	if err != nil {
+		return nil, err
+	}
+	archive := &domain.ExportArchive{Tables: make(map[domain.ExportTable]*domain.ExportTableData), EmptyIsNull: true}
+	if err := json.Unmarshal(raw, &archive.Manifest); err != nil {
+		return nil, fmt.Errorf("%w: manifest.json: %v", domain.ErrInvalidExportArchive, err)
+	}
+
+	// Check the whole manifest before decompressing any table
+	seenTables := make(map[domain.ExportTable]bool, len(archive.Manifest.Tables))
+	seenFiles := make(map[string]bool, len(archive.Manifest.Tables))
+	for _, summary := range archive.Manifest.Tables {
+		if !domain.IsExportTable(summary.Name) {
+			return nil, fmt.Errorf("%w: unknown table %q", domain.ErrInvalidExportArchive, summary.Name)
+		}
+		if seenTables[summary.Name] || seenFiles[summary.File] {
+			return nil, fmt.Errorf("%w: %s is listed twice in manifest.json", domain.ErrInvalidExportArchive, summary.Name)
+		}
+		seenTables[summary.Name], seenFiles[summary.File] = true, true
+		if _, ok := files[summary.File]; !ok {
+			return nil, fmt.Errorf("%w: %s is missing", domain.ErrInvalidExportArchive, summary.File)
+		}
+	}
+
+	for _, summary := range archive.Manifest.Tables {
+		raw, err := readZipFile(files[summary.File], &remaining)
+		if err != nil {
+			return nil, err
+		}
+		records, err := csv.NewReader(bytes.NewReader(raw)).ReadAll()
+		if err != nil || len(records) == 0 {
+			return nil, fmt.Errorf("%w: %s has no header row", domain.ErrInvalidExportArchive, summary.File)
+		}
+
+		t := domain.NewExportTableData(records[0])
+		for _, record := range records[1:] {
+			values := make([]*string, len(record))
+			for i := range record {
+				values[i] = &record[i]
+			}
+			t.Rows = append(t.Rows, values)
+		}
+		archive.Tables[summary.Name] = t
+	}
+	return archive, nil
+}
+
+// maxArchiveEntrySize caps one ZIP entry once decompressed: 256MB, far more
+// than an export's CSV of a 50MB upload holds, but a bound on a zip bomb.
+// maxArchiveSize caps all of an archive's entries together, so many entries
+// each under the entry cap can't add up to a bomb either.
+var (
+	maxArchiveEntrySize int64 = 256 << 20
+	maxArchiveSize      int64 = 512 << 20
+)
+
+// readZipFile reads one entry of a CSV archive, refusing entries that
+// decompress to more than maxArchiveEntrySize or to more than remaining, the
+// archive's budget left, which it then takes the entry's size off. The size
+// in the entry's header is checked first but not trusted.
+func readZipFile(f *zip.File, remaining *int64) ([]byte, error) {
+	tooLarge := fmt.Errorf("%w: %s is larger than %d MB uncompressed", domain.ErrInvalidExportArchive, f.Name, maxArchiveEntrySize>>20)
+	archiveTooLarge := fmt.Errorf("%w: archive is larger than %d MB uncompressed", domain.ErrInvalidExportArchive, maxArchiveSize>>20)
+	if f.UncompressedSize64 > uint64(maxArchiveEntrySize) {
+		return nil, tooLarge
+	}
+	if f.UncompressedSize64 > uint64(*remaining) {
+		return nil, archiveTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", domain.ErrInvalidExportArchive, f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, min(maxArchiveEntrySize, *remaining)+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", domain.ErrInvalidExportArchive, f.Name, err)
+	}
+	if int64(len(data)) > maxArchiveEntrySize {
+		return nil, tooLarge
+	}
+	if int64(len(data)) > *remaining {
+		return nil, archiveTooLarge
+	}
+	*remaining -= int64(len(data))
+	return data, nil
+}
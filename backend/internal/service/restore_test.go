@@ -0,0 +1,170 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"victus/internal/domain"
+)
+
+// Justification: A restore must read back exactly what an export wrote, in
+// both formats, before any row reaches the database; tests round-trip the
+// export sinks through the archive parser without a database.
+type RestoreArchiveSuite struct {
+	suite.Suite
+	at time.Time
+}
+
+func TestRestoreArchiveSuite(t *testing.T) {
+	suite.Run(t, new(RestoreArchiveSuite))
+}
+
+func (s *RestoreArchiveSuite) SetupTest() {
+	s.at = time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+}
+
+// writeSample writes two logs and one session through an export sink.
+func (s *RestoreArchiveSuite) writeSample(sink interface {
+	BeginTable(domain.ExportTable, []string) error
+	WriteRow([]any) error
+}) {
+	s.Require().NoError(sink.BeginTable(domain.ExportDailyLogs, []string{"id", "log_date", "weight_kg", "notes", "body_fat_percent"}))
+	s.Require().NoError(sink.WriteRow([]any{int64(7), "2026-03-01", 82.3, "", nil}))
+	s.Require().NoError(sink.WriteRow([]any{int64(9), "2026-03-02", 82.1, "Felt \"great\", slept well", 18.5}))
+	s.Require().NoError(sink.BeginTable(domain.ExportTrainingSessions, []string{"log_date", "id", "daily_log_id", "is_planned", "created_at"}))
+	s.Require().NoError(sink.WriteRow([]any{"2026-03-02", int64(3), int64(9), false, time.Date(2026, 3, 2, 7, 10, 0, 123456000, time.UTC)}))
+}
+
+func (s *RestoreArchiveSuite) TestJSONRoundTrip() {
+	var buf bytes.Buffer
+	sink := &jsonExportSink{w: &buf, manifest: domain.NewExportManifest(s.at)}
+	s.writeSample(sink)
+	s.Require().NoError(sink.close())
+
+	archive, err := parseExportArchive(buf.Bytes())
+	s.Require().NoError(err)
+	s.Require().NoError(archive.Validate())
+	s.False(archive.EmptyIsNull, "JSON keeps NULL and empty strings apart")
+
+	logs := archive.Tables[domain.ExportDailyLogs]
+	s.Require().Len(logs.Rows, 2)
+	notes, _ := logs.Value(logs.Rows[0], "notes")
+	s.Equal("", *notes)
+	bodyFat, _ := logs.Value(logs.Rows[0], "body_fat_percent")
+	s.Nil(bodyFat)
+	weight, _ := logs.Value(logs.Rows[0], "weight_kg")
+	s.Equal("82.3", *weight)
+
+	sessions := archive.Tables[domain.ExportTrainingSessions]
+	s.Require().Len(sessions.Rows, 1)
+	planned, _ := sessions.Value(sessions.Rows[0], "is_planned")
+	s.Equal("false", *planned)
+	created, _ := sessions.Value(sessions.Rows[0], "created_at")
+	s.Equal("2026-03-02T07:10:00.123456Z", *created)
+}
+
+func (s *RestoreArchiveSuite) TestCSVRoundTrip() {
+	var buf bytes.Buffer
+	sink := &csvExportSink{zw: zip.NewWriter(&buf), manifest: domain.NewExportManifest(s.at)}
+	s.writeSample(sink)
+	s.Require().NoError(sink.close())
+
+	archive, err := parseExportArchive(buf.Bytes())
+	s.Require().NoError(err)
+	s.Require().NoError(archive.Validate())
+	s.True(archive.EmptyIsNull)
+	s.Equal(s.at.Format(time.RFC3339), archive.Manifest.ExportedAt)
+
+	logs := archive.Tables[domain.ExportDailyLogs]
+	s.Require().Len(logs.Rows, 2)
+	notes, _ := logs.Value(logs.Rows[1], "notes")
+	s.Equal(`Felt "great", slept well`, *notes)
+	bodyFat, _ := logs.Value(logs.Rows[0], "body_fat_percent")
+	s.Equal("", *bodyFat, "NULL is an empty cell")
+
+	sessions := archive.Tables[domain.ExportTrainingSessions]
+	s.Require().Len(sessions.Rows, 1)
+	created, _ := sessions.Value(sessions.Rows[0], "created_at")
+	s.Equal("2026-03-02T07:10:00.123456Z", *created)
+}
+
+func (s *RestoreArchiveSuite) TestRejectsOtherFiles() {
+	_, err := parseExportArchive([]byte("date,weight\n2026-03-01,82.3\n"))
+	s.ErrorIs(err, domain.ErrInvalidExportArchive)
+
+	archive, err := parseExportArchive([]byte(`{"format":"something-else","version":1}`))
+	s.Require().NoError(err)
+	s.ErrorIs(archive.Validate(), domain.ErrInvalidExportArchive)
+
+	archive, err = parseExportArchive([]byte(`{"format":"victus-export","version":2}`))
+	s.Require().NoError(err)
+	s.ErrorIs(archive.Validate(), domain.ErrUnsupportedExportVersion)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("daily_logs.csv")
+	f.Write([]byte("id,log_date\n1,2026-03-01\n"))
+	s.Require().NoError(zw.Close())
+	_, err = parseExportArchive(buf.Bytes())
+	s.ErrorIs(err, domain.ErrInvalidExportArchive, "a ZIP without manifest.json")
+}
+
+func (s *RestoreArchiveSuite) TestRejectsOversizedEntries() {
+	defer func(size int64) { maxArchiveEntrySize = size }(maxArchiveEntrySize)
+	maxArchiveEntrySize = 1 << 10
+
+	var buf bytes.Buffer
+	sink := &csvExportSink{zw: zip.NewWriter(&buf), manifest: domain.NewExportManifest(s.at)}
+	s.Require().NoError(sink.BeginTable(domain.ExportDailyLogs, []string{"id", "log_date", "notes"}))
+	s.Require().NoError(sink.WriteRow([]any{int64(1), "2026-03-01", string(bytes.Repeat([]byte("a"), 2<<10))}))
+	s.Require().NoError(sink.close())
+
+	_, err := parseExportArchive(buf.Bytes())
+	s.ErrorIs(err, domain.ErrInvalidExportArchive)
+	s.ErrorContains(err, "daily_logs.csv is larger than")
+}
+
+func (s *RestoreArchiveSuite) TestRejectsOversizedArchives() {
+	defer func(size int64) { maxArchiveSize = size }(maxArchiveSize)
+	maxArchiveSize = 2 << 10
+
+	var buf bytes.Buffer
+	sink := &csvExportSink{zw: zip.NewWriter(&buf), manifest: domain.NewExportManifest(s.at)}
+	notes := string(bytes.Repeat([]byte("a"), 1<<10))
+	s.Require().NoError(sink.BeginTable(domain.ExportDailyLogs, []string{"id", "log_date", "notes"}))
+	s.Require().NoError(sink.WriteRow([]any{int64(1), "2026-03-01", notes}))
+	s.Require().NoError(sink.BeginTable(domain.ExportMetabolicHistory, []string{"id", "log_date", "notes"}))
+	s.Require().NoError(sink.WriteRow([]any{int64(1), "2026-03-01", notes}))
+	s.Require().NoError(sink.close())
+
+	_, err := parseExportArchive(buf.Bytes())
+	s.ErrorIs(err, domain.ErrInvalidExportArchive)
+	s.ErrorContains(err, "archive is larger than", "each entry fits, but not both")
+}
+
+func (s *RestoreArchiveSuite) TestRejectsBadManifests() {
+	zipWith := func(manifest string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		f, _ := zw.Create("manifest.json")
+		f.Write([]byte(manifest))
+		f, _ = zw.Create("daily_logs.csv")
+		f.Write([]byte("id,log_date\n1,2026-03-01\n"))
+		s.Require().NoError(zw.Close())
+		return buf.Bytes()
+	}
+
+	_, err := parseExportArchive(zipWith(`{"format":"victus-export","version":1,"tables":[
+		{"name":"daily_logs","file":"daily_logs.csv"},{"name":"users","file":"users.csv"}]}`))
+	s.ErrorIs(err, domain.ErrInvalidExportArchive)
+	s.ErrorContains(err, `unknown table "users"`, "checked before users.csv is looked for")
+
+	_, err = parseExportArchive(zipWith(`{"format":"victus-export","version":1,"tables":[
+		{"name":"daily_logs","file":"daily_logs.csv"},{"name":"daily_logs","file":"daily_logs.csv"}]}`))
+	s.ErrorIs(err, domain.ErrInvalidExportArchive)
+	s.ErrorContains(err, "listed twice")
+}
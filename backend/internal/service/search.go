@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// SearchService answers keyword searches across notes, sessions, programs,
+// foods, movements and tagged days.
+type SearchService struct {
+	searchStore *store.SearchStore
+}
+
+// NewSearchService creates a new SearchService.
+func NewSearchService(ss *store.SearchStore) *SearchService {
+	return &SearchService{searchStore: ss}
+}
+
+// Search validates the query and kind filter (comma-separated, empty for all)
+// and returns the ranked results.
+func (s *SearchService) Search(ctx context.Context, query, kindFilter string, limit int) ([]domain.SearchResult, error) {
+	query, err := domain.ValidateSearchQuery(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	kinds, err := domain.ParseSearchKinds(kindFilter)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchStore.Search(ctx, query, kinds, limit)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// ErrSemanticSearchUnavailable is returned when a query can't be embedded,
+// e.g. Ollama is down or the semantic search budget is used up.
+var ErrSemanticSearchUnavailable = errors.New("semantic search unavailable")
+
+const (
+	// semanticReindexInterval is how often the catalogs are re-indexed. Only
+	// new or changed items are embedded, so a run with no changes is cheap.
+	semanticReindexInterval = 6 * time.Hour
+	// semanticEmbedBatchSize is the number of documents per /api/embed call.
+	semanticEmbedBatchSize = 32
+)
+
+// SemanticIndexCounts reports one catalog's re-indexing.
+type SemanticIndexCounts struct {
+	Embedded  int `json:"embedded"`  // New or changed items
+	Unchanged int `json:"unchanged"` // Already indexed with the current model
+	Removed   int `json:"removed"`   // Items no longer in the catalog
+}
+
+// SemanticIndexResult reports a re-indexing run.
+type SemanticIndexResult struct {
+	Model     string              `json:"model"`
+	Foods     SemanticIndexCounts `json:"foods"`
+	Movements SemanticIndexCounts `json:"movements"`
+}
+
+// SemanticSearchService indexes foods and movements as embeddings and answers
+// free-text searches against them.
+type SemanticSearchService struct {
+	foodReferenceStore *store.FoodReferenceStore
+	movementStore      *store.MovementStore
+	embeddingStore     *store.EmbeddingStore
+	ollamaService      *OllamaService
+	jobs               *JobMonitor
+}
+
+// NewSemanticSearchService creates a new SemanticSearchService.
+func NewSemanticSearchService(
+	foodReferenceStore *store.FoodReferenceStore,
+	movementStore *store.MovementStore,
+	embeddingStore *store.EmbeddingStore,
+	ollamaService *OllamaService,
+) *SemanticSearchService {
+	return &SemanticSearchService{
+		foodReferenceStore: foodReferenceStore,
+		movementStore:      movementStore,
+		embeddingStore:     embeddingStore,
+		ollamaService:      ollamaService,
+	}
+}
+
+// SetJobMonitor enables heartbeats for the re-indexing job.
+func (s *SemanticSearchService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// Reindex embeds new and changed foods and movements and drops deleted ones.
+// Switching the embedding model re-embeds everything.
+func (s *SemanticSearchService) Reindex(ctx context.Context) (*SemanticIndexResult, error) {
+	// Resolve the embedding model from what's installed, not the pre-detection default
+	if !s.ollamaService.ModelStatus().Detected {
+		if _, err := s.ollamaService.DetectModels(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSemanticSearchUnavailable, err)
+		}
+	}
+
+	foods, err := s.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	foodDocs := make([]domain.EmbeddingDocument, len(foods))
+	for i, f := range foods {
+		foodDocs[i] = domain.FoodEmbeddingDocument(f)
+	}
+	movementDocs := make([]domain.EmbeddingDocument, len(movements))
+	for i, m := range movements {
+		movementDocs[i] = domain.MovementEmbeddingDocument(m)
+	}
+
+	result := &SemanticIndexResult{Model: s.ollamaService.ModelFor(OllamaTaskEmbed)}
+	if result.Foods, err = s.reindexKind(ctx, domain.EmbeddingKindFood, foodDocs, result.Model); err != nil {
+		return nil, fmt.Errorf("index foods: %w", err)
+	}
+	if result.Movements, err = s.reindexKind(ctx, domain.EmbeddingKindMovement, movementDocs, result.Model); err != nil {
+		return nil, fmt.Errorf("index movements: %w", err)
+	}
+	return result, nil
+}
+
+// reindexKind brings one catalog's embeddings up to date with docs.
+func (s *SemanticSearchService) reindexKind(ctx context.Context, kind domain.EmbeddingKind, docs []domain.EmbeddingDocument, model string) (SemanticIndexCounts, error) {
+	var counts SemanticIndexCounts
+	hashes, err := s.embeddingStore.ContentHashes(ctx, kind, model)
+	if err != nil {
+		return counts, err
+	}
+
+	keep := make([]string, 0, len(docs))
+	var stale []domain.EmbeddingDocument
+	for _, doc := range docs {
+		keep = append(keep, doc.ItemID)
+		if hashes[doc.ItemID] == doc.Hash() {
+			counts.Unchanged++
+		} else {
+			stale = append(stale, doc)
+		}
+	}
+
+	for start := 0; start < len(stale); start += semanticEmbedBatchSize {
+		batch := stale[start:min(start+semanticEmbedBatchSize, len(stale))]
+		texts := make([]string, len(batch))
+		for i, doc := range batch {
+			texts[i] = doc.Text
+		}
+		vectors, embedModel, err := s.ollamaService.Embed(ctx, domain.LLMFeatureSemanticIndex, texts)
+		if err != nil {
+			return counts, fmt.Errorf("%w: %v", ErrSemanticSearchUnavailable, err)
+		}
+		for i, doc := range batch {
+			if err := s.embeddingStore.Upsert(ctx, kind, doc, embedModel, vectors[i]); err != nil {
+				return counts, err
+			}
+			counts.Embedded++
+		}
+	}
+
+	if counts.Removed, err = s.embeddingStore.DeleteExcept(ctx, kind, keep); err != nil {
+		return counts, err
+	}
+	return counts, nil
+}
+
+// RunReindexSchedule blocks until ctx is cancelled, re-indexing at startup
+// and every semanticReindexInterval.
+func (s *SemanticSearchService) RunReindexSchedule(ctx context.Context) {
+	ticker := time.NewTicker(semanticReindexInterval)
+	defer ticker.Stop()
+	s.jobs.Start(ctx, "semantic_reindex", semanticReindexInterval, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "semantic_reindex", time.Now()) {
+			result, err := s.Reindex(ctx)
+			s.jobs.Beat("semantic_reindex", time.Now(), err)
+			if err != nil {
+				log.Printf("semantic: re-index failed: %v", err)
+			} else if result.Foods.Embedded+result.Movements.Embedded+result.Foods.Removed+result.Movements.Removed > 0 {
+				log.Printf("semantic: re-indexed with %s (foods %+v, movements %+v)", result.Model, result.Foods, result.Movements)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// embedQuery embeds a search query, mapping Ollama failures to ErrSemanticSearchUnavailable.
+func (s *SemanticSearchService) embedQuery(ctx context.Context, query string) ([]float32, string, error) {
+	vectors, model, err := s.ollamaService.Embed(ctx, domain.LLMFeatureSemanticSearch, []string{query})
+	if err != nil {
+		log.Printf("semantic: embedding query failed: %v", err)
+		return nil, "", fmt.Errorf("%w: %v", ErrSemanticSearchUnavailable, err)
+	}
+	return vectors[0], model, nil
+}
+
+// SearchFoods returns the foods closest in meaning to a free-text query.
+func (s *SemanticSearchService) SearchFoods(ctx context.Context, query string, limit int) ([]domain.FoodSearchHit, error) {
+	query, err := domain.ValidateSemanticQuery(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	vector, model, err := s.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.embeddingStore.Search(ctx, domain.EmbeddingKindFood, model, vector, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	foods, err := s.foodReferenceStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.FoodNutrition, len(foods))
+	for _, f := range foods {
+		byID[strconv.FormatInt(f.ID, 10)] = f
+	}
+
+	hits := make([]domain.FoodSearchHit, 0, len(matches))
+	for _, m := range matches {
+		if f, ok := byID[m.ItemID]; ok { // Skip foods deleted since the last re-index
+			hits = append(hits, domain.FoodSearchHit{Food: f, Similarity: m.Similarity})
+		}
+	}
+	return hits, nil
+}
+
+// SearchMovements returns the movements closest in meaning to a free-text query.
+func (s *SemanticSearchService) SearchMovements(ctx context.Context, query string, limit int) ([]domain.MovementSearchHit, error) {
+	query, err := domain.ValidateSemanticQuery(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	vector, model, err := s.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.embeddingStore.Search(ctx, domain.EmbeddingKindMovement, model, vector, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.movementStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Movement, len(movements))
+	for _, m := range movements {
+		byID[m.ID] = m
+	}
+
+	hits := make([]domain.MovementSearchHit, 0, len(matches))
+	for _, m := range matches {
+		if mov, ok := byID[m.ItemID]; ok { // Skip movements deleted since the last re-index
+			hits = append(hits, domain.MovementSearchHit{Movement: mov, Similarity: m.Similarity})
+		}
+	}
+	return hits, nil
+}
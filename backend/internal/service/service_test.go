@@ -44,6 +44,7 @@ func (s *DailyLogServiceSuite) SetupTest() {
 	s.sessionStore = store.NewTrainingSessionStore(s.db)
 	s.profileService = NewProfileService(s.profileStore)
 	s.logService = NewDailyLogService(s.logStore, s.sessionStore, s.profileStore)
+	s.logService.SetCalorieEstimator(NewCalorieEstimationService(store.NewTrainingConfigStore(s.db), s.logStore, s.sessionStore, s.profileStore))
 	s.now = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
 }
 
@@ -649,12 +650,27 @@ func (s *NutritionPlanServiceSuite) TestStateTransitions() {
 		plan, err := s.service.Create(s.ctx, s.validInput(), s.now)
 		s.Require().NoError(err)
 
-		err = s.service.Abandon(s.ctx, plan.ID)
+		err = s.service.Abandon(s.ctx, plan.ID, domain.PlanExitSurvey{
+			Reasons: []domain.PlanAbandonReason{"bored"},
+		})
+		s.Require().ErrorIs(err, domain.ErrInvalidAbandonReason)
+
+		err = s.service.Abandon(s.ctx, plan.ID, domain.PlanExitSurvey{
+			Reasons: []domain.PlanAbandonReason{domain.AbandonReasonTooAggressive},
+			Note:    "  constantly hungry ",
+		})
 		s.Require().NoError(err)
 
 		loaded, err := s.service.GetByID(s.ctx, plan.ID)
 		s.Require().NoError(err)
 		s.Equal(domain.PlanStatusAbandoned, loaded.Status)
+		s.Equal([]domain.PlanAbandonReason{domain.AbandonReasonTooAggressive}, loaded.AbandonReasons)
+		s.Equal("constantly hungry", loaded.AbandonNote)
+
+		again, err := s.service.PreviewCreate(s.ctx, s.validInput(), s.now)
+		s.Require().NoError(err)
+		s.Require().Len(again.HistoryWarnings, 1, "repeating an abandoned plan warns")
+		s.Equal(domain.AbandonReasonTooAggressive, again.HistoryWarnings[0].Reason)
 	})
 
 	s.Run("pause transitions active plan to paused", func() {
@@ -690,7 +706,7 @@ func (s *NutritionPlanServiceSuite) TestStateTransitions() {
 		err := s.service.Complete(s.ctx, 99999)
 		s.Require().ErrorIs(err, store.ErrPlanNotFound)
 
-		err = s.service.Abandon(s.ctx, 99999)
+		err = s.service.Abandon(s.ctx, 99999, domain.PlanExitSurvey{})
 		s.Require().ErrorIs(err, store.ErrPlanNotFound)
 
 		err = s.service.Pause(s.ctx, 99999)
@@ -701,6 +717,58 @@ func (s *NutritionPlanServiceSuite) TestStateTransitions() {
 	})
 }
 
+// stubDayTypes serves fixed day types to a plan's daily targets.
+type stubDayTypes map[string]domain.DayType
+
+func (d stubDayTypes) ListDayTypes(ctx context.Context, startDate, endDate string) (map[string]domain.DayType, error) {
+	return d, nil
+}
+
+func (s *NutritionPlanServiceSuite) TestDailyTargetsFollowDayTypes() {
+	s.createProfile()
+	plannedStore := store.NewPlannedDayTypeStore(s.db)
+	s.service.SetDayTypeLister(plannedStore)
+	s.service.SetClock(NewSimClock(s.now))
+
+	// The plan's first day is a performance day in the default pattern
+	s.Require().NoError(plannedStore.Upsert(s.ctx, &domain.PlannedDayType{Date: "2026-01-15", DayType: domain.DayTypeMetabolize}))
+	plan, err := s.service.Create(s.ctx, s.validInput(), s.now)
+	s.Require().NoError(err)
+
+	targets, err := s.service.DailyTargets(s.ctx, plan.ID, "2026-01-15", "2026-01-16")
+	s.Require().NoError(err)
+	s.Require().Len(targets, 2)
+	s.Equal(domain.DayTypeMetabolize, targets[0].DayType, "planned day type")
+	s.Equal(domain.DayTypeFatburner, targets[1].DayType, "default pattern")
+
+	s.Run("days planned later are picked up once refreshed", func() {
+		s.Require().NoError(plannedStore.Upsert(s.ctx, &domain.PlannedDayType{Date: "2026-01-16", DayType: domain.DayTypePerformance}))
+
+		targets, err := s.service.DailyTargets(s.ctx, plan.ID, "2026-01-16", "2026-01-16")
+		s.Require().NoError(err)
+		s.Equal(domain.DayTypeFatburner, targets[0].DayType, "lookup doesn't rewrite daily targets")
+
+		s.Require().NoError(s.service.RefreshDailyTargets(s.ctx, "2026-01-16"))
+
+		targets, err = s.service.DailyTargets(s.ctx, plan.ID, "2026-01-16", "2026-01-16")
+		s.Require().NoError(err)
+		s.Require().Len(targets, 1)
+		s.Equal(domain.DayTypePerformance, targets[0].DayType)
+	})
+}
+
+func (s *NutritionPlanServiceSuite) TestCreateRollsBackWhenDailyTargetsFail() {
+	s.createProfile()
+	// plan_daily_targets rejects unknown day types
+	s.service.SetDayTypeLister(stubDayTypes{"2026-01-15": domain.DayType("bogus")})
+
+	_, err := s.service.Create(s.ctx, s.validInput(), s.now)
+	s.Require().Error(err)
+
+	_, err = s.planStore.GetActive(s.ctx)
+	s.ErrorIs(err, store.ErrPlanNotFound, "the plan isn't saved without its daily targets")
+}
+
 func (s *NutritionPlanServiceSuite) TestGetCurrentWeekTargetWhenNoPlan() {
 	s.Run("returns error when no active plan exists", func() {
 		_, err := s.service.GetCurrentWeekTarget(s.ctx, s.now)
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// SessionTemplateService handles saved training session templates.
+type SessionTemplateService struct {
+	templateStore   *store.SessionTemplateStore
+	dailyLogService *DailyLogService
+}
+
+// NewSessionTemplateService creates a new SessionTemplateService.
+func NewSessionTemplateService(templateStore *store.SessionTemplateStore, dailyLogService *DailyLogService) *SessionTemplateService {
+	return &SessionTemplateService{
+		templateStore:   templateStore,
+		dailyLogService: dailyLogService,
+	}
+}
+
+// List returns all templates, favorites first.
+func (s *SessionTemplateService) List(ctx context.Context) ([]domain.SessionTemplate, error) {
+	return s.templateStore.List(ctx)
+}
+
+// Create validates and stores a template.
+func (s *SessionTemplateService) Create(ctx context.Context, t *domain.SessionTemplate) error {
+	if t.Exercises == nil {
+		t.Exercises = []domain.SessionTemplateExercise{}
+	}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	return s.templateStore.Create(ctx, t)
+}
+
+// CreateFromSession saves a session logged on date as a template. The actual
+// session with the given order is used, or the planned one when nothing was
+// logged as actual that day.
+func (s *SessionTemplateService) CreateFromSession(ctx context.Context, name, date string, sessionOrder int, favorite bool) (*domain.SessionTemplate, error) {
+	log, err := s.dailyLogService.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := log.ActualSessions
+	if len(sessions) == 0 {
+		sessions = log.PlannedSessions
+	}
+	for _, session := range sessions {
+		if session.SessionOrder == sessionOrder {
+			t := domain.NewSessionTemplateFromSession(name, session)
+			t.Favorite = favorite
+			if err := s.Create(ctx, t); err != nil {
+				return nil, err
+			}
+			return t, nil
+		}
+	}
+	return nil, domain.ErrSessionNotFoundOnDay
+}
+
+// SetFavorite marks or unmarks a template as a favorite.
+func (s *SessionTemplateService) SetFavorite(ctx context.Context, id int64, favorite bool) (*domain.SessionTemplate, error) {
+	if err := s.templateStore.SetFavorite(ctx, id, favorite); err != nil {
+		return nil, err
+	}
+	return s.templateStore.GetByID(ctx, id)
+}
+
+// Delete removes a template.
+func (s *SessionTemplateService) Delete(ctx context.Context, id int64) error {
+	return s.templateStore.Delete(ctx, id)
+}
+
+// Apply logs the template as a new actual session on date, after any
+// sessions already logged, and records the use.
+func (s *SessionTemplateService) Apply(ctx context.Context, date string, id int64, now time.Time) (*domain.DailyLog, error) {
+	t, err := s.templateStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	log, err := s.dailyLogService.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := append([]domain.TrainingSession(nil), log.ActualSessions...)
+	sessions = append(sessions, t.ToSession())
+	updated, err := s.dailyLogService.UpdateActualTraining(ctx, date, sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateStore.MarkUsed(ctx, id, now); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"time"
 
 	"victus/internal/domain"
 	"victus/internal/store"
@@ -13,6 +12,16 @@ type SolverService struct {
 	foodStore      *store.FoodReferenceStore
 	ollama         *OllamaService
 	fatigueService *FatigueService
+	feedbackStore  *store.SolverFeedbackStore // Optional: learned food preferences
+	satietyStore   *store.SatietyStore        // Optional: per-meal hunger ratings for satiety learning
+	priceStore     *store.FoodPriceStore      // Optional: solution cost estimates
+	bodyStatus     bodyStatusSource           // Optional: aggregated body status for the prompt
+	clocked
+}
+
+// bodyStatusSource provides today's aggregated body status.
+type bodyStatusSource interface {
+	GetBodyStatus(ctx context.Context) (*domain.BodyStatus, error)
 }
 
 // NewSolverService creates a new SolverService.
@@ -24,21 +33,50 @@ func NewSolverService(foodStore *store.FoodReferenceStore, ollama *OllamaService
 	}
 }
 
+// SetFeedbackStore enables learning food preferences from solver feedback.
+func (s *SolverService) SetFeedbackStore(feedbackStore *store.SolverFeedbackStore) {
+	s.feedbackStore = feedbackStore
+}
+
+// SetPriceStore enables cost estimates on solutions and filtering by cost.
+func (s *SolverService) SetPriceStore(priceStore *store.FoodPriceStore) {
+	s.priceStore = priceStore
+}
+
+// SetSatietyStore enables per-meal hunger ratings and satiety-aware scoring.
+// Satiety is learned from feedback logged against a meal, so it also needs
+// the feedback store.
+func (s *SolverService) SetSatietyStore(satietyStore *store.SatietyStore) {
+	s.satietyStore = satietyStore
+}
+
+// SetBodyStatusSource makes the solver's prompt read the aggregated body
+// status (issues and readiness included) instead of muscle fatigue alone.
+func (s *SolverService) SetBodyStatusSource(source bodyStatusSource) {
+	s.bodyStatus = source
+}
+
 // Solve finds meal combinations for the given macro budget.
 // Uses the pantry foods from the database and optionally generates
 // creative recipe names via Ollama.
 func (s *SolverService) Solve(ctx context.Context, budget domain.MacroBudget) (*domain.SolverResponse, error) {
-	return s.SolveWithContext(ctx, budget, nil)
+	return s.SolveWithContext(ctx, budget, nil, nil)
 }
 
 // SolveWithContext finds meal combinations with optional training context for semantic refinement.
 // When trainingCtx is provided, generates AI-enhanced recipe presentation with tactical names,
-// preparation instructions, and contextual insights.
+// preparation instructions, and contextual insights. When maxCost is set, only solutions that
+// can be fully priced at or below it are returned.
 func (s *SolverService) SolveWithContext(
 	ctx context.Context,
 	budget domain.MacroBudget,
 	trainingCtx *domain.TrainingContextForSolver,
+	maxCost *float64,
 ) (*domain.SolverResponse, error) {
+	if maxCost != nil && *maxCost <= 0 {
+		return nil, domain.ErrInvalidMaxCost
+	}
+
 	// Get pantry foods with nutritional data
 	pantry, err := s.foodStore.ListPantryFoods(ctx)
 	if err != nil {
@@ -51,12 +89,37 @@ func (s *SolverService) SolveWithContext(
 		}, nil
 	}
 
+	prefs, err := s.Preferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices domain.FoodPrices
+	if s.priceStore != nil {
+		prices, err = s.priceStore.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Determine meal time for protocol locking
 	mealTime := "any"
 	if trainingCtx != nil {
 		mealTime = trainingCtx.MealTime
 	}
 
+	// On fatburner days with hunger running high, favor filling foods
+	var satiety *domain.SatietyProfile
+	if trainingCtx != nil && trainingCtx.DayType == domain.DayTypeFatburner {
+		profile, err := s.Satiety(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil && profile.FavorsSatiety(trainingCtx.DayType) {
+			satiety = profile
+		}
+	}
+
 	// Build solver request
 	req := domain.SolverRequest{
 		RemainingBudget:  budget,
@@ -65,6 +128,10 @@ func (s *SolverService) SolveWithContext(
 		TolerancePercent: 0.10,
 		PantryFoods:      pantry,
 		MealTime:         mealTime,
+		Preferences:      prefs,
+		Satiety:          satiety,
+		Prices:           prices,
+		MaxCost:          maxCost,
 	}
 
 	// Run the solver algorithm
@@ -74,8 +141,14 @@ func (s *SolverService) SolveWithContext(
 	// Only generate AI refinement for the TOP solution to avoid frontend timeouts
 	// (3 solutions × 8s each = 24s, which can cause timeouts)
 	if s.ollama != nil && result.Computed && len(result.Solutions) > 0 {
-		// Get current body status from fatigue service
-		bodyStatus, err := s.fatigueService.GetBodyStatus(ctx, time.Now())
+		// Get current body status, aggregated when available
+		var bodyStatus *domain.BodyStatus
+		var err error
+		if s.bodyStatus != nil {
+			bodyStatus, err = s.bodyStatus.GetBodyStatus(ctx)
+		} else {
+			bodyStatus, err = s.fatigueService.GetBodyStatus(ctx, s.now())
+		}
 		if err != nil {
 			bodyStatus = nil // Gracefully handle errors; continue without body context
 		}
@@ -93,7 +166,7 @@ func (s *SolverService) SolveWithContext(
 		// For remaining solutions, use fast fallback refinement (no LLM call)
 		for i := 1; i < len(result.Solutions); i++ {
 			absurdity := domain.CheckAbsurdity(result.Solutions[i])
-			fallbackRefinement := BuildFallbackRefinement(result.Solutions[i], absurdity)
+			fallbackRefinement := BuildFallbackRefinement(result.Solutions[i], absurdity, i)
 			result.Solutions[i].Refinement = &fallbackRefinement
 			result.Solutions[i].RecipeName = fallbackRefinement.MissionTitle
 		}
@@ -101,3 +174,115 @@ func (s *SolverService) SolveWithContext(
 
 	return &result, nil
 }
+
+// SolverScoreItem is one food and amount in a hand-edited solution.
+type SolverScoreItem struct {
+	FoodID  int64
+	AmountG float64
+}
+
+// Score re-scores a hand-edited ingredient list against the budget, using the
+// same breakdown the solver reports.
+// Returns store.ErrFoodReferenceNotFound if an item isn't a pantry food.
+func (s *SolverService) Score(ctx context.Context, budget domain.MacroBudget, items []SolverScoreItem) (*domain.SolverSolution, error) {
+	pantry, err := s.foodStore.ListPantryFoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods := make(map[int64]domain.FoodNutrition, len(pantry))
+	for _, f := range pantry {
+		foods[f.ID] = f
+	}
+
+	ingredients := make([]domain.SolverIngredient, 0, len(items))
+	for _, item := range items {
+		food, ok := foods[item.FoodID]
+		if !ok {
+			return nil, store.ErrFoodReferenceNotFound
+		}
+		ingredients = append(ingredients, domain.SolverIngredient{Food: food, AmountG: item.AmountG})
+	}
+
+	prefs, err := s.Preferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := domain.ScoreIngredients(ingredients, budget, prefs)
+	if err != nil {
+		return nil, err
+	}
+	if s.priceStore != nil {
+		prices, err := s.priceStore.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(prices) > 0 {
+			cost := domain.EstimateIngredientCost(solution.Ingredients, prices)
+			solution.Cost = &cost
+		}
+	}
+	return &solution, nil
+}
+
+// RecordFeedback stores what the user did with a suggestion, one signal per food.
+// Feedback naming a meal without a date is taken to be for today.
+// Returns store.ErrFoodReferenceNotFound for an unknown food.
+func (s *SolverService) RecordFeedback(ctx context.Context, feedback domain.SolverFeedback) error {
+	if feedback.Meal != nil && feedback.Date == "" {
+		feedback.Date = s.now().Format("2006-01-02")
+	}
+	if err := feedback.Validate(); err != nil {
+		return err
+	}
+	if s.feedbackStore == nil {
+		return nil
+	}
+	return s.feedbackStore.RecordBatch(ctx, feedback.Signals(s.now()))
+}
+
+// Preferences returns the food preferences learned from recent feedback.
+// Returns nil when feedback isn't enabled.
+func (s *SolverService) Preferences(ctx context.Context) (domain.FoodPreferences, error) {
+	if s.feedbackStore == nil {
+		return nil, nil
+	}
+	now := s.now()
+	signals, err := s.feedbackStore.ListSince(ctx, now.AddDate(0, 0, -domain.SolverPreferenceWindowDays))
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildFoodPreferences(signals, now), nil
+}
+
+// RecordMealHunger stores the hunger rating that followed a meal, replacing
+// any earlier rating for that meal.
+func (s *SolverService) RecordMealHunger(ctx context.Context, hunger domain.MealHunger) error {
+	if err := hunger.Validate(); err != nil {
+		return err
+	}
+	if s.satietyStore == nil {
+		return nil
+	}
+	return s.satietyStore.UpsertMealHunger(ctx, hunger)
+}
+
+// Satiety returns the satiety profile learned from recent meal hunger
+// ratings and the foods logged in those meals.
+// Returns nil when satiety learning isn't enabled.
+func (s *SolverService) Satiety(ctx context.Context) (*domain.SatietyProfile, error) {
+	if s.satietyStore == nil || s.feedbackStore == nil {
+		return nil, nil
+	}
+	now := s.now()
+	since := now.AddDate(0, 0, -domain.SatietyWindowDays).Format("2006-01-02")
+	ratings, err := s.satietyStore.ListMealHungerSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	foods, err := s.feedbackStore.ListLoggedMealFoods(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	profile := domain.BuildSatietyProfile(ratings, foods, now)
+	return &profile, nil
+}
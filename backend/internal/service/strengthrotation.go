@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// rotationRecorder moves the strength rotation past a logged archetype.
+// Implemented by StrengthRotationService.
+type rotationRecorder interface {
+	Record(ctx context.Context, archetype domain.Archetype, date string) error
+}
+
+// rotationForecaster returns the archetypes expected for the next strength sessions.
+// Implemented by StrengthRotationService.
+type rotationForecaster interface {
+	Upcoming(ctx context.Context, n int) ([]domain.Archetype, error)
+}
+
+// StrengthRotationService tracks where the user is in their strength split.
+type StrengthRotationService struct {
+	store *store.StrengthRotationStore
+	clocked
+}
+
+// NewStrengthRotationService creates a new StrengthRotationService.
+func NewStrengthRotationService(rs *store.StrengthRotationStore) *StrengthRotationService {
+	return &StrengthRotationService{store: rs}
+}
+
+// Get returns the current strength rotation.
+func (s *StrengthRotationService) Get(ctx context.Context) (*domain.StrengthRotation, error) {
+	return s.store.Get(ctx)
+}
+
+// Upcoming returns the next n expected strength archetypes.
+func (s *StrengthRotationService) Upcoming(ctx context.Context, n int) ([]domain.Archetype, error) {
+	rotation, err := s.store.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rotation.Upcoming(n), nil
+}
+
+// Configure switches the split and sets the next expected archetype; an
+// empty archetype starts the split from the beginning.
+// Returns domain.ErrInvalidStrengthSplit or domain.ErrArchetypeNotInSplit.
+func (s *StrengthRotationService) Configure(ctx context.Context, split domain.StrengthSplit, next domain.Archetype) (*domain.StrengthRotation, error) {
+	rotation, err := s.store.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := rotation.Configure(split, next); err != nil {
+		return nil, err
+	}
+	rotation.UpdatedAt = s.now()
+	if err := s.store.Save(ctx, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// Record moves the rotation past an archetype logged on date. Archetypes
+// outside the split are ignored.
+func (s *StrengthRotationService) Record(ctx context.Context, archetype domain.Archetype, date string) error {
+	rotation, err := s.store.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if !rotation.Record(archetype, date) {
+		return nil
+	}
+	rotation.UpdatedAt = s.now()
+	return s.store.Save(ctx, rotation)
+}
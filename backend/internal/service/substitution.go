@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// SubstitutionService proposes fatigue-aware exercise swaps for today's
+// session and logs the decisions for the weekly debrief.
+type SubstitutionService struct {
+	programService    *TrainingProgramService
+	movementService   *MovementService
+	fatigueService    *FatigueService
+	substitutionStore *store.SubstitutionStore
+	clocked
+}
+
+// NewSubstitutionService creates a new SubstitutionService.
+func NewSubstitutionService(ps *TrainingProgramService, ms *MovementService, fs *FatigueService, ss *store.SubstitutionStore) *SubstitutionService {
+	return &SubstitutionService{
+		programService:    ps,
+		movementService:   ms,
+		fatigueService:    fs,
+		substitutionStore: ss,
+	}
+}
+
+// ProposeToday returns swaps for exercises in today's scheduled session that
+// target an overreached muscle. Without an active program or a session today
+// the proposal list is empty.
+func (s *SubstitutionService) ProposeToday(ctx context.Context) (*domain.TodaySubstitutions, error) {
+	now := s.now()
+	result := &domain.TodaySubstitutions{
+		Date:      now.Format("2006-01-02"),
+		Proposals: []domain.SubstitutionProposal{},
+	}
+
+	session, err := s.programService.TodaySession(ctx)
+	if errors.Is(err, store.ErrInstallationNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return result, nil
+	}
+	result.SessionLabel = session.Label
+
+	warmupInput, err := s.movementService.WarmupInput(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	archetypes, err := s.fatigueService.GetAllArchetypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bodyStatus, err := s.fatigueService.GetBodyStatus(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	// A limb overloaded on one side is judged by that side
+	fatigue := make(map[domain.MuscleGroup]float64, len(bodyStatus.Muscles))
+	overloaded := make(map[domain.MuscleGroup]domain.BodySide)
+	for _, m := range bodyStatus.Muscles {
+		fatigue[m.Muscle] = m.PeakPercent()
+		if m.OverloadedSide != "" {
+			overloaded[m.Muscle] = m.OverloadedSide
+		}
+	}
+
+	result.Proposals = domain.ProposeFatigueSubstitutions(session.SessionExercises, domain.FatigueSubstitutionInput{
+		Catalog:        warmupInput.Movements,
+		Archetypes:     archetypes,
+		Fatigue:        fatigue,
+		Equipment:      warmupInput.Equipment,
+		JointIntegrity: warmupInput.JointIntegrity,
+		Overloaded:     overloaded,
+	})
+	return result, nil
+}
+
+// RecordDecision logs whether a proposed swap was taken, dated today when
+// no date is given.
+func (s *SubstitutionService) RecordDecision(ctx context.Context, d domain.SubstitutionDecision) (*domain.SubstitutionDecision, error) {
+	if d.Date == "" {
+		d.Date = s.now().Format("2006-01-02")
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.substitutionStore.Create(ctx, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
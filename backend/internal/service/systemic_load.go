@@ -16,6 +16,7 @@ type SystemicLoadService struct {
 	dailyLogService *DailyLogService
 	fatigueService  *FatigueService
 	ollamaService   *OllamaService
+	clocked
 }
 
 // NewSystemicLoadService creates a new SystemicLoadService.
@@ -36,7 +37,7 @@ type SystemicLoadResponse struct {
 // GetSystemicLoad computes the dual-axis load from today's data.
 // Returns nil if no daily log exists for today.
 func (s *SystemicLoadService) GetSystemicLoad(ctx context.Context) (*domain.SystemicLoad, error) {
-	now := time.Now()
+	now := s.now()
 
 	// Read: get today's log (includes CNS, recovery score, sleep)
 	todayLog, err := s.dailyLogService.GetToday(ctx, now)
@@ -106,7 +107,7 @@ func (s *SystemicLoadService) generateSystemicRx(ctx context.Context, load domai
 	rxCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
 	defer cancel()
 
-	raw, err := s.ollamaService.Generate(rxCtx, prompt)
+	raw, err := s.ollamaService.Generate(rxCtx, domain.LLMFeatureSystemicRx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("ollama generate: %w", err)
 	}
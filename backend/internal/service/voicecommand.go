@@ -15,12 +15,38 @@ type VoiceActionTaken struct {
 	Summary string // Human-readable summary
 }
 
+// VoiceClarificationOutcome is a held command after an answer: pending with
+// the next question, resolved with what was persisted, or dismissed.
+type VoiceClarificationOutcome struct {
+	Clarification *domain.VoiceClarification
+	Action        *VoiceActionTaken // Set once resolved
+}
+
 // VoiceCommandService handles business logic for voice command processing.
 type VoiceCommandService struct {
 	ollamaService      *OllamaService
 	bodyIssueStore     *store.BodyIssueStore
 	dailyLogService    *DailyLogService
 	foodReferenceStore *store.FoodReferenceStore
+	clarifications     *store.VoiceClarificationStore
+	foodResolver       foodResolver               // Optional: synonym-aware matching (falls back to FindBestFoodMatch)
+	portionLearner     portionLearner             // Optional: learned default portions (falls back to 100g)
+	archetypes         archetypeInferrer          // Optional: infers archetypes and applies fatigue for logged sessions
+	plannerSessions    *store.PlannerSessionStore // Optional: executes PLANNING session moves
+	plannedDayTypes    *store.PlannedDayTypeStore // Optional: executes PLANNING day type changes
+	dailyTargets       dailyTargetRefresher       // Optional: follows planned day type changes
+	clocked
+}
+
+// foodResolver resolves spoken food names to food reference rows.
+type foodResolver interface {
+	MatchAll(ctx context.Context, queries []string) ([]domain.FoodMatchResult, error)
+}
+
+// portionLearner supplies personal default portions and learns from logged quantities.
+type portionLearner interface {
+	DefaultPortion(ctx context.Context, food domain.FoodNutrition, meal *domain.MealName) (domain.PortionDefault, error)
+	RecordPortion(ctx context.Context, entry domain.FoodPortionEntry) error
 }
 
 // NewVoiceCommandService creates a new VoiceCommandService.
@@ -29,17 +55,50 @@ func NewVoiceCommandService(
 	bodyIssueStore *store.BodyIssueStore,
 	dailyLogService *DailyLogService,
 	foodReferenceStore *store.FoodReferenceStore,
+	clarifications *store.VoiceClarificationStore,
 ) *VoiceCommandService {
 	return &VoiceCommandService{
 		ollamaService:      ollama,
 		bodyIssueStore:     bodyIssueStore,
 		dailyLogService:    dailyLogService,
 		foodReferenceStore: foodReferenceStore,
+		clarifications:     clarifications,
 	}
 }
 
+// SetFoodResolver enables synonym-aware food matching with serving-unit conversion.
+func (s *VoiceCommandService) SetFoodResolver(resolver foodResolver) {
+	s.foodResolver = resolver
+}
+
+// SetPortionLearner enables learned default portions for foods logged without a quantity.
+func (s *VoiceCommandService) SetPortionLearner(learner portionLearner) {
+	s.portionLearner = learner
+}
+
+// SetArchetypeInferrer enables archetype inference for voice-logged sessions,
+// so their fatigue is applied without the user picking an archetype.
+func (s *VoiceCommandService) SetArchetypeInferrer(inferrer archetypeInferrer) {
+	s.archetypes = inferrer
+}
+
+// SetPlanner enables PLANNING commands, which move planned sessions between
+// days and set planned day types once confirmed.
+func (s *VoiceCommandService) SetPlanner(sessions *store.PlannerSessionStore, dayTypes *store.PlannedDayTypeStore) {
+	s.plannerSessions = sessions
+	s.plannedDayTypes = dayTypes
+}
+
+// SetDailyTargetRefresher keeps the active plan's daily targets following the
+// day types PLANNING commands set.
+func (s *VoiceCommandService) SetDailyTargetRefresher(r dailyTargetRefresher) {
+	s.dailyTargets = r
+}
+
 // ProcessCommand parses raw voice input via Ollama and persists the result.
-// This is the main orchestration method (fire-and-forget safe).
+// This is the main orchestration method (fire-and-forget safe). A command
+// missing a critical field or parsed with low confidence is held for
+// clarification instead (see AnswerClarification).
 func (s *VoiceCommandService) ProcessCommand(ctx context.Context, rawInput, date string) {
 	// Parse voice command using Ollama (this is the slow part)
 	result, err := s.ollamaService.ParseVoiceCommand(ctx, rawInput)
@@ -55,6 +114,68 @@ func (s *VoiceCommandService) ProcessCommand(ctx context.Context, rawInput, date
 
 	log.Printf("[VOICE] Async parse complete: intent=%s", result.Intent)
 
+	if clarification := domain.NewVoiceClarification(date, *result); clarification != nil {
+		if err := s.clarifications.Create(ctx, clarification); err != nil {
+			log.Printf("[VOICE] Failed to hold command for clarification: %v", err)
+			return
+		}
+		log.Printf("[VOICE] Holding command %d for clarification: %s", clarification.ID, clarification.Question)
+		return
+	}
+
+	action := s.persistCommand(ctx, date, result)
+	if action != nil {
+		log.Printf("[VOICE] Async action completed: %s - %s", action.Type, action.Summary)
+	}
+}
+
+// PendingClarifications returns the held commands waiting for an answer,
+// oldest first.
+func (s *VoiceCommandService) PendingClarifications(ctx context.Context) ([]domain.VoiceClarification, error) {
+	return s.clarifications.ListPending(ctx)
+}
+
+// AnswerClarification merges the user's answer into a held command. Once
+// nothing more is missing the command is persisted for the day it was given.
+func (s *VoiceCommandService) AnswerClarification(ctx context.Context, id int64, answer string) (*VoiceClarificationOutcome, error) {
+	clarification, err := s.clarifications.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := clarification.Answer(answer); err != nil {
+		return nil, err
+	}
+	if err := s.clarifications.Update(ctx, clarification); err != nil {
+		return nil, err
+	}
+
+	outcome := &VoiceClarificationOutcome{Clarification: clarification}
+	if clarification.Status == domain.VoiceClarificationResolved {
+		outcome.Action = s.persistCommand(ctx, clarification.Date, &clarification.Command)
+	}
+	return outcome, nil
+}
+
+// DismissClarification drops a held command without persisting it.
+func (s *VoiceCommandService) DismissClarification(ctx context.Context, id int64) (*domain.VoiceClarification, error) {
+	clarification, err := s.clarifications.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if clarification.Status != domain.VoiceClarificationPending {
+		return nil, domain.ErrVoiceClarificationClosed
+	}
+	clarification.Status = domain.VoiceClarificationDismissed
+	clarification.Field, clarification.Question = "", ""
+	if err := s.clarifications.Update(ctx, clarification); err != nil {
+		return nil, err
+	}
+	return clarification, nil
+}
+
+// persistCommand stores the body issues named in a complete command and its
+// data.
+func (s *VoiceCommandService) persistCommand(ctx context.Context, date string, result *domain.VoiceCommandResult) *VoiceActionTaken {
 	// Extract body map updates if sensation is present
 	bodyMapUpdates := result.ExtractBodyMapUpdates()
 	if len(bodyMapUpdates) > 0 {
@@ -62,10 +183,7 @@ func (s *VoiceCommandService) ProcessCommand(ctx context.Context, rawInput, date
 	}
 
 	// Persist the parsed data based on intent
-	action := s.persistVoiceData(ctx, date, result)
-	if action != nil {
-		log.Printf("[VOICE] Async action completed: %s - %s", action.Type, action.Summary)
-	}
+	return s.persistVoiceData(ctx, date, result)
 }
 
 // persistVoiceData persists the parsed voice command data based on intent.
@@ -77,6 +195,8 @@ func (s *VoiceCommandService) persistVoiceData(ctx context.Context, date string,
 		return s.persistTraining(ctx, date, result.Training)
 	case domain.VoiceIntentBiometrics:
 		return s.persistBiometrics(ctx, date, result.Biometrics)
+	case domain.VoiceIntentPlanning:
+		return s.executePlanning(ctx, result.Planning)
 	}
 	return nil
 }
@@ -87,30 +207,33 @@ func (s *VoiceCommandService) persistNutrition(ctx context.Context, date string,
 		return nil
 	}
 
-	// Get all foods from database for fuzzy matching
-	var allFoods []domain.FoodNutrition
-	if s.foodReferenceStore != nil {
-		foods, err := s.foodReferenceStore.ListPantryFoods(ctx)
-		if err != nil {
-			log.Printf("[VOICE] Failed to load food reference: %v", err)
-		} else {
-			allFoods = foods
-		}
+	foods, ambiguous := s.resolveNutritionItems(ctx, data.Items)
+
+	meal := data.Meal
+	if meal == nil {
+		meal = domain.MealForHour(s.now().Hour())
 	}
 
 	// Calculate total macros from all items
 	var totalCalories, totalProtein, totalCarbs, totalFat float64
 	var loggedItems []string
 
-	for _, item := range data.Items {
-		food := domain.FindBestFoodMatch(item.Food, allFoods)
+	for i, item := range data.Items {
+		food := foods[i]
 
-		// Default quantity to 100g if not specified
+		// Default quantity to the learned portion (or 100g) if not specified
 		var quantityG float64 = 100
-		if item.Quantity != nil && item.Unit != nil {
-			quantityG = domain.ConvertToGrams(*item.Quantity, *item.Unit)
-		} else if item.Quantity != nil {
-			quantityG = *item.Quantity
+		if item.Quantity != nil {
+			unit := ""
+			if item.Unit != nil {
+				unit = *item.Unit
+			}
+			quantityG = domain.ServingToGrams(*item.Quantity, unit, food)
+			if food != nil && !ambiguous[i] {
+				s.recordPortion(ctx, date, meal, food, quantityG)
+			}
+		} else if food != nil {
+			quantityG = s.defaultPortion(ctx, meal, food)
 		}
 
 		if food != nil {
@@ -120,7 +243,11 @@ func (s *VoiceCommandService) persistNutrition(ctx context.Context, date string,
 			totalFat += food.FatGPer100 * multiplier
 			itemCals := (food.ProteinGPer100*4 + food.CarbsGPer100*4 + food.FatGPer100*9) * multiplier
 			totalCalories += itemCals
-			loggedItems = append(loggedItems, item.Food)
+			if ambiguous[i] {
+				loggedItems = append(loggedItems, item.Food+" (confirm match: "+food.FoodItem+")")
+			} else {
+				loggedItems = append(loggedItems, item.Food)
+			}
 			log.Printf("[VOICE] Matched food '%s' -> %s (%.0fg): %.0f cal", item.Food, food.FoodItem, quantityG, itemCals)
 		} else {
 			// Use default estimates for unknown foods
@@ -161,6 +288,78 @@ func (s *VoiceCommandService) persistNutrition(ctx context.Context, date string,
 	return nil
 }
 
+// defaultPortion returns the learned portion for a food logged without a quantity.
+func (s *VoiceCommandService) defaultPortion(ctx context.Context, meal *domain.MealName, food *domain.FoodNutrition) float64 {
+	if s.portionLearner == nil {
+		return domain.PortionFallbackG
+	}
+	portion, err := s.portionLearner.DefaultPortion(ctx, *food, meal)
+	if err != nil {
+		log.Printf("[VOICE] Failed to load portion history for %s: %v", food.FoodItem, err)
+		return domain.PortionFallbackG
+	}
+	log.Printf("[VOICE] No quantity for %s - using %s default %.0fg", food.FoodItem, portion.Source, portion.QuantityG)
+	return portion.QuantityG
+}
+
+// recordPortion stores an explicitly stated quantity for learning defaults.
+// Defaulted portions are not recorded so the default cannot reinforce itself.
+func (s *VoiceCommandService) recordPortion(ctx context.Context, date string, meal *domain.MealName, food *domain.FoodNutrition, quantityG float64) {
+	if s.portionLearner == nil || quantityG <= 0 {
+		return
+	}
+	entry := domain.FoodPortionEntry{
+		FoodReferenceID: food.ID,
+		Meal:            meal,
+		QuantityG:       quantityG,
+		Date:            date,
+	}
+	if err := s.portionLearner.RecordPortion(ctx, entry); err != nil {
+		log.Printf("[VOICE] Failed to record portion for %s: %v", food.FoodItem, err)
+	}
+}
+
+// resolveNutritionItems matches each spoken item to a food (nil when unknown)
+// and reports which matches the user should confirm.
+func (s *VoiceCommandService) resolveNutritionItems(ctx context.Context, items []domain.NutritionItem) ([]*domain.FoodNutrition, []bool) {
+	foods := make([]*domain.FoodNutrition, len(items))
+	ambiguous := make([]bool, len(items))
+
+	if s.foodResolver != nil {
+		queries := make([]string, len(items))
+		for i, item := range items {
+			queries[i] = item.Food
+		}
+		results, err := s.foodResolver.MatchAll(ctx, queries)
+		if err == nil {
+			for i, r := range results {
+				if r.Best != nil {
+					food := r.Best.Food
+					foods[i] = &food
+					ambiguous[i] = r.Ambiguous
+				}
+			}
+			return foods, ambiguous
+		}
+		log.Printf("[VOICE] Food resolver failed, falling back to name match: %v", err)
+	}
+
+	// Get all foods from database for fuzzy matching
+	var allFoods []domain.FoodNutrition
+	if s.foodReferenceStore != nil {
+		list, err := s.foodReferenceStore.ListPantryFoods(ctx)
+		if err != nil {
+			log.Printf("[VOICE] Failed to load food reference: %v", err)
+		} else {
+			allFoods = list
+		}
+	}
+	for i, item := range items {
+		foods[i] = domain.FindBestFoodMatch(item.Food, allFoods)
+	}
+	return foods, ambiguous
+}
+
 // persistTraining adds a training session to the daily log.
 func (s *VoiceCommandService) persistTraining(ctx context.Context, date string, data *domain.TrainingVoiceData) *VoiceActionTaken {
 	if data == nil {
@@ -207,6 +406,12 @@ func (s *VoiceCommandService) persistTraining(ctx context.Context, date string,
 
 	log.Printf("[VOICE] Added training: %s for %d min", session.Type, session.DurationMin)
 
+	if s.archetypes != nil {
+		if _, err := s.archetypes.InferForDate(ctx, date); err != nil {
+			log.Printf("[VOICE] Archetype inference failed for %s: %v", date, err)
+		}
+	}
+
 	return &VoiceActionTaken{
 		Type:    "training_logged",
 		Summary: data.Activity,
@@ -237,6 +442,68 @@ func (s *VoiceCommandService) persistBiometrics(ctx context.Context, date string
 	}
 }
 
+// executePlanning applies a confirmed planning command to the planner.
+func (s *VoiceCommandService) executePlanning(ctx context.Context, data *domain.PlanningVoiceData) *VoiceActionTaken {
+	if data == nil || !data.Confirmed {
+		return nil
+	}
+	if s.plannerSessions == nil || s.plannedDayTypes == nil {
+		log.Printf("[VOICE] Planning command received but planner is not configured")
+		return nil
+	}
+
+	switch data.Action {
+	case domain.PlanningActionSetDayType:
+		if err := s.plannedDayTypes.Upsert(ctx, &domain.PlannedDayType{Date: data.Day, DayType: data.DayType}); err != nil {
+			log.Printf("[VOICE] Failed to set planned day type for %s: %v", data.Day, err)
+			return nil
+		}
+		log.Printf("[VOICE] Planned %s as %s", data.Day, data.DayType)
+		refreshDailyTargets(ctx, s.dailyTargets, data.Day)
+		return &VoiceActionTaken{
+			Type:    "day_type_planned",
+			Summary: data.Summary(),
+		}
+
+	case domain.PlanningActionMoveSession:
+		if data.Day == data.TargetDay {
+			return &VoiceActionTaken{Type: "planning_skipped", Summary: "already planned for " + data.Day}
+		}
+		from, err := s.plannerSessions.GetByDate(ctx, data.Day)
+		if err != nil {
+			log.Printf("[VOICE] Failed to load planned sessions for %s: %v", data.Day, err)
+			return nil
+		}
+		to, err := s.plannerSessions.GetByDate(ctx, data.TargetDay)
+		if err != nil {
+			log.Printf("[VOICE] Failed to load planned sessions for %s: %v", data.TargetDay, err)
+			return nil
+		}
+		fromPlan, toPlan, moved := domain.MovePlannedSessions(from, to, data.SessionType, data.TargetDay)
+		if moved == 0 {
+			return &VoiceActionTaken{
+				Type:    "planning_skipped",
+				Summary: "no matching session planned for " + data.Day,
+			}
+		}
+		// Add to the target day first so a failure can't drop the session
+		if err := s.plannerSessions.UpsertForDate(ctx, data.TargetDay, toPlan); err != nil {
+			log.Printf("[VOICE] Failed to add moved sessions to %s: %v", data.TargetDay, err)
+			return nil
+		}
+		if err := s.plannerSessions.UpsertForDate(ctx, data.Day, fromPlan); err != nil {
+			log.Printf("[VOICE] Failed to remove moved sessions from %s: %v", data.Day, err)
+			return nil
+		}
+		log.Printf("[VOICE] Moved %d planned session(s) from %s to %s", moved, data.Day, data.TargetDay)
+		return &VoiceActionTaken{
+			Type:    "session_moved",
+			Summary: data.Summary(),
+		}
+	}
+	return nil
+}
+
 // persistBodyIssues creates body issues from body map updates.
 func (s *VoiceCommandService) persistBodyIssues(ctx context.Context, date string, updates []domain.BodyMapUpdate) {
 	if s.bodyIssueStore == nil {
@@ -252,6 +519,7 @@ func (s *VoiceCommandService) persistBodyIssues(ctx context.Context, date string
 					BodyPart: mg,
 					Symptom:  update.Symptom,
 					RawText:  update.RawText,
+					Side:     domain.SideForAlias(update.BodyPart, mg),
 				}
 				input.ResolveSeverity()
 				if _, err := s.bodyIssueStore.Create(ctx, input); err != nil {
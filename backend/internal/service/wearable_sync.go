@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// WearableSyncService imports Garmin Connect and Apple Health export payloads:
+// day metrics into daily logs and activities into actual training sessions.
+// Each imported record is remembered per source, so re-sent exports are skipped.
+type WearableSyncService struct {
+	dailyLogStore *store.DailyLogStore
+	sessionStore  *store.TrainingSessionStore
+	importStore   *store.WearableImportStore
+	reconciler    lateDataReconciler
+	clocked
+}
+
+// NewWearableSyncService creates a new WearableSyncService.
+func NewWearableSyncService(ds *store.DailyLogStore, ss *store.TrainingSessionStore, is *store.WearableImportStore) *WearableSyncService {
+	return &WearableSyncService{dailyLogStore: ds, sessionStore: ss, importStore: is}
+}
+
+// SetReconciler enables late-data reconciliation for imports of past days.
+func (s *WearableSyncService) SetReconciler(r lateDataReconciler) {
+	s.reconciler = r
+}
+
+// Sync imports a payload. Days are applied before activities, so a day's
+// metrics can create the log its activities attach to. Invalid or failed
+// records are reported in the result without stopping the rest; only an
+// invalid payload as a whole returns an error.
+func (s *WearableSyncService) Sync(ctx context.Context, payload domain.WearablePayload) (*domain.WearableSyncResult, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &domain.WearableSyncResult{Source: payload.Source}
+	lateFields := make(map[string][]domain.LateDataField)
+
+	for _, day := range payload.Days {
+		imported, err := s.importDay(ctx, payload.Source, day)
+		switch {
+		case err != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("day %s: %v", day.Date, err))
+		case imported:
+			result.DaysImported++
+			lateFields[day.Date] = append(lateFields[day.Date], day.LateDataFields()...)
+		default:
+			result.DaysSkipped++
+		}
+	}
+
+	dates, byDate := domain.GroupWearableActivitiesByDate(payload.Activities)
+	for _, date := range dates {
+		s.importActivities(ctx, payload.Source, date, byDate[date], result)
+	}
+
+	// Device data for past days arrives late; patch what depended on it
+	if s.reconciler != nil {
+		for _, day := range payload.Days {
+			fields := lateFields[day.Date]
+			if len(fields) == 0 {
+				continue
+			}
+			delete(lateFields, day.Date)
+			rec, err := s.reconciler.ReconcileDate(ctx, day.Date, fields, s.now())
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("reconciliation %s: %v", day.Date, err))
+			} else if rec != nil {
+				result.ReconciledDates = append(result.ReconciledDates, day.Date)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// importDay applies a day's metrics, returning false when the same values were
+// already imported from this source.
+func (s *WearableSyncService) importDay(ctx context.Context, source domain.WearableSource, day domain.WearableDay) (bool, error) {
+	if err := day.Validate(); err != nil {
+		return false, err
+	}
+	fingerprint := day.Fingerprint()
+	previous, found, err := s.importStore.GetFingerprint(ctx, source, domain.WearableRecordDay, day.Date)
+	if err != nil {
+		return false, err
+	}
+	if found && previous == fingerprint {
+		return false, nil
+	}
+
+	// Sleep, HRV and RHR upsert, creating the day's log when missing
+	if day.HasSleepData() {
+		sd := store.SleepData{
+			SleepQuality:     day.SleepScore,
+			SleepHours:       day.SleepHours,
+			RestingHeartRate: day.RestingHR,
+			HRVMs:            day.HRVMs,
+		}
+		if err := s.dailyLogStore.UpdateSleepData(ctx, day.Date, sd); err != nil {
+			return false, err
+		}
+	}
+	if day.ActiveCalories != nil {
+		if err := s.dailyLogStore.UpdateActiveCaloriesBurned(ctx, day.Date, day.ActiveCalories); err != nil {
+			if errors.Is(err, store.ErrDailyLogNotFound) {
+				return false, errors.New("no daily log for active calories; log the day first")
+			}
+			return false, err
+		}
+	}
+
+	return true, s.importStore.Record(ctx, domain.WearableImportRecord{
+		Source:      source,
+		Kind:        domain.WearableRecordDay,
+		ExternalID:  day.Date,
+		Date:        day.Date,
+		Fingerprint: fingerprint,
+		ImportedAt:  s.now(),
+	})
+}
+
+// importActivities appends a day's new activities to its actual sessions.
+// Activities already imported from this source, or matching a session the day
+// already has, are skipped.
+func (s *WearableSyncService) importActivities(ctx context.Context, source domain.WearableSource, date string, activities []domain.WearableActivity, result *domain.WearableSyncResult) {
+	logID, err := s.dailyLogStore.GetIDByDate(ctx, date)
+	if errors.Is(err, store.ErrDailyLogNotFound) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("no daily log for %s; %d activities not imported", date, len(activities)))
+		return
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("activities %s: %v", date, err))
+		return
+	}
+	existing, err := s.sessionStore.GetActualByLogID(ctx, logID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("activities %s: %v", date, err))
+		return
+	}
+
+	var added []domain.TrainingSession
+	var records []domain.WearableImportRecord
+	for _, a := range activities {
+		if err := a.Validate(); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("activity %s: %v", a.Key(), err))
+			continue
+		}
+		_, found, err := s.importStore.GetFingerprint(ctx, source, domain.WearableRecordActivity, a.Key())
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("activity %s: %v", a.Key(), err))
+			continue
+		}
+		if found || a.DuplicatesSession(source, append(existing, added...)) {
+			result.ActivitiesSkipped++
+			continue
+		}
+
+		session := a.ToSession(source)
+		session.SessionOrder = len(existing) + len(added) + 1
+		added = append(added, session)
+		records = append(records, domain.WearableImportRecord{
+			Source:      source,
+			Kind:        domain.WearableRecordActivity,
+			ExternalID:  a.Key(),
+			Date:        date,
+			Fingerprint: a.Fingerprint(),
+			ImportedAt:  s.now(),
+		})
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	if err := domain.ValidateTrainingSessions(append(existing, added...)); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("activities %s: %v", date, err))
+		return
+	}
+	if err := s.sessionStore.CreateForLog(ctx, logID, added); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("activities %s: %v", date, err))
+		return
+	}
+	for _, rec := range records {
+		if err := s.importStore.Record(ctx, rec); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("activity %s: %v", rec.ExternalID, err))
+		}
+	}
+	result.ActivitiesImported += len(added)
+}
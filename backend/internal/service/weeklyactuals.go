@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// weeklyRollup refreshes the plan week's actuals after a day's log changed.
+// Implemented by WeeklyActualsService.
+type weeklyRollup interface {
+	RollupDate(ctx context.Context, date string) (*int, error)
+}
+
+// weeklyActualsRollupHour is the local hour the nightly rollup runs.
+const weeklyActualsRollupHour = 3
+
+// WeeklyActualsService keeps the actual weight, intake and days logged of the
+// active plan's weekly targets in step with the daily logs. Weeks that began
+// before logging did are prorated to the days that could have been logged.
+type WeeklyActualsService struct {
+	planStore *store.NutritionPlanStore
+	logStore  *store.DailyLogStore
+	jobs      *JobMonitor
+	clocked
+}
+
+// NewWeeklyActualsService creates a new WeeklyActualsService.
+func NewWeeklyActualsService(ps *store.NutritionPlanStore, ls *store.DailyLogStore) *WeeklyActualsService {
+	return &WeeklyActualsService{planStore: ps, logStore: ls}
+}
+
+// SetJobMonitor enables heartbeats for the nightly rollup.
+func (s *WeeklyActualsService) SetJobMonitor(m *JobMonitor) {
+	s.jobs = m
+}
+
+// RollupDate recomputes the actuals of the active plan's week containing
+// date. Returns the week number, or nil if no active plan covers the date.
+func (s *WeeklyActualsService) RollupDate(ctx context.Context, date string) (*int, error) {
+	plan, err := s.planStore.GetActive(ctx)
+	if errors.Is(err, store.ErrPlanNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	week := plan.WeekContaining(date)
+	if week == nil {
+		return nil, nil
+	}
+	trackingStart, err := s.trackingStart(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rollupWeek(ctx, plan.ID, *week, trackingStart); err != nil {
+		return nil, err
+	}
+	return &week.WeekNumber, nil
+}
+
+// RollupActivePlan recomputes the actuals of every week of the active plan
+// that has started by now. Returns the number of weeks updated.
+func (s *WeeklyActualsService) RollupActivePlan(ctx context.Context, now time.Time) (int, error) {
+	plan, err := s.planStore.GetActive(ctx)
+	if errors.Is(err, store.ErrPlanNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	trackingStart, err := s.trackingStart(ctx, plan)
+	if err != nil {
+		return 0, err
+	}
+
+	today := now.Format("2006-01-02")
+	updated := 0
+	for _, week := range plan.WeeklyTargets {
+		if week.StartDate.Format("2006-01-02") > today {
+			break
+		}
+		if err := s.rollupWeek(ctx, plan.ID, week, trackingStart); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// rollupWeek saves a week's actuals, prorating the days it could have been
+// logged to those on or after trackingStart.
+func (s *WeeklyActualsService) rollupWeek(ctx context.Context, planID int64, week domain.WeeklyTarget, trackingStart time.Time) error {
+	actuals, err := s.logStore.GetWeeklyActuals(ctx, week.StartDate.Format("2006-01-02"), week.EndDate.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	actuals.DaysExpected = week.ExpectedDays(trackingStart)
+	return s.planStore.UpdateWeeklyRollup(ctx, planID, week.WeekNumber, actuals)
+}
+
+// trackingStart finds the first day the plan's logging is expected, from when
+// it was created and the first day logged within it.
+func (s *WeeklyActualsService) trackingStart(ctx context.Context, plan *domain.NutritionPlan) (time.Time, error) {
+	firstLog, err := s.logStore.GetFirstDateFrom(ctx, plan.StartDate.Format("2006-01-02"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return domain.PlanTrackingStart(plan, firstLog), nil
+}
+
+// RunNightlySchedule blocks until ctx is cancelled, rolling up the active
+// plan's actuals once at startup and then nightly at weeklyActualsRollupHour.
+// The nightly pass catches log writes that bypass RollupDate, such as
+// wearable syncs and imports.
+func (s *WeeklyActualsService) RunNightlySchedule(ctx context.Context) {
+	s.jobs.Start(ctx, "weekly_actuals_rollup", 24*time.Hour, time.Now())
+
+	for {
+		if s.jobs.ShouldRun(ctx, "weekly_actuals_rollup", time.Now()) {
+			updated, err := s.RollupActivePlan(ctx, s.now())
+			s.jobs.Beat("weekly_actuals_rollup", time.Now(), err)
+			if err != nil {
+				log.Printf("weekly actuals: rollup failed: %v", err)
+			} else if updated > 0 {
+				log.Printf("weekly actuals: rolled up %d plan weeks", updated)
+			}
+		}
+
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), weeklyActualsRollupHour, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.Add(24 * time.Hour)
+		}
+		select {
+		case <-time.After(next.Sub(now)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
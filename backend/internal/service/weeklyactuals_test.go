@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+	"victus/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: The rollup runs silently from log writes and a nightly job;
+// a regression would leave plan weeks with stale actuals and nothing failing.
+type WeeklyActualsServiceSuite struct {
+	suite.Suite
+	pg        *testutil.PostgresContainer
+	db        *sql.DB
+	planStore *store.NutritionPlanStore
+	logStore  *store.DailyLogStore
+	service   *WeeklyActualsService
+	plan      *domain.NutritionPlan
+	ctx       context.Context
+}
+
+func TestWeeklyActualsServiceSuite(t *testing.T) {
+	suite.Run(t, new(WeeklyActualsServiceSuite))
+}
+
+func (s *WeeklyActualsServiceSuite) SetupSuite() {
+	s.pg = testutil.SetupPostgres(s.T())
+	s.db = s.pg.DB
+}
+
+func (s *WeeklyActualsServiceSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.Require().NoError(s.pg.ClearTables(s.ctx))
+
+	profileStore := store.NewProfileStore(s.db)
+	s.planStore = store.NewNutritionPlanStore(s.db)
+	s.logStore = store.NewDailyLogStore(s.db)
+	s.service = NewWeeklyActualsService(s.planStore, s.logStore)
+
+	s.Require().NoError(profileStore.Upsert(s.ctx, &domain.UserProfile{
+		HeightCM:             180,
+		BirthDate:            time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sex:                  domain.SexMale,
+		Goal:                 domain.GoalLoseWeight,
+		TargetWeightKg:       85,
+		TargetWeeklyChangeKg: -0.5,
+		CarbRatio:            0.45,
+		ProteinRatio:         0.30,
+		FatRatio:             0.25,
+		MealRatios:           domain.MealRatios{Breakfast: 0.30, Lunch: 0.30, Dinner: 0.40},
+		PointsConfig:         domain.PointsConfig{CarbMultiplier: 1.15, ProteinMultiplier: 4.35, FatMultiplier: 3.5},
+		CurrentWeightKg:      90,
+	}))
+	plan, err := NewNutritionPlanService(s.planStore, profileStore).Create(s.ctx, domain.NutritionPlanInput{
+		StartDate:     "2026-01-12", // A Monday
+		StartWeightKg: 90,
+		GoalWeightKg:  86,
+		DurationWeeks: 8,
+	}, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.plan = plan
+}
+
+// logDay stores a log with a weigh-in and the calories eaten.
+func (s *WeeklyActualsServiceSuite) logDay(date string, weightKg float64, kcal int) {
+	_, err := s.logStore.Create(s.ctx, &domain.DailyLog{
+		Date:         date,
+		WeightKg:     weightKg,
+		SleepQuality: 80,
+		DayType:      domain.DayTypeFatburner,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.logStore.AddConsumedMacros(s.ctx, date, store.ConsumedMacros{Calories: kcal}))
+}
+
+// week returns the stored plan week.
+func (s *WeeklyActualsServiceSuite) week(number int) domain.WeeklyTarget {
+	plan, err := s.planStore.GetByID(s.ctx, s.plan.ID)
+	s.Require().NoError(err)
+	s.Require().GreaterOrEqual(len(plan.WeeklyTargets), number)
+	return plan.WeeklyTargets[number-1]
+}
+
+func (s *WeeklyActualsServiceSuite) TestRollupDateUpdatesItsWeek() {
+	s.logDay("2026-01-12", 90, 2000)
+	s.logDay("2026-01-13", 89.6, 2200)
+	s.logDay("2026-01-20", 89, 1800)
+
+	week, err := s.service.RollupDate(s.ctx, "2026-01-13")
+	s.Require().NoError(err)
+	s.Require().NotNil(week)
+	s.Equal(1, *week)
+
+	first := s.week(1)
+	s.Require().NotNil(first.ActualWeightKg)
+	s.Equal(89.8, *first.ActualWeightKg)
+	s.Require().NotNil(first.ActualIntakeKcal)
+	s.Equal(2100, *first.ActualIntakeKcal)
+	s.Equal(2, first.DaysLogged)
+	s.Equal(7, first.DaysExpected, "logging started on the plan's first day")
+
+	second := s.week(2)
+	s.Nil(second.ActualWeightKg, "only the changed day's week is rolled up")
+	s.Zero(second.DaysLogged)
+}
+
+func (s *WeeklyActualsServiceSuite) TestRollupActivePlanCoversStartedWeeks() {
+	s.logDay("2026-01-12", 90, 2000)
+	s.logDay("2026-01-20", 89, 1800)
+
+	updated, err := s.service.RollupActivePlan(s.ctx, time.Date(2026, 1, 21, 3, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Equal(2, updated, "weeks 1 and 2 have started")
+
+	s.Equal(1, s.week(1).DaysLogged)
+	s.Require().NotNil(s.week(2).ActualIntakeKcal)
+	s.Equal(1800, *s.week(2).ActualIntakeKcal)
+	s.Nil(s.week(3).ActualIntakeKcal, "future weeks are left alone")
+}
+
+func (s *WeeklyActualsServiceSuite) TestNoActivePlanIsANoOp() {
+	s.logDay("2026-01-12", 90, 2000)
+	s.Require().NoError(s.planStore.UpdateStatus(s.ctx, s.plan.ID, domain.PlanStatusCompleted))
+
+	week, err := s.service.RollupDate(s.ctx, "2026-01-12")
+	s.Require().NoError(err)
+	s.Nil(week)
+
+	updated, err := s.service.RollupActivePlan(s.ctx, time.Date(2026, 1, 21, 3, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Zero(updated)
+	s.Zero(s.week(1).DaysLogged)
+}
+
+func (s *WeeklyActualsServiceSuite) TestDateOutsidePlanRange() {
+	s.logDay("2026-01-05", 91, 2400)
+
+	week, err := s.service.RollupDate(s.ctx, "2026-01-05")
+	s.Require().NoError(err)
+	s.Nil(week, "before the plan starts")
+
+	week, err = s.service.RollupDate(s.ctx, "2026-03-09")
+	s.Require().NoError(err)
+	s.Nil(week, "after the last week ends")
+	s.Zero(s.week(1).DaysLogged)
+}
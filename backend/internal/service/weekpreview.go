@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+	"victus/internal/store"
+)
+
+// weekPreviewWeightLookbackDays bounds how far back to look for a weight to project targets.
+const weekPreviewWeightLookbackDays = 30
+
+// WeekPreviewService builds forward-looking previews of the coming training week.
+type WeekPreviewService struct {
+	profileStore        *store.ProfileStore
+	dailyLogStore       *store.DailyLogStore
+	sessionStore        *store.TrainingSessionStore
+	plannedDayTypeStore *store.PlannedDayTypeStore
+	plannerSessionStore *store.PlannerSessionStore
+	programStore        *store.TrainingProgramStore
+}
+
+// NewWeekPreviewService creates a new WeekPreviewService.
+func NewWeekPreviewService(
+	ps *store.ProfileStore,
+	dls *store.DailyLogStore,
+	ss *store.TrainingSessionStore,
+	pdts *store.PlannedDayTypeStore,
+	pss *store.PlannerSessionStore,
+	prs *store.TrainingProgramStore,
+) *WeekPreviewService {
+	return &WeekPreviewService{
+		profileStore:        ps,
+		dailyLogStore:       dls,
+		sessionStore:        ss,
+		plannedDayTypeStore: pdts,
+		plannerSessionStore: pss,
+		programStore:        prs,
+	}
+}
+
+// getNextWeekStart returns the Monday after now. On Sunday this is tomorrow.
+func getNextWeekStart(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (8 - int(day.Weekday())) % 7
+	if offset == 0 {
+		offset = 7
+	}
+	return day.AddDate(0, 0, offset)
+}
+
+// GetPreview builds the 7-day preview starting at weekStart.
+// If weekStart is zero, previews the coming week (next Monday).
+func (s *WeekPreviewService) GetPreview(ctx context.Context, weekStart, now time.Time) (*domain.WeekPreview, error) {
+	if weekStart.IsZero() {
+		weekStart = getNextWeekStart(now)
+	}
+	startDate := weekStart.Format("2006-01-02")
+	endDate := weekStart.AddDate(0, 0, 6).Format("2006-01-02")
+
+	profile, err := s.profileStore.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weightKg, err := s.latestWeight(ctx, profile, now)
+	if err != nil {
+		return nil, err
+	}
+
+	plannedDays, err := s.plannedDayTypeStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	plannerSessions, err := s.plannerSessionStore.ListByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled []domain.ScheduledSession
+	installation, err := s.programStore.GetActiveInstallation(ctx)
+	if err != nil && !errors.Is(err, store.ErrInstallationNotFound) {
+		return nil, err
+	}
+	if installation != nil {
+		scheduled = installation.GetScheduledSessions()
+	}
+
+	// Seed the ACR trajectory with the 28 days before the preview
+	historyStart := weekStart.AddDate(0, 0, -28).Format("2006-01-02")
+	historyEnd := weekStart.AddDate(0, 0, -1).Format("2006-01-02")
+	sessionsData, err := s.sessionStore.GetSessionsForDateRange(ctx, historyStart, historyEnd)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]domain.DailyLoadDataPoint, len(sessionsData))
+	for i, sd := range sessionsData {
+		history[i] = domain.DailyLoadDataPoint{
+			Date:      sd.Date,
+			DailyLoad: domain.DailyLoad(sd.ActualSessions, sd.PlannedSessions),
+		}
+	}
+
+	days := buildWeekPreviewInputs(weekStart, plannedDays, plannerSessions, scheduled)
+	preview := domain.BuildWeekPreview(profile, weightKg, days, history, now)
+	return &preview, nil
+}
+
+// latestWeight returns the most recent logged weight, falling back to the profile's
+// current weight when no recent logs exist.
+func (s *WeekPreviewService) latestWeight(ctx context.Context, profile *domain.UserProfile, now time.Time) (float64, error) {
+	startDate := now.AddDate(0, 0, -weekPreviewWeightLookbackDays).Format("2006-01-02")
+	samples, err := s.dailyLogStore.ListWeights(ctx, startDate)
+	if err != nil {
+		return 0, err
+	}
+	if len(samples) == 0 {
+		if profile.CurrentWeightKg > 0 {
+			return profile.CurrentWeightKg, nil
+		}
+		return 0, domain.ErrInsufficientWeightData
+	}
+	return samples[len(samples)-1].WeightKg, nil
+}
+
+// buildWeekPreviewInputs groups the raw schedule sources into one input per day.
+func buildWeekPreviewInputs(
+	weekStart time.Time,
+	plannedDays []domain.PlannedDayType,
+	plannerSessions []domain.PlannerSession,
+	scheduled []domain.ScheduledSession,
+) []domain.WeekPreviewDayInput {
+	days := make([]domain.WeekPreviewDayInput, 7)
+	index := make(map[string]int, 7)
+	for i := range days {
+		date := weekStart.AddDate(0, 0, i).Format("2006-01-02")
+		days[i] = domain.WeekPreviewDayInput{Date: date}
+		index[date] = i
+	}
+
+	for _, pd := range plannedDays {
+		if i, ok := index[pd.Date]; ok {
+			dayType := pd.DayType
+			days[i].PlannedDayType = &dayType
+		}
+	}
+
+	for _, ps := range plannerSessions {
+		if i, ok := index[ps.Date]; ok {
+			days[i].Sessions = append(days[i].Sessions, domain.WeekPreviewSession{
+				Label:        ps.Notes,
+				TrainingType: ps.TrainingType,
+				DurationMin:  ps.DurationMin,
+				RPE:          ps.RPE,
+				ExpectedLoad: domain.SessionLoad(ps.TrainingType, ps.DurationMin, ps.RPE),
+				Source:       domain.WeekPreviewSourcePlanner,
+			})
+		}
+	}
+
+	for _, ss := range scheduled {
+		i, ok := index[ss.Date.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		if ss.NutritionDay != "" {
+			dayType := ss.NutritionDay
+			days[i].ProgramDayType = &dayType
+		}
+		days[i].Sessions = append(days[i].Sessions, domain.WeekPreviewSession{
+			Label:        ss.Label,
+			TrainingType: ss.TrainingType,
+			DurationMin:  ss.DurationMin,
+			ExpectedLoad: domain.SessionLoad(ss.TrainingType, ss.DurationMin, nil),
+			Source:       domain.WeekPreviewSourceProgram,
+		})
+	}
+
+	return days
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrDayExemptionNotFound is returned when a date has no exemption.
+var ErrDayExemptionNotFound = errors.New("day exemption not found")
+
+// DayExemptionStore handles persistence for sick/travel day markers.
+type DayExemptionStore struct {
+	db DBTX
+}
+
+// NewDayExemptionStore creates a new DayExemptionStore.
+func NewDayExemptionStore(db DBTX) *DayExemptionStore {
+	return &DayExemptionStore{db: db}
+}
+
+// Upsert marks a date as exempt, replacing any earlier reason for that date.
+func (s *DayExemptionStore) Upsert(ctx context.Context, e domain.DayExemption) error {
+	const query = `
+		INSERT INTO day_exemptions (exempt_date, reason, note)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (exempt_date) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			note = EXCLUDED.note
+	`
+	_, err := s.db.ExecContext(ctx, query, e.Date, e.Reason, e.Note)
+	return err
+}
+
+// Delete removes a date's exemption.
+// Returns ErrDayExemptionNotFound if the date has none.
+func (s *DayExemptionStore) Delete(ctx context.Context, date string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM day_exemptions WHERE exempt_date = $1`, date)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDayExemptionNotFound
+	}
+	return nil
+}
+
+// ListRange returns exemptions between startDate and endDate (inclusive), ordered by date.
+func (s *DayExemptionStore) ListRange(ctx context.Context, startDate, endDate string) ([]domain.DayExemption, error) {
+	const query = `
+		SELECT exempt_date, reason, note
+		FROM day_exemptions
+		WHERE exempt_date >= $1 AND exempt_date <= $2
+		ORDER BY exempt_date
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exemptions := make([]domain.DayExemption, 0)
+	for rows.Next() {
+		var e domain.DayExemption
+		if err := rows.Scan(&e.Date, &e.Reason, &e.Note); err != nil {
+			return nil, err
+		}
+		exemptions = append(exemptions, e)
+	}
+	return exemptions, rows.Err()
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrAnnualReviewNotFound is returned when no review is stored for a year.
+var ErrAnnualReviewNotFound = errors.New("annual review not found")
+
+// AnnualReviewStore handles persistence for generated annual reviews.
+type AnnualReviewStore struct {
+	db DBTX
+}
+
+// NewAnnualReviewStore creates a new AnnualReviewStore.
+func NewAnnualReviewStore(db DBTX) *AnnualReviewStore {
+	return &AnnualReviewStore{db: db}
+}
+
+// Get returns the stored review for a year.
+// Returns ErrAnnualReviewNotFound if none has been generated.
+func (s *AnnualReviewStore) Get(ctx context.Context, year int) (*domain.AnnualReview, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx, `SELECT review FROM annual_reviews WHERE year = $1`, year).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAnnualReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var review domain.AnnualReview
+	if err := json.Unmarshal(raw, &review); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Save stores a review, replacing any earlier one for the same year.
+func (s *AnnualReviewStore) Save(ctx context.Context, review *domain.AnnualReview, generatedAt time.Time) error {
+	raw, err := json.Marshal(review)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO annual_reviews (year, review, generated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (year) DO UPDATE SET review = EXCLUDED.review, generated_at = EXCLUDED.generated_at
+	`
+	_, err = s.db.ExecContext(ctx, query, review.Year, raw, generatedAt)
+	return err
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrAPITokenNotFound is returned when an API token doesn't exist.
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// APITokenStore handles persistence for personal API tokens.
+type APITokenStore struct {
+	db DBTX
+}
+
+// NewAPITokenStore creates a new APITokenStore.
+func NewAPITokenStore(db DBTX) *APITokenStore {
+	return &APITokenStore{db: db}
+}
+
+const apiTokenColumns = `
+	id, name, token_hash, hint, scopes, expires_at, last_used_at, last_used_ip,
+	revoked_at, require_signature, signing_secret, created_at`
+
+// Create stores a new token and sets its ID and creation time.
+func (s *APITokenStore) Create(ctx context.Context, t *domain.APIToken) error {
+	scopes, err := json.Marshal(t.Scopes)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO api_tokens (name, token_hash, hint, scopes, expires_at, require_signature, signing_secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, t.Name, t.TokenHash, t.Hint, scopes, t.ExpiresAt, t.RequireSignature, t.SigningSecret).
+		Scan(&t.ID, &t.CreatedAt)
+}
+
+// GetByID retrieves a token.
+// Returns ErrAPITokenNotFound if it doesn't exist.
+func (s *APITokenStore) GetByID(ctx context.Context, id int64) (*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE id = $1`
+
+	t, err := scanAPIToken(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPITokenNotFound
+	}
+	return t, err
+}
+
+// GetByHash retrieves a token by the hash of its plaintext.
+// Returns ErrAPITokenNotFound if no token matches.
+func (s *APITokenStore) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+
+	t, err := scanAPIToken(s.db.QueryRowContext(ctx, query, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPITokenNotFound
+	}
+	return t, err
+}
+
+// List returns all tokens, newest first.
+func (s *APITokenStore) List(ctx context.Context) ([]domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens ORDER BY created_at DESC, id DESC`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]domain.APIToken, 0)
+	for rows.Next() {
+		t, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	return tokens, rows.Err()
+}
+
+// RecordUsage stamps the token's last use and counts the request against
+// its endpoint (the matched route pattern).
+func (s *APITokenStore) RecordUsage(ctx context.Context, id int64, endpoint, ip string, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE api_tokens SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`, at, ip, id); err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO api_token_usage (token_id, endpoint, request_count, last_used_at, last_used_ip)
+		VALUES ($1, $2, 1, $3, $4)
+		ON CONFLICT (token_id, endpoint) DO UPDATE SET
+			request_count = api_token_usage.request_count + 1,
+			last_used_at = EXCLUDED.last_used_at,
+			last_used_ip = EXCLUDED.last_used_ip
+	`
+	_, err := s.db.ExecContext(ctx, query, id, endpoint, at, ip)
+	return err
+}
+
+// ListUsage returns a token's request counts per endpoint, busiest first.
+func (s *APITokenStore) ListUsage(ctx context.Context, id int64) ([]domain.APITokenEndpointUsage, error) {
+	const query = `
+		SELECT endpoint, request_count, last_used_at, last_used_ip
+		FROM api_token_usage
+		WHERE token_id = $1
+		ORDER BY request_count DESC, endpoint
+	`
+	rows, err := s.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := make([]domain.APITokenEndpointUsage, 0)
+	for rows.Next() {
+		var u domain.APITokenEndpointUsage
+		if err := rows.Scan(&u.Endpoint, &u.Requests, &u.LastUsedAt, &u.LastUsedIP); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// ClaimNonce records a signed request's nonce. Returns false if the token
+// already used the nonce. Nonces seen before expireBefore are pruned first.
+func (s *APITokenStore) ClaimNonce(ctx context.Context, id int64, nonce string, at, expireBefore time.Time) (bool, error) {
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM api_token_nonces WHERE seen_at < $1`, expireBefore); err != nil {
+		return false, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO api_token_nonces (token_id, nonce, seen_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token_id, nonce) DO NOTHING
+	`, id, nonce, at)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Revoke stops a token from authenticating, effective immediately. The token
+// is kept so its usage history stays available.
+// Returns ErrAPITokenNotFound if it doesn't exist or is already revoked.
+func (s *APITokenStore) Revoke(ctx context.Context, id int64, at time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE api_tokens SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, at, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func scanAPIToken(row mealTemplateScanner) (*domain.APIToken, error) {
+	var (
+		t          domain.APIToken
+		scopes     []byte
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+	if err := row.Scan(
+		&t.ID, &t.Name, &t.TokenHash, &t.Hint, &scopes, &expiresAt, &lastUsedAt, &t.LastUsedIP,
+		&revokedAt, &t.RequireSignature, &t.SigningSecret, &t.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		t.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		t.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	if err := json.Unmarshal(scopes, &t.Scopes); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrArchetypeReviewNotFound is returned when an archetype review doesn't exist.
+var ErrArchetypeReviewNotFound = errors.New("archetype review not found")
+
+// ArchetypeReviewStore handles database operations for the archetype review queue.
+type ArchetypeReviewStore struct {
+	db DBTX
+}
+
+// NewArchetypeReviewStore creates a new ArchetypeReviewStore.
+func NewArchetypeReviewStore(db DBTX) *ArchetypeReviewStore {
+	return &ArchetypeReviewStore{db: db}
+}
+
+// Create queues a session's inference for review. A session is queued at
+// most once; returns false if it already has a review.
+func (s *ArchetypeReviewStore) Create(ctx context.Context, sessionID int64, inference domain.ArchetypeInference) (bool, error) {
+	var suggested interface{}
+	if inference.Archetype != "" {
+		suggested = string(inference.Archetype)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO archetype_reviews (training_session_id, suggested_archetype, confidence, source, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (training_session_id) DO NOTHING
+	`, sessionID, suggested, inference.Confidence, string(inference.Source), inference.Reason)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ReviewedSessionIDs returns the IDs of a log's sessions that have a review,
+// whatever its status.
+func (s *ArchetypeReviewStore) ReviewedSessionIDs(ctx context.Context, logID int64) (map[int64]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT ar.training_session_id
+		FROM archetype_reviews ar
+		JOIN training_sessions ts ON ts.id = ar.training_session_id
+		WHERE ts.daily_log_id = $1
+	`, logID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
+const archetypeReviewColumns = `
+	ar.id, ar.training_session_id, dl.log_date, ts.session_order, ts.training_type,
+	ts.duration_min, COALESCE(ts.notes, ''), COALESCE(ar.suggested_archetype, ''),
+	ar.confidence, ar.source, ar.reason, ar.status, COALESCE(ar.resolved_archetype, ''),
+	ar.created_at, ar.resolved_at
+`
+
+const archetypeReviewFrom = `
+	FROM archetype_reviews ar
+	JOIN training_sessions ts ON ts.id = ar.training_session_id
+	JOIN daily_logs dl ON dl.id = ts.daily_log_id
+`
+
+// ListPending returns the reviews awaiting the user, oldest first.
+func (s *ArchetypeReviewStore) ListPending(ctx context.Context) ([]domain.ArchetypeReview, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+archetypeReviewColumns+archetypeReviewFrom+`
+		WHERE ar.status = 'pending'
+		ORDER BY ar.created_at, ar.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domain.ArchetypeReview, 0)
+	for rows.Next() {
+		r, err := scanArchetypeReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *r)
+	}
+	return reviews, rows.Err()
+}
+
+// Get retrieves an archetype review.
+// Returns ErrArchetypeReviewNotFound if it doesn't exist.
+func (s *ArchetypeReviewStore) Get(ctx context.Context, id int64) (*domain.ArchetypeReview, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+archetypeReviewColumns+archetypeReviewFrom+`WHERE ar.id = $1`, id)
+	r, err := scanArchetypeReview(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrArchetypeReviewNotFound
+	}
+	return r, err
+}
+
+// Resolve records a review's outcome. Only a pending review is updated;
+// returns domain.ErrArchetypeReviewResolved otherwise.
+func (s *ArchetypeReviewStore) Resolve(ctx context.Context, id int64, status domain.ArchetypeReviewStatus, archetype domain.Archetype, at time.Time) error {
+	var resolved interface{}
+	if archetype != "" {
+		resolved = string(archetype)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE archetype_reviews SET status = $2, resolved_archetype = $3, resolved_at = $4
+		WHERE id = $1 AND status = 'pending'
+	`, id, string(status), resolved, at)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrArchetypeReviewResolved
+	}
+	return nil
+}
+
+func scanArchetypeReview(row mealTemplateScanner) (*domain.ArchetypeReview, error) {
+	var r domain.ArchetypeReview
+	var resolvedAt sql.NullTime
+	if err := row.Scan(&r.ID, &r.SessionID, &r.Date, &r.SessionOrder, &r.Type,
+		&r.DurationMin, &r.Notes, &r.Inference.Archetype,
+		&r.Inference.Confidence, &r.Inference.Source, &r.Inference.Reason, &r.Status, &r.Resolved,
+		&r.CreatedAt, &resolvedAt); err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		r.ResolvedAt = &resolvedAt.Time
+	}
+	return &r, nil
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"victus/internal/db"
+	"victus/internal/testutil"
+)
+
+// benchSeedDays is how much history the benchmarks seed: three years of
+// daily logs, each with a planned session, two actual sessions and a flux
+// calculation.
+const benchSeedDays = 3 * 365
+
+// benchIndexes are the composite indexes added for these queries. They are
+// dropped for the "before" runs and recreated by re-running migrations.
+var benchIndexes = []string{
+	"idx_training_sessions_log_planned",
+	"idx_metabolic_history_pending",
+}
+
+func seedBenchData(b *testing.B, ctx context.Context, pc *testutil.PostgresContainer) {
+	b.Helper()
+
+	statements := []string{
+		`INSERT INTO daily_logs (log_date, weight_kg, sleep_quality, planned_training_type, planned_duration_min, total_calories)
+		 SELECT to_char(CURRENT_DATE - g, 'YYYY-MM-DD'), 80 + sin(g / 10.0), 70, 'strength', 60, 2200
+		 FROM generate_series(0, $1 - 1) g`,
+		`INSERT INTO training_sessions (daily_log_id, session_order, is_planned, training_type, duration_min)
+		 SELECT id, 1, true, 'strength', 60 FROM daily_logs`,
+		`INSERT INTO training_sessions (daily_log_id, session_order, is_planned, training_type, duration_min, perceived_intensity)
+		 SELECT id, o, false, 'strength', 45, 7 FROM daily_logs, generate_series(1, 2) o`,
+		`INSERT INTO metabolic_history (daily_log_id, calculated_at, calculated_tdee, tdee_source, bmr_value, notification_pending)
+		 SELECT id, to_date(log_date, 'YYYY-MM-DD'), 2500, 'flux', 1750, id % 97 = 0 FROM daily_logs`,
+		`ANALYZE`,
+	}
+	for i, stmt := range statements {
+		var err error
+		if i == 0 {
+			_, err = pc.DB.ExecContext(ctx, stmt, benchSeedDays)
+		} else {
+			_, err = pc.DB.ExecContext(ctx, stmt)
+		}
+		if err != nil {
+			b.Fatalf("seeding benchmark data: %v", err)
+		}
+	}
+}
+
+// BenchmarkStoreQueries measures the hottest store queries against seeded
+// history, before and after the composite indexes they rely on.
+func BenchmarkStoreQueries(b *testing.B) {
+	ctx := context.Background()
+	pc := testutil.SetupPostgres(b)
+	seedBenchData(b, ctx, pc)
+
+	logStore := NewDailyLogStore(pc.DB)
+	sessionStore := NewTrainingSessionStore(pc.DB)
+	metabolicStore := NewMetabolicStore(pc.DB)
+
+	var logID int64
+	if err := pc.DB.QueryRowContext(ctx, `SELECT id FROM daily_logs ORDER BY log_date DESC LIMIT 1`).Scan(&logID); err != nil {
+		b.Fatalf("finding latest log: %v", err)
+	}
+	var startDate, endDate string
+	if err := pc.DB.QueryRowContext(ctx,
+		`SELECT to_char(CURRENT_DATE - 89, 'YYYY-MM-DD'), to_char(CURRENT_DATE, 'YYYY-MM-DD')`,
+	).Scan(&startDate, &endDate); err != nil {
+		b.Fatalf("computing range: %v", err)
+	}
+
+	queries := []struct {
+		name string
+		run  func() error
+	}{
+		{"LogsByDateRange90d", func() error {
+			_, err := logStore.ListByDateRange(ctx, startDate, endDate)
+			return err
+		}},
+		{"SessionsJoinDateRange90d", func() error {
+			_, err := sessionStore.GetSessionsForDateRange(ctx, startDate, endDate)
+			return err
+		}},
+		{"ActualSessionsByLog", func() error {
+			_, err := sessionStore.GetActualByLogID(ctx, logID)
+			return err
+		}},
+		{"FluxChart12w", func() error {
+			_, err := metabolicStore.ListForChart(ctx, 12, endDate)
+			return err
+		}},
+		{"FluxLatest", func() error {
+			_, err := metabolicStore.GetLatest(ctx)
+			return err
+		}},
+		{"FluxPendingNotification", func() error {
+			_, err := metabolicStore.GetPendingNotification(ctx)
+			return err
+		}},
+	}
+
+	runAll := func(phase string) {
+		for _, q := range queries {
+			b.Run(phase+"/"+q.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if err := q.run(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+
+	for _, idx := range benchIndexes {
+		if _, err := pc.DB.ExecContext(ctx, `DROP INDEX IF EXISTS `+idx); err != nil {
+			b.Fatalf("dropping %s: %v", idx, err)
+		}
+	}
+	runAll("before")
+
+	if err := db.RunMigrations(pc.DB); err != nil {
+		b.Fatalf("recreating indexes: %v", err)
+	}
+	if _, err := pc.DB.ExecContext(ctx, `ANALYZE`); err != nil {
+		b.Fatalf("analyzing: %v", err)
+	}
+	runAll("after")
+}
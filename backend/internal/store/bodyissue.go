@@ -22,8 +22,8 @@ func NewBodyIssueStore(db DBTX) *BodyIssueStore {
 // Caller must set input.Severity before calling (e.g. via input.ResolveSeverity()).
 func (s *BodyIssueStore) Create(ctx context.Context, input domain.BodyPartIssueInput) (*domain.BodyPartIssue, error) {
 	const query = `
-		INSERT INTO body_part_issues (date, body_part, symptom, severity, raw_text, session_id, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO body_part_issues (date, body_part, symptom, severity, raw_text, session_id, side, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
@@ -35,6 +35,7 @@ func (s *BodyIssueStore) Create(ctx context.Context, input domain.BodyPartIssueI
 		input.Severity,
 		input.RawText,
 		input.SessionID,
+		string(input.Side),
 		time.Now(),
 	).Scan(&id)
 	if err != nil {
@@ -57,8 +58,8 @@ func (s *BodyIssueStore) CreateBatch(ctx context.Context, inputs []domain.BodyPa
 	defer tx.Rollback()
 
 	const query = `
-		INSERT INTO body_part_issues (date, body_part, symptom, severity, raw_text, session_id, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO body_part_issues (date, body_part, symptom, severity, raw_text, session_id, side, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
@@ -73,6 +74,7 @@ func (s *BodyIssueStore) CreateBatch(ctx context.Context, inputs []domain.BodyPa
 			input.Severity,
 			input.RawText,
 			input.SessionID,
+			string(input.Side),
 			now,
 		).Scan(&id)
 		if err != nil {
@@ -103,7 +105,7 @@ func (s *BodyIssueStore) CreateBatch(ctx context.Context, inputs []domain.BodyPa
 // GetByID retrieves a body part issue by its ID.
 func (s *BodyIssueStore) GetByID(ctx context.Context, id int64) (*domain.BodyPartIssue, error) {
 	const query = `
-		SELECT id, date, body_part, symptom, severity, raw_text, session_id, created_at
+		SELECT id, date, body_part, symptom, severity, raw_text, session_id, side, created_at
 		FROM body_part_issues
 		WHERE id = $1
 	`
@@ -119,6 +121,7 @@ func (s *BodyIssueStore) GetByID(ctx context.Context, id int64) (*domain.BodyPar
 		&issue.Severity,
 		&issue.RawText,
 		&issue.SessionID,
+		&issue.Side,
 		&createdAt,
 	)
 	if err != nil {
@@ -135,7 +138,7 @@ func (s *BodyIssueStore) GetByID(ctx context.Context, id int64) (*domain.BodyPar
 // GetByDateRange retrieves all body part issues within a date range.
 func (s *BodyIssueStore) GetByDateRange(ctx context.Context, startDate, endDate string) ([]domain.BodyPartIssue, error) {
 	const query = `
-		SELECT id, date, body_part, symptom, severity, raw_text, session_id, created_at
+		SELECT id, date, body_part, symptom, severity, raw_text, session_id, side, created_at
 		FROM body_part_issues
 		WHERE date >= $1 AND date <= $2
 		ORDER BY date DESC, created_at DESC
@@ -160,6 +163,7 @@ func (s *BodyIssueStore) GetByDateRange(ctx context.Context, startDate, endDate
 			&issue.Severity,
 			&issue.RawText,
 			&issue.SessionID,
+			&issue.Side,
 			&createdAt,
 		); err != nil {
 			return nil, err
@@ -176,7 +180,7 @@ func (s *BodyIssueStore) GetByDateRange(ctx context.Context, startDate, endDate
 // Issues older than IssueDecayDays are considered inactive.
 func (s *BodyIssueStore) GetActiveIssues(ctx context.Context) ([]domain.BodyPartIssue, error) {
 	const query = `
-		SELECT id, date, body_part, symptom, severity, raw_text, session_id, created_at
+		SELECT id, date, body_part, symptom, severity, raw_text, session_id, side, created_at
 		FROM body_part_issues
 		WHERE date >= CURRENT_DATE - $1 * INTERVAL '1 day'
 		ORDER BY date DESC, created_at DESC
@@ -201,6 +205,7 @@ func (s *BodyIssueStore) GetActiveIssues(ctx context.Context) ([]domain.BodyPart
 			&issue.Severity,
 			&issue.RawText,
 			&issue.SessionID,
+			&issue.Side,
 			&createdAt,
 		); err != nil {
 			return nil, err
@@ -216,7 +221,7 @@ func (s *BodyIssueStore) GetActiveIssues(ctx context.Context) ([]domain.BodyPart
 // GetActiveIssuesByMuscle retrieves active issues for a specific muscle group.
 func (s *BodyIssueStore) GetActiveIssuesByMuscle(ctx context.Context, muscle domain.MuscleGroup) ([]domain.BodyPartIssue, error) {
 	const query = `
-		SELECT id, date, body_part, symptom, severity, raw_text, session_id, created_at
+		SELECT id, date, body_part, symptom, severity, raw_text, session_id, side, created_at
 		FROM body_part_issues
 		WHERE body_part = $1
 		  AND date >= CURRENT_DATE - $2 * INTERVAL '1 day'
@@ -242,6 +247,7 @@ func (s *BodyIssueStore) GetActiveIssuesByMuscle(ctx context.Context, muscle dom
 			&issue.Severity,
 			&issue.RawText,
 			&issue.SessionID,
+			&issue.Side,
 			&createdAt,
 		); err != nil {
 			return nil, err
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// BodyStatusStore handles persistence for daily body status snapshots.
+type BodyStatusStore struct {
+	db DBTX
+}
+
+// NewBodyStatusStore creates a new BodyStatusStore.
+func NewBodyStatusStore(db DBTX) *BodyStatusStore {
+	return &BodyStatusStore{db: db}
+}
+
+// Save stores a day's snapshot, replacing any earlier one for the same day.
+func (s *BodyStatusStore) Save(ctx context.Context, status *domain.DailyBodyStatus, computedAt time.Time) error {
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO body_status_snapshots (snapshot_date, status, systemic_load, computed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (snapshot_date) DO UPDATE SET
+			status = EXCLUDED.status,
+			systemic_load = EXCLUDED.systemic_load,
+			computed_at = EXCLUDED.computed_at
+	`
+	_, err = s.db.ExecContext(ctx, query, status.Date, raw, status.Status.SystemicLoad, computedAt)
+	return err
+}
+
+// ListRange returns the snapshots between two dates (inclusive), oldest first.
+func (s *BodyStatusStore) ListRange(ctx context.Context, from, to string) ([]domain.DailyBodyStatus, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT status FROM body_status_snapshots
+		WHERE snapshot_date >= $1 AND snapshot_date <= $2
+		ORDER BY snapshot_date
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]domain.DailyBodyStatus, 0)
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var snapshot domain.DailyBodyStatus
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
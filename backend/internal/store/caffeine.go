@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrCaffeineEntryNotFound is returned when a caffeine entry doesn't exist.
+var ErrCaffeineEntryNotFound = errors.New("caffeine entry not found")
+
+// CaffeineStore handles persistence for logged caffeine intake.
+type CaffeineStore struct {
+	db DBTX
+}
+
+// NewCaffeineStore creates a new CaffeineStore.
+func NewCaffeineStore(db DBTX) *CaffeineStore {
+	return &CaffeineStore{db: db}
+}
+
+// Create stores an entry and sets its ID and CreatedAt.
+func (s *CaffeineStore) Create(ctx context.Context, e *domain.CaffeineEntry) error {
+	const query = `
+		INSERT INTO caffeine_log (log_date, intake_time, source, mg)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, e.Date, e.Time, string(e.Source), e.Mg).Scan(&e.ID, &e.CreatedAt)
+}
+
+// ListByDateRange returns entries in a date range (inclusive), by date and time.
+func (s *CaffeineStore) ListByDateRange(ctx context.Context, startDate, endDate string) ([]domain.CaffeineEntry, error) {
+	const query = `
+		SELECT id, log_date, intake_time, source, mg, created_at
+		FROM caffeine_log
+		WHERE log_date >= $1 AND log_date <= $2
+		ORDER BY log_date, intake_time, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.CaffeineEntry, 0)
+	for rows.Next() {
+		var e domain.CaffeineEntry
+		if err := rows.Scan(&e.ID, &e.Date, &e.Time, &e.Source, &e.Mg, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Delete removes an entry.
+// Returns ErrCaffeineEntryNotFound if it doesn't exist.
+func (s *CaffeineStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM caffeine_log WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrCaffeineEntryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrChallengeNotFound is returned when a challenge doesn't exist.
+var ErrChallengeNotFound = errors.New("challenge not found")
+
+// ChallengeStore handles persistence for self-challenges.
+type ChallengeStore struct {
+	db DBTX
+}
+
+// NewChallengeStore creates a new ChallengeStore.
+func NewChallengeStore(db DBTX) *ChallengeStore {
+	return &ChallengeStore{db: db}
+}
+
+const selectChallengeColumns = `
+	SELECT id, name, challenge_type, start_date, duration_days, allowed_misses,
+		status, COALESCE(ended_date, ''), notification_pending, created_at
+	FROM challenges
+`
+
+// Create stores a new active challenge and sets its ID, Status and CreatedAt.
+func (s *ChallengeStore) Create(ctx context.Context, c *domain.Challenge) error {
+	const query = `
+		INSERT INTO challenges (name, challenge_type, start_date, duration_days, allowed_misses)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, c.Name, string(c.Type), c.StartDate, c.DurationDays, c.AllowedMisses).
+		Scan(&c.ID, &c.Status, &c.CreatedAt)
+}
+
+// GetByID retrieves a challenge.
+// Returns ErrChallengeNotFound if it doesn't exist.
+func (s *ChallengeStore) GetByID(ctx context.Context, id int64) (*domain.Challenge, error) {
+	c, err := scanChallenge(s.db.QueryRowContext(ctx, selectChallengeColumns+`WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrChallengeNotFound
+	}
+	return c, err
+}
+
+// List returns all challenges, newest start first.
+func (s *ChallengeStore) List(ctx context.Context) ([]domain.Challenge, error) {
+	return s.list(ctx, selectChallengeColumns+`ORDER BY start_date DESC, id DESC`)
+}
+
+// ListByStatus returns the challenges with a status, oldest start first.
+func (s *ChallengeStore) ListByStatus(ctx context.Context, status domain.ChallengeStatus) ([]domain.Challenge, error) {
+	return s.list(ctx, selectChallengeColumns+`WHERE status = $1 ORDER BY start_date, id`, string(status))
+}
+
+// ListPendingNotifications returns the ended challenges whose completion or
+// failure hasn't been acknowledged, oldest start first.
+func (s *ChallengeStore) ListPendingNotifications(ctx context.Context) ([]domain.Challenge, error) {
+	return s.list(ctx, selectChallengeColumns+`WHERE notification_pending ORDER BY start_date, id`)
+}
+
+func (s *ChallengeStore) list(ctx context.Context, query string, args ...any) ([]domain.Challenge, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	challenges := make([]domain.Challenge, 0)
+	for rows.Next() {
+		c, err := scanChallenge(rows)
+		if err != nil {
+			return nil, err
+		}
+		challenges = append(challenges, *c)
+	}
+	return challenges, rows.Err()
+}
+
+// End records a challenge's final status and the day it ended. Completions
+// and failures raise a pending notification; abandoning doesn't.
+// Returns ErrChallengeNotFound if it doesn't exist.
+func (s *ChallengeStore) End(ctx context.Context, id int64, status domain.ChallengeStatus, endedDate string) error {
+	const query = `
+		UPDATE challenges
+		SET status = $2, ended_date = $3, notification_pending = $4
+		WHERE id = $1
+	`
+	notify := status == domain.ChallengeCompleted || status == domain.ChallengeFailed
+	return challengeRowAffected(s.db.ExecContext(ctx, query, id, string(status), endedDate, notify))
+}
+
+// DismissNotification acknowledges a challenge's completion or failure.
+// Returns ErrChallengeNotFound if it doesn't exist.
+func (s *ChallengeStore) DismissNotification(ctx context.Context, id int64) error {
+	return challengeRowAffected(s.db.ExecContext(ctx, `UPDATE challenges SET notification_pending = FALSE WHERE id = $1`, id))
+}
+
+// Delete removes a challenge.
+// Returns ErrChallengeNotFound if it doesn't exist.
+func (s *ChallengeStore) Delete(ctx context.Context, id int64) error {
+	return challengeRowAffected(s.db.ExecContext(ctx, `DELETE FROM challenges WHERE id = $1`, id))
+}
+
+// challengeRowAffected maps an update or delete that touched no row to
+// ErrChallengeNotFound.
+func challengeRowAffected(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrChallengeNotFound
+	}
+	return nil
+}
+
+type challengeScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanChallenge(row challengeScanner) (*domain.Challenge, error) {
+	var c domain.Challenge
+	err := row.Scan(&c.ID, &c.Name, &c.Type, &c.StartDate, &c.DurationDays, &c.AllowedMisses,
+		&c.Status, &c.EndedDate, &c.NotificationPending, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -30,24 +30,10 @@ func NewDailyLogStore(db DBTX) *DailyLogStore {
 	return &DailyLogStore{db: db}
 }
 
-// WithTx executes fn within a transaction.
+// WithTx executes fn within a transaction, joining the request-scoped one
+// (RunInTx) when ctx carries it.
 func (s *DailyLogStore) WithTx(ctx context.Context, fn func(*sql.Tx) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	if err := fn(tx); err != nil {
-		_ = tx.Rollback()
-		return err
-	}
-
-	if err := tx.Commit(); err != nil {
-		_ = tx.Rollback()
-		return err
-	}
-
-	return nil
+	return withTx(ctx, s.db, fn)
 }
 
 // GetByDate retrieves a daily log by date (YYYY-MM-DD format).
@@ -57,9 +43,9 @@ func (s *DailyLogStore) WithTx(ctx context.Context, fn func(*sql.Tx) error) erro
 func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.DailyLog, error) {
 	const query = `
 		SELECT
-			id, log_date, weight_kg, body_fat_percent, resting_heart_rate, hrv_ms,
+			id, log_date, weight_kg, weigh_in_time, body_fat_percent, resting_heart_rate, hrv_ms,
 			hrv_reference_min, hrv_reference_max,
-			sleep_quality, sleep_hours,
+			sleep_quality, sleep_hours, bedtime,
 			COALESCE(total_carbs_g, 0), COALESCE(total_protein_g, 0), COALESCE(total_fats_g, 0), COALESCE(total_calories, 0),
 			COALESCE(breakfast_carb_points, 0), COALESCE(breakfast_protein_points, 0), COALESCE(breakfast_fat_points, 0),
 			COALESCE(lunch_carb_points, 0), COALESCE(lunch_protein_points, 0), COALESCE(lunch_fat_points, 0),
@@ -67,8 +53,9 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 			COALESCE(fruit_g, 0), COALESCE(veggies_g, 0), COALESCE(water_l, 0), COALESCE(day_type, 'fatburner'),
 			COALESCE(estimated_tdee, 0), COALESCE(formula_tdee, 0),
 			COALESCE(tdee_source_used, 'formula'), COALESCE(tdee_confidence, 0), COALESCE(data_points_used, 0),
-			active_calories_burned, steps, COALESCE(notes, ''),
+			active_calories_burned, COALESCE(active_calories_source, ''), steps, COALESCE(notes, ''),
 			fasting_override, COALESCE(fasted_items_kcal, 0),
+			mood, stress, soreness, hunger, environment,
 			COALESCE(consumed_calories, 0), COALESCE(consumed_protein_g, 0),
 			COALESCE(consumed_carbs_g, 0), COALESCE(consumed_fat_g, 0),
 			COALESCE(breakfast_consumed_kcal, 0), COALESCE(breakfast_consumed_protein_g, 0),
@@ -84,7 +71,9 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 
 	var (
 		log                  domain.DailyLog
+		weighInTime          sql.NullString
 		bodyFatPercent       sql.NullFloat64
+		bedtime              sql.NullString
 		heartRate            sql.NullInt64
 		hrvMs                sql.NullInt64
 		hrvReferenceMin      sql.NullInt64
@@ -93,14 +82,19 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 		activeCaloriesBurned sql.NullInt64
 		steps                sql.NullInt64
 		fastingOverride      sql.NullString
+		mood                 sql.NullInt64
+		stress               sql.NullInt64
+		soreness             sql.NullInt64
+		hunger               sql.NullInt64
+		environment          string
 		createdAt            string
 		updatedAt            string
 	)
 
 	err := s.db.QueryRowContext(ctx, query, date).Scan(
-		&log.ID, &log.Date, &log.WeightKg, &bodyFatPercent, &heartRate, &hrvMs,
+		&log.ID, &log.Date, &log.WeightKg, &weighInTime, &bodyFatPercent, &heartRate, &hrvMs,
 		&hrvReferenceMin, &hrvReferenceMax,
-		&log.SleepQuality, &sleepHours,
+		&log.SleepQuality, &sleepHours, &bedtime,
 		&log.CalculatedTargets.TotalCarbsG, &log.CalculatedTargets.TotalProteinG,
 		&log.CalculatedTargets.TotalFatsG, &log.CalculatedTargets.TotalCalories,
 		&log.CalculatedTargets.Meals.Breakfast.Carbs, &log.CalculatedTargets.Meals.Breakfast.Protein,
@@ -113,8 +107,9 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 		&log.CalculatedTargets.WaterL, &log.CalculatedTargets.DayType,
 		&log.EstimatedTDEE, &log.FormulaTDEE,
 		&log.TDEESourceUsed, &log.TDEEConfidence, &log.DataPointsUsed,
-		&activeCaloriesBurned, &steps, &log.Notes,
+		&activeCaloriesBurned, &log.ActiveCaloriesSource, &steps, &log.Notes,
 		&fastingOverride, &log.FastedItemsKcal,
+		&mood, &stress, &soreness, &hunger, &environment,
 		&log.ConsumedCalories, &log.ConsumedProteinG,
 		&log.ConsumedCarbsG, &log.ConsumedFatG,
 		&log.MealConsumed.Breakfast.Calories, &log.MealConsumed.Breakfast.ProteinG,
@@ -134,6 +129,12 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 	}
 
 	// Handle nullable fields
+	if weighInTime.Valid {
+		log.WeighInTime = &weighInTime.String
+	}
+	if bedtime.Valid {
+		log.Bedtime = &bedtime.String
+	}
 	if bodyFatPercent.Valid {
 		log.BodyFatPercent = &bodyFatPercent.Float64
 	}
@@ -168,6 +169,8 @@ func (s *DailyLogStore) GetByDate(ctx context.Context, date string) (*domain.Dai
 		fp := domain.FastingProtocol(fastingOverride.String)
 		log.FastingOverride = &fp
 	}
+	log.CheckIn = checkInFromColumns(mood, stress, soreness, hunger)
+	log.Environment = parseEnvironmentJSON(environment)
 
 	// Parse timestamps
 	log.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
@@ -215,7 +218,7 @@ func (s *DailyLogStore) create(ctx context.Context, execer sqlExecer, log *domai
 			dinner_carb_points, dinner_protein_points, dinner_fat_points,
 			fruit_g, veggies_g, water_l, day_type, estimated_tdee, formula_tdee,
 			tdee_source_used, tdee_confidence, data_points_used, notes,
-			created_at, updated_at
+			created_at, updated_at, weigh_in_time, environment, bedtime
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7,
@@ -226,7 +229,7 @@ func (s *DailyLogStore) create(ctx context.Context, execer sqlExecer, log *domai
 			$18, $19, $20,
 			$21, $22, $23, $24, $25, $26,
 			$27, $28, $29, $30,
-			$31, $32
+			$31, $32, $33, $34, $35
 		)
 		RETURNING id
 	`
@@ -267,7 +270,7 @@ func (s *DailyLogStore) create(ctx context.Context, execer sqlExecer, log *domai
 		log.CalculatedTargets.WaterL, log.DayType,
 		log.EstimatedTDEE, log.FormulaTDEE,
 		log.TDEESourceUsed, log.TDEEConfidence, log.DataPointsUsed, log.Notes,
-		now, now,
+		now, now, log.WeighInTime, environmentJSON(log.Environment), log.Bedtime,
 	).Scan(&id)
 	if err != nil {
 		if isUniqueConstraint(err) {
@@ -285,10 +288,108 @@ func (s *DailyLogStore) DeleteByDate(ctx context.Context, date string) error {
 	return err
 }
 
+// DeleteByDateWithTx removes the daily log for the given date within a transaction.
+// Returns ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogStore) DeleteByDateWithTx(ctx context.Context, tx *sql.Tx, date string) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM daily_logs WHERE log_date = $1", date)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDailyLogNotFound
+	}
+	return nil
+}
+
+// dayEntryTables are the tables holding rows logged on a day alongside its
+// log, keyed by date rather than by the log's ID.
+var dayEntryTables = []struct{ table, dateColumn string }{
+	{"meal_entries", "log_date"},
+	{"meal_hunger", "log_date"},
+	{"caffeine_log", "log_date"},
+	{"food_portion_log", "log_date"},
+	{"recovery_activities", "activity_date"},
+}
+
+// DeleteDayEntriesWithTx removes the rows logged on date alongside its log:
+// meal entries and items, meal hunger, caffeine, portions and recovery
+// activities. They would otherwise attach to a log later recreated for the
+// date. Returns the number of rows removed.
+func (s *DailyLogStore) DeleteDayEntriesWithTx(ctx context.Context, tx *sql.Tx, date string) (int, error) {
+	removed := 0
+	for _, t := range dayEntryTables {
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = $1", t.table, t.dateColumn), date)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete %s: %w", t.table, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += int(n)
+	}
+	return removed, nil
+}
+
+// GetLatestDate returns the date of the most recent daily log.
+// Returns ErrDailyLogNotFound if there are no logs.
+func (s *DailyLogStore) GetLatestDate(ctx context.Context) (string, error) {
+	var date string
+	err := s.db.QueryRowContext(ctx, "SELECT log_date FROM daily_logs ORDER BY log_date DESC LIMIT 1").Scan(&date)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrDailyLogNotFound
+	}
+	return date, err
+}
+
+// GetFirstDateFrom returns the earliest log date on or after from, or an
+// empty string if there is none.
+func (s *DailyLogStore) GetFirstDateFrom(ctx context.Context, from string) (string, error) {
+	var date sql.NullString
+	err := s.db.QueryRowContext(ctx, "SELECT MIN(log_date) FROM daily_logs WHERE log_date >= $1", from).Scan(&date)
+	return date.String, err
+}
+
+// GetWeeklyActuals averages the logs between startDate and endDate
+// (inclusive): explicit weigh-ins, intake on days with food logged, and the
+// number of days logged.
+func (s *DailyLogStore) GetWeeklyActuals(ctx context.Context, startDate, endDate string) (domain.WeeklyActuals, error) {
+	const query = `
+		SELECT
+			AVG(weight_kg) FILTER (WHERE has_explicit_weight = true),
+			AVG(consumed_calories) FILTER (WHERE consumed_calories > 0),
+			COUNT(*)
+		FROM daily_logs
+		WHERE log_date BETWEEN $1 AND $2
+	`
+
+	var (
+		actuals domain.WeeklyActuals
+		weight  sql.NullFloat64
+		intake  sql.NullFloat64
+	)
+	if err := s.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&weight, &intake, &actuals.DaysLogged); err != nil {
+		return domain.WeeklyActuals{}, err
+	}
+	if weight.Valid {
+		w := domain.RoundTo(weight.Float64, 1)
+		actuals.WeightKg = &w
+	}
+	if intake.Valid {
+		kcal := domain.RoundInt(intake.Float64)
+		actuals.IntakeKcal = &kcal
+	}
+	return actuals, nil
+}
+
 // ListWeights returns weight samples ordered by date.
 // If startDate is empty, all samples are returned.
 func (s *DailyLogStore) ListWeights(ctx context.Context, startDate string) ([]domain.WeightSample, error) {
-	query := "SELECT log_date, weight_kg FROM daily_logs WHERE has_explicit_weight = true"
+	query := "SELECT log_date, weight_kg, COALESCE(weigh_in_time, '') FROM daily_logs WHERE has_explicit_weight = true"
 	var args []interface{}
 	if startDate != "" {
 		query += " AND log_date >= $1"
@@ -305,7 +406,7 @@ func (s *DailyLogStore) ListWeights(ctx context.Context, startDate string) ([]do
 	var samples []domain.WeightSample
 	for rows.Next() {
 		var sample domain.WeightSample
-		if err := rows.Scan(&sample.Date, &sample.WeightKg); err != nil {
+		if err := rows.Scan(&sample.Date, &sample.WeightKg, &sample.WeighInTime); err != nil {
 			return nil, err
 		}
 		samples = append(samples, sample)
@@ -318,6 +419,33 @@ func (s *DailyLogStore) ListWeights(ctx context.Context, startDate string) ([]do
 	return samples, nil
 }
 
+// ListBodyFat returns body fat readings on or after startDate, ordered by date.
+func (s *DailyLogStore) ListBodyFat(ctx context.Context, startDate string) ([]domain.BodyFatSample, error) {
+	const query = `
+		SELECT log_date, body_fat_percent
+		FROM daily_logs
+		WHERE log_date >= $1 AND body_fat_percent IS NOT NULL
+		ORDER BY log_date ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []domain.BodyFatSample
+	for rows.Next() {
+		var sample domain.BodyFatSample
+		if err := rows.Scan(&sample.Date, &sample.Percent); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
 // ListHistoryPoints returns history points ordered by date.
 // If startDate is empty, all samples are returned.
 func (s *DailyLogStore) ListHistoryPoints(ctx context.Context, startDate string) ([]domain.HistoryPoint, error) {
@@ -445,11 +573,12 @@ func (s *DailyLogStore) ListDailyTargets(ctx context.Context, startDate, endDate
 // Returns data points ordered by date (oldest first) for the specified lookback period.
 func (s *DailyLogStore) ListAdaptiveDataPoints(ctx context.Context, endDate string, maxDays int) ([]domain.AdaptiveDataPoint, error) {
 	const query = `
-		SELECT log_date, weight_kg, total_calories, COALESCE(estimated_tdee, 0), COALESCE(formula_tdee, 0)
+		SELECT log_date, weight_kg, total_calories, COALESCE(consumed_calories, 0),
+		       COALESCE(estimated_tdee, 0), COALESCE(formula_tdee, 0)
 		FROM daily_logs
 		WHERE log_date <= $1
 		  AND has_explicit_weight = true
-		  AND total_calories > 0
+		  AND (total_calories > 0 OR consumed_calories > 0)
 		ORDER BY log_date DESC
 		LIMIT $2
 	`
@@ -467,6 +596,7 @@ func (s *DailyLogStore) ListAdaptiveDataPoints(ctx context.Context, endDate stri
 			&point.Date,
 			&point.WeightKg,
 			&point.TargetCalories,
+			&point.ConsumedCalories,
 			&point.EstimatedTDEE,
 			&point.FormulaTDEE,
 		); err != nil {
@@ -487,21 +617,40 @@ func (s *DailyLogStore) ListAdaptiveDataPoints(ctx context.Context, endDate stri
 	return points, nil
 }
 
-// UpdateActiveCaloriesBurned updates only the active_calories_burned field for a given date.
+// GetAdaptiveCoverage counts the explicit weigh-ins and the days with intake
+// (targets or logged food) in a date range (inclusive), for adaptive TDEE
+// confidence. AsOfDate is set to endDate.
+func (s *DailyLogStore) GetAdaptiveCoverage(ctx context.Context, startDate, endDate string) (domain.AdaptiveCoverage, error) {
+	const query = `
+		SELECT
+			COUNT(*) FILTER (WHERE has_explicit_weight = true),
+			COUNT(*) FILTER (WHERE total_calories > 0 OR consumed_calories > 0)
+		FROM daily_logs
+		WHERE log_date >= $1 AND log_date <= $2
+	`
+
+	coverage := domain.AdaptiveCoverage{AsOfDate: endDate}
+	err := s.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&coverage.WeighInDays, &coverage.IntakeDays)
+	return coverage, err
+}
+
+// UpdateActiveCaloriesBurned sets a day's active calories from a wearable.
+// Clearing them (nil) also clears the source, so sessions can be estimated again.
 // Returns ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogStore) UpdateActiveCaloriesBurned(ctx context.Context, date string, calories *int) error {
 	const query = `
 		UPDATE daily_logs
-		SET active_calories_burned = $1, updated_at = $2
-		WHERE log_date = $3
+		SET active_calories_burned = $1, active_calories_source = $2, updated_at = $3
+		WHERE log_date = $4
 	`
 
-	var caloriesVal interface{}
+	var caloriesVal, sourceVal interface{}
 	if calories != nil {
 		caloriesVal = *calories
+		sourceVal = domain.ActiveCaloriesWearable
 	}
 
-	result, err := s.db.ExecContext(ctx, query, caloriesVal, time.Now(), date)
+	result, err := s.db.ExecContext(ctx, query, caloriesVal, sourceVal, time.Now(), date)
 	if err != nil {
 		return err
 	}
@@ -518,34 +667,43 @@ func (s *DailyLogStore) UpdateActiveCaloriesBurned(ctx context.Context, date str
 	return nil
 }
 
-// UpdateActiveCaloriesBurnedWithTx updates active calories burned within a transaction.
-func (s *DailyLogStore) UpdateActiveCaloriesBurnedWithTx(ctx context.Context, tx *sql.Tx, date string, calories *int) error {
+// UpdateEstimatedActiveCalories stores a session-based estimate of a day's
+// active calories. Wearable values are kept: returns false without writing
+// when the day already has one.
+// Returns ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogStore) UpdateEstimatedActiveCalories(ctx context.Context, date string, calories int) (bool, error) {
+	return s.updateEstimatedActiveCalories(ctx, s.db, date, calories)
+}
+
+// UpdateEstimatedActiveCaloriesWithTx stores an active calorie estimate within a transaction.
+func (s *DailyLogStore) UpdateEstimatedActiveCaloriesWithTx(ctx context.Context, tx *sql.Tx, date string, calories int) (bool, error) {
+	return s.updateEstimatedActiveCalories(ctx, tx, date, calories)
+}
+
+func (s *DailyLogStore) updateEstimatedActiveCalories(ctx context.Context, execer sqlExecer, date string, calories int) (bool, error) {
 	const query = `
 		UPDATE daily_logs
-		SET active_calories_burned = $1, updated_at = $2
-		WHERE log_date = $3
+		SET active_calories_burned = $1, active_calories_source = $2, updated_at = $3
+		WHERE log_date = $4
+		  AND (active_calories_burned IS NULL OR active_calories_source IS DISTINCT FROM $5)
 	`
 
-	var caloriesVal interface{}
-	if calories != nil {
-		caloriesVal = *calories
-	}
-
-	result, err := tx.ExecContext(ctx, query, caloriesVal, time.Now(), date)
+	result, err := execer.ExecContext(ctx, query, calories, domain.ActiveCaloriesEstimated, time.Now(), date, domain.ActiveCaloriesWearable)
 	if err != nil {
-		return err
+		return false, err
 	}
-
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	if rowsAffected == 0 {
-		return ErrDailyLogNotFound
+	if rowsAffected > 0 {
+		return true, nil
 	}
 
-	return nil
+	if _, err := s.GetIDByDate(ctx, date); err != nil {
+		return false, err
+	}
+	return false, nil
 }
 
 // GetRecentBodyFat returns the most recent body fat measurement within the lookback period.
@@ -738,6 +896,50 @@ func (s *DailyLogStore) GetRHRHistory(ctx context.Context, beforeDate string, da
 	return rhrValues, nil
 }
 
+// RecoveryMetrics holds the HRV, resting heart rate and sleep quality logged
+// over a date range, oldest first. Days without a value are left out.
+type RecoveryMetrics struct {
+	HRV          []float64
+	RestingHR    []float64
+	SleepQuality []float64
+}
+
+// ListRecoveryMetrics returns the recovery metrics logged between startDate
+// and endDate (inclusive).
+func (s *DailyLogStore) ListRecoveryMetrics(ctx context.Context, startDate, endDate string) (RecoveryMetrics, error) {
+	const query = `
+		SELECT hrv_ms, resting_heart_rate, sleep_quality
+		FROM daily_logs
+		WHERE log_date BETWEEN $1 AND $2
+		ORDER BY log_date
+	`
+
+	var metrics RecoveryMetrics
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return metrics, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hrv, rhr, sleep sql.NullInt64
+		if err := rows.Scan(&hrv, &rhr, &sleep); err != nil {
+			return metrics, err
+		}
+		if hrv.Valid {
+			metrics.HRV = append(metrics.HRV, float64(hrv.Int64))
+		}
+		if rhr.Valid {
+			metrics.RestingHR = append(metrics.RestingHR, float64(rhr.Int64))
+		}
+		if sleep.Valid && sleep.Int64 > 0 {
+			metrics.SleepQuality = append(metrics.SleepQuality, float64(sleep.Int64))
+		}
+	}
+
+	return metrics, rows.Err()
+}
+
 // UpdateFastingOverride updates the fasting override for a given date.
 // Pass nil to clear the override (revert to profile default).
 // Returns ErrDailyLogNotFound if no log exists for that date.
@@ -765,6 +967,78 @@ func (s *DailyLogStore) UpdateFastingOverride(ctx context.Context, date string,
 	return nil
 }
 
+// UpdateCheckIn sets the mood, stress, soreness and hunger check-in for a given date.
+// Returns ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogStore) UpdateCheckIn(ctx context.Context, date string, checkIn domain.CheckIn) error {
+	const query = `
+		UPDATE daily_logs
+		SET mood = $1, stress = $2, soreness = $3, hunger = $4, updated_at = $5
+		WHERE log_date = $6
+	`
+
+	result, err := s.db.ExecContext(ctx, query, checkIn.Mood, checkIn.Stress, checkIn.Soreness, checkIn.Hunger, time.Now(), date)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDailyLogNotFound
+	}
+
+	return nil
+}
+
+// UpdateEnvironment sets the day's environment conditions and the water
+// target recalculated for them.
+// Returns ErrDailyLogNotFound if no log exists for that date.
+func (s *DailyLogStore) UpdateEnvironment(ctx context.Context, date string, conditions []domain.EnvironmentCondition, waterL float64) error {
+	const query = `
+		UPDATE daily_logs
+		SET environment = $1, water_l = $2, updated_at = $3
+		WHERE log_date = $4
+	`
+
+	result, err := s.db.ExecContext(ctx, query, environmentJSON(conditions), waterL, time.Now(), date)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDailyLogNotFound
+	}
+
+	return nil
+}
+
+// checkInFromColumns builds a check-in from its nullable columns, which are
+// always written together (hunger may be NULL). Returns nil for days without
+// a check-in.
+func checkInFromColumns(mood, stress, soreness, hunger sql.NullInt64) *domain.CheckIn {
+	if !mood.Valid || !stress.Valid || !soreness.Valid {
+		return nil
+	}
+	checkIn := &domain.CheckIn{
+		Mood:     int(mood.Int64),
+		Stress:   int(stress.Int64),
+		Soreness: int(soreness.Int64),
+	}
+	if hunger.Valid {
+		h := int(hunger.Int64)
+		checkIn.Hunger = &h
+	}
+	return checkIn
+}
+
 // UpdateFastedItemsKcal updates the fasted items kcal for a given date.
 // Returns ErrDailyLogNotFound if no log exists for that date.
 func (s *DailyLogStore) UpdateFastedItemsKcal(ctx context.Context, date string, kcal int) error {
@@ -793,8 +1067,10 @@ func (s *DailyLogStore) UpdateFastedItemsKcal(ctx context.Context, date string,
 
 // ConsumedMacros represents the macros to add to the daily log.
 // Meal is optional - if provided, also updates per-meal columns.
+// EatenAt is optional - if provided, also records a timed meal entry.
 type ConsumedMacros struct {
 	Meal     *domain.MealName // Optional: "breakfast", "lunch", or "dinner"
+	EatenAt  string           // Optional: HH:MM the food was eaten
 	Calories int
 	ProteinG int
 	CarbsG   int
@@ -853,9 +1129,56 @@ func (s *DailyLogStore) AddConsumedMacros(ctx context.Context, date string, macr
 		return ErrDailyLogNotFound
 	}
 
+	if macros.EatenAt != "" {
+		var meal interface{}
+		if macros.Meal != nil {
+			meal = string(*macros.Meal)
+		}
+		_, err := s.db.ExecContext(ctx,
+			`INSERT INTO meal_entries (log_date, meal, eaten_at, calories) VALUES ($1, $2, $3, $4)`,
+			date, meal, macros.EatenAt, macros.Calories,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record meal entry: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// ListMealEntries returns the timed meal entries from startDate to endDate
+// (inclusive), ordered by date and time eaten. Food items logged without a
+// time are left out.
+func (s *DailyLogStore) ListMealEntries(ctx context.Context, startDate, endDate string) ([]domain.MealEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT log_date, meal, eaten_at, calories
+		FROM meal_entries
+		WHERE log_date >= $1 AND log_date <= $2 AND eaten_at IS NOT NULL
+		ORDER BY log_date, eaten_at
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []domain.MealEntry{}
+	for rows.Next() {
+		var (
+			entry domain.MealEntry
+			meal  sql.NullString
+		)
+		if err := rows.Scan(&entry.Date, &meal, &entry.EatenAt, &entry.Calories); err != nil {
+			return nil, err
+		}
+		if meal.Valid {
+			m := domain.MealName(meal.String)
+			entry.Meal = &m
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
 // ClearMealConsumedMacros clears the consumed macros for a specific meal slot and
 // subtracts those values from the aggregate totals.
 // Returns ErrDailyLogNotFound if no log exists for that date.
@@ -912,6 +1235,13 @@ func (s *DailyLogStore) ClearMealConsumedMacros(ctx context.Context, date string
 		return ErrDailyLogNotFound
 	}
 
+	// The meal's timed entries go with its macros
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM meal_entries WHERE log_date = $1 AND meal = $2`, date, mealPrefix,
+	); err != nil {
+		return fmt.Errorf("failed to clear meal entries: %w", err)
+	}
+
 	return nil
 }
 
@@ -923,6 +1253,7 @@ type HealthKitMetrics struct {
 	RestingHeartRate     *int
 	SleepHours           *float64
 	WeightKg             *float64
+	WeighInTime          *string // HH:MM of the weight reading
 	BodyFatPercent       *float64
 }
 
@@ -971,6 +1302,9 @@ func (s *DailyLogStore) updateHealthKitMetrics(ctx context.Context, date string,
 		setClauses = append(setClauses, fmt.Sprintf("active_calories_burned = $%d", paramNum))
 		args = append(args, *metrics.ActiveCaloriesBurned)
 		paramNum++
+		setClauses = append(setClauses, fmt.Sprintf("active_calories_source = $%d", paramNum))
+		args = append(args, domain.ActiveCaloriesWearable)
+		paramNum++
 	}
 	if metrics.RestingHeartRate != nil {
 		setClauses = append(setClauses, fmt.Sprintf("resting_heart_rate = $%d", paramNum))
@@ -987,6 +1321,10 @@ func (s *DailyLogStore) updateHealthKitMetrics(ctx context.Context, date string,
 		args = append(args, *metrics.WeightKg)
 		paramNum++
 		setClauses = append(setClauses, "has_explicit_weight = true")
+		// A new reading replaces the old reading's time, known or not
+		setClauses = append(setClauses, fmt.Sprintf("weigh_in_time = $%d", paramNum))
+		args = append(args, metrics.WeighInTime)
+		paramNum++
 	}
 	if metrics.BodyFatPercent != nil {
 		setClauses = append(setClauses, fmt.Sprintf("body_fat_percent = $%d", paramNum))
@@ -1033,19 +1371,19 @@ func (s *DailyLogStore) createMinimalLog(ctx context.Context, date string, metri
 			log_date, weight_kg, body_fat_percent, resting_heart_rate,
 			sleep_quality, sleep_hours,
 			planned_training_type, planned_duration_min,
-			day_type, active_calories_burned, steps,
-			created_at, updated_at
+			day_type, active_calories_burned, active_calories_source, steps,
+			created_at, updated_at, weigh_in_time
 		) VALUES (
 			$1, $2, $3, $4,
 			50, $5,
 			'rest', 0,
-			'fatburner', $6, $7,
-			$8, $9
+			'fatburner', $6, $7, $8,
+			$9, $10, $11
 		)
 	`
 
 	// Handle nullable fields
-	var bodyFatPercent, sleepHours, activeCaloriesBurned, steps, heartRate interface{}
+	var bodyFatPercent, sleepHours, activeCaloriesBurned, activeCaloriesSource, steps, heartRate interface{}
 
 	if metrics.BodyFatPercent != nil {
 		bodyFatPercent = *metrics.BodyFatPercent
@@ -1055,6 +1393,7 @@ func (s *DailyLogStore) createMinimalLog(ctx context.Context, date string, metri
 	}
 	if metrics.ActiveCaloriesBurned != nil {
 		activeCaloriesBurned = *metrics.ActiveCaloriesBurned
+		activeCaloriesSource = domain.ActiveCaloriesWearable
 	}
 	if metrics.Steps != nil {
 		steps = *metrics.Steps
@@ -1067,8 +1406,8 @@ func (s *DailyLogStore) createMinimalLog(ctx context.Context, date string, metri
 	_, err := s.db.ExecContext(ctx, query,
 		date, *metrics.WeightKg, bodyFatPercent, heartRate,
 		sleepHours,
-		activeCaloriesBurned, steps,
-		now, now,
+		activeCaloriesBurned, activeCaloriesSource, steps,
+		now, now, metrics.WeighInTime,
 	)
 	if err != nil {
 		if isUniqueConstraint(err) {
@@ -1087,7 +1426,7 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 	const query = `
 		SELECT
 			id, log_date, weight_kg, body_fat_percent, resting_heart_rate, hrv_ms,
-			sleep_quality, sleep_hours,
+			sleep_quality, sleep_hours, bedtime,
 			COALESCE(total_carbs_g, 0), COALESCE(total_protein_g, 0), COALESCE(total_fats_g, 0), COALESCE(total_calories, 0),
 			COALESCE(breakfast_carb_points, 0), COALESCE(breakfast_protein_points, 0), COALESCE(breakfast_fat_points, 0),
 			COALESCE(lunch_carb_points, 0), COALESCE(lunch_protein_points, 0), COALESCE(lunch_fat_points, 0),
@@ -1095,8 +1434,9 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 			COALESCE(fruit_g, 0), COALESCE(veggies_g, 0), COALESCE(water_l, 0), COALESCE(day_type, 'fatburner'),
 			COALESCE(estimated_tdee, 0), COALESCE(formula_tdee, 0),
 			COALESCE(tdee_source_used, 'formula'), COALESCE(tdee_confidence, 0), COALESCE(data_points_used, 0),
-			active_calories_burned, steps, COALESCE(notes, ''),
+			active_calories_burned, COALESCE(active_calories_source, ''), steps, COALESCE(notes, ''),
 			fasting_override, COALESCE(fasted_items_kcal, 0),
+			mood, stress, soreness, hunger, environment,
 			COALESCE(consumed_calories, 0), COALESCE(consumed_protein_g, 0),
 			COALESCE(consumed_carbs_g, 0), COALESCE(consumed_fat_g, 0),
 			COALESCE(breakfast_consumed_kcal, 0), COALESCE(breakfast_consumed_protein_g, 0),
@@ -1125,16 +1465,22 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 			heartRate            sql.NullInt64
 			hrvMs                sql.NullInt64
 			sleepHours           sql.NullFloat64
+			bedtime              sql.NullString
 			activeCaloriesBurned sql.NullInt64
 			stepsVal             sql.NullInt64
 			fastingOverride      sql.NullString
+			mood                 sql.NullInt64
+			stress               sql.NullInt64
+			soreness             sql.NullInt64
+			hunger               sql.NullInt64
+			environment          string
 			createdAt            string
 			updatedAt            string
 		)
 
 		if err := rows.Scan(
 			&log.ID, &log.Date, &log.WeightKg, &bodyFatPercent, &heartRate, &hrvMs,
-			&log.SleepQuality, &sleepHours,
+			&log.SleepQuality, &sleepHours, &bedtime,
 			&log.CalculatedTargets.TotalCarbsG, &log.CalculatedTargets.TotalProteinG,
 			&log.CalculatedTargets.TotalFatsG, &log.CalculatedTargets.TotalCalories,
 			&log.CalculatedTargets.Meals.Breakfast.Carbs, &log.CalculatedTargets.Meals.Breakfast.Protein,
@@ -1147,8 +1493,9 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 			&log.CalculatedTargets.WaterL, &log.CalculatedTargets.DayType,
 			&log.EstimatedTDEE, &log.FormulaTDEE,
 			&log.TDEESourceUsed, &log.TDEEConfidence, &log.DataPointsUsed,
-			&activeCaloriesBurned, &stepsVal, &log.Notes,
+			&activeCaloriesBurned, &log.ActiveCaloriesSource, &stepsVal, &log.Notes,
 			&fastingOverride, &log.FastedItemsKcal,
+			&mood, &stress, &soreness, &hunger, &environment,
 			&log.ConsumedCalories, &log.ConsumedProteinG,
 			&log.ConsumedCarbsG, &log.ConsumedFatG,
 			&log.MealConsumed.Breakfast.Calories, &log.MealConsumed.Breakfast.ProteinG,
@@ -1177,6 +1524,9 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 		if sleepHours.Valid {
 			log.SleepHours = &sleepHours.Float64
 		}
+		if bedtime.Valid {
+			log.Bedtime = &bedtime.String
+		}
 		if activeCaloriesBurned.Valid {
 			acb := int(activeCaloriesBurned.Int64)
 			log.ActiveCaloriesBurned = &acb
@@ -1189,6 +1539,8 @@ func (s *DailyLogStore) ListByDateRange(ctx context.Context, startDate, endDate
 			fp := domain.FastingProtocol(fastingOverride.String)
 			log.FastingOverride = &fp
 		}
+		log.CheckIn = checkInFromColumns(mood, stress, soreness, hunger)
+		log.Environment = parseEnvironmentJSON(environment)
 
 		// Parse timestamps
 		log.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
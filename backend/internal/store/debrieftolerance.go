@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// DebriefToleranceStore keeps the adherence tolerances each completed week
+// was scored with.
+type DebriefToleranceStore struct {
+	db DBTX
+}
+
+// NewDebriefToleranceStore creates a new DebriefToleranceStore.
+func NewDebriefToleranceStore(db DBTX) *DebriefToleranceStore {
+	return &DebriefToleranceStore{db: db}
+}
+
+// Get returns the tolerances recorded for a week, or nil if none were.
+func (s *DebriefToleranceStore) Get(ctx context.Context, weekStartDate string) (*domain.AdherenceTolerances, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT tolerances FROM debrief_tolerances WHERE week_start_date = $1`, weekStartDate,
+	).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var t domain.AdherenceTolerances
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Record stores a week's tolerances. The first record wins, so a week keeps
+// the tolerances it was first scored with.
+func (s *DebriefToleranceStore) Record(ctx context.Context, weekStartDate string, t domain.AdherenceTolerances, now time.Time) error {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO debrief_tolerances (week_start_date, tolerances, recorded_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (week_start_date) DO NOTHING
+	`, weekStartDate, raw, now)
+	return err
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"victus/internal/domain"
+)
+
+// EmbeddingStore handles persistence for pgvector embeddings of catalog items.
+type EmbeddingStore struct {
+	db DBTX
+}
+
+// NewEmbeddingStore creates a new EmbeddingStore.
+func NewEmbeddingStore(db DBTX) *EmbeddingStore {
+	return &EmbeddingStore{db: db}
+}
+
+// vectorLiteral formats an embedding as a pgvector text literal, e.g. "[0.1,-0.2]".
+func vectorLiteral(v []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// ContentHashes returns the content hash of each item of a kind indexed with model.
+// Items indexed with another model are left out, so they get re-embedded.
+func (s *EmbeddingStore) ContentHashes(ctx context.Context, kind domain.EmbeddingKind, model string) (map[string]string, error) {
+	const query = `SELECT item_id, content_hash FROM embeddings WHERE kind = $1 AND model = $2`
+
+	rows, err := s.db.QueryContext(ctx, query, string(kind), model)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, err
+		}
+		hashes[id] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// Upsert stores an item's embedding, replacing any earlier one.
+func (s *EmbeddingStore) Upsert(ctx context.Context, kind domain.EmbeddingKind, doc domain.EmbeddingDocument, model string, embedding []float32) error {
+	const query = `
+		INSERT INTO embeddings (kind, item_id, model, content_hash, embedding, indexed_at)
+		VALUES ($1, $2, $3, $4, $5::vector, NOW())
+		ON CONFLICT (kind, item_id) DO UPDATE SET
+			model = EXCLUDED.model,
+			content_hash = EXCLUDED.content_hash,
+			embedding = EXCLUDED.embedding,
+			indexed_at = EXCLUDED.indexed_at
+	`
+	_, err := s.db.ExecContext(ctx, query, string(kind), doc.ItemID, model, doc.Hash(), vectorLiteral(embedding))
+	return err
+}
+
+// DeleteExcept removes embeddings of a kind whose item is not in keep,
+// i.e. items deleted from the catalog. Returns the number removed.
+func (s *EmbeddingStore) DeleteExcept(ctx context.Context, kind domain.EmbeddingKind, keep []string) (int, error) {
+	const query = `DELETE FROM embeddings WHERE kind = $1 AND NOT (item_id = ANY($2))`
+
+	result, err := s.db.ExecContext(ctx, query, string(kind), keep)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// Search returns the items of a kind closest to the query embedding by cosine
+// similarity, best first. Only embeddings from the same model are compared.
+func (s *EmbeddingStore) Search(ctx context.Context, kind domain.EmbeddingKind, model string, embedding []float32, limit int) ([]domain.SemanticMatch, error) {
+	const query = `
+		SELECT item_id, 1 - (embedding <=> $3::vector) AS similarity
+		FROM embeddings
+		WHERE kind = $1 AND model = $2 AND vector_dims(embedding) = $4
+		ORDER BY embedding <=> $3::vector
+		LIMIT $5
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, string(kind), model, vectorLiteral(embedding), len(embedding), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make([]domain.SemanticMatch, 0, limit)
+	for rows.Next() {
+		var m domain.SemanticMatch
+		if err := rows.Scan(&m.ItemID, &m.Similarity); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
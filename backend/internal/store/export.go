@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// exportQueries select every column of each exported table. Rows that refer
+// to a log, session or plan also carry its natural key (log_date,
+// session_order, plan_start_date), so an archive reads without the ids and
+// can be matched against another database's rows.
+var exportQueries = map[domain.ExportTable]string{
+	domain.ExportDailyLogs: `
+		SELECT * FROM daily_logs ORDER BY log_date`,
+	domain.ExportTrainingSessions: `
+		SELECT dl.log_date, ts.*
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		ORDER BY dl.log_date, ts.is_planned DESC, ts.session_order`,
+	domain.ExportNutritionPlans: `
+		SELECT * FROM nutrition_plans ORDER BY start_date, id`,
+	domain.ExportWeeklyTargets: `
+		SELECT np.start_date AS plan_start_date, wt.*
+		FROM weekly_targets wt
+		JOIN nutrition_plans np ON np.id = wt.plan_id
+		ORDER BY np.start_date, wt.plan_id, wt.week_number`,
+	domain.ExportMetabolicHistory: `
+		SELECT dl.log_date, mh.*
+		FROM metabolic_history mh
+		JOIN daily_logs dl ON dl.id = mh.daily_log_id
+		ORDER BY dl.log_date, mh.calculated_at, mh.id`,
+	domain.ExportFatigueEvents: `
+		SELECT dl.log_date, ts.session_order, ts.is_planned, fe.*
+		FROM fatigue_events fe
+		JOIN training_sessions ts ON ts.id = fe.training_session_id
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		ORDER BY fe.applied_at, fe.id`,
+}
+
+// ExportSink receives the rows of an export, one table at a time.
+type ExportSink interface {
+	BeginTable(table domain.ExportTable, columns []string) error
+	WriteRow(values []any) error
+}
+
+// ExportStore reads whole tables for full-history exports.
+type ExportStore struct {
+	db DBTX
+}
+
+// NewExportStore creates a new ExportStore.
+func NewExportStore(db DBTX) *ExportStore {
+	return &ExportStore{db: db}
+}
+
+// StreamTables sends every row of the tables to sink, in order, from one
+// read-only snapshot so the tables agree with each other. Values are nil,
+// string, bool, int64, float64 or time.Time.
+func (s *ExportStore) StreamTables(ctx context.Context, tables []domain.ExportTable, sink ExportSink) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range tables {
+		if err := streamTable(ctx, tx, table, sink); err != nil {
+			return fmt.Errorf("export %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func streamTable(ctx context.Context, tx *sql.Tx, table domain.ExportTable, sink ExportSink) error {
+	query, ok := exportQueries[table]
+	if !ok {
+		return fmt.Errorf("table is not exportable")
+	}
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	columns := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = ct.Name()
+	}
+	if err := sink.BeginTable(table, columns); err != nil {
+		return err
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		row := make([]any, len(values))
+		for i, v := range values {
+			row[i] = exportValue(v, columnTypes[i].DatabaseTypeName())
+		}
+		if err := sink.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportValue normalizes a scanned value. REAL columns arrive widened to
+// float64 and are rounded back to their stored precision, so 82.3 exports as
+// 82.3 rather than 82.30000305175781.
+func exportValue(v any, dbType string) any {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return exportFloat32(v)
+	case float64:
+		if dbType == "FLOAT4" {
+			return exportFloat32(float32(v))
+		}
+		return v
+	case time.Time:
+		return v.UTC()
+	}
+	return v
+}
+
+func exportFloat32(f float32) float64 {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return rounded
+}
@@ -122,33 +122,50 @@ func (s *FatigueStore) GetArchetypeByName(ctx context.Context, name domain.Arche
 type MuscleFatigueRow struct {
 	MuscleGroupID  int
 	MuscleName     string
-	FatiguePercent float64
+	FatiguePercent float64             // Average of the sides when they're split
+	Sides          *domain.SideFatigue // Nil unless a left/right split is tracked
 	LastUpdated    string
 }
 
+// allMuscleFatigueQuery reads the current fatigue of every muscle with an entry.
+const allMuscleFatigueQuery = `
+	SELECT mf.muscle_group_id, mg.name, mf.fatigue_percent, mf.left_percent, mf.right_percent, mf.last_updated
+	FROM muscle_fatigue mf
+	JOIN muscle_groups mg ON mf.muscle_group_id = mg.id
+	ORDER BY mg.id
+`
+
 // GetAllMuscleFatigue retrieves current fatigue state for all muscles.
 // Returns rows for muscles that have fatigue entries.
 func (s *FatigueStore) GetAllMuscleFatigue(ctx context.Context) ([]MuscleFatigueRow, error) {
-	const query = `
-		SELECT mf.muscle_group_id, mg.name, mf.fatigue_percent, mf.last_updated
-		FROM muscle_fatigue mf
-		JOIN muscle_groups mg ON mf.muscle_group_id = mg.id
-		ORDER BY mg.id
-	`
+	rows, err := s.db.QueryContext(ctx, allMuscleFatigueQuery)
+	if err != nil {
+		return nil, err
+	}
+	return scanAllMuscleFatigue(rows)
+}
 
-	rows, err := s.db.QueryContext(ctx, query)
+// GetAllMuscleFatigueWithTx retrieves current fatigue state for all muscles
+// within a transaction, locking the rows until it ends so the levels read
+// are still current when the transaction writes new ones.
+func (s *FatigueStore) GetAllMuscleFatigueWithTx(ctx context.Context, tx *sql.Tx) ([]MuscleFatigueRow, error) {
+	rows, err := tx.QueryContext(ctx, allMuscleFatigueQuery+" FOR UPDATE OF mf")
 	if err != nil {
 		return nil, err
 	}
+	return scanAllMuscleFatigue(rows)
+}
+
+func scanAllMuscleFatigue(rows *sql.Rows) ([]MuscleFatigueRow, error) {
 	defer rows.Close()
 
 	var results []MuscleFatigueRow
 	for rows.Next() {
-		var r MuscleFatigueRow
-		if err := rows.Scan(&r.MuscleGroupID, &r.MuscleName, &r.FatiguePercent, &r.LastUpdated); err != nil {
+		r, err := scanMuscleFatigue(rows)
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, r)
+		results = append(results, *r)
 	}
 
 	return results, rows.Err()
@@ -157,16 +174,13 @@ func (s *FatigueStore) GetAllMuscleFatigue(ctx context.Context) ([]MuscleFatigue
 // GetMuscleFatigue retrieves fatigue state for a specific muscle.
 func (s *FatigueStore) GetMuscleFatigue(ctx context.Context, muscleGroupID int) (*MuscleFatigueRow, error) {
 	const query = `
-		SELECT mf.muscle_group_id, mg.name, mf.fatigue_percent, mf.last_updated
+		SELECT mf.muscle_group_id, mg.name, mf.fatigue_percent, mf.left_percent, mf.right_percent, mf.last_updated
 		FROM muscle_fatigue mf
 		JOIN muscle_groups mg ON mf.muscle_group_id = mg.id
 		WHERE mf.muscle_group_id = $1
 	`
 
-	var r MuscleFatigueRow
-	err := s.db.QueryRowContext(ctx, query, muscleGroupID).Scan(
-		&r.MuscleGroupID, &r.MuscleName, &r.FatiguePercent, &r.LastUpdated,
-	)
+	r, err := scanMuscleFatigue(s.db.QueryRowContext(ctx, query, muscleGroupID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // No fatigue entry = fresh muscle
@@ -174,35 +188,93 @@ func (s *FatigueStore) GetMuscleFatigue(ctx context.Context, muscleGroupID int)
 		return nil, err
 	}
 
+	return r, nil
+}
+
+type muscleFatigueScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMuscleFatigue(row muscleFatigueScanner) (*MuscleFatigueRow, error) {
+	var r MuscleFatigueRow
+	var left, right sql.NullFloat64
+	if err := row.Scan(&r.MuscleGroupID, &r.MuscleName, &r.FatiguePercent, &left, &right, &r.LastUpdated); err != nil {
+		return nil, err
+	}
+	if left.Valid && right.Valid {
+		r.Sides = &domain.SideFatigue{Left: left.Float64, Right: right.Float64}
+	}
 	return &r, nil
 }
 
-// UpsertMuscleFatigue updates or inserts fatigue for a muscle.
-func (s *FatigueStore) UpsertMuscleFatigue(ctx context.Context, muscleGroupID int, fatiguePercent float64) error {
-	const query = `
-		INSERT INTO muscle_fatigue (muscle_group_id, fatigue_percent, last_updated)
-		VALUES ($1, $2, $3)
+// upsertMuscleFatigueQuery updates the current fatigue for a muscle and appends
+// the new value to muscle_fatigue_snapshots so historical states can be rebuilt.
+const upsertMuscleFatigueQuery = `
+	WITH upserted AS (
+		INSERT INTO muscle_fatigue (muscle_group_id, fatigue_percent, left_percent, right_percent, last_updated)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT(muscle_group_id) DO UPDATE SET
 			fatigue_percent = excluded.fatigue_percent,
+			left_percent = excluded.left_percent,
+			right_percent = excluded.right_percent,
 			last_updated = excluded.last_updated
-	`
-
-	_, err := s.db.ExecContext(ctx, query, muscleGroupID, fatiguePercent, time.Now())
+		RETURNING muscle_group_id, fatigue_percent, left_percent, right_percent, last_updated
+	)
+	INSERT INTO muscle_fatigue_snapshots (muscle_group_id, fatigue_percent, left_percent, right_percent, recorded_at)
+	SELECT muscle_group_id, fatigue_percent, left_percent, right_percent, last_updated FROM upserted
+`
+
+// UpsertMuscleFatigue updates or inserts fatigue for a muscle. sides is nil
+// when the muscle has no left/right split.
+func (s *FatigueStore) UpsertMuscleFatigue(ctx context.Context, muscleGroupID int, fatiguePercent float64, sides *domain.SideFatigue) error {
+	left, right := sideArgs(sides)
+	_, err := s.db.ExecContext(ctx, upsertMuscleFatigueQuery, muscleGroupID, fatiguePercent, left, right, time.Now())
 	return err
 }
 
 // UpsertMuscleFatigueWithTx updates or inserts fatigue for a muscle within a transaction.
-func (s *FatigueStore) UpsertMuscleFatigueWithTx(ctx context.Context, tx *sql.Tx, muscleGroupID int, fatiguePercent float64) error {
+func (s *FatigueStore) UpsertMuscleFatigueWithTx(ctx context.Context, tx *sql.Tx, muscleGroupID int, fatiguePercent float64, sides *domain.SideFatigue) error {
+	left, right := sideArgs(sides)
+	_, err := tx.ExecContext(ctx, upsertMuscleFatigueQuery, muscleGroupID, fatiguePercent, left, right, time.Now())
+	return err
+}
+
+func sideArgs(sides *domain.SideFatigue) (left, right sql.NullFloat64) {
+	if sides == nil {
+		return left, right
+	}
+	return sql.NullFloat64{Float64: sides.Left, Valid: true}, sql.NullFloat64{Float64: sides.Right, Valid: true}
+}
+
+// GetMuscleFatigueAsOf retrieves the last recorded fatigue for each muscle at or
+// before asOf. LastUpdated holds the snapshot time so callers can apply decay.
+// Muscles with no snapshot before asOf are omitted (fresh at that time).
+func (s *FatigueStore) GetMuscleFatigueAsOf(ctx context.Context, asOf time.Time) ([]MuscleFatigueRow, error) {
 	const query = `
-		INSERT INTO muscle_fatigue (muscle_group_id, fatigue_percent, last_updated)
-		VALUES ($1, $2, $3)
-		ON CONFLICT(muscle_group_id) DO UPDATE SET
-			fatigue_percent = excluded.fatigue_percent,
-			last_updated = excluded.last_updated
+		SELECT DISTINCT ON (mfs.muscle_group_id)
+			mfs.muscle_group_id, mg.name, mfs.fatigue_percent, mfs.left_percent, mfs.right_percent, mfs.recorded_at
+		FROM muscle_fatigue_snapshots mfs
+		JOIN muscle_groups mg ON mfs.muscle_group_id = mg.id
+		WHERE mfs.recorded_at <= $1
+		ORDER BY mfs.muscle_group_id, mfs.recorded_at DESC
 	`
 
-	_, err := tx.ExecContext(ctx, query, muscleGroupID, fatiguePercent, time.Now())
-	return err
+	rows, err := s.db.QueryContext(ctx, query, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []MuscleFatigueRow
+	for rows.Next() {
+		r, err := scanMuscleFatigue(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *r)
+	}
+
+	return results, rows.Err()
 }
 
 // RecordFatigueEvent logs a fatigue injection event.
@@ -216,6 +288,40 @@ func (s *FatigueStore) RecordFatigueEvent(ctx context.Context, tx *sql.Tx, train
 	return err
 }
 
+// FatigueEventRow is a recorded fatigue injection.
+type FatigueEventRow struct {
+	ArchetypeID int
+	TotalLoad   float64
+	AppliedAt   time.Time
+}
+
+// DeleteEventsForLogWithTx removes the fatigue events of a daily log's
+// sessions and returns them, so their injections can be reversed.
+func (s *FatigueStore) DeleteEventsForLogWithTx(ctx context.Context, tx *sql.Tx, dailyLogID int64) ([]FatigueEventRow, error) {
+	const query = `
+		DELETE FROM fatigue_events fe
+		USING training_sessions ts
+		WHERE fe.training_session_id = ts.id AND ts.daily_log_id = $1
+		RETURNING fe.archetype_id, fe.total_load, fe.applied_at
+	`
+
+	rows, err := tx.QueryContext(ctx, query, dailyLogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []FatigueEventRow
+	for rows.Next() {
+		var e FatigueEventRow
+		if err := rows.Scan(&e.ArchetypeID, &e.TotalLoad, &e.AppliedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
 // GetMuscleGroupIDByName retrieves the ID for a muscle group by name.
 func (s *FatigueStore) GetMuscleGroupIDByName(ctx context.Context, name domain.MuscleGroup) (int, error) {
 	const query = `SELECT id FROM muscle_groups WHERE name = $1`
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+
+	"victus/internal/domain"
+)
+
+// FoodPortionStore handles persistence for logged food portions used to learn default serving sizes.
+type FoodPortionStore struct {
+	db DBTX
+}
+
+// NewFoodPortionStore creates a new FoodPortionStore.
+func NewFoodPortionStore(db DBTX) *FoodPortionStore {
+	return &FoodPortionStore{db: db}
+}
+
+// Record stores a logged portion.
+// Returns ErrFoodReferenceNotFound for an unknown food.
+func (s *FoodPortionStore) Record(ctx context.Context, e domain.FoodPortionEntry) error {
+	const query = `
+		INSERT INTO food_portion_log (food_reference_id, meal, quantity_g, log_date)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	meal := ""
+	if e.Meal != nil {
+		meal = string(*e.Meal)
+	}
+
+	_, err := s.db.ExecContext(ctx, query, e.FoodReferenceID, meal, e.QuantityG, e.Date)
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrFoodReferenceNotFound
+	}
+	return err
+}
+
+// ListRecent returns the most recent portions logged for a food, newest first.
+func (s *FoodPortionStore) ListRecent(ctx context.Context, foodReferenceID int64, limit int) ([]domain.FoodPortionEntry, error) {
+	const query = `
+		SELECT food_reference_id, meal, quantity_g, log_date, created_at
+		FROM food_portion_log
+		WHERE food_reference_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, foodReferenceID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.FoodPortionEntry, 0)
+	for rows.Next() {
+		var e domain.FoodPortionEntry
+		var meal string
+		if err := rows.Scan(&e.FoodReferenceID, &meal, &e.QuantityG, &e.Date, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if meal != "" {
+			m := domain.MealName(meal)
+			e.Meal = &m
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// ListDailyTotals returns the total quantity of each food logged per day in
+// a date range (inclusive), with the food's serving size for pricing.
+func (s *FoodPortionStore) ListDailyTotals(ctx context.Context, startDate, endDate string) ([]domain.LoggedFoodQuantity, error) {
+	const query = `
+		SELECT p.log_date, p.food_reference_id, SUM(p.quantity_g), COALESCE(f.serving_size_g, 100)
+		FROM food_portion_log p
+		JOIN food_reference f ON f.id = p.food_reference_id
+		WHERE p.log_date >= $1 AND p.log_date <= $2
+		GROUP BY p.log_date, p.food_reference_id, f.serving_size_g
+		ORDER BY p.log_date, p.food_reference_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]domain.LoggedFoodQuantity, 0)
+	for rows.Next() {
+		var q domain.LoggedFoodQuantity
+		if err := rows.Scan(&q.Date, &q.FoodReferenceID, &q.QuantityG, &q.ServingSizeG); err != nil {
+			return nil, err
+		}
+		totals = append(totals, q)
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrFoodPriceNotFound is returned when a food has no price to remove.
+var ErrFoodPriceNotFound = errors.New("food price not found")
+
+// FoodPriceStore handles persistence for user-entered food prices.
+type FoodPriceStore struct {
+	db DBTX
+}
+
+// NewFoodPriceStore creates a new FoodPriceStore.
+func NewFoodPriceStore(db DBTX) *FoodPriceStore {
+	return &FoodPriceStore{db: db}
+}
+
+// List returns all prices keyed by food.
+func (s *FoodPriceStore) List(ctx context.Context) (domain.FoodPrices, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT food_reference_id, amount, basis, updated_at FROM food_prices`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(domain.FoodPrices)
+	for rows.Next() {
+		var p domain.FoodPrice
+		if err := rows.Scan(&p.FoodReferenceID, &p.Amount, &p.Basis, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		prices[p.FoodReferenceID] = p
+	}
+	return prices, rows.Err()
+}
+
+// Upsert sets a food's price, replacing any earlier one.
+// Returns ErrFoodReferenceNotFound for an unknown food.
+func (s *FoodPriceStore) Upsert(ctx context.Context, p domain.FoodPrice) error {
+	const query = `
+		INSERT INTO food_prices (food_reference_id, amount, basis, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (food_reference_id) DO UPDATE
+		SET amount = EXCLUDED.amount, basis = EXCLUDED.basis, updated_at = EXCLUDED.updated_at
+	`
+	_, err := s.db.ExecContext(ctx, query, p.FoodReferenceID, p.Amount, string(p.Basis), p.UpdatedAt)
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrFoodReferenceNotFound
+	}
+	return err
+}
+
+// Delete removes a food's price.
+// Returns ErrFoodPriceNotFound if the food has none.
+func (s *FoodPriceStore) Delete(ctx context.Context, foodReferenceID int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM food_prices WHERE food_reference_id = $1`, foodReferenceID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrFoodPriceNotFound
+	}
+	return nil
+}
@@ -3,11 +3,15 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"victus/internal/domain"
 )
 
+// ErrFoodReferenceNotFound is returned when a food reference item doesn't exist.
+var ErrFoodReferenceNotFound = errors.New("food reference not found")
+
 // FoodReferenceStore handles database operations for food reference items.
 type FoodReferenceStore struct {
 	db DBTX
@@ -146,3 +150,79 @@ func (s *FoodReferenceStore) ListPantryFoods(ctx context.Context) ([]domain.Food
 
 	return result, nil
 }
+
+// GetNutrition retrieves a food's nutritional data, with or without macros set.
+// Returns ErrFoodReferenceNotFound if the food doesn't exist.
+func (s *FoodReferenceStore) GetNutrition(ctx context.Context, id int64) (*domain.FoodNutrition, error) {
+	const query = `
+		SELECT
+			id, category, food_item,
+			COALESCE(protein_g_per_100, 0), COALESCE(carbs_g_per_100, 0), COALESCE(fat_g_per_100, 0),
+			COALESCE(serving_unit, 'g'), COALESCE(serving_size_g, 100), COALESCE(is_pantry_staple, false)
+		FROM food_reference
+		WHERE id = $1
+	`
+
+	var fn domain.FoodNutrition
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&fn.ID, &fn.Category, &fn.FoodItem,
+		&fn.ProteinGPer100, &fn.CarbsGPer100, &fn.FatGPer100,
+		&fn.ServingUnit, &fn.ServingSizeG, &fn.IsPantryStaple,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrFoodReferenceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &fn, nil
+}
+
+// ListSynonyms retrieves all food aliases.
+func (s *FoodReferenceStore) ListSynonyms(ctx context.Context) ([]domain.FoodSynonym, error) {
+	const query = `
+		SELECT alias, food_reference_id, source
+		FROM food_synonyms
+		ORDER BY alias
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]domain.FoodSynonym, 0)
+	for rows.Next() {
+		var syn domain.FoodSynonym
+		if err := rows.Scan(&syn.Alias, &syn.FoodReferenceID, &syn.Source); err != nil {
+			return nil, err
+		}
+		result = append(result, syn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpsertSynonym maps an alias to a food, replacing any earlier mapping for the alias.
+// Returns ErrFoodReferenceNotFound if the food doesn't exist.
+func (s *FoodReferenceStore) UpsertSynonym(ctx context.Context, syn domain.FoodSynonym) error {
+	const query = `
+		INSERT INTO food_synonyms (alias, food_reference_id, source)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (alias) DO UPDATE SET
+			food_reference_id = EXCLUDED.food_reference_id,
+			source = EXCLUDED.source,
+			updated_at = NOW()
+	`
+
+	_, err := s.db.ExecContext(ctx, query, syn.Alias, syn.FoodReferenceID, syn.Source)
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrFoodReferenceNotFound
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+var (
+	// ErrGroceryListNotFound is returned when a grocery list doesn't exist.
+	ErrGroceryListNotFound = errors.New("grocery list not found")
+	// ErrGroceryItemNotFound is returned when an item isn't on the given list.
+	ErrGroceryItemNotFound = errors.New("grocery item not found")
+)
+
+// GroceryStore handles persistence for grocery lists and their purchases.
+type GroceryStore struct {
+	db DBTX
+}
+
+// NewGroceryStore creates a new GroceryStore.
+func NewGroceryStore(db DBTX) *GroceryStore {
+	return &GroceryStore{db: db}
+}
+
+// Create stores a list and its items in a single transaction, setting their
+// IDs and the list's creation time.
+// Returns ErrFoodReferenceNotFound if an item names an unknown food.
+func (s *GroceryStore) Create(ctx context.Context, l *domain.GroceryList) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO grocery_lists (week_start) VALUES ($1) RETURNING id, created_at`, l.WeekStart,
+	).Scan(&l.ID, &l.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	const itemQuery = `
+		INSERT INTO grocery_list_items (list_id, food_reference_id, amount_g)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+	for i := range l.Items {
+		item := &l.Items[i]
+		if err := tx.QueryRowContext(ctx, itemQuery, l.ID, item.FoodReferenceID, item.AmountG).Scan(&item.ID); err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrFoodReferenceNotFound
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a list with its items.
+// Returns ErrGroceryListNotFound if it doesn't exist.
+func (s *GroceryStore) GetByID(ctx context.Context, id int64) (*domain.GroceryList, error) {
+	l := domain.GroceryList{ID: id}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT week_start, created_at FROM grocery_lists WHERE id = $1`, id,
+	).Scan(&l.WeekStart, &l.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGroceryListNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.listItems(ctx, `WHERE list_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	l.Items = items[id]
+	if l.Items == nil {
+		l.Items = []domain.GroceryItem{}
+	}
+	return &l, nil
+}
+
+// List returns all lists with their items, newest week first.
+func (s *GroceryStore) List(ctx context.Context) ([]domain.GroceryList, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, week_start, created_at FROM grocery_lists ORDER BY week_start DESC, id DESC`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]domain.GroceryList, 0)
+	for rows.Next() {
+		var l domain.GroceryList
+		if err := rows.Scan(&l.ID, &l.WeekStart, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		lists = append(lists, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items, err := s.listItems(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].Items = items[lists[i].ID]
+		if lists[i].Items == nil {
+			lists[i].Items = []domain.GroceryItem{}
+		}
+	}
+	return lists, nil
+}
+
+// listItems returns the items matching where, keyed by list.
+func (s *GroceryStore) listItems(ctx context.Context, where string, args ...any) (map[int64][]domain.GroceryItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, list_id, food_reference_id, amount_g, purchased_on
+		FROM grocery_list_items
+		`+where+`
+		ORDER BY list_id, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[int64][]domain.GroceryItem)
+	for rows.Next() {
+		var (
+			item        domain.GroceryItem
+			listID      int64
+			purchasedOn sql.NullString
+		)
+		if err := rows.Scan(&item.ID, &listID, &item.FoodReferenceID, &item.AmountG, &purchasedOn); err != nil {
+			return nil, err
+		}
+		if purchasedOn.Valid {
+			item.PurchasedOn = &purchasedOn.String
+		}
+		items[listID] = append(items[listID], item)
+	}
+	return items, rows.Err()
+}
+
+// SetItemPurchased sets or clears (nil) the date an item was bought.
+// Returns ErrGroceryItemNotFound if the item isn't on the list.
+func (s *GroceryStore) SetItemPurchased(ctx context.Context, listID, itemID int64, purchasedOn *string) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE grocery_list_items SET purchased_on = $3 WHERE id = $2 AND list_id = $1`,
+		listID, itemID, purchasedOn,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrGroceryItemNotFound
+	}
+	return nil
+}
+
+// Delete removes a list and its items.
+// Returns ErrGroceryListNotFound if it doesn't exist.
+func (s *GroceryStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM grocery_lists WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrGroceryListNotFound
+	}
+	return nil
+}
+
+// ListPurchasedBetween returns the items bought from startDate to endDate
+// (inclusive), with each food's name and serving size.
+func (s *GroceryStore) ListPurchasedBetween(ctx context.Context, startDate, endDate string) ([]domain.PurchasedGroceryItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.food_reference_id, f.food_item, i.amount_g, COALESCE(f.serving_size_g, 100), i.purchased_on
+		FROM grocery_list_items i
+		JOIN food_reference f ON f.id = i.food_reference_id
+		WHERE i.purchased_on >= $1 AND i.purchased_on <= $2
+		ORDER BY i.purchased_on, i.id
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.PurchasedGroceryItem, 0)
+	for rows.Next() {
+		var p domain.PurchasedGroceryItem
+		if err := rows.Scan(&p.ItemID, &p.FoodReferenceID, &p.FoodItem, &p.AmountG, &p.ServingSizeG, &p.PurchasedOn); err != nil {
+			return nil, err
+		}
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+var (
+	// ErrHabitNotFound is returned when a habit doesn't exist.
+	ErrHabitNotFound = errors.New("habit not found")
+	// ErrHabitExists is returned when a habit with the same name already exists.
+	ErrHabitExists = errors.New("habit with this name already exists")
+)
+
+// HabitStore handles persistence for daily habits and their check-offs.
+type HabitStore struct {
+	db DBTX
+}
+
+// NewHabitStore creates a new HabitStore.
+func NewHabitStore(db DBTX) *HabitStore {
+	return &HabitStore{db: db}
+}
+
+// Create stores a new habit and sets its ID and CreatedAt.
+// Returns ErrHabitExists if the name is taken.
+func (s *HabitStore) Create(ctx context.Context, h *domain.Habit) error {
+	const query = `
+		INSERT INTO habits (name, count_in_vitality, archived, start_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := s.db.QueryRowContext(ctx, query, h.Name, h.CountInVitality, h.Archived, h.StartDate).Scan(&h.ID, &h.CreatedAt)
+	if err != nil && isUniqueConstraint(err) {
+		return ErrHabitExists
+	}
+	return err
+}
+
+// GetByID retrieves a habit.
+// Returns ErrHabitNotFound if it doesn't exist.
+func (s *HabitStore) GetByID(ctx context.Context, id int64) (*domain.Habit, error) {
+	const query = `
+		SELECT id, name, count_in_vitality, archived, start_date, created_at
+		FROM habits
+		WHERE id = $1
+	`
+
+	var h domain.Habit
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&h.ID, &h.Name, &h.CountInVitality, &h.Archived, &h.StartDate, &h.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrHabitNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// List returns all habits, archived included, in creation order.
+func (s *HabitStore) List(ctx context.Context) ([]domain.Habit, error) {
+	const query = `
+		SELECT id, name, count_in_vitality, archived, start_date, created_at
+		FROM habits
+		ORDER BY id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	habits := make([]domain.Habit, 0)
+	for rows.Next() {
+		var h domain.Habit
+		if err := rows.Scan(&h.ID, &h.Name, &h.CountInVitality, &h.Archived, &h.StartDate, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		habits = append(habits, h)
+	}
+	return habits, rows.Err()
+}
+
+// Update saves a habit's name, vitality flag and archived state.
+// Returns ErrHabitNotFound if it doesn't exist, ErrHabitExists if the name is taken.
+func (s *HabitStore) Update(ctx context.Context, h *domain.Habit) error {
+	const query = `
+		UPDATE habits
+		SET name = $2, count_in_vitality = $3, archived = $4
+		WHERE id = $1
+	`
+
+	result, err := s.db.ExecContext(ctx, query, h.ID, h.Name, h.CountInVitality, h.Archived)
+	if err != nil {
+		if isUniqueConstraint(err) {
+			return ErrHabitExists
+		}
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrHabitNotFound
+	}
+	return nil
+}
+
+// Delete removes a habit and its check-offs.
+// Returns ErrHabitNotFound if it doesn't exist.
+func (s *HabitStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM habits WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrHabitNotFound
+	}
+	return nil
+}
+
+// SetCheck marks a habit done or not done on a date. Repeating either is a no-op.
+func (s *HabitStore) SetCheck(ctx context.Context, habitID int64, date string, done bool) error {
+	if !done {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM habit_checks WHERE habit_id = $1 AND check_date = $2`, habitID, date)
+		return err
+	}
+
+	const query = `
+		INSERT INTO habit_checks (habit_id, check_date)
+		VALUES ($1, $2)
+		ON CONFLICT (habit_id, check_date) DO NOTHING
+	`
+	_, err := s.db.ExecContext(ctx, query, habitID, date)
+	return err
+}
+
+// ListChecks returns the check-offs in a date range (inclusive), by date.
+func (s *HabitStore) ListChecks(ctx context.Context, startDate, endDate string) ([]domain.HabitCheck, error) {
+	const query = `
+		SELECT habit_id, check_date
+		FROM habit_checks
+		WHERE check_date >= $1 AND check_date <= $2
+		ORDER BY check_date, habit_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make([]domain.HabitCheck, 0)
+	for rows.Next() {
+		var c domain.HabitCheck
+		if err := rows.Scan(&c.HabitID, &c.Date); err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	return checks, rows.Err()
+}
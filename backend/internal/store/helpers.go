@@ -3,7 +3,10 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
+
+	"victus/internal/domain"
 )
 
 // sqlExecer abstracts sql.DB and sql.Tx for executing queries.
@@ -18,3 +21,24 @@ func isUniqueConstraint(err error) bool {
 	return strings.Contains(err.Error(), "duplicate key value violates unique constraint") ||
 		strings.Contains(err.Error(), "UNIQUE constraint") // Keep for any edge cases
 }
+
+// isForeignKeyViolation checks if error is a foreign key violation (PostgreSQL).
+func isForeignKeyViolation(err error) bool {
+	return strings.Contains(err.Error(), "violates foreign key constraint")
+}
+
+// environmentJSON encodes environment conditions for a JSONB column ("[]" when none).
+func environmentJSON(conditions []domain.EnvironmentCondition) string {
+	raw, _ := json.Marshal(domain.MergeEnvironment(conditions))
+	return string(raw)
+}
+
+// parseEnvironmentJSON decodes an environment JSONB column. Returns nil for
+// an empty list or unreadable value.
+func parseEnvironmentJSON(raw string) []domain.EnvironmentCondition {
+	var conditions []domain.EnvironmentCondition
+	if err := json.Unmarshal([]byte(raw), &conditions); err != nil || len(conditions) == 0 {
+		return nil
+	}
+	return conditions
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// JobLeaseStore handles leases that elect one replica to run each scheduled job.
+type JobLeaseStore struct {
+	db DBTX
+}
+
+// NewJobLeaseStore creates a new JobLeaseStore.
+func NewJobLeaseStore(db DBTX) *JobLeaseStore {
+	return &JobLeaseStore{db: db}
+}
+
+// Acquire takes or renews the lease on job for holder until now+ttl.
+// Succeeds if the job is unleased, already held by holder, or the current
+// lease has expired (its holder crashed or lost the database).
+func (s *JobLeaseStore) Acquire(ctx context.Context, job, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	const query = `
+		INSERT INTO job_leases (job_name, holder, lease_until, renewed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (job_name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			lease_until = EXCLUDED.lease_until,
+			renewed_at = EXCLUDED.renewed_at
+		WHERE job_leases.holder = EXCLUDED.holder OR job_leases.lease_until < EXCLUDED.renewed_at
+		RETURNING holder
+	`
+	var got string
+	err := s.db.QueryRowContext(ctx, query, job, holder, now.Add(ttl), now).Scan(&got)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return got == holder, nil
+}
+
+// Release gives up holder's lease on job so another replica can take over
+// without waiting for it to expire.
+func (s *JobLeaseStore) Release(ctx context.Context, job, holder string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM job_leases WHERE job_name = $1 AND holder = $2`, job, holder)
+	return err
+}
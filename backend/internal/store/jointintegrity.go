@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// JointIntegrityStore handles persistence for echo joint deltas and the
+// daily joint integrity series.
+type JointIntegrityStore struct {
+	db DBTX
+}
+
+// NewJointIntegrityStore creates a new JointIntegrityStore.
+func NewJointIntegrityStore(db DBTX) *JointIntegrityStore {
+	return &JointIntegrityStore{db: db}
+}
+
+// AddDeltas stores a day's deltas per joint.
+func (s *JointIntegrityStore) AddDeltas(ctx context.Context, date string, deltas map[string]float64, sessionID *int64) error {
+	for joint, delta := range deltas {
+		if _, err := s.db.ExecContext(ctx, `
+			INSERT INTO joint_integrity_deltas (delta_date, joint, delta, session_id)
+			VALUES ($1, $2, $3, $4)
+		`, date, joint, delta, sessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeltasByDate returns deltas between two dates (inclusive), summed per day
+// and joint.
+func (s *JointIntegrityStore) DeltasByDate(ctx context.Context, from, to string) (map[string]map[string]float64, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT delta_date, joint, SUM(delta)
+		FROM joint_integrity_deltas
+		WHERE delta_date >= $1 AND delta_date <= $2
+		GROUP BY delta_date, joint
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byDate := make(map[string]map[string]float64)
+	for rows.Next() {
+		var date, joint string
+		var delta float64
+		if err := rows.Scan(&date, &joint, &delta); err != nil {
+			return nil, err
+		}
+		if byDate[date] == nil {
+			byDate[date] = make(map[string]float64)
+		}
+		byDate[date][joint] = delta
+	}
+	return byDate, rows.Err()
+}
+
+// EarliestDeltaDate returns the date of the first stored delta, or "" when
+// there are none.
+func (s *JointIntegrityStore) EarliestDeltaDate(ctx context.Context) (string, error) {
+	var date sql.NullString
+	if err := s.db.QueryRowContext(ctx, `SELECT MIN(delta_date) FROM joint_integrity_deltas`).Scan(&date); err != nil {
+		return "", err
+	}
+	return date.String, nil
+}
+
+// SaveDay stores a day of the series, replacing any earlier one for the same day.
+func (s *JointIntegrityStore) SaveDay(ctx context.Context, day domain.JointIntegrityDay, computedAt time.Time) error {
+	joints, err := json.Marshal(day.Joints)
+	if err != nil {
+		return err
+	}
+	painJoints := day.PainJoints
+	if painJoints == nil {
+		painJoints = []string{}
+	}
+	pain, err := json.Marshal(painJoints)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO joint_integrity_series (series_date, joints, rest_day, pain_joints, computed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (series_date) DO UPDATE SET
+			joints = EXCLUDED.joints,
+			rest_day = EXCLUDED.rest_day,
+			pain_joints = EXCLUDED.pain_joints,
+			computed_at = EXCLUDED.computed_at
+	`
+	_, err = s.db.ExecContext(ctx, query, day.Date, joints, day.RestDay, pain, computedAt)
+	return err
+}
+
+// LatestDayBefore returns the most recent stored day before the given date,
+// or nil when there is none.
+func (s *JointIntegrityStore) LatestDayBefore(ctx context.Context, date string) (*domain.JointIntegrityDay, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT series_date, joints, rest_day, pain_joints
+		FROM joint_integrity_series
+		WHERE series_date < $1
+		ORDER BY series_date DESC
+		LIMIT 1
+	`, date)
+	day, err := scanJointIntegrityDay(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return day, err
+}
+
+// ListRange returns the series between two dates (inclusive), oldest first.
+func (s *JointIntegrityStore) ListRange(ctx context.Context, from, to string) ([]domain.JointIntegrityDay, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT series_date, joints, rest_day, pain_joints
+		FROM joint_integrity_series
+		WHERE series_date >= $1 AND series_date <= $2
+		ORDER BY series_date
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make([]domain.JointIntegrityDay, 0)
+	for rows.Next() {
+		day, err := scanJointIntegrityDay(rows)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, *day)
+	}
+	return days, rows.Err()
+}
+
+func scanJointIntegrityDay(row mealTemplateScanner) (*domain.JointIntegrityDay, error) {
+	var day domain.JointIntegrityDay
+	var joints, pain []byte
+	if err := row.Scan(&day.Date, &joints, &day.RestDay, &pain); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(joints, &day.Joints); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(pain, &day.PainJoints); err != nil {
+		return nil, err
+	}
+	return &day, nil
+}
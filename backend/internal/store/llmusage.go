@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// LLMUsageStore handles persistence for LLM call accounting.
+type LLMUsageStore struct {
+	db DBTX
+}
+
+// NewLLMUsageStore creates a new LLMUsageStore.
+func NewLLMUsageStore(db DBTX) *LLMUsageStore {
+	return &LLMUsageStore{db: db}
+}
+
+// Record stores one LLM call.
+func (s *LLMUsageStore) Record(ctx context.Context, u domain.LLMUsage) error {
+	const query = `
+		INSERT INTO llm_usage (feature, model, prompt_tokens, completion_tokens, duration_ms, success, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := s.db.ExecContext(ctx, query,
+		string(u.Feature), u.Model, u.PromptTokens, u.CompletionTokens, u.DurationMs, u.Success, u.CreatedAt)
+	return err
+}
+
+// TotalsSince returns a feature's call count and compute time at or after since.
+// Failed calls count too: they still occupied the GPU.
+func (s *LLMUsageStore) TotalsSince(ctx context.Context, feature domain.LLMFeature, since time.Time) (domain.LLMUsageTotals, error) {
+	const query = `
+		SELECT COUNT(*), COALESCE(SUM(duration_ms), 0)
+		FROM llm_usage
+		WHERE feature = $1 AND created_at >= $2
+	`
+	var totals domain.LLMUsageTotals
+	err := s.db.QueryRowContext(ctx, query, string(feature), since).Scan(&totals.Calls, &totals.ComputeMs)
+	return totals, err
+}
+
+// SummarizeSince aggregates calls at or after since by feature and model,
+// busiest feature first.
+func (s *LLMUsageStore) SummarizeSince(ctx context.Context, since time.Time) ([]domain.LLMUsageSummary, error) {
+	const query = `
+		SELECT feature, model, COUNT(*),
+		       COUNT(*) FILTER (WHERE NOT success),
+		       COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0),
+		       COALESCE(SUM(duration_ms), 0)
+		FROM llm_usage
+		WHERE created_at >= $1
+		GROUP BY feature, model
+		ORDER BY SUM(duration_ms) DESC, feature, model
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]domain.LLMUsageSummary, 0)
+	for rows.Next() {
+		var sum domain.LLMUsageSummary
+		var feature string
+		if err := rows.Scan(&feature, &sum.Model, &sum.Calls, &sum.Failures,
+			&sum.PromptTokens, &sum.CompletionTokens, &sum.ComputeMs); err != nil {
+			return nil, err
+		}
+		sum.Feature = domain.LLMFeature(feature)
+		if sum.Calls > 0 {
+			sum.AvgDurationMs = sum.ComputeMs / int64(sum.Calls)
+		}
+		summaries = append(summaries, sum)
+	}
+	return summaries, rows.Err()
+}
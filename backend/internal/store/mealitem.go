@@ -0,0 +1,204 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrMealItemNotFound is returned when a logged food item doesn't exist.
+var ErrMealItemNotFound = errors.New("meal item not found")
+
+// MealItemStore handles persistence for food items logged one by one. Items
+// are the meal_entries rows with a food reference.
+type MealItemStore struct {
+	db DBTX
+}
+
+// NewMealItemStore creates a new MealItemStore.
+func NewMealItemStore(db DBTX) *MealItemStore {
+	return &MealItemStore{db: db}
+}
+
+// InTx runs fn in one transaction; statements made through TxScoped stores
+// with fn's context join it.
+func (s *MealItemStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, s.db, fn)
+}
+
+// Create stores a new item and sets its ID and timestamps. Items are only
+// stored for a date that has a log.
+// Returns ErrDailyLogNotFound if no log exists for the item's date, or
+// ErrFoodReferenceNotFound for an unknown food.
+func (s *MealItemStore) Create(ctx context.Context, item *domain.MealItem) error {
+	const query = `
+		INSERT INTO meal_entries (log_date, meal, eaten_at, food_reference_id, grams, calories, protein_g, carbs_g, fat_g)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+		WHERE EXISTS (SELECT 1 FROM daily_logs WHERE log_date = $1)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := s.db.QueryRowContext(ctx, query,
+		item.Date, mealItemMeal(item.Meal), mealItemEatenAt(item.EatenAt), item.FoodReferenceID, item.Grams,
+		item.Calories, item.ProteinG, item.CarbsG, item.FatG,
+	).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDailyLogNotFound
+	}
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrFoodReferenceNotFound
+	}
+	return err
+}
+
+// Update replaces a logged item's food, portion, slot, time and macros.
+// Returns ErrMealItemNotFound if the item doesn't exist.
+func (s *MealItemStore) Update(ctx context.Context, item *domain.MealItem) error {
+	const query = `
+		UPDATE meal_entries
+		SET meal = $1, eaten_at = $2, food_reference_id = $3, grams = $4,
+		    calories = $5, protein_g = $6, carbs_g = $7, fat_g = $8, updated_at = NOW()
+		WHERE id = $9 AND log_date = $10 AND food_reference_id IS NOT NULL
+		RETURNING updated_at
+	`
+
+	err := s.db.QueryRowContext(ctx, query,
+		mealItemMeal(item.Meal), mealItemEatenAt(item.EatenAt), item.FoodReferenceID, item.Grams,
+		item.Calories, item.ProteinG, item.CarbsG, item.FatG, item.ID, item.Date,
+	).Scan(&item.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrMealItemNotFound
+	}
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrFoodReferenceNotFound
+	}
+	return err
+}
+
+// Delete removes a logged item.
+// Returns ErrMealItemNotFound if the item doesn't exist.
+func (s *MealItemStore) Delete(ctx context.Context, date string, id int64) error {
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM meal_entries WHERE id = $1 AND log_date = $2 AND food_reference_id IS NOT NULL`, id, date,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMealItemNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves an item logged on date.
+// Returns ErrMealItemNotFound if it doesn't exist.
+func (s *MealItemStore) GetByID(ctx context.Context, date string, id int64) (*domain.MealItem, error) {
+	rows, err := s.db.QueryContext(ctx, mealItemSelect+`
+		WHERE e.id = $1 AND e.log_date = $2
+	`, id, date)
+	if err != nil {
+		return nil, err
+	}
+	items, err := scanMealItems(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrMealItemNotFound
+	}
+	return &items[0], nil
+}
+
+// ListByDate returns the items logged on date, in the order they were logged.
+func (s *MealItemStore) ListByDate(ctx context.Context, date string) ([]domain.MealItem, error) {
+	rows, err := s.db.QueryContext(ctx, mealItemSelect+`
+		WHERE e.log_date = $1
+		ORDER BY e.created_at, e.id
+	`, date)
+	if err != nil {
+		return nil, err
+	}
+	return scanMealItems(rows)
+}
+
+// MacrosByMeal sums the macros of the items logged on date per meal slot.
+func (s *MealItemStore) MacrosByMeal(ctx context.Context, date string) ([]domain.MealSlotMacros, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT meal, SUM(calories), SUM(protein_g), SUM(carbs_g), SUM(fat_g)
+		FROM meal_entries
+		WHERE log_date = $1 AND food_reference_id IS NOT NULL
+		GROUP BY meal
+	`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []domain.MealSlotMacros
+	for rows.Next() {
+		var (
+			t    domain.MealSlotMacros
+			meal sql.NullString
+		)
+		if err := rows.Scan(&meal, &t.Macros.Calories, &t.Macros.ProteinG, &t.Macros.CarbsG, &t.Macros.FatG); err != nil {
+			return nil, err
+		}
+		if meal.Valid {
+			m := domain.MealName(meal.String)
+			t.Meal = &m
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+const mealItemSelect = `
+		SELECT e.id, e.log_date, e.meal, e.eaten_at, e.food_reference_id, f.food_item, e.grams,
+		       e.calories, e.protein_g, e.carbs_g, e.fat_g, e.created_at, e.updated_at
+		FROM meal_entries e
+		JOIN food_reference f ON f.id = e.food_reference_id`
+
+func scanMealItems(rows *sql.Rows) ([]domain.MealItem, error) {
+	defer rows.Close()
+
+	items := []domain.MealItem{}
+	for rows.Next() {
+		var (
+			item    domain.MealItem
+			meal    sql.NullString
+			eatenAt sql.NullString
+		)
+		err := rows.Scan(&item.ID, &item.Date, &meal, &eatenAt, &item.FoodReferenceID, &item.FoodItem, &item.Grams,
+			&item.Calories, &item.ProteinG, &item.CarbsG, &item.FatG, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if meal.Valid {
+			m := domain.MealName(meal.String)
+			item.Meal = &m
+		}
+		item.EatenAt = eatenAt.String
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// mealItemMeal maps an optional meal slot to its column value.
+func mealItemMeal(meal *domain.MealName) interface{} {
+	if meal == nil {
+		return nil
+	}
+	return string(*meal)
+}
+
+// mealItemEatenAt maps an optional HH:MM to its column value.
+func mealItemEatenAt(hhmm string) sql.NullString {
+	return sql.NullString{String: hhmm, Valid: hhmm != ""}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+var (
+	// ErrMealTemplateNotFound is returned when a meal template doesn't exist.
+	ErrMealTemplateNotFound = errors.New("meal template not found")
+	// ErrMealTemplateExists is returned when a template with the same name already exists.
+	ErrMealTemplateExists = errors.New("meal template with this name already exists")
+)
+
+// MealTemplateStore handles persistence for saved meal templates.
+type MealTemplateStore struct {
+	db DBTX
+}
+
+// NewMealTemplateStore creates a new MealTemplateStore.
+func NewMealTemplateStore(db DBTX) *MealTemplateStore {
+	return &MealTemplateStore{db: db}
+}
+
+// Create stores a new template and sets its ID and timestamps.
+// Returns ErrMealTemplateExists if the name is taken.
+func (s *MealTemplateStore) Create(ctx context.Context, t *domain.MealTemplate) error {
+	items, err := marshalTemplateItems(t.Items)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO meal_templates (name, meal, items)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = s.db.QueryRowContext(ctx, query, t.Name, t.Meal, items).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil && isUniqueConstraint(err) {
+		return ErrMealTemplateExists
+	}
+	return err
+}
+
+// GetByID retrieves a template.
+// Returns ErrMealTemplateNotFound if it doesn't exist.
+func (s *MealTemplateStore) GetByID(ctx context.Context, id int64) (*domain.MealTemplate, error) {
+	const query = `
+		SELECT id, name, meal, items, created_at, updated_at
+		FROM meal_templates
+		WHERE id = $1
+	`
+
+	t, err := scanMealTemplate(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMealTemplateNotFound
+	}
+	return t, err
+}
+
+// List returns all templates ordered by meal slot and name.
+func (s *MealTemplateStore) List(ctx context.Context) ([]domain.MealTemplate, error) {
+	const query = `
+		SELECT id, name, meal, items, created_at, updated_at
+		FROM meal_templates
+		ORDER BY CASE meal WHEN 'breakfast' THEN 1 WHEN 'lunch' THEN 2 ELSE 3 END, name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]domain.MealTemplate, 0)
+	for rows.Next() {
+		t, err := scanMealTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+	return templates, rows.Err()
+}
+
+// Delete removes a template.
+// Returns ErrMealTemplateNotFound if it doesn't exist.
+func (s *MealTemplateStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM meal_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMealTemplateNotFound
+	}
+	return nil
+}
+
+// marshalTemplateItems encodes items without the display-only food name.
+func marshalTemplateItems(items []domain.MealTemplateItem) ([]byte, error) {
+	stored := make([]domain.MealTemplateItem, len(items))
+	for i, item := range items {
+		item.FoodItem = ""
+		stored[i] = item
+	}
+	return json.Marshal(stored)
+}
+
+type mealTemplateScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMealTemplate(row mealTemplateScanner) (*domain.MealTemplate, error) {
+	var t domain.MealTemplate
+	var items []byte
+	if err := row.Scan(&t.ID, &t.Name, &t.Meal, &items, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &t.Items); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
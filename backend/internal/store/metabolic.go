@@ -22,15 +22,16 @@ func NewMetabolicStore(db DBTX) *MetabolicStore {
 	return &MetabolicStore{db: db}
 }
 
-// Create inserts a new metabolic history record.
+// Create inserts a new metabolic history record. CalculatedAt defaults to
+// the database time when empty.
 func (s *MetabolicStore) Create(ctx context.Context, record *domain.MetabolicHistoryRecord) (int64, error) {
 	const query = `
 		INSERT INTO metabolic_history (
 			daily_log_id, calculated_tdee, previous_tdee, delta_kcal, tdee_source,
 			was_swing_constrained, bmr_floor_applied, adherence_gate_passed,
 			confidence, data_points_used, ema_weight_kg, bmr_value,
-			notification_pending
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			notification_pending, calculated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE(NULLIF($14, '')::timestamp, NOW()))
 		RETURNING id
 	`
 
@@ -49,6 +50,7 @@ func (s *MetabolicStore) Create(ctx context.Context, record *domain.MetabolicHis
 		record.EMAWeightKg,
 		record.BMRValue,
 		record.NotificationPending,
+		record.CalculatedAt,
 	).Scan(&id)
 	if err != nil {
 		return 0, err
@@ -64,8 +66,8 @@ func (s *MetabolicStore) CreateWithTx(ctx context.Context, tx *sql.Tx, record *d
 			daily_log_id, calculated_tdee, previous_tdee, delta_kcal, tdee_source,
 			was_swing_constrained, bmr_floor_applied, adherence_gate_passed,
 			confidence, data_points_used, ema_weight_kg, bmr_value,
-			notification_pending
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			notification_pending, calculated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE(NULLIF($14, '')::timestamp, NOW()))
 		RETURNING id
 	`
 
@@ -84,6 +86,7 @@ func (s *MetabolicStore) CreateWithTx(ctx context.Context, tx *sql.Tx, record *d
 		record.EMAWeightKg,
 		record.BMRValue,
 		record.NotificationPending,
+		record.CalculatedAt,
 	).Scan(&id)
 	if err != nil {
 		return 0, err
@@ -92,6 +95,17 @@ func (s *MetabolicStore) CreateWithTx(ctx context.Context, tx *sql.Tx, record *d
 	return id, nil
 }
 
+// DeleteByDailyLogIDWithTx removes a daily log's metabolic history within a
+// transaction. Returns the number of records removed.
+func (s *MetabolicStore) DeleteByDailyLogIDWithTx(ctx context.Context, tx *sql.Tx, dailyLogID int64) (int, error) {
+	result, err := tx.ExecContext(ctx, "DELETE FROM metabolic_history WHERE daily_log_id = $1", dailyLogID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
 // GetLatest returns the most recent metabolic history record.
 func (s *MetabolicStore) GetLatest(ctx context.Context) (*domain.MetabolicHistoryRecord, error) {
 	const query = `
@@ -189,8 +203,9 @@ func (s *MetabolicStore) DismissNotification(ctx context.Context, id int64) erro
 	return nil
 }
 
-// ListForChart returns metabolic history data for visualization.
-func (s *MetabolicStore) ListForChart(ctx context.Context, weeks int) ([]domain.FluxChartPoint, error) {
+// ListForChart returns metabolic history data for visualization, covering
+// the given number of weeks up to and including asOf (YYYY-MM-DD).
+func (s *MetabolicStore) ListForChart(ctx context.Context, weeks int, asOf string) ([]domain.FluxChartPoint, error) {
 	const query = `
 		SELECT
 			mh.calculated_at,
@@ -200,12 +215,13 @@ func (s *MetabolicStore) ListForChart(ctx context.Context, weeks int) ([]domain.
 			mh.was_swing_constrained
 		FROM metabolic_history mh
 		JOIN daily_logs dl ON dl.id = mh.daily_log_id
-		WHERE mh.calculated_at >= CURRENT_DATE - $1 * INTERVAL '1 day'
+		WHERE mh.calculated_at >= $2::date - $1 * INTERVAL '1 day'
+		  AND mh.calculated_at < $2::date + INTERVAL '1 day'
 		ORDER BY mh.calculated_at ASC
 	`
 
 	days := weeks * 7
-	rows, err := s.db.QueryContext(ctx, query, days)
+	rows, err := s.db.QueryContext(ctx, query, days, asOf)
 	if err != nil {
 		return nil, err
 	}
@@ -249,35 +265,38 @@ func (s *MetabolicStore) GetPreviousTDEE(ctx context.Context) (int, error) {
 	return tdee, nil
 }
 
-// CountRecentLogs counts the number of daily logs in the last N days.
-// Used for adherence validation.
-func (s *MetabolicStore) CountRecentLogs(ctx context.Context, days int) (int, error) {
+// CountRecentLogs counts the number of daily logs in the N days up to and
+// including asOf (YYYY-MM-DD). Used for adherence validation.
+func (s *MetabolicStore) CountRecentLogs(ctx context.Context, days int, asOf string) (int, error) {
 	const query = `
 		SELECT COUNT(*)
 		FROM daily_logs
-		WHERE log_date >= CURRENT_DATE - $1 * INTERVAL '1 day'
-		AND total_calories > 0
+		WHERE log_date >= to_char($2::date - $1 * INTERVAL '1 day', 'YYYY-MM-DD')
+		  AND log_date <= to_char($2::date, 'YYYY-MM-DD')
+		  AND total_calories > 0
 	`
 
 	var count int
-	err := s.db.QueryRowContext(ctx, query, days).Scan(&count)
+	err := s.db.QueryRowContext(ctx, query, days, asOf).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// ListRecentWeights returns weight data for EMA calculation.
-func (s *MetabolicStore) ListRecentWeights(ctx context.Context, days int) ([]domain.WeightDataPoint, error) {
+// ListRecentWeights returns weight data for EMA calculation from the N days
+// up to and including asOf (YYYY-MM-DD).
+func (s *MetabolicStore) ListRecentWeights(ctx context.Context, days int, asOf string) ([]domain.WeightDataPoint, error) {
 	const query = `
-		SELECT log_date, weight_kg
+		SELECT log_date, weight_kg, COALESCE(weigh_in_time, '')
 		FROM daily_logs
-		WHERE log_date >= CURRENT_DATE - $1 * INTERVAL '1 day'
+		WHERE log_date >= to_char($2::date - $1 * INTERVAL '1 day', 'YYYY-MM-DD')
+		  AND log_date <= to_char($2::date, 'YYYY-MM-DD')
 		  AND has_explicit_weight = true
 		ORDER BY log_date ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, days)
+	rows, err := s.db.QueryContext(ctx, query, days, asOf)
 	if err != nil {
 		return nil, err
 	}
@@ -286,7 +305,7 @@ func (s *MetabolicStore) ListRecentWeights(ctx context.Context, days int) ([]dom
 	var weights []domain.WeightDataPoint
 	for rows.Next() {
 		var w domain.WeightDataPoint
-		if err := rows.Scan(&w.Date, &w.WeightKg); err != nil {
+		if err := rows.Scan(&w.Date, &w.WeightKg, &w.WeighInTime); err != nil {
 			return nil, err
 		}
 		weights = append(weights, w)
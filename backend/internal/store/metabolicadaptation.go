@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrMetabolicAdaptationNotFound is returned when an adaptation event doesn't exist.
+var ErrMetabolicAdaptationNotFound = errors.New("metabolic adaptation event not found")
+
+// MetabolicAdaptationStore handles persistence for detected metabolic
+// adaptation events.
+type MetabolicAdaptationStore struct {
+	db DBTX
+}
+
+// NewMetabolicAdaptationStore creates a new MetabolicAdaptationStore.
+func NewMetabolicAdaptationStore(db DBTX) *MetabolicAdaptationStore {
+	return &MetabolicAdaptationStore{db: db}
+}
+
+const selectMetabolicAdaptationColumns = `
+	SELECT id, detected_on, severity, weeks_sustained, baseline_ratio, recent_ratio,
+		unexplained_kcal, observed_drop_kcal, predicted_drop_kcal, weight_change_kg,
+		maintenance_kcal, recommendation, notification_pending
+	FROM metabolic_adaptation_events
+`
+
+// Create stores a detection with a pending notification and sets its ID.
+// A second detection on the same day replaces the first.
+func (s *MetabolicAdaptationStore) Create(ctx context.Context, a *domain.MetabolicAdaptation) error {
+	const query = `
+		INSERT INTO metabolic_adaptation_events (
+			detected_on, severity, weeks_sustained, baseline_ratio, recent_ratio,
+			unexplained_kcal, observed_drop_kcal, predicted_drop_kcal, weight_change_kg,
+			maintenance_kcal, recommendation
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (detected_on) DO UPDATE SET
+			severity = EXCLUDED.severity,
+			weeks_sustained = EXCLUDED.weeks_sustained,
+			baseline_ratio = EXCLUDED.baseline_ratio,
+			recent_ratio = EXCLUDED.recent_ratio,
+			unexplained_kcal = EXCLUDED.unexplained_kcal,
+			observed_drop_kcal = EXCLUDED.observed_drop_kcal,
+			predicted_drop_kcal = EXCLUDED.predicted_drop_kcal,
+			weight_change_kg = EXCLUDED.weight_change_kg,
+			maintenance_kcal = EXCLUDED.maintenance_kcal,
+			recommendation = EXCLUDED.recommendation
+		RETURNING id, notification_pending
+	`
+	recommendation, err := json.Marshal(a.Recommendation)
+	if err != nil {
+		return err
+	}
+	return s.db.QueryRowContext(ctx, query,
+		a.DetectedOn, string(a.Severity), a.WeeksSustained, a.BaselineRatio, a.RecentRatio,
+		a.UnexplainedKcal, a.ObservedDropKcal, a.PredictedDropKcal, a.WeightChangeKg,
+		a.MaintenanceKcal, recommendation,
+	).Scan(&a.ID, &a.NotificationPending)
+}
+
+// LatestOnOrBefore returns the most recent event detected on or before date
+// (YYYY-MM-DD), or nil when there is none.
+func (s *MetabolicAdaptationStore) LatestOnOrBefore(ctx context.Context, date string) (*domain.MetabolicAdaptation, error) {
+	a, err := scanMetabolicAdaptation(s.db.QueryRowContext(ctx,
+		selectMetabolicAdaptationColumns+`WHERE detected_on <= $1 ORDER BY detected_on DESC LIMIT 1`, date))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return a, err
+}
+
+// List returns every event, newest first.
+func (s *MetabolicAdaptationStore) List(ctx context.Context) ([]domain.MetabolicAdaptation, error) {
+	return s.list(ctx, selectMetabolicAdaptationColumns+`ORDER BY detected_on DESC`)
+}
+
+// ListPendingNotifications returns the events whose notification hasn't been
+// dismissed, newest first.
+func (s *MetabolicAdaptationStore) ListPendingNotifications(ctx context.Context) ([]domain.MetabolicAdaptation, error) {
+	return s.list(ctx, selectMetabolicAdaptationColumns+`WHERE notification_pending ORDER BY detected_on DESC`)
+}
+
+func (s *MetabolicAdaptationStore) list(ctx context.Context, query string, args ...any) ([]domain.MetabolicAdaptation, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domain.MetabolicAdaptation, 0)
+	for rows.Next() {
+		a, err := scanMetabolicAdaptation(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *a)
+	}
+	return events, rows.Err()
+}
+
+// DismissNotification acknowledges an event's notification.
+// Returns ErrMetabolicAdaptationNotFound if it doesn't exist.
+func (s *MetabolicAdaptationStore) DismissNotification(ctx context.Context, id int64) error {
+	const query = `
+		UPDATE metabolic_adaptation_events
+		SET notification_pending = false, notification_dismissed_at = $1
+		WHERE id = $2
+	`
+	result, err := s.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMetabolicAdaptationNotFound
+	}
+	return nil
+}
+
+type metabolicAdaptationScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMetabolicAdaptation(row metabolicAdaptationScanner) (*domain.MetabolicAdaptation, error) {
+	var a domain.MetabolicAdaptation
+	var recommendation []byte
+	err := row.Scan(&a.ID, &a.DetectedOn, &a.Severity, &a.WeeksSustained, &a.BaselineRatio, &a.RecentRatio,
+		&a.UnexplainedKcal, &a.ObservedDropKcal, &a.PredictedDropKcal, &a.WeightChangeKg,
+		&a.MaintenanceKcal, &recommendation, &a.NotificationPending)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(recommendation, &a.Recommendation); err != nil {
+		return nil, err
+	}
+	// REAL columns read back with float32 noise
+	a.BaselineRatio = math.Round(a.BaselineRatio*1000) / 1000
+	a.RecentRatio = math.Round(a.RecentRatio*1000) / 1000
+	a.WeightChangeKg = math.Round(a.WeightChangeKg*10) / 10
+	return &a, nil
+}
@@ -27,6 +27,8 @@ func (s *MonthlySummaryStore) Upsert(ctx context.Context, summary domain.Monthly
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (year_month, activity_type) DO UPDATE SET
 			session_count = EXCLUDED.session_count,
+			data_source = EXCLUDED.data_source,
+			raw_activity_name = EXCLUDED.raw_activity_name,
 			total_calories = COALESCE(EXCLUDED.total_calories, monthly_summaries.total_calories),
 			avg_calories_per_session = CASE
 				WHEN EXCLUDED.session_count > 0 AND COALESCE(EXCLUDED.total_calories, monthly_summaries.total_calories) > 0
@@ -56,11 +58,13 @@ func (s *MonthlySummaryStore) Upsert(ctx context.Context, summary domain.Monthly
 
 // UpdateCalories updates just the calorie data for a monthly summary.
 // This is used when importing calorie data separately from activity counts.
+// The import takes over a computed row, so recomputation leaves it alone.
 func (s *MonthlySummaryStore) UpdateCalories(ctx context.Context, yearMonth string, activityType domain.TrainingType, calories int) error {
 	const query = `
 		UPDATE monthly_summaries
 		SET total_calories = $1,
-		    avg_calories_per_session = CASE WHEN session_count > 0 THEN $2 / session_count ELSE 0 END
+		    avg_calories_per_session = CASE WHEN session_count > 0 THEN $2 / session_count ELSE 0 END,
+		    data_source = 'garmin_import'
 		WHERE year_month = $3 AND activity_type = $4
 	`
 
@@ -84,6 +88,44 @@ func (s *MonthlySummaryStore) UpdateCalories(ctx context.Context, yearMonth stri
 	return nil
 }
 
+// UpsertComputed writes a computed summary. Rows owned by an import are left
+// untouched; returns false when the row belongs to one.
+func (s *MonthlySummaryStore) UpsertComputed(ctx context.Context, summary domain.MonthlySummary) (bool, error) {
+	const query = `
+		INSERT INTO monthly_summaries
+			(year_month, activity_type, session_count, total_calories, avg_calories_per_session, data_source, raw_activity_name)
+		VALUES ($1, $2, $3, $4, $5, 'computed', '')
+		ON CONFLICT (year_month, activity_type) DO UPDATE SET
+			session_count = EXCLUDED.session_count,
+			total_calories = EXCLUDED.total_calories,
+			avg_calories_per_session = EXCLUDED.avg_calories_per_session
+		WHERE monthly_summaries.data_source = 'computed'
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		summary.YearMonth,
+		string(summary.ActivityType),
+		summary.SessionCount,
+		summary.TotalCalories,
+		summary.AvgCaloriesPerSession,
+	)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// DeleteComputed removes a computed summary. Imported rows are never deleted.
+func (s *MonthlySummaryStore) DeleteComputed(ctx context.Context, yearMonth string, activityType domain.TrainingType) error {
+	const query = `
+		DELETE FROM monthly_summaries
+		WHERE year_month = $1 AND activity_type = $2 AND data_source = 'computed'
+	`
+	_, err := s.db.ExecContext(ctx, query, yearMonth, string(activityType))
+	return err
+}
+
 // GetByYearMonth retrieves all summaries for a specific month.
 func (s *MonthlySummaryStore) GetByYearMonth(ctx context.Context, yearMonth string) ([]domain.MonthlySummary, error) {
 	const query = `
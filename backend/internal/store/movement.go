@@ -25,7 +25,7 @@ func NewMovementStore(db DBTX) *MovementStore {
 // GetAll returns all movements in the taxonomy.
 func (s *MovementStore) GetAll(ctx context.Context) ([]domain.Movement, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, name, category, tags, difficulty, primary_load, joint_stress, progression_id
+		SELECT id, name, category, tags, difficulty, primary_load, joint_stress, progression_id, equipment
 		FROM movements ORDER BY category, difficulty
 	`)
 	if err != nil {
@@ -47,13 +47,13 @@ func (s *MovementStore) GetAll(ctx context.Context) ([]domain.Movement, error) {
 // GetByID returns a single movement by ID.
 func (s *MovementStore) GetByID(ctx context.Context, id string) (*domain.Movement, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT id, name, category, tags, difficulty, primary_load, joint_stress, progression_id
+		SELECT id, name, category, tags, difficulty, primary_load, joint_stress, progression_id, equipment
 		FROM movements WHERE id = $1
 	`, id)
 
 	var m domain.Movement
-	var tagsJSON, stressJSON []byte
-	err := row.Scan(&m.ID, &m.Name, &m.Category, &tagsJSON, &m.Difficulty, &m.PrimaryLoad, &stressJSON, &m.ProgressionID)
+	var tagsJSON, stressJSON, equipmentJSON []byte
+	err := row.Scan(&m.ID, &m.Name, &m.Category, &tagsJSON, &m.Difficulty, &m.PrimaryLoad, &stressJSON, &m.ProgressionID, &equipmentJSON)
 	if err == sql.ErrNoRows {
 		return nil, ErrMovementNotFound
 	}
@@ -66,6 +66,9 @@ func (s *MovementStore) GetByID(ctx context.Context, id string) (*domain.Movemen
 	if err := json.Unmarshal(stressJSON, &m.JointStress); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(equipmentJSON, &m.Equipment); err != nil {
+		return nil, err
+	}
 	return &m, nil
 }
 
@@ -111,8 +114,8 @@ type movementScanner interface {
 
 func scanMovement(rows movementScanner) (domain.Movement, error) {
 	var m domain.Movement
-	var tagsJSON, stressJSON []byte
-	err := rows.Scan(&m.ID, &m.Name, &m.Category, &tagsJSON, &m.Difficulty, &m.PrimaryLoad, &stressJSON, &m.ProgressionID)
+	var tagsJSON, stressJSON, equipmentJSON []byte
+	err := rows.Scan(&m.ID, &m.Name, &m.Category, &tagsJSON, &m.Difficulty, &m.PrimaryLoad, &stressJSON, &m.ProgressionID, &equipmentJSON)
 	if err != nil {
 		return m, err
 	}
@@ -122,6 +125,9 @@ func scanMovement(rows movementScanner) (domain.Movement, error) {
 	if err := json.Unmarshal(stressJSON, &m.JointStress); err != nil {
 		return m, err
 	}
+	if err := json.Unmarshal(equipmentJSON, &m.Equipment); err != nil {
+		return m, err
+	}
 	return m, nil
 }
 
@@ -151,5 +157,74 @@ func (s *MovementStore) GetAllUserProgress(ctx context.Context) (map[string]doma
 	return result, rows.Err()
 }
 
+// RecordSession appends a movement completion to the session log.
+func (s *MovementStore) RecordSession(ctx context.Context, r domain.MovementSessionRecord) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO movement_session_log
+			(movement_id, difficulty, completed_reps, target_reps, rpe, had_form_issue, successful, performed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, r.MovementID, r.Difficulty, r.CompletedReps, r.TargetReps, r.RPE, r.HadFormIssue, r.Successful, r.PerformedAt)
+	return err
+}
+
+// GetSessionLog returns logged movement completions, optionally filtered to one movement.
+// Pass an empty movementID to return the log for all movements.
+func (s *MovementStore) GetSessionLog(ctx context.Context, movementID string) ([]domain.MovementSessionRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT movement_id, difficulty, completed_reps, target_reps, rpe, had_form_issue, successful, performed_at
+		FROM movement_session_log
+		WHERE $1 = '' OR movement_id = $1
+		ORDER BY performed_at
+	`, movementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []domain.MovementSessionRecord
+	for rows.Next() {
+		var r domain.MovementSessionRecord
+		if err := rows.Scan(&r.MovementID, &r.Difficulty, &r.CompletedReps, &r.TargetReps, &r.RPE, &r.HadFormIssue, &r.Successful, &r.PerformedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// ListWarmupPins returns pinned warm-up movement IDs, oldest pin first.
+func (s *MovementStore) ListWarmupPins(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT movement_id FROM warmup_pins ORDER BY pinned_at, movement_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PinWarmup marks a movement as a preferred warm-up. Pinning twice is a no-op.
+func (s *MovementStore) PinWarmup(ctx context.Context, movementID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO warmup_pins (movement_id) VALUES ($1)
+		ON CONFLICT (movement_id) DO NOTHING
+	`, movementID)
+	return err
+}
+
+// UnpinWarmup removes a preferred warm-up movement. Unpinning an unpinned movement is a no-op.
+func (s *MovementStore) UnpinWarmup(ctx context.Context, movementID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM warmup_pins WHERE movement_id = $1`, movementID)
+	return err
+}
+
 // ensure time import is used
 var _ = time.Now
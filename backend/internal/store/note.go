@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrNoteConflictNotFound is returned when a note conflict doesn't exist.
+var ErrNoteConflictNotFound = errors.New("note conflict not found")
+
+// NoteStore reads and writes day and session notes and their conflicts.
+type NoteStore struct {
+	db DBTX
+}
+
+// NewNoteStore creates a new NoteStore.
+func NewNoteStore(db DBTX) *NoteStore {
+	return &NoteStore{db: db}
+}
+
+// SessionNoteDate returns the day a training session is logged on.
+// Returns domain.ErrSessionNotFound if the session doesn't exist.
+func (s *NoteStore) SessionNoteDate(ctx context.Context, sessionID int64) (string, error) {
+	var date string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT dl.log_date
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		WHERE ts.id = $1
+	`, sessionID).Scan(&date)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrSessionNotFound
+	}
+	return date, err
+}
+
+// EditNote locks the note, passes its stored text to edit and saves the text
+// edit returns, with the conflict it returns (if any), in one transaction.
+// Concurrent edits of the same note are serialized by the row lock.
+// Returns ErrDailyLogNotFound or domain.ErrSessionNotFound if the note's day
+// or session doesn't exist.
+func (s *NoteStore) EditNote(ctx context.Context, ref domain.NoteRef, edit func(current string) (string, *domain.NoteConflict)) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		return editNote(ctx, tx, ref, edit)
+	})
+}
+
+func editNote(ctx context.Context, tx *sql.Tx, ref domain.NoteRef, edit func(current string) (string, *domain.NoteConflict)) error {
+	var err error
+	var current string
+	switch ref.Target {
+	case domain.NoteTargetSession:
+		err = tx.QueryRowContext(ctx,
+			`SELECT COALESCE(notes, '') FROM training_sessions WHERE id = $1 FOR UPDATE`, ref.SessionID,
+		).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrSessionNotFound
+		}
+	default:
+		err = tx.QueryRowContext(ctx,
+			`SELECT COALESCE(notes, '') FROM daily_logs WHERE log_date = $1 FOR UPDATE`, ref.Date,
+		).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDailyLogNotFound
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	text, conflict := edit(current)
+	if text != current {
+		if ref.Target == domain.NoteTargetSession {
+			_, err = tx.ExecContext(ctx, `UPDATE training_sessions SET notes = $1 WHERE id = $2`, text, ref.SessionID)
+		} else {
+			_, err = tx.ExecContext(ctx, `UPDATE daily_logs SET notes = $1, updated_at = $2 WHERE log_date = $3`, text, time.Now(), ref.Date)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if conflict != nil {
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO note_conflicts (target, log_date, session_id, base, kept, overwritten, suggested, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, string(conflict.Target), conflict.Date, conflict.SessionID, conflict.Base,
+			conflict.Kept, conflict.Overwritten, conflict.Suggested, conflict.CreatedAt,
+		).Scan(&conflict.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const noteConflictColumns = `
+	id, target, log_date, session_id, base, kept, overwritten, suggested,
+	created_at, resolved_at, COALESCE(resolution, '')
+`
+
+// ListOpenConflicts returns the unresolved note conflicts, oldest first.
+func (s *NoteStore) ListOpenConflicts(ctx context.Context) ([]domain.NoteConflict, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+noteConflictColumns+`
+		FROM note_conflicts
+		WHERE resolved_at IS NULL
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := make([]domain.NoteConflict, 0)
+	for rows.Next() {
+		c, err := scanNoteConflict(rows)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, *c)
+	}
+	return conflicts, rows.Err()
+}
+
+// GetConflict retrieves a note conflict.
+// Returns ErrNoteConflictNotFound if it doesn't exist.
+func (s *NoteStore) GetConflict(ctx context.Context, id int64) (*domain.NoteConflict, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+noteConflictColumns+` FROM note_conflicts WHERE id = $1`, id)
+	c, err := scanNoteConflict(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoteConflictNotFound
+	}
+	return c, err
+}
+
+// MarkConflictResolved records how a conflict was resolved. Only an open
+// conflict is updated; returns domain.ErrNoteConflictResolved otherwise.
+func (s *NoteStore) MarkConflictResolved(ctx context.Context, c *domain.NoteConflict) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE note_conflicts SET resolved_at = $1, resolution = $2
+		WHERE id = $3 AND resolved_at IS NULL
+	`, c.ResolvedAt, string(c.Resolution), c.ID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNoteConflictResolved
+	}
+	return nil
+}
+
+func scanNoteConflict(row mealTemplateScanner) (*domain.NoteConflict, error) {
+	var c domain.NoteConflict
+	var sessionID sql.NullInt64
+	var resolvedAt sql.NullTime
+	var resolution string
+	if err := row.Scan(&c.ID, &c.Target, &c.Date, &sessionID, &c.Base, &c.Kept, &c.Overwritten, &c.Suggested,
+		&c.CreatedAt, &resolvedAt, &resolution); err != nil {
+		return nil, err
+	}
+	if sessionID.Valid {
+		c.SessionID = &sessionID.Int64
+	}
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	c.Resolution = domain.NoteResolution(resolution)
+	return &c, nil
+}
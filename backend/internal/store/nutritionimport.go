@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrImportedFoodEntryNotFound is returned when an imported food entry doesn't exist.
+var ErrImportedFoodEntryNotFound = errors.New("imported food entry not found")
+
+// NutritionImportStore handles persistence for food entries imported from
+// MyFitnessPal and Cronometer exports.
+type NutritionImportStore struct {
+	db DBTX
+}
+
+// NewNutritionImportStore creates a new NutritionImportStore.
+func NewNutritionImportStore(db DBTX) *NutritionImportStore {
+	return &NutritionImportStore{db: db}
+}
+
+// InsertEntry stores an imported food entry and sets its ID.
+// Returns false without error if the same row was already imported.
+func (s *NutritionImportStore) InsertEntry(ctx context.Context, e *domain.ImportedFoodEntry) (bool, error) {
+	const query = `
+		INSERT INTO imported_food_entries (
+			source, log_date, meal, raw_name, amount, food_reference_id, match_score,
+			calories, protein_g, carbs_g, fat_g
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (source, log_date, meal, raw_name, amount, calories) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	meal := ""
+	if e.Meal != nil {
+		meal = string(*e.Meal)
+	}
+
+	err := s.db.QueryRowContext(ctx, query,
+		e.Source, e.Date, meal, e.RawName, e.Amount, e.FoodReferenceID, e.MatchScore,
+		e.Calories, e.ProteinG, e.CarbsG, e.FatG,
+	).Scan(&e.ID, &e.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListUnmatched returns named entries not yet linked to food_reference, oldest date first.
+func (s *NutritionImportStore) ListUnmatched(ctx context.Context) ([]domain.ImportedFoodEntry, error) {
+	const query = `
+		SELECT id, source, log_date, meal, raw_name, amount, food_reference_id, match_score,
+		       calories, protein_g, carbs_g, fat_g, created_at
+		FROM imported_food_entries
+		WHERE food_reference_id IS NULL AND raw_name <> ''
+		ORDER BY log_date, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.ImportedFoodEntry, 0)
+	for rows.Next() {
+		var e domain.ImportedFoodEntry
+		var meal string
+		var foodReferenceID sql.NullInt64
+		if err := rows.Scan(
+			&e.ID, &e.Source, &e.Date, &meal, &e.RawName, &e.Amount, &foodReferenceID, &e.MatchScore,
+			&e.Calories, &e.ProteinG, &e.CarbsG, &e.FatG, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if meal != "" {
+			m := domain.MealName(meal)
+			e.Meal = &m
+		}
+		if foodReferenceID.Valid {
+			e.FoodReferenceID = &foodReferenceID.Int64
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// ResolveEntry links an imported entry to a food_reference row.
+// When applyToName is true, every other unmatched entry with the same raw name is linked too.
+// Returns the number of entries updated, ErrImportedFoodEntryNotFound, or
+// ErrFoodReferenceNotFound for an unknown food.
+func (s *NutritionImportStore) ResolveEntry(ctx context.Context, id, foodReferenceID int64, applyToName bool) (int, error) {
+	query := `
+		UPDATE imported_food_entries
+		SET food_reference_id = $1, match_score = 1
+		WHERE id = $2
+	`
+	if applyToName {
+		query = `
+			UPDATE imported_food_entries
+			SET food_reference_id = $1, match_score = 1
+			WHERE id = $2
+			   OR (food_reference_id IS NULL
+			       AND raw_name = (SELECT raw_name FROM imported_food_entries WHERE id = $2))
+		`
+	}
+
+	result, err := s.db.ExecContext(ctx, query, foodReferenceID, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, ErrFoodReferenceNotFound
+		}
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, ErrImportedFoodEntryNotFound
+	}
+	return int(n), nil
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrOnboardingNotFound is returned when onboarding hasn't been started.
+var ErrOnboardingNotFound = errors.New("onboarding state not found")
+
+// OnboardingStore handles persistence for the first-run wizard's progress.
+type OnboardingStore struct {
+	db DBTX
+}
+
+// NewOnboardingStore creates a new OnboardingStore.
+func NewOnboardingStore(db DBTX) *OnboardingStore {
+	return &OnboardingStore{db: db}
+}
+
+// Get returns the wizard's progress.
+// Returns ErrOnboardingNotFound if onboarding hasn't been started.
+func (s *OnboardingStore) Get(ctx context.Context) (*domain.OnboardingState, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx, `SELECT state FROM onboarding_state WHERE id = 1`).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOnboardingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state domain.OnboardingState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save creates or replaces the wizard's progress.
+func (s *OnboardingStore) Save(ctx context.Context, state *domain.OnboardingState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO onboarding_state (id, state, updated_at)
+		VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at
+	`
+	_, err = s.db.ExecContext(ctx, query, raw, state.UpdatedAt)
+	return err
+}
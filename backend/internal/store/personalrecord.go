@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrPersonalRecordNotFound is returned when a personal record entry doesn't exist.
+var ErrPersonalRecordNotFound = errors.New("personal record not found")
+
+// PersonalRecordStore handles persistence for the personal record history.
+type PersonalRecordStore struct {
+	db DBTX
+}
+
+// NewPersonalRecordStore creates a new PersonalRecordStore.
+func NewPersonalRecordStore(db DBTX) *PersonalRecordStore {
+	return &PersonalRecordStore{db: db}
+}
+
+const personalRecordColumns = `id, movement_id, kind, value, source, session_id, record_date, note, celebration_pending, created_at`
+
+// Create stores an entry and sets its ID and CreatedAt.
+func (s *PersonalRecordStore) Create(ctx context.Context, r *domain.PersonalRecord) error {
+	const query = `
+		INSERT INTO personal_records (movement_id, kind, value, source, session_id, record_date, note, celebration_pending)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query,
+		r.MovementID, string(r.Kind), r.Value, string(r.Source), r.SessionID, r.Date, r.Note, r.CelebrationPending,
+	).Scan(&r.ID, &r.CreatedAt)
+}
+
+// GetByID returns an entry.
+// Returns ErrPersonalRecordNotFound if it doesn't exist.
+func (s *PersonalRecordStore) GetByID(ctx context.Context, id int64) (*domain.PersonalRecord, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+personalRecordColumns+` FROM personal_records WHERE id = $1`, id)
+	r, err := scanPersonalRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPersonalRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// List returns the history by date, optionally filtered to one movement.
+// Pass an empty movementID to return the history for all movements.
+func (s *PersonalRecordStore) List(ctx context.Context, movementID string) ([]domain.PersonalRecord, error) {
+	return s.query(ctx, `
+		SELECT `+personalRecordColumns+`
+		FROM personal_records
+		WHERE $1 = '' OR movement_id = $1
+		ORDER BY record_date, id
+	`, movementID)
+}
+
+// ListPendingCelebrations returns entries whose celebration hasn't been dismissed, oldest first.
+func (s *PersonalRecordStore) ListPendingCelebrations(ctx context.Context) ([]domain.PersonalRecord, error) {
+	return s.query(ctx, `
+		SELECT `+personalRecordColumns+`
+		FROM personal_records
+		WHERE celebration_pending
+		ORDER BY record_date, id
+	`)
+}
+
+// Update corrects an entry's value, date and note.
+// Returns ErrPersonalRecordNotFound if it doesn't exist.
+func (s *PersonalRecordStore) Update(ctx context.Context, r *domain.PersonalRecord) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE personal_records SET value = $2, record_date = $3, note = $4 WHERE id = $1
+	`, r.ID, r.Value, r.Date, r.Note)
+	return personalRecordAffected(result, err)
+}
+
+// DismissCelebration clears an entry's pending celebration.
+// Returns ErrPersonalRecordNotFound if it doesn't exist.
+func (s *PersonalRecordStore) DismissCelebration(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE personal_records SET celebration_pending = false WHERE id = $1`, id)
+	return personalRecordAffected(result, err)
+}
+
+// Delete removes an entry.
+// Returns ErrPersonalRecordNotFound if it doesn't exist.
+func (s *PersonalRecordStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM personal_records WHERE id = $1`, id)
+	return personalRecordAffected(result, err)
+}
+
+func (s *PersonalRecordStore) query(ctx context.Context, query string, args ...any) ([]domain.PersonalRecord, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]domain.PersonalRecord, 0)
+	for rows.Next() {
+		r, err := scanPersonalRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *r)
+	}
+	return records, rows.Err()
+}
+
+func scanPersonalRecord(row mealTemplateScanner) (*domain.PersonalRecord, error) {
+	var r domain.PersonalRecord
+	var sessionID sql.NullInt64
+	if err := row.Scan(&r.ID, &r.MovementID, &r.Kind, &r.Value, &r.Source, &sessionID, &r.Date, &r.Note, &r.CelebrationPending, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	if sessionID.Valid {
+		r.SessionID = &sessionID.Int64
+	}
+	return &r, nil
+}
+
+func personalRecordAffected(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPersonalRecordNotFound
+	}
+	return nil
+}
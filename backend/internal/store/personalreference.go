@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// PersonalReferenceStore holds the user's personal reference ranges, one row
+// per metric.
+type PersonalReferenceStore struct {
+	db DBTX
+}
+
+// NewPersonalReferenceStore creates a new PersonalReferenceStore.
+func NewPersonalReferenceStore(db DBTX) *PersonalReferenceStore {
+	return &PersonalReferenceStore{db: db}
+}
+
+// List returns the stored ranges ordered by metric.
+func (s *PersonalReferenceStore) List(ctx context.Context) ([]domain.PersonalReferenceRange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT metric, p10, p25, p50, p75, p90, sample_count, window_start, window_end, computed_at
+		FROM personal_reference_ranges
+		ORDER BY metric
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranges []domain.PersonalReferenceRange
+	for rows.Next() {
+		var r domain.PersonalReferenceRange
+		if err := rows.Scan(
+			&r.Metric, &r.P10, &r.P25, &r.P50, &r.P75, &r.P90,
+			&r.SampleCount, &r.WindowStart, &r.WindowEnd, &r.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, rows.Err()
+}
+
+// Upsert stores a metric's range, replacing the previous one.
+func (s *PersonalReferenceStore) Upsert(ctx context.Context, r domain.PersonalReferenceRange, now time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO personal_reference_ranges
+			(metric, p10, p25, p50, p75, p90, sample_count, window_start, window_end, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (metric) DO UPDATE SET
+			p10 = EXCLUDED.p10, p25 = EXCLUDED.p25, p50 = EXCLUDED.p50,
+			p75 = EXCLUDED.p75, p90 = EXCLUDED.p90,
+			sample_count = EXCLUDED.sample_count,
+			window_start = EXCLUDED.window_start, window_end = EXCLUDED.window_end,
+			computed_at = EXCLUDED.computed_at
+	`, r.Metric, r.P10, r.P25, r.P50, r.P75, r.P90, r.SampleCount, r.WindowStart, r.WindowEnd, now)
+	return err
+}
+
+// Delete removes a metric's range, so the generic thresholds apply again.
+func (s *PersonalReferenceStore) Delete(ctx context.Context, metric domain.ReferenceMetric) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM personal_reference_ranges WHERE metric = $1`, metric)
+	return err
+}
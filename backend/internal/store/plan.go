@@ -27,7 +27,15 @@ func NewNutritionPlanStore(db DBTX) *NutritionPlanStore {
 	return &NutritionPlanStore{db: db}
 }
 
+// InTx runs fn in one transaction; statements made through TxScoped stores
+// with fn's context join it, as do the plan writes that use their own
+// transaction otherwise.
+func (s *NutritionPlanStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, s.db, fn)
+}
+
 // Create creates a new nutrition plan with its weekly targets.
+// Joins the request-scoped transaction (InTx) when ctx carries it.
 // Returns ErrActivePlanExists if an active plan already exists.
 func (s *NutritionPlanStore) Create(ctx context.Context, plan *domain.NutritionPlan) (int64, error) {
 	// Check for existing active plan
@@ -40,92 +48,121 @@ func (s *NutritionPlanStore) Create(ctx context.Context, plan *domain.NutritionP
 		return 0, ErrActivePlanExists
 	}
 
-	// Start transaction for plan + weekly targets
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback()
-
-	// Insert plan
-	const planQuery = `
-		INSERT INTO nutrition_plans (
-			name, start_date, start_weight_kg, goal_weight_kg, duration_weeks,
-			required_weekly_change_kg, required_daily_deficit_kcal, status,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id
-	`
-
-	now := time.Now()
+	// Plan + weekly targets in one transaction, joining the caller's when
+	// there is one
 	var planID int64
-	err = tx.QueryRowContext(ctx, planQuery,
-		plan.Name,
-		plan.StartDate.Format("2006-01-02"),
-		plan.StartWeightKg,
-		plan.GoalWeightKg,
-		plan.DurationWeeks,
-		plan.RequiredWeeklyChangeKg,
-		plan.RequiredDailyDeficitKcal,
-		plan.Status,
-		now,
-		now,
-	).Scan(&planID)
-	if err != nil {
-		return 0, err
-	}
-
-	// Insert weekly targets
-	const targetQuery = `
-		INSERT INTO weekly_targets (
-			plan_id, week_number, start_date, end_date,
-			projected_weight_kg, projected_tdee, target_intake_kcal,
-			target_carbs_g, target_protein_g, target_fats_g,
-			days_logged
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 0)
-	`
+	err = withTx(ctx, s.db, func(tx *sql.Tx) error {
+		// Insert plan
+		const planQuery = `
+			INSERT INTO nutrition_plans (
+				name, start_date, start_weight_kg, goal_weight_kg, duration_weeks,
+				required_weekly_change_kg, required_daily_deficit_kcal, status,
+				kcal_factor_override, kcal_factor_auto_tune,
+				mode, start_body_fat_percent, goal_body_fat_percent,
+				event_date, event_name,
+				created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			RETURNING id
+		`
+
+		var eventDate sql.NullString
+		if plan.EventDate != nil {
+			eventDate = sql.NullString{String: plan.EventDate.Format("2006-01-02"), Valid: true}
+		}
 
-	for _, target := range plan.WeeklyTargets {
-		_, err := tx.ExecContext(ctx, targetQuery,
-			planID,
-			target.WeekNumber,
-			target.StartDate.Format("2006-01-02"),
-			target.EndDate.Format("2006-01-02"),
-			target.ProjectedWeightKg,
-			target.ProjectedTDEE,
-			target.TargetIntakeKcal,
-			target.TargetCarbsG,
-			target.TargetProteinG,
-			target.TargetFatsG,
-		)
+		now := time.Now()
+		err := tx.QueryRowContext(ctx, planQuery,
+			plan.Name,
+			plan.StartDate.Format("2006-01-02"),
+			plan.StartWeightKg,
+			plan.GoalWeightKg,
+			plan.DurationWeeks,
+			plan.RequiredWeeklyChangeKg,
+			plan.RequiredDailyDeficitKcal,
+			plan.Status,
+			plan.KcalFactorOverride,
+			plan.KcalFactorAutoTune,
+			plan.Mode,
+			plan.StartBodyFatPercent,
+			plan.GoalBodyFatPercent,
+			eventDate,
+			plan.EventName,
+			now,
+			now,
+		).Scan(&planID)
 		if err != nil {
-			return 0, err
+			return err
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
+		// Insert weekly targets
+		const targetQuery = `
+			INSERT INTO weekly_targets (
+				plan_id, week_number, start_date, end_date,
+				projected_weight_kg, projected_tdee, target_intake_kcal,
+				target_carbs_g, target_protein_g, target_fats_g,
+				days_logged, days_expected
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 0, $11)
+		`
+
+		for _, target := range plan.WeeklyTargets {
+			_, err := tx.ExecContext(ctx, targetQuery,
+				planID,
+				target.WeekNumber,
+				target.StartDate.Format("2006-01-02"),
+				target.EndDate.Format("2006-01-02"),
+				target.ProjectedWeightKg,
+				target.ProjectedTDEE,
+				target.TargetIntakeKcal,
+				target.TargetCarbsG,
+				target.TargetProteinG,
+				target.TargetFatsG,
+				target.DaysExpected,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return 0, err
 	}
-
 	return planID, nil
 }
 
 // GetByID retrieves a nutrition plan by ID with its weekly targets.
 func (s *NutritionPlanStore) GetByID(ctx context.Context, id int64) (*domain.NutritionPlan, error) {
-	const query = `
+	return s.getPlan(ctx, "id = $1", id)
+}
+
+// GetActive retrieves the currently active nutrition plan.
+func (s *NutritionPlanStore) GetActive(ctx context.Context) (*domain.NutritionPlan, error) {
+	return s.getPlan(ctx, "status = 'active'")
+}
+
+// getPlan retrieves the first nutrition plan matching where, then its weekly
+// targets: two queries in total.
+func (s *NutritionPlanStore) getPlan(ctx context.Context, where string, args ...interface{}) (*domain.NutritionPlan, error) {
+	query := `
 		SELECT
 			id, COALESCE(name, ''), start_date, start_weight_kg, goal_weight_kg, duration_weeks,
 			required_weekly_change_kg, required_daily_deficit_kcal, status,
+			kcal_factor_override, kcal_factor_auto_tune, kcal_factor_tuned_at,
+			mode, start_body_fat_percent, goal_body_fat_percent,
+			event_date, event_name, abandon_reasons, abandon_note,
 			last_recalibrated_at, created_at, updated_at
 		FROM nutrition_plans
-		WHERE id = $1
+		WHERE ` + where + `
+		LIMIT 1
 	`
 
 	var plan domain.NutritionPlan
 	var startDate, createdAt, updatedAt string
-	var lastRecalibratedAt sql.NullString
+	var lastRecalibratedAt, kcalFactorTunedAt, eventDate sql.NullString
+	var kcalFactorOverride, startBodyFat, goalBodyFat sql.NullFloat64
+	var abandonReasons []byte
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
 		&plan.ID,
 		&plan.Name,
 		&startDate,
@@ -135,6 +172,16 @@ func (s *NutritionPlanStore) GetByID(ctx context.Context, id int64) (*domain.Nut
 		&plan.RequiredWeeklyChangeKg,
 		&plan.RequiredDailyDeficitKcal,
 		&plan.Status,
+		&kcalFactorOverride,
+		&plan.KcalFactorAutoTune,
+		&kcalFactorTunedAt,
+		&plan.Mode,
+		&startBodyFat,
+		&goalBodyFat,
+		&eventDate,
+		&plan.EventName,
+		&abandonReasons,
+		&plan.AbandonNote,
 		&lastRecalibratedAt,
 		&createdAt,
 		&updatedAt,
@@ -153,6 +200,26 @@ func (s *NutritionPlanStore) GetByID(ctx context.Context, id int64) (*domain.Nut
 		t, _ := time.Parse("2006-01-02 15:04:05", lastRecalibratedAt.String)
 		plan.LastRecalibratedAt = &t
 	}
+	if kcalFactorOverride.Valid {
+		plan.KcalFactorOverride = &kcalFactorOverride.Float64
+	}
+	if startBodyFat.Valid {
+		plan.StartBodyFatPercent = &startBodyFat.Float64
+	}
+	if goalBodyFat.Valid {
+		plan.GoalBodyFatPercent = &goalBodyFat.Float64
+	}
+	if kcalFactorTunedAt.Valid {
+		t, _ := time.Parse("2006-01-02 15:04:05", kcalFactorTunedAt.String)
+		plan.KcalFactorTunedAt = &t
+	}
+	if eventDate.Valid {
+		t, _ := time.Parse("2006-01-02", eventDate.String)
+		plan.EventDate = &t
+	}
+	if err := json.Unmarshal(abandonReasons, &plan.AbandonReasons); err != nil {
+		return nil, err
+	}
 
 	// Load weekly targets
 	targets, err := s.getWeeklyTargets(ctx, plan.ID)
@@ -164,33 +231,48 @@ func (s *NutritionPlanStore) GetByID(ctx context.Context, id int64) (*domain.Nut
 	return &plan, nil
 }
 
-// GetActive retrieves the currently active nutrition plan.
-func (s *NutritionPlanStore) GetActive(ctx context.Context) (*domain.NutritionPlan, error) {
+// UpdateStatus updates the status of a nutrition plan.
+func (s *NutritionPlanStore) UpdateStatus(ctx context.Context, id int64, status domain.PlanStatus) error {
 	const query = `
-		SELECT id FROM nutrition_plans WHERE status = 'active' LIMIT 1
+		UPDATE nutrition_plans
+		SET status = $1, updated_at = $2
+		WHERE id = $3
 	`
 
-	var id int64
-	err := s.db.QueryRowContext(ctx, query).Scan(&id)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, ErrPlanNotFound
+	result, err := s.db.ExecContext(ctx, query, status, time.Now(), id)
+	if err != nil {
+		return err
 	}
+
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPlanNotFound
 	}
 
-	return s.GetByID(ctx, id)
+	return nil
 }
 
-// UpdateStatus updates the status of a nutrition plan.
-func (s *NutritionPlanStore) UpdateStatus(ctx context.Context, id int64, status domain.PlanStatus) error {
+// Abandon marks a nutrition plan as abandoned and records its exit survey.
+func (s *NutritionPlanStore) Abandon(ctx context.Context, id int64, survey domain.PlanExitSurvey) error {
+	reasons := survey.Reasons
+	if reasons == nil {
+		reasons = []domain.PlanAbandonReason{}
+	}
+	rawReasons, err := json.Marshal(reasons)
+	if err != nil {
+		return err
+	}
+
 	const query = `
 		UPDATE nutrition_plans
-		SET status = $1, updated_at = $2
-		WHERE id = $3
+		SET status = $1, abandon_reasons = $2, abandon_note = $3, updated_at = $4
+		WHERE id = $5
 	`
 
-	result, err := s.db.ExecContext(ctx, query, status, time.Now(), id)
+	result, err := s.db.ExecContext(ctx, query, domain.PlanStatusAbandoned, rawReasons, survey.Note, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -230,32 +312,51 @@ func (s *NutritionPlanStore) UpdateWeeklyActuals(ctx context.Context, planID int
 	return nil
 }
 
-// UpdatePlan updates a nutrition plan and replaces its weekly targets.
-// Used during recalibration to apply new goals, duration, or calorie targets.
-func (s *NutritionPlanStore) UpdatePlan(ctx context.Context, plan *domain.NutritionPlan) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+// UpdateWeeklyRollup saves a week's actuals rolled up from the daily logs,
+// with the days it could have been logged.
+// Returns ErrPlanNotFound if the plan has no such week.
+func (s *NutritionPlanStore) UpdateWeeklyRollup(ctx context.Context, planID int64, weekNumber int, actuals domain.WeeklyActuals) error {
+	const query = `
+		UPDATE weekly_targets
+		SET actual_weight_kg = $1, actual_intake_kcal = $2, days_logged = $3, days_expected = $4
+		WHERE plan_id = $5 AND week_number = $6
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		actuals.WeightKg, actuals.IntakeKcal, actuals.DaysLogged, actuals.DaysExpected, planID, weekNumber)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
 
-	// Update plan fields
-	const updatePlanQuery = `
-		UPDATE nutrition_plans
-		SET goal_weight_kg = $1, duration_weeks = $2,
-			required_weekly_change_kg = $3, required_daily_deficit_kcal = $4,
-			last_recalibrated_at = $5, updated_at = $6
-		WHERE id = $7
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPlanNotFound
+	}
+
+	return nil
+}
+
+// UpdateWeeklyTarget saves a manually edited week's intake, macros and pin flag.
+// Returns ErrPlanNotFound if the plan has no such week.
+func (s *NutritionPlanStore) UpdateWeeklyTarget(ctx context.Context, target domain.WeeklyTarget) error {
+	const query = `
+		UPDATE weekly_targets
+		SET target_intake_kcal = $1, target_carbs_g = $2, target_protein_g = $3,
+			target_fats_g = $4, is_pinned = $5
+		WHERE plan_id = $6 AND week_number = $7
 	`
 
-	result, err := tx.ExecContext(ctx, updatePlanQuery,
-		plan.GoalWeightKg,
-		plan.DurationWeeks,
-		plan.RequiredWeeklyChangeKg,
-		plan.RequiredDailyDeficitKcal,
-		plan.LastRecalibratedAt,
-		time.Now(),
-		plan.ID,
+	result, err := s.db.ExecContext(ctx, query,
+		target.TargetIntakeKcal,
+		target.TargetCarbsG,
+		target.TargetProteinG,
+		target.TargetFatsG,
+		target.Pinned,
+		target.PlanID,
+		target.WeekNumber,
 	)
 	if err != nil {
 		return err
@@ -269,44 +370,112 @@ func (s *NutritionPlanStore) UpdatePlan(ctx context.Context, plan *domain.Nutrit
 		return ErrPlanNotFound
 	}
 
-	// Delete existing weekly targets
-	_, err = tx.ExecContext(ctx, "DELETE FROM weekly_targets WHERE plan_id = $1", plan.ID)
+	return nil
+}
+
+// UpdateKcalFactorTunedAt records when kcal factor auto-tuning last evaluated a plan
+// without changing it.
+func (s *NutritionPlanStore) UpdateKcalFactorTunedAt(ctx context.Context, id int64, at time.Time) error {
+	const query = `
+		UPDATE nutrition_plans
+		SET kcal_factor_tuned_at = $1
+		WHERE id = $2
+	`
+
+	result, err := s.db.ExecContext(ctx, query, at, id)
 	if err != nil {
 		return err
 	}
 
-	// Insert new weekly targets
-	const insertTargetQuery = `
-		INSERT INTO weekly_targets (
-			plan_id, week_number, start_date, end_date,
-			projected_weight_kg, projected_tdee, target_intake_kcal,
-			target_carbs_g, target_protein_g, target_fats_g,
-			actual_weight_kg, actual_intake_kcal, days_logged
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPlanNotFound
+	}
 
-	for _, target := range plan.WeeklyTargets {
-		_, err := tx.ExecContext(ctx, insertTargetQuery,
+	return nil
+}
+
+// UpdatePlan updates a nutrition plan and replaces its weekly targets.
+// Used during recalibration to apply new goals, duration, or calorie targets.
+func (s *NutritionPlanStore) UpdatePlan(ctx context.Context, plan *domain.NutritionPlan) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		// Update plan fields
+		const updatePlanQuery = `
+			UPDATE nutrition_plans
+			SET goal_weight_kg = $1, duration_weeks = $2,
+				required_weekly_change_kg = $3, required_daily_deficit_kcal = $4,
+				last_recalibrated_at = $5, updated_at = $6,
+				kcal_factor_override = $7, kcal_factor_tuned_at = $8
+			WHERE id = $9
+		`
+
+		result, err := tx.ExecContext(ctx, updatePlanQuery,
+			plan.GoalWeightKg,
+			plan.DurationWeeks,
+			plan.RequiredWeeklyChangeKg,
+			plan.RequiredDailyDeficitKcal,
+			plan.LastRecalibratedAt,
+			time.Now(),
+			plan.KcalFactorOverride,
+			plan.KcalFactorTunedAt,
 			plan.ID,
-			target.WeekNumber,
-			target.StartDate.Format("2006-01-02"),
-			target.EndDate.Format("2006-01-02"),
-			target.ProjectedWeightKg,
-			target.ProjectedTDEE,
-			target.TargetIntakeKcal,
-			target.TargetCarbsG,
-			target.TargetProteinG,
-			target.TargetFatsG,
-			target.ActualWeightKg,
-			target.ActualIntakeKcal,
-			target.DaysLogged,
 		)
 		if err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit()
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrPlanNotFound
+		}
+
+		// Delete existing weekly targets
+		_, err = tx.ExecContext(ctx, "DELETE FROM weekly_targets WHERE plan_id = $1", plan.ID)
+		if err != nil {
+			return err
+		}
+
+		// Insert new weekly targets
+		const insertTargetQuery = `
+			INSERT INTO weekly_targets (
+				plan_id, week_number, start_date, end_date,
+				projected_weight_kg, projected_tdee, target_intake_kcal,
+				target_carbs_g, target_protein_g, target_fats_g,
+				actual_weight_kg, actual_intake_kcal, days_logged, is_pinned, days_expected
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`
+
+		for _, target := range plan.WeeklyTargets {
+			_, err := tx.ExecContext(ctx, insertTargetQuery,
+				plan.ID,
+				target.WeekNumber,
+				target.StartDate.Format("2006-01-02"),
+				target.EndDate.Format("2006-01-02"),
+				target.ProjectedWeightKg,
+				target.ProjectedTDEE,
+				target.TargetIntakeKcal,
+				target.TargetCarbsG,
+				target.TargetProteinG,
+				target.TargetFatsG,
+				target.ActualWeightKg,
+				target.ActualIntakeKcal,
+				target.DaysLogged,
+				target.Pinned,
+				target.DaysExpected,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 // Delete removes a nutrition plan and its weekly targets (cascade).
@@ -320,8 +489,8 @@ func (s *NutritionPlanStore) ListAll(ctx context.Context) ([]*domain.NutritionPl
 	const query = `
 		SELECT
 			id, COALESCE(name, ''), start_date, start_weight_kg, goal_weight_kg, duration_weeks,
-			required_weekly_change_kg, required_daily_deficit_kcal, status,
-			created_at, updated_at
+			required_weekly_change_kg, required_daily_deficit_kcal, status, mode,
+			abandon_reasons, abandon_note, created_at, updated_at
 		FROM nutrition_plans
 		ORDER BY start_date DESC
 	`
@@ -336,6 +505,7 @@ func (s *NutritionPlanStore) ListAll(ctx context.Context) ([]*domain.NutritionPl
 	for rows.Next() {
 		var plan domain.NutritionPlan
 		var startDate, createdAt, updatedAt string
+		var abandonReasons []byte
 
 		err := rows.Scan(
 			&plan.ID,
@@ -347,12 +517,18 @@ func (s *NutritionPlanStore) ListAll(ctx context.Context) ([]*domain.NutritionPl
 			&plan.RequiredWeeklyChangeKg,
 			&plan.RequiredDailyDeficitKcal,
 			&plan.Status,
+			&plan.Mode,
+			&abandonReasons,
+			&plan.AbandonNote,
 			&createdAt,
 			&updatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if err := json.Unmarshal(abandonReasons, &plan.AbandonReasons); err != nil {
+			return nil, err
+		}
 
 		plan.StartDate, _ = time.Parse("2006-01-02", startDate)
 		plan.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
@@ -370,96 +546,97 @@ func (s *NutritionPlanStore) ListAll(ctx context.Context) ([]*domain.NutritionPl
 
 // UpdatePlanWithRecalibration atomically updates a plan's fields/targets and inserts a recalibration record.
 func (s *NutritionPlanStore) UpdatePlanWithRecalibration(ctx context.Context, plan *domain.NutritionPlan, record domain.RecalibrationRecord) error {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// Update plan fields
-	const updatePlanQuery = `
-		UPDATE nutrition_plans
-		SET goal_weight_kg = $1, duration_weeks = $2,
-			required_weekly_change_kg = $3, required_daily_deficit_kcal = $4,
-			last_recalibrated_at = $5, updated_at = $6
-		WHERE id = $7
-	`
-
-	result, err := tx.ExecContext(ctx, updatePlanQuery,
-		plan.GoalWeightKg,
-		plan.DurationWeeks,
-		plan.RequiredWeeklyChangeKg,
-		plan.RequiredDailyDeficitKcal,
-		plan.LastRecalibratedAt,
-		time.Now(),
-		plan.ID,
-	)
-	if err != nil {
-		return err
-	}
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		// Update plan fields
+		const updatePlanQuery = `
+			UPDATE nutrition_plans
+			SET goal_weight_kg = $1, duration_weeks = $2,
+				required_weekly_change_kg = $3, required_daily_deficit_kcal = $4,
+				last_recalibrated_at = $5, updated_at = $6,
+				kcal_factor_override = $7, kcal_factor_tuned_at = $8
+			WHERE id = $9
+		`
+
+		result, err := tx.ExecContext(ctx, updatePlanQuery,
+			plan.GoalWeightKg,
+			plan.DurationWeeks,
+			plan.RequiredWeeklyChangeKg,
+			plan.RequiredDailyDeficitKcal,
+			plan.LastRecalibratedAt,
+			time.Now(),
+			plan.KcalFactorOverride,
+			plan.KcalFactorTunedAt,
+			plan.ID,
+		)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return ErrPlanNotFound
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrPlanNotFound
+		}
 
-	// Delete existing weekly targets
-	_, err = tx.ExecContext(ctx, "DELETE FROM weekly_targets WHERE plan_id = $1", plan.ID)
-	if err != nil {
-		return err
-	}
+		// Delete existing weekly targets
+		_, err = tx.ExecContext(ctx, "DELETE FROM weekly_targets WHERE plan_id = $1", plan.ID)
+		if err != nil {
+			return err
+		}
 
-	// Insert new weekly targets
-	const insertTargetQuery = `
-		INSERT INTO weekly_targets (
-			plan_id, week_number, start_date, end_date,
-			projected_weight_kg, projected_tdee, target_intake_kcal,
-			target_carbs_g, target_protein_g, target_fats_g,
-			actual_weight_kg, actual_intake_kcal, days_logged
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`
+		// Insert new weekly targets
+		const insertTargetQuery = `
+			INSERT INTO weekly_targets (
+				plan_id, week_number, start_date, end_date,
+				projected_weight_kg, projected_tdee, target_intake_kcal,
+				target_carbs_g, target_protein_g, target_fats_g,
+				actual_weight_kg, actual_intake_kcal, days_logged, is_pinned, days_expected
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`
+
+		for _, target := range plan.WeeklyTargets {
+			_, err := tx.ExecContext(ctx, insertTargetQuery,
+				plan.ID,
+				target.WeekNumber,
+				target.StartDate.Format("2006-01-02"),
+				target.EndDate.Format("2006-01-02"),
+				target.ProjectedWeightKg,
+				target.ProjectedTDEE,
+				target.TargetIntakeKcal,
+				target.TargetCarbsG,
+				target.TargetProteinG,
+				target.TargetFatsG,
+				target.ActualWeightKg,
+				target.ActualIntakeKcal,
+				target.DaysLogged,
+				target.Pinned,
+				target.DaysExpected,
+			)
+			if err != nil {
+				return err
+			}
+		}
 
-	for _, target := range plan.WeeklyTargets {
-		_, err := tx.ExecContext(ctx, insertTargetQuery,
-			plan.ID,
-			target.WeekNumber,
-			target.StartDate.Format("2006-01-02"),
-			target.EndDate.Format("2006-01-02"),
-			target.ProjectedWeightKg,
-			target.ProjectedTDEE,
-			target.TargetIntakeKcal,
-			target.TargetCarbsG,
-			target.TargetProteinG,
-			target.TargetFatsG,
-			target.ActualWeightKg,
-			target.ActualIntakeKcal,
-			target.DaysLogged,
+		// Insert recalibration record
+		detailsJSON, err := json.Marshal(record.Details)
+		if err != nil {
+			return fmt.Errorf("marshal recalibration details: %w", err)
+		}
+		const insertRecordQuery = `
+			INSERT INTO recalibration_history (plan_id, action_type, details, created_at)
+			VALUES ($1, $2, $3, $4)
+		`
+		_, err = tx.ExecContext(ctx, insertRecordQuery,
+			record.PlanID, string(record.ActionType), detailsJSON, record.CreatedAt,
 		)
 		if err != nil {
 			return err
 		}
-	}
-
-	// Insert recalibration record
-	detailsJSON, err := json.Marshal(record.Details)
-	if err != nil {
-		return fmt.Errorf("marshal recalibration details: %w", err)
-	}
-	const insertRecordQuery = `
-		INSERT INTO recalibration_history (plan_id, action_type, details, created_at)
-		VALUES ($1, $2, $3, $4)
-	`
-	_, err = tx.ExecContext(ctx, insertRecordQuery,
-		record.PlanID, string(record.ActionType), detailsJSON, record.CreatedAt,
-	)
-	if err != nil {
-		return err
-	}
 
-	return tx.Commit()
+		return nil
+	})
 }
 
 // InsertRecalibrationRecord inserts a single recalibration record (used for keep_current).
@@ -516,7 +693,7 @@ func (s *NutritionPlanStore) getWeeklyTargets(ctx context.Context, planID int64)
 			id, plan_id, week_number, start_date, end_date,
 			projected_weight_kg, projected_tdee, target_intake_kcal,
 			target_carbs_g, target_protein_g, target_fats_g,
-			actual_weight_kg, actual_intake_kcal, days_logged
+			actual_weight_kg, actual_intake_kcal, days_logged, is_pinned, days_expected
 		FROM weekly_targets
 		WHERE plan_id = $1
 		ORDER BY week_number ASC
@@ -550,6 +727,8 @@ func (s *NutritionPlanStore) getWeeklyTargets(ctx context.Context, planID int64)
 			&actualWeight,
 			&actualIntake,
 			&target.DaysLogged,
+			&target.Pinned,
+			&target.DaysExpected,
 		)
 		if err != nil {
 			return nil, err
@@ -577,3 +756,84 @@ func (s *NutritionPlanStore) getWeeklyTargets(ctx context.Context, planID int64)
 	return targets, nil
 }
 
+// ReplaceDailyTargets materializes a plan's daily targets from date from
+// (YYYY-MM-DD) onwards; earlier days keep the targets they already have.
+// An empty from replaces every day.
+func (s *NutritionPlanStore) ReplaceDailyTargets(ctx context.Context, planID int64, targets []domain.DailyPlanTarget, from string) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"DELETE FROM plan_daily_targets WHERE plan_id = $1 AND target_date >= $2", planID, from)
+		if err != nil {
+			return err
+		}
+
+		const insertQuery = `
+			INSERT INTO plan_daily_targets (
+				plan_id, target_date, week_number, day_number, day_type,
+				carbs_g, protein_g, fats_g, calories
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+		for _, target := range targets {
+			date := target.Date.Format("2006-01-02")
+			if date < from {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, insertQuery,
+				planID,
+				date,
+				target.WeekNumber,
+				target.DayNumber,
+				string(target.DayType),
+				target.CarbsG,
+				target.ProteinG,
+				target.FatsG,
+				target.Calories,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// HasDailyTargets reports whether a plan's daily targets have been materialized.
+func (s *NutritionPlanStore) HasDailyTargets(ctx context.Context, planID int64) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM plan_daily_targets WHERE plan_id = $1)", planID).Scan(&exists)
+	return exists, err
+}
+
+// ListDailyTargets retrieves a plan's materialized daily targets within a date
+// range (inclusive), ordered by date.
+func (s *NutritionPlanStore) ListDailyTargets(ctx context.Context, planID int64, startDate, endDate string) ([]domain.DailyPlanTarget, error) {
+	const query = `
+		SELECT target_date, week_number, day_number, day_type,
+		       carbs_g, protein_g, fats_g, calories
+		FROM plan_daily_targets
+		WHERE plan_id = $1 AND target_date >= $2 AND target_date <= $3
+		ORDER BY target_date
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, planID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := []domain.DailyPlanTarget{}
+	for rows.Next() {
+		var target domain.DailyPlanTarget
+		var date string
+		if err := rows.Scan(&date, &target.WeekNumber, &target.DayNumber, &target.DayType,
+			&target.CarbsG, &target.ProteinG, &target.FatsG, &target.Calories); err != nil {
+			return nil, err
+		}
+		target.Date, _ = time.Parse("2006-01-02", date)
+		targets = append(targets, target)
+	}
+
+	return targets, rows.Err()
+}
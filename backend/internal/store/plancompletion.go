@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// ErrPlanRetrospectiveNotFound is returned when a plan has no retrospective.
+var ErrPlanRetrospectiveNotFound = errors.New("plan retrospective not found")
+
+// PlanCompletionStore handles persistence for completed plans' retrospectives
+// and archived weekly targets.
+type PlanCompletionStore struct {
+	db DBTX
+}
+
+// NewPlanCompletionStore creates a new PlanCompletionStore.
+func NewPlanCompletionStore(db DBTX) *PlanCompletionStore {
+	return &PlanCompletionStore{db: db}
+}
+
+// InTx runs fn in one transaction; statements made through TxScoped stores
+// with fn's context join it.
+func (s *PlanCompletionStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, s.db, fn)
+}
+
+// ArchiveWeeklyTargets snapshots a plan's weekly targets with their actuals.
+// Archiving again replaces the snapshot.
+func (s *PlanCompletionStore) ArchiveWeeklyTargets(ctx context.Context, planID int64, targets []domain.WeeklyTarget) error {
+	const query = `
+		INSERT INTO archived_weekly_targets (
+			plan_id, week_number, start_date, end_date, projected_weight_kg, projected_tdee,
+			target_intake_kcal, target_carbs_g, target_protein_g, target_fats_g,
+			actual_weight_kg, actual_intake_kcal, days_logged, days_expected, is_pinned
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (plan_id, week_number) DO UPDATE SET
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			projected_weight_kg = EXCLUDED.projected_weight_kg,
+			projected_tdee = EXCLUDED.projected_tdee,
+			target_intake_kcal = EXCLUDED.target_intake_kcal,
+			target_carbs_g = EXCLUDED.target_carbs_g,
+			target_protein_g = EXCLUDED.target_protein_g,
+			target_fats_g = EXCLUDED.target_fats_g,
+			actual_weight_kg = EXCLUDED.actual_weight_kg,
+			actual_intake_kcal = EXCLUDED.actual_intake_kcal,
+			days_logged = EXCLUDED.days_logged,
+			days_expected = EXCLUDED.days_expected,
+			is_pinned = EXCLUDED.is_pinned,
+			archived_at = NOW()
+	`
+	for _, t := range targets {
+		_, err := s.db.ExecContext(ctx, query,
+			planID, t.WeekNumber, t.StartDate.Format("2006-01-02"), t.EndDate.Format("2006-01-02"),
+			t.ProjectedWeightKg, t.ProjectedTDEE,
+			t.TargetIntakeKcal, t.TargetCarbsG, t.TargetProteinG, t.TargetFatsG,
+			t.ActualWeightKg, t.ActualIntakeKcal, t.DaysLogged, t.DaysExpected, t.Pinned,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListArchivedWeeklyTargets returns a plan's archived weekly targets in week order.
+func (s *PlanCompletionStore) ListArchivedWeeklyTargets(ctx context.Context, planID int64) ([]domain.WeeklyTarget, error) {
+	const query = `
+		SELECT
+			id, plan_id, week_number, start_date, end_date,
+			projected_weight_kg, projected_tdee, target_intake_kcal,
+			target_carbs_g, target_protein_g, target_fats_g,
+			actual_weight_kg, actual_intake_kcal, days_logged, days_expected, is_pinned
+		FROM archived_weekly_targets
+		WHERE plan_id = $1
+		ORDER BY week_number
+	`
+	rows, err := s.db.QueryContext(ctx, query, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := make([]domain.WeeklyTarget, 0)
+	for rows.Next() {
+		var t domain.WeeklyTarget
+		var startDate, endDate string
+		var actualWeight sql.NullFloat64
+		var actualIntake sql.NullInt64
+		err := rows.Scan(&t.ID, &t.PlanID, &t.WeekNumber, &startDate, &endDate,
+			&t.ProjectedWeightKg, &t.ProjectedTDEE, &t.TargetIntakeKcal,
+			&t.TargetCarbsG, &t.TargetProteinG, &t.TargetFatsG,
+			&actualWeight, &actualIntake, &t.DaysLogged, &t.DaysExpected, &t.Pinned)
+		if err != nil {
+			return nil, err
+		}
+		t.StartDate, _ = time.Parse("2006-01-02", startDate)
+		t.EndDate, _ = time.Parse("2006-01-02", endDate)
+		if actualWeight.Valid {
+			w := actualWeight.Float64
+			t.ActualWeightKg = &w
+		}
+		if actualIntake.Valid {
+			i := int(actualIntake.Int64)
+			t.ActualIntakeKcal = &i
+		}
+		targets = append(targets, t)
+	}
+	return targets, rows.Err()
+}
+
+// SaveRetrospective stores a completed plan's retrospective with a pending
+// next phase prompt, replacing any earlier one for the plan.
+func (s *PlanCompletionStore) SaveRetrospective(ctx context.Context, r domain.PlanRetrospective) error {
+	report, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO plan_retrospectives (plan_id, completed_on, report)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (plan_id) DO UPDATE SET
+			completed_on = EXCLUDED.completed_on,
+			report = EXCLUDED.report,
+			prompt_pending = true,
+			prompt_dismissed_at = NULL
+	`
+	_, err = s.db.ExecContext(ctx, query, r.PlanID, r.CompletedOn, report)
+	return err
+}
+
+// GetRetrospective returns a plan's retrospective and whether its next phase
+// prompt is pending.
+// Returns ErrPlanRetrospectiveNotFound if the plan has none.
+func (s *PlanCompletionStore) GetRetrospective(ctx context.Context, planID int64) (*domain.PlanRetrospective, bool, error) {
+	return s.getRetrospective(ctx, `WHERE plan_id = $1`, planID)
+}
+
+// LatestPendingRetrospective returns the most recently completed plan's
+// retrospective whose next phase prompt is pending.
+// Returns ErrPlanRetrospectiveNotFound if there is none.
+func (s *PlanCompletionStore) LatestPendingRetrospective(ctx context.Context) (*domain.PlanRetrospective, error) {
+	r, _, err := s.getRetrospective(ctx, `WHERE prompt_pending ORDER BY completed_on DESC, id DESC LIMIT 1`)
+	return r, err
+}
+
+func (s *PlanCompletionStore) getRetrospective(ctx context.Context, where string, args ...any) (*domain.PlanRetrospective, bool, error) {
+	var raw []byte
+	var pending bool
+	err := s.db.QueryRowContext(ctx, `SELECT report, prompt_pending FROM plan_retrospectives `+where, args...).
+		Scan(&raw, &pending)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, ErrPlanRetrospectiveNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var r domain.PlanRetrospective
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, false, err
+	}
+	return &r, pending, nil
+}
+
+// DismissPrompt answers a completed plan's next phase prompt.
+// Returns ErrPlanRetrospectiveNotFound if the plan has no retrospective.
+func (s *PlanCompletionStore) DismissPrompt(ctx context.Context, planID int64) error {
+	const query = `
+		UPDATE plan_retrospectives
+		SET prompt_pending = false, prompt_dismissed_at = $1
+		WHERE plan_id = $2
+	`
+	result, err := s.db.ExecContext(ctx, query, time.Now(), planID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPlanRetrospectiveNotFound
+	}
+	return nil
+}
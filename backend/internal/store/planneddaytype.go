@@ -97,3 +97,39 @@ func (s *PlannedDayTypeStore) DeleteByDate(ctx context.Context, date string) err
 	_, err := s.db.ExecContext(ctx, "DELETE FROM planned_day_types WHERE plan_date = $1", date)
 	return err
 }
+
+// ListDayTypes returns the day type each date in the range (inclusive) shows
+// on the dashboard: the logged day type, else the planned one. Dates with
+// neither are left out.
+func (s *PlannedDayTypeStore) ListDayTypes(ctx context.Context, startDate, endDate string) (map[string]domain.DayType, error) {
+	const query = `
+		SELECT COALESCE(l.log_date, p.plan_date), COALESCE(l.day_type, p.day_type)
+		FROM planned_day_types p
+		FULL OUTER JOIN (
+			SELECT log_date, day_type FROM daily_logs WHERE day_type IS NOT NULL
+		) l ON l.log_date = p.plan_date
+		WHERE COALESCE(l.log_date, p.plan_date) BETWEEN $1 AND $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]domain.DayType)
+	for rows.Next() {
+		var date string
+		var dayType domain.DayType
+		if err := rows.Scan(&date, &dayType); err != nil {
+			return nil, err
+		}
+		result[date] = dayType
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
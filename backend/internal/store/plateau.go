@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"victus/internal/domain"
+)
+
+// PlateauStore handles database operations for plateau detections.
+type PlateauStore struct {
+	db DBTX
+}
+
+// NewPlateauStore creates a new PlateauStore.
+func NewPlateauStore(db DBTX) *PlateauStore {
+	return &PlateauStore{db: db}
+}
+
+// Upsert records a detection. Re-detecting the same plateau on the same day
+// replaces the stored metrics and recommendations.
+func (s *PlateauStore) Upsert(ctx context.Context, d domain.PlateauDetection) (int64, error) {
+	recs, err := json.Marshal(d.Recommendations)
+	if err != nil {
+		return 0, err
+	}
+
+	const query = `
+		INSERT INTO plateau_detections
+			(plateau_type, subject, detected_on, duration_days, session_count, metric, recommendations)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (plateau_type, subject, detected_on) DO UPDATE SET
+			duration_days = EXCLUDED.duration_days,
+			session_count = EXCLUDED.session_count,
+			metric = EXCLUDED.metric,
+			recommendations = EXCLUDED.recommendations
+		RETURNING id
+	`
+
+	var id int64
+	err = s.db.QueryRowContext(ctx, query,
+		d.Type, d.Subject, d.DetectedOn, d.DurationDays, d.SessionCount, d.Metric, recs,
+	).Scan(&id)
+	return id, err
+}
+
+// ListSince returns detections on or after the given date, newest first.
+func (s *PlateauStore) ListSince(ctx context.Context, sinceDate string) ([]domain.PlateauDetection, error) {
+	const query = `
+		SELECT id, plateau_type, subject, detected_on, duration_days, session_count, metric, recommendations
+		FROM plateau_detections
+		WHERE detected_on >= $1
+		ORDER BY detected_on DESC, id DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, sinceDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	detections := make([]domain.PlateauDetection, 0)
+	for rows.Next() {
+		var d domain.PlateauDetection
+		var recs []byte
+		if err := rows.Scan(&d.ID, &d.Type, &d.Subject, &d.DetectedOn, &d.DurationDays, &d.SessionCount, &d.Metric, &recs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(recs, &d.Recommendations); err != nil {
+			return nil, err
+		}
+		detections = append(detections, d)
+	}
+	return detections, rows.Err()
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -38,6 +39,9 @@ func (s *ProfileStore) Get(ctx context.Context) (*domain.UserProfile, error) {
 			COALESCE(tdee_source, 'formula'), COALESCE(manual_tdee, 0),
 			COALESCE(recalibration_tolerance, 3),
 			COALESCE(fasting_protocol, 'standard'), COALESCE(eating_window_start, '08:00'), COALESCE(eating_window_end, '20:00'),
+			weight_trend_method, weight_trend_window,
+			adherence_calorie_tolerance, adherence_protein_floor, adherence_fat_minimum, adherence_carb_tolerance,
+			log_lock_days,
 			created_at, updated_at
 		FROM user_profile
 		WHERE id = 1
@@ -65,6 +69,9 @@ func (s *ProfileStore) Get(ctx context.Context) (*domain.UserProfile, error) {
 		&p.TDEESource, &p.ManualTDEE,
 		&p.RecalibrationTolerance,
 		&p.FastingProtocol, &p.EatingWindowStart, &p.EatingWindowEnd,
+		&p.WeightSmoothing.Method, &p.WeightSmoothing.Window,
+		&p.AdherenceTolerances.Calories, &p.AdherenceTolerances.ProteinFloor, &p.AdherenceTolerances.FatMinimum, &p.AdherenceTolerances.Carbs,
+		&p.LogLockDays,
 		&createdAt, &updatedAt,
 	)
 
@@ -109,6 +116,9 @@ func (s *ProfileStore) Upsert(ctx context.Context, p *domain.UserProfile) error
 			tdee_source, manual_tdee,
 			recalibration_tolerance,
 			fasting_protocol, eating_window_start, eating_window_end,
+			weight_trend_method, weight_trend_window,
+			adherence_calorie_tolerance, adherence_protein_floor, adherence_fat_minimum, adherence_carb_tolerance,
+			log_lock_days,
 			created_at, updated_at
 		) VALUES (
 			1, $1, $2, $3, $4,
@@ -122,7 +132,10 @@ func (s *ProfileStore) Upsert(ctx context.Context, p *domain.UserProfile) error
 			$25, $26,
 			$27,
 			$28, $29, $30,
-			$31, $32
+			$31, $32,
+			$33, $34, $35, $36,
+			$37,
+			$38, $39
 		)
 		ON CONFLICT(id) DO UPDATE SET
 			height_cm = excluded.height_cm,
@@ -155,6 +168,13 @@ func (s *ProfileStore) Upsert(ctx context.Context, p *domain.UserProfile) error
 			fasting_protocol = excluded.fasting_protocol,
 			eating_window_start = excluded.eating_window_start,
 			eating_window_end = excluded.eating_window_end,
+			weight_trend_method = excluded.weight_trend_method,
+			weight_trend_window = excluded.weight_trend_window,
+			adherence_calorie_tolerance = excluded.adherence_calorie_tolerance,
+			adherence_protein_floor = excluded.adherence_protein_floor,
+			adherence_fat_minimum = excluded.adherence_fat_minimum,
+			adherence_carb_tolerance = excluded.adherence_carb_tolerance,
+			log_lock_days = excluded.log_lock_days,
 			updated_at = excluded.updated_at
 	`
 
@@ -168,6 +188,8 @@ func (s *ProfileStore) Upsert(ctx context.Context, p *domain.UserProfile) error
 		bodyFatPercent = p.BodyFatPercent
 	}
 
+	tolerances := p.AdherenceTolerances.WithDefaults()
+
 	now := time.Now()
 	_, err := s.db.ExecContext(ctx, query,
 		p.HeightCM, p.BirthDate.Format("2006-01-02"), p.Sex, p.Goal,
@@ -181,6 +203,9 @@ func (s *ProfileStore) Upsert(ctx context.Context, p *domain.UserProfile) error
 		p.TDEESource, p.ManualTDEE,
 		p.RecalibrationTolerance,
 		p.FastingProtocol, p.EatingWindowStart, p.EatingWindowEnd,
+		p.WeightSmoothing.EffectiveMethod(), p.WeightSmoothing.Window,
+		tolerances.Calories, tolerances.ProteinFloor, tolerances.FatMinimum, tolerances.Carbs,
+		p.LogLockDays,
 		now, now,
 	)
 
@@ -192,3 +217,121 @@ func (s *ProfileStore) Delete(ctx context.Context) error {
 	_, err := s.db.ExecContext(ctx, "DELETE FROM user_profile WHERE id = 1")
 	return err
 }
+
+// GetEquipment returns the equipment inventory and travel override stored on the profile.
+// Returns ErrProfileNotFound if no profile exists.
+func (s *ProfileStore) GetEquipment(ctx context.Context) (*domain.EquipmentProfile, error) {
+	var (
+		equipmentJSON []byte
+		travelJSON    []byte
+		travelUntil   sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx, `
+		SELECT equipment, travel_equipment, travel_until
+		FROM user_profile
+		WHERE id = 1
+	`).Scan(&equipmentJSON, &travelJSON, &travelUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProfileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ep := &domain.EquipmentProfile{Inventory: []domain.EquipmentType{}}
+	if err := json.Unmarshal(equipmentJSON, &ep.Inventory); err != nil {
+		return nil, err
+	}
+	if travelUntil.Valid && travelJSON != nil {
+		travel := &domain.TravelModeOverride{Until: travelUntil.String}
+		if err := json.Unmarshal(travelJSON, &travel.Equipment); err != nil {
+			return nil, err
+		}
+		ep.TravelMode = travel
+	}
+	return ep, nil
+}
+
+// UpdateEquipment replaces the profile's equipment inventory and travel override.
+// A nil TravelMode clears the override.
+// Returns ErrProfileNotFound if no profile exists.
+func (s *ProfileStore) UpdateEquipment(ctx context.Context, ep domain.EquipmentProfile) error {
+	inventory := ep.Inventory
+	if inventory == nil {
+		inventory = []domain.EquipmentType{}
+	}
+	equipmentJSON, err := json.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+
+	var travelJSON []byte
+	var travelUntil sql.NullString
+	if ep.TravelMode != nil {
+		travel := ep.TravelMode.Equipment
+		if travel == nil {
+			travel = []domain.EquipmentType{}
+		}
+		if travelJSON, err = json.Marshal(travel); err != nil {
+			return err
+		}
+		travelUntil = sql.NullString{String: ep.TravelMode.Until, Valid: true}
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE user_profile
+		SET equipment = $1, travel_equipment = $2, travel_until = $3
+		WHERE id = 1
+	`, equipmentJSON, travelJSON, travelUntil)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// GetTrainingExperience returns the experience model stored on the profile,
+// or nil if it hasn't been derived yet.
+// Returns ErrProfileNotFound if no profile exists.
+func (s *ProfileStore) GetTrainingExperience(ctx context.Context) (*domain.TrainingExperience, error) {
+	var experienceJSON []byte
+	err := s.db.QueryRowContext(ctx, `SELECT training_experience FROM user_profile WHERE id = 1`).Scan(&experienceJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProfileNotFound
+	}
+	if err != nil || experienceJSON == nil {
+		return nil, err
+	}
+	var exp domain.TrainingExperience
+	if err := json.Unmarshal(experienceJSON, &exp); err != nil {
+		return nil, err
+	}
+	return &exp, nil
+}
+
+// UpdateTrainingExperience stores the derived experience model on the profile.
+// Returns ErrProfileNotFound if no profile exists.
+func (s *ProfileStore) UpdateTrainingExperience(ctx context.Context, exp domain.TrainingExperience) error {
+	experienceJSON, err := json.Marshal(exp)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE user_profile SET training_experience = $1 WHERE id = 1`, experienceJSON)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrProfileNotFound
+	}
+	return nil
+}
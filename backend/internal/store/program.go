@@ -221,11 +221,11 @@ func (s *TrainingProgramStore) GetByID(ctx context.Context, id int64) (*domain.T
 	program.UpdatedAt = updatedAt
 
 	// Load weeks and days
-	weeks, err := s.getWeeks(ctx, program.ID)
+	weeksByProgram, err := s.loadWeeks(ctx, []int64{program.ID})
 	if err != nil {
 		return nil, err
 	}
-	program.Weeks = weeks
+	program.Weeks = weeksByProgram[program.ID]
 
 	return &program, nil
 }
@@ -326,6 +326,29 @@ func (s *TrainingProgramStore) List(ctx context.Context, filters ProgramFilters)
 	return programs, nil
 }
 
+// ListWithWeeks retrieves training programs like List, with each program's
+// weeks and days loaded in one additional query.
+func (s *TrainingProgramStore) ListWithWeeks(ctx context.Context, filters ProgramFilters) ([]*domain.TrainingProgram, error) {
+	programs, err := s.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(programs))
+	for i, p := range programs {
+		ids[i] = p.ID
+	}
+	weeksByProgram, err := s.loadWeeks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range programs {
+		p.Weeks = weeksByProgram[p.ID]
+	}
+
+	return programs, nil
+}
+
 // ProgramFilters contains optional filters for listing programs.
 type ProgramFilters struct {
 	Difficulty string
@@ -400,25 +423,39 @@ func (s *TrainingProgramStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-// getWeeks retrieves all weeks for a program with their days.
-func (s *TrainingProgramStore) getWeeks(ctx context.Context, programID int64) ([]domain.ProgramWeek, error) {
+// loadWeeks retrieves the weeks and days of the given programs in a single
+// joined query, keyed by program ID. Weeks are ordered by week number and
+// days by day number; a week without days has nil Days.
+func (s *TrainingProgramStore) loadWeeks(ctx context.Context, programIDs []int64) (map[int64][]domain.ProgramWeek, error) {
+	weeksByProgram := make(map[int64][]domain.ProgramWeek, len(programIDs))
+	if len(programIDs) == 0 {
+		return weeksByProgram, nil
+	}
+
 	const query = `
-		SELECT id, program_id, week_number, label, is_deload, volume_scale, intensity_scale
-		FROM program_weeks
-		WHERE program_id = $1
-		ORDER BY week_number ASC
+		SELECT
+			w.id, w.program_id, w.week_number, w.label, w.is_deload, w.volume_scale, w.intensity_scale,
+			d.id, COALESCE(d.day_number, 0), COALESCE(d.label, ''), COALESCE(d.training_type, ''),
+			COALESCE(d.duration_min, 0), COALESCE(d.load_score, 0), COALESCE(d.nutrition_day, ''),
+			COALESCE(d.notes, ''), COALESCE(d.progression_config, ''), COALESCE(d.session_exercises, '')
+		FROM program_weeks w
+		LEFT JOIN program_days d ON d.week_id = w.id
+		WHERE w.program_id = ANY($1)
+		ORDER BY w.program_id, w.week_number ASC, d.day_number ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, programID)
+	rows, err := s.db.QueryContext(ctx, query, programIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	// First pass: collect all weeks
-	var weeks []domain.ProgramWeek
 	for rows.Next() {
 		var week domain.ProgramWeek
+		var day domain.ProgramDay
+		var dayID sql.NullInt64
+		var progressionJSON string
+		var sessionExercisesJSON string
 		err := rows.Scan(
 			&week.ID,
 			&week.ProgramID,
@@ -427,57 +464,7 @@ func (s *TrainingProgramStore) getWeeks(ctx context.Context, programID int64) ([
 			&week.IsDeload,
 			&week.VolumeScale,
 			&week.IntensityScale,
-		)
-		if err != nil {
-			return nil, err
-		}
-		weeks = append(weeks, week)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	// Close rows before making nested queries
-	rows.Close()
-
-	// Second pass: load days for each week
-	for i := range weeks {
-		days, err := s.getDays(ctx, weeks[i].ID)
-		if err != nil {
-			return nil, err
-		}
-		weeks[i].Days = days
-	}
-
-	return weeks, nil
-}
-
-// getDays retrieves all days for a week.
-func (s *TrainingProgramStore) getDays(ctx context.Context, weekID int64) ([]domain.ProgramDay, error) {
-	const query = `
-		SELECT id, week_id, day_number, label, training_type, duration_min,
-			   load_score, nutrition_day, COALESCE(notes, ''), COALESCE(progression_config, ''),
-			   COALESCE(session_exercises, '')
-		FROM program_days
-		WHERE week_id = $1
-		ORDER BY day_number ASC
-	`
-
-	rows, err := s.db.QueryContext(ctx, query, weekID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var days []domain.ProgramDay
-	for rows.Next() {
-		var day domain.ProgramDay
-		var progressionJSON string
-		var sessionExercisesJSON string
-		err := rows.Scan(
-			&day.ID,
-			&day.WeekID,
+			&dayID,
 			&day.DayNumber,
 			&day.Label,
 			&day.TrainingType,
@@ -492,28 +479,42 @@ func (s *TrainingProgramStore) getDays(ctx context.Context, weekID int64) ([]dom
 			return nil, err
 		}
 
-		if progressionJSON != "" {
-			var pp domain.ProgressionPattern
-			if err := json.Unmarshal([]byte(progressionJSON), &pp); err == nil {
-				day.ProgressionPattern = &pp
-			}
+		// Rows arrive grouped by week, so a new week starts whenever the ID changes
+		weeks := weeksByProgram[week.ProgramID]
+		if len(weeks) == 0 || weeks[len(weeks)-1].ID != week.ID {
+			weeks = append(weeks, week)
 		}
 
-		if sessionExercisesJSON != "" {
-			var ses []domain.SessionExercise
-			if err := json.Unmarshal([]byte(sessionExercisesJSON), &ses); err == nil {
-				day.SessionExercises = ses
+		if dayID.Valid {
+			day.ID = dayID.Int64
+			day.WeekID = week.ID
+
+			if progressionJSON != "" {
+				var pp domain.ProgressionPattern
+				if err := json.Unmarshal([]byte(progressionJSON), &pp); err == nil {
+					day.ProgressionPattern = &pp
+				}
+			}
+
+			if sessionExercisesJSON != "" {
+				var ses []domain.SessionExercise
+				if err := json.Unmarshal([]byte(sessionExercisesJSON), &ses); err == nil {
+					day.SessionExercises = ses
+				}
 			}
+
+			last := &weeks[len(weeks)-1]
+			last.Days = append(last.Days, day)
 		}
 
-		days = append(days, day)
+		weeksByProgram[week.ProgramID] = weeks
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return days, nil
+	return weeksByProgram, nil
 }
 
 // =============================================================================
@@ -542,8 +543,8 @@ func (s *TrainingProgramStore) CreateInstallation(ctx context.Context, installat
 	const query = `
 		INSERT INTO program_installations (
 			program_id, start_date, week_day_mapping, current_week, status,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			autoregulation_bound, keep_deload_day_types, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 
@@ -555,6 +556,8 @@ func (s *TrainingProgramStore) CreateInstallation(ctx context.Context, installat
 		string(mappingJSON),
 		installation.CurrentWeek,
 		installation.Status,
+		installation.AutoregulationBound,
+		installation.KeepDeloadDayTypes,
 		now,
 		now,
 	).Scan(&id)
@@ -569,7 +572,7 @@ func (s *TrainingProgramStore) CreateInstallation(ctx context.Context, installat
 func (s *TrainingProgramStore) GetActiveInstallation(ctx context.Context) (*domain.ProgramInstallation, error) {
 	const query = `
 		SELECT id, program_id, start_date, week_day_mapping, current_week, status,
-			   created_at, updated_at
+			   reschedules, autoregulation_bound, keep_deload_day_types, created_at, updated_at
 		FROM program_installations
 		WHERE status = 'active'
 		LIMIT 1
@@ -578,6 +581,7 @@ func (s *TrainingProgramStore) GetActiveInstallation(ctx context.Context) (*doma
 	var installation domain.ProgramInstallation
 	var startDateStr string
 	var mappingJSON string
+	var reschedulesJSON string
 
 	err := s.db.QueryRowContext(ctx, query).Scan(
 		&installation.ID,
@@ -586,6 +590,9 @@ func (s *TrainingProgramStore) GetActiveInstallation(ctx context.Context) (*doma
 		&mappingJSON,
 		&installation.CurrentWeek,
 		&installation.Status,
+		&reschedulesJSON,
+		&installation.AutoregulationBound,
+		&installation.KeepDeloadDayTypes,
 		&installation.CreatedAt,
 		&installation.UpdatedAt,
 	)
@@ -601,6 +608,9 @@ func (s *TrainingProgramStore) GetActiveInstallation(ctx context.Context) (*doma
 	if err := json.Unmarshal([]byte(mappingJSON), &installation.WeekDayMapping); err != nil {
 		installation.WeekDayMapping = []int{}
 	}
+	if err := json.Unmarshal([]byte(reschedulesJSON), &installation.Reschedules); err != nil {
+		installation.Reschedules = nil
+	}
 
 	// Load the associated program
 	program, err := s.GetByID(ctx, installation.ProgramID)
@@ -616,7 +626,7 @@ func (s *TrainingProgramStore) GetActiveInstallation(ctx context.Context) (*doma
 func (s *TrainingProgramStore) GetInstallationByID(ctx context.Context, id int64) (*domain.ProgramInstallation, error) {
 	const query = `
 		SELECT id, program_id, start_date, week_day_mapping, current_week, status,
-			   created_at, updated_at
+			   reschedules, autoregulation_bound, keep_deload_day_types, created_at, updated_at
 		FROM program_installations
 		WHERE id = $1
 	`
@@ -624,6 +634,7 @@ func (s *TrainingProgramStore) GetInstallationByID(ctx context.Context, id int64
 	var installation domain.ProgramInstallation
 	var startDateStr string
 	var mappingJSON string
+	var reschedulesJSON string
 
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
 		&installation.ID,
@@ -632,6 +643,9 @@ func (s *TrainingProgramStore) GetInstallationByID(ctx context.Context, id int64
 		&mappingJSON,
 		&installation.CurrentWeek,
 		&installation.Status,
+		&reschedulesJSON,
+		&installation.AutoregulationBound,
+		&installation.KeepDeloadDayTypes,
 		&installation.CreatedAt,
 		&installation.UpdatedAt,
 	)
@@ -647,6 +661,9 @@ func (s *TrainingProgramStore) GetInstallationByID(ctx context.Context, id int64
 	if err := json.Unmarshal([]byte(mappingJSON), &installation.WeekDayMapping); err != nil {
 		installation.WeekDayMapping = []int{}
 	}
+	if err := json.Unmarshal([]byte(reschedulesJSON), &installation.Reschedules); err != nil {
+		installation.Reschedules = nil
+	}
 
 	// Load the associated program
 	program, err := s.GetByID(ctx, installation.ProgramID)
@@ -706,6 +723,144 @@ func (s *TrainingProgramStore) UpdateInstallationWeek(ctx context.Context, id in
 	return nil
 }
 
+// UpdateInstallationReschedules replaces the recorded reschedules of a program installation.
+func (s *TrainingProgramStore) UpdateInstallationReschedules(ctx context.Context, id int64, reschedules []domain.ProgramReschedule) error {
+	if reschedules == nil {
+		reschedules = []domain.ProgramReschedule{}
+	}
+	reschedulesJSON, err := json.Marshal(reschedules)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE program_installations
+		SET reschedules = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, string(reschedulesJSON), time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInstallationNotFound
+	}
+
+	return nil
+}
+
+// UpdateInstallationAutoregulation sets the readiness scaling bound of a program installation (0 = off).
+func (s *TrainingProgramStore) UpdateInstallationAutoregulation(ctx context.Context, id int64, bound float64) error {
+	const query = `
+		UPDATE program_installations
+		SET autoregulation_bound = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, bound, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInstallationNotFound
+	}
+
+	return nil
+}
+
+// UpdateInstallationDeloadDayTypes sets whether a program installation's deload
+// weeks keep the program's nutrition days.
+func (s *TrainingProgramStore) UpdateInstallationDeloadDayTypes(ctx context.Context, id int64, keep bool) error {
+	const query = `
+		UPDATE program_installations
+		SET keep_deload_day_types = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, keep, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInstallationNotFound
+	}
+
+	return nil
+}
+
+// UpsertSessionAutoregulation records the readiness scaling applied to a program day.
+// A later readiness reading for the same day replaces the earlier record.
+func (s *TrainingProgramStore) UpsertSessionAutoregulation(ctx context.Context, installationID int64, a domain.SessionAutoregulation) error {
+	const query = `
+		INSERT INTO program_session_autoregulation (
+			installation_id, session_date, week_number, day_number, readiness_score, scale,
+			base_load_score, base_duration_min, load_score, duration_min, recorded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (installation_id, week_number, day_number) DO UPDATE SET
+			session_date = EXCLUDED.session_date,
+			readiness_score = EXCLUDED.readiness_score,
+			scale = EXCLUDED.scale,
+			base_load_score = EXCLUDED.base_load_score,
+			base_duration_min = EXCLUDED.base_duration_min,
+			load_score = EXCLUDED.load_score,
+			duration_min = EXCLUDED.duration_min,
+			recorded_at = EXCLUDED.recorded_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		installationID, a.Date, a.WeekNumber, a.DayNumber, a.ReadinessScore, a.Scale,
+		a.BaseLoadScore, a.BaseDurationMin, a.LoadScore, a.DurationMin, time.Now(),
+	)
+	return err
+}
+
+// ListSessionAutoregulations returns the readiness scaling recorded for an installation's days, oldest first.
+func (s *TrainingProgramStore) ListSessionAutoregulations(ctx context.Context, installationID int64) ([]domain.SessionAutoregulation, error) {
+	const query = `
+		SELECT session_date, week_number, day_number, readiness_score, scale,
+			   base_load_score, base_duration_min, load_score, duration_min
+		FROM program_session_autoregulation
+		WHERE installation_id = $1
+		ORDER BY session_date, day_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, installationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]domain.SessionAutoregulation, 0)
+	for rows.Next() {
+		var a domain.SessionAutoregulation
+		if err := rows.Scan(
+			&a.Date, &a.WeekNumber, &a.DayNumber, &a.ReadinessScore, &a.Scale,
+			&a.BaseLoadScore, &a.BaseDurationMin, &a.LoadScore, &a.DurationMin,
+		); err != nil {
+			return nil, err
+		}
+		records = append(records, a)
+	}
+
+	return records, rows.Err()
+}
+
 // DeleteInstallation removes a program installation.
 func (s *TrainingProgramStore) DeleteInstallation(ctx context.Context, id int64) error {
 	result, err := s.db.ExecContext(ctx, "DELETE FROM program_installations WHERE id = $1", id)
@@ -729,7 +884,7 @@ func (s *TrainingProgramStore) DeleteInstallation(ctx context.Context, id int64)
 func (s *TrainingProgramStore) GetActiveInstallationForProgram(ctx context.Context, programID int64) (*domain.ProgramInstallation, error) {
 	const query = `
 		SELECT id, program_id, start_date, week_day_mapping, current_week, status,
-			   created_at, updated_at
+			   reschedules, autoregulation_bound, keep_deload_day_types, created_at, updated_at
 		FROM program_installations
 		WHERE program_id = $1 AND status = 'active'
 		LIMIT 1
@@ -738,6 +893,7 @@ func (s *TrainingProgramStore) GetActiveInstallationForProgram(ctx context.Conte
 	var installation domain.ProgramInstallation
 	var startDateStr string
 	var mappingJSON string
+	var reschedulesJSON string
 
 	err := s.db.QueryRowContext(ctx, query, programID).Scan(
 		&installation.ID,
@@ -746,6 +902,9 @@ func (s *TrainingProgramStore) GetActiveInstallationForProgram(ctx context.Conte
 		&mappingJSON,
 		&installation.CurrentWeek,
 		&installation.Status,
+		&reschedulesJSON,
+		&installation.AutoregulationBound,
+		&installation.KeepDeloadDayTypes,
 		&installation.CreatedAt,
 		&installation.UpdatedAt,
 	)
@@ -761,6 +920,9 @@ func (s *TrainingProgramStore) GetActiveInstallationForProgram(ctx context.Conte
 	if err := json.Unmarshal([]byte(mappingJSON), &installation.WeekDayMapping); err != nil {
 		installation.WeekDayMapping = []int{}
 	}
+	if err := json.Unmarshal([]byte(reschedulesJSON), &installation.Reschedules); err != nil {
+		installation.Reschedules = nil
+	}
 
 	return &installation, nil
 }
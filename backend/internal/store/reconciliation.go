@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"victus/internal/domain"
+)
+
+// ReconciliationStore handles persistence for late-data reconciliation runs
+// and the debrief regeneration flags they raise.
+type ReconciliationStore struct {
+	db DBTX
+}
+
+// NewReconciliationStore creates a new ReconciliationStore.
+func NewReconciliationStore(db DBTX) *ReconciliationStore {
+	return &ReconciliationStore{db: db}
+}
+
+// RecordRun stores the outcome of a reconciliation pass.
+func (s *ReconciliationStore) RecordRun(ctx context.Context, r domain.ReconciliationResult) error {
+	fields, err := json.Marshal(r.Fields)
+	if err != nil {
+		return err
+	}
+	cns, err := json.Marshal(r.CNSReclassified)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO daily_log_reconciliations (log_date, fields, cns_reclassified, flux_recalculated)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = s.db.ExecContext(ctx, query, r.Date, fields, cns, r.FluxRecalculated)
+	return err
+}
+
+// MarkDebriefStale flags a week's debrief for regeneration.
+// Re-flagging an already regenerated week clears its regenerated timestamp.
+func (s *ReconciliationStore) MarkDebriefStale(ctx context.Context, weekStartDate, reason string) error {
+	const query = `
+		INSERT INTO debrief_regeneration_flags (week_start_date, reason)
+		VALUES ($1, $2)
+		ON CONFLICT (week_start_date) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			flagged_at = NOW(),
+			regenerated_at = NULL
+	`
+	_, err := s.db.ExecContext(ctx, query, weekStartDate, reason)
+	return err
+}
+
+// ClearDebriefStale marks a week's debrief as regenerated. No-op if not flagged.
+func (s *ReconciliationStore) ClearDebriefStale(ctx context.Context, weekStartDate string) error {
+	const query = `
+		UPDATE debrief_regeneration_flags
+		SET regenerated_at = NOW()
+		WHERE week_start_date = $1 AND regenerated_at IS NULL
+	`
+	_, err := s.db.ExecContext(ctx, query, weekStartDate)
+	return err
+}
+
+// IsDebriefStale reports whether a week's debrief is awaiting regeneration.
+func (s *ReconciliationStore) IsDebriefStale(ctx context.Context, weekStartDate string) (bool, error) {
+	const query = `
+		SELECT EXISTS(
+			SELECT 1 FROM debrief_regeneration_flags
+			WHERE week_start_date = $1 AND regenerated_at IS NULL
+		)
+	`
+	var stale bool
+	err := s.db.QueryRowContext(ctx, query, weekStartDate).Scan(&stale)
+	return stale, err
+}
+
+// ListStaleDebriefs returns weeks awaiting debrief regeneration, newest first.
+func (s *ReconciliationStore) ListStaleDebriefs(ctx context.Context) ([]domain.StaleDebrief, error) {
+	const query = `
+		SELECT week_start_date, reason, flagged_at
+		FROM debrief_regeneration_flags
+		WHERE regenerated_at IS NULL
+		ORDER BY week_start_date DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stale := make([]domain.StaleDebrief, 0)
+	for rows.Next() {
+		var d domain.StaleDebrief
+		if err := rows.Scan(&d.WeekStartDate, &d.Reason, &d.FlaggedAt); err != nil {
+			return nil, err
+		}
+		stale = append(stale, d)
+	}
+	return stale, rows.Err()
+}
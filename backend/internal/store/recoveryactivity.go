@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrRecoveryActivityNotFound is returned when a recovery activity doesn't exist.
+var ErrRecoveryActivityNotFound = errors.New("recovery activity not found")
+
+// RecoveryActivityStore handles persistence for logged recovery activities.
+type RecoveryActivityStore struct {
+	db DBTX
+}
+
+// NewRecoveryActivityStore creates a new RecoveryActivityStore.
+func NewRecoveryActivityStore(db DBTX) *RecoveryActivityStore {
+	return &RecoveryActivityStore{db: db}
+}
+
+// Create stores an activity and sets its ID and CreatedAt.
+func (s *RecoveryActivityStore) Create(ctx context.Context, a *domain.RecoveryActivity) error {
+	const query = `
+		INSERT INTO recovery_activities (activity_date, activity_type, duration_min, intensity)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, a.Date, string(a.Type), a.DurationMin, a.Intensity).Scan(&a.ID, &a.CreatedAt)
+}
+
+// ListByDateRange returns activities in a date range (inclusive), by date and
+// logging order.
+func (s *RecoveryActivityStore) ListByDateRange(ctx context.Context, startDate, endDate string) ([]domain.RecoveryActivity, error) {
+	const query = `
+		SELECT id, activity_date, activity_type, duration_min, intensity, created_at
+		FROM recovery_activities
+		WHERE activity_date >= $1 AND activity_date <= $2
+		ORDER BY activity_date, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := make([]domain.RecoveryActivity, 0)
+	for rows.Next() {
+		var a domain.RecoveryActivity
+		if err := rows.Scan(&a.ID, &a.Date, &a.Type, &a.DurationMin, &a.Intensity, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		activities = append(activities, a)
+	}
+	return activities, rows.Err()
+}
+
+// Delete removes an activity.
+// Returns ErrRecoveryActivityNotFound if it doesn't exist.
+func (s *RecoveryActivityStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM recovery_activities WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRecoveryActivityNotFound
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"victus/internal/domain"
+)
+
+// RestoreColumn is a column of a restorable table as this database has it.
+type RestoreColumn struct {
+	Name     string
+	Type     string // e.g. "integer", "real", "timestamp without time zone"
+	Nullable bool
+}
+
+// RestoreValue is a text value bound for a column; nil is NULL. Postgres
+// casts it to the column's type, so an archive read as text restores as is.
+type RestoreValue struct {
+	Column RestoreColumn
+	Value  *string
+}
+
+// RestoreStore writes export archive rows back into the exported tables.
+// Table names come from domain.ExportTables and column names from the
+// database's catalog, never from the archive.
+type RestoreStore struct {
+	db DBTX
+}
+
+// NewRestoreStore creates a new RestoreStore.
+func NewRestoreStore(db DBTX) *RestoreStore {
+	return &RestoreStore{db: db}
+}
+
+// InTx runs fn in one transaction; the store's statements made with fn's
+// context join it.
+func (s *RestoreStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, s.db, fn)
+}
+
+// Columns returns a table's columns in definition order.
+func (s *RestoreStore) Columns(ctx context.Context, table domain.ExportTable) ([]RestoreColumn, error) {
+	if _, ok := exportQueries[table]; !ok {
+		return nil, errors.New("table is not restorable")
+	}
+	const query = `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull
+		FROM pg_attribute a
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`
+	rows, err := s.db.QueryContext(ctx, query, string(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []RestoreColumn
+	for rows.Next() {
+		var c RestoreColumn
+		if err := rows.Scan(&c.Name, &c.Type, &c.Nullable); err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+// FindRow returns the id of the row whose key columns equal the values, and
+// false when there is none. NULL keys match NULL.
+func (s *RestoreStore) FindRow(ctx context.Context, table domain.ExportTable, key []RestoreValue) (int64, bool, error) {
+	conds := make([]string, len(key))
+	args := make([]any, len(key))
+	for i, v := range key {
+		conds[i] = fmt.Sprintf("%s IS NOT DISTINCT FROM %s", quoteIdent(v.Column.Name), castParam(i+1, v.Column))
+		args[i] = textArg(v.Value)
+	}
+	query := fmt.Sprintf("SELECT id FROM %s WHERE %s ORDER BY id LIMIT 1", quoteIdent(string(table)), strings.Join(conds, " AND "))
+
+	var id int64
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// InsertRow inserts a row and returns its new id.
+func (s *RestoreStore) InsertRow(ctx context.Context, table domain.ExportTable, values []RestoreValue) (int64, error) {
+	cols := make([]string, len(values))
+	params := make([]string, len(values))
+	args := make([]any, len(values))
+	for i, v := range values {
+		cols[i] = quoteIdent(v.Column.Name)
+		params[i] = castParam(i+1, v.Column)
+		args[i] = textArg(v.Value)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING id",
+		quoteIdent(string(table)), strings.Join(cols, ", "), strings.Join(params, ", "))
+
+	var id int64
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	return id, err
+}
+
+// UpdateRow sets the row's columns to the values. With fillNullsOnly, only
+// columns that are NULL in the row are set.
+func (s *RestoreStore) UpdateRow(ctx context.Context, table domain.ExportTable, id int64, values []RestoreValue, fillNullsOnly bool) error {
+	if len(values) == 0 {
+		return nil
+	}
+	sets := make([]string, len(values))
+	args := make([]any, len(values), len(values)+1)
+	for i, v := range values {
+		col, param := quoteIdent(v.Column.Name), castParam(i+1, v.Column)
+		if fillNullsOnly {
+			sets[i] = fmt.Sprintf("%s = COALESCE(%s, %s)", col, col, param)
+		} else {
+			sets[i] = fmt.Sprintf("%s = %s", col, param)
+		}
+		args[i] = textArg(v.Value)
+	}
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d",
+		quoteIdent(string(table)), strings.Join(sets, ", "), len(args))
+
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func castParam(n int, c RestoreColumn) string {
+	return fmt.Sprintf("CAST($%d::text AS %s)", n, c.Type)
+}
+
+func textArg(v *string) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
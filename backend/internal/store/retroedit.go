@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// RetroEditStore keeps the audit trail of edits to locked days.
+type RetroEditStore struct {
+	db DBTX
+}
+
+// NewRetroEditStore creates a new RetroEditStore.
+func NewRetroEditStore(db DBTX) *RetroEditStore {
+	return &RetroEditStore{db: db}
+}
+
+// Create stores a newly opened retro-edit and sets its ID.
+func (s *RetroEditStore) Create(ctx context.Context, e *domain.RetroEdit) error {
+	actions, err := json.Marshal(e.Actions)
+	if err != nil {
+		return err
+	}
+	return s.db.QueryRowContext(ctx, `
+		INSERT INTO retro_edits (log_date, reason, opened_at, expires_at, actions)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, e.Date, e.Reason, e.OpenedAt, e.ExpiresAt, actions).Scan(&e.ID)
+}
+
+// GetOpen returns the latest retro-edit for date still open at now, or nil
+// if there is none.
+func (s *RetroEditStore) GetOpen(ctx context.Context, date string, now time.Time) (*domain.RetroEdit, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, log_date, reason, opened_at, expires_at, actions
+		FROM retro_edits
+		WHERE log_date = $1 AND expires_at > $2
+		ORDER BY opened_at DESC
+		LIMIT 1
+	`, date, now)
+	e, err := scanRetroEdit(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return e, err
+}
+
+// AppendAction adds a change to a retro-edit's audit trail.
+func (s *RetroEditStore) AppendAction(ctx context.Context, id int64, action domain.RetroEditAction) error {
+	raw, err := json.Marshal([]domain.RetroEditAction{action})
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE retro_edits SET actions = actions || $2::jsonb WHERE id = $1`, id, raw,
+	)
+	return err
+}
+
+// ListByDateRange returns the retro-edits for days from startDate to
+// endDate (inclusive), oldest first.
+func (s *RetroEditStore) ListByDateRange(ctx context.Context, startDate, endDate string) ([]domain.RetroEdit, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, log_date, reason, opened_at, expires_at, actions
+		FROM retro_edits
+		WHERE log_date >= $1 AND log_date <= $2
+		ORDER BY log_date, opened_at
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edits := []domain.RetroEdit{}
+	for rows.Next() {
+		e, err := scanRetroEdit(rows)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, *e)
+	}
+	return edits, rows.Err()
+}
+
+type retroEditScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRetroEdit(row retroEditScanner) (*domain.RetroEdit, error) {
+	var (
+		e       domain.RetroEdit
+		actions []byte
+	)
+	if err := row.Scan(&e.ID, &e.Date, &e.Reason, &e.OpenedAt, &e.ExpiresAt, &actions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actions, &e.Actions); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+
+	"victus/internal/domain"
+)
+
+// SatietyStore handles persistence for per-meal hunger ratings.
+type SatietyStore struct {
+	db DBTX
+}
+
+// NewSatietyStore creates a new SatietyStore.
+func NewSatietyStore(db DBTX) *SatietyStore {
+	return &SatietyStore{db: db}
+}
+
+// UpsertMealHunger stores the hunger rating for a meal, replacing any earlier one.
+func (s *SatietyStore) UpsertMealHunger(ctx context.Context, h domain.MealHunger) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO meal_hunger (log_date, meal, hunger, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (log_date, meal) DO UPDATE SET
+			hunger = EXCLUDED.hunger,
+			updated_at = NOW()
+	`, h.Date, string(h.Meal), h.Hunger)
+	return err
+}
+
+// ListMealHungerSince returns the ratings for meals on or after since
+// (YYYY-MM-DD), oldest first.
+func (s *SatietyStore) ListMealHungerSince(ctx context.Context, since string) ([]domain.MealHunger, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT log_date, meal, hunger
+		FROM meal_hunger
+		WHERE log_date >= $1
+		ORDER BY log_date, meal
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := []domain.MealHunger{}
+	for rows.Next() {
+		var h domain.MealHunger
+		var meal string
+		if err := rows.Scan(&h.Date, &meal, &h.Hunger); err != nil {
+			return nil, err
+		}
+		h.Meal = domain.MealName(meal)
+		ratings = append(ratings, h)
+	}
+	return ratings, rows.Err()
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"victus/internal/domain"
+)
+
+// searchHeadline is the ts_headline option string for snippets: plain text,
+// a short excerpt around the match.
+const searchHeadline = `'StartSel="",StopSel="",MaxWords=20,MinWords=8'`
+
+// searchSources holds one SELECT per result kind. Each matches against the
+// query in the "q" CTE and yields the same columns: kind, id, title, snippet,
+// log_date, parent_id and rank. The tsvector expressions must stay identical
+// to the GIN indexes in the migrations or the planner won't use them.
+var searchSources = map[domain.SearchKind]string{
+	domain.SearchKindNote: `
+		SELECT 'note', dl.log_date, 'Day note',
+			ts_headline('english', dl.notes, q.query, ` + searchHeadline + `),
+			dl.log_date, 0::BIGINT,
+			ts_rank(to_tsvector('english', coalesce(dl.notes, '')), q.query)
+		FROM daily_logs dl, q
+		WHERE to_tsvector('english', coalesce(dl.notes, '')) @@ q.query`,
+
+	domain.SearchKindSession: `
+		SELECT 'session', ts.id::TEXT,
+			initcap(ts.training_type) || CASE WHEN ts.is_planned THEN ' (planned)' ELSE '' END,
+			ts_headline('english', ts.notes, q.query, ` + searchHeadline + `),
+			dl.log_date, 0::BIGINT,
+			ts_rank(to_tsvector('english', coalesce(ts.notes, '')), q.query)
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id, q
+		WHERE to_tsvector('english', coalesce(ts.notes, '')) @@ q.query`,
+
+	// Template programs repeat day labels every week; keep each label's first week
+	domain.SearchKindProgramDay: `
+		SELECT kind, id, title, snippet, log_date, parent_id, rank FROM (
+			SELECT DISTINCT ON (tp.id, pd.label)
+				'program_day' AS kind, pd.id::TEXT AS id, pd.label AS title,
+				tp.name || ' - Week ' || pw.week_number || ', Day ' || pd.day_number AS snippet,
+				'' AS log_date, tp.id::BIGINT AS parent_id,
+				ts_rank(to_tsvector('english', pd.label || ' ' || coalesce(pd.notes, '')), q.query) AS rank
+			FROM program_days pd
+			JOIN program_weeks pw ON pw.id = pd.week_id
+			JOIN training_programs tp ON tp.id = pw.program_id, q
+			WHERE to_tsvector('english', pd.label || ' ' || coalesce(pd.notes, '')) @@ q.query
+			ORDER BY tp.id, pd.label, pw.week_number, pd.day_number
+		) program_days_found`,
+
+	domain.SearchKindSessionTemplate: `
+		SELECT 'session_template', st.id::TEXT, st.name,
+			CASE WHEN st.notes = '' THEN initcap(st.training_type) || ', ' || st.duration_min || ' min'
+				ELSE ts_headline('english', st.notes, q.query, ` + searchHeadline + `) END,
+			'', 0::BIGINT,
+			ts_rank(to_tsvector('english', st.name || ' ' || st.notes), q.query)
+		FROM session_templates st, q
+		WHERE to_tsvector('english', st.name || ' ' || st.notes) @@ q.query`,
+
+	domain.SearchKindFood: `
+		SELECT 'food', fr.id::TEXT, fr.food_item, replace(fr.category, '_', ' '),
+			'', 0::BIGINT,
+			ts_rank(to_tsvector('english', fr.food_item), q.query)
+		FROM food_reference fr, q
+		WHERE to_tsvector('english', fr.food_item) @@ q.query`,
+
+	domain.SearchKindMovement: `
+		SELECT 'movement', m.id, m.name,
+			initcap(m.category) || coalesce(': ' || (SELECT string_agg(t, ', ') FROM jsonb_array_elements_text(m.tags) t), ''),
+			'', 0::BIGINT,
+			ts_rank(to_tsvector('english', m.name) || jsonb_to_tsvector('english', m.tags, '["string"]'), q.query)
+		FROM movements m, q
+		WHERE (to_tsvector('english', m.name) || jsonb_to_tsvector('english', m.tags, '["string"]')) @@ q.query`,
+
+	domain.SearchKindProgram: `
+		SELECT 'program', tp.id::TEXT, tp.name,
+			CASE WHEN coalesce(tp.description, '') = '' THEN initcap(tp.focus) || ', ' || tp.duration_weeks || ' weeks'
+				ELSE ts_headline('english', tp.description, q.query, ` + searchHeadline + `) END,
+			'', 0::BIGINT,
+			ts_rank(to_tsvector('english', tp.name || ' ' || coalesce(tp.description, '') || ' ' || tp.tags), q.query)
+		FROM training_programs tp, q
+		WHERE to_tsvector('english', tp.name || ' ' || coalesce(tp.description, '') || ' ' || tp.tags) @@ q.query`,
+
+	// Tagged days come from environment conditions and from sick/travel exemptions
+	domain.SearchKindDay: `
+		SELECT 'day', dl.log_date, 'Tagged day',
+			(SELECT string_agg(t, ', ') FROM jsonb_array_elements_text(dl.environment) t),
+			dl.log_date, 0::BIGINT,
+			ts_rank(jsonb_to_tsvector('english', dl.environment, '["string"]'), q.query)
+		FROM daily_logs dl, q
+		WHERE jsonb_to_tsvector('english', dl.environment, '["string"]') @@ q.query
+		UNION ALL
+		SELECT 'day', de.exempt_date, initcap(de.reason) || ' day',
+			CASE WHEN de.note = '' THEN de.reason ELSE de.note END,
+			de.exempt_date, 0::BIGINT,
+			ts_rank(to_tsvector('english', de.reason || ' ' || de.note), q.query)
+		FROM day_exemptions de, q
+		WHERE to_tsvector('english', de.reason || ' ' || de.note) @@ q.query`,
+}
+
+// SearchStore runs full-text search across entities.
+type SearchStore struct {
+	db DBTX
+}
+
+// NewSearchStore creates a new SearchStore.
+func NewSearchStore(db DBTX) *SearchStore {
+	return &SearchStore{db: db}
+}
+
+// Search returns up to limit results of the given kinds matching the query,
+// best rank first and newest first among ties. The query uses web search
+// syntax: quoted phrases, "or", and -word to exclude.
+func (s *SearchStore) Search(ctx context.Context, query string, kinds []domain.SearchKind, limit int) ([]domain.SearchResult, error) {
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if source, ok := searchSources[kind]; ok {
+			parts = append(parts, source)
+		}
+	}
+	results := make([]domain.SearchResult, 0)
+	if len(parts) == 0 {
+		return results, nil
+	}
+
+	sqlQuery := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query)
+		SELECT kind, id, title, coalesce(snippet, ''), log_date, parent_id, rank
+		FROM (` + strings.Join(parts, "\n\t\tUNION ALL") + `
+		) found (kind, id, title, snippet, log_date, parent_id, rank)
+		ORDER BY rank DESC, log_date DESC, kind, id
+		LIMIT $2
+	`
+	rows, err := s.db.QueryContext(ctx, sqlQuery, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r domain.SearchResult
+		var parentID int64
+		if err := rows.Scan(&r.Kind, &r.ID, &r.Title, &r.Snippet, &r.Date, &parentID, &r.Rank); err != nil {
+			return nil, err
+		}
+		r.Link = domain.SearchLink(r.Kind, r.ID, r.Date, parentID)
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
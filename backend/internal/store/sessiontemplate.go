@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"victus/internal/domain"
+)
+
+var (
+	// ErrSessionTemplateNotFound is returned when a session template doesn't exist.
+	ErrSessionTemplateNotFound = errors.New("session template not found")
+	// ErrSessionTemplateExists is returned when a template with the same name already exists.
+	ErrSessionTemplateExists = errors.New("session template with this name already exists")
+)
+
+// SessionTemplateStore handles persistence for saved training session templates.
+type SessionTemplateStore struct {
+	db DBTX
+}
+
+// NewSessionTemplateStore creates a new SessionTemplateStore.
+func NewSessionTemplateStore(db DBTX) *SessionTemplateStore {
+	return &SessionTemplateStore{db: db}
+}
+
+const sessionTemplateColumns = `
+	id, name, training_type, duration_min, typical_rpe, exercises, notes,
+	favorite, use_count, last_used_at, created_at, updated_at`
+
+// Create stores a new template and sets its ID and timestamps.
+// Returns ErrSessionTemplateExists if the name is taken.
+func (s *SessionTemplateStore) Create(ctx context.Context, t *domain.SessionTemplate) error {
+	exercises, err := json.Marshal(t.Exercises)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO session_templates (name, training_type, duration_min, typical_rpe, exercises, notes, favorite)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = s.db.QueryRowContext(ctx, query,
+		t.Name, t.Type, t.DurationMin, t.TypicalRPE, exercises, t.Notes, t.Favorite,
+	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil && isUniqueConstraint(err) {
+		return ErrSessionTemplateExists
+	}
+	return err
+}
+
+// GetByID retrieves a template.
+// Returns ErrSessionTemplateNotFound if it doesn't exist.
+func (s *SessionTemplateStore) GetByID(ctx context.Context, id int64) (*domain.SessionTemplate, error) {
+	query := `SELECT ` + sessionTemplateColumns + ` FROM session_templates WHERE id = $1`
+
+	t, err := scanSessionTemplate(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionTemplateNotFound
+	}
+	return t, err
+}
+
+// List returns all templates: favorites first, then most used, then by name.
+func (s *SessionTemplateStore) List(ctx context.Context) ([]domain.SessionTemplate, error) {
+	query := `SELECT ` + sessionTemplateColumns + `
+		FROM session_templates
+		ORDER BY favorite DESC, use_count DESC, name`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]domain.SessionTemplate, 0)
+	for rows.Next() {
+		t, err := scanSessionTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+	return templates, rows.Err()
+}
+
+// SetFavorite marks or unmarks a template as a favorite.
+// Returns ErrSessionTemplateNotFound if it doesn't exist.
+func (s *SessionTemplateStore) SetFavorite(ctx context.Context, id int64, favorite bool) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE session_templates SET favorite = $1, updated_at = NOW() WHERE id = $2`, favorite, id)
+	if err != nil {
+		return err
+	}
+	return sessionTemplateRowsAffected(result)
+}
+
+// MarkUsed increments the template's use count and stamps the last use.
+func (s *SessionTemplateStore) MarkUsed(ctx context.Context, id int64, at time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE session_templates SET use_count = use_count + 1, last_used_at = $1 WHERE id = $2`, at, id)
+	if err != nil {
+		return err
+	}
+	return sessionTemplateRowsAffected(result)
+}
+
+// Delete removes a template.
+// Returns ErrSessionTemplateNotFound if it doesn't exist.
+func (s *SessionTemplateStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM session_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return sessionTemplateRowsAffected(result)
+}
+
+func sessionTemplateRowsAffected(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSessionTemplateNotFound
+	}
+	return nil
+}
+
+func scanSessionTemplate(row mealTemplateScanner) (*domain.SessionTemplate, error) {
+	var (
+		t          domain.SessionTemplate
+		typicalRPE sql.NullInt64
+		exercises  []byte
+		lastUsedAt sql.NullTime
+	)
+	if err := row.Scan(
+		&t.ID, &t.Name, &t.Type, &t.DurationMin, &typicalRPE, &exercises, &t.Notes,
+		&t.Favorite, &t.UseCount, &lastUsedAt, &t.CreatedAt, &t.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if typicalRPE.Valid {
+		rpe := int(typicalRPE.Int64)
+		t.TypicalRPE = &rpe
+	}
+	if lastUsedAt.Valid {
+		t.LastUsedAt = &lastUsedAt.Time
+	}
+	if err := json.Unmarshal(exercises, &t.Exercises); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
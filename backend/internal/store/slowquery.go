@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+)
+
+// slowQueryDB logs statements that take longer than a threshold.
+// Statements run inside a transaction (*sql.Tx) are not timed.
+type slowQueryDB struct {
+	DBTX
+	threshold time.Duration
+}
+
+// LogSlowQueries wraps db so statements slower than threshold are logged.
+// A threshold of zero or less returns db unchanged.
+func LogSlowQueries(db DBTX, threshold time.Duration) DBTX {
+	if threshold <= 0 {
+		return db
+	}
+	return &slowQueryDB{DBTX: db, threshold: threshold}
+}
+
+func (d *slowQueryDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	defer d.observe(time.Now(), query)
+	return d.DBTX.ExecContext(ctx, query, args...)
+}
+
+// QueryContext times execution up to the first row, not the caller's scan loop.
+func (d *slowQueryDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	defer d.observe(time.Now(), query)
+	return d.DBTX.QueryContext(ctx, query, args...)
+}
+
+func (d *slowQueryDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer d.observe(time.Now(), query)
+	return d.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+func (d *slowQueryDB) observe(start time.Time, query string) {
+	if elapsed := time.Since(start); elapsed >= d.threshold {
+		log.Printf("slow query %dms (threshold %dms): %s",
+			elapsed.Milliseconds(), d.threshold.Milliseconds(), compactQuery(query))
+	}
+}
+
+// compactQuery collapses whitespace so multi-line SQL fits on one log line.
+func compactQuery(query string) string {
+	q := strings.Join(strings.Fields(query), " ")
+	if len(q) > 300 {
+		q = q[:300] + "..."
+	}
+	return q
+}
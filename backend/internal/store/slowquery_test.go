@@ -0,0 +1,61 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Justification: Slow-query logging is the release gate's signal for store
+// regressions; it must flag slow statements and stay quiet for fast ones.
+type SlowQuerySuite struct {
+	suite.Suite
+	logs bytes.Buffer
+}
+
+func TestSlowQuerySuite(t *testing.T) {
+	suite.Run(t, new(SlowQuerySuite))
+}
+
+func (s *SlowQuerySuite) SetupTest() {
+	s.logs.Reset()
+	log.SetOutput(&s.logs)
+	s.T().Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+// delayedDB is a DBTX whose statements take a fixed time.
+type delayedDB struct {
+	DBTX
+	delay time.Duration
+}
+
+func (d delayedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	time.Sleep(d.delay)
+	return nil, nil
+}
+
+func (s *SlowQuerySuite) TestLogsStatementsOverThreshold() {
+	db := LogSlowQueries(delayedDB{delay: 20 * time.Millisecond}, 10*time.Millisecond)
+	_, _ = db.ExecContext(context.Background(), "UPDATE daily_logs\n\t\tSET notes = $1")
+
+	s.Contains(s.logs.String(), "slow query")
+	s.Contains(s.logs.String(), "UPDATE daily_logs SET notes = $1", "whitespace is collapsed")
+}
+
+func (s *SlowQuerySuite) TestQuietUnderThreshold() {
+	db := LogSlowQueries(delayedDB{}, time.Second)
+	_, _ = db.ExecContext(context.Background(), "SELECT 1")
+
+	s.Empty(s.logs.String())
+}
+
+func (s *SlowQuerySuite) TestZeroThresholdDisables() {
+	inner := delayedDB{}
+	s.Equal(DBTX(inner), LogSlowQueries(inner, 0))
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"victus/internal/domain"
+)
+
+// SolverFeedbackStore handles persistence for per-food solver feedback used to learn food preferences.
+type SolverFeedbackStore struct {
+	db DBTX
+}
+
+// NewSolverFeedbackStore creates a new SolverFeedbackStore.
+func NewSolverFeedbackStore(db DBTX) *SolverFeedbackStore {
+	return &SolverFeedbackStore{db: db}
+}
+
+// RecordBatch stores the signals from one piece of feedback in a single transaction.
+// Returns ErrFoodReferenceNotFound if any signal names an unknown food.
+func (s *SolverFeedbackStore) RecordBatch(ctx context.Context, signals []domain.SolverFoodFeedback) error {
+	if len(signals) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const query = `
+		INSERT INTO solver_food_feedback (food_reference_id, signal, created_at, log_date, meal)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, sig := range signals {
+		var logDate, meal interface{}
+		if sig.Meal != nil {
+			logDate, meal = sig.Date, string(*sig.Meal)
+		}
+		if _, err := tx.ExecContext(ctx, query, sig.FoodReferenceID, string(sig.Signal), sig.CreatedAt, logDate, meal); err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrFoodReferenceNotFound
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListSince returns the signals recorded at or after since, oldest first.
+func (s *SolverFeedbackStore) ListSince(ctx context.Context, since time.Time) ([]domain.SolverFoodFeedback, error) {
+	const query = `
+		SELECT food_reference_id, signal, created_at
+		FROM solver_food_feedback
+		WHERE created_at >= $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	signals := make([]domain.SolverFoodFeedback, 0)
+	for rows.Next() {
+		var sig domain.SolverFoodFeedback
+		var signal string
+		if err := rows.Scan(&sig.FoodReferenceID, &signal, &sig.CreatedAt); err != nil {
+			return nil, err
+		}
+		sig.Signal = domain.SolverFoodSignal(signal)
+		signals = append(signals, sig)
+	}
+	return signals, rows.Err()
+}
+
+// ListLoggedMealFoods returns the foods logged in a meal slot through the
+// solver on or after since (YYYY-MM-DD), with each food's category. Only
+// accepted and added foods count: those are the ones that were eaten.
+func (s *SolverFeedbackStore) ListLoggedMealFoods(ctx context.Context, since string) ([]domain.LoggedMealFood, error) {
+	const query = `
+		SELECT DISTINCT f.log_date, f.meal, f.food_reference_id, r.category
+		FROM solver_food_feedback f
+		JOIN food_reference r ON r.id = f.food_reference_id
+		WHERE f.meal IS NOT NULL AND f.log_date >= $1
+		  AND f.signal IN ('accepted', 'added')
+		ORDER BY f.log_date, f.meal, f.food_reference_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := make([]domain.LoggedMealFood, 0)
+	for rows.Next() {
+		var f domain.LoggedMealFood
+		var meal, category string
+		if err := rows.Scan(&f.Date, &meal, &f.FoodReferenceID, &category); err != nil {
+			return nil, err
+		}
+		f.Meal = domain.MealName(meal)
+		f.Category = domain.FoodCategory(category)
+		foods = append(foods, f)
+	}
+	return foods, rows.Err()
+}
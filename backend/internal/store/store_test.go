@@ -504,6 +504,41 @@ func (s *DailyLogStoreSuite) TestListAdaptiveDataPointsFieldValues() {
 	})
 }
 
+func (s *DailyLogStoreSuite) TestGetWeeklyActuals() {
+	s.Run("averages explicit weigh-ins and days with food logged", func() {
+		for i, date := range []string{"2026-01-12", "2026-01-13", "2026-01-14", "2026-01-19"} {
+			_, err := s.store.Create(s.ctx, &domain.DailyLog{
+				Date:         date,
+				WeightKg:     90 - 0.25*float64(i),
+				SleepQuality: 80,
+				DayType:      domain.DayTypeFatburner,
+			})
+			s.Require().NoError(err)
+		}
+		s.Require().NoError(s.store.AddConsumedMacros(s.ctx, "2026-01-12", ConsumedMacros{Calories: 2000}))
+		s.Require().NoError(s.store.AddConsumedMacros(s.ctx, "2026-01-13", ConsumedMacros{Calories: 2102}))
+		// A carried-forward weight isn't a weigh-in
+		_, err := s.db.Exec(`UPDATE daily_logs SET has_explicit_weight = false WHERE log_date = '2026-01-14'`)
+		s.Require().NoError(err)
+
+		actuals, err := s.store.GetWeeklyActuals(s.ctx, "2026-01-12", "2026-01-18")
+		s.Require().NoError(err)
+		s.Equal(3, actuals.DaysLogged, "the next week's log is outside the range")
+		s.Require().NotNil(actuals.WeightKg)
+		s.Equal(89.9, *actuals.WeightKg, "mean of 90 and 89.75, to 0.1 kg")
+		s.Require().NotNil(actuals.IntakeKcal)
+		s.Equal(2051, *actuals.IntakeKcal, "the day without food doesn't count")
+	})
+
+	s.Run("returns no weight or intake for an empty week", func() {
+		actuals, err := s.store.GetWeeklyActuals(s.ctx, "2026-02-02", "2026-02-08")
+		s.Require().NoError(err)
+		s.Zero(actuals.DaysLogged)
+		s.Nil(actuals.WeightKg)
+		s.Nil(actuals.IntakeKcal)
+	})
+}
+
 // --- Training Session Store Suite ---
 
 type TrainingSessionStoreSuite struct {
@@ -1323,6 +1358,43 @@ func (s *MetabolicStoreSuite) TestGetPreviousTDEE() {
 	})
 }
 
+func (s *MetabolicStoreSuite) TestWindowsFollowAsOfDate() {
+	// Dates far from the database clock, as under a simulated clock
+	for i, date := range []string{"2031-03-01", "2031-03-08", "2031-03-09", "2031-03-12"} {
+		logID := s.createDailyLog(date)
+		_, err := s.db.ExecContext(s.ctx, `UPDATE daily_logs SET total_calories = 2000 WHERE id = $1`, logID)
+		s.Require().NoError(err)
+		_, err = s.store.Create(s.ctx, &domain.MetabolicHistoryRecord{
+			DailyLogID:     logID,
+			CalculatedAt:   date + " 12:00:00",
+			CalculatedTDEE: 2400 + 10*i,
+			TDEESource:     "formula",
+		})
+		s.Require().NoError(err)
+	}
+
+	s.Run("chart covers the weeks up to asOf", func() {
+		points, err := s.store.ListForChart(s.ctx, 1, "2031-03-09")
+		s.Require().NoError(err)
+		s.Require().Len(points, 2, "2031-03-01 is outside the window, 2031-03-12 is after asOf")
+		s.Equal(2410, points[0].CalculatedTDEE)
+		s.Equal(2420, points[1].CalculatedTDEE)
+	})
+
+	s.Run("adherence counts logs up to asOf", func() {
+		count, err := s.store.CountRecentLogs(s.ctx, 7, "2031-03-09")
+		s.Require().NoError(err)
+		s.Equal(2, count)
+	})
+
+	s.Run("weights come from the days up to asOf", func() {
+		weights, err := s.store.ListRecentWeights(s.ctx, 7, "2031-03-12")
+		s.Require().NoError(err)
+		s.Require().Len(weights, 3)
+		s.Equal("2031-03-08", weights[0].Date)
+	})
+}
+
 func (s *FoodReferenceStoreSuite) TestListPantryFoods() {
 	s.Run("returns foods with nutritional data", func() {
 		// Food with nutrition data
@@ -1372,3 +1444,173 @@ func (s *FoodReferenceStoreSuite) TestListPantryFoods() {
 		s.InDelta(5.0, food.FatGPer100, 0.001)
 	})
 }
+
+// --- Job Lease Store Suite ---
+
+type JobLeaseStoreSuite struct {
+	suite.Suite
+	pg    *testutil.PostgresContainer
+	db    *sql.DB
+	store *JobLeaseStore
+	ctx   context.Context
+	now   time.Time
+}
+
+func TestJobLeaseStoreSuite(t *testing.T) {
+	suite.Run(t, new(JobLeaseStoreSuite))
+}
+
+func (s *JobLeaseStoreSuite) SetupSuite() {
+	s.pg = testutil.SetupPostgres(s.T())
+	s.db = s.pg.DB
+}
+
+func (s *JobLeaseStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.Require().NoError(s.pg.ClearTables(s.ctx))
+	s.store = NewJobLeaseStore(s.db)
+	s.now = time.Date(2026, 3, 1, 4, 0, 0, 0, time.UTC)
+}
+
+func (s *JobLeaseStoreSuite) TestOnlyOneHolderAtATime() {
+	ok, err := s.store.Acquire(s.ctx, "draft_lifecycle", "replica-a", s.now, time.Minute)
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = s.store.Acquire(s.ctx, "draft_lifecycle", "replica-b", s.now.Add(30*time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.False(ok, "lease is still held")
+
+	ok, err = s.store.Acquire(s.ctx, "draft_lifecycle", "replica-a", s.now.Add(50*time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.True(ok, "holder renews")
+
+	ok, err = s.store.Acquire(s.ctx, "kcal_factor_tune", "replica-b", s.now, time.Minute)
+	s.Require().NoError(err)
+	s.True(ok, "leases are per job")
+}
+
+func (s *JobLeaseStoreSuite) TestTakeoverAfterExpiry() {
+	_, err := s.store.Acquire(s.ctx, "draft_lifecycle", "replica-a", s.now, time.Minute)
+	s.Require().NoError(err)
+
+	ok, err := s.store.Acquire(s.ctx, "draft_lifecycle", "replica-b", s.now.Add(61*time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.True(ok, "crashed holder's lease is taken over")
+
+	ok, err = s.store.Acquire(s.ctx, "draft_lifecycle", "replica-a", s.now.Add(62*time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.False(ok)
+}
+
+func (s *JobLeaseStoreSuite) TestReleaseHandsOver() {
+	_, err := s.store.Acquire(s.ctx, "draft_lifecycle", "replica-a", s.now, time.Minute)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.store.Release(s.ctx, "draft_lifecycle", "replica-b"), "non-holder release is a no-op")
+	ok, err := s.store.Acquire(s.ctx, "draft_lifecycle", "replica-b", s.now.Add(time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.False(ok)
+
+	s.Require().NoError(s.store.Release(s.ctx, "draft_lifecycle", "replica-a"))
+	ok, err = s.store.Acquire(s.ctx, "draft_lifecycle", "replica-b", s.now.Add(time.Second), time.Minute)
+	s.Require().NoError(err)
+	s.True(ok)
+}
+
+// --- Training Program Store Suite ---
+
+type TrainingProgramStoreSuite struct {
+	suite.Suite
+	pg    *testutil.PostgresContainer
+	db    *sql.DB
+	store *TrainingProgramStore
+	ctx   context.Context
+}
+
+func TestTrainingProgramStoreSuite(t *testing.T) {
+	suite.Run(t, new(TrainingProgramStoreSuite))
+}
+
+func (s *TrainingProgramStoreSuite) SetupSuite() {
+	s.pg = testutil.SetupPostgres(s.T())
+	s.db = s.pg.DB
+}
+
+func (s *TrainingProgramStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.Require().NoError(s.pg.ClearTables(s.ctx))
+	s.store = NewTrainingProgramStore(s.db)
+}
+
+// createProgram stores a program with daysPerWeek days in each of its weeks;
+// the last week has no days.
+func (s *TrainingProgramStoreSuite) createProgram(name string, weeks, daysPerWeek int) int64 {
+	program := &domain.TrainingProgram{
+		Name:                name,
+		DurationWeeks:       weeks,
+		TrainingDaysPerWeek: daysPerWeek,
+		Difficulty:          domain.ProgramDifficultyBeginner,
+		Focus:               domain.ProgramFocusGeneral,
+		Equipment:           []domain.EquipmentType{},
+		Tags:                []string{},
+		Status:              domain.ProgramStatusTemplate,
+		IsTemplate:          true,
+	}
+	for w := 1; w <= weeks; w++ {
+		week := domain.ProgramWeek{WeekNumber: w, Label: "Week", VolumeScale: 1, IntensityScale: 1}
+		for d := 1; d <= daysPerWeek && w < weeks; d++ {
+			week.Days = append(week.Days, domain.ProgramDay{
+				DayNumber:    d,
+				Label:        "Day",
+				TrainingType: domain.TrainingTypeStrength,
+				DurationMin:  60,
+				LoadScore:    3,
+				NutritionDay: domain.DayTypePerformance,
+			})
+		}
+		program.Weeks = append(program.Weeks, week)
+	}
+	id, err := s.store.Create(s.ctx, program)
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *TrainingProgramStoreSuite) TestGetByIDAssemblesWeeksAndDays() {
+	id := s.createProgram("Base", 3, 2)
+
+	program, err := s.store.GetByID(s.ctx, id)
+	s.Require().NoError(err)
+	s.Require().Len(program.Weeks, 3)
+	for i, week := range program.Weeks[:2] {
+		s.Equal(i+1, week.WeekNumber)
+		s.Require().Len(week.Days, 2)
+		s.Equal(1, week.Days[0].DayNumber)
+		s.Equal(2, week.Days[1].DayNumber)
+		s.Equal(week.ID, week.Days[0].WeekID)
+	}
+	s.Empty(program.Weeks[2].Days, "week without days")
+}
+
+func (s *TrainingProgramStoreSuite) TestListWithWeeksGroupsByProgram() {
+	first := s.createProgram("First", 2, 3)
+	second := s.createProgram("Second", 4, 1)
+	s.createProgram("Empty", 1, 1)
+
+	programs, err := s.store.ListWithWeeks(s.ctx, ProgramFilters{})
+	s.Require().NoError(err)
+	s.Require().Len(programs, 3)
+
+	byID := make(map[int64]*domain.TrainingProgram)
+	for _, p := range programs {
+		byID[p.ID] = p
+	}
+	s.Len(byID[first].Weeks, 2)
+	s.Len(byID[first].Weeks[0].Days, 3)
+	s.Len(byID[second].Weeks, 4)
+	s.Len(byID[second].Weeks[2].Days, 1)
+
+	single, err := s.store.GetByID(s.ctx, second)
+	s.Require().NoError(err)
+	s.Equal(single.Weeks, byID[second].Weeks)
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// StrengthRotationStore handles database operations for the strength rotation.
+type StrengthRotationStore struct {
+	db DBTX
+}
+
+// NewStrengthRotationStore creates a new StrengthRotationStore.
+func NewStrengthRotationStore(db DBTX) *StrengthRotationStore {
+	return &StrengthRotationStore{db: db}
+}
+
+// Get retrieves the strength rotation, or the default rotation if none has
+// been saved yet.
+func (s *StrengthRotationStore) Get(ctx context.Context) (*domain.StrengthRotation, error) {
+	var (
+		r             domain.StrengthRotation
+		split         string
+		lastArchetype sql.NullString
+		lastDate      sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx, `
+		SELECT split, position, last_archetype, last_date, updated_at
+		FROM strength_rotation
+		WHERE id = 1
+	`).Scan(&split, &r.Position, &lastArchetype, &lastDate, &r.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		rotation := domain.DefaultStrengthRotation()
+		return &rotation, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.Split = domain.StrengthSplit(split)
+	r.LastArchetype = domain.Archetype(lastArchetype.String)
+	r.LastDate = lastDate.String
+	return &r, nil
+}
+
+// Save creates or replaces the strength rotation.
+func (s *StrengthRotationStore) Save(ctx context.Context, r *domain.StrengthRotation) error {
+	var lastArchetype, lastDate interface{}
+	if r.LastArchetype != "" {
+		lastArchetype = string(r.LastArchetype)
+	}
+	if r.LastDate != "" {
+		lastDate = r.LastDate
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO strength_rotation (id, split, position, last_archetype, last_date, updated_at)
+		VALUES (1, $1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			split = EXCLUDED.split,
+			position = EXCLUDED.position,
+			last_archetype = EXCLUDED.last_archetype,
+			last_date = EXCLUDED.last_date,
+			updated_at = EXCLUDED.updated_at
+	`, string(r.Split), r.Position, lastArchetype, lastDate, r.UpdatedAt)
+	return err
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"victus/internal/domain"
+)
+
+// SubstitutionStore handles persistence for fatigue substitution decisions.
+type SubstitutionStore struct {
+	db DBTX
+}
+
+// NewSubstitutionStore creates a new SubstitutionStore.
+func NewSubstitutionStore(db DBTX) *SubstitutionStore {
+	return &SubstitutionStore{db: db}
+}
+
+// Create stores a decision and sets its ID and CreatedAt.
+func (s *SubstitutionStore) Create(ctx context.Context, d *domain.SubstitutionDecision) error {
+	muscles := d.FatiguedMuscles
+	if muscles == nil {
+		muscles = []domain.MuscleGroup{}
+	}
+	raw, err := json.Marshal(muscles)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO substitution_log (log_date, session_label, original_id, substitute_id, fatigued_muscles, accepted)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query,
+		d.Date, d.SessionLabel, d.OriginalID, d.SubstituteID, raw, d.Accepted,
+	).Scan(&d.ID, &d.CreatedAt)
+}
+
+// ListByDateRange returns decisions between two dates (inclusive), oldest first.
+func (s *SubstitutionStore) ListByDateRange(ctx context.Context, from, to string) ([]domain.SubstitutionDecision, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, log_date, session_label, original_id, substitute_id, fatigued_muscles, accepted, created_at
+		FROM substitution_log
+		WHERE log_date >= $1 AND log_date <= $2
+		ORDER BY log_date, id
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := make([]domain.SubstitutionDecision, 0)
+	for rows.Next() {
+		var d domain.SubstitutionDecision
+		var raw []byte
+		if err := rows.Scan(&d.ID, &d.Date, &d.SessionLabel, &d.OriginalID, &d.SubstituteID, &raw, &d.Accepted, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &d.FatiguedMuscles); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, rows.Err()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"time"
 
 	"victus/internal/domain"
 )
@@ -32,8 +34,10 @@ func (s *TrainingSessionStore) createForLog(ctx context.Context, execer sqlExece
 	const query = `
 		INSERT INTO training_sessions (
 			daily_log_id, session_order, is_planned, training_type,
-			duration_min, perceived_intensity, notes
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			duration_min, perceived_intensity, notes, environment, start_time,
+			avg_heart_rate, archetype_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			(SELECT id FROM training_archetypes WHERE name = NULLIF($11, '')))
 	`
 
 	for _, session := range sessions {
@@ -55,6 +59,10 @@ func (s *TrainingSessionStore) createForLog(ctx context.Context, execer sqlExece
 			session.DurationMin,
 			intensity,
 			notes,
+			environmentJSON(session.Environment),
+			session.StartTime,
+			session.AvgHeartRate,
+			session.Archetype,
 		)
 		if err != nil {
 			return err
@@ -63,11 +71,15 @@ func (s *TrainingSessionStore) createForLog(ctx context.Context, execer sqlExece
 	return nil
 }
 
+// sessionArchetypeColumn selects a session's archetype name, empty when unassigned.
+const sessionArchetypeColumn = `COALESCE((SELECT name FROM training_archetypes WHERE id = training_sessions.archetype_id), '')`
+
 // GetByLogID retrieves all sessions for a daily log, ordered by session_order.
 func (s *TrainingSessionStore) GetByLogID(ctx context.Context, logID int64) ([]domain.TrainingSession, error) {
 	const query = `
 		SELECT id, session_order, is_planned, training_type,
-		       duration_min, perceived_intensity, notes
+		       duration_min, perceived_intensity, notes, environment, start_time,
+		       avg_heart_rate, ` + sessionArchetypeColumn + `
 		FROM training_sessions
 		WHERE daily_log_id = $1
 		ORDER BY session_order
@@ -82,8 +94,9 @@ func (s *TrainingSessionStore) GetByLogID(ctx context.Context, logID int64) ([]d
 	var sessions []domain.TrainingSession
 	for rows.Next() {
 		var session domain.TrainingSession
-		var intensity sql.NullInt64
+		var intensity, avgHeartRate sql.NullInt64
 		var notes sql.NullString
+		var environment string
 
 		err := rows.Scan(
 			&session.ID,
@@ -93,6 +106,10 @@ func (s *TrainingSessionStore) GetByLogID(ctx context.Context, logID int64) ([]d
 			&session.DurationMin,
 			&intensity,
 			&notes,
+			&environment,
+			&session.StartTime,
+			&avgHeartRate,
+			&session.Archetype,
 		)
 		if err != nil {
 			return nil, err
@@ -105,6 +122,11 @@ func (s *TrainingSessionStore) GetByLogID(ctx context.Context, logID int64) ([]d
 		if notes.Valid {
 			session.Notes = notes.String
 		}
+		if avgHeartRate.Valid {
+			hr := int(avgHeartRate.Int64)
+			session.AvgHeartRate = &hr
+		}
+		session.Environment = parseEnvironmentJSON(environment)
 
 		sessions = append(sessions, session)
 	}
@@ -132,7 +154,8 @@ func (s *TrainingSessionStore) GetActualByLogID(ctx context.Context, logID int64
 func (s *TrainingSessionStore) getSessionsByLogIDAndType(ctx context.Context, logID int64, isPlanned bool) ([]domain.TrainingSession, error) {
 	const query = `
 		SELECT id, session_order, is_planned, training_type,
-		       duration_min, perceived_intensity, notes
+		       duration_min, perceived_intensity, notes, environment, start_time,
+		       avg_heart_rate, ` + sessionArchetypeColumn + `
 		FROM training_sessions
 		WHERE daily_log_id = $1 AND is_planned = $2
 		ORDER BY session_order
@@ -147,8 +170,9 @@ func (s *TrainingSessionStore) getSessionsByLogIDAndType(ctx context.Context, lo
 	var sessions []domain.TrainingSession
 	for rows.Next() {
 		var session domain.TrainingSession
-		var intensity sql.NullInt64
+		var intensity, avgHeartRate sql.NullInt64
 		var notes sql.NullString
+		var environment string
 
 		err := rows.Scan(
 			&session.ID,
@@ -158,6 +182,10 @@ func (s *TrainingSessionStore) getSessionsByLogIDAndType(ctx context.Context, lo
 			&session.DurationMin,
 			&intensity,
 			&notes,
+			&environment,
+			&session.StartTime,
+			&avgHeartRate,
+			&session.Archetype,
 		)
 		if err != nil {
 			return nil, err
@@ -170,6 +198,11 @@ func (s *TrainingSessionStore) getSessionsByLogIDAndType(ctx context.Context, lo
 		if notes.Valid {
 			session.Notes = notes.String
 		}
+		if avgHeartRate.Valid {
+			hr := int(avgHeartRate.Int64)
+			session.AvgHeartRate = &hr
+		}
+		session.Environment = parseEnvironmentJSON(environment)
 
 		sessions = append(sessions, session)
 	}
@@ -192,11 +225,24 @@ func (s *TrainingSessionStore) deleteActualByLogID(ctx context.Context, execer s
 	return err
 }
 
+// DeleteByLogIDWithTx removes all of a daily log's sessions, planned and
+// actual, within a transaction. Returns the number removed.
+func (s *TrainingSessionStore) DeleteByLogIDWithTx(ctx context.Context, tx *sql.Tx, logID int64) (int, error) {
+	result, err := tx.ExecContext(ctx, "DELETE FROM training_sessions WHERE daily_log_id = $1", logID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
 // SessionsByDate represents training sessions grouped by date for ACR calculation.
+// Each session's Environment includes the day's conditions.
 type SessionsByDate struct {
 	Date            string
 	PlannedSessions []domain.TrainingSession
 	ActualSessions  []domain.TrainingSession
+	Environment     []domain.EnvironmentCondition // The day's own conditions
 }
 
 // GetSessionsForDateRange retrieves all training sessions within a date range for ACR calculation.
@@ -211,7 +257,10 @@ func (s *TrainingSessionStore) GetSessionsForDateRange(ctx context.Context, star
 			ts.training_type,
 			ts.duration_min,
 			ts.perceived_intensity,
-			ts.notes
+			ts.notes,
+			dl.environment,
+			COALESCE(ts.environment, '[]'),
+			COALESCE(ts.start_time, '')
 		FROM daily_logs dl
 		LEFT JOIN training_sessions ts ON dl.id = ts.daily_log_id
 		WHERE dl.log_date >= $1 AND dl.log_date <= $2
@@ -237,16 +286,19 @@ func (s *TrainingSessionStore) GetSessionsForDateRange(ctx context.Context, star
 			durationMin  sql.NullInt64
 			intensity    sql.NullInt64
 			notes        sql.NullString
+			dayEnv       string
+			sessionEnv   string
+			startTime    string
 		)
 
 		if err := rows.Scan(&date, &sessionOrder, &isPlanned, &trainingType,
-			&durationMin, &intensity, &notes); err != nil {
+			&durationMin, &intensity, &notes, &dayEnv, &sessionEnv, &startTime); err != nil {
 			return nil, err
 		}
 
 		// Initialize date entry if needed
 		if _, exists := byDate[date]; !exists {
-			byDate[date] = &SessionsByDate{Date: date}
+			byDate[date] = &SessionsByDate{Date: date, Environment: parseEnvironmentJSON(dayEnv)}
 			orderedDates = append(orderedDates, date)
 		}
 
@@ -255,11 +307,14 @@ func (s *TrainingSessionStore) GetSessionsForDateRange(ctx context.Context, star
 			continue
 		}
 
+		// The day's conditions apply to each of its sessions
 		session := domain.TrainingSession{
 			SessionOrder: int(sessionOrder.Int64),
 			IsPlanned:    isPlanned.Bool,
 			Type:         domain.TrainingType(trainingType.String),
 			DurationMin:  int(durationMin.Int64),
+			StartTime:    startTime,
+			Environment:  domain.MergeEnvironment(byDate[date].Environment, parseEnvironmentJSON(sessionEnv)),
 		}
 
 		if intensity.Valid {
@@ -290,11 +345,61 @@ func (s *TrainingSessionStore) GetSessionsForDateRange(ctx context.Context, star
 	return result, nil
 }
 
+// SessionDay is a logged day's actual sessions with the day's weight.
+type SessionDay struct {
+	Date     string
+	WeightKg float64
+	Sessions []domain.TrainingSession
+}
+
+// ListActualSessionDays retrieves the actual sessions of each logged day within
+// a date range (inclusive) that has any, ordered by date.
+func (s *TrainingSessionStore) ListActualSessionDays(ctx context.Context, startDate, endDate string) ([]SessionDay, error) {
+	const query = `
+		SELECT dl.log_date, dl.weight_kg, ts.session_order, ts.training_type,
+		       ts.duration_min, ts.avg_heart_rate
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		WHERE ts.is_planned = false AND dl.log_date >= $1 AND dl.log_date <= $2
+		ORDER BY dl.log_date, ts.session_order
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []SessionDay
+	for rows.Next() {
+		var date string
+		var weightKg float64
+		var session domain.TrainingSession
+		var avgHeartRate sql.NullInt64
+		if err := rows.Scan(&date, &weightKg, &session.SessionOrder, &session.Type,
+			&session.DurationMin, &avgHeartRate); err != nil {
+			return nil, err
+		}
+		if avgHeartRate.Valid {
+			hr := int(avgHeartRate.Int64)
+			session.AvgHeartRate = &hr
+		}
+
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, SessionDay{Date: date, WeightKg: weightKg})
+		}
+		days[len(days)-1].Sessions = append(days[len(days)-1].Sessions, session)
+	}
+
+	return days, rows.Err()
+}
+
 // GetByID retrieves a single training session by its ID.
 func (s *TrainingSessionStore) GetByID(ctx context.Context, id int64) (*domain.TrainingSession, error) {
 	const query = `
 		SELECT id, session_order, is_planned, is_draft, training_type,
-		       duration_min, perceived_intensity, notes, raw_echo_log, extra_metadata
+		       duration_min, perceived_intensity, notes, raw_echo_log, extra_metadata,
+		       ` + sessionArchetypeColumn + `
 		FROM training_sessions
 		WHERE id = $1
 	`
@@ -317,6 +422,7 @@ func (s *TrainingSessionStore) GetByID(ctx context.Context, id int64) (*domain.T
 		&notes,
 		&rawEchoLog,
 		&extraMetadata,
+		&session.Archetype,
 	)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrSessionNotFound
@@ -467,3 +573,196 @@ func (s *TrainingSessionStore) FinalizeDraft(ctx context.Context, id int64) erro
 
 	return nil
 }
+
+// ListDrafts returns all draft sessions with their lifecycle timestamps, oldest first.
+func (s *TrainingSessionStore) ListDrafts(ctx context.Context) ([]domain.DraftSessionState, error) {
+	const query = `
+		SELECT ts.id, ts.session_order, ts.training_type, ts.duration_min,
+		       ts.perceived_intensity, ts.notes, ts.created_at, ts.draft_reminded_at,
+		       dl.log_date
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		WHERE ts.is_draft = true
+		ORDER BY ts.created_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drafts := make([]domain.DraftSessionState, 0)
+	for rows.Next() {
+		var d domain.DraftSessionState
+		var intensity sql.NullInt64
+		var notes sql.NullString
+		var remindedAt sql.NullTime
+
+		if err := rows.Scan(
+			&d.Session.ID,
+			&d.Session.SessionOrder,
+			&d.Session.Type,
+			&d.Session.DurationMin,
+			&intensity,
+			&notes,
+			&d.CreatedAt,
+			&remindedAt,
+			&d.LogDate,
+		); err != nil {
+			return nil, err
+		}
+
+		d.Session.IsDraft = true
+		if intensity.Valid {
+			i := int(intensity.Int64)
+			d.Session.PerceivedIntensity = &i
+		}
+		if notes.Valid {
+			d.Session.Notes = notes.String
+		}
+		if remindedAt.Valid {
+			d.RemindedAt = &remindedAt.Time
+		}
+		drafts = append(drafts, d)
+	}
+
+	return drafts, rows.Err()
+}
+
+// MarkDraftReminded records that an RPE reminder was raised for a draft session.
+func (s *TrainingSessionStore) MarkDraftReminded(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE training_sessions SET draft_reminded_at = $2 WHERE id = $1 AND is_draft = true",
+		id, at,
+	)
+	return err
+}
+
+// AutoCloseDraft finalizes a draft with default RPE and duration and stamps auto_closed_at.
+// Returns domain.ErrSessionNotDraft if the session was finalized concurrently.
+func (s *TrainingSessionStore) AutoCloseDraft(ctx context.Context, session domain.TrainingSession, at time.Time) error {
+	var intensity interface{}
+	if session.PerceivedIntensity != nil {
+		intensity = *session.PerceivedIntensity
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE training_sessions
+		SET is_draft = false, perceived_intensity = $2, duration_min = $3, auto_closed_at = $4
+		WHERE id = $1 AND is_draft = true
+	`, session.ID, intensity, session.DurationMin, at)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSessionNotDraft
+	}
+	return nil
+}
+
+// CountAutoClosed returns how many sessions logged in the date range (inclusive)
+// were auto-closed rather than finalized by the user.
+func (s *TrainingSessionStore) CountAutoClosed(ctx context.Context, startDate, endDate string) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		WHERE ts.auto_closed_at IS NOT NULL
+		  AND dl.log_date >= $1 AND dl.log_date <= $2
+	`
+	var count int
+	err := s.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&count)
+	return count, err
+}
+
+// WeeklyTrainingVolume returns the logged (actual, non-rest) sessions and
+// minutes per calendar week, oldest first. Weeks without training are omitted.
+func (s *TrainingSessionStore) WeeklyTrainingVolume(ctx context.Context) ([]domain.TrainingWeek, error) {
+	const query = `
+		SELECT to_char(date_trunc('week', dl.log_date::date), 'YYYY-MM-DD') AS week_start,
+		       COUNT(*), COALESCE(SUM(ts.duration_min), 0)
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		WHERE ts.is_planned = false AND ts.training_type <> 'rest'
+		GROUP BY week_start
+		ORDER BY week_start
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	weeks := make([]domain.TrainingWeek, 0)
+	for rows.Next() {
+		var w domain.TrainingWeek
+		if err := rows.Scan(&w.WeekStart, &w.Sessions, &w.Minutes); err != nil {
+			return nil, err
+		}
+		weeks = append(weeks, w)
+	}
+	return weeks, rows.Err()
+}
+
+// SetArchetype assigns an archetype to a session that has none. Reports
+// whether it was assigned, so fatigue is applied once per session.
+// Returns ErrArchetypeNotFound if the archetype isn't seeded.
+func (s *TrainingSessionStore) SetArchetype(ctx context.Context, sessionID int64, archetype domain.Archetype) (bool, error) {
+	var archetypeID int
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM training_archetypes WHERE name = $1`, archetype).Scan(&archetypeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrArchetypeNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE training_sessions SET archetype_id = $2 WHERE id = $1 AND archetype_id IS NULL",
+		sessionID, archetypeID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// RecentArchetypes returns the archetypes of the actual sessions logged
+// before the given session (earlier days, or earlier in the same day), most
+// recent first.
+func (s *TrainingSessionStore) RecentArchetypes(ctx context.Context, date string, sessionOrder, limit int) ([]domain.Archetype, error) {
+	const query = `
+		SELECT ta.name
+		FROM training_sessions ts
+		JOIN daily_logs dl ON dl.id = ts.daily_log_id
+		JOIN training_archetypes ta ON ta.id = ts.archetype_id
+		WHERE ts.is_planned = false
+		  AND (dl.log_date < $1 OR (dl.log_date = $1 AND ts.session_order < $2))
+		ORDER BY dl.log_date DESC, ts.session_order DESC
+		LIMIT $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, date, sessionOrder, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var archetypes []domain.Archetype
+	for rows.Next() {
+		var a domain.Archetype
+		if err := rows.Scan(&a); err != nil {
+			return nil, err
+		}
+		archetypes = append(archetypes, a)
+	}
+	return archetypes, rows.Err()
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNestedTx is returned when a store tries to begin its own transaction
+// while a request-scoped transaction (RunInTx) is active.
+var ErrNestedTx = errors.New("store: cannot begin a transaction inside a request-scoped transaction")
+
+type txContextKey struct{}
+
+// txScopedDB sends statements to the transaction carried by the context,
+// if any, so work done through several stores can commit or roll back as one.
+type txScopedDB struct {
+	DBTX
+}
+
+// TxScoped wraps db so statements issued with a context from RunInTx run in
+// that transaction. Without one, statements go to db as before.
+func TxScoped(db DBTX) DBTX {
+	return &txScopedDB{DBTX: db}
+}
+
+func (d *txScopedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return d.DBTX.ExecContext(ctx, query, args...)
+}
+
+func (d *txScopedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return d.DBTX.QueryContext(ctx, query, args...)
+}
+
+func (d *txScopedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return d.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx refuses to start a second transaction inside a request-scoped one;
+// committing it would commit the outer work early. Stores that need their own
+// transaction join the outer one through withTx instead.
+func (d *txScopedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if txFromContext(ctx) != nil {
+		return nil, ErrNestedTx
+	}
+	return d.DBTX.BeginTx(ctx, opts)
+}
+
+// RunInTx runs fn in one transaction. Statements made through a TxScoped
+// DBTX with fn's context join it. An error from fn, or a failed commit, rolls
+// back everything fn did.
+func RunInTx(ctx context.Context, db DBTX, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return ErrNestedTx
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withTx runs fn in a transaction: the request-scoped one when ctx carries
+// it (leaving the commit to RunInTx), otherwise a new one committed when fn
+// succeeds.
+func withTx(ctx context.Context, db DBTX, fn func(*sql.Tx) error) error {
+	if tx := txFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func txFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx
+}
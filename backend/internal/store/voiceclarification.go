@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// ErrVoiceClarificationNotFound is returned when a held voice command doesn't exist.
+var ErrVoiceClarificationNotFound = errors.New("voice clarification not found")
+
+// VoiceClarificationStore handles persistence for voice commands held for
+// clarification.
+type VoiceClarificationStore struct {
+	db DBTX
+}
+
+// NewVoiceClarificationStore creates a new VoiceClarificationStore.
+func NewVoiceClarificationStore(db DBTX) *VoiceClarificationStore {
+	return &VoiceClarificationStore{db: db}
+}
+
+const selectVoiceClarificationColumns = `
+	SELECT id, log_date, raw_input, command, field, question, answers, status, created_at
+	FROM voice_clarifications
+`
+
+// Create stores a held command and sets its ID and CreatedAt.
+func (s *VoiceClarificationStore) Create(ctx context.Context, c *domain.VoiceClarification) error {
+	command, answers, err := marshalVoiceClarification(c)
+	if err != nil {
+		return err
+	}
+	const query = `
+		INSERT INTO voice_clarifications (log_date, raw_input, command, field, question, answers, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, c.Date, c.RawInput, command, string(c.Field), c.Question, answers, string(c.Status)).
+		Scan(&c.ID, &c.CreatedAt)
+}
+
+// GetByID retrieves a held command.
+// Returns ErrVoiceClarificationNotFound if it doesn't exist.
+func (s *VoiceClarificationStore) GetByID(ctx context.Context, id int64) (*domain.VoiceClarification, error) {
+	c, err := scanVoiceClarification(s.db.QueryRowContext(ctx, selectVoiceClarificationColumns+`WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVoiceClarificationNotFound
+	}
+	return c, err
+}
+
+// ListPending returns the commands waiting for an answer, oldest first.
+func (s *VoiceClarificationStore) ListPending(ctx context.Context) ([]domain.VoiceClarification, error) {
+	rows, err := s.db.QueryContext(ctx, selectVoiceClarificationColumns+`WHERE status = $1 ORDER BY created_at, id`,
+		string(domain.VoiceClarificationPending))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := make([]domain.VoiceClarification, 0)
+	for rows.Next() {
+		c, err := scanVoiceClarification(rows)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, *c)
+	}
+	return pending, rows.Err()
+}
+
+// Update saves a held command after an answer or a dismissal.
+// Returns ErrVoiceClarificationNotFound if it doesn't exist.
+func (s *VoiceClarificationStore) Update(ctx context.Context, c *domain.VoiceClarification) error {
+	command, answers, err := marshalVoiceClarification(c)
+	if err != nil {
+		return err
+	}
+	const query = `
+		UPDATE voice_clarifications
+		SET command = $2, field = $3, question = $4, answers = $5, status = $6
+		WHERE id = $1
+	`
+	result, err := s.db.ExecContext(ctx, query, c.ID, command, string(c.Field), c.Question, answers, string(c.Status))
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrVoiceClarificationNotFound
+	}
+	return nil
+}
+
+func marshalVoiceClarification(c *domain.VoiceClarification) (command, answers []byte, err error) {
+	if command, err = json.Marshal(c.Command); err != nil {
+		return nil, nil, err
+	}
+	if answers, err = json.Marshal(c.Answers); err != nil {
+		return nil, nil, err
+	}
+	return command, answers, nil
+}
+
+type voiceClarificationScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVoiceClarification(row voiceClarificationScanner) (*domain.VoiceClarification, error) {
+	var c domain.VoiceClarification
+	var command, answers []byte
+	if err := row.Scan(&c.ID, &c.Date, &c.RawInput, &command, &c.Field, &c.Question, &answers, &c.Status, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(command, &c.Command); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(answers, &c.Answers); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"victus/internal/domain"
+)
+
+// WearableImportStore remembers the records imported from each wearable
+// source, so re-sent exports aren't applied twice.
+type WearableImportStore struct {
+	db DBTX
+}
+
+// NewWearableImportStore creates a new WearableImportStore.
+func NewWearableImportStore(db DBTX) *WearableImportStore {
+	return &WearableImportStore{db: db}
+}
+
+// GetFingerprint returns the fingerprint stored for a record, and false when
+// the record was never imported.
+func (s *WearableImportStore) GetFingerprint(ctx context.Context, source domain.WearableSource, kind domain.WearableRecordKind, externalID string) (string, bool, error) {
+	const query = `
+		SELECT fingerprint FROM wearable_imports
+		WHERE source = $1 AND record_kind = $2 AND external_id = $3
+	`
+	var fingerprint string
+	err := s.db.QueryRowContext(ctx, query, string(source), string(kind), externalID).Scan(&fingerprint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return fingerprint, true, nil
+}
+
+// Record stores an imported record, replacing an earlier import of it.
+func (s *WearableImportStore) Record(ctx context.Context, rec domain.WearableImportRecord) error {
+	const query = `
+		INSERT INTO wearable_imports (source, record_kind, external_id, log_date, fingerprint, imported_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (source, record_kind, external_id) DO UPDATE SET
+			log_date = EXCLUDED.log_date,
+			fingerprint = EXCLUDED.fingerprint,
+			imported_at = EXCLUDED.imported_at
+	`
+	_, err := s.db.ExecContext(ctx, query,
+		string(rec.Source), string(rec.Kind), rec.ExternalID, rec.Date, rec.Fingerprint, rec.ImportedAt)
+	return err
+}
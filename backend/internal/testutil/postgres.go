@@ -24,14 +24,14 @@ type PostgresContainer struct {
 }
 
 // SetupPostgres creates a new PostgreSQL container for testing.
-// The container is automatically cleaned up when the test completes.
-func SetupPostgres(t *testing.T) *PostgresContainer {
+// The container is automatically cleaned up when the test or benchmark completes.
+func SetupPostgres(t testing.TB) *PostgresContainer {
 	t.Helper()
 
 	ctx := context.Background()
 
 	container, err := postgres.Run(ctx,
-		"postgres:16-alpine",
+		"pgvector/pgvector:pg16", // Postgres 16 with pgvector for semantic search
 		postgres.WithDatabase("victus_test"),
 		postgres.WithUsername("test"),
 		postgres.WithPassword("test"),
@@ -94,20 +94,63 @@ func (pc *PostgresContainer) Close() {
 func (pc *PostgresContainer) ClearTables(ctx context.Context) error {
 	tables := []string{
 		"fatigue_events",
+		"archetype_reviews",
+		"strength_rotation",
+		"personal_reference_ranges",
+		"movement_session_log",
+		"warmup_pins",
 		"body_part_issues",
+		"muscle_fatigue_snapshots",
 		"muscle_fatigue",
 		"training_sessions",
+		"note_conflicts",
+		"program_session_autoregulation",
 		"program_installations",
 		"program_days",
 		"program_weeks",
 		"training_programs",
 		"metabolic_history",
 		"monthly_summaries",
+		"plan_daily_targets",
 		"weekly_targets",
 		"nutrition_plans",
 		"planned_sessions",
+		"plateau_detections",
+		"daily_log_reconciliations",
+		"debrief_regeneration_flags",
+		"imported_food_entries",
+		"food_portion_log",
+		"food_prices",
+		"caffeine_log",
+		"recovery_activities",
+		"habit_checks",
+		"habits",
+		"challenges",
+		"personal_records",
+		"body_status_snapshots",
+		"joint_integrity_deltas",
+		"joint_integrity_series",
+		"substitution_log",
+		"debrief_tolerances",
+		"retro_edits",
+		"meal_entries",
+		"meal_hunger",
+		"grocery_list_items",
+		"grocery_lists",
+		"solver_food_feedback",
+		"llm_usage",
+		"embeddings",
+		"annual_reviews",
+		"meal_templates",
+		"session_templates",
+		"api_token_nonces",
+		"api_token_usage",
+		"api_tokens",
+		"job_leases",
+		"day_exemptions",
 		"planned_day_types",
 		"daily_logs",
+		"onboarding_state",
 		"user_profile",
 	}
 
@@ -1,5 +1,10 @@
 import type {
   UserProfile,
+  OnboardingState,
+  OnboardingBasicInfo,
+  OnboardingGoal,
+  OnboardingRatios,
+  OnboardingPlan,
   APIError,
   DailyLog,
   CreateDailyLogRequest,
@@ -7,6 +12,9 @@ import type {
   UpdateActiveCaloriesRequest,
   UpdateFastingOverrideRequest,
   AddConsumedMacrosRequest,
+  MealItem,
+  MealItemRequest,
+  MealItemResponse,
   TrainingConfig,
   WeightTrendRange,
   WeightTrendResponse,
@@ -18,14 +26,25 @@ import type {
   PlannedSessionInput,
   FoodCategory,
   FoodReferenceResponse,
+  FoodSearchHit,
   DayType,
   NutritionPlan,
   NutritionPlanSummary,
+  PlanExitSurvey,
+  PlanCompletion,
   CreatePlanRequest,
   WeeklyTarget,
+  DailyPlanTarget,
   DualTrackAnalysis,
   RecalibrationRecord,
   BodyStatus,
+  DailyBodyStatus,
+  JointIntegrityDay,
+  TodaySubstitutions,
+  SubstitutionDecision,
+  RecordSubstitutionRequest,
+  MacroComplianceReport,
+  RetroEdit,
   ArchetypeConfig,
   SessionFatigueReport,
   ApplyLoadRequest,
@@ -34,25 +53,64 @@ import type {
   WaveformPoint,
   ProgramInstallation,
   ScheduledSession,
+  RescheduleResult,
   CreateProgramRequest as CreateTrainingProgramRequest,
   InstallProgramRequest,
+  GenerateProgramRequest,
   ProgramDifficulty,
+  TrainingExperience,
   ProgramFocus,
   SolverRequest,
   SolverResponse,
+  SolverScoreRequest,
+  SolverSolution,
+  SolverFeedbackRequest,
+  SolverPreferencesResponse,
+  SolverSatiety,
+  MealHunger,
   WeeklyDebrief,
   CalendarSummaryResponse,
   DayInsightResponse,
   PhaseInsightResponse,
   Movement,
+  MovementSearchHit,
+  SemanticIndexResult,
+  SearchKind,
+  SearchResponse,
+  DailyDigest,
+  DigestSendResult,
+  ActiveCalorieEstimate,
+  ArchetypeAssignment,
+  ArchetypeReview,
+  ConfirmArchetypeReviewResult,
+  StrengthRotation,
+  UpdateStrengthRotationRequest,
+  LogDeletionResult,
+  WeeklyActualsRollupResult,
+  PersonalReferenceRanges,
+  Archetype,
+  EventProjection,
+  NoteConflict,
+  NoteEditResult,
+  NoteResolution,
+  BatchItemRequest,
+  BatchResponse,
   UserMovementProgress,
   NeuralBattery,
   FormCorrectionRequest,
   FormCorrectionResult,
   MovementProgressionInput,
+  PersonalRecord,
+  AddPersonalRecordRequest,
+  CorrectPersonalRecordRequest,
   SystemicLoadResponse,
   SystemicLoad,
   GarminSyncResult,
+  WearablePayload,
+  WearableSyncResult,
+  ExportTable,
+  RestoreStrategy,
+  RestoreResult,
 } from './types';
 
 const API_BASE = '/api';
@@ -140,6 +198,62 @@ export async function saveProfile(profile: UserProfile, signal?: AbortSignal): P
   return handleResponse<UserProfile>(response);
 }
 
+/**
+ * First-run setup progress; installs with a profile report step 'complete'.
+ */
+export async function getOnboarding(signal?: AbortSignal): Promise<OnboardingState> {
+  const response = await fetch(`${API_BASE}/onboarding`, { signal });
+  return handleResponse<OnboardingState>(response);
+}
+
+async function submitOnboardingStep(path: string, body: unknown, signal?: AbortSignal): Promise<OnboardingState> {
+  const response = await fetch(`${API_BASE}/onboarding/${path}`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(body),
+    signal,
+  });
+
+  return handleResponse<OnboardingState>(response);
+}
+
+export async function submitOnboardingBasicInfo(info: OnboardingBasicInfo, signal?: AbortSignal): Promise<OnboardingState> {
+  return submitOnboardingStep('basic-info', info, signal);
+}
+
+export async function submitOnboardingGoal(goal: OnboardingGoal, signal?: AbortSignal): Promise<OnboardingState> {
+  return submitOnboardingStep('goal', goal, signal);
+}
+
+/**
+ * Saves the profile from the answers so far.
+ */
+export async function submitOnboardingRatios(ratios: OnboardingRatios, signal?: AbortSignal): Promise<OnboardingState> {
+  return submitOnboardingStep('ratios', ratios, signal);
+}
+
+/**
+ * Creates the first plan, or skips it with { skip: true }, and finishes onboarding.
+ */
+export async function submitOnboardingFirstPlan(plan: OnboardingPlan, signal?: AbortSignal): Promise<OnboardingState> {
+  return submitOnboardingStep('first-plan', plan, signal);
+}
+
+/**
+ * Training experience derived from the log; null until a profile exists.
+ */
+export async function getTrainingExperience(signal?: AbortSignal): Promise<TrainingExperience | null> {
+  const response = await fetch(`${API_BASE}/profile/training-experience`, { signal });
+
+  if (response.status === 404) {
+    return null;
+  }
+
+  return handleResponse<TrainingExperience>(response);
+}
+
 export async function getTodayLog(signal?: AbortSignal): Promise<DailyLog | null> {
   const response = await fetch(`${API_BASE}/logs/today`, { signal });
 
@@ -175,6 +289,14 @@ export async function deleteTodayLog(signal?: AbortSignal): Promise<void> {
   await handleEmptyResponse(response);
 }
 
+export async function deleteLog(date: string, signal?: AbortSignal): Promise<LogDeletionResult> {
+  const response = await fetch(`${API_BASE}/logs/${date}`, {
+    method: 'DELETE',
+    signal,
+  });
+  return handleResponse<LogDeletionResult>(response);
+}
+
 export async function updateActualTraining(
   date: string,
   request: UpdateActualTrainingRequest,
@@ -209,6 +331,76 @@ export async function updateActiveCalories(
   return handleResponse<DailyLog>(response);
 }
 
+export async function getActiveCalorieEstimate(date: string, signal?: AbortSignal): Promise<ActiveCalorieEstimate> {
+  const response = await fetch(`${API_BASE}/logs/${date}/active-calories/estimate`, { signal });
+  return handleResponse<ActiveCalorieEstimate>(response);
+}
+
+export async function applyActiveCalorieEstimate(date: string, signal?: AbortSignal): Promise<ActiveCalorieEstimate> {
+  const response = await fetch(`${API_BASE}/logs/${date}/active-calories/estimate`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<ActiveCalorieEstimate>(response);
+}
+
+export async function inferArchetypes(date: string, signal?: AbortSignal): Promise<ArchetypeAssignment[]> {
+  const response = await fetch(`${API_BASE}/logs/${date}/archetypes/infer`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<ArchetypeAssignment[]>(response);
+}
+
+export async function getArchetypeReviews(signal?: AbortSignal): Promise<ArchetypeReview[]> {
+  const response = await fetch(`${API_BASE}/archetype-reviews`, { signal });
+  return handleResponse<ArchetypeReview[]>(response);
+}
+
+export async function confirmArchetypeReview(
+  id: number,
+  archetype?: Archetype,
+  signal?: AbortSignal
+): Promise<ConfirmArchetypeReviewResult> {
+  const response = await fetch(`${API_BASE}/archetype-reviews/${id}/confirm`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(archetype ? { archetype } : {}),
+    signal,
+  });
+  return handleResponse<ConfirmArchetypeReviewResult>(response);
+}
+
+export async function dismissArchetypeReview(id: number, signal?: AbortSignal): Promise<ArchetypeReview> {
+  const response = await fetch(`${API_BASE}/archetype-reviews/${id}/dismiss`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<ArchetypeReview>(response);
+}
+
+export async function getStrengthRotation(signal?: AbortSignal): Promise<StrengthRotation> {
+  const response = await fetch(`${API_BASE}/strength-rotation`, { signal });
+  return handleResponse<StrengthRotation>(response);
+}
+
+export async function updateStrengthRotation(
+  request: UpdateStrengthRotationRequest,
+  signal?: AbortSignal
+): Promise<StrengthRotation> {
+  const response = await fetch(`${API_BASE}/strength-rotation`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<StrengthRotation>(response);
+}
+
 export async function updateFastingOverride(
   date: string,
   request: UpdateFastingOverrideRequest,
@@ -256,6 +448,60 @@ export async function clearMealConsumedMacros(
   return handleResponse<DailyLog>(response);
 }
 
+/**
+ * Get the food items logged on a day.
+ */
+export async function getMealItems(date: string, signal?: AbortSignal): Promise<MealItem[]> {
+  const response = await fetch(`${API_BASE}/logs/${date}/meal-items`, { signal });
+  return handleResponse<MealItem[]>(response);
+}
+
+/**
+ * Log a food item; its macros are added to the day's totals.
+ */
+export async function addMealItem(date: string, request: MealItemRequest, signal?: AbortSignal): Promise<MealItemResponse> {
+  const response = await fetch(`${API_BASE}/logs/${date}/meal-items`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<MealItemResponse>(response);
+}
+
+/**
+ * Replace a logged food item; the day's totals move by the difference.
+ */
+export async function updateMealItem(
+  date: string,
+  id: number,
+  request: MealItemRequest,
+  signal?: AbortSignal
+): Promise<MealItemResponse> {
+  const response = await fetch(`${API_BASE}/logs/${date}/meal-items/${id}`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<MealItemResponse>(response);
+}
+
+/**
+ * Remove a logged food item and take its macros off the day.
+ */
+export async function deleteMealItem(date: string, id: number, signal?: AbortSignal): Promise<MealItemResponse> {
+  const response = await fetch(`${API_BASE}/logs/${date}/meal-items/${id}`, {
+    method: 'DELETE',
+    signal,
+  });
+  return handleResponse<MealItemResponse>(response);
+}
+
 export async function getTrainingConfigs(signal?: AbortSignal): Promise<TrainingConfig[]> {
   const response = await fetch(`${API_BASE}/training-configs`, { signal });
   return handleResponse<TrainingConfig[]>(response);
@@ -341,6 +587,19 @@ export async function getFoodReference(signal?: AbortSignal): Promise<FoodRefere
   return { foods };
 }
 
+/**
+ * Search foods by meaning, e.g. "something crunchy high protein".
+ */
+export async function searchFoods(query: string, limit = 10, signal?: AbortSignal): Promise<FoodSearchHit[]> {
+  const params = new URLSearchParams({ q: query, limit: String(limit) });
+  const response = await fetch(`${API_BASE}/food-reference/search?${params}`, { signal });
+  const hits = await handleResponse<FoodSearchHit[]>(response);
+  return hits.map((hit) => ({
+    ...hit,
+    category: normalizeFoodCategory(hit.category as ApiFoodCategory),
+  }));
+}
+
 type ApiFoodCategory = FoodCategory | 'veg';
 
 function normalizeFoodCategory(category: ApiFoodCategory): FoodCategory {
@@ -401,17 +660,49 @@ export async function createPlan(request: CreatePlanRequest, signal?: AbortSigna
   return handleResponse<NutritionPlan>(response);
 }
 
-export async function completePlan(id: number, signal?: AbortSignal): Promise<void> {
+export async function completePlan(id: number, signal?: AbortSignal): Promise<PlanCompletion> {
   const response = await fetch(`${API_BASE}/plans/${id}/complete`, {
     method: 'POST',
     signal,
   });
+  return handleResponse<PlanCompletion>(response);
+}
+
+/**
+ * Get the latest completed plan awaiting a next phase choice, completing the
+ * active plan first if its final week has passed. Returns null when none.
+ */
+export async function getPendingPlanCompletion(signal?: AbortSignal): Promise<PlanCompletion | null> {
+  const response = await fetch(`${API_BASE}/plans/completion/pending`, { signal });
+  return handleResponse<PlanCompletion | null>(response);
+}
+
+/**
+ * Get a completed plan's retrospective, archived weeks and next phase options.
+ */
+export async function getPlanRetrospective(id: number, signal?: AbortSignal): Promise<PlanCompletion> {
+  const response = await fetch(`${API_BASE}/plans/${id}/retrospective`, { signal });
+  return handleResponse<PlanCompletion>(response);
+}
+
+/**
+ * Dismiss a completed plan's next phase prompt.
+ */
+export async function dismissNextPhasePrompt(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/plans/${id}/next-phase/dismiss`, {
+    method: 'POST',
+    signal,
+  });
   await handleEmptyResponse(response);
 }
 
-export async function abandonPlan(id: number, signal?: AbortSignal): Promise<void> {
+export async function abandonPlan(id: number, survey?: PlanExitSurvey, signal?: AbortSignal): Promise<void> {
   const response = await fetch(`${API_BASE}/plans/${id}/abandon`, {
     method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(survey ?? {}),
     signal,
   });
   await handleEmptyResponse(response);
@@ -467,6 +758,47 @@ export async function getCurrentWeekTarget(signal?: AbortSignal): Promise<Weekly
   return handleResponse<WeeklyTarget>(response);
 }
 
+/**
+ * Get the active plan's daily targets, optionally within a date range (defaults to the whole plan).
+ * Returns null when no plan is active.
+ */
+export async function getActivePlanDailyTargets(
+  start?: string,
+  end?: string,
+  signal?: AbortSignal
+): Promise<DailyPlanTarget[] | null> {
+  const params = new URLSearchParams();
+  if (start) params.append('start', start);
+  if (end) params.append('end', end);
+
+  const query = params.toString() ? `?${params}` : '';
+  const response = await fetch(`${API_BASE}/plans/active/daily-targets${query}`, { signal });
+
+  if (response.status === 404) {
+    return null;
+  }
+
+  return handleResponse<DailyPlanTarget[]>(response);
+}
+
+/**
+ * Get a plan's daily targets, optionally within a date range (defaults to the whole plan).
+ */
+export async function getPlanDailyTargets(
+  id: number,
+  start?: string,
+  end?: string,
+  signal?: AbortSignal
+): Promise<DailyPlanTarget[]> {
+  const params = new URLSearchParams();
+  if (start) params.append('start', start);
+  if (end) params.append('end', end);
+
+  const query = params.toString() ? `?${params}` : '';
+  const response = await fetch(`${API_BASE}/plans/${id}/daily-targets${query}`, { signal });
+  return handleResponse<DailyPlanTarget[]>(response);
+}
+
 // Dual-Track Analysis API (Issue #29)
 
 export async function getActivePlanAnalysis(date?: string, signal?: AbortSignal): Promise<DualTrackAnalysis> {
@@ -485,6 +817,22 @@ export async function getPlanAnalysis(id: number, date?: string, signal?: AbortS
   return handleResponse<DualTrackAnalysis>(response);
 }
 
+export async function getActiveEventProjection(date?: string, signal?: AbortSignal): Promise<EventProjection> {
+  const url = date
+    ? `${API_BASE}/plans/active/event-projection?date=${encodeURIComponent(date)}`
+    : `${API_BASE}/plans/active/event-projection`;
+  const response = await fetch(url, { signal });
+  return handleResponse<EventProjection>(response);
+}
+
+export async function getEventProjection(id: number, date?: string, signal?: AbortSignal): Promise<EventProjection> {
+  const url = date
+    ? `${API_BASE}/plans/${id}/event-projection?date=${encodeURIComponent(date)}`
+    : `${API_BASE}/plans/${id}/event-projection`;
+  const response = await fetch(url, { signal });
+  return handleResponse<EventProjection>(response);
+}
+
 export async function getPlanRecalibrations(planId: number, signal?: AbortSignal): Promise<RecalibrationRecord[]> {
   const response = await fetch(`${API_BASE}/plans/${planId}/recalibrations`, { signal });
   return handleResponse<RecalibrationRecord[]>(response);
@@ -505,6 +853,37 @@ export async function getBodyStatus(signal?: AbortSignal): Promise<BodyStatus> {
   return handleResponse<BodyStatus>(response);
 }
 
+export async function getBodyStatusToday(signal?: AbortSignal): Promise<DailyBodyStatus> {
+  const response = await fetch(`${API_BASE}/body-status/today`, { signal });
+  return handleResponse<DailyBodyStatus>(response);
+}
+
+export async function getJointIntegritySeries(days = 14, signal?: AbortSignal): Promise<JointIntegrityDay[]> {
+  const response = await fetch(`${API_BASE}/body-status/joints?days=${days}`, { signal });
+  return handleResponse<JointIntegrityDay[]>(response);
+}
+
+// Fatigue-aware substitution API
+
+export async function getTodaySubstitutions(signal?: AbortSignal): Promise<TodaySubstitutions> {
+  const response = await fetch(`${API_BASE}/substitutions/today`, { signal });
+  return handleResponse<TodaySubstitutions>(response);
+}
+
+export async function recordSubstitution(req: RecordSubstitutionRequest): Promise<SubstitutionDecision> {
+  const response = await fetch(`${API_BASE}/substitutions`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(req),
+  });
+  return handleResponse<SubstitutionDecision>(response);
+}
+
+export async function getMacroCompliance(days = 28, signal?: AbortSignal): Promise<MacroComplianceReport> {
+  const response = await fetch(`${API_BASE}/adherence/macros?days=${days}`, { signal });
+  return handleResponse<MacroComplianceReport>(response);
+}
+
 export async function getArchetypes(signal?: AbortSignal): Promise<ArchetypeConfig[]> {
   const response = await fetch(`${API_BASE}/archetypes`, { signal });
   return handleResponse<ArchetypeConfig[]>(response);
@@ -542,17 +921,20 @@ export async function applyFatigue(
   return handleResponse<SessionFatigueReport>(response);
 }
 
-// Apply pre-computed per-muscle fatigue from a GMB session
+// Apply pre-computed per-muscle fatigue from a GMB session. leftShare gives
+// the left side's share (0-1) for limb muscles worked unevenly by unilateral
+// movements.
 export async function applyMuscleFatigue(
   muscles: Record<string, number>,
-  signal?: AbortSignal
+  signal?: AbortSignal,
+  leftShare?: Record<string, number>
 ): Promise<SessionFatigueReport> {
   const response = await fetch(`${API_BASE}/fatigue/apply-muscles`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
     },
-    body: JSON.stringify({ muscles }),
+    body: JSON.stringify({ muscles, leftShare }),
     signal,
   });
   return handleResponse<SessionFatigueReport>(response);
@@ -606,6 +988,24 @@ export async function createTrainingProgram(
   return handleResponse<TrainingProgram>(response);
 }
 
+/** Generates a program from mesocycle parameters; dryRun previews without saving. */
+export async function generateTrainingProgram(
+  request: GenerateProgramRequest,
+  dryRun = false,
+  signal?: AbortSignal
+): Promise<TrainingProgram> {
+  const params = dryRun ? '?dryRun=true' : '';
+  const response = await fetch(`${API_BASE}/training-programs/generate${params}`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<TrainingProgram>(response);
+}
+
 export async function deleteTrainingProgram(
   id: number,
   options?: { force?: boolean },
@@ -679,11 +1079,69 @@ export async function getScheduledSessions(
   return handleResponse<ScheduledSession[]>(response);
 }
 
+export async function swapProgramDays(
+  installationId: number,
+  dateA: string,
+  dateB: string,
+  signal?: AbortSignal
+): Promise<RescheduleResult> {
+  const response = await fetch(`${API_BASE}/program-installations/${installationId}/swap`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ dateA, dateB }),
+    signal,
+  });
+  return handleResponse<RescheduleResult>(response);
+}
+
+export async function setInstallationAutoregulation(
+  installationId: number,
+  enabled: boolean,
+  bound?: number,
+  signal?: AbortSignal
+): Promise<ProgramInstallation> {
+  const response = await fetch(`${API_BASE}/program-installations/${installationId}/autoregulation`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ enabled, bound }),
+    signal,
+  });
+  return handleResponse<ProgramInstallation>(response);
+}
+
+export async function setInstallationDeloadDayTypes(
+  installationId: number,
+  keep: boolean,
+  signal?: AbortSignal
+): Promise<ProgramInstallation> {
+  const response = await fetch(`${API_BASE}/program-installations/${installationId}/deload-day-types`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ keep }),
+    signal,
+  });
+  return handleResponse<ProgramInstallation>(response);
+}
+
+export async function pushProgramDay(installationId: number, signal?: AbortSignal): Promise<RescheduleResult> {
+  const response = await fetch(`${API_BASE}/program-installations/${installationId}/push`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<RescheduleResult>(response);
+}
+
 // =============================================================================
 // Metabolic Flux Engine API
 // =============================================================================
 
-import type { FluxChartData, FluxNotification } from './types';
+import type { DietFatigueIndex, FluxChartData, FluxNotification, MetabolicAdaptation } from './types';
 
 /**
  * Get metabolic history data for the Metabolism Graph.
@@ -694,6 +1152,14 @@ export async function getMetabolicChart(weeks: number = 12, signal?: AbortSignal
   return handleResponse<FluxChartData>(response);
 }
 
+/**
+ * Get today's diet fatigue index with any refeed or diet-break recommendations.
+ */
+export async function getDietFatigue(signal?: AbortSignal): Promise<DietFatigueIndex> {
+  const response = await fetch(`${API_BASE}/metabolic/diet-fatigue`, { signal });
+  return handleResponse<DietFatigueIndex>(response);
+}
+
 /**
  * Get any pending weekly strategy notification.
  * Returns null if no notification is pending.
@@ -726,70 +1192,707 @@ export async function dismissMetabolicNotification(id: number, signal?: AbortSig
   await handleEmptyResponse(response);
 }
 
-// =============================================================================
-// MACRO TETRIS SOLVER
-// =============================================================================
+/**
+ * Run the metabolic adaptation check as of today.
+ * Returns null when no adaptation is showing.
+ */
+export async function getMetabolicAdaptation(signal?: AbortSignal): Promise<MetabolicAdaptation | null> {
+  const response = await fetch(`${API_BASE}/metabolic/adaptation`, { signal });
+  return handleResponse<MetabolicAdaptation | null>(response);
+}
 
 /**
- * Solve remaining macros with optimal food combinations.
- * Requires at least 150 kcal remaining to solve.
+ * Get every recorded metabolic adaptation event, newest first.
  */
-export async function solveMacros(request: SolverRequest, signal?: AbortSignal): Promise<SolverResponse> {
-  const response = await fetch(`${API_BASE}/solver/solve`, {
-    method: 'POST',
-    headers: {
-      'Content-Type': 'application/json',
-    },
-    body: JSON.stringify(request),
-    signal,
-  });
-  return handleResponse<SolverResponse>(response);
+export async function getMetabolicAdaptationHistory(signal?: AbortSignal): Promise<MetabolicAdaptation[]> {
+  const response = await fetch(`${API_BASE}/metabolic/adaptation/history`, { signal });
+  return handleResponse<MetabolicAdaptation[]>(response);
+}
+
+/**
+ * Get the metabolic adaptation detections not yet acknowledged.
+ */
+export async function getMetabolicAdaptationNotifications(signal?: AbortSignal): Promise<MetabolicAdaptation[]> {
+  const response = await fetch(`${API_BASE}/metabolic/adaptation/notifications`, { signal });
+  return handleResponse<MetabolicAdaptation[]>(response);
+}
+
+/**
+ * Acknowledge a metabolic adaptation detection.
+ */
+export async function dismissMetabolicAdaptation(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/metabolic/adaptation/${id}/dismiss`, {
+    method: 'POST',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+// =============================================================================
+// MACRO TETRIS SOLVER
+// =============================================================================
+
+/**
+ * Solve remaining macros with optimal food combinations.
+ * Requires at least 150 kcal remaining to solve.
+ */
+export async function solveMacros(request: SolverRequest, signal?: AbortSignal): Promise<SolverResponse> {
+  const response = await fetch(`${API_BASE}/solver/solve`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<SolverResponse>(response);
+}
+
+/**
+ * Re-score a hand-edited solver solution against the remaining budget.
+ */
+export async function scoreSolverSolution(request: SolverScoreRequest, signal?: AbortSignal): Promise<SolverSolution> {
+  const response = await fetch(`${API_BASE}/solver/score`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  return handleResponse<SolverSolution>(response);
+}
+
+/**
+ * Record whether a solver suggestion was accepted, rejected or edited.
+ */
+export async function sendSolverFeedback(request: SolverFeedbackRequest, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/solver/feedback`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+/**
+ * Get the food preferences learned from solver feedback.
+ */
+export async function getSolverPreferences(signal?: AbortSignal): Promise<SolverPreferencesResponse> {
+  const response = await fetch(`${API_BASE}/solver/preferences`, { signal });
+  return handleResponse<SolverPreferencesResponse>(response);
+}
+
+/**
+ * Get the food and category satiety learned from meal hunger ratings.
+ */
+export async function getSolverSatiety(signal?: AbortSignal): Promise<SolverSatiety> {
+  const response = await fetch(`${API_BASE}/solver/satiety`, { signal });
+  return handleResponse<SolverSatiety>(response);
+}
+
+/**
+ * Rate how hungry a meal left the user before the next one (1-5),
+ * replacing any earlier rating for that meal.
+ */
+export async function setMealHunger(
+  date: string,
+  meal: MealHunger['meal'],
+  hunger: number,
+  signal?: AbortSignal
+): Promise<MealHunger> {
+  const response = await fetch(
+    `${API_BASE}/logs/${encodeURIComponent(date)}/meal-hunger/${meal}`,
+    {
+      method: 'PUT',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ hunger }),
+      signal,
+    }
+  );
+  return handleResponse<MealHunger>(response);
+}
+
+// =============================================================================
+// WEEKLY DEBRIEF (MISSION REPORT)
+// =============================================================================
+
+/**
+ * Get the weekly debrief for the most recent completed week.
+ */
+export async function getWeeklyDebrief(signal?: AbortSignal): Promise<WeeklyDebrief> {
+  const response = await fetch(`${API_BASE}/debrief/weekly`, { signal });
+  return handleResponse<WeeklyDebrief>(response);
+}
+
+/**
+ * Get the weekly debrief for a specific week (any day in the week).
+ */
+export async function getWeeklyDebriefByDate(
+  date: string,
+  signal?: AbortSignal
+): Promise<WeeklyDebrief> {
+  const response = await fetch(`${API_BASE}/debrief/weekly/${encodeURIComponent(date)}`, {
+    signal,
+  });
+  return handleResponse<WeeklyDebrief>(response);
+}
+
+/**
+ * URL of the printable PDF report for a week (any day in the week).
+ * Use as a link href so the browser handles the download.
+ */
+export function getWeeklyDebriefReportUrl(date: string): string {
+  return `${API_BASE}/debrief/weekly/${encodeURIComponent(date)}/report.pdf`;
+}
+
+/**
+ * Get an in-progress debrief for the current incomplete week.
+ * Returns null if it's Monday and there's no data yet.
+ */
+export async function getCurrentWeekDebrief(signal?: AbortSignal): Promise<WeeklyDebrief | null> {
+  const response = await fetch(`${API_BASE}/debrief/current`, { signal });
+
+  if (response.status === 404) {
+    return null;
+  }
+
+  return handleResponse<WeeklyDebrief>(response);
+}
+
+// =============================================================================
+// Food Prices API
+// =============================================================================
+
+import type { CostEstimate, FoodPrice, PriceBasis, WeeklyFoodCost } from './types';
+
+export async function listFoodPrices(signal?: AbortSignal): Promise<FoodPrice[]> {
+  const response = await fetch(`${API_BASE}/food-prices`, { signal });
+  return handleResponse<FoodPrice[]>(response);
+}
+
+/**
+ * Set a food's price per standard serving or per 100g.
+ */
+export async function setFoodPrice(
+  foodId: number,
+  amount: number,
+  basis: PriceBasis,
+  signal?: AbortSignal
+): Promise<FoodPrice> {
+  const response = await fetch(`${API_BASE}/food-prices/${foodId}`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ amount, basis }),
+    signal,
+  });
+  return handleResponse<FoodPrice>(response);
+}
+
+export async function deleteFoodPrice(foodId: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/food-prices/${foodId}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+/**
+ * Estimate the cost of a grocery list or meal.
+ */
+export async function estimateFoodCost(
+  items: { foodId: number; amountG: number }[],
+  signal?: AbortSignal
+): Promise<CostEstimate> {
+  const response = await fetch(`${API_BASE}/food-prices/estimate`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ items }),
+    signal,
+  });
+  return handleResponse<CostEstimate>(response);
+}
+
+/**
+ * Get the estimated cost of logged food per week, oldest first.
+ */
+export async function getWeeklyFoodCosts(weeks = 12, signal?: AbortSignal): Promise<WeeklyFoodCost[]> {
+  const response = await fetch(`${API_BASE}/food-prices/weekly?weeks=${weeks}`, { signal });
+  return handleResponse<WeeklyFoodCost[]>(response);
+}
+
+// =============================================================================
+// Grocery Lists API
+// =============================================================================
+
+import type { GroceryList, ShoppingRollup } from './types';
+
+export async function listGroceryLists(signal?: AbortSignal): Promise<GroceryList[]> {
+  const response = await fetch(`${API_BASE}/grocery-lists`, { signal });
+  return handleResponse<GroceryList[]>(response);
+}
+
+/**
+ * Save a grocery list for the week starting weekStart (YYYY-MM-DD).
+ */
+export async function createGroceryList(
+  weekStart: string,
+  items: { foodId: number; amountG: number }[],
+  signal?: AbortSignal
+): Promise<GroceryList> {
+  const response = await fetch(`${API_BASE}/grocery-lists`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ weekStart, items }),
+    signal,
+  });
+  return handleResponse<GroceryList>(response);
+}
+
+/**
+ * Mark a grocery item bought (on purchasedOn, default today) or not bought.
+ */
+export async function setGroceryItemPurchased(
+  listId: number,
+  itemId: number,
+  purchased: boolean,
+  purchasedOn?: string,
+  signal?: AbortSignal
+): Promise<GroceryList> {
+  const response = await fetch(`${API_BASE}/grocery-lists/${listId}/items/${itemId}`, {
+    method: 'PATCH',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ purchased, purchasedOn }),
+    signal,
+  });
+  return handleResponse<GroceryList>(response);
+}
+
+export async function deleteGroceryList(listId: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/grocery-lists/${listId}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+/**
+ * Get the month's bought-versus-eaten rollup (YYYY-MM, default current month).
+ */
+export async function getShoppingRollup(month?: string, signal?: AbortSignal): Promise<ShoppingRollup> {
+  const params = month ? `?month=${encodeURIComponent(month)}` : '';
+  const response = await fetch(`${API_BASE}/stats/shopping${params}`, { signal });
+  return handleResponse<ShoppingRollup>(response);
+}
+
+// =============================================================================
+// Caffeine API
+// =============================================================================
+
+import type { CaffeineEntry, CaffeineSource } from './types';
+
+export async function getCaffeineEntries(date: string, signal?: AbortSignal): Promise<CaffeineEntry[]> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/caffeine`, { signal });
+  return handleResponse<CaffeineEntry[]>(response);
+}
+
+/**
+ * Log a caffeine intake. The time (HH:MM) defaults to now for today.
+ */
+export async function logCaffeine(
+  date: string,
+  source: CaffeineSource,
+  mg: number,
+  time?: string,
+  signal?: AbortSignal
+): Promise<CaffeineEntry> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/caffeine`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ source, mg, time }),
+    signal,
+  });
+  return handleResponse<CaffeineEntry>(response);
+}
+
+export async function deleteCaffeineEntry(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/caffeine/${id}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+// =============================================================================
+// Recovery Activity API
+// =============================================================================
+
+import type { RecoveryActivity, RecoveryActivityLibraryEntry, RecoveryActivityType } from './types';
+
+export async function getRecoveryActivityLibrary(signal?: AbortSignal): Promise<RecoveryActivityLibraryEntry[]> {
+  const response = await fetch(`${API_BASE}/recovery-activities/library`, { signal });
+  return handleResponse<RecoveryActivityLibraryEntry[]>(response);
+}
+
+export async function getRecoveryActivities(date: string, signal?: AbortSignal): Promise<RecoveryActivity[]> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/recovery-activities`, { signal });
+  return handleResponse<RecoveryActivity[]>(response);
+}
+
+/**
+ * Log a sauna, cold, massage or similar session. Intensity (1-5) defaults to 3.
+ */
+export async function logRecoveryActivity(
+  date: string,
+  type: RecoveryActivityType,
+  durationMin: number,
+  intensity?: number,
+  signal?: AbortSignal
+): Promise<RecoveryActivity> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/recovery-activities`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ type, durationMin, intensity }),
+    signal,
+  });
+  return handleResponse<RecoveryActivity>(response);
+}
+
+export async function deleteRecoveryActivity(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/recovery-activities/${id}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+// =============================================================================
+// Habit API
+// =============================================================================
+
+import type { ChecklistItem, Habit } from './types';
+
+export async function getHabits(signal?: AbortSignal): Promise<Habit[]> {
+  const response = await fetch(`${API_BASE}/habits`, { signal });
+  return handleResponse<Habit[]>(response);
+}
+
+export async function createHabit(name: string, countInVitality: boolean, signal?: AbortSignal): Promise<Habit> {
+  const response = await fetch(`${API_BASE}/habits`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ name, countInVitality }),
+    signal,
+  });
+  return handleResponse<Habit>(response);
+}
+
+/**
+ * Rename a habit, change whether it counts toward vitality, or archive it.
+ */
+export async function updateHabit(
+  id: number,
+  habit: Pick<Habit, 'name' | 'countInVitality' | 'archived'>,
+  signal?: AbortSignal
+): Promise<Habit> {
+  const response = await fetch(`${API_BASE}/habits/${id}`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(habit),
+    signal,
+  });
+  return handleResponse<Habit>(response);
+}
+
+/**
+ * Delete a habit and its history. Archive it instead to keep the history.
+ */
+export async function deleteHabit(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/habits/${id}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+export async function getChecklist(date: string, signal?: AbortSignal): Promise<ChecklistItem[]> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/checklist`, { signal });
+  return handleResponse<ChecklistItem[]>(response);
+}
+
+export async function setChecklistItem(
+  date: string,
+  habitId: number,
+  done: boolean,
+  signal?: AbortSignal
+): Promise<ChecklistItem> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/checklist/${habitId}`, {
+    method: 'PUT',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ done }),
+    signal,
+  });
+  return handleResponse<ChecklistItem>(response);
+}
+
+// =============================================================================
+// Challenge API
+// =============================================================================
+
+import type { Challenge, ChallengeProgress, ChallengeType } from './types';
+
+export async function getChallenges(signal?: AbortSignal): Promise<ChallengeProgress[]> {
+  const response = await fetch(`${API_BASE}/challenges`, { signal });
+  return handleResponse<ChallengeProgress[]>(response);
+}
+
+export async function getChallenge(id: number, signal?: AbortSignal): Promise<ChallengeProgress> {
+  const response = await fetch(`${API_BASE}/challenges/${id}`, { signal });
+  return handleResponse<ChallengeProgress>(response);
+}
+
+/**
+ * Start a challenge. It starts today and takes its name from the type and
+ * length unless they're given.
+ */
+export async function createChallenge(
+  challenge: {
+    type: ChallengeType;
+    durationDays: number;
+    name?: string;
+    startDate?: string;
+    allowedMisses?: number;
+  },
+  signal?: AbortSignal
+): Promise<ChallengeProgress> {
+  const response = await fetch(`${API_BASE}/challenges`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(challenge),
+    signal,
+  });
+  return handleResponse<ChallengeProgress>(response);
+}
+
+export async function abandonChallenge(id: number, signal?: AbortSignal): Promise<ChallengeProgress> {
+  const response = await fetch(`${API_BASE}/challenges/${id}/abandon`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<ChallengeProgress>(response);
+}
+
+export async function deleteChallenge(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/challenges/${id}`, {
+    method: 'DELETE',
+    signal,
+  });
+  await handleEmptyResponse(response);
+}
+
+/**
+ * Challenges that completed or failed and haven't been acknowledged yet.
+ */
+export async function getChallengeNotifications(signal?: AbortSignal): Promise<Challenge[]> {
+  const response = await fetch(`${API_BASE}/challenges/notifications`, { signal });
+  return handleResponse<Challenge[]>(response);
+}
+
+export async function dismissChallengeNotification(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/challenges/${id}/dismiss`, {
+    method: 'POST',
+    signal,
+  });
+  await handleEmptyResponse(response);
 }
 
 // =============================================================================
-// WEEKLY DEBRIEF (MISSION REPORT)
+// Check-in API
 // =============================================================================
 
+import type { CheckIn, CheckInRollup, EnvironmentCondition, MealTimingRollup } from './types';
+
+export async function updateCheckIn(date: string, checkIn: CheckIn, signal?: AbortSignal): Promise<DailyLog> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/check-in`, {
+    method: 'PATCH',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(checkIn),
+    signal,
+  });
+  return handleResponse<DailyLog>(response);
+}
+
 /**
- * Get the weekly debrief for the most recent completed week.
+ * Open a retro-edit on a locked day. Edits to the day are accepted for the
+ * next 30 minutes and recorded against the reason.
  */
-export async function getWeeklyDebrief(signal?: AbortSignal): Promise<WeeklyDebrief> {
-  const response = await fetch(`${API_BASE}/debrief/weekly`, { signal });
-  return handleResponse<WeeklyDebrief>(response);
+export async function openRetroEdit(date: string, reason: string): Promise<RetroEdit> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/retro-edit`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ reason }),
+  });
+  return handleResponse<RetroEdit>(response);
+}
+
+export async function getRetroEdits(startDate: string, endDate: string, signal?: AbortSignal): Promise<RetroEdit[]> {
+  const response = await fetch(
+    `${API_BASE}/logs/retro-edits?start=${encodeURIComponent(startDate)}&end=${encodeURIComponent(endDate)}`,
+    { signal }
+  );
+  return handleResponse<RetroEdit[]>(response);
 }
 
 /**
- * Get the weekly debrief for a specific week (any day in the week).
+ * Tag a day with environment conditions (empty to clear). The day's water
+ * target is recalculated and its sessions' load scaled.
  */
-export async function getWeeklyDebriefByDate(
+export async function updateEnvironment(
   date: string,
+  environment: EnvironmentCondition[],
   signal?: AbortSignal
-): Promise<WeeklyDebrief> {
-  const response = await fetch(`${API_BASE}/debrief/weekly/${encodeURIComponent(date)}`, {
+): Promise<DailyLog> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/environment`, {
+    method: 'PATCH',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ environment }),
     signal,
   });
-  return handleResponse<WeeklyDebrief>(response);
+  return handleResponse<DailyLog>(response);
 }
 
 /**
- * Get an in-progress debrief for the current incomplete week.
- * Returns null if it's Monday and there's no data yet.
+ * Get the check-in rollup for a month (YYYY-MM). Defaults to the current month.
  */
-export async function getCurrentWeekDebrief(signal?: AbortSignal): Promise<WeeklyDebrief | null> {
-  const response = await fetch(`${API_BASE}/debrief/current`, { signal });
+export async function getCheckInRollup(month?: string, signal?: AbortSignal): Promise<CheckInRollup> {
+  const params = month ? `?month=${encodeURIComponent(month)}` : '';
+  const response = await fetch(`${API_BASE}/stats/check-ins${params}`, { signal });
+  return handleResponse<CheckInRollup>(response);
+}
 
-  if (response.status === 404) {
-    return null;
-  }
+/**
+ * Get meal timing for a month (YYYY-MM) with its strongest correlations
+ * against sleep and next-day training. Defaults to the current month.
+ */
+export async function getMealTimingRollup(month?: string, signal?: AbortSignal): Promise<MealTimingRollup> {
+  const params = month ? `?month=${encodeURIComponent(month)}` : '';
+  const response = await fetch(`${API_BASE}/stats/meal-timing${params}`, { signal });
+  return handleResponse<MealTimingRollup>(response);
+}
 
-  return handleResponse<WeeklyDebrief>(response);
+// =============================================================================
+// Annual Review API
+// =============================================================================
+
+import type { AnnualReview } from './types';
+
+/**
+ * Get the annual review for a year (the current year is reviewed to date).
+ * Defaults to the most recent completed year. Pass refresh to regenerate a
+ * stored review.
+ */
+export async function getAnnualReview(
+  year?: number,
+  refresh = false,
+  signal?: AbortSignal
+): Promise<AnnualReview> {
+  const path = year === undefined ? '/review/annual' : `/review/annual/${year}`;
+  const query = refresh ? '?refresh=true' : '';
+  const response = await fetch(`${API_BASE}${path}${query}`, { signal });
+  return handleResponse<AnnualReview>(response);
+}
+
+// =============================================================================
+// Injury Risk API
+// =============================================================================
+
+import type { InjuryRiskReport } from './types';
+
+/**
+ * Get the injury risk report for a month (YYYY-MM). Defaults to the current
+ * month, analyzed to date.
+ */
+export async function getInjuryRiskReport(month?: string, signal?: AbortSignal): Promise<InjuryRiskReport> {
+  const path = month ? `/review/injury-risk/${encodeURIComponent(month)}` : '/review/injury-risk';
+  const response = await fetch(`${API_BASE}${path}`, { signal });
+  return handleResponse<InjuryRiskReport>(response);
+}
+
+// =============================================================================
+// Voice Clarification API
+// =============================================================================
+
+import type { VoiceClarification, VoiceClarificationResponse } from './voiceTypes';
+
+// Voice commands held for an answer, oldest first
+export async function getVoiceClarifications(signal?: AbortSignal): Promise<VoiceClarification[]> {
+  const response = await fetch(`${API_BASE}/voice/clarifications`, { signal });
+  return handleResponse<VoiceClarification[]>(response);
+}
+
+/**
+ * Answer a held voice command's question. The command is logged once nothing
+ * more is missing; otherwise the response carries the next question.
+ */
+export async function answerVoiceClarification(
+  id: number,
+  answer: string,
+  signal?: AbortSignal
+): Promise<VoiceClarificationResponse> {
+  const response = await fetch(`${API_BASE}/voice/clarifications/${id}/answer`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ answer }),
+    signal,
+  });
+  return handleResponse<VoiceClarificationResponse>(response);
+}
+
+// Drop a held voice command without logging it
+export async function dismissVoiceClarification(id: number, signal?: AbortSignal): Promise<VoiceClarificationResponse> {
+  const response = await fetch(`${API_BASE}/voice/clarifications/${id}/dismiss`, {
+    method: 'POST',
+    signal,
+  });
+  return handleResponse<VoiceClarificationResponse>(response);
 }
 
 // =============================================================================
 // Garmin Data Import API
 // =============================================================================
 
-import type { GarminImportResult, MonthlySummary } from './types';
+import type { GarminImportResult, MonthlySummary, MonthlySummaryComputation } from './types';
 
 /**
  * Upload a Garmin export file (CSV or ZIP) for import.
@@ -826,6 +1929,49 @@ export async function syncGarmin(date?: string): Promise<GarminSyncResult> {
   return handleResponse<GarminSyncResult>(response);
 }
 
+/**
+ * Import a Garmin Connect or Apple Health export payload. Records already
+ * imported from the same source are skipped.
+ */
+export async function syncWearable(payload: WearablePayload): Promise<WearableSyncResult> {
+  const response = await fetch(`${API_BASE}/sync/wearable`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(payload),
+  });
+  return handleResponse<WearableSyncResult>(response);
+}
+
+/**
+ * URL of the full-history export download.
+ * Use as a link href so the browser handles the download.
+ * @param format 'json' for one document, 'csv' for a ZIP of CSV files
+ */
+export function getExportUrl(format: 'json' | 'csv' = 'json'): string {
+  return `${API_BASE}/export?format=${format}`;
+}
+
+/**
+ * Restore an archive from getExportUrl. Rows matching existing ones are
+ * skipped unless the table's strategy says otherwise.
+ */
+export async function restoreHistory(
+  file: File,
+  conflicts?: Partial<Record<ExportTable, RestoreStrategy>>
+): Promise<RestoreResult> {
+  const formData = new FormData();
+  formData.append('file', file);
+  if (conflicts) {
+    formData.append('conflicts', JSON.stringify(conflicts));
+  }
+
+  const response = await fetch(`${API_BASE}/import`, {
+    method: 'POST',
+    body: formData,
+  });
+  return handleResponse<RestoreResult>(response);
+}
+
 /**
  * Get monthly activity summaries.
  * @param from Optional start year-month (e.g., "2025-01")
@@ -873,6 +2019,21 @@ export async function getMonthlySummaries(
   }));
 }
 
+/**
+ * Recompute monthly summaries from logged sessions now. Imported months keep priority.
+ * @param from Optional start year-month (defaults to every month)
+ * @param to Optional end year-month (defaults to the current month)
+ */
+export async function computeMonthlySummaries(from?: string, to?: string): Promise<MonthlySummaryComputation> {
+  const params = new URLSearchParams();
+  if (from) params.append('from', from);
+  if (to) params.append('to', to);
+
+  const query = params.toString() ? `?${params}` : '';
+  const response = await fetch(`${API_BASE}/admin/monthly-summaries/compute${query}`, { method: 'POST' });
+  return handleResponse<MonthlySummaryComputation>(response);
+}
+
 // =============================================================================
 // Semantic Body API (Phase 4 - Body Part Issues)
 // =============================================================================
@@ -922,7 +2083,7 @@ export async function getFatigueModifiers(signal?: AbortSignal): Promise<MuscleF
 // Strategy Auditor API (Phase 4.2 - Check Engine Light)
 // =============================================================================
 
-import type { AuditStatus } from './types';
+import type { AuditStatus, LLMUsageReport, OllamaCircuitStats, OllamaModelStatus, OllamaPullProgress } from './types';
 
 /**
  * Get the current audit status including any detected mismatches.
@@ -933,6 +2094,35 @@ export async function getAuditStatus(signal?: AbortSignal): Promise<AuditStatus>
   return handleResponse<AuditStatus>(response);
 }
 
+// === OLLAMA MODEL MANAGEMENT ===
+
+export async function getOllamaModels(signal?: AbortSignal): Promise<OllamaModelStatus> {
+  const response = await fetch(`${API_BASE}/admin/ollama/models`, { signal });
+  return handleResponse<OllamaModelStatus>(response);
+}
+
+export async function getOllamaCircuits(signal?: AbortSignal): Promise<OllamaCircuitStats[]> {
+  const response = await fetch(`${API_BASE}/admin/ollama/circuits`, { signal });
+  return handleResponse<OllamaCircuitStats[]>(response);
+}
+
+export async function pullOllamaModel(model: string, signal?: AbortSignal): Promise<OllamaPullProgress> {
+  const response = await fetch(`${API_BASE}/admin/ollama/models/pull`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ model }),
+    signal,
+  });
+  return handleResponse<OllamaPullProgress>(response);
+}
+
+export async function getLLMUsage(days = 7, signal?: AbortSignal): Promise<LLMUsageReport> {
+  const response = await fetch(`${API_BASE}/admin/llm/usage?days=${days}`, { signal });
+  return handleResponse<LLMUsageReport>(response);
+}
+
 // === CALENDAR SUMMARY ===
 
 export async function getCalendarSummary(
@@ -1045,6 +2235,144 @@ export async function listMovements(signal?: AbortSignal): Promise<Movement[]> {
   return handleResponse<Movement[]>(response);
 }
 
+/**
+ * Search movements by meaning, e.g. "knee-friendly leg exercise".
+ */
+export async function searchMovements(query: string, limit = 10, signal?: AbortSignal): Promise<MovementSearchHit[]> {
+  const params = new URLSearchParams({ q: query, limit: String(limit) });
+  const response = await fetch(`${API_BASE}/movements/search?${params}`, { signal });
+  return handleResponse<MovementSearchHit[]>(response);
+}
+
+/**
+ * Keyword search across notes, sessions, programs, foods, movements and tagged days.
+ */
+export async function search(
+  query: string,
+  options: { types?: SearchKind[]; limit?: number } = {},
+  signal?: AbortSignal
+): Promise<SearchResponse> {
+  const params = new URLSearchParams({ q: query });
+  if (options.types?.length) params.set('types', options.types.join(','));
+  if (options.limit !== undefined) params.set('limit', String(options.limit));
+  const response = await fetch(`${API_BASE}/search?${params}`, { signal });
+  return handleResponse<SearchResponse>(response);
+}
+
+// ── Note Sync ───────────────────────────────────────────────────────────────
+
+/**
+ * Save a day's notes. Pass the text loaded before editing as base so a
+ * concurrent edit from another device is merged or recorded as a conflict.
+ */
+export async function updateDayNotes(date: string, notes: string, base?: string): Promise<NoteEditResult> {
+  const response = await fetch(`${API_BASE}/logs/${encodeURIComponent(date)}/notes`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ notes, base }),
+  });
+  return handleResponse<NoteEditResult>(response);
+}
+
+/**
+ * Save a training session's notes, with the same conflict handling as day notes.
+ */
+export async function updateSessionNotes(sessionId: number, notes: string, base?: string): Promise<NoteEditResult> {
+  const response = await fetch(`${API_BASE}/sessions/${sessionId}/notes`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ notes, base }),
+  });
+  return handleResponse<NoteEditResult>(response);
+}
+
+/**
+ * List unresolved note conflicts, oldest first.
+ */
+export async function getNoteConflicts(signal?: AbortSignal): Promise<NoteConflict[]> {
+  const response = await fetch(`${API_BASE}/note-conflicts`, { signal });
+  return handleResponse<NoteConflict[]>(response);
+}
+
+/**
+ * Settle a note conflict. text is only used with the 'custom' resolution.
+ */
+export async function resolveNoteConflict(id: number, resolution: NoteResolution, text?: string): Promise<NoteEditResult> {
+  const response = await fetch(`${API_BASE}/note-conflicts/${id}/resolve`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ resolution, text }),
+  });
+  return handleResponse<NoteEditResult>(response);
+}
+
+// ── Batch ───────────────────────────────────────────────────────────────────
+
+/**
+ * Run several requests in one round trip. Log writes run atomically: if one
+ * fails, none are saved.
+ */
+export async function batch(requests: BatchItemRequest[]): Promise<BatchResponse> {
+  const response = await fetch(`${API_BASE}/batch`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ requests }),
+  });
+  return handleResponse<BatchResponse>(response);
+}
+
+// ── End-of-day Digest ───────────────────────────────────────────────────────
+
+/**
+ * Preview the end-of-day digest for a date (default today) without sending it.
+ */
+export async function getDailyDigest(date?: string, signal?: AbortSignal): Promise<DailyDigest> {
+  const params = date ? `?date=${date}` : '';
+  const response = await fetch(`${API_BASE}/digest/daily${params}`, { signal });
+  return handleResponse<DailyDigest>(response);
+}
+
+/**
+ * Send the digest now to the configured ntfy topic and/or email recipients.
+ */
+export async function sendDailyDigest(date?: string): Promise<DigestSendResult> {
+  const params = date ? `?date=${date}` : '';
+  const response = await fetch(`${API_BASE}/admin/digest/send${params}`, { method: 'POST' });
+  return handleResponse<DigestSendResult>(response);
+}
+
+/**
+ * Re-index foods and movements for semantic search now.
+ */
+export async function reindexSemanticSearch(signal?: AbortSignal): Promise<SemanticIndexResult> {
+  const response = await fetch(`${API_BASE}/admin/semantic-index/reindex`, { method: 'POST', signal });
+  return handleResponse<SemanticIndexResult>(response);
+}
+
+/**
+ * Recompute the active plan's weekly actuals from the daily logs now.
+ */
+export async function rollupWeeklyActuals(): Promise<WeeklyActualsRollupResult> {
+  const response = await fetch(`${API_BASE}/admin/weekly-actuals/rollup`, { method: 'POST' });
+  return handleResponse<WeeklyActualsRollupResult>(response);
+}
+
+/**
+ * Get the personal reference ranges for HRV, resting HR and sleep quality.
+ */
+export async function getReferenceRanges(signal?: AbortSignal): Promise<PersonalReferenceRanges> {
+  const response = await fetch(`${API_BASE}/reference-ranges`, { signal });
+  return handleResponse<PersonalReferenceRanges>(response);
+}
+
+/**
+ * Recompute the personal reference ranges now (admin scope for API tokens).
+ */
+export async function recomputeReferenceRanges(): Promise<PersonalReferenceRanges> {
+  const response = await fetch(`${API_BASE}/admin/reference-ranges/recompute`, { method: 'POST' });
+  return handleResponse<PersonalReferenceRanges>(response);
+}
+
 /**
  * List movements filtered by joint integrity and intensity ceiling.
  */
@@ -1104,6 +2432,75 @@ export async function analyzeFormCorrection(req: FormCorrectionRequest, signal?:
   return handleResponse<FormCorrectionResult>(response);
 }
 
+// ─── Personal Records ───────────────────────────────────────────────
+
+/**
+ * Get the current best per movement and kind.
+ */
+export async function getRecordRegistry(signal?: AbortSignal): Promise<PersonalRecord[]> {
+  const response = await fetch(`${API_BASE}/records`, { signal });
+  return handleResponse<PersonalRecord[]>(response);
+}
+
+/**
+ * Get the record history by date, optionally for one movement.
+ */
+export async function getRecordHistory(movementId?: string, signal?: AbortSignal): Promise<PersonalRecord[]> {
+  const params = movementId ? `?movementId=${encodeURIComponent(movementId)}` : '';
+  const response = await fetch(`${API_BASE}/records/history${params}`, { signal });
+  return handleResponse<PersonalRecord[]>(response);
+}
+
+/**
+ * Add a record by hand.
+ */
+export async function addPersonalRecord(req: AddPersonalRecordRequest, signal?: AbortSignal): Promise<PersonalRecord> {
+  const response = await fetch(`${API_BASE}/records`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(req),
+    signal,
+  });
+  return handleResponse<PersonalRecord>(response);
+}
+
+/**
+ * Correct a record entry's value, date or note.
+ */
+export async function correctPersonalRecord(id: number, req: CorrectPersonalRecordRequest, signal?: AbortSignal): Promise<PersonalRecord> {
+  const response = await fetch(`${API_BASE}/records/${id}`, {
+    method: 'PATCH',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(req),
+    signal,
+  });
+  return handleResponse<PersonalRecord>(response);
+}
+
+/**
+ * Delete a record entry; the registry falls back to the next best.
+ */
+export async function deletePersonalRecord(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/records/${id}`, { method: 'DELETE', signal });
+  await handleEmptyResponse(response);
+}
+
+/**
+ * Get new records waiting to be celebrated.
+ */
+export async function getRecordCelebrations(signal?: AbortSignal): Promise<PersonalRecord[]> {
+  const response = await fetch(`${API_BASE}/records/celebrations`, { signal });
+  return handleResponse<PersonalRecord[]>(response);
+}
+
+/**
+ * Dismiss a record's celebration.
+ */
+export async function dismissRecordCelebration(id: number, signal?: AbortSignal): Promise<void> {
+  const response = await fetch(`${API_BASE}/records/celebrations/${id}/dismiss`, { method: 'POST', signal });
+  await handleEmptyResponse(response);
+}
+
 // ─── Systemic Gyroscope ─────────────────────────────────────────────
 
 /**
@@ -22,6 +22,14 @@ export interface SupplementConfig {
   collagenG: number;
 }
 
+// Adherence bands as fractions of target; zero fields use the defaults
+export interface AdherenceTolerances {
+  calories: number;     // ±deviation, 0.02-0.30 (default 0.10)
+  proteinFloor: number; // Minimum fraction, 0.50-1.00 (default 0.90)
+  fatMinimum: number;   // Minimum fraction, 0.30-1.00 (default 0.70)
+  carbs: number;        // ±deviation, 0.05-0.50 (default 0.20)
+}
+
 export interface UserProfile {
   height_cm: number;
   birthDate: string;
@@ -48,10 +56,64 @@ export interface UserProfile {
   eatingWindowStart?: string;          // HH:MM format (e.g., "12:00")
   eatingWindowEnd?: string;            // HH:MM format (e.g., "20:00")
   effectiveMealRatios?: MealRatios;    // Meal ratios adjusted for fasting protocol
+  adherenceTolerances?: AdherenceTolerances; // Calorie and per-macro adherence bands
+  logLockDays?: number; // Days older than this need a retro-edit; 0 = off (max 90)
   createdAt?: string;
   updatedAt?: string;
 }
 
+export type OnboardingStep = 'basic_info' | 'goal' | 'ratios' | 'first_plan' | 'complete';
+
+export interface OnboardingBasicInfo {
+  heightCm: number;
+  birthDate: string; // YYYY-MM-DD
+  sex: Sex;
+  currentWeightKg: number;
+  bodyFatPercent?: number;
+}
+
+// Zero target, weekly change and timeframe are derived from the goal
+export interface OnboardingGoal {
+  goal: Goal;
+  targetWeightKg?: number;
+  targetWeeklyChangeKg?: number;
+  timeframeWeeks?: number;
+}
+
+export interface OnboardingRatios {
+  useDefaults: boolean;
+  carbRatio?: number;
+  proteinRatio?: number;
+  fatRatio?: number;
+}
+
+// Unset fields take the suggested plan's values
+export interface OnboardingPlan {
+  skip?: boolean;
+  name?: string;
+  startDate?: string;
+  goalWeightKg?: number;
+  durationWeeks?: number;
+}
+
+export interface OnboardingDefaults {
+  goals?: Required<OnboardingGoal>[]; // One per goal, once basic info is in
+  ratios?: OnboardingRatios; // Once the goal is in
+  plan?: OnboardingPlan; // Once the goal is in
+}
+
+export interface OnboardingState {
+  step: OnboardingStep;
+  basicInfo?: OnboardingBasicInfo;
+  goal?: Required<OnboardingGoal>;
+  ratios?: OnboardingRatios;
+  planId?: number; // Omitted when the first plan was skipped
+  startedAt: string;
+  updatedAt: string;
+  completedAt?: string;
+  defaults: OnboardingDefaults;
+}
+
 export interface APIError {
   error: string;
   message?: string;
@@ -83,13 +145,18 @@ export interface PlannedTraining {
   plannedDurationMin: number;
 }
 
+// EnvironmentCondition tags a day or session; it raises the water target and session load.
+export type EnvironmentCondition = 'hot' | 'humid' | 'altitude';
+
 // TrainingSession represents a single training session within a day.
 // A day can have multiple sessions (e.g., morning Qigong + afternoon strength).
 export interface TrainingSession {
   sessionOrder?: number;
   type: TrainingType;
   durationMin: number;
+  archetype?: Archetype; // Planned archetype; strength defaults to the next in the rotation
   notes?: string;
+  environment?: EnvironmentCondition[]; // The day's conditions also apply
 }
 
 // ActualTrainingSession represents an actual training session logged after completion.
@@ -98,7 +165,11 @@ export interface ActualTrainingSession {
   type: TrainingType;
   durationMin: number;
   perceivedIntensity?: number; // RPE 1-10
+  avgHeartRate?: number; // Average bpm, improves calorie estimates
+  archetype?: Archetype; // Workout archetype, if known
+  startTime?: string; // HH:MM the session started
   notes?: string;
+  environment?: EnvironmentCondition[]; // The day's conditions also apply
 }
 
 // TrainingSummary provides aggregate info about training sessions.
@@ -116,6 +187,7 @@ export interface RecoveryScoreBreakdown {
   acrComponent: number;   // ACR zone component (0-30)
   sleepComponent: number; // Sleep quality component (0-20)
   rhrComponent?: number;  // RHR deviation component (0-15)
+  activityComponent?: number; // Recovery activities component (-10 to +10)
 }
 
 // AdjustmentMultipliers contains adjustment factors for daily TDEE.
@@ -137,10 +209,12 @@ export interface CNSStatusBreakdown {
   deviationPct: number;    // (current - baseline) / baseline
   status: CNSStatus;       // optimized, strained, depleted
   depletionReason?: string; // Why status is strained/depleted
-  referenceMin?: number;   // Garmin reference range minimum
-  referenceMax?: number;   // Garmin reference range maximum
+  referenceMin?: number;   // Reference range minimum (personal 10th percentile or Garmin)
+  referenceMax?: number;   // Reference range maximum (personal 90th percentile or Garmin)
+  referenceSource?: 'personal' | 'garmin';
   belowReference?: boolean; // True if below reference minimum
   referenceRatio?: number;  // 7-day average / reference min
+  subjectiveStrain?: boolean; // True if the check-in reported strain
 }
 
 // TrainingOverride contains recommended training modification when CNS is depleted.
@@ -197,6 +271,7 @@ export interface DailyLog {
   hrvMs?: number;                              // Heart Rate Variability in milliseconds
   sleepQuality: SleepQuality;
   sleepHours?: number;
+  bedtime?: string;                            // HH:MM of the night the sleep describes
   plannedTrainingSessions: TrainingSession[];
   actualTrainingSessions?: ActualTrainingSession[];
   trainingSummary: TrainingSummary;
@@ -211,11 +286,14 @@ export interface DailyLog {
   adjustmentMultipliers?: AdjustmentMultipliers; // Adjustment multipliers breakdown
   cnsStatus?: CNSStatusBreakdown;               // CNS status from HRV analysis
   trainingOverrides?: TrainingOverride[];       // Training adjustments when CNS depleted
-  activeCaloriesBurned?: number;                // User-entered active calories from wearable
+  activeCaloriesBurned?: number;                // Active calories from a wearable or estimated from sessions
+  activeCaloriesSource?: ActiveCaloriesSource;  // Where activeCaloriesBurned came from
   bmrPrecisionMode?: boolean;                   // True if Katch-McArdle auto-selected using recent body fat
   bodyFatUsedDate?: string;                     // Date of body fat measurement used for precision BMR
   notes?: string;                               // Daily notes/observations
   fastingOverride?: FastingProtocol;            // Override for fasting protocol (nil = use profile default)
+  checkIn?: CheckIn;                            // Mood, stress and soreness (1-5)
+  environment?: EnvironmentCondition[];         // Day's conditions (hot, humid, altitude)
   fastedItemsKcal?: number;                     // Calories logged during fasting window
   consumedCalories: number;                     // Total consumed calories
   consumedProteinG: number;                     // Total consumed protein in grams
@@ -251,10 +329,48 @@ export interface MealsConsumed {
  */
 export interface AddConsumedMacrosRequest {
   meal?: 'breakfast' | 'lunch' | 'dinner';  // Optional: specify which meal this is for
+  eatenAt?: string;                         // Optional: HH:MM, recorded for meal timing
+  calories: number;
+  proteinG: number;
+  carbsG: number;
+  fatG: number;
+}
+
+/**
+ * Request body for logging or replacing a food item.
+ */
+export interface MealItemRequest {
+  foodReferenceId: number;
+  grams: number;
+  meal?: 'breakfast' | 'lunch' | 'dinner'; // Omit for food outside a meal slot
+  eatenAt?: string;                        // Optional: HH:MM, recorded for meal timing
+}
+
+/**
+ * MealItem is one logged food with the macros it added to the day.
+ */
+export interface MealItem {
+  id: number;
+  date: string;
+  meal?: 'breakfast' | 'lunch' | 'dinner';
+  foodReferenceId: number;
+  foodItem: string;
+  grams: number;
+  eatenAt?: string;
   calories: number;
   proteinG: number;
   carbsG: number;
   fatG: number;
+  createdAt: string;
+  updatedAt: string;
+}
+
+/**
+ * MealItemResponse is a logged, edited or deleted item with the updated log.
+ */
+export interface MealItemResponse {
+  item?: MealItem; // Omitted after a delete
+  log: DailyLog;
 }
 
 export interface CreateDailyLogRequest {
@@ -265,9 +381,11 @@ export interface CreateDailyLogRequest {
   hrvMs?: number;                 // Heart Rate Variability in milliseconds
   sleepQuality: SleepQuality;
   sleepHours?: number;
+  bedtime?: string;               // HH:MM of the night the sleep describes
   plannedTrainingSessions: TrainingSession[];
   dayType: DayType;
   notes?: string;
+  environment?: EnvironmentCondition[];
 }
 
 export interface UpdateActualTrainingRequest {
@@ -390,6 +508,19 @@ export interface FoodReferenceResponse {
   foods: FoodReference[];
 }
 
+export interface FoodSearchHit {
+  id: number;
+  category: FoodCategory;
+  foodItem: string;
+  proteinGPer100: number;
+  carbsGPer100: number;
+  fatGPer100: number;
+  servingUnit: string;
+  servingSizeG: number;
+  isPantryStaple: boolean;
+  similarity: number; // Cosine similarity to the query, 1 is identical
+}
+
 // Nutrition Plan Types (Issue #27, #28)
 export type PlanStatus = 'active' | 'completed' | 'abandoned' | 'paused';
 
@@ -406,6 +537,25 @@ export interface WeeklyTarget {
   actualWeightKg?: number;
   actualIntakeKcal?: number;
   daysLogged: number;
+  daysExpected: number; // 7, fewer when the week began before tracking
+  prorated: boolean;
+  loggingComplete: boolean;
+  loggingAdherence?: number; // 0-1, logged over expected days
+}
+
+/**
+ * DailyPlanTarget is one plan day's targets, materialized when the plan's
+ * weekly targets were saved. Past days keep their original targets.
+ */
+export interface DailyPlanTarget {
+  date: string;
+  weekNumber: number;
+  dayNumber: number; // 1-7 within the plan week
+  dayType: DayType;
+  carbsG: number;
+  proteinG: number;
+  fatsG: number;
+  calories: number;
 }
 
 export interface NutritionPlan {
@@ -421,10 +571,43 @@ export interface NutritionPlan {
   currentWeek: number;
   weeklyTargets: WeeklyTarget[];
   lastRecalibratedAt?: string; // Timestamp of last recalibration (ISO 8601)
+  mode?: PlanMode;
+  eventDate?: string; // Event plans: weigh-in date (YYYY-MM-DD)
+  eventName?: string;
+  daysToEvent?: number;
+  abandonReasons?: PlanAbandonReason[]; // Exit survey (abandoned plans)
+  abandonNote?: string;
+  historyWarnings?: PlanHistoryWarning[]; // Create and dry run: repeats of abandoned plans
   createdAt: string;
   updatedAt: string;
 }
 
+export type PlanAbandonReason =
+  | 'too_aggressive'
+  | 'life_event'
+  | 'injury'
+  | 'illness'
+  | 'lost_motivation'
+  | 'no_progress'
+  | 'other';
+
+/** Optional exit survey sent when abandoning a plan. */
+export interface PlanExitSurvey {
+  reasons?: PlanAbandonReason[];
+  note?: string; // Up to 500 characters
+}
+
+/**
+ * PlanHistoryWarning flags a new plan that repeats plans abandoned for the
+ * same reason, aggregated per reason.
+ */
+export interface PlanHistoryWarning {
+  reason: PlanAbandonReason;
+  planIds: number[];
+  occurrences: number;
+  message: string;
+}
+
 export interface NutritionPlanSummary {
   id: number;
   name?: string; // User-defined plan name
@@ -436,6 +619,7 @@ export interface NutritionPlanSummary {
   requiredDailyDeficitKcal: number;
   status: PlanStatus;
   currentWeek: number;
+  abandonReasons?: PlanAbandonReason[];
   createdAt: string;
   updatedAt: string;
 }
@@ -445,9 +629,14 @@ export interface CreatePlanRequest {
   startDate: string;
   startWeightKg: number;
   goalWeightKg: number;
-  durationWeeks: number;
+  durationWeeks: number; // Event plans: 0 runs the plan up to the event date
+  mode?: PlanMode;
+  eventDate?: string; // Required for event plans (YYYY-MM-DD)
+  eventName?: string;
 }
 
+export type PlanMode = 'weight' | 'recomp' | 'event';
+
 // Dual-Track Analysis Types (Issue #29)
 export type RecalibrationOptionType = 'increase_deficit' | 'extend_timeline' | 'revise_goal' | 'keep_current';
 export type FeasibilityTag = 'Achievable' | 'Moderate' | 'Ambitious';
@@ -512,6 +701,68 @@ export interface DualTrackAnalysis {
   planProjection: ProjectionPoint[];
   trendProjection?: ProjectionPoint[];
   landingPoint?: LandingPointProjection;
+  event?: EventCountdown; // Event plans only
+}
+
+// Event-targeting plans (weight class by a fixed weigh-in date)
+export type EventUrgency = 'low' | 'moderate' | 'high' | 'critical';
+export type WaterCutSeverity = 'info' | 'caution' | 'danger';
+
+export interface WaterCutWarning {
+  severity: WaterCutSeverity;
+  requiredCutKg: number;
+  requiredCutPercent: number; // Share of current body weight
+  message: string;
+}
+
+export interface EventCountdown {
+  eventName?: string;
+  eventDate: string;
+  daysToEvent: number;
+  urgency: EventUrgency;
+  tolerancePercent: number; // Recalibration tolerance tightened for urgency
+  warnings?: WaterCutWarning[]; // Final week only
+}
+
+export interface EventProjection {
+  planId: number;
+  eventName?: string;
+  eventDate: string;
+  daysToEvent: number;
+  urgency: EventUrgency;
+  currentWeightKg: number;
+  goalWeightKg: number;
+  projectedWeightKg: number;
+  weeklyChangeKg: number;
+  requiredWeeklyChangeKg: number;
+  uncertaintyKg: number; // One standard deviation
+  makeWeightProbability: number; // 0-1
+  samples: number;
+  points: ProjectionPoint[];
+  warnings?: WaterCutWarning[];
+}
+
+// ActiveCaloriesSource records where a day's active calories came from.
+export type ActiveCaloriesSource = 'wearable' | 'estimated';
+
+// SessionCalorieEstimate is one session's share of an active calorie estimate.
+export interface SessionCalorieEstimate {
+  sessionOrder: number;
+  type: TrainingType;
+  durationMin: number;
+  avgHeartRate?: number;
+  met: number;
+  method: 'met' | 'heart_rate';
+  kcal: number;
+}
+
+// ActiveCalorieEstimate is a day's active burn estimated from its actual sessions.
+export interface ActiveCalorieEstimate {
+  date: string;
+  weightKg: number;
+  kcal: number;
+  sessions: SessionCalorieEstimate[];
+  applied?: boolean; // Set when applying; false when wearable data exists
 }
 
 // Body Map / Fatigue Types (Adaptive Load feature)
@@ -544,6 +795,14 @@ export type Archetype =
 
 export type FatigueStatus = 'fresh' | 'stimulated' | 'fatigued' | 'overreached';
 
+export type BodySide = 'left' | 'right';
+
+// Per-side fatigue (0-100) for a limb muscle whose sides differ
+export interface SideFatigue {
+  left: number;
+  right: number;
+}
+
 export interface MuscleFatigue {
   muscleGroupId: number;
   muscle: MuscleGroup;
@@ -552,12 +811,72 @@ export interface MuscleFatigue {
   status: FatigueStatus;
   color: string;
   lastUpdated?: string;
+  sides?: SideFatigue; // fatiguePercent is their average
+  overloadedSide?: BodySide; // One side 15+ points above the other
 }
 
 export interface BodyStatus {
   muscles: MuscleFatigue[];
   overallScore: number;
   asOfTime: string;
+  jointIntegrity?: Record<string, number>; // 0-1 per joint, 1 = intact
+  systemicLoad?: number; // 0-10
+}
+
+// Fatigue, active issues and readiness aggregated for a day
+export interface DailyBodyStatus {
+  date: string;
+  status: BodyStatus;
+  load?: SystemicLoad; // Absent when the day isn't logged
+  activeIssues: number;
+  hrvAvailable: boolean;
+}
+
+// One day of the joint recovery series fed by echo joint deltas
+export interface JointIntegrityDay {
+  date: string;
+  joints: Record<string, number>; // 0-1 per joint, 1 = intact
+  restDay: boolean;
+  painJoints?: string[];
+}
+
+// Proposed swap for a session exercise that targets an overreached muscle
+export interface SubstitutionProposal {
+  phase: SessionPhase;
+  order: number;
+  originalId: string;
+  originalName: string;
+  substituteId: string;
+  substituteName: string;
+  fatiguedMuscles: MuscleGroup[];
+  reason: string;
+}
+
+export interface TodaySubstitutions {
+  date: string;
+  sessionLabel?: string; // Absent when nothing is scheduled today
+  proposals: SubstitutionProposal[];
+}
+
+// Logged accept/decline decision for a proposed swap
+export interface SubstitutionDecision {
+  id: number;
+  date: string;
+  sessionLabel: string;
+  originalId: string;
+  substituteId: string;
+  fatiguedMuscles: MuscleGroup[];
+  accepted: boolean;
+  createdAt: string;
+}
+
+export interface RecordSubstitutionRequest {
+  date?: string; // Defaults to today
+  sessionLabel: string;
+  originalId: string;
+  substituteId: string;
+  fatiguedMuscles: MuscleGroup[];
+  accepted: boolean;
 }
 
 export interface FatigueInjection {
@@ -566,6 +885,7 @@ export interface FatigueInjection {
   injectedPercent: number;
   newTotal: number;
   status: FatigueStatus;
+  newSides?: SideFatigue;
 }
 
 export interface SessionFatigueReport {
@@ -587,6 +907,103 @@ export interface ApplyLoadRequest {
   archetype: Archetype;
   durationMin: number;
   rpe?: number;
+  side?: BodySide; // Session done on one side only
+}
+
+// ArchetypeInference is an inferred archetype with its evidence.
+export interface ArchetypeInference {
+  archetype?: Archetype; // Omitted when nothing could be inferred
+  confidence: number; // 0-1
+  source: 'rules' | 'llm';
+  reason: string;
+}
+
+// ArchetypeAssignment is the outcome of inferring one session's archetype.
+export interface ArchetypeAssignment {
+  sessionId: number;
+  sessionOrder: number;
+  inference: ArchetypeInference;
+  applied: boolean; // Fatigue applied
+  queued: boolean; // Waiting in the review queue
+  fatigue?: SessionFatigueReport;
+}
+
+// ArchetypeReview is a low-confidence inference waiting for confirmation.
+export interface ArchetypeReview {
+  id: number;
+  sessionId: number;
+  date: string;
+  sessionOrder: number;
+  type: TrainingType;
+  durationMin: number;
+  notes?: string;
+  suggestion: ArchetypeInference;
+  status: 'pending' | 'confirmed' | 'dismissed';
+  resolvedArchetype?: Archetype;
+  createdAt: string;
+  resolvedAt?: string;
+}
+
+export interface ConfirmArchetypeReviewResult {
+  review: ArchetypeReview;
+  fatigue?: SessionFatigueReport;
+}
+
+export type StrengthSplit = 'push_pull_legs' | 'upper_lower' | 'full_body';
+
+// StrengthRotation is where the user is in their strength split.
+export interface StrengthRotation {
+  split: StrengthSplit;
+  sequence: Archetype[];
+  nextArchetype: Archetype; // Pre-filled on new strength sessions
+  lastArchetype?: Archetype;
+  lastDate?: string;
+  updatedAt?: string;
+}
+
+export interface UpdateStrengthRotationRequest {
+  split: StrengthSplit;
+  nextArchetype?: Archetype; // Omit to start the split from the beginning
+}
+
+// LogDeletionResult reports what deleting a daily log removed and recomputed.
+export interface LogDeletionResult {
+  date: string;
+  sessionsRemoved: number;
+  fatigueEventsReversed: number;
+  fatigueReversed?: Partial<Record<MuscleGroup, number>>; // Percentage points per muscle
+  metabolicRecordsRemoved: number;
+  entriesRemoved: number; // Meal, hunger, caffeine, portion and recovery rows
+  fluxRecalculated: boolean;
+  planWeekUpdated?: number;
+  staleDebriefWeek?: string;
+}
+
+export interface WeeklyActualsRollupResult {
+  weeksUpdated: number;
+}
+
+export type ReferenceMetric = 'hrv' | 'resting_hr' | 'sleep_quality';
+
+/**
+ * PersonalReferenceRange is a metric's percentiles over the trailing 90 days.
+ * Metrics without one (fewer than 21 logged days) use the generic thresholds.
+ */
+export interface PersonalReferenceRange {
+  metric: ReferenceMetric;
+  p10: number;
+  p25: number;
+  p50: number;
+  p75: number;
+  p90: number;
+  sampleCount: number;
+  windowStart: string;
+  windowEnd: string;
+  computedAt: string;
+}
+
+export interface PersonalReferenceRanges {
+  ranges: PersonalReferenceRange[];
 }
 
 // =============================================================================
@@ -617,6 +1034,17 @@ export interface GuardrailWarning {
 
 export type ProgramDifficulty = 'beginner' | 'intermediate' | 'advanced';
 export type ProgramFocus = 'hypertrophy' | 'strength' | 'conditioning' | 'general';
+
+// Training experience derived from the log (training age and progression velocity)
+export interface TrainingExperience {
+  level: ProgramDifficulty;
+  trainingAgeWeeks: number; // Weeks with at least 2 logged sessions
+  firstTrainingWeek?: string; // Monday of the first logged training week
+  recentWeeklyMinutes: number; // Mean over the last 12 weeks
+  prsPerMonth: number; // Personal records per month over the last 90 days
+  defaultRpe: number; // RPE target for sessions planned without one
+  computedAt: string;
+}
 export type EquipmentType = 'barbell' | 'dumbbell' | 'bodyweight' | 'machine' | 'kettlebell' | 'bands' | 'cables';
 export type ProgramStatus = 'template' | 'draft' | 'published';
 export type InstallationStatus = 'active' | 'completed' | 'abandoned';
@@ -751,10 +1179,25 @@ export interface ProgramInstallation {
   currentWeek: number;
   status: InstallationStatus;
   totalSessionsScheduled: number;
+  reschedules: ProgramReschedule[];
+  autoregulationBound: number; // Readiness scaling limit (0.15 = ±15%); 0 = off
+  keepDeloadDayTypes: boolean; // Deload weeks keep the program's nutrition days
   createdAt?: string;
   updatedAt?: string;
 }
 
+/**
+ * ProgramReschedule records one program day moved off its mapped date.
+ */
+export interface ProgramReschedule {
+  kind: 'swap' | 'push';
+  weekNumber: number;
+  dayNumber: number;
+  fromDate: string;
+  toDate: string;
+  createdAt: string;
+}
+
 /**
  * ScheduledSession represents a training session scheduled for a specific date.
  */
@@ -767,7 +1210,33 @@ export interface ScheduledSession {
   durationMin: number;
   loadScore: number;
   nutritionDay: DayType;
+  deloadAdjusted: boolean; // Nutrition day biased away from performance for a deload week
   progressionPattern?: ProgressionPattern;
+  autoregulation?: SessionAutoregulation;
+}
+
+/**
+ * SessionAutoregulation is the readiness scaling applied to a scheduled session.
+ */
+export interface SessionAutoregulation {
+  date: string;
+  weekNumber: number;
+  dayNumber: number;
+  readinessScore: number;
+  scale: number;
+  baseLoadScore: number;
+  baseDurationMin: number;
+  loadScore: number;
+  durationMin: number;
+}
+
+/**
+ * RescheduleResult is the moves made by a swap or push and the installation's
+ * sessions with their new dates.
+ */
+export interface RescheduleResult {
+  moves: ProgramReschedule[];
+  sessions: ScheduledSession[];
 }
 
 /**
@@ -809,12 +1278,34 @@ export interface CreateProgramRequest {
   weeks: ProgramWeekInput[];
 }
 
+/** How volume and intensity move through a generated block */
+export type WaveShape = 'linear' | 'undulating' | 'step';
+
+/**
+ * GenerateProgramRequest builds a custom program's weeks and days from
+ * mesocycle parameters.
+ */
+export interface GenerateProgramRequest {
+  name?: string; // Defaults to e.g. "8-Week Hypertrophy Mesocycle"
+  description?: string;
+  durationWeeks: number;
+  trainingDaysPerWeek: number;
+  focus: ProgramFocus;
+  difficulty?: ProgramDifficulty; // Defaults to the derived training experience level
+  deloadEvery?: number; // Every Nth week (3-8) is a deload; 0 or omitted = none
+  waveShape?: WaveShape; // Defaults to linear
+  equipment?: EquipmentType[];
+  tags?: string[];
+}
+
 /**
  * InstallProgramRequest is the request body for installing a program.
  */
 export interface InstallProgramRequest {
   startDate: string;
   weekDayMapping: number[];
+  autoregulationBound?: number; // e.g. 0.15 = ±15%; omit or 0 for off
+  keepDeloadDayTypes?: boolean; // Don't bias deload weeks' nutrition days
 }
 
 // =============================================================================
@@ -859,6 +1350,119 @@ export interface FluxNotification {
   createdAt: string;
 }
 
+export type DietFatigueLevel = 'low' | 'moderate' | 'high' | 'critical';
+
+/** A refeed or diet-break recommendation from the diet fatigue index. */
+export interface DietFatigueRecommendation {
+  type: 'diet_break' | 'refeed' | 'deload';
+  title: string;
+  action: string;
+  rationale: string;
+}
+
+/**
+ * DietFatigueIndex scores accumulated fatigue (0-100) over the current run
+ * of deficit weeks. Hunger and downregulation are null without enough data,
+ * and the score is scaled over the signals present.
+ */
+export interface DietFatigueIndex {
+  asOf: string; // YYYY-MM-DD
+  score: number;
+  level: DietFatigueLevel;
+  weeksInDeficit: number;
+  avgDeficitKcal: number;
+  avgDeficitPercent: number; // % of TDEE
+  avgHunger?: number;        // 1-5, last 14 days
+  tdeeDropKcal?: number;     // Adaptive TDEE lost across the run
+  components: {
+    duration: number;              // 0-30
+    deficit: number;               // 0-25
+    hunger: number | null;         // 0-25
+    downregulation: number | null; // 0-20
+  };
+  recommendations: DietFatigueRecommendation[];
+}
+
+export type AdaptationSeverity = 'moderate' | 'severe';
+
+/**
+ * MetabolicAdaptation is a detected stretch of adaptive TDEE running below
+ * what weight loss predicts, with the recommended response.
+ */
+export interface MetabolicAdaptation {
+  id?: number;
+  detectedOn: string; // YYYY-MM-DD
+  severity: AdaptationSeverity;
+  weeksSustained: number;
+  baselineRatio: number;     // Adaptive / formula TDEE in the baseline weeks
+  recentRatio: number;       // ...and in the sustained weeks
+  unexplainedKcal: number;   // Average daily drop weight loss doesn't explain
+  observedDropKcal: number;  // Adaptive TDEE, baseline minus recent
+  predictedDropKcal: number; // Formula TDEE, baseline minus recent
+  weightChangeKg: number;
+  maintenanceKcal: number;   // What maintenance is now
+  recommendation: {
+    type: 'refeed' | 'diet_break' | 'reverse_phase';
+    title: string;
+    action: string;
+    rationale: string;
+  };
+  notificationPending: boolean;
+}
+
+/**
+ * PlanRetrospective summarises how a completed plan went against its targets.
+ */
+export interface PlanRetrospective {
+  planId: number;
+  planName?: string;
+  startDate: string;   // YYYY-MM-DD
+  endDate: string;     // Last day of the final week
+  completedOn: string; // YYYY-MM-DD
+  durationWeeks: number;
+  startWeightKg: number;
+  goalWeightKg: number;
+  finalWeightKg?: number;       // Latest weekly weigh-in; absent with no weigh-ins
+  plannedChangeKg: number;
+  actualChangeKg?: number;
+  goalProgressPercent?: number; // Share of the planned change achieved
+  goalReached: boolean;
+  avgTargetKcal: number;
+  avgIntakeKcal?: number;
+  daysLogged: number;
+  daysExpected: number;
+  loggingAdherence?: number;    // 0-1
+  weeksWeighed: number;
+  weeksOnTrack: number;
+  recalibrations: number;
+  highlights: string[];
+}
+
+export type NextPhaseType = 'maintenance' | 'reverse' | 'new_cut';
+
+/**
+ * NextPhaseOption is a phase offered after a plan completes; simulatorInput
+ * pre-fills the plan simulator.
+ */
+export interface NextPhaseOption {
+  type: NextPhaseType;
+  title: string;
+  rationale: string;
+  recommended: boolean;
+  simulatorInput: CreatePlanRequest;
+}
+
+/**
+ * PlanCompletion is a completed plan's retrospective, its weekly targets as
+ * archived at completion, and the next phases on offer.
+ */
+export interface PlanCompletion {
+  retrospective: PlanRetrospective;
+  archivedWeeks: WeeklyTarget[];
+  nextPhases: NextPhaseOption[];
+  promptPending: boolean; // Next phase not yet chosen or dismissed
+}
+
 // =============================================================================
 // MACRO TETRIS SOLVER TYPES
 // =============================================================================
@@ -884,6 +1488,7 @@ export interface SolverRequest {
   plannedTraining?: PlannedTrainingForSolver[];
   mealTime?: 'breakfast' | 'lunch' | 'dinner' | 'snack';
   activeProtocol?: FastingProtocol;
+  maxCost?: number; // Drop solutions that cost more or can't be fully priced
 }
 
 /**
@@ -919,6 +1524,99 @@ export interface SemanticRefinement {
   generatedByLlm: boolean; // True if generated by Ollama, false if fallback
 }
 
+/**
+ * MacroScore is one macro's contribution to macroAccuracy.
+ */
+export interface MacroScore {
+  target: number;
+  actual: number;
+  errorPercent: number; // |actual - target| / target, as a percent
+  score: number; // 0-100; reaches 0 at 50% error
+  weight: number; // Share of macroAccuracy
+}
+
+/**
+ * SolverScoreBreakdown explains a matchScore:
+ * total = macroPoints + ingredientCountPoints + fiberPoints + preferenceBonus + satietyBonus - absurdityPenalty.
+ */
+export interface SolverScoreBreakdown {
+  calories: MacroScore;
+  protein: MacroScore;
+  carbs: MacroScore;
+  fat: MacroScore;
+  macroAccuracy: number;
+  macroPoints: number;
+  ingredientCountPoints: number;
+  ingredientCountPenalty: number;
+  estimatedFiberG: number;
+  fiberPoints: number;
+  preferenceBonus: number; // -10 to +10 from learned food preferences
+  satietyBonus: number; // -8 to +8 from learned satiety (fatburner days with high hunger)
+  compatibility: number; // 0-1 culinary compatibility of the worst-matched pair
+  absurdityCode?: string;
+  absurdityPenalty: number;
+  total: number;
+}
+
+/**
+ * SolverScoreRequest re-scores a hand-edited ingredient list.
+ */
+export interface SolverScoreRequest {
+  remainingProteinG: number;
+  remainingCarbsG: number;
+  remainingFatG: number;
+  remainingCalories: number;
+  ingredients: { foodId: number; amountG: number }[];
+}
+
+/**
+ * SolverFeedbackRequest records what the user did with a suggestion.
+ */
+export interface SolverFeedbackRequest {
+  action: 'accepted' | 'rejected' | 'edited';
+  foodIds: number[]; // Foods in the suggestion
+  editedFoodIds?: number[]; // Foods actually logged (edited only)
+  date?: string; // YYYY-MM-DD the meal was logged (defaults to today when meal is set)
+  meal?: 'breakfast' | 'lunch' | 'dinner'; // Meal slot, for satiety learning
+}
+
+/**
+ * MealHunger is the hunger rating that followed a meal.
+ */
+export interface MealHunger {
+  date: string; // YYYY-MM-DD
+  meal: 'breakfast' | 'lunch' | 'dinner';
+  hunger: number; // 1 (satisfied) to 5 (ravenous) before the next meal
+}
+
+/**
+ * SolverSatiety is the satiety learned from meal hunger ratings, most filling first.
+ * Scores run from -1 (followed by more hunger than usual) to 1 (less).
+ */
+export interface SolverSatiety {
+  foods: { foodId: number; foodName: string; score: number; meals: number }[];
+  categories: { category: string; score: number; meals: number }[];
+  recentHunger?: number; // Average meal hunger over the last 7 days
+  hungerTrendingHigh: boolean; // Fatburner-day suggestions favor filling foods
+}
+
+/**
+ * FoodPreference is the learned solver preference for one food.
+ */
+export interface FoodPreference {
+  foodId: number;
+  foodName: string;
+  level: 'liked' | 'tolerated' | 'never_suggest';
+  weight: number; // -1 to 1
+  score: number;
+  signalCount: number;
+  lastSignalAt: string;
+}
+
+export interface SolverPreferencesResponse {
+  preferences: FoodPreference[];
+}
+
 /**
  * SolverSolution represents a single meal suggestion from the solver.
  */
@@ -926,9 +1624,11 @@ export interface SolverSolution {
   ingredients: SolverIngredient[];
   totalMacros: SolverMacros;
   matchScore: number; // 0-100 where 100 is perfect match
+  scoreBreakdown: SolverScoreBreakdown;
   recipeName: string;
   whyText: string;
   refinement?: SemanticRefinement; // AI-enhanced recipe presentation
+  cost?: CostEstimate; // Present when food prices are entered
 }
 
 /**
@@ -940,14 +1640,113 @@ export interface SolverResponse {
 }
 
 // =============================================================================
-// Weekly Debrief Types (Mission Report)
+// Food Price Types
 // =============================================================================
 
 /**
- * MetabolicFlux represents the metabolic trend for the week.
+ * PriceBasis is the quantity a food price is quoted for.
  */
-export interface MetabolicFlux {
-  startTDEE: number;
+export type PriceBasis = 'serving' | '100g';
+
+/**
+ * FoodPrice is the user's price for one food (amounts carry no currency).
+ */
+export interface FoodPrice {
+  foodReferenceId: number;
+  amount: number;
+  basis: PriceBasis;
+  updatedAt: string;
+}
+
+/**
+ * CostLine is the cost of one item in an estimate.
+ */
+export interface CostLine {
+  foodReferenceId: number;
+  foodItem: string;
+  amountG: number;
+  cost: number | null; // null when the food has no price
+}
+
+/**
+ * CostEstimate is the estimated cost of a meal or grocery list.
+ */
+export interface CostEstimate {
+  total: number; // Sum over priced items
+  lines: CostLine[];
+  complete: boolean; // Every item has a price
+}
+
+/**
+ * WeeklyFoodCost is the estimated cost of the food logged in one week.
+ */
+export interface WeeklyFoodCost {
+  weekStart: string; // Monday YYYY-MM-DD
+  total: number;
+  loggedG: number;
+  pricedG: number;
+  coveragePct: number; // Share of logged grams that had a price
+}
+
+/**
+ * GroceryItem is one food on a grocery list; purchasedOn is set once bought.
+ */
+export interface GroceryItem {
+  id: number;
+  foodReferenceId: number;
+  amountG: number;
+  purchasedOn?: string; // YYYY-MM-DD
+}
+
+export interface GroceryList {
+  id: number;
+  weekStart: string; // YYYY-MM-DD
+  items: GroceryItem[];
+  createdAt: string;
+}
+
+/**
+ * ShoppingFood is what happened to one food bought during the month.
+ */
+export interface ShoppingFood {
+  foodReferenceId: number;
+  foodItem: string;
+  purchases: number;
+  boughtG: number;
+  eatenG: number;
+  wastedG: number;
+  wastedPct: number;
+  wastedCost: number | null; // null when the food has no price
+}
+
+/**
+ * ShoppingRollup compares a month's purchases with the portions logged in the
+ * 10 days after each. Purchases whose window is still open are only counted
+ * in pendingItems.
+ */
+export interface ShoppingRollup {
+  month: string; // YYYY-MM
+  itemsBought: number;
+  pendingItems: number;
+  boughtG: number;
+  eatenG: number;
+  wastedG: number;
+  wastagePct: number;
+  wastedCost: number; // Cost of the priced wasted grams
+  wastedCostComplete: boolean; // Every wasted food has a price
+  foods: ShoppingFood[]; // Most wasted first
+  boughtNeverEaten: ShoppingFood[];
+}
+
+// =============================================================================
+// Weekly Debrief Types (Mission Report)
+// =============================================================================
+
+/**
+ * MetabolicFlux represents the metabolic trend for the week.
+ */
+export interface MetabolicFlux {
+  startTDEE: number;
   endTDEE: number;
   deltaKcal: number;
   trend: 'upregulated' | 'downregulated' | 'stable';
@@ -963,6 +1762,7 @@ export interface VitalityScore {
   weightDelta: number; // kg change
   trendWeight: number; // EMA-filtered trend weight
   metabolicFlux: MetabolicFlux;
+  habitAdherence?: number; // % of counted habit-days done; only when a habit counts
 }
 
 /**
@@ -1006,6 +1806,17 @@ export interface DebriefDay {
   sleepQuality: number;
   sleepHours?: number;
   notes?: string;
+  environment?: EnvironmentCondition[]; // Conditions of the day and its sessions
+}
+
+/**
+ * EnvironmentNote explains a tagged day's training shortfall, which is not
+ * counted against training adherence.
+ */
+export interface EnvironmentNote {
+  date: string;
+  conditions: EnvironmentCondition[];
+  message: string;
 }
 
 /**
@@ -1018,9 +1829,333 @@ export interface WeeklyDebrief {
   narrative: DebriefNarrative;
   recommendations: TacticalRecommendation[];
   dailyBreakdown: DebriefDay[];
+  caffeine?: CaffeineSleepAnalysis; // Present when caffeine was logged
+  environmentNotes: EnvironmentNote[];
+  substitutions: SubstitutionDecision[];
+  macroCompliance: MacroComplianceSummary;
+  tolerances: AdherenceTolerances; // Bands the week was scored with
+  challenges?: ChallengeProgress[]; // Present while a challenge runs during the week
+  metabolicAdaptation?: MetabolicAdaptation; // Present when adaptation was detected that week
   generatedAt: string;
 }
 
+// =============================================================================
+// MACRO COMPLIANCE TYPES
+// =============================================================================
+
+// Judged against the profile's adherence tolerances
+export type MacroStatus = 'met' | 'under' | 'over' | 'unjudged';
+
+export interface MacroDayCompliance {
+  date: string;
+  protein: MacroStatus;
+  carbs: MacroStatus;
+  fat: MacroStatus;
+}
+
+// % of judged days inside the band over the 7 days ending on date
+export interface MacroRollingDay {
+  date: string;
+  protein?: number;
+  carbs?: number;
+  fat?: number;
+}
+
+export interface MacroComplianceSummary {
+  proteinDaysMet: number;
+  carbsDaysMet: number;
+  fatDaysMet: number;
+  daysJudged: number;
+  proteinPercent?: number; // Absent when no day was judged
+  carbsPercent?: number;
+  fatPercent?: number;
+}
+
+export interface MacroComplianceReport {
+  startDate: string;
+  endDate: string;
+  days: MacroDayCompliance[];
+  rolling: MacroRollingDay[];
+  summary: MacroComplianceSummary;
+}
+
+// =============================================================================
+// LOGGING LOCK TYPES
+// =============================================================================
+
+export type LogEditKind =
+  | 'create'
+  | 'actual_training'
+  | 'active_calories'
+  | 'fasting_override'
+  | 'check_in'
+  | 'environment'
+  | 'consumed_macros'
+  | 'clear_meal'
+  | 'meal_item'
+  | 'notes';
+
+export interface RetroEditAction {
+  kind: LogEditKind;
+  at: string; // ISO8601 timestamp
+}
+
+// Approval to edit a locked day; open until expiresAt (30 minutes)
+export interface RetroEdit {
+  id: number;
+  date: string;
+  reason: string;
+  openedAt: string;
+  expiresAt: string;
+  actions: RetroEditAction[]; // Audit trail of edits made under the approval
+}
+
+// =============================================================================
+// CAFFEINE TYPES
+// =============================================================================
+
+export type CaffeineSource =
+  | 'coffee'
+  | 'espresso'
+  | 'tea'
+  | 'energy_drink'
+  | 'soda'
+  | 'pre_workout'
+  | 'supplement'
+  | 'other';
+
+/**
+ * CaffeineEntry is one logged caffeine intake.
+ */
+export interface CaffeineEntry {
+  id: number;
+  date: string;
+  time: string; // HH:MM, local
+  source: CaffeineSource;
+  mg: number;
+  createdAt: string;
+}
+
+/**
+ * CaffeineDay pairs a day's intake with the following night's sleep
+ * (the sleep recorded on the next day's log).
+ */
+export interface CaffeineDay {
+  date: string;
+  totalMg: number;
+  lateMg: number; // Taken at or after 14:00
+  lastIntake?: string;
+  nextSleepQuality?: number;
+  nextHrvMs?: number;
+  nextSleepHours?: number;
+}
+
+export interface CaffeineWarning {
+  code: 'late_intake_poor_sleep' | 'high_daily_intake';
+  message: string;
+}
+
+/**
+ * CaffeineSleepAnalysis relates caffeine to next-night sleep and HRV.
+ * Correlations are Pearson r (-1 to 1), absent until 7 days pair up.
+ */
+export interface CaffeineSleepAnalysis {
+  windowStart: string;
+  windowEnd: string;
+  week: CaffeineDay[];
+  avgDailyMg: number;
+  lateIntakeDays: number;
+  sleepPairs: number;
+  totalVsSleep?: number;
+  lateVsSleep?: number;
+  hrvPairs: number;
+  totalVsHrv?: number;
+  latePoorSleepNights: number;
+  warnings: CaffeineWarning[];
+}
+
+// =============================================================================
+// RECOVERY ACTIVITY TYPES
+// =============================================================================
+
+export type RecoveryActivityType =
+  | 'sauna'
+  | 'cold_plunge'
+  | 'massage'
+  | 'foam_rolling'
+  | 'compression';
+
+/**
+ * RecoveryActivity is one logged recovery session. netPoints is its benefit
+ * less its load; hard sessions can go negative.
+ */
+export interface RecoveryActivity {
+  id: number;
+  date: string;
+  type: RecoveryActivityType;
+  durationMin: number;
+  intensity: number; // 1-5
+  createdAt: string;
+  netPoints: number;
+}
+
+/**
+ * RecoveryActivityLibraryEntry holds a type's points per minute at moderate
+ * intensity (3). Benefit scales with intensity, load with its square.
+ */
+export interface RecoveryActivityLibraryEntry {
+  type: RecoveryActivityType;
+  benefitPerMin: number;
+  loadPerMin: number;
+  maxDurationMin: number;
+}
+
+// =============================================================================
+// HABIT TYPES
+// =============================================================================
+
+/**
+ * Habit is a daily yes/no item on the checklist. Habits with countInVitality
+ * make up a small part of the weekly vitality score. Archived habits leave the
+ * checklist but keep their history.
+ */
+export interface Habit {
+  id: number;
+  name: string;
+  countInVitality: boolean;
+  archived: boolean;
+  startDate: string; // Days before it don't count against the habit
+  createdAt: string;
+}
+
+/**
+ * ChecklistItem is one habit on a day's checklist. An unchecked day doesn't
+ * break currentStreak until it's over.
+ */
+export interface ChecklistItem {
+  habitId: number;
+  name: string;
+  done: boolean;
+  countInVitality: boolean;
+  currentStreak: number;
+  bestStreak: number;
+}
+
+// =============================================================================
+// CHALLENGE TYPES
+// =============================================================================
+
+export type ChallengeType = 'protein_target' | 'no_missed_sessions' | 'daily_logging';
+
+export type ChallengeStatus = 'active' | 'completed' | 'failed' | 'abandoned';
+
+/**
+ * Challenge is a time-boxed self-challenge (7-90 days). It fails once it has
+ * more missed days than allowedMisses and completes when its last day is met.
+ */
+export interface Challenge {
+  id: number;
+  name: string;
+  type: ChallengeType;
+  startDate: string;
+  durationDays: number;
+  allowedMisses: number;
+  status: ChallengeStatus;
+  endedDate?: string; // Day it completed, failed or was abandoned
+  notificationPending: boolean; // Completion or failure not yet acknowledged
+  createdAt: string;
+}
+
+export type ChallengeDayResult = 'met' | 'missed' | 'pending';
+
+/**
+ * ChallengeProgress is a challenge evaluated from the daily logs. Today is
+ * 'pending' rather than 'missed' until the day is over.
+ */
+export interface ChallengeProgress extends Challenge {
+  dayNumber: number; // 0 before the challenge starts
+  daysMet: number;
+  daysMissed: number;
+  daysRemaining: number;
+  days: { date: string; result: ChallengeDayResult }[];
+}
+
+// =============================================================================
+// CHECK-IN TYPES
+// =============================================================================
+
+/**
+ * CheckIn is the subjective part of a daily log. Mood runs from 1 (awful)
+ * to 5 (great); stress and soreness from 1 (none) to 5 (severe).
+ */
+export interface CheckIn {
+  mood: number;
+  stress: number;
+  soreness: number;
+  hunger?: number; // Optional, 1 (none) to 5 (severe)
+}
+
+/**
+ * CheckInRollup summarizes a month of check-ins. Correlations are Pearson r
+ * (-1 to 1), absent until 7 days pair up.
+ */
+export interface CheckInRollup {
+  month: string; // YYYY-MM
+  daysCheckedIn: number;
+  avgMood: number;
+  avgStress: number;
+  avgSoreness: number;
+  stressVsAdherence?: number; // Stress vs same-day calorie accuracy
+  stressPairs: number;
+  sorenessVsNextDayRpe?: number; // Soreness vs next day's training RPE
+  sorenessPairs: number;
+}
+
+// =============================================================================
+// MEAL TIMING TYPES
+// =============================================================================
+
+export type MealTimingMetric = 'eating_window' | 'last_meal_to_bed' | 'pre_workout_gap' | 'post_workout_gap';
+export type MealTimingOutcome = 'next_sleep_quality' | 'next_day_rpe';
+
+/**
+ * MealTimingDay is one day's meal timing, paired with the next night's sleep
+ * and the next day's training. Measures are absent when times are missing.
+ */
+export interface MealTimingDay {
+  date: string;
+  firstMeal: string; // HH:MM
+  lastMeal: string;  // HH:MM
+  eatingWindowHours?: number;
+  lastMealToBedHours?: number;
+  preWorkoutGapMin?: number;  // Last meal to session start
+  postWorkoutGapMin?: number; // Session end to first meal
+  nextSleepQuality?: number;
+  nextDayRpe?: number;
+}
+
+export interface MealTimingCorrelation {
+  metric: MealTimingMetric;
+  outcome: MealTimingOutcome;
+  r: number; // Pearson r, -1 to 1
+  pairs: number;
+}
+
+/**
+ * MealTimingRollup summarizes a month of meal timing. Correlations need 7
+ * paired days and are sorted strongest first.
+ */
+export interface MealTimingRollup {
+  month: string; // YYYY-MM
+  daysTimed: number;
+  avgEatingWindowHours?: number;
+  avgLastMealToBedHours?: number;
+  avgPreWorkoutGapMin?: number;
+  avgPostWorkoutGapMin?: number;
+  days: MealTimingDay[];
+  correlations: MealTimingCorrelation[];
+}
+
 // =============================================================================
 // GARMIN DATA IMPORT TYPES
 // =============================================================================
@@ -1060,8 +2195,75 @@ export interface GarminSyncResult {
   errors?: string[];
 }
 
+export type WearableSource = 'garmin' | 'apple_health';
+
+export interface WearableDay {
+  date: string;
+  hrvMs?: number;
+  restingHr?: number;
+  sleepHours?: number;
+  sleepScore?: number; // 1-100
+  activeCalories?: number;
+}
+
+export interface WearableActivity {
+  externalId?: string; // Source's activity ID, used for deduplication
+  date: string;
+  startTime?: string; // HH:MM
+  activityType: string; // e.g. "Running" or "HKWorkoutActivityTypeRunning"
+  durationMin: number;
+  calories?: number;
+  avgHeartRate?: number;
+}
+
+export interface WearablePayload {
+  source: WearableSource;
+  days: WearableDay[];
+  activities: WearableActivity[];
+}
+
+export interface WearableSyncResult {
+  source: WearableSource;
+  daysImported: number;
+  daysSkipped: number; // Unchanged since the last import
+  activitiesImported: number;
+  activitiesSkipped: number; // Already imported or already logged
+  reconciledDates?: string[];
+  warnings?: string[];
+  errors?: string[];
+}
+
+export type ExportTable =
+  | 'daily_logs'
+  | 'training_sessions'
+  | 'nutrition_plans'
+  | 'weekly_targets'
+  | 'metabolic_history'
+  | 'fatigue_events';
+
+// What to do with an archive row matching an existing row
+export type RestoreStrategy = 'skip' | 'overwrite' | 'merge';
+
+export interface RestoreTableResult {
+  table: ExportTable;
+  strategy: RestoreStrategy;
+  rows: number;
+  inserted: number;
+  conflicts: number; // Rows matching an existing row
+  skipped: number;
+  overwritten: number;
+  merged: number; // Existing row's NULLs filled from the archive
+  ignoredColumns?: string[]; // Archive columns this database doesn't have
+}
+
+export interface RestoreResult {
+  exportedAt: string;
+  tables: RestoreTableResult[];
+}
+
 /**
- * MonthlySummary represents aggregated monthly activity data from Garmin.
+ * MonthlySummary represents aggregated monthly activity data, imported from
+ * Garmin or computed from logged sessions.
  */
 export interface MonthlySummary {
   id: number;
@@ -1070,11 +2272,22 @@ export interface MonthlySummary {
   sessionCount: number;
   totalCalories: number;
   avgCaloriesPerSession: number;
-  dataSource: string;
+  dataSource: string; // 'garmin_import' or 'computed'
   rawActivityName: string;
   createdAt: string;
 }
 
+/**
+ * MonthlySummaryComputation reports a recomputation of monthly summaries.
+ */
+export interface MonthlySummaryComputation {
+  from: string;
+  to: string;
+  upserts: number; // Computed summaries written
+  removed: number; // Computed summaries whose sessions are gone
+  skipped: number; // Left to imported summaries
+}
+
 // =============================================================================
 // SEMANTIC BODY (PHASE 4) - BODY PART ISSUES
 // =============================================================================
@@ -1094,6 +2307,7 @@ export interface BodyPartIssue {
   id: number;
   date: string;
   bodyPart: MuscleGroup;
+  side?: BodySide; // Limb muscles only
   symptom: string;
   severity: IssueSeverity;
   rawText: string;
@@ -1105,7 +2319,8 @@ export interface BodyPartIssue {
  * CreateBodyIssueInput represents a single body part issue to create.
  */
 export interface CreateBodyIssueInput {
-  bodyPart: string;
+  bodyPart: string; // May name a side, e.g. "left knee"
+  side?: BodySide;
   symptom: string;
   rawText: string;
   sessionId?: number;
@@ -1177,6 +2392,103 @@ export interface AuditStatus {
   checkedAt: string;
 }
 
+// === OLLAMA MODEL MANAGEMENT TYPES ===
+
+export type OllamaTask = 'parse' | 'narrative' | 'embed';
+
+export interface OllamaModel {
+  name: string;
+  sizeBytes: number;
+  modifiedAt: string;
+}
+
+export interface OllamaTaskModel {
+  task: OllamaTask;
+  candidates: string[]; // Most preferred first
+  model: string; // Model the task currently runs on
+  installed: boolean; // False when no candidate or fallback is installed
+}
+
+export interface OllamaPullProgress {
+  model: string;
+  status: string; // Ollama's latest status line, e.g. "pulling manifest"
+  completedBytes: number;
+  totalBytes: number;
+  percent: number;
+  done: boolean;
+  error?: string;
+  startedAt: string;
+  finishedAt?: string;
+}
+
+export type CircuitState = 'closed' | 'open' | 'half_open';
+
+export interface OllamaCircuitStats {
+  endpoint: string; // Ollama endpoint, e.g. "/api/generate"
+  state: CircuitState;
+  consecutiveFailures: number;
+  opens: number;
+  halfOpens: number;
+  closes: number;
+  rejected: number; // Calls failed fast while open
+  retries: number;
+  lastTransitionAt?: string;
+  openUntil?: string;
+}
+
+export interface OllamaModelStatus {
+  detected: boolean; // False until Ollama's model list has been read once
+  detectedAt?: string;
+  installed: OllamaModel[];
+  tasks: OllamaTaskModel[];
+  pulls: OllamaPullProgress[];
+}
+
+export type LLMFeature =
+  | 'recipe_name'
+  | 'solver_refinement'
+  | 'debrief'
+  | 'day_insight'
+  | 'phase_insight'
+  | 'systemic_rx'
+  | 'form_correction'
+  | 'echo'
+  | 'voice_command'
+  | 'semantic_index'
+  | 'semantic_search'
+  | 'annual_review'
+  | 'archetype_inference';
+
+export interface LLMUsageTotals {
+  calls: number;
+  computeMs: number;
+}
+
+export interface LLMUsageSummary {
+  feature: LLMFeature;
+  model: string;
+  calls: number;
+  failures: number;
+  promptTokens: number;
+  completionTokens: number;
+  computeMs: number;
+  avgDurationMs: number;
+}
+
+export interface LLMBudgetStatus {
+  feature: LLMFeature;
+  maxCalls: number; // 0 = unlimited
+  maxComputeSeconds: number; // 0 = unlimited
+  today: LLMUsageTotals;
+  exhausted: boolean;
+}
+
+export interface LLMUsageReport {
+  since: string;
+  features: LLMUsageSummary[];
+  budgets: LLMBudgetStatus[];
+}
+
 // === CALENDAR SUMMARY TYPES ===
 
 export interface CalendarSummaryPoint {
@@ -1288,6 +2600,7 @@ export interface EchoResponse {
   session: SessionResponse;
   echoResult?: EchoResult;
   bodyIssuesCreated?: EchoBodyIssue[];
+  recordsSet?: PersonalRecord[];
 }
 
 // ─── Adaptive Movement Engine ───────────────────────────────────────
@@ -1305,6 +2618,237 @@ export interface Movement {
   progressionId: string;
 }
 
+export interface MovementSearchHit {
+  movement: Movement;
+  similarity: number;
+}
+
+export interface SemanticIndexCounts {
+  embedded: number;
+  unchanged: number;
+  removed: number;
+}
+
+export interface SemanticIndexResult {
+  model: string;
+  foods: SemanticIndexCounts;
+  movements: SemanticIndexCounts;
+}
+
+// Cross-entity keyword search
+
+export type SearchKind =
+  | 'note'
+  | 'session'
+  | 'program_day'
+  | 'session_template'
+  | 'food'
+  | 'movement'
+  | 'program'
+  | 'day';
+
+export interface SearchResult {
+  kind: SearchKind;
+  id: string; // Entity ID; the date for notes and tagged days
+  title: string;
+  snippet: string;
+  date?: string; // YYYY-MM-DD for dated entities
+  link: string; // API path of the entity
+  rank: number;
+}
+
+export interface SearchResponse {
+  query: string;
+  results: SearchResult[];
+}
+
+// End-of-day digest
+
+export interface DigestMacros {
+  calories: number;
+  proteinG: number;
+  carbsG: number;
+  fatG: number;
+}
+
+export interface DigestTomorrowSession {
+  label?: string;
+  trainingType: TrainingType;
+  durationMin: number;
+  rpe?: number;
+  expectedLoad: number;
+  source: 'planner' | 'program';
+}
+
+export interface DailyDigest {
+  date: string;
+  logged: boolean;
+  target: DigestMacros;
+  consumed: DigestMacros;
+  remaining: DigestMacros; // Negative when over target
+  sessions: { trainingType: TrainingType; durationMin: number; isDraft: boolean }[];
+  tomorrow?: { date: string; dayType: DayType; sessions: DigestTomorrowSession[] };
+  pendingDrafts: { sessionId: number; date: string; trainingType: TrainingType; durationMin: number }[];
+  title: string;
+  text: string;
+}
+
+export interface DigestSendResult {
+  digest: Omit<DailyDigest, 'title' | 'text'>;
+  channels: string[];
+}
+
+// Note sync (day and session notes edited on several devices)
+
+export type NoteEditOutcome = 'unchanged' | 'saved' | 'merged' | 'conflict';
+export type NoteResolution = 'keep' | 'restore' | 'merge' | 'custom';
+
+export interface NoteConflict {
+  id: number;
+  target: 'day' | 'session';
+  date: string; // Day of the note (YYYY-MM-DD)
+  sessionId?: number; // Set for session notes
+  base: string; // Text the winning edit started from
+  kept: string; // Winning (last written) text
+  overwritten: string; // Concurrent text it replaced
+  suggested: string; // Kept plus the overwritten text's extra lines
+  createdAt: string;
+  resolvedAt?: string;
+  resolution?: NoteResolution;
+}
+
+export interface NoteEditResult {
+  outcome: NoteEditOutcome;
+  notes: string; // Note text now stored
+  conflict?: NoteConflict; // Set when a concurrent edit was overwritten
+}
+
+// Batch
+
+export interface BatchItemRequest {
+  method: 'GET' | 'POST' | 'PUT' | 'PATCH' | 'DELETE';
+  path: string; // e.g. /api/logs/2026-03-02/check-in
+  body?: unknown;
+}
+
+export interface BatchItemResult {
+  status: number;
+  body?: unknown;
+}
+
+export interface BatchResponse {
+  atomic: boolean; // Items ran in one transaction
+  rolledBack?: boolean; // An atomic batch failed and nothing was saved
+  results: BatchItemResult[];
+}
+
+// Annual review (year-in-numbers)
+export interface AnnualTypeTotals {
+  type: TrainingType;
+  sessions: number;
+  durationMin: number;
+  load: number;
+}
+
+export interface AnnualTraining {
+  totalSessions: number;
+  totalDurationMin: number;
+  totalLoad: number; // RPE-weighted session load
+  byType: AnnualTypeTotals[];
+  peakWeekStart?: string;
+  peakWeekLoad: number;
+}
+
+export interface AnnualWeight {
+  startKg: number | null;
+  endKg: number | null;
+  changeKg: number | null;
+  lowestKg: number | null;
+  highestKg: number | null;
+}
+
+export interface VitalityStreak {
+  startDate: string;
+  endDate: string;
+  weeks: number;
+  averageScore: number;
+}
+
+export interface AnnualVitality {
+  weeksScored: number;
+  averageScore: number;
+  bestWeekStart?: string;
+  bestWeekScore: number;
+  streaks: VitalityStreak[]; // Longest first, at most 3
+  tolerances: AdherenceTolerances; // Bands the weeks were scored with
+}
+
+export interface AnnualPlanOutcome {
+  planId: number;
+  name: string;
+  status: PlanStatus;
+  startDate: string;
+  endDate: string;
+  startWeightKg: number;
+  goalWeightKg: number;
+  finalWeightKg: number | null;
+  progressPercent: number | null;
+  goalReached: boolean;
+}
+
+export interface AnnualReview {
+  year: number;
+  startDate: string;
+  endDate: string; // Dec 31, or yesterday for the current year
+  daysLogged: number;
+  training: AnnualTraining;
+  weight: AnnualWeight;
+  vitality: AnnualVitality;
+  plans: AnnualPlanOutcome[];
+  narrative: string;
+  generatedByLlm: boolean;
+  narrativeModel: string;
+  generatedAt: string;
+}
+
+// Injury risk (monthly report)
+export type InjuryRiskLevel = 'low' | 'moderate' | 'high';
+
+export type InjuryRiskFactorKind = 'load_spike' | 'recurring_issue' | 'fatigue_asymmetry';
+
+/**
+ * InjuryRiskFactor is one warning sign in the month: a run of days with ACR
+ * above 1.3, a joint reported on 3+ days, or one side of a limb muscle
+ * overloaded on 7+ days.
+ */
+export interface InjuryRiskFactor {
+  kind: InjuryRiskFactorKind;
+  level: InjuryRiskLevel;
+  startDate: string;
+  endDate: string;
+  joint?: string; // Recurring issues
+  issueDays?: number; // Recurring issues: days reported
+  symptoms?: string[]; // Recurring issues
+  afterSpike?: boolean; // Issues started within a week of a load spike
+  peakAcr?: number; // Load spikes
+  muscle?: MuscleGroup; // Fatigue asymmetry
+  side?: BodySide; // Fatigue asymmetry: the overloaded side
+  asymmetryDays?: number; // Fatigue asymmetry
+  peakGap?: number; // Fatigue asymmetry: widest gap between sides
+  detail: string;
+  recommendation: string;
+}
+
+export interface InjuryRiskReport {
+  month: string; // YYYY-MM
+  startDate: string;
+  endDate: string; // Month end, or today for the current month
+  level: InjuryRiskLevel;
+  peakAcr: number;
+  daysAboveOptimal: number;
+  factors: InjuryRiskFactor[]; // Highest risk first
+}
+
 export interface UserMovementProgress {
   movementId: string;
   userDifficulty: number;
@@ -1337,6 +2881,41 @@ export interface MovementProgressionInput {
   targetReps: number;
   rpe: number;
   hadFormIssue: boolean;
+  loadKg?: number;     // Feeds the personal record registry
+  holdSec?: number;
+  circuitSec?: number;
+}
+
+// ─── Personal Records ───────────────────────────────────────────────
+
+export type RecordKind = 'heaviest_load' | 'longest_hold' | 'fastest_circuit';
+export type RecordSource = 'set_log' | 'echo' | 'manual';
+
+export interface PersonalRecord {
+  id: number;
+  movementId: string;
+  kind: RecordKind;
+  value: number; // kg for heaviest_load, seconds otherwise
+  source: RecordSource;
+  sessionId?: number;
+  date: string;
+  note?: string;
+  celebrationPending: boolean; // Beat the previous best and not yet dismissed
+  createdAt: string;
+}
+
+export interface AddPersonalRecordRequest {
+  movementId: string;
+  kind: RecordKind;
+  value: number;
+  date?: string; // Defaults to today
+  note?: string;
+}
+
+export interface CorrectPersonalRecordRequest {
+  value: number;
+  date?: string;
+  note?: string;
 }
 
 // ─── Systemic Gyroscope (Load Balancing) ────────────────────────────
@@ -1,6 +1,6 @@
 // Voice Command Types (for frontend use)
 
-export type VoiceCommandIntent = 'TRAINING' | 'NUTRITION' | 'BIOMETRICS';
+export type VoiceCommandIntent = 'TRAINING' | 'NUTRITION' | 'BIOMETRICS' | 'PLANNING';
 
 export interface TrainingVoiceData {
     activity: string;
@@ -27,11 +27,24 @@ export interface BiometricData {
     sensation?: string | null;
 }
 
+export type PlanningVoiceAction = 'move_session' | 'set_day_type';
+
+// Days are YYYY-MM-DD once the command is held for confirmation
+export interface PlanningVoiceData {
+    action: PlanningVoiceAction;
+    day: string;
+    target_day?: string;  // move_session
+    session_type?: string;  // move_session, e.g. "strength"; empty moves every session
+    day_type?: 'performance' | 'fatburner' | 'metabolize';  // set_day_type
+    confirmed?: boolean;
+}
+
 export interface VoiceCommandResult {
     intent: VoiceCommandIntent;
     training_data?: TrainingVoiceData;
     nutrition_data?: NutritionData;
     biometric_data?: BiometricData;
+    planning_data?: PlanningVoiceData;
     parsed_at: string;
     raw_input: string;
     confidence: number;
@@ -45,7 +58,7 @@ export interface BodyMapUpdate {
 }
 
 export interface ActionTaken {
-    type: string;  // "training_logged", "nutrition_logged", "training_draft", etc.
+    type: string;  // "training_logged", "nutrition_logged", "training_draft", "session_moved", etc.
     summary: string;
 }
 
@@ -63,3 +76,26 @@ export interface ParseVoiceCommandRequest {
     raw_input: string;
     date?: string;
 }
+
+// A voice command held until a missing field is answered or a low-confidence
+// parse is confirmed
+export type VoiceClarificationField = 'activity' | 'duration_min' | 'food' | 'metric' | 'value' | 'day' | 'target_day' | 'day_type' | 'confirm';
+
+export type VoiceClarificationStatus = 'pending' | 'resolved' | 'dismissed';
+
+export interface VoiceClarification {
+    id: number;
+    date: string;  // Log date the command applies to
+    raw_input: string;
+    command: VoiceCommandResult;  // With the answers merged so far
+    field?: VoiceClarificationField;
+    question?: string;  // e.g. "How long was the row?"
+    answers: string[];
+    status: VoiceClarificationStatus;
+    created_at: string;
+}
+
+export interface VoiceClarificationResponse {
+    clarification: VoiceClarification;
+    action_taken?: ActionTaken;  // What was persisted once resolved
+}
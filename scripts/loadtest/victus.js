@@ -0,0 +1,99 @@
+// Load profile for the hot paths: today dashboard, daily log create, solver run.
+// Thresholds are the latency budgets; k6 exits non-zero when one is missed.
+//
+//   make loadtest                          # against http://localhost:8080
+//   k6 run -e BASE_URL=http://host:8080 scripts/loadtest/victus.js
+//
+// Run against a disposable database: the log scenario deletes and recreates
+// today's log on every iteration. Point OLLAMA_URL at nothing so solver runs
+// measure the solver, not the LLM refinement.
+
+import http from 'k6/http';
+import { check } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+const DURATION = __ENV.DURATION || '1m';
+const JSON_HEADERS = { headers: { 'Content-Type': 'application/json' } };
+
+export const options = {
+  scenarios: {
+    today_dashboard: {
+      executor: 'constant-vus',
+      exec: 'todayDashboard',
+      vus: 10,
+      duration: DURATION,
+    },
+    log_create: {
+      executor: 'constant-vus',
+      exec: 'logCreate',
+      vus: 1, // One log per day: concurrent creates would only measure conflicts
+      duration: DURATION,
+    },
+    solver_run: {
+      executor: 'constant-arrival-rate',
+      exec: 'solverRun',
+      rate: 2,
+      timeUnit: '1s',
+      duration: DURATION,
+      preAllocatedVUs: 4,
+    },
+  },
+  thresholds: {
+    'http_req_failed{endpoint:today}': ['rate<0.01'],
+    'http_req_duration{endpoint:today}': ['p(95)<150', 'p(99)<300'],
+    'http_req_failed{endpoint:log_create}': ['rate<0.01'],
+    'http_req_duration{endpoint:log_create}': ['p(95)<300', 'p(99)<600'],
+    'http_req_failed{endpoint:solver}': ['rate<0.01'],
+    'http_req_duration{endpoint:solver}': ['p(95)<800', 'p(99)<1500'],
+  },
+};
+
+export function setup() {
+  const profile = {
+    height_cm: 180,
+    birthDate: '1990-06-15',
+    sex: 'male',
+    goal: 'lose_weight',
+    targetWeightKg: 85,
+    targetWeeklyChangeKg: -0.5,
+  };
+  const res = http.put(`${BASE_URL}/api/profile`, JSON.stringify(profile), JSON_HEADERS);
+  check(res, { 'profile saved': (r) => r.status === 200 });
+}
+
+export function todayDashboard() {
+  const res = http.get(`${BASE_URL}/api/logs/today`, { tags: { endpoint: 'today' } });
+  check(res, { 'today 200': (r) => r.status === 200 });
+}
+
+export function logCreate() {
+  http.del(`${BASE_URL}/api/logs/today`, null, { tags: { endpoint: 'log_reset' } });
+
+  const log = {
+    weightKg: 88.5,
+    sleepQuality: 75,
+    dayType: 'performance',
+    plannedTrainingSessions: [{ type: 'strength', durationMin: 60 }],
+  };
+  const res = http.post(`${BASE_URL}/api/logs`, JSON.stringify(log), {
+    ...JSON_HEADERS,
+    tags: { endpoint: 'log_create' },
+  });
+  check(res, { 'log created': (r) => r.status === 201 });
+}
+
+export function solverRun() {
+  const budget = {
+    remainingProteinG: 60,
+    remainingCarbsG: 80,
+    remainingFatG: 20,
+    remainingCalories: 740,
+    dayType: 'performance',
+    mealTime: 'dinner',
+  };
+  const res = http.post(`${BASE_URL}/api/solver/solve`, JSON.stringify(budget), {
+    ...JSON_HEADERS,
+    tags: { endpoint: 'solver' },
+  });
+  check(res, { 'solver 200': (r) => r.status === 200 });
+}